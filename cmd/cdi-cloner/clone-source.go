@@ -16,6 +16,7 @@ import (
 
 	"k8s.io/klog/v2"
 
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
 	"kubevirt.io/containerized-data-importer/pkg/common"
 	metrics "kubevirt.io/containerized-data-importer/pkg/monitoring/metrics/cdi-cloner"
 	"kubevirt.io/containerized-data-importer/pkg/util"
@@ -23,9 +24,12 @@ import (
 )
 
 var (
-	contentType string
-	mountPoint  string
-	uploadBytes uint64
+	contentType   string
+	mountPoint    string
+	uploadBytes   uint64
+	rsyncTunnel   bool
+	exportURL     string
+	reflinkTarget string
 )
 
 type execReader struct {
@@ -55,9 +59,12 @@ func (er *execReader) Close() error {
 }
 
 func init() {
-	flag.StringVar(&contentType, "content-type", "", "filesystem-clone|blockdevice-clone")
+	flag.StringVar(&contentType, "content-type", "", "filesystem-clone|filesystem-rsync-clone|blockdevice-clone|image-clone")
 	flag.StringVar(&mountPoint, "mount", "", "pvc mount point")
 	flag.Uint64Var(&uploadBytes, "upload-bytes", 0, "approx number of bytes in input")
+	flag.BoolVar(&rsyncTunnel, "rsync-tunnel", false, "tunnel the rsync protocol to the upload server, as the remote shell of rsync")
+	flag.StringVar(&exportURL, "export-url", "", "url of the volume another cluster exports, read instead of the mount")
+	flag.StringVar(&reflinkTarget, "reflink-target", "", "mount point of the target pvc the files of the mount are reflinked onto, instead of uploading them")
 	klog.InitFlags(nil)
 }
 
@@ -132,14 +139,14 @@ func pipeToSnappy(reader io.ReadCloser) io.ReadCloser {
 
 func validateContentType() {
 	switch contentType {
-	case "filesystem-clone", "blockdevice-clone":
+	case "filesystem-clone", "filesystem-rsync-clone", "blockdevice-clone", "image-clone":
 	default:
 		klog.Fatalf("Invalid content-type %q", contentType)
 	}
 }
 
 func validateMount() {
-	if mountPoint == "" {
+	if mountPoint == "" && exportURL == "" {
 		klog.Fatalf("Invalid mount %q", mountPoint)
 	}
 }
@@ -203,10 +210,11 @@ func getInputStream(preallocation bool) io.ReadCloser {
 			klog.Fatalf("Error creating tar reader for %q: %+v", mountPoint, err)
 		}
 		return rc
-	case "blockdevice-clone":
+	case "blockdevice-clone", "image-clone":
+		// image clones read the block device or the disk image of the filesystem
 		rc, err := os.Open(mountPoint)
 		if err != nil {
-			klog.Fatalf("Error opening block device %q: %+v", mountPoint, err)
+			klog.Fatalf("Error opening %q: %+v", mountPoint, err)
 		}
 		return rc
 	default:
@@ -220,6 +228,31 @@ func main() {
 	flag.Parse()
 	defer klog.Flush()
 
+	if rsyncTunnel {
+		// the standard output carries the rsync protocol
+		if err := runRsyncTunnel(flag.Args()); err != nil {
+			klog.Errorf("Error tunnelling rsync: %v", err)
+			klog.Flush()
+			os.Exit(1)
+		}
+		return
+	}
+
+	if reflinkTarget != "" {
+		validateMount()
+		klog.Infof("Reflinking %q onto %q", mountPoint, reflinkTarget)
+		cloned, err := reflinkTree(mountPoint, reflinkTarget)
+		if err != nil {
+			klog.Fatalf("Error reflinking %q: %v", mountPoint, err)
+		}
+		klog.V(1).Infof("clone complete, %d bytes", cloned)
+		if err := util.WriteTerminationMessage("Clone Complete"); err != nil {
+			klog.Errorf("%+v", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	klog.Infof("content-type is %q\n", contentType)
 	klog.Infof("mount is %q\n", mountPoint)
 	klog.Infof("upload-bytes is %d", uploadBytes)
@@ -229,11 +262,6 @@ func main() {
 
 	ownerUID := getEnvVarOrDie(common.OwnerUID)
 
-	clientKey := []byte(getEnvVarOrDie("CLIENT_KEY"))
-	clientCert := []byte(getEnvVarOrDie("CLIENT_CERT"))
-	serverCert := []byte(getEnvVarOrDie("SERVER_CA_CERT"))
-
-	url := getEnvVarOrDie("UPLOAD_URL")
 	preallocation, err := strconv.ParseBool(getEnvVarOrDie(common.Preallocation)) // False is default in case of error
 	if err != nil {
 		klog.V(3).Infof("Preallocation variable (%s) not set, defaulting to 'false'", common.Preallocation)
@@ -241,15 +269,59 @@ func main() {
 
 	klog.V(1).Infoln("Starting cloner target")
 
-	progressReader, err := createProgressReader(getInputStream(preallocation), ownerUID, uploadBytes)
+	if contentType == common.FilesystemRsyncCloneContentType {
+		if err := metrics.SetupMetrics(); err != nil {
+			klog.Fatalf("Error setting up metrics: %v", err)
+		}
+		startPrometheus()
+		if err := runRsync(preallocation, metrics.Progress(ownerUID)); err != nil {
+			klog.Fatalf("Error transferring with rsync: %v", err)
+		}
+	} else {
+		uploadStream(ownerUID, preallocation)
+	}
+
+	klog.V(1).Infoln("clone complete")
+	message := "Clone Complete"
+	if preallocation {
+		message += ", " + common.PreallocationApplied
+	}
+	err = util.WriteTerminationMessage(message)
 	if err != nil {
-		klog.Fatalf("Error creating progress reader: %v", err)
+		klog.Errorf("%+v", err)
+		os.Exit(1)
 	}
-	reader := pipeToSnappy(progressReader)
+}
 
-	startPrometheus()
+// uploadStream streams the mount point to the upload server
+func uploadStream(ownerUID string, preallocation bool) {
+	clientKey := []byte(getEnvVarOrDie("CLIENT_KEY"))
+	clientCert := []byte(getEnvVarOrDie("CLIENT_CERT"))
+	serverCert := []byte(getEnvVarOrDie("SERVER_CA_CERT"))
+
+	url := getEnvVarOrDie("UPLOAD_URL")
 
 	client := createHTTPClient(clientKey, clientCert, serverCert)
+	compression := negotiateCompression(client, url, os.Getenv(common.CloneCompression))
+
+	var progressReader io.ReadCloser
+	var err error
+	if exportURL != "" {
+		progressReader, err = newExportReader(exportURL, ownerUID)
+	} else {
+		progressReader, err = createProgressReader(getInputStream(preallocation), ownerUID, uploadBytes)
+	}
+	if err != nil {
+		klog.Fatalf("Error creating progress reader: %v", err)
+	}
+	var reader io.ReadCloser
+	if compression == cdiv1.CloneCompressionZstd {
+		reader = pipeToZstd(progressReader, zstdConcurrency(os.Getenv(common.CloneCPULimit)))
+	} else {
+		reader = pipeToSnappy(progressReader)
+	}
+
+	startPrometheus()
 
 	req, _ := http.NewRequest(http.MethodPost, url, reader)
 
@@ -257,6 +329,10 @@ func main() {
 		req.Header.Set("x-cdi-content-type", contentType)
 		klog.Infof("Set header to %s", contentType)
 	}
+	if compression == cdiv1.CloneCompressionZstd {
+		// the upload server decodes the content encoding instead of snappy
+		req.Header.Set("Content-Encoding", string(cdiv1.CloneCompressionZstd))
+	}
 
 	response, err := client.Do(req)
 	if err != nil {
@@ -274,15 +350,4 @@ func main() {
 	}
 
 	klog.V(1).Infof("Response body:\n%s", buf.String())
-
-	klog.V(1).Infoln("clone complete")
-	message := "Clone Complete"
-	if preallocation {
-		message += ", " + common.PreallocationApplied
-	}
-	err = util.WriteTerminationMessage(message)
-	if err != nil {
-		klog.Errorf("%+v", err)
-		os.Exit(1)
-	}
 }
@@ -1,14 +1,27 @@
 package main
 
 import (
+	"bytes"
 	"errors"
+	"fmt"
 	"io"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
 	"time"
 
+	"github.com/klauspost/compress/zstd"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"golang.org/x/sys/unix"
+
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+	"kubevirt.io/containerized-data-importer/pkg/common"
+	metrics "kubevirt.io/containerized-data-importer/pkg/monitoring/metrics/cdi-cloner"
 	prometheusutil "kubevirt.io/containerized-data-importer/pkg/util/prometheus"
 )
 
@@ -42,3 +55,194 @@ func isDirEmpty(dirName string) (bool, error) {
 	}
 	return false, err
 }
+
+var _ = Describe("Rsync", func() {
+	It("should tunnel rsync through this binary and keep the target sparse without preallocation", func() {
+		args := rsyncArgs("/usr/bin/cdi-cloner", false)
+		Expect(args).To(ContainElements("--inplace", "--delete", "--sparse", "--rsh=/usr/bin/cdi-cloner -rsync-tunnel"))
+		Expect(args).ToNot(ContainElement("--preallocate"))
+		Expect(args[len(args)-2:]).To(Equal([]string{"./", "upload-server:"}))
+	})
+
+	It("should preallocate the target when requested", func() {
+		args := rsyncArgs("/usr/bin/cdi-cloner", true)
+		Expect(args).To(ContainElement("--preallocate"))
+		Expect(args).ToNot(ContainElement("--sparse"))
+	})
+
+	It("should report the progress of rsync", func() {
+		progress := metrics.Progress("rsync-owner")
+		defer progress.Delete()
+		output := "sending incremental file list\n" +
+			"      1,048,576  10%   10.00MB/s    0:00:01 (xfr#0, to-chk=1/2)\r" +
+			"      5,242,880  50%   10.00MB/s    0:00:01 (xfr#0, to-chk=1/2)\r" +
+			"     10,485,760 100%   10.00MB/s    0:00:01 (xfr#1, to-chk=0/2)\n"
+		reportRsyncProgress(strings.NewReader(output), progress)
+		Expect(progress.Get()).To(BeEquivalentTo(100))
+		Expect(progress.GetTransferredBytes()).To(BeEquivalentTo(10485760))
+	})
+})
+
+var _ = Describe("Compression", func() {
+	newUploadServer := func(acceptEncoding string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			Expect(r.Method).To(Equal(http.MethodHead))
+			if acceptEncoding != "" {
+				w.Header().Set("Accept-Encoding", acceptEncoding)
+			}
+			w.WriteHeader(http.StatusOK)
+		}))
+	}
+
+	DescribeTable("should negotiate the compression with the upload server", func(requested, acceptEncoding string, expected cdiv1.CloneCompression) {
+		server := newUploadServer(acceptEncoding)
+		defer server.Close()
+		Expect(negotiateCompression(server.Client(), server.URL, requested)).To(Equal(expected))
+	},
+		Entry("zstd if accepted", "zstd", "gzip, zstd", cdiv1.CloneCompressionZstd),
+		Entry("snappy if zstd is not accepted", "zstd", "gzip", cdiv1.CloneCompressionSnappy),
+		Entry("snappy with upload servers not listing encodings", "zstd", "", cdiv1.CloneCompressionSnappy),
+		Entry("snappy if not requested", "", "gzip, zstd", cdiv1.CloneCompressionSnappy),
+	)
+
+	It("should fall back to snappy if the upload server is unreachable", func() {
+		server := newUploadServer("zstd")
+		server.Close()
+		Expect(negotiateCompression(server.Client(), server.URL, "zstd")).To(Equal(cdiv1.CloneCompressionSnappy))
+	})
+
+	DescribeTable("should bound the zstd threads", func(cpuLimit string, expected int) {
+		Expect(zstdConcurrency(cpuLimit)).To(Equal(min(expected, max(runtime.GOMAXPROCS(0)-1, 1))))
+	},
+		Entry("leaving a CPU of the limit for reading the volume", "3", 2),
+		Entry("to a single thread with a CPU", "1", 1),
+		Entry("to the maximum", "64", maxZstdConcurrency),
+		Entry("without a limit", "", maxZstdConcurrency),
+	)
+
+	It("should compress the stream with zstd", func() {
+		data := strings.Repeat("compressible ", 1<<16)
+		reader := pipeToZstd(io.NopCloser(strings.NewReader(data)), 2)
+		decoder, err := zstd.NewReader(reader)
+		Expect(err).ToNot(HaveOccurred())
+		defer decoder.Close()
+		decompressed, err := io.ReadAll(decoder)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(decompressed)).To(Equal(data))
+	})
+})
+
+var _ = Describe("Export", func() {
+	var credentialDir string
+
+	BeforeEach(func() {
+		var err error
+		credentialDir, err = os.MkdirTemp("", "export")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(os.WriteFile(filepath.Join(credentialDir, common.KeyExportToken), []byte("token\n"), 0600)).To(Succeed())
+		DeferCleanup(os.RemoveAll, credentialDir)
+	})
+
+	It("should download the export with the export token", func() {
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get(exportTokenHeader) != "token" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			_, _ = w.Write([]byte("disk"))
+		}))
+		defer server.Close()
+
+		export, size, err := openExport(server.Client(), server.URL+"/disk.img", credentialDir)
+		Expect(err).ToNot(HaveOccurred())
+		defer export.Close()
+		Expect(size).To(BeEquivalentTo(4))
+		content, err := io.ReadAll(export)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(content)).To(Equal("disk"))
+	})
+
+	It("should fail when the export server refuses the token", func() {
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+		}))
+		defer server.Close()
+
+		_, _, err := openExport(server.Client(), server.URL+"/disk.img", credentialDir)
+		Expect(err).To(MatchError(ContainSubstring("401")))
+	})
+
+	It("should not send the token without TLS", func() {
+		_, _, err := openExport(http.DefaultClient, "http://export.example.com/disk.img", credentialDir)
+		Expect(err).To(MatchError(ContainSubstring("must be https")))
+	})
+})
+
+var _ = Describe("Reflink", func() {
+	It("should clone the files, directories and links of the source onto the target", func() {
+		source := GinkgoT().TempDir()
+		target := GinkgoT().TempDir()
+		Expect(os.WriteFile(filepath.Join(source, "disk.img"), []byte("disk"), 0640)).To(Succeed())
+		Expect(os.Mkdir(filepath.Join(source, "dir"), 0750)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(source, "dir", "file"), []byte("file"), 0600)).To(Succeed())
+		Expect(os.Symlink("dir/file", filepath.Join(source, "link"))).To(Succeed())
+		Expect(os.Mkdir(filepath.Join(source, "lost+found"), 0700)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(source, "lost+found", "orphan"), []byte("orphan"), 0600)).To(Succeed())
+
+		cloned, err := reflinkTree(source, target)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cloned).To(BeEquivalentTo(8))
+
+		content, err := os.ReadFile(filepath.Join(target, "disk.img"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(content)).To(Equal("disk"))
+		info, err := os.Stat(filepath.Join(target, "disk.img"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(info.Mode().Perm()).To(Equal(os.FileMode(0640)))
+		content, err = os.ReadFile(filepath.Join(target, "link"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(content)).To(Equal("file"))
+		Expect(filepath.Join(target, "lost+found")).ToNot(BeAnExistingFile())
+	})
+
+	It("should clone the files between two mounts of the same filesystem", func() {
+		dir := GinkgoT().TempDir()
+		source := filepath.Join(dir, "source")
+		target := filepath.Join(dir, "target")
+		for _, d := range []string{"source-volume", "target-volume", "source", "target"} {
+			Expect(os.Mkdir(filepath.Join(dir, d), 0750)).To(Succeed())
+		}
+		if err := unix.Mount(filepath.Join(dir, "source-volume"), source, "", unix.MS_BIND, ""); err != nil {
+			Skip(fmt.Sprintf("can't bind mount the source: %v", err))
+		}
+		DeferCleanup(unix.Unmount, source, 0)
+		Expect(unix.Mount(filepath.Join(dir, "target-volume"), target, "", unix.MS_BIND, "")).To(Succeed())
+		DeferCleanup(unix.Unmount, target, 0)
+		data := bytes.Repeat([]byte("disk"), 1024*1024)
+		Expect(os.WriteFile(filepath.Join(source, "disk.img"), data, 0640)).To(Succeed())
+
+		info, err := os.Stat(filepath.Join(source, "disk.img"))
+		Expect(err).ToNot(HaveOccurred())
+		offload := true
+		cloned, err := reflinkFile(filepath.Join(source, "disk.img"), filepath.Join(target, "disk.img"), info, &offload)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(offload).To(BeTrue())
+		Expect(cloned).To(BeEquivalentTo(len(data)))
+		content, err := os.ReadFile(filepath.Join(dir, "target-volume", "disk.img"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(content).To(Equal(data))
+	})
+
+	It("should replace the files already on the target", func() {
+		source := GinkgoT().TempDir()
+		target := GinkgoT().TempDir()
+		Expect(os.WriteFile(filepath.Join(source, "disk.img"), []byte("disk"), 0640)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(target, "disk.img"), []byte("previous disk"), 0640)).To(Succeed())
+
+		_, err := reflinkTree(source, target)
+		Expect(err).ToNot(HaveOccurred())
+		content, err := os.ReadFile(filepath.Join(target, "disk.img"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(content)).To(Equal("disk"))
+	})
+})
@@ -16,6 +16,18 @@
 
 set -euo pipefail
 
+if [[ -n "${CLONE_EXPORT_URL:-}" ]]; then
+    echo "Downloading the volume exported by another cluster"
+    if [ "$VOLUME_MODE" == "filesystem" ]; then
+        CONTENT_TYPE=filesystem-clone
+    elif [ "${CONVERT_IMAGE:-}" == "true" ]; then
+        CONTENT_TYPE=image-clone
+    else
+        CONTENT_TYPE=blockdevice-clone
+    fi
+    exec /usr/bin/cdi-cloner -v=3 -alsologtostderr -content-type $CONTENT_TYPE -export-url "$CLONE_EXPORT_URL"
+fi
+
 if [[ -z "$VOLUME_MODE" ]]; then
     echo "VOLUME_MODE missing" 1>&2
     exit 1
@@ -33,7 +45,18 @@ if [ "$VOLUME_MODE" == "block" ]; then
     UPLOAD_BYTES=$(blockdev --getsize64 $MOUNT_POINT)
     echo "UPLOAD_BYTES=$UPLOAD_BYTES"
 
-    /usr/bin/cdi-cloner -v=3 -alsologtostderr -content-type blockdevice-clone -upload-bytes $UPLOAD_BYTES -mount $MOUNT_POINT
+    if [ "${CONVERT_IMAGE:-}" == "true" ]; then
+        echo "Sending the disk image to convert it onto a filesystem target"
+        /usr/bin/cdi-cloner -v=3 -alsologtostderr -content-type image-clone -upload-bytes $UPLOAD_BYTES -mount $MOUNT_POINT
+    else
+        /usr/bin/cdi-cloner -v=3 -alsologtostderr -content-type blockdevice-clone -upload-bytes $UPLOAD_BYTES -mount $MOUNT_POINT
+    fi
+elif [ "${CONVERT_IMAGE:-}" == "true" ]; then
+    echo "Sending the disk image to convert it onto a block target"
+    UPLOAD_BYTES=$(stat -c %s $MOUNT_POINT/disk.img)
+    echo "UPLOAD_BYTES=$UPLOAD_BYTES"
+
+    /usr/bin/cdi-cloner -v=3 -alsologtostderr -content-type image-clone -upload-bytes $UPLOAD_BYTES -mount $MOUNT_POINT/disk.img
 else
     pushd $MOUNT_POINT
     if [ "$PREALLOCATION" == "true" ]; then
@@ -47,7 +70,12 @@ else
     fi
     echo "UPLOAD_BYTES=$UPLOAD_BYTES"
 
-    /usr/bin/cdi-cloner -v=3 -alsologtostderr -content-type filesystem-clone -upload-bytes $UPLOAD_BYTES -mount $MOUNT_POINT
+    if [ "${TRANSFER_MODE:-}" == "rsync" ]; then
+        echo "Transferring filesystem with rsync"
+        /usr/bin/cdi-cloner -v=3 -alsologtostderr -content-type filesystem-rsync-clone -upload-bytes $UPLOAD_BYTES -mount $MOUNT_POINT
+    else
+        /usr/bin/cdi-cloner -v=3 -alsologtostderr -content-type filesystem-clone -upload-bytes $UPLOAD_BYTES -mount $MOUNT_POINT
+    fi
 
     popd
 fi
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"io"
+	"net/http"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+
+	"k8s.io/klog/v2"
+
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+)
+
+const (
+	// maxZstdConcurrency bounds the threads compressing with zstd, more of them rarely outpace the network
+	maxZstdConcurrency = 4
+
+	// zstdWindowSize bounds the memory zstd takes on both ends of the clone
+	zstdWindowSize = 8 << 20
+)
+
+// negotiateCompression returns the compression of the clone stream: zstd if it was requested and the upload server
+// accepts it as content encoding, snappy otherwise
+func negotiateCompression(client *http.Client, url, requested string) cdiv1.CloneCompression {
+	if requested != string(cdiv1.CloneCompressionZstd) {
+		return cdiv1.CloneCompressionSnappy
+	}
+	response, err := client.Head(url)
+	if err != nil {
+		klog.Errorf("Unable to negotiate the compression with %s, falling back to snappy: %v", url, err)
+		return cdiv1.CloneCompressionSnappy
+	}
+	response.Body.Close()
+	if response.StatusCode != http.StatusOK || !acceptsEncoding(response.Header, "zstd") {
+		klog.Infof("The upload server does not accept zstd, falling back to snappy")
+		return cdiv1.CloneCompressionSnappy
+	}
+	return cdiv1.CloneCompressionZstd
+}
+
+// acceptsEncoding returns true if the Accept-Encoding header lists the encoding
+func acceptsEncoding(header http.Header, encoding string) bool {
+	for _, value := range header.Values("Accept-Encoding") {
+		for _, accepted := range strings.Split(value, ",") {
+			token, _, _ := strings.Cut(accepted, ";")
+			if strings.EqualFold(strings.TrimSpace(token), encoding) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// zstdConcurrency returns the number of threads compressing with zstd, leaving one of the CPUs the pod is limited to
+// for reading the volume
+func zstdConcurrency(cpuLimit string) int {
+	concurrency := runtime.GOMAXPROCS(0)
+	if limit, err := strconv.Atoi(cpuLimit); err == nil && limit > 0 && limit < concurrency {
+		concurrency = limit
+	}
+	if concurrency > 1 {
+		concurrency--
+	}
+	return min(concurrency, maxZstdConcurrency)
+}
+
+func pipeToZstd(reader io.ReadCloser, concurrency int) io.ReadCloser {
+	pr, pw := io.Pipe()
+	zw, err := zstd.NewWriter(pw,
+		// the fastest level keeps up with the network, the stronger ones cost more CPU than the bandwidth they save
+		zstd.WithEncoderLevel(zstd.SpeedFastest),
+		zstd.WithEncoderConcurrency(concurrency),
+		zstd.WithWindowSize(zstdWindowSize))
+	if err != nil {
+		klog.Fatalf("Error creating zstd writer %+v", err)
+	}
+	klog.Infof("Compressing with zstd, %d threads", concurrency)
+
+	go func() {
+		n, err := io.Copy(zw, reader)
+		if err != nil {
+			klog.Fatalf("Error %s piping to zstd", err)
+		}
+		if err = zw.Close(); err != nil {
+			klog.Fatalf("Error closing zstd writer %+v", err)
+		}
+		if err = pw.Close(); err != nil {
+			klog.Fatalf("Error closing pipe writer %+v", err)
+		}
+		klog.Infof("Wrote %d bytes\n", n)
+	}()
+
+	return pr
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"compress/gzip"
+	"crypto/tls"
+	"crypto/x509"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"k8s.io/klog/v2"
+
+	"kubevirt.io/containerized-data-importer/pkg/common"
+)
+
+// exportTokenHeader is the header the export server of the source cluster reads the export token from
+const exportTokenHeader = "x-kubevirt-export-token"
+
+// gzipReader closes the export along with the gzip reader decompressing it
+type gzipReader struct {
+	*gzip.Reader
+	export io.Closer
+}
+
+func (gr *gzipReader) Close() error {
+	if err := gr.Reader.Close(); err != nil {
+		return err
+	}
+	return gr.export.Close()
+}
+
+// createExportHTTPClient returns an http client trusting the CAs in certDir, or the system ones if there is none, and
+// presenting the client certificate of the export secret for mutual TLS when the secret holds one
+func createExportHTTPClient(credentialDir, certDir string) (*http.Client, error) {
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	files, err := os.ReadDir(certDir)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	if len(files) > 0 {
+		tlsConfig.RootCAs, err = x509.SystemCertPool()
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			if file.IsDir() || strings.HasPrefix(file.Name(), "..") {
+				continue
+			}
+			certs, err := os.ReadFile(filepath.Join(certDir, file.Name()))
+			if err != nil {
+				return nil, err
+			}
+			tlsConfig.RootCAs.AppendCertsFromPEM(certs)
+		}
+	}
+
+	certFile := filepath.Join(credentialDir, common.KeyExportClientCert)
+	if _, err := os.Stat(certFile); err == nil {
+		clientCert, err := tls.LoadX509KeyPair(certFile, filepath.Join(credentialDir, common.KeyExportClientKey))
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to load the client certificate of the export secret")
+		}
+		tlsConfig.Certificates = []tls.Certificate{clientCert}
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	return &http.Client{Transport: transport}, nil
+}
+
+// openExport downloads the volume exported at the url with the export token of the secret in credentialDir. It returns
+// the body of the export and its size, 0 if the export server does not report it.
+func openExport(client *http.Client, exportURL, credentialDir string) (io.ReadCloser, uint64, error) {
+	ep, err := url.Parse(exportURL)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "unable to parse the export url")
+	}
+	if ep.Scheme != "https" {
+		return nil, 0, errors.Errorf("export url %s must be https", ep.Redacted())
+	}
+	content, err := os.ReadFile(filepath.Join(credentialDir, common.KeyExportToken))
+	if err != nil {
+		return nil, 0, errors.Wrapf(err, "export secret has no %s", common.KeyExportToken)
+	}
+	token := strings.TrimSpace(string(content))
+	if token == "" {
+		return nil, 0, errors.New("export token is empty")
+	}
+
+	req, err := http.NewRequest(http.MethodGet, ep.String(), nil)
+	if err != nil {
+		return nil, 0, err
+	}
+	req.Header.Set(exportTokenHeader, token)
+	response, err := client.Do(req)
+	if err != nil {
+		return nil, 0, errors.Wrapf(err, "error downloading %s", ep.Redacted())
+	}
+	if response.StatusCode != http.StatusOK {
+		response.Body.Close()
+		return nil, 0, errors.Errorf("unable to download the exported volume %s: %s", ep.Redacted(), response.Status)
+	}
+	size := uint64(0)
+	if response.ContentLength > 0 {
+		size = uint64(response.ContentLength)
+	}
+	klog.Infof("Downloading %s, %d bytes", ep.Redacted(), size)
+	return response.Body, size, nil
+}
+
+// newExportReader returns the stream of the volume exported at the url, decompressed if the export is gzipped. The
+// progress of the clone is the progress of the download.
+func newExportReader(exportURL, ownerUID string) (io.ReadCloser, error) {
+	client, err := createExportHTTPClient(common.ImporterExportCredentialDir, common.ImporterCertDir)
+	if err != nil {
+		return nil, err
+	}
+	export, size, err := openExport(client, exportURL, common.ImporterExportCredentialDir)
+	if err != nil {
+		return nil, err
+	}
+	progressReader, err := createProgressReader(export, ownerUID, size)
+	if err != nil {
+		export.Close()
+		return nil, err
+	}
+	if ep, _ := url.Parse(exportURL); !strings.HasSuffix(ep.Path, ".gz") {
+		return progressReader, nil
+	}
+	zr, err := gzip.NewReader(progressReader)
+	if err != nil {
+		progressReader.Close()
+		return nil, errors.Wrap(err, "unable to decompress the export")
+	}
+	return &gzipReader{Reader: zr, export: progressReader}, nil
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/sys/unix"
+
+	"k8s.io/klog/v2"
+)
+
+// reflinkTree clones the files of source onto target, a volume of the same shared filesystem. The source and the target
+// are separate mounts of the pod, so the files are cloned with copy_file_range, which, unlike the FICLONE ioctl, is not
+// limited to a single mount: the kernel shares the extents of the files on filesystems supporting reflinks, and NFS
+// and CephFS offload the copy to the file server. It returns the bytes of the files cloned.
+func reflinkTree(source, target string) (uint64, error) {
+	var cloned uint64
+	offload := true
+	err := filepath.WalkDir(source, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(source, path)
+		if err != nil {
+			return err
+		}
+		if rel == "." {
+			return nil
+		}
+		if rel == "lost+found" && d.IsDir() {
+			return filepath.SkipDir
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		dst := filepath.Join(target, rel)
+
+		switch {
+		case d.IsDir():
+			if err := os.Mkdir(dst, info.Mode().Perm()); err != nil && !os.IsExist(err) {
+				return err
+			}
+		case d.Type()&fs.ModeSymlink != 0:
+			link, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			if err := os.Remove(dst); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+			return os.Symlink(link, dst)
+		case d.Type().IsRegular():
+			n, err := reflinkFile(path, dst, info, &offload)
+			if err != nil {
+				return err
+			}
+			cloned += n
+		default:
+			klog.Warningf("Skipping %s, %s files are not cloned", rel, d.Type())
+		}
+		return nil
+	})
+	return cloned, err
+}
+
+// reflinkFile clones the file at source onto target with copy_file_range, falling back to reading and writing the
+// data while the kernel can't offload the copy between the files
+func reflinkFile(source, target string, info fs.FileInfo, offload *bool) (uint64, error) {
+	src, err := os.Open(source)
+	if err != nil {
+		return 0, err
+	}
+	defer src.Close()
+
+	dst, err := os.OpenFile(target, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, info.Mode().Perm())
+	if err != nil {
+		return 0, err
+	}
+
+	var copied int64
+	if *offload {
+		copied, err = copyFileRange(dst, src, info.Size())
+		if err != nil && offloadUnsupported(err) {
+			klog.Infof("The kernel can't offload the copy of the files (%v), copying them", err)
+			*offload = false
+			err = nil
+		}
+	}
+	if err == nil && !*offload {
+		_, err = io.Copy(struct{ io.Writer }{dst}, struct{ io.Reader }{io.NewSectionReader(src, copied, info.Size()-copied)})
+	}
+	if err != nil {
+		dst.Close()
+		return 0, err
+	}
+	if err := dst.Close(); err != nil {
+		return 0, err
+	}
+	if err := os.Chtimes(target, info.ModTime(), info.ModTime()); err != nil {
+		return 0, err
+	}
+	return uint64(info.Size()), nil
+}
+
+// copyFileRange copies size bytes of src onto dst with copy_file_range, within the kernel or the file server, and
+// returns the bytes copied
+func copyFileRange(dst, src *os.File, size int64) (int64, error) {
+	var copied int64
+	for copied < size {
+		n, err := unix.CopyFileRange(int(src.Fd()), nil, int(dst.Fd()), nil, int(size-copied), 0)
+		if err != nil {
+			return copied, err
+		}
+		if n == 0 {
+			// the source shrank while it was copied
+			break
+		}
+		copied += int64(n)
+	}
+	return copied, nil
+}
+
+// offloadUnsupported returns true if copy_file_range failed because the kernel can't copy between the files, like
+// files of different filesystems
+func offloadUnsupported(err error) bool {
+	return errors.Is(err, unix.EOPNOTSUPP) || errors.Is(err, unix.EXDEV) || errors.Is(err, unix.EINVAL) ||
+		errors.Is(err, unix.ENOSYS)
+}
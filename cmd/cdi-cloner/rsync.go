@@ -0,0 +1,163 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"k8s.io/klog/v2"
+
+	"kubevirt.io/containerized-data-importer/pkg/common"
+	metrics "kubevirt.io/containerized-data-importer/pkg/monitoring/metrics/cdi-cloner"
+)
+
+const rsyncPath = "/usr/bin/rsync"
+
+// rsyncProgress matches the bytes transferred and the percentage of the transfer in the progress reported by
+// rsync --info=progress2
+var rsyncProgress = regexp.MustCompile(`\s([\d,]+)\s+(\d{1,3})%\s`)
+
+// rsyncArgs returns the arguments of the rsync sending the mount point to the upload server. This binary is the remote
+// shell of rsync, tunnelling its protocol to the upload server, which picks the target directory itself.
+func rsyncArgs(self string, preallocation bool) []string {
+	args := []string{
+		"--recursive",
+		"--links",
+		"--perms",
+		"--times",
+		// only the changed blocks of the disk image are written, without a temporary copy of it on the target
+		"--inplace",
+		"--delete",
+		"--exclude=/lost+found",
+		"--info=progress2",
+		"--rsh=" + self + " -rsync-tunnel",
+	}
+	if preallocation {
+		args = append(args, "--preallocate")
+	} else {
+		args = append(args, "--sparse")
+	}
+	return append(args, "./", "upload-server:")
+}
+
+// runRsync transfers the mount point to the upload server with rsync, reporting its progress to the clone progress metric
+func runRsync(preallocation bool, progress *metrics.CloneProgress) error {
+	self, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	args := rsyncArgs(self, preallocation)
+	klog.Infof("Executing %s %+v", rsyncPath, args)
+
+	cmd := exec.Command(rsyncPath, args...)
+	cmd.Dir = mountPoint
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	reportRsyncProgress(stdout, progress)
+	if err := cmd.Wait(); err != nil {
+		return errors.Wrapf(err, "rsync failed: %s", stderr.String())
+	}
+	return nil
+}
+
+// reportRsyncProgress adds the progress rsync reports to the clone progress metric until its output is closed
+func reportRsyncProgress(output io.Reader, progress *metrics.CloneProgress) {
+	scanner := bufio.NewScanner(output)
+	// rsync rewrites its progress line with carriage returns
+	scanner.Split(scanProgressLines)
+	for scanner.Scan() {
+		m := rsyncProgress.FindStringSubmatch(" " + scanner.Text() + " ")
+		if m == nil {
+			continue
+		}
+		if transferred, err := strconv.ParseUint(strings.ReplaceAll(m[1], ",", ""), 10, 64); err == nil {
+			// the total of rsync grows as it lists the files, it is not reported
+			progress.SetBytes(transferred, 0)
+		}
+		percent, err := strconv.ParseFloat(m[2], 64)
+		if err != nil {
+			continue
+		}
+		current, err := progress.Get()
+		if err != nil {
+			klog.Errorf("Unable to read the clone progress: %v", err)
+			continue
+		}
+		if percent > current {
+			progress.Add(percent - current)
+		}
+	}
+}
+
+// scanProgressLines splits the output of rsync in lines ended by a new line or a carriage return
+func scanProgressLines(data []byte, atEOF bool) (int, []byte, error) {
+	if i := bytes.IndexAny(data, "\r\n"); i >= 0 {
+		return i + 1, data[:i], nil
+	}
+	if atEOF && len(data) > 0 {
+		return len(data), data, nil
+	}
+	return 0, nil, nil
+}
+
+// runRsyncTunnel connects the standard input and output of the rsync sender to the rsync receiver the upload server
+// runs. rsync runs it as its remote shell, with the host and the command of the remote rsync as arguments.
+func runRsyncTunnel(args []string) error {
+	if len(args) < 2 {
+		return errors.Errorf("expected the host and the command of the remote rsync, got %q", args)
+	}
+	command, err := json.Marshal(args[1:])
+	if err != nil {
+		return err
+	}
+
+	client := createHTTPClient([]byte(getEnvVarOrDie("CLIENT_KEY")), []byte(getEnvVarOrDie("CLIENT_CERT")), []byte(getEnvVarOrDie("SERVER_CA_CERT")))
+	url := getEnvVarOrDie("UPLOAD_URL")
+	req, err := http.NewRequest(http.MethodPost, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set(common.UploadContentTypeHeader, common.FilesystemRsyncCloneContentType)
+	req.Header.Set(common.RsyncArgsHeader, string(command))
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Upgrade", common.RsyncUpgradeProtocol)
+
+	response, err := client.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "error POSTing to %s", url)
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusSwitchingProtocols {
+		body, _ := io.ReadAll(io.LimitReader(response.Body, 4096))
+		return fmt.Errorf("unexpected status code %d: %s", response.StatusCode, string(body))
+	}
+	conn, ok := response.Body.(io.ReadWriteCloser)
+	if !ok {
+		return errors.New("the upload server connection is not writable")
+	}
+
+	go func() {
+		if _, err := io.Copy(conn, os.Stdin); err != nil {
+			klog.Errorf("Error sending the rsync protocol: %v", err)
+		}
+	}()
+	_, err = io.Copy(os.Stdout, conn)
+	return err
+}
@@ -2,7 +2,6 @@ package main
 
 import (
 	"context"
-	"crypto/rsa"
 	"crypto/tls"
 	"flag"
 	"fmt"
@@ -24,6 +23,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
 	apiruntime "k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
@@ -46,9 +46,10 @@ import (
 	dvc "kubevirt.io/containerized-data-importer/pkg/controller/datavolume"
 	"kubevirt.io/containerized-data-importer/pkg/controller/populators"
 	"kubevirt.io/containerized-data-importer/pkg/controller/transfer"
+	"kubevirt.io/containerized-data-importer/pkg/keys"
 	metrics "kubevirt.io/containerized-data-importer/pkg/monitoring/metrics/cdi-controller"
+	"kubevirt.io/containerized-data-importer/pkg/token"
 	"kubevirt.io/containerized-data-importer/pkg/util"
-	"kubevirt.io/containerized-data-importer/pkg/util/cert"
 	"kubevirt.io/containerized-data-importer/pkg/util/cert/fetcher"
 	"kubevirt.io/containerized-data-importer/pkg/util/cert/generator"
 )
@@ -248,8 +249,10 @@ func start() {
 
 	ctx := signals.SetupSignalHandler()
 
+	tokenKeys := getTokenKeys()
+
 	// TODO: Current DV controller had threadiness 3, should we do the same here, defaults to one thread.
-	if _, err := dvc.NewImportController(ctx, mgr, log, installerLabels); err != nil {
+	if _, err := dvc.NewImportController(ctx, mgr, log, importerImage, pullPolicy, installerLabels); err != nil {
 		klog.Errorf("Unable to setup datavolume import controller: %v", err)
 		os.Exit(1)
 	}
@@ -258,12 +261,12 @@ func start() {
 		os.Exit(1)
 	}
 	if _, err := dvc.NewPvcCloneController(ctx, mgr, log,
-		clonerImage, importerImage, pullPolicy, getTokenPublicKey(), getTokenPrivateKey(), installerLabels); err != nil {
+		clonerImage, importerImage, pullPolicy, tokenKeys, installerLabels); err != nil {
 		klog.Errorf("Unable to setup datavolume pvc clone controller: %v", err)
 		os.Exit(1)
 	}
 	if _, err := dvc.NewSnapshotCloneController(ctx, mgr, log,
-		clonerImage, importerImage, pullPolicy, getTokenPublicKey(), getTokenPrivateKey(), installerLabels); err != nil {
+		clonerImage, importerImage, pullPolicy, tokenKeys, installerLabels); err != nil {
 		klog.Errorf("Unable to setup datavolume snapshot clone controller: %v", err)
 		os.Exit(1)
 	}
@@ -277,7 +280,7 @@ func start() {
 		os.Exit(1)
 	}
 
-	if _, err := controller.NewCloneController(mgr, log, clonerImage, pullPolicy, verbose, uploadClientCertGenerator, uploadServerBundleFetcher, getTokenPublicKey(), installerLabels); err != nil {
+	if _, err := controller.NewCloneController(mgr, log, clonerImage, pullPolicy, verbose, uploadClientCertGenerator, uploadServerBundleFetcher, tokenKeys, installerLabels); err != nil {
 		klog.Errorf("Unable to setup clone controller: %v", err)
 		os.Exit(1)
 	}
@@ -300,6 +303,22 @@ func start() {
 		klog.Errorf("Unable to setup datasource controller: %v", err)
 		os.Exit(1)
 	}
+	if _, err := controller.NewMultiDataVolumeController(mgr, log, installerLabels); err != nil {
+		klog.Errorf("Unable to setup multidatavolume controller: %v", err)
+		os.Exit(1)
+	}
+	if _, err := controller.NewDataVolumeReplicationController(mgr, log, installerLabels); err != nil {
+		klog.Errorf("Unable to setup datavolumereplication controller: %v", err)
+		os.Exit(1)
+	}
+	if _, err := controller.NewDataVolumeMigrationController(mgr, log, installerLabels); err != nil {
+		klog.Errorf("Unable to setup datavolumemigration controller: %v", err)
+		os.Exit(1)
+	}
+	if _, err := controller.NewDataExportController(mgr, log, importerImage, pullPolicy, installerLabels); err != nil {
+		klog.Errorf("Unable to setup dataexport controller: %v", err)
+		os.Exit(1)
+	}
 	// Populator controllers and indexes
 	if err := populators.CreateCommonPopulatorIndexes(mgr); err != nil {
 		klog.Errorf("Unable to create common populator indexes: %v", err)
@@ -313,7 +332,7 @@ func start() {
 		klog.Errorf("Unable to setup upload populator: %v", err)
 		os.Exit(1)
 	}
-	if _, err := populators.NewClonePopulator(ctx, mgr, log, clonerImage, pullPolicy, installerLabels, getTokenPublicKey()); err != nil {
+	if _, err := populators.NewClonePopulator(ctx, mgr, log, clonerImage, pullPolicy, installerLabels, tokenKeys); err != nil {
 		klog.Errorf("Unable to setup clone populator: %v", err)
 		os.Exit(1)
 	}
@@ -369,37 +388,14 @@ func deleteReadyFile() {
 	os.Remove(readyFile)
 }
 
-func getTokenPublicKey() *rsa.PublicKey {
-	keyBytes, err := os.ReadFile(controller.TokenPublicKeyPath)
-	if err != nil {
-		klog.Fatalf("Error reading apiserver public key")
-	}
-
-	key, err := controller.DecodePublicKey(keyBytes)
-	if err != nil {
-		klog.Fatalf("Error decoding public key")
-	}
-
-	return key
-}
-
-func getTokenPrivateKey() *rsa.PrivateKey {
-	bytes, err := os.ReadFile(controller.TokenPrivateKeyPath)
-	if err != nil {
-		klog.Fatalf("Error reading private key")
-	}
-
-	obj, err := cert.ParsePrivateKeyPEM(bytes)
+// getTokenKeys returns the keys of the apiserver validating the clone tokens and signing the extended ones, reloaded
+// when the apiserver rotates them
+func getTokenKeys() token.KeySet {
+	tokenKeys, err := keys.NewFileKeySet(controller.TokenKeyDir)
 	if err != nil {
-		klog.Fatalf("Error decoding private key")
+		klog.Fatalf("Error reading apiserver keys: %v", err)
 	}
-
-	key, ok := obj.(*rsa.PrivateKey)
-	if !ok {
-		klog.Fatalf("Invalid private key format")
-	}
-
-	return key
+	return tokenKeys
 }
 
 // Restricts some types in the cache's ListWatch to specific fields/labels per GVK at the specified object,
@@ -409,6 +405,11 @@ func getTokenPrivateKey() *rsa.PrivateKey {
 // at the point of writing this, we don't care about VolumeSnapshots without the CDI label
 func getCacheOptions(apiClient client.Client, cdiNamespace string) cache.Options {
 	namespaceSelector := fields.Set{"metadata.namespace": cdiNamespace}.AsSelector()
+	// The jobs of the CDI namespace are never read, only the post completion hook jobs of DataVolumes are watched
+	hookJobSelector, err := labels.Parse(common.PostCompletionHookLabel)
+	if err != nil {
+		klog.Fatalf("Unable to parse post completion hook job selector: %v", err)
+	}
 
 	cacheOptions := cache.Options{
 		ByObject: map[client.Object]cache.ByObject{
@@ -419,7 +420,7 @@ func getCacheOptions(apiClient client.Client, cdiNamespace string) cache.Options
 				Field: namespaceSelector,
 			},
 			&batchv1.Job{}: {
-				Field: namespaceSelector,
+				Label: hookJobSelector,
 			},
 			&v1.ConfigMap{}: {
 				Field: namespaceSelector,
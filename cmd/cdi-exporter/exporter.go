@@ -0,0 +1,69 @@
+package main
+
+// exporter.go implements the export of a PVC, mounted as a file system or block volume, to a remote
+// target. The disk image is converted to qcow2 in the scratch space and uploaded with a HTTP PUT
+// request, as a S3 object, or pushed to a registry as a containerDisk.
+// This process expects several environmental variables:
+//    ExporterSourcePath     The disk image or block device exported.
+//    ExporterSourceFormat   Optional. The format of the source, raw if omitted.
+//    ExporterTarget         One of http, s3 and registry.
+//    ExporterEndpoint       The url of the target.
+//    ExporterAccessKeyID    Optional. The user of the target.
+//    ExporterSecretKey      Optional. The password of the target.
+
+import (
+	"context"
+	"flag"
+	"os"
+	"strconv"
+
+	"k8s.io/klog/v2"
+
+	"kubevirt.io/containerized-data-importer/pkg/common"
+	"kubevirt.io/containerized-data-importer/pkg/exporter"
+	"kubevirt.io/containerized-data-importer/pkg/util"
+)
+
+func init() {
+	klog.InitFlags(nil)
+	flag.Parse()
+}
+
+func main() {
+	defer klog.Flush()
+
+	source, _ := util.ParseEnvVar(common.ExporterSourcePath, false)
+	format, _ := util.ParseEnvVar(common.ExporterSourceFormat, false)
+	target, _ := util.ParseEnvVar(common.ExporterTarget, false)
+	ep, _ := util.ParseEnvVar(common.ExporterEndpoint, false)
+	acc, _ := util.ParseEnvVar(common.ExporterAccessKeyID, false)
+	sec, _ := util.ParseEnvVar(common.ExporterSecretKey, false)
+	certDir, _ := util.ParseEnvVar(common.ExporterCertDirVar, false)
+	compress, _ := strconv.ParseBool(os.Getenv(common.ExporterCompress))
+
+	klog.V(1).Infoln("Starting exporter")
+	digest, err := exporter.Export(context.Background(), exporter.Options{
+		SourcePath:   source,
+		SourceFormat: format,
+		ScratchDir:   common.ScratchDataDir,
+		Compress:     compress,
+		Target:       target,
+		Endpoint:     ep,
+		AccessKey:    acc,
+		SecretKey:    sec,
+		CertDir:      certDir,
+	})
+	if err != nil {
+		klog.Errorf("%+v", err)
+		if err := util.WriteTerminationMessage("Unable to export: " + err.Error()); err != nil {
+			klog.Errorf("%+v", err)
+		}
+		os.Exit(1)
+	}
+
+	klog.Infof("Exported %s with digest %s", source, digest)
+	if err := util.WriteTerminationMessage(digest); err != nil {
+		klog.Errorf("%+v", err)
+		os.Exit(1)
+	}
+}
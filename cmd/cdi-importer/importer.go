@@ -17,6 +17,7 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"time"
@@ -36,7 +37,8 @@ import (
 )
 
 const (
-	completeMessage = "Import Complete"
+	completeMessage           = "Import Complete"
+	validationCompleteMessage = "Validation Complete"
 )
 
 func init() {
@@ -116,6 +118,13 @@ func main() {
 		os.Exit(1)
 	}
 
+	if isValidateOnly() {
+		if exitCode := handleValidation(source, contentType, imageSize, filesystemOverhead); exitCode != 0 {
+			os.Exit(exitCode)
+		}
+		return
+	}
+
 	volumeMode := v1.PersistentVolumeBlock
 	if _, err := os.Stat(common.WriteBlockPath); os.IsNotExist(err) {
 		volumeMode = v1.PersistentVolumeFilesystem
@@ -124,7 +133,8 @@ func main() {
 	// With writeback cache mode it's possible that the process will exit before all writes have been committed to storage.
 	// To guarantee that our write was committed to storage, we make a fsync syscall and ensure success.
 	// Also might be a good idea to sync any chmod's we might have done.
-	defer fsyncDataFile(contentType, volumeMode)
+	// The content type may be picked from the data during the import, so it is only read when syncing.
+	defer func() { fsyncDataFile(contentType, volumeMode) }()
 
 	//Registry import currently support kubevirt content type only
 	if contentType != string(cdiv1.DataVolumeKubeVirt) && (source == cc.SourceRegistry || source == cc.SourceImageio) {
@@ -145,7 +155,8 @@ func main() {
 		}
 	} else {
 		waitForReadyFile()
-		exitCode := handleImport(source, contentType, volumeMode, imageSize, filesystemOverhead, preallocation)
+		var exitCode int
+		exitCode, contentType = handleImport(source, contentType, volumeMode, imageSize, filesystemOverhead, preallocation)
 		if exitCode != 0 {
 			os.Exit(exitCode)
 		}
@@ -155,10 +166,16 @@ func main() {
 func handleEmptyImage(contentType string, imageSize string, availableDestSpace int64, preallocation bool, volumeMode v1.PersistentVolumeMode, filesystemOverhead float64) error {
 	if contentType == string(cdiv1.DataVolumeKubeVirt) {
 		if volumeMode == v1.PersistentVolumeBlock && !preallocation {
-			klog.V(1).Infoln("Blank block without preallocation is exactly an empty PVC, done populating")
-			return nil
+			// Recycled block PVs may still hold partition tables or data of a previous user, wipe before handing out
+			zeroEdges, _ := strconv.ParseBool(os.Getenv(common.ImporterBlankZeroEdges))
+			if err := image.InitializeBlankBlock(common.WriteBlockPath, availableDestSpace, zeroEdges); err != nil {
+				klog.Errorf("%+v", err)
+				writeFailureTerminationMessage(fmt.Sprintf("Unable to initialize blank block volume: %v", err), err, nil)
+				os.Exit(1)
+			}
+		} else {
+			createBlankImage(imageSize, availableDestSpace, preallocation, volumeMode, filesystemOverhead)
 		}
-		createBlankImage(imageSize, availableDestSpace, preallocation, volumeMode, filesystemOverhead)
 	} else {
 		errorEmptyDiskWithContentTypeArchive()
 	}
@@ -167,6 +184,9 @@ func handleEmptyImage(contentType string, imageSize string, availableDestSpace i
 		PreallocationApplied: ptr.To(preallocation),
 		Message:              ptr.To(completeMessage),
 	}
+	if method := image.PreallocationMethod(); method != "" {
+		msg.PreallocationMethod = ptr.To(method)
+	}
 
 	err := writeTerminationMessage(msg)
 	return err
@@ -178,7 +198,7 @@ func handleImport(
 	volumeMode v1.PersistentVolumeMode,
 	imageSize string,
 	filesystemOverhead float64,
-	preallocation bool) int {
+	preallocation bool) (int, string) {
 	klog.V(1).Infoln("begin import process")
 
 	ds := newDataSource(source, contentType, volumeMode)
@@ -190,10 +210,8 @@ func handleImport(
 	scratchSpaceRequired := errors.Is(err, importer.ErrRequiresScratchSpace)
 	if err != nil && !scratchSpaceRequired {
 		klog.Errorf("%+v", err)
-		if err := util.WriteTerminationMessage(fmt.Sprintf("Unable to process data: %v", err.Error())); err != nil {
-			klog.Errorf("%+v", err)
-		}
-		return 1
+		writeFailureTerminationMessage(fmt.Sprintf("Unable to process data: %v", err.Error()), err, ds)
+		return 1, contentType
 	}
 
 	termMsg := ds.GetTerminationMessage()
@@ -202,12 +220,30 @@ func handleImport(
 	}
 	termMsg.ScratchSpaceRequired = &scratchSpaceRequired
 	termMsg.PreallocationApplied = ptr.To(processor.PreallocationApplied())
+	if method := image.PreallocationMethod(); method != "" {
+		termMsg.PreallocationMethod = ptr.To(method)
+	}
 	termMsg.Message = ptr.To(completeMessage)
+	if reporter, ok := ds.(importer.SourceDigestReporter); ok && reporter.ObservedSourceDigest() != "" {
+		termMsg.ObservedSourceDigest = ptr.To(reporter.ObservedSourceDigest())
+	}
+	for phase, duration := range processor.PhaseDurations() {
+		if termMsg.PhaseDurations == nil {
+			termMsg.PhaseDurations = make(map[common.ImportPhase]string)
+		}
+		termMsg.PhaseDurations[phase] = duration.Round(time.Millisecond).String()
+	}
+	if detector, ok := ds.(importer.ContentTypeDetector); ok {
+		contentType = string(detector.ContentType())
+		if detected := importer.DetectImportedContentType(detector, getImporterDestPath(contentType, volumeMode)); detected != "" {
+			termMsg.DetectedContentType = ptr.To(detected)
+		}
+	}
 
 	touchDoneFile()
 	if err := writeTerminationMessage(termMsg); err != nil {
 		klog.Errorf("%+v", err)
-		return 1
+		return 1, contentType
 	}
 
 	if scratchSpaceRequired {
@@ -217,9 +253,33 @@ func handleImport(
 		os.Exit(0)
 	}
 
+	return 0, contentType
+}
+
+// handleValidation validates the source without writing it, the PVC is not mounted
+func handleValidation(source string, contentType string, imageSize string, filesystemOverhead float64) int {
+	klog.V(1).Infoln("begin validation of the source")
+	waitForReadyFile()
+
+	ds := newDataSource(source, contentType, v1.PersistentVolumeFilesystem)
+	defer ds.Close()
+
+	termMsg := &common.TerminationMessage{
+		SourceValidation: importer.ValidateSource(ds, imageSize, filesystemOverhead),
+		Message:          ptr.To(validationCompleteMessage),
+	}
+	if err := writeTerminationMessage(termMsg); err != nil {
+		klog.Errorf("%+v", err)
+		return 1
+	}
 	return 0
 }
 
+func isValidateOnly() bool {
+	validateOnly, _ := strconv.ParseBool(os.Getenv(common.ImporterValidateOnly))
+	return validateOnly
+}
+
 func writeTerminationMessage(termMsg *common.TerminationMessage) error {
 	msg, err := termMsg.String()
 	if err != nil {
@@ -232,9 +292,50 @@ func writeTerminationMessage(termMsg *common.TerminationMessage) error {
 	return nil
 }
 
+// writeFailureTerminationMessage writes the termination message of a failed import. The reason and the retry class
+// of the failure and the digest of the source are added when they are known, the message is written as is otherwise.
+func writeFailureTerminationMessage(message string, err error, ds importer.DataSourceInterface) {
+	termMsg := &common.TerminationMessage{Message: ptr.To(message)}
+	if reason := importer.FailureReason(err); reason != "" {
+		termMsg.FailureReason = ptr.To(reason)
+	}
+	if class := importer.FailureClass(err); class != "" {
+		termMsg.FailureClass = ptr.To(string(class))
+	}
+	if reporter, ok := ds.(importer.SourceDigestReporter); ok && reporter.ObservedSourceDigest() != "" {
+		termMsg.ObservedSourceDigest = ptr.To(reporter.ObservedSourceDigest())
+	}
+	if termMsg.FailureReason != nil || termMsg.FailureClass != nil || termMsg.ObservedSourceDigest != nil {
+		err := writeTerminationMessage(termMsg)
+		if err == nil {
+			return
+		}
+		klog.Errorf("%+v", err)
+	}
+	if err := util.WriteTerminationMessage(message); err != nil {
+		klog.Errorf("%+v", err)
+	}
+}
+
 func newDataProcessor(contentType string, volumeMode v1.PersistentVolumeMode, ds importer.DataSourceInterface, imageSize string, filesystemOverhead float64, preallocation bool) *importer.DataProcessor {
 	dest := getImporterDestPath(contentType, volumeMode)
 	processor := importer.NewDataProcessor(ds, dest, common.ImporterDataDir, common.ScratchDataDir, imageSize, filesystemOverhead, preallocation, os.Getenv(common.CacheMode))
+	if storageFormat, _ := util.ParseEnvVar(common.ImporterStorageFormat, false); storageFormat != "" {
+		processor.SetStorageFormat(storageFormat)
+	}
+	if expand, _ := strconv.ParseBool(os.Getenv(common.ImporterExpandFilesystem)); expand {
+		processor.SetExpandFilesystem(expand)
+	}
+	if sparsify, _ := strconv.ParseBool(os.Getenv(common.ImporterSparsify)); sparsify {
+		processor.SetSparsify(sparsify)
+	}
+	if passphraseFile, _ := util.ParseEnvVar(common.ImporterEncryptionPassphraseFile, false); passphraseFile != "" {
+		processor.SetEncryptionPassphraseFile(passphraseFile)
+	}
+	if verify, _ := strconv.ParseBool(os.Getenv(common.ImporterVerify)); verify {
+		checksum, _ := strconv.ParseBool(os.Getenv(common.ImporterVerifyChecksum))
+		processor.SetVerification(verify, checksum)
+	}
 	return processor
 }
 
@@ -256,6 +357,8 @@ func newDataSource(source string, contentType string, volumeMode v1.PersistentVo
 	acc, _ := util.ParseEnvVar(common.ImporterAccessKeyID, false)
 	sec, _ := util.ParseEnvVar(common.ImporterSecretKey, false)
 	keyf, _ := util.ParseEnvVar(common.ImporterGoogleCredentialFileVar, false)
+	azureCredDir, _ := util.ParseEnvVar(common.ImporterAzureCredentialDirVar, false)
+	sftpCredDir, _ := util.ParseEnvVar(common.ImporterSFTPCredentialDirVar, false)
 	diskID, _ := util.ParseEnvVar(common.ImporterDiskID, false)
 	uuid, _ := util.ParseEnvVar(common.ImporterUUID, false)
 	backingFile, _ := util.ParseEnvVar(common.ImporterBackingFile, false)
@@ -285,17 +388,130 @@ func newDataSource(source string, contentType string, volumeMode v1.PersistentVo
 		ds := importer.NewRegistryDataSource(ep, acc, sec, registryImageArchitecture, certDir, insecureTLS)
 		return ds
 	case cc.SourceS3:
-		ds, err := importer.NewS3DataSource(ep, acc, sec, certDir)
+		sessionToken, _ := util.ParseEnvVar(common.ImporterSessionToken, false)
+		roleARN, _ := util.ParseEnvVar(common.ImporterRoleARN, false)
+		externalID, _ := util.ParseEnvVar(common.ImporterExternalID, false)
+		ds, err := importer.NewS3DataSource(ep, importer.S3Credentials{
+			AccessKey:    acc,
+			SecretKey:    sec,
+			SessionToken: sessionToken,
+			RoleARN:      roleARN,
+			ExternalID:   externalID,
+		}, certDir)
 		if err != nil {
 			errorCannotConnectDataSource(err, "s3")
 		}
 		return ds
 	case cc.SourceGCS:
-		ds, err := importer.NewGCSDataSource(ep, keyf)
+		ds, err := importer.NewGCSDataSource(ep, keyf, certDir)
 		if err != nil {
 			errorCannotConnectDataSource(err, "gcs")
 		}
 		return ds
+	case cc.SourceAzure:
+		ds, err := importer.NewAzureBlobDataSource(ep, azureCredDir, certDir)
+		if err != nil {
+			errorCannotConnectDataSource(err, "azure")
+		}
+		return ds
+	case cc.SourceSFTP:
+		ds, err := importer.NewSFTPDataSource(ep, sftpCredDir)
+		if err != nil {
+			errorCannotConnectDataSource(err, "sftp")
+		}
+		return ds
+	case cc.SourceSMB:
+		ds, err := importer.NewSMBDataSource(ep, common.ImporterSMBDir, cdiv1.DataVolumeContentType(contentType))
+		if err != nil {
+			errorCannotConnectDataSource(err, "smb")
+		}
+		return ds
+	case cc.SourceGlance:
+		glanceCredDir, _ := util.ParseEnvVar(common.ImporterGlanceCredentialDirVar, false)
+		glanceImage, _ := util.ParseEnvVar(common.ImporterGlanceImage, false)
+		ds, err := importer.NewGlanceDataSource(ep, glanceImage, glanceCredDir, certDir)
+		if err != nil {
+			errorCannotConnectDataSource(err, "glance")
+		}
+		return ds
+	case cc.SourceRBD:
+		rbdCredDir, _ := util.ParseEnvVar(common.ImporterRBDCredentialDirVar, false)
+		rbdImage, _ := util.ParseEnvVar(common.ImporterRBDImage, false)
+		ds, err := importer.NewRBDDataSource(ep, rbdImage, rbdCredDir)
+		if err != nil {
+			errorCannotConnectDataSource(err, "rbd")
+		}
+		return ds
+	case cc.SourceISCSI:
+		ds, err := importer.NewISCSIDataSource(ep, acc, sec)
+		if err != nil {
+			errorCannotConnectDataSource(err, "iscsi")
+		}
+		return ds
+	case cc.SourceHostPath:
+		ds, err := importer.NewHostPathDataSource(filepath.Join(common.ImporterHostPathDir, filepath.Base(ep)), cdiv1.DataVolumeContentType(contentType))
+		if err != nil {
+			errorCannotConnectDataSource(err, "hostpath")
+		}
+		return ds
+	case cc.SourceDataVolume:
+		// The controller passes where the PVC of the source DataVolume is mounted
+		ds, err := importer.NewHostPathDataSource(ep, cdiv1.DataVolumeContentType(contentType))
+		if err != nil {
+			errorCannotConnectDataSource(err, "datavolume")
+		}
+		return ds
+	case cc.SourceExport:
+		exportCredDir, _ := util.ParseEnvVar(common.ImporterExportCredentialDirVar, false)
+		ds, err := importer.NewExportDataSource(ep, exportCredDir, certDir, cdiv1.DataVolumeContentType(contentType))
+		if err != nil {
+			errorCannotConnectDataSource(err, "export")
+		}
+		return ds
+	case cc.SourceV2V:
+		vm, _ := util.ParseEnvVar(common.ImporterV2VVM, false)
+		blockDriver, _ := util.ParseEnvVar(common.ImporterV2VBlockDriver, false)
+		virtioWin, _ := util.ParseEnvVar(common.ImporterV2VVirtioWin, false)
+		diskIndex, err := strconv.Atoi(os.Getenv(common.ImporterV2VDiskIndex))
+		if err != nil {
+			errorCannotConnectDataSource(err, "v2v")
+		}
+		var macMappings []string
+		if mappings := os.Getenv(common.ImporterV2VMACMappings); mappings != "" {
+			macMappings = strings.Split(mappings, ";")
+		}
+		ds, err := importer.NewV2VDataSource(ep, acc, sec, vm, diskIndex, blockDriver, macMappings, virtioWin)
+		if err != nil {
+			errorCannotConnectDataSource(err, "v2v")
+		}
+		return ds
+	case cc.SourceProxmox:
+		proxmoxCredDir, _ := util.ParseEnvVar(common.ImporterProxmoxCredentialDirVar, false)
+		node, _ := util.ParseEnvVar(common.ImporterProxmoxNode, false)
+		disk, _ := util.ParseEnvVar(common.ImporterProxmoxDisk, false)
+		vmid, err := strconv.Atoi(os.Getenv(common.ImporterProxmoxVMID))
+		if err != nil {
+			errorCannotConnectDataSource(err, "proxmox")
+		}
+		ds, err := importer.NewProxmoxDataSource(ep, node, vmid, disk, proxmoxCredDir, certDir)
+		if err != nil {
+			errorCannotConnectDataSource(err, "proxmox")
+		}
+		return ds
+	case cc.SourcePlugin:
+		ds, err := importer.NewPluginDataSource(os.Getenv(common.ImporterPluginParameters))
+		if err != nil {
+			errorCannotConnectDataSource(err, "plugin")
+		}
+		return ds
+	case cc.SourceTorrent:
+		file, _ := util.ParseEnvVar(common.ImporterTorrentFile, false)
+		webSeeds := strings.Fields(os.Getenv(common.ImporterTorrentWebSeeds))
+		ds, err := importer.NewTorrentDataSource(ep, webSeeds, file, certDir)
+		if err != nil {
+			errorCannotConnectDataSource(err, "torrent")
+		}
+		return ds
 	case cc.SourceVDDK:
 		ds, err := importer.NewVDDKDataSource(ep, acc, sec, thumbprint, uuid, backingFile, currentCheckpoint, previousCheckpoint, finalCheckpoint, volumeMode)
 		if err != nil {
@@ -335,21 +551,27 @@ func createBlankImage(imageSize string, availableDestSpace int64, preallocation
 
 	if err != nil {
 		klog.Errorf("%+v", err)
-		message := fmt.Sprintf("Unable to create blank image: %v", err)
-		err = util.WriteTerminationMessage(message)
-		if err != nil {
-			klog.Errorf("%+v", err)
-		}
+		writeFailureTerminationMessage(fmt.Sprintf("Unable to create blank image: %v", err), err, nil)
 		os.Exit(1)
 	}
 }
 
 func errorCannotConnectDataSource(err error, dsName string) {
 	klog.Errorf("%+v", err)
-	err = util.WriteTerminationMessage(fmt.Sprintf("Unable to connect to %s data source: %v", dsName, err))
-	if err != nil {
-		klog.Errorf("%+v", err)
+	message := fmt.Sprintf("Unable to connect to %s data source: %v", dsName, err)
+	if isValidateOnly() {
+		// The validation completed, finding the source is not valid
+		termMsg := &common.TerminationMessage{
+			SourceValidation: &common.SourceValidation{Message: message},
+			Message:          ptr.To(validationCompleteMessage),
+		}
+		if err := writeTerminationMessage(termMsg); err != nil {
+			klog.Errorf("%+v", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
 	}
+	writeFailureTerminationMessage(message, err, nil)
 	os.Exit(1)
 }
 
@@ -15,6 +15,8 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/manager/signals"
 
 	cdiclient "kubevirt.io/containerized-data-importer/pkg/client/clientset/versioned"
+	"kubevirt.io/containerized-data-importer/pkg/controller"
+	"kubevirt.io/containerized-data-importer/pkg/keys"
 	"kubevirt.io/containerized-data-importer/pkg/uploadproxy"
 	"kubevirt.io/containerized-data-importer/pkg/util"
 	certfetcher "kubevirt.io/containerized-data-importer/pkg/util/cert/fetcher"
@@ -89,9 +91,9 @@ func main() {
 
 	ctx := signals.SetupSignalHandler()
 
-	apiServerPublicKey, err := getAPIServerPublicKey()
+	tokenKeys, err := keys.NewFileKeySet(controller.TokenKeyDir)
 	if err != nil {
-		klog.Fatalf("Unable to get apiserver public key %v\n", errors.WithStack(err))
+		klog.Fatalf("Unable to get apiserver signing keys %v\n", errors.WithStack(err))
 	}
 
 	cdiConfigTLSWatcher, err := cryptowatch.NewCdiConfigTLSWatcher(ctx, cdiClient)
@@ -112,7 +114,7 @@ func main() {
 
 	uploadProxy, err := uploadproxy.NewUploadProxy(defaultHost,
 		defaultPort,
-		apiServerPublicKey,
+		tokenKeys,
 		cdiConfigTLSWatcher,
 		certWatcher,
 		clientCertFetcher,
@@ -136,12 +138,3 @@ func main() {
 		klog.Fatalf("TLS server failed: %v\n", errors.WithStack(err))
 	}
 }
-
-func getAPIServerPublicKey() (string, error) {
-	const envName = "APISERVER_PUBLIC_KEY"
-	val, ok := os.LookupEnv(envName)
-	if !ok {
-		return "", errors.Errorf("%s not defined", envName)
-	}
-	return val, nil
-}
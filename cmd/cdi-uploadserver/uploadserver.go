@@ -60,20 +60,23 @@ func main() {
 
 	filesystemOverhead, _ := strconv.ParseFloat(os.Getenv(common.FilesystemOverheadVar), 64)
 	preallocation, _ := strconv.ParseBool(os.Getenv(common.Preallocation))
+	streamingConversion, _ := strconv.ParseBool(os.Getenv(common.UploadStreamingConversion))
 
 	config := &uploadserver.Config{
-		BindAddress:        listenAddress,
-		BindPort:           listenPort,
-		Destination:        destination,
-		ServerKeyFile:      os.Getenv("TLS_KEY_FILE"),
-		ServerCertFile:     os.Getenv("TLS_CERT_FILE"),
-		ClientCertFile:     os.Getenv("CLIENT_CERT_FILE"),
-		ClientName:         os.Getenv("CLIENT_NAME"),
-		ImageSize:          os.Getenv(common.UploadImageSize),
-		FilesystemOverhead: filesystemOverhead,
-		Preallocation:      preallocation,
-		CryptoConfig:       cryptoConfig,
-		Deadline:           deadline,
+		BindAddress:         listenAddress,
+		BindPort:            listenPort,
+		Destination:         destination,
+		ServerKeyFile:       os.Getenv("TLS_KEY_FILE"),
+		ServerCertFile:      os.Getenv("TLS_CERT_FILE"),
+		ClientCertFile:      os.Getenv("CLIENT_CERT_FILE"),
+		ClientName:          os.Getenv("CLIENT_NAME"),
+		ImageSize:           os.Getenv(common.UploadImageSize),
+		FilesystemOverhead:  filesystemOverhead,
+		Preallocation:       preallocation,
+		StreamingConversion: streamingConversion,
+		StorageFormat:       os.Getenv(common.UploadStorageFormat),
+		CryptoConfig:        cryptoConfig,
+		Deadline:            deadline,
 	}
 
 	server := uploadserver.NewUploadServer(config)
@@ -95,6 +98,9 @@ func main() {
 			termMsg.Message = ptr.To("Upload Complete")
 		}
 		termMsg.PreallocationApplied = ptr.To(result.PreallocationApplied)
+		if result.UploadDigest != "" {
+			termMsg.UploadDigest = ptr.To(result.UploadDigest)
+		}
 	} else {
 		termMsg.Message = ptr.To("Deadline Passed")
 		termMsg.DeadlinePassed = ptr.To(true)
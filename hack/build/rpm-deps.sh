@@ -30,10 +30,12 @@ centos_base="
   vim-minimal
   util-linux-core
 "
+# rsync transfers the filesystem clones requesting it between the clone source and the upload server
 centos_extra="
   coreutils-single
   glibc-minimal-langpack
   libcurl-minimal
+  rsync
   tar
 "
 
@@ -42,22 +44,30 @@ bazel run \
     --config=${ARCHITECTURE} \
     //:bazeldnf -- fetch ${bazeldnf_repos}
 
+# aria2 comes from EPEL, it downloads the images of torrent sources
 cdi_importer="
+aria2
 libnbd
 libstdc++
 nbdkit-server
 nbdkit-basic-filters
 nbdkit-curl-plugin
+nbdkit-ssh-plugin
 nbdkit-xz-filter
 nbdkit-gzip-filter
 qemu-img
+qemu-kvm-block-iscsi
+qemu-kvm-block-rbd
 python3-pycurl
 python3-six
 "
 
 cdi_importer_extra_x86_64="
+libguestfs
+guestfs-tools
 nbdkit-vddk-plugin
 sqlite-libs
+virt-v2v
 ovirt-imageio-client
 python3-ovirt-engine-sdk4
 "
@@ -21,12 +21,12 @@ package apiserver
 
 import (
 	"context"
-	"crypto/rsa"
 	"crypto/tls"
 	"crypto/x509"
 	"encoding/json"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"reflect"
 	"sort"
@@ -38,22 +38,29 @@ import (
 	snapclient "github.com/kubernetes-csi/external-snapshotter/client/v6/clientset/versioned"
 	"github.com/pkg/errors"
 
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
 	"k8s.io/klog/v2"
 	aggregatorclient "k8s.io/kube-aggregator/pkg/client/clientset_generated/clientset"
 	k8sspec "k8s.io/kube-openapi/pkg/validation/spec"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
 	cdiuploadv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/upload/v1beta1"
 	pkgcdiuploadv1 "kubevirt.io/containerized-data-importer/pkg/apis/upload/v1beta1"
 	"kubevirt.io/containerized-data-importer/pkg/apiserver/webhooks"
 	cdiclient "kubevirt.io/containerized-data-importer/pkg/client/clientset/versioned"
 	"kubevirt.io/containerized-data-importer/pkg/common"
+	dvc "kubevirt.io/containerized-data-importer/pkg/controller/datavolume"
 	"kubevirt.io/containerized-data-importer/pkg/keys"
 	"kubevirt.io/containerized-data-importer/pkg/token"
 	"kubevirt.io/containerized-data-importer/pkg/util"
+	"kubevirt.io/containerized-data-importer/pkg/util/audit"
 	"kubevirt.io/containerized-data-importer/pkg/util/openapi"
 	cryptowatch "kubevirt.io/containerized-data-importer/pkg/util/tls-crypto-watch"
 	cdiversion "kubevirt.io/containerized-data-importer/pkg/version"
@@ -67,6 +74,21 @@ const (
 	//nolint:gosec // This is not a real token
 	uploadTokenGroup = "upload.cdi.kubevirt.io"
 
+	//nolint:gosec // This is not a real token
+	uploadTokenResource = "uploadtokenrequests"
+
+	// uploadCertRotationResource is the cluster resource requesting the rotation of the certs of the upload servers
+	uploadCertRotationResource = "uploadcertrotationrequests"
+
+	// dataVolumeRenderResource is the namespaced resource rendering what CDI creates for a DataVolume
+	dataVolumeRenderResource = "datavolumerenderrequests"
+
+	// maxUploadSessionTTL is the longest upload session of renewable upload tokens
+	maxUploadSessionTTL = 24 * time.Hour
+
+	// signingKeySyncInterval is how often the signing key is checked for rotation
+	signingKeySyncInterval = time.Minute
+
 	dvValidatePath = "/datavolume-validate"
 
 	dvMutatePath = "/datavolume-mutate"
@@ -106,7 +128,11 @@ type cdiAPIApp struct {
 	snapClient              snapclient.Interface
 	controllerRuntimeClient client.Client
 
-	privateSigningKey *rsa.PrivateKey
+	// signs the upload and clone tokens, rotated at the interval of the TokenConfig of the CDIConfig
+	signingKeys *keys.SecretKeySet
+
+	// the TokenConfig of the CDIConfig the tokens are issued with
+	tokenConfig token.ConfigStore
 
 	container *restful.Container
 
@@ -118,6 +144,9 @@ type cdiAPIApp struct {
 
 	tokenGenerator token.Generator
 
+	// emits the audit events of the upload and clone tokens issued
+	auditor *audit.Auditor
+
 	installerLabels map[string]string
 }
 
@@ -154,6 +183,7 @@ func NewCdiAPIServer(bindAddress string,
 		authConfigWatcher:       authConfigWatcher,
 		cdiConfigTLSWatcher:     cdiConfigTLSWatcher,
 		certWarcher:             certWatcher,
+		auditor:                 audit.NewAuditor(client, common.CDIApiServerResourceName),
 		installerLabels:         installerLabels,
 	}
 
@@ -164,6 +194,18 @@ func NewCdiAPIServer(bindAddress string,
 
 	app.composeUploadTokenAPI()
 
+	_, err = cdiConfigTLSWatcher.GetInformer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			app.updateConfig(obj.(*cdiv1.CDIConfig))
+		},
+		UpdateFunc: func(_, obj interface{}) {
+			app.updateConfig(obj.(*cdiv1.CDIConfig))
+		},
+	})
+	if err != nil {
+		return nil, errors.Errorf("unable to watch the token config: %v", errors.WithStack(err))
+	}
+
 	app.container.Filter(func(req *restful.Request, resp *restful.Response, chain *restful.FilterChain) {
 		var username = "-"
 		if req.Request.URL.User != nil {
@@ -222,25 +264,50 @@ func NewCdiAPIServer(bindAddress string,
 	return app, nil
 }
 
-func newUploadTokenGenerator(key *rsa.PrivateKey) token.Generator {
-	return token.NewGenerator(common.UploadTokenIssuer, key, 5*time.Minute)
+// updateConfig applies the token audit and the token config of the CDIConfig
+func (app *cdiAPIApp) updateConfig(config *cdiv1.CDIConfig) {
+	app.auditor.Update(config.Spec.TokenAudit)
+	app.tokenConfig.Update(config.Spec.TokenConfig)
+}
+
+func newUploadTokenGenerator(signingKeys token.KeySet, config token.ConfigFunc) token.Generator {
+	return token.NewConfigurableGenerator(common.UploadTokenIssuer, signingKeys, token.UploadTokenLifetime(common.UploadTokenLifetime), config)
 }
 
 func (app *cdiAPIApp) Start(ch <-chan struct{}) error {
+	go wait.Until(app.syncSigningKeys, signingKeySyncInterval, ch)
 	return app.startTLS(ch)
 }
 
+// syncSigningKeys rotates the signing key when the rotation interval of the TokenConfig elapsed or the tokens were
+// revoked since the last rotation, and reloads the key rotated by the other replicas
+func (app *cdiAPIApp) syncSigningKeys() {
+	var interval time.Duration
+	var revokedBefore time.Time
+	if config, _ := app.tokenConfig.Get(); config != nil {
+		if config.SigningKeyRotationInterval != nil {
+			interval = config.SigningKeyRotationInterval.Duration
+		}
+		if config.RevokeTokensIssuedBefore != nil {
+			revokedBefore = config.RevokeTokensIssuedBefore.Time
+		}
+	}
+	if err := app.signingKeys.Sync(interval, revokedBefore); err != nil {
+		klog.Errorf("Unable to sync the signing key: %v", err)
+	}
+}
+
 func (app *cdiAPIApp) getKeysAndCerts() error {
 	namespace := util.GetNamespace()
 
-	privateKey, err := keys.GetOrCreatePrivateKey(app.client, namespace, APISigningKeySecretName, app.installerLabels)
+	signingKeys, err := keys.NewSecretKeySet(app.client, namespace, APISigningKeySecretName, app.installerLabels)
 	if err != nil {
 		return errors.Wrap(err, "Error getting/creating signing key")
 	}
 
-	app.privateSigningKey = privateKey
+	app.signingKeys = signingKeys
 
-	app.tokenGenerator = newUploadTokenGenerator(privateKey)
+	app.tokenGenerator = newUploadTokenGenerator(signingKeys, app.tokenConfig.Get)
 
 	return nil
 }
@@ -314,14 +381,26 @@ func (app *cdiAPIApp) startTLS(stopChan <-chan struct{}) error {
 }
 
 func (app *cdiAPIApp) uploadHandler(request *restful.Request, response *restful.Response) {
+	namespace := request.PathParameter("namespace")
+	auditEvent := audit.Event{
+		Action:    audit.ActionIssueUploadToken,
+		User:      app.requestUser(request.Request),
+		SourceIP:  requestSourceIP(request.Request),
+		Kind:      "PersistentVolumeClaim",
+		Namespace: namespace,
+	}
 	allowed, reason, err := app.authorizer.Authorize(request)
 
 	if err != nil {
 		klog.Error(err)
+		auditEvent.Outcome, auditEvent.Reason = audit.OutcomeFailure, err.Error()
+		app.auditor.Emit(auditEvent)
 		response.WriteHeader(http.StatusInternalServerError)
 		return
 	} else if !allowed {
 		klog.Infof("Rejected Request: %s", reason)
+		auditEvent.Outcome, auditEvent.Reason = audit.OutcomeDenied, reason
+		app.auditor.Emit(auditEvent)
 		writeErr := response.WriteErrorString(http.StatusUnauthorized, reason)
 		if writeErr != nil {
 			klog.Error("uploadHandler: failed to send response", err)
@@ -329,7 +408,6 @@ func (app *cdiAPIApp) uploadHandler(request *restful.Request, response *restful.
 		return
 	}
 
-	namespace := request.PathParameter("namespace")
 	defer request.Request.Body.Close()
 	body, err := io.ReadAll(request.Request.Body)
 	if err != nil {
@@ -355,16 +433,169 @@ func (app *cdiAPIApp) uploadHandler(request *restful.Request, response *restful.
 		},
 	}
 
+	auditEvent.Name = uploadToken.Spec.PvcName
+	if ttl := uploadToken.Spec.SessionTTL; ttl != nil {
+		params, status, err := app.uploadSessionParams(namespace, uploadToken.Spec.PvcName, ttl.Duration)
+		if err != nil {
+			auditEvent.Outcome, auditEvent.Reason = audit.OutcomeFailure, err.Error()
+			app.auditor.Emit(auditEvent)
+			writeErrorResponse(response, status, err)
+			return
+		}
+		tokenData.Params = params
+	}
+
 	tkn, err := app.tokenGenerator.Generate(tokenData)
 	if err != nil {
+		auditEvent.Outcome, auditEvent.Reason = audit.OutcomeFailure, err.Error()
+		app.auditor.Emit(auditEvent)
 		writeErrorResponse(response, http.StatusInternalServerError, err)
 		return
 	}
 
+	auditEvent.Outcome = audit.OutcomeSuccess
+	app.auditor.Emit(auditEvent)
 	uploadToken.Status.Token = tkn
 	writeJSONResponse(response, uploadToken)
 }
 
+// certRotationHandler requests the rotation of the certs of all the upload servers, by recording the time of the
+// request in the CDIConfig. The upload controller then rotates the certs issued before.
+func (app *cdiAPIApp) certRotationHandler(request *restful.Request, response *restful.Response) {
+	allowed, reason, err := app.authorizer.Authorize(request)
+	if err != nil {
+		klog.Error(err)
+		response.WriteHeader(http.StatusInternalServerError)
+		return
+	} else if !allowed {
+		klog.Infof("Rejected Request: %s", reason)
+		writeErr := response.WriteErrorString(http.StatusUnauthorized, reason)
+		if writeErr != nil {
+			klog.Error("certRotationHandler: failed to send response", writeErr)
+		}
+		return
+	}
+
+	config, err := app.cdiClient.CdiV1beta1().CDIConfigs().Get(context.TODO(), common.ConfigName, metav1.GetOptions{})
+	if err != nil {
+		writeErrorResponse(response, http.StatusInternalServerError, err)
+		return
+	}
+	if config.Spec.UploadCertRotation == nil {
+		writeErrorResponse(response, http.StatusBadRequest, errors.New("uploadCertRotation is not set in the CDIConfig"))
+		return
+	}
+
+	requestedAt := time.Now().UTC().Format(time.RFC3339Nano)
+	patch := fmt.Sprintf(`{"metadata":{"annotations":{%q:%q}}}`, common.AnnUploadCertRotationRequested, requestedAt)
+	if _, err := app.cdiClient.CdiV1beta1().CDIConfigs().Patch(context.TODO(), common.ConfigName, types.MergePatchType, []byte(patch), metav1.PatchOptions{}); err != nil {
+		writeErrorResponse(response, http.StatusInternalServerError, err)
+		return
+	}
+
+	klog.Infof("Rotation of the upload server certs requested by %s", app.requestUser(request.Request))
+	writeJSONResponse(response, &metav1.Status{
+		TypeMeta: metav1.TypeMeta{Kind: "Status", APIVersion: "v1"},
+		Status:   metav1.StatusSuccess,
+		Message:  "rotation of the upload server certs requested at " + requestedAt,
+		Code:     http.StatusOK,
+	})
+}
+
+// renderHandler renders the PVC of the DataVolume of the request and how it is populated, the way the DataVolume
+// controllers would, without creating anything
+func (app *cdiAPIApp) renderHandler(request *restful.Request, response *restful.Response) {
+	allowed, reason, err := app.authorizer.Authorize(request)
+	if err != nil {
+		klog.Error(err)
+		response.WriteHeader(http.StatusInternalServerError)
+		return
+	} else if !allowed {
+		klog.Infof("Rejected Request: %s", reason)
+		writeErr := response.WriteErrorString(http.StatusUnauthorized, reason)
+		if writeErr != nil {
+			klog.Error("renderHandler: failed to send response", writeErr)
+		}
+		return
+	}
+
+	defer request.Request.Body.Close()
+	body, err := io.ReadAll(request.Request.Body)
+	if err != nil {
+		writeErrorResponse(response, http.StatusBadRequest, err)
+		return
+	}
+
+	renderRequest := &cdiuploadv1.DataVolumeRenderRequest{}
+	if err := json.Unmarshal(body, renderRequest); err != nil {
+		writeErrorResponse(response, http.StatusBadRequest, err)
+		return
+	}
+
+	dv := &renderRequest.Spec.DataVolume
+	dv.Namespace = request.PathParameter("namespace")
+	status, err := dvc.RenderDataVolume(request.Request.Context(), app.controllerRuntimeClient, klog.NewKlogr().WithName("render"), dv)
+	if err != nil {
+		httpStatus := http.StatusBadRequest
+		var apiStatus k8serrors.APIStatus
+		if errors.As(err, &apiStatus) {
+			httpStatus = int(apiStatus.Status().Code)
+		}
+		writeErrorResponse(response, httpStatus, err)
+		return
+	}
+
+	renderRequest.Status = *status
+	writeJSONResponse(response, renderRequest)
+}
+
+// requestUser returns the user of the request, from the headers the API server authenticating it sets
+func (app *cdiAPIApp) requestUser(r *http.Request) string {
+	if app.authConfigWatcher == nil {
+		return ""
+	}
+	for _, header := range app.authConfigWatcher.GetAuthConfig().UserHeaders {
+		if user := r.Header.Get(header); user != "" {
+			return user
+		}
+	}
+	return ""
+}
+
+// requestSourceIP returns the address of the client of the request, the first address of the X-Forwarded-For header
+// the API server proxying it sets
+func requestSourceIP(r *http.Request) string {
+	if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
+		return strings.TrimSpace(strings.Split(forwarded, ",")[0])
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// uploadSessionParams returns the token params of an upload session to the PVC lasting ttl, along with the status of
+// the error if the session can't be opened. The session ends early if the PVC is deleted, which its UID tells.
+func (app *cdiAPIApp) uploadSessionParams(namespace, pvcName string, ttl time.Duration) (map[string]string, int, error) {
+	if ttl < common.UploadTokenLifetime || ttl > maxUploadSessionTTL {
+		return nil, http.StatusBadRequest, errors.Errorf("sessionTTL must be between %s and %s", common.UploadTokenLifetime, maxUploadSessionTTL)
+	}
+
+	pvc, err := app.client.CoreV1().PersistentVolumeClaims(namespace).Get(context.TODO(), pvcName, metav1.GetOptions{})
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil, http.StatusNotFound, errors.Errorf("upload session requested for PVC %s that doesn't exist", pvcName)
+		}
+		return nil, http.StatusInternalServerError, err
+	}
+
+	return map[string]string{
+		common.UploadTokenSessionExpiryParam: time.Now().Add(ttl).UTC().Format(time.RFC3339),
+		common.UploadTokenUIDParam:           string(pvc.UID),
+	}, http.StatusOK, nil
+}
+
 func uploadTokenAPIGroup() metav1.APIGroup {
 	apiGroup := metav1.APIGroup{
 		Name: uploadTokenGroup,
@@ -393,10 +624,12 @@ func (app *cdiAPIApp) composeUploadTokenAPI() {
 	objPointer := &cdiuploadv1.UploadTokenRequest{}
 	objExample := reflect.ValueOf(objPointer).Elem().Interface()
 	objKind := "UploadTokenRequest"
-	resource := "uploadtokenrequests"
 
 	groupPath := fmt.Sprintf("/apis/%s", uploadTokenGroup)
-	createPath := fmt.Sprintf("/namespaces/{namespace:[a-z0-9][a-z0-9\\-]*}/%s", resource)
+	createPath := fmt.Sprintf("/namespaces/{namespace:[a-z0-9][a-z0-9\\-]*}/%s", uploadTokenResource)
+	certRotationPath := "/" + uploadCertRotationResource
+	renderPath := fmt.Sprintf("/namespaces/{namespace:[a-z0-9][a-z0-9\\-]*}/%s", dataVolumeRenderResource)
+	renderExample := cdiuploadv1.DataVolumeRenderRequest{}
 
 	app.container = restful.NewContainer()
 
@@ -423,6 +656,26 @@ func (app *cdiAPIApp) composeUploadTokenAPI() {
 			Returns(http.StatusUnauthorized, "Unauthorized", "").
 			Param(uploadTokenWs.PathParameter("namespace", "Object name and auth scope, such as for teams and projects").Required(true)))
 
+		uploadTokenWs.Route(uploadTokenWs.POST(certRotationPath).
+			Produces("application/json").
+			Operation("createUploadCertRotationRequest-"+v).
+			To(app.certRotationHandler).Writes(metav1.Status{}).
+			Doc("Request the rotation of the certificates of the upload servers.").
+			Returns(http.StatusOK, "OK", metav1.Status{}).
+			Returns(http.StatusBadRequest, "Bad Request", "").
+			Returns(http.StatusUnauthorized, "Unauthorized", ""))
+
+		uploadTokenWs.Route(uploadTokenWs.POST(renderPath).
+			Produces("application/json").
+			Consumes("application/json").
+			Operation("createNamespacedDataVolumeRenderRequest-"+v).
+			To(app.renderHandler).Reads(renderExample).Writes(renderExample).
+			Doc("Render the PVC of a DataVolume and how it is populated, without creating anything.").
+			Returns(http.StatusOK, "OK", renderExample).
+			Returns(http.StatusBadRequest, "Bad Request", "").
+			Returns(http.StatusUnauthorized, "Unauthorized", "").
+			Param(uploadTokenWs.PathParameter("namespace", "Object name and auth scope, such as for teams and projects").Required(true)))
+
 		uploadTokenWs.Route(uploadTokenWs.GET("/").
 			Produces("application/json").Writes(metav1.APIResourceList{}).
 			To(func(request *restful.Request, response *restful.Response) {
@@ -431,7 +684,7 @@ func (app *cdiAPIApp) composeUploadTokenAPI() {
 				list.APIVersion = "v1" // this is the version of the resource list
 				list.GroupVersion = uploadTokenGroup + "/" + uploadTokenVersion
 				list.APIResources = append(list.APIResources, metav1.APIResource{
-					Name:         "uploadtokenrequests",
+					Name:         uploadTokenResource,
 					SingularName: "uploadtokenrequest",
 					Namespaced:   true,
 					Group:        uploadTokenGroup,
@@ -439,6 +692,22 @@ func (app *cdiAPIApp) composeUploadTokenAPI() {
 					Kind:         "UploadTokenRequest",
 					Verbs:        []string{"create"},
 					ShortNames:   []string{"utr", "utrs"},
+				}, metav1.APIResource{
+					Name:         uploadCertRotationResource,
+					SingularName: "uploadcertrotationrequest",
+					Namespaced:   false,
+					Group:        uploadTokenGroup,
+					Version:      uploadTokenVersion,
+					Kind:         "UploadCertRotationRequest",
+					Verbs:        []string{"create"},
+				}, metav1.APIResource{
+					Name:         dataVolumeRenderResource,
+					SingularName: "datavolumerenderrequest",
+					Namespaced:   true,
+					Group:        uploadTokenGroup,
+					Version:      uploadTokenVersion,
+					Kind:         "DataVolumeRenderRequest",
+					Verbs:        []string{"create"},
 				})
 				writeJSONResponse(response, list)
 			}).
@@ -533,7 +802,7 @@ func (app *cdiAPIApp) createDataVolumeValidatingWebhook() error {
 }
 
 func (app *cdiAPIApp) createDataVolumeMutatingWebhook() error {
-	app.container.ServeMux.Handle(dvMutatePath, webhooks.NewDataVolumeMutatingWebhook(app.client, app.cdiClient, app.privateSigningKey))
+	app.container.ServeMux.Handle(dvMutatePath, webhooks.NewDataVolumeMutatingWebhook(app.client, app.cdiClient, app.signingKeys, app.tokenConfig.Get, app.auditor))
 	return nil
 }
 
@@ -20,6 +20,7 @@ package apiserver
 
 import (
 	"bytes"
+	"context"
 	"crypto/rand"
 	"crypto/rsa"
 	"encoding/json"
@@ -27,21 +28,34 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"reflect"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
 	restful "github.com/emicklei/go-restful/v3"
+	"github.com/go-jose/go-jose/v3/jwt"
 
 	v1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	k8sfake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
 	core "k8s.io/client-go/testing"
+	"k8s.io/utils/ptr"
 
+	crfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
 	cdiuploadv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/upload/v1beta1"
+	cdiclientfake "kubevirt.io/containerized-data-importer/pkg/client/clientset/versioned/fake"
+	"kubevirt.io/containerized-data-importer/pkg/common"
+	"kubevirt.io/containerized-data-importer/pkg/keys"
 	"kubevirt.io/containerized-data-importer/pkg/keys/keystest"
+	"kubevirt.io/containerized-data-importer/pkg/token"
+	"kubevirt.io/containerized-data-importer/pkg/util/audit"
 )
 
 type testAuthorizer struct {
@@ -54,6 +68,14 @@ func (a *testAuthorizer) Authorize(req *restful.Request) (bool, string, error) {
 	return a.allowed, a.reason, a.err
 }
 
+type testAuthConfigWatcher struct {
+	config *AuthConfig
+}
+
+func (w *testAuthConfigWatcher) GetAuthConfig() *AuthConfig {
+	return w.config
+}
+
 func signingKeySecretGetAction() core.Action {
 	return core.NewGetAction(
 		schema.GroupVersionResource{
@@ -207,7 +229,9 @@ var _ = Describe("API server tests", func() {
 		actions := []core.Action{}
 		actions = append(actions, signingKeySecretGetAction())
 		actions = append(actions, cdiConfigGetAction())
-		actions = append(actions, signingKeySecretCreateAction(app.privateSigningKey))
+		privateKey, err := app.signingKeys.PrivateKey()
+		Expect(err).ToNot(HaveOccurred())
+		actions = append(actions, signingKeySecretCreateAction(privateKey))
 
 		checkActions(actions, client.Actions())
 	})
@@ -236,6 +260,24 @@ var _ = Describe("API server tests", func() {
 					Verbs:        []string{"create"},
 					ShortNames:   []string{"utr", "utrs"},
 				},
+				{
+					Name:         "uploadcertrotationrequests",
+					SingularName: "uploadcertrotationrequest",
+					Namespaced:   false,
+					Group:        "upload.cdi.kubevirt.io",
+					Version:      version,
+					Kind:         "UploadCertRotationRequest",
+					Verbs:        []string{"create"},
+				},
+				{
+					Name:         "datavolumerenderrequests",
+					SingularName: "datavolumerenderrequest",
+					Namespaced:   true,
+					Group:        "upload.cdi.kubevirt.io",
+					Version:      version,
+					Kind:         "DataVolumeRenderRequest",
+					Verbs:        []string{"create"},
+				},
 			},
 		}
 
@@ -346,9 +388,8 @@ var _ = Describe("API server tests", func() {
 		client := k8sfake.NewSimpleClientset(kubeobjects...)
 
 		app := &cdiAPIApp{client: client,
-			privateSigningKey: signingKey,
-			authorizer:        args.authorizer,
-			tokenGenerator:    newUploadTokenGenerator(signingKey)}
+			authorizer:     args.authorizer,
+			tokenGenerator: newUploadTokenGenerator(token.NewStaticKeySet(signingKey), (&token.ConfigStore{}).Get)}
 		app.composeUploadTokenAPI()
 
 		req, err := http.NewRequest(http.MethodPost,
@@ -399,4 +440,270 @@ var _ = Describe("API server tests", func() {
 			http.StatusOK,
 			true),
 	)
+
+	DescribeTable("should audit the issuance of tokens", func(authorizer CdiAPIAuthorizer, expectedStatus int, expectedOutcome audit.Outcome) {
+		received := make(chan audit.Event, 1)
+		webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var event audit.Event
+			Expect(json.NewDecoder(r.Body).Decode(&event)).To(Succeed())
+			received <- event
+		}))
+		defer webhook.Close()
+
+		client := k8sfake.NewSimpleClientset(pvc)
+		auditor := audit.NewAuditor(client, common.CDIApiServerResourceName)
+		auditor.Update(&cdiv1.TokenAuditConfig{Sink: cdiv1.TokenAuditSinkWebhook, WebhookURL: webhook.URL})
+		app := &cdiAPIApp{client: client,
+			authorizer:        authorizer,
+			authConfigWatcher: &testAuthConfigWatcher{config: &AuthConfig{UserHeaders: []string{"X-Remote-User"}}},
+			tokenGenerator:    newUploadTokenGenerator(token.NewStaticKeySet(signingKey), (&token.ConfigStore{}).Get),
+			auditor:           auditor}
+		app.composeUploadTokenAPI()
+
+		req, err := http.NewRequest(http.MethodPost,
+			"/apis/upload.cdi.kubevirt.io/v1beta1/namespaces/default/uploadtokenrequests",
+			bytes.NewReader(serializedRequest))
+		Expect(err).ToNot(HaveOccurred())
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Remote-User", "alice")
+		req.Header.Set("X-Forwarded-For", "10.0.0.1, 10.0.0.2")
+		rr := httptest.NewRecorder()
+
+		app.container.ServeHTTP(rr, req)
+		Expect(rr.Code).To(Equal(expectedStatus))
+
+		var event audit.Event
+		Eventually(received).Should(Receive(&event))
+		Expect(event.Action).To(Equal(audit.ActionIssueUploadToken))
+		Expect(event.Outcome).To(Equal(expectedOutcome))
+		Expect(event.Component).To(Equal(common.CDIApiServerResourceName))
+		Expect(event.User).To(Equal("alice"))
+		Expect(event.SourceIP).To(Equal("10.0.0.1"))
+		Expect(event.Namespace).To(Equal("default"))
+	},
+		Entry("issued", authorizeSuccess, http.StatusOK, audit.OutcomeSuccess),
+		Entry("denied", &testAuthorizer{allowed: false, reason: "bad person"}, http.StatusUnauthorized, audit.OutcomeDenied),
+	)
+
+	DescribeTable("Get token of an upload session", func(ttl time.Duration, pvc *v1.PersistentVolumeClaim, expectedStatus int) {
+		kubeobjects := []runtime.Object{}
+		if pvc != nil {
+			kubeobjects = append(kubeobjects, pvc)
+		}
+		app := &cdiAPIApp{client: k8sfake.NewSimpleClientset(kubeobjects...),
+			authorizer:     authorizeSuccess,
+			tokenGenerator: newUploadTokenGenerator(token.NewStaticKeySet(signingKey), (&token.ConfigStore{}).Get)}
+		app.composeUploadTokenAPI()
+
+		sessionRequest := request.DeepCopy()
+		sessionRequest.Spec.SessionTTL = &metav1.Duration{Duration: ttl}
+		serializedSessionRequest, err := json.Marshal(sessionRequest)
+		Expect(err).ToNot(HaveOccurred())
+		req, err := http.NewRequest(http.MethodPost,
+			"/apis/upload.cdi.kubevirt.io/v1beta1/namespaces/default/uploadtokenrequests",
+			bytes.NewReader(serializedSessionRequest))
+		Expect(err).ToNot(HaveOccurred())
+		req.Header.Set("Content-Type", "application/json")
+		rr := httptest.NewRecorder()
+
+		app.container.ServeHTTP(rr, req)
+		Expect(rr.Code).To(Equal(expectedStatus))
+		if expectedStatus != http.StatusOK {
+			return
+		}
+
+		uploadTokenRequest := &cdiuploadv1.UploadTokenRequest{}
+		Expect(json.Unmarshal(rr.Body.Bytes(), uploadTokenRequest)).To(Succeed())
+		validator := token.NewValidator(common.UploadTokenIssuer, &signingKey.PublicKey, 0)
+		payload, err := validator.Validate(uploadTokenRequest.Status.Token)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(payload.Params).To(HaveKeyWithValue(common.UploadTokenUIDParam, string(pvc.UID)))
+		expiry, err := time.Parse(time.RFC3339, payload.Params[common.UploadTokenSessionExpiryParam])
+		Expect(err).ToNot(HaveOccurred())
+		Expect(expiry).To(BeTemporally("~", time.Now().Add(ttl), time.Minute))
+	},
+		Entry("of the PVC", 8*time.Hour, &v1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-pvc",
+				Namespace: "default",
+				UID:       "1234",
+			},
+		}, http.StatusOK),
+		Entry("of a PVC that does not exist", 8*time.Hour, nil, http.StatusNotFound),
+		Entry("shorter than the token", time.Minute, pvc, http.StatusBadRequest),
+		Entry("too long", 48*time.Hour, pvc, http.StatusBadRequest),
+	)
+
+	It("should issue upload tokens with the token config", func() {
+		app := &cdiAPIApp{client: k8sfake.NewSimpleClientset(pvc), authorizer: authorizeSuccess}
+		app.tokenConfig.Update(&cdiv1.TokenConfig{
+			UploadTokenLifetime: &metav1.Duration{Duration: time.Minute},
+			Audiences:           []string{"upload-proxy"},
+		})
+		app.tokenGenerator = newUploadTokenGenerator(token.NewStaticKeySet(signingKey), app.tokenConfig.Get)
+		app.composeUploadTokenAPI()
+
+		req, err := http.NewRequest(http.MethodPost,
+			"/apis/upload.cdi.kubevirt.io/v1beta1/namespaces/default/uploadtokenrequests",
+			bytes.NewReader(serializedRequest))
+		Expect(err).ToNot(HaveOccurred())
+		req.Header.Set("Content-Type", "application/json")
+		rr := httptest.NewRecorder()
+
+		app.container.ServeHTTP(rr, req)
+		Expect(rr.Code).To(Equal(http.StatusOK))
+
+		uploadTokenRequest := &cdiuploadv1.UploadTokenRequest{}
+		Expect(json.Unmarshal(rr.Body.Bytes(), uploadTokenRequest)).To(Succeed())
+		tok, err := jwt.ParseSigned(uploadTokenRequest.Status.Token)
+		Expect(err).ToNot(HaveOccurred())
+		claims := &jwt.Claims{}
+		Expect(tok.Claims(&signingKey.PublicKey, claims)).To(Succeed())
+		Expect(claims.Audience).To(ConsistOf("upload-proxy"))
+		Expect(claims.Expiry.Time()).To(BeTemporally("~", time.Now().Add(time.Minute), 10*time.Second))
+	})
+
+	It("should rotate the signing key at the interval of the token config", func() {
+		secret, err := keystest.NewPrivateKeySecret("cdi", APISigningKeySecretName, signingKey)
+		Expect(err).ToNot(HaveOccurred())
+		secret.CreationTimestamp = metav1.NewTime(time.Now().Add(-time.Hour))
+		client := k8sfake.NewSimpleClientset(secret)
+		signingKeys, err := keys.NewSecretKeySet(client, "cdi", APISigningKeySecretName, nil)
+		Expect(err).ToNot(HaveOccurred())
+		app := &cdiAPIApp{client: client, signingKeys: signingKeys}
+
+		app.syncSigningKeys()
+		secret, err = client.CoreV1().Secrets("cdi").Get(context.TODO(), APISigningKeySecretName, metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(secret.Data).ToNot(HaveKey(keys.KeyStorePreviousPublicKeyFile))
+
+		app.tokenConfig.Update(&cdiv1.TokenConfig{SigningKeyRotationInterval: &metav1.Duration{Duration: 30 * time.Minute}})
+		app.syncSigningKeys()
+		secret, err = client.CoreV1().Secrets("cdi").Get(context.TODO(), APISigningKeySecretName, metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(secret.Data).To(HaveKey(keys.KeyStorePreviousPublicKeyFile))
+		publicKeys, err := app.signingKeys.PublicKeys()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(publicKeys).To(HaveLen(2))
+		Expect(publicKeys[1]).To(Equal(&signingKey.PublicKey))
+		// the previous key signs until the new public key reaches the other components
+		privateKey, err := app.signingKeys.PrivateKey()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(privateKey).To(Equal(signingKey))
+	})
+
+	It("should rotate the signing key and drop the previous one when the tokens are revoked", func() {
+		secret, err := keystest.NewPrivateKeySecret("cdi", APISigningKeySecretName, signingKey)
+		Expect(err).ToNot(HaveOccurred())
+		secret.CreationTimestamp = metav1.NewTime(time.Now().Add(-time.Hour))
+		client := k8sfake.NewSimpleClientset(secret)
+		signingKeys, err := keys.NewSecretKeySet(client, "cdi", APISigningKeySecretName, nil)
+		Expect(err).ToNot(HaveOccurred())
+		app := &cdiAPIApp{client: client, signingKeys: signingKeys}
+
+		app.tokenConfig.Update(&cdiv1.TokenConfig{RevokeTokensIssuedBefore: &metav1.Time{Time: time.Now().Add(-time.Minute)}})
+		app.syncSigningKeys()
+		secret, err = client.CoreV1().Secrets("cdi").Get(context.TODO(), APISigningKeySecretName, metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(secret.Data).ToNot(HaveKey(keys.KeyStorePreviousPublicKeyFile))
+		publicKeys, err := app.signingKeys.PublicKeys()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(publicKeys).To(HaveLen(1))
+		Expect(publicKeys[0]).ToNot(Equal(&signingKey.PublicKey))
+		privateKey, err := app.signingKeys.PrivateKey()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(&privateKey.PublicKey).To(Equal(publicKeys[0]))
+	})
+
+	DescribeTable("Request the rotation of the upload server certs", func(authorizer CdiAPIAuthorizer, rotation *cdiv1.UploadCertRotationConfig, expectedStatus int) {
+		config := &cdiv1.CDIConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: common.ConfigName},
+			Spec:       cdiv1.CDIConfigSpec{UploadCertRotation: rotation},
+		}
+		cdiClient := cdiclientfake.NewSimpleClientset(config)
+		app := &cdiAPIApp{client: k8sfake.NewSimpleClientset(),
+			cdiClient:  cdiClient,
+			authorizer: authorizer}
+		app.composeUploadTokenAPI()
+
+		req, err := http.NewRequest(http.MethodPost, "/apis/upload.cdi.kubevirt.io/v1beta1/uploadcertrotationrequests", nil)
+		Expect(err).ToNot(HaveOccurred())
+		rr := httptest.NewRecorder()
+
+		app.container.ServeHTTP(rr, req)
+		Expect(rr.Code).To(Equal(expectedStatus))
+
+		config, err = cdiClient.CdiV1beta1().CDIConfigs().Get(context.TODO(), common.ConfigName, metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		if expectedStatus != http.StatusOK {
+			Expect(config.Annotations).ToNot(HaveKey(common.AnnUploadCertRotationRequested))
+			return
+		}
+		requestedAt, err := time.Parse(time.RFC3339Nano, config.Annotations[common.AnnUploadCertRotationRequested])
+		Expect(err).ToNot(HaveOccurred())
+		Expect(requestedAt).To(BeTemporally("~", time.Now(), time.Minute))
+		status := &metav1.Status{}
+		Expect(json.Unmarshal(rr.Body.Bytes(), status)).To(Succeed())
+		Expect(status.Status).To(Equal(metav1.StatusSuccess))
+	},
+		Entry("when rotation is configured", authorizeSuccess, &cdiv1.UploadCertRotationConfig{}, http.StatusOK),
+		Entry("when rotation is not configured", authorizeSuccess, nil, http.StatusBadRequest),
+		Entry("when not allowed", &testAuthorizer{allowed: false, reason: "bad person"}, &cdiv1.UploadCertRotationConfig{}, http.StatusUnauthorized),
+	)
+
+	DescribeTable("Render a DataVolume", func(authorizer CdiAPIAuthorizer, pvcSpec *v1.PersistentVolumeClaimSpec, expectedStatus int) {
+		storageClass := &storagev1.StorageClass{
+			ObjectMeta:  metav1.ObjectMeta{Name: "test-sc"},
+			Provisioner: "kubernetes.io/no-provisioner",
+		}
+		s := runtime.NewScheme()
+		Expect(scheme.AddToScheme(s)).To(Succeed())
+		Expect(cdiv1.AddToScheme(s)).To(Succeed())
+		crClient := crfake.NewClientBuilder().WithScheme(s).WithObjects(storageClass).Build()
+		app := &cdiAPIApp{client: k8sfake.NewSimpleClientset(),
+			controllerRuntimeClient: crClient,
+			authorizer:              authorizer}
+		app.composeUploadTokenAPI()
+
+		renderRequest := &cdiuploadv1.DataVolumeRenderRequest{
+			Spec: cdiuploadv1.DataVolumeRenderRequestSpec{
+				DataVolume: cdiv1.DataVolume{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-dv"},
+					Spec: cdiv1.DataVolumeSpec{
+						Source: &cdiv1.DataVolumeSource{Blank: &cdiv1.DataVolumeBlankImage{}},
+						PVC:    pvcSpec,
+					},
+				},
+			},
+		}
+		serializedRenderRequest, err := json.Marshal(renderRequest)
+		Expect(err).ToNot(HaveOccurred())
+		req, err := http.NewRequest(http.MethodPost,
+			"/apis/upload.cdi.kubevirt.io/v1beta1/namespaces/default/datavolumerenderrequests",
+			bytes.NewReader(serializedRenderRequest))
+		Expect(err).ToNot(HaveOccurred())
+		req.Header.Set("Content-Type", "application/json")
+		rr := httptest.NewRecorder()
+
+		app.container.ServeHTTP(rr, req)
+		Expect(rr.Code).To(Equal(expectedStatus))
+		if expectedStatus != http.StatusOK {
+			return
+		}
+
+		Expect(json.Unmarshal(rr.Body.Bytes(), renderRequest)).To(Succeed())
+		Expect(renderRequest.Status.PVCSpec).To(HaveValue(Equal(*pvcSpec)))
+		Expect(renderRequest.Status.PopulationStrategy).To(Equal(cdiuploadv1.PopulationStrategyPod))
+		Expect(renderRequest.Status.StorageProfile).To(BeNil())
+		dvs := &cdiv1.DataVolumeList{}
+		Expect(crClient.List(context.TODO(), dvs)).To(Succeed())
+		Expect(dvs.Items).To(BeEmpty())
+	},
+		Entry("with a PVC spec", authorizeSuccess, &v1.PersistentVolumeClaimSpec{
+			StorageClassName: ptr.To("test-sc"),
+			AccessModes:      []v1.PersistentVolumeAccessMode{v1.ReadWriteOnce},
+		}, http.StatusOK),
+		Entry("without PVC spec", authorizeSuccess, nil, http.StatusBadRequest),
+		Entry("when not allowed", &testAuthorizer{allowed: false, reason: "bad person"}, &v1.PersistentVolumeClaimSpec{}, http.StatusUnauthorized),
+	)
 })
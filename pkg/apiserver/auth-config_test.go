@@ -166,8 +166,10 @@ var _ = Describe("Auth config tests", func() {
 		authorizer := &testAuthorizer{}
 		acw, err := NewAuthConfigWatcher(ctx, client)
 		Expect(err).ToNot(HaveOccurred())
+		ctw, err := cryptowatch.NewCdiConfigTLSWatcher(ctx, cdiClient)
+		Expect(err).ToNot(HaveOccurred())
 
-		server, err := NewCdiAPIServer("0.0.0.0", 0, client, aggregatorClient, cdiClient, nil, nil, authorizer, acw, nil, nil, map[string]string{})
+		server, err := NewCdiAPIServer("0.0.0.0", 0, client, aggregatorClient, cdiClient, nil, nil, authorizer, acw, ctw, nil, map[string]string{})
 		Expect(err).ToNot(HaveOccurred())
 
 		app := server.(*cdiAPIApp)
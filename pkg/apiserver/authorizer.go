@@ -109,10 +109,18 @@ func (a *authorizor) generateAccessReview(req *restful.Request) (*authorization.
 		return nil, fmt.Errorf("no URL in http request")
 	}
 
-	// URL example
+	// URL examples
 	// /apis/upload.cdi.kubevirt.io/v1beta1/namespaces/default/uploadtokenrequest(s)
+	// /apis/upload.cdi.kubevirt.io/v1beta1/uploadcertrotationrequests
 	pathSplit := strings.Split(url.Path, "/")
-	if len(pathSplit) != 7 {
+	var namespace, resource string
+	switch len(pathSplit) {
+	case 7:
+		namespace = pathSplit[5]
+		resource = pathSplit[6]
+	case 5:
+		resource = pathSplit[4]
+	default:
 		return nil, fmt.Errorf("unknown api endpoint %s", url.Path)
 	}
 
@@ -120,15 +128,16 @@ func (a *authorizor) generateAccessReview(req *restful.Request) (*authorization.
 
 	group := pathSplit[2]
 	version := pathSplit[3]
-	namespace := pathSplit[5]
-	resource := pathSplit[6]
 	userExtras := a.getUserExtras(headers, authConfig.ExtraPrefixHeaders)
 
 	if group != uploadTokenGroup {
 		return nil, fmt.Errorf("unknown api group %s", group)
 	}
 
-	if resource != "uploadtokenrequests" {
+	// upload tokens are requested in the namespace of their PVC, DataVolume renderings in the namespace of their
+	// DataVolume, cert rotations for the whole cluster
+	namespaced := resource == uploadTokenResource || resource == dataVolumeRenderResource
+	if (namespace != "" || resource != uploadCertRotationResource) && (namespace == "" || !namespaced) {
 		return nil, fmt.Errorf("unknown resource type %s", resource)
 	}
 
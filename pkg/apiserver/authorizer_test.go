@@ -127,6 +127,46 @@ var _ = Describe("Authorizer test", func() {
 		Expect(authReview.Spec.Extra["test/value"]).To(Equal(extraValue))
 	})
 
+	It("Generate access review of cert rotation requests", func() {
+		app := newAuthorizor()
+		req := fakeRequest()
+		req.Request.URL.Path = "/apis/upload.cdi.kubevirt.io/v1beta1/uploadcertrotationrequests"
+		authReview, err := app.generateAccessReview(req)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(authReview.Spec.ResourceAttributes.Namespace).To(BeEmpty())
+		Expect(authReview.Spec.ResourceAttributes.Resource).To(Equal("uploadcertrotationrequests"))
+		Expect(authReview.Spec.ResourceAttributes.Verb).To(Equal("create"))
+	})
+
+	It("Generate access review path err namespaced cert rotation requests", func() {
+		app := newAuthorizor()
+		req := fakeRequest()
+		req.Request.URL.Path = "/apis/upload.cdi.kubevirt.io/v1beta1/namespaces/default/uploadcertrotationrequests"
+		authReview, err := app.generateAccessReview(req)
+		Expect(err).To(HaveOccurred())
+		Expect(authReview).To(BeNil())
+	})
+
+	It("Generate access review of DataVolume render requests", func() {
+		app := newAuthorizor()
+		req := fakeRequest()
+		req.Request.URL.Path = "/apis/upload.cdi.kubevirt.io/v1beta1/namespaces/default/datavolumerenderrequests"
+		authReview, err := app.generateAccessReview(req)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(authReview.Spec.ResourceAttributes.Namespace).To(Equal("default"))
+		Expect(authReview.Spec.ResourceAttributes.Resource).To(Equal("datavolumerenderrequests"))
+		Expect(authReview.Spec.ResourceAttributes.Verb).To(Equal("create"))
+	})
+
+	It("Generate access review path err cluster DataVolume render requests", func() {
+		app := newAuthorizor()
+		req := fakeRequest()
+		req.Request.URL.Path = "/apis/upload.cdi.kubevirt.io/v1beta1/datavolumerenderrequests"
+		authReview, err := app.generateAccessReview(req)
+		Expect(err).To(HaveOccurred())
+		Expect(authReview).To(BeNil())
+	})
+
 	It("Generate access review path err resource", func() {
 		app := newAuthorizor()
 		req := fakeRequest()
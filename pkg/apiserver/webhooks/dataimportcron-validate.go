@@ -136,6 +136,27 @@ func (wh *dataImportCronValidatingWebhook) validateDataImportCronSpec(request *a
 		return causes
 	}
 
+	if spec.MaxAge != nil && spec.MaxAge.Duration <= 0 {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: "Illegal MaxAge value",
+			Field:   field.Child("MaxAge").String(),
+		})
+		return causes
+	}
+
+	if spec.SourceFormat != nil &&
+		*spec.SourceFormat != cdiv1.DataImportCronSourceFormatPvc &&
+		*spec.SourceFormat != cdiv1.DataImportCronSourceFormatPvcQcow2 &&
+		*spec.SourceFormat != cdiv1.DataImportCronSourceFormatSnapshot {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: "Illegal SourceFormat value",
+			Field:   field.Child("SourceFormat").String(),
+		})
+		return causes
+	}
+
 	if spec.GarbageCollect != nil &&
 		*spec.GarbageCollect != cdiv1.DataImportCronGarbageCollectNever &&
 		*spec.GarbageCollect != cdiv1.DataImportCronGarbageCollectOutdated {
@@ -22,6 +22,7 @@ package webhooks
 import (
 	"encoding/json"
 	"fmt"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -148,6 +149,27 @@ var _ = Describe("Validating Webhook", func() {
 			resp := validateDataImportCronCreate(cron)
 			Expect(resp.Allowed).To(BeFalse())
 		})
+		It("should reject DataImportCron with illegal MaxAge on create", func() {
+			cron := newDataImportCron(cdiv1.DataVolumeSourceRegistry{URL: &testRegistryURL})
+			cron.Spec.MaxAge = &metav1.Duration{}
+			resp := validateDataImportCronCreate(cron)
+			Expect(resp.Allowed).To(BeFalse())
+		})
+		It("should reject DataImportCron with illegal SourceFormat on create", func() {
+			sourceFormat := cdiv1.DataImportCronSourceFormat("nosuch")
+			cron := newDataImportCron(cdiv1.DataVolumeSourceRegistry{URL: &testRegistryURL})
+			cron.Spec.SourceFormat = &sourceFormat
+			resp := validateDataImportCronCreate(cron)
+			Expect(resp.Allowed).To(BeFalse())
+		})
+		It("should accept DataImportCron with the pvc-qcow2 SourceFormat and a MaxAge on create", func() {
+			sourceFormat := cdiv1.DataImportCronSourceFormatPvcQcow2
+			cron := newDataImportCron(cdiv1.DataVolumeSourceRegistry{URL: &testRegistryURL})
+			cron.Spec.SourceFormat = &sourceFormat
+			cron.Spec.MaxAge = &metav1.Duration{Duration: time.Hour}
+			resp := validateDataImportCronCreate(cron)
+			Expect(resp.Allowed).To(BeTrue())
+		})
 		It("should reject invalid DataImportCron spec update", func() {
 			newCron := newDataImportCron(cdiv1.DataVolumeSourceRegistry{URL: &testRegistryURL})
 			newBytes, _ := json.Marshal(&newCron)
@@ -36,12 +36,20 @@ import (
 	cdiclient "kubevirt.io/containerized-data-importer/pkg/client/clientset/versioned"
 	cc "kubevirt.io/containerized-data-importer/pkg/controller/common"
 	"kubevirt.io/containerized-data-importer/pkg/token"
+	"kubevirt.io/containerized-data-importer/pkg/util/audit"
 )
 
 type dataVolumeMutatingWebhook struct {
 	k8sClient      kubernetes.Interface
 	cdiClient      cdiclient.Interface
 	tokenGenerator token.Generator
+	auditor        *audit.Auditor
+}
+
+// tokenResourceKinds are the kinds of the sources of clone tokens
+var tokenResourceKinds = map[string]string{
+	"persistentvolumeclaims": "PersistentVolumeClaim",
+	"volumesnapshots":        "VolumeSnapshot",
 }
 
 type authProxy struct {
@@ -61,6 +69,22 @@ func (p *authProxy) GetDataSource(namespace, name string) (*cdiv1.DataSource, er
 	return p.cdiClient.CdiV1beta1().DataSources(namespace).Get(context.TODO(), name, metav1.GetOptions{})
 }
 
+func (p *authProxy) ListVolumeSnapshotGrants(namespace string) ([]cdiv1.VolumeSnapshotGrant, error) {
+	grants, err := p.cdiClient.CdiV1beta1().VolumeSnapshotGrants(namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return grants.Items, nil
+}
+
+func (p *authProxy) ListCloneGrants(namespace string) ([]cdiv1.CloneGrant, error) {
+	grants, err := p.cdiClient.CdiV1beta1().CloneGrants(namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return grants.Items, nil
+}
+
 func (wh *dataVolumeMutatingWebhook) Admit(ar admissionv1.AdmissionReview) *admissionv1.AdmissionResponse {
 	dataVolume := &cdiv1.DataVolume{}
 
@@ -96,10 +120,12 @@ func (wh *dataVolumeMutatingWebhook) Admit(ar admissionv1.AdmissionReview) *admi
 		if errors.Is(err, cdiv1.ErrNoTokenOkay) {
 			return toPatchResponse(dataVolume, modifiedDataVolume)
 		}
+		wh.auditCloneToken(ar, response, targetNamespace, audit.OutcomeFailure, err.Error())
 		return toAdmissionResponseError(err)
 	}
 
 	if !response.Allowed {
+		wh.auditCloneToken(ar, response, targetNamespace, audit.OutcomeDenied, response.Reason)
 		causes := []metav1.StatusCause{
 			{
 				Type:    metav1.CauseTypeFieldValueInvalid,
@@ -133,15 +159,44 @@ func (wh *dataVolumeMutatingWebhook) Admit(ar admissionv1.AdmissionReview) *admi
 
 	token, err := wh.tokenGenerator.Generate(tokenData)
 	if err != nil {
+		wh.auditCloneToken(ar, response, targetNamespace, audit.OutcomeFailure, err.Error())
 		return toAdmissionResponseError(err)
 	}
+	wh.auditCloneToken(ar, response, targetNamespace, audit.OutcomeSuccess, "")
 
 	if modifiedDataVolume.Annotations == nil {
 		modifiedDataVolume.Annotations = make(map[string]string)
 	}
 	modifiedDataVolume.Annotations[cc.AnnCloneToken] = token
+	if response.Grant != "" {
+		klog.V(1).Infof("CloneGrant %s authorizes DataVolume %s/%s to clone %s/%s", response.Grant, targetNamespace, targetName, sourceNamespace, sourceName)
+		modifiedDataVolume.Annotations[cc.AnnCloneGrant] = response.Grant
+	}
 
 	klog.V(3).Infof("Sending patch response...")
 
 	return toPatchResponse(dataVolume, modifiedDataVolume)
 }
+
+// auditCloneToken emits the audit event of the clone token of the source of response, when the DataVolume is created
+// since tokens are only issued then
+func (wh *dataVolumeMutatingWebhook) auditCloneToken(ar admissionv1.AdmissionReview, response cdiv1.CloneAuthResponse, targetNamespace string, outcome audit.Outcome, reason string) {
+	if ar.Request.Operation != admissionv1.Create {
+		return
+	}
+	handler := response.Handler
+	sourceNamespace := handler.SourceNamespace
+	if sourceNamespace == "" {
+		sourceNamespace = targetNamespace
+	}
+	wh.auditor.Emit(audit.Event{
+		Action:    audit.ActionIssueCloneToken,
+		Outcome:   outcome,
+		Reason:    reason,
+		User:      ar.Request.UserInfo.Username,
+		Kind:      tokenResourceKinds[handler.TokenResource.Resource],
+		Namespace: sourceNamespace,
+		Name:      handler.SourceName,
+		Grant:     response.Grant,
+	})
+}
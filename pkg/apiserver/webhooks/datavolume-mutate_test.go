@@ -32,6 +32,7 @@ import (
 	"github.com/appscode/jsonpatch"
 
 	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
 	authorization "k8s.io/api/authorization/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -42,11 +43,18 @@ import (
 	cdicorev1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
 	cdiclientfake "kubevirt.io/containerized-data-importer/pkg/client/clientset/versioned/fake"
 	cc "kubevirt.io/containerized-data-importer/pkg/controller/common"
+	"kubevirt.io/containerized-data-importer/pkg/token"
 )
 
 var _ = Describe("Mutating DataVolume Webhook", func() {
 	Context("with DataVolume admission review", func() {
 		key, _ := rsa.GenerateKey(rand.Reader, 2048)
+		pvcCloneSource := cdicorev1.DataVolumeSource{
+			PVC: &cdicorev1.DataVolumeSourcePVC{Namespace: "testNamespace", Name: "golden"},
+		}
+		snapshotCloneSource := cdicorev1.DataVolumeSource{
+			Snapshot: &cdicorev1.DataVolumeSourceSnapshot{Namespace: "testNamespace", Name: "golden"},
+		}
 
 		It("should reject review without request", func() {
 			ar := &admissionv1.AdmissionReview{}
@@ -359,6 +367,130 @@ var _ = Describe("Mutating DataVolume Webhook", func() {
 			Entry("succeed with empty namespace", ""),
 		)
 
+		DescribeTable("should authorize a snapshot clone from another namespace with a VolumeSnapshotGrant", func(grantSpec cdicorev1.VolumeSnapshotGrantSpec, allowed bool) {
+			grant := &cdicorev1.VolumeSnapshotGrant{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "golden-images",
+					Namespace: "testNamespace",
+				},
+				Spec: grantSpec,
+			}
+			dataVolume := newDataVolume("testDV", cdicorev1.DataVolumeSource{
+				Snapshot: &cdicorev1.DataVolumeSourceSnapshot{
+					Namespace: "testNamespace",
+					Name:      "fedora",
+				},
+			}, newPVCSpec(pvcSizeDefault))
+			dvBytes, _ := json.Marshal(&dataVolume)
+
+			ar := &admissionv1.AdmissionReview{
+				Request: &admissionv1.AdmissionRequest{
+					Operation: admissionv1.Create,
+					Resource: metav1.GroupVersionResource{
+						Group:    cdicorev1.SchemeGroupVersion.Group,
+						Version:  cdicorev1.SchemeGroupVersion.Version,
+						Resource: "datavolumes",
+					},
+					Object: runtime.RawExtension{
+						Raw: dvBytes,
+					},
+				},
+			}
+
+			resp := mutateDVs(key, ar, false, grant)
+			Expect(resp.Allowed).To(Equal(allowed))
+			if !allowed {
+				return
+			}
+			var patchObjs []jsonpatch.Operation
+			err := json.Unmarshal(resp.Patch, &patchObjs)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(patchObjs).Should(HaveLen(1))
+			Expect(patchObjs[0].Value).Should(HaveKey(cc.AnnCloneToken))
+		},
+			Entry("granting all the snapshots of the namespace", cdicorev1.VolumeSnapshotGrantSpec{Namespaces: []string{"default"}}, true),
+			Entry("granting the snapshot", cdicorev1.VolumeSnapshotGrantSpec{Namespaces: []string{"tenant", "default"}, Snapshots: []string{"fedora"}}, true),
+			Entry("not granting the snapshot", cdicorev1.VolumeSnapshotGrantSpec{Namespaces: []string{"default"}, Snapshots: []string{"centos"}}, false),
+			Entry("not granting the namespace", cdicorev1.VolumeSnapshotGrantSpec{Namespaces: []string{"tenant"}}, false),
+		)
+
+		DescribeTable("should authorize a clone from another namespace with a CloneGrant", func(source cdicorev1.DataVolumeSource, grantSpec cdicorev1.CloneGrantSpec, username string, allowed bool) {
+			grant := &cdicorev1.CloneGrant{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "golden-images",
+					Namespace: "testNamespace",
+				},
+				Spec: grantSpec,
+			}
+			dataVolume := newDataVolume("testDV", source, newPVCSpec(pvcSizeDefault))
+			dvBytes, _ := json.Marshal(&dataVolume)
+
+			ar := &admissionv1.AdmissionReview{
+				Request: &admissionv1.AdmissionRequest{
+					Operation: admissionv1.Create,
+					Resource: metav1.GroupVersionResource{
+						Group:    cdicorev1.SchemeGroupVersion.Group,
+						Version:  cdicorev1.SchemeGroupVersion.Version,
+						Resource: "datavolumes",
+					},
+					Object: runtime.RawExtension{
+						Raw: dvBytes,
+					},
+					UserInfo: authenticationv1.UserInfo{Username: username},
+				},
+			}
+
+			resp := mutateDVs(key, ar, false, grant)
+			Expect(resp.Allowed).To(Equal(allowed))
+			if !allowed {
+				return
+			}
+			var patchObjs []jsonpatch.Operation
+			err := json.Unmarshal(resp.Patch, &patchObjs)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(patchObjs).Should(HaveLen(1))
+			Expect(patchObjs[0].Value).Should(HaveKey(cc.AnnCloneToken))
+			Expect(patchObjs[0].Value).Should(HaveKeyWithValue(cc.AnnCloneGrant, "testNamespace/golden-images"))
+		},
+			Entry("granting all the sources of the namespace",
+				pvcCloneSource, cdicorev1.CloneGrantSpec{Namespaces: []string{"default"}}, "user", true),
+			Entry("granting the PVC",
+				pvcCloneSource, cdicorev1.CloneGrantSpec{
+					Namespaces: []string{"default"},
+					Sources:    []cdicorev1.CloneGrantSource{{Kind: cdicorev1.CloneGrantSourcePVC, Name: "golden"}},
+				}, "user", true),
+			Entry("granting the snapshot",
+				snapshotCloneSource, cdicorev1.CloneGrantSpec{
+					Namespaces: []string{"default"},
+					Sources:    []cdicorev1.CloneGrantSource{{Kind: cdicorev1.CloneGrantSourceSnapshot, Name: "golden"}},
+				}, "user", true),
+			Entry("granting the ServiceAccount",
+				pvcCloneSource, cdicorev1.CloneGrantSpec{
+					ServiceAccounts: []cdicorev1.CloneGrantServiceAccount{{Namespace: "tenant", Name: "provisioner"}},
+				}, "system:serviceaccount:tenant:provisioner", true),
+			Entry("not yet expired",
+				pvcCloneSource, cdicorev1.CloneGrantSpec{
+					Namespaces:     []string{"default"},
+					ExpirationTime: &metav1.Time{Time: time.Now().Add(time.Hour)},
+				}, "user", true),
+			Entry("expired",
+				pvcCloneSource, cdicorev1.CloneGrantSpec{
+					Namespaces:     []string{"default"},
+					ExpirationTime: &metav1.Time{Time: time.Now().Add(-time.Hour)},
+				}, "user", false),
+			Entry("not granting the namespace",
+				pvcCloneSource, cdicorev1.CloneGrantSpec{Namespaces: []string{"tenant"}}, "user", false),
+			Entry("not granting the ServiceAccount",
+				pvcCloneSource, cdicorev1.CloneGrantSpec{
+					ServiceAccounts: []cdicorev1.CloneGrantServiceAccount{{Namespace: "tenant", Name: "provisioner"}},
+				}, "system:serviceaccount:tenant:builder", false),
+			Entry("granting a snapshot of the name of the PVC",
+				pvcCloneSource, cdicorev1.CloneGrantSpec{
+					Namespaces: []string{"default"},
+					Sources:    []cdicorev1.CloneGrantSource{{Kind: cdicorev1.CloneGrantSourceSnapshot, Name: "golden"}},
+				}, "user", false),
+		)
+
 		It("should allow update to DataVolume with sourceRef DataSource reference with clone token", func() {
 			dsRef := &cdicorev1.DataSource{
 				ObjectMeta: metav1.ObjectMeta{
@@ -436,6 +568,6 @@ func mutateDVs(key *rsa.PrivateKey, ar *admissionv1.AdmissionReview, isAuthorize
 	})
 
 	cdiClient := cdiclientfake.NewSimpleClientset(cdiObjects...)
-	wh := NewDataVolumeMutatingWebhook(client, cdiClient, key)
+	wh := NewDataVolumeMutatingWebhook(client, cdiClient, token.NewStaticKeySet(key), (&token.ConfigStore{}).Get, nil)
 	return serve(ar, wh)
 }
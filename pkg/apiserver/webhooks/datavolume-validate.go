@@ -28,6 +28,7 @@ import (
 	snapclient "github.com/kubernetes-csi/external-snapshotter/client/v6/clientset/versioned"
 
 	admissionv1 "k8s.io/api/admission/v1"
+	authv1 "k8s.io/api/authorization/v1"
 	v1 "k8s.io/api/core/v1"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
@@ -41,7 +42,10 @@ import (
 
 	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
 	cdiclient "kubevirt.io/containerized-data-importer/pkg/client/clientset/versioned"
+	"kubevirt.io/containerized-data-importer/pkg/common"
 	cc "kubevirt.io/containerized-data-importer/pkg/controller/common"
+	dvc "kubevirt.io/containerized-data-importer/pkg/controller/datavolume"
+	"kubevirt.io/containerized-data-importer/pkg/util"
 )
 
 type dataVolumeValidatingWebhook struct {
@@ -51,6 +55,127 @@ type dataVolumeValidatingWebhook struct {
 	controllerRuntimeClient client.Client
 }
 
+// isUserAllowed sends a SubjectAccessReview checking the user of the admission request is allowed the attributes
+func (wh *dataVolumeValidatingWebhook) isUserAllowed(request *admissionv1.AdmissionRequest, attributes *authv1.ResourceAttributes) (bool, error) {
+	var extra map[string]authv1.ExtraValue
+	if len(request.UserInfo.Extra) > 0 {
+		extra = make(map[string]authv1.ExtraValue)
+		for k, v := range request.UserInfo.Extra {
+			extra[k] = authv1.ExtraValue(v)
+		}
+	}
+	sar := &authv1.SubjectAccessReview{
+		Spec: authv1.SubjectAccessReviewSpec{
+			User:               request.UserInfo.Username,
+			Groups:             request.UserInfo.Groups,
+			Extra:              extra,
+			UID:                request.UserInfo.UID,
+			ResourceAttributes: attributes,
+		},
+	}
+	klog.V(3).Infof("Sending SubjectAccessReview %+v", sar)
+	response, err := wh.k8sClient.AuthorizationV1().SubjectAccessReviews().Create(context.TODO(), sar, metav1.CreateOptions{})
+	if err != nil {
+		return false, err
+	}
+	return response.Status.Allowed, nil
+}
+
+// authorizePostCompletionHookJobs makes sure the user creating a DataVolume with job hooks may create the Jobs
+// running them, as the controller creates them on behalf of the user with the images of the hooks
+func (wh *dataVolumeValidatingWebhook) authorizePostCompletionHookJobs(request *admissionv1.AdmissionRequest, spec *cdiv1.DataVolumeSpec, field *k8sfield.Path, namespace *string) []metav1.StatusCause {
+	if request == nil || request.Operation != admissionv1.Create {
+		return nil
+	}
+	hasJob := false
+	for _, hook := range spec.PostCompletionHooks {
+		hasJob = hasJob || hook.Job != nil
+	}
+	if !hasJob {
+		return nil
+	}
+	ns := request.Namespace
+	if namespace != nil && *namespace != "" {
+		ns = *namespace
+	}
+
+	allowed, err := wh.isUserAllowed(request, &authv1.ResourceAttributes{
+		Namespace: ns,
+		Verb:      "create",
+		Group:     "batch",
+		Resource:  "jobs",
+	})
+	if err != nil {
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("could not authorize the job post completion hooks: %v", err),
+			Field:   field.Child("postCompletionHooks").String(),
+		}}
+	}
+	if !allowed {
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("User %s has insufficient permissions to run job post completion hooks, jobs can not be created in namespace %s", request.UserInfo.Username, ns),
+			Field:   field.Child("postCompletionHooks").String(),
+		}}
+	}
+	return nil
+}
+
+// authorizeS3ServiceAccount makes sure the user creating an S3 source read with the token of a service account may
+// impersonate it, as the importer pod runs with that service account on behalf of the user. The VolumeImportSources
+// the controller creates for DataVolumes are not checked again, the user creating the DataVolume was.
+func (wh *dataVolumeValidatingWebhook) authorizeS3ServiceAccount(request *admissionv1.AdmissionRequest, s3 *cdiv1.DataVolumeSourceS3, field *k8sfield.Path, namespace string) []metav1.StatusCause {
+	if request == nil || request.Operation != admissionv1.Create || s3 == nil || s3.ServiceAccountName == "" {
+		return nil
+	}
+	if request.UserInfo.Username == fmt.Sprintf("system:serviceaccount:%s:%s", util.GetNamespace(), common.ControllerServiceAccountName) {
+		return nil
+	}
+
+	allowed, err := wh.isUserAllowed(request, &authv1.ResourceAttributes{
+		Namespace: namespace,
+		Verb:      "impersonate",
+		Resource:  "serviceaccounts",
+		Name:      s3.ServiceAccountName,
+	})
+	if err != nil {
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("could not authorize the S3 service account: %v", err),
+			Field:   field.Child("S3", "serviceAccountName").String(),
+		}}
+	}
+	if !allowed {
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("User %s has insufficient permissions to read the S3 source with service account %s, it can not be impersonated in namespace %s", request.UserInfo.Username, s3.ServiceAccountName, namespace),
+			Field:   field.Child("S3", "serviceAccountName").String(),
+		}}
+	}
+	return nil
+}
+
+// authorizeS3ServiceAccounts authorizes the service accounts of the S3 source and S3 fallbacks of a DataVolume
+func (wh *dataVolumeValidatingWebhook) authorizeS3ServiceAccounts(request *admissionv1.AdmissionRequest, spec *cdiv1.DataVolumeSpec, field *k8sfield.Path, namespace *string) []metav1.StatusCause {
+	if request == nil || spec.Source == nil {
+		return nil
+	}
+	ns := request.Namespace
+	if namespace != nil && *namespace != "" {
+		ns = *namespace
+	}
+	if causes := wh.authorizeS3ServiceAccount(request, spec.Source.S3, field.Child("source"), ns); causes != nil {
+		return causes
+	}
+	for i := range spec.SourceFallbacks {
+		if causes := wh.authorizeS3ServiceAccount(request, spec.SourceFallbacks[i].S3, field.Child("sourceFallbacks").Index(i), ns); causes != nil {
+			return causes
+		}
+	}
+	return nil
+}
+
 func validateNameLength(name string, maxLen int) *metav1.StatusCause {
 	if len(name) > maxLen {
 		return &metav1.StatusCause{
@@ -93,6 +218,36 @@ func (wh *dataVolumeValidatingWebhook) validateDataVolumeSpec(request *admission
 		causes = append(causes, *cause)
 		return causes
 	}
+	if causes := validateRetention(spec, field); causes != nil {
+		return causes
+	}
+	if causes := validatePostCompletionHooks(spec, field); causes != nil {
+		return causes
+	}
+	if causes := validateValidateOnly(spec, field); causes != nil {
+		return causes
+	}
+	if causes := validateExpandFilesystem(spec, field); causes != nil {
+		return causes
+	}
+	if causes := validateVerify(spec, field); causes != nil {
+		return causes
+	}
+	if causes := validateDeduplicate(spec, field); causes != nil {
+		return causes
+	}
+	if causes := validateTopology(spec, field); causes != nil {
+		return causes
+	}
+	if causes := validateScratchSpace(spec, field); causes != nil {
+		return causes
+	}
+	if causes := wh.authorizePostCompletionHookJobs(request, spec, field, namespace); causes != nil {
+		return causes
+	}
+	if causes := wh.authorizeS3ServiceAccounts(request, spec, field, namespace); causes != nil {
+		return causes
+	}
 
 	if spec.PVC != nil {
 		dataSourceRef = spec.PVC.DataSourceRef
@@ -154,6 +309,27 @@ func (wh *dataVolumeValidatingWebhook) validateDataVolumeSpec(request *admission
 		})
 		return causes
 	}
+	if causes := validateRetryPolicy(spec, field); causes != nil {
+		return causes
+	}
+	if causes := validatePaused(spec, field); causes != nil {
+		return causes
+	}
+	if causes := validatePodOverrides(spec, field); causes != nil {
+		return causes
+	}
+	if causes := validateProxy(spec, field); causes != nil {
+		return causes
+	}
+	if causes := validateCloneStrategy(spec, field); causes != nil {
+		return causes
+	}
+	if causes := validateCloneFormat(spec, field); causes != nil {
+		return causes
+	}
+	if causes := validateCloneGroup(spec, field); causes != nil {
+		return causes
+	}
 	if spec.SourceRef != nil {
 		cause := wh.validateSourceRef(request, spec, field, namespace)
 		if cause != nil {
@@ -173,7 +349,7 @@ func (wh *dataVolumeValidatingWebhook) validateDataVolumeSpec(request *admission
 
 	// Validate import sources
 	if http := spec.Source.HTTP; http != nil {
-		if causes := validateHTTPSource(http, field); causes != nil {
+		if causes := validateHTTPSource(http, spec.ContentType, field); causes != nil {
 			return causes
 		}
 	}
@@ -187,6 +363,71 @@ func (wh *dataVolumeValidatingWebhook) validateDataVolumeSpec(request *admission
 			return causes
 		}
 	}
+	if azure := spec.Source.Azure; azure != nil {
+		if causes := validateAzureSource(azure, spec.ContentType, field); causes != nil {
+			return causes
+		}
+	}
+	if sftp := spec.Source.SFTP; sftp != nil {
+		if causes := validateSFTPSource(sftp, spec.ContentType, field); causes != nil {
+			return causes
+		}
+	}
+	if smb := spec.Source.SMB; smb != nil {
+		if causes := validateSMBSource(smb, spec.ContentType, field); causes != nil {
+			return causes
+		}
+	}
+	if glance := spec.Source.Glance; glance != nil {
+		if causes := validateGlanceSource(glance, spec.ContentType, field); causes != nil {
+			return causes
+		}
+	}
+	if rbd := spec.Source.RBD; rbd != nil {
+		if causes := validateRBDSource(rbd, spec.ContentType, field); causes != nil {
+			return causes
+		}
+	}
+	if iscsi := spec.Source.ISCSI; iscsi != nil {
+		if causes := validateISCSISource(iscsi, spec.ContentType, field); causes != nil {
+			return causes
+		}
+	}
+	if hostPath := spec.Source.HostPath; hostPath != nil {
+		if causes := validateHostPathSource(hostPath, field); causes != nil {
+			return causes
+		}
+	}
+	if dataVolume := spec.Source.DataVolume; dataVolume != nil {
+		if causes := validateDataVolumeSource(dataVolume, spec, field); causes != nil {
+			return causes
+		}
+	}
+	if export := spec.Source.Export; export != nil {
+		if causes := validateExportSource(export, field); causes != nil {
+			return causes
+		}
+	}
+	if v2v := spec.Source.V2V; v2v != nil {
+		if causes := validateV2VSource(v2v, spec.ContentType, field); causes != nil {
+			return causes
+		}
+	}
+	if proxmox := spec.Source.Proxmox; proxmox != nil {
+		if causes := validateProxmoxSource(proxmox, spec.ContentType, field); causes != nil {
+			return causes
+		}
+	}
+	if plugin := spec.Source.Plugin; plugin != nil {
+		if causes := validatePluginSource(plugin, spec.ContentType, field); causes != nil {
+			return causes
+		}
+	}
+	if torrent := spec.Source.Torrent; torrent != nil {
+		if causes := validateTorrentSource(torrent, spec.ContentType, field); causes != nil {
+			return causes
+		}
+	}
 	if blank := spec.Source.Blank; blank != nil {
 		if causes := validateBlankSource(spec.ContentType, field); causes != nil {
 			return causes
@@ -207,6 +448,9 @@ func (wh *dataVolumeValidatingWebhook) validateDataVolumeSpec(request *admission
 			return causes
 		}
 	}
+	if causes := validateSourceFallbacks(spec, field); causes != nil {
+		return causes
+	}
 
 	// Validate clone sources
 	if spec.Source.PVC != nil {
@@ -363,8 +607,10 @@ func validateStorageSize(spec *cdiv1.DataVolumeSpec, field *k8sfield.Path) (*met
 
 	// The storage size of a DataVolume can only be empty when two conditios are met:
 	//	1. The 'Storage' spec API is used, which allows for additional logic in CDI.
-	//	2. The 'PVC'/'Snapshot' source or SourceRef is used, so the original size can be extracted from the source.
-	isClone := spec.SourceRef != nil || (spec.Source != nil && spec.Source.PVC != nil) || (spec.Source != nil && spec.Source.Snapshot != nil)
+	//	2. The 'PVC'/'Snapshot' source or SourceRef is used, so the original size can be extracted from the source,
+	//	   or the import source reports the virtual size of its image.
+	isClone := spec.SourceRef != nil || (spec.Source != nil && spec.Source.PVC != nil) || (spec.Source != nil && spec.Source.Snapshot != nil) ||
+		dvc.CanDetectImportSize(spec)
 	if pvcSize, ok := resources.Requests["storage"]; ok {
 		if pvcSize.IsZero() || pvcSize.Value() < 0 {
 			cause := metav1.StatusCause{
@@ -451,23 +697,33 @@ func (wh *dataVolumeValidatingWebhook) Admit(ar admissionv1.AdmissionReview) *ad
 			return toAdmissionResponseError(err)
 		}
 
+		// Always admit pausing and resuming the import, and changing how long it is kept once completed.
+		oldSpec := oldDV.Spec.DeepCopy()
+		oldSpec.Paused = false
+		oldSpec.TTLAfterCompletion = nil
+		oldSpec.FailureRetentionPolicy = nil
+		newSpec := dv.Spec.DeepCopy()
+		newSpec.Paused = false
+		newSpec.TTLAfterCompletion = nil
+		newSpec.FailureRetentionPolicy = nil
+
 		// Always admit checkpoint updates for multi-stage migrations.
 		multiStageAdmitted := false
 		isMultiStage := dv.Spec.Source != nil && len(dv.Spec.Checkpoints) > 0 &&
-			(dv.Spec.Source.VDDK != nil || dv.Spec.Source.Imageio != nil)
+			(dv.Spec.Source.VDDK != nil || dv.Spec.Source.Imageio != nil || dv.Spec.Source.Export != nil)
 		if isMultiStage {
-			oldSpec := oldDV.Spec.DeepCopy()
+			oldSpec := oldSpec.DeepCopy()
 			oldSpec.FinalCheckpoint = false
 			oldSpec.Checkpoints = nil
 
-			newSpec := dv.Spec.DeepCopy()
+			newSpec := newSpec.DeepCopy()
 			newSpec.FinalCheckpoint = false
 			newSpec.Checkpoints = nil
 
 			multiStageAdmitted = apiequality.Semantic.DeepEqual(newSpec, oldSpec)
 		}
 
-		if !multiStageAdmitted && !apiequality.Semantic.DeepEqual(dv.Spec, oldDV.Spec) {
+		if !multiStageAdmitted && !apiequality.Semantic.DeepEqual(newSpec, oldSpec) {
 			klog.Errorf("Cannot update spec for DataVolume %s/%s", dv.GetNamespace(), dv.GetName())
 			var causes []metav1.StatusCause
 			causes = append(causes, metav1.StatusCause{
@@ -525,6 +781,14 @@ func (wh *dataVolumeValidatingWebhook) Admit(ar admissionv1.AdmissionReview) *ad
 		klog.Infof("rejected DataVolume admission %s", causes)
 		return toRejectedAdmissionResponse(causes)
 	}
+	if source := dv.Spec.Source; source != nil && source.DataVolume != nil && source.DataVolume.Name == dv.Name {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: "DataVolume can not be its own dataVolume source",
+			Field:   k8sfield.NewPath("spec", "source", "DataVolume", "name").String(),
+		})
+		return toRejectedAdmissionResponse(causes)
+	}
 
 	reviewResponse := admissionv1.AdmissionResponse{}
 	reviewResponse.Allowed = true
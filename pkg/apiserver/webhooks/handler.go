@@ -20,7 +20,6 @@
 package webhooks
 
 import (
-	"crypto/rsa"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -43,6 +42,7 @@ import (
 	cdiclient "kubevirt.io/containerized-data-importer/pkg/client/clientset/versioned"
 	"kubevirt.io/containerized-data-importer/pkg/common"
 	"kubevirt.io/containerized-data-importer/pkg/token"
+	"kubevirt.io/containerized-data-importer/pkg/util/audit"
 )
 
 // Admitter is the interface implemented by admission webhooks
@@ -66,9 +66,9 @@ func NewDataVolumeValidatingWebhook(k8sClient kubernetes.Interface, cdiClient cd
 }
 
 // NewDataVolumeMutatingWebhook creates a new DataVolumeMutation webhook
-func NewDataVolumeMutatingWebhook(k8sClient kubernetes.Interface, cdiClient cdiclient.Interface, key *rsa.PrivateKey) http.Handler {
-	generator := newCloneTokenGenerator(key)
-	return newAdmissionHandler(&dataVolumeMutatingWebhook{k8sClient: k8sClient, cdiClient: cdiClient, tokenGenerator: generator})
+func NewDataVolumeMutatingWebhook(k8sClient kubernetes.Interface, cdiClient cdiclient.Interface, signingKeys token.KeySet, tokenConfig token.ConfigFunc, auditor *audit.Auditor) http.Handler {
+	generator := newCloneTokenGenerator(signingKeys, tokenConfig)
+	return newAdmissionHandler(&dataVolumeMutatingWebhook{k8sClient: k8sClient, cdiClient: cdiClient, tokenGenerator: generator, auditor: auditor})
 }
 
 // NewPvcMutatingWebhook creates a new PvcMutation webhook
@@ -96,8 +96,8 @@ func NewPopulatorValidatingWebhook(k8sClient kubernetes.Interface, cdiClient cdi
 	return newAdmissionHandler(&populatorValidatingWebhook{dataVolumeValidatingWebhook{k8sClient: k8sClient, cdiClient: cdiClient}})
 }
 
-func newCloneTokenGenerator(key *rsa.PrivateKey) token.Generator {
-	return token.NewGenerator(common.CloneTokenIssuer, key, 5*time.Minute)
+func newCloneTokenGenerator(signingKeys token.KeySet, tokenConfig token.ConfigFunc) token.Generator {
+	return token.NewConfigurableGenerator(common.CloneTokenIssuer, signingKeys, token.CloneTokenLifetime(5*time.Minute), tokenConfig)
 }
 
 func newAdmissionHandler(a Admitter) http.Handler {
@@ -154,6 +154,12 @@ func (wh *populatorValidatingWebhook) validateVolumeImportSource(ar admissionv1.
 		return causes, nil
 	}
 
+	causes = wh.authorizeS3ServiceAccount(ar.Request, volumeImportSource.Spec.Source.S3, k8sfield.NewPath("spec", "source"), ar.Request.Namespace)
+	if causes != nil {
+		klog.Infof("rejected VolumeImportSource admission %s", causes)
+		return causes, nil
+	}
+
 	return nil, nil
 }
 
@@ -178,7 +184,7 @@ func (wh *populatorValidatingWebhook) validateVolumeImportSourceSpec(field *k8sf
 
 	// Validate import sources
 	if http := spec.Source.HTTP; http != nil {
-		return validateHTTPSource(http, field)
+		return validateHTTPSource(http, spec.ContentType, field)
 	}
 	if s3 := spec.Source.S3; s3 != nil {
 		return validateS3Source(s3, field)
@@ -186,6 +192,42 @@ func (wh *populatorValidatingWebhook) validateVolumeImportSourceSpec(field *k8sf
 	if gcs := spec.Source.GCS; gcs != nil {
 		return validateGCSSource(gcs, field)
 	}
+	if azure := spec.Source.Azure; azure != nil {
+		return validateAzureSource(azure, spec.ContentType, field)
+	}
+	if sftp := spec.Source.SFTP; sftp != nil {
+		return validateSFTPSource(sftp, spec.ContentType, field)
+	}
+	if smb := spec.Source.SMB; smb != nil {
+		return validateSMBSource(smb, spec.ContentType, field)
+	}
+	if glance := spec.Source.Glance; glance != nil {
+		return validateGlanceSource(glance, spec.ContentType, field)
+	}
+	if rbd := spec.Source.RBD; rbd != nil {
+		return validateRBDSource(rbd, spec.ContentType, field)
+	}
+	if iscsi := spec.Source.ISCSI; iscsi != nil {
+		return validateISCSISource(iscsi, spec.ContentType, field)
+	}
+	if hostPath := spec.Source.HostPath; hostPath != nil {
+		return validateHostPathSource(hostPath, field)
+	}
+	if export := spec.Source.Export; export != nil {
+		return validateExportSource(export, field)
+	}
+	if v2v := spec.Source.V2V; v2v != nil {
+		return validateV2VSource(v2v, spec.ContentType, field)
+	}
+	if proxmox := spec.Source.Proxmox; proxmox != nil {
+		return validateProxmoxSource(proxmox, spec.ContentType, field)
+	}
+	if plugin := spec.Source.Plugin; plugin != nil {
+		return validatePluginSource(plugin, spec.ContentType, field)
+	}
+	if torrent := spec.Source.Torrent; torrent != nil {
+		return validateTorrentSource(torrent, spec.ContentType, field)
+	}
 	if blank := spec.Source.Blank; blank != nil {
 		return validateBlankSource(spec.ContentType, field)
 	}
@@ -234,5 +276,5 @@ func (wh *populatorValidatingWebhook) validateVolumeImportSourceUpdate(ar admiss
 
 func isMultiStageImport(spec *cdiv1.VolumeImportSourceSpec) bool {
 	return spec.Source != nil && len(spec.Checkpoints) > 0 &&
-		(spec.Source.VDDK != nil || spec.Source.Imageio != nil)
+		(spec.Source.VDDK != nil || spec.Source.Imageio != nil || spec.Source.Export != nil)
 }
@@ -21,18 +21,24 @@ package webhooks
 
 import (
 	"encoding/json"
+	"fmt"
 	"strings"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
 	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorization "k8s.io/api/authorization/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	fakeclient "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
 
 	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
 	cdiclientfake "kubevirt.io/containerized-data-importer/pkg/client/clientset/versioned/fake"
+	"kubevirt.io/containerized-data-importer/pkg/common"
+	"kubevirt.io/containerized-data-importer/pkg/util"
 )
 
 var (
@@ -78,6 +84,32 @@ var _ = Describe("Validating Webhook", func() {
 			Expect(resp.Allowed).To(BeFalse())
 		})
 
+		It("should reject an S3 VolumeImportSource with a service account the user may not impersonate", func() {
+			source := &cdiv1.ImportSourceType{
+				S3: &cdiv1.DataVolumeSourceS3{URL: "https://s3.example.com/bucket/disk.img", ServiceAccountName: "s3-reader"},
+			}
+			importCR := newVolumeImportSource(cdiv1.DataVolumeKubeVirt, source)
+			var sars []*authorization.SubjectAccessReview
+			resp := validateVolumeImportSourceCreateAs(importCR, "user", false, &sars)
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Details.Causes[0].Field).To(Equal("spec.source.S3.serviceAccountName"))
+			Expect(sars).To(HaveLen(1))
+			Expect(sars[0].Spec.ResourceAttributes.Verb).To(Equal("impersonate"))
+			Expect(sars[0].Spec.ResourceAttributes.Name).To(Equal("s3-reader"))
+		})
+
+		It("should not authorize the S3 service account of VolumeImportSources created by the controller", func() {
+			source := &cdiv1.ImportSourceType{
+				S3: &cdiv1.DataVolumeSourceS3{URL: "https://s3.example.com/bucket/disk.img", ServiceAccountName: "s3-reader"},
+			}
+			importCR := newVolumeImportSource(cdiv1.DataVolumeKubeVirt, source)
+			controller := fmt.Sprintf("system:serviceaccount:%s:%s", util.GetNamespace(), common.ControllerServiceAccountName)
+			var sars []*authorization.SubjectAccessReview
+			resp := validateVolumeImportSourceCreateAs(importCR, controller, false, &sars)
+			Expect(resp.Allowed).To(BeTrue())
+			Expect(sars).To(BeEmpty())
+		})
+
 		It("should reject VolumeImportSource spec update", func() {
 			source := &cdiv1.ImportSourceType{
 				HTTP: &cdiv1.DataVolumeSourceHTTP{
@@ -411,6 +443,37 @@ func validateVolumeImportSourceCreateEx(source *cdiv1.VolumeImportSource, k8sObj
 	return serve(ar, wh)
 }
 
+// validateVolumeImportSourceCreateAs admits the VolumeImportSource created by username, answering its
+// SubjectAccessReviews with isAuthorized and recording them in sars
+func validateVolumeImportSourceCreateAs(source *cdiv1.VolumeImportSource, username string, isAuthorized bool, sars *[]*authorization.SubjectAccessReview) *admissionv1.AdmissionResponse {
+	client := fakeclient.NewSimpleClientset()
+	client.PrependReactor("create", "subjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		sar := action.(k8stesting.CreateAction).GetObject().(*authorization.SubjectAccessReview)
+		*sars = append(*sars, sar)
+		return true, &authorization.SubjectAccessReview{Status: authorization.SubjectAccessReviewStatus{Allowed: isAuthorized}}, nil
+	})
+	wh := NewPopulatorValidatingWebhook(client, cdiclientfake.NewSimpleClientset())
+
+	sourceBytes, _ := json.Marshal(source)
+	ar := &admissionv1.AdmissionReview{
+		Request: &admissionv1.AdmissionRequest{
+			Operation: admissionv1.Create,
+			Namespace: source.Namespace,
+			UserInfo:  authenticationv1.UserInfo{Username: username},
+			Resource: metav1.GroupVersionResource{
+				Group:    cdiv1.SchemeGroupVersion.Group,
+				Version:  cdiv1.SchemeGroupVersion.Version,
+				Resource: "volumeimportsources",
+			},
+			Object: runtime.RawExtension{
+				Raw: sourceBytes,
+			},
+		},
+	}
+
+	return serve(ar, wh)
+}
+
 func validateVolumeUploadSourceCreateEx(source *cdiv1.VolumeUploadSource, k8sObjects, cdiObjects, snapObjects []runtime.Object) *admissionv1.AdmissionResponse {
 	client := fakeclient.NewSimpleClientset(k8sObjects...)
 	cdiClient := cdiclientfake.NewSimpleClientset(cdiObjects...)
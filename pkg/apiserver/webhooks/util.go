@@ -21,15 +21,39 @@ package webhooks
 
 import (
 	"fmt"
+	"net"
 	neturl "net/url"
+	"path"
 	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
 	field "k8s.io/apimachinery/pkg/util/validation/field"
 
 	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+	dvc "kubevirt.io/containerized-data-importer/pkg/controller/datavolume"
 )
 
+// proxmoxDiskKey matches the keys of the disks in the configuration of a Proxmox VE VM
+var proxmoxDiskKey = regexp.MustCompile(`^(ide|sata|scsi|virtio)\d+$`)
+
+// iscsiQualifiedName matches the iqn., eui. and naa. names of iSCSI targets, which end up in an iscsi:// url
+var iscsiQualifiedName = regexp.MustCompile(`^(iqn\.\d{4}-\d{2}\.[^/\s]+|eui\.[0-9A-Fa-f]{16}|naa\.[0-9A-Fa-f]{16}([0-9A-Fa-f]{16})?)$`)
+
+// checksumLengths are the lengths of the hex encoded digests of the checksum algorithms
+var checksumLengths = map[cdiv1.DataVolumeChecksumAlgorithm]int{
+	cdiv1.ChecksumMD5:    32,
+	cdiv1.ChecksumSHA1:   40,
+	cdiv1.ChecksumSHA256: 64,
+	cdiv1.ChecksumSHA512: 128,
+}
+
+// hexDigest matches the hex encoded digests of checksums
+var hexDigest = regexp.MustCompile(`^[0-9a-fA-F]+$`)
+
 func validateNumberOfSources(source interface{}, sourceKind string, field *field.Path) []metav1.StatusCause {
 	numberOfSources := 0
 	s := reflect.ValueOf(source).Elem()
@@ -157,21 +181,1082 @@ func validateDataVolumeSourceRegistry(sourceRegistry *cdiv1.DataVolumeSourceRegi
 		return causes
 	}
 
+	// The node pulls the image with the credentials of the kubelet
+	clientCertSecretRef := sourceRegistry.ClientCertSecretRef
+	if clientCertSecretRef != nil && *clientCertSecretRef != "" && importMethod != nil && *importMethod == cdiv1.RegistryPullNode {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: "Source registry clientCertSecretRef is not supported with node pull import method",
+			Field:   field.Child("source", "Registry", "clientCertSecretRef").String(),
+		})
+		return causes
+	}
+
 	return causes
 }
 
-// if source types are HTTP, Imageio, S3, GCS or VDDK, check if URL is valid
+// if source types are HTTP, Imageio, S3, GCS, Azure or VDDK, check if URL is valid
 
-func validateHTTPSource(http *cdiv1.DataVolumeSourceHTTP, field *field.Path) []metav1.StatusCause {
-	return checkSourceURL(http.URL, "HTTP", field)
+func validateHTTPSource(http *cdiv1.DataVolumeSourceHTTP, contentType cdiv1.DataVolumeContentType, field *field.Path) []metav1.StatusCause {
+	if causes := checkSourceURL(http.URL, "HTTP", field); causes != nil {
+		return causes
+	}
+	// Both are sent as the Authorization header
+	if http.OAuth2SecretRef != "" && http.SecretRef != "" {
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s HTTP secretRef and oauth2SecretRef cannot be used together", field.Child("source").String()),
+			Field:   field.Child("source", "HTTP", "oauth2SecretRef").String(),
+		}}
+	}
+	if http.OVA != nil && http.XVA != nil {
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s HTTP ova and xva cannot be used together", field.Child("source").String()),
+			Field:   field.Child("source", "HTTP", "xva").String(),
+		}}
+	}
+	if causes := validateChecksum(http.Checksum, "HTTP", field); causes != nil {
+		return causes
+	}
+	if causes := validateSignature(http.Signature, "HTTP", field); causes != nil {
+		return causes
+	}
+	if http.OVA != nil {
+		return validateOVA(http.OVA, contentType, field)
+	}
+	if http.XVA != nil {
+		return validateXVA(http.XVA, contentType, field)
+	}
+	return nil
+}
+
+func validateOVA(ova *cdiv1.DataVolumeSourceOVA, contentType cdiv1.DataVolumeContentType, field *field.Path) []metav1.StatusCause {
+	if contentType == cdiv1.DataVolumeArchive {
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("OVA disks cannot be imported with content type %s", contentType),
+			Field:   field.Child("contentType").String(),
+		}}
+	}
+	if ova.DiskIndex != nil && *ova.DiskIndex < 0 {
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s HTTP OVA diskIndex must not be negative: %d", field.Child("source").String(), *ova.DiskIndex),
+			Field:   field.Child("source", "HTTP", "ova", "diskIndex").String(),
+		}}
+	}
+	return nil
+}
+
+func validateXVA(xva *cdiv1.DataVolumeSourceXVA, contentType cdiv1.DataVolumeContentType, field *field.Path) []metav1.StatusCause {
+	if contentType == cdiv1.DataVolumeArchive {
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("XVA disks cannot be imported with content type %s", contentType),
+			Field:   field.Child("contentType").String(),
+		}}
+	}
+	if xva.DiskIndex != nil && *xva.DiskIndex < 0 {
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s HTTP XVA diskIndex must not be negative: %d", field.Child("source").String(), *xva.DiskIndex),
+			Field:   field.Child("source", "HTTP", "xva", "diskIndex").String(),
+		}}
+	}
+	return nil
 }
 
 func validateS3Source(s3 *cdiv1.DataVolumeSourceS3, field *field.Path) []metav1.StatusCause {
-	return checkSourceURL(s3.URL, "S3", field)
+	if causes := checkSourceURL(s3.URL, "S3", field); causes != nil {
+		return causes
+	}
+	if s3.ServiceAccountName != "" {
+		if errs := validation.IsDNS1123Subdomain(s3.ServiceAccountName); len(errs) > 0 {
+			return []metav1.StatusCause{{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s S3 serviceAccountName %q is invalid: %s", field.Child("source").String(), s3.ServiceAccountName, strings.Join(errs, ", ")),
+				Field:   field.Child("source", "S3", "serviceAccountName").String(),
+			}}
+		}
+	}
+	if causes := validateChecksum(s3.Checksum, "S3", field); causes != nil {
+		return causes
+	}
+	return validateSignature(s3.Signature, "S3", field)
+}
+
+func validateSourceFallbacks(spec *cdiv1.DataVolumeSpec, field *field.Path) []metav1.StatusCause {
+	if len(spec.SourceFallbacks) == 0 {
+		return nil
+	}
+	if spec.Source.HTTP == nil && spec.Source.S3 == nil {
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s is only supported with HTTP and S3 sources", field.Child("sourceFallbacks").String()),
+			Field:   field.Child("sourceFallbacks").String(),
+		}}
+	}
+	for i := range spec.SourceFallbacks {
+		fallback := &spec.SourceFallbacks[i]
+		fallbackField := field.Child("sourceFallbacks").Index(i)
+		if causes := validateNumberOfSources(fallback, "Data volume fallback", fallbackField); causes != nil {
+			return causes
+		}
+		if http := fallback.HTTP; http != nil {
+			if causes := validateHTTPSource(http, spec.ContentType, fallbackField); causes != nil {
+				return causes
+			}
+		}
+		if s3 := fallback.S3; s3 != nil {
+			if causes := validateS3Source(s3, fallbackField); causes != nil {
+				return causes
+			}
+		}
+	}
+	return nil
+}
+
+func validateRetryPolicy(spec *cdiv1.DataVolumeSpec, field *field.Path) []metav1.StatusCause {
+	policy := spec.RetryPolicy
+	if policy == nil {
+		return nil
+	}
+	policyField := field.Child("retryPolicy")
+	invalid := func(message, fieldPath string) []metav1.StatusCause {
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: message,
+			Field:   fieldPath,
+		}}
+	}
+	if spec.Source == nil || spec.Source.PVC != nil || spec.Source.Snapshot != nil || spec.Source.Upload != nil {
+		return invalid(fmt.Sprintf("%s is only supported with import sources", policyField.String()), policyField.String())
+	}
+	if policy.MaxRetries != nil && *policy.MaxRetries < 0 {
+		return invalid(fmt.Sprintf("%s maxRetries must not be negative", policyField.String()), policyField.Child("maxRetries").String())
+	}
+	if policy.BackoffBase != nil && policy.BackoffBase.Duration <= 0 {
+		return invalid(fmt.Sprintf("%s backoffBase must be positive", policyField.String()), policyField.Child("backoffBase").String())
+	}
+	if policy.BackoffCap != nil && policy.BackoffCap.Duration <= 0 {
+		return invalid(fmt.Sprintf("%s backoffCap must be positive", policyField.String()), policyField.Child("backoffCap").String())
+	}
+	if policy.BackoffBase != nil && policy.BackoffCap != nil && policy.BackoffBase.Duration > policy.BackoffCap.Duration {
+		return invalid(fmt.Sprintf("%s backoffBase must not be longer than backoffCap", policyField.String()), policyField.Child("backoffBase").String())
+	}
+	for i, class := range policy.RetryOn {
+		switch class {
+		case cdiv1.RetryOnNetwork, cdiv1.RetryOnSource, cdiv1.RetryOnStorage, cdiv1.RetryOnOther:
+		default:
+			return invalid(fmt.Sprintf("%s error class %q is not supported, use Network, Source, Storage or Other", policyField.String(), class), policyField.Child("retryOn").Index(i).String())
+		}
+	}
+	return nil
+}
+
+func validatePaused(spec *cdiv1.DataVolumeSpec, field *field.Path) []metav1.StatusCause {
+	if !spec.Paused {
+		return nil
+	}
+	if spec.Source == nil || spec.Source.PVC != nil || spec.Source.Snapshot != nil || spec.Source.Upload != nil || len(spec.Checkpoints) > 0 {
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s is only supported with import sources, without checkpoints", field.Child("paused").String()),
+			Field:   field.Child("paused").String(),
+		}}
+	}
+	return nil
+}
+
+func validatePodOverrides(spec *cdiv1.DataVolumeSpec, field *field.Path) []metav1.StatusCause {
+	if spec.PodOverrides == nil {
+		return nil
+	}
+	overridesField := field.Child("podOverrides")
+	if spec.Source == nil || spec.Source.PVC != nil || spec.Source.Snapshot != nil || spec.Source.Upload != nil {
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s is only supported with import sources", overridesField.String()),
+			Field:   overridesField.String(),
+		}}
+	}
+	return nil
+}
+
+func validateRetention(spec *cdiv1.DataVolumeSpec, field *field.Path) []metav1.StatusCause {
+	invalid := func(message, fieldPath string) []metav1.StatusCause {
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: message,
+			Field:   fieldPath,
+		}}
+	}
+	ttlField := field.Child("ttlAfterCompletion")
+	if spec.TTLAfterCompletion != nil && spec.TTLAfterCompletion.Duration < 0 {
+		return invalid(fmt.Sprintf("%s must not be negative", ttlField.String()), ttlField.String())
+	}
+	if spec.FailureRetentionPolicy == nil {
+		return nil
+	}
+	policyField := field.Child("failureRetentionPolicy")
+	switch *spec.FailureRetentionPolicy {
+	case cdiv1.FailureRetentionRetain:
+	case cdiv1.FailureRetentionDelete:
+		if spec.TTLAfterCompletion == nil {
+			return invalid(fmt.Sprintf("%s Delete requires %s", policyField.String(), ttlField.String()), policyField.String())
+		}
+	default:
+		return invalid(fmt.Sprintf("%s %q is not supported, use Retain or Delete", policyField.String(), *spec.FailureRetentionPolicy), policyField.String())
+	}
+	return nil
+}
+
+func validatePostCompletionHooks(spec *cdiv1.DataVolumeSpec, field *field.Path) []metav1.StatusCause {
+	invalid := func(message, fieldPath string) []metav1.StatusCause {
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: message,
+			Field:   fieldPath,
+		}}
+	}
+	names := map[string]bool{}
+	for i, hook := range spec.PostCompletionHooks {
+		hookField := field.Child("postCompletionHooks").Index(i)
+		if errs := validation.IsDNS1123Label(hook.Name); len(errs) > 0 {
+			return invalid(fmt.Sprintf("%s name %q is invalid: %s", hookField.String(), hook.Name, strings.Join(errs, ", ")), hookField.Child("name").String())
+		}
+		if names[hook.Name] {
+			return invalid(fmt.Sprintf("%s name %q is not unique", hookField.String(), hook.Name), hookField.Child("name").String())
+		}
+		names[hook.Name] = true
+		if (hook.Job == nil) == (hook.Webhook == nil) {
+			return invalid(fmt.Sprintf("%s must have exactly one of job and webhook", hookField.String()), hookField.String())
+		}
+		if hook.Job != nil && hook.Job.Image == "" {
+			return invalid(fmt.Sprintf("%s job has no image", hookField.String()), hookField.Child("job", "image").String())
+		}
+		if hook.Job != nil && hook.Job.BackoffLimit != nil && *hook.Job.BackoffLimit < 0 {
+			return invalid(fmt.Sprintf("%s job backoffLimit must not be negative", hookField.String()), hookField.Child("job", "backoffLimit").String())
+		}
+		if hook.Webhook != nil {
+			url, err := neturl.Parse(hook.Webhook.URL)
+			if err != nil || (url.Scheme != "http" && url.Scheme != "https") || url.Host == "" {
+				return invalid(fmt.Sprintf("%s webhook url %q is not a valid http or https URL", hookField.String(), hook.Webhook.URL), hookField.Child("webhook", "url").String())
+			}
+		}
+	}
+	return nil
+}
+
+// validateValidateOnly makes sure validate only DataVolumes import their data, in a single stage
+func validateValidateOnly(spec *cdiv1.DataVolumeSpec, field *field.Path) []metav1.StatusCause {
+	if !spec.ValidateOnly {
+		return nil
+	}
+	invalid := func(message string) []metav1.StatusCause {
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: message,
+			Field:   field.Child("validateOnly").String(),
+		}}
+	}
+	// The source is validated by a pod that does not mount any PVC
+	if !dvc.CanInspectImportSource(spec) {
+		return invalid("validateOnly is only supported with http, s3, gcs and registry sources without client certificates, OAuth2, secret headers, OVA or XVA, and registry images pulled by the importer pod")
+	}
+	if len(spec.SourceFallbacks) > 0 {
+		return invalid("validateOnly is not supported with source fallbacks")
+	}
+	if len(spec.Checkpoints) > 0 {
+		return invalid("validateOnly is not supported with multi-stage imports")
+	}
+	if len(spec.PostCompletionHooks) > 0 {
+		return invalid("validateOnly is not supported with post completion hooks")
+	}
+	return nil
+}
+
+func validateExpandFilesystem(spec *cdiv1.DataVolumeSpec, field *field.Path) []metav1.StatusCause {
+	if !spec.ExpandFilesystem {
+		return nil
+	}
+	invalid := func(message string) []metav1.StatusCause {
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: message,
+			Field:   field.Child("expandFilesystem").String(),
+		}}
+	}
+	source := spec.Source
+	if source == nil || source.PVC != nil || source.Snapshot != nil || source.Upload != nil || source.Blank != nil {
+		return invalid("expandFilesystem is only supported with import sources")
+	}
+	if spec.ContentType == cdiv1.DataVolumeArchive {
+		return invalid("expandFilesystem is not supported with archive content")
+	}
+	if len(spec.Checkpoints) > 0 {
+		return invalid("expandFilesystem is not supported with multi-stage imports")
+	}
+	return nil
+}
+
+func validateVerify(spec *cdiv1.DataVolumeSpec, field *field.Path) []metav1.StatusCause {
+	if spec.Verify == nil {
+		return nil
+	}
+	invalid := func(message string) []metav1.StatusCause {
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: message,
+			Field:   field.Child("verify").String(),
+		}}
+	}
+	source := spec.Source
+	if source == nil || source.PVC != nil || source.Snapshot != nil || source.Upload != nil || source.Blank != nil {
+		return invalid("verify is only supported with import sources")
+	}
+	if spec.ContentType == cdiv1.DataVolumeArchive {
+		return invalid("verify is not supported with archive content")
+	}
+	if len(spec.Checkpoints) > 0 {
+		return invalid("verify is not supported with multi-stage imports")
+	}
+	if spec.ValidateOnly {
+		return invalid("verify is not supported with validateOnly")
+	}
+	if source.DataVolume != nil && source.DataVolume.Transformation != nil && source.DataVolume.Transformation.Encryption != nil {
+		return invalid("verify is not supported with encryption")
+	}
+	if !spec.Verify.Checksum {
+		return nil
+	}
+	hasChecksum := (source.HTTP != nil && source.HTTP.Checksum != nil) ||
+		(source.S3 != nil && source.S3.Checksum != nil) ||
+		(source.GCS != nil && source.GCS.Checksum != nil)
+	if !hasChecksum {
+		return invalid("verify checksum requires a http, s3 or gcs source with a checksum")
+	}
+	if source.HTTP != nil && source.HTTP.XVA != nil {
+		return invalid("verify checksum is not supported with XVA disks")
+	}
+	if spec.ExpandFilesystem {
+		return invalid("verify checksum is not supported with expandFilesystem")
+	}
+	return nil
+}
+
+func validateDeduplicate(spec *cdiv1.DataVolumeSpec, field *field.Path) []metav1.StatusCause {
+	if !spec.Deduplicate {
+		return nil
+	}
+	invalid := func(message string) []metav1.StatusCause {
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: message,
+			Field:   field.Child("deduplicate").String(),
+		}}
+	}
+	if dvc.DeduplicationDigest(spec.Source) == "" {
+		return invalid("deduplicate requires a registry source pinned by digest, or a http, s3 or gcs source with a checksum")
+	}
+	if spec.ValidateOnly {
+		return invalid("deduplicate is not supported with validateOnly")
+	}
+	if spec.ExpandFilesystem {
+		return invalid("deduplicate is not supported with expandFilesystem")
+	}
+	if spec.Verify != nil {
+		return invalid("deduplicate is not supported with verify")
+	}
+	return nil
+}
+
+func validateTopology(spec *cdiv1.DataVolumeSpec, field *field.Path) []metav1.StatusCause {
+	if spec.Topology == nil {
+		return nil
+	}
+	invalid := func(message string) []metav1.StatusCause {
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: message,
+			Field:   field.Child("topology").String(),
+		}}
+	}
+	source := spec.Source
+	if source == nil || source.PVC != nil || source.Snapshot != nil || source.Upload != nil {
+		return invalid("topology is only supported with import sources")
+	}
+	if len(spec.Topology.NodeSelector) == 0 && spec.Topology.VirtualMachineInstance == "" {
+		return invalid("topology requires a nodeSelector or a virtualMachineInstance")
+	}
+	return nil
+}
+
+func validateScratchSpace(spec *cdiv1.DataVolumeSpec, field *field.Path) []metav1.StatusCause {
+	if spec.ScratchSpace == nil {
+		return nil
+	}
+	scratchField := field.Child("scratchSpace")
+	invalid := func(message string) []metav1.StatusCause {
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: message,
+			Field:   scratchField.String(),
+		}}
+	}
+	source := spec.Source
+	if source == nil || source.PVC != nil || source.Snapshot != nil {
+		return invalid(fmt.Sprintf("%s is only supported with import and upload sources", scratchField.String()))
+	}
+	scratchSpace := spec.ScratchSpace
+	if scratchSpace.StorageClassName != nil && *scratchSpace.StorageClassName == "" {
+		return invalid(fmt.Sprintf("%s storageClassName cannot be empty", scratchField.String()))
+	}
+	if scratchSpace.Size != nil && scratchSpace.SizeMultiplier != "" {
+		return invalid(fmt.Sprintf("%s cannot set both size and sizeMultiplier", scratchField.String()))
+	}
+	if scratchSpace.Size != nil && scratchSpace.Size.Sign() <= 0 {
+		return invalid(fmt.Sprintf("%s size must be positive", scratchField.String()))
+	}
+	if scratchSpace.SizeMultiplier != "" {
+		if multiplier, err := strconv.ParseFloat(scratchSpace.SizeMultiplier, 64); err != nil || multiplier <= 0 {
+			return invalid(fmt.Sprintf("%s sizeMultiplier %q must be a positive number", scratchField.String(), scratchSpace.SizeMultiplier))
+		}
+	}
+	return nil
+}
+
+func validateProxy(spec *cdiv1.DataVolumeSpec, field *field.Path) []metav1.StatusCause {
+	if spec.Proxy == nil {
+		return nil
+	}
+	proxyField := field.Child("proxy")
+	invalid := func(message, field string) []metav1.StatusCause {
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: message,
+			Field:   field,
+		}}
+	}
+	if spec.Source == nil || spec.Source.PVC != nil || spec.Source.Snapshot != nil || spec.Source.Upload != nil {
+		return invalid(fmt.Sprintf("%s is only supported with import sources", proxyField.String()), proxyField.String())
+	}
+	for _, proxy := range []struct{ name, url string }{{"httpProxy", spec.Proxy.HTTPProxy}, {"httpsProxy", spec.Proxy.HTTPSProxy}} {
+		if proxy.url == "" {
+			continue
+		}
+		if u, err := neturl.Parse(proxy.url); err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+			return invalid(fmt.Sprintf("%s %s %q is not a http or https URL", proxyField.String(), proxy.name, proxy.url), proxyField.Child(proxy.name).String())
+		}
+	}
+	return nil
+}
+
+func validateCloneStrategy(spec *cdiv1.DataVolumeSpec, field *field.Path) []metav1.StatusCause {
+	if spec.CloneStrategy == nil && spec.CloneStrategyFallbacks == nil {
+		return nil
+	}
+	invalid := func(message, field string) []metav1.StatusCause {
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: message,
+			Field:   field,
+		}}
+	}
+	isClone := spec.SourceRef != nil || spec.Deduplicate ||
+		(spec.Source != nil && (spec.Source.PVC != nil || spec.Source.Snapshot != nil))
+	if !isClone {
+		strategyField := field.Child("cloneStrategy").String()
+		return invalid(fmt.Sprintf("%s is only supported with clone sources, source references or deduplicated imports", strategyField), strategyField)
+	}
+	type listedStrategy struct {
+		strategy cdiv1.CDICloneStrategy
+		field    string
+	}
+	var listed []listedStrategy
+	if spec.CloneStrategy != nil {
+		listed = append(listed, listedStrategy{*spec.CloneStrategy, field.Child("cloneStrategy").String()})
+	}
+	for i, strategy := range spec.CloneStrategyFallbacks {
+		listed = append(listed, listedStrategy{strategy, field.Child("cloneStrategyFallbacks").Index(i).String()})
+	}
+	seen := map[cdiv1.CDICloneStrategy]bool{}
+	for _, l := range listed {
+		switch l.strategy {
+		case cdiv1.CloneStrategySnapshot, cdiv1.CloneStrategyCsiClone, cdiv1.CloneStrategyReflink, cdiv1.CloneStrategyHostAssisted:
+		default:
+			return invalid(fmt.Sprintf("%s %q is not one of snapshot, csi-clone, reflink or copy", l.field, l.strategy), l.field)
+		}
+		if seen[l.strategy] {
+			return invalid(fmt.Sprintf("%s: clone strategy %q is listed twice", l.field, l.strategy), l.field)
+		}
+		seen[l.strategy] = true
+	}
+	return nil
+}
+
+func validateCloneFormat(spec *cdiv1.DataVolumeSpec, field *field.Path) []metav1.StatusCause {
+	if spec.CloneFormat == nil {
+		return nil
+	}
+	formatField := field.Child("cloneFormat").String()
+	invalid := func(message string) []metav1.StatusCause {
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: message,
+			Field:   formatField,
+		}}
+	}
+	isClone := spec.SourceRef != nil || (spec.Source != nil && (spec.Source.PVC != nil || spec.Source.Snapshot != nil))
+	if !isClone {
+		return invalid(fmt.Sprintf("%s is only supported with clone sources or source references", formatField))
+	}
+	if spec.ContentType == cdiv1.DataVolumeArchive {
+		return invalid(fmt.Sprintf("%s does not support content type %s", formatField, spec.ContentType))
+	}
+	// the image is only converted by host-assisted clones
+	if spec.CloneStrategy == nil && spec.CloneStrategyFallbacks == nil {
+		return nil
+	}
+	if spec.CloneStrategy != nil && *spec.CloneStrategy == cdiv1.CloneStrategyHostAssisted {
+		return nil
+	}
+	for _, strategy := range spec.CloneStrategyFallbacks {
+		if strategy == cdiv1.CloneStrategyHostAssisted {
+			return nil
+		}
+	}
+	return invalid(fmt.Sprintf("%s needs a host-assisted clone, the clone strategies must include copy", formatField))
+}
+
+func validateCloneGroup(spec *cdiv1.DataVolumeSpec, field *field.Path) []metav1.StatusCause {
+	if spec.CloneGroup == "" {
+		return nil
+	}
+	groupField := field.Child("cloneGroup").String()
+	isClone := spec.SourceRef != nil || spec.Deduplicate ||
+		(spec.Source != nil && (spec.Source.PVC != nil || spec.Source.Snapshot != nil))
+	if !isClone {
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s is only supported with clone sources, source references or deduplicated imports", groupField),
+			Field:   groupField,
+		}}
+	}
+	if errs := validation.IsDNS1123Label(spec.CloneGroup); len(errs) > 0 {
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s %q is not a valid name: %s", groupField, spec.CloneGroup, strings.Join(errs, ", ")),
+			Field:   groupField,
+		}}
+	}
+	return nil
 }
 
 func validateGCSSource(gcs *cdiv1.DataVolumeSourceGCS, field *field.Path) []metav1.StatusCause {
-	return checkSourceURL(gcs.URL, "GCS", field)
+	if causes := checkSourceURL(gcs.URL, "GCS", field); causes != nil {
+		return causes
+	}
+	return validateChecksum(gcs.Checksum, "GCS", field)
+}
+
+func validateChecksum(checksum *cdiv1.DataVolumeChecksum, sourceKind string, field *field.Path) []metav1.StatusCause {
+	if checksum == nil {
+		return nil
+	}
+	length, ok := checksumLengths[checksum.Algorithm]
+	if !ok {
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s %s checksum algorithm %q is not supported, use md5, sha1, sha256 or sha512", field.Child("source").String(), sourceKind, checksum.Algorithm),
+			Field:   field.Child("source", sourceKind, "checksum", "algorithm").String(),
+		}}
+	}
+	if len(checksum.Value) != length || !hexDigest.MatchString(checksum.Value) {
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s %s checksum value %q is not a hex encoded %s digest of %d characters", field.Child("source").String(), sourceKind, checksum.Value, checksum.Algorithm, length),
+			Field:   field.Child("source", sourceKind, "checksum", "value").String(),
+		}}
+	}
+	return nil
+}
+
+func validateSignature(signature *cdiv1.DataVolumeSignature, sourceKind string, field *field.Path) []metav1.StatusCause {
+	if signature == nil {
+		return nil
+	}
+	// The url may be relative to the one of the source
+	if _, err := neturl.Parse(signature.URL); err != nil || signature.URL == "" {
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s %s signature url %q is invalid", field.Child("source").String(), sourceKind, signature.URL),
+			Field:   field.Child("source", sourceKind, "signature", "url").String(),
+		}}
+	}
+	if errs := validation.IsDNS1123Subdomain(signature.KeyringSecretRef); len(errs) > 0 {
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s %s signature keyringSecretRef %q is invalid: %s", field.Child("source").String(), sourceKind, signature.KeyringSecretRef, strings.Join(errs, ", ")),
+			Field:   field.Child("source", sourceKind, "signature", "keyringSecretRef").String(),
+		}}
+	}
+	return nil
+}
+
+func validateAzureSource(azure *cdiv1.DataVolumeSourceAzure, contentType cdiv1.DataVolumeContentType, field *field.Path) []metav1.StatusCause {
+	if causes := checkSourceURL(azure.URL, "Azure", field); causes != nil {
+		return causes
+	}
+	url, _ := neturl.ParseRequestURI(azure.URL)
+	if url.Scheme == "gs" || len(strings.Split(strings.Trim(url.Path, "/"), "/")) < 2 {
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s Azure URL must be an http(s) url naming a container and a blob: %s", field.Child("source").String(), azure.URL),
+			Field:   field.Child("source", "Azure", "url").String(),
+		}}
+	}
+	if contentType == cdiv1.DataVolumeArchive {
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("Azure source type does not support content type %s", contentType),
+			Field:   field.Child("contentType").String(),
+		}}
+	}
+	return nil
+}
+
+func validateSFTPSource(sftp *cdiv1.DataVolumeSourceSFTP, contentType cdiv1.DataVolumeContentType, field *field.Path) []metav1.StatusCause {
+	url, err := neturl.Parse(sftp.URL)
+	if err != nil || url.Scheme != "sftp" || url.User == nil || url.User.Username() == "" || url.Hostname() == "" ||
+		url.Path == "" || strings.HasSuffix(url.Path, "/") {
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s SFTP URL must be an sftp://user@host/path url naming a file: %s", field.Child("source").String(), sftp.URL),
+			Field:   field.Child("source", "SFTP", "url").String(),
+		}}
+	}
+	if _, hasPassword := url.User.Password(); hasPassword {
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s SFTP URL must not contain a password, it belongs in the secret", field.Child("source").String()),
+			Field:   field.Child("source", "SFTP", "url").String(),
+		}}
+	}
+	if sftp.SecretRef == "" {
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s SFTP source needs a secretRef holding the known hosts of the server", field.Child("source").String()),
+			Field:   field.Child("source", "SFTP", "secretRef").String(),
+		}}
+	}
+	if contentType == cdiv1.DataVolumeArchive {
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("SFTP source type does not support content type %s", contentType),
+			Field:   field.Child("contentType").String(),
+		}}
+	}
+	return nil
+}
+
+func validateSMBSource(smb *cdiv1.DataVolumeSourceSMB, contentType cdiv1.DataVolumeContentType, field *field.Path) []metav1.StatusCause {
+	url, err := neturl.Parse(smb.URL)
+	if err != nil || (url.Scheme != "smb" && url.Scheme != "smbs") || url.Hostname() == "" ||
+		len(strings.Split(strings.Trim(url.Path, "/"), "/")) < 2 || strings.HasSuffix(url.Path, "/") {
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s SMB URL must be an smb(s)://server/share/path url naming a file: %s", field.Child("source").String(), smb.URL),
+			Field:   field.Child("source", "SMB", "url").String(),
+		}}
+	}
+	if url.User != nil {
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s SMB URL must not contain credentials, they belong in the secret", field.Child("source").String()),
+			Field:   field.Child("source", "SMB", "url").String(),
+		}}
+	}
+	if contentType == cdiv1.DataVolumeArchive {
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("SMB source type does not support content type %s", contentType),
+			Field:   field.Child("contentType").String(),
+		}}
+	}
+	return nil
+}
+
+func validateGlanceSource(glance *cdiv1.DataVolumeSourceGlance, contentType cdiv1.DataVolumeContentType, field *field.Path) []metav1.StatusCause {
+	url, err := neturl.Parse(glance.URL)
+	if err != nil || (url.Scheme != "http" && url.Scheme != "https") || url.Hostname() == "" {
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s Glance URL must be the http(s) url of the Keystone identity service: %s", field.Child("source").String(), glance.URL),
+			Field:   field.Child("source", "Glance", "url").String(),
+		}}
+	}
+	if glance.Image == "" {
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s Glance source needs the name or the UUID of an image", field.Child("source").String()),
+			Field:   field.Child("source", "Glance", "image").String(),
+		}}
+	}
+	if glance.SecretRef == "" {
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s Glance source needs a secretRef holding the Keystone credentials", field.Child("source").String()),
+			Field:   field.Child("source", "Glance", "secretRef").String(),
+		}}
+	}
+	if contentType == cdiv1.DataVolumeArchive {
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("Glance source type does not support content type %s", contentType),
+			Field:   field.Child("contentType").String(),
+		}}
+	}
+	return nil
+}
+
+func validateRBDSource(rbd *cdiv1.DataVolumeSourceRBD, contentType cdiv1.DataVolumeContentType, field *field.Path) []metav1.StatusCause {
+	if len(rbd.Monitors) == 0 {
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s RBD source needs the addresses of the Ceph monitors", field.Child("source").String()),
+			Field:   field.Child("source", "RBD", "monitors").String(),
+		}}
+	}
+	for _, monitor := range rbd.Monitors {
+		if monitor == "" || strings.ContainsAny(monitor, ",; \t") {
+			return []metav1.StatusCause{{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s RBD monitor address is not valid: %q", field.Child("source").String(), monitor),
+				Field:   field.Child("source", "RBD", "monitors").String(),
+			}}
+		}
+	}
+	// The names end up in a qemu rbd:pool/image@snapshot filename, where these characters are separators
+	names := []struct {
+		name, value string
+		required    bool
+	}{{"pool", rbd.Pool, true}, {"image", rbd.Image, true}, {"snapshot", rbd.Snapshot, false}}
+	for _, n := range names {
+		if (n.required && n.value == "") || strings.ContainsAny(n.value, "/@:") {
+			return []metav1.StatusCause{{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s RBD %s name is not valid: %q", field.Child("source").String(), n.name, n.value),
+				Field:   field.Child("source", "RBD", n.name).String(),
+			}}
+		}
+	}
+	if rbd.SecretRef == "" {
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s RBD source needs a secretRef holding the Ceph keyring", field.Child("source").String()),
+			Field:   field.Child("source", "RBD", "secretRef").String(),
+		}}
+	}
+	if contentType == cdiv1.DataVolumeArchive {
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("RBD source type does not support content type %s", contentType),
+			Field:   field.Child("contentType").String(),
+		}}
+	}
+	return nil
+}
+
+func validateISCSISource(iscsi *cdiv1.DataVolumeSourceISCSI, contentType cdiv1.DataVolumeContentType, field *field.Path) []metav1.StatusCause {
+	portal, err := neturl.Parse("iscsi://" + iscsi.Portal)
+	if iscsi.Portal == "" || err != nil || portal.Host != iscsi.Portal || portal.User != nil {
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s iSCSI portal must be a host or host:port: %s", field.Child("source").String(), iscsi.Portal),
+			Field:   field.Child("source", "ISCSI", "portal").String(),
+		}}
+	}
+	if !iscsiQualifiedName.MatchString(iscsi.IQN) {
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s iSCSI target name must be an iqn., eui. or naa. name: %s", field.Child("source").String(), iscsi.IQN),
+			Field:   field.Child("source", "ISCSI", "iqn").String(),
+		}}
+	}
+	if iscsi.LUN < 0 || iscsi.LUN > 16383 {
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s iSCSI LUN must be between 0 and 16383: %d", field.Child("source").String(), iscsi.LUN),
+			Field:   field.Child("source", "ISCSI", "lun").String(),
+		}}
+	}
+	if contentType == cdiv1.DataVolumeArchive {
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("iSCSI source type does not support content type %s", contentType),
+			Field:   field.Child("contentType").String(),
+		}}
+	}
+	return nil
+}
+
+func validateHostPathSource(hostPath *cdiv1.DataVolumeSourceHostPath, field *field.Path) []metav1.StatusCause {
+	if errs := validation.IsDNS1123Subdomain(hostPath.NodeName); len(errs) > 0 {
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s hostPath source needs the name of a node: %s", field.Child("source").String(), strings.Join(errs, ", ")),
+			Field:   field.Child("source", "HostPath", "nodeName").String(),
+		}}
+	}
+	// The path is checked against the allowed directories by the import controller, it must not escape them
+	if !path.IsAbs(hostPath.Path) || path.Clean(hostPath.Path) != hostPath.Path || hostPath.Path == "/" {
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s hostPath path must be a clean absolute path naming a file: %s", field.Child("source").String(), hostPath.Path),
+			Field:   field.Child("source", "HostPath", "path").String(),
+		}}
+	}
+	return nil
+}
+
+func validateDataVolumeSource(dataVolume *cdiv1.DataVolumeSourceDataVolume, spec *cdiv1.DataVolumeSpec, field *field.Path) []metav1.StatusCause {
+	invalid := func(message, fieldName string) []metav1.StatusCause {
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: message,
+			Field:   field.Child("source", "DataVolume", fieldName).String(),
+		}}
+	}
+	if errs := validation.IsDNS1123Subdomain(dataVolume.Name); len(errs) > 0 {
+		return invalid(fmt.Sprintf("%s dataVolume source needs the name of a DataVolume: %s", field.Child("source").String(), strings.Join(errs, ", ")), "name")
+	}
+	// The importer reads the disk image of the source PVC, archives are not stored as one
+	if spec.ContentType == cdiv1.DataVolumeArchive {
+		return invalid(fmt.Sprintf("dataVolume source type does not support content type %s", spec.ContentType), "name")
+	}
+	if len(spec.Checkpoints) > 0 {
+		return invalid("dataVolume source does not support checkpoints", "name")
+	}
+	transformation := dataVolume.Transformation
+	if transformation == nil || transformation.Encryption == nil {
+		return nil
+	}
+	if transformation.Encryption.SecretRef == "" {
+		return invalid(fmt.Sprintf("%s dataVolume encryption needs a secretRef holding the passphrase", field.Child("source").String()), "transformation")
+	}
+	if transformation.Format == cdiv1.TransformationFormatQcow2 {
+		return invalid("dataVolume encryption stores the image as encrypted qcow2, it can not be combined with the qcow2 format", "transformation")
+	}
+	return nil
+}
+
+func validateExportSource(export *cdiv1.DataVolumeSourceExport, field *field.Path) []metav1.StatusCause {
+	// The export token is a bearer secret, it is only sent over TLS
+	url, err := neturl.Parse(export.URL)
+	if err != nil || url.Scheme != "https" || url.Hostname() == "" || url.User != nil {
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s export URL must be the https url of an exported volume, without credentials: %s", field.Child("source").String(), export.URL),
+			Field:   field.Child("source", "Export", "url").String(),
+		}}
+	}
+	if export.SecretRef == "" {
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s export source needs a secretRef holding the export token", field.Child("source").String()),
+			Field:   field.Child("source", "Export", "secretRef").String(),
+		}}
+	}
+	return nil
+}
+
+func validateV2VSource(v2v *cdiv1.DataVolumeSourceV2V, contentType cdiv1.DataVolumeContentType, field *field.Path) []metav1.StatusCause {
+	invalid := func(message, fieldName string) []metav1.StatusCause {
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s %s", field.Child("source").String(), message),
+			Field:   field.Child("source", "V2V", fieldName).String(),
+		}}
+	}
+	// The user is taken from the secret, virt-v2v reads the password from a file
+	url, err := neturl.Parse(v2v.URL)
+	if err != nil || (url.Scheme != "vpx" && url.Scheme != "esx") || url.Hostname() == "" || url.User != nil {
+		return invalid(fmt.Sprintf("v2v URL must be a vpx:// or esx:// libvirt url without credentials: %s", v2v.URL), "url")
+	}
+	if v2v.VMName == "" {
+		return invalid("v2v source needs the name of a VM", "vmName")
+	}
+	if v2v.SecretRef == "" {
+		return invalid("v2v source needs a secretRef holding the vSphere credentials", "secretRef")
+	}
+	if v2v.DiskIndex < 0 {
+		return invalid(fmt.Sprintf("v2v disk index must not be negative: %d", v2v.DiskIndex), "diskIndex")
+	}
+	if v2v.BlockDriver != "" && v2v.BlockDriver != "virtio-blk" && v2v.BlockDriver != "virtio-scsi" {
+		return invalid(fmt.Sprintf("v2v block driver must be virtio-blk or virtio-scsi: %s", v2v.BlockDriver), "blockDriver")
+	}
+	if v2v.VirtioWinPVC != "" && len(validation.IsDNS1123Subdomain(v2v.VirtioWinPVC)) > 0 {
+		return invalid(fmt.Sprintf("v2v virtio-win PVC name is not valid: %s", v2v.VirtioWinPVC), "virtioWinPVC")
+	}
+	for _, mapping := range v2v.NetworkMappings {
+		if hw, err := net.ParseMAC(mapping.MAC); err != nil || len(hw) != 6 {
+			return invalid(fmt.Sprintf("v2v network mapping MAC address is not valid: %s", mapping.MAC), "networkMappings")
+		}
+		if mapping.Network == "" && mapping.IP == "" {
+			return invalid(fmt.Sprintf("v2v network mapping of %s needs a network or an IP address", mapping.MAC), "networkMappings")
+		}
+		// The mappings are passed to the importer as a ';' separated list
+		if strings.Contains(mapping.Network, ";") {
+			return invalid(fmt.Sprintf("v2v network mapping network name is not valid: %s", mapping.Network), "networkMappings")
+		}
+		if causes := validateV2VStaticIP(mapping, invalid); causes != nil {
+			return causes
+		}
+	}
+	if contentType == cdiv1.DataVolumeArchive {
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("v2v source type does not support content type %s", contentType),
+			Field:   field.Child("contentType").String(),
+		}}
+	}
+	return nil
+}
+
+// validateV2VStaticIP checks the static IP configuration of a network mapping, its values are
+// positional in the virt-v2v --mac option so a prefix length needs a gateway and nameservers a prefix length
+func validateV2VStaticIP(mapping cdiv1.V2VNetworkMapping, invalid func(string, string) []metav1.StatusCause) []metav1.StatusCause {
+	if mapping.IP == "" {
+		if mapping.Gateway != "" || mapping.PrefixLength != 0 || len(mapping.Nameservers) > 0 {
+			return invalid(fmt.Sprintf("v2v network mapping of %s configures a gateway, prefix length or nameservers without an IP address", mapping.MAC), "networkMappings")
+		}
+		return nil
+	}
+	ip := net.ParseIP(mapping.IP)
+	if ip == nil {
+		return invalid(fmt.Sprintf("v2v network mapping IP address is not valid: %s", mapping.IP), "networkMappings")
+	}
+	if mapping.Gateway != "" && net.ParseIP(mapping.Gateway) == nil {
+		return invalid(fmt.Sprintf("v2v network mapping gateway is not valid: %s", mapping.Gateway), "networkMappings")
+	}
+	maxPrefixLength := int32(128)
+	if ip.To4() != nil {
+		maxPrefixLength = 32
+	}
+	if mapping.PrefixLength < 0 || mapping.PrefixLength > maxPrefixLength || (mapping.PrefixLength > 0 && mapping.Gateway == "") {
+		return invalid(fmt.Sprintf("v2v network mapping prefix length of %s is not valid, or has no gateway: %d", mapping.IP, mapping.PrefixLength), "networkMappings")
+	}
+	if len(mapping.Nameservers) > 0 && mapping.PrefixLength == 0 {
+		return invalid(fmt.Sprintf("v2v network mapping nameservers of %s need a prefix length", mapping.IP), "networkMappings")
+	}
+	for _, nameserver := range mapping.Nameservers {
+		if net.ParseIP(nameserver) == nil {
+			return invalid(fmt.Sprintf("v2v network mapping nameserver is not valid: %s", nameserver), "networkMappings")
+		}
+	}
+	return nil
+}
+
+func validateProxmoxSource(proxmox *cdiv1.DataVolumeSourceProxmox, contentType cdiv1.DataVolumeContentType, field *field.Path) []metav1.StatusCause {
+	invalid := func(message, fieldName string) []metav1.StatusCause {
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s %s", field.Child("source").String(), message),
+			Field:   field.Child("source", "Proxmox", fieldName).String(),
+		}}
+	}
+	// The API token is sent in a header, it is only sent over TLS
+	url, err := neturl.Parse(proxmox.URL)
+	if err != nil || url.Scheme != "https" || url.Hostname() == "" || url.User != nil {
+		return invalid(fmt.Sprintf("Proxmox URL must be the https url of the Proxmox VE API, without credentials: %s", proxmox.URL), "url")
+	}
+	if len(validation.IsDNS1123Label(strings.ToLower(proxmox.Node))) > 0 {
+		return invalid(fmt.Sprintf("Proxmox node name is not valid: %q", proxmox.Node), "node")
+	}
+	// Proxmox VE reserves the ids below 100
+	if proxmox.VMID < 100 {
+		return invalid(fmt.Sprintf("Proxmox VM id must be at least 100: %d", proxmox.VMID), "vmid")
+	}
+	if proxmox.Disk != "" && !proxmoxDiskKey.MatchString(proxmox.Disk) {
+		return invalid(fmt.Sprintf("Proxmox disk must be an ide, sata, scsi or virtio disk key like scsi0: %s", proxmox.Disk), "disk")
+	}
+	if proxmox.SecretRef == "" {
+		return invalid("Proxmox source needs a secretRef holding the API token and the SSH credentials of the node", "secretRef")
+	}
+	if contentType == cdiv1.DataVolumeArchive {
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("Proxmox source type does not support content type %s", contentType),
+			Field:   field.Child("contentType").String(),
+		}}
+	}
+	return nil
+}
+
+func validatePluginSource(plugin *cdiv1.DataVolumeSourcePlugin, contentType cdiv1.DataVolumeContentType, field *field.Path) []metav1.StatusCause {
+	// The name is the key of the plugin in the cdi-source-plugins ConfigMap
+	if len(validation.IsDNS1123Label(plugin.Name)) > 0 {
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s source plugin name is not valid: %q", field.Child("source").String(), plugin.Name),
+			Field:   field.Child("source", "Plugin", "name").String(),
+		}}
+	}
+	if contentType == cdiv1.DataVolumeArchive {
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("Plugin source type does not support content type %s", contentType),
+			Field:   field.Child("contentType").String(),
+		}}
+	}
+	return nil
+}
+
+func validateTorrentSource(torrent *cdiv1.DataVolumeSourceTorrent, contentType cdiv1.DataVolumeContentType, field *field.Path) []metav1.StatusCause {
+	invalid := func(message, fieldName string) []metav1.StatusCause {
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s %s", field.Child("source").String(), message),
+			Field:   field.Child("source", "Torrent", fieldName).String(),
+		}}
+	}
+	url, err := neturl.Parse(torrent.URL)
+	if err != nil {
+		return invalid(fmt.Sprintf("torrent URL is not valid: %s", torrent.URL), "url")
+	}
+	switch url.Scheme {
+	case "magnet":
+		// Magnet links identify the torrent by its v1 or v2 info hash
+		xt := url.Query().Get("xt")
+		if !strings.HasPrefix(xt, "urn:btih:") && !strings.HasPrefix(xt, "urn:btmh:") {
+			return invalid(fmt.Sprintf("torrent magnet link has no info hash: %s", torrent.URL), "url")
+		}
+	case "http", "https":
+		if url.Host == "" {
+			return invalid(fmt.Sprintf("torrent URL has no host: %s", torrent.URL), "url")
+		}
+	default:
+		return invalid(fmt.Sprintf("torrent URL must be a magnet link or the http(s) url of a .torrent file: %s", torrent.URL), "url")
+	}
+	for _, webSeed := range torrent.WebSeeds {
+		url, err := neturl.ParseRequestURI(webSeed)
+		if err != nil || (url.Scheme != "http" && url.Scheme != "https") {
+			return invalid(fmt.Sprintf("torrent web seed must be an http(s) url: %s", webSeed), "webSeeds")
+		}
+	}
+	if file := torrent.File; file != "" && (path.IsAbs(file) || path.Clean(file) != file || strings.HasPrefix(file, "../")) {
+		return invalid(fmt.Sprintf("torrent file must be a relative path in the torrent: %s", file), "file")
+	}
+	if contentType == cdiv1.DataVolumeArchive {
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("Torrent source type does not support content type %s", contentType),
+			Field:   field.Child("contentType").String(),
+		}}
+	}
+	return nil
 }
 
 func validateImageIOSource(imageio *cdiv1.DataVolumeSourceImageIO, field *field.Path) []metav1.StatusCause {
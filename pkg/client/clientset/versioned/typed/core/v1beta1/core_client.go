@@ -30,13 +30,21 @@ type CdiV1beta1Interface interface {
 	RESTClient() rest.Interface
 	CDIsGetter
 	CDIConfigsGetter
+	CloneGrantsGetter
+	DataExportsGetter
 	DataImportCronsGetter
 	DataSourcesGetter
 	DataVolumesGetter
+	DataVolumeMigrationsGetter
+	DataVolumeReplicationsGetter
+	ImageVerificationPoliciesGetter
+	ImportQuotasGetter
+	MultiDataVolumesGetter
 	ObjectTransfersGetter
 	StorageProfilesGetter
 	VolumeCloneSourcesGetter
 	VolumeImportSourcesGetter
+	VolumeSnapshotGrantsGetter
 	VolumeUploadSourcesGetter
 }
 
@@ -53,6 +61,14 @@ func (c *CdiV1beta1Client) CDIConfigs() CDIConfigInterface {
 	return newCDIConfigs(c)
 }
 
+func (c *CdiV1beta1Client) CloneGrants(namespace string) CloneGrantInterface {
+	return newCloneGrants(c, namespace)
+}
+
+func (c *CdiV1beta1Client) DataExports(namespace string) DataExportInterface {
+	return newDataExports(c, namespace)
+}
+
 func (c *CdiV1beta1Client) DataImportCrons(namespace string) DataImportCronInterface {
 	return newDataImportCrons(c, namespace)
 }
@@ -65,6 +81,26 @@ func (c *CdiV1beta1Client) DataVolumes(namespace string) DataVolumeInterface {
 	return newDataVolumes(c, namespace)
 }
 
+func (c *CdiV1beta1Client) DataVolumeMigrations(namespace string) DataVolumeMigrationInterface {
+	return newDataVolumeMigrations(c, namespace)
+}
+
+func (c *CdiV1beta1Client) DataVolumeReplications(namespace string) DataVolumeReplicationInterface {
+	return newDataVolumeReplications(c, namespace)
+}
+
+func (c *CdiV1beta1Client) ImageVerificationPolicies() ImageVerificationPolicyInterface {
+	return newImageVerificationPolicies(c)
+}
+
+func (c *CdiV1beta1Client) ImportQuotas(namespace string) ImportQuotaInterface {
+	return newImportQuotas(c, namespace)
+}
+
+func (c *CdiV1beta1Client) MultiDataVolumes(namespace string) MultiDataVolumeInterface {
+	return newMultiDataVolumes(c, namespace)
+}
+
 func (c *CdiV1beta1Client) ObjectTransfers() ObjectTransferInterface {
 	return newObjectTransfers(c)
 }
@@ -81,6 +117,10 @@ func (c *CdiV1beta1Client) VolumeImportSources(namespace string) VolumeImportSou
 	return newVolumeImportSources(c, namespace)
 }
 
+func (c *CdiV1beta1Client) VolumeSnapshotGrants(namespace string) VolumeSnapshotGrantInterface {
+	return newVolumeSnapshotGrants(c, namespace)
+}
+
 func (c *CdiV1beta1Client) VolumeUploadSources(namespace string) VolumeUploadSourceInterface {
 	return newVolumeUploadSources(c, namespace)
 }
@@ -36,6 +36,14 @@ func (c *FakeCdiV1beta1) CDIConfigs() v1beta1.CDIConfigInterface {
 	return &FakeCDIConfigs{c}
 }
 
+func (c *FakeCdiV1beta1) CloneGrants(namespace string) v1beta1.CloneGrantInterface {
+	return &FakeCloneGrants{c, namespace}
+}
+
+func (c *FakeCdiV1beta1) DataExports(namespace string) v1beta1.DataExportInterface {
+	return &FakeDataExports{c, namespace}
+}
+
 func (c *FakeCdiV1beta1) DataImportCrons(namespace string) v1beta1.DataImportCronInterface {
 	return &FakeDataImportCrons{c, namespace}
 }
@@ -48,6 +56,26 @@ func (c *FakeCdiV1beta1) DataVolumes(namespace string) v1beta1.DataVolumeInterfa
 	return &FakeDataVolumes{c, namespace}
 }
 
+func (c *FakeCdiV1beta1) DataVolumeMigrations(namespace string) v1beta1.DataVolumeMigrationInterface {
+	return &FakeDataVolumeMigrations{c, namespace}
+}
+
+func (c *FakeCdiV1beta1) DataVolumeReplications(namespace string) v1beta1.DataVolumeReplicationInterface {
+	return &FakeDataVolumeReplications{c, namespace}
+}
+
+func (c *FakeCdiV1beta1) ImageVerificationPolicies() v1beta1.ImageVerificationPolicyInterface {
+	return &FakeImageVerificationPolicies{c}
+}
+
+func (c *FakeCdiV1beta1) ImportQuotas(namespace string) v1beta1.ImportQuotaInterface {
+	return &FakeImportQuotas{c, namespace}
+}
+
+func (c *FakeCdiV1beta1) MultiDataVolumes(namespace string) v1beta1.MultiDataVolumeInterface {
+	return &FakeMultiDataVolumes{c, namespace}
+}
+
 func (c *FakeCdiV1beta1) ObjectTransfers() v1beta1.ObjectTransferInterface {
 	return &FakeObjectTransfers{c}
 }
@@ -64,6 +92,10 @@ func (c *FakeCdiV1beta1) VolumeImportSources(namespace string) v1beta1.VolumeImp
 	return &FakeVolumeImportSources{c, namespace}
 }
 
+func (c *FakeCdiV1beta1) VolumeSnapshotGrants(namespace string) v1beta1.VolumeSnapshotGrantInterface {
+	return &FakeVolumeSnapshotGrants{c, namespace}
+}
+
 func (c *FakeCdiV1beta1) VolumeUploadSources(namespace string) v1beta1.VolumeUploadSourceInterface {
 	return &FakeVolumeUploadSources{c, namespace}
 }
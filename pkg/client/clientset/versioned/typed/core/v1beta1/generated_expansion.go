@@ -22,12 +22,26 @@ type CDIExpansion interface{}
 
 type CDIConfigExpansion interface{}
 
+type CloneGrantExpansion interface{}
+
+type DataExportExpansion interface{}
+
 type DataImportCronExpansion interface{}
 
 type DataSourceExpansion interface{}
 
 type DataVolumeExpansion interface{}
 
+type DataVolumeMigrationExpansion interface{}
+
+type DataVolumeReplicationExpansion interface{}
+
+type ImageVerificationPolicyExpansion interface{}
+
+type ImportQuotaExpansion interface{}
+
+type MultiDataVolumeExpansion interface{}
+
 type ObjectTransferExpansion interface{}
 
 type StorageProfileExpansion interface{}
@@ -36,4 +50,6 @@ type VolumeCloneSourceExpansion interface{}
 
 type VolumeImportSourceExpansion interface{}
 
+type VolumeSnapshotGrantExpansion interface{}
+
 type VolumeUploadSourceExpansion interface{}
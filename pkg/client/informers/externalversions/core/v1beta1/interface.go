@@ -28,12 +28,26 @@ type Interface interface {
 	CDIs() CDIInformer
 	// CDIConfigs returns a CDIConfigInformer.
 	CDIConfigs() CDIConfigInformer
+	// CloneGrants returns a CloneGrantInformer.
+	CloneGrants() CloneGrantInformer
+	// DataExports returns a DataExportInformer.
+	DataExports() DataExportInformer
 	// DataImportCrons returns a DataImportCronInformer.
 	DataImportCrons() DataImportCronInformer
 	// DataSources returns a DataSourceInformer.
 	DataSources() DataSourceInformer
 	// DataVolumes returns a DataVolumeInformer.
 	DataVolumes() DataVolumeInformer
+	// DataVolumeMigrations returns a DataVolumeMigrationInformer.
+	DataVolumeMigrations() DataVolumeMigrationInformer
+	// DataVolumeReplications returns a DataVolumeReplicationInformer.
+	DataVolumeReplications() DataVolumeReplicationInformer
+	// ImageVerificationPolicies returns a ImageVerificationPolicyInformer.
+	ImageVerificationPolicies() ImageVerificationPolicyInformer
+	// ImportQuotas returns a ImportQuotaInformer.
+	ImportQuotas() ImportQuotaInformer
+	// MultiDataVolumes returns a MultiDataVolumeInformer.
+	MultiDataVolumes() MultiDataVolumeInformer
 	// ObjectTransfers returns a ObjectTransferInformer.
 	ObjectTransfers() ObjectTransferInformer
 	// StorageProfiles returns a StorageProfileInformer.
@@ -42,6 +56,8 @@ type Interface interface {
 	VolumeCloneSources() VolumeCloneSourceInformer
 	// VolumeImportSources returns a VolumeImportSourceInformer.
 	VolumeImportSources() VolumeImportSourceInformer
+	// VolumeSnapshotGrants returns a VolumeSnapshotGrantInformer.
+	VolumeSnapshotGrants() VolumeSnapshotGrantInformer
 	// VolumeUploadSources returns a VolumeUploadSourceInformer.
 	VolumeUploadSources() VolumeUploadSourceInformer
 }
@@ -67,6 +83,16 @@ func (v *version) CDIConfigs() CDIConfigInformer {
 	return &cDIConfigInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
 
+// CloneGrants returns a CloneGrantInformer.
+func (v *version) CloneGrants() CloneGrantInformer {
+	return &cloneGrantInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// DataExports returns a DataExportInformer.
+func (v *version) DataExports() DataExportInformer {
+	return &dataExportInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // DataImportCrons returns a DataImportCronInformer.
 func (v *version) DataImportCrons() DataImportCronInformer {
 	return &dataImportCronInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -82,6 +108,31 @@ func (v *version) DataVolumes() DataVolumeInformer {
 	return &dataVolumeInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// DataVolumeMigrations returns a DataVolumeMigrationInformer.
+func (v *version) DataVolumeMigrations() DataVolumeMigrationInformer {
+	return &dataVolumeMigrationInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// DataVolumeReplications returns a DataVolumeReplicationInformer.
+func (v *version) DataVolumeReplications() DataVolumeReplicationInformer {
+	return &dataVolumeReplicationInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// ImageVerificationPolicies returns a ImageVerificationPolicyInformer.
+func (v *version) ImageVerificationPolicies() ImageVerificationPolicyInformer {
+	return &imageVerificationPolicyInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
+// ImportQuotas returns a ImportQuotaInformer.
+func (v *version) ImportQuotas() ImportQuotaInformer {
+	return &importQuotaInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// MultiDataVolumes returns a MultiDataVolumeInformer.
+func (v *version) MultiDataVolumes() MultiDataVolumeInformer {
+	return &multiDataVolumeInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // ObjectTransfers returns a ObjectTransferInformer.
 func (v *version) ObjectTransfers() ObjectTransferInformer {
 	return &objectTransferInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
@@ -102,6 +153,11 @@ func (v *version) VolumeImportSources() VolumeImportSourceInformer {
 	return &volumeImportSourceInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// VolumeSnapshotGrants returns a VolumeSnapshotGrantInformer.
+func (v *version) VolumeSnapshotGrants() VolumeSnapshotGrantInformer {
+	return &volumeSnapshotGrantInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // VolumeUploadSources returns a VolumeUploadSourceInformer.
 func (v *version) VolumeUploadSources() VolumeUploadSourceInformer {
 	return &volumeUploadSourceInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
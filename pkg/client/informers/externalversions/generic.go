@@ -64,12 +64,26 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Cdi().V1beta1().CDIs().Informer()}, nil
 	case v1beta1.SchemeGroupVersion.WithResource("cdiconfigs"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Cdi().V1beta1().CDIConfigs().Informer()}, nil
+	case v1beta1.SchemeGroupVersion.WithResource("clonegrants"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Cdi().V1beta1().CloneGrants().Informer()}, nil
+	case v1beta1.SchemeGroupVersion.WithResource("dataexports"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Cdi().V1beta1().DataExports().Informer()}, nil
 	case v1beta1.SchemeGroupVersion.WithResource("dataimportcrons"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Cdi().V1beta1().DataImportCrons().Informer()}, nil
 	case v1beta1.SchemeGroupVersion.WithResource("datasources"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Cdi().V1beta1().DataSources().Informer()}, nil
 	case v1beta1.SchemeGroupVersion.WithResource("datavolumes"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Cdi().V1beta1().DataVolumes().Informer()}, nil
+	case v1beta1.SchemeGroupVersion.WithResource("datavolumemigrations"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Cdi().V1beta1().DataVolumeMigrations().Informer()}, nil
+	case v1beta1.SchemeGroupVersion.WithResource("datavolumereplications"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Cdi().V1beta1().DataVolumeReplications().Informer()}, nil
+	case v1beta1.SchemeGroupVersion.WithResource("imageverificationpolicies"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Cdi().V1beta1().ImageVerificationPolicies().Informer()}, nil
+	case v1beta1.SchemeGroupVersion.WithResource("importquotas"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Cdi().V1beta1().ImportQuotas().Informer()}, nil
+	case v1beta1.SchemeGroupVersion.WithResource("multidatavolumes"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Cdi().V1beta1().MultiDataVolumes().Informer()}, nil
 	case v1beta1.SchemeGroupVersion.WithResource("objecttransfers"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Cdi().V1beta1().ObjectTransfers().Informer()}, nil
 	case v1beta1.SchemeGroupVersion.WithResource("storageprofiles"):
@@ -78,6 +92,8 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Cdi().V1beta1().VolumeCloneSources().Informer()}, nil
 	case v1beta1.SchemeGroupVersion.WithResource("volumeimportsources"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Cdi().V1beta1().VolumeImportSources().Informer()}, nil
+	case v1beta1.SchemeGroupVersion.WithResource("volumesnapshotgrants"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Cdi().V1beta1().VolumeSnapshotGrants().Informer()}, nil
 	case v1beta1.SchemeGroupVersion.WithResource("volumeuploadsources"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Cdi().V1beta1().VolumeUploadSources().Informer()}, nil
 
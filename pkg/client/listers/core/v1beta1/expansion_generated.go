@@ -26,6 +26,22 @@ type CDIListerExpansion interface{}
 // CDIConfigLister.
 type CDIConfigListerExpansion interface{}
 
+// CloneGrantListerExpansion allows custom methods to be added to
+// CloneGrantLister.
+type CloneGrantListerExpansion interface{}
+
+// CloneGrantNamespaceListerExpansion allows custom methods to be added to
+// CloneGrantNamespaceLister.
+type CloneGrantNamespaceListerExpansion interface{}
+
+// DataExportListerExpansion allows custom methods to be added to
+// DataExportLister.
+type DataExportListerExpansion interface{}
+
+// DataExportNamespaceListerExpansion allows custom methods to be added to
+// DataExportNamespaceLister.
+type DataExportNamespaceListerExpansion interface{}
+
 // DataImportCronListerExpansion allows custom methods to be added to
 // DataImportCronLister.
 type DataImportCronListerExpansion interface{}
@@ -50,6 +66,42 @@ type DataVolumeListerExpansion interface{}
 // DataVolumeNamespaceLister.
 type DataVolumeNamespaceListerExpansion interface{}
 
+// DataVolumeMigrationListerExpansion allows custom methods to be added to
+// DataVolumeMigrationLister.
+type DataVolumeMigrationListerExpansion interface{}
+
+// DataVolumeMigrationNamespaceListerExpansion allows custom methods to be added to
+// DataVolumeMigrationNamespaceLister.
+type DataVolumeMigrationNamespaceListerExpansion interface{}
+
+// DataVolumeReplicationListerExpansion allows custom methods to be added to
+// DataVolumeReplicationLister.
+type DataVolumeReplicationListerExpansion interface{}
+
+// DataVolumeReplicationNamespaceListerExpansion allows custom methods to be added to
+// DataVolumeReplicationNamespaceLister.
+type DataVolumeReplicationNamespaceListerExpansion interface{}
+
+// ImageVerificationPolicyListerExpansion allows custom methods to be added to
+// ImageVerificationPolicyLister.
+type ImageVerificationPolicyListerExpansion interface{}
+
+// ImportQuotaListerExpansion allows custom methods to be added to
+// ImportQuotaLister.
+type ImportQuotaListerExpansion interface{}
+
+// ImportQuotaNamespaceListerExpansion allows custom methods to be added to
+// ImportQuotaNamespaceLister.
+type ImportQuotaNamespaceListerExpansion interface{}
+
+// MultiDataVolumeListerExpansion allows custom methods to be added to
+// MultiDataVolumeLister.
+type MultiDataVolumeListerExpansion interface{}
+
+// MultiDataVolumeNamespaceListerExpansion allows custom methods to be added to
+// MultiDataVolumeNamespaceLister.
+type MultiDataVolumeNamespaceListerExpansion interface{}
+
 // ObjectTransferListerExpansion allows custom methods to be added to
 // ObjectTransferLister.
 type ObjectTransferListerExpansion interface{}
@@ -74,6 +126,14 @@ type VolumeImportSourceListerExpansion interface{}
 // VolumeImportSourceNamespaceLister.
 type VolumeImportSourceNamespaceListerExpansion interface{}
 
+// VolumeSnapshotGrantListerExpansion allows custom methods to be added to
+// VolumeSnapshotGrantLister.
+type VolumeSnapshotGrantListerExpansion interface{}
+
+// VolumeSnapshotGrantNamespaceListerExpansion allows custom methods to be added to
+// VolumeSnapshotGrantNamespaceLister.
+type VolumeSnapshotGrantNamespaceListerExpansion interface{}
+
 // VolumeUploadSourceListerExpansion allows custom methods to be added to
 // VolumeUploadSourceLister.
 type VolumeUploadSourceListerExpansion interface{}
@@ -64,6 +64,14 @@ const (
 	DataImportCronNsLabel = CDIComponentLabel + "/dataImportCronNs"
 	// DataImportCronCleanupLabel tells whether to delete the resource when its DataImportCron is deleted
 	DataImportCronCleanupLabel = DataImportCronLabel + ".cleanup"
+	// MultiDataVolumeLabel has the name of the MultiDataVolume responsible for the labeled DataVolume
+	MultiDataVolumeLabel = CDIComponentLabel + "/multiDataVolume"
+	// DataVolumeReplicationLabel has the name of the DataVolumeReplication responsible for the labeled DataVolume
+	DataVolumeReplicationLabel = CDIComponentLabel + "/dataVolumeReplication"
+	// DataVolumeMigrationLabel has the name of the DataVolumeMigration responsible for the labeled PVC
+	DataVolumeMigrationLabel = CDIComponentLabel + "/dataVolumeMigration"
+	// PostCompletionHookLabel has the name of the DataVolume post completion hook run by the labeled Job
+	PostCompletionHookLabel = CDIComponentLabel + "/postCompletionHook"
 
 	// PvcApplyStorageProfileLabel tells whether the PVC should be rendered by the mutating webhook based on StorageProfiles
 	PvcApplyStorageProfileLabel = CDIComponentLabel + "/applyStorageProfile"
@@ -105,12 +113,20 @@ const (
 	ImporterSource = "IMPORTER_SOURCE"
 	// ImporterContentType provides a constant to capture our env variable "IMPORTER_CONTENTTYPE"
 	ImporterContentType = "IMPORTER_CONTENTTYPE"
+	// ImporterDetectContentType provides a constant to capture our env variable "IMPORTER_DETECT_CONTENT_TYPE"
+	ImporterDetectContentType = "IMPORTER_DETECT_CONTENT_TYPE"
 	// ImporterEndpoint provides a constant to capture our env variable "IMPORTER_ENDPOINT"
 	ImporterEndpoint = "IMPORTER_ENDPOINT"
 	// ImporterAccessKeyID provides a constant to capture our env variable "IMPORTER_ACCES_KEY_ID"
 	ImporterAccessKeyID = "IMPORTER_ACCESS_KEY_ID"
 	// ImporterSecretKey provides a constant to capture our env variable "IMPORTER_SECRET_KEY"
 	ImporterSecretKey = "IMPORTER_SECRET_KEY"
+	// ImporterSessionToken provides a constant to capture our env variable "IMPORTER_SESSION_TOKEN"
+	ImporterSessionToken = "IMPORTER_SESSION_TOKEN"
+	// ImporterRoleARN provides a constant to capture our env variable "IMPORTER_ROLE_ARN"
+	ImporterRoleARN = "IMPORTER_ROLE_ARN"
+	// ImporterExternalID provides a constant to capture our env variable "IMPORTER_EXTERNAL_ID"
+	ImporterExternalID = "IMPORTER_EXTERNAL_ID"
 	// ImporterImageSize provides a constant to capture our env variable "IMPORTER_IMAGE_SIZE"
 	ImporterImageSize = "IMPORTER_IMAGE_SIZE"
 	// ImporterCertDirVar provides a constant to capture our env variable "IMPORTER_CERT_DIR"
@@ -147,6 +163,12 @@ const (
 	CacheModeTryNone = "TRYNONE"
 	// Preallocation provides a constant to capture out env variable "PREALLOCATION"
 	Preallocation = "PREALLOCATION"
+	// CloneCompression provides a constant to capture our env variable "CLONE_COMPRESSION"
+	CloneCompression = "CLONE_COMPRESSION"
+	// CloneCPULimit provides a constant to capture our env variable "CLONE_CPU_LIMIT"
+	CloneCPULimit = "CLONE_CPU_LIMIT"
+	// CloneExportURL provides a constant to capture our env variable "CLONE_EXPORT_URL"
+	CloneExportURL = "CLONE_EXPORT_URL"
 	// ImportProxyHTTP provides a constant to capture our env variable "http_proxy"
 	ImportProxyHTTP = "http_proxy"
 	// ImportProxyHTTPS provides a constant to capture our env variable "https_proxy"
@@ -163,8 +185,73 @@ const (
 	ImporterExtraHeader = "IMPORTER_EXTRA_HEADER_"
 	// ImporterSecretExtraHeadersDir is where the secrets containing extra HTTP headers will be mounted
 	ImporterSecretExtraHeadersDir = "/extraheaders"
+	// ImporterOAuth2CredentialDir is where the secret of the OAuth2 client of an http source will be mounted
+	ImporterOAuth2CredentialDir = "/oauth2"
+	// ImporterSignatureKeyringDir is where the secret of the keyring the signature of a source is checked with will be mounted
+	ImporterSignatureKeyringDir = "/keyring"
+	// ImporterClientCertDir is where the secret of the client certificate presented to a source requiring mutual TLS will be mounted
+	ImporterClientCertDir = "/client-cert"
+	// KeyOAuth2TokenURL provides a constant to the tokenUrl key of an OAuth2 secret
+	KeyOAuth2TokenURL = "tokenUrl"
+	// KeyOAuth2ClientID provides a constant to the clientId key of an OAuth2 secret
+	KeyOAuth2ClientID = "clientId"
+	// KeyOAuth2ClientSecret provides a constant to the clientSecret key of an OAuth2 secret
+	//nolint:gosec // This is not a real credential
+	KeyOAuth2ClientSecret = "clientSecret"
+	// KeyOAuth2Scopes provides a constant to the scopes key of an OAuth2 secret
+	KeyOAuth2Scopes = "scopes"
 	// ImporterRegistryImageArchitecture provides a constant to capture our env variable "IMPORTER_REGISTRY_IMAGE_ARCHITECTURE"
 	ImporterRegistryImageArchitecture = "IMPORTER_REGISTRY_IMAGE_ARCHITECTURE"
+	// ImporterBlankZeroEdges provides a constant to capture our env variable "IMPORTER_BLANK_ZERO_EDGES"
+	ImporterBlankZeroEdges = "IMPORTER_BLANK_ZERO_EDGES"
+	// ImporterDownloadParallelism provides a constant to capture our env variable "IMPORTER_DOWNLOAD_PARALLELISM"
+	ImporterDownloadParallelism = "IMPORTER_DOWNLOAD_PARALLELISM"
+	// ImporterDownloadPartSize provides a constant to capture our env variable "IMPORTER_DOWNLOAD_PART_SIZE"
+	ImporterDownloadPartSize = "IMPORTER_DOWNLOAD_PART_SIZE"
+	// ImporterMemoryLimit provides a constant to capture our env variable "IMPORTER_MEMORY_LIMIT"
+	ImporterMemoryLimit = "IMPORTER_MEMORY_LIMIT"
+	// ImporterCurlConnections provides a constant to capture our env variable "IMPORTER_CURL_CONNECTIONS"
+	ImporterCurlConnections = "IMPORTER_CURL_CONNECTIONS"
+	// ImporterCurlHTTPVersion provides a constant to capture our env variable "IMPORTER_CURL_HTTP_VERSION"
+	ImporterCurlHTTPVersion = "IMPORTER_CURL_HTTP_VERSION"
+	// ImporterCurlTLS13Ciphers provides a constant to capture our env variable "IMPORTER_CURL_TLS13_CIPHERS"
+	ImporterCurlTLS13Ciphers = "IMPORTER_CURL_TLS13_CIPHERS"
+	// ImporterCurlTimeout provides a constant to capture our env variable "IMPORTER_CURL_TIMEOUT"
+	ImporterCurlTimeout = "IMPORTER_CURL_TIMEOUT"
+	// ImporterOVADisk provides a constant to capture our env variable "IMPORTER_OVA_DISK"
+	ImporterOVADisk = "IMPORTER_OVA_DISK"
+	// ImporterXVADisk provides a constant to capture our env variable "IMPORTER_XVA_DISK"
+	ImporterXVADisk = "IMPORTER_XVA_DISK"
+	// ImporterChecksum provides a constant to capture our env variable "IMPORTER_CHECKSUM"
+	ImporterChecksum = "IMPORTER_CHECKSUM"
+	// ImporterSignatureURL provides a constant to capture our env variable "IMPORTER_SIGNATURE_URL"
+	ImporterSignatureURL = "IMPORTER_SIGNATURE_URL"
+	// ImporterImageVerificationPolicies provides a constant to capture our env variable "IMPORTER_IMAGE_VERIFICATION_POLICIES"
+	ImporterImageVerificationPolicies = "IMPORTER_IMAGE_VERIFICATION_POLICIES"
+	// ImporterMaxBandwidth provides a constant to capture our env variable "IMPORTER_MAX_BANDWIDTH"
+	ImporterMaxBandwidth = "IMPORTER_MAX_BANDWIDTH"
+	// ImporterStorageFormat provides a constant to capture our env variable "IMPORTER_STORAGE_FORMAT"
+	ImporterStorageFormat = "IMPORTER_STORAGE_FORMAT"
+	// StorageFormatQcow2 is the storage format keeping the imported image as compressed qcow2
+	StorageFormatQcow2 = "qcow2"
+	// ImporterValidateOnly provides a constant to capture our env variable "IMPORTER_VALIDATE_ONLY"
+	ImporterValidateOnly = "IMPORTER_VALIDATE_ONLY"
+	// ImporterSparseThreshold provides a constant to capture our env variable "IMPORTER_SPARSE_THRESHOLD"
+	ImporterSparseThreshold = "IMPORTER_SPARSE_THRESHOLD"
+	// ImporterCoroutines provides a constant to capture our env variable "IMPORTER_COROUTINES"
+	ImporterCoroutines = "IMPORTER_COROUTINES"
+	// ImporterPreallocationMethod provides a constant to capture our env variable "IMPORTER_PREALLOCATION_METHOD"
+	ImporterPreallocationMethod = "IMPORTER_PREALLOCATION_METHOD"
+	// ImporterExpandFilesystem provides a constant to capture our env variable "IMPORTER_EXPAND_FILESYSTEM"
+	ImporterExpandFilesystem = "IMPORTER_EXPAND_FILESYSTEM"
+	// ImporterVerify provides a constant to capture our env variable "IMPORTER_VERIFY"
+	ImporterVerify = "IMPORTER_VERIFY"
+	// ImporterVerifyChecksum provides a constant to capture our env variable "IMPORTER_VERIFY_CHECKSUM"
+	ImporterVerifyChecksum = "IMPORTER_VERIFY_CHECKSUM"
+	// ImporterLayerCacheMaxSize provides a constant to capture our env variable "IMPORTER_LAYER_CACHE_MAX_SIZE"
+	ImporterLayerCacheMaxSize = "IMPORTER_LAYER_CACHE_MAX_SIZE"
+	// ImporterLayerCacheDir provides a constant to capture the mount Dir of the registry layer cache of the node
+	ImporterLayerCacheDir = "/layer-cache"
 
 	// ImporterGoogleCredentialFileVar provides a constant to capture our env variable "GOOGLE_APPLICATION_CREDENTIALS"
 	//nolint:gosec // This is not a real credential
@@ -175,6 +262,147 @@ const (
 	//nolint:gosec // This is not the credential itself
 	ImporterGoogleCredentialFile = "/google/credentials.json"
 
+	// ImporterAzureCredentialDirVar provides a constant to capture our env variable "IMPORTER_AZURE_CREDENTIAL_DIR"
+	ImporterAzureCredentialDirVar = "IMPORTER_AZURE_CREDENTIAL_DIR"
+	// ImporterAzureCredentialDir provides a constant to capture our Azure secret mount Dir
+	ImporterAzureCredentialDir = "/azure"
+	// KeyAzureSASToken provides a constant to the sasToken key of an Azure secret
+	//nolint:gosec // This is not a real credential
+	KeyAzureSASToken = "sasToken"
+	// KeyAzureTenantID provides a constant to the tenantId key of an Azure secret
+	KeyAzureTenantID = "tenantId"
+	// KeyAzureClientID provides a constant to the clientId key of an Azure secret
+	KeyAzureClientID = "clientId"
+	// KeyAzureClientSecret provides a constant to the clientSecret key of an Azure secret
+	//nolint:gosec // This is not a real credential
+	KeyAzureClientSecret = "clientSecret"
+
+	// ImporterSFTPCredentialDirVar provides a constant to capture our env variable "IMPORTER_SFTP_CREDENTIAL_DIR"
+	ImporterSFTPCredentialDirVar = "IMPORTER_SFTP_CREDENTIAL_DIR"
+	// ImporterSFTPCredentialDir provides a constant to capture our SFTP secret mount Dir
+	ImporterSFTPCredentialDir = "/sftp"
+	// KeySFTPPassword provides a constant to the password key of an SFTP secret
+	//nolint:gosec // This is not a real credential
+	KeySFTPPassword = "password"
+	// KeySFTPPrivateKey provides a constant to the ssh-privatekey key of an SFTP secret, as in kubernetes.io/ssh-auth secrets
+	//nolint:gosec // This is not a real credential
+	KeySFTPPrivateKey = "ssh-privatekey"
+	// KeySFTPKnownHosts provides a constant to the known_hosts key of an SFTP secret
+	KeySFTPKnownHosts = "known_hosts"
+
+	// ImporterGlanceCredentialDirVar provides a constant to capture our env variable "IMPORTER_GLANCE_CREDENTIAL_DIR"
+	ImporterGlanceCredentialDirVar = "IMPORTER_GLANCE_CREDENTIAL_DIR"
+	// ImporterGlanceCredentialDir provides a constant to capture our Glance secret mount Dir
+	ImporterGlanceCredentialDir = "/glance"
+	// ImporterGlanceImage provides a constant to capture our env variable "IMPORTER_GLANCE_IMAGE"
+	ImporterGlanceImage = "IMPORTER_GLANCE_IMAGE"
+	// KeyGlanceApplicationCredentialID provides a constant to the applicationCredentialId key of a Glance secret
+	KeyGlanceApplicationCredentialID = "applicationCredentialId"
+	// KeyGlanceApplicationCredentialSecret provides a constant to the applicationCredentialSecret key of a Glance secret
+	//nolint:gosec // This is not a real credential
+	KeyGlanceApplicationCredentialSecret = "applicationCredentialSecret"
+	// KeyGlanceUsername provides a constant to the username key of a Glance secret
+	KeyGlanceUsername = "username"
+	// KeyGlancePassword provides a constant to the password key of a Glance secret
+	//nolint:gosec // This is not a real credential
+	KeyGlancePassword = "password"
+	// KeyGlanceUserDomainName provides a constant to the userDomainName key of a Glance secret
+	KeyGlanceUserDomainName = "userDomainName"
+	// KeyGlanceProjectName provides a constant to the projectName key of a Glance secret
+	KeyGlanceProjectName = "projectName"
+	// KeyGlanceProjectDomainName provides a constant to the projectDomainName key of a Glance secret
+	KeyGlanceProjectDomainName = "projectDomainName"
+	// KeyGlanceRegionName provides a constant to the regionName key of a Glance secret
+	KeyGlanceRegionName = "regionName"
+
+	// ImporterRBDCredentialDirVar provides a constant to capture our env variable "IMPORTER_RBD_CREDENTIAL_DIR"
+	ImporterRBDCredentialDirVar = "IMPORTER_RBD_CREDENTIAL_DIR"
+	// ImporterRBDCredentialDir provides a constant to capture our RBD secret mount Dir
+	ImporterRBDCredentialDir = "/rbd"
+	// ImporterRBDImage provides a constant to capture our env variable "IMPORTER_RBD_IMAGE"
+	ImporterRBDImage = "IMPORTER_RBD_IMAGE"
+	// KeyRBDKeyring provides a constant to the keyring key of an RBD secret
+	KeyRBDKeyring = "keyring"
+	// KeyRBDUserID provides a constant to the userID key of an RBD secret
+	KeyRBDUserID = "userID"
+
+	// ImporterHostPathDir provides a constant to capture our hostPath source mount Dir
+	ImporterHostPathDir = "/hostpath"
+	// ImporterSMBDir provides a constant to capture our SMB source share mount Dir
+	ImporterSMBDir = "/smb"
+
+	// ImporterSourceDataVolumeDir provides a constant to capture the mount Dir of the filesystem PVC of a source DataVolume
+	ImporterSourceDataVolumeDir = "/source-datavolume"
+	// ImporterSourceDataVolumeDevice provides a constant to capture the device path of the block PVC of a source DataVolume
+	ImporterSourceDataVolumeDevice = "/dev/cdi-source-block-volume"
+	// ImporterSparsify provides a constant to capture our env variable "IMPORTER_SPARSIFY"
+	ImporterSparsify = "IMPORTER_SPARSIFY"
+	// ImporterEncryptionPassphraseFile provides a constant to capture our env variable "IMPORTER_ENCRYPTION_PASSPHRASE_FILE"
+	ImporterEncryptionPassphraseFile = "IMPORTER_ENCRYPTION_PASSPHRASE_FILE"
+	// ImporterEncryptionDir provides a constant to capture our encryption secret mount Dir
+	ImporterEncryptionDir = "/encryption"
+	// KeyEncryptionPassphrase provides a constant to the passphrase key of an encryption secret
+	KeyEncryptionPassphrase = "passphrase"
+
+	// ImporterExportCredentialDirVar provides a constant to capture our env variable "IMPORTER_EXPORT_CREDENTIAL_DIR"
+	ImporterExportCredentialDirVar = "IMPORTER_EXPORT_CREDENTIAL_DIR"
+	// ImporterExportCredentialDir provides a constant to capture our export secret mount Dir
+	ImporterExportCredentialDir = "/export"
+	// KeyExportToken provides a constant to the token key of an export secret
+	KeyExportToken = "token"
+	// KeyExportClientCert provides a constant to the tls.crt key of an export secret
+	KeyExportClientCert = "tls.crt"
+	// KeyExportClientKey provides a constant to the tls.key key of an export secret
+	KeyExportClientKey = "tls.key"
+
+	// ImporterV2VVM provides a constant to capture our env variable "IMPORTER_V2V_VM"
+	ImporterV2VVM = "IMPORTER_V2V_VM"
+	// ImporterV2VDiskIndex provides a constant to capture our env variable "IMPORTER_V2V_DISK_INDEX"
+	ImporterV2VDiskIndex = "IMPORTER_V2V_DISK_INDEX"
+	// ImporterV2VBlockDriver provides a constant to capture our env variable "IMPORTER_V2V_BLOCK_DRIVER"
+	ImporterV2VBlockDriver = "IMPORTER_V2V_BLOCK_DRIVER"
+	// ImporterV2VMACMappings provides a constant to capture our env variable "IMPORTER_V2V_MAC_MAPPINGS"
+	ImporterV2VMACMappings = "IMPORTER_V2V_MAC_MAPPINGS"
+	// ImporterV2VVirtioWin provides a constant to capture our env variable "IMPORTER_V2V_VIRTIO_WIN"
+	ImporterV2VVirtioWin = "IMPORTER_V2V_VIRTIO_WIN"
+	// ImporterV2VVirtioWinDir provides a constant to capture our virtio-win PVC mount Dir
+	ImporterV2VVirtioWinDir = "/virtio-win"
+
+	// ImporterProxmoxCredentialDirVar provides a constant to capture our env variable "IMPORTER_PROXMOX_CREDENTIAL_DIR"
+	ImporterProxmoxCredentialDirVar = "IMPORTER_PROXMOX_CREDENTIAL_DIR"
+	// ImporterProxmoxCredentialDir provides a constant to capture our Proxmox secret mount Dir
+	ImporterProxmoxCredentialDir = "/proxmox"
+	// ImporterProxmoxNode provides a constant to capture our env variable "IMPORTER_PROXMOX_NODE"
+	ImporterProxmoxNode = "IMPORTER_PROXMOX_NODE"
+	// ImporterProxmoxVMID provides a constant to capture our env variable "IMPORTER_PROXMOX_VMID"
+	ImporterProxmoxVMID = "IMPORTER_PROXMOX_VMID"
+	// ImporterProxmoxDisk provides a constant to capture our env variable "IMPORTER_PROXMOX_DISK"
+	ImporterProxmoxDisk = "IMPORTER_PROXMOX_DISK"
+	// KeyProxmoxTokenID provides a constant to the tokenID key of a Proxmox secret, like root@pam!cdi
+	KeyProxmoxTokenID = "tokenID"
+	// KeyProxmoxTokenSecret provides a constant to the tokenSecret key of a Proxmox secret
+	KeyProxmoxTokenSecret = "tokenSecret"
+	// KeyProxmoxSSHUser provides a constant to the optional sshUser key of a Proxmox secret, root if unset
+	KeyProxmoxSSHUser = "sshUser"
+
+	// ImporterPluginParameters provides a constant to capture our env variable "IMPORTER_PLUGIN_PARAMETERS", the JSON encoded parameters of a source plugin
+	ImporterPluginParameters = "IMPORTER_PLUGIN_PARAMETERS"
+	// ImporterPluginSocketDir provides a constant to capture the Dir shared with the source plugin container, holding its socket
+	ImporterPluginSocketDir = "/plugin"
+	// ImporterPluginSocket is the unix socket the source plugin serves the image on
+	ImporterPluginSocket = ImporterPluginSocketDir + "/plugin.sock"
+	// ImporterPluginSecretDir provides a constant to capture the secret mount Dir of the source plugin container
+	ImporterPluginSecretDir = "/plugin-secret"
+	// PluginSocketVar provides a constant to capture the env variable "CDI_PLUGIN_SOCKET" of the source plugin container
+	PluginSocketVar = "CDI_PLUGIN_SOCKET"
+	// PluginSecretDirVar provides a constant to capture the env variable "CDI_PLUGIN_SECRET_DIR" of the source plugin container
+	PluginSecretDirVar = "CDI_PLUGIN_SECRET_DIR"
+
+	// ImporterTorrentWebSeeds provides a constant to capture our env variable "IMPORTER_TORRENT_WEB_SEEDS", the space separated web seeds of a torrent
+	ImporterTorrentWebSeeds = "IMPORTER_TORRENT_WEB_SEEDS"
+	// ImporterTorrentFile provides a constant to capture our env variable "IMPORTER_TORRENT_FILE"
+	ImporterTorrentFile = "IMPORTER_TORRENT_FILE"
+
 	// CloningLabelValue provides a constant to use as a label value for pod affinity (controller pkg only)
 	CloningLabelValue = "host-assisted-cloning"
 	// CloningTopologyKey  (controller pkg only)
@@ -183,6 +411,8 @@ const (
 	ClonerSourcePodName = "cdi-clone-source"
 	// ClonerMountPath (controller pkg only)
 	ClonerMountPath = "/var/run/cdi/clone/source"
+	// ClonerTargetMountPath (controller pkg only)
+	ClonerTargetMountPath = "/var/run/cdi/clone/target"
 	// ClonerSourcePodNameSuffix (controller pkg only)
 	ClonerSourcePodNameSuffix = "-source-pod"
 
@@ -209,6 +439,39 @@ const (
 	UploadServerServiceLabel = "service"
 	// UploadImageSize provides a constant to capture our env variable "UPLOAD_IMAGE_SIZE"
 	UploadImageSize = "UPLOAD_IMAGE_SIZE"
+	// UploadStreamingConversion provides a constant to capture our env variable "UPLOAD_STREAMING_CONVERSION"
+	UploadStreamingConversion = "UPLOAD_STREAMING_CONVERSION"
+	// UploadStorageFormat provides a constant to capture our env variable "UPLOAD_STORAGE_FORMAT", the format clones
+	// converting the image store it in
+	UploadStorageFormat = "UPLOAD_STORAGE_FORMAT"
+
+	// ExporterPodName is the label applied to the exporter pods of DataExports
+	ExporterPodName = "cdi-exporter"
+	// ExporterSourcePath provides a constant to capture our env variable "EXPORTER_SOURCE_PATH"
+	ExporterSourcePath = "EXPORTER_SOURCE_PATH"
+	// ExporterSourceFormat provides a constant to capture our env variable "EXPORTER_SOURCE_FORMAT"
+	ExporterSourceFormat = "EXPORTER_SOURCE_FORMAT"
+	// ExporterTarget provides a constant to capture our env variable "EXPORTER_TARGET"
+	ExporterTarget = "EXPORTER_TARGET"
+	// ExporterEndpoint provides a constant to capture our env variable "EXPORTER_ENDPOINT"
+	ExporterEndpoint = "EXPORTER_ENDPOINT"
+	// ExporterCompress provides a constant to capture our env variable "EXPORTER_COMPRESS"
+	ExporterCompress = "EXPORTER_COMPRESS"
+	// ExporterAccessKeyID provides a constant to capture our env variable "EXPORTER_ACCESS_KEY_ID"
+	ExporterAccessKeyID = "EXPORTER_ACCESS_KEY_ID"
+	// ExporterSecretKey provides a constant to capture our env variable "EXPORTER_SECRET_KEY"
+	ExporterSecretKey = "EXPORTER_SECRET_KEY"
+	// ExporterCertDirVar provides a constant to capture our env variable "EXPORTER_CERT_DIR"
+	ExporterCertDirVar = "EXPORTER_CERT_DIR"
+
+	// HookDataPathVar provides a constant to capture the env variable "CDI_DATA_PATH" of post completion hook jobs
+	HookDataPathVar = "CDI_DATA_PATH"
+	// HookDataVolumeNameVar provides a constant to capture the env variable "CDI_DATAVOLUME_NAME" of post completion hook jobs
+	HookDataVolumeNameVar = "CDI_DATAVOLUME_NAME"
+	// HookDataVolumeNamespaceVar provides a constant to capture the env variable "CDI_DATAVOLUME_NAMESPACE" of post completion hook jobs
+	HookDataVolumeNamespaceVar = "CDI_DATAVOLUME_NAMESPACE"
+	// HookNameVar provides a constant to capture the env variable "CDI_HOOK_NAME" of post completion hook jobs
+	HookNameVar = "CDI_HOOK_NAME"
 
 	// FilesystemOverheadVar provides a constant to capture our env variable "FILESYSTEM_OVERHEAD"
 	FilesystemOverheadVar = "FILESYSTEM_OVERHEAD"
@@ -225,6 +488,12 @@ const (
 	KeyAccess = "accessKeyId"
 	// KeySecret provides a constant to the secretKey label using in controller pkg and transport_test.go
 	KeySecret = "secretKey"
+	// KeySessionToken provides a constant to the optional S3 sessionToken secret label
+	KeySessionToken = "sessionToken"
+	// KeyRoleARN provides a constant to the optional S3 roleArn secret label, the role is assumed with STS
+	KeyRoleARN = "roleArn"
+	// KeyExternalID provides a constant to the optional S3 externalId secret label, passed when assuming the role
+	KeyExternalID = "externalId"
 
 	// DefaultResyncPeriod sets a 10 minute resync period, used in the controller pkg and the controller cmd executable
 	DefaultResyncPeriod = 10 * time.Minute
@@ -235,6 +504,20 @@ const (
 	// UploadTokenIssuer is the JWT issuer of upload tokens
 	UploadTokenIssuer = "cdi-apiserver"
 
+	// UploadTokenLifetime is the lifetime of upload tokens
+	UploadTokenLifetime = 5 * time.Minute
+
+	// UploadTokenSessionExpiryParam is the upload token param holding the end of the upload session of renewable tokens,
+	// in RFC 3339 format
+	UploadTokenSessionExpiryParam = "sessionExpiry"
+
+	// UploadTokenUIDParam is the upload token param holding the UID of the PVC of renewable tokens
+	UploadTokenUIDParam = "uid"
+
+	// AnnUploadCertRotationRequested is the CDIConfig annotation holding the time of the last rotation of the upload
+	// server certs requested, in RFC 3339 format. The certs issued before are rotated.
+	AnnUploadCertRotationRequested = "cdi.kubevirt.io/uploadCertRotationRequested"
+
 	// CloneTokenIssuer is the JWT issuer for clone tokens
 	CloneTokenIssuer = "cdi-apiserver"
 
@@ -256,6 +539,10 @@ const (
 	VddkConfigDataKey = "vddk-init-image"
 	// AwaitingVDDK is a Pending condition reason that indicates the PVC is waiting for a VDDK image
 	AwaitingVDDK = "AwaitingVDDK"
+	// SourcePluginConfigMap is the name of the ConfigMap registering the images of the source plugins by plugin name
+	SourcePluginConfigMap = "cdi-source-plugins"
+	// AwaitingSourcePlugin is a Pending condition reason that indicates the PVC is waiting for its source plugin to be registered
+	AwaitingSourcePlugin = "AwaitingSourcePlugin"
 	// VddkArgsDir is the path to the volume mount containing extra VDDK arguments
 	VddkArgsDir = "/vddk-args"
 	// VddkArgsVolName is the name of the volume referencing the extra VDDK arguments ConfigMap
@@ -265,13 +552,44 @@ const (
 
 	// UploadContentTypeHeader is the header upload clients may use to set the content type explicitly
 	UploadContentTypeHeader = "x-cdi-content-type"
+	// UploadSHA256Header is the header or trailer upload clients may use to send the sha256 digest of the data they upload,
+	// checked by the upload server
+	UploadSHA256Header = "x-cdi-upload-sha256"
+	// UploadOVADiskHeader is the header upload clients may use to name the disk of the OVA they upload to import, by its
+	// index in the OVF descriptor, its disk id or the name of its file. The first disk of OVAs is imported without it.
+	UploadOVADiskHeader = "x-cdi-upload-ova-disk"
+	// UploadPreferHeader is the header upload clients may set to UploadPreferRespondAsync to get the response of their
+	// upload once the upload server received the data, before it is converted
+	UploadPreferHeader = "Prefer"
+	// UploadPreferRespondAsync is the preference of the clients of asynchronous finalization, answered with 202 Accepted
+	UploadPreferRespondAsync = "respond-async"
+	// UploadPreferenceAppliedHeader is the header of the responses applying the preference of the client
+	UploadPreferenceAppliedHeader = "Preference-Applied"
 
 	// FilesystemCloneContentType is the content type when cloning a filesystem
 	FilesystemCloneContentType = "filesystem-clone"
 
+	// FilesystemRsyncCloneContentType is the content type when cloning a filesystem with rsync
+	FilesystemRsyncCloneContentType = "filesystem-rsync-clone"
+
+	// RsyncUpgradeProtocol is the protocol the rsync clone requests upgrade their connection to, tunnelling the rsync
+	// protocol between the clone source and the upload server
+	RsyncUpgradeProtocol = "cdi-rsync"
+
+	// RsyncArgsHeader is the header of the rsync clone requests holding the JSON encoded command of the remote rsync
+	RsyncArgsHeader = "x-cdi-rsync-args"
+
 	// BlockdeviceClone is the content type when cloning a block device
 	BlockdeviceClone = "blockdevice-clone"
 
+	// ImageCloneContentType is the content type when cloning the disk image of a volume to a volume of the other volume
+	// mode, converted onto the target by the upload server
+	ImageCloneContentType = "image-clone"
+
+	// RawDirectContentType is the content type of uploads of raw data sized for the target, written directly to the block
+	// device without conversion
+	RawDirectContentType = "raw-direct"
+
 	// UploadPathSync is the path to POST CDI uploads
 	UploadPathSync = "/v1beta1/upload"
 
@@ -290,9 +608,54 @@ const (
 	// UploadFormAsync is the path to POST CDI uploads as form data in async mode
 	UploadFormAsync = "/v1beta1/upload-form-async"
 
+	// UploadTusPath is the path of CDI resumable uploads with the tus protocol
+	UploadTusPath = "/v1beta1/upload-tus"
+
+	// UploadTusArchivePath is the path of CDI resumable uploads of archives with the tus protocol
+	UploadTusArchivePath = "/v1beta1/upload-archive-tus"
+
+	// UploadParallelPath is the path of CDI uploads sending parts of the data with concurrent requests
+	UploadParallelPath = "/v1beta1/upload-parallel"
+
+	// UploadParallelArchivePath is the path of CDI uploads of archives sending parts of the data with concurrent requests
+	UploadParallelArchivePath = "/v1beta1/upload-archive-parallel"
+
+	// UploadMultipartPath is the path of CDI uploads sending numbered parts of the data, concatenated once completed
+	UploadMultipartPath = "/v1beta1/upload-multipart"
+
+	// UploadMultipartArchivePath is the path of CDI uploads of archives sending numbered parts of the data, concatenated
+	// once completed
+	UploadMultipartArchivePath = "/v1beta1/upload-archive-multipart"
+
+	// UploadS3Path is the path of the S3-compatible endpoint of the upload proxy, taking the namespace of the PVC as
+	// bucket and its name as key
+	UploadS3Path = "/v1beta1/s3"
+
+	// UploadWebsocketPath is the path of the synchronous CDI uploads of browsers sending the data over a websocket
+	UploadWebsocketPath = "/v1beta1/upload-websocket"
+
+	// UploadTokenQueryParam is the query parameter of the upload token of the clients which can't set headers
+	UploadTokenQueryParam = "token"
+
+	// UploadSHA256QueryParam is the query parameter of the sha256 digest of the websocket uploads
+	UploadSHA256QueryParam = "sha256"
+
+	// UploadProgressPath is the path to GET the progress of the upload
+	UploadProgressPath = "/v1beta1/upload-progress"
+
+	// UploadTokenRefreshPath is the path to POST to renew the upload token of an upload session
+	UploadTokenRefreshPath = "/v1beta1/upload-token-refresh"
+
 	// PreallocationApplied is a string inserted into importer's/uploader's exit message
 	PreallocationApplied = "Preallocation applied"
 
+	// PreallocationMethodFalloc preallocates with fallocate
+	PreallocationMethodFalloc = "falloc"
+	// PreallocationMethodFull preallocates by writing zeroes
+	PreallocationMethodFull = "full"
+	// PreallocationMethodZero preallocates by writing the zeroes of the image instead of leaving it sparse
+	PreallocationMethodZero = "zero"
+
 	// ScratchSpaceRequired is a string inserted into a pod exist message when scratch space is needed
 	ScratchSpaceRequired = "scratch space required and none found"
 
@@ -312,6 +675,18 @@ const (
 	// both to create and to later check the error in the termination text of the importer pod.
 	ImagePullFailureText = "failed to pull image"
 
+	// ChecksumMismatchText is the text of the error of an import not matching the checksum of its source, used both to
+	// create and to later check the error in the termination text of the importer pod.
+	ChecksumMismatchText = "checksum mismatch"
+
+	// SignatureVerificationFailureText is the text of the error of an import not signed by a key of the keyring of its
+	// source, used both to create and to later check the error in the termination text of the importer pod.
+	SignatureVerificationFailureText = "signature verification failed"
+
+	// ImageVerificationFailureText is the text of the error of a registry import not meeting the image verification
+	// policies of its image, used both to create and to later check the error in the termination text of the importer pod.
+	ImageVerificationFailureText = "image verification failed"
+
 	// The restricted SCC and particularly v2 is considered best practice for workloads that can manage without extended privileges
 	RestrictedSCCName = "restricted-v2"
 )
@@ -358,14 +733,52 @@ type VddkInfo struct {
 	Host    string
 }
 
+// ImportPhase is a phase of the importer whose duration is reported in the status of the DataVolume
+type ImportPhase string
+
+const (
+	// ImportPhaseDownload is the phase reading the source of the import
+	ImportPhaseDownload ImportPhase = "download"
+	// ImportPhaseConvert is the phase converting the image to raw
+	ImportPhaseConvert ImportPhase = "convert"
+	// ImportPhaseResize is the phase resizing the image to the size of the PVC
+	ImportPhaseResize ImportPhase = "resize"
+	// ImportPhaseVerify is the phase validating the image before it is written, and verifying it once it is
+	ImportPhaseVerify ImportPhase = "verify"
+)
+
 // TerminationMessage contains data to be serialized and used as the termination message of the importer.
 type TerminationMessage struct {
-	ScratchSpaceRequired *bool             `json:"scratchSpaceRequired,omitempty"`
-	PreallocationApplied *bool             `json:"preallocationApplied,omitempty"`
-	DeadlinePassed       *bool             `json:"deadlinePassed,omitempty"`
-	VddkInfo             *VddkInfo         `json:"vddkInfo,omitempty"`
-	Labels               map[string]string `json:"labels,omitempty"`
-	Message              *string           `json:"message,omitempty"`
+	ScratchSpaceRequired *bool                  `json:"scratchSpaceRequired,omitempty"`
+	PreallocationApplied *bool                  `json:"preallocationApplied,omitempty"`
+	PreallocationMethod  *string                `json:"preallocationMethod,omitempty"`
+	DeadlinePassed       *bool                  `json:"deadlinePassed,omitempty"`
+	VddkInfo             *VddkInfo              `json:"vddkInfo,omitempty"`
+	Labels               map[string]string      `json:"labels,omitempty"`
+	Message              *string                `json:"message,omitempty"`
+	SourceDigest         *string                `json:"sourceDigest,omitempty"`
+	DetectedContentType  *string                `json:"detectedContentType,omitempty"`
+	UploadDigest         *string                `json:"uploadDigest,omitempty"`
+	PhaseDurations       map[ImportPhase]string `json:"phaseDurations,omitempty"`
+	SourceValidation     *SourceValidation      `json:"sourceValidation,omitempty"`
+	FailureReason        *string                `json:"failureReason,omitempty"`
+	FailureClass         *string                `json:"failureClass,omitempty"`
+	ObservedSourceDigest *string                `json:"observedSourceDigest,omitempty"`
+	ImportedPlatform     *ImportedPlatform      `json:"importedPlatform,omitempty"`
+}
+
+// ImportedPlatform is the platform of the registry image copied by an import
+type ImportedPlatform struct {
+	Architecture string `json:"architecture,omitempty"`
+	Digest       string `json:"digest,omitempty"`
+}
+
+// SourceValidation is the result of the validation of the source of a validate only import
+type SourceValidation struct {
+	Valid       bool   `json:"valid"`
+	Format      string `json:"format,omitempty"`
+	VirtualSize *int64 `json:"virtualSize,omitempty"`
+	Message     string `json:"message,omitempty"`
 }
 
 func (it *TerminationMessage) String() (string, error) {
@@ -382,6 +795,46 @@ func (it *TerminationMessage) String() (string, error) {
 	return string(msg), nil
 }
 
+// UploadPhase is the phase of an upload reported by the upload progress endpoint
+type UploadPhase string
+
+const (
+	// UploadPhaseWaiting means the upload server waits for the data of the upload
+	UploadPhaseWaiting UploadPhase = "Waiting"
+	// UploadPhaseUploading means the upload server receives the data of the upload
+	UploadPhaseUploading UploadPhase = "Uploading"
+	// UploadPhaseProcessing means the upload server received the data of the upload and converts it
+	UploadPhaseProcessing UploadPhase = "Processing"
+	// UploadPhaseComplete means the data of the upload was written to the PVC
+	UploadPhaseComplete UploadPhase = "Complete"
+)
+
+// UploadProgress contains data to be serialized and used as the body of responses to the upload progress endpoint of
+// the upload proxy.
+type UploadProgress struct {
+	Phase UploadPhase `json:"phase"`
+	// BytesReceived is the number of bytes of the upload received by the upload server
+	BytesReceived int64 `json:"bytesReceived"`
+	// BytesTotal is the size of the upload, 0 if the client did not send it
+	BytesTotal int64 `json:"bytesTotal,omitempty"`
+	// ConversionProgress is the percentage of the image converted to the PVC, only reported for converted images
+	ConversionProgress float64 `json:"conversionProgress"`
+}
+
+// UploadMultipartCompletion contains data to be serialized and used as the body of requests completing multipart
+// uploads.
+type UploadMultipartCompletion struct {
+	// Parts are the parts of the upload, in the order of the data
+	Parts []UploadMultipartPart `json:"parts"`
+}
+
+// UploadMultipartPart is a part of a multipart upload
+type UploadMultipartPart struct {
+	PartNumber int `json:"partNumber"`
+	// ETag is the ETag returned when the part was uploaded, checked if set
+	ETag string `json:"etag,omitempty"`
+}
+
 // ServerInfo contains data to be serialized and used as the body of responses to the info endpoint of the containerimage-server.
 type ServerInfo struct {
 	Env []string `json:"env,omitempty"`
@@ -2,8 +2,8 @@ package controller
 
 import (
 	"context"
-	"crypto/rsa"
 	"fmt"
+	"net/url"
 	"reflect"
 	"strconv"
 	"strings"
@@ -33,6 +33,7 @@ import (
 	"kubevirt.io/containerized-data-importer/pkg/common"
 	cc "kubevirt.io/containerized-data-importer/pkg/controller/common"
 	"kubevirt.io/containerized-data-importer/pkg/operator"
+	"kubevirt.io/containerized-data-importer/pkg/token"
 	"kubevirt.io/containerized-data-importer/pkg/util"
 	"kubevirt.io/containerized-data-importer/pkg/util/cert/fetcher"
 	"kubevirt.io/containerized-data-importer/pkg/util/cert/generator"
@@ -80,17 +81,17 @@ func NewCloneController(mgr manager.Manager,
 	verbose string,
 	clientCertGenerator generator.CertGenerator,
 	serverCAFetcher fetcher.CertBundleFetcher,
-	apiServerKey *rsa.PublicKey,
+	tokenKeys token.KeySet,
 	installerLabels map[string]string) (controller.Controller, error) {
 	reconciler := &CloneReconciler{
 		client:              mgr.GetClient(),
 		scheme:              mgr.GetScheme(),
 		log:                 log.WithName("clone-controller"),
-		multiTokenValidator: cc.NewMultiTokenValidator(apiServerKey),
+		multiTokenValidator: cc.NewMultiTokenValidator(tokenKeys, cc.NewTokenConfigFunc(mgr.GetClient())),
 		image:               image,
 		verbose:             verbose,
 		pullPolicy:          pullPolicy,
-		recorder:            mgr.GetEventRecorderFor("clone-controller"),
+		recorder:            cc.NewEventAggregator(mgr.GetEventRecorderFor("clone-controller"), mgr.GetClient()),
 		clientCertGenerator: clientCertGenerator,
 		serverCAFetcher:     serverCAFetcher,
 		installerLabels:     installerLabels,
@@ -230,40 +231,17 @@ func (r *CloneReconciler) Reconcile(ctx context.Context, req reconcile.Request)
 
 func (r *CloneReconciler) reconcileSourcePod(ctx context.Context, sourcePod *corev1.Pod, targetPvc *corev1.PersistentVolumeClaim, log logr.Logger) (time.Duration, error) {
 	if sourcePod == nil {
-		sourcePvc, err := r.getCloneRequestSourcePVC(targetPvc)
-		if err != nil {
-			return 0, err
-		}
-
-		sourcePopulated, err := cc.IsPopulated(sourcePvc, r.client)
-		if err != nil {
-			return 0, err
-		}
-		if !sourcePopulated {
-			return 2 * time.Second, nil
-		}
-
-		if err := r.validateSourceAndTarget(ctx, sourcePvc, targetPvc); err != nil {
-			return 0, err
+		// the source pod downloads the volumes exported by other clusters, there is no source pvc to wait for
+		if !isExportClone(targetPvc) {
+			if requeueAfter, err := r.waitSourcePVCAvailable(ctx, targetPvc); requeueAfter != 0 || err != nil {
+				return requeueAfter, err
+			}
 		}
 
-		pods, err := cc.GetPodsUsingPVCs(ctx, r.client, sourcePvc.Namespace, sets.New(sourcePvc.Name), true)
-		if err != nil {
-			return 0, err
-		}
-
-		if len(pods) > 0 {
-			es, err := cc.GetAnnotatedEventSource(ctx, r.client, targetPvc)
-			if err != nil {
-				return 0, err
-			}
-			for _, pod := range pods {
-				r.log.V(1).Info("can't create clone source pod, pvc in use by other pod",
-					"namespace", sourcePvc.Namespace, "name", sourcePvc.Name, "pod", pod.Name)
-				r.recorder.Eventf(es, corev1.EventTypeWarning, cc.CloneSourceInUse,
-					"pod %s/%s using PersistentVolumeClaim %s", pod.Namespace, pod.Name, sourcePvc.Name)
-			}
-			return 2 * time.Second, nil
+		_, sourceNamespace, _ := ParseCloneRequestAnnotation(targetPvc)
+		podKey := types.NamespacedName{Namespace: sourceNamespace, Name: targetPvc.Annotations[cc.AnnCloneSourcePod]}
+		if queued, err := queueTransfer(ctx, r.client, r.recorder, targetPvc, podKey, log); err != nil || queued {
+			return transferQueuedRequeue, err
 		}
 
 		sourcePod, err := r.CreateCloneSourcePod(r.image, r.pullPolicy, targetPvc, log)
@@ -277,6 +255,48 @@ func (r *CloneReconciler) reconcileSourcePod(ctx context.Context, sourcePod *cor
 	return 0, nil
 }
 
+// waitSourcePVCAvailable validates the source pvc of the clone, and returns when to check it again if it is not populated
+// yet or used by other pods
+func (r *CloneReconciler) waitSourcePVCAvailable(ctx context.Context, targetPvc *corev1.PersistentVolumeClaim) (time.Duration, error) {
+	sourcePvc, err := r.getCloneRequestSourcePVC(targetPvc)
+	if err != nil {
+		return 0, err
+	}
+
+	sourcePopulated, err := cc.IsPopulated(sourcePvc, r.client)
+	if err != nil {
+		return 0, err
+	}
+	if !sourcePopulated {
+		return 2 * time.Second, nil
+	}
+
+	if err := r.validateSourceAndTarget(ctx, sourcePvc, targetPvc); err != nil {
+		return 0, err
+	}
+
+	pods, err := cc.GetPodsUsingPVCs(ctx, r.client, sourcePvc.Namespace, sets.New(sourcePvc.Name), true)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(pods) > 0 {
+		es, err := cc.GetAnnotatedEventSource(ctx, r.client, targetPvc)
+		if err != nil {
+			return 0, err
+		}
+		for _, pod := range pods {
+			r.log.V(1).Info("can't create clone source pod, pvc in use by other pod",
+				"namespace", sourcePvc.Namespace, "name", sourcePvc.Name, "pod", pod.Name)
+			r.recorder.Eventf(es, corev1.EventTypeWarning, cc.CloneSourceInUse,
+				"pod %s/%s using PersistentVolumeClaim %s", pod.Namespace, pod.Name, sourcePvc.Name)
+		}
+		return 2 * time.Second, nil
+	}
+
+	return 0, nil
+}
+
 func (r *CloneReconciler) ensureCertSecret(sourcePod *corev1.Pod, targetPvc *corev1.PersistentVolumeClaim) error {
 	if sourcePod == nil {
 		return nil
@@ -508,20 +528,37 @@ func (r *CloneReconciler) CreateCloneSourcePod(image, pullPolicy string, pvc *co
 		return nil, err
 	}
 
-	sourcePvc, err := r.getCloneRequestSourcePVC(pvc)
+	injection, err := cc.GetTransferPodInjection(context.TODO(), r.client)
 	if err != nil {
 		return nil, err
 	}
 
-	var sourceVolumeMode corev1.PersistentVolumeMode
-	if sourcePvc.Spec.VolumeMode != nil {
-		sourceVolumeMode = *sourcePvc.Spec.VolumeMode
-	} else {
-		sourceVolumeMode = corev1.PersistentVolumeFilesystem
+	priorityClassName, err := cc.GetTransferPodPriorityClass(context.TODO(), r.client, cc.GetPriorityClass(pvc), cc.CloneSourcePriorityClass)
+	if err != nil {
+		return nil, err
 	}
 
-	pod := MakeCloneSourcePodSpec(sourceVolumeMode, image, pullPolicy, ownerKey, imagePullSecrets, serverCABundle, pvc, sourcePvc, podResourceRequirements, workloadNodePlacement)
+	var pod *corev1.Pod
+	if isExportClone(pvc) {
+		pod = MakeExportCloneSourcePodSpec(image, pullPolicy, ownerKey, imagePullSecrets, serverCABundle, pvc, podResourceRequirements, workloadNodePlacement)
+	} else {
+		sourcePvc, err := r.getCloneRequestSourcePVC(pvc)
+		if err != nil {
+			return nil, err
+		}
+
+		var sourceVolumeMode corev1.PersistentVolumeMode
+		if sourcePvc.Spec.VolumeMode != nil {
+			sourceVolumeMode = *sourcePvc.Spec.VolumeMode
+		} else {
+			sourceVolumeMode = corev1.PersistentVolumeFilesystem
+		}
+
+		pod = MakeCloneSourcePodSpec(sourceVolumeMode, image, pullPolicy, ownerKey, imagePullSecrets, serverCABundle, pvc, sourcePvc, podResourceRequirements, workloadNodePlacement)
+	}
+	pod.Spec.PriorityClassName = priorityClassName
 	util.SetRecommendedLabels(pod, r.installerLabels, "cdi-controller")
+	cc.InjectTransferPodContainers(injection, &pod.Spec)
 
 	if err := r.client.Create(context.TODO(), pod); err != nil {
 		return nil, errors.Wrap(err, "source pod API create errored")
@@ -730,6 +767,37 @@ func MakeCloneSourcePodSpec(sourceVolumeMode corev1.PersistentVolumeMode, image,
 				Value: common.ClonerMountPath,
 			},
 		}
+		// the host clone phase only requests rsync between filesystem volumes
+		if targetPvc.Annotations[cc.AnnCloneTransferMode] == string(cdiv1.CloneTransferModeRsync) {
+			addVars = append(addVars, corev1.EnvVar{
+				Name:  "TRANSFER_MODE",
+				Value: string(cdiv1.CloneTransferModeRsync),
+			})
+		}
+	}
+
+	if sourceVolumeMode != util.ResolveVolumeMode(targetPvc.Spec.VolumeMode) || targetPvc.Annotations[cc.AnnCloneFormat] != "" {
+		// the clone source sends the disk image itself, which the upload server converts onto the target
+		addVars = append(addVars, corev1.EnvVar{
+			Name:  "CONVERT_IMAGE",
+			Value: "true",
+		})
+	}
+
+	if compression := targetPvc.Annotations[cc.AnnCloneCompression]; compression != "" {
+		// the clone source bounds the compression threads by its CPU limit
+		addVars = append(addVars, corev1.EnvVar{
+			Name:  common.CloneCompression,
+			Value: compression,
+		}, corev1.EnvVar{
+			Name: common.CloneCPULimit,
+			ValueFrom: &corev1.EnvVarSource{
+				ResourceFieldRef: &corev1.ResourceFieldSelector{
+					ContainerName: common.ClonerSourcePodName,
+					Resource:      "limits.cpu",
+				},
+			},
+		})
 	}
 
 	pod.Spec.Containers[0].Env = append(pod.Spec.Containers[0].Env, addVars...)
@@ -738,6 +806,73 @@ func MakeCloneSourcePodSpec(sourceVolumeMode corev1.PersistentVolumeMode, image,
 	return pod
 }
 
+// MakeExportCloneSourcePodSpec creates and returns the spec of the clone source pod downloading the volume another
+// cluster exports to the target pvc, instead of mounting a source pvc.
+func MakeExportCloneSourcePodSpec(image, pullPolicy, ownerRefAnno string, imagePullSecrets []corev1.LocalObjectReference,
+	serverCACert []byte, targetPvc *corev1.PersistentVolumeClaim, resourceRequirements *corev1.ResourceRequirements,
+	workloadNodePlacement *sdkapi.NodePlacement) *corev1.Pod {
+	_, namespace, name := ParseCloneRequestAnnotation(targetPvc)
+	exportURL := targetPvc.Annotations[cc.AnnCloneExportURL]
+	// the export is the archive of a filesystem volume, or the disk image of a block volume
+	sourceVolumeMode := corev1.PersistentVolumeBlock
+	if isArchiveExportURL(exportURL) {
+		sourceVolumeMode = corev1.PersistentVolumeFilesystem
+	}
+	exportedPvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+			UID:       targetPvc.UID,
+		},
+	}
+
+	pod := MakeCloneSourcePodSpec(sourceVolumeMode, image, pullPolicy, ownerRefAnno, imagePullSecrets, serverCACert, targetPvc, exportedPvc, resourceRequirements, workloadNodePlacement)
+	container := &pod.Spec.Containers[0]
+	container.VolumeDevices = nil
+	container.VolumeMounts = []corev1.VolumeMount{
+		{
+			Name:      SecretVolName,
+			MountPath: common.ImporterExportCredentialDir,
+			ReadOnly:  true,
+		},
+	}
+	pod.Spec.Volumes = []corev1.Volume{createSecretVolume(SecretVolName, targetPvc.Annotations[cc.AnnCloneExportSecret])}
+	if certConfigMap := targetPvc.Annotations[cc.AnnCloneExportCertConfigMap]; certConfigMap != "" {
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+			Name:      CertVolName,
+			MountPath: common.ImporterCertDir,
+			ReadOnly:  true,
+		})
+		pod.Spec.Volumes = append(pod.Spec.Volumes, createConfigMapVolume(CertVolName, certConfigMap))
+	}
+
+	env := []corev1.EnvVar{{Name: common.CloneExportURL, Value: exportURL}}
+	for _, envVar := range container.Env {
+		// nothing is mounted to read from
+		if envVar.Name != "MOUNT_POINT" {
+			env = append(env, envVar)
+		}
+	}
+	container.Env = env
+	return pod
+}
+
+// isExportClone returns true if the target pvc clones a volume exported by another cluster
+func isExportClone(targetPvc *corev1.PersistentVolumeClaim) bool {
+	_, ok := targetPvc.Annotations[cc.AnnCloneExportURL]
+	return ok
+}
+
+// isArchiveExportURL returns true if the url exports the tar archive of a filesystem volume, like the disk.tar.gz url of
+// a VirtualMachineExport
+func isArchiveExportURL(exportURL string) bool {
+	u, err := url.Parse(exportURL)
+	if err != nil {
+		return false
+	}
+	return strings.HasSuffix(u.Path, ".tar") || strings.HasSuffix(u.Path, ".tar.gz")
+}
+
 // ParseCloneRequestAnnotation parses the clone request annotation
 func ParseCloneRequestAnnotation(pvc *corev1.PersistentVolumeClaim) (exists bool, namespace, name string) {
 	var ann string
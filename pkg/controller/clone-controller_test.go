@@ -32,6 +32,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
 
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -42,6 +43,7 @@ import (
 	cc "kubevirt.io/containerized-data-importer/pkg/controller/common"
 	"kubevirt.io/containerized-data-importer/pkg/token"
 	"kubevirt.io/containerized-data-importer/pkg/util/cert/fetcher"
+	sdkapi "kubevirt.io/controller-lifecycle-operator-sdk/api"
 )
 
 var (
@@ -309,6 +311,25 @@ var _ = Describe("Clone controller reconcile loop", func() {
 		}),
 	)
 
+	It("Should create new source pod downloading the export without a source PVC", func() {
+		testPvc := cc.CreatePvc("testPvc1", "default", map[string]string{
+			cc.AnnCloneRequest:      "default/source",
+			cc.AnnPodReady:          "true",
+			AnnUploadClientName:     "uploadclient",
+			cc.AnnCloneSourcePod:    "default-testPvc1-source-pod",
+			cc.AnnCloneExportURL:    "https://export.example.com/volumes/source/disk.img.gz",
+			cc.AnnCloneExportSecret: "export-token"}, nil)
+		reconciler = createCloneReconciler(testPvc)
+		_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "testPvc1", Namespace: "default"}})
+		Expect(err).ToNot(HaveOccurred())
+		sourcePod, err := reconciler.findCloneSourcePod(testPvc)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(sourcePod).ToNot(BeNil())
+		Expect(sourcePod.Namespace).To(Equal("default"))
+		Expect(sourcePod.Spec.Volumes).To(HaveLen(1))
+		Expect(sourcePod.Spec.Volumes[0].Secret.SecretName).To(Equal("export-token"))
+	})
+
 	It("Should error with missing upload client name annotation if none provided", func() {
 		testPvc := cc.CreatePvc("testPvc1", "default", map[string]string{
 			cc.AnnCloneRequest: "default/source", cc.AnnPodReady: "true", cc.AnnCloneToken: "foobaz", cc.AnnCloneSourcePod: "default-testPvc1-source-pod"}, nil)
@@ -584,6 +605,110 @@ var _ = Describe("ParseCloneRequestAnnotation", func() {
 	})
 })
 
+var _ = Describe("MakeCloneSourcePodSpec", func() {
+	DescribeTable("should pass the transfer mode to the clone source", func(annotations map[string]string, expected string) {
+		targetPvc := cc.CreatePvc("target", "default", annotations, nil)
+		sourcePvc := cc.CreatePvc("source", "default", map[string]string{}, nil)
+		pod := MakeCloneSourcePodSpec(corev1.PersistentVolumeFilesystem, "image", "Always", "default/target", nil, nil,
+			targetPvc, sourcePvc, nil, &sdkapi.NodePlacement{})
+		var transferMode string
+		for _, env := range pod.Spec.Containers[0].Env {
+			if env.Name == "TRANSFER_MODE" {
+				transferMode = env.Value
+			}
+		}
+		Expect(transferMode).To(Equal(expected))
+	},
+		Entry("rsync when requested", map[string]string{cc.AnnCloneTransferMode: "rsync"}, "rsync"),
+		Entry("none by default", map[string]string{}, ""),
+	)
+
+	DescribeTable("should convert the disk image onto targets of the other volume mode", func(sourceVolumeMode, targetVolumeMode corev1.PersistentVolumeMode, expected string) {
+		targetPvc := cc.CreatePvc("target", "default", map[string]string{}, nil)
+		targetPvc.Spec.VolumeMode = &targetVolumeMode
+		sourcePvc := cc.CreatePvc("source", "default", map[string]string{}, nil)
+		sourcePvc.Spec.VolumeMode = &sourceVolumeMode
+		pod := MakeCloneSourcePodSpec(sourceVolumeMode, "image", "Always", "default/target", nil, nil,
+			targetPvc, sourcePvc, nil, &sdkapi.NodePlacement{})
+		var convertImage string
+		for _, env := range pod.Spec.Containers[0].Env {
+			if env.Name == "CONVERT_IMAGE" {
+				convertImage = env.Value
+			}
+		}
+		Expect(convertImage).To(Equal(expected))
+	},
+		Entry("from filesystem to block", corev1.PersistentVolumeFilesystem, corev1.PersistentVolumeBlock, "true"),
+		Entry("from block to filesystem", corev1.PersistentVolumeBlock, corev1.PersistentVolumeFilesystem, "true"),
+		Entry("not between filesystem volumes", corev1.PersistentVolumeFilesystem, corev1.PersistentVolumeFilesystem, ""),
+		Entry("not between block volumes", corev1.PersistentVolumeBlock, corev1.PersistentVolumeBlock, ""),
+	)
+
+	It("should convert the disk image to the format of the clone", func() {
+		targetPvc := cc.CreatePvc("target", "default", map[string]string{cc.AnnCloneFormat: "qcow2"}, nil)
+		sourcePvc := cc.CreatePvc("source", "default", map[string]string{}, nil)
+		pod := MakeCloneSourcePodSpec(corev1.PersistentVolumeFilesystem, "image", "Always", "default/target", nil, nil,
+			targetPvc, sourcePvc, nil, &sdkapi.NodePlacement{})
+		Expect(pod.Spec.Containers[0].Env).To(ContainElement(corev1.EnvVar{Name: "CONVERT_IMAGE", Value: "true"}))
+	})
+
+	DescribeTable("should download the export instead of mounting a source pvc", func(exportURL, volumeMode string) {
+		targetPvc := cc.CreatePvc("target", "default", map[string]string{
+			cc.AnnCloneRequest:             "default/source",
+			cc.AnnCloneExportURL:           exportURL,
+			cc.AnnCloneExportSecret:        "export-token",
+			cc.AnnCloneExportCertConfigMap: "export-ca",
+		}, nil)
+		targetPvc.Spec.VolumeMode = ptr.To(corev1.PersistentVolumeBlock)
+		pod := MakeExportCloneSourcePodSpec("image", "Always", "default/target", nil, nil, targetPvc, nil, &sdkapi.NodePlacement{})
+		container := pod.Spec.Containers[0]
+		Expect(container.Env).To(ContainElements(
+			corev1.EnvVar{Name: common.CloneExportURL, Value: exportURL},
+			corev1.EnvVar{Name: "VOLUME_MODE", Value: volumeMode},
+		))
+		Expect(container.Env).ToNot(ContainElement(HaveField("Name", "MOUNT_POINT")))
+		Expect(container.VolumeDevices).To(BeEmpty())
+		Expect(container.VolumeMounts).To(ConsistOf(
+			corev1.VolumeMount{Name: SecretVolName, MountPath: common.ImporterExportCredentialDir, ReadOnly: true},
+			corev1.VolumeMount{Name: CertVolName, MountPath: common.ImporterCertDir, ReadOnly: true},
+		))
+		Expect(pod.Spec.Volumes).To(ConsistOf(
+			createSecretVolume(SecretVolName, "export-token"),
+			createConfigMapVolume(CertVolName, "export-ca"),
+		))
+		Expect(pod.Spec.Affinity.PodAffinity.RequiredDuringSchedulingIgnoredDuringExecution).To(BeEmpty())
+	},
+		Entry("of block volumes", "https://export.example.com/volumes/source/disk.img.gz", "block"),
+		Entry("of filesystem volumes", "https://export.example.com/volumes/source/disk.tar.gz", "filesystem"),
+	)
+
+	DescribeTable("should pass the compression to the clone source", func(annotations map[string]string, expected string) {
+		targetPvc := cc.CreatePvc("target", "default", annotations, nil)
+		sourcePvc := cc.CreatePvc("source", "default", map[string]string{}, nil)
+		pod := MakeCloneSourcePodSpec(corev1.PersistentVolumeBlock, "image", "Always", "default/target", nil, nil,
+			targetPvc, sourcePvc, nil, &sdkapi.NodePlacement{})
+		var compression string
+		var cpuLimit *corev1.EnvVarSource
+		for _, env := range pod.Spec.Containers[0].Env {
+			switch env.Name {
+			case common.CloneCompression:
+				compression = env.Value
+			case common.CloneCPULimit:
+				cpuLimit = env.ValueFrom
+			}
+		}
+		Expect(compression).To(Equal(expected))
+		if expected == "" {
+			Expect(cpuLimit).To(BeNil())
+		} else {
+			Expect(cpuLimit.ResourceFieldRef.Resource).To(Equal("limits.cpu"))
+		}
+	},
+		Entry("zstd when requested", map[string]string{cc.AnnCloneCompression: "zstd"}, "zstd"),
+		Entry("none by default", map[string]string{}, ""),
+	)
+})
+
 var _ = Describe("CloneSourcePodName", func() {
 	It("Should be unique and deterministic", func() {
 		pvc1d := cc.CreatePvc("testPvc1", "default", map[string]string{cc.AnnCloneRequest: "default/test"}, nil)
@@ -680,7 +805,9 @@ var _ = Describe("Update PVC", func() {
 
 var _ = Describe("TokenValidation", func() {
 	g := token.NewGenerator(common.CloneTokenIssuer, cc.GetAPIServerKey(), 5*time.Minute)
-	v := cc.NewCloneTokenValidator(common.CloneTokenIssuer, &cc.GetAPIServerKey().PublicKey)
+	v := cc.NewCloneTokenValidator(common.CloneTokenIssuer, token.NewStaticKeySet(cc.GetAPIServerKey()), func() (*cdiv1.TokenConfig, error) {
+		return nil, nil
+	})
 
 	goodTokenData := func() *token.Payload {
 		return &token.Payload{
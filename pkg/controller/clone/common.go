@@ -2,7 +2,10 @@ package clone
 
 import (
 	"context"
+	"crypto/sha256"
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/go-logr/logr"
 	snapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v6/apis/volumesnapshot/v1"
@@ -32,6 +35,9 @@ const (
 
 	// ErrorPhaseName is the phase when the clone is in error
 	ErrorPhaseName = "Error"
+
+	// LabelCloneGroupMemberPrefix prefixes the labels of a snapshot shared by a clone group, one per clone restoring it
+	LabelCloneGroupMemberPrefix = "member.clone-group.cdi.kubevirt.io/"
 )
 
 // IsDataSourcePVC checks for PersistentVolumeClaim source kind
@@ -44,6 +50,22 @@ func IsDataSourceSnapshot(kind string) bool {
 	return kind == "VolumeSnapshot"
 }
 
+// groupSnapshotName returns the name of the snapshot of the source shared by the snapshot clones of a group
+func groupSnapshotName(group, sourceName, snapshotClass string) string {
+	hash := sha256.Sum256([]byte(group + "/" + sourceName + "/" + snapshotClass))
+	return fmt.Sprintf("tmp-snapshot-group-%x", hash[:8])
+}
+
+// hasCloneGroupMembers returns true if clones of a group still restore the snapshot
+func hasCloneGroupMembers(snapshot *snapshotv1.VolumeSnapshot) bool {
+	for label := range snapshot.GetLabels() {
+		if strings.HasPrefix(label, LabelCloneGroupMemberPrefix) {
+			return true
+		}
+	}
+	return false
+}
+
 // AddCommonLabels adds common labels to a resource
 func AddCommonLabels(obj metav1.Object) {
 	if obj.GetLabels() == nil {
@@ -283,6 +305,77 @@ func GetCompatibleVolumeSnapshotClass(ctx context.Context, c client.Client, log
 	return cc.GetVolumeSnapshotClass(context.TODO(), c, targetClaim, *driver, snapshotClassName, log, recorder)
 }
 
+// GetStorageClassForSnapshotRestore returns the storage class of driver to restore snapshot into before copying it to
+// a target of another storage class. Snapshots can only be restored to storage classes compatible with the one of
+// their source, so it prefers the storage class of the source PVC, then the storage classes whose StorageProfile
+// uses the VolumeSnapshotClass of the snapshot, then the default storage class.
+func GetStorageClassForSnapshotRestore(ctx context.Context, c client.Client, snapshot *snapshotv1.VolumeSnapshot, driver string) (string, error) {
+	if name := snapshot.Spec.Source.PersistentVolumeClaimName; name != nil {
+		sourceClaim := &corev1.PersistentVolumeClaim{}
+		exists, err := getResource(ctx, c, snapshot.Namespace, *name, sourceClaim)
+		if err != nil {
+			return "", err
+		}
+		if exists {
+			sc, err := GetStorageClassForClaim(ctx, c, sourceClaim)
+			if err != nil {
+				return "", err
+			}
+			if sc != nil && sc.Provisioner == driver {
+				return sc.Name, nil
+			}
+		}
+	}
+
+	storageClasses := &storagev1.StorageClassList{}
+	if err := c.List(ctx, storageClasses); err != nil {
+		return "", err
+	}
+	var matches []storagev1.StorageClass
+	for _, sc := range storageClasses.Items {
+		if sc.Provisioner == driver {
+			matches = append(matches, sc)
+		}
+	}
+	if len(matches) == 0 {
+		return "", fmt.Errorf("unable to find a valid storage class for the temporal source claim")
+	}
+	sort.Slice(matches, func(i, j int) bool { return matches[i].Name < matches[j].Name })
+
+	if snapshotClass := snapshot.Spec.VolumeSnapshotClassName; snapshotClass != nil {
+		for _, sc := range matches {
+			sp := &cdiv1.StorageProfile{}
+			exists, err := getResource(ctx, c, "", sc.Name, sp)
+			if err != nil {
+				return "", err
+			}
+			if exists && sp.Status.SnapshotClass != nil && *sp.Status.SnapshotClass == *snapshotClass {
+				return sc.Name, nil
+			}
+		}
+	}
+	for _, sc := range matches {
+		if sc.Annotations[cc.AnnDefaultStorageClass] == "true" {
+			return sc.Name, nil
+		}
+	}
+	return matches[0].Name, nil
+}
+
+// SameSnapshotClass returns false if the StorageProfile of the storage class of the target pins a VolumeSnapshotClass
+// other than the one of the snapshot, its volumes then being on a backend the snapshot can not be restored to
+func SameSnapshotClass(ctx context.Context, c client.Client, vsc *snapshotv1.VolumeSnapshotContent, targetClaim *corev1.PersistentVolumeClaim) (bool, error) {
+	if vsc.Spec.VolumeSnapshotClassName == nil || targetClaim.Spec.StorageClassName == nil {
+		return true, nil
+	}
+	sp := &cdiv1.StorageProfile{}
+	exists, err := getResource(ctx, c, "", *targetClaim.Spec.StorageClassName, sp)
+	if err != nil || !exists {
+		return true, err
+	}
+	return sp.Spec.SnapshotClass == nil || *sp.Spec.SnapshotClass == *vsc.Spec.VolumeSnapshotClassName, nil
+}
+
 // SameVolumeMode returns true if all target pvcs have the same volume mode as the source
 func SameVolumeMode(srcVolumeMode *corev1.PersistentVolumeMode, others ...*corev1.PersistentVolumeClaim) bool {
 	vm := util.ResolveVolumeMode(srcVolumeMode)
@@ -34,11 +34,18 @@ type CSIClonePhase struct {
 
 var _ Phase = &CSIClonePhase{}
 
+var _ ProgressPhaser = &CSIClonePhase{}
+
 // Name returns the name of the phase
 func (p *CSIClonePhase) Name() string {
 	return CSIClonePhaseName
 }
 
+// ProgressPhase returns the phase of the clone progress
+func (p *CSIClonePhase) ProgressPhase() cdiv1.DataVolumeClonePhase {
+	return cdiv1.ClonePhaseTransfer
+}
+
 // Reconcile ensures a csi cloned pvc is created correctly
 func (p *CSIClonePhase) Reconcile(ctx context.Context) (*reconcile.Result, error) {
 	pvc := &corev1.PersistentVolumeClaim{}
@@ -3,6 +3,7 @@ package clone
 import (
 	"context"
 	"fmt"
+	"maps"
 	"net/http"
 	"strconv"
 	"time"
@@ -19,11 +20,19 @@ import (
 
 	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
 	cc "kubevirt.io/containerized-data-importer/pkg/controller/common"
-	metrics "kubevirt.io/containerized-data-importer/pkg/monitoring/metrics/cdi-cloner"
+	cloneMetrics "kubevirt.io/containerized-data-importer/pkg/monitoring/metrics/cdi-cloner"
 )
 
-// HostClonePhaseName is the name of the host clone phase
-const HostClonePhaseName = "HostClone"
+const (
+	// HostClonePhaseName is the name of the host clone phase
+	HostClonePhaseName = "HostClone"
+
+	// RsyncTransferNotApplicable reports that a host-assisted clone requesting rsync is copied with tar (reason)
+	RsyncTransferNotApplicable = "RsyncTransferNotApplicable"
+
+	// MessageRsyncTransferNotApplicable reports that a host-assisted clone requesting rsync is copied with tar (message)
+	MessageRsyncTransferNotApplicable = "rsync only transfers between filesystem volumes, the clone is copied in full"
+)
 
 // HostClonePhase creates and monitors a dumb clone operation
 type HostClonePhase struct {
@@ -35,6 +44,10 @@ type HostClonePhase struct {
 	OwnershipLabel    string
 	Preallocation     bool
 	PriorityClassName string
+	TransferMode      cdiv1.CloneTransferMode
+	Compression       cdiv1.CloneCompression
+	Format            cdiv1.DataVolumeTransformationFormat
+	Export            *cdiv1.DataVolumeSourceExport
 	Client            client.Client
 	Log               logr.Logger
 	Recorder          record.EventRecorder
@@ -44,6 +57,8 @@ var _ Phase = &HostClonePhase{}
 
 var _ StatusReporter = &HostClonePhase{}
 
+var _ ProgressPhaser = &HostClonePhase{}
+
 var httpClient *http.Client
 
 func init() {
@@ -55,6 +70,14 @@ func (p *HostClonePhase) Name() string {
 	return HostClonePhaseName
 }
 
+// ProgressPhase returns the phase of the clone progress, Convert if the target converts the image
+func (p *HostClonePhase) ProgressPhase() cdiv1.DataVolumeClonePhase {
+	if p.Format != "" {
+		return cdiv1.ClonePhaseConvert
+	}
+	return cdiv1.ClonePhaseTransfer
+}
+
 // Status returns the phase status
 func (p *HostClonePhase) Status(ctx context.Context) (*PhaseStatus, error) {
 	result := &PhaseStatus{}
@@ -84,12 +107,17 @@ func (p *HostClonePhase) Status(ctx context.Context) (*PhaseStatus, error) {
 		OwnerUID:     string(p.Owner.GetUID()),
 	}
 
-	progress, err := progressFromClaim(ctx, args)
+	progress, report, err := progressFromClaim(ctx, args)
 	if err != nil {
 		return nil, err
 	}
 
 	result.Progress = progress
+	if report != nil {
+		// the annotations of the claim are shared with the cache
+		result.Annotations = maps.Clone(pvc.Annotations)
+		cc.SetCloneReportAnnotations(result.Annotations, report)
+	}
 
 	return result, nil
 }
@@ -104,11 +132,11 @@ type progressFromClaimArgs struct {
 	PodName      string
 }
 
-// progressFromClaim returns the progres
-func progressFromClaim(ctx context.Context, args *progressFromClaimArgs) (string, error) {
+// progressFromClaim returns the progress of the clone, and the bytes and throughput the clone source pod reports
+func progressFromClaim(ctx context.Context, args *progressFromClaimArgs) (string, *cc.CloneReport, error) {
 	// Just set 100.0% if pod is succeeded
 	if args.Claim.Annotations[cc.AnnPodPhase] == string(corev1.PodSucceeded) {
-		return cc.ProgressDone, nil
+		return cc.ProgressDone, nil, nil
 	}
 
 	pod := &corev1.Pod{
@@ -119,35 +147,37 @@ func progressFromClaim(ctx context.Context, args *progressFromClaimArgs) (string
 	}
 	if err := args.Client.Get(ctx, client.ObjectKeyFromObject(pod), pod); err != nil {
 		if k8serrors.IsNotFound(err) {
-			return "", nil
+			return "", nil, nil
 		}
-		return "", err
+		return "", nil, err
 	}
 
 	// This will only work when the clone source pod is running
 	if pod.Status.Phase != corev1.PodRunning {
-		return "", nil
+		return "", nil, nil
 	}
 	url, err := cc.GetMetricsURL(pod)
 	if err != nil {
-		return "", err
+		return "", nil, err
 	}
 	if url == "" {
-		return "", nil
+		return "", nil, nil
 	}
 
 	// We fetch the clone progress from the clone source pod metrics
-	progressReport, err := cc.GetProgressReportFromURL(ctx, url, args.HTTPClient, metrics.CloneProgressMetricName, args.OwnerUID)
+	metrics, err := cc.GetMetricsFromURL(ctx, url, args.HTTPClient)
 	if err != nil {
-		return "", err
+		return "", nil, err
 	}
+	report := cc.ParseCloneReport(metrics, args.OwnerUID)
+	progressReport := cc.ParseProgressReport(metrics, cloneMetrics.CloneProgressMetricName, args.OwnerUID)
 	if progressReport != "" {
 		if f, err := strconv.ParseFloat(progressReport, 64); err == nil {
-			return fmt.Sprintf("%.2f%%", f), nil
+			return fmt.Sprintf("%.2f%%", f), report, nil
 		}
 	}
 
-	return "", nil
+	return "", report, nil
 }
 
 // Reconcile creates the desired pvc and waits for the operation to complete
@@ -193,6 +223,30 @@ func (p *HostClonePhase) createClaim(ctx context.Context) (*corev1.PersistentVol
 	if p.PriorityClassName != "" {
 		cc.AddAnnotation(claim, cc.AnnPriorityClassName, p.PriorityClassName)
 	}
+	if p.TransferMode == cdiv1.CloneTransferModeRsync {
+		rsync, err := p.canTransferWithRsync(ctx, claim)
+		if err != nil {
+			return nil, err
+		}
+		if rsync {
+			cc.AddAnnotation(claim, cc.AnnCloneTransferMode, string(cdiv1.CloneTransferModeRsync))
+		} else {
+			p.Recorder.Event(p.Owner, corev1.EventTypeNormal, RsyncTransferNotApplicable, MessageRsyncTransferNotApplicable)
+		}
+	}
+	if p.Compression != "" && p.Compression != cdiv1.CloneCompressionSnappy {
+		cc.AddAnnotation(claim, cc.AnnCloneCompression, string(p.Compression))
+	}
+	if p.Format != "" {
+		cc.AddAnnotation(claim, cc.AnnCloneFormat, string(p.Format))
+	}
+	if p.Export != nil {
+		cc.AddAnnotation(claim, cc.AnnCloneExportURL, p.Export.URL)
+		cc.AddAnnotation(claim, cc.AnnCloneExportSecret, p.Export.SecretRef)
+		if p.Export.CertConfigMap != "" {
+			cc.AddAnnotation(claim, cc.AnnCloneExportCertConfigMap, p.Export.CertConfigMap)
+		}
+	}
 	cc.AddLabel(claim, cc.LabelExcludeFromVeleroBackup, "true")
 
 	if err := p.Client.Create(ctx, claim); err != nil {
@@ -203,6 +257,19 @@ func (p *HostClonePhase) createClaim(ctx context.Context) (*corev1.PersistentVol
 	return claim, nil
 }
 
+// canTransferWithRsync returns whether the source and the target of the clone are filesystem volumes, block volumes
+// are always copied in full. Converted images and exported volumes are sent to the target as a stream.
+func (p *HostClonePhase) canTransferWithRsync(ctx context.Context, claim *corev1.PersistentVolumeClaim) (bool, error) {
+	if p.Format != "" || p.Export != nil {
+		return false, nil
+	}
+	source := &corev1.PersistentVolumeClaim{}
+	if err := p.Client.Get(ctx, client.ObjectKey{Namespace: p.Namespace, Name: p.SourceName}, source); err != nil {
+		return false, err
+	}
+	return cc.GetVolumeMode(source) == corev1.PersistentVolumeFilesystem && cc.GetVolumeMode(claim) == corev1.PersistentVolumeFilesystem, nil
+}
+
 func (p *HostClonePhase) hostCloneComplete(pvc *corev1.PersistentVolumeClaim) bool {
 	// this is awfully lame
 	// both the upload controller and clone controller update the PVC status to succeeded
@@ -127,6 +127,96 @@ var _ = Describe("HostClonePhase test", func() {
 		Expect(pvc.Annotations[cc.AnnPriorityClassName]).To(Equal("priority"))
 	})
 
+	DescribeTable("should transfer with rsync between filesystem volumes", func(sourceMode, targetMode corev1.PersistentVolumeMode, rsync bool) {
+		source := &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "ns",
+				Name:      "source",
+			},
+			Spec: corev1.PersistentVolumeClaimSpec{
+				VolumeMode: &sourceMode,
+			},
+		}
+		p := creatHostClonePhase(source)
+		p.TransferMode = cdiv1.CloneTransferModeRsync
+		p.DesiredClaim.Spec.VolumeMode = &targetMode
+
+		_, err := p.Reconcile(context.Background())
+		Expect(err).ToNot(HaveOccurred())
+
+		pvc := getDesiredClaim(p)
+		if rsync {
+			Expect(pvc.Annotations[cc.AnnCloneTransferMode]).To(Equal(string(cdiv1.CloneTransferModeRsync)))
+			Expect(p.Recorder.(*record.FakeRecorder).Events).ToNot(Receive())
+		} else {
+			Expect(pvc.Annotations).ToNot(HaveKey(cc.AnnCloneTransferMode))
+			Expect(p.Recorder.(*record.FakeRecorder).Events).To(Receive(ContainSubstring(RsyncTransferNotApplicable)))
+		}
+	},
+		Entry("filesystem to filesystem", corev1.PersistentVolumeFilesystem, corev1.PersistentVolumeFilesystem, true),
+		Entry("block to block", corev1.PersistentVolumeBlock, corev1.PersistentVolumeBlock, false),
+		Entry("filesystem to block", corev1.PersistentVolumeFilesystem, corev1.PersistentVolumeBlock, false),
+	)
+
+	DescribeTable("should annotate the compression of the clone", func(compression cdiv1.CloneCompression, annotated bool) {
+		p := creatHostClonePhase()
+		p.Compression = compression
+
+		_, err := p.Reconcile(context.Background())
+		Expect(err).ToNot(HaveOccurred())
+
+		pvc := getDesiredClaim(p)
+		if annotated {
+			Expect(pvc.Annotations[cc.AnnCloneCompression]).To(Equal(string(compression)))
+		} else {
+			Expect(pvc.Annotations).ToNot(HaveKey(cc.AnnCloneCompression))
+		}
+	},
+		Entry("zstd", cdiv1.CloneCompressionZstd, true),
+		Entry("not snappy, the default of the clone source", cdiv1.CloneCompressionSnappy, false),
+	)
+
+	It("should convert the image instead of transferring with rsync", func() {
+		p := creatHostClonePhase()
+		p.TransferMode = cdiv1.CloneTransferModeRsync
+		p.Format = cdiv1.TransformationFormatQcow2
+
+		_, err := p.Reconcile(context.Background())
+		Expect(err).ToNot(HaveOccurred())
+
+		pvc := getDesiredClaim(p)
+		Expect(pvc.Annotations[cc.AnnCloneFormat]).To(Equal(string(cdiv1.TransformationFormatQcow2)))
+		Expect(pvc.Annotations).ToNot(HaveKey(cc.AnnCloneTransferMode))
+		Expect(p.Recorder.(*record.FakeRecorder).Events).To(Receive(ContainSubstring(RsyncTransferNotApplicable)))
+	})
+
+	It("should annotate the export of the clone", func() {
+		p := creatHostClonePhase()
+		p.Export = &cdiv1.DataVolumeSourceExport{
+			URL:           "https://export.example.com/volumes/source/disk.img.gz",
+			SecretRef:     "export-token",
+			CertConfigMap: "export-ca",
+		}
+
+		_, err := p.Reconcile(context.Background())
+		Expect(err).ToNot(HaveOccurred())
+
+		pvc := getDesiredClaim(p)
+		Expect(pvc.Annotations).To(HaveKeyWithValue(cc.AnnCloneExportURL, p.Export.URL))
+		Expect(pvc.Annotations).To(HaveKeyWithValue(cc.AnnCloneExportSecret, "export-token"))
+		Expect(pvc.Annotations).To(HaveKeyWithValue(cc.AnnCloneExportCertConfigMap, "export-ca"))
+	})
+
+	It("should transfer with tar by default", func() {
+		p := creatHostClonePhase()
+
+		_, err := p.Reconcile(context.Background())
+		Expect(err).ToNot(HaveOccurred())
+
+		pvc := getDesiredClaim(p)
+		Expect(pvc.Annotations).ToNot(HaveKey(cc.AnnCloneTransferMode))
+	})
+
 	Context("with desired claim created", func() {
 		getCliam := func() *corev1.PersistentVolumeClaim {
 			return &corev1.PersistentVolumeClaim{
@@ -3,8 +3,10 @@ package clone
 import (
 	"context"
 	"fmt"
+	"net/url"
 	"reflect"
-	"sort"
+	"slices"
+	"strings"
 	"sync"
 
 	"github.com/go-logr/logr"
@@ -12,6 +14,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	storagev1 "k8s.io/api/storage/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -66,11 +69,41 @@ const (
 	// MessageNoProvisionerMatch reports that the storageclass provisioner does not match the volumesnapshotcontent driver (message)
 	MessageNoProvisionerMatch = "The storageclass provisioner does not match the volumesnapshotcontent driver"
 
+	// IncompatibleSnapshotClass reports that the storage class of the target uses another volumesnapshotclass than the source snapshot (reason)
+	IncompatibleSnapshotClass = "IncompatibleSnapshotClass"
+
+	// MessageIncompatibleSnapshotClass reports that the storage class of the target uses another volumesnapshotclass than the source snapshot (message)
+	MessageIncompatibleSnapshotClass = "The storageclass of the target uses another volumesnapshotclass than the source snapshot"
+
 	// IncompatibleProvisioners reports that the provisioners are incompatible (reason)
 	IncompatibleProvisioners = "IncompatibleProvisioners"
 
 	// MessageIncompatibleProvisioners reports that the provisioners are incompatible (message)
 	MessageIncompatibleProvisioners = "Provisioners are incompatible"
+
+	// CloneStrategyNotSupported reports that a clone strategy can't clone the kind of the source (reason)
+	CloneStrategyNotSupported = "CloneStrategyNotSupported"
+
+	// MessageCloneStrategyNotSupported reports that a clone strategy can't clone the kind of the source (message)
+	MessageCloneStrategyNotSupported = "The %s clone strategy does not support %s sources"
+
+	// CloneFormatConversion reports that the image is converted, which only host-assisted clones do (reason)
+	CloneFormatConversion = "CloneFormatConversion"
+
+	// MessageCloneFormatConversion reports that the image is converted, which only host-assisted clones do (message)
+	MessageCloneFormatConversion = "The image is converted to the requested format, which only host-assisted clones do"
+
+	// DifferentFilesystems reports that the source and the target of a reflink clone are not on the same shared filesystem (reason)
+	DifferentFilesystems = "DifferentFilesystems"
+
+	// MessageDifferentFilesystems reports that the source and the target of a reflink clone are not on the same shared filesystem (message)
+	MessageDifferentFilesystems = "Reflink clones need filesystem volumes of the storage class of the source"
+
+	// NoCloneStrategy reports that none of the clone strategies requested can be used (reason)
+	NoCloneStrategy = "NoCloneStrategy"
+
+	// MessageNoCloneStrategy reports that none of the clone strategies requested can be used (message)
+	MessageNoCloneStrategy = "None of the clone strategies %v can be used"
 )
 
 // Planner plans clone operations
@@ -108,6 +141,11 @@ type StatusReporter interface {
 	Status(context.Context) (*PhaseStatus, error)
 }
 
+// ProgressPhaser is implemented by the phases reported as a phase of the clone progress
+type ProgressPhaser interface {
+	ProgressPhase() cdiv1.DataVolumeClonePhase
+}
+
 // list of all possible (core) types created
 var coreTypesCreated = []client.Object{
 	&corev1.PersistentVolumeClaim{},
@@ -151,10 +189,15 @@ type ChooseStrategyArgs struct {
 type ChooseStrategyResult struct {
 	Strategy       cdiv1.CDICloneStrategy
 	FallbackReason *string
+	Fallbacks      []cdiv1.CloneStrategyFallback
 }
 
 // ChooseStrategy picks the strategy for a clone op
 func (p *Planner) ChooseStrategy(ctx context.Context, args *ChooseStrategyArgs) (*ChooseStrategyResult, error) {
+	if args.DataSource.Spec.Export != nil {
+		args.Log.V(3).Info("Getting strategy for exported source")
+		return p.computeStrategyForSourceExport(ctx, args)
+	}
 	if IsDataSourcePVC(args.DataSource.Spec.Source.Kind) {
 		args.Log.V(3).Info("Getting strategy for PVC source")
 		return p.computeStrategyForSourcePVC(ctx, args)
@@ -195,6 +238,10 @@ func (p *Planner) Plan(ctx context.Context, args *PlanArgs) ([]Phase, error) {
 			args.Log.V(3).Info("Planning csi clone from PVC")
 
 			return p.planCSIClone(ctx, args)
+		} else if args.Strategy == cdiv1.CloneStrategyReflink {
+			args.Log.V(3).Info("Planning reflink clone from PVC")
+
+			return p.planReflinkClone(ctx, args)
 		}
 	}
 
@@ -231,6 +278,42 @@ func (p *Planner) Cleanup(ctx context.Context, log logr.Logger, owner client.Obj
 		}
 	}
 
+	return p.leaveCloneGroups(ctx, log, owner)
+}
+
+// leaveCloneGroups removes the owner from the members of the snapshots shared by clone groups, and deletes the
+// snapshots left without members
+func (p *Planner) leaveCloneGroups(ctx context.Context, log logr.Logger, owner client.Object) error {
+	memberLabel := LabelCloneGroupMemberPrefix + string(owner.GetUID())
+	ls, err := labels.Parse(memberLabel)
+	if err != nil {
+		return err
+	}
+
+	snapshots := &snapshotv1.VolumeSnapshotList{}
+	if err := p.Client.List(ctx, snapshots, &client.ListOptions{LabelSelector: ls}); err != nil {
+		if meta.IsNoMatchError(err) {
+			return nil
+		}
+		return err
+	}
+
+	for i := range snapshots.Items {
+		snapshot := &snapshots.Items[i]
+		delete(snapshot.Labels, memberLabel)
+		if hasCloneGroupMembers(snapshot) {
+			if err := p.Client.Update(ctx, snapshot); err != nil {
+				return err
+			}
+			continue
+		}
+		log.V(3).Info("Deleting the snapshot of the clone group", "snapshot", client.ObjectKeyFromObject(snapshot))
+		// a clone joining the group in the meantime keeps the snapshot
+		if err := p.Client.Delete(ctx, snapshot, client.Preconditions{ResourceVersion: &snapshot.ResourceVersion}); err != nil && !k8serrors.IsNotFound(err) {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -263,28 +346,26 @@ func (p *Planner) watchOwned(log logr.Logger, obj client.Object) error {
 	objList := p.RootObjectType.DeepCopyObject().(client.ObjectList)
 	if err := p.Controller.Watch(source.Kind(p.GetCache(), obj, handler.EnqueueRequestsFromMapFunc(
 		func(ctx context.Context, obj client.Object) []reconcile.Request {
-			uid, ok := obj.GetLabels()[p.OwnershipLabel]
-			if !ok {
-				return nil
-			}
-			matchingFields := client.MatchingFields{
-				p.UIDField: uid,
-			}
-			if err := p.Client.List(ctx, objList, matchingFields); err != nil {
-				log.Error(err, "Unable to list resource", "matchingFields", matchingFields)
-				return nil
-			}
-			sv := reflect.ValueOf(objList).Elem()
-			iv := sv.FieldByName("Items")
 			var reqs []reconcile.Request
-			for i := 0; i < iv.Len(); i++ {
-				o := iv.Index(i).Addr().Interface().(client.Object)
-				reqs = append(reqs, reconcile.Request{
-					NamespacedName: types.NamespacedName{
-						Namespace: o.GetNamespace(),
-						Name:      o.GetName(),
-					},
-				})
+			for _, uid := range p.ownerUIDs(obj) {
+				matchingFields := client.MatchingFields{
+					p.UIDField: uid,
+				}
+				if err := p.Client.List(ctx, objList, matchingFields); err != nil {
+					log.Error(err, "Unable to list resource", "matchingFields", matchingFields)
+					return nil
+				}
+				sv := reflect.ValueOf(objList).Elem()
+				iv := sv.FieldByName("Items")
+				for i := 0; i < iv.Len(); i++ {
+					o := iv.Index(i).Addr().Interface().(client.Object)
+					reqs = append(reqs, reconcile.Request{
+						NamespacedName: types.NamespacedName{
+							Namespace: o.GetNamespace(),
+							Name:      o.GetName(),
+						},
+					})
+				}
 			}
 			return reqs
 		}),
@@ -294,6 +375,19 @@ func (p *Planner) watchOwned(log logr.Logger, obj client.Object) error {
 	return nil
 }
 
+// ownerUIDs returns the UID of the owner of an object, or the UIDs of the clones sharing it in a clone group
+func (p *Planner) ownerUIDs(obj client.Object) []string {
+	var uids []string
+	for label, value := range obj.GetLabels() {
+		if label == p.OwnershipLabel {
+			uids = append(uids, value)
+		} else if uid, ok := strings.CutPrefix(label, LabelCloneGroupMemberPrefix); ok {
+			uids = append(uids, uid)
+		}
+	}
+	return uids
+}
+
 func (p *Planner) computeStrategyForSourcePVC(ctx context.Context, args *ChooseStrategyArgs) (*ChooseStrategyResult, error) {
 	res := &ChooseStrategyResult{}
 
@@ -328,6 +422,8 @@ func (p *Planner) computeStrategyForSourcePVC(ctx context.Context, args *ChooseS
 
 	if cs != nil {
 		strategy = *cs
+	} else if args.DataSource.Spec.Strategy != nil {
+		strategy = *args.DataSource.Spec.Strategy
 	} else if args.TargetClaim.Spec.StorageClassName != nil {
 		sp := &cdiv1.StorageProfile{}
 		exists, err := getResource(ctx, p.Client, metav1.NamespaceNone, *args.TargetClaim.Spec.StorageClassName, sp)
@@ -344,27 +440,124 @@ func (p *Planner) computeStrategyForSourcePVC(ctx context.Context, args *ChooseS
 		}
 	}
 
-	if strategy == cdiv1.CloneStrategySnapshot {
-		n, err := GetCompatibleVolumeSnapshotClass(ctx, p.Client, args.Log, p.Recorder, sourceClaim, args.TargetClaim)
+	strategies := cloneStrategyChain(args.DataSource, strategy)
+	for _, strategy := range strategies {
+		reason, message, err := p.validateStrategyForSourcePVC(ctx, args, sourceClaim, strategy)
 		if err != nil {
 			return nil, err
 		}
-
-		if n == nil {
-			p.fallbackToHostAssisted(args.TargetClaim, res, NoVolumeSnapshotClass, MessageNoVolumeSnapshotClass)
+		if reason == "" {
+			res.Strategy = strategy
 			return res, nil
 		}
+		p.fallBack(args.TargetClaim, res, strategy, reason, message)
 	}
 
-	res.Strategy = strategy
-	if strategy == cdiv1.CloneStrategySnapshot ||
-		strategy == cdiv1.CloneStrategyCsiClone {
-		if err := p.validateAdvancedClonePVC(ctx, args, res, sourceClaim); err != nil {
-			return nil, err
+	p.noCloneStrategy(args, strategies)
+	return nil, nil
+}
+
+// computeStrategyForSourceExport returns host-assisted for the volumes exported by other clusters, which no other
+// strategy copies
+func (p *Planner) computeStrategyForSourceExport(ctx context.Context, args *ChooseStrategyArgs) (*ChooseStrategyResult, error) {
+	if ok, err := p.validateTargetStorageClassAssignment(ctx, args); !ok || err != nil {
+		return nil, err
+	}
+
+	if err := validateSourceExport(&args.DataSource.Spec); err != nil {
+		p.Recorder.Event(args.TargetClaim, corev1.EventTypeWarning, CloneValidationFailed, err.Error())
+		args.Log.V(3).Info("Validation failed", "target", args.TargetClaim, "export", args.DataSource.Spec.Export.URL)
+		return nil, err
+	}
+
+	return &ChooseStrategyResult{Strategy: cdiv1.CloneStrategyHostAssisted}, nil
+}
+
+// validateSourceExport returns an error if the clone can't download the volume it exports
+func validateSourceExport(spec *cdiv1.VolumeCloneSourceSpec) error {
+	if !IsDataSourcePVC(spec.Source.Kind) {
+		return fmt.Errorf("exported sources are claims, not %s", spec.Source.Kind)
+	}
+	// the export token is a bearer secret, it is only sent over TLS
+	u, err := url.Parse(spec.Export.URL)
+	if err != nil || u.Scheme != "https" || u.Hostname() == "" || u.User != nil {
+		return fmt.Errorf("export url must be the https url of an exported volume, without credentials")
+	}
+	if spec.Export.SecretRef == "" {
+		return fmt.Errorf("export source needs a secretRef holding the export token")
+	}
+	if spec.Export.ClientCertSecretRef != "" {
+		return fmt.Errorf("the client certificate of exported sources is read from the tls.crt and tls.key keys of their secretRef")
+	}
+	strategies := slices.Clone(spec.StrategyFallbacks)
+	if spec.Strategy != nil {
+		strategies = append(strategies, *spec.Strategy)
+	}
+	for _, strategy := range strategies {
+		if strategy != cdiv1.CloneStrategyHostAssisted {
+			return fmt.Errorf("exported sources are only cloned with the %s strategy", cdiv1.CloneStrategyHostAssisted)
 		}
 	}
+	return nil
+}
 
-	return res, nil
+// cloneStrategyChain returns the strategies tried in order for the clone, starting with the preferred one. Unless the
+// clone lists its strategies, it falls back to host-assisted
+func cloneStrategyChain(dataSource *cdiv1.VolumeCloneSource, preferred cdiv1.CDICloneStrategy) []cdiv1.CDICloneStrategy {
+	fallbacks := dataSource.Spec.StrategyFallbacks
+	if dataSource.Spec.Strategy == nil && fallbacks == nil {
+		fallbacks = []cdiv1.CDICloneStrategy{cdiv1.CloneStrategyHostAssisted}
+	}
+	strategies := []cdiv1.CDICloneStrategy{preferred}
+	for _, strategy := range fallbacks {
+		if !slices.Contains(strategies, strategy) {
+			strategies = append(strategies, strategy)
+		}
+	}
+	return strategies
+}
+
+// validateStrategyForSourcePVC returns the reason and the message why the strategy can't clone the source claim, empty
+// if it can
+func (p *Planner) validateStrategyForSourcePVC(ctx context.Context, args *ChooseStrategyArgs, sourceClaim *corev1.PersistentVolumeClaim, strategy cdiv1.CDICloneStrategy) (string, string, error) {
+	switch strategy {
+	case cdiv1.CloneStrategyHostAssisted:
+		return "", "", nil
+	case cdiv1.CloneStrategySnapshot:
+		n, err := GetCompatibleVolumeSnapshotClass(ctx, p.Client, args.Log, p.Recorder, sourceClaim, args.TargetClaim)
+		if err != nil {
+			return "", "", err
+		}
+		if n == nil {
+			return NoVolumeSnapshotClass, MessageNoVolumeSnapshotClass, nil
+		}
+	case cdiv1.CloneStrategyCsiClone:
+	case cdiv1.CloneStrategyReflink:
+		if args.DataSource.Spec.Format != nil {
+			return CloneFormatConversion, MessageCloneFormatConversion, nil
+		}
+		if !sameFilesystem(sourceClaim, args.TargetClaim) {
+			return DifferentFilesystems, MessageDifferentFilesystems, nil
+		}
+		return "", "", nil
+	default:
+		return CloneStrategyNotSupported, fmt.Sprintf(MessageCloneStrategyNotSupported, strategy, "PersistentVolumeClaim"), nil
+	}
+	if args.DataSource.Spec.Format != nil {
+		return CloneFormatConversion, MessageCloneFormatConversion, nil
+	}
+
+	return p.validateAdvancedClonePVC(ctx, args, sourceClaim)
+}
+
+// sameFilesystem returns true if the source and the target of a reflink clone are filesystem volumes of the same
+// storage class, which provisions them on the filesystem sharing their extents
+func sameFilesystem(sourceClaim, targetClaim *corev1.PersistentVolumeClaim) bool {
+	if cc.GetVolumeMode(sourceClaim) != corev1.PersistentVolumeFilesystem || cc.GetVolumeMode(targetClaim) != corev1.PersistentVolumeFilesystem {
+		return false
+	}
+	return sourceClaim.Spec.StorageClassName != nil && targetClaim.Spec.StorageClassName != nil &&
+		*sourceClaim.Spec.StorageClassName == *targetClaim.Spec.StorageClassName
 }
 
 func (p *Planner) computeStrategyForSourceSnapshot(ctx context.Context, args *ChooseStrategyArgs) (*ChooseStrategyResult, error) {
@@ -399,35 +592,78 @@ func (p *Planner) computeStrategyForSourceSnapshot(ctx context.Context, args *Ch
 	if targetStorageClass == nil {
 		return nil, fmt.Errorf("target claim's storageclass doesn't exist, clone will not work")
 	}
+
+	strategy := cdiv1.CloneStrategySnapshot
+	if args.DataSource.Spec.Strategy != nil {
+		strategy = *args.DataSource.Spec.Strategy
+	}
+
+	strategies := cloneStrategyChain(args.DataSource, strategy)
+	for _, strategy := range strategies {
+		reason, message, err := p.validateStrategyForSourceSnapshot(ctx, args, sourceSnapshot, vsc, targetStorageClass, strategy)
+		if err != nil {
+			return nil, err
+		}
+		if reason == "" {
+			res.Strategy = strategy
+			return res, nil
+		}
+		p.fallBack(args.TargetClaim, res, strategy, reason, message)
+	}
+
+	p.noCloneStrategy(args, strategies)
+	return nil, nil
+}
+
+// validateStrategyForSourceSnapshot returns the reason and the message why the strategy can't clone the source
+// snapshot, empty if it can
+func (p *Planner) validateStrategyForSourceSnapshot(ctx context.Context, args *ChooseStrategyArgs, sourceSnapshot *snapshotv1.VolumeSnapshot,
+	vsc *snapshotv1.VolumeSnapshotContent, targetStorageClass *storagev1.StorageClass, strategy cdiv1.CDICloneStrategy) (string, string, error) {
+	switch strategy {
+	case cdiv1.CloneStrategyHostAssisted:
+		return "", "", nil
+	case cdiv1.CloneStrategySnapshot:
+	default:
+		return CloneStrategyNotSupported, fmt.Sprintf(MessageCloneStrategyNotSupported, strategy, "VolumeSnapshot"), nil
+	}
+	if args.DataSource.Spec.Format != nil {
+		return CloneFormatConversion, MessageCloneFormatConversion, nil
+	}
+
 	valid, err := cc.ValidateSnapshotCloneProvisioners(vsc, targetStorageClass)
 	if err != nil {
-		return nil, err
+		return "", "", err
 	}
 	if !valid {
-		p.fallbackToHostAssisted(args.TargetClaim, res, NoProvisionerMatch, MessageNoProvisionerMatch)
 		args.Log.V(3).Info("Provisioner differs, need to fall back to host assisted")
-		return res, nil
+		return NoProvisionerMatch, MessageNoProvisionerMatch, nil
+	}
+
+	valid, err = SameSnapshotClass(ctx, p.Client, vsc, args.TargetClaim)
+	if err != nil {
+		return "", "", err
+	}
+	if !valid {
+		args.Log.V(3).Info("Snapshot class differs from the one of the target storage class, need to fall back to host assisted")
+		return IncompatibleSnapshotClass, MessageIncompatibleSnapshotClass, nil
 	}
 
 	// do size validation
 	valid, err = cc.ValidateSnapshotCloneSize(sourceSnapshot, &args.TargetClaim.Spec, targetStorageClass, args.Log)
 	if err != nil {
-		return nil, err
+		return "", "", err
 	}
 	if !valid {
-		p.fallbackToHostAssisted(args.TargetClaim, res, NoVolumeExpansion, MessageNoVolumeExpansion)
-		return res, nil
+		return NoVolumeExpansion, MessageNoVolumeExpansion, nil
 	}
 
 	// Lastly, do volume mode validation to determine whether to use dumb or smart cloning
 	if !SameVolumeMode(vsc.Spec.SourceVolumeMode, args.TargetClaim) {
-		p.fallbackToHostAssisted(args.TargetClaim, res, IncompatibleVolumeModes, MessageIncompatibleVolumeModes)
 		args.Log.V(3).Info("Volume modes differs, need to fall back to host assisted - Snapshot")
-		return res, nil
+		return IncompatibleVolumeModes, MessageIncompatibleVolumeModes, nil
 	}
 
-	res.Strategy = cdiv1.CloneStrategySnapshot
-	return res, nil
+	return "", "", nil
 }
 
 func (p *Planner) validateTargetStorageClassAssignment(ctx context.Context, args *ChooseStrategyArgs) (bool, error) {
@@ -469,55 +705,60 @@ func (p *Planner) validateSourcePVC(args *ChooseStrategyArgs, sourceClaim *corev
 	return nil
 }
 
-func (p *Planner) validateAdvancedClonePVC(ctx context.Context, args *ChooseStrategyArgs, res *ChooseStrategyResult, sourceClaim *corev1.PersistentVolumeClaim) error {
+func (p *Planner) validateAdvancedClonePVC(ctx context.Context, args *ChooseStrategyArgs, sourceClaim *corev1.PersistentVolumeClaim) (string, string, error) {
 	driver, err := GetCommonDriver(ctx, p.Client, sourceClaim, args.TargetClaim)
 	if err != nil {
-		return err
+		return "", "", err
 	}
 
 	if driver == nil {
-		p.fallbackToHostAssisted(args.TargetClaim, res, IncompatibleProvisioners, MessageIncompatibleProvisioners)
 		args.Log.V(3).Info("CSIDrivers not compatible for advanced clone")
-		return nil
+		return IncompatibleProvisioners, MessageIncompatibleProvisioners, nil
 	}
 
 	if !SameVolumeMode(sourceClaim.Spec.VolumeMode, args.TargetClaim) {
-		p.fallbackToHostAssisted(args.TargetClaim, res, IncompatibleVolumeModes, MessageIncompatibleVolumeModes)
 		args.Log.V(3).Info("volume modes not compatible for advanced clone")
-		return nil
+		return IncompatibleVolumeModes, MessageIncompatibleVolumeModes, nil
 	}
 
 	sc, err := GetStorageClassForClaim(ctx, p.Client, args.TargetClaim)
 	if err != nil {
-		return err
+		return "", "", err
 	}
 
 	if sc == nil {
 		args.Log.V(3).Info("target storage class not found")
-		return fmt.Errorf("target storage class not found")
+		return "", "", fmt.Errorf("target storage class not found")
 	}
 
 	srcCapacity, hasSrcCapacity := sourceClaim.Status.Capacity[corev1.ResourceStorage]
 	targetRequest, hasTargetRequest := args.TargetClaim.Spec.Resources.Requests[corev1.ResourceStorage]
 	allowExpansion := sc.AllowVolumeExpansion != nil && *sc.AllowVolumeExpansion
 	if !hasSrcCapacity || !hasTargetRequest {
-		return fmt.Errorf("source/target size info missing")
+		return "", "", fmt.Errorf("source/target size info missing")
 	}
 
 	if srcCapacity.Cmp(targetRequest) < 0 && !allowExpansion {
-		p.fallbackToHostAssisted(args.TargetClaim, res, NoVolumeExpansion, MessageNoVolumeExpansion)
 		args.Log.V(3).Info("advanced clone not possible, no volume expansion")
+		return NoVolumeExpansion, MessageNoVolumeExpansion, nil
 	}
 
-	return nil
+	return "", "", nil
 }
 
-func (p *Planner) fallbackToHostAssisted(targetClaim *corev1.PersistentVolumeClaim, res *ChooseStrategyResult, reason, message string) {
-	res.Strategy = cdiv1.CloneStrategyHostAssisted
+// fallBack records that the strategy can't be used for the clone, which goes on with the next one
+func (p *Planner) fallBack(targetClaim *corev1.PersistentVolumeClaim, res *ChooseStrategyResult, strategy cdiv1.CDICloneStrategy, reason, message string) {
 	res.FallbackReason = &message
+	res.Fallbacks = append(res.Fallbacks, cdiv1.CloneStrategyFallback{Strategy: strategy, Reason: reason, Message: message})
 	p.Recorder.Event(targetClaim, corev1.EventTypeWarning, reason, message)
 }
 
+// noCloneStrategy reports that none of the strategies the clone lists can be used, the strategy is chosen again later
+func (p *Planner) noCloneStrategy(args *ChooseStrategyArgs, strategies []cdiv1.CDICloneStrategy) {
+	p.Recorder.Event(args.TargetClaim, corev1.EventTypeWarning, NoCloneStrategy, fmt.Sprintf(MessageNoCloneStrategy, strategies))
+	args.Log.V(3).Info("None of the clone strategies can be used", "strategies", strategies)
+}
+
 func (p *Planner) planHostAssistedFromPVC(ctx context.Context, args *PlanArgs) ([]Phase, error) {
 	desiredClaim := createDesiredClaim(args.DataSource.Namespace, args.TargetClaim)
 
@@ -528,7 +769,8 @@ func (p *Planner) planHostAssistedFromPVC(ctx context.Context, args *PlanArgs) (
 		DesiredClaim:   desiredClaim,
 		ImmediateBind:  true,
 		OwnershipLabel: p.OwnershipLabel,
-		Preallocation:  cc.GetPreallocation(ctx, p.Client, args.DataSource.Spec.Preallocation),
+		Preallocation:  cc.GetPreallocation(ctx, p.Client, args.DataSource.Spec.Preallocation, args.TargetClaim.Spec.StorageClassName),
+		Compression:    cc.GetCloneCompression(ctx, p.Client, args.DataSource.Spec.Compression, args.TargetClaim.Spec.StorageClassName),
 		Client:         p.Client,
 		Log:            args.Log,
 		Recorder:       p.Recorder,
@@ -537,6 +779,13 @@ func (p *Planner) planHostAssistedFromPVC(ctx context.Context, args *PlanArgs) (
 	if args.DataSource.Spec.PriorityClassName != nil {
 		hcp.PriorityClassName = *args.DataSource.Spec.PriorityClassName
 	}
+	if args.DataSource.Spec.TransferMode != nil {
+		hcp.TransferMode = *args.DataSource.Spec.TransferMode
+	}
+	if args.DataSource.Spec.Format != nil {
+		hcp.Format = *args.DataSource.Spec.Format
+	}
+	hcp.Export = args.DataSource.Spec.Export
 
 	rp := &RebindPhase{
 		SourceNamespace: desiredClaim.Namespace,
@@ -597,6 +846,7 @@ func (p *Planner) planHostAssistedFromSnapshot(ctx context.Context, args *PlanAr
 		DesiredClaim:   desiredClaim,
 		ImmediateBind:  true,
 		OwnershipLabel: p.OwnershipLabel,
+		Compression:    cc.GetCloneCompression(ctx, p.Client, args.DataSource.Spec.Compression, args.TargetClaim.Spec.StorageClassName),
 		Client:         p.Client,
 		Log:            args.Log,
 		Recorder:       p.Recorder,
@@ -605,6 +855,12 @@ func (p *Planner) planHostAssistedFromSnapshot(ctx context.Context, args *PlanAr
 	if args.DataSource.Spec.PriorityClassName != nil {
 		hcp.PriorityClassName = *args.DataSource.Spec.PriorityClassName
 	}
+	if args.DataSource.Spec.TransferMode != nil {
+		hcp.TransferMode = *args.DataSource.Spec.TransferMode
+	}
+	if args.DataSource.Spec.Format != nil {
+		hcp.Format = *args.DataSource.Spec.Format
+	}
 
 	rp := &RebindPhase{
 		SourceNamespace: desiredClaim.Namespace,
@@ -697,6 +953,11 @@ func (p *Planner) planSnapshotFromPVC(ctx context.Context, args *PlanArgs) ([]Ph
 		Log:                 args.Log,
 		Recorder:            p.Recorder,
 	}
+	if group := args.DataSource.Spec.Group; group != "" {
+		// the clones of the group restore a single snapshot of the source
+		sp.TargetName = groupSnapshotName(group, sp.SourceName, *vsc)
+		sp.Shared = true
+	}
 
 	desiredClaim := createDesiredClaim(args.DataSource.Namespace, args.TargetClaim)
 	cfsp := &SnapshotClonePhase{
@@ -773,6 +1034,39 @@ func (p *Planner) planCSIClone(ctx context.Context, args *PlanArgs) ([]Phase, er
 	return []Phase{cp, pcp, rp}, nil
 }
 
+func (p *Planner) planReflinkClone(ctx context.Context, args *PlanArgs) ([]Phase, error) {
+	desiredClaim := createDesiredClaim(args.DataSource.Namespace, args.TargetClaim)
+	rcp := &ReflinkClonePhase{
+		Owner:           args.TargetClaim,
+		Namespace:       args.DataSource.Namespace,
+		SourceName:      args.DataSource.Spec.Source.Name,
+		DesiredClaim:    desiredClaim.DeepCopy(),
+		Image:           p.Image,
+		PullPolicy:      p.PullPolicy,
+		InstallerLabels: p.InstallerLabels,
+		OwnershipLabel:  p.OwnershipLabel,
+		Client:          p.Client,
+		Log:             args.Log,
+		Recorder:        p.Recorder,
+	}
+
+	if args.DataSource.Spec.PriorityClassName != nil {
+		rcp.PriorityClassName = *args.DataSource.Spec.PriorityClassName
+	}
+
+	rp := &RebindPhase{
+		SourceNamespace: desiredClaim.Namespace,
+		SourceName:      desiredClaim.Name,
+		TargetNamespace: args.TargetClaim.Namespace,
+		TargetName:      args.TargetClaim.Name,
+		Client:          p.Client,
+		Log:             args.Log,
+		Recorder:        p.Recorder,
+	}
+
+	return []Phase{rcp, rp}, nil
+}
+
 func createDesiredClaim(namespace string, targetClaim *corev1.PersistentVolumeClaim) *corev1.PersistentVolumeClaim {
 	targetCpy := targetClaim.DeepCopy()
 	desiredClaim := &corev1.PersistentVolumeClaim{
@@ -795,7 +1089,7 @@ func createTempSourceClaim(ctx context.Context, log logr.Logger, namespace strin
 	if err != nil {
 		return nil, err
 	}
-	scName, err := getStorageClassNameForTempSourceClaim(ctx, vsc, client)
+	scName, err := GetStorageClassForSnapshotRestore(ctx, client, snapshot, vsc.Spec.Driver)
 	if err != nil {
 		return nil, err
 	}
@@ -842,26 +1136,6 @@ func createTempSourceClaim(ctx context.Context, log logr.Logger, namespace strin
 	return desiredClaim, nil
 }
 
-func getStorageClassNameForTempSourceClaim(ctx context.Context, vsc *snapshotv1.VolumeSnapshotContent, client client.Client) (string, error) {
-	var matches []string
-
-	// Attempting to get a storageClass compatible with the source snapshot
-	storageClasses := &storagev1.StorageClassList{}
-	if err := client.List(ctx, storageClasses); err != nil {
-		return "", err
-	}
-	for _, storageClass := range storageClasses.Items {
-		if storageClass.Provisioner == vsc.Spec.Driver {
-			matches = append(matches, storageClass.Name)
-		}
-	}
-	if len(matches) == 0 {
-		return "", fmt.Errorf("unable to find a valid storage class for the temporal source claim")
-	}
-	sort.Strings(matches)
-	return matches[0], nil
-}
-
 func getVolumeModeForTempSourceClaim(log logr.Logger, snapshot *snapshotv1.VolumeSnapshot, vsc *snapshotv1.VolumeSnapshotContent, fallback *corev1.PersistentVolumeMode) (*corev1.PersistentVolumeMode, error) {
 	if vsc.Spec.SourceVolumeMode != nil {
 		// Since 1.29 we should always return here
@@ -355,6 +355,9 @@ var _ = Describe("Planner test", func() {
 				Expect(csr.Strategy).To(Equal(cdiv1.CloneStrategyHostAssisted))
 				Expect(csr.FallbackReason).ToNot(BeNil())
 				Expect(*csr.FallbackReason).To(Equal(MessageNoVolumeSnapshotClass))
+				Expect(csr.Fallbacks).To(Equal([]cdiv1.CloneStrategyFallback{
+					{Strategy: cdiv1.CloneStrategySnapshot, Reason: NoVolumeSnapshotClass, Message: MessageNoVolumeSnapshotClass},
+				}))
 				expectEvent(planner, NoVolumeSnapshotClass)
 			})
 
@@ -525,6 +528,172 @@ var _ = Describe("Planner test", func() {
 				Expect(csr.FallbackReason).ToNot(BeNil())
 				Expect(*csr.FallbackReason).To(Equal(MessageIncompatibleProvisioners))
 			})
+
+			It("should try the clone strategies of the data source in order", func() {
+				dataSource := createPVCDataSource()
+				dataSource.Spec.Strategy = ptr.To(cdiv1.CloneStrategySnapshot)
+				dataSource.Spec.StrategyFallbacks = []cdiv1.CDICloneStrategy{cdiv1.CloneStrategyCsiClone, cdiv1.CloneStrategyHostAssisted}
+				args := &ChooseStrategyArgs{
+					TargetClaim: createTargetClaim(),
+					DataSource:  dataSource,
+					Log:         log,
+				}
+				planner = createPlanner(createStorageClass(), createSourceClaim(), createSourceVolume())
+				csr, err := planner.ChooseStrategy(context.Background(), args)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(csr).ToNot(BeNil())
+				Expect(csr.Strategy).To(Equal(cdiv1.CloneStrategyCsiClone))
+				Expect(csr.Fallbacks).To(Equal([]cdiv1.CloneStrategyFallback{
+					{Strategy: cdiv1.CloneStrategySnapshot, Reason: NoVolumeSnapshotClass, Message: MessageNoVolumeSnapshotClass},
+				}))
+				expectEvent(planner, NoVolumeSnapshotClass)
+			})
+
+			It("should not fall back to host assisted if the data source lists its strategies", func() {
+				dataSource := createPVCDataSource()
+				dataSource.Spec.Strategy = ptr.To(cdiv1.CloneStrategySnapshot)
+				args := &ChooseStrategyArgs{
+					TargetClaim: createTargetClaim(),
+					DataSource:  dataSource,
+					Log:         log,
+				}
+				planner = createPlanner(createStorageClass(), createSourceClaim())
+				csr, err := planner.ChooseStrategy(context.Background(), args)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(csr).To(BeNil())
+				expectEvent(planner, NoCloneStrategy)
+			})
+			It("should return host assisted if the data source converts the image", func() {
+				cs := cdiv1.CloneStrategyCsiClone
+				dataSource := createPVCDataSource()
+				dataSource.Spec.Format = ptr.To(cdiv1.TransformationFormatQcow2)
+				args := &ChooseStrategyArgs{
+					TargetClaim: createTargetClaim(),
+					DataSource:  dataSource,
+					Log:         log,
+				}
+				sp := &cdiv1.StorageProfile{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: storageClassName,
+					},
+					Status: cdiv1.StorageProfileStatus{
+						CloneStrategy: &cs,
+					},
+				}
+				planner = createPlanner(sp, createStorageClass(), createSourceClaim(), createSourceVolume())
+				csr, err := planner.ChooseStrategy(context.Background(), args)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(csr).ToNot(BeNil())
+				Expect(csr.Strategy).To(Equal(cdiv1.CloneStrategyHostAssisted))
+				Expect(csr.FallbackReason).To(HaveValue(Equal(MessageCloneFormatConversion)))
+			})
+
+			It("should return reflink if the data source sets it and the claims share the storage class", func() {
+				dataSource := createPVCDataSource()
+				dataSource.Spec.Strategy = ptr.To(cdiv1.CloneStrategyReflink)
+				args := &ChooseStrategyArgs{
+					TargetClaim: createTargetClaim(),
+					DataSource:  dataSource,
+					Log:         log,
+				}
+				planner = createPlanner(createStorageClass(), createSourceClaim(), createSourceVolume())
+				csr, err := planner.ChooseStrategy(context.Background(), args)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(csr).ToNot(BeNil())
+				Expect(csr.Strategy).To(Equal(cdiv1.CloneStrategyReflink))
+				Expect(csr.FallbackReason).To(BeNil())
+			})
+
+			DescribeTable("should fall back from reflink if the claims are not on the same filesystem", func(source *corev1.PersistentVolumeClaim) {
+				dataSource := createPVCDataSource()
+				dataSource.Spec.Strategy = ptr.To(cdiv1.CloneStrategyReflink)
+				dataSource.Spec.StrategyFallbacks = []cdiv1.CDICloneStrategy{cdiv1.CloneStrategyHostAssisted}
+				args := &ChooseStrategyArgs{
+					TargetClaim: createTargetClaim(),
+					DataSource:  dataSource,
+					Log:         log,
+				}
+				planner = createPlanner(createStorageClass(), source, createSourceVolume())
+				csr, err := planner.ChooseStrategy(context.Background(), args)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(csr).ToNot(BeNil())
+				Expect(csr.Strategy).To(Equal(cdiv1.CloneStrategyHostAssisted))
+				Expect(csr.Fallbacks).To(Equal([]cdiv1.CloneStrategyFallback{
+					{Strategy: cdiv1.CloneStrategyReflink, Reason: DifferentFilesystems, Message: MessageDifferentFilesystems},
+				}))
+				expectEvent(planner, DifferentFilesystems)
+			},
+				Entry("with another storage class", func() *corev1.PersistentVolumeClaim {
+					s := createSourceClaim()
+					s.Spec.StorageClassName = ptr.To("other")
+					return s
+				}()),
+				Entry("with a block source", func() *corev1.PersistentVolumeClaim {
+					s := createSourceClaim()
+					s.Spec.VolumeMode = ptr.To(corev1.PersistentVolumeBlock)
+					return s
+				}()),
+			)
+		})
+
+		Context("Export source", func() {
+			createExportDataSource := func() *cdiv1.VolumeCloneSource {
+				source := createPVCDataSource()
+				source.Spec.Export = &cdiv1.DataVolumeSourceExport{
+					URL:       "https://export.example.com/volumes/source/disk.img.gz",
+					SecretRef: "export-token",
+				}
+				return source
+			}
+
+			It("should return host assisted without a source claim", func() {
+				args := &ChooseStrategyArgs{
+					TargetClaim: createTargetClaim(),
+					DataSource:  createExportDataSource(),
+					Log:         log,
+				}
+				planner = createPlanner(createStorageClass())
+				csr, err := planner.ChooseStrategy(context.Background(), args)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(csr).ToNot(BeNil())
+				Expect(csr.Strategy).To(Equal(cdiv1.CloneStrategyHostAssisted))
+				Expect(csr.FallbackReason).To(BeNil())
+			})
+
+			DescribeTable("should refuse the exports", func(update func(*cdiv1.VolumeCloneSourceSpec)) {
+				source := createExportDataSource()
+				update(&source.Spec)
+				args := &ChooseStrategyArgs{
+					TargetClaim: createTargetClaim(),
+					DataSource:  source,
+					Log:         log,
+				}
+				planner = createPlanner(createStorageClass())
+				csr, err := planner.ChooseStrategy(context.Background(), args)
+				Expect(err).To(HaveOccurred())
+				Expect(csr).To(BeNil())
+				event := <-planner.Recorder.(*record.FakeRecorder).Events
+				Expect(event).To(ContainSubstring(CloneValidationFailed))
+			},
+				Entry("of snapshots", func(spec *cdiv1.VolumeCloneSourceSpec) {
+					spec.Source.Kind = "VolumeSnapshot"
+				}),
+				Entry("without TLS", func(spec *cdiv1.VolumeCloneSourceSpec) {
+					spec.Export.URL = "http://export.example.com/volumes/source/disk.img.gz"
+				}),
+				Entry("without a secret", func(spec *cdiv1.VolumeCloneSourceSpec) {
+					spec.Export.SecretRef = ""
+				}),
+				Entry("with a client certificate secret", func(spec *cdiv1.VolumeCloneSourceSpec) {
+					spec.Export.ClientCertSecretRef = "client-cert"
+				}),
+				Entry("with another strategy", func(spec *cdiv1.VolumeCloneSourceSpec) {
+					spec.Strategy = ptr.To(cdiv1.CloneStrategySnapshot)
+				}),
+				Entry("with another fallback", func(spec *cdiv1.VolumeCloneSourceSpec) {
+					spec.StrategyFallbacks = []cdiv1.CDICloneStrategy{cdiv1.CloneStrategyHostAssisted, cdiv1.CloneStrategyCsiClone}
+				}),
+			)
 		})
 
 		Context("Snapshot source", func() {
@@ -677,6 +846,54 @@ var _ = Describe("Planner test", func() {
 				Expect(csr).ToNot(BeNil())
 				Expect(csr.Strategy).To(Equal(cdiv1.CloneStrategySnapshot))
 			})
+
+			It("should fall back from csi-clone, which can't clone snapshots", func() {
+				source := createSourceSnapshot(sourceName, "test-snapshot-content-name", "vsc")
+				dataSource := createSnapshotDataSource()
+				dataSource.Spec.Strategy = ptr.To(cdiv1.CloneStrategyCsiClone)
+				dataSource.Spec.StrategyFallbacks = []cdiv1.CDICloneStrategy{cdiv1.CloneStrategyHostAssisted}
+				args := &ChooseStrategyArgs{
+					TargetClaim: createTargetClaim(),
+					DataSource:  dataSource,
+					Log:         log,
+				}
+				planner = createPlanner(createStorageClass(), source, createDefaultVolumeSnapshotContent("driver"))
+				csr, err := planner.ChooseStrategy(context.Background(), args)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(csr).ToNot(BeNil())
+				Expect(csr.Strategy).To(Equal(cdiv1.CloneStrategyHostAssisted))
+				Expect(csr.Fallbacks).To(HaveLen(1))
+				Expect(csr.Fallbacks[0].Strategy).To(Equal(cdiv1.CloneStrategyCsiClone))
+				Expect(csr.Fallbacks[0].Reason).To(Equal(CloneStrategyNotSupported))
+				expectEvent(planner, CloneStrategyNotSupported)
+			})
+
+			DescribeTable("should check the snapshot class pinned by the storage profile of the target", func(snapshotClass string, expected cdiv1.CDICloneStrategy) {
+				source := createSourceSnapshot(sourceName, "test-snapshot-content-name", "vsc")
+				args := &ChooseStrategyArgs{
+					TargetClaim: createTargetClaim(),
+					DataSource:  createSnapshotDataSource(),
+					Log:         log,
+				}
+				vsc := createDefaultVolumeSnapshotContent("driver")
+				vsc.Spec.VolumeSnapshotClassName = ptr.To("vsc")
+				sp := &cdiv1.StorageProfile{
+					ObjectMeta: metav1.ObjectMeta{Name: storageClassName},
+					Spec:       cdiv1.StorageProfileSpec{SnapshotClass: &snapshotClass},
+				}
+				planner = createPlanner(createStorageClass(), source, vsc, sp)
+				csr, err := planner.ChooseStrategy(context.Background(), args)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(csr).ToNot(BeNil())
+				Expect(csr.Strategy).To(Equal(expected))
+				if expected == cdiv1.CloneStrategyHostAssisted {
+					Expect(csr.FallbackReason).To(HaveValue(Equal(MessageIncompatibleSnapshotClass)))
+					expectEvent(planner, IncompatibleSnapshotClass)
+				}
+			},
+				Entry("smart clone with the snapshot class", "vsc", cdiv1.CloneStrategySnapshot),
+				Entry("host assisted with another snapshot class", "other-vsc", cdiv1.CloneStrategyHostAssisted),
+			)
 		})
 	})
 
@@ -799,6 +1016,43 @@ var _ = Describe("Planner test", func() {
 			validateRebindPhase(planner, args, plan[1])
 		})
 
+		It("should plan host assisted with the format of the data source", func() {
+			dataSource := createPVCDataSource()
+			dataSource.Spec.Format = ptr.To(cdiv1.TransformationFormatRaw)
+			args := &PlanArgs{
+				Strategy:    cdiv1.CloneStrategyHostAssisted,
+				TargetClaim: createTargetClaim(),
+				DataSource:  dataSource,
+				Log:         log,
+			}
+			planner = createPlanner(cdiConfig, createStorageClass(), createSourceClaim())
+			plan, err := planner.Plan(context.Background(), args)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(plan).To(HaveLen(2))
+			validateHostClonePhase(planner, args, plan[0])
+			Expect(plan[0].(*HostClonePhase).Format).To(Equal(cdiv1.TransformationFormatRaw))
+		})
+
+		It("should plan host assisted with the export of the data source", func() {
+			dataSource := createPVCDataSource()
+			dataSource.Spec.Export = &cdiv1.DataVolumeSourceExport{
+				URL:       "https://export.example.com/volumes/source/disk.img.gz",
+				SecretRef: "export-token",
+			}
+			args := &PlanArgs{
+				Strategy:    cdiv1.CloneStrategyHostAssisted,
+				TargetClaim: createTargetClaim(),
+				DataSource:  dataSource,
+				Log:         log,
+			}
+			planner = createPlanner(cdiConfig, createStorageClass())
+			plan, err := planner.Plan(context.Background(), args)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(plan).To(HaveLen(2))
+			validateHostClonePhase(planner, args, plan[0])
+			Expect(plan[0].(*HostClonePhase).Export).To(Equal(dataSource.Spec.Export))
+		})
+
 		It("should plan snapshot", func() {
 			source := createSourceClaim()
 			target := createTargetClaim()
@@ -819,6 +1073,32 @@ var _ = Describe("Planner test", func() {
 			validateRebindPhase(planner, args, plan[3])
 		})
 
+		It("should plan snapshot shared by the clone group", func() {
+			dataSource := createPVCDataSource()
+			dataSource.Spec.Group = "vdi"
+			args := &PlanArgs{
+				Strategy:    cdiv1.CloneStrategySnapshot,
+				TargetClaim: createTargetClaim(),
+				DataSource:  dataSource,
+				Log:         log,
+			}
+			planner = createPlanner(cdiConfig, createStorageClass(), createVolumeSnapshotClass(), createSourceClaim())
+			plan, err := planner.Plan(context.Background(), args)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(plan).To(HaveLen(4))
+			sp := plan[0].(*SnapshotPhase)
+			Expect(sp.Shared).To(BeTrue())
+			Expect(sp.TargetName).To(Equal(groupSnapshotName("vdi", sourceName, "vsc")))
+			Expect(plan[1].(*SnapshotClonePhase).SourceName).To(Equal(sp.TargetName))
+
+			other := createTargetClaim()
+			other.UID = "other-uid"
+			args.TargetClaim = other
+			plan, err = planner.Plan(context.Background(), args)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(plan[0].(*SnapshotPhase).TargetName).To(Equal(sp.TargetName))
+		})
+
 		It("should plan csi-clone", func() {
 			source := createSourceClaim()
 			target := createTargetClaim()
@@ -838,6 +1118,28 @@ var _ = Describe("Planner test", func() {
 			validateRebindPhase(planner, args, plan[2])
 		})
 
+		It("should plan reflink clone", func() {
+			source := createSourceClaim()
+			target := createTargetClaim()
+			args := &PlanArgs{
+				Strategy:    cdiv1.CloneStrategyReflink,
+				TargetClaim: target,
+				DataSource:  createPVCDataSource(),
+				Log:         log,
+			}
+			planner = createPlanner(cdiConfig, createStorageClass(), source)
+			plan, err := planner.Plan(context.Background(), args)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(plan).To(HaveLen(2))
+			rcp := plan[0].(*ReflinkClonePhase)
+			Expect(rcp.Owner).To(Equal(args.TargetClaim))
+			Expect(rcp.SourceName).To(Equal(sourceName))
+			Expect(rcp.DesiredClaim.Name).To(Equal(tmpClaimName(args.TargetClaim.UID)))
+			Expect(rcp.Image).To(Equal(planner.Image))
+			Expect(rcp.OwnershipLabel).To(Equal(planner.OwnershipLabel))
+			validateRebindPhase(planner, args, plan[1])
+		})
+
 		It("should plan smart clone from snapshot", func() {
 			source := createSourceSnapshot(sourceName, "test-snapshot-content-name", "vsc")
 			target := createTargetClaim()
@@ -951,6 +1253,51 @@ var _ = Describe("Planner test", func() {
 			})
 		})
 
+		Context("temp host assisted source pvc storage class", func() {
+			createStorageClassNamed := func(name string, isDefault bool) *storagev1.StorageClass {
+				sc := createStorageClass()
+				sc.Name = name
+				if isDefault {
+					cc.AddAnnotation(sc, cc.AnnDefaultStorageClass, "true")
+				}
+				return sc
+			}
+
+			snapshottedClaim := func(scName string) *corev1.PersistentVolumeClaim {
+				claim := createClaim("some-pvc-that-was-snapshotted")
+				claim.Spec.StorageClassName = ptr.To(scName)
+				return claim
+			}
+
+			storageProfile := func(name, snapshotClass string) *cdiv1.StorageProfile {
+				return &cdiv1.StorageProfile{
+					ObjectMeta: metav1.ObjectMeta{Name: name},
+					Status:     cdiv1.StorageProfileStatus{SnapshotClass: ptr.To(snapshotClass)},
+				}
+			}
+
+			DescribeTable("should restore the snapshot to a storage class compatible with its source", func(objs []runtime.Object, expected string) {
+				args := &PlanArgs{
+					Strategy:    cdiv1.CloneStrategyHostAssisted,
+					TargetClaim: createTargetClaim(),
+					DataSource:  createSnapshotDataSource(),
+					Log:         log,
+				}
+				runtimeObjs := []runtime.Object{cdiConfig, createSourceSnapshot(sourceName, "test-snapshot-content-name", "vsc"),
+					createVolumeSnapshotClass(), createDefaultVolumeSnapshotContent(),
+					createStorageClassNamed("a-sc", false), createStorageClassNamed("b-sc", false), createStorageClassNamed("c-sc", true)}
+				planner = createPlanner(append(runtimeObjs, objs...)...)
+				plan, err := planner.Plan(context.Background(), args)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(plan).To(HaveLen(4))
+				Expect(plan[0].(*SnapshotClonePhase).DesiredClaim.Spec.StorageClassName).To(HaveValue(Equal(expected)))
+			},
+				Entry("storage class of the snapshotted claim", []runtime.Object{snapshottedClaim("b-sc"), storageProfile("a-sc", "vsc")}, "b-sc"),
+				Entry("storage class whose profile uses the snapshot class", []runtime.Object{storageProfile("a-sc", "other-vsc"), storageProfile("b-sc", "vsc")}, "b-sc"),
+				Entry("default storage class", []runtime.Object{storageProfile("a-sc", "other-vsc")}, "c-sc"),
+			)
+		})
+
 		It("should fail planning host-assisted clone from snapshot when no valid storage class for source PVC is found", func() {
 			source := createSourceSnapshot(sourceName, "test-snapshot-content-name", "vsc")
 			target := createTargetClaim()
@@ -1017,5 +1364,40 @@ var _ = Describe("Planner test", func() {
 				Expect(k8serrors.IsNotFound(err)).To(BeTrue())
 			}
 		})
+
+		groupSnapshot := func(members ...string) *snapshotv1.VolumeSnapshot {
+			snapshot := &snapshotv1.VolumeSnapshot{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: namespace,
+					Name:      "groupSnapshot",
+					Labels:    map[string]string{},
+				},
+			}
+			for _, uid := range members {
+				snapshot.Labels[LabelCloneGroupMemberPrefix+uid] = ""
+			}
+			return snapshot
+		}
+
+		It("should leave the snapshot still restored by its clone group", func() {
+			target := createTargetClaim()
+			planner = createPlanner(groupSnapshot(string(target.UID), "other-uid"))
+			err := planner.Cleanup(context.Background(), log, target)
+			Expect(err).ToNot(HaveOccurred())
+			snapshot := &snapshotv1.VolumeSnapshot{}
+			err = planner.Client.Get(context.Background(), client.ObjectKey{Namespace: namespace, Name: "groupSnapshot"}, snapshot)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(snapshot.Labels).To(HaveKey(LabelCloneGroupMemberPrefix + "other-uid"))
+			Expect(snapshot.Labels).ToNot(HaveKey(LabelCloneGroupMemberPrefix + string(target.UID)))
+		})
+
+		It("should delete the snapshot of the clone group with its last member", func() {
+			target := createTargetClaim()
+			planner = createPlanner(groupSnapshot(string(target.UID)))
+			err := planner.Cleanup(context.Background(), log, target)
+			Expect(err).ToNot(HaveOccurred())
+			err = planner.Client.Get(context.Background(), client.ObjectKey{Namespace: namespace, Name: "groupSnapshot"}, &snapshotv1.VolumeSnapshot{})
+			Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+		})
 	})
 })
@@ -13,6 +13,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
 	"kubevirt.io/containerized-data-importer/pkg/common"
 	cc "kubevirt.io/containerized-data-importer/pkg/controller/common"
 	"kubevirt.io/containerized-data-importer/pkg/util"
@@ -36,11 +37,18 @@ type PrepClaimPhase struct {
 
 var _ Phase = &PrepClaimPhase{}
 
+var _ ProgressPhaser = &PrepClaimPhase{}
+
 // Name returns the name of the phase
 func (p *PrepClaimPhase) Name() string {
 	return PrepClaimPhaseName
 }
 
+// ProgressPhase returns the phase of the clone progress
+func (p *PrepClaimPhase) ProgressPhase() cdiv1.DataVolumeClonePhase {
+	return cdiv1.ClonePhaseResize
+}
+
 // Reconcile ensures that a pvc is bound and resized if necessary
 func (p *PrepClaimPhase) Reconcile(ctx context.Context) (*reconcile.Result, error) {
 	actualClaim := &corev1.PersistentVolumeClaim{}
@@ -0,0 +1,255 @@
+package clone
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+	"kubevirt.io/containerized-data-importer/pkg/common"
+	cc "kubevirt.io/containerized-data-importer/pkg/controller/common"
+	"kubevirt.io/containerized-data-importer/pkg/util"
+)
+
+const (
+	// ReflinkClonePhaseName is the name of the reflink clone phase
+	ReflinkClonePhaseName = "ReflinkClone"
+
+	reflinkVolName = "cdi-reflink-target"
+)
+
+// ReflinkClonePhase clones the files of a filesystem claim onto a new claim of the same shared filesystem, with a pod
+// mounting both of them. The files share their extents with the source, or are copied by the file server, instead of
+// being streamed between pods.
+type ReflinkClonePhase struct {
+	Owner             client.Object
+	Namespace         string
+	SourceName        string
+	DesiredClaim      *corev1.PersistentVolumeClaim
+	Image             string
+	PullPolicy        corev1.PullPolicy
+	InstallerLabels   map[string]string
+	OwnershipLabel    string
+	PriorityClassName string
+	Client            client.Client
+	Log               logr.Logger
+	Recorder          record.EventRecorder
+}
+
+var _ Phase = &ReflinkClonePhase{}
+
+var _ ProgressPhaser = &ReflinkClonePhase{}
+
+// Name returns the name of the phase
+func (p *ReflinkClonePhase) Name() string {
+	return ReflinkClonePhaseName
+}
+
+// ProgressPhase returns the phase of the clone progress
+func (p *ReflinkClonePhase) ProgressPhase() cdiv1.DataVolumeClonePhase {
+	return cdiv1.ClonePhaseTransfer
+}
+
+// Reconcile creates the desired pvc and the pod cloning the source onto it, and waits for the pod to complete
+func (p *ReflinkClonePhase) Reconcile(ctx context.Context) (*reconcile.Result, error) {
+	podName := fmt.Sprintf("reflink-%s", string(p.Owner.GetUID()))
+	pod := &corev1.Pod{}
+	podExists, err := getResource(ctx, p.Client, p.Namespace, podName, pod)
+	if err != nil {
+		return nil, err
+	}
+
+	actualClaim := &corev1.PersistentVolumeClaim{}
+	exists, err := getResource(ctx, p.Client, p.Namespace, p.DesiredClaim.Name, actualClaim)
+	if err != nil {
+		return nil, err
+	}
+
+	if !exists {
+		args := &IsSourceClaimReadyArgs{
+			Target:          p.Owner,
+			SourceNamespace: p.Namespace,
+			SourceName:      p.SourceName,
+			Client:          p.Client,
+			Log:             p.Log,
+			Recorder:        p.Recorder,
+		}
+
+		ready, err := IsSourceClaimReady(ctx, args)
+		if err != nil {
+			return nil, err
+		}
+
+		if !ready {
+			return &reconcile.Result{RequeueAfter: 2 * time.Second}, nil
+		}
+
+		actualClaim, err = p.createClaim(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if actualClaim.Annotations[cc.AnnPodPhase] == string(corev1.PodSucceeded) {
+		if podExists {
+			p.Log.V(3).Info("Reflink pod succeeded, deleting")
+			if err := p.Client.Delete(ctx, pod); err != nil {
+				return nil, cc.IgnoreNotFound(err)
+			}
+		}
+		return nil, nil
+	}
+
+	if !podExists {
+		p.Log.V(3).Info("creating reflink pod")
+		if err := p.createPod(ctx, podName, actualClaim); err != nil {
+			return nil, err
+		}
+		return &reconcile.Result{}, nil
+	}
+
+	if pod.Status.Phase != corev1.PodSucceeded {
+		// pod is running
+		return &reconcile.Result{}, nil
+	}
+
+	p.Log.V(3).Info("Reflink pod succeeded")
+	claim := actualClaim.DeepCopy()
+	cc.AddAnnotation(claim, cc.AnnPodPhase, string(corev1.PodSucceeded))
+	if err := p.Client.Update(ctx, claim); err != nil {
+		return nil, err
+	}
+
+	// come back once pvc is updated
+	return &reconcile.Result{}, nil
+}
+
+func (p *ReflinkClonePhase) createClaim(ctx context.Context) (*corev1.PersistentVolumeClaim, error) {
+	claim := p.DesiredClaim.DeepCopy()
+
+	claim.Namespace = p.Namespace
+	cc.AddAnnotation(claim, cc.AnnOwnerUID, string(p.Owner.GetUID()))
+	cc.AddAnnotation(claim, cc.AnnPopulatorKind, cdiv1.VolumeCloneSourceRef)
+	if p.OwnershipLabel != "" {
+		AddOwnershipLabel(p.OwnershipLabel, claim, p.Owner)
+	}
+	cc.AddLabel(claim, cc.LabelExcludeFromVeleroBackup, "true")
+
+	if err := p.Client.Create(ctx, claim); err != nil {
+		checkQuotaExceeded(p.Recorder, p.Owner, err)
+		return nil, err
+	}
+
+	return claim, nil
+}
+
+func (p *ReflinkClonePhase) createPod(ctx context.Context, name string, pvc *corev1.PersistentVolumeClaim) error {
+	resourceRequirements, err := cc.GetDefaultPodResourceRequirements(p.Client)
+	if err != nil {
+		return err
+	}
+
+	imagePullSecrets, err := cc.GetImagePullSecrets(p.Client)
+	if err != nil {
+		return err
+	}
+
+	workloadNodePlacement, err := cc.GetWorkloadNodePlacement(ctx, p.Client)
+	if err != nil {
+		return err
+	}
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: pvc.Namespace,
+			Annotations: map[string]string{
+				cc.AnnCreatedBy: "yes",
+			},
+			Labels: map[string]string{
+				common.CDILabelKey:       common.CDILabelValue,
+				common.CDIComponentLabel: "cdi-reflink-clone",
+			},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name:            "reflink",
+					Image:           p.Image,
+					ImagePullPolicy: p.PullPolicy,
+					Command:         []string{"/usr/bin/cdi-cloner"},
+					Args: []string{
+						"-v=3",
+						"-alsologtostderr",
+						"-mount", common.ClonerMountPath,
+						"-reflink-target", common.ClonerTargetMountPath,
+					},
+					VolumeMounts: []corev1.VolumeMount{
+						{
+							Name:      cc.DataVolName,
+							MountPath: common.ClonerMountPath,
+							ReadOnly:  true,
+						},
+						{
+							Name:      reflinkVolName,
+							MountPath: common.ClonerTargetMountPath,
+						},
+					},
+					TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+				},
+			},
+			ImagePullSecrets: imagePullSecrets,
+			RestartPolicy:    corev1.RestartPolicyOnFailure,
+			Volumes: []corev1.Volume{
+				{
+					Name: cc.DataVolName,
+					VolumeSource: corev1.VolumeSource{
+						PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+							ClaimName: p.SourceName,
+							ReadOnly:  true,
+						},
+					},
+				},
+				{
+					Name: reflinkVolName,
+					VolumeSource: corev1.VolumeSource{
+						PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+							ClaimName: pvc.Name,
+						},
+					},
+				},
+			},
+			NodeSelector:      workloadNodePlacement.NodeSelector,
+			Tolerations:       workloadNodePlacement.Tolerations,
+			Affinity:          workloadNodePlacement.Affinity,
+			PriorityClassName: p.PriorityClassName,
+		},
+	}
+	util.SetRecommendedLabels(pod, p.InstallerLabels, "cdi-controller")
+
+	if resourceRequirements != nil {
+		pod.Spec.Containers[0].Resources = *resourceRequirements
+	}
+
+	if p.OwnershipLabel != "" {
+		AddOwnershipLabel(p.OwnershipLabel, pod, p.Owner)
+	}
+
+	cc.CopyAllowedAnnotations(pvc, pod)
+	cc.SetRestrictedSecurityContext(&pod.Spec)
+
+	if err := p.Client.Create(ctx, pod); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,190 @@
+/*
+Copyright 2026 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clone
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+	"kubevirt.io/containerized-data-importer/pkg/common"
+	cc "kubevirt.io/containerized-data-importer/pkg/controller/common"
+)
+
+var _ = Describe("ReflinkClonePhase test", func() {
+	log := logf.Log.WithName("reflink-clone-phase-test")
+
+	const (
+		namespace  = "ns"
+		sourceName = "source"
+		podName    = "reflink-uid"
+	)
+
+	storageClassName := "shared-fs"
+
+	createReflinkClonePhase := func(objects ...runtime.Object) *ReflinkClonePhase {
+		s := scheme.Scheme
+		_ = cdiv1.AddToScheme(s)
+
+		objects = append(objects, cc.MakeEmptyCDICR(), cc.MakeEmptyCDIConfigSpec(common.ConfigName))
+
+		cl := fake.NewClientBuilder().
+			WithScheme(s).
+			WithRuntimeObjects(objects...).
+			Build()
+
+		owner := &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      "owner",
+				UID:       "uid",
+			},
+		}
+
+		desired := &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      "desired",
+			},
+			Spec: corev1.PersistentVolumeClaimSpec{
+				StorageClassName: &storageClassName,
+				Resources: corev1.VolumeResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceStorage: resource.MustParse("10Gi"),
+					},
+				},
+			},
+		}
+
+		return &ReflinkClonePhase{
+			Owner:          owner,
+			OwnershipLabel: "label",
+			Namespace:      namespace,
+			SourceName:     sourceName,
+			DesiredClaim:   desired,
+			Image:          "cloner",
+			Client:         cl,
+			Recorder:       record.NewFakeRecorder(10),
+			Log:            log,
+		}
+	}
+
+	sourceClaim := func() *corev1.PersistentVolumeClaim {
+		return &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      sourceName,
+			},
+			Spec: corev1.PersistentVolumeClaimSpec{
+				StorageClassName: &storageClassName,
+			},
+			Status: corev1.PersistentVolumeClaimStatus{
+				Phase: corev1.ClaimBound,
+			},
+		}
+	}
+
+	desiredClaim := func(p *ReflinkClonePhase) *corev1.PersistentVolumeClaim {
+		pvc := &corev1.PersistentVolumeClaim{}
+		err := p.Client.Get(context.Background(), client.ObjectKeyFromObject(p.DesiredClaim), pvc)
+		Expect(err).ToNot(HaveOccurred())
+		return pvc
+	}
+
+	reflinkPod := func(p *ReflinkClonePhase) (*corev1.Pod, bool) {
+		pod := &corev1.Pod{}
+		exists, err := getResource(context.Background(), p.Client, namespace, podName, pod)
+		Expect(err).ToNot(HaveOccurred())
+		return pod, exists
+	}
+
+	It("should requeue if source does not exist", func() {
+		p := createReflinkClonePhase()
+		result, err := p.Reconcile(context.Background())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).ToNot(BeNil())
+		Expect(result.RequeueAfter).ToNot(BeZero())
+	})
+
+	It("should create the pvc and the pod mounting it with the source", func() {
+		p := createReflinkClonePhase(sourceClaim())
+
+		result, err := p.Reconcile(context.Background())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).ToNot(BeNil())
+
+		pvc := desiredClaim(p)
+		Expect(pvc.Spec.DataSourceRef).To(BeNil())
+		Expect(pvc.Annotations[cc.AnnPopulatorKind]).To(Equal(cdiv1.VolumeCloneSourceRef))
+		Expect(pvc.Annotations).ToNot(HaveKey(cc.AnnCloneRequest))
+		Expect(pvc.Labels[p.OwnershipLabel]).To(Equal("uid"))
+
+		pod, exists := reflinkPod(p)
+		Expect(exists).To(BeTrue())
+		Expect(pod.Labels[p.OwnershipLabel]).To(Equal("uid"))
+		Expect(pod.Spec.Containers[0].Image).To(Equal("cloner"))
+		Expect(pod.Spec.Containers[0].Args).To(ContainElements(common.ClonerMountPath, common.ClonerTargetMountPath))
+		Expect(pod.Spec.Volumes).To(HaveLen(2))
+		Expect(pod.Spec.Volumes[0].PersistentVolumeClaim.ClaimName).To(Equal(sourceName))
+		Expect(pod.Spec.Volumes[0].PersistentVolumeClaim.ReadOnly).To(BeTrue())
+		Expect(pod.Spec.Volumes[1].PersistentVolumeClaim.ClaimName).To(Equal(pvc.Name))
+	})
+
+	It("should wait for the pod to complete", func() {
+		p := createReflinkClonePhase(sourceClaim())
+		_, err := p.Reconcile(context.Background())
+		Expect(err).ToNot(HaveOccurred())
+
+		result, err := p.Reconcile(context.Background())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).ToNot(BeNil())
+		Expect(desiredClaim(p).Annotations).ToNot(HaveKey(cc.AnnPodPhase))
+	})
+
+	It("should record the completion of the pod on the pvc and delete it", func() {
+		p := createReflinkClonePhase(sourceClaim())
+		_, err := p.Reconcile(context.Background())
+		Expect(err).ToNot(HaveOccurred())
+		pod, _ := reflinkPod(p)
+		pod.Status.Phase = corev1.PodSucceeded
+		Expect(p.Client.Status().Update(context.Background(), pod)).To(Succeed())
+
+		result, err := p.Reconcile(context.Background())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).ToNot(BeNil())
+		Expect(desiredClaim(p).Annotations[cc.AnnPodPhase]).To(Equal(string(corev1.PodSucceeded)))
+
+		result, err = p.Reconcile(context.Background())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(BeNil())
+		_, exists := reflinkPod(p)
+		Expect(exists).To(BeFalse())
+	})
+})
@@ -35,11 +35,18 @@ type SnapshotClonePhase struct {
 
 var _ Phase = &SnapshotClonePhase{}
 
+var _ ProgressPhaser = &SnapshotClonePhase{}
+
 // Name returns the name of the phase
 func (p *SnapshotClonePhase) Name() string {
 	return SnapshotClonePhaseName
 }
 
+// ProgressPhase returns the phase of the clone progress
+func (p *SnapshotClonePhase) ProgressPhase() cdiv1.DataVolumeClonePhase {
+	return cdiv1.ClonePhaseTransfer
+}
+
 // Reconcile ensures a snapshot is created correctly
 func (p *SnapshotClonePhase) Reconcile(ctx context.Context) (*reconcile.Result, error) {
 	pvc := &corev1.PersistentVolumeClaim{}
@@ -12,6 +12,8 @@ import (
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
 )
 
 // SnapshotPhaseName is the name of the snapshot phase
@@ -24,19 +26,28 @@ type SnapshotPhase struct {
 	SourceName          string
 	TargetName          string
 	VolumeSnapshotClass string
-	OwnershipLabel      string
-	Client              client.Client
-	Log                 logr.Logger
-	Recorder            record.EventRecorder
+	// Shared snapshots are restored by the clones of a group, which label them as members instead of owning them
+	Shared         bool
+	OwnershipLabel string
+	Client         client.Client
+	Log            logr.Logger
+	Recorder       record.EventRecorder
 }
 
 var _ Phase = &SnapshotPhase{}
 
+var _ ProgressPhaser = &SnapshotPhase{}
+
 // Name returns the name of the phase
 func (p *SnapshotPhase) Name() string {
 	return SnapshotPhaseName
 }
 
+// ProgressPhase returns the phase of the clone progress
+func (p *SnapshotPhase) ProgressPhase() cdiv1.DataVolumeClonePhase {
+	return cdiv1.ClonePhaseSnapshot
+}
+
 // Reconcile ensures a snapshot is created correctly
 func (p *SnapshotPhase) Reconcile(ctx context.Context) (*reconcile.Result, error) {
 	snapshot := &snapshotv1.VolumeSnapshot{}
@@ -45,6 +56,16 @@ func (p *SnapshotPhase) Reconcile(ctx context.Context) (*reconcile.Result, error
 		return nil, err
 	}
 
+	if exists && p.Shared {
+		if !snapshot.DeletionTimestamp.IsZero() {
+			// the last clone of the group left it, wait to snapshot the source again
+			return &reconcile.Result{RequeueAfter: 2 * time.Second}, nil
+		}
+		if err := p.joinGroup(ctx, snapshot); err != nil {
+			return nil, err
+		}
+	}
+
 	if !exists {
 		args := &IsSourceClaimReadyArgs{
 			Target:          p.Owner,
@@ -94,7 +115,9 @@ func (p *SnapshotPhase) createSnapshot(ctx context.Context) (*snapshotv1.VolumeS
 	}
 
 	AddCommonLabels(snapshot)
-	if p.OwnershipLabel != "" {
+	if p.Shared {
+		snapshot.Labels[LabelCloneGroupMemberPrefix+string(p.Owner.GetUID())] = ""
+	} else if p.OwnershipLabel != "" {
 		AddOwnershipLabel(p.OwnershipLabel, snapshot, p.Owner)
 	}
 
@@ -104,3 +127,18 @@ func (p *SnapshotPhase) createSnapshot(ctx context.Context) (*snapshotv1.VolumeS
 
 	return snapshot, nil
 }
+
+// joinGroup adds the owner to the members of the snapshot of its clone group
+func (p *SnapshotPhase) joinGroup(ctx context.Context, snapshot *snapshotv1.VolumeSnapshot) error {
+	memberLabel := LabelCloneGroupMemberPrefix + string(p.Owner.GetUID())
+	if _, ok := snapshot.Labels[memberLabel]; ok {
+		return nil
+	}
+	snapshotCpy := snapshot.DeepCopy()
+	if snapshotCpy.Labels == nil {
+		snapshotCpy.Labels = map[string]string{}
+	}
+	snapshotCpy.Labels[memberLabel] = ""
+	p.Log.V(3).Info("Joining the clone group of snapshot", "snapshot", client.ObjectKeyFromObject(snapshot))
+	return p.Client.Update(ctx, snapshotCpy)
+}
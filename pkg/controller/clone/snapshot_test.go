@@ -138,6 +138,18 @@ var _ = Describe("SnapshotPhase test", func() {
 			snapshot := getSnapshot(p)
 			Expect(*snapshot.Spec.Source.PersistentVolumeClaimName).To(Equal(sourceName))
 			Expect(*snapshot.Spec.VolumeSnapshotClassName).To(Equal(snapClass))
+			Expect(snapshot.Labels).To(HaveKeyWithValue("label", "uid"))
+		})
+
+		It("should create a shared snapshot labeled with its member", func() {
+			p := createSnapshotPhase(sourceClaim())
+			p.Shared = true
+			_, err := p.Reconcile(context.Background())
+			Expect(err).ToNot(HaveOccurred())
+
+			snapshot := getSnapshot(p)
+			Expect(snapshot.Labels).To(HaveKey(LabelCloneGroupMemberPrefix + "uid"))
+			Expect(snapshot.Labels).ToNot(HaveKey("label"))
 		})
 
 		Context("with snapshot", func() {
@@ -175,6 +187,36 @@ var _ = Describe("SnapshotPhase test", func() {
 				Expect(err).ToNot(HaveOccurred())
 				Expect(result).To(BeNil())
 			})
+
+			It("should join the clone group of a shared snapshot", func() {
+				t := metav1.Now()
+				s := createSnapshot()
+				s.Labels = map[string]string{LabelCloneGroupMemberPrefix + "other": ""}
+				s.Status.CreationTime = &t
+				p := createSnapshotPhase(s)
+				p.Shared = true
+				result, err := p.Reconcile(context.Background())
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result).To(BeNil())
+
+				snapshot := getSnapshot(p)
+				Expect(snapshot.Labels).To(HaveKey(LabelCloneGroupMemberPrefix + "other"))
+				Expect(snapshot.Labels).To(HaveKey(LabelCloneGroupMemberPrefix + "uid"))
+			})
+
+			It("should wait for the shared snapshot left by its clone group to be deleted", func() {
+				t := metav1.Now()
+				s := createSnapshot()
+				s.DeletionTimestamp = &t
+				s.Finalizers = []string{"snapshot.storage.kubernetes.io/volumesnapshot-as-source-protection"}
+				p := createSnapshotPhase(s)
+				p.Shared = true
+				result, err := p.Reconcile(context.Background())
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result).ToNot(BeNil())
+				Expect(result.RequeueAfter).ToNot(BeZero())
+				Expect(getSnapshot(p).Labels).ToNot(HaveKey(LabelCloneGroupMemberPrefix + "uid"))
+			})
 		})
 	})
 })
@@ -211,6 +211,11 @@ func checkpointAlreadyCopied(pvc *corev1.PersistentVolumeClaim, checkpoint strin
 	return metav1.HasAnnotation(pvc.ObjectMeta, annotation)
 }
 
+// IsCheckpointCopied returns true if the checkpoint of a multistage import was copied to the PVC
+func IsCheckpointCopied(pvc *corev1.PersistentVolumeClaim, checkpoint string) bool {
+	return checkpointAlreadyCopied(pvc, checkpoint)
+}
+
 // GetNextCheckpoint returns the appropriate checkpoint according to multistage annotations
 func GetNextCheckpoint(pvc *corev1.PersistentVolumeClaim, args *CheckpointArgs) *CheckpointRecord {
 	numCheckpoints := len(args.Checkpoints)
@@ -0,0 +1,184 @@
+/*
+Copyright 2026 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+	"kubevirt.io/containerized-data-importer/pkg/common"
+)
+
+const (
+	// DefaultDuplicateSuppressionWindow is how long a repeated event is suppressed by default
+	DefaultDuplicateSuppressionWindow = 10 * time.Minute
+	// DefaultProgressSummaryInterval is how often progress summary events are emitted by default
+	DefaultProgressSummaryInterval = 5 * time.Minute
+)
+
+type eventKey struct {
+	object    types.UID
+	eventType string
+	reason    string
+	message   string
+}
+
+type aggregatedEvent struct {
+	emitted    time.Time
+	window     time.Duration
+	suppressed int
+}
+
+// EventAggregator is an event recorder suppressing the events repeated within the duplicateSuppressionWindow of the
+// eventAggregation of the CDIConfig, and rate limiting the progress events to its progressSummaryInterval. The events
+// are passed as is to the wrapped recorder while eventAggregation is unset.
+type EventAggregator struct {
+	recorder record.EventRecorder
+	client   client.Client
+	now      func() time.Time
+
+	mutex     sync.Mutex
+	events    map[eventKey]*aggregatedEvent
+	lastPrune time.Time
+}
+
+var _ record.EventRecorder = &EventAggregator{}
+
+// NewEventAggregator creates an EventAggregator wrapping recorder, reading its thresholds from the CDIConfig with c
+func NewEventAggregator(recorder record.EventRecorder, c client.Client) *EventAggregator {
+	return &EventAggregator{
+		recorder: recorder,
+		client:   c,
+		now:      time.Now,
+		events:   map[eventKey]*aggregatedEvent{},
+	}
+}
+
+// Event emits the event unless it was already emitted for object within the duplicate suppression window
+func (a *EventAggregator) Event(object runtime.Object, eventtype, reason, message string) {
+	a.AnnotatedEventf(object, nil, eventtype, reason, "%s", message)
+}
+
+// Eventf is like Event, with a formatted message
+func (a *EventAggregator) Eventf(object runtime.Object, eventtype, reason, messageFmt string, args ...interface{}) {
+	a.AnnotatedEventf(object, nil, eventtype, reason, messageFmt, args...)
+}
+
+// AnnotatedEventf is like Eventf, adding annotations to the event
+func (a *EventAggregator) AnnotatedEventf(object runtime.Object, annotations map[string]string, eventtype, reason, messageFmt string, args ...interface{}) {
+	message := fmt.Sprintf(messageFmt, args...)
+	config := a.getConfig()
+	if config == nil {
+		a.recorder.AnnotatedEventf(object, annotations, eventtype, reason, "%s", message)
+		return
+	}
+	window := DefaultDuplicateSuppressionWindow
+	if config.DuplicateSuppressionWindow != nil && config.DuplicateSuppressionWindow.Duration > 0 {
+		window = config.DuplicateSuppressionWindow.Duration
+	}
+	suppressed, emit := a.aggregate(eventKey{object: objectUID(object), eventType: eventtype, reason: reason, message: message}, window)
+	if !emit {
+		return
+	}
+	if suppressed > 0 {
+		message = fmt.Sprintf("%s (%d duplicate events suppressed)", message, suppressed)
+	}
+	a.recorder.AnnotatedEventf(object, annotations, eventtype, reason, "%s", message)
+}
+
+// ProgressEvent emits a progress summary event for object, at most once per progress summary interval whatever its
+// message. Progress events are only emitted while eventAggregation is set.
+func (a *EventAggregator) ProgressEvent(object runtime.Object, eventtype, reason, message string) {
+	config := a.getConfig()
+	if config == nil {
+		return
+	}
+	interval := DefaultProgressSummaryInterval
+	if config.ProgressSummaryInterval != nil && config.ProgressSummaryInterval.Duration > 0 {
+		interval = config.ProgressSummaryInterval.Duration
+	}
+	if _, emit := a.aggregate(eventKey{object: objectUID(object), eventType: eventtype, reason: reason}, interval); emit {
+		a.recorder.Event(object, eventtype, reason, message)
+	}
+}
+
+// aggregate records an occurrence of the event of key, and returns whether it is emitted with how many occurrences
+// were suppressed since it was last emitted
+func (a *EventAggregator) aggregate(key eventKey, window time.Duration) (int, bool) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	now := a.now()
+	e, ok := a.events[key]
+	if ok && now.Sub(e.emitted) < window {
+		e.suppressed++
+		return 0, false
+	}
+	suppressed := 0
+	if ok {
+		suppressed = e.suppressed
+	}
+	a.events[key] = &aggregatedEvent{emitted: now, window: window}
+
+	// the events not repeated within their window are forgotten with their suppressed occurrences
+	if now.Sub(a.lastPrune) > window {
+		for k, e := range a.events {
+			if now.Sub(e.emitted) > e.window {
+				delete(a.events, k)
+			}
+		}
+		a.lastPrune = now
+	}
+	return suppressed, true
+}
+
+func (a *EventAggregator) getConfig() *cdiv1.EventAggregationConfig {
+	config := &cdiv1.CDIConfig{}
+	if err := a.client.Get(context.TODO(), types.NamespacedName{Name: common.ConfigName}, config); err != nil {
+		return nil
+	}
+	return config.Spec.EventAggregation
+}
+
+func objectUID(object runtime.Object) types.UID {
+	obj, err := meta.Accessor(object)
+	if err != nil {
+		return ""
+	}
+	if obj.GetUID() != "" {
+		return obj.GetUID()
+	}
+	return types.UID(obj.GetNamespace() + "/" + obj.GetName())
+}
+
+// EmitProgressEvent emits a progress summary event with recorder if it aggregates events, progress is not reported in
+// events otherwise
+func EmitProgressEvent(recorder record.EventRecorder, object runtime.Object, eventtype, reason, message string) {
+	if aggregator, ok := recorder.(*EventAggregator); ok {
+		aggregator.ProgressEvent(object, eventtype, reason, message)
+	}
+}
@@ -0,0 +1,92 @@
+package common
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+	"kubevirt.io/containerized-data-importer/pkg/common"
+)
+
+var _ = Describe("EventAggregator", func() {
+	var (
+		recorder *record.FakeRecorder
+		now      time.Time
+	)
+
+	createAggregator := func(aggregation *cdiv1.EventAggregationConfig) *EventAggregator {
+		config := &cdiv1.CDIConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: common.ConfigName},
+			Spec:       cdiv1.CDIConfigSpec{EventAggregation: aggregation},
+		}
+		recorder = record.NewFakeRecorder(10)
+		now = time.Now()
+		aggregator := NewEventAggregator(recorder, CreateClient(config))
+		aggregator.now = func() time.Time { return now }
+		return aggregator
+	}
+
+	expectEvents := func(events ...string) {
+		for _, event := range events {
+			Expect(recorder.Events).To(Receive(Equal(event)))
+		}
+		Expect(recorder.Events).ToNot(Receive())
+	}
+
+	It("Should pass the events through if unset", func() {
+		aggregator := createAggregator(nil)
+		pvc := CreatePvc("testPVC", "default", nil, nil)
+		aggregator.Event(pvc, v1.EventTypeNormal, "Pending", "Waiting")
+		aggregator.Event(pvc, v1.EventTypeNormal, "Pending", "Waiting")
+		EmitProgressEvent(aggregator, pvc, v1.EventTypeNormal, "Progress", "10%")
+		expectEvents("Normal Pending Waiting", "Normal Pending Waiting")
+	})
+
+	It("Should suppress the duplicate events within the window and report them", func() {
+		aggregator := createAggregator(&cdiv1.EventAggregationConfig{
+			DuplicateSuppressionWindow: &metav1.Duration{Duration: time.Minute},
+		})
+		pvc := CreatePvc("testPVC", "default", nil, nil)
+		other := CreatePvc("otherPVC", "default", nil, nil)
+		aggregator.Event(pvc, v1.EventTypeNormal, "Pending", "Waiting")
+		aggregator.Eventf(pvc, v1.EventTypeNormal, "Pending", "Wait%s", "ing")
+		aggregator.Event(pvc, v1.EventTypeNormal, "Pending", "Still waiting")
+		aggregator.Event(other, v1.EventTypeNormal, "Pending", "Waiting")
+		expectEvents("Normal Pending Waiting", "Normal Pending Still waiting", "Normal Pending Waiting")
+
+		now = now.Add(30 * time.Second)
+		aggregator.Event(pvc, v1.EventTypeNormal, "Pending", "Waiting")
+		expectEvents()
+
+		now = now.Add(time.Minute)
+		aggregator.Event(pvc, v1.EventTypeNormal, "Pending", "Waiting")
+		expectEvents("Normal Pending Waiting (2 duplicate events suppressed)")
+	})
+
+	It("Should emit the progress events once per interval", func() {
+		aggregator := createAggregator(&cdiv1.EventAggregationConfig{
+			ProgressSummaryInterval: &metav1.Duration{Duration: time.Minute},
+		})
+		pvc := CreatePvc("testPVC", "default", nil, nil)
+		EmitProgressEvent(aggregator, pvc, v1.EventTypeNormal, "Progress", "10%")
+		now = now.Add(30 * time.Second)
+		EmitProgressEvent(aggregator, pvc, v1.EventTypeNormal, "Progress", "20%")
+		expectEvents("Normal Progress 10%")
+
+		now = now.Add(time.Minute)
+		EmitProgressEvent(aggregator, pvc, v1.EventTypeNormal, "Progress", "40%")
+		expectEvents("Normal Progress 40%")
+	})
+
+	It("Should not emit progress events with a recorder not aggregating them", func() {
+		recorder = record.NewFakeRecorder(10)
+		EmitProgressEvent(recorder, CreatePvc("testPVC", "default", nil, nil), v1.EventTypeNormal, "Progress", "10%")
+		expectEvents()
+	})
+})
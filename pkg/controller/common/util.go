@@ -21,6 +21,7 @@ import (
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/tls"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net"
@@ -63,6 +64,8 @@ import (
 	"kubevirt.io/containerized-data-importer/pkg/client/clientset/versioned/scheme"
 	"kubevirt.io/containerized-data-importer/pkg/common"
 	featuregates "kubevirt.io/containerized-data-importer/pkg/feature-gates"
+	cloneMetrics "kubevirt.io/containerized-data-importer/pkg/monitoring/metrics/cdi-cloner"
+	importMetrics "kubevirt.io/containerized-data-importer/pkg/monitoring/metrics/cdi-importer"
 	"kubevirt.io/containerized-data-importer/pkg/token"
 	"kubevirt.io/containerized-data-importer/pkg/util"
 	sdkapi "kubevirt.io/controller-lifecycle-operator-sdk/api"
@@ -91,6 +94,12 @@ const (
 	AnnPopulatedFor = AnnAPIGroup + "/storage.populatedFor"
 	// AnnPrePopulated is a PVC annotation telling the datavolume controller that the PVC is already populated
 	AnnPrePopulated = AnnAPIGroup + "/storage.prePopulated"
+	// AnnDataVolumeMigration is a DataVolume annotation holding the DataVolumeMigration replacing its PVC, pausing the
+	// datavolume controller until the PVC is replaced
+	AnnDataVolumeMigration = AnnAPIGroup + "/storage.migration"
+	// AnnMigratedClaimMetadata is an annotation of the copy of a migrated PVC holding the labels, annotations and owners of
+	// the PVC, given to the PVC replacing it
+	AnnMigratedClaimMetadata = AnnAPIGroup + "/storage.migration.claimMetadata"
 	// AnnPriorityClassName is PVC annotation to indicate the priority class name for importer, cloner and uploader pod
 	AnnPriorityClassName = AnnAPIGroup + "/storage.pod.priorityclassname"
 	// AnnExternalPopulation annotation marks a PVC as "externally populated", allowing the import-controller to skip it
@@ -156,11 +165,19 @@ const (
 
 	// AnnRequiresDirectIO provides a const for our PVC requiring direct io annotation (due to OOMs we need to try qemu cache=none)
 	AnnRequiresDirectIO = AnnAPIGroup + "/storage.import.requiresDirectIo"
+	// AnnMaxBandwidth provides a const for the bandwidth, a quantity of bytes per second, network sources are read at most at
+	AnnMaxBandwidth = AnnAPIGroup + "/storage.import.maxBandwidth"
+	// AnnStorageFormat provides a const for the format the imported image is stored in on a filesystem PVC, raw if unset
+	AnnStorageFormat = AnnAPIGroup + "/storage.import.storageFormat"
 	// OOMKilledReason provides a value that container runtimes must return in the reason field for an OOMKilled container
 	OOMKilledReason = "OOMKilled"
 
 	// AnnContentType provides a const for the PVC content-type
 	AnnContentType = AnnAPIGroup + "/storage.contentType"
+	// AnnDetectContentType provides a const for our PVC annotation asking the importer to pick the content type from the data, as none was declared
+	AnnDetectContentType = AnnAPIGroup + "/storage.contentType.detect"
+	// AnnDetectedContentType provides a const for the type of the data imported to our PVC, found by its magic bytes
+	AnnDetectedContentType = AnnAPIGroup + "/storage.import.detectedContentType"
 
 	// AnnSource provide a const for our PVC import source annotation
 	AnnSource = AnnAPIGroup + "/storage.import.source"
@@ -171,10 +188,28 @@ const (
 	AnnSecret = AnnAPIGroup + "/storage.import.secretName"
 	// AnnCertConfigMap is the name of a configmap containing tls certs
 	AnnCertConfigMap = AnnAPIGroup + "/storage.import.certConfigMap"
+	// AnnClientCertSecret is the name of a kubernetes.io/tls secret containing the client certificate presented to the source
+	AnnClientCertSecret = AnnAPIGroup + "/storage.import.clientCertSecretName"
 	// AnnRegistryImportMethod provides a const for registry import method annotation
 	AnnRegistryImportMethod = AnnAPIGroup + "/storage.import.registryImportMethod"
 	// AnnRegistryImageStream provides a const for registry image stream annotation
 	AnnRegistryImageStream = AnnAPIGroup + "/storage.import.registryImageStream"
+	// AnnSourceDigest provides a const for the digest the registry image of our PVC resolved to at import time
+	AnnSourceDigest = AnnAPIGroup + "/storage.import.sourceDigest"
+	// AnnObservedSourceDigest provides a const for the digest of the source read by the last import of our PVC
+	AnnObservedSourceDigest = AnnAPIGroup + "/storage.import.observedSourceDigest"
+	// AnnImportedPlatform provides a const for the platform of the registry image copied by the last import of our PVC, as json
+	AnnImportedPlatform = AnnAPIGroup + "/storage.import.importedPlatform"
+	// AnnCollectFailureArtifacts provides a const to have the logs and termination message of the failed imports of our PVC kept in a ConfigMap
+	AnnCollectFailureArtifacts = AnnAPIGroup + "/storage.import.collectFailureArtifacts"
+	// AnnFailureArtifacts provides a const for the name of the ConfigMap holding the artifacts of the last failed import of our PVC
+	AnnFailureArtifacts = AnnAPIGroup + "/storage.import.failureArtifacts"
+	// AnnDeduplicationKey provides a const for the content our PVC is imported with when its DataVolume deduplicates imports
+	AnnDeduplicationKey = AnnAPIGroup + "/storage.import.deduplicationKey"
+	// AnnDeduplicatedFrom provides a const for the PVC our PVC is cloned from instead of importing its source
+	AnnDeduplicatedFrom = AnnAPIGroup + "/storage.import.deduplicatedFrom"
+	// AnnDeduplicationInvalidatedBy provides a const for the pod that mounted our PVC read-write, our PVC is then no longer cloned by deduplicating DataVolumes
+	AnnDeduplicationInvalidatedBy = AnnAPIGroup + "/storage.import.deduplicationInvalidatedBy"
 	// AnnImportPod provides a const for our PVC importPodName annotation
 	AnnImportPod = AnnAPIGroup + "/storage.import.importPodName"
 	// AnnDiskID provides a const for our PVC diskId annotation
@@ -189,11 +224,109 @@ const (
 	AnnExtraHeaders = AnnAPIGroup + "/storage.import.extraHeaders"
 	// AnnSecretExtraHeaders provides a const for our PVC secretExtraHeaders annotation
 	AnnSecretExtraHeaders = AnnAPIGroup + "/storage.import.secretExtraHeaders"
+	// AnnOAuth2Secret provides a const for our PVC oauth2SecretName annotation
+	AnnOAuth2Secret = AnnAPIGroup + "/storage.import.oauth2SecretName"
 	// AnnRegistryImageArchitecture provides a const for our PVC registryImageArchitecture annotation
 	AnnRegistryImageArchitecture = AnnAPIGroup + "/storage.import.registryImageArchitecture"
+	// AnnImportServiceAccount provides a const for the service account the importer pod runs as
+	AnnImportServiceAccount = AnnAPIGroup + "/storage.import.serviceAccountName"
+	// AnnBlankZeroEdges provides a const for our PVC annotation requesting the first and last MiB of a blank block volume be zeroed
+	AnnBlankZeroEdges = AnnAPIGroup + "/storage.import.blankZeroEdges"
+	// AnnDownloadParallelism provides a const for our PVC annotation setting how many byte ranges of an http or s3 source are downloaded concurrently
+	AnnDownloadParallelism = AnnAPIGroup + "/storage.import.downloadParallelism"
+	// AnnDownloadPartSize provides a const for our PVC annotation setting the size of the byte ranges downloaded concurrently
+	AnnDownloadPartSize = AnnAPIGroup + "/storage.import.downloadPartSize"
+	// AnnCurlConnections provides a const for our PVC annotation setting how many connections nbdkit opens to an http, s3 or gcs source
+	AnnCurlConnections = AnnAPIGroup + "/storage.import.curl.connections"
+	// AnnCurlHTTPVersion provides a const for our PVC annotation setting the HTTP version nbdkit requests from an http, s3 or gcs source
+	AnnCurlHTTPVersion = AnnAPIGroup + "/storage.import.curl.httpVersion"
+	// AnnCurlTLS13Ciphers provides a const for our PVC annotation setting the TLS 1.3 cipher suites nbdkit offers to an http, s3 or gcs source
+	AnnCurlTLS13Ciphers = AnnAPIGroup + "/storage.import.curl.tls13Ciphers"
+	// AnnCurlTimeout provides a const for our PVC annotation setting how long a request of nbdkit to an http, s3 or gcs source may take
+	AnnCurlTimeout = AnnAPIGroup + "/storage.import.curl.timeout"
+	// AnnOVADisk provides a const for our PVC annotation selecting the disk of an OVA to import from an http source
+	AnnOVADisk = AnnAPIGroup + "/storage.import.ovaDisk"
+	// AnnXVADisk provides a const for our PVC annotation selecting the disk of an XVA to import from an http source
+	AnnXVADisk = AnnAPIGroup + "/storage.import.xvaDisk"
+	// AnnChecksum provides a const for our PVC annotation holding the algorithm:digest the data of an http, s3 or gcs source is checked against
+	AnnChecksum = AnnAPIGroup + "/storage.import.checksum"
+	// AnnSignatureURL provides a const for our PVC annotation holding the url of the detached signature of an http or s3 source
+	AnnSignatureURL = AnnAPIGroup + "/storage.import.signatureUrl"
+	// AnnSignatureKeyringSecret provides a const for our PVC annotation naming the secret of the keyring the signature is checked with
+	AnnSignatureKeyringSecret = AnnAPIGroup + "/storage.import.signatureKeyringSecretName"
+	// AnnSourceFallbacks provides a const for our PVC annotation holding the json list of the fallback sources of the import
+	AnnSourceFallbacks = AnnAPIGroup + "/storage.import.sourceFallbacks"
+	// AnnSourceFallbackIndex provides a const for our PVC annotation counting the fallback sources the import moved to
+	AnnSourceFallbackIndex = AnnAPIGroup + "/storage.import.sourceFallbackIndex"
+	// AnnRetryPolicy provides a const for our PVC annotation holding the json retry policy of the import
+	AnnRetryPolicy = AnnAPIGroup + "/storage.import.retryPolicy"
+	// AnnTransferPriority provides a const for our PVC annotation ordering the transfers waiting for a slot, higher first
+	AnnTransferPriority = AnnAPIGroup + "/storage.transfer.priority"
+	// AnnTransferQueued provides a const for our PVC annotation holding when its transfer was queued, waiting for a slot
+	AnnTransferQueued = AnnAPIGroup + "/storage.transfer.queued"
+	// AnnImportProxy provides a const for our PVC annotation holding the json proxy configuration replacing the CDI-wide one of the import
+	AnnImportProxy = AnnAPIGroup + "/storage.import.proxy"
+	// AnnPodOverrides provides a const for our PVC annotation holding the json settings replacing the CDI-wide ones of the importer pod
+	AnnPodOverrides = AnnAPIGroup + "/storage.pod.overrides"
+	// AnnScratchSpace provides a const for our PVC annotation holding the json settings replacing the CDI-wide ones of the scratch space PVC
+	AnnScratchSpace = AnnAPIGroup + "/storage.scratch.overrides"
+	// AnnImportRetryCount provides a const for our PVC annotation counting the retries of the failed import
+	AnnImportRetryCount = AnnAPIGroup + "/storage.import.retryCount"
+	// AnnImportNextRetryTime provides a const for our PVC annotation holding when the failed import is retried next
+	AnnImportNextRetryTime = AnnAPIGroup + "/storage.import.nextRetryTime"
+	// AnnImportPaused provides a const for our PVC annotation pausing the import until it is removed
+	AnnImportPaused = AnnAPIGroup + "/storage.import.paused"
+	// AnnExpandFilesystem provides a const for our PVC annotation growing the imported partition and filesystem to fill the PVC
+	AnnExpandFilesystem = AnnAPIGroup + "/storage.import.expandFilesystem"
+	// AnnVerify provides a const for our PVC annotation checking the imported disk image before the import succeeds
+	AnnVerify = AnnAPIGroup + "/storage.import.verify"
+	// AnnVerifyChecksum provides a const for our PVC annotation comparing the imported disk image with its source
+	AnnVerifyChecksum = AnnAPIGroup + "/storage.import.verifyChecksum"
+	// AnnTopologyPlacement provides a const for our PVC annotation holding the json placement of the topology domain the importer pod is scheduled in
+	AnnTopologyPlacement = AnnAPIGroup + "/storage.import.topologyPlacement"
+	// AnnImportTransferredBytes provides a const for our PVC annotation holding the bytes the import read from its source
+	AnnImportTransferredBytes = AnnAPIGroup + "/storage.import.transferredBytes"
+	// AnnImportTotalBytes provides a const for our PVC annotation holding the size in bytes of the source of the import
+	AnnImportTotalBytes = AnnAPIGroup + "/storage.import.totalBytes"
+	// AnnImportPhaseDurations provides a const for our PVC annotation holding the JSON time the importer spent in each of its phases
+	AnnImportPhaseDurations = AnnAPIGroup + "/storage.import.phaseDurations"
+	// AnnGlanceImage provides a const for our PVC annotation naming the image to import from a glance source
+	AnnGlanceImage = AnnAPIGroup + "/storage.import.glanceImage"
+	// AnnRBDImage provides a const for our PVC annotation naming the pool/image[@snapshot] to import from an rbd source
+	AnnRBDImage = AnnAPIGroup + "/storage.import.rbdImage"
+	// AnnSparsify provides a const for our PVC annotation discarding the blocks the filesystems of the imported image do not use
+	AnnSparsify = AnnAPIGroup + "/storage.import.sparsify"
+	// AnnEncryptionSecret provides a const for our PVC annotation naming the secret holding the passphrase the imported image is encrypted with
+	AnnEncryptionSecret = AnnAPIGroup + "/storage.import.encryptionSecret"
+	// AnnHostPathNode provides a const for our PVC annotation naming the node holding the file of a hostpath source
+	AnnHostPathNode = AnnAPIGroup + "/storage.import.hostPathNode"
+	// AnnV2VVM provides a const for our PVC annotation naming the VM converted by a v2v source
+	AnnV2VVM = AnnAPIGroup + "/storage.import.v2v.vmName"
+	// AnnV2VDiskIndex provides a const for our PVC annotation selecting the disk of the VM imported from a v2v source
+	AnnV2VDiskIndex = AnnAPIGroup + "/storage.import.v2v.diskIndex"
+	// AnnV2VBlockDriver provides a const for our PVC annotation naming the virtio driver the guest of a v2v source boots from
+	AnnV2VBlockDriver = AnnAPIGroup + "/storage.import.v2v.blockDriver"
+	// AnnV2VVirtioWinPVC provides a const for our PVC annotation naming the PVC holding the virtio-win ISO of a v2v source
+	AnnV2VVirtioWinPVC = AnnAPIGroup + "/storage.import.v2v.virtioWinPVC"
+	// AnnV2VMACMappings provides a const for our PVC annotation holding the ';' separated virt-v2v --mac values of a v2v source
+	AnnV2VMACMappings = AnnAPIGroup + "/storage.import.v2v.macMappings"
+	// AnnProxmoxNode provides a const for our PVC annotation naming the node running the VM of a proxmox source
+	AnnProxmoxNode = AnnAPIGroup + "/storage.import.proxmox.node"
+	// AnnProxmoxVMID provides a const for our PVC annotation holding the id of the VM of a proxmox source
+	AnnProxmoxVMID = AnnAPIGroup + "/storage.import.proxmox.vmid"
+	// AnnProxmoxDisk provides a const for our PVC annotation naming the disk of the VM imported from a proxmox source
+	AnnProxmoxDisk = AnnAPIGroup + "/storage.import.proxmox.disk"
+	// AnnPluginParameters provides a const for our PVC annotation holding the JSON encoded parameters of a plugin source
+	AnnPluginParameters = AnnAPIGroup + "/storage.import.plugin.parameters"
+	// AnnTorrentWebSeeds provides a const for our PVC annotation holding the space separated web seeds of a torrent source
+	AnnTorrentWebSeeds = AnnAPIGroup + "/storage.import.torrent.webSeeds"
+	// AnnTorrentFile provides a const for our PVC annotation naming the file of the torrent imported from a torrent source
+	AnnTorrentFile = AnnAPIGroup + "/storage.import.torrent.file"
 
 	// AnnCloneToken is the annotation containing the clone token
 	AnnCloneToken = AnnAPIGroup + "/storage.clone.token"
+	// AnnCloneGrant is the annotation containing the namespace/name of the CloneGrant that authorized a cross-namespace clone
+	AnnCloneGrant = AnnAPIGroup + "/storage.clone.grant"
 	// AnnExtendedCloneToken is the annotation containing the long term clone token
 	AnnExtendedCloneToken = AnnAPIGroup + "/storage.extended.clone.token"
 	// AnnPermissiveClone annotation allows the clone-controller to skip the clone size validation
@@ -204,9 +337,34 @@ const (
 	AnnCloneType = AnnAPIGroup + "/cloneType"
 	// AnnCloneSourcePod name of the source clone pod
 	AnnCloneSourcePod = AnnAPIGroup + "/storage.sourceClonePodName"
+	// AnnCloneTransferMode is the annotation containing how the data of a host-assisted clone is transferred
+	AnnCloneTransferMode = AnnAPIGroup + "/storage.clone.transferMode"
+	// AnnCloneCompression is the annotation containing how the data of a host-assisted clone is compressed on the network
+	AnnCloneCompression = AnnAPIGroup + "/storage.clone.compression"
+
+	// AnnCloneFormat is the annotation containing the format the target of a host-assisted clone converts the image to
+	AnnCloneFormat = AnnAPIGroup + "/storage.clone.format"
+	// AnnCloneExportURL is the annotation containing the url of the volume another cluster exports to a host-assisted clone
+	AnnCloneExportURL = AnnAPIGroup + "/storage.clone.export.url"
+	// AnnCloneExportSecret is the annotation containing the secret with the export token of a host-assisted clone
+	AnnCloneExportSecret = AnnAPIGroup + "/storage.clone.export.secret"
+	// AnnCloneExportCertConfigMap is the annotation containing the configmap with the CA of the export server of a host-assisted clone
+	AnnCloneExportCertConfigMap = AnnAPIGroup + "/storage.clone.export.certConfigMap"
+	// AnnCloneTransferredBytes is the annotation containing the bytes the source of a host-assisted clone sent to the target
+	AnnCloneTransferredBytes = AnnAPIGroup + "/storage.clone.transferredBytes"
+	// AnnCloneTotalBytes is the annotation containing the size in bytes of the data the source of a host-assisted clone sends
+	AnnCloneTotalBytes = AnnAPIGroup + "/storage.clone.totalBytes"
+	// AnnCloneThroughput is the annotation containing the bytes per second the source of a host-assisted clone sends
+	AnnCloneThroughput = AnnAPIGroup + "/storage.clone.throughput"
+	// AnnCloneProgressPhase is the annotation containing the phase of the clone in progress
+	AnnCloneProgressPhase = AnnAPIGroup + "/storage.clone.progressPhase"
 
 	// AnnUploadRequest marks that a PVC should be made available for upload
 	AnnUploadRequest = AnnAPIGroup + "/storage.upload.target"
+	// AnnUploadDigest provides a const for the sha256 digest of the data uploaded to our PVC, checked by the upload server
+	AnnUploadDigest = AnnAPIGroup + "/storage.upload.digest"
+	// AnnUploadStreamingConversion marks that qcow2 uploads to a PVC are converted as they are streamed, without scratch space
+	AnnUploadStreamingConversion = AnnAPIGroup + "/storage.upload.streamingConversion"
 
 	// AnnCheckStaticVolume checks if a statically allocated PV exists before creating the target PVC.
 	// If so, PVC is still created but population is skipped
@@ -224,6 +382,10 @@ const (
 	// AnnMinimumSupportedPVCSize annotation on a StorageProfile specifies its minimum supported PVC size
 	AnnMinimumSupportedPVCSize = AnnAPIGroup + "/minimumSupportedPvcSize"
 
+	// AnnPreallocationMethod annotation on a StorageProfile records the preallocation method the last preallocated
+	// import to its storage class found supported
+	AnnPreallocationMethod = AnnAPIGroup + "/preallocationMethod"
+
 	// AnnDefaultStorageClass is the annotation indicating that a storage class is the default one
 	AnnDefaultStorageClass = "storageclass.kubernetes.io/is-default-class"
 	// AnnDefaultVirtStorageClass is the annotation indicating that a storage class is the default one for virtualization purposes
@@ -293,8 +455,34 @@ const (
 	SourceS3 = "s3"
 	// SourceGCS is the source type GCS
 	SourceGCS = "gcs"
+	// SourceAzure is the source type Azure Blob Storage
+	SourceAzure = "azure"
+	// SourceSFTP is the source type SFTP
+	SourceSFTP = "sftp"
+	// SourceSMB is the source type SMB
+	SourceSMB = "smb"
+	// SMBCSIDriver is the CSI driver mounting the shares of SMB sources
+	SMBCSIDriver = "smb.csi.k8s.io"
 	// SourceGlance is the source type of glance
 	SourceGlance = "glance"
+	// SourceRBD is the source type Ceph RBD
+	SourceRBD = "rbd"
+	// SourceISCSI is the source type iSCSI
+	SourceISCSI = "iscsi"
+	// SourceHostPath is the source type of files present on a node
+	SourceHostPath = "hostpath"
+	// SourceDataVolume is the source type of the PVCs of other DataVolumes
+	SourceDataVolume = "datavolume"
+	// SourceExport is the source type of volumes exported by another cluster
+	SourceExport = "export"
+	// SourceV2V is the source type of vSphere VMs converted by virt-v2v
+	SourceV2V = "v2v"
+	// SourceProxmox is the source type of Proxmox VE VM disks
+	SourceProxmox = "proxmox"
+	// SourcePlugin is the source type of external source plugins
+	SourcePlugin = "plugin"
+	// SourceTorrent is the source type of BitTorrent swarms
+	SourceTorrent = "torrent"
 	// SourceNone means there is no source.
 	SourceNone = "none"
 	// SourceRegistry is the source type of Registry
@@ -475,16 +663,30 @@ func (mtv *MultiTokenValidator) getTokenAndValidator(pvc *corev1.PersistentVolum
 }
 
 // NewMultiTokenValidator returns a new multi token validator
-func NewMultiTokenValidator(key *rsa.PublicKey) *MultiTokenValidator {
+func NewMultiTokenValidator(tokenKeys token.KeySet, tokenConfig token.ConfigFunc) *MultiTokenValidator {
 	return &MultiTokenValidator{
-		ShortTokenValidator: NewCloneTokenValidator(common.CloneTokenIssuer, key),
-		LongTokenValidator:  NewCloneTokenValidator(common.ExtendedCloneTokenIssuer, key),
+		ShortTokenValidator: NewCloneTokenValidator(common.CloneTokenIssuer, tokenKeys, tokenConfig),
+		LongTokenValidator:  NewCloneTokenValidator(common.ExtendedCloneTokenIssuer, tokenKeys, tokenConfig),
 	}
 }
 
 // NewCloneTokenValidator returns a new token validator
-func NewCloneTokenValidator(issuer string, key *rsa.PublicKey) token.Validator {
-	return token.NewValidator(issuer, key, cloneTokenLeeway)
+func NewCloneTokenValidator(issuer string, tokenKeys token.KeySet, tokenConfig token.ConfigFunc) token.Validator {
+	return token.NewConfigurableValidator(issuer, tokenKeys, cloneTokenLeeway, tokenConfig)
+}
+
+// NewTokenConfigFunc returns the token.ConfigFunc reading the TokenConfig of the CDIConfig with c
+func NewTokenConfigFunc(c client.Client) token.ConfigFunc {
+	return func() (*cdiv1.TokenConfig, error) {
+		config := &cdiv1.CDIConfig{}
+		if err := c.Get(context.TODO(), types.NamespacedName{Name: common.ConfigName}, config); err != nil {
+			if k8serrors.IsNotFound(err) {
+				return nil, nil
+			}
+			return nil, err
+		}
+		return config.Spec.TokenConfig, nil
+	}
 }
 
 // GetRequestedImageSize returns the PVC requested size
@@ -829,12 +1031,17 @@ func IsPopulated(pvc *corev1.PersistentVolumeClaim, c client.Client) (bool, erro
 }
 
 // GetPreallocation returns the preallocation setting for the specified object (DV or VolumeImportSource), falling back to StorageClass and global setting (in this order)
-func GetPreallocation(ctx context.Context, client client.Client, preallocation *bool) bool {
+func GetPreallocation(ctx context.Context, client client.Client, preallocation *bool, storageClassName *string) bool {
 	// First, the DV's preallocation
 	if preallocation != nil {
 		return *preallocation
 	}
 
+	// Then, the conversion defaults of the storage class
+	if defaults := GetConversionDefaults(ctx, client, storageClassName); defaults != nil && defaults.Preallocation != nil {
+		return *defaults.Preallocation
+	}
+
 	cdiconfig := &cdiv1.CDIConfig{}
 	if err := client.Get(context.TODO(), types.NamespacedName{Name: common.ConfigName}, cdiconfig); err != nil {
 		klog.Errorf("Unable to find CDI configuration, %v\n", err)
@@ -844,6 +1051,78 @@ func GetPreallocation(ctx context.Context, client client.Client, preallocation *
 	return cdiconfig.Status.Preallocation
 }
 
+// GetConversionDefaults returns the conversion defaults of the StorageProfile of the storage class, the default one if
+// storageClassName is nil. It returns nil if there are none
+func GetConversionDefaults(ctx context.Context, client client.Client, storageClassName *string) *cdiv1.ConversionDefaults {
+	storageProfile := getStorageProfile(ctx, client, storageClassName)
+	if storageProfile == nil {
+		return nil
+	}
+	return storageProfile.Status.ConversionDefaults
+}
+
+// GetCloneCompression returns the compression of the host-assisted clones to the storage class, falling back to the
+// StorageProfile of the storage class and snappy (in this order)
+func GetCloneCompression(ctx context.Context, client client.Client, compression *cdiv1.CloneCompression, storageClassName *string) cdiv1.CloneCompression {
+	if compression != nil {
+		return *compression
+	}
+
+	sc, err := GetStorageClassByNameWithK8sFallback(ctx, client, storageClassName)
+	if err != nil || sc == nil {
+		return cdiv1.CloneCompressionSnappy
+	}
+	storageProfile := &cdiv1.StorageProfile{}
+	if err := client.Get(ctx, types.NamespacedName{Name: sc.Name}, storageProfile); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			klog.Errorf("Unable to get StorageProfile %s, %v\n", sc.Name, err)
+		}
+		return cdiv1.CloneCompressionSnappy
+	}
+	if storageProfile.Status.CloneCompression != nil {
+		return *storageProfile.Status.CloneCompression
+	}
+	return cdiv1.CloneCompressionSnappy
+}
+
+// getStorageProfile returns the StorageProfile of the storage class, the default one if storageClassName is nil. It
+// returns nil if there is none
+func getStorageProfile(ctx context.Context, client client.Client, storageClassName *string) *cdiv1.StorageProfile {
+	sc, err := GetStorageClassByNameWithK8sFallback(ctx, client, storageClassName)
+	if err != nil || sc == nil {
+		return nil
+	}
+	storageProfile := &cdiv1.StorageProfile{}
+	if err := client.Get(ctx, types.NamespacedName{Name: sc.Name}, storageProfile); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			klog.Errorf("Unable to get StorageProfile %s, %v\n", sc.Name, err)
+		}
+		return nil
+	}
+	return storageProfile
+}
+
+// GetPreallocationMethod returns the preallocation method recorded on the StorageProfile of the storage class, empty
+// if none was
+func GetPreallocationMethod(ctx context.Context, client client.Client, storageClassName *string) string {
+	storageProfile := getStorageProfile(ctx, client, storageClassName)
+	if storageProfile == nil {
+		return ""
+	}
+	return storageProfile.Annotations[AnnPreallocationMethod]
+}
+
+// SetPreallocationMethod records the preallocation method supported by the storage class on its StorageProfile, so
+// the later imports to the storage class skip the methods before it
+func SetPreallocationMethod(ctx context.Context, client client.Client, storageClassName *string, method string) error {
+	storageProfile := getStorageProfile(ctx, client, storageClassName)
+	if storageProfile == nil || storageProfile.Annotations[AnnPreallocationMethod] == method {
+		return nil
+	}
+	AddAnnotation(storageProfile, AnnPreallocationMethod, method)
+	return client.Update(ctx, storageProfile)
+}
+
 // ImmediateBindingRequested returns if an object has the ImmediateBinding annotation
 func ImmediateBindingRequested(obj metav1.Object) bool {
 	_, isImmediateBindingRequested := obj.GetAnnotations()[AnnImmediateBinding]
@@ -1064,7 +1343,19 @@ func GetSource(pvc *corev1.PersistentVolumeClaim) string {
 		SourceHTTP,
 		SourceS3,
 		SourceGCS,
+		SourceAzure,
+		SourceSFTP,
+		SourceSMB,
 		SourceGlance,
+		SourceRBD,
+		SourceISCSI,
+		SourceHostPath,
+		SourceDataVolume,
+		SourceExport,
+		SourceV2V,
+		SourceProxmox,
+		SourcePlugin,
+		SourceTorrent,
 		SourceNone,
 		SourceRegistry,
 		SourceImageio,
@@ -1607,7 +1898,15 @@ func GetMetricsURL(pod *corev1.Pod) (string, error) {
 
 // GetProgressReportFromURL fetches the progress report from the passed URL according to an specific metric expression and ownerUID
 func GetProgressReportFromURL(ctx context.Context, url string, httpClient *http.Client, metricExp, ownerUID string) (string, error) {
-	regExp := regexp.MustCompile(fmt.Sprintf("(%s)\\{ownerUID\\=%q\\} (\\d{1,3}\\.?\\d*)", metricExp, ownerUID))
+	metrics, err := GetMetricsFromURL(ctx, url, httpClient)
+	if err != nil {
+		return "", err
+	}
+	return ParseProgressReport(metrics, metricExp, ownerUID), nil
+}
+
+// GetMetricsFromURL fetches the metrics exposed at the passed URL, empty if the pod is gone
+func GetMetricsFromURL(ctx context.Context, url string, httpClient *http.Client) (string, error) {
 	// pod could be gone, don't block an entire thread for 30 seconds
 	// just to get back an i/o timeout
 	ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
@@ -1628,14 +1927,146 @@ func GetProgressReportFromURL(ctx context.Context, url string, httpClient *http.
 	if err != nil {
 		return "", err
 	}
+	return string(body), nil
+}
 
-	// Parse the progress from the body
+// ParseProgressReport parses the progress report from the passed metrics according to an specific metric expression and ownerUID
+func ParseProgressReport(metrics, metricExp, ownerUID string) string {
+	regExp := regexp.MustCompile(fmt.Sprintf("(%s)\\{ownerUID\\=%q\\} (\\d{1,3}\\.?\\d*)", metricExp, ownerUID))
 	progressReport := ""
-	match := regExp.FindStringSubmatch(string(body))
+	match := regExp.FindStringSubmatch(metrics)
 	if match != nil {
 		progressReport = match[len(match)-1]
 	}
-	return progressReport, nil
+	return progressReport
+}
+
+// TopologyPlacement is the topology domain of the consumer of a PVC the importer pod is scheduled in, on top of its workload placement
+type TopologyPlacement struct {
+	NodeSelector map[string]string    `json:"nodeSelector,omitempty"`
+	NodeAffinity *corev1.NodeSelector `json:"nodeAffinity,omitempty"`
+}
+
+// ImportReport is the progress in bytes and the phase durations reported by an importer pod
+type ImportReport struct {
+	TransferredBytes *int64
+	TotalBytes       *int64
+	PhaseDurations   map[common.ImportPhase]string
+}
+
+// ParseImportReport parses the import report of ownerUID from the metrics of an importer pod
+func ParseImportReport(metrics, ownerUID string) *ImportReport {
+	report := &ImportReport{
+		TransferredBytes: parseBytesMetric(metrics, importMetrics.ImportTransferredBytesMetricName, ownerUID),
+		TotalBytes:       parseBytesMetric(metrics, importMetrics.ImportTotalBytesMetricName, ownerUID),
+	}
+	regExp := regexp.MustCompile(fmt.Sprintf("%s\\{ownerUID\\=%q,phase\\=\"([a-z]+)\"\\} (\\S+)", importMetrics.ImportPhaseDurationMetricName, ownerUID))
+	for _, match := range regExp.FindAllStringSubmatch(metrics, -1) {
+		seconds, err := strconv.ParseFloat(match[2], 64)
+		if err != nil {
+			continue
+		}
+		if report.PhaseDurations == nil {
+			report.PhaseDurations = make(map[common.ImportPhase]string)
+		}
+		duration := time.Duration(seconds * float64(time.Second))
+		report.PhaseDurations[common.ImportPhase(match[1])] = duration.Round(time.Millisecond).String()
+	}
+	return report
+}
+
+func parseBytesMetric(metrics, metricName, ownerUID string) *int64 {
+	regExp := regexp.MustCompile(fmt.Sprintf("%s\\{ownerUID\\=%q\\} (\\S+)", metricName, ownerUID))
+	match := regExp.FindStringSubmatch(metrics)
+	if match == nil {
+		return nil
+	}
+	value, err := strconv.ParseFloat(match[1], 64)
+	if err != nil {
+		return nil
+	}
+	return ptr.To(int64(value))
+}
+
+// SetImportReportAnnotations records the import report in the passed annotations
+func SetImportReportAnnotations(anno map[string]string, report *ImportReport) {
+	if report.TransferredBytes != nil {
+		anno[AnnImportTransferredBytes] = strconv.FormatInt(*report.TransferredBytes, 10)
+	}
+	if report.TotalBytes != nil {
+		anno[AnnImportTotalBytes] = strconv.FormatInt(*report.TotalBytes, 10)
+	}
+	if len(report.PhaseDurations) > 0 {
+		if durations, err := json.Marshal(report.PhaseDurations); err == nil {
+			anno[AnnImportPhaseDurations] = string(durations)
+		}
+	}
+}
+
+// GetImportReportFromAnnotations returns the import report recorded in the passed annotations
+func GetImportReportFromAnnotations(anno map[string]string) *ImportReport {
+	report := &ImportReport{}
+	if i, err := strconv.ParseInt(anno[AnnImportTransferredBytes], 10, 64); err == nil {
+		report.TransferredBytes = ptr.To(i)
+	}
+	if i, err := strconv.ParseInt(anno[AnnImportTotalBytes], 10, 64); err == nil {
+		report.TotalBytes = ptr.To(i)
+	}
+	if durations, ok := anno[AnnImportPhaseDurations]; ok {
+		if err := json.Unmarshal([]byte(durations), &report.PhaseDurations); err != nil {
+			klog.V(3).Infof("Ignoring invalid phase durations %s: %v", durations, err)
+		}
+	}
+	return report
+}
+
+// CloneReport is the progress of a clone, the bytes and throughput being reported by the clone source pod of
+// host-assisted clones
+type CloneReport struct {
+	Phase            cdiv1.DataVolumeClonePhase
+	TransferredBytes *int64
+	TotalBytes       *int64
+	Throughput       *int64
+}
+
+// ParseCloneReport parses the bytes and throughput of the clone of ownerUID from the metrics of a clone source pod
+func ParseCloneReport(metrics, ownerUID string) *CloneReport {
+	return &CloneReport{
+		TransferredBytes: parseBytesMetric(metrics, cloneMetrics.CloneTransferredBytesMetricName, ownerUID),
+		TotalBytes:       parseBytesMetric(metrics, cloneMetrics.CloneTotalBytesMetricName, ownerUID),
+		Throughput:       parseBytesMetric(metrics, cloneMetrics.CloneThroughputMetricName, ownerUID),
+	}
+}
+
+// SetCloneReportAnnotations records the clone report in the passed annotations
+func SetCloneReportAnnotations(anno map[string]string, report *CloneReport) {
+	for key, value := range map[string]*int64{
+		AnnCloneTransferredBytes: report.TransferredBytes,
+		AnnCloneTotalBytes:       report.TotalBytes,
+		AnnCloneThroughput:       report.Throughput,
+	} {
+		if value != nil {
+			anno[key] = strconv.FormatInt(*value, 10)
+		}
+	}
+	if report.Phase != "" {
+		anno[AnnCloneProgressPhase] = string(report.Phase)
+	}
+}
+
+// GetCloneReportFromAnnotations returns the clone report recorded in the passed annotations
+func GetCloneReportFromAnnotations(anno map[string]string) *CloneReport {
+	report := &CloneReport{Phase: cdiv1.DataVolumeClonePhase(anno[AnnCloneProgressPhase])}
+	if i, err := strconv.ParseInt(anno[AnnCloneTransferredBytes], 10, 64); err == nil {
+		report.TransferredBytes = ptr.To(i)
+	}
+	if i, err := strconv.ParseInt(anno[AnnCloneTotalBytes], 10, 64); err == nil {
+		report.TotalBytes = ptr.To(i)
+	}
+	if i, err := strconv.ParseInt(anno[AnnCloneThroughput], 10, 64); err == nil {
+		report.Throughput = ptr.To(i)
+	}
+	return report
 }
 
 // UpdateHTTPAnnotations updates the passed annotations for proper http import
@@ -1649,12 +2080,34 @@ func UpdateHTTPAnnotations(annotations map[string]string, http *cdiv1.DataVolume
 	if http.CertConfigMap != "" {
 		annotations[AnnCertConfigMap] = http.CertConfigMap
 	}
+	if http.ClientCertSecretRef != "" {
+		annotations[AnnClientCertSecret] = http.ClientCertSecretRef
+	}
 	for index, header := range http.ExtraHeaders {
 		annotations[fmt.Sprintf("%s.%d", AnnExtraHeaders, index)] = header
 	}
 	for index, header := range http.SecretExtraHeaders {
 		annotations[fmt.Sprintf("%s.%d", AnnSecretExtraHeaders, index)] = header
 	}
+	if http.OAuth2SecretRef != "" {
+		annotations[AnnOAuth2Secret] = http.OAuth2SecretRef
+	}
+	if http.OVA != nil {
+		diskIndex := int32(0)
+		if http.OVA.DiskIndex != nil {
+			diskIndex = *http.OVA.DiskIndex
+		}
+		annotations[AnnOVADisk] = strconv.Itoa(int(diskIndex))
+	}
+	if http.XVA != nil {
+		diskIndex := int32(0)
+		if http.XVA.DiskIndex != nil {
+			diskIndex = *http.XVA.DiskIndex
+		}
+		annotations[AnnXVADisk] = strconv.Itoa(int(diskIndex))
+	}
+	updateChecksumAnnotation(annotations, http.Checksum)
+	updateSignatureAnnotations(annotations, http.Signature)
 }
 
 // UpdateS3Annotations updates the passed annotations for proper S3 import
@@ -1667,6 +2120,33 @@ func UpdateS3Annotations(annotations map[string]string, s3 *cdiv1.DataVolumeSour
 	if s3.CertConfigMap != "" {
 		annotations[AnnCertConfigMap] = s3.CertConfigMap
 	}
+	if s3.ClientCertSecretRef != "" {
+		annotations[AnnClientCertSecret] = s3.ClientCertSecretRef
+	}
+	if s3.ServiceAccountName != "" {
+		annotations[AnnImportServiceAccount] = s3.ServiceAccountName
+	}
+	updateChecksumAnnotation(annotations, s3.Checksum)
+	updateSignatureAnnotations(annotations, s3.Signature)
+}
+
+// UpdateSourceFallbackAnnotations replaces the source annotations with the ones of the passed fallback source
+func UpdateSourceFallbackAnnotations(annotations map[string]string, fallback *cdiv1.DataVolumeSourceFallback) {
+	for _, ann := range []string{AnnEndpoint, AnnSource, AnnSecret, AnnCertConfigMap, AnnClientCertSecret, AnnOAuth2Secret,
+		AnnImportServiceAccount, AnnOVADisk, AnnXVADisk, AnnChecksum, AnnSignatureURL, AnnSignatureKeyringSecret} {
+		delete(annotations, ann)
+	}
+	for ann := range annotations {
+		if strings.HasPrefix(ann, AnnExtraHeaders+".") || strings.HasPrefix(ann, AnnSecretExtraHeaders+".") {
+			delete(annotations, ann)
+		}
+	}
+	if http := fallback.HTTP; http != nil {
+		UpdateHTTPAnnotations(annotations, http)
+	}
+	if s3 := fallback.S3; s3 != nil {
+		UpdateS3Annotations(annotations, s3)
+	}
 }
 
 // UpdateGCSAnnotations updates the passed annotations for proper GCS import
@@ -1676,6 +2156,220 @@ func UpdateGCSAnnotations(annotations map[string]string, gcs *cdiv1.DataVolumeSo
 	if gcs.SecretRef != "" {
 		annotations[AnnSecret] = gcs.SecretRef
 	}
+	if gcs.CertConfigMap != "" {
+		annotations[AnnCertConfigMap] = gcs.CertConfigMap
+	}
+	if gcs.ClientCertSecretRef != "" {
+		annotations[AnnClientCertSecret] = gcs.ClientCertSecretRef
+	}
+	updateChecksumAnnotation(annotations, gcs.Checksum)
+}
+
+func updateChecksumAnnotation(annotations map[string]string, checksum *cdiv1.DataVolumeChecksum) {
+	if checksum != nil {
+		annotations[AnnChecksum] = fmt.Sprintf("%s:%s", checksum.Algorithm, strings.ToLower(checksum.Value))
+	}
+}
+
+func updateSignatureAnnotations(annotations map[string]string, signature *cdiv1.DataVolumeSignature) {
+	if signature != nil {
+		annotations[AnnSignatureURL] = signature.URL
+		annotations[AnnSignatureKeyringSecret] = signature.KeyringSecretRef
+	}
+}
+
+// UpdateAzureAnnotations updates the passed annotations for proper Azure Blob Storage import
+func UpdateAzureAnnotations(annotations map[string]string, azure *cdiv1.DataVolumeSourceAzure) {
+	annotations[AnnEndpoint] = azure.URL
+	annotations[AnnSource] = SourceAzure
+	if azure.SecretRef != "" {
+		annotations[AnnSecret] = azure.SecretRef
+	}
+	if azure.CertConfigMap != "" {
+		annotations[AnnCertConfigMap] = azure.CertConfigMap
+	}
+	if azure.ClientCertSecretRef != "" {
+		annotations[AnnClientCertSecret] = azure.ClientCertSecretRef
+	}
+}
+
+// UpdateSFTPAnnotations updates the passed annotations for proper SFTP import
+func UpdateSFTPAnnotations(annotations map[string]string, sftp *cdiv1.DataVolumeSourceSFTP) {
+	annotations[AnnEndpoint] = sftp.URL
+	annotations[AnnSource] = SourceSFTP
+	annotations[AnnSecret] = sftp.SecretRef
+}
+
+// UpdateSMBAnnotations updates the passed annotations for proper SMB import
+func UpdateSMBAnnotations(annotations map[string]string, smb *cdiv1.DataVolumeSourceSMB) {
+	annotations[AnnEndpoint] = smb.URL
+	annotations[AnnSource] = SourceSMB
+	if smb.SecretRef != "" {
+		annotations[AnnSecret] = smb.SecretRef
+	}
+}
+
+// UpdateGlanceAnnotations updates the passed annotations for proper Glance import
+func UpdateGlanceAnnotations(annotations map[string]string, glance *cdiv1.DataVolumeSourceGlance) {
+	annotations[AnnEndpoint] = glance.URL
+	annotations[AnnSource] = SourceGlance
+	annotations[AnnGlanceImage] = glance.Image
+	annotations[AnnSecret] = glance.SecretRef
+	if glance.CertConfigMap != "" {
+		annotations[AnnCertConfigMap] = glance.CertConfigMap
+	}
+	if glance.ClientCertSecretRef != "" {
+		annotations[AnnClientCertSecret] = glance.ClientCertSecretRef
+	}
+}
+
+// UpdateRBDAnnotations updates the passed annotations for proper RBD import
+func UpdateRBDAnnotations(annotations map[string]string, rbd *cdiv1.DataVolumeSourceRBD) {
+	annotations[AnnEndpoint] = strings.Join(rbd.Monitors, ",")
+	annotations[AnnSource] = SourceRBD
+	image := rbd.Pool + "/" + rbd.Image
+	if rbd.Snapshot != "" {
+		image += "@" + rbd.Snapshot
+	}
+	annotations[AnnRBDImage] = image
+	annotations[AnnSecret] = rbd.SecretRef
+}
+
+// UpdateISCSIAnnotations updates the passed annotations for proper iSCSI import
+func UpdateISCSIAnnotations(annotations map[string]string, iscsi *cdiv1.DataVolumeSourceISCSI) {
+	annotations[AnnEndpoint] = fmt.Sprintf("iscsi://%s/%s/%d", iscsi.Portal, iscsi.IQN, iscsi.LUN)
+	annotations[AnnSource] = SourceISCSI
+	if iscsi.SecretRef != "" {
+		annotations[AnnSecret] = iscsi.SecretRef
+	}
+}
+
+// UpdateHostPathAnnotations updates the passed annotations for proper hostPath import
+func UpdateHostPathAnnotations(annotations map[string]string, hostPath *cdiv1.DataVolumeSourceHostPath) {
+	annotations[AnnEndpoint] = hostPath.Path
+	annotations[AnnSource] = SourceHostPath
+	annotations[AnnHostPathNode] = hostPath.NodeName
+}
+
+// UpdateDataVolumeSourceAnnotations updates the passed annotations for proper import of the PVC of a source DataVolume
+func UpdateDataVolumeSourceAnnotations(annotations map[string]string, dataVolume *cdiv1.DataVolumeSourceDataVolume) {
+	annotations[AnnEndpoint] = dataVolume.Name
+	annotations[AnnSource] = SourceDataVolume
+	transformation := dataVolume.Transformation
+	if transformation == nil {
+		return
+	}
+	if transformation.Format == cdiv1.TransformationFormatQcow2 {
+		annotations[AnnStorageFormat] = common.StorageFormatQcow2
+	}
+	if transformation.Sparsify {
+		annotations[AnnSparsify] = "true"
+	}
+	if transformation.Encryption != nil {
+		annotations[AnnEncryptionSecret] = transformation.Encryption.SecretRef
+	}
+}
+
+// UpdateExportAnnotations updates the passed annotations for proper import of an exported volume
+func UpdateExportAnnotations(annotations map[string]string, export *cdiv1.DataVolumeSourceExport) {
+	annotations[AnnEndpoint] = export.URL
+	annotations[AnnSource] = SourceExport
+	annotations[AnnSecret] = export.SecretRef
+	if export.CertConfigMap != "" {
+		annotations[AnnCertConfigMap] = export.CertConfigMap
+	}
+	if export.ClientCertSecretRef != "" {
+		annotations[AnnClientCertSecret] = export.ClientCertSecretRef
+	}
+}
+
+// UpdateV2VAnnotations updates the passed annotations for proper import of a VM converted by virt-v2v
+func UpdateV2VAnnotations(annotations map[string]string, v2v *cdiv1.DataVolumeSourceV2V) {
+	annotations[AnnEndpoint] = v2v.URL
+	annotations[AnnSource] = SourceV2V
+	annotations[AnnSecret] = v2v.SecretRef
+	annotations[AnnV2VVM] = v2v.VMName
+	annotations[AnnV2VDiskIndex] = strconv.Itoa(int(v2v.DiskIndex))
+	if v2v.BlockDriver != "" {
+		annotations[AnnV2VBlockDriver] = v2v.BlockDriver
+	}
+	if v2v.VirtioWinPVC != "" {
+		annotations[AnnV2VVirtioWinPVC] = v2v.VirtioWinPVC
+	}
+	if macMappings := GetV2VMACMappings(v2v.NetworkMappings); len(macMappings) > 0 {
+		annotations[AnnV2VMACMappings] = strings.Join(macMappings, ";")
+	}
+}
+
+// GetV2VMACMappings returns the virt-v2v --mac values of the network mappings, MAC:network:NAME to map
+// the interface to a network and MAC:ip:IP[,GATEWAY[,PREFIXLENGTH[,NAMESERVER...]]] to configure a static IP
+func GetV2VMACMappings(mappings []cdiv1.V2VNetworkMapping) []string {
+	var macMappings []string
+	for _, mapping := range mappings {
+		if mapping.Network != "" {
+			macMappings = append(macMappings, mapping.MAC+":network:"+mapping.Network)
+		}
+		if mapping.IP == "" {
+			continue
+		}
+		ipConfig := []string{mapping.IP}
+		if mapping.Gateway != "" {
+			ipConfig = append(ipConfig, mapping.Gateway)
+			if mapping.PrefixLength > 0 {
+				ipConfig = append(ipConfig, strconv.Itoa(int(mapping.PrefixLength)))
+				ipConfig = append(ipConfig, mapping.Nameservers...)
+			}
+		}
+		macMappings = append(macMappings, mapping.MAC+":ip:"+strings.Join(ipConfig, ","))
+	}
+	return macMappings
+}
+
+// UpdateProxmoxAnnotations updates the passed annotations for proper import of a Proxmox VE VM disk
+func UpdateProxmoxAnnotations(annotations map[string]string, proxmox *cdiv1.DataVolumeSourceProxmox) {
+	annotations[AnnEndpoint] = proxmox.URL
+	annotations[AnnSource] = SourceProxmox
+	annotations[AnnSecret] = proxmox.SecretRef
+	annotations[AnnProxmoxNode] = proxmox.Node
+	annotations[AnnProxmoxVMID] = strconv.Itoa(int(proxmox.VMID))
+	if proxmox.Disk != "" {
+		annotations[AnnProxmoxDisk] = proxmox.Disk
+	}
+	if proxmox.CertConfigMap != "" {
+		annotations[AnnCertConfigMap] = proxmox.CertConfigMap
+	}
+	if proxmox.ClientCertSecretRef != "" {
+		annotations[AnnClientCertSecret] = proxmox.ClientCertSecretRef
+	}
+}
+
+// UpdatePluginAnnotations updates the passed annotations for proper import from a source plugin
+func UpdatePluginAnnotations(annotations map[string]string, plugin *cdiv1.DataVolumeSourcePlugin) {
+	annotations[AnnEndpoint] = plugin.Name
+	annotations[AnnSource] = SourcePlugin
+	if plugin.SecretRef != "" {
+		annotations[AnnSecret] = plugin.SecretRef
+	}
+	if len(plugin.Parameters) > 0 {
+		// A map of strings always marshals
+		parameters, _ := json.Marshal(plugin.Parameters)
+		annotations[AnnPluginParameters] = string(parameters)
+	}
+}
+
+// UpdateTorrentAnnotations updates the passed annotations for proper import from a BitTorrent swarm
+func UpdateTorrentAnnotations(annotations map[string]string, torrent *cdiv1.DataVolumeSourceTorrent) {
+	annotations[AnnEndpoint] = torrent.URL
+	annotations[AnnSource] = SourceTorrent
+	if len(torrent.WebSeeds) > 0 {
+		annotations[AnnTorrentWebSeeds] = strings.Join(torrent.WebSeeds, " ")
+	}
+	if torrent.File != "" {
+		annotations[AnnTorrentFile] = torrent.File
+	}
+	if torrent.CertConfigMap != "" {
+		annotations[AnnCertConfigMap] = torrent.CertConfigMap
+	}
 }
 
 // UpdateRegistryAnnotations updates the passed annotations for proper registry import
@@ -1703,6 +2397,10 @@ func UpdateRegistryAnnotations(annotations map[string]string, registry *cdiv1.Da
 	if certConfigMap != nil && *certConfigMap != "" {
 		annotations[AnnCertConfigMap] = *certConfigMap
 	}
+	clientCertSecretRef := registry.ClientCertSecretRef
+	if clientCertSecretRef != nil && *clientCertSecretRef != "" {
+		annotations[AnnClientCertSecret] = *clientCertSecretRef
+	}
 
 	if registry.Platform != nil && registry.Platform.Architecture != "" {
 		annotations[AnnRegistryImageArchitecture] = registry.Platform.Architecture
@@ -1731,6 +2429,9 @@ func UpdateImageIOAnnotations(annotations map[string]string, imageio *cdiv1.Data
 	annotations[AnnSource] = SourceImageio
 	annotations[AnnSecret] = imageio.SecretRef
 	annotations[AnnCertConfigMap] = imageio.CertConfigMap
+	if imageio.ClientCertSecretRef != "" {
+		annotations[AnnClientCertSecret] = imageio.ClientCertSecretRef
+	}
 	annotations[AnnDiskID] = imageio.DiskID
 }
 
@@ -2050,6 +2751,148 @@ func CopyAllowedLabels(srcLabels map[string]string, dstObj metav1.Object, overwr
 	}
 }
 
+// GetMetadataPropagationPolicy returns the metadata propagation policy of the CDIConfig, nil if it has none
+func GetMetadataPropagationPolicy(ctx context.Context, c client.Client) (*cdiv1.MetadataPropagationPolicy, error) {
+	config := &cdiv1.CDIConfig{}
+	if err := c.Get(ctx, types.NamespacedName{Name: common.ConfigName}, config); err != nil {
+		return nil, IgnoreNotFound(err)
+	}
+	return config.Spec.MetadataPropagation, nil
+}
+
+// PropagateMetadata copies the labels and annotations of the source object allowed by the policy to the destination
+// object. The keys the destination object already has and the ones of CDI are left alone
+func PropagateMetadata(policy *cdiv1.MetadataPropagationPolicy, srcObj, dstObj metav1.Object) {
+	if policy == nil {
+		return
+	}
+	for key, value := range srcObj.GetLabels() {
+		if _, found := dstObj.GetLabels()[key]; !found && metadataKeyAllowed(policy.Labels, key) {
+			AddLabel(dstObj, key, value)
+		}
+	}
+	for key, value := range srcObj.GetAnnotations() {
+		if _, found := dstObj.GetAnnotations()[key]; !found && metadataKeyAllowed(policy.Annotations, key) {
+			AddAnnotation(dstObj, key, value)
+		}
+	}
+}
+
+// GetTransferPodInjection returns the transfer pod injection of the CDIConfig, nil if it has none
+func GetTransferPodInjection(ctx context.Context, c client.Client) (*cdiv1.TransferPodInjection, error) {
+	config := &cdiv1.CDIConfig{}
+	if err := c.Get(ctx, types.NamespacedName{Name: common.ConfigName}, config); err != nil {
+		return nil, IgnoreNotFound(err)
+	}
+	return config.Spec.TransferPodInjection, nil
+}
+
+// InjectTransferPodContainers adds the init containers, sidecars and volumes of the injection to the pod spec, before
+// its own init containers so the sidecars are running when they start
+func InjectTransferPodContainers(injection *cdiv1.TransferPodInjection, podSpec *corev1.PodSpec) {
+	if injection == nil {
+		return
+	}
+	injection = injection.DeepCopy()
+	initContainers := injection.InitContainers
+	for _, sidecar := range injection.Sidecars {
+		sidecar.RestartPolicy = ptr.To(corev1.ContainerRestartPolicyAlways)
+		initContainers = append(initContainers, sidecar)
+	}
+	podSpec.InitContainers = append(initContainers, podSpec.InitContainers...)
+	podSpec.Volumes = append(podSpec.Volumes, injection.Volumes...)
+}
+
+// GetTransferPodPriorityClass returns the priority class of a worker pod: priorityClassName if set, else the one the
+// CDIConfig selects for the pod with selectClass
+func GetTransferPodPriorityClass(ctx context.Context, c client.Client, priorityClassName string, selectClass func(*cdiv1.TransferPodPriorityClasses) string) (string, error) {
+	if priorityClassName != "" {
+		return priorityClassName, nil
+	}
+	config := &cdiv1.CDIConfig{}
+	if err := c.Get(ctx, types.NamespacedName{Name: common.ConfigName}, config); err != nil {
+		return "", IgnoreNotFound(err)
+	}
+	if config.Spec.TransferPodPriorityClasses == nil {
+		return "", nil
+	}
+	return selectClass(config.Spec.TransferPodPriorityClasses), nil
+}
+
+// ImporterPriorityClass selects the priority class of importer pods
+func ImporterPriorityClass(classes *cdiv1.TransferPodPriorityClasses) string {
+	return classes.Importer
+}
+
+// UploadPriorityClass selects the priority class of upload server pods
+func UploadPriorityClass(classes *cdiv1.TransferPodPriorityClasses) string {
+	return classes.Upload
+}
+
+// CloneSourcePriorityClass selects the priority class of clone source pods
+func CloneSourcePriorityClass(classes *cdiv1.TransferPodPriorityClasses) string {
+	return classes.CloneSource
+}
+
+// CloneTargetPriorityClass selects the priority class of clone target pods
+func CloneTargetPriorityClass(classes *cdiv1.TransferPodPriorityClasses) string {
+	return classes.CloneTarget
+}
+
+// GetImporterImage returns the image of the importer pods of source: the one the CDIConfig sets for the source, else
+// the one it sets for all importers, else image
+func GetImporterImage(ctx context.Context, c client.Client, source, image string) (string, error) {
+	images, err := getTransferPodImages(ctx, c)
+	if err != nil || images == nil {
+		return image, err
+	}
+	if sourceImage := images.Sources[source]; sourceImage != "" {
+		return sourceImage, nil
+	}
+	if images.Importer != "" {
+		return images.Importer, nil
+	}
+	return image, nil
+}
+
+// GetUploadServerImage returns the image of the upload server pods the CDIConfig sets, image if it sets none
+func GetUploadServerImage(ctx context.Context, c client.Client, image string) (string, error) {
+	images, err := getTransferPodImages(ctx, c)
+	if err != nil || images == nil || images.Uploader == "" {
+		return image, err
+	}
+	return images.Uploader, nil
+}
+
+func getTransferPodImages(ctx context.Context, c client.Client) (*cdiv1.TransferPodImages, error) {
+	config := &cdiv1.CDIConfig{}
+	if err := c.Get(ctx, types.NamespacedName{Name: common.ConfigName}, config); err != nil {
+		return nil, IgnoreNotFound(err)
+	}
+	return config.Spec.TransferPodImages, nil
+}
+
+// GetDefaultImageArchitecture returns the architecture the CDIConfig picks from multi-architecture registry images
+func GetDefaultImageArchitecture(ctx context.Context, c client.Client) (string, error) {
+	config := &cdiv1.CDIConfig{}
+	if err := c.Get(ctx, types.NamespacedName{Name: common.ConfigName}, config); err != nil {
+		return "", IgnoreNotFound(err)
+	}
+	return config.Spec.DefaultImageArchitecture, nil
+}
+
+func metadataKeyAllowed(allowed []string, key string) bool {
+	if strings.HasPrefix(key, AnnAPIGroup+"/") {
+		return false
+	}
+	for _, pattern := range allowed {
+		if prefix, wildcard := strings.CutSuffix(pattern, "*"); pattern == key || wildcard && strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
 // ClaimMayExistBeforeDataVolume returns true if the PVC may exist before the DataVolume
 func ClaimMayExistBeforeDataVolume(c client.Client, pvc *corev1.PersistentVolumeClaim, dv *cdiv1.DataVolume) (bool, error) {
 	if ClaimIsPopulatedForDataVolume(pvc, dv) {
@@ -2219,3 +3062,33 @@ func UpdatePVCBoundContionFromEvents(pvc *corev1.PersistentVolumeClaim, c client
 
 	return nil
 }
+
+// MakeS3SecretEnv returns the env variables taken from an S3 secret. All keys are optional, a secret
+// may hold static keys, a role to assume, or both.
+func MakeS3SecretEnv(secretName string) []corev1.EnvVar {
+	var env []corev1.EnvVar
+	for _, key := range []struct {
+		name string
+		key  string
+	}{
+		{common.ImporterAccessKeyID, common.KeyAccess},
+		{common.ImporterSecretKey, common.KeySecret},
+		{common.ImporterSessionToken, common.KeySessionToken},
+		{common.ImporterRoleARN, common.KeyRoleARN},
+		{common.ImporterExternalID, common.KeyExternalID},
+	} {
+		env = append(env, corev1.EnvVar{
+			Name: key.name,
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: secretName,
+					},
+					Key:      key.key,
+					Optional: ptr.To(true),
+				},
+			},
+		})
+	}
+	return env
+}
@@ -11,8 +11,10 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 
 	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+	"kubevirt.io/containerized-data-importer/pkg/common"
 	sdkapi "kubevirt.io/controller-lifecycle-operator-sdk/api"
 )
 
@@ -370,6 +372,138 @@ var _ = Describe("CopyAllowedLabels", func() {
 	)
 })
 
+var _ = Describe("PropagateMetadata", func() {
+	policy := &cdiv1.MetadataPropagationPolicy{
+		Labels:      []string{"team", "cost.example.com/*"},
+		Annotations: []string{"sidecar.istio.io/inject", "*"},
+	}
+	src := &v1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: map[string]string{
+				"team":                    "storage",
+				"cost.example.com/center": "42",
+				"app":                     "vm",
+			},
+			Annotations: map[string]string{
+				"sidecar.istio.io/inject": "false",
+				"owner":                   "team-a",
+				AnnImportPod:              "importer-pvc",
+			},
+		},
+	}
+
+	It("Should copy the allowed labels and annotations but the ones of CDI", func() {
+		pod := &v1.Pod{}
+		PropagateMetadata(policy, src, pod)
+		Expect(pod.Labels).To(Equal(map[string]string{"team": "storage", "cost.example.com/center": "42"}))
+		Expect(pod.Annotations).To(Equal(map[string]string{"sidecar.istio.io/inject": "false", "owner": "team-a"}))
+	})
+
+	It("Should not replace the labels and annotations of the destination", func() {
+		pod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Labels:      map[string]string{"team": "cdi"},
+			Annotations: map[string]string{"sidecar.istio.io/inject": "true"},
+		}}
+		PropagateMetadata(policy, src, pod)
+		Expect(pod.Labels).To(HaveKeyWithValue("team", "cdi"))
+		Expect(pod.Annotations).To(HaveKeyWithValue("sidecar.istio.io/inject", "true"))
+	})
+
+	It("Should copy nothing without policy", func() {
+		pod := &v1.Pod{}
+		PropagateMetadata(nil, src, pod)
+		Expect(pod.Labels).To(BeEmpty())
+		Expect(pod.Annotations).To(BeEmpty())
+	})
+})
+
+var _ = Describe("InjectTransferPodContainers", func() {
+	It("Should add the init containers and sidecars before the init containers of the pod", func() {
+		injection := &cdiv1.TransferPodInjection{
+			InitContainers: []v1.Container{{Name: "egress-setup"}},
+			Sidecars:       []v1.Container{{Name: "spiffe-agent"}},
+			Volumes:        []v1.Volume{{Name: "spiffe-socket"}},
+		}
+		podSpec := &v1.PodSpec{
+			InitContainers: []v1.Container{{Name: "vddk-side-car"}},
+			Containers:     []v1.Container{{Name: "importer"}},
+			Volumes:        []v1.Volume{{Name: "cdi-data-vol"}},
+		}
+		InjectTransferPodContainers(injection, podSpec)
+		Expect(podSpec.InitContainers).To(HaveLen(3))
+		Expect(podSpec.InitContainers[0].Name).To(Equal("egress-setup"))
+		Expect(podSpec.InitContainers[0].RestartPolicy).To(BeNil())
+		Expect(podSpec.InitContainers[1].Name).To(Equal("spiffe-agent"))
+		Expect(podSpec.InitContainers[1].RestartPolicy).To(HaveValue(Equal(v1.ContainerRestartPolicyAlways)))
+		Expect(podSpec.InitContainers[2].Name).To(Equal("vddk-side-car"))
+		Expect(podSpec.Containers).To(HaveLen(1))
+		Expect(podSpec.Volumes).To(Equal([]v1.Volume{{Name: "cdi-data-vol"}, {Name: "spiffe-socket"}}))
+		Expect(injection.Sidecars[0].RestartPolicy).To(BeNil())
+	})
+
+	It("Should leave the pod alone without injection", func() {
+		podSpec := &v1.PodSpec{Containers: []v1.Container{{Name: "importer"}}}
+		InjectTransferPodContainers(nil, podSpec)
+		Expect(podSpec.InitContainers).To(BeEmpty())
+		Expect(podSpec.Volumes).To(BeEmpty())
+	})
+})
+
+var _ = Describe("GetTransferPodPriorityClass", func() {
+	It("Should select the priority class of the CDIConfig unless one is set", func() {
+		config := MakeEmptyCDIConfigSpec(common.ConfigName)
+		config.Spec.TransferPodPriorityClasses = &cdiv1.TransferPodPriorityClasses{Importer: "import-priority", CloneSource: "clone-priority"}
+		c := CreateClient(config)
+		priorityClassName, err := GetTransferPodPriorityClass(context.TODO(), c, "", ImporterPriorityClass)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(priorityClassName).To(Equal("import-priority"))
+		priorityClassName, err = GetTransferPodPriorityClass(context.TODO(), c, "", CloneSourcePriorityClass)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(priorityClassName).To(Equal("clone-priority"))
+		priorityClassName, err = GetTransferPodPriorityClass(context.TODO(), c, "", UploadPriorityClass)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(priorityClassName).To(BeEmpty())
+		priorityClassName, err = GetTransferPodPriorityClass(context.TODO(), c, "p0", ImporterPriorityClass)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(priorityClassName).To(Equal("p0"))
+	})
+
+	It("Should not select a priority class without CDIConfig", func() {
+		priorityClassName, err := GetTransferPodPriorityClass(context.TODO(), CreateClient(), "", ImporterPriorityClass)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(priorityClassName).To(BeEmpty())
+	})
+})
+
+var _ = Describe("GetImporterImage", func() {
+	It("Should pick the image of the source, then the one of all importers", func() {
+		config := MakeEmptyCDIConfigSpec(common.ConfigName)
+		config.Spec.TransferPodImages = &cdiv1.TransferPodImages{
+			Importer: "registry/importer-custom",
+			Sources:  map[string]string{SourceVDDK: "registry/importer-vddk"},
+		}
+		c := CreateClient(config)
+		image, err := GetImporterImage(context.TODO(), c, SourceVDDK, "registry/importer")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(image).To(Equal("registry/importer-vddk"))
+		image, err = GetImporterImage(context.TODO(), c, SourceHTTP, "registry/importer")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(image).To(Equal("registry/importer-custom"))
+		image, err = GetUploadServerImage(context.TODO(), c, "registry/uploadserver")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(image).To(Equal("registry/uploadserver"))
+	})
+
+	It("Should keep the images CDI is deployed with without CDIConfig", func() {
+		image, err := GetImporterImage(context.TODO(), CreateClient(), SourceVDDK, "registry/importer")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(image).To(Equal("registry/importer"))
+		image, err = GetUploadServerImage(context.TODO(), CreateClient(), "registry/uploadserver")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(image).To(Equal("registry/uploadserver"))
+	})
+})
+
 var _ = Describe("sortEvents", func() {
 	It("Should sort events by timestamp but prioritize longer messages", func() {
 		events := &v1.EventList{
@@ -407,6 +541,210 @@ var _ = Describe("sortEvents", func() {
 	})
 })
 
+var _ = Describe("UpdateV2VAnnotations", func() {
+	It("Should pass the network mappings as virt-v2v --mac values", func() {
+		annotations := map[string]string{}
+		UpdateV2VAnnotations(annotations, &cdiv1.DataVolumeSourceV2V{
+			URL:       "vpx://vcenter.example.com/Datacenter/Cluster/esxi1.example.com",
+			VMName:    "win2019",
+			DiskIndex: 1,
+			SecretRef: "vsphere-creds",
+			NetworkMappings: []cdiv1.V2VNetworkMapping{
+				{MAC: "00:50:56:aa:bb:01", Network: "pod"},
+				{MAC: "00:50:56:aa:bb:02", Network: "vlan10", IP: "192.168.10.5", Gateway: "192.168.10.1", PrefixLength: 24, Nameservers: []string{"192.168.10.2", "192.168.10.3"}},
+				{MAC: "00:50:56:aa:bb:03", IP: "fd00::5"},
+			},
+		})
+		Expect(annotations).To(Equal(map[string]string{
+			AnnEndpoint:     "vpx://vcenter.example.com/Datacenter/Cluster/esxi1.example.com",
+			AnnSource:       SourceV2V,
+			AnnSecret:       "vsphere-creds",
+			AnnV2VVM:        "win2019",
+			AnnV2VDiskIndex: "1",
+			AnnV2VMACMappings: "00:50:56:aa:bb:01:network:pod;00:50:56:aa:bb:02:network:vlan10;" +
+				"00:50:56:aa:bb:02:ip:192.168.10.5,192.168.10.1,24,192.168.10.2,192.168.10.3;00:50:56:aa:bb:03:ip:fd00::5",
+		}))
+	})
+})
+
+var _ = Describe("UpdateProxmoxAnnotations", func() {
+	It("Should only set the disk and the CA when they are given", func() {
+		annotations := map[string]string{}
+		UpdateProxmoxAnnotations(annotations, &cdiv1.DataVolumeSourceProxmox{
+			URL:       "https://pve.example.com:8006",
+			Node:      "pve2",
+			VMID:      100,
+			SecretRef: "proxmox-credentials",
+		})
+		Expect(annotations).To(Equal(map[string]string{
+			AnnEndpoint:    "https://pve.example.com:8006",
+			AnnSource:      SourceProxmox,
+			AnnSecret:      "proxmox-credentials",
+			AnnProxmoxNode: "pve2",
+			AnnProxmoxVMID: "100",
+		}))
+	})
+})
+
+var _ = Describe("UpdatePluginAnnotations", func() {
+	It("Should encode the parameters of the plugin", func() {
+		annotations := map[string]string{}
+		UpdatePluginAnnotations(annotations, &cdiv1.DataVolumeSourcePlugin{
+			Name:       "tape",
+			Parameters: map[string]string{"library": "lib0", "volume": "vm-backup-0042"},
+		})
+		Expect(annotations).To(Equal(map[string]string{
+			AnnEndpoint:         "tape",
+			AnnSource:           SourcePlugin,
+			AnnPluginParameters: `{"library":"lib0","volume":"vm-backup-0042"}`,
+		}))
+	})
+})
+
+var _ = Describe("UpdateTorrentAnnotations", func() {
+	It("Should join the web seeds of the torrent", func() {
+		annotations := map[string]string{}
+		UpdateTorrentAnnotations(annotations, &cdiv1.DataVolumeSourceTorrent{
+			URL:      "https://images.example.com/fedora.torrent",
+			WebSeeds: []string{"https://mirror-a.example.com/fedora.qcow2", "https://mirror-b.example.com/fedora.qcow2"},
+			File:     "fedora.qcow2",
+		})
+		Expect(annotations).To(Equal(map[string]string{
+			AnnEndpoint:        "https://images.example.com/fedora.torrent",
+			AnnSource:          SourceTorrent,
+			AnnTorrentWebSeeds: "https://mirror-a.example.com/fedora.qcow2 https://mirror-b.example.com/fedora.qcow2",
+			AnnTorrentFile:     "fedora.qcow2",
+		}))
+	})
+})
+
+var _ = Describe("UpdateSourceFallbackAnnotations", func() {
+	It("Should replace the annotations of the source with the ones of the fallback", func() {
+		annotations := map[string]string{AnnContentType: "kubevirt"}
+		UpdateHTTPAnnotations(annotations, &cdiv1.DataVolumeSourceHTTP{
+			URL:          "https://mirror.example.com/disk.img",
+			SecretRef:    "mirror-credentials",
+			ExtraHeaders: []string{"X-Mirror: 1"},
+			Checksum:     &cdiv1.DataVolumeChecksum{Algorithm: cdiv1.ChecksumSHA256, Value: "abcd"},
+		})
+		UpdateSourceFallbackAnnotations(annotations, &cdiv1.DataVolumeSourceFallback{
+			S3: &cdiv1.DataVolumeSourceS3{URL: "https://s3.example.com/bucket/disk.img"},
+		})
+		Expect(annotations).To(Equal(map[string]string{
+			AnnContentType: "kubevirt",
+			AnnEndpoint:    "https://s3.example.com/bucket/disk.img",
+			AnnSource:      SourceS3,
+		}))
+	})
+})
+
+var _ = Describe("ImportReport", func() {
+	const importerMetrics = `kubevirt_cdi_import_phase_duration_seconds{ownerUID="1234",phase="convert"} 2.5
+kubevirt_cdi_import_phase_duration_seconds{ownerUID="1234",phase="download"} 62
+kubevirt_cdi_import_phase_duration_seconds{ownerUID="5678",phase="download"} 3
+kubevirt_cdi_import_progress_total{ownerUID="1234"} 100
+kubevirt_cdi_import_total_bytes{ownerUID="1234"} 1.073741824e+09
+kubevirt_cdi_import_transferred_bytes{ownerUID="1234"} 5.36870912e+08
+`
+
+	It("Should parse the report of the owner from the importer metrics", func() {
+		report := ParseImportReport(importerMetrics, "1234")
+		Expect(report.TransferredBytes).To(HaveValue(Equal(int64(536870912))))
+		Expect(report.TotalBytes).To(HaveValue(Equal(int64(1073741824))))
+		Expect(report.PhaseDurations).To(Equal(map[common.ImportPhase]string{
+			common.ImportPhaseConvert:  "2.5s",
+			common.ImportPhaseDownload: "1m2s",
+		}))
+		Expect(ParseProgressReport(importerMetrics, "kubevirt_cdi_import_progress_total", "1234")).To(Equal("100"))
+	})
+
+	It("Should leave the report empty without metrics of the owner", func() {
+		report := ParseImportReport(importerMetrics, "0000")
+		Expect(report.TransferredBytes).To(BeNil())
+		Expect(report.TotalBytes).To(BeNil())
+		Expect(report.PhaseDurations).To(BeEmpty())
+	})
+
+	It("Should round trip the report through annotations", func() {
+		annotations := map[string]string{}
+		SetImportReportAnnotations(annotations, ParseImportReport(importerMetrics, "1234"))
+		Expect(annotations).To(HaveKeyWithValue(AnnImportTransferredBytes, "536870912"))
+		Expect(annotations).To(HaveKeyWithValue(AnnImportTotalBytes, "1073741824"))
+		Expect(GetImportReportFromAnnotations(annotations)).To(Equal(ParseImportReport(importerMetrics, "1234")))
+	})
+})
+
+var _ = Describe("CloneReport", func() {
+	const clonerMetrics = `kubevirt_cdi_clone_progress_total{ownerUID="1234"} 50
+kubevirt_cdi_clone_throughput_bytes_per_second{ownerUID="1234"} 1.048576e+07
+kubevirt_cdi_clone_total_bytes{ownerUID="1234"} 1.073741824e+09
+kubevirt_cdi_clone_transferred_bytes{ownerUID="1234"} 5.36870912e+08
+kubevirt_cdi_clone_transferred_bytes{ownerUID="5678"} 1024
+`
+
+	It("Should parse the report of the owner from the cloner metrics", func() {
+		report := ParseCloneReport(clonerMetrics, "1234")
+		Expect(report.TransferredBytes).To(HaveValue(Equal(int64(536870912))))
+		Expect(report.TotalBytes).To(HaveValue(Equal(int64(1073741824))))
+		Expect(report.Throughput).To(HaveValue(Equal(int64(10485760))))
+		Expect(report.Phase).To(BeEmpty())
+	})
+
+	It("Should leave the report empty without metrics of the owner", func() {
+		report := ParseCloneReport(clonerMetrics, "0000")
+		Expect(report.TransferredBytes).To(BeNil())
+		Expect(report.TotalBytes).To(BeNil())
+		Expect(report.Throughput).To(BeNil())
+	})
+
+	It("Should round trip the report through annotations", func() {
+		report := ParseCloneReport(clonerMetrics, "1234")
+		report.Phase = cdiv1.ClonePhaseTransfer
+		annotations := map[string]string{}
+		SetCloneReportAnnotations(annotations, report)
+		Expect(annotations).To(HaveKeyWithValue(AnnCloneTransferredBytes, "536870912"))
+		Expect(annotations).To(HaveKeyWithValue(AnnCloneThroughput, "10485760"))
+		Expect(annotations).To(HaveKeyWithValue(AnnCloneProgressPhase, string(cdiv1.ClonePhaseTransfer)))
+		Expect(GetCloneReportFromAnnotations(annotations)).To(Equal(report))
+	})
+})
+
+var _ = Describe("Client certificate annotation", func() {
+	DescribeTable("Should name the client certificate secret of the source", func(update func(map[string]string)) {
+		annotations := map[string]string{}
+		update(annotations)
+		Expect(annotations).To(HaveKeyWithValue(AnnClientCertSecret, "client-cert"))
+	},
+		Entry("http", func(a map[string]string) {
+			UpdateHTTPAnnotations(a, &cdiv1.DataVolumeSourceHTTP{URL: "https://example.com/disk.img", ClientCertSecretRef: "client-cert"})
+		}),
+		Entry("s3", func(a map[string]string) {
+			UpdateS3Annotations(a, &cdiv1.DataVolumeSourceS3{URL: "https://s3.example.com/bucket/disk.img", ClientCertSecretRef: "client-cert"})
+		}),
+		Entry("gcs", func(a map[string]string) {
+			UpdateGCSAnnotations(a, &cdiv1.DataVolumeSourceGCS{URL: "https://storage.example.com/bucket/disk.img", ClientCertSecretRef: "client-cert"})
+		}),
+		Entry("azure", func(a map[string]string) {
+			UpdateAzureAnnotations(a, &cdiv1.DataVolumeSourceAzure{URL: "https://account.blob.core.windows.net/disks/disk.vhd", ClientCertSecretRef: "client-cert"})
+		}),
+		Entry("glance", func(a map[string]string) {
+			UpdateGlanceAnnotations(a, &cdiv1.DataVolumeSourceGlance{URL: "https://keystone.example.com/v3", ClientCertSecretRef: "client-cert"})
+		}),
+		Entry("export", func(a map[string]string) {
+			UpdateExportAnnotations(a, &cdiv1.DataVolumeSourceExport{URL: "https://export.example.com/volumes/disk/disk.img.gz", ClientCertSecretRef: "client-cert"})
+		}),
+		Entry("proxmox", func(a map[string]string) {
+			UpdateProxmoxAnnotations(a, &cdiv1.DataVolumeSourceProxmox{URL: "https://pve.example.com:8006", ClientCertSecretRef: "client-cert"})
+		}),
+		Entry("registry", func(a map[string]string) {
+			UpdateRegistryAnnotations(a, &cdiv1.DataVolumeSourceRegistry{URL: ptr.To("docker://registry.example.com/disk"), ClientCertSecretRef: ptr.To("client-cert")})
+		}),
+		Entry("imageio", func(a map[string]string) {
+			UpdateImageIOAnnotations(a, &cdiv1.DataVolumeSourceImageIO{URL: "https://engine.example.com/ovirt-engine/api", ClientCertSecretRef: "client-cert"})
+		}),
+	)
+})
+
 func createPvcNoSize(name, ns string, annotations, labels map[string]string) *v1.PersistentVolumeClaim {
 	return &v1.PersistentVolumeClaim{
 		ObjectMeta: metav1.ObjectMeta{
@@ -0,0 +1,434 @@
+/*
+Copyright 2026 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+	"kubevirt.io/containerized-data-importer/pkg/common"
+	cc "kubevirt.io/containerized-data-importer/pkg/controller/common"
+	"kubevirt.io/containerized-data-importer/pkg/exporter"
+	"kubevirt.io/containerized-data-importer/pkg/util"
+)
+
+const (
+	dataExportControllerName = "dataexport-controller"
+
+	// ExportFailed provides a const to indicate a DataExport failed
+	ExportFailed = "ExportFailed"
+	// ExportSucceeded provides a const to indicate a DataExport succeeded
+	ExportSucceeded = "ExportSucceeded"
+
+	dataExportSourceKindDV = "DataVolume"
+)
+
+// DataExportReconciler members
+type DataExportReconciler struct {
+	client          client.Client
+	recorder        record.EventRecorder
+	scheme          *runtime.Scheme
+	log             logr.Logger
+	image           string
+	pullPolicy      string
+	installerLabels map[string]string
+}
+
+// Reconcile loop for DataExportReconciler
+func (r *DataExportReconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	dex := &cdiv1.DataExport{}
+	if err := r.client.Get(ctx, req.NamespacedName, dex); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+	if dex.DeletionTimestamp != nil {
+		return reconcile.Result{}, nil
+	}
+	if err := r.update(ctx, dex); err != nil {
+		return reconcile.Result{}, err
+	}
+	return reconcile.Result{}, nil
+}
+
+// update runs the exporter pod of the DataExport once its source is ready, and reports its result. The pod is
+// deleted once the export is done, as a DataExport is never exported twice.
+func (r *DataExportReconciler) update(ctx context.Context, dex *cdiv1.DataExport) error {
+	dexCopy := dex.DeepCopy()
+	done := dex.Status.Phase == cdiv1.DataExportSucceeded || dex.Status.Phase == cdiv1.DataExportFailed
+	if !done {
+		if err := r.export(ctx, dex); err != nil {
+			return err
+		}
+	}
+	if !reflect.DeepEqual(dex, dexCopy) {
+		if err := r.client.Update(ctx, dex); err != nil {
+			return err
+		}
+	}
+	if dex.Status.Phase == cdiv1.DataExportSucceeded || dex.Status.Phase == cdiv1.DataExportFailed {
+		return r.deleteExporterPod(ctx, dex)
+	}
+	return nil
+}
+
+// export creates the exporter pod once the source is ready, and moves the DataExport to the phase of the pod
+func (r *DataExportReconciler) export(ctx context.Context, dex *cdiv1.DataExport) error {
+	target, targetURL, secret, certConfigMap, err := dataExportTarget(&dex.Spec.Target)
+	if err != nil {
+		r.setFailed(dex, err.Error())
+		return nil
+	}
+	pvc, waiting, err := r.getSourcePVC(ctx, dex)
+	if err != nil {
+		return err
+	}
+	if waiting != "" {
+		dex.Status.Phase = cdiv1.DataExportPending
+		dex.Status.Message = waiting
+		return nil
+	}
+
+	pod := &corev1.Pod{}
+	if err := r.client.Get(ctx, types.NamespacedName{Namespace: dex.Namespace, Name: exporterPodName(dex)}, pod); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return err
+		}
+		pod, err = r.newExporterPod(ctx, dex, pvc, target, targetURL, secret, certConfigMap)
+		if err != nil {
+			return err
+		}
+		r.log.V(1).Info("Creating exporter pod", "dataExport", dex.Name, "pod", pod.Name)
+		if err := r.client.Create(ctx, pod); err != nil {
+			return err
+		}
+	}
+	if !metav1.IsControlledBy(pod, dex) {
+		r.setFailed(dex, fmt.Sprintf("Pod %s already exists and is not owned by the DataExport", pod.Name))
+		return nil
+	}
+
+	switch pod.Status.Phase {
+	case corev1.PodSucceeded:
+		dex.Status.Phase = cdiv1.DataExportSucceeded
+		dex.Status.Digest = exporterTerminationMessage(pod)
+		dex.Status.Message = ""
+		r.recorder.Eventf(dex, corev1.EventTypeNormal, ExportSucceeded, "Exported %s %s", dex.Spec.Source.Kind, dex.Spec.Source.Name)
+	case corev1.PodFailed:
+		message := exporterTerminationMessage(pod)
+		if message == "" {
+			message = "Exporter pod failed"
+		}
+		r.setFailed(dex, message)
+	default:
+		dex.Status.Phase = cdiv1.DataExportInProgress
+		dex.Status.Message = ""
+	}
+	return nil
+}
+
+func (r *DataExportReconciler) setFailed(dex *cdiv1.DataExport, message string) {
+	dex.Status.Phase = cdiv1.DataExportFailed
+	dex.Status.Message = message
+	r.recorder.Event(dex, corev1.EventTypeWarning, ExportFailed, message)
+}
+
+// getSourcePVC returns the PVC exported, or why the export waits for it
+func (r *DataExportReconciler) getSourcePVC(ctx context.Context, dex *cdiv1.DataExport) (*corev1.PersistentVolumeClaim, string, error) {
+	source := dex.Spec.Source
+	if source.Kind == dataExportSourceKindDV {
+		dv := &cdiv1.DataVolume{}
+		if err := r.client.Get(ctx, types.NamespacedName{Namespace: dex.Namespace, Name: source.Name}, dv); err != nil {
+			if k8serrors.IsNotFound(err) {
+				return nil, fmt.Sprintf("Waiting for DataVolume %s to be created", source.Name), nil
+			}
+			return nil, "", err
+		}
+		if dv.Status.Phase != cdiv1.Succeeded {
+			return nil, fmt.Sprintf("Waiting for DataVolume %s to succeed", source.Name), nil
+		}
+	}
+	pvc := &corev1.PersistentVolumeClaim{}
+	if err := r.client.Get(ctx, types.NamespacedName{Namespace: dex.Namespace, Name: source.Name}, pvc); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil, fmt.Sprintf("Waiting for PVC %s to be created", source.Name), nil
+		}
+		return nil, "", err
+	}
+	if pvc.Status.Phase != corev1.ClaimBound {
+		return nil, fmt.Sprintf("Waiting for PVC %s to be bound", source.Name), nil
+	}
+	return pvc, "", nil
+}
+
+// dataExportTarget returns the exporter target of the only target set
+func dataExportTarget(target *cdiv1.DataExportTarget) (string, string, string, string, error) {
+	count := 0
+	var name, url, secret, certConfigMap string
+	if target.HTTP != nil {
+		count++
+		name, url, secret, certConfigMap = exporter.TargetHTTP, target.HTTP.URL, target.HTTP.SecretRef, target.HTTP.CertConfigMap
+	}
+	if target.S3 != nil {
+		count++
+		name, url, secret, certConfigMap = exporter.TargetS3, target.S3.URL, target.S3.SecretRef, target.S3.CertConfigMap
+	}
+	if target.Registry != nil {
+		count++
+		name, url, secret, certConfigMap = exporter.TargetRegistry, target.Registry.URL, target.Registry.SecretRef, target.Registry.CertConfigMap
+	}
+	if count != 1 {
+		return "", "", "", "", errors.New("exactly one of the http, s3 and registry targets must be set")
+	}
+	if url == "" {
+		return "", "", "", "", errors.Errorf("the url of the %s target is required", name)
+	}
+	return name, url, secret, certConfigMap, nil
+}
+
+func exporterPodName(dex *cdiv1.DataExport) string {
+	return dex.Name + "-exporter"
+}
+
+// newExporterPod creates the pod exporting the PVC, which is mounted read only
+func (r *DataExportReconciler) newExporterPod(ctx context.Context, dex *cdiv1.DataExport, pvc *corev1.PersistentVolumeClaim, target, targetURL, secret, certConfigMap string) (*corev1.Pod, error) {
+	resourceRequirements, err := cc.GetDefaultPodResourceRequirements(r.client)
+	if err != nil {
+		return nil, err
+	}
+	imagePullSecrets, err := cc.GetImagePullSecrets(r.client)
+	if err != nil {
+		return nil, err
+	}
+	workloadNodePlacement, err := cc.GetWorkloadNodePlacement(ctx, r.client)
+	if err != nil {
+		return nil, err
+	}
+
+	sourcePath, sourceFormat := common.ImporterWritePath, pvc.Annotations[cc.AnnStorageFormat]
+	container := corev1.Container{
+		Name:            common.ExporterPodName,
+		Image:           r.image,
+		ImagePullPolicy: corev1.PullPolicy(r.pullPolicy),
+		Command:         []string{"/usr/bin/cdi-exporter", "-alsologtostderr"},
+		VolumeMounts: []corev1.VolumeMount{
+			{
+				Name:      cc.ScratchVolName,
+				MountPath: common.ScratchDataDir,
+			},
+		},
+		TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+	}
+	if cc.GetVolumeMode(pvc) == corev1.PersistentVolumeBlock {
+		sourcePath, sourceFormat = common.WriteBlockPath, ""
+		container.VolumeDevices = cc.AddVolumeDevices()
+	} else {
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+			Name:      cc.DataVolName,
+			MountPath: common.ImporterDataDir,
+			ReadOnly:  true,
+		})
+	}
+	container.Env = []corev1.EnvVar{
+		{Name: common.ExporterSourcePath, Value: sourcePath},
+		{Name: common.ExporterSourceFormat, Value: sourceFormat},
+		{Name: common.ExporterTarget, Value: target},
+		{Name: common.ExporterEndpoint, Value: targetURL},
+		{Name: common.ExporterCompress, Value: strconv.FormatBool(dex.Spec.Compress)},
+	}
+	if resourceRequirements != nil {
+		container.Resources = *resourceRequirements
+	}
+
+	volumes := []corev1.Volume{
+		{
+			Name: cc.DataVolName,
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: pvc.Name,
+					ReadOnly:  true,
+				},
+			},
+		},
+		{
+			Name: cc.ScratchVolName,
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			},
+		},
+	}
+	if secret != "" {
+		container.Env = append(container.Env,
+			corev1.EnvVar{
+				Name: common.ExporterAccessKeyID,
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: secret},
+						Key:                  common.KeyAccess,
+					},
+				},
+			},
+			corev1.EnvVar{
+				Name: common.ExporterSecretKey,
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: secret},
+						Key:                  common.KeySecret,
+					},
+				},
+			})
+	}
+	if certConfigMap != "" {
+		container.Env = append(container.Env, corev1.EnvVar{Name: common.ExporterCertDirVar, Value: common.ImporterCertDir})
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+			Name:      CertVolName,
+			MountPath: common.ImporterCertDir,
+		})
+		volumes = append(volumes, corev1.Volume{
+			Name: CertVolName,
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: certConfigMap},
+				},
+			},
+		})
+	}
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      exporterPodName(dex),
+			Namespace: dex.Namespace,
+			Labels: map[string]string{
+				common.CDILabelKey:       common.CDILabelValue,
+				common.CDIComponentLabel: common.ExporterPodName,
+			},
+		},
+		Spec: corev1.PodSpec{
+			Containers:        []corev1.Container{container},
+			Volumes:           volumes,
+			RestartPolicy:     corev1.RestartPolicyNever,
+			NodeSelector:      workloadNodePlacement.NodeSelector,
+			Tolerations:       workloadNodePlacement.Tolerations,
+			Affinity:          workloadNodePlacement.Affinity,
+			PriorityClassName: cc.GetPriorityClass(pvc),
+			ImagePullSecrets:  imagePullSecrets,
+		},
+	}
+	util.SetRecommendedLabels(pod, r.installerLabels, common.CDIControllerName)
+	cc.SetRestrictedSecurityContext(&pod.Spec)
+	if err := controllerutil.SetControllerReference(dex, pod, r.scheme); err != nil {
+		return nil, err
+	}
+	return pod, nil
+}
+
+// exporterTerminationMessage returns the digest written by a successful exporter, or the error of a failed one
+func exporterTerminationMessage(pod *corev1.Pod) string {
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.State.Terminated != nil {
+			return status.State.Terminated.Message
+		}
+	}
+	return ""
+}
+
+func (r *DataExportReconciler) deleteExporterPod(ctx context.Context, dex *cdiv1.DataExport) error {
+	pod := &corev1.Pod{}
+	if err := r.client.Get(ctx, types.NamespacedName{Namespace: dex.Namespace, Name: exporterPodName(dex)}, pod); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if !metav1.IsControlledBy(pod, dex) || pod.DeletionTimestamp != nil {
+		return nil
+	}
+	r.log.V(1).Info("Deleting exporter pod", "dataExport", dex.Name, "pod", pod.Name)
+	return client.IgnoreNotFound(r.client.Delete(ctx, pod))
+}
+
+// NewDataExportController creates a new instance of the DataExport controller
+func NewDataExportController(mgr manager.Manager, log logr.Logger, importerImage, pullPolicy string, installerLabels map[string]string) (controller.Controller, error) {
+	reconciler := &DataExportReconciler{
+		client:          mgr.GetClient(),
+		recorder:        mgr.GetEventRecorderFor(dataExportControllerName),
+		scheme:          mgr.GetScheme(),
+		log:             log.WithName(dataExportControllerName),
+		image:           importerImage,
+		pullPolicy:      pullPolicy,
+		installerLabels: installerLabels,
+	}
+	dataExportController, err := controller.New(dataExportControllerName, mgr, controller.Options{
+		MaxConcurrentReconciles: 3,
+		Reconciler:              reconciler,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := addDataExportControllerWatches(mgr, dataExportController); err != nil {
+		return nil, err
+	}
+	log.Info("Initialized DataExport controller")
+	return dataExportController, nil
+}
+
+func addDataExportControllerWatches(mgr manager.Manager, c controller.Controller) error {
+	if err := c.Watch(source.Kind(mgr.GetCache(), &cdiv1.DataExport{}, &handler.TypedEnqueueRequestForObject[*cdiv1.DataExport]{})); err != nil {
+		return err
+	}
+	if err := c.Watch(source.Kind(mgr.GetCache(), &corev1.Pod{}, handler.TypedEnqueueRequestForOwner[*corev1.Pod](
+		mgr.GetScheme(), mgr.GetClient().RESTMapper(), &cdiv1.DataExport{}, handler.OnlyControllerOwner()))); err != nil {
+		return err
+	}
+	// The exports of DataVolumes wait for them to succeed
+	mapDataVolumeToDataExports := func(ctx context.Context, dv *cdiv1.DataVolume) []reconcile.Request {
+		dexList := &cdiv1.DataExportList{}
+		if err := mgr.GetClient().List(ctx, dexList, client.InNamespace(dv.Namespace)); err != nil {
+			return nil
+		}
+		var reqs []reconcile.Request
+		for _, dex := range dexList.Items {
+			if dex.Spec.Source.Kind == dataExportSourceKindDV && dex.Spec.Source.Name == dv.Name {
+				reqs = append(reqs, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: dex.Namespace, Name: dex.Name}})
+			}
+		}
+		return reqs
+	}
+	if err := c.Watch(source.Kind(mgr.GetCache(), &cdiv1.DataVolume{}, handler.TypedEnqueueRequestsFromMapFunc[*cdiv1.DataVolume](mapDataVolumeToDataExports))); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,267 @@
+/*
+Copyright 2026 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+	"kubevirt.io/containerized-data-importer/pkg/common"
+	. "kubevirt.io/containerized-data-importer/pkg/controller/common"
+)
+
+const (
+	dexName     = "test-dex"
+	dexPVCName  = "test-pvc"
+	dexPodName  = dexName + "-exporter"
+	dexImage    = "test/myimage"
+	dexRegistry = "docker://registry.example.com/disks/fedora:40"
+)
+
+var _ = Describe("DataExport controller reconcile loop", func() {
+	reconcileDataExport := func(reconciler *DataExportReconciler) *cdiv1.DataExport {
+		key := types.NamespacedName{Name: dexName, Namespace: metav1.NamespaceDefault}
+		_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: key})
+		Expect(err).ToNot(HaveOccurred())
+		dex := &cdiv1.DataExport{}
+		Expect(reconciler.client.Get(context.TODO(), key, dex)).To(Succeed())
+		return dex
+	}
+
+	getExporterPod := func(reconciler *DataExportReconciler) (*corev1.Pod, error) {
+		pod := &corev1.Pod{}
+		err := reconciler.client.Get(context.TODO(), types.NamespacedName{Name: dexPodName, Namespace: metav1.NamespaceDefault}, pod)
+		return pod, err
+	}
+
+	setExporterPodStatus := func(reconciler *DataExportReconciler, phase corev1.PodPhase, message string) {
+		pod, err := getExporterPod(reconciler)
+		Expect(err).ToNot(HaveOccurred())
+		pod.Status.Phase = phase
+		pod.Status.ContainerStatuses = []corev1.ContainerStatus{{
+			State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Message: message}},
+		}}
+		Expect(reconciler.client.Status().Update(context.TODO(), pod)).To(Succeed())
+	}
+
+	envValue := func(container corev1.Container, name string) string {
+		for _, env := range container.Env {
+			if env.Name == name {
+				return env.Value
+			}
+		}
+		return ""
+	}
+
+	It("Should do nothing and return nil when no DataExport exists", func() {
+		reconciler := createDataExportReconciler()
+		_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: dexName, Namespace: metav1.NamespaceDefault}})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("Should create an exporter pod mounting the PVC read only", func() {
+		dex := createRegistryDataExport()
+		dex.Spec.Compress = true
+		pvc := CreatePvcInStorageClass(dexPVCName, metav1.NamespaceDefault, nil, map[string]string{AnnStorageFormat: "qcow2"}, nil, corev1.ClaimBound)
+		reconciler := createDataExportReconciler(dex, pvc)
+		dex = reconcileDataExport(reconciler)
+		Expect(dex.Status.Phase).To(Equal(cdiv1.DataExportInProgress))
+
+		pod, err := getExporterPod(reconciler)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(metav1.IsControlledBy(pod, dex)).To(BeTrue())
+		Expect(pod.Labels).To(HaveKeyWithValue(common.CDIComponentLabel, common.ExporterPodName))
+		Expect(pod.Spec.RestartPolicy).To(Equal(corev1.RestartPolicyNever))
+		Expect(pod.Spec.Volumes[0].PersistentVolumeClaim.ClaimName).To(Equal(dexPVCName))
+		Expect(pod.Spec.Volumes[0].PersistentVolumeClaim.ReadOnly).To(BeTrue())
+		container := pod.Spec.Containers[0]
+		Expect(container.Image).To(Equal(dexImage))
+		Expect(container.Command[0]).To(Equal("/usr/bin/cdi-exporter"))
+		Expect(container.VolumeMounts).To(ContainElement(corev1.VolumeMount{Name: DataVolName, MountPath: common.ImporterDataDir, ReadOnly: true}))
+		Expect(container.VolumeMounts).To(ContainElement(corev1.VolumeMount{Name: ScratchVolName, MountPath: common.ScratchDataDir}))
+		Expect(envValue(container, common.ExporterSourcePath)).To(Equal(common.ImporterWritePath))
+		Expect(envValue(container, common.ExporterSourceFormat)).To(Equal("qcow2"))
+		Expect(envValue(container, common.ExporterTarget)).To(Equal("registry"))
+		Expect(envValue(container, common.ExporterEndpoint)).To(Equal(dexRegistry))
+		Expect(envValue(container, common.ExporterCompress)).To(Equal("true"))
+	})
+
+	It("Should export block PVCs from their device", func() {
+		pvc := CreatePvcInStorageClass(dexPVCName, metav1.NamespaceDefault, nil, nil, nil, corev1.ClaimBound)
+		pvc.Spec.VolumeMode = ptr.To(corev1.PersistentVolumeBlock)
+		reconciler := createDataExportReconciler(createRegistryDataExport(), pvc)
+		reconcileDataExport(reconciler)
+
+		pod, err := getExporterPod(reconciler)
+		Expect(err).ToNot(HaveOccurred())
+		container := pod.Spec.Containers[0]
+		Expect(container.VolumeDevices).To(Equal(AddVolumeDevices()))
+		Expect(envValue(container, common.ExporterSourcePath)).To(Equal(common.WriteBlockPath))
+	})
+
+	It("Should pass the credentials and the certificates of the target", func() {
+		dex := createRegistryDataExport()
+		dex.Spec.Target = cdiv1.DataExportTarget{
+			S3: &cdiv1.DataExportTargetS3{
+				URL:           "https://s3.example.com/bucket/fedora.qcow2",
+				SecretRef:     "s3-secret",
+				CertConfigMap: "s3-certs",
+			},
+		}
+		pvc := CreatePvcInStorageClass(dexPVCName, metav1.NamespaceDefault, nil, nil, nil, corev1.ClaimBound)
+		reconciler := createDataExportReconciler(dex, pvc)
+		reconcileDataExport(reconciler)
+
+		pod, err := getExporterPod(reconciler)
+		Expect(err).ToNot(HaveOccurred())
+		container := pod.Spec.Containers[0]
+		Expect(envValue(container, common.ExporterTarget)).To(Equal("s3"))
+		Expect(envValue(container, common.ExporterCertDirVar)).To(Equal(common.ImporterCertDir))
+		Expect(container.Env).To(ContainElement(corev1.EnvVar{
+			Name: common.ExporterAccessKeyID,
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "s3-secret"},
+					Key:                  common.KeyAccess,
+				},
+			},
+		}))
+		Expect(container.VolumeMounts).To(ContainElement(corev1.VolumeMount{Name: CertVolName, MountPath: common.ImporterCertDir}))
+		Expect(pod.Spec.Volumes[2].ConfigMap.Name).To(Equal("s3-certs"))
+	})
+
+	It("Should wait for the DataVolume source to succeed", func() {
+		dex := createRegistryDataExport()
+		dex.Spec.Source = cdiv1.DataExportSource{Kind: "DataVolume", Name: dexPVCName}
+		dv := NewImportDataVolume(dexPVCName)
+		dv.Status.Phase = cdiv1.ImportInProgress
+		pvc := CreatePvcInStorageClass(dexPVCName, metav1.NamespaceDefault, nil, nil, nil, corev1.ClaimBound)
+		reconciler := createDataExportReconciler(dex, dv, pvc)
+		dex = reconcileDataExport(reconciler)
+		Expect(dex.Status.Phase).To(Equal(cdiv1.DataExportPending))
+		Expect(dex.Status.Message).To(Equal("Waiting for DataVolume test-pvc to succeed"))
+		_, err := getExporterPod(reconciler)
+		Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+
+		dv.Status.Phase = cdiv1.Succeeded
+		Expect(reconciler.client.Update(context.TODO(), dv)).To(Succeed())
+		dex = reconcileDataExport(reconciler)
+		Expect(dex.Status.Phase).To(Equal(cdiv1.DataExportInProgress))
+		Expect(dex.Status.Message).To(BeEmpty())
+	})
+
+	It("Should fail if several targets are set", func() {
+		dex := createRegistryDataExport()
+		dex.Spec.Target.HTTP = &cdiv1.DataExportTargetHTTP{URL: "https://example.com/fedora.qcow2"}
+		reconciler := createDataExportReconciler(dex)
+		dex = reconcileDataExport(reconciler)
+		Expect(dex.Status.Phase).To(Equal(cdiv1.DataExportFailed))
+		Expect(dex.Status.Message).To(Equal("exactly one of the http, s3 and registry targets must be set"))
+	})
+
+	It("Should report the digest and delete the pod once the export succeeds", func() {
+		pvc := CreatePvcInStorageClass(dexPVCName, metav1.NamespaceDefault, nil, nil, nil, corev1.ClaimBound)
+		reconciler := createDataExportReconciler(createRegistryDataExport(), pvc)
+		reconcileDataExport(reconciler)
+
+		setExporterPodStatus(reconciler, corev1.PodSucceeded, "sha256:1234")
+		dex := reconcileDataExport(reconciler)
+		Expect(dex.Status.Phase).To(Equal(cdiv1.DataExportSucceeded))
+		Expect(dex.Status.Digest).To(Equal("sha256:1234"))
+		_, err := getExporterPod(reconciler)
+		Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+
+		// The export is not run again
+		dex = reconcileDataExport(reconciler)
+		Expect(dex.Status.Phase).To(Equal(cdiv1.DataExportSucceeded))
+		_, err = getExporterPod(reconciler)
+		Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("Should report the error and delete the pod once the export fails", func() {
+		pvc := CreatePvcInStorageClass(dexPVCName, metav1.NamespaceDefault, nil, nil, nil, corev1.ClaimBound)
+		reconciler := createDataExportReconciler(createRegistryDataExport(), pvc)
+		reconcileDataExport(reconciler)
+
+		setExporterPodStatus(reconciler, corev1.PodFailed, "Unable to export: unauthorized")
+		dex := reconcileDataExport(reconciler)
+		Expect(dex.Status.Phase).To(Equal(cdiv1.DataExportFailed))
+		Expect(dex.Status.Message).To(Equal("Unable to export: unauthorized"))
+		Expect(dex.Status.Digest).To(BeEmpty())
+		_, err := getExporterPod(reconciler)
+		Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("Should fail if the exporter pod name is taken", func() {
+		pvc := CreatePvcInStorageClass(dexPVCName, metav1.NamespaceDefault, nil, nil, nil, corev1.ClaimBound)
+		pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: dexPodName, Namespace: metav1.NamespaceDefault}}
+		reconciler := createDataExportReconciler(createRegistryDataExport(), pvc, pod)
+		dex := reconcileDataExport(reconciler)
+		Expect(dex.Status.Phase).To(Equal(cdiv1.DataExportFailed))
+		Expect(dex.Status.Message).To(ContainSubstring("is not owned by the DataExport"))
+		_, err := getExporterPod(reconciler)
+		Expect(err).ToNot(HaveOccurred())
+	})
+})
+
+func createDataExportReconciler(objects ...runtime.Object) *DataExportReconciler {
+	s := scheme.Scheme
+	_ = cdiv1.AddToScheme(s)
+	objs := append([]runtime.Object{MakeEmptyCDICR(), MakeEmptyCDIConfigSpec(common.ConfigName)}, objects...)
+	cl := fake.NewClientBuilder().WithScheme(s).WithRuntimeObjects(objs...).Build()
+	r := &DataExportReconciler{
+		client:     cl,
+		recorder:   record.NewFakeRecorder(10),
+		scheme:     s,
+		log:        cronLog,
+		image:      dexImage,
+		pullPolicy: string(corev1.PullIfNotPresent),
+	}
+	return r
+}
+
+func createRegistryDataExport() *cdiv1.DataExport {
+	return &cdiv1.DataExport{
+		TypeMeta: metav1.TypeMeta{APIVersion: cdiv1.SchemeGroupVersion.String(), Kind: "DataExport"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      dexName,
+			Namespace: metav1.NamespaceDefault,
+			UID:       "dex-uid",
+		},
+		Spec: cdiv1.DataExportSpec{
+			Source: cdiv1.DataExportSource{Kind: "PersistentVolumeClaim", Name: dexPVCName},
+			Target: cdiv1.DataExportTarget{
+				Registry: &cdiv1.DataExportTargetRegistry{URL: dexRegistry},
+			},
+		},
+	}
+}
@@ -372,7 +372,7 @@ func (r *DataImportCronReconciler) update(ctx context.Context, dataImportCron *c
 		}
 		cc.AddAnnotation(dataImportCron, AnnStorageClass, desiredStorageClass.Name)
 	}
-	format, err := r.getSourceFormat(ctx, desiredStorageClass)
+	format, err := r.getSourceFormat(ctx, dataImportCron, desiredStorageClass)
 	if err != nil {
 		return res, err
 	}
@@ -415,7 +415,7 @@ func (r *DataImportCronReconciler) update(ctx context.Context, dataImportCron *c
 			return res, err
 		}
 	case snapshot != nil:
-		if format == cdiv1.DataImportCronSourceFormatPvc {
+		if format != cdiv1.DataImportCronSourceFormatSnapshot {
 			if err := r.client.Delete(ctx, snapshot); cc.IgnoreNotFound(err) != nil {
 				return res, err
 			}
@@ -488,7 +488,7 @@ func (r *DataImportCronReconciler) update(ctx context.Context, dataImportCron *c
 			}
 		}
 		if importSucceeded || len(imports) == 0 {
-			if err := r.createImportDataVolume(ctx, dataImportCron); err != nil {
+			if err := r.createImportDataVolume(ctx, dataImportCron, format); err != nil {
 				return res, err
 			}
 		}
@@ -589,7 +589,7 @@ func (r *DataImportCronReconciler) deleteErroneousDataVolume(ctx context.Context
 	log := r.log.WithValues("name", dv.Name).WithValues("uid", dv.UID)
 	if cond := dvc.FindConditionByType(cdiv1.DataVolumeRunning, dv.Status.Conditions); cond != nil {
 		if cond.Status == corev1.ConditionFalse &&
-			(cond.Reason == common.GenericError || cond.Reason == ImagePullFailedReason) {
+			(cond.Reason == common.GenericError || cond.Reason == ImagePullFailedReason || isImportFailureReason(cond.Reason)) {
 			log.Info("Delete DataVolume and reset DesiredDigest due to error", "message", cond.Message)
 			// Unlabel the DV before deleting it, to eliminate reconcile before DIC is updated
 			dv.Labels[common.DataImportCronLabel] = ""
@@ -811,7 +811,7 @@ func populateDataSource(format cdiv1.DataImportCronSourceFormat, dataSource *cdi
 	}
 
 	switch format {
-	case cdiv1.DataImportCronSourceFormatPvc:
+	case cdiv1.DataImportCronSourceFormatPvc, cdiv1.DataImportCronSourceFormatPvcQcow2:
 		dataSource.Spec.Source = cdiv1.DataSourceSource{
 			PVC: sourcePVC,
 		}
@@ -856,7 +856,7 @@ func updateLastExecutionTimestamp(cron *cdiv1.DataImportCron) error {
 	return nil
 }
 
-func (r *DataImportCronReconciler) createImportDataVolume(ctx context.Context, dataImportCron *cdiv1.DataImportCron) error {
+func (r *DataImportCronReconciler) createImportDataVolume(ctx context.Context, dataImportCron *cdiv1.DataImportCron, format cdiv1.DataImportCronSourceFormat) error {
 	dataSourceName := dataImportCron.Spec.ManagedDataSource
 	digest := dataImportCron.Annotations[AnnSourceDesiredDigest]
 	if digest == "" {
@@ -884,6 +884,9 @@ func (r *DataImportCronReconciler) createImportDataVolume(ctx context.Context, d
 	}
 
 	dv := r.newSourceDataVolume(dataImportCron, dvName)
+	if format == cdiv1.DataImportCronSourceFormatPvcQcow2 {
+		setQcow2StorageFormat(dv)
+	}
 	if err := r.client.Create(ctx, dv); err != nil && !k8serrors.IsAlreadyExists(err) {
 		return err
 	}
@@ -925,7 +928,7 @@ func (r *DataImportCronReconciler) handleStorageClassChange(ctx context.Context,
 
 func (r *DataImportCronReconciler) handleCronFormat(ctx context.Context, dataImportCron *cdiv1.DataImportCron, pvc *corev1.PersistentVolumeClaim, format cdiv1.DataImportCronSourceFormat, desiredStorageClass *storagev1.StorageClass) error {
 	switch format {
-	case cdiv1.DataImportCronSourceFormatPvc:
+	case cdiv1.DataImportCronSourceFormatPvc, cdiv1.DataImportCronSourceFormatPvcQcow2:
 		return nil
 	case cdiv1.DataImportCronSourceFormatSnapshot:
 		return r.handleSnapshot(ctx, dataImportCron, pvc, desiredStorageClass)
@@ -998,7 +1001,7 @@ func (r *DataImportCronReconciler) updateDataImportCronSuccessCondition(dataImpo
 	dataImportCron.Status.SourceFormat = &format
 
 	switch format {
-	case cdiv1.DataImportCronSourceFormatPvc:
+	case cdiv1.DataImportCronSourceFormatPvc, cdiv1.DataImportCronSourceFormatPvcQcow2:
 		updateDataImportCronCondition(dataImportCron, cdiv1.DataImportCronUpToDate, corev1.ConditionTrue, "Latest import is up to date", upToDate)
 	case cdiv1.DataImportCronSourceFormatSnapshot:
 		if snapshot == nil {
@@ -1017,7 +1020,10 @@ func (r *DataImportCronReconciler) updateDataImportCronSuccessCondition(dataImpo
 	return nil
 }
 
-func (r *DataImportCronReconciler) getSourceFormat(ctx context.Context, desiredStorageClass *storagev1.StorageClass) (cdiv1.DataImportCronSourceFormat, error) {
+func (r *DataImportCronReconciler) getSourceFormat(ctx context.Context, cron *cdiv1.DataImportCron, desiredStorageClass *storagev1.StorageClass) (cdiv1.DataImportCronSourceFormat, error) {
+	if cron.Spec.SourceFormat != nil {
+		return *cron.Spec.SourceFormat, nil
+	}
 	format := cdiv1.DataImportCronSourceFormatPvc
 	if desiredStorageClass == nil {
 		return format, nil
@@ -1049,27 +1055,30 @@ func (r *DataImportCronReconciler) garbageCollectOldImports(ctx context.Context,
 		maxImports = int(*cron.Spec.ImportsToKeep)
 	}
 
-	if err := r.garbageCollectPVCs(ctx, cron.Namespace, cron.Name, selector, maxImports); err != nil {
+	if err := r.garbageCollectPVCs(ctx, cron.Namespace, cron.Name, selector, maxImports, cron.Spec.MaxAge); err != nil {
 		return err
 	}
-	if err := r.garbageCollectSnapshots(ctx, cron.Namespace, selector, maxImports); err != nil {
+	if err := r.garbageCollectSnapshots(ctx, cron.Namespace, selector, maxImports, cron.Spec.MaxAge); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func (r *DataImportCronReconciler) garbageCollectPVCs(ctx context.Context, namespace, cronName string, selector labels.Selector, maxImports int) error {
+func (r *DataImportCronReconciler) garbageCollectPVCs(ctx context.Context, namespace, cronName string, selector labels.Selector, maxImports int, maxAge *metav1.Duration) error {
 	pvcList := &corev1.PersistentVolumeClaimList{}
 
 	if err := r.client.List(ctx, pvcList, &client.ListOptions{Namespace: namespace, LabelSelector: selector}); err != nil {
 		return err
 	}
-	if len(pvcList.Items) > maxImports {
+	if len(pvcList.Items) > maxImports || maxAge != nil {
 		sort.Slice(pvcList.Items, func(i, j int) bool {
 			return pvcList.Items[i].Annotations[AnnLastUseTime] > pvcList.Items[j].Annotations[AnnLastUseTime]
 		})
-		for _, pvc := range pvcList.Items[maxImports:] {
+		for i, pvc := range pvcList.Items {
+			if !isImportOutdated(i, maxImports, pvc.CreationTimestamp, maxAge) {
+				continue
+			}
 			r.log.Info("Deleting dv/pvc", "name", pvc.Name, "pvc.uid", pvc.UID)
 			if err := r.deleteDvPvc(ctx, pvc.Name, pvc.Namespace); err != nil {
 				return err
@@ -1115,7 +1124,7 @@ func (r *DataImportCronReconciler) deleteDvPvc(ctx context.Context, name, namesp
 	return nil
 }
 
-func (r *DataImportCronReconciler) garbageCollectSnapshots(ctx context.Context, namespace string, selector labels.Selector, maxImports int) error {
+func (r *DataImportCronReconciler) garbageCollectSnapshots(ctx context.Context, namespace string, selector labels.Selector, maxImports int, maxAge *metav1.Duration) error {
 	snapList := &snapshotv1.VolumeSnapshotList{}
 
 	if err := r.client.List(ctx, snapList, &client.ListOptions{Namespace: namespace, LabelSelector: selector}); err != nil {
@@ -1124,11 +1133,14 @@ func (r *DataImportCronReconciler) garbageCollectSnapshots(ctx context.Context,
 		}
 		return err
 	}
-	if len(snapList.Items) > maxImports {
+	if len(snapList.Items) > maxImports || maxAge != nil {
 		sort.Slice(snapList.Items, func(i, j int) bool {
 			return snapList.Items[i].Annotations[AnnLastUseTime] > snapList.Items[j].Annotations[AnnLastUseTime]
 		})
-		for _, snap := range snapList.Items[maxImports:] {
+		for i, snap := range snapList.Items {
+			if !isImportOutdated(i, maxImports, snap.CreationTimestamp, maxAge) {
+				continue
+			}
 			r.log.Info("Deleting snapshot", "name", snap.Name, "uid", snap.UID)
 			if err := r.client.Delete(ctx, &snap); err != nil && !k8serrors.IsNotFound(err) {
 				return err
@@ -1139,6 +1151,15 @@ func (r *DataImportCronReconciler) garbageCollectSnapshots(ctx context.Context,
 	return nil
 }
 
+// isImportOutdated returns whether the import at index i of the ones sorted by last use, most recent first, is
+// garbage collected. The most recent import is kept regardless of its age.
+func isImportOutdated(i, maxImports int, created metav1.Time, maxAge *metav1.Duration) bool {
+	if i >= maxImports {
+		return true
+	}
+	return i > 0 && maxAge != nil && time.Since(created.Time) > maxAge.Duration
+}
+
 func (r *DataImportCronReconciler) cleanup(ctx context.Context, cron types.NamespacedName) error {
 	// Don't keep alerting over a cron thats being deleted, will get set back to 1 again by reconcile loop if needed.
 	metrics.DeleteDataImportCronOutdated(getPrometheusCronLabels(cron.Namespace, cron.Name))
@@ -1496,6 +1517,15 @@ func InitPollerPodSpec(c client.Client, cron *cdiv1.DataImportCron, podSpec *cor
 		volumes = append(volumes, createConfigMapVolume(CertVolName, *regSource.CertConfigMap))
 	}
 
+	if regSource.ClientCertSecretRef != nil && *regSource.ClientCertSecretRef != "" {
+		vm := corev1.VolumeMount{
+			Name:      clientCertVolumeName,
+			MountPath: common.ImporterClientCertDir,
+		}
+		container.VolumeMounts = append(container.VolumeMounts, vm)
+		volumes = append(volumes, createSecretVolume(clientCertVolumeName, *regSource.ClientCertSecretRef))
+	}
+
 	if volName, _ := GetImportProxyConfig(cdiConfig, common.ImportProxyConfigMapName); volName != "" {
 		vm := corev1.VolumeMount{
 			Name:      ProxyCertVolName,
@@ -1648,6 +1678,7 @@ func (r *DataImportCronReconciler) newSourceDataVolume(cron *cdiv1.DataImportCro
 	cc.AddAnnotation(dv, cc.AnnImmediateBinding, "true")
 	cc.AddAnnotation(dv, AnnLastUseTime, time.Now().UTC().Format(time.RFC3339Nano))
 	passCronAnnotationToDv(cron, dv, cc.AnnPodRetainAfterCompletion)
+	passCronAnnotationToDv(cron, dv, cc.AnnMaxBandwidth)
 
 	for _, defaultInstanceTypeLabel := range cc.DefaultInstanceTypeLabels {
 		passCronLabelToDv(cron, dv, defaultInstanceTypeLabel)
@@ -1683,6 +1714,18 @@ func untagDigestedDockerURL(dockerURL string) string {
 	return dockerURL
 }
 
+// setQcow2StorageFormat makes the import DataVolume keep its image as compressed qcow2, which requires a filesystem volume
+func setQcow2StorageFormat(dv *cdiv1.DataVolume) {
+	cc.AddAnnotation(dv, cc.AnnStorageFormat, common.StorageFormatQcow2)
+	filesystem := corev1.PersistentVolumeFilesystem
+	if dv.Spec.Storage != nil {
+		dv.Spec.Storage.VolumeMode = &filesystem
+	}
+	if dv.Spec.PVC != nil {
+		dv.Spec.PVC.VolumeMode = &filesystem
+	}
+}
+
 func passCronLabelToDv(cron *cdiv1.DataImportCron, dv *cdiv1.DataVolume, ann string) {
 	if val := cron.Labels[ann]; val != "" {
 		cc.AddLabel(dv, ann, val)
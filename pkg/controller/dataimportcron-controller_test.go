@@ -269,6 +269,24 @@ var _ = Describe("All DataImportCron Tests", func() {
 			Expect(err).To(HaveOccurred())
 		})
 
+		It("Should mount the client certificate of the registry in the poller", func() {
+			cron = newDataImportCron(cronName)
+			cron.Spec.Template.Spec.Source.Registry.ClientCertSecretRef = ptr.To("client-cert")
+			reconciler = createDataImportCronReconciler(cron)
+			_, err := reconciler.Reconcile(context.TODO(), cronReq)
+			Expect(err).ToNot(HaveOccurred())
+
+			cronjob := &batchv1.CronJob{}
+			err = reconciler.client.Get(context.TODO(), cronJobKey(cron), cronjob)
+			Expect(err).ToNot(HaveOccurred())
+			podSpec := cronjob.Spec.JobTemplate.Spec.Template.Spec
+			Expect(podSpec.Containers[0].VolumeMounts).To(ContainElement(corev1.VolumeMount{
+				Name:      clientCertVolumeName,
+				MountPath: common.ImporterClientCertDir,
+			}))
+			Expect(podSpec.Volumes).To(ContainElement(createSecretVolume(clientCertVolumeName, "client-cert")))
+		})
+
 		It("Should delete DataImportCron-orphan CronJob", func() {
 			reconciler = createDataImportCronReconciler()
 
@@ -982,6 +1000,70 @@ var _ = Describe("All DataImportCron Tests", func() {
 			Expect(err).ToNot(HaveOccurred())
 		})
 
+		It("Should garbage collect the imports older than maxAge, keeping the most recent one", func() {
+			cron = newDataImportCron(cronName)
+			cron.Spec.ImportsToKeep = ptr.To[int32](3)
+			cron.Spec.MaxAge = &metav1.Duration{Duration: time.Hour}
+			now := time.Now()
+			importPvc := func(name string, created, lastUse time.Time) *corev1.PersistentVolumeClaim {
+				pvc := cc.CreatePvc(name, metav1.NamespaceDefault, map[string]string{AnnLastUseTime: lastUse.UTC().Format(time.RFC3339Nano)}, nil)
+				pvc.Labels = map[string]string{common.DataImportCronLabel: cronName}
+				pvc.CreationTimestamp = metav1.NewTime(created)
+				return pvc
+			}
+			latest := importPvc("latest", now.Add(-2*time.Hour), now)
+			young := importPvc("young", now.Add(-10*time.Minute), now.Add(-time.Minute))
+			old := importPvc("old", now.Add(-2*time.Hour), now.Add(-2*time.Minute))
+			reconciler = createDataImportCronReconciler(cron, latest, young, old)
+
+			err := reconciler.garbageCollectOldImports(context.TODO(), cron)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(reconciler.client.Get(context.TODO(), dvKey(latest.Name), latest)).To(Succeed())
+			Expect(reconciler.client.Get(context.TODO(), dvKey(young.Name), young)).To(Succeed())
+			err = reconciler.client.Get(context.TODO(), dvKey(old.Name), old)
+			Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+		})
+
+		It("Should use the source format of the cron over the one of the storage profile", func() {
+			sc := cc.CreateStorageClass(storageClassName, map[string]string{cc.AnnDefaultStorageClass: "true"})
+			sp := &cdiv1.StorageProfile{
+				ObjectMeta: metav1.ObjectMeta{Name: storageClassName},
+				Status:     cdiv1.StorageProfileStatus{DataImportCronSourceFormat: ptr.To(cdiv1.DataImportCronSourceFormatSnapshot)},
+			}
+			cron = newDataImportCron(cronName)
+			reconciler = createDataImportCronReconciler(cron, sc, sp)
+			format, err := reconciler.getSourceFormat(context.TODO(), cron, sc)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(format).To(Equal(cdiv1.DataImportCronSourceFormatSnapshot))
+
+			cron.Spec.SourceFormat = ptr.To(cdiv1.DataImportCronSourceFormatPvcQcow2)
+			format, err = reconciler.getSourceFormat(context.TODO(), cron, sc)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(format).To(Equal(cdiv1.DataImportCronSourceFormatPvcQcow2))
+		})
+
+		It("Should import to a filesystem volume as qcow2 with the pvc-qcow2 source format", func() {
+			cron = newDataImportCron(cronName)
+			cron.Annotations[AnnSourceDesiredDigest] = testDigest
+			cron.Spec.Template.Spec.Storage.VolumeMode = ptr.To(corev1.PersistentVolumeBlock)
+			reconciler = createDataImportCronReconciler(cron)
+
+			err := reconciler.createImportDataVolume(context.TODO(), cron, cdiv1.DataImportCronSourceFormatPvcQcow2)
+			Expect(err).ToNot(HaveOccurred())
+			dv := &cdiv1.DataVolume{}
+			Expect(reconciler.client.Get(context.TODO(), dvKey(cron.Status.CurrentImports[0].DataVolumeName), dv)).To(Succeed())
+			Expect(dv.Annotations[cc.AnnStorageFormat]).To(Equal(common.StorageFormatQcow2))
+			Expect(*dv.Spec.Storage.VolumeMode).To(Equal(corev1.PersistentVolumeFilesystem))
+		})
+
+		It("Should point the DataSource to the PVC with the pvc-qcow2 source format", func() {
+			dataSource := &cdiv1.DataSource{}
+			populateDataSource(cdiv1.DataImportCronSourceFormatPvcQcow2, dataSource, &cdiv1.DataVolumeSourcePVC{Namespace: "ns", Name: "import"})
+			Expect(dataSource.Spec.Source.PVC).To(Equal(&cdiv1.DataVolumeSourcePVC{Namespace: "ns", Name: "import"}))
+			Expect(dataSource.Spec.Source.Snapshot).To(BeNil())
+		})
+
 		It("should pass through metadata to DataVolume", func() {
 			cron = newDataImportCron(cronName)
 			cron.Annotations[AnnSourceDesiredDigest] = testDigest
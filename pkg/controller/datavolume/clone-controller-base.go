@@ -18,9 +18,11 @@ package datavolume
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"reflect"
 
+	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
 
 	corev1 "k8s.io/api/core/v1"
@@ -178,7 +180,7 @@ func (r *CloneReconcilerBase) addVolumeCloneSourceWatch(mgr manager.Manager, dat
 				r.log.Info("Failed to get DataVolume", "error", err)
 				return nil
 			}
-			if err := r.populateSourceIfSourceRef(dv); err != nil {
+			if err := populateSourceIfSourceRef(r.client, dv); err != nil {
 				r.log.Info("Failed to check DataSource", "error", err)
 				return nil
 			}
@@ -254,6 +256,13 @@ func (r *CloneReconcilerBase) fallbackToHostAssisted(pvc *corev1.PersistentVolum
 
 	cc.AddAnnotation(pvcCpy, cc.AnnCloneType, string(cdiv1.CloneStrategyHostAssisted))
 	cc.AddAnnotation(pvcCpy, populators.AnnCloneFallbackReason, NoPopulatorMessage)
+	if pvcCpy.Annotations[populators.AnnCloneStrategyFallbacks] == "" {
+		fallbacks, err := json.Marshal([]cdiv1.CloneStrategyFallback{{Reason: NoPopulator, Message: NoPopulatorMessage}})
+		if err != nil {
+			return err
+		}
+		cc.AddAnnotation(pvcCpy, populators.AnnCloneStrategyFallbacks, string(fallbacks))
+	}
 
 	if !reflect.DeepEqual(pvc, pvcCpy) {
 		r.recorder.Event(pvcCpy, corev1.EventTypeWarning, NoPopulator, NoPopulatorMessage)
@@ -265,6 +274,22 @@ func (r *CloneReconcilerBase) fallbackToHostAssisted(pvc *corev1.PersistentVolum
 	return nil
 }
 
+// cloneStrategyStatus returns the strategy of the clone populating the PVC and the strategies it fell back from, nil if
+// the PVC is not populated by a clone
+func cloneStrategyStatus(log logr.Logger, pvc *corev1.PersistentVolumeClaim) *cdiv1.DataVolumeCloneStrategyStatus {
+	strategy := pvc.Annotations[cc.AnnCloneType]
+	if strategy == "" {
+		return nil
+	}
+	status := &cdiv1.DataVolumeCloneStrategyStatus{Strategy: cdiv1.CDICloneStrategy(strategy)}
+	if fallbacks := pvc.Annotations[populators.AnnCloneStrategyFallbacks]; fallbacks != "" {
+		if err := json.Unmarshal([]byte(fallbacks), &status.Fallbacks); err != nil {
+			log.Error(err, "Invalid clone strategy fallbacks annotation", "pvc", pvc.Name)
+		}
+	}
+	return status
+}
+
 func (r *CloneReconcilerBase) ensureExtendedTokenPVC(dv *cdiv1.DataVolume, pvc *corev1.PersistentVolumeClaim) error {
 	if !isCrossNamespaceClone(dv) {
 		return nil
@@ -352,6 +377,17 @@ func (r *CloneReconcilerBase) reconcileVolumeCloneSourceCR(syncState *dvSyncStat
 		volumeCloneSource.Spec.PriorityClassName = &dv.Spec.PriorityClassName
 	}
 
+	if dv.Spec.CloneTransferMode != nil {
+		volumeCloneSource.Spec.TransferMode = dv.Spec.CloneTransferMode
+	}
+	if dv.Spec.CloneCompression != nil {
+		volumeCloneSource.Spec.Compression = dv.Spec.CloneCompression
+	}
+	volumeCloneSource.Spec.Strategy = dv.Spec.CloneStrategy
+	volumeCloneSource.Spec.StrategyFallbacks = dv.Spec.CloneStrategyFallbacks
+	volumeCloneSource.Spec.Format = dv.Spec.CloneFormat
+	volumeCloneSource.Spec.Group = dv.Spec.CloneGroup
+
 	if sourceNamespace == dv.Namespace {
 		if err := controllerutil.SetControllerReference(dv, volumeCloneSource, r.scheme); err != nil {
 			return err
@@ -448,7 +484,7 @@ func (r *CloneReconcilerBase) updateStatusPhaseForPopulator(pvc *corev1.Persiste
 }
 
 func (r *CloneReconcilerBase) updateStatusPhase(pvc *corev1.PersistentVolumeClaim, dataVolumeCopy *cdiv1.DataVolume, event *Event) error {
-	if err := r.populateSourceIfSourceRef(dataVolumeCopy); err != nil {
+	if err := populateSourceIfSourceRef(r.client, dataVolumeCopy); err != nil {
 		return err
 	}
 	_, sourceName, sourceNamespace := cc.GetCloneSourceInfo(dataVolumeCopy)
@@ -504,7 +540,7 @@ func (r *CloneReconcilerBase) updateStatusPhase(pvc *corev1.PersistentVolumeClai
 
 // If SourceRef is set, populate spec.Source with data from the DataSource
 // Note that when the controller actually updates the DV (updateDataVolume), we nil out spec.Source when SourceRef is set
-func (r *CloneReconcilerBase) populateSourceIfSourceRef(dv *cdiv1.DataVolume) error {
+func populateSourceIfSourceRef(c client.Client, dv *cdiv1.DataVolume) error {
 	if dv.Spec.SourceRef == nil {
 		return nil
 	}
@@ -516,7 +552,7 @@ func (r *CloneReconcilerBase) populateSourceIfSourceRef(dv *cdiv1.DataVolume) er
 		ns = *dv.Spec.SourceRef.Namespace
 	}
 	dataSource := &cdiv1.DataSource{}
-	if err := r.client.Get(context.TODO(), types.NamespacedName{Name: dv.Spec.SourceRef.Name, Namespace: ns}, dataSource); err != nil {
+	if err := c.Get(context.TODO(), types.NamespacedName{Name: dv.Spec.SourceRef.Name, Namespace: ns}, dataSource); err != nil {
 		return err
 	}
 	if dataSource.Spec.Source.DataSource != nil {
@@ -18,7 +18,6 @@ package datavolume
 
 import (
 	"context"
-	"crypto/rsa"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -29,7 +28,9 @@ import (
 	"github.com/go-logr/logr"
 	snapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v6/apis/volumesnapshot/v1"
 	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
 
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	storagev1 "k8s.io/api/storage/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
@@ -66,6 +67,8 @@ const (
 	ErrResourceMarkedForDeletion = "ErrResourceMarkedForDeletion"
 	// ErrClaimLost provides a const to indicate a claim is lost
 	ErrClaimLost = "ErrClaimLost"
+	// PopulationProgress provides a const to indicate the progress of a DataVolume being populated
+	PopulationProgress = "PopulationProgress"
 
 	// MessageResourceMarkedForDeletion provides a const to form a resource marked for deletion error message
 	MessageResourceMarkedForDeletion = "Resource %q marked for deletion"
@@ -73,12 +76,16 @@ const (
 	MessageResourceExists = "Resource %q already exists and is not managed by DataVolume"
 	// MessageErrClaimLost provides a const to form claim lost message
 	MessageErrClaimLost = "PVC %s lost"
+	// MessagePopulationProgress provides a const to form the progress summary message of a DataVolume
+	MessagePopulationProgress = "DataVolume %s is %s, progress %s"
 
 	dvPhaseField = "status.phase"
 
 	claimRefField = "spec.claimRef"
 
 	claimStorageClassNameField = "spec.storageClassName"
+
+	claimDeduplicationKeyField = "metadata.annotations.deduplicationKey"
 )
 
 var (
@@ -97,9 +104,10 @@ type Event struct {
 }
 
 type statusPhaseSync struct {
-	phase  cdiv1.DataVolumePhase
-	pvcKey *client.ObjectKey
-	event  Event
+	phase      cdiv1.DataVolumePhase
+	pvcKey     *client.ObjectKey
+	event      Event
+	validation *cdiv1.DataVolumeValidation
 }
 
 type dvSyncResult struct {
@@ -141,6 +149,12 @@ func dvIsPrePopulated(dv *cdiv1.DataVolume) bool {
 	return ok
 }
 
+// dvIsMigrating returns true while a DataVolumeMigration replaces the PVC of the DataVolume
+func dvIsMigrating(dv *cdiv1.DataVolume) bool {
+	_, ok := dv.Annotations[cc.AnnDataVolumeMigration]
+	return ok
+}
+
 func checkStaticProvisionPending(pvc *corev1.PersistentVolumeClaim, dv *cdiv1.DataVolume) bool {
 	if pvc == nil || dv == nil {
 		return false
@@ -208,6 +222,11 @@ func getIndexArgs() []indexArgs {
 			field:        claimStorageClassNameField,
 			extractValue: extractAvailablePersistentVolumeStorageClassName,
 		},
+		{
+			obj:          &corev1.PersistentVolumeClaim{},
+			field:        claimDeduplicationKeyField,
+			extractValue: extractDeduplicationKey,
+		},
 	}
 }
 
@@ -294,6 +313,17 @@ func addDataVolumeControllerCommonWatches(mgr manager.Manager, dataVolumeControl
 	)); err != nil {
 		return err
 	}
+	if err := dataVolumeController.Watch(source.Kind(mgr.GetCache(), &batchv1.Job{}, handler.TypedEnqueueRequestsFromMapFunc[*batchv1.Job](
+		func(ctx context.Context, obj *batchv1.Job) []reconcile.Request {
+			owner := metav1.GetControllerOf(obj)
+			if owner == nil || owner.Kind != "DataVolume" {
+				return nil
+			}
+			return appendMatchingDataVolumeRequest(ctx, nil, mgr, obj.GetNamespace(), owner.Name)
+		}),
+	)); err != nil {
+		return err
+	}
 	for _, k := range []client.Object{&corev1.PersistentVolumeClaim{}, &corev1.Pod{}, &cdiv1.ObjectTransfer{}} {
 		if err := dataVolumeController.Watch(source.Kind(mgr.GetCache(), k, handler.EnqueueRequestsFromMapFunc(
 			func(ctx context.Context, obj client.Object) []reconcile.Request {
@@ -385,7 +415,7 @@ func getDataVolumeOp(ctx context.Context, log logr.Logger, dv *cdiv1.DataVolume,
 	if src.Upload != nil {
 		return dataVolumeUpload
 	}
-	if src.HTTP != nil || src.S3 != nil || src.GCS != nil || src.Registry != nil || src.Blank != nil || src.Imageio != nil || src.VDDK != nil {
+	if src.HTTP != nil || src.S3 != nil || src.GCS != nil || src.Azure != nil || src.SFTP != nil || src.SMB != nil || src.Glance != nil || src.RBD != nil || src.ISCSI != nil || src.HostPath != nil || src.DataVolume != nil || src.Export != nil || src.V2V != nil || src.Proxmox != nil || src.Plugin != nil || src.Torrent != nil || src.Registry != nil || src.Blank != nil || src.Imageio != nil || src.VDDK != nil {
 		return dataVolumeImport
 	}
 
@@ -470,9 +500,51 @@ func (r *ReconcilerBase) reconcile(ctx context.Context, req reconcile.Request, d
 	if syncRes.result != nil {
 		res = *syncRes.result
 	}
+	if err == nil {
+		err = r.garbageCollect(log, req, &res)
+	}
 	return res, err
 }
 
+// garbageCollect deletes the DataVolume once it completed and its TTL went by, requeueing until then
+func (r *ReconcilerBase) garbageCollect(log logr.Logger, req reconcile.Request, res *reconcile.Result) error {
+	dv, err := r.getDataVolume(req.NamespacedName)
+	if dv == nil || err != nil {
+		return err
+	}
+	if dv.DeletionTimestamp != nil || dvIsMigrating(dv) || dv.Spec.TTLAfterCompletion == nil || dv.Status.CurrentPhaseStartTime == nil {
+		return nil
+	}
+	// DataVolumes owned by VMs, crons and the like are cleaned up by their owner
+	if metav1.GetControllerOf(dv) != nil {
+		return nil
+	}
+	switch dv.Status.Phase {
+	case cdiv1.Succeeded:
+	case cdiv1.Failed:
+		if dv.Spec.FailureRetentionPolicy == nil || *dv.Spec.FailureRetentionPolicy != cdiv1.FailureRetentionDelete ||
+			dv.Status.NextRetryTime != nil {
+			return nil
+		}
+	default:
+		return nil
+	}
+
+	remaining := time.Until(dv.Status.CurrentPhaseStartTime.Add(dv.Spec.TTLAfterCompletion.Duration))
+	if remaining > 0 {
+		if res.RequeueAfter == 0 || remaining < res.RequeueAfter {
+			res.RequeueAfter = remaining
+		}
+		return nil
+	}
+
+	log.Info("Deleting DataVolume, TTL after completion expired", "phase", dv.Status.Phase)
+	if err := r.client.Delete(context.TODO(), dv); err != nil && !k8serrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
 type dvSyncStateFunc func(*dvSyncState) error
 
 func (r *ReconcilerBase) syncCommon(log logr.Logger, req reconcile.Request, cleanup, prepare dvSyncStateFunc) (dvSyncState, error) {
@@ -509,6 +581,12 @@ func (r *ReconcilerBase) syncDvPvcState(log logr.Logger, req reconcile.Request,
 		return syncState, nil
 	}
 
+	if dvIsMigrating(dv) {
+		log.V(1).Info("Waiting for the PVC to be migrated", "migration", dv.Annotations[cc.AnnDataVolumeMigration])
+		syncState.result = &reconcile.Result{}
+		return syncState, nil
+	}
+
 	if prepare != nil {
 		if err := prepare(&syncState); err != nil {
 			return syncState, err
@@ -812,6 +890,13 @@ func (r *ReconcilerBase) reconcileProgressUpdate(datavolume *cdiv1.DataVolume, p
 		} else {
 			datavolume.Status.Progress = "N/A"
 		}
+		updateImportReport(datavolume, cc.GetImportReportFromAnnotations(pvc.Annotations))
+		updateCloneReport(datavolume, cc.GetCloneReportFromAnnotations(pvc.Annotations))
+		return nil
+	}
+
+	if datavolume.Spec.Source != nil && datavolume.Spec.Source.Upload != nil {
+		// The progress of uploads is only reported by the upload populator
 		return nil
 	}
 
@@ -822,6 +907,8 @@ func (r *ReconcilerBase) reconcileProgressUpdate(datavolume *cdiv1.DataVolume, p
 	}
 
 	if datavolume.Status.Phase == cdiv1.Succeeded || datavolume.Status.Phase == cdiv1.Failed {
+		datavolume.Status.ClonePhase = ""
+		datavolume.Status.Throughput = nil
 		// Data volume completed progress, or failed, either way stop queueing the data volume.
 		r.log.Info("Datavolume finished, no longer updating progress", "Namespace", datavolume.Namespace, "Name", datavolume.Name, "Phase", datavolume.Status.Phase)
 		return nil
@@ -853,7 +940,7 @@ func (r *ReconcilerBase) syncDataVolumeStatusPhaseWithEvent(syncState *dvSyncSta
 	return nil
 }
 
-func (r *ReconcilerBase) updateDataVolumeStatusPhaseSync(ps *statusPhaseSync, dv *cdiv1.DataVolume, dvCopy *cdiv1.DataVolume, pvc *corev1.PersistentVolumeClaim) error {
+func (r *ReconcilerBase) updateDataVolumeStatusPhaseSync(ps *statusPhaseSync, dv *cdiv1.DataVolume, dvCopy *cdiv1.DataVolume, pvc *corev1.PersistentVolumeClaim, result *reconcile.Result) error {
 	var condPvc *corev1.PersistentVolumeClaim
 	var err error
 	if ps.pvcKey != nil {
@@ -866,7 +953,10 @@ func (r *ReconcilerBase) updateDataVolumeStatusPhaseSync(ps *statusPhaseSync, dv
 			condPvc = pvc
 		}
 	}
-	return r.updateDataVolumeStatusPhaseWithEvent(ps.phase, dv, dvCopy, condPvc, ps.event)
+	if ps.validation != nil {
+		dvCopy.Status.Validation = ps.validation
+	}
+	return r.updateDataVolumeStatusPhaseWithEvent(ps.phase, dv, dvCopy, condPvc, ps.event, result)
 }
 
 func (r *ReconcilerBase) updateDataVolumeStatusPhaseWithEvent(
@@ -874,7 +964,8 @@ func (r *ReconcilerBase) updateDataVolumeStatusPhaseWithEvent(
 	dataVolume *cdiv1.DataVolume,
 	dataVolumeCopy *cdiv1.DataVolume,
 	pvc *corev1.PersistentVolumeClaim,
-	event Event) error {
+	event Event,
+	result *reconcile.Result) error {
 	if dataVolume == nil {
 		return nil
 	}
@@ -882,6 +973,10 @@ func (r *ReconcilerBase) updateDataVolumeStatusPhaseWithEvent(
 	curPhase := dataVolumeCopy.Status.Phase
 	dataVolumeCopy.Status.Phase = phase
 
+	if err := r.reconcilePostCompletionHooks(dataVolumeCopy, pvc, &event, result); err != nil {
+		return err
+	}
+
 	reason := ""
 	message := ""
 	if pvc == nil {
@@ -896,9 +991,17 @@ func (r *ReconcilerBase) updateStatus(req reconcile.Request, phaseSync *statusPh
 	result := reconcile.Result{}
 	dv, err := r.getDataVolume(req.NamespacedName)
 	if dv == nil || err != nil {
+		if dv == nil && err == nil {
+			metrics.DeleteDataVolumeCloneProgress(prometheus.Labels{metrics.PrometheusCloneNsLabel: req.Namespace, metrics.PrometheusCloneNameLabel: req.Name})
+		}
 		return reconcile.Result{}, err
 	}
 
+	// The DataVolumeMigration controller reports the status while the PVC is replaced
+	if dvIsMigrating(dv) {
+		return reconcile.Result{}, nil
+	}
+
 	dataVolumeCopy := dv.DeepCopy()
 
 	pvc, err := r.getPVC(req.NamespacedName)
@@ -907,8 +1010,8 @@ func (r *ReconcilerBase) updateStatus(req reconcile.Request, phaseSync *statusPh
 	}
 
 	if phaseSync != nil {
-		err = r.updateDataVolumeStatusPhaseSync(phaseSync, dv, dataVolumeCopy, pvc)
-		return reconcile.Result{}, err
+		err = r.updateDataVolumeStatusPhaseSync(phaseSync, dv, dataVolumeCopy, pvc, &result)
+		return result, err
 	}
 
 	curPhase := dataVolumeCopy.Status.Phase
@@ -971,13 +1074,32 @@ func (r *ReconcilerBase) updateStatus(req reconcile.Request, phaseSync *statusPh
 		if i, err := strconv.ParseInt(pvc.Annotations[cc.AnnPodRestarts], 10, 32); err == nil && i >= 0 {
 			dataVolumeCopy.Status.RestartCount = int32(i)
 		}
+		if i, err := strconv.ParseInt(pvc.Annotations[cc.AnnImportRetryCount], 10, 32); err == nil && i >= 0 {
+			dataVolumeCopy.Status.RetryCount = int32(i)
+		}
+		if digest := pvc.Annotations[cc.AnnObservedSourceDigest]; digest != "" {
+			dataVolumeCopy.Status.ObservedSourceDigest = digest
+		}
+		if artifacts := pvc.Annotations[cc.AnnFailureArtifacts]; artifacts != "" {
+			dataVolumeCopy.Status.FailureArtifacts = artifacts
+		}
+		dataVolumeCopy.Status.CloneStrategy = cloneStrategyStatus(r.log, pvc)
+		dataVolumeCopy.Status.NextRetryTime = nil
+		if next, err := time.Parse(time.RFC3339, pvc.Annotations[cc.AnnImportNextRetryTime]); err == nil {
+			dataVolumeCopy.Status.NextRetryTime = &metav1.Time{Time: next}
+		}
 		if err := r.reconcileProgressUpdate(dataVolumeCopy, pvc, &result); err != nil {
 			return result, err
 		}
 	}
 
+	updateCloneMetrics(dataVolumeCopy)
+
 	currentCond := make([]cdiv1.DataVolumeCondition, len(dataVolumeCopy.Status.Conditions))
 	copy(currentCond, dataVolumeCopy.Status.Conditions)
+	if err := r.reconcilePostCompletionHooks(dataVolumeCopy, pvc, &event, &result); err != nil {
+		return result, err
+	}
 	r.updateConditions(dataVolumeCopy, pvc, "", "")
 	return result, r.emitEvent(dv, dataVolumeCopy, curPhase, currentCond, &event)
 }
@@ -1079,6 +1201,10 @@ func (r *ReconcilerBase) emitEvent(dataVolume *cdiv1.DataVolume, dataVolumeCopy
 	if !reflect.DeepEqual(dataVolume.ObjectMeta, dataVolumeCopy.ObjectMeta) {
 		return fmt.Errorf("meta update is not allowed in updateStatus phase")
 	}
+	if curPhase != dataVolumeCopy.Status.Phase || dataVolumeCopy.Status.CurrentPhaseStartTime == nil {
+		now := metav1.Now()
+		dataVolumeCopy.Status.CurrentPhaseStartTime = &now
+	}
 	// Update status subresource only if changed
 	if !reflect.DeepEqual(dataVolume.Status, dataVolumeCopy.Status) {
 		if err := r.client.Status().Update(context.TODO(), dataVolumeCopy); err != nil {
@@ -1089,6 +1215,12 @@ func (r *ReconcilerBase) emitEvent(dataVolume *cdiv1.DataVolume, dataVolumeCopy
 		if event.eventType != "" && curPhase != dataVolumeCopy.Status.Phase {
 			r.recorder.Event(dataVolumeCopy, event.eventType, event.reason, event.message)
 		}
+		// The progress is summarized periodically when events are aggregated
+		if curPhase == dataVolumeCopy.Status.Phase && dataVolume.Status.Progress != dataVolumeCopy.Status.Progress &&
+			dataVolumeCopy.Status.Progress != "N/A" && dataVolumeCopy.Status.Phase != cdiv1.Succeeded {
+			cc.EmitProgressEvent(r.recorder, dataVolumeCopy, corev1.EventTypeNormal, PopulationProgress,
+				fmt.Sprintf(MessagePopulationProgress, dataVolumeCopy.Name, dataVolumeCopy.Status.Phase, dataVolumeCopy.Status.Progress))
+		}
 
 		r.emitConditionEvent(dataVolumeCopy, originalCond)
 	}
@@ -1113,21 +1245,95 @@ func updateProgressUsingPod(dataVolumeCopy *cdiv1.DataVolume, pod *corev1.Pod) e
 		return nil
 	}
 
-	// Used for both import and clone, so it should match both metric names
-	progressReport, err := cc.GetProgressReportFromURL(context.TODO(), url, httpClient,
-		fmt.Sprintf("%s|%s", importMetrics.ImportProgressMetricName, cloneMetrics.CloneProgressMetricName),
-		string(dataVolumeCopy.UID))
+	metrics, err := cc.GetMetricsFromURL(context.TODO(), url, httpClient)
 	if err != nil {
 		return err
 	}
+	// Used for both import and clone, so it should match both metric names
+	progressReport := cc.ParseProgressReport(metrics,
+		fmt.Sprintf("%s|%s", importMetrics.ImportProgressMetricName, cloneMetrics.CloneProgressMetricName),
+		string(dataVolumeCopy.UID))
 	if progressReport != "" {
 		if f, err := strconv.ParseFloat(progressReport, 64); err == nil {
 			dataVolumeCopy.Status.Progress = cdiv1.DataVolumeProgress(fmt.Sprintf("%.2f%%", f))
 		}
 	}
+	updateImportReport(dataVolumeCopy, cc.ParseImportReport(metrics, string(dataVolumeCopy.UID)))
+	if dataVolumeCopy.Spec.Source != nil && dataVolumeCopy.Spec.Source.PVC != nil {
+		report := cc.ParseCloneReport(metrics, string(dataVolumeCopy.UID))
+		report.Phase = cdiv1.ClonePhaseTransfer
+		updateCloneReport(dataVolumeCopy, report)
+	}
 	return nil
 }
 
+// updateCloneReport sets the progress in bytes, the throughput and the phase of the clone in the status. The
+// throughput is only reported while the data is copied by the clone pods.
+func updateCloneReport(dataVolumeCopy *cdiv1.DataVolume, report *cc.CloneReport) {
+	if report.TransferredBytes != nil {
+		dataVolumeCopy.Status.TransferredBytes = report.TransferredBytes
+	}
+	if report.TotalBytes != nil {
+		dataVolumeCopy.Status.TotalBytes = report.TotalBytes
+		if dataVolumeCopy.Status.Phase == cdiv1.Succeeded {
+			// The whole source was sent
+			dataVolumeCopy.Status.TransferredBytes = ptr.To(*report.TotalBytes)
+		}
+	}
+	dataVolumeCopy.Status.ClonePhase = report.Phase
+	dataVolumeCopy.Status.Throughput = nil
+	if report.Phase == cdiv1.ClonePhaseTransfer || report.Phase == cdiv1.ClonePhaseConvert {
+		dataVolumeCopy.Status.Throughput = report.Throughput
+	}
+}
+
+// updateCloneMetrics exports the progress of the clone of the DataVolume while it is in progress
+func updateCloneMetrics(dv *cdiv1.DataVolume) {
+	labels := prometheus.Labels{metrics.PrometheusCloneNsLabel: dv.Namespace, metrics.PrometheusCloneNameLabel: dv.Name}
+	if dv.Status.ClonePhase == "" || dv.Spec.Source == nil {
+		metrics.DeleteDataVolumeCloneProgress(labels)
+		return
+	}
+	_, _, sourceNamespace := cc.GetCloneSourceInfo(dv)
+	if sourceNamespace == "" {
+		sourceNamespace = dv.Namespace
+	}
+	labels[metrics.PrometheusCloneSourceNsLabel] = sourceNamespace
+	metrics.SetDataVolumeCloneProgress(labels, string(dv.Status.ClonePhase), dv.Status.TransferredBytes, dv.Status.TotalBytes, dv.Status.Throughput)
+}
+
+// updateImportReport sets the progress in bytes and the phase durations reported by the importer in the status
+func updateImportReport(dataVolumeCopy *cdiv1.DataVolume, report *cc.ImportReport) {
+	if report.TransferredBytes != nil {
+		dataVolumeCopy.Status.TransferredBytes = report.TransferredBytes
+	}
+	if report.TotalBytes != nil {
+		dataVolumeCopy.Status.TotalBytes = report.TotalBytes
+	}
+	if len(report.PhaseDurations) == 0 {
+		return
+	}
+	durations := &cdiv1.DataVolumePhaseDurations{}
+	for phase, value := range report.PhaseDurations {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			continue
+		}
+		duration := &metav1.Duration{Duration: d}
+		switch phase {
+		case common.ImportPhaseDownload:
+			durations.Download = duration
+		case common.ImportPhaseConvert:
+			durations.Convert = duration
+		case common.ImportPhaseResize:
+			durations.Resize = duration
+		case common.ImportPhaseVerify:
+			durations.Verify = duration
+		}
+	}
+	dataVolumeCopy.Status.PhaseDurations = durations
+}
+
 // newPersistentVolumeClaim creates a new PVC for the DataVolume resource.
 // It also sets the appropriate OwnerReferences on the resource
 // which allows handleObject to discover the DataVolume resource
@@ -1152,7 +1358,17 @@ func (r *ReconcilerBase) newPersistentVolumeClaim(dataVolume *cdiv1.DataVolume,
 	if dataVolume.Spec.PriorityClassName != "" {
 		annotations[cc.AnnPriorityClassName] = dataVolume.Spec.PriorityClassName
 	}
-	annotations[cc.AnnPreallocationRequested] = strconv.FormatBool(cc.GetPreallocation(context.TODO(), r.client, dataVolume.Spec.Preallocation))
+	if dataVolume.Spec.TransferPriority != nil {
+		annotations[cc.AnnTransferPriority] = strconv.Itoa(int(*dataVolume.Spec.TransferPriority))
+	}
+	if dataVolume.Spec.ScratchSpace != nil {
+		scratchSpace, err := json.Marshal(dataVolume.Spec.ScratchSpace)
+		if err != nil {
+			return nil, err
+		}
+		annotations[cc.AnnScratchSpace] = string(scratchSpace)
+	}
+	annotations[cc.AnnPreallocationRequested] = strconv.FormatBool(cc.GetPreallocation(context.TODO(), r.client, dataVolume.Spec.Preallocation, targetPvcSpec.StorageClassName))
 	annotations[cc.AnnCreatedForDataVolume] = string(dataVolume.UID)
 
 	if dataVolume.Spec.Storage != nil && labels[common.PvcApplyStorageProfileLabel] == "true" {
@@ -1227,8 +1443,8 @@ func (r *ReconcilerBase) updatePVC(pvc *corev1.PersistentVolumeClaim) error {
 	return r.client.Update(context.TODO(), pvc)
 }
 
-func newLongTermCloneTokenGenerator(key *rsa.PrivateKey) token.Generator {
-	return token.NewGenerator(common.ExtendedCloneTokenIssuer, key, 10*365*24*time.Hour)
+func newLongTermCloneTokenGenerator(tokenKeys token.KeySet, tokenConfig token.ConfigFunc) token.Generator {
+	return token.NewConfigurableGenerator(common.ExtendedCloneTokenIssuer, tokenKeys, token.FixedLifetime(10*365*24*time.Hour), tokenConfig)
 }
 
 // storageClassWaitForFirstConsumer returns if the binding mode of a given storage class is WFFC
@@ -1308,8 +1524,20 @@ func (r *ReconcilerBase) handlePvcCreation(log logr.Logger, syncState *dvSyncSta
 // Currently it will use populators only if:
 // * storageClass used is CSI storageClass
 // * annotation cdi.kubevirt.io/storage.usePopulator is not set by user to "false"
+// * the DataVolume is not validate only
 func (r *ReconcilerBase) shouldUseCDIPopulator(syncState *dvSyncState) (bool, error) {
-	dv := syncState.dvMutated
+	return shouldUseCDIPopulator(r.client, r.log, syncState.dvMutated, syncState.pvcSpec.StorageClassName)
+}
+
+func shouldUseCDIPopulator(c client.Client, log logr.Logger, dv *cdiv1.DataVolume, storageClassName *string) (bool, error) {
+	if dv.Spec.ValidateOnly {
+		// Validate only imports never create a PVC
+		return false, nil
+	}
+	if dv.Spec.Source != nil && dv.Spec.Source.DataVolume != nil {
+		// The importer pod of chained DataVolumes mounts the PVC of their source next to their own
+		return false, nil
+	}
 	if usePopulator, ok := dv.Annotations[cc.AnnUsePopulator]; ok {
 		boolUsePopulator, err := strconv.ParseBool(usePopulator)
 		if err != nil {
@@ -1317,14 +1545,14 @@ func (r *ReconcilerBase) shouldUseCDIPopulator(syncState *dvSyncState) (bool, er
 		}
 		return boolUsePopulator, nil
 	}
-	log := r.log.WithValues("DataVolume", dv.Name, "Namespace", dv.Namespace)
-	usePopulator, err := storageClassCSIDriverExists(r.client, r.log, syncState.pvcSpec.StorageClassName)
+	usePopulator, err := storageClassCSIDriverExists(c, log, storageClassName)
 	if err != nil {
 		return false, err
 	}
 	if !usePopulator {
-		if syncState.pvcSpec.StorageClassName != nil {
-			log.Info("Not using CDI populators, storage class is not a CSI storage", "storageClass", *syncState.pvcSpec.StorageClassName)
+		if storageClassName != nil {
+			log.WithValues("DataVolume", dv.Name, "Namespace", dv.Namespace).
+				Info("Not using CDI populators, storage class is not a CSI storage", "storageClass", *storageClassName)
 		}
 	}
 
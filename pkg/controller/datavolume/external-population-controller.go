@@ -65,7 +65,7 @@ func NewPopulatorController(ctx context.Context, mgr manager.Manager, log logr.L
 			client:               client,
 			scheme:               mgr.GetScheme(),
 			log:                  log.WithName(populatorControllerName),
-			recorder:             mgr.GetEventRecorderFor(populatorControllerName),
+			recorder:             cc.NewEventAggregator(mgr.GetEventRecorderFor(populatorControllerName), client),
 			featureGates:         featuregates.NewFeatureGates(client),
 			installerLabels:      installerLabels,
 			shouldUpdateProgress: false,
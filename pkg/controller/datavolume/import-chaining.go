@@ -0,0 +1,94 @@
+/*
+Copyright 2026 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package datavolume
+
+import (
+	"context"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+)
+
+const (
+	// SourceDataVolumeNotReady provides a const to indicate the source DataVolume of a chained DataVolume did not succeed yet
+	SourceDataVolumeNotReady = "SourceDataVolumeNotReady"
+	// MessageSourceDataVolumeNotReady provides a const to form the message when the source DataVolume did not succeed yet
+	MessageSourceDataVolumeNotReady = "Waiting for source DataVolume %s to succeed"
+	// SourceDataVolumeDeleted provides a const to indicate the intermediate source DataVolume of a chained DataVolume was deleted
+	SourceDataVolumeDeleted = "SourceDataVolumeDeleted"
+	// MessageSourceDataVolumeDeleted provides a const to form the message when the intermediate source DataVolume was deleted
+	MessageSourceDataVolumeDeleted = "Intermediate source DataVolume %s deleted"
+
+	// sourceDataVolumeRequeueDuration is how often the source DataVolume of a chained DataVolume is checked
+	sourceDataVolumeRequeueDuration = 5 * time.Second
+)
+
+// waitForSourceDataVolume returns true once the source DataVolume of a chained DataVolume succeeded, its PVC can then
+// be read by the importer pod
+func (r *ImportReconciler) waitForSourceDataVolume(syncState *dvSyncState) (bool, error) {
+	dv := syncState.dvMutated
+	if dv.Spec.Source == nil || dv.Spec.Source.DataVolume == nil {
+		return true, nil
+	}
+	sourceName := dv.Spec.Source.DataVolume.Name
+	source := &cdiv1.DataVolume{}
+	if err := r.client.Get(context.TODO(), types.NamespacedName{Namespace: dv.Namespace, Name: sourceName}, source); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return false, err
+		}
+	} else if source.Status.Phase == cdiv1.Succeeded {
+		return true, nil
+	}
+
+	r.recorder.Eventf(dv, corev1.EventTypeNormal, SourceDataVolumeNotReady, MessageSourceDataVolumeNotReady, sourceName)
+	if syncState.result == nil {
+		syncState.result = &reconcile.Result{}
+	}
+	syncState.result.RequeueAfter = sourceDataVolumeRequeueDuration
+	return false, nil
+}
+
+// deleteIntermediateDataVolume deletes the source DataVolume of a succeeded chained DataVolume when it is only an
+// intermediate volume of the pipeline
+func (r *ImportReconciler) deleteIntermediateDataVolume(syncState *dvSyncState) error {
+	dv := syncState.dvMutated
+	if dv.Spec.Source == nil || dv.Spec.Source.DataVolume == nil || !dv.Spec.Source.DataVolume.DeleteSource ||
+		dv.Status.Phase != cdiv1.Succeeded || dv.DeletionTimestamp != nil {
+		return nil
+	}
+	source := &cdiv1.DataVolume{}
+	if err := r.client.Get(context.TODO(), types.NamespacedName{Namespace: dv.Namespace, Name: dv.Spec.Source.DataVolume.Name}, source); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if source.DeletionTimestamp != nil {
+		return nil
+	}
+	if err := r.client.Delete(context.TODO(), source); err != nil && !k8serrors.IsNotFound(err) {
+		return err
+	}
+	r.recorder.Eventf(dv, corev1.EventTypeNormal, SourceDataVolumeDeleted, MessageSourceDataVolumeDeleted, source.Name)
+	return nil
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2026 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package datavolume
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+	"kubevirt.io/containerized-data-importer/pkg/common"
+	. "kubevirt.io/containerized-data-importer/pkg/controller/common"
+)
+
+var _ = Describe("DataVolume chaining", func() {
+	var (
+		reconciler *ImportReconciler
+		dvKey      = types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}
+		sourceKey  = types.NamespacedName{Name: "source-dv", Namespace: metav1.NamespaceDefault}
+	)
+
+	AfterEach(func() {
+		if reconciler != nil {
+			close(reconciler.recorder.(*record.FakeRecorder).Events)
+			reconciler = nil
+		}
+	})
+
+	newChainedDataVolume := func(source *cdiv1.DataVolumeSourceDataVolume) *cdiv1.DataVolume {
+		dv := NewImportDataVolume("test-dv")
+		dv.Spec.Source = &cdiv1.DataVolumeSource{DataVolume: source}
+		return dv
+	}
+
+	newSourceDataVolume := func(phase cdiv1.DataVolumePhase) *cdiv1.DataVolume {
+		source := NewImportDataVolume("source-dv")
+		source.Status.Phase = phase
+		return source
+	}
+
+	It("Should wait for the source DataVolume to succeed before creating the PVC", func() {
+		dv := newChainedDataVolume(&cdiv1.DataVolumeSourceDataVolume{Name: "source-dv"})
+		reconciler = createImportReconciler(dv, newSourceDataVolume(cdiv1.ImportInProgress))
+		res, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: dvKey})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(res.RequeueAfter).To(Equal(sourceDataVolumeRequeueDuration))
+		pvc := &corev1.PersistentVolumeClaim{}
+		err = reconciler.client.Get(context.TODO(), dvKey, pvc)
+		Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+		event := <-reconciler.recorder.(*record.FakeRecorder).Events
+		Expect(event).To(ContainSubstring(SourceDataVolumeNotReady))
+	})
+
+	It("Should import from the PVC of the succeeded source DataVolume with its transformation", func() {
+		dv := newChainedDataVolume(&cdiv1.DataVolumeSourceDataVolume{
+			Name: "source-dv",
+			Transformation: &cdiv1.DataVolumeTransformation{
+				Format:   cdiv1.TransformationFormatQcow2,
+				Sparsify: true,
+			},
+		})
+		reconciler = createImportReconciler(dv, newSourceDataVolume(cdiv1.Succeeded))
+		_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: dvKey})
+		Expect(err).ToNot(HaveOccurred())
+		pvc := &corev1.PersistentVolumeClaim{}
+		Expect(reconciler.client.Get(context.TODO(), dvKey, pvc)).To(Succeed())
+		Expect(pvc.Spec.DataSourceRef).To(BeNil())
+		Expect(pvc.Annotations).To(HaveKeyWithValue(AnnSource, SourceDataVolume))
+		Expect(pvc.Annotations).To(HaveKeyWithValue(AnnEndpoint, "source-dv"))
+		Expect(pvc.Annotations).To(HaveKeyWithValue(AnnStorageFormat, common.StorageFormatQcow2))
+		Expect(pvc.Annotations).To(HaveKeyWithValue(AnnSparsify, "true"))
+		Expect(pvc.Annotations).ToNot(HaveKey(AnnEncryptionSecret))
+	})
+
+	It("Should delete the intermediate source DataVolume once succeeded", func() {
+		dv := newChainedDataVolume(&cdiv1.DataVolumeSourceDataVolume{Name: "source-dv", DeleteSource: true})
+		dv.Status.Phase = cdiv1.Succeeded
+		reconciler = createImportReconciler(dv, newSourceDataVolume(cdiv1.Succeeded))
+		_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: dvKey})
+		Expect(err).ToNot(HaveOccurred())
+		err = reconciler.client.Get(context.TODO(), sourceKey, &cdiv1.DataVolume{})
+		Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+		Expect(reconciler.recorder.(*record.FakeRecorder).Events).To(Receive(ContainSubstring(SourceDataVolumeDeleted)))
+	})
+
+	It("Should keep the source DataVolume without deleteSource", func() {
+		dv := newChainedDataVolume(&cdiv1.DataVolumeSourceDataVolume{Name: "source-dv"})
+		dv.Status.Phase = cdiv1.Succeeded
+		reconciler = createImportReconciler(dv, newSourceDataVolume(cdiv1.Succeeded))
+		_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: dvKey})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(reconciler.client.Get(context.TODO(), sourceKey, &cdiv1.DataVolume{})).To(Succeed())
+	})
+})
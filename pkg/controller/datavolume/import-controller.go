@@ -18,6 +18,7 @@ package datavolume
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"reflect"
 	"strconv"
@@ -29,6 +30,7 @@ import (
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -52,6 +54,10 @@ const (
 	ImportFailed = "ImportFailed"
 	// ImportSucceeded provides a const to indicate import has succeeded
 	ImportSucceeded = "ImportSucceeded"
+	// SourceValidationSucceeded provides a const to indicate the source of a validate only import is valid
+	SourceValidationSucceeded = "SourceValidationSucceeded"
+	// SourceValidationFailed provides a const to indicate the source of a validate only import is not valid
+	SourceValidationFailed = "SourceValidationFailed"
 
 	// MessageImportScheduled provides a const to form import is scheduled message
 	MessageImportScheduled = "Import into %s scheduled"
@@ -61,6 +67,12 @@ const (
 	MessageImportFailed = "Failed to import into PVC %s"
 	// MessageImportSucceeded provides a const to form import has succeeded message
 	MessageImportSucceeded = "Successfully imported into PVC %s"
+	// MessageImportPausedBySpec provides a const to form import is paused by the DataVolume message
+	MessageImportPausedBySpec = "Import into %s paused"
+	// MessageSourceValidationSucceeded provides a const to form source is valid message
+	MessageSourceValidationSucceeded = "Source of %s is valid"
+	// MessageSourceValidationFailed provides a const to form source is not valid message
+	MessageSourceValidationFailed = "Source of %s is not valid: %s"
 
 	importControllerName = "datavolume-import-controller"
 
@@ -70,6 +82,8 @@ const (
 // ImportReconciler members
 type ImportReconciler struct {
 	ReconcilerBase
+	importerImage string
+	pullPolicy    string
 }
 
 // NewImportController creates a new instance of the datavolume import controller
@@ -77,6 +91,8 @@ func NewImportController(
 	ctx context.Context,
 	mgr manager.Manager,
 	log logr.Logger,
+	importerImage string,
+	pullPolicy string,
 	installerLabels map[string]string,
 ) (controller.Controller, error) {
 	client := mgr.GetClient()
@@ -85,11 +101,13 @@ func NewImportController(
 			client:               client,
 			scheme:               mgr.GetScheme(),
 			log:                  log.WithName(importControllerName),
-			recorder:             mgr.GetEventRecorderFor(importControllerName),
+			recorder:             cc.NewEventAggregator(mgr.GetEventRecorderFor(importControllerName), client),
 			featureGates:         featuregates.NewFeatureGates(client),
 			installerLabels:      installerLabels,
 			shouldUpdateProgress: true,
 		},
+		importerImage: importerImage,
+		pullPolicy:    pullPolicy,
 	}
 
 	datavolumeController, err := controller.New(importControllerName, mgr, controller.Options{
@@ -114,6 +132,9 @@ func addDataVolumeImportControllerWatches(mgr manager.Manager, datavolumeControl
 		mgr.GetScheme(), mgr.GetClient().RESTMapper(), &cdiv1.DataVolume{}, handler.OnlyControllerOwner()))); err != nil {
 		return err
 	}
+	if err := addDeduplicationWriterWatch(mgr, datavolumeController); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -121,6 +142,18 @@ func (r *ImportReconciler) updatePVCForPopulation(dataVolume *cdiv1.DataVolume,
 	if dataVolume.Spec.Source.HTTP == nil &&
 		dataVolume.Spec.Source.S3 == nil &&
 		dataVolume.Spec.Source.GCS == nil &&
+		dataVolume.Spec.Source.Azure == nil &&
+		dataVolume.Spec.Source.SFTP == nil &&
+		dataVolume.Spec.Source.SMB == nil &&
+		dataVolume.Spec.Source.Glance == nil &&
+		dataVolume.Spec.Source.RBD == nil &&
+		dataVolume.Spec.Source.ISCSI == nil &&
+		dataVolume.Spec.Source.HostPath == nil &&
+		dataVolume.Spec.Source.Export == nil &&
+		dataVolume.Spec.Source.V2V == nil &&
+		dataVolume.Spec.Source.Proxmox == nil &&
+		dataVolume.Spec.Source.Plugin == nil &&
+		dataVolume.Spec.Source.Torrent == nil &&
 		dataVolume.Spec.Source.Registry == nil &&
 		dataVolume.Spec.Source.Imageio == nil &&
 		dataVolume.Spec.Source.VDDK == nil &&
@@ -130,6 +163,30 @@ func (r *ImportReconciler) updatePVCForPopulation(dataVolume *cdiv1.DataVolume,
 	if err := cc.AddImmediateBindingAnnotationIfWFFCDisabled(pvc, r.featureGates); err != nil {
 		return err
 	}
+	// The populator passes the fallbacks, the retry policy, the pod overrides and the proxy to the PVC' the importer populates
+	if err := updateSourceFallbacksAnnotation(dataVolume, pvc); err != nil {
+		return err
+	}
+	if err := updateRetryPolicyAnnotation(dataVolume, pvc); err != nil {
+		return err
+	}
+	if err := updatePodOverridesAnnotation(dataVolume, pvc); err != nil {
+		return err
+	}
+	if err := updateProxyAnnotation(dataVolume, pvc); err != nil {
+		return err
+	}
+	if dataVolume.Spec.Paused {
+		cc.AddAnnotation(pvc, cc.AnnImportPaused, "true")
+	}
+	if dataVolume.Spec.Source.Registry != nil {
+		if err := r.updateRegistryImageArchitecture(dataVolume, pvc.Annotations); err != nil {
+			return err
+		}
+	}
+	if err := r.updateDeduplicationKeyAnnotation(dataVolume, pvc); err != nil {
+		return err
+	}
 	apiGroup := cc.AnnAPIGroup
 	pvc.Spec.DataSourceRef = &corev1.TypedObjectReference{
 		APIGroup: &apiGroup,
@@ -139,6 +196,62 @@ func (r *ImportReconciler) updatePVCForPopulation(dataVolume *cdiv1.DataVolume,
 	return nil
 }
 
+// updateSourceFallbacksAnnotation passes the fallback sources of the DataVolume to the import controller, which moves
+// the PVC to the next one each time the importer fails
+func updateSourceFallbacksAnnotation(dataVolume *cdiv1.DataVolume, pvc *corev1.PersistentVolumeClaim) error {
+	if len(dataVolume.Spec.SourceFallbacks) == 0 {
+		return nil
+	}
+	fallbacks, err := json.Marshal(dataVolume.Spec.SourceFallbacks)
+	if err != nil {
+		return err
+	}
+	cc.AddAnnotation(pvc, cc.AnnSourceFallbacks, string(fallbacks))
+	return nil
+}
+
+// updateRetryPolicyAnnotation passes the retry policy of the DataVolume to the import controller, which retries the
+// failed importer pods following it
+func updateRetryPolicyAnnotation(dataVolume *cdiv1.DataVolume, pvc *corev1.PersistentVolumeClaim) error {
+	if dataVolume.Spec.RetryPolicy == nil {
+		return nil
+	}
+	policy, err := json.Marshal(dataVolume.Spec.RetryPolicy)
+	if err != nil {
+		return err
+	}
+	cc.AddAnnotation(pvc, cc.AnnRetryPolicy, string(policy))
+	return nil
+}
+
+// updatePodOverridesAnnotation passes the pod overrides of the DataVolume to the import controller, which applies them
+// to the importer pod
+func updatePodOverridesAnnotation(dataVolume *cdiv1.DataVolume, pvc *corev1.PersistentVolumeClaim) error {
+	if dataVolume.Spec.PodOverrides == nil {
+		return nil
+	}
+	overrides, err := json.Marshal(dataVolume.Spec.PodOverrides)
+	if err != nil {
+		return err
+	}
+	cc.AddAnnotation(pvc, cc.AnnPodOverrides, string(overrides))
+	return nil
+}
+
+// updateProxyAnnotation passes the proxy configuration of the DataVolume to the import controller, which uses it
+// instead of the one of the CDIConfig
+func updateProxyAnnotation(dataVolume *cdiv1.DataVolume, pvc *corev1.PersistentVolumeClaim) error {
+	if dataVolume.Spec.Proxy == nil {
+		return nil
+	}
+	proxy, err := json.Marshal(dataVolume.Spec.Proxy)
+	if err != nil {
+		return err
+	}
+	cc.AddAnnotation(pvc, cc.AnnImportProxy, string(proxy))
+	return nil
+}
+
 func (r *ImportReconciler) updateAnnotations(dataVolume *cdiv1.DataVolume, pvc *corev1.PersistentVolumeClaim) error {
 	annotations := pvc.Annotations
 
@@ -148,6 +261,38 @@ func (r *ImportReconciler) updateAnnotations(dataVolume *cdiv1.DataVolume, pvc *
 		annotations[cc.AnnFinalCheckpoint] = strconv.FormatBool(checkpoint.IsFinal)
 	}
 
+	if err := updateSourceFallbacksAnnotation(dataVolume, pvc); err != nil {
+		return err
+	}
+	if err := updateRetryPolicyAnnotation(dataVolume, pvc); err != nil {
+		return err
+	}
+	if err := updatePodOverridesAnnotation(dataVolume, pvc); err != nil {
+		return err
+	}
+	if err := updateProxyAnnotation(dataVolume, pvc); err != nil {
+		return err
+	}
+	if dataVolume.Spec.Paused {
+		cc.AddAnnotation(pvc, cc.AnnImportPaused, "true")
+	}
+	if dataVolume.Spec.ExpandFilesystem {
+		annotations[cc.AnnExpandFilesystem] = "true"
+	}
+	if verify := dataVolume.Spec.Verify; verify != nil {
+		annotations[cc.AnnVerify] = "true"
+		if verify.Checksum {
+			annotations[cc.AnnVerifyChecksum] = "true"
+		}
+	}
+
+	if dataVolume.Spec.ContentType == "" {
+		annotations[cc.AnnDetectContentType] = "true"
+	}
+	if err := r.updateDeduplicationKeyAnnotation(dataVolume, pvc); err != nil {
+		return err
+	}
+
 	if http := dataVolume.Spec.Source.HTTP; http != nil {
 		cc.UpdateHTTPAnnotations(annotations, http)
 		return nil
@@ -160,9 +305,61 @@ func (r *ImportReconciler) updateAnnotations(dataVolume *cdiv1.DataVolume, pvc *
 		cc.UpdateGCSAnnotations(annotations, gcs)
 		return nil
 	}
+	if azure := dataVolume.Spec.Source.Azure; azure != nil {
+		cc.UpdateAzureAnnotations(annotations, azure)
+		return nil
+	}
+	if sftp := dataVolume.Spec.Source.SFTP; sftp != nil {
+		cc.UpdateSFTPAnnotations(annotations, sftp)
+		return nil
+	}
+	if smb := dataVolume.Spec.Source.SMB; smb != nil {
+		cc.UpdateSMBAnnotations(annotations, smb)
+		return nil
+	}
+	if glance := dataVolume.Spec.Source.Glance; glance != nil {
+		cc.UpdateGlanceAnnotations(annotations, glance)
+		return nil
+	}
+	if rbd := dataVolume.Spec.Source.RBD; rbd != nil {
+		cc.UpdateRBDAnnotations(annotations, rbd)
+		return nil
+	}
+	if iscsi := dataVolume.Spec.Source.ISCSI; iscsi != nil {
+		cc.UpdateISCSIAnnotations(annotations, iscsi)
+		return nil
+	}
+	if hostPath := dataVolume.Spec.Source.HostPath; hostPath != nil {
+		cc.UpdateHostPathAnnotations(annotations, hostPath)
+		return nil
+	}
+	if sourceDataVolume := dataVolume.Spec.Source.DataVolume; sourceDataVolume != nil {
+		cc.UpdateDataVolumeSourceAnnotations(annotations, sourceDataVolume)
+		return nil
+	}
+	if export := dataVolume.Spec.Source.Export; export != nil {
+		cc.UpdateExportAnnotations(annotations, export)
+		return nil
+	}
+	if v2v := dataVolume.Spec.Source.V2V; v2v != nil {
+		cc.UpdateV2VAnnotations(annotations, v2v)
+		return nil
+	}
+	if proxmox := dataVolume.Spec.Source.Proxmox; proxmox != nil {
+		cc.UpdateProxmoxAnnotations(annotations, proxmox)
+		return nil
+	}
+	if plugin := dataVolume.Spec.Source.Plugin; plugin != nil {
+		cc.UpdatePluginAnnotations(annotations, plugin)
+		return nil
+	}
+	if torrent := dataVolume.Spec.Source.Torrent; torrent != nil {
+		cc.UpdateTorrentAnnotations(annotations, torrent)
+		return nil
+	}
 	if registry := dataVolume.Spec.Source.Registry; registry != nil {
 		cc.UpdateRegistryAnnotations(annotations, registry)
-		return nil
+		return r.updateRegistryImageArchitecture(dataVolume, annotations)
 	}
 	if imageio := dataVolume.Spec.Source.Imageio; imageio != nil {
 		cc.UpdateImageIOAnnotations(annotations, imageio)
@@ -179,6 +376,27 @@ func (r *ImportReconciler) updateAnnotations(dataVolume *cdiv1.DataVolume, pvc *
 	return errors.Errorf("no source set for import datavolume")
 }
 
+// updateRegistryImageArchitecture picks the architecture of the image from the multi-architecture image index of the
+// registry source of DataVolumes selecting it: the one of the node selector of their topology, else the CDIConfig default
+func (r *ImportReconciler) updateRegistryImageArchitecture(dataVolume *cdiv1.DataVolume, annotations map[string]string) error {
+	platform := dataVolume.Spec.Source.Registry.Platform
+	if platform == nil || !platform.SelectArchitecture || platform.Architecture != "" {
+		return nil
+	}
+	if topology := dataVolume.Spec.Topology; topology != nil && topology.NodeSelector[corev1.LabelArchStable] != "" {
+		annotations[cc.AnnRegistryImageArchitecture] = topology.NodeSelector[corev1.LabelArchStable]
+		return nil
+	}
+	architecture, err := cc.GetDefaultImageArchitecture(context.TODO(), r.client)
+	if err != nil {
+		return err
+	}
+	if architecture != "" {
+		annotations[cc.AnnRegistryImageArchitecture] = architecture
+	}
+	return nil
+}
+
 // Reconcile loop for the import data volumes
 func (r *ImportReconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
 	return r.reconcile(ctx, req, r)
@@ -198,9 +416,34 @@ func (r *ImportReconciler) syncImport(log logr.Logger, req reconcile.Request) (d
 		return syncState, syncErr
 	}
 
+	if syncState.dvMutated.Spec.ValidateOnly {
+		return syncState, r.syncValidateOnly(&syncState)
+	}
+
+	var deduplicationSource *corev1.PersistentVolumeClaim
+	if syncState.pvc == nil {
+		if done, err := r.waitForSourceDataVolume(&syncState); err != nil || !done {
+			return syncState, err
+		}
+		var err error
+		if deduplicationSource, err = r.getDeduplicationSource(&syncState); err != nil {
+			return syncState, err
+		}
+		if deduplicationSource == nil {
+			if done, err := r.detectImportSize(&syncState); err != nil || !done {
+				return syncState, err
+			}
+		}
+	}
+
 	pvcModifier := r.updateAnnotations
-	if syncState.usePopulator {
-		if r.shouldReconcileVolumeSourceCR(&syncState) {
+	if deduplicationSource != nil {
+		if err := r.reconcileDeduplicationSourceCR(syncState.dvMutated, deduplicationSource); err != nil {
+			return syncState, err
+		}
+		pvcModifier = r.deduplicatedPVCModifier(deduplicationSource)
+	} else if syncState.usePopulator {
+		if r.shouldReconcileVolumeSourceCR(&syncState) && !isPVCDeduplicated(syncState.pvc) {
 			err := r.reconcileVolumeImportSourceCR(&syncState)
 			if err != nil {
 				return syncState, err
@@ -211,6 +454,20 @@ func (r *ImportReconciler) syncImport(log logr.Logger, req reconcile.Request) (d
 
 	if err := r.handlePvcCreation(log, &syncState, pvcModifier); err != nil {
 		syncErr = err
+	} else if deduplicationSource != nil && syncState.pvc != nil {
+		r.recorder.Eventf(syncState.dvMutated, corev1.EventTypeNormal, ImportDeduplicated, MessageImportDeduplicated, deduplicationSource.Name, syncState.pvc.Name)
+	}
+
+	if syncState.pvc != nil && syncErr == nil {
+		syncErr = r.syncPausedAnnotation(syncState.dvMutated, syncState.pvc)
+	}
+
+	if syncState.pvc != nil && syncErr == nil {
+		syncErr = r.invalidateDeduplicationKey(syncState.dvMutated, syncState.pvc)
+	}
+
+	if syncState.pvc != nil && syncErr == nil {
+		syncErr = r.syncTopologyPlacement(&syncState)
 	}
 
 	if syncState.pvc != nil && syncErr == nil && !syncState.usePopulator {
@@ -220,7 +477,23 @@ func (r *ImportReconciler) syncImport(log logr.Logger, req reconcile.Request) (d
 	return syncState, syncErr
 }
 
+// syncPausedAnnotation pauses and resumes the import of the PVC following the spec of the DataVolume
+func (r *ImportReconciler) syncPausedAnnotation(dataVolume *cdiv1.DataVolume, pvc *corev1.PersistentVolumeClaim) error {
+	if dataVolume.Spec.Paused == (pvc.Annotations[cc.AnnImportPaused] == "true") {
+		return nil
+	}
+	if dataVolume.Spec.Paused {
+		cc.AddAnnotation(pvc, cc.AnnImportPaused, "true")
+	} else {
+		delete(pvc.Annotations, cc.AnnImportPaused)
+	}
+	return r.updatePVC(pvc)
+}
+
 func (r *ImportReconciler) cleanup(syncState *dvSyncState) error {
+	if err := r.deleteIntermediateDataVolume(syncState); err != nil {
+		return err
+	}
 	// The cleanup is to delete the volumeImportSourceCR which is used only with populators,
 	// it is owner by the DV so will be deleted when dv is deleted
 	// also we can already delete once dv is succeeded
@@ -228,6 +501,12 @@ func (r *ImportReconciler) cleanup(syncState *dvSyncState) error {
 	if err != nil {
 		return err
 	}
+	if isPVCDeduplicated(syncState.pvc) {
+		if syncState.dvMutated.Status.Phase != cdiv1.Succeeded {
+			return nil
+		}
+		return r.deleteDeduplicationSourceCR(syncState.dvMutated)
+	}
 	if usePopulator && !r.shouldReconcileVolumeSourceCR(syncState) {
 		return r.deleteVolumeImportSourceCR(syncState)
 	}
@@ -262,6 +541,9 @@ func (r *ImportReconciler) shouldUpdateStatusPhase(pvc *corev1.PersistentVolumeC
 }
 
 func (r *ImportReconciler) updateStatusPhase(pvc *corev1.PersistentVolumeClaim, dataVolumeCopy *cdiv1.DataVolume, event *Event) error {
+	if isPVCDeduplicated(pvc) {
+		return updateDeduplicatedStatusPhase(pvc, dataVolumeCopy, event)
+	}
 	phase, ok := pvc.Annotations[cc.AnnPodPhase]
 	if phase != string(corev1.PodSucceeded) {
 		update, err := r.shouldUpdateStatusPhase(pvc, dataVolumeCopy)
@@ -270,6 +552,13 @@ func (r *ImportReconciler) updateStatusPhase(pvc *corev1.PersistentVolumeClaim,
 		}
 	}
 	dataVolumeCopy.Status.Phase = cdiv1.ImportScheduled
+	if pvc.Annotations[cc.AnnImportPaused] == "true" && phase != string(corev1.PodSucceeded) {
+		dataVolumeCopy.Status.Phase = cdiv1.ImportPaused
+		event.eventType = corev1.EventTypeNormal
+		event.reason = cc.ImportPaused
+		event.message = fmt.Sprintf(MessageImportPausedBySpec, pvc.Name)
+		return nil
+	}
 	if !ok {
 		return nil
 	}
@@ -308,6 +597,14 @@ func (r *ImportReconciler) updateStatusPhase(pvc *corev1.PersistentVolumeClaim,
 		}
 		dataVolumeCopy.Status.Phase = cdiv1.Succeeded
 		dataVolumeCopy.Status.Progress = cdiv1.DataVolumeProgress("100.0%")
+		if err := updateImportedSourceStatus(pvc, dataVolumeCopy); err != nil {
+			return err
+		}
+		updateImportReport(dataVolumeCopy, cc.GetImportReportFromAnnotations(pvc.Annotations))
+		if total := dataVolumeCopy.Status.TotalBytes; total != nil {
+			// The whole source was read
+			dataVolumeCopy.Status.TransferredBytes = ptr.To(*total)
+		}
 		event.eventType = corev1.EventTypeNormal
 		event.reason = ImportSucceeded
 		event.message = fmt.Sprintf(MessageImportSucceeded, pvc.Name)
@@ -315,6 +612,24 @@ func (r *ImportReconciler) updateStatusPhase(pvc *corev1.PersistentVolumeClaim,
 	return nil
 }
 
+// updateImportedSourceStatus reports what the import of the PVC found about its source
+func updateImportedSourceStatus(pvc *corev1.PersistentVolumeClaim, dataVolumeCopy *cdiv1.DataVolume) error {
+	if digest := pvc.Annotations[cc.AnnSourceDigest]; digest != "" {
+		dataVolumeCopy.Status.SourceDigest = digest
+	}
+	if detected := pvc.Annotations[cc.AnnDetectedContentType]; detected != "" {
+		dataVolumeCopy.Status.DetectedContentType = detected
+	}
+	if platform := pvc.Annotations[cc.AnnImportedPlatform]; platform != "" {
+		importedPlatform := &cdiv1.DataVolumeImportedPlatform{}
+		if err := json.Unmarshal([]byte(platform), importedPlatform); err != nil {
+			return errors.Wrapf(err, "invalid %s annotation", cc.AnnImportedPlatform)
+		}
+		dataVolumeCopy.Status.ImportedPlatform = importedPlatform
+	}
+	return nil
+}
+
 func (r *ImportReconciler) setVddkAnnotations(syncState *dvSyncState) {
 	if cc.GetSource(syncState.pvc) != cc.SourceVDDK {
 		return
@@ -345,7 +660,7 @@ func (r *ImportReconciler) reconcileVolumeImportSourceCR(syncState *dvSyncState)
 	importSource := &cdiv1.VolumeImportSource{}
 	importSourceName := volumeImportSourceName(dv)
 	isMultiStage := dv.Spec.Source != nil && len(dv.Spec.Checkpoints) > 0 &&
-		(dv.Spec.Source.VDDK != nil || dv.Spec.Source.Imageio != nil)
+		(dv.Spec.Source.VDDK != nil || dv.Spec.Source.Imageio != nil || dv.Spec.Source.Export != nil)
 
 	// check if import source already exists
 	if exists, err := cc.GetResource(context.TODO(), r.client, dv.Namespace, importSourceName, importSource); err != nil {
@@ -361,6 +676,30 @@ func (r *ImportReconciler) reconcileVolumeImportSourceCR(syncState *dvSyncState)
 		source.S3 = s3
 	} else if gcs := dv.Spec.Source.GCS; gcs != nil {
 		source.GCS = gcs
+	} else if azure := dv.Spec.Source.Azure; azure != nil {
+		source.Azure = azure
+	} else if sftp := dv.Spec.Source.SFTP; sftp != nil {
+		source.SFTP = sftp
+	} else if smb := dv.Spec.Source.SMB; smb != nil {
+		source.SMB = smb
+	} else if glance := dv.Spec.Source.Glance; glance != nil {
+		source.Glance = glance
+	} else if rbd := dv.Spec.Source.RBD; rbd != nil {
+		source.RBD = rbd
+	} else if iscsi := dv.Spec.Source.ISCSI; iscsi != nil {
+		source.ISCSI = iscsi
+	} else if hostPath := dv.Spec.Source.HostPath; hostPath != nil {
+		source.HostPath = hostPath
+	} else if export := dv.Spec.Source.Export; export != nil {
+		source.Export = export
+	} else if v2v := dv.Spec.Source.V2V; v2v != nil {
+		source.V2V = v2v
+	} else if proxmox := dv.Spec.Source.Proxmox; proxmox != nil {
+		source.Proxmox = proxmox
+	} else if plugin := dv.Spec.Source.Plugin; plugin != nil {
+		source.Plugin = plugin
+	} else if torrent := dv.Spec.Source.Torrent; torrent != nil {
+		source.Torrent = torrent
 	} else if registry := dv.Spec.Source.Registry; registry != nil {
 		source.Registry = registry
 	} else if imageio := dv.Spec.Source.Imageio; imageio != nil {
@@ -24,6 +24,7 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -199,6 +200,57 @@ var _ = Describe("All DataVolume Tests", func() {
 			Expect(val).To(Equal(string(dv.UID)))
 		})
 
+		It("Should not recreate the PVC or update the status while the DataVolume is migrated", func() {
+			dv := NewImportDataVolume("test-dv")
+			dv.Status.Phase = cdiv1.Succeeded
+			dv.Annotations = map[string]string{AnnDataVolumeMigration: "test-dvm"}
+			reconciler = createImportReconciler(dv)
+			_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}})
+			Expect(err).ToNot(HaveOccurred())
+			err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}, &corev1.PersistentVolumeClaim{})
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+			dv = &cdiv1.DataVolume{}
+			Expect(reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}, dv)).To(Succeed())
+			Expect(dv.Status.Phase).To(Equal(cdiv1.Succeeded))
+		})
+
+		It("Should pass the fallback sources to the PVC", func() {
+			dv := NewImportDataVolume("test-dv")
+			dv.Spec.SourceFallbacks = []cdiv1.DataVolumeSourceFallback{
+				{S3: &cdiv1.DataVolumeSourceS3{URL: "https://s3.example.com/bucket/disk.img"}},
+			}
+			reconciler = createImportReconciler(dv)
+			_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}})
+			Expect(err).ToNot(HaveOccurred())
+			pvc := &corev1.PersistentVolumeClaim{}
+			err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}, pvc)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pvc.Annotations[AnnSourceFallbacks]).To(MatchJSON(`[{"s3":{"url":"https://s3.example.com/bucket/disk.img"}}]`))
+		})
+
+		It("Should pause and resume the import of the PVC following the DV", func() {
+			dv := NewImportDataVolume("test-dv")
+			dv.Spec.Paused = true
+			reconciler = createImportReconciler(dv)
+			_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}})
+			Expect(err).ToNot(HaveOccurred())
+			pvc := &corev1.PersistentVolumeClaim{}
+			err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}, pvc)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pvc.Annotations[AnnImportPaused]).To(Equal("true"))
+
+			err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}, dv)
+			Expect(err).ToNot(HaveOccurred())
+			dv.Spec.Paused = false
+			err = reconciler.client.Update(context.TODO(), dv)
+			Expect(err).ToNot(HaveOccurred())
+			_, err = reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}})
+			Expect(err).ToNot(HaveOccurred())
+			err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}, pvc)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pvc.Annotations).ToNot(HaveKey(AnnImportPaused))
+		})
+
 		It("Should create a PVC on a valid import DV without delayed annotation then add on success", func() {
 			dv := NewImportDataVolume("test-dv")
 			AddAnnotation(dv, "foo", "bar")
@@ -307,6 +359,51 @@ var _ = Describe("All DataVolume Tests", func() {
 			Expect(dv.Status.Progress).To(BeEquivalentTo("13.45%"))
 		})
 
+		It("Should summarize the import progress in events when they are aggregated", func() {
+			scName := "testSC"
+			sc := CreateStorageClassWithProvisioner(scName, map[string]string{AnnDefaultStorageClass: "true"}, map[string]string{}, "csi-plugin")
+			csiDriver := &storagev1.CSIDriver{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "csi-plugin",
+				},
+			}
+			dv := NewImportDataVolume("test-dv")
+			reconciler = createImportReconciler(dv, sc, csiDriver)
+			fakeRecorder := reconciler.recorder
+			reconciler.recorder = NewEventAggregator(fakeRecorder, reconciler.client)
+
+			config := &cdiv1.CDIConfig{}
+			err := reconciler.client.Get(context.TODO(), types.NamespacedName{Name: common.ConfigName}, config)
+			Expect(err).ToNot(HaveOccurred())
+			config.Spec.EventAggregation = &cdiv1.EventAggregationConfig{}
+			err = reconciler.client.Update(context.TODO(), config)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}})
+			Expect(err).ToNot(HaveOccurred())
+
+			pvc := &corev1.PersistentVolumeClaim{}
+			err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}, pvc)
+			Expect(err).ToNot(HaveOccurred())
+			for _, progress := range []string{"13.45%", "27.01%"} {
+				AddAnnotation(pvc, AnnPopulatorProgress, progress)
+				err = reconciler.client.Update(context.TODO(), pvc)
+				Expect(err).ToNot(HaveOccurred())
+				_, err = reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}})
+				Expect(err).ToNot(HaveOccurred())
+			}
+
+			var progressEvents []string
+			close(fakeRecorder.(*record.FakeRecorder).Events)
+			for event := range fakeRecorder.(*record.FakeRecorder).Events {
+				if strings.Contains(event, PopulationProgress) {
+					progressEvents = append(progressEvents, event)
+				}
+			}
+			Expect(progressEvents).To(ConsistOf(ContainSubstring("progress 13.45%")))
+			reconciler = nil
+		})
+
 		It("Should pass labels from DV to PVC", func() {
 			dv := NewImportDataVolume("test-dv")
 			dv.Labels = map[string]string{}
@@ -390,7 +487,9 @@ var _ = Describe("All DataVolume Tests", func() {
 		})
 
 		It("Should fail on missing size, without storageClass", func() {
-			importDataVolume := newImportDataVolumeWithPvc("test-dv", nil)
+			// The size of S3 sources is not detected
+			importDataVolume := newS3ImportDataVolume("test-dv")
+			importDataVolume.Spec.PVC = nil
 			// spec with accessMode/VolumeMode so storageprofile is not needed
 			importDataVolume.Spec.Storage = createStorageSpec()
 			importDataVolume.Spec.Storage.Resources = corev1.VolumeResourceRequirements{}
@@ -404,7 +503,9 @@ var _ = Describe("All DataVolume Tests", func() {
 
 		It("Should fail on missing size, with StorageClass", func() {
 			storageClassName := "defaultSc"
-			importDataVolume := newImportDataVolumeWithPvc("test-dv", nil)
+			// The size of S3 sources is not detected
+			importDataVolume := newS3ImportDataVolume("test-dv")
+			importDataVolume.Spec.PVC = nil
 			// spec with accessMode/VolumeMode so storageprofile is not needed
 			importDataVolume.Spec.Storage = createStorageSpec()
 			importDataVolume.Spec.Storage.Resources = corev1.VolumeResourceRequirements{}
@@ -719,6 +820,43 @@ var _ = Describe("All DataVolume Tests", func() {
 			Expect(pvc.GetAnnotations()[AnnPriorityClassName]).To(Equal("p0-s3"))
 		})
 
+		It("Should pass the S3 service account to the PVC", func() {
+			dv := newS3ImportDataVolume("test-dv")
+			dv.Spec.Source.S3.ServiceAccountName = "s3-reader"
+			reconciler = createImportReconciler(dv)
+			_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}})
+			Expect(err).ToNot(HaveOccurred())
+			pvc := &corev1.PersistentVolumeClaim{}
+			err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}, pvc)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pvc.GetAnnotations()[AnnImportServiceAccount]).To(Equal("s3-reader"))
+		})
+
+		It("Should pass the checksum of the S3 object to the PVC", func() {
+			dv := newS3ImportDataVolume("test-dv")
+			dv.Spec.Source.S3.Checksum = &cdiv1.DataVolumeChecksum{Algorithm: cdiv1.ChecksumSHA1, Value: "0123456789ABCDEF0123456789ABCDEF01234567"}
+			reconciler = createImportReconciler(dv)
+			_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}})
+			Expect(err).ToNot(HaveOccurred())
+			pvc := &corev1.PersistentVolumeClaim{}
+			err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}, pvc)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pvc.GetAnnotations()[AnnChecksum]).To(Equal("sha1:0123456789abcdef0123456789abcdef01234567"))
+		})
+
+		It("Should pass the signature of the S3 object to the PVC", func() {
+			dv := newS3ImportDataVolume("test-dv")
+			dv.Spec.Source.S3.Signature = &cdiv1.DataVolumeSignature{URL: "disk.img.sig", KeyringSecretRef: "image-keys"}
+			reconciler = createImportReconciler(dv)
+			_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}})
+			Expect(err).ToNot(HaveOccurred())
+			pvc := &corev1.PersistentVolumeClaim{}
+			err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}, pvc)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pvc.GetAnnotations()[AnnSignatureURL]).To(Equal("disk.img.sig"))
+			Expect(pvc.GetAnnotations()[AnnSignatureKeyringSecret]).To(Equal("image-keys"))
+		})
+
 		It("Should follow the phase of the created PVC", func() {
 			reconciler = createImportReconciler(NewImportDataVolume("test-dv"))
 			_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}})
@@ -1418,6 +1556,251 @@ var _ = Describe("All DataVolume Tests", func() {
 			Expect(dv.Status.Phase).To(Equal(dvPhase))
 		})
 
+		It("Should record the digest, the content type and the platform of the source in the status", func() {
+			reconciler = createImportReconciler(NewImportDataVolume("test-dv"))
+			_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}})
+			Expect(err).ToNot(HaveOccurred())
+			dv := &cdiv1.DataVolume{}
+			err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}, dv)
+			Expect(err).ToNot(HaveOccurred())
+
+			pvc := &corev1.PersistentVolumeClaim{}
+			err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}, pvc)
+			Expect(err).ToNot(HaveOccurred())
+			// No content type was declared, the importer picks it from the data
+			Expect(pvc.GetAnnotations()[AnnDetectContentType]).To(Equal("true"))
+			pvc.GetAnnotations()[AnnPodPhase] = string(corev1.PodSucceeded)
+			pvc.GetAnnotations()[AnnSourceDigest] = "sha256:12345678"
+			pvc.GetAnnotations()[AnnDetectedContentType] = "qcow2"
+			pvc.GetAnnotations()[AnnImportedPlatform] = `{"architecture":"arm64","digest":"sha256:87654321"}`
+			err = reconciler.client.Update(context.TODO(), pvc)
+			Expect(err).ToNot(HaveOccurred())
+			pvc.Status.Phase = corev1.ClaimBound
+			err = reconciler.client.Status().Update(context.TODO(), pvc)
+			Expect(err).ToNot(HaveOccurred())
+			_, err = reconciler.updateStatus(getReconcileRequest(dv), nil, reconciler)
+			Expect(err).ToNot(HaveOccurred())
+			dv = &cdiv1.DataVolume{}
+			err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}, dv)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(dv.Status.Phase).To(Equal(cdiv1.Succeeded))
+			Expect(dv.Status.SourceDigest).To(Equal("sha256:12345678"))
+			Expect(dv.Status.DetectedContentType).To(Equal("qcow2"))
+			Expect(dv.Status.ImportedPlatform).To(HaveValue(Equal(cdiv1.DataVolumeImportedPlatform{Architecture: "arm64", Digest: "sha256:87654321"})))
+		})
+
+		It("Should record the observed digest, the failure artifacts and the reason of failed imports", func() {
+			reconciler = createImportReconciler(NewImportDataVolume("test-dv"))
+			_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}})
+			Expect(err).ToNot(HaveOccurred())
+			dv := &cdiv1.DataVolume{}
+			err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}, dv)
+			Expect(err).ToNot(HaveOccurred())
+
+			pvc := &corev1.PersistentVolumeClaim{}
+			err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}, pvc)
+			Expect(err).ToNot(HaveOccurred())
+			pvc.GetAnnotations()[AnnPodPhase] = string(corev1.PodFailed)
+			pvc.GetAnnotations()[AnnObservedSourceDigest] = "sha256:12345678"
+			pvc.GetAnnotations()[AnnFailureArtifacts] = "import-failure-test-dv"
+			pvc.GetAnnotations()[AnnRunningCondition] = "false"
+			pvc.GetAnnotations()[AnnRunningConditionMessage] = "Unable to process data: Invalid format raw2 for image /data/disk.img"
+			pvc.GetAnnotations()[AnnRunningConditionReason] = cdiv1.DataVolumeReasonCorrupt
+			err = reconciler.client.Update(context.TODO(), pvc)
+			Expect(err).ToNot(HaveOccurred())
+			pvc.Status.Phase = corev1.ClaimBound
+			err = reconciler.client.Status().Update(context.TODO(), pvc)
+			Expect(err).ToNot(HaveOccurred())
+			_, err = reconciler.updateStatus(getReconcileRequest(dv), nil, reconciler)
+			Expect(err).ToNot(HaveOccurred())
+			dv = &cdiv1.DataVolume{}
+			err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}, dv)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(dv.Status.ObservedSourceDigest).To(Equal("sha256:12345678"))
+			Expect(dv.Status.FailureArtifacts).To(Equal("import-failure-test-dv"))
+			runningCondition := FindConditionByType(cdiv1.DataVolumeRunning, dv.Status.Conditions)
+			Expect(runningCondition).ToNot(BeNil())
+			Expect(runningCondition.Reason).To(Equal(cdiv1.DataVolumeReasonCorrupt))
+		})
+
+		It("Should annotate the PVC of imports expanding the filesystem", func() {
+			importDataVolume := NewImportDataVolume("test-dv")
+			importDataVolume.Spec.ExpandFilesystem = true
+			reconciler = createImportReconciler(importDataVolume)
+			_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}})
+			Expect(err).ToNot(HaveOccurred())
+			pvc := &corev1.PersistentVolumeClaim{}
+			err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}, pvc)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pvc.GetAnnotations()[AnnExpandFilesystem]).To(Equal("true"))
+		})
+
+		It("Should annotate the PVC of imports with the proxy of the DataVolume", func() {
+			importDataVolume := NewImportDataVolume("test-dv")
+			importDataVolume.Spec.Proxy = &cdiv1.DataVolumeProxy{HTTPSProxy: "https://proxy.example.com:3128", TrustedCAProxy: "proxy-ca"}
+			reconciler = createImportReconciler(importDataVolume)
+			_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}})
+			Expect(err).ToNot(HaveOccurred())
+			pvc := &corev1.PersistentVolumeClaim{}
+			err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}, pvc)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pvc.GetAnnotations()[AnnImportProxy]).To(MatchJSON(`{"httpsProxy":"https://proxy.example.com:3128","trustedCAProxy":"proxy-ca"}`))
+		})
+
+		DescribeTable("Should annotate the PVC of imports verifying the image", func(checksum bool) {
+			importDataVolume := NewImportDataVolume("test-dv")
+			importDataVolume.Spec.Verify = &cdiv1.DataVolumeVerify{Checksum: checksum}
+			reconciler = createImportReconciler(importDataVolume)
+			_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}})
+			Expect(err).ToNot(HaveOccurred())
+			pvc := &corev1.PersistentVolumeClaim{}
+			err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}, pvc)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pvc.GetAnnotations()).To(HaveKeyWithValue(AnnVerify, "true"))
+			if checksum {
+				Expect(pvc.GetAnnotations()).To(HaveKeyWithValue(AnnVerifyChecksum, "true"))
+			} else {
+				Expect(pvc.GetAnnotations()).ToNot(HaveKey(AnnVerifyChecksum))
+			}
+		},
+			Entry("with qemu-img check", false),
+			Entry("comparing it with its source", true),
+		)
+
+		DescribeTable("Should pick the architecture of multi-architecture registry images", func(platform *cdiv1.PlatformOptions, nodeSelector map[string]string, expected string) {
+			importDataVolume := NewImportDataVolume("test-dv")
+			importDataVolume.Spec.Source = &cdiv1.DataVolumeSource{
+				Registry: &cdiv1.DataVolumeSourceRegistry{URL: ptr.To("docker://registry/image"), Platform: platform},
+			}
+			if nodeSelector != nil {
+				importDataVolume.Spec.Topology = &cdiv1.DataVolumeTopology{NodeSelector: nodeSelector}
+			}
+			reconciler = createImportReconciler(importDataVolume)
+			cdiConfig := &cdiv1.CDIConfig{}
+			Expect(reconciler.client.Get(context.TODO(), types.NamespacedName{Name: common.ConfigName}, cdiConfig)).To(Succeed())
+			cdiConfig.Spec.DefaultImageArchitecture = "amd64"
+			Expect(reconciler.client.Update(context.TODO(), cdiConfig)).To(Succeed())
+
+			_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}})
+			Expect(err).ToNot(HaveOccurred())
+			pvc := &corev1.PersistentVolumeClaim{}
+			err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}, pvc)
+			Expect(err).ToNot(HaveOccurred())
+			if expected == "" {
+				Expect(pvc.GetAnnotations()).ToNot(HaveKey(AnnRegistryImageArchitecture))
+			} else {
+				Expect(pvc.GetAnnotations()).To(HaveKeyWithValue(AnnRegistryImageArchitecture, expected))
+			}
+		},
+			Entry("not without selecting it", nil, map[string]string{corev1.LabelArchStable: "arm64"}, ""),
+			Entry("from the node selector of the topology", &cdiv1.PlatformOptions{SelectArchitecture: true}, map[string]string{corev1.LabelArchStable: "arm64"}, "arm64"),
+			Entry("from the CDIConfig without node selector", &cdiv1.PlatformOptions{SelectArchitecture: true}, nil, "amd64"),
+			Entry("not over the architecture of the source", &cdiv1.PlatformOptions{Architecture: "s390x", SelectArchitecture: true}, map[string]string{corev1.LabelArchStable: "arm64"}, "s390x"),
+		)
+
+		It("Should pick the architecture of multi-architecture registry images when using populators", func() {
+			sc := CreateStorageClassWithProvisioner("testSC", map[string]string{AnnDefaultStorageClass: "true"}, map[string]string{}, "csi-plugin")
+			csiDriver := &storagev1.CSIDriver{ObjectMeta: metav1.ObjectMeta{Name: "csi-plugin"}}
+			importDataVolume := NewImportDataVolume("test-dv")
+			importDataVolume.Spec.Source = &cdiv1.DataVolumeSource{
+				Registry: &cdiv1.DataVolumeSourceRegistry{URL: ptr.To("docker://registry/image"), Platform: &cdiv1.PlatformOptions{SelectArchitecture: true}},
+			}
+			importDataVolume.Spec.Topology = &cdiv1.DataVolumeTopology{NodeSelector: map[string]string{corev1.LabelArchStable: "arm64"}}
+			reconciler = createImportReconcilerWFFCDisabled(importDataVolume, sc, csiDriver)
+			_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}})
+			Expect(err).ToNot(HaveOccurred())
+			pvc := &corev1.PersistentVolumeClaim{}
+			err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}, pvc)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pvc.Spec.DataSourceRef.Kind).To(Equal(cdiv1.VolumeImportSourceRef))
+			Expect(pvc.GetAnnotations()).To(HaveKeyWithValue(AnnRegistryImageArchitecture, "arm64"))
+		})
+
+		It("Should record the bytes transferred and the phase durations of the import in the status", func() {
+			reconciler = createImportReconciler(NewImportDataVolume("test-dv"))
+			_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}})
+			Expect(err).ToNot(HaveOccurred())
+			dv := &cdiv1.DataVolume{}
+			err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}, dv)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(dv.Status.CurrentPhaseStartTime).ToNot(BeNil())
+			dv.Status.TransferredBytes = ptr.To[int64](512)
+			dv.Status.TotalBytes = ptr.To[int64](1024)
+			err = reconciler.client.Status().Update(context.TODO(), dv)
+			Expect(err).ToNot(HaveOccurred())
+
+			pvc := &corev1.PersistentVolumeClaim{}
+			err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}, pvc)
+			Expect(err).ToNot(HaveOccurred())
+			pvc.GetAnnotations()[AnnPodPhase] = string(corev1.PodSucceeded)
+			pvc.GetAnnotations()[AnnImportPhaseDurations] = `{"download": "1m2s", "convert": "2.5s"}`
+			err = reconciler.client.Update(context.TODO(), pvc)
+			Expect(err).ToNot(HaveOccurred())
+			pvc.Status.Phase = corev1.ClaimBound
+			err = reconciler.client.Status().Update(context.TODO(), pvc)
+			Expect(err).ToNot(HaveOccurred())
+			_, err = reconciler.updateStatus(getReconcileRequest(dv), nil, reconciler)
+			Expect(err).ToNot(HaveOccurred())
+			dv = &cdiv1.DataVolume{}
+			err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}, dv)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(dv.Status.Phase).To(Equal(cdiv1.Succeeded))
+			Expect(dv.Status.TransferredBytes).To(HaveValue(Equal(int64(1024))))
+			Expect(dv.Status.TotalBytes).To(HaveValue(Equal(int64(1024))))
+			Expect(dv.Status.PhaseDurations).To(Equal(&cdiv1.DataVolumePhaseDurations{
+				Download: &metav1.Duration{Duration: 62 * time.Second},
+				Convert:  &metav1.Duration{Duration: 2500 * time.Millisecond},
+			}))
+		})
+
+		DescribeTable("Should garbage collect completed DataVolumes once their TTL expired", func(phase cdiv1.DataVolumePhase, policy *cdiv1.DataVolumeFailureRetentionPolicy, age time.Duration, deleted bool) {
+			dv := NewImportDataVolume("test-dv")
+			dv.Spec.TTLAfterCompletion = &metav1.Duration{Duration: time.Hour}
+			dv.Spec.FailureRetentionPolicy = policy
+			dv.Status.Phase = phase
+			dv.Status.CurrentPhaseStartTime = &metav1.Time{Time: time.Now().Add(-age)}
+			reconciler = createImportReconciler(dv)
+			res := reconcile.Result{}
+			err := reconciler.garbageCollect(reconciler.log, getReconcileRequest(dv), &res)
+			Expect(err).ToNot(HaveOccurred())
+			err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}, &cdiv1.DataVolume{})
+			if deleted {
+				Expect(errors.IsNotFound(err)).To(BeTrue())
+				return
+			}
+			Expect(err).ToNot(HaveOccurred())
+			if age < time.Hour {
+				Expect(res.RequeueAfter).To(BeNumerically("~", time.Hour-age, time.Minute))
+			}
+		},
+			Entry("succeeded past its TTL", cdiv1.Succeeded, nil, 2*time.Hour, true),
+			Entry("succeeded within its TTL", cdiv1.Succeeded, nil, 10*time.Minute, false),
+			Entry("failed and retained", cdiv1.Failed, ptr.To(cdiv1.FailureRetentionRetain), 2*time.Hour, false),
+			Entry("failed without a policy", cdiv1.Failed, nil, 2*time.Hour, false),
+			Entry("failed and deleted", cdiv1.Failed, ptr.To(cdiv1.FailureRetentionDelete), 2*time.Hour, true),
+			Entry("still importing", cdiv1.ImportInProgress, nil, 2*time.Hour, false),
+		)
+
+		It("Should not garbage collect DataVolumes owned by another controller", func() {
+			dv := NewImportDataVolume("test-dv")
+			dv.Spec.TTLAfterCompletion = &metav1.Duration{Duration: time.Minute}
+			dv.Status.Phase = cdiv1.Succeeded
+			dv.Status.CurrentPhaseStartTime = &metav1.Time{Time: time.Now().Add(-time.Hour)}
+			dv.OwnerReferences = []metav1.OwnerReference{{
+				APIVersion: "kubevirt.io/v1",
+				Kind:       "VirtualMachine",
+				Name:       "vm",
+				UID:        "vm-uid",
+				Controller: ptr.To(true),
+			}}
+			reconciler = createImportReconciler(dv)
+			res := reconcile.Result{}
+			err := reconciler.garbageCollect(reconciler.log, getReconcileRequest(dv), &res)
+			Expect(err).ToNot(HaveOccurred())
+			err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}, &cdiv1.DataVolume{})
+			Expect(err).ToNot(HaveOccurred())
+		})
+
 		It("Should switch to succeeded if PVC phase is pending, but pod phase is succeeded", func() {
 			reconciler = createImportReconciler(NewImportDataVolume("test-dv"))
 			_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}})
@@ -1547,6 +1930,7 @@ var _ = Describe("All DataVolume Tests", func() {
 			Entry("should switch to bound for import", NewImportDataVolume("test-dv"), cdiv1.Unknown, cdiv1.PVCBound, corev1.ClaimBound, corev1.PodPending, "invalid", "PVC test-dv Bound", AnnPriorityClassName, "p0"),
 			Entry("should switch to scheduled for import", NewImportDataVolume("test-dv"), cdiv1.Pending, cdiv1.ImportScheduled, corev1.ClaimBound, corev1.PodPending, AnnImportPod, "Import into test-dv scheduled", AnnPriorityClassName, "p0"),
 			Entry("should switch to inprogress for import", NewImportDataVolume("test-dv"), cdiv1.Pending, cdiv1.ImportInProgress, corev1.ClaimBound, corev1.PodRunning, AnnImportPod, "Import into test-dv in progress", AnnPriorityClassName, "p0"),
+			Entry("should switch to paused for a paused import", NewImportDataVolume("test-dv"), cdiv1.ImportInProgress, cdiv1.ImportPaused, corev1.ClaimBound, corev1.PodRunning, AnnImportPod, "Import into test-dv paused", AnnImportPaused, "true"),
 			Entry("should stay the same for import after pod fails", NewImportDataVolume("test-dv"), cdiv1.Pending, cdiv1.ImportScheduled, corev1.ClaimBound, corev1.PodFailed, AnnImportPod, "Failed to import into PVC test-dv", AnnPriorityClassName, "p0"),
 			Entry("should switch to failed on claim lost for impot", NewImportDataVolume("test-dv"), cdiv1.Pending, cdiv1.Failed, corev1.ClaimLost, corev1.PodFailed, AnnImportPod, "PVC test-dv lost", AnnPriorityClassName, "p0"),
 			Entry("should switch to succeeded for import", NewImportDataVolume("test-dv"), cdiv1.Pending, cdiv1.Succeeded, corev1.ClaimBound, corev1.PodSucceeded, AnnImportPod, "Successfully imported into PVC test-dv", AnnPriorityClassName, "p0"),
@@ -1986,6 +2370,8 @@ func createImportReconcilerWithoutConfig(objects ...client.Object) *ImportReconc
 			},
 			shouldUpdateProgress: true,
 		},
+		importerImage: "test/importer:latest",
+		pullPolicy:    string(corev1.PullIfNotPresent),
 	}
 	return r
 }
@@ -0,0 +1,392 @@
+/*
+Copyright 2026 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package datavolume
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/opencontainers/go-digest"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+	"kubevirt.io/containerized-data-importer/pkg/common"
+	"kubevirt.io/containerized-data-importer/pkg/controller/clone"
+	cc "kubevirt.io/containerized-data-importer/pkg/controller/common"
+	"kubevirt.io/containerized-data-importer/pkg/controller/populators"
+)
+
+const (
+	// ImportDeduplicated provides a const to indicate the source of an import is cloned from a PVC that imported it
+	ImportDeduplicated = "ImportDeduplicated"
+	// MessageImportDeduplicated provides a const to form the message when the source of an import is cloned from a PVC
+	MessageImportDeduplicated = "Cloning PVC %s, which imported the same source, into PVC %s"
+	// MessageImportDeduplicationSucceeded provides a const to form the message when the clone of a deduplicated import succeeded
+	MessageImportDeduplicationSucceeded = "Successfully cloned PVC %s, which imported the same source, into PVC %s"
+	// DeduplicationInvalidated provides a const to indicate a PVC is no longer cloned by deduplicating DataVolumes
+	DeduplicationInvalidated = "DeduplicationInvalidated"
+	// MessageDeduplicationInvalidated provides a const to form the message when a PVC is no longer cloned by deduplicating DataVolumes
+	MessageDeduplicationInvalidated = "Pod %s mounted PVC %s read-write, it is no longer cloned by deduplicating DataVolumes"
+)
+
+// deduplicatedAnnotations are the annotations of the import of the source PVC a deduplicated PVC reports as its own
+var deduplicatedAnnotations = []string{cc.AnnSourceDigest, cc.AnnObservedSourceDigest, cc.AnnDetectedContentType, cc.AnnImportedPlatform}
+
+// DeduplicationDigest returns the digest the source of a DataVolume is pinned to, as algorithm:hex: the digest of the
+// url of registry sources, or the checksum of http, s3 and gcs sources. It is empty if the source is not pinned.
+func DeduplicationDigest(source *cdiv1.DataVolumeSource) string {
+	if source == nil {
+		return ""
+	}
+	var checksum *cdiv1.DataVolumeChecksum
+	switch {
+	case source.Registry != nil:
+		if source.Registry.URL == nil {
+			return ""
+		}
+		url := *source.Registry.URL
+		i := strings.LastIndex(url, "@")
+		if i < 0 {
+			return ""
+		}
+		pinned, err := digest.Parse(url[i+1:])
+		if err != nil {
+			return ""
+		}
+		return pinned.String()
+	case source.HTTP != nil:
+		// The checksum of OVA and XVA sources is the one of the whole archive, not of the imported disk
+		if source.HTTP.OVA == nil && source.HTTP.XVA == nil {
+			checksum = source.HTTP.Checksum
+		}
+	case source.S3 != nil:
+		checksum = source.S3.Checksum
+	case source.GCS != nil:
+		checksum = source.GCS.Checksum
+	}
+	if checksum == nil {
+		return ""
+	}
+	return fmt.Sprintf("%s:%s", checksum.Algorithm, strings.ToLower(checksum.Value))
+}
+
+// getDeduplicationKey returns the key of the content the PVC of a deduplicating DataVolume is imported with, empty if
+// the DataVolume does not deduplicate its import. PVCs with the same key hold the same data.
+func (r *ImportReconciler) getDeduplicationKey(dv *cdiv1.DataVolume) (string, error) {
+	if !dv.Spec.Deduplicate {
+		return "", nil
+	}
+	pinned := DeduplicationDigest(dv.Spec.Source)
+	if pinned == "" {
+		return "", nil
+	}
+	key := fmt.Sprintf("%s/%s", cc.GetContentType(dv.Spec.ContentType), pinned)
+	if registry := dv.Spec.Source.Registry; registry != nil {
+		annotations := map[string]string{}
+		cc.UpdateRegistryAnnotations(annotations, registry)
+		if err := r.updateRegistryImageArchitecture(dv, annotations); err != nil {
+			return "", err
+		}
+		if architecture := annotations[cc.AnnRegistryImageArchitecture]; architecture != "" {
+			key = fmt.Sprintf("%s/%s", key, architecture)
+		}
+	}
+	return key, nil
+}
+
+// updateDeduplicationKeyAnnotation records the deduplication key of the DataVolume on its PVC, so the DataVolumes
+// importing the same content can later clone it
+func (r *ImportReconciler) updateDeduplicationKeyAnnotation(dataVolume *cdiv1.DataVolume, pvc *corev1.PersistentVolumeClaim) error {
+	key, err := r.getDeduplicationKey(dataVolume)
+	if err != nil {
+		return err
+	}
+	if key != "" {
+		cc.AddAnnotation(pvc, cc.AnnDeduplicationKey, key)
+	}
+	return nil
+}
+
+func deduplicationIndexKey(storageClassName, key string) string {
+	return storageClassName + "/" + key
+}
+
+func extractDeduplicationKey(obj client.Object) []string {
+	pvc, ok := obj.(*corev1.PersistentVolumeClaim)
+	if !ok || pvc.Spec.StorageClassName == nil {
+		return nil
+	}
+	if key := pvc.Annotations[cc.AnnDeduplicationKey]; key != "" {
+		return []string{deduplicationIndexKey(*pvc.Spec.StorageClassName, key)}
+	}
+	return nil
+}
+
+// isDeduplicationWriter returns true if the pod may modify the content of the PVCs it mounts read-write: the pods of
+// CDI only write the PVCs they import or clone
+func isDeduplicationWriter(pod *corev1.Pod) bool {
+	return pod.Labels[common.CDILabelKey] != common.CDILabelValue
+}
+
+// getDeduplicationWriter returns a pod, other than the ones of CDI, mounting the PVC read-write, nil if there is none
+func (r *ImportReconciler) getDeduplicationWriter(pvc *corev1.PersistentVolumeClaim) (*corev1.Pod, error) {
+	pods, err := cc.GetPodsUsingPVCs(context.TODO(), r.client, pvc.Namespace, sets.New(pvc.Name), true)
+	if err != nil {
+		return nil, err
+	}
+	for i := range pods {
+		if isDeduplicationWriter(&pods[i]) {
+			return &pods[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// invalidateDeduplicationKey removes the deduplication key of the PVC once a pod other than the ones of CDI mounts it
+// read-write: the PVC may then no longer hold the content it imported, so deduplicating DataVolumes must not clone it
+func (r *ImportReconciler) invalidateDeduplicationKey(dv *cdiv1.DataVolume, pvc *corev1.PersistentVolumeClaim) error {
+	if pvc.Annotations[cc.AnnDeduplicationKey] == "" {
+		return nil
+	}
+	pod, err := r.getDeduplicationWriter(pvc)
+	if err != nil || pod == nil {
+		return err
+	}
+	delete(pvc.Annotations, cc.AnnDeduplicationKey)
+	cc.AddAnnotation(pvc, cc.AnnDeduplicationInvalidatedBy, pod.Name)
+	if err := r.updatePVC(pvc); err != nil {
+		return err
+	}
+	r.recorder.Eventf(dv, corev1.EventTypeNormal, DeduplicationInvalidated, MessageDeduplicationInvalidated, pod.Name, pvc.Name)
+	return nil
+}
+
+// addDeduplicationWriterWatch reconciles the DataVolumes of the PVCs with a deduplication key that pods other than the
+// ones of CDI mount read-write, to invalidate their key
+func addDeduplicationWriterWatch(mgr manager.Manager, datavolumeController controller.Controller) error {
+	return datavolumeController.Watch(source.Kind(mgr.GetCache(), &corev1.Pod{}, handler.TypedEnqueueRequestsFromMapFunc[*corev1.Pod](
+		func(ctx context.Context, pod *corev1.Pod) []reconcile.Request {
+			if !isDeduplicationWriter(pod) {
+				return nil
+			}
+			var reqs []reconcile.Request
+			for _, volume := range pod.Spec.Volumes {
+				if volume.PersistentVolumeClaim == nil || volume.PersistentVolumeClaim.ReadOnly {
+					continue
+				}
+				pvc := &corev1.PersistentVolumeClaim{}
+				if err := mgr.GetClient().Get(ctx, types.NamespacedName{Namespace: pod.Namespace, Name: volume.PersistentVolumeClaim.ClaimName}, pvc); err != nil {
+					continue
+				}
+				owner := metav1.GetControllerOf(pvc)
+				if pvc.Annotations[cc.AnnDeduplicationKey] == "" || owner == nil || owner.Kind != "DataVolume" {
+					continue
+				}
+				reqs = append(reqs, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: pod.Namespace, Name: owner.Name}})
+			}
+			return reqs
+		}),
+	))
+}
+
+func isPVCDeduplicated(pvc *corev1.PersistentVolumeClaim) bool {
+	return pvc != nil && populators.IsPVCDataSourceRefKind(pvc, cdiv1.VolumeCloneSourceRef)
+}
+
+// isDeduplicationSourceComplete returns true once the content of a PVC with a deduplication key was imported, or
+// cloned from another one
+func isDeduplicationSourceComplete(pvc *corev1.PersistentVolumeClaim) bool {
+	if isPVCDeduplicated(pvc) {
+		return pvc.Annotations[populators.AnnClonePhase] == clone.SucceededPhaseName
+	}
+	return cc.IsPVCComplete(pvc) && !cc.IsMultiStageImportInProgress(pvc)
+}
+
+// getDeduplicationSource returns the PVC the import of a deduplicating DataVolume can be cloned from: a complete PVC
+// of its namespace, in the same storage class, that imported the same content and is not larger than the DataVolume.
+// The PVCs that were mounted read-write by other pods than the ones of CDI lost their key, and are not cloned.
+// It returns nil if there is none, the DataVolume then imports its source. The size of DataVolumes without one is
+// taken from the PVC.
+func (r *ImportReconciler) getDeduplicationSource(syncState *dvSyncState) (*corev1.PersistentVolumeClaim, error) {
+	dv := syncState.dvMutated
+	if !syncState.usePopulator {
+		return nil, nil
+	}
+	key, err := r.getDeduplicationKey(dv)
+	if err != nil || key == "" {
+		return nil, err
+	}
+	storageClass, err := cc.GetStorageClassByNameWithK8sFallback(context.TODO(), r.client, syncState.pvcSpec.StorageClassName)
+	if err != nil || storageClass == nil {
+		return nil, err
+	}
+
+	pvcs := &corev1.PersistentVolumeClaimList{}
+	if err := r.client.List(context.TODO(), pvcs, client.InNamespace(dv.Namespace),
+		client.MatchingFields{claimDeduplicationKeyField: deduplicationIndexKey(storageClass.Name, key)}); err != nil {
+		return nil, err
+	}
+	requestedSize := syncState.pvcSpec.Resources.Requests[corev1.ResourceStorage]
+	for i := range pvcs.Items {
+		pvc := &pvcs.Items[i]
+		if pvc.Name == dv.Name || pvc.DeletionTimestamp != nil || !cc.IsBound(pvc) || !isDeduplicationSourceComplete(pvc) {
+			continue
+		}
+		size := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+		if !requestedSize.IsZero() && size.Cmp(requestedSize) > 0 {
+			continue
+		}
+		// The watch of the pods may not have invalidated the key yet
+		if writer, err := r.getDeduplicationWriter(pvc); err != nil || writer != nil {
+			if err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if requestedSize.IsZero() {
+			if syncState.pvcSpec.Resources.Requests == nil {
+				syncState.pvcSpec.Resources.Requests = corev1.ResourceList{}
+			}
+			syncState.pvcSpec.Resources.Requests[corev1.ResourceStorage] = size
+		}
+		return pvc, nil
+	}
+	return nil, nil
+}
+
+// reconcileDeduplicationSourceCR creates the VolumeCloneSource the clone populator clones the source PVC of a
+// deduplicated import with
+func (r *ImportReconciler) reconcileDeduplicationSourceCR(dv *cdiv1.DataVolume, source *corev1.PersistentVolumeClaim) error {
+	cloneSource := &cdiv1.VolumeCloneSource{}
+	exists, err := cc.GetResource(context.TODO(), r.client, dv.Namespace, volumeCloneSourceName(dv), cloneSource)
+	if err != nil {
+		return err
+	}
+	if exists {
+		// An earlier attempt to create the PVC picked another source
+		if cloneSource.Spec.Source.Name == source.Name {
+			return nil
+		}
+		cloneSource.Spec.Source.Name = source.Name
+		return r.client.Update(context.TODO(), cloneSource)
+	}
+
+	cloneSource = &cdiv1.VolumeCloneSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      volumeCloneSourceName(dv),
+			Namespace: dv.Namespace,
+		},
+		Spec: cdiv1.VolumeCloneSourceSpec{
+			Source: corev1.TypedLocalObjectReference{
+				Kind: "PersistentVolumeClaim",
+				Name: source.Name,
+			},
+			Preallocation: dv.Spec.Preallocation,
+		},
+	}
+	if dv.Spec.PriorityClassName != "" {
+		cloneSource.Spec.PriorityClassName = &dv.Spec.PriorityClassName
+	}
+	if dv.Spec.CloneTransferMode != nil {
+		cloneSource.Spec.TransferMode = dv.Spec.CloneTransferMode
+	}
+	if dv.Spec.CloneCompression != nil {
+		cloneSource.Spec.Compression = dv.Spec.CloneCompression
+	}
+	cloneSource.Spec.Strategy = dv.Spec.CloneStrategy
+	cloneSource.Spec.StrategyFallbacks = dv.Spec.CloneStrategyFallbacks
+	cloneSource.Spec.Group = dv.Spec.CloneGroup
+	if err := controllerutil.SetControllerReference(dv, cloneSource, r.scheme); err != nil {
+		return err
+	}
+	if err := r.client.Create(context.TODO(), cloneSource); err != nil && !k8serrors.IsAlreadyExists(err) {
+		return err
+	}
+	return nil
+}
+
+// deleteDeduplicationSourceCR deletes the VolumeCloneSource of a deduplicated import once it succeeded
+func (r *ImportReconciler) deleteDeduplicationSourceCR(dv *cdiv1.DataVolume) error {
+	cloneSource := &cdiv1.VolumeCloneSource{}
+	exists, err := cc.GetResource(context.TODO(), r.client, dv.Namespace, volumeCloneSourceName(dv), cloneSource)
+	if err != nil || !exists {
+		return err
+	}
+	if err := r.client.Delete(context.TODO(), cloneSource); err != nil && !k8serrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// deduplicatedPVCModifier returns the modifier of the PVC of a DataVolume cloning source, instead of importing it
+func (r *ImportReconciler) deduplicatedPVCModifier(source *corev1.PersistentVolumeClaim) pvcModifierFunc {
+	return func(dataVolume *cdiv1.DataVolume, pvc *corev1.PersistentVolumeClaim) error {
+		if err := cc.AddImmediateBindingAnnotationIfWFFCDisabled(pvc, r.featureGates); err != nil {
+			return err
+		}
+		cc.AddAnnotation(pvc, cc.AnnDeduplicationKey, source.Annotations[cc.AnnDeduplicationKey])
+		cc.AddAnnotation(pvc, cc.AnnDeduplicatedFrom, source.Name)
+		for _, ann := range deduplicatedAnnotations {
+			if value, ok := source.Annotations[ann]; ok {
+				cc.AddAnnotation(pvc, ann, value)
+			}
+		}
+		apiGroup := cc.AnnAPIGroup
+		pvc.Spec.DataSourceRef = &corev1.TypedObjectReference{
+			APIGroup: &apiGroup,
+			Kind:     cdiv1.VolumeCloneSourceRef,
+			Name:     volumeCloneSourceName(dataVolume),
+		}
+		return nil
+	}
+}
+
+// updateDeduplicatedStatusPhase follows the clone populator cloning the source PVC of a deduplicated import, and
+// reports the import of the source PVC once the clone succeeded
+func updateDeduplicatedStatusPhase(pvc *corev1.PersistentVolumeClaim, dataVolumeCopy *cdiv1.DataVolume, event *Event) error {
+	phase, ok := populatorPhaseMap[pvc.Annotations[populators.AnnClonePhase]]
+	// Avoid setting DV to failed for consistency with the clones
+	if !ok || phase == cdiv1.Failed {
+		return nil
+	}
+	dataVolumeCopy.Status.Phase = phase
+	if phase != cdiv1.Succeeded {
+		return nil
+	}
+	dataVolumeCopy.Status.Progress = cdiv1.DataVolumeProgress("100.0%")
+	if err := updateImportedSourceStatus(pvc, dataVolumeCopy); err != nil {
+		return err
+	}
+	event.eventType = corev1.EventTypeNormal
+	event.reason = ImportSucceeded
+	event.message = fmt.Sprintf(MessageImportDeduplicationSucceeded, pvc.Annotations[cc.AnnDeduplicatedFrom], pvc.Name)
+	return nil
+}
@@ -0,0 +1,268 @@
+/*
+Copyright 2026 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package datavolume
+
+import (
+	"context"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+	"kubevirt.io/containerized-data-importer/pkg/common"
+	"kubevirt.io/containerized-data-importer/pkg/controller/clone"
+	. "kubevirt.io/containerized-data-importer/pkg/controller/common"
+	"kubevirt.io/containerized-data-importer/pkg/controller/populators"
+)
+
+var _ = Describe("Import deduplication", func() {
+	const scName = "testSC"
+
+	var (
+		reconciler *ImportReconciler
+		dvKey      = types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}
+		checksum   = strings.Repeat("0123456789ABCDEF", 4)
+		key        = "kubevirt/sha256:" + strings.ToLower(checksum)
+		sc         = CreateStorageClassWithProvisioner(scName, map[string]string{AnnDefaultStorageClass: "true"}, nil, "csi-plugin")
+		csiDriver  = &storagev1.CSIDriver{ObjectMeta: metav1.ObjectMeta{Name: "csi-plugin"}}
+	)
+
+	AfterEach(func() {
+		if reconciler != nil {
+			close(reconciler.recorder.(*record.FakeRecorder).Events)
+			reconciler = nil
+		}
+	})
+
+	newDeduplicatingDataVolume := func() *cdiv1.DataVolume {
+		dv := NewImportDataVolume("test-dv")
+		dv.Spec.Source.HTTP.Checksum = &cdiv1.DataVolumeChecksum{Algorithm: cdiv1.ChecksumSHA256, Value: checksum}
+		dv.Spec.Deduplicate = true
+		return dv
+	}
+
+	newImportedPvc := func(name, size string, annotations map[string]string) *corev1.PersistentVolumeClaim {
+		anno := map[string]string{
+			AnnDeduplicationKey:     key,
+			AnnPodPhase:             string(corev1.PodSucceeded),
+			AnnObservedSourceDigest: "sha256:" + strings.ToLower(checksum),
+		}
+		for k, v := range annotations {
+			anno[k] = v
+		}
+		pvc := CreatePvcInStorageClass(name, metav1.NamespaceDefault, ptr.To(scName), anno, nil, corev1.ClaimBound)
+		pvc.Spec.Resources.Requests = corev1.ResourceList{corev1.ResourceStorage: resource.MustParse(size)}
+		return pvc
+	}
+
+	reconcileDataVolume := func() *corev1.PersistentVolumeClaim {
+		_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: dvKey})
+		Expect(err).ToNot(HaveOccurred())
+		pvc := &corev1.PersistentVolumeClaim{}
+		Expect(reconciler.client.Get(context.TODO(), dvKey, pvc)).To(Succeed())
+		return pvc
+	}
+
+	It("Should import the source and record its deduplication key without a PVC that imported it", func() {
+		reconciler = createImportReconcilerWFFCDisabled(newDeduplicatingDataVolume(), sc, csiDriver)
+		pvc := reconcileDataVolume()
+		Expect(pvc.Spec.DataSourceRef.Kind).To(Equal(cdiv1.VolumeImportSourceRef))
+		Expect(pvc.Annotations).To(HaveKeyWithValue(AnnDeduplicationKey, key))
+	})
+
+	It("Should clone the PVC that imported the same source", func() {
+		source := newImportedPvc("source", "1Gi", nil)
+		reconciler = createImportReconcilerWFFCDisabled(newDeduplicatingDataVolume(), sc, csiDriver, source)
+		pvc := reconcileDataVolume()
+		Expect(pvc.Spec.DataSourceRef.Kind).To(Equal(cdiv1.VolumeCloneSourceRef))
+		Expect(pvc.Spec.Resources.Requests.Storage().Cmp(resource.MustParse("1Gi"))).To(BeZero())
+		Expect(pvc.Annotations).To(HaveKeyWithValue(AnnDeduplicationKey, key))
+		Expect(pvc.Annotations).To(HaveKeyWithValue(AnnDeduplicatedFrom, "source"))
+		Expect(pvc.Annotations).To(HaveKeyWithValue(AnnObservedSourceDigest, "sha256:"+strings.ToLower(checksum)))
+		Expect(pvc.Annotations).ToNot(HaveKey(AnnEndpoint))
+
+		cloneSource := &cdiv1.VolumeCloneSource{}
+		Expect(reconciler.client.Get(context.TODO(), types.NamespacedName{Namespace: metav1.NamespaceDefault, Name: pvc.Spec.DataSourceRef.Name}, cloneSource)).To(Succeed())
+		Expect(cloneSource.Spec.Source.Kind).To(Equal("PersistentVolumeClaim"))
+		Expect(cloneSource.Spec.Source.Name).To(Equal("source"))
+		Expect(metav1.IsControlledBy(cloneSource, &cdiv1.DataVolume{ObjectMeta: metav1.ObjectMeta{UID: "default-test-dv"}})).To(BeTrue())
+		err := reconciler.client.Get(context.TODO(), types.NamespacedName{Namespace: metav1.NamespaceDefault, Name: volumeImportSourceName(newDeduplicatingDataVolume())}, &cdiv1.VolumeImportSource{})
+		Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+		Expect(reconciler.recorder.(*record.FakeRecorder).Events).To(Receive(ContainSubstring(ImportDeduplicated)))
+	})
+
+	DescribeTable("Should import the source instead of cloning", func(dv *cdiv1.DataVolume, source *corev1.PersistentVolumeClaim) {
+		reconciler = createImportReconcilerWFFCDisabled(dv, sc, csiDriver, source)
+		pvc := reconcileDataVolume()
+		Expect(pvc.Spec.DataSourceRef.Kind).To(Equal(cdiv1.VolumeImportSourceRef))
+	},
+		Entry("without deduplicate", func() *cdiv1.DataVolume {
+			dv := newDeduplicatingDataVolume()
+			dv.Spec.Deduplicate = false
+			return dv
+		}(), newImportedPvc("source", "1Gi", nil)),
+		Entry("a PVC still importing", newDeduplicatingDataVolume(), newImportedPvc("source", "1Gi", map[string]string{AnnPodPhase: string(corev1.PodRunning)})),
+		Entry("a PVC importing another content type", newDeduplicatingDataVolume(), newImportedPvc("source", "1Gi", map[string]string{AnnDeduplicationKey: "archive/sha256:" + strings.ToLower(checksum)})),
+		Entry("a PVC larger than the DataVolume", func() *cdiv1.DataVolume {
+			dv := newDeduplicatingDataVolume()
+			dv.Spec.PVC.Resources.Requests = corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("1Gi")}
+			return dv
+		}(), newImportedPvc("source", "2Gi", nil)),
+		Entry("a PVC of another storage class", newDeduplicatingDataVolume(), func() *corev1.PersistentVolumeClaim {
+			pvc := newImportedPvc("source", "1Gi", nil)
+			pvc.Spec.StorageClassName = ptr.To("other")
+			return pvc
+		}()),
+		Entry("a PVC of another namespace", newDeduplicatingDataVolume(), func() *corev1.PersistentVolumeClaim {
+			pvc := newImportedPvc("source", "1Gi", nil)
+			pvc.Namespace = "other"
+			return pvc
+		}()),
+	)
+
+	newPodUsingPvc := func(claimName string, readOnly bool, labels map[string]string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "vm-pod", Namespace: metav1.NamespaceDefault, Labels: labels},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{
+					Name:         "compute",
+					VolumeMounts: []corev1.VolumeMount{{Name: "disk", MountPath: "/disk", ReadOnly: readOnly}},
+				}},
+				Volumes: []corev1.Volume{{
+					Name: "disk",
+					VolumeSource: corev1.VolumeSource{
+						PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: claimName, ReadOnly: readOnly},
+					},
+				}},
+			},
+			Status: corev1.PodStatus{Phase: corev1.PodRunning},
+		}
+	}
+
+	It("Should not clone a PVC another pod mounts read-write", func() {
+		source := newImportedPvc("source", "1Gi", nil)
+		reconciler = createImportReconcilerWFFCDisabled(newDeduplicatingDataVolume(), sc, csiDriver, source, newPodUsingPvc("source", false, nil))
+		pvc := reconcileDataVolume()
+		Expect(pvc.Spec.DataSourceRef.Kind).To(Equal(cdiv1.VolumeImportSourceRef))
+	})
+
+	DescribeTable("Should clone a PVC mounted by", func(pod *corev1.Pod) {
+		source := newImportedPvc("source", "1Gi", nil)
+		reconciler = createImportReconcilerWFFCDisabled(newDeduplicatingDataVolume(), sc, csiDriver, source, pod)
+		pvc := reconcileDataVolume()
+		Expect(pvc.Annotations).To(HaveKeyWithValue(AnnDeduplicatedFrom, "source"))
+	},
+		Entry("a read-only pod", newPodUsingPvc("source", true, nil)),
+		Entry("a CDI pod", newPodUsingPvc("source", false, map[string]string{common.CDILabelKey: common.CDILabelValue})),
+	)
+
+	It("Should invalidate the deduplication key once another pod mounts the PVC read-write", func() {
+		reconciler = createImportReconcilerWFFCDisabled(newDeduplicatingDataVolume(), sc, csiDriver)
+		pvc := reconcileDataVolume()
+		Expect(pvc.Annotations).To(HaveKeyWithValue(AnnDeduplicationKey, key))
+
+		Expect(reconciler.client.Create(context.TODO(), newPodUsingPvc(pvc.Name, true, nil))).To(Succeed())
+		pvc = reconcileDataVolume()
+		Expect(pvc.Annotations).To(HaveKeyWithValue(AnnDeduplicationKey, key))
+
+		Expect(reconciler.client.Create(context.TODO(), func() *corev1.Pod {
+			pod := newPodUsingPvc(pvc.Name, false, nil)
+			pod.Name = "writer"
+			return pod
+		}())).To(Succeed())
+		pvc = reconcileDataVolume()
+		Expect(pvc.Annotations).ToNot(HaveKey(AnnDeduplicationKey))
+		Expect(pvc.Annotations).To(HaveKeyWithValue(AnnDeduplicationInvalidatedBy, "writer"))
+
+		close(reconciler.recorder.(*record.FakeRecorder).Events)
+		found := false
+		for event := range reconciler.recorder.(*record.FakeRecorder).Events {
+			if strings.Contains(event, DeduplicationInvalidated) {
+				found = true
+			}
+		}
+		reconciler = nil
+		Expect(found).To(BeTrue())
+	})
+
+	It("Should clone a PVC that was itself deduplicated once its clone succeeded", func() {
+		source := newImportedPvc("source", "1Gi", map[string]string{populators.AnnClonePhase: clone.SucceededPhaseName})
+		delete(source.Annotations, AnnPodPhase)
+		source.Spec.DataSourceRef = &corev1.TypedObjectReference{APIGroup: ptr.To(AnnAPIGroup), Kind: cdiv1.VolumeCloneSourceRef, Name: "volume-clone-source"}
+		reconciler = createImportReconcilerWFFCDisabled(newDeduplicatingDataVolume(), sc, csiDriver, source)
+		pvc := reconcileDataVolume()
+		Expect(pvc.Annotations).To(HaveKeyWithValue(AnnDeduplicatedFrom, "source"))
+	})
+
+	It("Should report the import of the source PVC once cloned and delete the VolumeCloneSource", func() {
+		dv := newDeduplicatingDataVolume()
+		dv.Annotations = map[string]string{AnnUsePopulator: "true"}
+		pvc := newImportedPvc("test-dv", "1Gi", map[string]string{
+			AnnUsePopulator:          "true",
+			AnnDeduplicatedFrom:      "source",
+			AnnSourceDigest:          "sha256:" + strings.ToLower(checksum),
+			populators.AnnClonePhase: clone.SucceededPhaseName,
+		})
+		delete(pvc.Annotations, AnnPodPhase)
+		pvc.Spec.DataSourceRef = &corev1.TypedObjectReference{APIGroup: ptr.To(AnnAPIGroup), Kind: cdiv1.VolumeCloneSourceRef, Name: volumeCloneSourceName(dv)}
+		pvc.OwnerReferences = []metav1.OwnerReference{{Kind: "DataVolume", Controller: ptr.To(true), Name: dv.Name, UID: dv.UID}}
+		cloneSource := &cdiv1.VolumeCloneSource{ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: volumeCloneSourceName(dv)}}
+		reconciler = createImportReconcilerWFFCDisabled(dv, sc, csiDriver, pvc, cloneSource)
+		reconcileDataVolume()
+
+		Expect(reconciler.client.Get(context.TODO(), dvKey, dv)).To(Succeed())
+		Expect(dv.Status.Phase).To(Equal(cdiv1.Succeeded))
+		Expect(dv.Status.SourceDigest).To(Equal("sha256:" + strings.ToLower(checksum)))
+		Expect(dv.Status.ObservedSourceDigest).To(Equal("sha256:" + strings.ToLower(checksum)))
+		Expect(reconciler.recorder.(*record.FakeRecorder).Events).To(Receive(ContainSubstring("Successfully cloned PVC source")))
+
+		reconcileDataVolume()
+		err := reconciler.client.Get(context.TODO(), client.ObjectKeyFromObject(cloneSource), cloneSource)
+		Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+	})
+
+	DescribeTable("Should find the digest a source is pinned to", func(source *cdiv1.DataVolumeSource, expected string) {
+		Expect(DeduplicationDigest(source)).To(Equal(expected))
+	},
+		Entry("of a registry image digest", &cdiv1.DataVolumeSource{Registry: &cdiv1.DataVolumeSourceRegistry{
+			URL: ptr.To("docker://registry:5000/image@sha256:" + strings.Repeat("ab", 32)),
+		}}, "sha256:"+strings.Repeat("ab", 32)),
+		Entry("not of a registry image tag", &cdiv1.DataVolumeSource{Registry: &cdiv1.DataVolumeSourceRegistry{URL: ptr.To("docker://registry:5000/image:latest")}}, ""),
+		Entry("of a checksum", &cdiv1.DataVolumeSource{S3: &cdiv1.DataVolumeSourceS3{
+			URL:      "https://s3.example.com/bucket/disk.img",
+			Checksum: &cdiv1.DataVolumeChecksum{Algorithm: cdiv1.ChecksumMD5, Value: strings.Repeat("AB", 16)},
+		}}, "md5:"+strings.Repeat("ab", 16)),
+		Entry("not of the checksum of an OVA", &cdiv1.DataVolumeSource{HTTP: &cdiv1.DataVolumeSourceHTTP{
+			URL:      "https://example.com/vm.ova",
+			OVA:      &cdiv1.DataVolumeSourceOVA{},
+			Checksum: &cdiv1.DataVolumeChecksum{Algorithm: cdiv1.ChecksumMD5, Value: strings.Repeat("ab", 16)},
+		}}, ""),
+	)
+})
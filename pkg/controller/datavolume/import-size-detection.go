@@ -0,0 +1,441 @@
+/*
+Copyright 2026 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package datavolume
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+	"kubevirt.io/containerized-data-importer/pkg/common"
+	cc "kubevirt.io/containerized-data-importer/pkg/controller/common"
+	"kubevirt.io/containerized-data-importer/pkg/util"
+	"kubevirt.io/containerized-data-importer/pkg/util/naming"
+)
+
+const (
+	// ErrSizeDetectionFailed provides a const to indicate the size of the import source could not be detected
+	ErrSizeDetectionFailed = "ErrSizeDetectionFailed"
+	// MessageSizeDetectionFailed provides a const to form the message when the size of the import source could not be detected
+	MessageSizeDetectionFailed = "Size of the source of %s could not be detected, the storage size must be set: %s"
+
+	sizeDetectionCertVolName         = "cdi-cert-vol"
+	sourceInspectionSecretVolName    = "cdi-secret-vol"
+	sourceInspectionProxyCertVolName = "cdi-proxy-cert-vol"
+)
+
+// CanDetectImportSize returns true if the storage size of the DataVolume can be left empty, the size of the PVC
+// being detected from the virtual size of the source image. Only plain HTTP sources are inspected.
+func CanDetectImportSize(spec *cdiv1.DataVolumeSpec) bool {
+	if spec.Storage == nil || spec.Source == nil || spec.Source.HTTP == nil {
+		return false
+	}
+	http := spec.Source.HTTP
+	return http.ClientCertSecretRef == "" && http.OAuth2SecretRef == "" && len(http.SecretExtraHeaders) == 0 &&
+		http.OVA == nil && http.XVA == nil
+}
+
+// detectImportSize sets the size of the PVC of imports without a storage size, inspecting the source image in a
+// size-detection pod. It returns true once the size is known.
+func (r *ImportReconciler) detectImportSize(syncState *dvSyncState) (bool, error) {
+	dv := syncState.dvMutated
+	requestedSize, hasSize := syncState.pvcSpec.Resources.Requests[corev1.ResourceStorage]
+	if (hasSize && !requestedSize.IsZero()) || !CanDetectImportSize(&dv.Spec) {
+		return true, nil
+	}
+
+	pod, err := r.getOrCreateImportSizeDetectionPod(dv)
+	if err != nil {
+		return false, err
+	}
+	if pod.Status.Phase == corev1.PodFailed {
+		return false, r.failImportSizeDetection(syncState, "size-detection pod failed")
+	}
+	if !isPodComplete(pod) {
+		r.recorder.Event(dv, corev1.EventTypeNormal, SizeDetectionPodNotReady, MessageSizeDetectionPodNotReady)
+		return false, nil
+	}
+
+	validation, err := getSourceInspectionResult(pod)
+	if err != nil {
+		return false, r.failImportSizeDetection(syncState, err.Error())
+	}
+	if validation.VirtualSize == nil {
+		message := "the size of the source can only be known once transferred"
+		if !validation.Valid {
+			message = validation.Message
+		}
+		return false, r.failImportSizeDetection(syncState, message)
+	}
+
+	targetCapacity, err := cc.InflateSizeWithOverhead(context.TODO(), r.client, *validation.VirtualSize, syncState.pvcSpec)
+	if err != nil {
+		return false, err
+	}
+	if syncState.pvcSpec.Resources.Requests == nil {
+		syncState.pvcSpec.Resources.Requests = corev1.ResourceList{}
+	}
+	syncState.pvcSpec.Resources.Requests[corev1.ResourceStorage] = targetCapacity
+	r.log.V(1).Info("Detected the size of the import source", "DataVolume", dv.Name, "virtualSize", *validation.VirtualSize, "size", targetCapacity.String())
+
+	if err := r.client.Delete(context.TODO(), pod); err != nil && !k8serrors.IsNotFound(err) {
+		return false, err
+	}
+	return true, nil
+}
+
+// failImportSizeDetection records why the size could not be detected, the DataVolume waits to be recreated with a size
+func (r *ImportReconciler) failImportSizeDetection(syncState *dvSyncState, reason string) error {
+	message := fmt.Sprintf(MessageSizeDetectionFailed, syncState.dvMutated.Name, reason)
+	r.recorder.Event(syncState.dvMutated, corev1.EventTypeWarning, ErrSizeDetectionFailed, message)
+	syncState.result = &reconcile.Result{}
+	return nil
+}
+
+// getSourceInspectionResult parses the validation of the source from the termination message of the pod
+func getSourceInspectionResult(pod *corev1.Pod) (*common.SourceValidation, error) {
+	if len(pod.Status.ContainerStatuses) == 0 || pod.Status.ContainerStatuses[0].State.Terminated == nil {
+		return nil, fmt.Errorf("pod %s has no termination message", pod.Name)
+	}
+	termMsg := &common.TerminationMessage{}
+	if err := json.Unmarshal([]byte(pod.Status.ContainerStatuses[0].State.Terminated.Message), termMsg); err != nil {
+		return nil, fmt.Errorf("invalid termination message of pod %s: %v", pod.Name, err)
+	}
+	if termMsg.SourceValidation == nil {
+		return nil, fmt.Errorf("pod %s did not report the source", pod.Name)
+	}
+	return termMsg.SourceValidation, nil
+}
+
+func importSizeDetectionPodName(dv *cdiv1.DataVolume) string {
+	return naming.GetResourceName(dv.Name, "size-detection")
+}
+
+// getOrCreateImportSizeDetectionPod gets the size-detection pod if it already exists/creates it if not
+func (r *ImportReconciler) getOrCreateImportSizeDetectionPod(dv *cdiv1.DataVolume) (*corev1.Pod, error) {
+	pod := &corev1.Pod{}
+	if err := r.client.Get(context.TODO(), types.NamespacedName{Namespace: dv.Namespace, Name: importSizeDetectionPodName(dv)}, pod); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return nil, err
+		}
+		pod, err = r.makeImportSizeDetectionPodSpec(dv)
+		if err != nil {
+			return nil, err
+		}
+		if err := r.client.Create(context.TODO(), pod); err != nil && !k8serrors.IsAlreadyExists(err) {
+			return nil, err
+		}
+		r.recorder.Event(dv, corev1.EventTypeNormal, SizeDetectionPodCreated, MessageSizeDetectionPodCreated)
+		r.log.V(3).Info(MessageSizeDetectionPodCreated, "pod.Name", pod.Name, "pod.Namespace", pod.Namespace)
+	}
+	return pod, nil
+}
+
+// makeImportSizeDetectionPodSpec creates the pod running the importer in validate only mode, reporting the virtual
+// size of the source image without a requested size to validate it against
+func (r *ImportReconciler) makeImportSizeDetectionPodSpec(dv *cdiv1.DataVolume) (*corev1.Pod, error) {
+	return r.makeSourceInspectionPodSpec(dv, importSizeDetectionPodName(dv), "size-detection", nil)
+}
+
+// makeSourceInspectionPodSpec creates a pod running the importer in validate only mode on the source of the
+// DataVolume, without any PVC. The result of the inspection is reported in its termination message.
+func (r *ImportReconciler) makeSourceInspectionPodSpec(dv *cdiv1.DataVolume, name, containerName string, extraEnv []corev1.EnvVar) (*corev1.Pod, error) {
+	source, err := getSourceInspectionSource(&dv.Spec)
+	if err != nil {
+		return nil, err
+	}
+	contentType := string(dv.Spec.ContentType)
+	if contentType == "" {
+		contentType = string(cdiv1.DataVolumeKubeVirt)
+	}
+	image, err := cc.GetImporterImage(context.TODO(), r.client, source.source, r.importerImage)
+	if err != nil {
+		return nil, err
+	}
+	container := corev1.Container{
+		Name:            containerName,
+		Image:           image,
+		ImagePullPolicy: corev1.PullPolicy(r.pullPolicy),
+		Env: []corev1.EnvVar{
+			{Name: common.ImporterSource, Value: source.source},
+			{Name: common.ImporterEndpoint, Value: source.url},
+			{Name: common.ImporterContentType, Value: contentType},
+			{Name: common.ImporterValidateOnly, Value: "true"},
+			{Name: common.Preallocation, Value: "false"},
+		},
+		TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+	}
+	container.Env = append(container.Env, extraEnv...)
+	container.Env = append(container.Env, source.env...)
+	proxy, err := r.getSourceInspectionProxy(dv, name)
+	if err != nil {
+		return nil, err
+	}
+	container.Env = append(container.Env,
+		corev1.EnvVar{Name: common.ImportProxyHTTP, Value: proxy.HTTPProxy},
+		corev1.EnvVar{Name: common.ImportProxyHTTPS, Value: proxy.HTTPSProxy},
+		corev1.EnvVar{Name: common.ImportProxyNoProxy, Value: proxy.NoProxy},
+	)
+
+	volumes := source.volumes
+	container.VolumeMounts = source.volumeMounts
+	if proxy.TrustedCAProxy != "" {
+		container.Env = append(container.Env, corev1.EnvVar{Name: common.ImporterProxyCertDirVar, Value: common.ImporterProxyCertDir})
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{Name: sourceInspectionProxyCertVolName, MountPath: common.ImporterProxyCertDir})
+		volumes = append(volumes, corev1.Volume{
+			Name: sourceInspectionProxyCertVolName,
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: proxy.TrustedCAProxy},
+				},
+			},
+		})
+	}
+	if source.certConfigMap != "" {
+		container.Env = append(container.Env, corev1.EnvVar{Name: common.ImporterCertDirVar, Value: common.ImporterCertDir})
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{Name: sizeDetectionCertVolName, MountPath: common.ImporterCertDir})
+		volumes = append(volumes, corev1.Volume{
+			Name: sizeDetectionCertVolName,
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: source.certConfigMap},
+				},
+			},
+		})
+	}
+
+	resourceRequirements, err := cc.GetDefaultPodResourceRequirements(r.client)
+	if err != nil {
+		return nil, err
+	}
+	if resourceRequirements != nil {
+		container.Resources = *resourceRequirements
+	}
+	workloadNodePlacement, err := cc.GetWorkloadNodePlacement(context.TODO(), r.client)
+	if err != nil {
+		return nil, err
+	}
+	imagePullSecrets, err := cc.GetImagePullSecrets(r.client)
+	if err != nil {
+		return nil, err
+	}
+	priorityClassName, err := cc.GetTransferPodPriorityClass(context.TODO(), r.client, dv.Spec.PriorityClassName, cc.ImporterPriorityClass)
+	if err != nil {
+		return nil, err
+	}
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: dv.Namespace,
+			Labels: map[string]string{
+				common.CDILabelKey:       common.CDILabelValue,
+				common.CDIComponentLabel: common.ImporterPodName,
+			},
+		},
+		Spec: corev1.PodSpec{
+			Containers:         []corev1.Container{container},
+			Volumes:            volumes,
+			RestartPolicy:      corev1.RestartPolicyNever,
+			NodeSelector:       workloadNodePlacement.NodeSelector,
+			Tolerations:        workloadNodePlacement.Tolerations,
+			Affinity:           workloadNodePlacement.Affinity,
+			PriorityClassName:  priorityClassName,
+			ImagePullSecrets:   imagePullSecrets,
+			ServiceAccountName: source.serviceAccountName,
+		},
+	}
+	util.SetRecommendedLabels(pod, r.installerLabels, common.CDIControllerName)
+	cc.CopyAllowedAnnotations(dv, pod)
+	cc.SetRestrictedSecurityContext(&pod.Spec)
+	if err := controllerutil.SetControllerReference(dv, pod, r.scheme); err != nil {
+		return nil, err
+	}
+	return pod, nil
+}
+
+// sourceInspection holds what the importer needs to read the source of a DataVolume outside of an import
+type sourceInspection struct {
+	source             string
+	url                string
+	certConfigMap      string
+	serviceAccountName string
+	env                []corev1.EnvVar
+	volumes            []corev1.Volume
+	volumeMounts       []corev1.VolumeMount
+}
+
+// CanInspectImportSource returns true if the source of the DataVolume can be read by a pod without any PVC, which
+// is the case of the HTTP, S3, GCS and registry sources that do not need files mounted other than their secret
+func CanInspectImportSource(spec *cdiv1.DataVolumeSpec) bool {
+	_, err := getSourceInspectionSource(spec)
+	return err == nil
+}
+
+func getSourceInspectionSource(spec *cdiv1.DataVolumeSpec) (*sourceInspection, error) {
+	if spec.Source == nil {
+		return nil, fmt.Errorf("no source set")
+	}
+	switch {
+	case spec.Source.HTTP != nil:
+		http := spec.Source.HTTP
+		if http.ClientCertSecretRef != "" || http.OAuth2SecretRef != "" || len(http.SecretExtraHeaders) > 0 || http.OVA != nil || http.XVA != nil {
+			return nil, fmt.Errorf("http sources with client certificates, OAuth2, secret headers, OVA or XVA can not be inspected")
+		}
+		inspection := &sourceInspection{source: cc.SourceHTTP, url: http.URL, certConfigMap: http.CertConfigMap}
+		if http.SecretRef != "" {
+			inspection.env = makeAccessKeyEnv(http.SecretRef)
+		}
+		for index, header := range http.ExtraHeaders {
+			inspection.env = append(inspection.env, corev1.EnvVar{
+				Name:  fmt.Sprintf("%s%d", common.ImporterExtraHeader, index),
+				Value: header,
+			})
+		}
+		return inspection, nil
+	case spec.Source.S3 != nil:
+		s3 := spec.Source.S3
+		if s3.ClientCertSecretRef != "" {
+			return nil, fmt.Errorf("s3 sources with client certificates can not be inspected")
+		}
+		inspection := &sourceInspection{source: cc.SourceS3, url: s3.URL, certConfigMap: s3.CertConfigMap, serviceAccountName: s3.ServiceAccountName}
+		if s3.SecretRef != "" {
+			inspection.env = cc.MakeS3SecretEnv(s3.SecretRef)
+		}
+		return inspection, nil
+	case spec.Source.GCS != nil:
+		gcs := spec.Source.GCS
+		if gcs.ClientCertSecretRef != "" {
+			return nil, fmt.Errorf("gcs sources with client certificates can not be inspected")
+		}
+		inspection := &sourceInspection{source: cc.SourceGCS, url: gcs.URL, certConfigMap: gcs.CertConfigMap}
+		if gcs.SecretRef != "" {
+			inspection.env = []corev1.EnvVar{{Name: common.ImporterGoogleCredentialFileVar, Value: common.ImporterGoogleCredentialFile}}
+			inspection.volumes = []corev1.Volume{{
+				Name:         sourceInspectionSecretVolName,
+				VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: gcs.SecretRef}},
+			}}
+			inspection.volumeMounts = []corev1.VolumeMount{{Name: sourceInspectionSecretVolName, MountPath: common.ImporterGoogleCredentialDir}}
+		}
+		return inspection, nil
+	case spec.Source.Registry != nil:
+		registry := spec.Source.Registry
+		if registry.URL == nil || ptr.Deref(registry.ClientCertSecretRef, "") != "" ||
+			ptr.Deref(registry.PullMethod, cdiv1.RegistryPullPod) != cdiv1.RegistryPullPod {
+			return nil, fmt.Errorf("only registry URLs pulled by the importer pod without client certificates can be inspected")
+		}
+		inspection := &sourceInspection{source: cc.SourceRegistry, url: *registry.URL, certConfigMap: ptr.Deref(registry.CertConfigMap, "")}
+		if secretRef := ptr.Deref(registry.SecretRef, ""); secretRef != "" {
+			inspection.env = makeAccessKeyEnv(secretRef)
+		}
+		if registry.Platform != nil && registry.Platform.Architecture != "" {
+			inspection.env = append(inspection.env, corev1.EnvVar{Name: common.ImporterRegistryImageArchitecture, Value: registry.Platform.Architecture})
+		}
+		return inspection, nil
+	}
+	return nil, fmt.Errorf("only http, s3, gcs and registry sources can be inspected")
+}
+
+// makeAccessKeyEnv returns the env variables of the access and secret keys of a secret
+func makeAccessKeyEnv(secretName string) []corev1.EnvVar {
+	var env []corev1.EnvVar
+	for _, key := range []struct {
+		name string
+		key  string
+	}{
+		{common.ImporterAccessKeyID, common.KeyAccess},
+		{common.ImporterSecretKey, common.KeySecret},
+	} {
+		env = append(env, corev1.EnvVar{
+			Name: key.name,
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: secretName},
+					Key:                  key.key,
+				},
+			},
+		})
+	}
+	return env
+}
+
+// getSourceInspectionProxy returns the import proxy of the DataVolume, or else the one of the CDIConfig, for the pod
+// inspecting its source. The CA bundle of the proxy of the CDIConfig is copied next to the DataVolume, where the pod
+// can mount it, and TrustedCAProxy set to the copy.
+func (r *ImportReconciler) getSourceInspectionProxy(dv *cdiv1.DataVolume, podName string) (*cdiv1.DataVolumeProxy, error) {
+	if dv.Spec.Proxy != nil {
+		// The proxy of the DataVolume replaces the one of the CDIConfig, its CA bundle is in the namespace already
+		return dv.Spec.Proxy, nil
+	}
+	cdiConfig := &cdiv1.CDIConfig{}
+	if err := r.client.Get(context.TODO(), types.NamespacedName{Name: common.ConfigName}, cdiConfig); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return &cdiv1.DataVolumeProxy{}, nil
+		}
+		return nil, err
+	}
+	importProxy := cdiConfig.Status.ImportProxy
+	if importProxy == nil {
+		return &cdiv1.DataVolumeProxy{}, nil
+	}
+	proxy := &cdiv1.DataVolumeProxy{
+		HTTPProxy:  ptr.Deref(importProxy.HTTPProxy, ""),
+		HTTPSProxy: ptr.Deref(importProxy.HTTPSProxy, ""),
+		NoProxy:    ptr.Deref(importProxy.NoProxy, ""),
+	}
+	if cmName := ptr.Deref(importProxy.TrustedCAProxy, ""); cmName != "" {
+		copyName, err := r.copyImportProxyConfigMap(dv, cmName, podName)
+		if err != nil {
+			return nil, err
+		}
+		proxy.TrustedCAProxy = copyName
+	}
+	return proxy, nil
+}
+
+// copyImportProxyConfigMap copies the CA bundle of the import proxy of the CDIConfig to the namespace of the
+// DataVolume, owned by the DataVolume so it goes away with it
+func (r *ImportReconciler) copyImportProxyConfigMap(dv *cdiv1.DataVolume, cmName, podName string) (string, error) {
+	cdiConfigMap := &corev1.ConfigMap{}
+	if err := r.client.Get(context.TODO(), types.NamespacedName{Name: cmName, Namespace: util.GetNamespace()}, cdiConfigMap); err != nil {
+		return "", err
+	}
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      naming.GetResourceName("import-proxy-cm", podName),
+			Namespace: dv.Namespace,
+		},
+		Data: cdiConfigMap.Data,
+	}
+	if err := controllerutil.SetOwnerReference(dv, configMap, r.scheme); err != nil {
+		return "", err
+	}
+	if err := r.client.Create(context.TODO(), configMap); err != nil && !k8serrors.IsAlreadyExists(err) {
+		return "", err
+	}
+	return configMap.Name, nil
+}
@@ -0,0 +1,158 @@
+/*
+Copyright 2026 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package datavolume
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+	"kubevirt.io/containerized-data-importer/pkg/common"
+	. "kubevirt.io/containerized-data-importer/pkg/controller/common"
+)
+
+var _ = Describe("Import size detection", func() {
+	var (
+		reconciler *ImportReconciler
+		dvKey      = types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}
+		podKey     = types.NamespacedName{Name: "test-dv-size-detection", Namespace: metav1.NamespaceDefault}
+	)
+
+	AfterEach(func() {
+		if reconciler != nil {
+			close(reconciler.recorder.(*record.FakeRecorder).Events)
+			reconciler = nil
+		}
+	})
+
+	dataVolumeWithoutSize := func() *cdiv1.DataVolume {
+		dv := newImportDataVolumeWithPvc("test-dv", nil)
+		// spec with accessMode/VolumeMode so storageprofile is not needed
+		dv.Spec.Storage = createStorageSpec()
+		dv.Spec.Storage.Resources = corev1.VolumeResourceRequirements{}
+		return dv
+	}
+
+	reconcileDataVolume := func() {
+		_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: dvKey})
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	completeSizeDetectionPod := func(message string) {
+		pod := &corev1.Pod{}
+		Expect(reconciler.client.Get(context.TODO(), podKey, pod)).To(Succeed())
+		pod.Status.Phase = corev1.PodSucceeded
+		pod.Status.ContainerStatuses = []corev1.ContainerStatus{
+			{State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Message: message}}},
+		}
+		Expect(reconciler.client.Status().Update(context.TODO(), pod)).To(Succeed())
+	}
+
+	DescribeTable("Should only detect the size of HTTP sources without a storage size", func(modify func(*cdiv1.DataVolumeSpec), expected bool) {
+		dv := dataVolumeWithoutSize()
+		modify(&dv.Spec)
+		Expect(CanDetectImportSize(&dv.Spec)).To(Equal(expected))
+	},
+		Entry("plain HTTP source", func(*cdiv1.DataVolumeSpec) {}, true),
+		Entry("PVC spec", func(spec *cdiv1.DataVolumeSpec) {
+			spec.Storage = nil
+			spec.PVC = &corev1.PersistentVolumeClaimSpec{}
+		}, false),
+		Entry("S3 source", func(spec *cdiv1.DataVolumeSpec) {
+			spec.Source = &cdiv1.DataVolumeSource{S3: &cdiv1.DataVolumeSourceS3{URL: "http://example.com/data"}}
+		}, false),
+		Entry("HTTP source with a client certificate", func(spec *cdiv1.DataVolumeSpec) {
+			spec.Source.HTTP.ClientCertSecretRef = "client-cert"
+		}, false),
+		Entry("OVA HTTP source", func(spec *cdiv1.DataVolumeSpec) {
+			spec.Source.HTTP.OVA = &cdiv1.DataVolumeSourceOVA{}
+		}, false),
+	)
+
+	It("Should create the PVC with the detected size of the source", func() {
+		dv := dataVolumeWithoutSize()
+		dv.Spec.Source.HTTP.SecretRef = "endpoint-secret"
+		dv.Spec.Source.HTTP.CertConfigMap = "endpoint-cert"
+		reconciler = createImportReconciler(CreateStorageClass("defaultSc", map[string]string{AnnDefaultStorageClass: "true"}), dv)
+		reconcileDataVolume()
+
+		pvc := &corev1.PersistentVolumeClaim{}
+		Expect(k8serrors.IsNotFound(reconciler.client.Get(context.TODO(), dvKey, pvc))).To(BeTrue())
+		pod := &corev1.Pod{}
+		Expect(reconciler.client.Get(context.TODO(), podKey, pod)).To(Succeed())
+		Expect(pod.OwnerReferences).To(HaveLen(1))
+		Expect(pod.OwnerReferences[0].Name).To(Equal("test-dv"))
+		Expect(pod.Spec.Containers[0].Image).To(Equal(reconciler.importerImage))
+		Expect(pod.Spec.Containers[0].Env).To(ContainElements(
+			corev1.EnvVar{Name: common.ImporterSource, Value: SourceHTTP},
+			corev1.EnvVar{Name: common.ImporterEndpoint, Value: "http://example.com/data"},
+			corev1.EnvVar{Name: common.ImporterValidateOnly, Value: "true"},
+			corev1.EnvVar{Name: common.ImporterCertDirVar, Value: common.ImporterCertDir},
+		))
+		Expect(pod.Spec.Containers[0].Env).To(ContainElement(HaveField("Name", common.ImporterAccessKeyID)))
+		Expect(pod.Spec.Volumes).To(HaveLen(1))
+		Expect(pod.Spec.Volumes[0].ConfigMap.Name).To(Equal("endpoint-cert"))
+
+		// Nothing happens until the pod completed
+		reconcileDataVolume()
+		Expect(k8serrors.IsNotFound(reconciler.client.Get(context.TODO(), dvKey, pvc))).To(BeTrue())
+
+		completeSizeDetectionPod(`{"sourceValidation":{"valid":true,"format":"qcow2","virtualSize":10737418240}}`)
+		reconcileDataVolume()
+		Expect(reconciler.client.Get(context.TODO(), dvKey, pvc)).To(Succeed())
+		// Block volumes have no filesystem overhead
+		size := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+		Expect(size.Cmp(resource.MustParse("10Gi"))).To(Equal(0))
+		Expect(k8serrors.IsNotFound(reconciler.client.Get(context.TODO(), podKey, pod))).To(BeTrue())
+	})
+
+	DescribeTable("Should not create the PVC if the size can not be detected", func(message, expectedEvent string) {
+		reconciler = createImportReconciler(CreateStorageClass("defaultSc", map[string]string{AnnDefaultStorageClass: "true"}), dataVolumeWithoutSize())
+		reconcileDataVolume()
+		completeSizeDetectionPod(message)
+		reconcileDataVolume()
+
+		pvc := &corev1.PersistentVolumeClaim{}
+		Expect(k8serrors.IsNotFound(reconciler.client.Get(context.TODO(), dvKey, pvc))).To(BeTrue())
+		found := false
+		for len(reconciler.recorder.(*record.FakeRecorder).Events) > 0 {
+			event := <-reconciler.recorder.(*record.FakeRecorder).Events
+			if event == expectedEvent {
+				found = true
+			}
+		}
+		Expect(found).To(BeTrue())
+	},
+		Entry("invalid source", `{"sourceValidation":{"valid":false,"message":"unauthorized"}}`,
+			"Warning ErrSizeDetectionFailed Size of the source of test-dv could not be detected, the storage size must be set: unauthorized"),
+		Entry("source only readable once transferred", `{"sourceValidation":{"valid":true}}`,
+			"Warning ErrSizeDetectionFailed Size of the source of test-dv could not be detected, the storage size must be set: the size of the source can only be known once transferred"),
+		Entry("invalid termination message", "error",
+			"Warning ErrSizeDetectionFailed Size of the source of test-dv could not be detected, the storage size must be set: invalid termination message of pod test-dv-size-detection: invalid character 'e' looking for beginning of value"),
+	)
+})
@@ -0,0 +1,157 @@
+/*
+Copyright 2026 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package datavolume
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+	"kubevirt.io/containerized-data-importer/pkg/common"
+	cc "kubevirt.io/containerized-data-importer/pkg/controller/common"
+	"kubevirt.io/containerized-data-importer/pkg/util/naming"
+)
+
+const (
+	// ValidationPodCreated provides a const to indicate the pod validating the source of a validate only import was created
+	ValidationPodCreated = "ValidationPodCreated"
+	// MessageValidationPodCreated provides a const to form validation pod created message
+	MessageValidationPodCreated = "Validation pod %s created"
+	// MessageValidationInProgress provides a const to form validation in progress message
+	MessageValidationInProgress = "Validation of the source of %s in progress"
+)
+
+// syncValidateOnly validates the source of a validate only DataVolume in a pod that does not mount any PVC, and
+// reports the result in the status. No PVC is ever created for validate only DataVolumes.
+func (r *ImportReconciler) syncValidateOnly(syncState *dvSyncState) error {
+	dv := syncState.dvMutated
+	syncState.result = &reconcile.Result{}
+
+	if dv.Status.Phase == cdiv1.ValidationSucceeded || dv.Status.Phase == cdiv1.ValidationFailed {
+		// The validation completed, the pod is no longer needed
+		pod := &corev1.Pod{}
+		if err := r.client.Get(context.TODO(), types.NamespacedName{Namespace: dv.Namespace, Name: validationPodName(dv)}, pod); err == nil {
+			if err := r.client.Delete(context.TODO(), pod); err != nil && !k8serrors.IsNotFound(err) {
+				return err
+			}
+		} else if !k8serrors.IsNotFound(err) {
+			return err
+		}
+		if dv.Status.Validation == nil {
+			return nil
+		}
+		return r.syncValidationResult(syncState, dv.Status.Validation)
+	}
+
+	pod, err := r.getOrCreateValidationPod(syncState)
+	if err != nil {
+		return err
+	}
+	switch pod.Status.Phase {
+	case corev1.PodSucceeded:
+		validation, err := getSourceInspectionResult(pod)
+		if err != nil {
+			validation = &common.SourceValidation{Message: err.Error()}
+		}
+		return r.syncValidationResult(syncState, &cdiv1.DataVolumeValidation{
+			Valid:       validation.Valid,
+			Format:      validation.Format,
+			VirtualSize: validation.VirtualSize,
+			Message:     validation.Message,
+		})
+	case corev1.PodFailed:
+		message := fmt.Sprintf("validation pod %s failed", pod.Name)
+		if len(pod.Status.ContainerStatuses) > 0 && pod.Status.ContainerStatuses[0].State.Terminated != nil &&
+			pod.Status.ContainerStatuses[0].State.Terminated.Message != "" {
+			message = fmt.Sprintf("%s: %s", message, pod.Status.ContainerStatuses[0].State.Terminated.Message)
+		}
+		return r.syncValidationResult(syncState, &cdiv1.DataVolumeValidation{Message: message})
+	case corev1.PodRunning:
+		return r.syncDataVolumeStatusPhaseWithEvent(syncState, cdiv1.ImportInProgress, nil,
+			Event{corev1.EventTypeNormal, ImportInProgress, fmt.Sprintf(MessageValidationInProgress, dv.Name)})
+	default:
+		return r.syncDataVolumeStatusPhaseWithEvent(syncState, cdiv1.ImportScheduled, nil,
+			Event{corev1.EventTypeNormal, ImportScheduled, fmt.Sprintf(MessageImportScheduled, dv.Name)})
+	}
+}
+
+// syncValidationResult reports the validation of the source in the status of the DataVolume
+func (r *ImportReconciler) syncValidationResult(syncState *dvSyncState, validation *cdiv1.DataVolumeValidation) error {
+	dv := syncState.dvMutated
+	phase := cdiv1.ValidationSucceeded
+	event := Event{corev1.EventTypeNormal, SourceValidationSucceeded, fmt.Sprintf(MessageSourceValidationSucceeded, dv.Name)}
+	if !validation.Valid {
+		phase = cdiv1.ValidationFailed
+		event = Event{corev1.EventTypeWarning, SourceValidationFailed, fmt.Sprintf(MessageSourceValidationFailed, dv.Name, validation.Message)}
+	}
+	if err := r.syncDataVolumeStatusPhaseWithEvent(syncState, phase, nil, event); err != nil {
+		return err
+	}
+	syncState.phaseSync.validation = validation
+	return nil
+}
+
+func validationPodName(dv *cdiv1.DataVolume) string {
+	return naming.GetResourceName(dv.Name, "validation")
+}
+
+// getOrCreateValidationPod gets the validation pod if it already exists/creates it if not
+func (r *ImportReconciler) getOrCreateValidationPod(syncState *dvSyncState) (*corev1.Pod, error) {
+	dv := syncState.dvMutated
+	pod := &corev1.Pod{}
+	if err := r.client.Get(context.TODO(), types.NamespacedName{Namespace: dv.Namespace, Name: validationPodName(dv)}, pod); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return nil, err
+		}
+		env, err := r.getValidationEnv(syncState.pvcSpec)
+		if err != nil {
+			return nil, err
+		}
+		pod, err = r.makeSourceInspectionPodSpec(dv, validationPodName(dv), "validation", env)
+		if err != nil {
+			return nil, err
+		}
+		if err := r.client.Create(context.TODO(), pod); err != nil && !k8serrors.IsAlreadyExists(err) {
+			return nil, err
+		}
+		r.recorder.Eventf(dv, corev1.EventTypeNormal, ValidationPodCreated, MessageValidationPodCreated, pod.Name)
+		r.log.V(3).Info("Validation pod created", "pod.Name", pod.Name, "pod.Namespace", pod.Namespace)
+	}
+	return pod, nil
+}
+
+// getValidationEnv returns the env variables of the storage the source is validated against
+func (r *ImportReconciler) getValidationEnv(pvcSpec *corev1.PersistentVolumeClaimSpec) ([]corev1.EnvVar, error) {
+	var env []corev1.EnvVar
+	if size, ok := pvcSpec.Resources.Requests[corev1.ResourceStorage]; ok && !size.IsZero() {
+		env = append(env, corev1.EnvVar{Name: common.ImporterImageSize, Value: size.String()})
+	}
+	if pvcSpec.VolumeMode == nil || *pvcSpec.VolumeMode == corev1.PersistentVolumeFilesystem {
+		overhead, err := cc.GetFilesystemOverheadForStorageClass(context.TODO(), r.client, pvcSpec.StorageClassName)
+		if err != nil {
+			return nil, err
+		}
+		env = append(env, corev1.EnvVar{Name: common.FilesystemOverheadVar, Value: string(overhead)})
+	}
+	return env, nil
+}
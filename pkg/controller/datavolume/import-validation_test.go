@@ -0,0 +1,209 @@
+/*
+Copyright 2026 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package datavolume
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+	"kubevirt.io/containerized-data-importer/pkg/common"
+	. "kubevirt.io/containerized-data-importer/pkg/controller/common"
+)
+
+var _ = Describe("Validate only imports", func() {
+	var (
+		reconciler *ImportReconciler
+		dvKey      = types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}
+		podKey     = types.NamespacedName{Name: "test-dv-validation", Namespace: metav1.NamespaceDefault}
+	)
+
+	AfterEach(func() {
+		if reconciler != nil {
+			close(reconciler.recorder.(*record.FakeRecorder).Events)
+			reconciler = nil
+		}
+	})
+
+	validateOnlyDataVolume := func() *cdiv1.DataVolume {
+		dv := NewImportDataVolume("test-dv")
+		dv.Spec.ValidateOnly = true
+		dv.Spec.PVC.Resources.Requests = corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("1G")}
+		return dv
+	}
+
+	reconcileDataVolume := func() *cdiv1.DataVolume {
+		_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: dvKey})
+		Expect(err).ToNot(HaveOccurred())
+		dv := &cdiv1.DataVolume{}
+		Expect(reconciler.client.Get(context.TODO(), dvKey, dv)).To(Succeed())
+		return dv
+	}
+
+	updateValidationPod := func(phase corev1.PodPhase, message string) {
+		pod := &corev1.Pod{}
+		Expect(reconciler.client.Get(context.TODO(), podKey, pod)).To(Succeed())
+		pod.Status.Phase = phase
+		if message != "" {
+			pod.Status.ContainerStatuses = []corev1.ContainerStatus{
+				{State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Message: message}}},
+			}
+		}
+		Expect(reconciler.client.Status().Update(context.TODO(), pod)).To(Succeed())
+	}
+
+	expectNoPVC := func() {
+		pvc := &corev1.PersistentVolumeClaim{}
+		Expect(k8serrors.IsNotFound(reconciler.client.Get(context.TODO(), dvKey, pvc))).To(BeTrue())
+	}
+
+	DescribeTable("Should only inspect sources a pod can read without a PVC", func(source *cdiv1.DataVolumeSource, expected bool) {
+		Expect(CanInspectImportSource(&cdiv1.DataVolumeSpec{Source: source})).To(Equal(expected))
+	},
+		Entry("HTTP source", &cdiv1.DataVolumeSource{HTTP: &cdiv1.DataVolumeSourceHTTP{URL: "http://example.com/data"}}, true),
+		Entry("HTTP source with OAuth2", &cdiv1.DataVolumeSource{HTTP: &cdiv1.DataVolumeSourceHTTP{URL: "http://example.com/data", OAuth2SecretRef: "oauth2"}}, false),
+		Entry("S3 source", &cdiv1.DataVolumeSource{S3: &cdiv1.DataVolumeSourceS3{URL: "http://example.com/data"}}, true),
+		Entry("GCS source", &cdiv1.DataVolumeSource{GCS: &cdiv1.DataVolumeSourceGCS{URL: "gs://bucket/data"}}, true),
+		Entry("registry source", &cdiv1.DataVolumeSource{Registry: &cdiv1.DataVolumeSourceRegistry{URL: ptr.To("docker://example.com/disk")}}, true),
+		Entry("registry source pulled by the node", &cdiv1.DataVolumeSource{Registry: &cdiv1.DataVolumeSourceRegistry{
+			URL: ptr.To("docker://example.com/disk"), PullMethod: ptr.To(cdiv1.RegistryPullNode)}}, false),
+		Entry("registry image stream", &cdiv1.DataVolumeSource{Registry: &cdiv1.DataVolumeSourceRegistry{ImageStream: ptr.To("disk")}}, false),
+		Entry("imageio source", &cdiv1.DataVolumeSource{Imageio: &cdiv1.DataVolumeSourceImageIO{URL: "http://example.com/data"}}, false),
+	)
+
+	DescribeTable("Should validate the source in a pod without creating the PVC", func(message string, expectedPhase cdiv1.DataVolumePhase, expectedValidation cdiv1.DataVolumeValidation) {
+		reconciler = createImportReconciler(validateOnlyDataVolume())
+		dv := reconcileDataVolume()
+		Expect(dv.Status.Phase).To(Equal(cdiv1.ImportScheduled))
+		expectNoPVC()
+
+		pod := &corev1.Pod{}
+		Expect(reconciler.client.Get(context.TODO(), podKey, pod)).To(Succeed())
+		Expect(pod.OwnerReferences).To(HaveLen(1))
+		Expect(pod.OwnerReferences[0].Name).To(Equal("test-dv"))
+		Expect(pod.Spec.Volumes).To(BeEmpty())
+		Expect(pod.Spec.Containers[0].VolumeMounts).To(BeEmpty())
+		Expect(pod.Spec.Containers[0].VolumeDevices).To(BeEmpty())
+		Expect(pod.Spec.Containers[0].Env).To(ContainElements(
+			corev1.EnvVar{Name: common.ImporterSource, Value: SourceHTTP},
+			corev1.EnvVar{Name: common.ImporterValidateOnly, Value: "true"},
+			corev1.EnvVar{Name: common.ImporterImageSize, Value: "1G"},
+		))
+
+		updateValidationPod(corev1.PodRunning, "")
+		dv = reconcileDataVolume()
+		Expect(dv.Status.Phase).To(Equal(cdiv1.ImportInProgress))
+
+		updateValidationPod(corev1.PodSucceeded, message)
+		dv = reconcileDataVolume()
+		Expect(dv.Status.Phase).To(Equal(expectedPhase))
+		Expect(dv.Status.Validation).To(Equal(&expectedValidation))
+		expectNoPVC()
+
+		// The pod is deleted once the result is recorded, which is kept
+		dv = reconcileDataVolume()
+		Expect(dv.Status.Phase).To(Equal(expectedPhase))
+		Expect(dv.Status.Validation).To(Equal(&expectedValidation))
+		Expect(k8serrors.IsNotFound(reconciler.client.Get(context.TODO(), podKey, pod))).To(BeTrue())
+		readyCondition := FindConditionByType(cdiv1.DataVolumeReady, dv.Status.Conditions)
+		Expect(readyCondition).ToNot(BeNil())
+		Expect(readyCondition.Status).To(Equal(corev1.ConditionFalse))
+		if expectedValidation.Valid {
+			Expect(readyCondition.Reason).To(Equal(SourceValidationSucceeded))
+		} else {
+			Expect(readyCondition.Reason).To(Equal(SourceValidationFailed))
+		}
+		expectNoPVC()
+	},
+		Entry("valid source", `{"sourceValidation":{"valid":true,"format":"qcow2","virtualSize":1024}}`, cdiv1.ValidationSucceeded,
+			cdiv1.DataVolumeValidation{Valid: true, Format: "qcow2", VirtualSize: ptr.To[int64](1024)}),
+		Entry("invalid source", `{"sourceValidation":{"valid":false,"message":"unknown format"}}`, cdiv1.ValidationFailed,
+			cdiv1.DataVolumeValidation{Message: "unknown format"}),
+		Entry("invalid termination message", "error", cdiv1.ValidationFailed,
+			cdiv1.DataVolumeValidation{Message: "invalid termination message of pod test-dv-validation: invalid character 'e' looking for beginning of value"}),
+	)
+
+	It("Should validate the source through the proxy of the DataVolume", func() {
+		dv := validateOnlyDataVolume()
+		dv.Spec.Proxy = &cdiv1.DataVolumeProxy{HTTPSProxy: "https://proxy.example.com", NoProxy: "internal.example.com", TrustedCAProxy: "proxy-ca"}
+		reconciler = createImportReconciler(dv)
+		reconcileDataVolume()
+
+		pod := &corev1.Pod{}
+		Expect(reconciler.client.Get(context.TODO(), podKey, pod)).To(Succeed())
+		Expect(pod.Spec.Containers[0].Env).To(ContainElements(
+			corev1.EnvVar{Name: common.ImportProxyHTTP, Value: ""},
+			corev1.EnvVar{Name: common.ImportProxyHTTPS, Value: "https://proxy.example.com"},
+			corev1.EnvVar{Name: common.ImportProxyNoProxy, Value: "internal.example.com"},
+			corev1.EnvVar{Name: common.ImporterProxyCertDirVar, Value: common.ImporterProxyCertDir},
+		))
+		Expect(pod.Spec.Containers[0].VolumeMounts).To(ConsistOf(corev1.VolumeMount{Name: "cdi-proxy-cert-vol", MountPath: common.ImporterProxyCertDir}))
+		Expect(pod.Spec.Volumes).To(HaveLen(1))
+		Expect(pod.Spec.Volumes[0].ConfigMap.Name).To(Equal("proxy-ca"))
+	})
+
+	It("Should validate the source through the proxy of the CDIConfig, with a copy of its CA bundle", func() {
+		proxyCA := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "cdi-proxy-ca", Namespace: "cdi"},
+			Data:       map[string]string{"ca.pem": "bundle"},
+		}
+		reconciler = createImportReconciler(validateOnlyDataVolume(), proxyCA)
+		cdiConfig := &cdiv1.CDIConfig{}
+		Expect(reconciler.client.Get(context.TODO(), types.NamespacedName{Name: common.ConfigName}, cdiConfig)).To(Succeed())
+		cdiConfig.Status.ImportProxy = &cdiv1.ImportProxy{
+			HTTPProxy:      ptr.To("http://proxy.example.com"),
+			TrustedCAProxy: ptr.To("cdi-proxy-ca"),
+		}
+		Expect(reconciler.client.Status().Update(context.TODO(), cdiConfig)).To(Succeed())
+		reconcileDataVolume()
+
+		pod := &corev1.Pod{}
+		Expect(reconciler.client.Get(context.TODO(), podKey, pod)).To(Succeed())
+		Expect(pod.Spec.Containers[0].Env).To(ContainElements(
+			corev1.EnvVar{Name: common.ImportProxyHTTP, Value: "http://proxy.example.com"},
+			corev1.EnvVar{Name: common.ImporterProxyCertDirVar, Value: common.ImporterProxyCertDir},
+		))
+		Expect(pod.Spec.Volumes).To(HaveLen(1))
+		configMap := &corev1.ConfigMap{}
+		Expect(reconciler.client.Get(context.TODO(), types.NamespacedName{Name: pod.Spec.Volumes[0].ConfigMap.Name, Namespace: metav1.NamespaceDefault}, configMap)).To(Succeed())
+		Expect(configMap.Data).To(Equal(proxyCA.Data))
+		Expect(configMap.OwnerReferences).To(HaveLen(1))
+		Expect(configMap.OwnerReferences[0].Name).To(Equal("test-dv"))
+	})
+
+	It("Should fail the validation if the pod failed", func() {
+		reconciler = createImportReconciler(validateOnlyDataVolume())
+		reconcileDataVolume()
+		updateValidationPod(corev1.PodFailed, "out of memory")
+		dv := reconcileDataVolume()
+		Expect(dv.Status.Phase).To(Equal(cdiv1.ValidationFailed))
+		Expect(dv.Status.Validation).To(Equal(&cdiv1.DataVolumeValidation{Message: "validation pod test-dv-validation failed: out of memory"}))
+		expectNoPVC()
+	})
+})
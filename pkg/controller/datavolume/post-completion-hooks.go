@@ -0,0 +1,362 @@
+/*
+Copyright 2026 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package datavolume
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+	"kubevirt.io/containerized-data-importer/pkg/common"
+	cc "kubevirt.io/containerized-data-importer/pkg/controller/common"
+	"kubevirt.io/containerized-data-importer/pkg/util"
+	"kubevirt.io/containerized-data-importer/pkg/util/naming"
+)
+
+const (
+	// PostCompletionHookRunning provides a const to indicate a post completion hook is running
+	PostCompletionHookRunning = "PostCompletionHookRunning"
+	// PostCompletionHookFailed provides a const to indicate a post completion hook failed
+	PostCompletionHookFailed = "PostCompletionHookFailed"
+	// PostCompletionHooksSucceeded provides a const to indicate all the post completion hooks succeeded
+	PostCompletionHooksSucceeded = "PostCompletionHooksSucceeded"
+
+	// MessagePostCompletionHookRunning provides a const to form the message of a running post completion hook
+	MessagePostCompletionHookRunning = "Running post completion hook %s"
+	// MessagePostCompletionHookFailed provides a const to form the message of a failed post completion hook
+	MessagePostCompletionHookFailed = "Post completion hook %s failed: %s"
+	// MessagePostCompletionHooksSucceeded provides a const for the message of succeeded post completion hooks
+	MessagePostCompletionHooksSucceeded = "Post completion hooks succeeded"
+
+	postCompletionHookContainerName = "hook"
+
+	// postCompletionHookPollInterval is how often a DataVolume is reconciled while its webhook hook is called
+	postCompletionHookPollInterval = 2 * time.Second
+)
+
+// may be overridden in tests
+var postCompletionHookHTTPClient = &http.Client{Timeout: 30 * time.Second}
+
+// postCompletionHookCall is a webhook call made outside of the reconcile loop, so a slow webhook does not block it
+type postCompletionHookCall struct {
+	done bool
+	// err is set if the webhook could not be reached, the call is then made again
+	err error
+	// status is the status the webhook answered with
+	status string
+	ok     bool
+}
+
+// postCompletionHookCalls holds the webhook calls in flight, or completed and not reconciled yet, by DataVolume and hook
+var (
+	postCompletionHookCalls     = map[string]*postCompletionHookCall{}
+	postCompletionHookCallsLock sync.Mutex
+)
+
+// postCompletionHookRequest is the body of the requests of webhook hooks
+type postCompletionHookRequest struct {
+	Namespace  string `json:"namespace"`
+	DataVolume string `json:"dataVolume"`
+	ClaimName  string `json:"claimName"`
+	Hook       string `json:"hook"`
+}
+
+// reconcilePostCompletionHooks runs the post completion hooks of a populated DataVolume one after the other, holding
+// it in the PostCompletionHooksInProgress phase until they all succeeded and failing it once one of them failed
+func (r *ReconcilerBase) reconcilePostCompletionHooks(dataVolume *cdiv1.DataVolume, pvc *corev1.PersistentVolumeClaim, event *Event, result *reconcile.Result) error {
+	if dataVolume.Status.Phase != cdiv1.Succeeded || len(dataVolume.Spec.PostCompletionHooks) == 0 || pvc == nil {
+		return nil
+	}
+
+	for i := range dataVolume.Spec.PostCompletionHooks {
+		hook := &dataVolume.Spec.PostCompletionHooks[i]
+		status := findPostCompletionHookStatus(dataVolume, hook.Name)
+		if status == nil {
+			dataVolume.Status.PostCompletionHooks = append(dataVolume.Status.PostCompletionHooks, cdiv1.DataVolumeHookStatus{
+				Name:  hook.Name,
+				Phase: cdiv1.HookRunning,
+			})
+			status = &dataVolume.Status.PostCompletionHooks[len(dataVolume.Status.PostCompletionHooks)-1]
+		}
+		if status.Phase == cdiv1.HookRunning {
+			if err := r.runPostCompletionHook(dataVolume, pvc, hook, status, result); err != nil {
+				return err
+			}
+		}
+
+		switch status.Phase {
+		case cdiv1.HookSucceeded:
+			continue
+		case cdiv1.HookFailed:
+			message := fmt.Sprintf(MessagePostCompletionHookFailed, hook.Name, status.Message)
+			dataVolume.Status.Phase = cdiv1.Failed
+			dataVolume.Status.Conditions = updateCondition(dataVolume.Status.Conditions, cdiv1.DataVolumePostCompletionHooks, corev1.ConditionFalse, message, PostCompletionHookFailed)
+			event.eventType = corev1.EventTypeWarning
+			event.reason = PostCompletionHookFailed
+			event.message = message
+		default:
+			dataVolume.Status.Phase = cdiv1.PostCompletionHooksInProgress
+			dataVolume.Status.Conditions = updateCondition(dataVolume.Status.Conditions, cdiv1.DataVolumePostCompletionHooks, corev1.ConditionFalse,
+				fmt.Sprintf(MessagePostCompletionHookRunning, hook.Name), PostCompletionHookRunning)
+		}
+		return nil
+	}
+
+	dataVolume.Status.Conditions = updateCondition(dataVolume.Status.Conditions, cdiv1.DataVolumePostCompletionHooks, corev1.ConditionTrue,
+		MessagePostCompletionHooksSucceeded, PostCompletionHooksSucceeded)
+	event.eventType = corev1.EventTypeNormal
+	event.reason = PostCompletionHooksSucceeded
+	event.message = MessagePostCompletionHooksSucceeded
+	return nil
+}
+
+func findPostCompletionHookStatus(dataVolume *cdiv1.DataVolume, name string) *cdiv1.DataVolumeHookStatus {
+	for i := range dataVolume.Status.PostCompletionHooks {
+		if dataVolume.Status.PostCompletionHooks[i].Name == name {
+			return &dataVolume.Status.PostCompletionHooks[i]
+		}
+	}
+	return nil
+}
+
+// runPostCompletionHook starts or checks the running hook, updating its status once it completed
+func (r *ReconcilerBase) runPostCompletionHook(dataVolume *cdiv1.DataVolume, pvc *corev1.PersistentVolumeClaim, hook *cdiv1.DataVolumeHook, status *cdiv1.DataVolumeHookStatus, result *reconcile.Result) error {
+	switch {
+	case hook.Job != nil:
+		return r.runPostCompletionHookJob(dataVolume, pvc, hook, status)
+	case hook.Webhook != nil:
+		return r.runPostCompletionHookWebhook(dataVolume, pvc, hook, status, result)
+	}
+	status.Phase = cdiv1.HookFailed
+	status.Message = "hook has neither a job nor a webhook"
+	return nil
+}
+
+func (r *ReconcilerBase) runPostCompletionHookJob(dataVolume *cdiv1.DataVolume, pvc *corev1.PersistentVolumeClaim, hook *cdiv1.DataVolumeHook, status *cdiv1.DataVolumeHookStatus) error {
+	job := &batchv1.Job{}
+	jobName := getPostCompletionHookJobName(dataVolume, hook.Name)
+	if err := r.client.Get(context.TODO(), types.NamespacedName{Namespace: dataVolume.Namespace, Name: jobName}, job); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return err
+		}
+		job, err = r.newPostCompletionHookJob(dataVolume, pvc, hook, jobName)
+		if err != nil {
+			return err
+		}
+		r.log.V(1).Info("Creating post completion hook job", "DataVolume", dataVolume.Name, "hook", hook.Name)
+		if err := r.client.Create(context.TODO(), job); err != nil && !k8serrors.IsAlreadyExists(err) {
+			return err
+		}
+		return nil
+	}
+
+	for _, cond := range job.Status.Conditions {
+		if cond.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch cond.Type {
+		case batchv1.JobComplete:
+			status.Phase = cdiv1.HookSucceeded
+			status.Message = ""
+		case batchv1.JobFailed:
+			status.Phase = cdiv1.HookFailed
+			status.Message = cond.Message
+		}
+	}
+	return nil
+}
+
+func getPostCompletionHookJobName(dataVolume *cdiv1.DataVolume, hookName string) string {
+	return naming.GetLabelNameFromResourceName(dataVolume.Name + "-hook-" + hookName)
+}
+
+// newPostCompletionHookJob creates the Job running the hook with the PVC of the DataVolume mounted
+func (r *ReconcilerBase) newPostCompletionHookJob(dataVolume *cdiv1.DataVolume, pvc *corev1.PersistentVolumeClaim, hook *cdiv1.DataVolumeHook, name string) (*batchv1.Job, error) {
+	dataPath := common.ImporterWritePath
+	container := corev1.Container{
+		Name:    postCompletionHookContainerName,
+		Image:   hook.Job.Image,
+		Command: hook.Job.Command,
+		Args:    hook.Job.Args,
+	}
+	if cc.GetVolumeMode(pvc) == corev1.PersistentVolumeBlock {
+		dataPath = common.WriteBlockPath
+		container.VolumeDevices = cc.AddVolumeDevices()
+	} else {
+		container.VolumeMounts = []corev1.VolumeMount{
+			{
+				Name:      cc.DataVolName,
+				MountPath: common.ImporterDataDir,
+			},
+		}
+	}
+	container.Env = []corev1.EnvVar{
+		{Name: common.HookDataPathVar, Value: dataPath},
+		{Name: common.HookDataVolumeNameVar, Value: dataVolume.Name},
+		{Name: common.HookDataVolumeNamespaceVar, Value: dataVolume.Namespace},
+		{Name: common.HookNameVar, Value: hook.Name},
+	}
+
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: dataVolume.Namespace,
+			Labels: map[string]string{
+				common.CDILabelKey:             common.CDILabelValue,
+				common.PostCompletionHookLabel: hook.Name,
+			},
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: hook.Job.BackoffLimit,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						common.CDILabelKey:             common.CDILabelValue,
+						common.PostCompletionHookLabel: hook.Name,
+					},
+				},
+				Spec: corev1.PodSpec{
+					Containers:    []corev1.Container{container},
+					RestartPolicy: corev1.RestartPolicyNever,
+					Volumes: []corev1.Volume{
+						{
+							Name: cc.DataVolName,
+							VolumeSource: corev1.VolumeSource{
+								PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+									ClaimName: pvc.Name,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	util.SetRecommendedLabels(job, r.installerLabels, common.CDIControllerName)
+	cc.SetRestrictedSecurityContext(&job.Spec.Template.Spec)
+	if err := controllerutil.SetControllerReference(dataVolume, job, r.scheme); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// runPostCompletionHookWebhook calls the webhook of the hook in the background, requeueing the DataVolume until the
+// call completed. The hook fails unless its URL is allowed by the CDIConfig and the webhook answers with a 2xx status.
+// Errors reaching the webhook are returned so the call is made again.
+func (r *ReconcilerBase) runPostCompletionHookWebhook(dataVolume *cdiv1.DataVolume, pvc *corev1.PersistentVolumeClaim, hook *cdiv1.DataVolumeHook, status *cdiv1.DataVolumeHookStatus, result *reconcile.Result) error {
+	cdiConfig := &cdiv1.CDIConfig{}
+	if err := r.client.Get(context.TODO(), types.NamespacedName{Name: common.ConfigName}, cdiConfig); err != nil {
+		return err
+	}
+	if !isPostCompletionHookURLAllowed(hook.Webhook.URL, cdiConfig.Spec.PostCompletionHookURLs) {
+		status.Phase = cdiv1.HookFailed
+		status.Message = fmt.Sprintf("webhook url %s is not under any of the postCompletionHookURLs of the CDIConfig", hook.Webhook.URL)
+		return nil
+	}
+
+	key := fmt.Sprintf("%s/%s/%s/%s", dataVolume.Namespace, dataVolume.Name, dataVolume.UID, hook.Name)
+	postCompletionHookCallsLock.Lock()
+	defer postCompletionHookCallsLock.Unlock()
+	call, ok := postCompletionHookCalls[key]
+	if !ok {
+		call = &postCompletionHookCall{}
+		postCompletionHookCalls[key] = call
+		namespace, name, claimName, hook := dataVolume.Namespace, dataVolume.Name, pvc.Name, hook.DeepCopy()
+		go func() {
+			ok, respStatus, err := callPostCompletionHookWebhook(namespace, name, claimName, hook)
+			postCompletionHookCallsLock.Lock()
+			defer postCompletionHookCallsLock.Unlock()
+			call.done, call.ok, call.status, call.err = true, ok, respStatus, err
+		}()
+	}
+	if !call.done {
+		result.RequeueAfter = postCompletionHookPollInterval
+		return nil
+	}
+	delete(postCompletionHookCalls, key)
+	if call.err != nil {
+		return call.err
+	}
+	if !call.ok {
+		status.Phase = cdiv1.HookFailed
+		status.Message = fmt.Sprintf("webhook returned %s", call.status)
+		return nil
+	}
+	status.Phase = cdiv1.HookSucceeded
+	status.Message = ""
+	return nil
+}
+
+// isPostCompletionHookURLAllowed checks the webhook URL is under one of the allowed URLs. Schemes and hosts are compared
+// as a whole, so an allowed host can not be extended to another one.
+func isPostCompletionHookURLAllowed(hookURL string, allowedURLs []string) bool {
+	u, err := url.Parse(hookURL)
+	if err != nil {
+		return false
+	}
+	if u.Path == "" {
+		u.Path = "/"
+	}
+	for _, allowedURL := range allowedURLs {
+		allowed, err := url.Parse(allowedURL)
+		if err != nil || allowed.Host == "" {
+			continue
+		}
+		if strings.EqualFold(u.Scheme, allowed.Scheme) && strings.EqualFold(u.Host, allowed.Host) && strings.HasPrefix(u.Path, allowed.Path) {
+			return true
+		}
+	}
+	return false
+}
+
+// callPostCompletionHookWebhook posts the DataVolume to the webhook, returning whether it answered with a 2xx status
+// and the status it answered with
+func callPostCompletionHookWebhook(namespace, dataVolumeName, claimName string, hook *cdiv1.DataVolumeHook) (bool, string, error) {
+	body, err := json.Marshal(postCompletionHookRequest{
+		Namespace:  namespace,
+		DataVolume: dataVolumeName,
+		ClaimName:  claimName,
+		Hook:       hook.Name,
+	})
+	if err != nil {
+		return false, "", err
+	}
+	resp, err := postCompletionHookHTTPClient.Post(hook.Webhook.URL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return false, "", errors.Wrapf(err, "could not call webhook of post completion hook %s", hook.Name)
+	}
+	defer resp.Body.Close()
+	return resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices, resp.Status, nil
+}
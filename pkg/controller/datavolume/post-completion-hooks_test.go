@@ -0,0 +1,308 @@
+/*
+Copyright 2026 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package datavolume
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+	"kubevirt.io/containerized-data-importer/pkg/common"
+	. "kubevirt.io/containerized-data-importer/pkg/controller/common"
+)
+
+var _ = Describe("Post completion hooks", func() {
+	var (
+		reconciler *ImportReconciler
+		dvKey      = types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}
+	)
+
+	AfterEach(func() {
+		if reconciler != nil {
+			close(reconciler.recorder.(*record.FakeRecorder).Events)
+			reconciler = nil
+		}
+	})
+
+	populatedDataVolume := func(volumeMode corev1.PersistentVolumeMode, hooks ...cdiv1.DataVolumeHook) (*corev1.PersistentVolumeClaim, *cdiv1.DataVolume) {
+		pvc := CreatePvc("test-dv", metav1.NamespaceDefault, map[string]string{AnnPopulatedFor: "test-dv"}, nil)
+		pvc.Spec.VolumeMode = &volumeMode
+		pvc.Status.Phase = corev1.ClaimBound
+		dv := NewImportDataVolume("test-dv")
+		dv.Spec.PostCompletionHooks = hooks
+		return pvc, dv
+	}
+
+	reconcileDataVolume := func() *cdiv1.DataVolume {
+		_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: dvKey})
+		Expect(err).ToNot(HaveOccurred())
+		dv := &cdiv1.DataVolume{}
+		Expect(reconciler.client.Get(context.TODO(), dvKey, dv)).To(Succeed())
+		return dv
+	}
+
+	getHookJob := func(hookName string) *batchv1.Job {
+		job := &batchv1.Job{}
+		Expect(reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "test-dv-hook-" + hookName, Namespace: metav1.NamespaceDefault}, job)).To(Succeed())
+		return job
+	}
+
+	allowHookURLs := func(urls ...string) {
+		cdiConfig := &cdiv1.CDIConfig{}
+		Expect(reconciler.client.Get(context.TODO(), types.NamespacedName{Name: common.ConfigName}, cdiConfig)).To(Succeed())
+		cdiConfig.Spec.PostCompletionHookURLs = urls
+		Expect(reconciler.client.Update(context.TODO(), cdiConfig)).To(Succeed())
+	}
+
+	// reconcileWebhookHook reconciles the DataVolume until the call of the webhook of its first hook completed
+	reconcileWebhookHook := func() *cdiv1.DataVolume {
+		var dv *cdiv1.DataVolume
+		Eventually(func() cdiv1.DataVolumeHookPhase {
+			dv = reconcileDataVolume()
+			return dv.Status.PostCompletionHooks[0].Phase
+		}, 5*time.Second, 10*time.Millisecond).ShouldNot(Equal(cdiv1.HookRunning))
+		return dv
+	}
+
+	completeHookJob := func(hookName string, conditionType batchv1.JobConditionType, message string) {
+		job := getHookJob(hookName)
+		job.Status.Conditions = []batchv1.JobCondition{{Type: conditionType, Status: corev1.ConditionTrue, Message: message}}
+		Expect(reconciler.client.Status().Update(context.TODO(), job)).To(Succeed())
+	}
+
+	It("Should run a job hook with the PVC mounted before the DataVolume succeeds", func() {
+		pvc, dv := populatedDataVolume(corev1.PersistentVolumeFilesystem, cdiv1.DataVolumeHook{
+			Name: "sysprep",
+			Job: &cdiv1.DataVolumeHookJob{
+				Image:        "quay.io/example/sysprep",
+				Command:      []string{"/usr/bin/sysprep"},
+				BackoffLimit: ptr.To[int32](2),
+			},
+		})
+		reconciler = createImportReconciler(pvc, dv)
+
+		dv = reconcileDataVolume()
+		Expect(dv.Status.Phase).To(Equal(cdiv1.PostCompletionHooksInProgress))
+		Expect(dv.Status.PostCompletionHooks).To(Equal([]cdiv1.DataVolumeHookStatus{{Name: "sysprep", Phase: cdiv1.HookRunning}}))
+		cond := FindConditionByType(cdiv1.DataVolumePostCompletionHooks, dv.Status.Conditions)
+		Expect(cond).ToNot(BeNil())
+		Expect(cond.Status).To(Equal(corev1.ConditionFalse))
+		Expect(cond.Reason).To(Equal(PostCompletionHookRunning))
+		Expect(FindConditionByType(cdiv1.DataVolumeReady, dv.Status.Conditions).Status).To(Equal(corev1.ConditionFalse))
+
+		job := getHookJob("sysprep")
+		Expect(job.Labels[common.PostCompletionHookLabel]).To(Equal("sysprep"))
+		Expect(job.Labels[common.AppKubernetesPartOfLabel]).To(Equal("testing"))
+		Expect(metav1.IsControlledBy(job, dv)).To(BeTrue())
+		Expect(job.Spec.BackoffLimit).To(HaveValue(Equal(int32(2))))
+		podSpec := job.Spec.Template.Spec
+		Expect(podSpec.RestartPolicy).To(Equal(corev1.RestartPolicyNever))
+		Expect(podSpec.Volumes[0].PersistentVolumeClaim.ClaimName).To(Equal("test-dv"))
+		container := podSpec.Containers[0]
+		Expect(container.Image).To(Equal("quay.io/example/sysprep"))
+		Expect(container.Command).To(Equal([]string{"/usr/bin/sysprep"}))
+		Expect(container.VolumeMounts).To(ConsistOf(corev1.VolumeMount{Name: DataVolName, MountPath: common.ImporterDataDir}))
+		Expect(container.Env).To(ContainElements(
+			corev1.EnvVar{Name: common.HookDataPathVar, Value: common.ImporterWritePath},
+			corev1.EnvVar{Name: common.HookDataVolumeNameVar, Value: "test-dv"},
+			corev1.EnvVar{Name: common.HookDataVolumeNamespaceVar, Value: metav1.NamespaceDefault},
+			corev1.EnvVar{Name: common.HookNameVar, Value: "sysprep"},
+		))
+		Expect(container.SecurityContext.RunAsNonRoot).To(HaveValue(BeTrue()))
+
+		completeHookJob("sysprep", batchv1.JobComplete, "")
+		dv = reconcileDataVolume()
+		Expect(dv.Status.Phase).To(Equal(cdiv1.Succeeded))
+		Expect(dv.Status.PostCompletionHooks).To(Equal([]cdiv1.DataVolumeHookStatus{{Name: "sysprep", Phase: cdiv1.HookSucceeded}}))
+		cond = FindConditionByType(cdiv1.DataVolumePostCompletionHooks, dv.Status.Conditions)
+		Expect(cond.Status).To(Equal(corev1.ConditionTrue))
+		Expect(cond.Reason).To(Equal(PostCompletionHooksSucceeded))
+		Expect(FindConditionByType(cdiv1.DataVolumeReady, dv.Status.Conditions).Status).To(Equal(corev1.ConditionTrue))
+	})
+
+	It("Should attach block PVCs to job hooks", func() {
+		pvc, dv := populatedDataVolume(corev1.PersistentVolumeBlock, cdiv1.DataVolumeHook{
+			Name: "license",
+			Job:  &cdiv1.DataVolumeHookJob{Image: "quay.io/example/license"},
+		})
+		reconciler = createImportReconciler(pvc, dv)
+
+		reconcileDataVolume()
+		container := getHookJob("license").Spec.Template.Spec.Containers[0]
+		Expect(container.VolumeMounts).To(BeEmpty())
+		Expect(container.VolumeDevices).To(Equal(AddVolumeDevices()))
+		Expect(container.Env).To(ContainElement(corev1.EnvVar{Name: common.HookDataPathVar, Value: common.WriteBlockPath}))
+	})
+
+	It("Should fail the DataVolume and skip the next hooks once a job hook failed", func() {
+		pvc, dv := populatedDataVolume(corev1.PersistentVolumeFilesystem,
+			cdiv1.DataVolumeHook{Name: "sysprep", Job: &cdiv1.DataVolumeHookJob{Image: "quay.io/example/sysprep"}},
+			cdiv1.DataVolumeHook{Name: "license", Job: &cdiv1.DataVolumeHookJob{Image: "quay.io/example/license"}},
+		)
+		reconciler = createImportReconciler(pvc, dv)
+
+		reconcileDataVolume()
+		completeHookJob("sysprep", batchv1.JobFailed, "Job has reached the specified backoff limit")
+		dv = reconcileDataVolume()
+		Expect(dv.Status.Phase).To(Equal(cdiv1.Failed))
+		Expect(dv.Status.PostCompletionHooks).To(Equal([]cdiv1.DataVolumeHookStatus{
+			{Name: "sysprep", Phase: cdiv1.HookFailed, Message: "Job has reached the specified backoff limit"},
+		}))
+		cond := FindConditionByType(cdiv1.DataVolumePostCompletionHooks, dv.Status.Conditions)
+		Expect(cond.Status).To(Equal(corev1.ConditionFalse))
+		Expect(cond.Reason).To(Equal(PostCompletionHookFailed))
+		Expect(cond.Message).To(Equal("Post completion hook sysprep failed: Job has reached the specified backoff limit"))
+		found := false
+		for len(reconciler.recorder.(*record.FakeRecorder).Events) > 0 {
+			event := <-reconciler.recorder.(*record.FakeRecorder).Events
+			found = found || strings.Contains(event, PostCompletionHookFailed)
+		}
+		Expect(found).To(BeTrue())
+
+		dv = reconcileDataVolume()
+		Expect(dv.Status.Phase).To(Equal(cdiv1.Failed))
+		Expect(reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "test-dv-hook-license", Namespace: metav1.NamespaceDefault}, &batchv1.Job{})).ToNot(Succeed())
+	})
+
+	It("Should call webhook hooks and run the hooks in order", func() {
+		var request postCompletionHookRequest
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer GinkgoRecover()
+			Expect(r.Method).To(Equal(http.MethodPost))
+			Expect(json.NewDecoder(r.Body).Decode(&request)).To(Succeed())
+		}))
+		defer server.Close()
+		pvc, dv := populatedDataVolume(corev1.PersistentVolumeFilesystem,
+			cdiv1.DataVolumeHook{Name: "notify", Webhook: &cdiv1.DataVolumeHookWebhook{URL: server.URL}},
+			cdiv1.DataVolumeHook{Name: "sysprep", Job: &cdiv1.DataVolumeHookJob{Image: "quay.io/example/sysprep"}},
+		)
+		reconciler = createImportReconciler(pvc, dv)
+		allowHookURLs(server.URL + "/")
+
+		dv = reconcileWebhookHook()
+		Expect(request).To(Equal(postCompletionHookRequest{Namespace: metav1.NamespaceDefault, DataVolume: "test-dv", ClaimName: "test-dv", Hook: "notify"}))
+		Expect(dv.Status.Phase).To(Equal(cdiv1.PostCompletionHooksInProgress))
+		Expect(dv.Status.PostCompletionHooks).To(Equal([]cdiv1.DataVolumeHookStatus{
+			{Name: "notify", Phase: cdiv1.HookSucceeded},
+			{Name: "sysprep", Phase: cdiv1.HookRunning},
+		}))
+		getHookJob("sysprep")
+	})
+
+	It("Should fail the DataVolume if a webhook hook is rejected", func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+		}))
+		defer server.Close()
+		pvc, dv := populatedDataVolume(corev1.PersistentVolumeFilesystem,
+			cdiv1.DataVolumeHook{Name: "notify", Webhook: &cdiv1.DataVolumeHookWebhook{URL: server.URL}},
+		)
+		reconciler = createImportReconciler(pvc, dv)
+		allowHookURLs(server.URL)
+
+		dv = reconcileWebhookHook()
+		Expect(dv.Status.Phase).To(Equal(cdiv1.Failed))
+		Expect(dv.Status.PostCompletionHooks).To(Equal([]cdiv1.DataVolumeHookStatus{
+			{Name: "notify", Phase: cdiv1.HookFailed, Message: "webhook returned 403 Forbidden"},
+		}))
+	})
+
+	It("Should call webhook hooks without blocking the reconcile", func() {
+		release := make(chan struct{})
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-release
+		}))
+		defer server.Close()
+		pvc, dv := populatedDataVolume(corev1.PersistentVolumeFilesystem,
+			cdiv1.DataVolumeHook{Name: "notify", Webhook: &cdiv1.DataVolumeHookWebhook{URL: server.URL + "/notify"}},
+		)
+		reconciler = createImportReconciler(pvc, dv)
+		allowHookURLs(server.URL)
+
+		result, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: dvKey})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(postCompletionHookPollInterval))
+		dv = reconcileDataVolume()
+		Expect(dv.Status.Phase).To(Equal(cdiv1.PostCompletionHooksInProgress))
+		Expect(dv.Status.PostCompletionHooks).To(Equal([]cdiv1.DataVolumeHookStatus{{Name: "notify", Phase: cdiv1.HookRunning}}))
+
+		close(release)
+		dv = reconcileWebhookHook()
+		Expect(dv.Status.Phase).To(Equal(cdiv1.Succeeded))
+		Expect(dv.Status.PostCompletionHooks).To(Equal([]cdiv1.DataVolumeHookStatus{{Name: "notify", Phase: cdiv1.HookSucceeded}}))
+	})
+
+	It("Should fail webhook hooks whose URL the CDIConfig does not allow", func() {
+		called := false
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			called = true
+		}))
+		defer server.Close()
+		pvc, dv := populatedDataVolume(corev1.PersistentVolumeFilesystem,
+			cdiv1.DataVolumeHook{Name: "notify", Webhook: &cdiv1.DataVolumeHookWebhook{URL: server.URL + "/notify"}},
+		)
+		reconciler = createImportReconciler(pvc, dv)
+		allowHookURLs(server.URL + "/hooks/")
+
+		dv = reconcileDataVolume()
+		Expect(dv.Status.Phase).To(Equal(cdiv1.Failed))
+		Expect(dv.Status.PostCompletionHooks).To(Equal([]cdiv1.DataVolumeHookStatus{{
+			Name:    "notify",
+			Phase:   cdiv1.HookFailed,
+			Message: "webhook url " + server.URL + "/notify is not under any of the postCompletionHookURLs of the CDIConfig",
+		}}))
+		Expect(called).To(BeFalse())
+	})
+
+	It("Should not run hooks before the DataVolume is populated", func() {
+		dv := NewImportDataVolume("test-dv")
+		dv.Spec.PostCompletionHooks = []cdiv1.DataVolumeHook{{Name: "notify", Webhook: &cdiv1.DataVolumeHookWebhook{URL: "http://127.0.0.1:1"}}}
+		reconciler = createImportReconciler(dv)
+
+		dv = reconcileDataVolume()
+		Expect(dv.Status.Phase).ToNot(Equal(cdiv1.PostCompletionHooksInProgress))
+		Expect(dv.Status.PostCompletionHooks).To(BeEmpty())
+	})
+
+	DescribeTable("Should only allow webhook URLs under the allowed ones", func(hookURL string, allowedURLs []string, expected bool) {
+		Expect(isPostCompletionHookURLAllowed(hookURL, allowedURLs)).To(Equal(expected))
+	},
+		Entry("with no allowed URLs", "https://hooks.example.com/notify", nil, false),
+		Entry("under an allowed URL", "https://hooks.example.com/cdi/notify", []string{"https://other.example.com", "https://hooks.example.com/cdi/"}, true),
+		Entry("on an allowed host", "https://hooks.example.com/notify", []string{"https://hooks.example.com"}, true),
+		Entry("on a host extending an allowed one", "https://hooks.example.com.evil.com/notify", []string{"https://hooks.example.com"}, false),
+		Entry("on another port", "https://hooks.example.com:8443/notify", []string{"https://hooks.example.com"}, false),
+		Entry("with another scheme", "http://hooks.example.com/notify", []string{"https://hooks.example.com"}, false),
+		Entry("outside of the allowed path", "https://hooks.example.com/other", []string{"https://hooks.example.com/cdi/"}, false),
+	)
+})
@@ -18,7 +18,6 @@ package datavolume
 
 import (
 	"context"
-	"crypto/rsa"
 	"fmt"
 	"strconv"
 	"time"
@@ -43,6 +42,7 @@ import (
 	"kubevirt.io/containerized-data-importer/pkg/common"
 	cc "kubevirt.io/containerized-data-importer/pkg/controller/common"
 	featuregates "kubevirt.io/containerized-data-importer/pkg/feature-gates"
+	"kubevirt.io/containerized-data-importer/pkg/token"
 )
 
 const (
@@ -69,11 +69,11 @@ func NewPvcCloneController(
 	clonerImage string,
 	importerImage string,
 	pullPolicy string,
-	tokenPublicKey *rsa.PublicKey,
-	tokenPrivateKey *rsa.PrivateKey,
+	tokenKeys token.KeySet,
 	installerLabels map[string]string,
 ) (controller.Controller, error) {
 	client := mgr.GetClient()
+	tokenConfig := cc.NewTokenConfigFunc(client)
 	reconciler := &PvcCloneReconciler{
 		CloneReconcilerBase: CloneReconcilerBase{
 			ReconcilerBase: ReconcilerBase{
@@ -81,7 +81,7 @@ func NewPvcCloneController(
 				scheme:               mgr.GetScheme(),
 				log:                  log.WithName(pvcCloneControllerName),
 				featureGates:         featuregates.NewFeatureGates(client),
-				recorder:             mgr.GetEventRecorderFor(pvcCloneControllerName),
+				recorder:             cc.NewEventAggregator(mgr.GetEventRecorderFor(pvcCloneControllerName), client),
 				installerLabels:      installerLabels,
 				shouldUpdateProgress: true,
 			},
@@ -89,10 +89,10 @@ func NewPvcCloneController(
 			importerImage:       importerImage,
 			pullPolicy:          pullPolicy,
 			cloneSourceKind:     "PersistentVolumeClaim",
-			shortTokenValidator: cc.NewCloneTokenValidator(common.CloneTokenIssuer, tokenPublicKey),
-			longTokenValidator:  cc.NewCloneTokenValidator(common.ExtendedCloneTokenIssuer, tokenPublicKey),
+			shortTokenValidator: cc.NewCloneTokenValidator(common.CloneTokenIssuer, tokenKeys, tokenConfig),
+			longTokenValidator:  cc.NewCloneTokenValidator(common.ExtendedCloneTokenIssuer, tokenKeys, tokenConfig),
 			// for long term tokens to handle cross namespace dumb clones
-			tokenGenerator: newLongTermCloneTokenGenerator(tokenPrivateKey),
+			tokenGenerator: newLongTermCloneTokenGenerator(tokenKeys, tokenConfig),
 		},
 	}
 
@@ -139,7 +139,7 @@ func (r *PvcCloneReconciler) Reconcile(ctx context.Context, req reconcile.Reques
 
 func (r *PvcCloneReconciler) prepare(syncState *dvSyncState) error {
 	dv := syncState.dvMutated
-	if err := r.populateSourceIfSourceRef(dv); err != nil {
+	if err := populateSourceIfSourceRef(r.client, dv); err != nil {
 		return err
 	}
 	return nil
@@ -147,7 +147,7 @@ func (r *PvcCloneReconciler) prepare(syncState *dvSyncState) error {
 
 func (r *PvcCloneReconciler) cleanup(syncState *dvSyncState) error {
 	dv := syncState.dvMutated
-	if err := r.populateSourceIfSourceRef(dv); err != nil {
+	if err := populateSourceIfSourceRef(r.client, dv); err != nil {
 		return err
 	}
 
@@ -717,6 +717,10 @@ func (r *PvcCloneReconciler) makeSizeDetectionPodSpec(
 	if err != nil {
 		return nil
 	}
+	priorityClassName, err := cc.GetTransferPodPriorityClass(context.TODO(), r.client, cc.GetPriorityClass(sourcePvc), cc.CloneSourcePriorityClass)
+	if err != nil {
+		return nil
+	}
 
 	// Assemble the pod
 	pod := &corev1.Pod{
@@ -732,7 +736,7 @@ func (r *PvcCloneReconciler) makeSizeDetectionPodSpec(
 			NodeSelector:      workloadNodePlacement.NodeSelector,
 			Tolerations:       workloadNodePlacement.Tolerations,
 			Affinity:          workloadNodePlacement.Affinity,
-			PriorityClassName: cc.GetPriorityClass(sourcePvc),
+			PriorityClassName: priorityClassName,
 			ImagePullSecrets:  imagePullSecrets,
 		},
 	}
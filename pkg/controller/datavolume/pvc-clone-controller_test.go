@@ -27,6 +27,7 @@ import (
 	. "github.com/onsi/gomega"
 
 	snapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v6/apis/volumesnapshot/v1"
+	"github.com/prometheus/client_golang/prometheus"
 
 	corev1 "k8s.io/api/core/v1"
 	storagev1 "k8s.io/api/storage/v1"
@@ -50,6 +51,7 @@ import (
 	. "kubevirt.io/containerized-data-importer/pkg/controller/common"
 	"kubevirt.io/containerized-data-importer/pkg/controller/populators"
 	featuregates "kubevirt.io/containerized-data-importer/pkg/feature-gates"
+	metrics "kubevirt.io/containerized-data-importer/pkg/monitoring/metrics/cdi-controller"
 	"kubevirt.io/containerized-data-importer/pkg/token"
 )
 
@@ -163,6 +165,30 @@ var _ = Describe("All DataVolume Tests", func() {
 				Entry("with different namespace", "source-ns"),
 			)
 
+			It("should pass the clone transfer mode, compression, format, group and strategies to the VolumeCloneSource", func() {
+				dv := newCloneDataVolume("test-dv")
+				dv.Annotations[AnnExtendedCloneToken] = "foobar"
+				dv.Spec.CloneTransferMode = ptr.To(cdiv1.CloneTransferModeRsync)
+				dv.Spec.CloneCompression = ptr.To(cdiv1.CloneCompressionZstd)
+				dv.Spec.CloneFormat = ptr.To(cdiv1.TransformationFormatQcow2)
+				dv.Spec.CloneGroup = "vdi-pool"
+				dv.Spec.CloneStrategy = ptr.To(cdiv1.CloneStrategyCsiClone)
+				dv.Spec.CloneStrategyFallbacks = []cdiv1.CDICloneStrategy{cdiv1.CloneStrategyHostAssisted}
+				srcPvc := CreatePvcInStorageClass("test", metav1.NamespaceDefault, &scName, nil, nil, corev1.ClaimBound)
+				reconciler = createCloneReconcilerWFFCDisabled(storageClass, csiDriver, dv, srcPvc)
+				_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}})
+				Expect(err).ToNot(HaveOccurred())
+				vcs := &cdiv1.VolumeCloneSource{}
+				err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: volumeCloneSourceName(dv), Namespace: metav1.NamespaceDefault}, vcs)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(vcs.Spec.TransferMode).To(HaveValue(Equal(cdiv1.CloneTransferModeRsync)))
+				Expect(vcs.Spec.Compression).To(HaveValue(Equal(cdiv1.CloneCompressionZstd)))
+				Expect(vcs.Spec.Format).To(HaveValue(Equal(cdiv1.TransformationFormatQcow2)))
+				Expect(vcs.Spec.Group).To(Equal("vdi-pool"))
+				Expect(vcs.Spec.Strategy).To(HaveValue(Equal(cdiv1.CloneStrategyCsiClone)))
+				Expect(vcs.Spec.StrategyFallbacks).To(Equal([]cdiv1.CDICloneStrategy{cdiv1.CloneStrategyHostAssisted}))
+			})
+
 			It("should add cloneType annotation", func() {
 				dv := newCloneDataVolume("test-dv")
 				anno := map[string]string{
@@ -236,12 +262,59 @@ var _ = Describe("All DataVolume Tests", func() {
 				Expect(err).ToNot(HaveOccurred())
 				Expect(pvc.Annotations[AnnCloneType]).To(Equal(string(cdiv1.CloneStrategyHostAssisted)))
 				Expect(pvc.Annotations[populators.AnnCloneFallbackReason]).To(Equal(NoPopulatorMessage))
+				Expect(pvc.Annotations[populators.AnnCloneStrategyFallbacks]).To(ContainSubstring(NoPopulator))
 
 				event := <-reconciler.recorder.(*record.FakeRecorder).Events
 				Expect(event).To(ContainSubstring(NoPopulator))
 				Expect(event).To(ContainSubstring(NoPopulatorMessage))
 			})
 
+			It("should report the clone strategy and its fallbacks in the status", func() {
+				dv := newCloneDataVolume("test-dv")
+				anno := map[string]string{
+					AnnExtendedCloneToken:                "test-token",
+					AnnCloneType:                         string(cdiv1.CloneStrategyCsiClone),
+					populators.AnnClonePhase:             clone.CSIClonePhaseName,
+					populators.AnnCloneStrategyFallbacks: `[{"strategy":"snapshot","reason":"NoVolumeSnapshotClass","message":"no snapshot class"}]`,
+					AnnUsePopulator:                      "true",
+				}
+				pvc := CreatePvcInStorageClass("test-dv", metav1.NamespaceDefault, &scName, anno, nil, corev1.ClaimPending)
+				pvc.Spec.DataSourceRef = &corev1.TypedObjectReference{
+					Kind: cdiv1.VolumeCloneSourceRef,
+					Name: volumeCloneSourceName(dv),
+				}
+				pvc.OwnerReferences = append(pvc.OwnerReferences, metav1.OwnerReference{
+					Kind:       "DataVolume",
+					Controller: ptr.To[bool](true),
+					Name:       "test-dv",
+					UID:        dv.UID,
+				})
+				vcs := &cdiv1.VolumeCloneSource{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: metav1.NamespaceDefault,
+						Name:      volumeCloneSourceName(dv),
+					},
+					Spec: cdiv1.VolumeCloneSourceSpec{
+						Source: corev1.TypedLocalObjectReference{
+							Kind: "PersistentVolumeClaim",
+							Name: dv.Spec.Source.PVC.Name,
+						},
+					},
+				}
+				reconciler = createCloneReconciler(storageClass, csiDriver, dv, pvc, vcs)
+				_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}})
+				Expect(err).ToNot(HaveOccurred())
+				dv = &cdiv1.DataVolume{}
+				err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}, dv)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(dv.Status.CloneStrategy).To(Equal(&cdiv1.DataVolumeCloneStrategyStatus{
+					Strategy: cdiv1.CloneStrategyCsiClone,
+					Fallbacks: []cdiv1.CloneStrategyFallback{
+						{Strategy: cdiv1.CloneStrategySnapshot, Reason: "NoVolumeSnapshotClass", Message: "no snapshot class"},
+					},
+				}))
+			})
+
 			DescribeTable("should map phase correctly", func(phaseName string, dvPhase cdiv1.DataVolumePhase, eventReason string) {
 				dv := newCloneDataVolume("test-dv")
 				anno := map[string]string{
@@ -1091,6 +1164,52 @@ var _ = Describe("All DataVolume Tests", func() {
 	})
 })
 
+var _ = Describe("Clone progress", func() {
+	It("Should keep the throughput only while transferring", func() {
+		dv := newCloneDataVolume("test-dv")
+		updateCloneReport(dv, &CloneReport{
+			Phase:            cdiv1.ClonePhaseTransfer,
+			TransferredBytes: ptr.To[int64](512),
+			TotalBytes:       ptr.To[int64](1024),
+			Throughput:       ptr.To[int64](256),
+		})
+		Expect(dv.Status.ClonePhase).To(Equal(cdiv1.ClonePhaseTransfer))
+		Expect(dv.Status.TransferredBytes).To(HaveValue(Equal(int64(512))))
+		Expect(dv.Status.TotalBytes).To(HaveValue(Equal(int64(1024))))
+		Expect(dv.Status.Throughput).To(HaveValue(Equal(int64(256))))
+
+		updateCloneReport(dv, &CloneReport{Phase: cdiv1.ClonePhaseResize, Throughput: ptr.To[int64](256)})
+		Expect(dv.Status.ClonePhase).To(Equal(cdiv1.ClonePhaseResize))
+		Expect(dv.Status.TransferredBytes).To(HaveValue(Equal(int64(512))))
+		Expect(dv.Status.Throughput).To(BeNil())
+	})
+
+	It("Should report the whole source transferred once the clone succeeded", func() {
+		dv := newCloneDataVolume("test-dv")
+		dv.Status.Phase = cdiv1.Succeeded
+		updateCloneReport(dv, &CloneReport{TransferredBytes: ptr.To[int64](512), TotalBytes: ptr.To[int64](1024)})
+		Expect(dv.Status.TransferredBytes).To(HaveValue(Equal(int64(1024))))
+		Expect(dv.Status.ClonePhase).To(BeEmpty())
+	})
+
+	It("Should export the progress of the clone while it is in progress", func() {
+		dv := newCloneDataVolumeWithPVCNS("test-dv", "source-ns")
+		dv.Status.ClonePhase = cdiv1.ClonePhaseTransfer
+		dv.Status.TransferredBytes = ptr.To[int64](512)
+		updateCloneMetrics(dv)
+		labels := prometheus.Labels{
+			metrics.PrometheusCloneSourceNsLabel: "source-ns",
+			metrics.PrometheusCloneNsLabel:       dv.Namespace,
+			metrics.PrometheusCloneNameLabel:     dv.Name,
+		}
+		Expect(metrics.GetDataVolumeCloneTransferredBytes(labels)).To(Equal(float64(512)))
+
+		dv.Status.ClonePhase = ""
+		updateCloneMetrics(dv)
+		Expect(metrics.DeleteDataVolumeCloneProgress(labels)).To(BeZero())
+	})
+})
+
 func createCloneReconcilerWFFCDisabled(objects ...client.Object) *PvcCloneReconciler {
 	return createCloneReconcilerWithFeatureGates(nil, objects...)
 }
@@ -0,0 +1,173 @@
+/*
+Copyright 2026 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package datavolume
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	snapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v6/apis/volumesnapshot/v1"
+	"github.com/pkg/errors"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+	cdiuploadv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/upload/v1beta1"
+	"kubevirt.io/containerized-data-importer/pkg/controller/clone"
+	cc "kubevirt.io/containerized-data-importer/pkg/controller/common"
+)
+
+// RenderDataVolume renders the PVC of the DataVolume, picks how it is populated and gets the StorageProfile values it
+// uses, the way the DataVolume controllers do but without creating anything. The PVC spec is always rendered from
+// StorageProfiles, whatever the PVC mutating webhook is set to do. The missing size of clones is taken from their
+// source, the one of imports detecting it is left empty.
+func RenderDataVolume(ctx context.Context, c client.Client, log logr.Logger, dv *cdiv1.DataVolume) (*cdiuploadv1.DataVolumeRenderRequestStatus, error) {
+	dv = dv.DeepCopy()
+	if err := populateSourceIfSourceRef(c, dv); err != nil {
+		return nil, err
+	}
+	if dv.Spec.Source == nil {
+		return nil, errors.Errorf("datavolume one of {source, sourceRef} field is required")
+	}
+
+	pvcSpec, err := renderDataVolumePvcSpec(c, dv)
+	if err != nil {
+		return nil, err
+	}
+	targetClaim := &v1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: dv.Name, Namespace: dv.Namespace},
+		Spec:       *pvcSpec,
+	}
+	cloneSource := renderVolumeCloneSource(dv)
+	if cloneSource != nil {
+		if size := targetClaim.Spec.Resources.Requests[v1.ResourceStorage]; size.IsZero() {
+			if err := renderCloneVolumeSizeFromSourceRef(ctx, c, targetClaim, cloneSource.Namespace, cloneSource.Spec.Source); err != nil {
+				return nil, err
+			}
+		}
+	}
+	status := &cdiuploadv1.DataVolumeRenderRequestStatus{PVCSpec: &targetClaim.Spec}
+
+	usePopulator, err := shouldUseCDIPopulator(c, log, dv, pvcSpec.StorageClassName)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case cloneSource != nil && usePopulator:
+		if err := renderCloneStrategy(ctx, c, log, targetClaim, cloneSource, status); err != nil {
+			return nil, err
+		}
+	case cloneSource != nil:
+		status.PopulationStrategy = cdiuploadv1.PopulationStrategyHostAssisted
+	case usePopulator:
+		status.PopulationStrategy = cdiuploadv1.PopulationStrategyPopulator
+	default:
+		status.PopulationStrategy = cdiuploadv1.PopulationStrategyPod
+	}
+
+	if pvcSpec.StorageClassName != nil {
+		storageProfile := &cdiv1.StorageProfile{}
+		exists, err := cc.GetResource(ctx, c, metav1.NamespaceNone, *pvcSpec.StorageClassName, storageProfile)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			status.StorageProfile = storageProfile.Status.DeepCopy()
+		}
+	}
+
+	return status, nil
+}
+
+func renderDataVolumePvcSpec(c client.Client, dv *cdiv1.DataVolume) (*v1.PersistentVolumeClaimSpec, error) {
+	if dv.Spec.PVC != nil {
+		return dv.Spec.PVC.DeepCopy(), nil
+	}
+	if dv.Spec.Storage == nil {
+		return nil, errors.Errorf("datavolume one of {pvc, storage} field is required")
+	}
+
+	pvcSpec := copyStorageAsPvc(dv.Spec.Storage)
+	if err := renderPvcSpecVolumeModeAndAccessModesAndStorageClass(c, nil, nil, dv, pvcSpec, dv.Spec.ContentType); err != nil {
+		return nil, err
+	}
+	isClone := dv.Spec.Source.PVC != nil || dv.Spec.Source.Snapshot != nil
+	if err := renderPvcSpecVolumeSize(c, pvcSpec, isClone || CanDetectImportSize(&dv.Spec), nil); err != nil {
+		return nil, err
+	}
+	return pvcSpec, nil
+}
+
+// renderVolumeCloneSource returns the VolumeCloneSource the clone controllers would create for the DataVolume, nil if
+// it is not a clone
+func renderVolumeCloneSource(dv *cdiv1.DataVolume) *cdiv1.VolumeCloneSource {
+	_, sourceName, sourceNamespace := cc.GetCloneSourceInfo(dv)
+	if sourceName == "" {
+		return nil
+	}
+	if sourceNamespace == "" {
+		sourceNamespace = dv.Namespace
+	}
+	source := v1.TypedLocalObjectReference{Kind: "PersistentVolumeClaim", Name: sourceName}
+	if dv.Spec.Source.Snapshot != nil {
+		source = v1.TypedLocalObjectReference{APIGroup: ptr.To(snapshotv1.GroupName), Kind: "VolumeSnapshot", Name: sourceName}
+	}
+	return &cdiv1.VolumeCloneSource{
+		ObjectMeta: metav1.ObjectMeta{Namespace: sourceNamespace},
+		Spec: cdiv1.VolumeCloneSourceSpec{
+			Source:            source,
+			Strategy:          dv.Spec.CloneStrategy,
+			StrategyFallbacks: dv.Spec.CloneStrategyFallbacks,
+			Format:            dv.Spec.CloneFormat,
+		},
+	}
+}
+
+// renderCloneStrategy asks the clone planner for the strategy the clone populator would pick for the PVC
+func renderCloneStrategy(ctx context.Context, c client.Client, log logr.Logger, targetClaim *v1.PersistentVolumeClaim,
+	cloneSource *cdiv1.VolumeCloneSource, status *cdiuploadv1.DataVolumeRenderRequestStatus) error {
+	// Events of the planner are dropped, there is no object to record them on
+	planner := &clone.Planner{Client: c, Recorder: &record.FakeRecorder{}}
+	res, err := planner.ChooseStrategy(ctx, &clone.ChooseStrategyArgs{
+		Log:         log,
+		TargetClaim: targetClaim,
+		DataSource:  cloneSource,
+	})
+	if err != nil || res == nil {
+		return err
+	}
+
+	switch res.Strategy {
+	case cdiv1.CloneStrategyCsiClone:
+		status.PopulationStrategy = cdiuploadv1.PopulationStrategyCsiClone
+	case cdiv1.CloneStrategySnapshot:
+		status.PopulationStrategy = cdiuploadv1.PopulationStrategySnapshot
+	case cdiv1.CloneStrategyReflink:
+		status.PopulationStrategy = cdiuploadv1.PopulationStrategyReflink
+	default:
+		status.PopulationStrategy = cdiuploadv1.PopulationStrategyHostAssisted
+	}
+	if res.FallbackReason != nil {
+		status.FallbackReason = *res.FallbackReason
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+/*
+Copyright 2026 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package datavolume
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+	cdiuploadv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/upload/v1beta1"
+	. "kubevirt.io/containerized-data-importer/pkg/controller/common"
+)
+
+var _ = Describe("RenderDataVolume", func() {
+	const (
+		scName     = "test-sc"
+		pluginName = "csi-plugin"
+	)
+
+	var (
+		storageClass = CreateStorageClassWithProvisioner(scName, map[string]string{AnnDefaultStorageClass: "true"}, nil, pluginName)
+		csiDriver    = &storagev1.CSIDriver{ObjectMeta: metav1.ObjectMeta{Name: pluginName}}
+	)
+
+	storageProfile := func(cloneStrategy *cdiv1.CDICloneStrategy) *cdiv1.StorageProfile {
+		return createStorageProfileWithCloneStrategy(scName, []cdiv1.ClaimPropertySet{{
+			AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany},
+			VolumeMode:  ptr.To(corev1.PersistentVolumeBlock),
+		}}, cloneStrategy)
+	}
+
+	importDataVolume := func() *cdiv1.DataVolume {
+		dv := NewImportDataVolume("test-dv")
+		dv.Spec.PVC = nil
+		dv.Spec.Storage = &cdiv1.StorageSpec{
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("1Gi")},
+			},
+		}
+		return dv
+	}
+
+	sourcePvc := func() *corev1.PersistentVolumeClaim {
+		pvc := CreatePvcInStorageClass("test", metav1.NamespaceDefault, ptr.To(scName), nil, nil, corev1.ClaimBound)
+		pvc.Spec.VolumeMode = ptr.To(corev1.PersistentVolumeBlock)
+		pvc.Spec.Resources.Requests = corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("2Gi")}
+		pvc.Status.Capacity = corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("2Gi")}
+		return pvc
+	}
+
+	render := func(dv *cdiv1.DataVolume, objs ...client.Object) *cdiuploadv1.DataVolumeRenderRequestStatus {
+		c := createClient(objs...)
+		status, err := RenderDataVolume(context.TODO(), c, dvCloneLog, dv)
+		Expect(err).ToNot(HaveOccurred())
+		dvs := &cdiv1.DataVolumeList{}
+		Expect(c.List(context.TODO(), dvs)).To(Succeed())
+		Expect(dvs.Items).To(BeEmpty())
+		pvcs := &corev1.PersistentVolumeClaimList{}
+		Expect(c.List(context.TODO(), pvcs)).To(Succeed())
+		for _, pvc := range pvcs.Items {
+			Expect(pvc.Name).ToNot(Equal(dv.Name))
+		}
+		return status
+	}
+
+	It("Should render the PVC of imports from the StorageProfile", func() {
+		sp := storageProfile(nil)
+		status := render(importDataVolume(), storageClass, sp, csiDriver)
+		Expect(status.PVCSpec.StorageClassName).To(HaveValue(Equal(scName)))
+		Expect(status.PVCSpec.AccessModes).To(ConsistOf(corev1.ReadWriteMany))
+		Expect(status.PVCSpec.VolumeMode).To(HaveValue(Equal(corev1.PersistentVolumeBlock)))
+		Expect(status.PVCSpec.Resources.Requests.Storage().Cmp(resource.MustParse("1Gi"))).To(BeZero())
+		Expect(status.PopulationStrategy).To(Equal(cdiuploadv1.PopulationStrategyPopulator))
+		Expect(status.StorageProfile).To(HaveValue(Equal(sp.Status)))
+	})
+
+	It("Should import with pods without CSI driver", func() {
+		status := render(importDataVolume(), storageClass, storageProfile(nil))
+		Expect(status.PopulationStrategy).To(Equal(cdiuploadv1.PopulationStrategyPod))
+	})
+
+	It("Should fail DataVolumes the StorageProfile does not complete", func() {
+		_, err := RenderDataVolume(context.TODO(), createClient(storageClass, csiDriver), dvCloneLog, importDataVolume())
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("Should pick the clone strategy of the StorageProfile and size the clone from its source", func() {
+		dv := newCloneDataVolumeWithEmptyStorage("test-dv", metav1.NamespaceDefault)
+		status := render(dv, storageClass, storageProfile(ptr.To(cdiv1.CloneStrategyCsiClone)), csiDriver, sourcePvc(), MakeEmptyCDICR())
+		Expect(status.PopulationStrategy).To(Equal(cdiuploadv1.PopulationStrategyCsiClone))
+		Expect(status.FallbackReason).To(BeEmpty())
+		Expect(status.PVCSpec.Resources.Requests.Storage().Cmp(resource.MustParse("2Gi"))).To(BeZero())
+	})
+
+	It("Should report why clones fall back to host-assisted", func() {
+		dv := newCloneDataVolumeWithEmptyStorage("test-dv", metav1.NamespaceDefault)
+		status := render(dv, storageClass, storageProfile(nil), csiDriver, sourcePvc(), MakeEmptyCDICR())
+		Expect(status.PopulationStrategy).To(Equal(cdiuploadv1.PopulationStrategyHostAssisted))
+		Expect(status.FallbackReason).ToNot(BeEmpty())
+	})
+
+	It("Should clone with host-assisted without CSI driver", func() {
+		dv := newCloneDataVolumeWithEmptyStorage("test-dv", metav1.NamespaceDefault)
+		status := render(dv, storageClass, storageProfile(ptr.To(cdiv1.CloneStrategyCsiClone)), sourcePvc(), MakeEmptyCDICR())
+		Expect(status.PopulationStrategy).To(Equal(cdiuploadv1.PopulationStrategyHostAssisted))
+	})
+
+	It("Should resolve the source of DataVolumes referring to a DataSource", func() {
+		dataSource := &cdiv1.DataSource{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-ds", Namespace: metav1.NamespaceDefault},
+			Spec: cdiv1.DataSourceSpec{
+				Source: cdiv1.DataSourceSource{
+					PVC: &cdiv1.DataVolumeSourcePVC{Name: "test", Namespace: metav1.NamespaceDefault},
+				},
+			},
+		}
+		dv := newCloneDataVolumeWithEmptyStorage("test-dv", metav1.NamespaceDefault)
+		dv.Spec.Source = nil
+		dv.Spec.SourceRef = &cdiv1.DataVolumeSourceRef{Kind: cdiv1.DataVolumeDataSource, Name: dataSource.Name}
+		status := render(dv, storageClass, storageProfile(ptr.To(cdiv1.CloneStrategyCsiClone)), csiDriver, sourcePvc(), dataSource, MakeEmptyCDICR())
+		Expect(status.PopulationStrategy).To(Equal(cdiuploadv1.PopulationStrategyCsiClone))
+	})
+})
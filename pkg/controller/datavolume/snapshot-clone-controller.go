@@ -18,7 +18,6 @@ package datavolume
 
 import (
 	"context"
-	"crypto/rsa"
 	"fmt"
 
 	"github.com/go-logr/logr"
@@ -26,7 +25,6 @@ import (
 	"github.com/pkg/errors"
 
 	corev1 "k8s.io/api/core/v1"
-	storagev1 "k8s.io/api/storage/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -42,8 +40,10 @@ import (
 
 	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
 	"kubevirt.io/containerized-data-importer/pkg/common"
+	"kubevirt.io/containerized-data-importer/pkg/controller/clone"
 	cc "kubevirt.io/containerized-data-importer/pkg/controller/common"
 	featuregates "kubevirt.io/containerized-data-importer/pkg/feature-gates"
+	"kubevirt.io/containerized-data-importer/pkg/token"
 	"kubevirt.io/containerized-data-importer/pkg/util"
 )
 
@@ -69,11 +69,11 @@ func NewSnapshotCloneController(
 	clonerImage string,
 	importerImage string,
 	pullPolicy string,
-	tokenPublicKey *rsa.PublicKey,
-	tokenPrivateKey *rsa.PrivateKey,
+	tokenKeys token.KeySet,
 	installerLabels map[string]string,
 ) (controller.Controller, error) {
 	client := mgr.GetClient()
+	tokenConfig := cc.NewTokenConfigFunc(client)
 	reconciler := &SnapshotCloneReconciler{
 		CloneReconcilerBase: CloneReconcilerBase{
 			ReconcilerBase: ReconcilerBase{
@@ -81,7 +81,7 @@ func NewSnapshotCloneController(
 				scheme:               mgr.GetScheme(),
 				log:                  log.WithName(snapshotCloneControllerName),
 				featureGates:         featuregates.NewFeatureGates(client),
-				recorder:             mgr.GetEventRecorderFor(snapshotCloneControllerName),
+				recorder:             cc.NewEventAggregator(mgr.GetEventRecorderFor(snapshotCloneControllerName), client),
 				installerLabels:      installerLabels,
 				shouldUpdateProgress: true,
 			},
@@ -90,10 +90,10 @@ func NewSnapshotCloneController(
 			pullPolicy:          pullPolicy,
 			cloneSourceAPIGroup: ptr.To[string](snapshotv1.GroupName),
 			cloneSourceKind:     "VolumeSnapshot",
-			shortTokenValidator: cc.NewCloneTokenValidator(common.CloneTokenIssuer, tokenPublicKey),
-			longTokenValidator:  cc.NewCloneTokenValidator(common.ExtendedCloneTokenIssuer, tokenPublicKey),
+			shortTokenValidator: cc.NewCloneTokenValidator(common.CloneTokenIssuer, tokenKeys, tokenConfig),
+			longTokenValidator:  cc.NewCloneTokenValidator(common.ExtendedCloneTokenIssuer, tokenKeys, tokenConfig),
 			// for long term tokens to handle cross namespace dumb clones
-			tokenGenerator: newLongTermCloneTokenGenerator(tokenPrivateKey),
+			tokenGenerator: newLongTermCloneTokenGenerator(tokenKeys, tokenConfig),
 		},
 	}
 
@@ -150,7 +150,7 @@ func (r *SnapshotCloneReconciler) Reconcile(ctx context.Context, req reconcile.R
 
 func (r *SnapshotCloneReconciler) prepare(syncState *dvSyncState) error {
 	dv := syncState.dvMutated
-	if err := r.populateSourceIfSourceRef(dv); err != nil {
+	if err := populateSourceIfSourceRef(r.client, dv); err != nil {
 		return err
 	}
 
@@ -413,12 +413,11 @@ func (r *SnapshotCloneReconciler) createTempHostAssistedSourcePvc(dv *cdiv1.Data
 	tempHostAssistedSourcePvc.Labels[common.CDIComponentLabel] = common.CloneFromSnapshotFallbackPVCCDILabel
 	// Figure out storage class of source snap
 	// Can only restore to original storage class, but there might be several SCs with same driver
-	// So we do best effort here
 	vsc := &snapshotv1.VolumeSnapshotClass{}
 	if err := r.client.Get(context.TODO(), types.NamespacedName{Name: *snapshot.Spec.VolumeSnapshotClassName}, vsc); err != nil {
 		return err
 	}
-	sc, err := r.getStorageClassCorrespondingToSnapClass(vsc.Driver)
+	sc, err := clone.GetStorageClassForSnapshotRestore(context.TODO(), r.client, snapshot, vsc.Driver)
 	if err != nil {
 		return err
 	}
@@ -450,31 +449,6 @@ func (r *SnapshotCloneReconciler) createTempHostAssistedSourcePvc(dv *cdiv1.Data
 	return nil
 }
 
-func (r *SnapshotCloneReconciler) getStorageClassCorrespondingToSnapClass(driver string) (string, error) {
-	matches := []storagev1.StorageClass{}
-
-	storageClasses := &storagev1.StorageClassList{}
-	if err := r.client.List(context.TODO(), storageClasses); err != nil {
-		r.log.V(3).Info("Unable to retrieve available storage classes")
-		return "", errors.New("unable to retrieve storage classes")
-	}
-	for _, storageClass := range storageClasses.Items {
-		if storageClass.Provisioner == driver {
-			matches = append(matches, storageClass)
-		}
-	}
-
-	if len(matches) > 1 {
-		r.log.V(3).Info("more than one storage class match for snapshot driver, picking first", "storageClass.Name", matches[0].Name)
-		return matches[0].Name, nil
-	}
-	if len(matches) == 0 {
-		return "", errors.New("no storage class match for snapshot driver")
-	}
-
-	return matches[0].Name, nil
-}
-
 func (r *SnapshotCloneReconciler) makePvcFromSnapshot(pvcName string, dv *cdiv1.DataVolume, snapshot *snapshotv1.VolumeSnapshot, targetPvcSpec *corev1.PersistentVolumeClaimSpec) (*corev1.PersistentVolumeClaim, error) {
 	newPvc, err := newPvcFromSnapshot(dv, pvcName, snapshot, targetPvcSpec)
 	if err != nil {
@@ -511,7 +485,7 @@ func getTempHostAssistedSourcePvcName(dv *cdiv1.DataVolume) string {
 
 func (r *SnapshotCloneReconciler) cleanup(syncState *dvSyncState) error {
 	dv := syncState.dvMutated
-	if err := r.populateSourceIfSourceRef(dv); err != nil {
+	if err := populateSourceIfSourceRef(r.client, dv); err != nil {
 		return err
 	}
 
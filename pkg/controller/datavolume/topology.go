@@ -0,0 +1,136 @@
+/*
+Copyright 2026 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package datavolume
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/pkg/errors"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+	cc "kubevirt.io/containerized-data-importer/pkg/controller/common"
+)
+
+// topologyRequeueDuration is how often the VirtualMachineInstance hinting the topology of a DataVolume is looked for
+const topologyRequeueDuration = 10 * time.Second
+
+var virtualMachineInstanceGVK = schema.GroupVersionKind{Group: "kubevirt.io", Version: "v1", Kind: "VirtualMachineInstance"}
+
+// syncTopologyPlacement places the import of a PVC on WaitForFirstConsumer storage in the topology domain hinted by
+// the DataVolume, binding the PVC there instead of waiting for its consumer
+func (r *ReconcilerBase) syncTopologyPlacement(syncState *dvSyncState) error {
+	dv := syncState.dvMutated
+	pvc := syncState.pvc
+	if dv.Spec.Topology == nil || pvc.Status.Phase == corev1.ClaimBound {
+		return nil
+	}
+	if _, ok := pvc.Annotations[cc.AnnTopologyPlacement]; ok || pvc.Annotations[cc.AnnSelectedNode] != "" {
+		return nil
+	}
+	wffc, err := r.storageClassWaitForFirstConsumer(pvc.Spec.StorageClassName)
+	if err != nil || !wffc {
+		return err
+	}
+
+	placement, err := r.getTopologyPlacement(dv)
+	if err != nil {
+		return err
+	}
+	if placement == nil {
+		r.log.V(1).Info("Waiting for the VirtualMachineInstance hinting the topology of the DataVolume",
+			"VirtualMachineInstance", dv.Spec.Topology.VirtualMachineInstance)
+		if syncState.result == nil {
+			syncState.result = &reconcile.Result{}
+		}
+		syncState.result.RequeueAfter = topologyRequeueDuration
+		return nil
+	}
+	value, err := json.Marshal(placement)
+	if err != nil {
+		return err
+	}
+	cc.AddAnnotation(pvc, cc.AnnTopologyPlacement, string(value))
+	cc.AddAnnotation(pvc, cc.AnnImmediateBinding, "")
+	return r.updatePVC(pvc)
+}
+
+// getTopologyPlacement returns the placement of the topology domain hinted by the DataVolume, nil while the
+// VirtualMachineInstance it references does not exist
+func (r *ReconcilerBase) getTopologyPlacement(dv *cdiv1.DataVolume) (*cc.TopologyPlacement, error) {
+	topology := dv.Spec.Topology
+	placement := &cc.TopologyPlacement{NodeSelector: map[string]string{}}
+	for key, value := range topology.NodeSelector {
+		placement.NodeSelector[key] = value
+	}
+	if topology.VirtualMachineInstance == "" {
+		return placement, nil
+	}
+
+	vmi := &unstructured.Unstructured{}
+	vmi.SetGroupVersionKind(virtualMachineInstanceGVK)
+	key := types.NamespacedName{Namespace: dv.Namespace, Name: topology.VirtualMachineInstance}
+	if err := r.client.Get(context.TODO(), key, vmi); err != nil {
+		if k8serrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if nodeName, _, _ := unstructured.NestedString(vmi.Object, "status", "nodeName"); nodeName != "" {
+		placement.NodeAffinity = &corev1.NodeSelector{
+			NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+				MatchFields: []corev1.NodeSelectorRequirement{{
+					Key:      "metadata.name",
+					Operator: corev1.NodeSelectorOpIn,
+					Values:   []string{nodeName},
+				}},
+			}},
+		}
+		return placement, nil
+	}
+
+	nodeSelector, _, err := unstructured.NestedStringMap(vmi.Object, "spec", "nodeSelector")
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid node selector of VirtualMachineInstance %s", key)
+	}
+	for k, v := range nodeSelector {
+		placement.NodeSelector[k] = v
+	}
+	required, found, err := unstructured.NestedMap(vmi.Object, "spec", "affinity", "nodeAffinity", "requiredDuringSchedulingIgnoredDuringExecution")
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid node affinity of VirtualMachineInstance %s", key)
+	}
+	if !found {
+		return placement, nil
+	}
+	placement.NodeAffinity = &corev1.NodeSelector{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(required, placement.NodeAffinity); err != nil {
+		return nil, errors.Wrapf(err, "invalid node affinity of VirtualMachineInstance %s", key)
+	}
+	return placement, nil
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2026 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package datavolume
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+	. "kubevirt.io/containerized-data-importer/pkg/controller/common"
+)
+
+var _ = Describe("Topology placement", func() {
+	var (
+		reconciler *ImportReconciler
+		dvKey      = types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}
+	)
+
+	AfterEach(func() {
+		if reconciler != nil {
+			close(reconciler.recorder.(*record.FakeRecorder).Events)
+			reconciler = nil
+		}
+	})
+
+	createReconciler := func(bindingMode storagev1.VolumeBindingMode, topology *cdiv1.DataVolumeTopology) {
+		sc := createStorageClassWithBindingMode("test-sc", map[string]string{AnnDefaultStorageClass: "true"}, bindingMode)
+		dv := NewImportDataVolume("test-dv")
+		dv.Spec.Topology = topology
+		reconciler = createImportReconciler(sc, dv)
+	}
+
+	reconcileAndGetPVC := func() (reconcile.Result, *corev1.PersistentVolumeClaim) {
+		res, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: dvKey})
+		Expect(err).ToNot(HaveOccurred())
+		pvc := &corev1.PersistentVolumeClaim{}
+		Expect(reconciler.client.Get(context.TODO(), dvKey, pvc)).To(Succeed())
+		return res, pvc
+	}
+
+	It("Should bind the PVC in the topology domain of the node selector on WaitForFirstConsumer storage", func() {
+		createReconciler(storagev1.VolumeBindingWaitForFirstConsumer, &cdiv1.DataVolumeTopology{
+			NodeSelector: map[string]string{"topology.kubernetes.io/zone": "zone-a"},
+		})
+		_, pvc := reconcileAndGetPVC()
+		Expect(pvc.Annotations[AnnTopologyPlacement]).To(MatchJSON(`{"nodeSelector":{"topology.kubernetes.io/zone":"zone-a"}}`))
+		Expect(pvc.Annotations).To(HaveKey(AnnImmediateBinding))
+	})
+
+	It("Should not place the import on immediate binding storage", func() {
+		createReconciler(storagev1.VolumeBindingImmediate, &cdiv1.DataVolumeTopology{
+			NodeSelector: map[string]string{"topology.kubernetes.io/zone": "zone-a"},
+		})
+		_, pvc := reconcileAndGetPVC()
+		Expect(pvc.Annotations).ToNot(HaveKey(AnnTopologyPlacement))
+		Expect(pvc.Annotations).ToNot(HaveKey(AnnImmediateBinding))
+	})
+
+	It("Should wait for the VirtualMachineInstance and place the import on its node", func() {
+		createReconciler(storagev1.VolumeBindingWaitForFirstConsumer, &cdiv1.DataVolumeTopology{VirtualMachineInstance: "test-vmi"})
+		res, pvc := reconcileAndGetPVC()
+		Expect(res.RequeueAfter).To(Equal(topologyRequeueDuration))
+		Expect(pvc.Annotations).ToNot(HaveKey(AnnTopologyPlacement))
+		Expect(pvc.Annotations).ToNot(HaveKey(AnnImmediateBinding))
+
+		vmi := &unstructured.Unstructured{}
+		vmi.SetGroupVersionKind(virtualMachineInstanceGVK)
+		vmi.SetName("test-vmi")
+		vmi.SetNamespace(metav1.NamespaceDefault)
+		Expect(unstructured.SetNestedField(vmi.Object, "node01", "status", "nodeName")).To(Succeed())
+		Expect(reconciler.client.Create(context.TODO(), vmi)).To(Succeed())
+
+		_, pvc = reconcileAndGetPVC()
+		Expect(pvc.Annotations[AnnTopologyPlacement]).To(MatchJSON(
+			`{"nodeAffinity":{"nodeSelectorTerms":[{"matchFields":[{"key":"metadata.name","operator":"In","values":["node01"]}]}]}}`))
+		Expect(pvc.Annotations).To(HaveKey(AnnImmediateBinding))
+	})
+
+	It("Should place the import with the node selector and required node affinity of a pending VirtualMachineInstance", func() {
+		createReconciler(storagev1.VolumeBindingWaitForFirstConsumer, &cdiv1.DataVolumeTopology{VirtualMachineInstance: "test-vmi"})
+		vmi := &unstructured.Unstructured{Object: map[string]interface{}{
+			"spec": map[string]interface{}{
+				"nodeSelector": map[string]interface{}{"topology.kubernetes.io/zone": "zone-b"},
+				"affinity": map[string]interface{}{
+					"nodeAffinity": map[string]interface{}{
+						"requiredDuringSchedulingIgnoredDuringExecution": map[string]interface{}{
+							"nodeSelectorTerms": []interface{}{
+								map[string]interface{}{
+									"matchExpressions": []interface{}{
+										map[string]interface{}{"key": "rack", "operator": "In", "values": []interface{}{"r1"}},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		}}
+		vmi.SetGroupVersionKind(virtualMachineInstanceGVK)
+		vmi.SetName("test-vmi")
+		vmi.SetNamespace(metav1.NamespaceDefault)
+		Expect(reconciler.client.Create(context.TODO(), vmi)).To(Succeed())
+
+		_, pvc := reconcileAndGetPVC()
+		Expect(pvc.Annotations[AnnTopologyPlacement]).To(MatchJSON(`{"nodeSelector":{"topology.kubernetes.io/zone":"zone-b"},` +
+			`"nodeAffinity":{"nodeSelectorTerms":[{"matchExpressions":[{"key":"rack","operator":"In","values":["r1"]}]}]}}`))
+	})
+})
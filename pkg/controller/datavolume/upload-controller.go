@@ -85,10 +85,10 @@ func NewUploadController(
 			client:               client,
 			scheme:               mgr.GetScheme(),
 			log:                  log.WithName(uploadControllerName),
-			recorder:             mgr.GetEventRecorderFor(uploadControllerName),
+			recorder:             cc.NewEventAggregator(mgr.GetEventRecorderFor(uploadControllerName), client),
 			featureGates:         featuregates.NewFeatureGates(client),
 			installerLabels:      installerLabels,
-			shouldUpdateProgress: false,
+			shouldUpdateProgress: true,
 		},
 	}
 
@@ -181,7 +181,7 @@ var _ = Describe("All DataVolume Tests", func() {
 		Expect(val).To(Equal(string(dv.UID)))
 	})
 
-	It("Should always report NA progress for upload population", func() {
+	It("Should report the progress of the upload populator", func() {
 		scName := "testSC"
 		sc := CreateStorageClassWithProvisioner(scName, map[string]string{AnnDefaultStorageClass: "true"}, map[string]string{}, "csi-plugin")
 		csiDriver := &storagev1.CSIDriver{
@@ -203,8 +203,7 @@ var _ = Describe("All DataVolume Tests", func() {
 		Expect(err).ToNot(HaveOccurred())
 		Expect(pvc.GetAnnotations()[AnnUsePopulator]).To(Equal("true"))
 
-		// updating the annotation to make sure we dont update the progress
-		// in reality the annotation should never be on upload pvcs
+		// the upload populator reports the progress of the conversion of the upload
 		AddAnnotation(pvc, AnnPopulatorProgress, "13.45%")
 		err = reconciler.client.Update(context.TODO(), pvc)
 		Expect(err).ToNot(HaveOccurred())
@@ -215,7 +214,7 @@ var _ = Describe("All DataVolume Tests", func() {
 		dv = &cdiv1.DataVolume{}
 		err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}, dv)
 		Expect(err).ToNot(HaveOccurred())
-		Expect(string(dv.Status.Progress)).To(Equal("N/A"))
+		Expect(string(dv.Status.Progress)).To(Equal("13.45%"))
 	})
 
 	It("Should adopt a PVC (with annotation)", func() {
@@ -479,6 +478,7 @@ func createUploadReconcilerWithoutConfig(objects ...client.Object) *UploadReconc
 				common.AppKubernetesPartOfLabel:  "testing",
 				common.AppKubernetesVersionLabel: "v0.0.0-tests",
 			},
+			shouldUpdateProgress: true,
 		},
 	}
 	return r
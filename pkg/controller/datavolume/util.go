@@ -114,8 +114,9 @@ func pvcFromStorage(client client.Client, recorder record.EventRecorder, log log
 	}
 
 	if shouldRender {
+		// The size of clones and of imports from sources reporting one is detected when it is missing
 		isClone := dv.Spec.Source.PVC != nil || dv.Spec.Source.Snapshot != nil
-		if err := renderPvcSpecVolumeSize(client, pvcSpec, isClone, &log); err != nil {
+		if err := renderPvcSpecVolumeSize(client, pvcSpec, isClone || CanDetectImportSize(&dv.Spec), &log); err != nil {
 			return nil, err
 		}
 	}
@@ -217,8 +218,11 @@ func renderClonePvcVolumeSizeFromSource(ctx context.Context, client client.Clien
 		return err
 	}
 
-	source := volumeCloneSource.Spec.Source
+	return renderCloneVolumeSizeFromSourceRef(ctx, client, pvc, sourceNamespace, volumeCloneSource.Spec.Source)
+}
 
+// renderCloneVolumeSizeFromSourceRef sets the size of the clone PVC from its source PVC or VolumeSnapshot
+func renderCloneVolumeSizeFromSourceRef(ctx context.Context, client client.Client, pvc *v1.PersistentVolumeClaim, sourceNamespace string, source v1.TypedLocalObjectReference) error {
 	if source.Kind == "VolumeSnapshot" && source.Name != "" {
 		sourceSnapshot := &snapshotv1.VolumeSnapshot{}
 		if exists, err := cc.GetResource(ctx, client, sourceNamespace, source.Name, sourceSnapshot); err != nil || !exists {
@@ -279,7 +283,7 @@ func hasCloneSourceRef(pvc *v1.PersistentVolumeClaim) bool {
 func renderPvcSpecVolumeSize(client client.Client, pvcSpec *v1.PersistentVolumeClaimSpec, isClone bool, log *logr.Logger) error {
 	requestedSize, found := pvcSpec.Resources.Requests[v1.ResourceStorage]
 
-	// Storage size can be empty when cloning
+	// Storage size can be empty when cloning or when it is detected from the import source
 	if !found {
 		if !isClone {
 			return errors.Errorf("PVC Spec is not valid - missing storage size")
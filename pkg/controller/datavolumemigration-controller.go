@@ -0,0 +1,534 @@
+/*
+Copyright 2026 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/record"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+	"kubevirt.io/containerized-data-importer/pkg/common"
+	"kubevirt.io/containerized-data-importer/pkg/controller/clone"
+	cc "kubevirt.io/containerized-data-importer/pkg/controller/common"
+	dvc "kubevirt.io/containerized-data-importer/pkg/controller/datavolume"
+	"kubevirt.io/containerized-data-importer/pkg/controller/populators"
+	"kubevirt.io/containerized-data-importer/pkg/util"
+)
+
+const (
+	dataVolumeMigrationControllerName = "datavolumemigration-controller"
+
+	// ErrInvalidDataVolumeMigration provides a const to indicate a DataVolumeMigration cannot be done
+	ErrInvalidDataVolumeMigration = "ErrInvalidDataVolumeMigration"
+	// DataVolumeMigrated provides a const to indicate the PVC of a DataVolume was migrated to another storage class
+	DataVolumeMigrated = "DataVolumeMigrated"
+
+	// MessageDataVolumeMigrated provides a const to form the event message of a migrated DataVolume
+	MessageDataVolumeMigrated = "PVC of DataVolume %s migrated to storage class %s"
+	// DataVolumeMigrating provides a const for the reason of the Ready condition of a DataVolume whose PVC is being replaced
+	DataVolumeMigrating = "DataVolumeMigrating"
+	// MessageDataVolumeMigrating provides a const to form the Ready condition message of a DataVolume whose PVC is being replaced
+	MessageDataVolumeMigrating = "PVC of DataVolume %s is being migrated to storage class %s"
+
+	// dataVolumeMigrationRequeue is how often a migration checks whether pods still use the PVC
+	dataVolumeMigrationRequeue = 10 * time.Second
+)
+
+// DataVolumeMigrationReconciler members
+type DataVolumeMigrationReconciler struct {
+	client          client.Client
+	recorder        record.EventRecorder
+	scheme          *runtime.Scheme
+	log             logr.Logger
+	installerLabels map[string]string
+}
+
+// migratedClaimMetadata is the metadata of a migrated PVC kept on its copy, given to the PVC replacing it
+type migratedClaimMetadata struct {
+	Labels          map[string]string       `json:"labels,omitempty"`
+	Annotations     map[string]string       `json:"annotations,omitempty"`
+	OwnerReferences []metav1.OwnerReference `json:"ownerReferences,omitempty"`
+}
+
+// Reconcile loop for DataVolumeMigrationReconciler
+func (r *DataVolumeMigrationReconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	dvm := &cdiv1.DataVolumeMigration{}
+	if err := r.client.Get(ctx, req.NamespacedName, dvm); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+	if dvm.DeletionTimestamp != nil || dvm.Status.Phase == cdiv1.DataVolumeMigrationCompleted {
+		return reconcile.Result{}, nil
+	}
+	return r.update(ctx, dvm)
+}
+
+// update moves the migration one step further and updates its status
+func (r *DataVolumeMigrationReconciler) update(ctx context.Context, dvm *cdiv1.DataVolumeMigration) (reconcile.Result, error) {
+	dvmCopy := dvm.DeepCopy()
+	res, err := r.migrate(ctx, dvm)
+	if err != nil {
+		var invalid *invalidMigrationError
+		if !errors.As(err, &invalid) {
+			return res, err
+		}
+		r.recorder.Event(dvm, corev1.EventTypeWarning, ErrInvalidDataVolumeMigration, err.Error())
+		dvm.Status.Phase = cdiv1.DataVolumeMigrationFailed
+		dvm.Status.Message = err.Error()
+	}
+
+	if !reflect.DeepEqual(dvm, dvmCopy) {
+		if err := r.client.Update(ctx, dvm); err != nil {
+			return res, err
+		}
+	}
+	return res, nil
+}
+
+// invalidMigrationError is returned when the migration cannot be done until the DataVolumeMigration is fixed
+type invalidMigrationError struct {
+	err error
+}
+
+func (e *invalidMigrationError) Error() string {
+	return e.err.Error()
+}
+
+// migrate copies the PVC of the DataVolume once it succeeded and is unused, then replaces it with its copy
+func (r *DataVolumeMigrationReconciler) migrate(ctx context.Context, dvm *cdiv1.DataVolumeMigration) (reconcile.Result, error) {
+	dv := &cdiv1.DataVolume{}
+	exists, err := cc.GetResource(ctx, r.client, dvm.Namespace, dvm.Spec.DataVolumeName, dv)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if !exists {
+		return reconcile.Result{}, &invalidMigrationError{err: errors.Errorf("DataVolume %s not found", dvm.Spec.DataVolumeName)}
+	}
+	storageClass := &storagev1.StorageClass{}
+	exists, err = cc.GetResource(ctx, r.client, metav1.NamespaceNone, dvm.Spec.StorageClassName, storageClass)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if !exists {
+		return reconcile.Result{}, &invalidMigrationError{err: errors.Errorf("storage class %s not found", dvm.Spec.StorageClassName)}
+	}
+	// The PV of the copy is only set once the copy is done, failures after that retry rebinding
+	if dvm.Status.PersistentVolumeName != "" {
+		return r.rebind(ctx, dvm, dv, storageClass)
+	}
+
+	claim := &corev1.PersistentVolumeClaim{}
+	exists, err = cc.GetResource(ctx, r.client, dv.Namespace, dv.Name, claim)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if !exists || (dv.Status.Phase != cdiv1.Succeeded && dv.Annotations[cc.AnnDataVolumeMigration] != dvm.Name) {
+		dvm.Status.Phase = cdiv1.DataVolumeMigrationPending
+		dvm.Status.Message = fmt.Sprintf("Waiting for DataVolume %s to succeed", dv.Name)
+		return reconcile.Result{}, nil
+	}
+
+	copyClaim := &corev1.PersistentVolumeClaim{}
+	copyExists, err := cc.GetResource(ctx, r.client, dvm.Namespace, migrationClaimName(dvm), copyClaim)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if !copyExists {
+		if claim.Spec.StorageClassName != nil && *claim.Spec.StorageClassName == dvm.Spec.StorageClassName {
+			dvm.Status.Phase = cdiv1.DataVolumeMigrationCompleted
+			dvm.Status.Message = fmt.Sprintf("PVC %s is already in storage class %s", claim.Name, dvm.Spec.StorageClassName)
+			return reconcile.Result{}, nil
+		}
+		if inUse, err := r.isClaimInUse(ctx, dvm, claim); err != nil || inUse {
+			return reconcile.Result{RequeueAfter: dataVolumeMigrationRequeue}, err
+		}
+		return reconcile.Result{}, r.createMigrationClaim(ctx, dvm, claim)
+	}
+
+	dvm.Status.Phase = cdiv1.DataVolumeMigrationCopying
+	dvm.Status.Message = ""
+	if progress, ok := copyClaim.Annotations[cc.AnnPopulatorProgress]; ok {
+		dvm.Status.Progress = cdiv1.DataVolumeProgress(progress)
+	}
+	if copyClaim.Annotations[populators.AnnClonePhase] != clone.SucceededPhaseName || copyClaim.Spec.VolumeName == "" {
+		return reconcile.Result{}, nil
+	}
+	// New consumers are kept away from here on, as what they write to the PVC would not be in the copy
+	if blocked, err := r.blockConsumers(ctx, dvm, dv); err != nil || blocked {
+		return reconcile.Result{}, err
+	}
+	// A VM may have been started with the PVC during the copy
+	if inUse, err := r.isClaimInUse(ctx, dvm, claim); err != nil || inUse {
+		return reconcile.Result{RequeueAfter: dataVolumeMigrationRequeue}, err
+	}
+	if err := r.keepClaimMetadata(ctx, claim, copyClaim); err != nil {
+		return reconcile.Result{}, err
+	}
+	dvm.Status.Phase = cdiv1.DataVolumeMigrationRebinding
+	dvm.Status.PersistentVolumeName = copyClaim.Spec.VolumeName
+	dvm.Status.Progress = "100.0%"
+	return reconcile.Result{}, nil
+}
+
+// isClaimInUse reports the pods using the PVC in the status of the migration, which waits until they are gone
+func (r *DataVolumeMigrationReconciler) isClaimInUse(ctx context.Context, dvm *cdiv1.DataVolumeMigration, claim *corev1.PersistentVolumeClaim) (bool, error) {
+	pods, err := cc.GetPodsUsingPVCs(ctx, r.client, claim.Namespace, sets.New(claim.Name), false)
+	if err != nil || len(pods) == 0 {
+		return false, err
+	}
+	dvm.Status.Message = fmt.Sprintf("Waiting for PVC %s to be unused, pod %s uses it", claim.Name, pods[0].Name)
+	if dvm.Status.Phase == "" {
+		dvm.Status.Phase = cdiv1.DataVolumeMigrationPending
+	}
+	return true, nil
+}
+
+// blockConsumers annotates the DataVolume with the migration replacing its PVC, pausing the DataVolume controller, and
+// reports the DataVolume RebindInProgress and not ready, so that KubeVirt does not start VMs with it until the PVC is
+// replaced. It returns true when it updated the DataVolume, the next step is triggered by its watch.
+func (r *DataVolumeMigrationReconciler) blockConsumers(ctx context.Context, dvm *cdiv1.DataVolumeMigration, dv *cdiv1.DataVolume) (bool, error) {
+	if dv.Annotations[cc.AnnDataVolumeMigration] != dvm.Name {
+		cc.AddAnnotation(dv, cc.AnnDataVolumeMigration, dvm.Name)
+		return true, r.client.Update(ctx, dv)
+	}
+	if dv.Status.Phase != cdiv1.RebindInProgress {
+		dv.Status.Phase = cdiv1.RebindInProgress
+		dv.Status.Conditions = dvc.UpdateReadyCondition(dv.Status.Conditions, corev1.ConditionFalse,
+			fmt.Sprintf(MessageDataVolumeMigrating, dv.Name, dvm.Spec.StorageClassName), DataVolumeMigrating)
+		return true, r.client.Status().Update(ctx, dv)
+	}
+	return false, nil
+}
+
+// migrationClaimName returns the name of the PVC the PVC of the DataVolume is copied to
+func migrationClaimName(dvm *cdiv1.DataVolumeMigration) string {
+	return "tmp-migration-" + string(dvm.UID)
+}
+
+// createMigrationClaim creates the PVC in the storage class the PVC of the DataVolume is cloned to by the clone
+// populator, with the VolumeCloneSource it is populated from
+func (r *DataVolumeMigrationReconciler) createMigrationClaim(ctx context.Context, dvm *cdiv1.DataVolumeMigration, claim *corev1.PersistentVolumeClaim) error {
+	cloneSource := &cdiv1.VolumeCloneSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      migrationClaimName(dvm),
+			Namespace: dvm.Namespace,
+		},
+		Spec: cdiv1.VolumeCloneSourceSpec{
+			Source: corev1.TypedLocalObjectReference{
+				Kind: "PersistentVolumeClaim",
+				Name: claim.Name,
+			},
+		},
+	}
+	util.SetRecommendedLabels(cloneSource, r.installerLabels, common.CDIControllerName)
+	if err := controllerutil.SetControllerReference(dvm, cloneSource, r.scheme); err != nil {
+		return err
+	}
+	if err := r.client.Create(ctx, cloneSource); err != nil && !k8serrors.IsAlreadyExists(err) {
+		return err
+	}
+
+	size := claim.Spec.Resources.Requests[corev1.ResourceStorage]
+	if capacity, ok := claim.Status.Capacity[corev1.ResourceStorage]; ok && capacity.Cmp(size) > 0 {
+		size = capacity
+	}
+	apiGroup := cc.AnnAPIGroup
+	copyClaim := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      migrationClaimName(dvm),
+			Namespace: dvm.Namespace,
+			Labels: map[string]string{
+				common.DataVolumeMigrationLabel: dvm.Name,
+			},
+			// The copy has no consumer to wait for
+			Annotations: map[string]string{
+				cc.AnnImmediateBinding: "",
+			},
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes:      claim.Spec.AccessModes,
+			VolumeMode:       claim.Spec.VolumeMode,
+			StorageClassName: &dvm.Spec.StorageClassName,
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: size},
+			},
+			DataSourceRef: &corev1.TypedObjectReference{
+				APIGroup: &apiGroup,
+				Kind:     cdiv1.VolumeCloneSourceRef,
+				Name:     cloneSource.Name,
+			},
+		},
+	}
+	util.SetRecommendedLabels(copyClaim, r.installerLabels, common.CDIControllerName)
+	if err := controllerutil.SetControllerReference(dvm, copyClaim, r.scheme); err != nil {
+		return err
+	}
+	r.log.V(1).Info("Copying PVC to storage class", "dataVolumeMigration", dvm.Name, "pvc", claim.Name, "storageClass", dvm.Spec.StorageClassName)
+	if err := r.client.Create(ctx, copyClaim); err != nil && !k8serrors.IsAlreadyExists(err) {
+		return err
+	}
+	dvm.Status.Phase = cdiv1.DataVolumeMigrationCopying
+	dvm.Status.Message = ""
+	return nil
+}
+
+// keepClaimMetadata keeps the metadata of the migrated PVC on its copy, as the PVC is deleted before being replaced
+func (r *DataVolumeMigrationReconciler) keepClaimMetadata(ctx context.Context, claim, copyClaim *corev1.PersistentVolumeClaim) error {
+	metadata := migratedClaimMetadata{
+		Labels:          claim.Labels,
+		Annotations:     make(map[string]string),
+		OwnerReferences: claim.OwnerReferences,
+	}
+	for k, v := range claim.Annotations {
+		// Binding annotations belong to the PV of the migrated PVC
+		if strings.HasPrefix(k, "pv.kubernetes.io/") || strings.HasSuffix(k, "/storage-provisioner") || k == cc.AnnSelectedNode {
+			continue
+		}
+		metadata.Annotations[k] = v
+	}
+	bs, err := json.Marshal(metadata)
+	if err != nil {
+		return err
+	}
+	if copyClaim.Annotations[cc.AnnMigratedClaimMetadata] == string(bs) {
+		return nil
+	}
+	cc.AddAnnotation(copyClaim, cc.AnnMigratedClaimMetadata, string(bs))
+	return r.client.Update(ctx, copyClaim)
+}
+
+// rebind replaces the PVC of the DataVolume with a PVC of the same name bound to the PV of its copy. The PV is retained
+// meanwhile, so that it survives the deletion of the copy, and the DataVolume controller is paused until the PVC is
+// replaced, so that it does not populate a new one. Each call does one step and the next one is triggered by its watch.
+func (r *DataVolumeMigrationReconciler) rebind(ctx context.Context, dvm *cdiv1.DataVolumeMigration, dv *cdiv1.DataVolume, storageClass *storagev1.StorageClass) (reconcile.Result, error) {
+	pv := &corev1.PersistentVolume{}
+	exists, err := cc.GetResource(ctx, r.client, metav1.NamespaceNone, dvm.Status.PersistentVolumeName, pv)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if !exists {
+		return reconcile.Result{}, &invalidMigrationError{err: errors.Errorf("PV %s of the copy of the PVC not found", dvm.Status.PersistentVolumeName)}
+	}
+	claim := &corev1.PersistentVolumeClaim{}
+	claimExists, err := cc.GetResource(ctx, r.client, dv.Namespace, dv.Name, claim)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	copyClaim := &corev1.PersistentVolumeClaim{}
+	copyExists, err := cc.GetResource(ctx, r.client, dvm.Namespace, migrationClaimName(dvm), copyClaim)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	dvm.Status.Phase = cdiv1.DataVolumeMigrationRebinding
+	dvm.Status.Message = ""
+	if claimExists && claim.Spec.VolumeName == pv.Name && cc.IsBound(claim) {
+		return reconcile.Result{}, r.completeMigration(ctx, dvm, dv, pv, copyClaim, copyExists, storageClass)
+	}
+
+	if pv.Spec.PersistentVolumeReclaimPolicy != corev1.PersistentVolumeReclaimRetain {
+		pv.Spec.PersistentVolumeReclaimPolicy = corev1.PersistentVolumeReclaimRetain
+		return reconcile.Result{}, r.client.Update(ctx, pv)
+	}
+	if blocked, err := r.blockConsumers(ctx, dvm, dv); err != nil || blocked {
+		return reconcile.Result{}, err
+	}
+
+	switch {
+	case claimExists && claim.Spec.VolumeName != "" && claim.Spec.VolumeName != pv.Name:
+		if claim.DeletionTimestamp == nil {
+			// A pod started before the DataVolume was blocked would lose what it writes once the PVC is replaced
+			if inUse, err := r.isClaimInUse(ctx, dvm, claim); err != nil || inUse {
+				return reconcile.Result{RequeueAfter: dataVolumeMigrationRequeue}, err
+			}
+			r.log.V(1).Info("Deleting migrated PVC", "dataVolumeMigration", dvm.Name, "pvc", claim.Name)
+			if err := r.client.Delete(ctx, claim); err != nil && !k8serrors.IsNotFound(err) {
+				return reconcile.Result{}, err
+			}
+		}
+		return reconcile.Result{}, nil
+	case !copyExists:
+		return reconcile.Result{}, &invalidMigrationError{err: errors.Errorf("PVC %s the PVC was copied to not found", migrationClaimName(dvm))}
+	case !claimExists:
+		newClaim, err := newMigratedClaim(dvm, dv, copyClaim)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		r.log.V(1).Info("Creating PVC bound to the copy", "dataVolumeMigration", dvm.Name, "pvc", newClaim.Name, "pv", pv.Name)
+		if err := r.client.Create(ctx, newClaim); err != nil && !k8serrors.IsAlreadyExists(err) {
+			return reconcile.Result{}, err
+		}
+		return reconcile.Result{}, nil
+	default:
+		return reconcile.Result{}, cc.Rebind(ctx, r.client, copyClaim, claim)
+	}
+}
+
+// newMigratedClaim creates the PVC replacing the migrated PVC, with its metadata, in the storage class of the copy
+func newMigratedClaim(dvm *cdiv1.DataVolumeMigration, dv *cdiv1.DataVolume, copyClaim *corev1.PersistentVolumeClaim) (*corev1.PersistentVolumeClaim, error) {
+	metadata := migratedClaimMetadata{}
+	if err := json.Unmarshal([]byte(copyClaim.Annotations[cc.AnnMigratedClaimMetadata]), &metadata); err != nil {
+		return nil, errors.Wrap(err, "unable to read the metadata of the migrated PVC")
+	}
+	claim := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            dv.Name,
+			Namespace:       dv.Namespace,
+			Labels:          metadata.Labels,
+			Annotations:     metadata.Annotations,
+			OwnerReferences: metadata.OwnerReferences,
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes:      copyClaim.Spec.AccessModes,
+			VolumeMode:       copyClaim.Spec.VolumeMode,
+			StorageClassName: &dvm.Spec.StorageClassName,
+			Resources:        *copyClaim.Spec.Resources.DeepCopy(),
+		},
+	}
+	// The PVC is populated even if the DataVolume lost track of it
+	cc.AddAnnotation(claim, cc.AnnPopulatedFor, dv.Name)
+	return claim, nil
+}
+
+// completeMigration gives the PV back the reclaim policy of its storage class, deletes the copy of the PVC, which no
+// longer owns the PV, and resumes the DataVolume controller
+func (r *DataVolumeMigrationReconciler) completeMigration(ctx context.Context, dvm *cdiv1.DataVolumeMigration, dv *cdiv1.DataVolume,
+	pv *corev1.PersistentVolume, copyClaim *corev1.PersistentVolumeClaim, copyExists bool, storageClass *storagev1.StorageClass) error {
+	reclaimPolicy := corev1.PersistentVolumeReclaimDelete
+	if storageClass.ReclaimPolicy != nil {
+		reclaimPolicy = *storageClass.ReclaimPolicy
+	}
+	if pv.Spec.PersistentVolumeReclaimPolicy != reclaimPolicy {
+		pv.Spec.PersistentVolumeReclaimPolicy = reclaimPolicy
+		if err := r.client.Update(ctx, pv); err != nil {
+			return err
+		}
+	}
+	if copyExists {
+		if err := r.client.Delete(ctx, copyClaim); err != nil && !k8serrors.IsNotFound(err) {
+			return err
+		}
+	}
+	cloneSource := &cdiv1.VolumeCloneSource{ObjectMeta: metav1.ObjectMeta{Name: migrationClaimName(dvm), Namespace: dvm.Namespace}}
+	if err := r.client.Delete(ctx, cloneSource); err != nil && !k8serrors.IsNotFound(err) {
+		return err
+	}
+	// The DataVolume succeeded before it was migrated
+	if dv.Status.Phase == cdiv1.RebindInProgress {
+		dv.Status.Phase = cdiv1.Succeeded
+		dv.Status.Conditions = dvc.UpdateReadyCondition(dv.Status.Conditions, corev1.ConditionTrue, "", "")
+		if err := r.client.Status().Update(ctx, dv); err != nil {
+			return err
+		}
+	}
+	if _, ok := dv.Annotations[cc.AnnDataVolumeMigration]; ok {
+		delete(dv.Annotations, cc.AnnDataVolumeMigration)
+		if err := r.client.Update(ctx, dv); err != nil {
+			return err
+		}
+	}
+
+	dvm.Status.Phase = cdiv1.DataVolumeMigrationCompleted
+	dvm.Status.Message = ""
+	r.recorder.Event(dvm, corev1.EventTypeNormal, DataVolumeMigrated, fmt.Sprintf(MessageDataVolumeMigrated, dv.Name, dvm.Spec.StorageClassName))
+	return nil
+}
+
+// NewDataVolumeMigrationController creates a new instance of the DataVolumeMigration controller
+func NewDataVolumeMigrationController(mgr manager.Manager, log logr.Logger, installerLabels map[string]string) (controller.Controller, error) {
+	reconciler := &DataVolumeMigrationReconciler{
+		client:          mgr.GetClient(),
+		recorder:        mgr.GetEventRecorderFor(dataVolumeMigrationControllerName),
+		scheme:          mgr.GetScheme(),
+		log:             log.WithName(dataVolumeMigrationControllerName),
+		installerLabels: installerLabels,
+	}
+	dataVolumeMigrationController, err := controller.New(dataVolumeMigrationControllerName, mgr, controller.Options{
+		MaxConcurrentReconciles: 3,
+		Reconciler:              reconciler,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := addDataVolumeMigrationControllerWatches(mgr, dataVolumeMigrationController); err != nil {
+		return nil, err
+	}
+	log.Info("Initialized DataVolumeMigration controller")
+	return dataVolumeMigrationController, nil
+}
+
+func addDataVolumeMigrationControllerWatches(mgr manager.Manager, c controller.Controller) error {
+	if err := c.Watch(source.Kind(mgr.GetCache(), &cdiv1.DataVolumeMigration{}, &handler.TypedEnqueueRequestForObject[*cdiv1.DataVolumeMigration]{})); err != nil {
+		return err
+	}
+	// Migrations follow their DataVolume, the PVC of the DataVolume and the copy of the PVC
+	mapMigrations := func(ctx context.Context, obj client.Object) []reconcile.Request {
+		if migration, ok := obj.GetLabels()[common.DataVolumeMigrationLabel]; ok {
+			return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: obj.GetNamespace(), Name: migration}}}
+		}
+		dvms := &cdiv1.DataVolumeMigrationList{}
+		if err := mgr.GetClient().List(ctx, dvms, client.InNamespace(obj.GetNamespace())); err != nil {
+			return nil
+		}
+		var reqs []reconcile.Request
+		for _, dvm := range dvms.Items {
+			if dvm.Spec.DataVolumeName == obj.GetName() {
+				reqs = append(reqs, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: dvm.Namespace, Name: dvm.Name}})
+			}
+		}
+		return reqs
+	}
+	if err := c.Watch(source.Kind(mgr.GetCache(), &cdiv1.DataVolume{},
+		handler.TypedEnqueueRequestsFromMapFunc[*cdiv1.DataVolume](func(ctx context.Context, dv *cdiv1.DataVolume) []reconcile.Request {
+			return mapMigrations(ctx, dv)
+		}))); err != nil {
+		return err
+	}
+	if err := c.Watch(source.Kind(mgr.GetCache(), &corev1.PersistentVolumeClaim{},
+		handler.TypedEnqueueRequestsFromMapFunc[*corev1.PersistentVolumeClaim](func(ctx context.Context, pvc *corev1.PersistentVolumeClaim) []reconcile.Request {
+			return mapMigrations(ctx, pvc)
+		}))); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,314 @@
+/*
+Copyright 2026 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+	"kubevirt.io/containerized-data-importer/pkg/common"
+	"kubevirt.io/containerized-data-importer/pkg/controller/clone"
+	. "kubevirt.io/containerized-data-importer/pkg/controller/common"
+	"kubevirt.io/containerized-data-importer/pkg/controller/populators"
+)
+
+const (
+	dvmName        = "test-dvm"
+	dvmDataVolume  = "test-dv"
+	dvmOldSC       = "old-sc"
+	dvmNewSC       = "new-sc"
+	dvmCopyPVName  = "new-pv"
+	dvmCopyPVCName = "tmp-migration-dvm-uid"
+)
+
+var _ = Describe("DataVolumeMigration controller reconcile loop", func() {
+	dvmKey := types.NamespacedName{Name: dvmName, Namespace: metav1.NamespaceDefault}
+	dvKey := types.NamespacedName{Name: dvmDataVolume, Namespace: metav1.NamespaceDefault}
+	copyKey := types.NamespacedName{Name: dvmCopyPVCName, Namespace: metav1.NamespaceDefault}
+
+	reconcileDataVolumeMigration := func(reconciler *DataVolumeMigrationReconciler) *cdiv1.DataVolumeMigration {
+		_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: dvmKey})
+		Expect(err).ToNot(HaveOccurred())
+		dvm := &cdiv1.DataVolumeMigration{}
+		Expect(reconciler.client.Get(context.TODO(), dvmKey, dvm)).To(Succeed())
+		return dvm
+	}
+
+	getClaim := func(reconciler *DataVolumeMigrationReconciler, key types.NamespacedName) *corev1.PersistentVolumeClaim {
+		pvc := &corev1.PersistentVolumeClaim{}
+		Expect(reconciler.client.Get(context.TODO(), key, pvc)).To(Succeed())
+		return pvc
+	}
+
+	getPV := func(reconciler *DataVolumeMigrationReconciler) *corev1.PersistentVolume {
+		pv := &corev1.PersistentVolume{}
+		Expect(reconciler.client.Get(context.TODO(), types.NamespacedName{Name: dvmCopyPVName}, pv)).To(Succeed())
+		return pv
+	}
+
+	getDataVolume := func(reconciler *DataVolumeMigrationReconciler) *cdiv1.DataVolume {
+		dv := &cdiv1.DataVolume{}
+		Expect(reconciler.client.Get(context.TODO(), dvKey, dv)).To(Succeed())
+		return dv
+	}
+
+	// completeCopy does what the clone populator does once the PVC is copied
+	completeCopy := func(reconciler *DataVolumeMigrationReconciler) {
+		copyClaim := getClaim(reconciler, copyKey)
+		copyClaim.Annotations[populators.AnnClonePhase] = clone.SucceededPhaseName
+		copyClaim.Annotations[AnnPopulatorProgress] = "100.0%"
+		copyClaim.Spec.VolumeName = dvmCopyPVName
+		Expect(reconciler.client.Update(context.TODO(), copyClaim)).To(Succeed())
+		pv := &corev1.PersistentVolume{
+			ObjectMeta: metav1.ObjectMeta{Name: dvmCopyPVName},
+			Spec: corev1.PersistentVolumeSpec{
+				ClaimRef:                      &corev1.ObjectReference{Namespace: copyClaim.Namespace, Name: copyClaim.Name, UID: copyClaim.UID},
+				PersistentVolumeReclaimPolicy: corev1.PersistentVolumeReclaimDelete,
+				StorageClassName:              dvmNewSC,
+			},
+		}
+		Expect(reconciler.client.Create(context.TODO(), pv)).To(Succeed())
+	}
+
+	It("Should do nothing and return nil when no DataVolumeMigration exists", func() {
+		reconciler := createDataVolumeMigrationReconciler()
+		_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: dvmKey})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("Should copy the PVC to the storage class and rebind the copy to the name of the PVC", func() {
+		reconciler := createDataVolumeMigrationReconciler(createMigrationObjects()...)
+		dvm := reconcileDataVolumeMigration(reconciler)
+		Expect(dvm.Status.Phase).To(Equal(cdiv1.DataVolumeMigrationCopying))
+
+		copyClaim := getClaim(reconciler, copyKey)
+		Expect(copyClaim.Spec.StorageClassName).To(HaveValue(Equal(dvmNewSC)))
+		Expect(copyClaim.Spec.Resources.Requests.Storage().Cmp(resource.MustParse("2Gi"))).To(BeZero())
+		Expect(copyClaim.Spec.DataSourceRef.Kind).To(Equal(cdiv1.VolumeCloneSourceRef))
+		Expect(copyClaim.Annotations).To(HaveKey(AnnImmediateBinding))
+		Expect(copyClaim.Labels).To(HaveKeyWithValue(common.DataVolumeMigrationLabel, dvmName))
+		Expect(metav1.IsControlledBy(copyClaim, dvm)).To(BeTrue())
+		cloneSource := &cdiv1.VolumeCloneSource{}
+		Expect(reconciler.client.Get(context.TODO(), copyKey, cloneSource)).To(Succeed())
+		Expect(cloneSource.Spec.Source.Name).To(Equal(dvmDataVolume))
+
+		completeCopy(reconciler)
+		reconcileDataVolumeMigration(reconciler)
+		Expect(getDataVolume(reconciler).Annotations).To(HaveKeyWithValue(AnnDataVolumeMigration, dvmName))
+		reconcileDataVolumeMigration(reconciler)
+		dv := getDataVolume(reconciler)
+		Expect(dv.Status.Phase).To(Equal(cdiv1.RebindInProgress))
+		Expect(dv.Status.Conditions).To(ContainElement(And(
+			HaveField("Type", cdiv1.DataVolumeReady),
+			HaveField("Status", corev1.ConditionFalse),
+			HaveField("Reason", DataVolumeMigrating))))
+		dvm = reconcileDataVolumeMigration(reconciler)
+		Expect(dvm.Status.Phase).To(Equal(cdiv1.DataVolumeMigrationRebinding))
+		Expect(dvm.Status.PersistentVolumeName).To(Equal(dvmCopyPVName))
+		Expect(dvm.Status.Progress).To(BeEquivalentTo("100.0%"))
+		Expect(getClaim(reconciler, copyKey).Annotations).To(HaveKey(AnnMigratedClaimMetadata))
+
+		reconcileDataVolumeMigration(reconciler)
+		Expect(getPV(reconciler).Spec.PersistentVolumeReclaimPolicy).To(Equal(corev1.PersistentVolumeReclaimRetain))
+		reconcileDataVolumeMigration(reconciler)
+		err := reconciler.client.Get(context.TODO(), dvKey, &corev1.PersistentVolumeClaim{})
+		Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+
+		reconcileDataVolumeMigration(reconciler)
+		claim := getClaim(reconciler, dvKey)
+		Expect(claim.Spec.StorageClassName).To(HaveValue(Equal(dvmNewSC)))
+		Expect(claim.Spec.VolumeName).To(BeEmpty())
+		Expect(claim.Annotations).To(HaveKeyWithValue(AnnPodPhase, string(corev1.PodSucceeded)))
+		Expect(claim.Annotations).To(HaveKeyWithValue(AnnPopulatedFor, dvmDataVolume))
+		Expect(claim.Annotations).ToNot(HaveKey("pv.kubernetes.io/bind-completed"))
+		Expect(claim.Labels).To(HaveKeyWithValue("app", "containerized-data-importer"))
+		Expect(metav1.IsControlledBy(claim, getDataVolume(reconciler))).To(BeTrue())
+
+		reconcileDataVolumeMigration(reconciler)
+		Expect(getPV(reconciler).Spec.ClaimRef.Name).To(Equal(dvmDataVolume))
+
+		// The PV controller binds the PVC
+		claim.Spec.VolumeName = dvmCopyPVName
+		Expect(reconciler.client.Update(context.TODO(), claim)).To(Succeed())
+		claim.Status.Phase = corev1.ClaimBound
+		Expect(reconciler.client.Status().Update(context.TODO(), claim)).To(Succeed())
+		dvm = reconcileDataVolumeMigration(reconciler)
+		Expect(dvm.Status.Phase).To(Equal(cdiv1.DataVolumeMigrationCompleted))
+		Expect(getPV(reconciler).Spec.PersistentVolumeReclaimPolicy).To(Equal(corev1.PersistentVolumeReclaimDelete))
+		dv = getDataVolume(reconciler)
+		Expect(dv.Annotations).ToNot(HaveKey(AnnDataVolumeMigration))
+		Expect(dv.Status.Phase).To(Equal(cdiv1.Succeeded))
+		Expect(dv.Status.Conditions).To(ContainElement(And(
+			HaveField("Type", cdiv1.DataVolumeReady),
+			HaveField("Status", corev1.ConditionTrue))))
+		err = reconciler.client.Get(context.TODO(), copyKey, &corev1.PersistentVolumeClaim{})
+		Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+		err = reconciler.client.Get(context.TODO(), copyKey, &cdiv1.VolumeCloneSource{})
+		Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+		Expect(<-reconciler.recorder.(*record.FakeRecorder).Events).To(ContainSubstring(DataVolumeMigrated))
+	})
+
+	It("Should wait for the pods using the PVC to be gone", func() {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "vm-pod", Namespace: metav1.NamespaceDefault},
+			Spec: corev1.PodSpec{
+				Volumes: []corev1.Volume{{
+					Name: "disk",
+					VolumeSource: corev1.VolumeSource{
+						PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: dvmDataVolume},
+					},
+				}},
+			},
+		}
+		reconciler := createDataVolumeMigrationReconciler(append(createMigrationObjects(), pod)...)
+		res, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: dvmKey})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(res.RequeueAfter).To(Equal(dataVolumeMigrationRequeue))
+		dvm := &cdiv1.DataVolumeMigration{}
+		Expect(reconciler.client.Get(context.TODO(), dvmKey, dvm)).To(Succeed())
+		Expect(dvm.Status.Phase).To(Equal(cdiv1.DataVolumeMigrationPending))
+		Expect(dvm.Status.Message).To(ContainSubstring("vm-pod"))
+		err = reconciler.client.Get(context.TODO(), copyKey, &corev1.PersistentVolumeClaim{})
+		Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("Should not delete the PVC while a pod started before the DataVolume was blocked uses it", func() {
+		reconciler := createDataVolumeMigrationReconciler(createMigrationObjects()...)
+		reconcileDataVolumeMigration(reconciler)
+		completeCopy(reconciler)
+		for range 4 {
+			reconcileDataVolumeMigration(reconciler)
+		}
+		Expect(getPV(reconciler).Spec.PersistentVolumeReclaimPolicy).To(Equal(corev1.PersistentVolumeReclaimRetain))
+
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "vm-pod", Namespace: metav1.NamespaceDefault},
+			Spec: corev1.PodSpec{
+				Volumes: []corev1.Volume{{
+					Name: "disk",
+					VolumeSource: corev1.VolumeSource{
+						PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: dvmDataVolume},
+					},
+				}},
+			},
+		}
+		Expect(reconciler.client.Create(context.TODO(), pod)).To(Succeed())
+		res, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: dvmKey})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(res.RequeueAfter).To(Equal(dataVolumeMigrationRequeue))
+		dvm := &cdiv1.DataVolumeMigration{}
+		Expect(reconciler.client.Get(context.TODO(), dvmKey, dvm)).To(Succeed())
+		Expect(dvm.Status.Phase).To(Equal(cdiv1.DataVolumeMigrationRebinding))
+		Expect(dvm.Status.Message).To(ContainSubstring("vm-pod"))
+		Expect(getClaim(reconciler, dvKey).DeletionTimestamp).To(BeNil())
+
+		Expect(reconciler.client.Delete(context.TODO(), pod)).To(Succeed())
+		reconcileDataVolumeMigration(reconciler)
+		err = reconciler.client.Get(context.TODO(), dvKey, &corev1.PersistentVolumeClaim{})
+		Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("Should wait for the DataVolume to succeed", func() {
+		objs := createMigrationObjects()
+		objs[0].(*cdiv1.DataVolume).Status.Phase = cdiv1.ImportInProgress
+		reconciler := createDataVolumeMigrationReconciler(objs...)
+		dvm := reconcileDataVolumeMigration(reconciler)
+		Expect(dvm.Status.Phase).To(Equal(cdiv1.DataVolumeMigrationPending))
+	})
+
+	It("Should complete when the PVC is already in the storage class", func() {
+		objs := createMigrationObjects()
+		objs[1].(*corev1.PersistentVolumeClaim).Spec.StorageClassName = ptr.To(dvmNewSC)
+		reconciler := createDataVolumeMigrationReconciler(objs...)
+		dvm := reconcileDataVolumeMigration(reconciler)
+		Expect(dvm.Status.Phase).To(Equal(cdiv1.DataVolumeMigrationCompleted))
+		err := reconciler.client.Get(context.TODO(), copyKey, &corev1.PersistentVolumeClaim{})
+		Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("Should fail when the storage class does not exist", func() {
+		objs := createMigrationObjects()
+		objs[len(objs)-1].(*cdiv1.DataVolumeMigration).Spec.StorageClassName = "missing-sc"
+		reconciler := createDataVolumeMigrationReconciler(objs...)
+		dvm := reconcileDataVolumeMigration(reconciler)
+		Expect(dvm.Status.Phase).To(Equal(cdiv1.DataVolumeMigrationFailed))
+		Expect(dvm.Status.Message).To(Equal("storage class missing-sc not found"))
+		Expect(<-reconciler.recorder.(*record.FakeRecorder).Events).To(ContainSubstring(ErrInvalidDataVolumeMigration))
+	})
+})
+
+func createDataVolumeMigrationReconciler(objects ...runtime.Object) *DataVolumeMigrationReconciler {
+	s := scheme.Scheme
+	_ = cdiv1.AddToScheme(s)
+	cl := fake.NewClientBuilder().WithScheme(s).WithRuntimeObjects(objects...).
+		WithStatusSubresource(&cdiv1.DataVolume{}, &corev1.PersistentVolumeClaim{}).Build()
+	r := &DataVolumeMigrationReconciler{
+		client:   cl,
+		recorder: record.NewFakeRecorder(10),
+		scheme:   s,
+		log:      cronLog,
+	}
+	return r
+}
+
+// createMigrationObjects returns a succeeded DataVolume, its PVC, the storage classes and the DataVolumeMigration
+func createMigrationObjects() []runtime.Object {
+	dv := NewImportDataVolume(dvmDataVolume)
+	dv.Status.Phase = cdiv1.Succeeded
+	pvc := CreatePvcInStorageClass(dvmDataVolume, metav1.NamespaceDefault, ptr.To(dvmOldSC),
+		map[string]string{AnnPodPhase: string(corev1.PodSucceeded), "pv.kubernetes.io/bind-completed": "yes"},
+		map[string]string{"app": "containerized-data-importer"}, corev1.ClaimBound)
+	pvc.Spec.VolumeName = "old-pv"
+	pvc.Spec.Resources.Requests = corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("1Gi")}
+	pvc.Status.Capacity = corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("2Gi")}
+	pvc.OwnerReferences = []metav1.OwnerReference{{
+		APIVersion: cdiv1.SchemeGroupVersion.String(),
+		Kind:       "DataVolume",
+		Name:       dv.Name,
+		UID:        dv.UID,
+		Controller: ptr.To(true),
+	}}
+	dvm := &cdiv1.DataVolumeMigration{
+		TypeMeta: metav1.TypeMeta{APIVersion: cdiv1.SchemeGroupVersion.String(), Kind: "DataVolumeMigration"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      dvmName,
+			Namespace: metav1.NamespaceDefault,
+			UID:       "dvm-uid",
+		},
+		Spec: cdiv1.DataVolumeMigrationSpec{
+			DataVolumeName:   dvmDataVolume,
+			StorageClassName: dvmNewSC,
+		},
+	}
+	return []runtime.Object{dv, pvc, CreateStorageClass(dvmOldSC, nil), CreateStorageClass(dvmNewSC, nil), dvm}
+}
@@ -0,0 +1,320 @@
+/*
+Copyright 2026 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+	"kubevirt.io/containerized-data-importer/pkg/common"
+	cc "kubevirt.io/containerized-data-importer/pkg/controller/common"
+	"kubevirt.io/containerized-data-importer/pkg/util"
+)
+
+const (
+	dataVolumeReplicationControllerName = "datavolumereplication-controller"
+
+	// ErrInvalidDataVolumeReplication provides a const to indicate a DataVolumeReplication cannot be synchronized
+	ErrInvalidDataVolumeReplication = "ErrInvalidDataVolumeReplication"
+)
+
+// DataVolumeReplicationReconciler members
+type DataVolumeReplicationReconciler struct {
+	client          client.Client
+	recorder        record.EventRecorder
+	scheme          *runtime.Scheme
+	log             logr.Logger
+	installerLabels map[string]string
+}
+
+// Reconcile loop for DataVolumeReplicationReconciler
+func (r *DataVolumeReplicationReconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	dvr := &cdiv1.DataVolumeReplication{}
+	if err := r.client.Get(ctx, req.NamespacedName, dvr); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+	if dvr.DeletionTimestamp != nil {
+		return reconcile.Result{}, nil
+	}
+	if err := r.update(ctx, dvr); err != nil {
+		return reconcile.Result{}, err
+	}
+	return reconcile.Result{}, nil
+}
+
+// update creates the replicated DataVolume, passes it the checkpoints of the replication, and reports which of them
+// were synchronized to its PVC
+func (r *DataVolumeReplicationReconciler) update(ctx context.Context, dvr *cdiv1.DataVolumeReplication) error {
+	dvrCopy := dvr.DeepCopy()
+	dv, err := r.newReplicationDataVolume(dvr)
+	if err == nil {
+		err = r.syncReplicationDataVolume(ctx, dvr, dv)
+	}
+	if err != nil {
+		var invalid *invalidReplicationError
+		if !errors.As(err, &invalid) {
+			return err
+		}
+		r.recorder.Event(dvr, corev1.EventTypeWarning, ErrInvalidDataVolumeReplication, err.Error())
+		dvr.Status.Phase = cdiv1.DataVolumeReplicationFailed
+		dvr.Status.Message = err.Error()
+	} else {
+		if err := r.updateReplicationStatus(ctx, dvr, dv); err != nil {
+			return err
+		}
+	}
+
+	if !reflect.DeepEqual(dvr, dvrCopy) {
+		if err := r.client.Update(ctx, dvr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// invalidReplicationError is returned when the DataVolume cannot be synchronized until the DataVolumeReplication is fixed
+type invalidReplicationError struct {
+	err error
+}
+
+func (e *invalidReplicationError) Error() string {
+	return e.err.Error()
+}
+
+// newReplicationDataVolume creates the replicated DataVolume from the template, with a stage per checkpoint
+func (r *DataVolumeReplicationReconciler) newReplicationDataVolume(dvr *cdiv1.DataVolumeReplication) (*cdiv1.DataVolume, error) {
+	template := &dvr.Spec.Template
+	source := template.Spec.Source
+	// Export sources have no changed block tracking, each checkpoint would copy the whole volume again
+	if source == nil || (source.VDDK == nil && source.Imageio == nil) {
+		return nil, &invalidReplicationError{err: errors.New("the source of the template is not a vddk or imageio source")}
+	}
+	dv := &cdiv1.DataVolume{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        template.Name,
+			Namespace:   dvr.Namespace,
+			Labels:      make(map[string]string),
+			Annotations: make(map[string]string),
+		},
+		Spec: *template.Spec.DeepCopy(),
+	}
+	if dv.Name == "" {
+		dv.Name = dvr.Name
+	}
+	for k, v := range template.Labels {
+		dv.Labels[k] = v
+	}
+	for k, v := range template.Annotations {
+		dv.Annotations[k] = v
+	}
+	util.SetRecommendedLabels(dv, r.installerLabels, common.CDIControllerName)
+	dv.Labels[common.DataVolumeReplicationLabel] = dvr.Name
+
+	checkpoints, err := replicationCheckpoints(dvr.Spec.Checkpoints)
+	if err != nil {
+		return nil, &invalidReplicationError{err: err}
+	}
+	dv.Spec.Checkpoints = checkpoints
+	dv.Spec.FinalCheckpoint = dvr.Spec.Finalize
+	if err := controllerutil.SetControllerReference(dvr, dv, r.scheme); err != nil {
+		return nil, err
+	}
+	return dv, nil
+}
+
+// replicationCheckpoints chains the checkpoints of a replication, each stage copying the changes since the previous one
+func replicationCheckpoints(checkpoints []string) ([]cdiv1.DataVolumeCheckpoint, error) {
+	if len(checkpoints) == 0 {
+		return nil, errors.New("at least one checkpoint is required")
+	}
+	result := make([]cdiv1.DataVolumeCheckpoint, 0, len(checkpoints))
+	seen := make(map[string]bool, len(checkpoints))
+	previous := ""
+	for _, checkpoint := range checkpoints {
+		if checkpoint == "" {
+			return nil, errors.New("checkpoints cannot be empty")
+		}
+		if seen[checkpoint] {
+			return nil, errors.Errorf("checkpoint %s is listed twice", checkpoint)
+		}
+		seen[checkpoint] = true
+		result = append(result, cdiv1.DataVolumeCheckpoint{Previous: previous, Current: checkpoint})
+		previous = checkpoint
+	}
+	return result, nil
+}
+
+// syncReplicationDataVolume creates the replicated DataVolume unless it exists, passes the new checkpoints to an
+// existing one, and reads its status. The rest of the spec of the DataVolume is never updated.
+func (r *DataVolumeReplicationReconciler) syncReplicationDataVolume(ctx context.Context, dvr *cdiv1.DataVolumeReplication, dv *cdiv1.DataVolume) error {
+	existing := &cdiv1.DataVolume{}
+	if err := r.client.Get(ctx, types.NamespacedName{Namespace: dv.Namespace, Name: dv.Name}, existing); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return err
+		}
+		r.log.V(1).Info("Creating replicated DataVolume", "dataVolumeReplication", dvr.Name, "dataVolume", dv.Name)
+		return r.client.Create(ctx, dv)
+	}
+	if !metav1.IsControlledBy(existing, dvr) {
+		return &invalidReplicationError{err: errors.Errorf("DataVolume %s already exists and is not owned by the DataVolumeReplication", dv.Name)}
+	}
+	if !reflect.DeepEqual(existing.Spec.Checkpoints, dv.Spec.Checkpoints) || existing.Spec.FinalCheckpoint != dv.Spec.FinalCheckpoint {
+		r.log.V(1).Info("Updating checkpoints of replicated DataVolume", "dataVolumeReplication", dvr.Name, "dataVolume", dv.Name)
+		existing.Spec.Checkpoints = dv.Spec.Checkpoints
+		existing.Spec.FinalCheckpoint = dv.Spec.FinalCheckpoint
+		if err := r.client.Update(ctx, existing); err != nil {
+			return err
+		}
+	}
+	existing.Status.DeepCopyInto(&dv.Status)
+	return nil
+}
+
+// updateReplicationStatus reports the last checkpoint copied to the PVC of the replicated DataVolume
+func (r *DataVolumeReplicationReconciler) updateReplicationStatus(ctx context.Context, dvr *cdiv1.DataVolumeReplication, dv *cdiv1.DataVolume) error {
+	checkpoints := dvr.Spec.Checkpoints
+	synced := -1
+	if dv.Status.Phase == cdiv1.Succeeded {
+		synced = len(checkpoints) - 1
+	} else {
+		pvc := &corev1.PersistentVolumeClaim{}
+		if err := r.client.Get(ctx, types.NamespacedName{Namespace: dv.Namespace, Name: dv.Name}, pvc); err != nil {
+			if !k8serrors.IsNotFound(err) {
+				return err
+			}
+		} else {
+			for i, checkpoint := range checkpoints {
+				if cc.IsCheckpointCopied(pvc, checkpoint) {
+					synced = i
+				}
+			}
+		}
+	}
+
+	syncedCheckpoint := ""
+	if synced >= 0 {
+		syncedCheckpoint = checkpoints[synced]
+	}
+	if syncedCheckpoint != dvr.Status.SyncedCheckpoint {
+		dvr.Status.SyncedCheckpoint = syncedCheckpoint
+		now := metav1.Now()
+		dvr.Status.LastSyncTime = &now
+	}
+	dvr.Status.DataVolumeName = dv.Name
+	dvr.Status.Phase = dataVolumeReplicationPhase(dvr, dv, synced)
+	dvr.Status.Progress = dv.Status.Progress
+	dvr.Status.Message = ""
+	return nil
+}
+
+// dataVolumeReplicationPhase returns Completed once the DataVolume succeeded after the final checkpoint, Synced while
+// waiting for a new checkpoint, Syncing while a checkpoint is copied and Pending before the first checkpoint is copied
+func dataVolumeReplicationPhase(dvr *cdiv1.DataVolumeReplication, dv *cdiv1.DataVolume, synced int) cdiv1.DataVolumeReplicationPhase {
+	switch {
+	case dv.Status.Phase == cdiv1.Failed:
+		return cdiv1.DataVolumeReplicationFailed
+	case dv.Status.Phase == cdiv1.Succeeded:
+		return cdiv1.DataVolumeReplicationCompleted
+	case dvr.Spec.Finalize:
+		return cdiv1.DataVolumeReplicationFinalizing
+	case synced == len(dvr.Spec.Checkpoints)-1:
+		return cdiv1.DataVolumeReplicationSynced
+	}
+	switch dv.Status.Phase {
+	case cdiv1.PhaseUnset, cdiv1.Pending, cdiv1.PendingPopulation, cdiv1.WaitForFirstConsumer:
+		if synced < 0 {
+			return cdiv1.DataVolumeReplicationPending
+		}
+	}
+	return cdiv1.DataVolumeReplicationSyncing
+}
+
+// NewDataVolumeReplicationController creates a new instance of the DataVolumeReplication controller
+func NewDataVolumeReplicationController(mgr manager.Manager, log logr.Logger, installerLabels map[string]string) (controller.Controller, error) {
+	reconciler := &DataVolumeReplicationReconciler{
+		client:          mgr.GetClient(),
+		recorder:        mgr.GetEventRecorderFor(dataVolumeReplicationControllerName),
+		scheme:          mgr.GetScheme(),
+		log:             log.WithName(dataVolumeReplicationControllerName),
+		installerLabels: installerLabels,
+	}
+	dataVolumeReplicationController, err := controller.New(dataVolumeReplicationControllerName, mgr, controller.Options{
+		MaxConcurrentReconciles: 3,
+		Reconciler:              reconciler,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := addDataVolumeReplicationControllerWatches(mgr, dataVolumeReplicationController); err != nil {
+		return nil, err
+	}
+	log.Info("Initialized DataVolumeReplication controller")
+	return dataVolumeReplicationController, nil
+}
+
+func addDataVolumeReplicationControllerWatches(mgr manager.Manager, c controller.Controller) error {
+	if err := c.Watch(source.Kind(mgr.GetCache(), &cdiv1.DataVolumeReplication{}, &handler.TypedEnqueueRequestForObject[*cdiv1.DataVolumeReplication]{})); err != nil {
+		return err
+	}
+	if err := c.Watch(source.Kind(mgr.GetCache(), &cdiv1.DataVolume{}, handler.TypedEnqueueRequestForOwner[*cdiv1.DataVolume](
+		mgr.GetScheme(), mgr.GetClient().RESTMapper(), &cdiv1.DataVolumeReplication{}, handler.OnlyControllerOwner()))); err != nil {
+		return err
+	}
+	// Checkpoints are marked as copied on the PVC of the replicated DataVolume
+	mapPVC := func(ctx context.Context, pvc *corev1.PersistentVolumeClaim) []reconcile.Request {
+		owner := metav1.GetControllerOf(pvc)
+		if owner == nil || owner.Kind != "DataVolume" {
+			return nil
+		}
+		dv := &cdiv1.DataVolume{}
+		if err := mgr.GetClient().Get(ctx, types.NamespacedName{Namespace: pvc.Namespace, Name: owner.Name}, dv); err != nil {
+			return nil
+		}
+		dvr := metav1.GetControllerOf(dv)
+		if dvr == nil || dvr.Kind != "DataVolumeReplication" {
+			return nil
+		}
+		return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: dv.Namespace, Name: dvr.Name}}}
+	}
+	if err := c.Watch(source.Kind(mgr.GetCache(), &corev1.PersistentVolumeClaim{},
+		handler.TypedEnqueueRequestsFromMapFunc[*corev1.PersistentVolumeClaim](mapPVC))); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,227 @@
+/*
+Copyright 2026 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+	"kubevirt.io/containerized-data-importer/pkg/common"
+	. "kubevirt.io/containerized-data-importer/pkg/controller/common"
+)
+
+const dvrName = "test-dvr"
+
+var _ = Describe("DataVolumeReplication controller reconcile loop", func() {
+	dvKey := types.NamespacedName{Name: dvrName, Namespace: metav1.NamespaceDefault}
+
+	reconcileDataVolumeReplication := func(reconciler *DataVolumeReplicationReconciler) *cdiv1.DataVolumeReplication {
+		_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: dvKey})
+		Expect(err).ToNot(HaveOccurred())
+		dvr := &cdiv1.DataVolumeReplication{}
+		Expect(reconciler.client.Get(context.TODO(), dvKey, dvr)).To(Succeed())
+		return dvr
+	}
+
+	getDataVolume := func(reconciler *DataVolumeReplicationReconciler) *cdiv1.DataVolume {
+		dv := &cdiv1.DataVolume{}
+		Expect(reconciler.client.Get(context.TODO(), dvKey, dv)).To(Succeed())
+		return dv
+	}
+
+	setDataVolumePhase := func(reconciler *DataVolumeReplicationReconciler, phase cdiv1.DataVolumePhase) {
+		dv := getDataVolume(reconciler)
+		dv.Status.Phase = phase
+		Expect(reconciler.client.Update(context.TODO(), dv)).To(Succeed())
+	}
+
+	createCopiedPVC := func(reconciler *DataVolumeReplicationReconciler, checkpoints ...string) {
+		pvc := CreatePvc(dvrName, metav1.NamespaceDefault, map[string]string{}, nil)
+		for _, checkpoint := range checkpoints {
+			pvc.Annotations[AnnCheckpointsCopied+"."+checkpoint] = "pod-uid"
+		}
+		Expect(reconciler.client.Create(context.TODO(), pvc)).To(Succeed())
+	}
+
+	updateDataVolumeReplication := func(reconciler *DataVolumeReplicationReconciler, mutate func(*cdiv1.DataVolumeReplication)) {
+		dvr := &cdiv1.DataVolumeReplication{}
+		Expect(reconciler.client.Get(context.TODO(), dvKey, dvr)).To(Succeed())
+		mutate(dvr)
+		Expect(reconciler.client.Update(context.TODO(), dvr)).To(Succeed())
+	}
+
+	It("Should do nothing and return nil when no DataVolumeReplication exists", func() {
+		reconciler := createDataVolumeReplicationReconciler()
+		_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: dvKey})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("Should create a multistage DataVolume with a stage per checkpoint", func() {
+		reconciler := createDataVolumeReplicationReconciler(createVDDKDataVolumeReplication("snapshot-1", "snapshot-2"))
+		dvr := reconcileDataVolumeReplication(reconciler)
+		Expect(dvr.Status.DataVolumeName).To(Equal(dvrName))
+		Expect(dvr.Status.Phase).To(Equal(cdiv1.DataVolumeReplicationPending))
+
+		dv := getDataVolume(reconciler)
+		Expect(dv.Spec.Checkpoints).To(Equal([]cdiv1.DataVolumeCheckpoint{
+			{Previous: "", Current: "snapshot-1"},
+			{Previous: "snapshot-1", Current: "snapshot-2"},
+		}))
+		Expect(dv.Spec.FinalCheckpoint).To(BeFalse())
+		Expect(dv.Spec.Source.VDDK.UUID).To(Equal("uuid"))
+		Expect(dv.Labels).To(HaveKeyWithValue("template-label", "value"))
+		Expect(dv.Labels).To(HaveKeyWithValue(common.DataVolumeReplicationLabel, dvrName))
+		Expect(metav1.IsControlledBy(dv, dvr)).To(BeTrue())
+	})
+
+	It("Should report the checkpoints synchronized and pass new ones to the DataVolume", func() {
+		reconciler := createDataVolumeReplicationReconciler(createVDDKDataVolumeReplication("snapshot-1", "snapshot-2"))
+		reconcileDataVolumeReplication(reconciler)
+		setDataVolumePhase(reconciler, cdiv1.ImportInProgress)
+		createCopiedPVC(reconciler, "snapshot-1")
+		dvr := reconcileDataVolumeReplication(reconciler)
+		Expect(dvr.Status.Phase).To(Equal(cdiv1.DataVolumeReplicationSyncing))
+		Expect(dvr.Status.SyncedCheckpoint).To(Equal("snapshot-1"))
+		Expect(dvr.Status.LastSyncTime).ToNot(BeNil())
+
+		pvc := &corev1.PersistentVolumeClaim{}
+		Expect(reconciler.client.Get(context.TODO(), dvKey, pvc)).To(Succeed())
+		pvc.Annotations[AnnCheckpointsCopied+".snapshot-2"] = "pod-uid"
+		Expect(reconciler.client.Update(context.TODO(), pvc)).To(Succeed())
+		setDataVolumePhase(reconciler, cdiv1.Paused)
+		dvr = reconcileDataVolumeReplication(reconciler)
+		Expect(dvr.Status.Phase).To(Equal(cdiv1.DataVolumeReplicationSynced))
+		Expect(dvr.Status.SyncedCheckpoint).To(Equal("snapshot-2"))
+
+		updateDataVolumeReplication(reconciler, func(dvr *cdiv1.DataVolumeReplication) {
+			dvr.Spec.Checkpoints = append(dvr.Spec.Checkpoints, "snapshot-3")
+			dvr.Spec.Finalize = true
+		})
+		dvr = reconcileDataVolumeReplication(reconciler)
+		Expect(dvr.Status.Phase).To(Equal(cdiv1.DataVolumeReplicationFinalizing))
+		dv := getDataVolume(reconciler)
+		Expect(dv.Spec.Checkpoints).To(HaveLen(3))
+		Expect(dv.Spec.Checkpoints[2]).To(Equal(cdiv1.DataVolumeCheckpoint{Previous: "snapshot-2", Current: "snapshot-3"}))
+		Expect(dv.Spec.FinalCheckpoint).To(BeTrue())
+
+		setDataVolumePhase(reconciler, cdiv1.Succeeded)
+		dvr = reconcileDataVolumeReplication(reconciler)
+		Expect(dvr.Status.Phase).To(Equal(cdiv1.DataVolumeReplicationCompleted))
+		Expect(dvr.Status.SyncedCheckpoint).To(Equal("snapshot-3"))
+	})
+
+	It("Should fail when the DataVolume fails", func() {
+		reconciler := createDataVolumeReplicationReconciler(createVDDKDataVolumeReplication("snapshot-1"))
+		reconcileDataVolumeReplication(reconciler)
+		setDataVolumePhase(reconciler, cdiv1.Failed)
+		dvr := reconcileDataVolumeReplication(reconciler)
+		Expect(dvr.Status.Phase).To(Equal(cdiv1.DataVolumeReplicationFailed))
+	})
+
+	It("Should not take over a DataVolume it does not own", func() {
+		reconciler := createDataVolumeReplicationReconciler(createVDDKDataVolumeReplication("snapshot-1"), NewImportDataVolume(dvrName))
+		dvr := reconcileDataVolumeReplication(reconciler)
+		Expect(dvr.Status.Phase).To(Equal(cdiv1.DataVolumeReplicationFailed))
+		Expect(dvr.Status.Message).To(ContainSubstring("is not owned by the DataVolumeReplication"))
+		Expect(<-reconciler.recorder.(*record.FakeRecorder).Events).To(ContainSubstring(ErrInvalidDataVolumeReplication))
+		Expect(getDataVolume(reconciler).OwnerReferences).To(BeEmpty())
+	})
+
+	DescribeTable("Should refuse sources that cannot be synchronized", func(source *cdiv1.DataVolumeSource) {
+		dvr := createVDDKDataVolumeReplication("snapshot-1")
+		dvr.Spec.Template.Spec.Source = source
+		reconciler := createDataVolumeReplicationReconciler(dvr)
+		dvr = reconcileDataVolumeReplication(reconciler)
+		Expect(dvr.Status.Phase).To(Equal(cdiv1.DataVolumeReplicationFailed))
+		Expect(dvr.Status.Message).To(Equal("the source of the template is not a vddk or imageio source"))
+		Expect(<-reconciler.recorder.(*record.FakeRecorder).Events).To(ContainSubstring(ErrInvalidDataVolumeReplication))
+	},
+		Entry("with an http source", &cdiv1.DataVolumeSource{HTTP: &cdiv1.DataVolumeSourceHTTP{URL: "http://example.com/disk.img"}}),
+		Entry("with an export source, copied whole at each checkpoint", &cdiv1.DataVolumeSource{Export: &cdiv1.DataVolumeSourceExport{URL: "https://example.com/volumes/disk/disk.img.gz"}}),
+	)
+
+	DescribeTable("Should refuse invalid checkpoints", func(checkpoints []string, expected string) {
+		_, err := replicationCheckpoints(checkpoints)
+		Expect(err).To(MatchError(expected))
+	},
+		Entry("without checkpoints", nil, "at least one checkpoint is required"),
+		Entry("with an empty checkpoint", []string{"snapshot-1", ""}, "checkpoints cannot be empty"),
+		Entry("with a checkpoint listed twice", []string{"snapshot-1", "snapshot-2", "snapshot-1"}, "checkpoint snapshot-1 is listed twice"),
+	)
+})
+
+func createDataVolumeReplicationReconciler(objects ...runtime.Object) *DataVolumeReplicationReconciler {
+	s := scheme.Scheme
+	_ = cdiv1.AddToScheme(s)
+	cl := fake.NewClientBuilder().WithScheme(s).WithRuntimeObjects(objects...).Build()
+	r := &DataVolumeReplicationReconciler{
+		client:   cl,
+		recorder: record.NewFakeRecorder(10),
+		scheme:   s,
+		log:      cronLog,
+	}
+	return r
+}
+
+func createVDDKDataVolumeReplication(checkpoints ...string) *cdiv1.DataVolumeReplication {
+	return &cdiv1.DataVolumeReplication{
+		TypeMeta: metav1.TypeMeta{APIVersion: cdiv1.SchemeGroupVersion.String(), Kind: "DataVolumeReplication"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      dvrName,
+			Namespace: metav1.NamespaceDefault,
+			UID:       "dvr-uid",
+		},
+		Spec: cdiv1.DataVolumeReplicationSpec{
+			Template: cdiv1.DataVolume{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"template-label": "value"},
+				},
+				Spec: cdiv1.DataVolumeSpec{
+					Source: &cdiv1.DataVolumeSource{
+						VDDK: &cdiv1.DataVolumeSourceVDDK{
+							URL:         "https://vcenter.example.com",
+							UUID:        "uuid",
+							BackingFile: "[datastore] vm/vm.vmdk",
+							Thumbprint:  "thumbprint",
+							SecretRef:   "vddk-credentials",
+						},
+					},
+					Storage: &cdiv1.StorageSpec{
+						Resources: corev1.VolumeResourceRequirements{
+							Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("10Gi")},
+						},
+					},
+				},
+			},
+			Checkpoints: checkpoints,
+		},
+	}
+}
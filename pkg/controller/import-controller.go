@@ -2,24 +2,29 @@ package controller
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/url"
 	"path"
 	"reflect"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/containers/image/v5/docker/reference"
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
 
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/ptr"
 
@@ -50,6 +55,24 @@ const (
 
 	// ImportTargetInUse is reason for event created when an import pvc is in use
 	ImportTargetInUse = "ImportTargetInUse"
+	// HostPathImportNotAllowed is reason for event created when the file of a hostpath source is outside the allowed directories
+	HostPathImportNotAllowed = "HostPathImportNotAllowed"
+	// ImageVerificationNotSupported is reason for event created when a registry image that must be verified is pulled by the node
+	ImageVerificationNotSupported = "ImageVerificationNotSupported"
+	// ImportServiceAccountNotAuthorized is reason for event created when the service account of an import is not named by its DataVolume or VolumeImportSource
+	ImportServiceAccountNotAuthorized = "ImportServiceAccountNotAuthorized"
+	// ImporterPodSettingsNotAllowed is reason for event created when the priority class or pod overrides of an import are not allowed by the CDIConfig
+	ImporterPodSettingsNotAllowed = "ImporterPodSettingsNotAllowed"
+	// ImportSourceFallback is reason for event created when a failed import is retried from the next fallback source
+	ImportSourceFallback = "ImportSourceFallback"
+	// ImportRetry is reason for event created when a failed import is retried following the retry policy
+	ImportRetry = "ImportRetry"
+	// MaxBandwidthNotValid is reason for event created when the maximum bandwidth of an import is not a positive quantity
+	MaxBandwidthNotValid = "MaxBandwidthNotValid"
+	// StorageFormatNotValid is reason for event created when the storage format of an import is unknown or not supported by its target
+	StorageFormatNotValid = "StorageFormatNotValid"
+	// EncryptionNotValid is reason for event created when the encryption of an import is not supported by its target
+	EncryptionNotValid = "EncryptionNotValid"
 
 	// importPodImageStreamFinalizer ensures image stream import pod is deleted when pvc is deleted,
 	// as in this case pod has no pvc OwnerReference
@@ -57,12 +80,37 @@ const (
 
 	// secretExtraHeadersVolumeName is the format string that specifies where extra HTTP header secrets will be mounted
 	secretExtraHeadersVolumeName = "cdi-secret-extra-headers-vol-%d"
+	// oauth2SecretVolumeName is the name of the volume the OAuth2 client secret of an http source is mounted from
+	oauth2SecretVolumeName = "cdi-oauth2-secret-vol"
+	// signatureKeyringVolumeName is the name of the volume the keyring secret the signature of a source is checked with is mounted from
+	signatureKeyringVolumeName = "cdi-signature-keyring-vol"
+	// clientCertVolumeName is the name of the volume the client certificate secret of a source is mounted from
+	clientCertVolumeName = "cdi-client-cert-vol"
+	// hostPathSourceVolumeName is the name of the volume the file of a hostpath source is mounted from
+	hostPathSourceVolumeName = "cdi-hostpath-source-vol"
+	// smbSourceVolumeName is the name of the volume the share of an SMB source is mounted from
+	smbSourceVolumeName = "cdi-smb-source-vol"
+	// virtioWinVolumeName is the name of the volume the virtio-win PVC of a v2v source is mounted from
+	virtioWinVolumeName = "cdi-virtio-win-vol"
+	// sourceDataVolumeVolumeName is the name of the volume the PVC of the source DataVolume of a chained DataVolume is mounted from
+	sourceDataVolumeVolumeName = "cdi-source-datavolume-vol"
+	// encryptionSecretVolumeName is the name of the volume the secret holding the encryption passphrase is mounted from
+	encryptionSecretVolumeName = "cdi-encryption-secret-vol"
+	// layerCacheVolumeName is the name of the volume the registry layer cache of the node is mounted from
+	layerCacheVolumeName = "cdi-layer-cache-vol"
+	// defaultLayerCacheMaxSize is the size of the registry layer cache when the CDIConfig does not set it
+	defaultLayerCacheMaxSize = "10Gi"
+	// pluginSocketVolumeName is the name of the volume shared with the source plugin container, holding its socket
+	pluginSocketVolumeName = "cdi-plugin-socket-vol"
+	// sourcePluginContainerName is the name of the source plugin container of the importer pod
+	sourcePluginContainerName = "source-plugin"
 )
 
 // ImportReconciler members
 type ImportReconciler struct {
 	client             client.Client
 	uncachedClient     client.Client
+	k8sClient          kubernetes.Interface
 	recorder           record.EventRecorder
 	scheme             *runtime.Scheme
 	log                logr.Logger
@@ -82,6 +130,7 @@ type importPodEnvVar struct {
 	contentType               string
 	imageSize                 string
 	certConfigMap             string
+	clientCertSecret          string
 	diskID                    string
 	uuid                      string
 	pullMethod                string
@@ -101,8 +150,50 @@ type importPodEnvVar struct {
 	certConfigMapProxy        string
 	extraHeaders              []string
 	secretExtraHeaders        []string
+	oauth2SecretName          string
+	ovaDisk                   string
+	xvaDisk                   string
+	checksum                  string
+	signatureURL              string
+	signatureKeyringSecret    string
+	imageVerificationPolicies string
+	maxBandwidth              string
+	storageFormat             string
+	layerCacheHostPath        string
+	layerCacheMaxSize         string
+	glanceImage               string
+	rbdImage                  string
+	hostPathNode              string
+	v2vVM                     string
+	v2vDiskIndex              string
+	v2vBlockDriver            string
+	v2vMACMappings            string
+	v2vVirtioWinPVC           string
+	proxmoxNode               string
+	proxmoxVMID               string
+	proxmoxDisk               string
+	pluginParameters          string
+	torrentWebSeeds           string
+	torrentFile               string
 	cacheMode                 string
+	sparseThreshold           string
+	coroutines                string
+	preallocationMethod       string
 	registryImageArchitecture string
+	blankZeroEdges            bool
+	detectContentType         bool
+	expandFilesystem          bool
+	verify                    bool
+	verifyChecksum            bool
+	sparsify                  bool
+	encryptionSecret          string
+	sourceClaimName           string
+	downloadParallelism       string
+	downloadPartSize          string
+	curlConnections           string
+	curlHTTPVersion           string
+	curlTLS13Ciphers          string
+	curlTimeout               string
 }
 
 type importerPodArgs struct {
@@ -118,7 +209,9 @@ type importerPodArgs struct {
 	workloadNodePlacement   *sdkapi.NodePlacement
 	vddkImageName           *string
 	vddkExtraArgs           *string
+	pluginImageName         *string
 	priorityClassName       string
+	serviceAccountName      string
 }
 
 // NewImportController creates a new instance of the import controller.
@@ -130,16 +223,21 @@ func NewImportController(mgr manager.Manager, log logr.Logger, importerImage, pu
 	if err != nil {
 		return nil, err
 	}
+	k8sClient, err := kubernetes.NewForConfig(mgr.GetConfig())
+	if err != nil {
+		return nil, err
+	}
 	client := mgr.GetClient()
 	reconciler := &ImportReconciler{
 		client:          client,
 		uncachedClient:  uncachedClient,
+		k8sClient:       k8sClient,
 		scheme:          mgr.GetScheme(),
 		log:             log.WithName("import-controller"),
 		image:           importerImage,
 		verbose:         verbose,
 		pullPolicy:      pullPolicy,
-		recorder:        mgr.GetEventRecorderFor("import-controller"),
+		recorder:        cc.NewEventAggregator(mgr.GetEventRecorderFor("import-controller"), client),
 		cdiNamespace:    util.GetNamespace(),
 		featureGates:    featuregates.NewFeatureGates(client),
 		installerLabels: installerLabels,
@@ -255,6 +353,10 @@ func (r *ImportReconciler) reconcilePvc(pvc *corev1.PersistentVolumeClaim, log l
 		if cc.IsPVCComplete(pvc) {
 			// Don't create the POD if the PVC is completed already
 			log.V(1).Info("PVC is already complete")
+		} else if _, ok := pvc.Annotations[cc.AnnImportPod]; ok && isImportPaused(pvc) {
+			// The PVC is reconciled again once the import is resumed
+			log.V(1).Info("PVC import is paused")
+			return reconcile.Result{}, nil
 		} else if pvc.DeletionTimestamp == nil {
 			podsUsingPVC, err := cc.GetPodsUsingPVCs(context.TODO(), r.client, pvc.Namespace, sets.New(pvc.Name), false)
 			if err != nil {
@@ -272,6 +374,13 @@ func (r *ImportReconciler) reconcilePvc(pvc *corev1.PersistentVolumeClaim, log l
 			}
 
 			if _, ok := pvc.Annotations[cc.AnnImportPod]; ok {
+				podKey := types.NamespacedName{Namespace: pvc.Namespace, Name: pvc.Annotations[cc.AnnImportPod]}
+				if exceeded, err := checkImportQuotas(context.TODO(), r.client, r.recorder, pvc, podKey, r.requiresScratchSpace(pvc), log); err != nil || exceeded {
+					return reconcile.Result{RequeueAfter: importQuotaRequeue}, err
+				}
+				if queued, err := queueTransfer(context.TODO(), r.client, r.recorder, pvc, podKey, log); err != nil || queued {
+					return reconcile.Result{RequeueAfter: transferQueuedRequeue}, err
+				}
 				// Create importer pod, make sure the PVC owns it.
 				if err := r.createImporterPod(pvc); err != nil {
 					return reconcile.Result{}, err
@@ -289,6 +398,12 @@ func (r *ImportReconciler) reconcilePvc(pvc *corev1.PersistentVolumeClaim, log l
 			if err := r.cleanup(pvc, pod, log); err != nil {
 				return reconcile.Result{}, err
 			}
+		} else if isImportPaused(pvc) && pod.Status.Phase != corev1.PodSucceeded {
+			log.V(1).Info("PVC import is paused, delete pod", "pod.Name", pod.Name)
+			if err := r.pauseImport(pvc, pod, log); err != nil {
+				return reconcile.Result{}, err
+			}
+			return reconcile.Result{}, nil
 		} else {
 			// Copy import proxy ConfigMap (if exists) from cdi namespace to the import namespace
 			if err := r.copyImportProxyConfigMap(pvc, pod); err != nil {
@@ -311,6 +426,10 @@ func (r *ImportReconciler) reconcilePvc(pvc *corev1.PersistentVolumeClaim, log l
 }
 
 func (r *ImportReconciler) copyImportProxyConfigMap(pvc *corev1.PersistentVolumeClaim, pod *corev1.Pod) error {
+	if _, ok := pvc.Annotations[cc.AnnImportProxy]; ok {
+		// The CA bundle of the proxy of the DataVolume is in its namespace already
+		return nil
+	}
 	cdiConfig := &cdiv1.CDIConfig{}
 	if err := r.client.Get(context.TODO(), types.NamespacedName{Name: common.ConfigName}, cdiConfig); err != nil {
 		return err
@@ -349,6 +468,29 @@ func GetImportProxyConfigMapName(pvcName string) string {
 	return naming.GetResourceName("import-proxy-cm", pvcName)
 }
 
+// getImportProxyCertConfigMapName returns the name of the ConfigMap holding the CA bundle of the import proxy of the
+// PVC, the trustedCAProxy of its DataVolume or else the copy of the one of the CDIConfig
+func getImportProxyCertConfigMapName(pvc *corev1.PersistentVolumeClaim, podEnvVar *importPodEnvVar) string {
+	if _, ok := pvc.Annotations[cc.AnnImportProxy]; ok {
+		return podEnvVar.certConfigMapProxy
+	}
+	return GetImportProxyConfigMapName(pvc.Name)
+}
+
+// getDataVolumeProxy returns the proxy configuration of the DataVolume of the PVC, nil if it uses the one of the
+// CDIConfig
+func getDataVolumeProxy(pvc *corev1.PersistentVolumeClaim) (*cdiv1.DataVolumeProxy, error) {
+	value, ok := pvc.Annotations[cc.AnnImportProxy]
+	if !ok {
+		return nil, nil
+	}
+	proxy := &cdiv1.DataVolumeProxy{}
+	if err := json.Unmarshal([]byte(value), proxy); err != nil {
+		return nil, errors.Wrapf(err, "invalid %s annotation", cc.AnnImportProxy)
+	}
+	return proxy, nil
+}
+
 func (r *ImportReconciler) initPvcPodName(pvc *corev1.PersistentVolumeClaim, log logr.Logger) error {
 	currentPvcCopy := pvc.DeepCopyObject()
 
@@ -390,15 +532,52 @@ func (r *ImportReconciler) updatePvcFromPod(pvc *corev1.PersistentVolumeClaim, p
 	}
 	podModificationsNeeded := scratchSpaceRequired
 
+	sourceFallbackNeeded := false
 	if statuses := pod.Status.ContainerStatuses; len(statuses) > 0 {
 		if isOOMKilled(statuses[0]) {
 			log.V(1).Info("Pod died of an OOM, deleting pod, and restarting with qemu cache mode=none if storage supports it", "pod.Name", pod.Name)
 			podModificationsNeeded = true
 			anno[cc.AnnRequiresDirectIO] = "true"
+		} else if isImportFailure(statuses[0]) && pod.Annotations[cc.AnnSourceFallbackIndex] == anno[cc.AnnSourceFallbackIndex] {
+			fallback, err := useNextSourceFallback(anno)
+			if err != nil {
+				log.Error(err, "Unable to read the fallback sources of the import")
+			} else if fallback {
+				log.V(1).Info("Import failed, deleting pod, and restarting from the next fallback source", "pod.Name", pod.Name, "endpoint", anno[cc.AnnEndpoint])
+				r.recorder.Eventf(pvc, corev1.EventTypeWarning, ImportSourceFallback, "Import failed, retrying from fallback source %s", anno[cc.AnnSourceFallbackIndex])
+				podModificationsNeeded = true
+				sourceFallbackNeeded = true
+			}
 		}
 		if terminated := statuses[0].State.Terminated; terminated != nil && terminated.ExitCode > 0 {
 			log.Info("Pod termination code", "pod.Name", pod.Name, "ExitCode", terminated.ExitCode)
-			r.recorder.Event(pvc, corev1.EventTypeWarning, ErrImportFailedPVC, terminated.Message)
+			message := terminated.Message
+			if termMsg != nil && termMsg.Message != nil {
+				message = *termMsg.Message
+			}
+			r.recorder.Event(pvc, corev1.EventTypeWarning, ErrImportFailedPVC, message)
+		}
+		if err := r.collectFailureArtifacts(pvc, pod, statuses[0], log); err != nil {
+			return err
+		}
+	}
+
+	retryPending, retryNeeded := false, false
+	if !podModificationsNeeded && pod.Status.Phase == corev1.PodFailed && pod.DeletionTimestamp == nil &&
+		pod.Annotations[cc.AnnImportRetryCount] == anno[cc.AnnImportRetryCount] {
+		pending, due, err := scheduleImportRetry(anno, pod, time.Now())
+		if err != nil {
+			log.Error(err, "Unable to read the retry policy of the import")
+		} else if pending {
+			// Keep the failure from reaching the DataVolume while the retry is pending
+			retryPending = true
+			anno[cc.AnnPodPhase] = string(corev1.PodPending)
+			if due {
+				log.V(1).Info("Import failed, deleting pod, and retrying following the retry policy", "pod.Name", pod.Name, "retry", anno[cc.AnnImportRetryCount])
+				r.recorder.Eventf(pvc, corev1.EventTypeWarning, ImportRetry, "Import failed, retry %s", anno[cc.AnnImportRetryCount])
+				podModificationsNeeded = true
+				retryNeeded = true
+			}
 		}
 	}
 
@@ -406,8 +585,12 @@ func (r *ImportReconciler) updatePvcFromPod(pvc *corev1.PersistentVolumeClaim, p
 		anno[cc.AnnCurrentPodID] = string(pod.ObjectMeta.UID)
 	}
 
+	if digest := getNodeImportDigest(pvc, pod); digest != "" {
+		anno[cc.AnnSourceDigest] = digest
+	}
+
 	anno[cc.AnnImportPod] = pod.Name
-	if !podModificationsNeeded {
+	if !podModificationsNeeded && !retryPending {
 		// No scratch space required, update the phase based on the pod. If we require scratch space we don't want to update the
 		// phase, because the pod might terminate cleanly and mistakenly mark the import complete.
 		anno[cc.AnnPodPhase] = string(pod.Status.Phase)
@@ -451,6 +634,11 @@ func (r *ImportReconciler) updatePvcFromPod(pvc *corev1.PersistentVolumeClaim, p
 	}
 	if cc.IsPVCComplete(pvc) {
 		pvc.SetLabels(addLabelsFromTerminationMessage(pvc.GetLabels(), termMsg))
+		if termMsg != nil && termMsg.PreallocationMethod != nil {
+			if err := cc.SetPreallocationMethod(context.TODO(), r.client, pvc.Spec.StorageClassName, *termMsg.PreallocationMethod); err != nil {
+				log.Error(err, "Unable to record the preallocation method on the StorageProfile")
+			}
+		}
 	}
 
 	if !reflect.DeepEqual(currentPvcCopy, pvc) {
@@ -465,7 +653,7 @@ func (r *ImportReconciler) updatePvcFromPod(pvc *corev1.PersistentVolumeClaim, p
 			r.recorder.Event(pvc, corev1.EventTypeNormal, ImportSucceededPVC, "Import Successful")
 			log.V(1).Info("Import completed successfully")
 		}
-		if cc.ShouldDeletePod(pvc) {
+		if cc.ShouldDeletePod(pvc) || sourceFallbackNeeded || retryNeeded {
 			log.V(1).Info("Deleting pod", "pod.Name", pod.Name)
 			if err := r.cleanup(pvc, pod, log); err != nil {
 				return err
@@ -475,6 +663,41 @@ func (r *ImportReconciler) updatePvcFromPod(pvc *corev1.PersistentVolumeClaim, p
 	return nil
 }
 
+func isImportPaused(pvc *corev1.PersistentVolumeClaim) bool {
+	return pvc.Annotations[cc.AnnImportPaused] == "true"
+}
+
+// pauseImport stops the import of a paused PVC. Its scratch space is handed over to the PVC rather than deleted along
+// with the pod, so the download it holds continues once the import is resumed.
+func (r *ImportReconciler) pauseImport(pvc *corev1.PersistentVolumeClaim, pod *corev1.Pod, log logr.Logger) error {
+	anno := pvc.GetAnnotations()
+	if scratchPVCName, exists := getScratchNameFromPod(pod); exists {
+		scratchPvc := &corev1.PersistentVolumeClaim{}
+		err := r.client.Get(context.TODO(), types.NamespacedName{Namespace: pvc.Namespace, Name: scratchPVCName}, scratchPvc)
+		if cc.IgnoreNotFound(err) != nil {
+			return err
+		}
+		if err == nil && scratchPvc.DeletionTimestamp == nil && metav1.IsControlledBy(scratchPvc, pod) {
+			log.V(1).Info("Keeping scratch space of paused import", "scratchPvc.Name", scratchPvc.Name)
+			scratchPvc.OwnerReferences = []metav1.OwnerReference{
+				*metav1.NewControllerRef(pvc, corev1.SchemeGroupVersion.WithKind("PersistentVolumeClaim")),
+			}
+			if err := r.client.Update(context.TODO(), scratchPvc); err != nil {
+				return err
+			}
+			// The resumed pod mounts the scratch space right away
+			anno[cc.AnnRequiresScratch] = "true"
+		}
+	}
+	anno[cc.AnnRunningCondition] = "false"
+	anno[cc.AnnRunningConditionMessage] = "Import is paused"
+	anno[cc.AnnRunningConditionReason] = ImportPausedReason
+	if err := r.updatePVC(pvc, log); err != nil {
+		return err
+	}
+	return r.cleanup(pvc, pod, log)
+}
+
 func (r *ImportReconciler) cleanup(pvc *corev1.PersistentVolumeClaim, pod *corev1.Pod, log logr.Logger) error {
 	if err := r.client.Delete(context.TODO(), pod); cc.IgnoreNotFound(err) != nil {
 		return err
@@ -500,6 +723,7 @@ func (r *ImportReconciler) createImporterPod(pvc *corev1.PersistentVolumeClaim)
 	var scratchPvcName *string
 	var vddkImageName *string
 	var vddkExtraArgs *string
+	var pluginImageName *string
 	var err error
 
 	requiresScratch := r.requiresScratchSpace(pvc)
@@ -535,21 +759,59 @@ func (r *ImportReconciler) createImporterPod(pvc *corev1.PersistentVolumeClaim)
 		}
 	}
 
+	if cc.GetSource(pvc) == cc.SourcePlugin {
+		anno := pvc.GetAnnotations()
+		name := anno[cc.AnnEndpoint]
+		if pluginImageName, err = r.getSourcePluginImageName(name); err != nil {
+			message := fmt.Sprintf("waiting for source plugin %s to be registered in the %s configmap", name, common.SourcePluginConfigMap)
+			r.log.V(1).Error(err, message)
+			anno[cc.AnnBoundCondition] = "false"
+			anno[cc.AnnBoundConditionMessage] = message
+			anno[cc.AnnBoundConditionReason] = common.AwaitingSourcePlugin
+			if err := r.updatePVC(pvc, r.log); err != nil {
+				return err
+			}
+			return errors.New(message)
+		}
+	}
+
+	serviceAccountName, err := r.getImportServiceAccount(pvc)
+	if err != nil {
+		return err
+	}
 	podEnvVar, err := r.createImportEnvVar(pvc)
 	if err != nil {
 		return err
 	}
+	cdiConfig := &cdiv1.CDIConfig{}
+	if err := r.client.Get(context.TODO(), types.NamespacedName{Name: common.ConfigName}, cdiConfig); err != nil {
+		return err
+	}
+	if err := checkImporterPodSettings(pvc, cdiConfig); err != nil {
+		r.recorder.Event(pvc, corev1.EventTypeWarning, ImporterPodSettingsNotAllowed, err.Error())
+		return err
+	}
+	priorityClassName, err := cc.GetTransferPodPriorityClass(context.TODO(), r.client, cc.GetPriorityClass(pvc), cc.ImporterPriorityClass)
+	if err != nil {
+		return err
+	}
+	image, err := cc.GetImporterImage(context.TODO(), r.client, cc.GetSource(pvc), r.image)
+	if err != nil {
+		return err
+	}
 	// all checks passed, let's create the importer pod!
 	podArgs := &importerPodArgs{
-		image:             r.image,
-		verbose:           r.verbose,
-		pullPolicy:        r.pullPolicy,
-		podEnvVar:         podEnvVar,
-		pvc:               pvc,
-		scratchPvcName:    scratchPvcName,
-		vddkImageName:     vddkImageName,
-		vddkExtraArgs:     vddkExtraArgs,
-		priorityClassName: cc.GetPriorityClass(pvc),
+		image:              image,
+		verbose:            r.verbose,
+		pullPolicy:         r.pullPolicy,
+		podEnvVar:          podEnvVar,
+		pvc:                pvc,
+		scratchPvcName:     scratchPvcName,
+		vddkImageName:      vddkImageName,
+		vddkExtraArgs:      vddkExtraArgs,
+		pluginImageName:    pluginImageName,
+		priorityClassName:  priorityClassName,
+		serviceAccountName: serviceAccountName,
 	}
 
 	pod, err := createImporterPod(context.TODO(), r.log, r.client, podArgs, r.installerLabels)
@@ -577,6 +839,60 @@ func (r *ImportReconciler) createImporterPod(pvc *corev1.PersistentVolumeClaim)
 	return nil
 }
 
+// getImportServiceAccount returns the service account the importer pod runs as. The annotation is only honored when
+// the DataVolume or VolumeImportSource the PVC is populated from names the same service account, as the webhook
+// checked their creator may impersonate it, while anyone creating a PVC could set the annotation.
+func (r *ImportReconciler) getImportServiceAccount(pvc *corev1.PersistentVolumeClaim) (string, error) {
+	serviceAccountName := pvc.Annotations[cc.AnnImportServiceAccount]
+	if serviceAccountName == "" {
+		return "", nil
+	}
+
+	var sources []*cdiv1.DataVolumeSourceS3
+	owner := metav1.GetControllerOf(pvc)
+	switch {
+	case owner != nil && owner.Kind == "DataVolume":
+		dv := &cdiv1.DataVolume{}
+		if err := r.client.Get(context.TODO(), types.NamespacedName{Namespace: pvc.Namespace, Name: owner.Name}, dv); err != nil {
+			return "", err
+		}
+		if dv.UID == owner.UID && dv.Spec.Source != nil {
+			sources = append(sources, dv.Spec.Source.S3)
+			for _, fallback := range dv.Spec.SourceFallbacks {
+				sources = append(sources, fallback.S3)
+			}
+		}
+	case owner != nil && owner.Kind == "PersistentVolumeClaim":
+		target := &corev1.PersistentVolumeClaim{}
+		if err := r.client.Get(context.TODO(), types.NamespacedName{Namespace: pvc.Namespace, Name: owner.Name}, target); err != nil {
+			return "", err
+		}
+		ref := target.Spec.DataSourceRef
+		if target.UID == owner.UID && ref != nil && ref.Kind == cdiv1.VolumeImportSourceRef {
+			namespace := target.Namespace
+			if ref.Namespace != nil && *ref.Namespace != "" {
+				namespace = *ref.Namespace
+			}
+			volumeImportSource := &cdiv1.VolumeImportSource{}
+			if err := r.client.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: ref.Name}, volumeImportSource); err != nil {
+				return "", err
+			}
+			if volumeImportSource.Spec.Source != nil {
+				sources = append(sources, volumeImportSource.Spec.Source.S3)
+			}
+		}
+	}
+	for _, s3 := range sources {
+		if s3 != nil && s3.ServiceAccountName == serviceAccountName {
+			return serviceAccountName, nil
+		}
+	}
+
+	r.recorder.Eventf(pvc, corev1.EventTypeWarning, ImportServiceAccountNotAuthorized,
+		"Service account %s is not named by the DataVolume or VolumeImportSource populating the PVC", serviceAccountName)
+	return "", errors.Errorf("service account %s of PVC %s/%s is not named by its DataVolume or VolumeImportSource", serviceAccountName, pvc.Namespace, pvc.Name)
+}
+
 func createScratchNameFromPvc(pvc *v1.PersistentVolumeClaim) string {
 	return naming.GetResourceName(pvc.Name, common.ScratchNameSuffix)
 }
@@ -585,6 +901,7 @@ func (r *ImportReconciler) createImportEnvVar(pvc *corev1.PersistentVolumeClaim)
 	podEnvVar := &importPodEnvVar{}
 	podEnvVar.source = cc.GetSource(pvc)
 	podEnvVar.contentType = string(cc.GetPVCContentType(pvc))
+	podEnvVar.detectContentType = pvc.Annotations[cc.AnnDetectContentType] == "true"
 
 	var err error
 	if podEnvVar.source != cc.SourceNone {
@@ -606,6 +923,7 @@ func (r *ImportReconciler) createImportEnvVar(pvc *corev1.PersistentVolumeClaim)
 		if err != nil {
 			return nil, err
 		}
+		podEnvVar.clientCertSecret = getValueFromAnnotation(pvc, cc.AnnClientCertSecret)
 		podEnvVar.insecureTLS, err = r.isInsecureTLS(pvc, cdiConfig)
 		if err != nil {
 			return nil, err
@@ -618,6 +936,60 @@ func (r *ImportReconciler) createImportEnvVar(pvc *corev1.PersistentVolumeClaim)
 		podEnvVar.currentCheckpoint = getValueFromAnnotation(pvc, cc.AnnCurrentCheckpoint)
 		podEnvVar.finalCheckpoint = getValueFromAnnotation(pvc, cc.AnnFinalCheckpoint)
 		podEnvVar.registryImageArchitecture = getValueFromAnnotation(pvc, cc.AnnRegistryImageArchitecture)
+		podEnvVar.oauth2SecretName = getValueFromAnnotation(pvc, cc.AnnOAuth2Secret)
+		podEnvVar.ovaDisk = getValueFromAnnotation(pvc, cc.AnnOVADisk)
+		podEnvVar.xvaDisk = getValueFromAnnotation(pvc, cc.AnnXVADisk)
+		podEnvVar.checksum = getValueFromAnnotation(pvc, cc.AnnChecksum)
+		podEnvVar.signatureURL = getValueFromAnnotation(pvc, cc.AnnSignatureURL)
+		podEnvVar.signatureKeyringSecret = getValueFromAnnotation(pvc, cc.AnnSignatureKeyringSecret)
+		podEnvVar.glanceImage = getValueFromAnnotation(pvc, cc.AnnGlanceImage)
+		podEnvVar.rbdImage = getValueFromAnnotation(pvc, cc.AnnRBDImage)
+		podEnvVar.v2vVM = getValueFromAnnotation(pvc, cc.AnnV2VVM)
+		podEnvVar.v2vDiskIndex = getValueFromAnnotation(pvc, cc.AnnV2VDiskIndex)
+		podEnvVar.v2vBlockDriver = getValueFromAnnotation(pvc, cc.AnnV2VBlockDriver)
+		podEnvVar.v2vMACMappings = getValueFromAnnotation(pvc, cc.AnnV2VMACMappings)
+		podEnvVar.v2vVirtioWinPVC = getValueFromAnnotation(pvc, cc.AnnV2VVirtioWinPVC)
+		podEnvVar.proxmoxNode = getValueFromAnnotation(pvc, cc.AnnProxmoxNode)
+		podEnvVar.proxmoxVMID = getValueFromAnnotation(pvc, cc.AnnProxmoxVMID)
+		podEnvVar.proxmoxDisk = getValueFromAnnotation(pvc, cc.AnnProxmoxDisk)
+		podEnvVar.pluginParameters = getValueFromAnnotation(pvc, cc.AnnPluginParameters)
+		podEnvVar.torrentWebSeeds = getValueFromAnnotation(pvc, cc.AnnTorrentWebSeeds)
+		podEnvVar.torrentFile = getValueFromAnnotation(pvc, cc.AnnTorrentFile)
+		podEnvVar.maxBandwidth, err = r.getMaxBandwidth(pvc)
+		if err != nil {
+			return nil, err
+		}
+		podEnvVar.storageFormat, err = r.getStorageFormat(pvc)
+		if err != nil {
+			return nil, err
+		}
+		if podEnvVar.source == cc.SourceRegistry {
+			podEnvVar.imageVerificationPolicies, err = r.getImageVerificationPolicies(pvc, podEnvVar.ep)
+			if err != nil {
+				return nil, err
+			}
+			if pvc.Annotations[cc.AnnRegistryImportMethod] != string(cdiv1.RegistryPullNode) {
+				podEnvVar.layerCacheHostPath, podEnvVar.layerCacheMaxSize = r.getLayerCache(cdiConfig)
+			}
+		}
+		if podEnvVar.source == cc.SourceHostPath {
+			podEnvVar.hostPathNode = getValueFromAnnotation(pvc, cc.AnnHostPathNode)
+			if err := checkHostPathImport(podEnvVar.ep, podEnvVar.hostPathNode, cdiConfig); err != nil {
+				r.recorder.Event(pvc, corev1.EventTypeWarning, HostPathImportNotAllowed, err.Error())
+				return nil, err
+			}
+		}
+		if podEnvVar.source == cc.SourceDataVolume {
+			podEnvVar.sourceClaimName = podEnvVar.ep
+			podEnvVar.ep, err = r.getSourceDataVolumePath(pvc.Namespace, podEnvVar.sourceClaimName)
+			if err != nil {
+				return nil, err
+			}
+		}
+		podEnvVar.encryptionSecret, err = r.getEncryptionSecret(pvc)
+		if err != nil {
+			return nil, err
+		}
 
 		for annotation, value := range pvc.Annotations {
 			if strings.HasPrefix(annotation, cc.AnnExtraHeaders) {
@@ -628,23 +1000,35 @@ func (r *ImportReconciler) createImportEnvVar(pvc *corev1.PersistentVolumeClaim)
 			}
 		}
 
-		var field string
-		if field, err = GetImportProxyConfig(cdiConfig, common.ImportProxyHTTP); err != nil {
-			r.log.V(3).Info("no proxy http url will be supplied:", "error", err.Error())
-		}
-		podEnvVar.httpProxy = field
-		if field, err = GetImportProxyConfig(cdiConfig, common.ImportProxyHTTPS); err != nil {
-			r.log.V(3).Info("no proxy https url will be supplied:", "error", err.Error())
-		}
-		podEnvVar.httpsProxy = field
-		if field, err = GetImportProxyConfig(cdiConfig, common.ImportProxyNoProxy); err != nil {
-			r.log.V(3).Info("the noProxy field will not be supplied:", "error", err.Error())
+		proxy, err := getDataVolumeProxy(pvc)
+		if err != nil {
+			return nil, err
 		}
-		podEnvVar.noProxy = field
-		if field, err = GetImportProxyConfig(cdiConfig, common.ImportProxyConfigMapName); err != nil {
-			r.log.V(3).Info("no proxy CA certiticate will be supplied:", "error", err.Error())
+		if proxy != nil {
+			// The proxy of the DataVolume replaces the one of the CDIConfig
+			podEnvVar.httpProxy = proxy.HTTPProxy
+			podEnvVar.httpsProxy = proxy.HTTPSProxy
+			podEnvVar.noProxy = proxy.NoProxy
+			podEnvVar.certConfigMapProxy = proxy.TrustedCAProxy
+		} else {
+			var field string
+			if field, err = GetImportProxyConfig(cdiConfig, common.ImportProxyHTTP); err != nil {
+				r.log.V(3).Info("no proxy http url will be supplied:", "error", err.Error())
+			}
+			podEnvVar.httpProxy = field
+			if field, err = GetImportProxyConfig(cdiConfig, common.ImportProxyHTTPS); err != nil {
+				r.log.V(3).Info("no proxy https url will be supplied:", "error", err.Error())
+			}
+			podEnvVar.httpsProxy = field
+			if field, err = GetImportProxyConfig(cdiConfig, common.ImportProxyNoProxy); err != nil {
+				r.log.V(3).Info("the noProxy field will not be supplied:", "error", err.Error())
+			}
+			podEnvVar.noProxy = field
+			if field, err = GetImportProxyConfig(cdiConfig, common.ImportProxyConfigMapName); err != nil {
+				r.log.V(3).Info("no proxy CA certiticate will be supplied:", "error", err.Error())
+			}
+			podEnvVar.certConfigMapProxy = field
 		}
-		podEnvVar.certConfigMapProxy = field
 	}
 
 	fsOverhead, err := GetFilesystemOverhead(context.TODO(), r.client, pvc)
@@ -663,13 +1047,283 @@ func (r *ImportReconciler) createImportEnvVar(pvc *corev1.PersistentVolumeClaim)
 		return nil, err
 	}
 
+	if defaults := cc.GetConversionDefaults(context.TODO(), r.client, pvc.Spec.StorageClassName); defaults != nil {
+		if defaults.CacheMode != nil && *defaults.CacheMode == cdiv1.ConversionCacheModeNone {
+			podEnvVar.cacheMode = common.CacheModeTryNone
+		}
+		if defaults.SparseThreshold != nil {
+			podEnvVar.sparseThreshold = strconv.FormatInt(defaults.SparseThreshold.Value(), 10)
+		}
+		if defaults.Coroutines != nil {
+			podEnvVar.coroutines = strconv.Itoa(int(*defaults.Coroutines))
+		}
+	}
+	if podEnvVar.preallocation {
+		podEnvVar.preallocationMethod = cc.GetPreallocationMethod(context.TODO(), r.client, pvc.Spec.StorageClassName)
+	}
 	if v, ok := pvc.Annotations[cc.AnnRequiresDirectIO]; ok && v == "true" {
 		podEnvVar.cacheMode = common.CacheModeTryNone
 	}
 
+	if zeroEdges, err := strconv.ParseBool(getValueFromAnnotation(pvc, cc.AnnBlankZeroEdges)); err == nil {
+		podEnvVar.blankZeroEdges = zeroEdges
+	}
+	if expand, err := strconv.ParseBool(getValueFromAnnotation(pvc, cc.AnnExpandFilesystem)); err == nil {
+		podEnvVar.expandFilesystem = expand
+	}
+	if verify, err := strconv.ParseBool(getValueFromAnnotation(pvc, cc.AnnVerify)); err == nil {
+		podEnvVar.verify = verify
+	}
+	if verifyChecksum, err := strconv.ParseBool(getValueFromAnnotation(pvc, cc.AnnVerifyChecksum)); err == nil {
+		podEnvVar.verifyChecksum = verifyChecksum
+	}
+	if sparsify, err := strconv.ParseBool(getValueFromAnnotation(pvc, cc.AnnSparsify)); err == nil {
+		podEnvVar.sparsify = sparsify
+	}
+	podEnvVar.downloadParallelism = getValueFromAnnotation(pvc, cc.AnnDownloadParallelism)
+	podEnvVar.downloadPartSize = getValueFromAnnotation(pvc, cc.AnnDownloadPartSize)
+	podEnvVar.curlConnections = getValueFromAnnotation(pvc, cc.AnnCurlConnections)
+	podEnvVar.curlHTTPVersion = getValueFromAnnotation(pvc, cc.AnnCurlHTTPVersion)
+	podEnvVar.curlTLS13Ciphers = getValueFromAnnotation(pvc, cc.AnnCurlTLS13Ciphers)
+	podEnvVar.curlTimeout = getValueFromAnnotation(pvc, cc.AnnCurlTimeout)
+
 	return podEnvVar, nil
 }
 
+// checkHostPathImport makes sure the file of a hostpath source is under one of the directories the
+// CDIConfig allows imports from, the importer mounts it from the node.
+func checkHostPathImport(filePath, nodeName string, cdiConfig *cdiv1.CDIConfig) error {
+	if nodeName == "" {
+		return errors.New("hostpath source has no node")
+	}
+	if !path.IsAbs(filePath) || path.Clean(filePath) != filePath {
+		return errors.Errorf("hostpath source %s is not a clean absolute path", filePath)
+	}
+	for _, dir := range cdiConfig.Spec.HostPathImportDirectories {
+		if path.IsAbs(dir) && strings.HasPrefix(filePath, strings.TrimSuffix(path.Clean(dir), "/")+"/") {
+			return nil
+		}
+	}
+	return errors.Errorf("hostpath source %s is not under any of the hostPathImportDirectories of the CDIConfig", filePath)
+}
+
+// checkImporterPodSettings makes sure the priority class and the overridden tolerations and affinity of the importer
+// pod are among the ones the CDIConfig allows, as they let the pod preempt others or run on dedicated nodes.
+func checkImporterPodSettings(pvc *corev1.PersistentVolumeClaim, cdiConfig *cdiv1.CDIConfig) error {
+	priorityClassName := cc.GetPriorityClass(pvc)
+	if priorityClassName != "" && len(cdiConfig.Spec.ImporterPriorityClasses) > 0 &&
+		!slices.Contains(cdiConfig.Spec.ImporterPriorityClasses, priorityClassName) {
+		return errors.Errorf("priority class %s is not one of the importerPriorityClasses of the CDIConfig", priorityClassName)
+	}
+
+	value, ok := pvc.Annotations[cc.AnnPodOverrides]
+	if !ok {
+		return nil
+	}
+	overrides := &cdiv1.DataVolumePodOverrides{}
+	if err := json.Unmarshal([]byte(value), overrides); err != nil {
+		return errors.Wrapf(err, "invalid %s annotation", cc.AnnPodOverrides)
+	}
+	for _, toleration := range overrides.Tolerations {
+		if !slices.ContainsFunc(cdiConfig.Spec.PodOverrideTolerations, func(allowed corev1.Toleration) bool {
+			return reflect.DeepEqual(allowed, toleration)
+		}) {
+			return errors.Errorf("toleration of taint %s is not one of the podOverrideTolerations of the CDIConfig", toleration.Key)
+		}
+	}
+	if overrides.Affinity != nil {
+		if len(cdiConfig.Spec.PodOverrideAffinityKeys) == 0 {
+			return errors.New("affinity can not be overridden without podOverrideAffinityKeys in the CDIConfig")
+		}
+		allowedKeys := sets.New(cdiConfig.Spec.PodOverrideAffinityKeys...)
+		for _, key := range getAffinityKeys(overrides.Affinity) {
+			if !allowedKeys.Has(key) {
+				return errors.Errorf("affinity key %s is not one of the podOverrideAffinityKeys of the CDIConfig", key)
+			}
+		}
+	}
+	return nil
+}
+
+// getAffinityKeys returns the node label keys the node affinity selects on and the topology keys of the pod affinity
+// and anti-affinity
+func getAffinityKeys(affinity *corev1.Affinity) []string {
+	var keys []string
+	addNodeSelectorTerm := func(term corev1.NodeSelectorTerm) {
+		for _, requirement := range term.MatchExpressions {
+			keys = append(keys, requirement.Key)
+		}
+		for _, requirement := range term.MatchFields {
+			keys = append(keys, requirement.Key)
+		}
+	}
+	addPodAffinityTerms := func(required []corev1.PodAffinityTerm, preferred []corev1.WeightedPodAffinityTerm) {
+		for _, term := range required {
+			keys = append(keys, term.TopologyKey)
+		}
+		for _, term := range preferred {
+			keys = append(keys, term.PodAffinityTerm.TopologyKey)
+		}
+	}
+	if nodeAffinity := affinity.NodeAffinity; nodeAffinity != nil {
+		if required := nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution; required != nil {
+			for _, term := range required.NodeSelectorTerms {
+				addNodeSelectorTerm(term)
+			}
+		}
+		for _, term := range nodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution {
+			addNodeSelectorTerm(term.Preference)
+		}
+	}
+	if podAffinity := affinity.PodAffinity; podAffinity != nil {
+		addPodAffinityTerms(podAffinity.RequiredDuringSchedulingIgnoredDuringExecution, podAffinity.PreferredDuringSchedulingIgnoredDuringExecution)
+	}
+	if podAntiAffinity := affinity.PodAntiAffinity; podAntiAffinity != nil {
+		addPodAffinityTerms(podAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution, podAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution)
+	}
+	return keys
+}
+
+// getMaxBandwidth returns the maximum bandwidth of the import in bytes per second, empty if it is not throttled
+func (r *ImportReconciler) getMaxBandwidth(pvc *corev1.PersistentVolumeClaim) (string, error) {
+	value := getValueFromAnnotation(pvc, cc.AnnMaxBandwidth)
+	if value == "" {
+		return "", nil
+	}
+	maxBandwidth, err := resource.ParseQuantity(value)
+	if err != nil || maxBandwidth.Value() <= 0 {
+		err := errors.Errorf("maximum bandwidth %q is not a positive quantity of bytes per second", value)
+		r.recorder.Event(pvc, corev1.EventTypeWarning, MaxBandwidthNotValid, err.Error())
+		return "", err
+	}
+	return strconv.FormatInt(maxBandwidth.Value(), 10), nil
+}
+
+// getStorageFormat returns the format the imported image is stored in, empty for raw
+func (r *ImportReconciler) getStorageFormat(pvc *corev1.PersistentVolumeClaim) (string, error) {
+	value := getValueFromAnnotation(pvc, cc.AnnStorageFormat)
+	if value == "" {
+		return "", nil
+	}
+	var err error
+	switch {
+	case value != common.StorageFormatQcow2:
+		err = errors.Errorf("storage format %q is not supported", value)
+	case cc.GetVolumeMode(pvc) == corev1.PersistentVolumeBlock:
+		err = errors.Errorf("storage format %q requires a filesystem volume", value)
+	case cc.GetPVCContentType(pvc) != cdiv1.DataVolumeKubeVirt:
+		err = errors.Errorf("storage format %q requires the kubevirt content type", value)
+	default:
+		return value, nil
+	}
+	r.recorder.Event(pvc, corev1.EventTypeWarning, StorageFormatNotValid, err.Error())
+	return "", err
+}
+
+// getSourceDataVolumePath returns the path the importer pod reads the PVC of the source DataVolume of a chained
+// DataVolume from, the device of a block volume or the disk image of a filesystem volume
+func (r *ImportReconciler) getSourceDataVolumePath(namespace, claimName string) (string, error) {
+	sourcePvc := &corev1.PersistentVolumeClaim{}
+	if err := r.client.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: claimName}, sourcePvc); err != nil {
+		return "", errors.Wrapf(err, "could not get the PVC of source DataVolume %s", claimName)
+	}
+	if cc.GetVolumeMode(sourcePvc) == corev1.PersistentVolumeBlock {
+		return common.ImporterSourceDataVolumeDevice, nil
+	}
+	return path.Join(common.ImporterSourceDataVolumeDir, common.DiskImageName), nil
+}
+
+// getEncryptionSecret returns the name of the secret holding the passphrase the imported image is encrypted with,
+// empty if it is not encrypted
+func (r *ImportReconciler) getEncryptionSecret(pvc *corev1.PersistentVolumeClaim) (string, error) {
+	value := getValueFromAnnotation(pvc, cc.AnnEncryptionSecret)
+	if value == "" {
+		return "", nil
+	}
+	var err error
+	switch {
+	case cc.GetVolumeMode(pvc) == corev1.PersistentVolumeBlock:
+		err = errors.New("encryption requires a filesystem volume")
+	case cc.GetPVCContentType(pvc) != cdiv1.DataVolumeKubeVirt:
+		err = errors.New("encryption requires the kubevirt content type")
+	case getValueFromAnnotation(pvc, cc.AnnStorageFormat) != "":
+		err = errors.New("encryption can not be combined with a storage format")
+	default:
+		return value, nil
+	}
+	r.recorder.Event(pvc, corev1.EventTypeWarning, EncryptionNotValid, err.Error())
+	return "", err
+}
+
+// getLayerCache returns the node directory and the size in bytes of the registry layer cache, empty if the CDIConfig
+// does not enable it
+func (r *ImportReconciler) getLayerCache(cdiConfig *cdiv1.CDIConfig) (string, string) {
+	layerCache := cdiConfig.Spec.RegistryLayerCache
+	if layerCache == nil {
+		return "", ""
+	}
+	if !path.IsAbs(layerCache.HostPath) || path.Clean(layerCache.HostPath) != layerCache.HostPath {
+		r.log.Info("Ignoring the registry layer cache, its hostPath is not a clean absolute path", "hostPath", layerCache.HostPath)
+		return "", ""
+	}
+	maxSize := resource.MustParse(defaultLayerCacheMaxSize)
+	if layerCache.MaxSize != nil {
+		maxSize = *layerCache.MaxSize
+	}
+	return layerCache.HostPath, strconv.FormatInt(maxSize.Value(), 10)
+}
+
+// getImageVerificationPolicies returns the json of the ImageVerificationPolicies the image of a registry source must
+// meet, empty if none applies to it
+func (r *ImportReconciler) getImageVerificationPolicies(pvc *corev1.PersistentVolumeClaim, ep string) (string, error) {
+	policies, err := matchImageVerificationPolicies(context.TODO(), r.client, ep)
+	if err != nil || len(policies) == 0 {
+		return "", err
+	}
+	if pvc.Annotations[cc.AnnRegistryImportMethod] == string(cdiv1.RegistryPullNode) {
+		err := errors.Errorf("registry image %s must meet the ImageVerificationPolicy %s, images pulled by the node can not be verified", ep, policies[0].Name)
+		r.recorder.Event(pvc, corev1.EventTypeWarning, ImageVerificationNotSupported, err.Error())
+		return "", err
+	}
+	r.log.V(1).Info("Registry image must be verified", "endpoint", ep, "policies", len(policies))
+	policiesJSON, err := json.Marshal(policies)
+	if err != nil {
+		return "", err
+	}
+	return string(policiesJSON), nil
+}
+
+// matchImageVerificationPolicies returns the ImageVerificationPolicies with an image pattern matching the repository
+// of a docker registry endpoint
+func matchImageVerificationPolicies(ctx context.Context, c client.Client, ep string) ([]cdiv1.ImageVerificationPolicy, error) {
+	image, ok := strings.CutPrefix(ep, cdiv1.RegistrySchemeDocker+"://")
+	if !ok {
+		return nil, nil
+	}
+	ref, err := reference.ParseNormalizedNamed(image)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid registry image %s", ep)
+	}
+	policyList := &cdiv1.ImageVerificationPolicyList{}
+	if err := c.List(ctx, policyList); err != nil {
+		return nil, err
+	}
+	policies := []cdiv1.ImageVerificationPolicy{}
+	for _, policy := range policyList.Items {
+		for _, pattern := range policy.Spec.Images {
+			if matched, _ := path.Match(pattern, ref.Name()); matched {
+				// The importer only needs the name and the spec
+				policies = append(policies, cdiv1.ImageVerificationPolicy{
+					ObjectMeta: metav1.ObjectMeta{Name: policy.Name},
+					Spec:       policy.Spec,
+				})
+				break
+			}
+		}
+	}
+	return policies, nil
+}
+
 func (r *ImportReconciler) isInsecureTLS(pvc *corev1.PersistentVolumeClaim, cdiConfig *cdiv1.CDIConfig) (bool, error) {
 	ep, ok := pvc.Annotations[cc.AnnEndpoint]
 	if !ok || ep == "" {
@@ -786,6 +1440,14 @@ func (r *ImportReconciler) createScratchPvcForPod(pvc *corev1.PersistentVolumeCl
 		anno[cc.AnnBoundConditionMessage] = "Creating scratch space"
 		anno[cc.AnnBoundConditionReason] = creatingScratch
 	} else {
+		if metav1.IsControlledBy(scratchPvc, pvc) {
+			// The scratch space was kept while the import was paused, the resumed pod owns it again
+			r.log.V(1).Info("Reusing scratch space of paused import", "pod.Name", pod.Name, "pvc.Name", pvc.Name)
+			scratchPvc.OwnerReferences = []metav1.OwnerReference{MakePodOwnerReference(pod)}
+			if err := r.client.Update(context.TODO(), scratchPvc); err != nil {
+				return err
+			}
+		}
 		if scratchPvc.DeletionTimestamp != nil {
 			// Delete the pod since we are in a deadlock situation now. The scratch PVC from the previous import is not gone
 			// yet but terminating, and the new pod is still being created and the scratch PVC now has a finalizer on it.
@@ -821,6 +1483,27 @@ func (r *ImportReconciler) getVddkImageName() (*string, error) {
 	return nil, errors.Errorf("found %s ConfigMap in namespace %s, but it does not contain a '%s' entry", common.VddkConfigMap, namespace, common.VddkConfigDataKey)
 }
 
+// getSourcePluginImageName returns the image of the source plugin registered under name
+func (r *ImportReconciler) getSourcePluginImageName(name string) (*string, error) {
+	namespace := util.GetNamespace()
+
+	cm := &corev1.ConfigMap{}
+	err := r.uncachedClient.Get(context.TODO(), types.NamespacedName{Name: common.SourcePluginConfigMap, Namespace: namespace}, cm)
+	if k8serrors.IsNotFound(err) {
+		return nil, errors.Errorf("No %s ConfigMap present in namespace %s", common.SourcePluginConfigMap, namespace)
+	} else if err != nil {
+		return nil, err
+	}
+
+	image, found := cm.Data[name]
+	if found && image != "" {
+		r.log.V(1).Info("Found source plugin image", "plugin", name, "image", image)
+		return &image, nil
+	}
+
+	return nil, errors.Errorf("found %s ConfigMap in namespace %s, but it does not register a '%s' plugin", common.SourcePluginConfigMap, namespace, name)
+}
+
 // returns the import image part of the endpoint string
 func getRegistryImportImage(pvc *corev1.PersistentVolumeClaim) (string, error) {
 	ep, err := cc.GetEndpoint(pvc)
@@ -885,6 +1568,13 @@ func createImporterPod(ctx context.Context, log logr.Logger, client client.Clien
 		return nil, err
 	}
 
+	if err := applyImporterPodOverrides(args); err != nil {
+		return nil, err
+	}
+	if err := applyTopologyPlacement(args); err != nil {
+		return nil, err
+	}
+
 	if isRegistryNodeImport(args) {
 		args.importImage, err = getRegistryImportImage(args.pvc)
 		if err != nil {
@@ -895,6 +1585,9 @@ func createImporterPod(ctx context.Context, log logr.Logger, client client.Clien
 			setRegistryNodeImportNodeSelector(args)
 		}
 	}
+	if args.podEnvVar.source == cc.SourceHostPath {
+		setHostPathNodeAffinity(args)
+	}
 
 	pod := makeImporterPodSpec(args)
 
@@ -903,6 +1596,18 @@ func createImporterPod(ctx context.Context, log logr.Logger, client client.Clien
 	// add any labels from pvc to the importer pod
 	util.MergeLabels(args.pvc.Labels, pod.Labels)
 
+	policy, err := cc.GetMetadataPropagationPolicy(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+	cc.PropagateMetadata(policy, args.pvc, pod)
+
+	injection, err := cc.GetTransferPodInjection(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+	cc.InjectTransferPodContainers(injection, &pod.Spec)
+
 	if err = client.Create(context.TODO(), pod); err != nil {
 		return nil, err
 	}
@@ -944,18 +1649,32 @@ func makeImporterPodSpec(args *importerPodArgs) *corev1.Pod {
 			},
 		},
 		Spec: corev1.PodSpec{
-			Containers:        makeImporterContainerSpec(args),
-			InitContainers:    makeImporterInitContainersSpec(args),
-			Volumes:           makeImporterVolumeSpec(args),
-			RestartPolicy:     corev1.RestartPolicyOnFailure,
-			NodeSelector:      args.workloadNodePlacement.NodeSelector,
-			Tolerations:       args.workloadNodePlacement.Tolerations,
-			Affinity:          args.workloadNodePlacement.Affinity,
-			PriorityClassName: args.priorityClassName,
-			ImagePullSecrets:  args.imagePullSecrets,
+			Containers:         makeImporterContainerSpec(args),
+			InitContainers:     makeImporterInitContainersSpec(args),
+			Volumes:            makeImporterVolumeSpec(args),
+			RestartPolicy:      corev1.RestartPolicyOnFailure,
+			NodeSelector:       args.workloadNodePlacement.NodeSelector,
+			Tolerations:        args.workloadNodePlacement.Tolerations,
+			Affinity:           args.workloadNodePlacement.Affinity,
+			PriorityClassName:  args.priorityClassName,
+			ImagePullSecrets:   args.imagePullSecrets,
+			ServiceAccountName: args.serviceAccountName,
 		},
 	}
 
+	// Failures of the pod are only moved to the next fallback source once
+	if index, ok := args.pvc.Annotations[cc.AnnSourceFallbackIndex]; ok {
+		pod.Annotations[cc.AnnSourceFallbackIndex] = index
+	}
+
+	// With a retry policy failed pods are not restarted, the controller retries them once the backoff expires
+	if _, ok := args.pvc.Annotations[cc.AnnRetryPolicy]; ok {
+		pod.Spec.RestartPolicy = corev1.RestartPolicyNever
+		if count, ok := args.pvc.Annotations[cc.AnnImportRetryCount]; ok {
+			pod.Annotations[cc.AnnImportRetryCount] = count
+		}
+	}
+
 	/**
 	FIXME: When registry source is ImageStream, if we set importer pod OwnerReference (to its pvc, like all other cases),
 	for some reason (OCP issue?) we get the following error:
@@ -1057,21 +1776,128 @@ func makeImporterContainerSpec(args *importerPodArgs) []corev1.Container {
 			MountPath: common.ImporterGoogleCredentialDir,
 		})
 	}
-	for index := range args.podEnvVar.secretExtraHeaders {
+	if args.podEnvVar.source == cc.SourceAzure && args.podEnvVar.secretName != "" {
 		containers[0].VolumeMounts = append(containers[0].VolumeMounts, corev1.VolumeMount{
-			Name:      fmt.Sprintf(secretExtraHeadersVolumeName, index),
-			MountPath: path.Join(common.ImporterSecretExtraHeadersDir, fmt.Sprint(index)),
+			Name:      SecretVolName,
+			MountPath: common.ImporterAzureCredentialDir,
 		})
 	}
-	if args.podResourceRequirements != nil {
-		for i := range containers {
-			containers[i].Resources = *args.podResourceRequirements
-		}
+	if args.podEnvVar.source == cc.SourceSFTP && args.podEnvVar.secretName != "" {
+		containers[0].VolumeMounts = append(containers[0].VolumeMounts, corev1.VolumeMount{
+			Name:      SecretVolName,
+			MountPath: common.ImporterSFTPCredentialDir,
+		})
 	}
-	return containers
-}
-
-func makeImporterVolumeSpec(args *importerPodArgs) []corev1.Volume {
+	if args.podEnvVar.source == cc.SourceGlance && args.podEnvVar.secretName != "" {
+		containers[0].VolumeMounts = append(containers[0].VolumeMounts, corev1.VolumeMount{
+			Name:      SecretVolName,
+			MountPath: common.ImporterGlanceCredentialDir,
+		})
+	}
+	if args.podEnvVar.source == cc.SourceRBD && args.podEnvVar.secretName != "" {
+		containers[0].VolumeMounts = append(containers[0].VolumeMounts, corev1.VolumeMount{
+			Name:      SecretVolName,
+			MountPath: common.ImporterRBDCredentialDir,
+		})
+	}
+	if args.podEnvVar.source == cc.SourceExport && args.podEnvVar.secretName != "" {
+		containers[0].VolumeMounts = append(containers[0].VolumeMounts, corev1.VolumeMount{
+			Name:      SecretVolName,
+			MountPath: common.ImporterExportCredentialDir,
+		})
+	}
+	if args.podEnvVar.source == cc.SourceProxmox && args.podEnvVar.secretName != "" {
+		containers[0].VolumeMounts = append(containers[0].VolumeMounts, corev1.VolumeMount{
+			Name:      SecretVolName,
+			MountPath: common.ImporterProxmoxCredentialDir,
+		})
+	}
+	if args.pluginImageName != nil {
+		containers[0].VolumeMounts = append(containers[0].VolumeMounts, corev1.VolumeMount{
+			Name:      pluginSocketVolumeName,
+			MountPath: common.ImporterPluginSocketDir,
+		})
+	}
+	for index := range args.podEnvVar.secretExtraHeaders {
+		containers[0].VolumeMounts = append(containers[0].VolumeMounts, corev1.VolumeMount{
+			Name:      fmt.Sprintf(secretExtraHeadersVolumeName, index),
+			MountPath: path.Join(common.ImporterSecretExtraHeadersDir, fmt.Sprint(index)),
+		})
+	}
+	if args.podEnvVar.oauth2SecretName != "" {
+		containers[0].VolumeMounts = append(containers[0].VolumeMounts, corev1.VolumeMount{
+			Name:      oauth2SecretVolumeName,
+			MountPath: common.ImporterOAuth2CredentialDir,
+		})
+	}
+	if args.podEnvVar.signatureKeyringSecret != "" {
+		containers[0].VolumeMounts = append(containers[0].VolumeMounts, corev1.VolumeMount{
+			Name:      signatureKeyringVolumeName,
+			MountPath: common.ImporterSignatureKeyringDir,
+		})
+	}
+	if args.podEnvVar.clientCertSecret != "" {
+		containers[0].VolumeMounts = append(containers[0].VolumeMounts, corev1.VolumeMount{
+			Name:      clientCertVolumeName,
+			MountPath: common.ImporterClientCertDir,
+		})
+	}
+	if args.podEnvVar.source == cc.SourceHostPath {
+		containers[0].VolumeMounts = append(containers[0].VolumeMounts, corev1.VolumeMount{
+			Name:      hostPathSourceVolumeName,
+			MountPath: path.Join(common.ImporterHostPathDir, path.Base(args.podEnvVar.ep)),
+			ReadOnly:  true,
+		})
+	}
+	if args.podEnvVar.source == cc.SourceSMB {
+		containers[0].VolumeMounts = append(containers[0].VolumeMounts, corev1.VolumeMount{
+			Name:      smbSourceVolumeName,
+			MountPath: common.ImporterSMBDir,
+			ReadOnly:  true,
+		})
+	}
+	if args.podEnvVar.layerCacheHostPath != "" {
+		containers[0].VolumeMounts = append(containers[0].VolumeMounts, corev1.VolumeMount{
+			Name:      layerCacheVolumeName,
+			MountPath: common.ImporterLayerCacheDir,
+		})
+	}
+	if args.podEnvVar.v2vVirtioWinPVC != "" {
+		containers[0].VolumeMounts = append(containers[0].VolumeMounts, corev1.VolumeMount{
+			Name:      virtioWinVolumeName,
+			MountPath: common.ImporterV2VVirtioWinDir,
+			ReadOnly:  true,
+		})
+	}
+	if args.podEnvVar.sourceClaimName != "" {
+		if args.podEnvVar.ep == common.ImporterSourceDataVolumeDevice {
+			containers[0].VolumeDevices = append(containers[0].VolumeDevices, corev1.VolumeDevice{
+				Name:       sourceDataVolumeVolumeName,
+				DevicePath: common.ImporterSourceDataVolumeDevice,
+			})
+		} else {
+			containers[0].VolumeMounts = append(containers[0].VolumeMounts, corev1.VolumeMount{
+				Name:      sourceDataVolumeVolumeName,
+				MountPath: common.ImporterSourceDataVolumeDir,
+				ReadOnly:  true,
+			})
+		}
+	}
+	if args.podEnvVar.encryptionSecret != "" {
+		containers[0].VolumeMounts = append(containers[0].VolumeMounts, corev1.VolumeMount{
+			Name:      encryptionSecretVolumeName,
+			MountPath: common.ImporterEncryptionDir,
+		})
+	}
+	if args.podResourceRequirements != nil {
+		for i := range containers {
+			containers[i].Resources = *args.podResourceRequirements
+		}
+	}
+	return containers
+}
+
+func makeImporterVolumeSpec(args *importerPodArgs) []corev1.Volume {
 	volumes := []corev1.Volume{
 		{
 			Name: cc.DataVolName,
@@ -1126,11 +1952,19 @@ func makeImporterVolumeSpec(args *importerPodArgs) []corev1.Volume {
 		volumes = append(volumes, createConfigMapVolume(CertVolName, args.podEnvVar.certConfigMap))
 	}
 	if args.podEnvVar.certConfigMapProxy != "" {
-		volumes = append(volumes, createConfigMapVolume(ProxyCertVolName, GetImportProxyConfigMapName(args.pvc.Name)))
+		volumes = append(volumes, createConfigMapVolume(ProxyCertVolName, getImportProxyCertConfigMapName(args.pvc, args.podEnvVar)))
 	}
-	if args.podEnvVar.source == cc.SourceGCS && args.podEnvVar.secretName != "" {
+	if (args.podEnvVar.source == cc.SourceGCS || args.podEnvVar.source == cc.SourceAzure || args.podEnvVar.source == cc.SourceSFTP || args.podEnvVar.source == cc.SourceGlance || args.podEnvVar.source == cc.SourceRBD || args.podEnvVar.source == cc.SourceExport || args.podEnvVar.source == cc.SourceProxmox || args.podEnvVar.source == cc.SourcePlugin) && args.podEnvVar.secretName != "" {
 		volumes = append(volumes, createSecretVolume(SecretVolName, args.podEnvVar.secretName))
 	}
+	if args.pluginImageName != nil {
+		volumes = append(volumes, corev1.Volume{
+			Name: pluginSocketVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			},
+		})
+	}
 	for index, header := range args.podEnvVar.secretExtraHeaders {
 		volumes = append(volumes, corev1.Volume{
 			Name: fmt.Sprintf(secretExtraHeadersVolumeName, index),
@@ -1141,6 +1975,70 @@ func makeImporterVolumeSpec(args *importerPodArgs) []corev1.Volume {
 			},
 		})
 	}
+	if args.podEnvVar.oauth2SecretName != "" {
+		volumes = append(volumes, createSecretVolume(oauth2SecretVolumeName, args.podEnvVar.oauth2SecretName))
+	}
+	if args.podEnvVar.signatureKeyringSecret != "" {
+		volumes = append(volumes, createSecretVolume(signatureKeyringVolumeName, args.podEnvVar.signatureKeyringSecret))
+	}
+	if args.podEnvVar.clientCertSecret != "" {
+		volumes = append(volumes, createSecretVolume(clientCertVolumeName, args.podEnvVar.clientCertSecret))
+	}
+	if args.podEnvVar.source == cc.SourceHostPath {
+		volumes = append(volumes, corev1.Volume{
+			Name: hostPathSourceVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				HostPath: &corev1.HostPathVolumeSource{
+					Path: args.podEnvVar.ep,
+					Type: ptr.To(corev1.HostPathFile),
+				},
+			},
+		})
+	}
+	if args.podEnvVar.source == cc.SourceSMB {
+		volumes = append(volumes, corev1.Volume{
+			Name: smbSourceVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				CSI: makeSMBShareVolumeSource(args.podEnvVar.ep, args.podEnvVar.secretName),
+			},
+		})
+	}
+	if args.podEnvVar.layerCacheHostPath != "" {
+		volumes = append(volumes, corev1.Volume{
+			Name: layerCacheVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				HostPath: &corev1.HostPathVolumeSource{
+					Path: args.podEnvVar.layerCacheHostPath,
+					Type: ptr.To(corev1.HostPathDirectoryOrCreate),
+				},
+			},
+		})
+	}
+	if args.podEnvVar.v2vVirtioWinPVC != "" {
+		volumes = append(volumes, corev1.Volume{
+			Name: virtioWinVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: args.podEnvVar.v2vVirtioWinPVC,
+					ReadOnly:  true,
+				},
+			},
+		})
+	}
+	if args.podEnvVar.sourceClaimName != "" {
+		volumes = append(volumes, corev1.Volume{
+			Name: sourceDataVolumeVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: args.podEnvVar.sourceClaimName,
+					ReadOnly:  true,
+				},
+			},
+		})
+	}
+	if args.podEnvVar.encryptionSecret != "" {
+		volumes = append(volumes, createSecretVolume(encryptionSecretVolumeName, args.podEnvVar.encryptionSecret))
+	}
 	return volumes
 }
 
@@ -1174,6 +2072,9 @@ func makeImporterInitContainersSpec(args *importerPodArgs) []corev1.Container {
 			TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
 		})
 	}
+	if args.pluginImageName != nil {
+		initContainers = append(initContainers, makeSourcePluginContainerSpec(args))
+	}
 	if args.podResourceRequirements != nil {
 		for i := range initContainers {
 			initContainers[i].Resources = *args.podResourceRequirements
@@ -1182,11 +2083,62 @@ func makeImporterInitContainersSpec(args *importerPodArgs) []corev1.Container {
 	return initContainers
 }
 
+// makeSourcePluginContainerSpec returns the source plugin container, run as a sidecar so it serves the importer
+// until the import is done and does not keep the pod from completing
+func makeSourcePluginContainerSpec(args *importerPodArgs) corev1.Container {
+	container := corev1.Container{
+		Name:          sourcePluginContainerName,
+		Image:         *args.pluginImageName,
+		RestartPolicy: ptr.To(corev1.ContainerRestartPolicyAlways),
+		Env: []corev1.EnvVar{
+			{
+				Name:  common.PluginSocketVar,
+				Value: common.ImporterPluginSocket,
+			},
+		},
+		VolumeMounts: []corev1.VolumeMount{
+			{
+				Name:      pluginSocketVolumeName,
+				MountPath: common.ImporterPluginSocketDir,
+			},
+		},
+		TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+	}
+	if args.podEnvVar.secretName != "" {
+		container.Env = append(container.Env, corev1.EnvVar{
+			Name:  common.PluginSecretDirVar,
+			Value: common.ImporterPluginSecretDir,
+		})
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+			Name:      SecretVolName,
+			MountPath: common.ImporterPluginSecretDir,
+		})
+	}
+	return container
+}
+
 func isRegistryNodeImport(args *importerPodArgs) bool {
 	return cc.GetSource(args.pvc) == cc.SourceRegistry &&
 		args.pvc.Annotations[cc.AnnRegistryImportMethod] == string(cdiv1.RegistryPullNode)
 }
 
+// getNodeImportDigest returns the digest the registry image of a node import resolved to, taken from the image
+// the kubelet pulled to run the server container
+func getNodeImportDigest(pvc *corev1.PersistentVolumeClaim, pod *corev1.Pod) string {
+	if cc.GetSource(pvc) != cc.SourceRegistry || pvc.Annotations[cc.AnnRegistryImportMethod] != string(cdiv1.RegistryPullNode) {
+		return ""
+	}
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name != "server" {
+			continue
+		}
+		if idx := strings.Index(status.ImageID, digestSha256Prefix); idx >= 0 {
+			return status.ImageID[idx:]
+		}
+	}
+	return ""
+}
+
 func getOwnerUID(args *importerPodArgs) types.UID {
 	if len(args.pvc.OwnerReferences) == 1 {
 		return args.pvc.OwnerReferences[0].UID
@@ -1209,6 +2161,106 @@ func setRegistryNodeImportNodeSelector(args *importerPodArgs) {
 	args.workloadNodePlacement.NodeSelector[v1.LabelArchStable] = args.podEnvVar.registryImageArchitecture
 }
 
+// applyImporterPodOverrides replaces the CDI-wide settings of the importer pod with the ones the DataVolume overrides
+func applyImporterPodOverrides(args *importerPodArgs) error {
+	value, ok := args.pvc.Annotations[cc.AnnPodOverrides]
+	if !ok {
+		return nil
+	}
+	overrides := &cdiv1.DataVolumePodOverrides{}
+	if err := json.Unmarshal([]byte(value), overrides); err != nil {
+		return errors.Wrapf(err, "invalid %s annotation", cc.AnnPodOverrides)
+	}
+	if overrides.Resources != nil {
+		args.podResourceRequirements = overrides.Resources
+	}
+	if overrides.NodeSelector != nil {
+		args.workloadNodePlacement.NodeSelector = overrides.NodeSelector
+	}
+	if overrides.Tolerations != nil {
+		args.workloadNodePlacement.Tolerations = overrides.Tolerations
+	}
+	if overrides.Affinity != nil {
+		args.workloadNodePlacement.Affinity = overrides.Affinity
+	}
+	return nil
+}
+
+// applyTopologyPlacement restricts the workload placement of the importer pod to the topology domain of the consumer of the PVC
+func applyTopologyPlacement(args *importerPodArgs) error {
+	value, ok := args.pvc.Annotations[cc.AnnTopologyPlacement]
+	if !ok {
+		return nil
+	}
+	topology := &cc.TopologyPlacement{}
+	if err := json.Unmarshal([]byte(value), topology); err != nil {
+		return errors.Wrapf(err, "invalid %s annotation", cc.AnnTopologyPlacement)
+	}
+	placement := args.workloadNodePlacement
+	if len(topology.NodeSelector) > 0 && placement.NodeSelector == nil {
+		placement.NodeSelector = make(map[string]string, len(topology.NodeSelector))
+	}
+	for key, value := range topology.NodeSelector {
+		placement.NodeSelector[key] = value
+	}
+	if topology.NodeAffinity == nil || len(topology.NodeAffinity.NodeSelectorTerms) == 0 {
+		return nil
+	}
+	if placement.Affinity == nil {
+		placement.Affinity = &corev1.Affinity{}
+	}
+	if placement.Affinity.NodeAffinity == nil {
+		placement.Affinity.NodeAffinity = &corev1.NodeAffinity{}
+	}
+	required := placement.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution
+	if required == nil || len(required.NodeSelectorTerms) == 0 {
+		placement.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution = topology.NodeAffinity.DeepCopy()
+		return nil
+	}
+	required.NodeSelectorTerms = andNodeSelectorTerms(required.NodeSelectorTerms, topology.NodeAffinity.NodeSelectorTerms)
+	return nil
+}
+
+// andNodeSelectorTerms returns the terms matching the nodes matched by both ORed lists of terms
+func andNodeSelectorTerms(terms, others []corev1.NodeSelectorTerm) []corev1.NodeSelectorTerm {
+	result := make([]corev1.NodeSelectorTerm, 0, len(terms)*len(others))
+	for _, term := range terms {
+		for _, other := range others {
+			and := *term.DeepCopy()
+			and.MatchExpressions = append(and.MatchExpressions, other.MatchExpressions...)
+			and.MatchFields = append(and.MatchFields, other.MatchFields...)
+			result = append(result, and)
+		}
+	}
+	return result
+}
+
+// setHostPathNodeAffinity requires the node holding the file of a hostpath source on top of the workload placement.
+// The terms of the placement are ORed, so the node is added to each of them.
+func setHostPathNodeAffinity(args *importerPodArgs) {
+	placement := args.workloadNodePlacement
+	if placement.Affinity == nil {
+		placement.Affinity = &corev1.Affinity{}
+	}
+	if placement.Affinity.NodeAffinity == nil {
+		placement.Affinity.NodeAffinity = &corev1.NodeAffinity{}
+	}
+	if placement.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		placement.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution = &corev1.NodeSelector{}
+	}
+	required := placement.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution
+	if len(required.NodeSelectorTerms) == 0 {
+		required.NodeSelectorTerms = []corev1.NodeSelectorTerm{{}}
+	}
+	for i := range required.NodeSelectorTerms {
+		required.NodeSelectorTerms[i].MatchFields = append(required.NodeSelectorTerms[i].MatchFields, corev1.NodeSelectorRequirement{
+			Key:      "metadata.name",
+			Operator: corev1.NodeSelectorOpIn,
+			Values:   []string{args.podEnvVar.hostPathNode},
+		})
+	}
+}
+
 func createConfigMapVolume(certVolName, objRef string) corev1.Volume {
 	return corev1.Volume{
 		Name: certVolName,
@@ -1233,6 +2285,40 @@ func createSecretVolume(thisVolName, objRef string) corev1.Volume {
 	}
 }
 
+// makeSMBShareVolumeSource returns the inline volume of the SMB CSI driver mounting the share of an smb:// url with the
+// kernel SMB client, which speaks SMB 2 and 3 and follows DFS referrals. smbs:// urls require the encryption of SMB 3.
+// The secret holds the username, password and domain keys of the driver, the share is mounted as guest without it.
+func makeSMBShareVolumeSource(endpoint, secretName string) *corev1.CSIVolumeSource {
+	var source string
+	var mountOptions []string
+	if u, err := url.Parse(endpoint); err == nil {
+		share := strings.SplitN(strings.Trim(u.Path, "/"), "/", 2)[0]
+		source = fmt.Sprintf("//%s/%s", u.Hostname(), share)
+		if u.Port() != "" {
+			mountOptions = append(mountOptions, "port="+u.Port())
+		}
+		if u.Scheme == "smbs" {
+			mountOptions = append(mountOptions, "seal")
+		}
+	}
+	volumeSource := &corev1.CSIVolumeSource{
+		Driver:   cc.SMBCSIDriver,
+		ReadOnly: ptr.To(true),
+		VolumeAttributes: map[string]string{
+			"source": source,
+		},
+	}
+	if secretName != "" {
+		volumeSource.NodePublishSecretRef = &corev1.LocalObjectReference{Name: secretName}
+	} else {
+		mountOptions = append(mountOptions, "guest")
+	}
+	if len(mountOptions) > 0 {
+		volumeSource.VolumeAttributes["mountOptions"] = strings.Join(mountOptions, ",")
+	}
+	return volumeSource
+}
+
 // return the Env portion for the importer container.
 func makeImportEnv(podEnvVar *importPodEnvVar, uid types.UID) []corev1.EnvVar {
 	env := []corev1.EnvVar{
@@ -1329,7 +2415,7 @@ func makeImportEnv(podEnvVar *importPodEnvVar, uid types.UID) []corev1.EnvVar {
 			Value: podEnvVar.registryImageArchitecture,
 		},
 	}
-	if podEnvVar.secretName != "" && podEnvVar.source != cc.SourceGCS {
+	if podEnvVar.secretName != "" && podEnvVar.source != cc.SourceGCS && podEnvVar.source != cc.SourceAzure && podEnvVar.source != cc.SourceS3 && podEnvVar.source != cc.SourceSFTP && podEnvVar.source != cc.SourceSMB && podEnvVar.source != cc.SourceGlance && podEnvVar.source != cc.SourceRBD && podEnvVar.source != cc.SourceExport && podEnvVar.source != cc.SourceProxmox && podEnvVar.source != cc.SourcePlugin {
 		env = append(env, corev1.EnvVar{
 			Name: common.ImporterAccessKeyID,
 			ValueFrom: &corev1.EnvVarSource{
@@ -1352,12 +2438,112 @@ func makeImportEnv(podEnvVar *importPodEnvVar, uid types.UID) []corev1.EnvVar {
 			},
 		})
 	}
+	if podEnvVar.secretName != "" && podEnvVar.source == cc.SourceS3 {
+		env = append(env, cc.MakeS3SecretEnv(podEnvVar.secretName)...)
+	}
 	if podEnvVar.secretName != "" && podEnvVar.source == cc.SourceGCS {
 		env = append(env, corev1.EnvVar{
 			Name:  common.ImporterGoogleCredentialFileVar,
 			Value: common.ImporterGoogleCredentialFile,
 		})
 	}
+	if podEnvVar.secretName != "" && podEnvVar.source == cc.SourceAzure {
+		env = append(env, corev1.EnvVar{
+			Name:  common.ImporterAzureCredentialDirVar,
+			Value: common.ImporterAzureCredentialDir,
+		})
+	}
+	if podEnvVar.secretName != "" && podEnvVar.source == cc.SourceSFTP {
+		env = append(env, corev1.EnvVar{
+			Name:  common.ImporterSFTPCredentialDirVar,
+			Value: common.ImporterSFTPCredentialDir,
+		})
+	}
+	if podEnvVar.secretName != "" && podEnvVar.source == cc.SourceGlance {
+		env = append(env, corev1.EnvVar{
+			Name:  common.ImporterGlanceCredentialDirVar,
+			Value: common.ImporterGlanceCredentialDir,
+		})
+	}
+	if podEnvVar.glanceImage != "" {
+		env = append(env, corev1.EnvVar{
+			Name:  common.ImporterGlanceImage,
+			Value: podEnvVar.glanceImage,
+		})
+	}
+	if podEnvVar.secretName != "" && podEnvVar.source == cc.SourceRBD {
+		env = append(env, corev1.EnvVar{
+			Name:  common.ImporterRBDCredentialDirVar,
+			Value: common.ImporterRBDCredentialDir,
+		})
+	}
+	if podEnvVar.rbdImage != "" {
+		env = append(env, corev1.EnvVar{
+			Name:  common.ImporterRBDImage,
+			Value: podEnvVar.rbdImage,
+		})
+	}
+	if podEnvVar.secretName != "" && podEnvVar.source == cc.SourceExport {
+		env = append(env, corev1.EnvVar{
+			Name:  common.ImporterExportCredentialDirVar,
+			Value: common.ImporterExportCredentialDir,
+		})
+	}
+	if podEnvVar.source == cc.SourceV2V {
+		env = append(env, corev1.EnvVar{
+			Name:  common.ImporterV2VVM,
+			Value: podEnvVar.v2vVM,
+		}, corev1.EnvVar{
+			Name:  common.ImporterV2VDiskIndex,
+			Value: podEnvVar.v2vDiskIndex,
+		}, corev1.EnvVar{
+			Name:  common.ImporterV2VBlockDriver,
+			Value: podEnvVar.v2vBlockDriver,
+		}, corev1.EnvVar{
+			Name:  common.ImporterV2VMACMappings,
+			Value: podEnvVar.v2vMACMappings,
+		})
+	}
+	if podEnvVar.v2vVirtioWinPVC != "" {
+		// DataVolumes store the image of filesystem PVCs in disk.img
+		env = append(env, corev1.EnvVar{
+			Name:  common.ImporterV2VVirtioWin,
+			Value: path.Join(common.ImporterV2VVirtioWinDir, common.DiskImageName),
+		})
+	}
+	if podEnvVar.source == cc.SourceProxmox {
+		if podEnvVar.secretName != "" {
+			env = append(env, corev1.EnvVar{
+				Name:  common.ImporterProxmoxCredentialDirVar,
+				Value: common.ImporterProxmoxCredentialDir,
+			})
+		}
+		env = append(env, corev1.EnvVar{
+			Name:  common.ImporterProxmoxNode,
+			Value: podEnvVar.proxmoxNode,
+		}, corev1.EnvVar{
+			Name:  common.ImporterProxmoxVMID,
+			Value: podEnvVar.proxmoxVMID,
+		}, corev1.EnvVar{
+			Name:  common.ImporterProxmoxDisk,
+			Value: podEnvVar.proxmoxDisk,
+		})
+	}
+	if podEnvVar.source == cc.SourcePlugin {
+		env = append(env, corev1.EnvVar{
+			Name:  common.ImporterPluginParameters,
+			Value: podEnvVar.pluginParameters,
+		})
+	}
+	if podEnvVar.source == cc.SourceTorrent {
+		env = append(env, corev1.EnvVar{
+			Name:  common.ImporterTorrentWebSeeds,
+			Value: podEnvVar.torrentWebSeeds,
+		}, corev1.EnvVar{
+			Name:  common.ImporterTorrentFile,
+			Value: podEnvVar.torrentFile,
+		})
+	}
 	if podEnvVar.certConfigMap != "" {
 		env = append(env, corev1.EnvVar{
 			Name:  common.ImporterCertDirVar,
@@ -1370,6 +2556,163 @@ func makeImportEnv(podEnvVar *importPodEnvVar, uid types.UID) []corev1.EnvVar {
 			Value: common.ImporterProxyCertDir,
 		})
 	}
+	if podEnvVar.detectContentType {
+		env = append(env, corev1.EnvVar{
+			Name:  common.ImporterDetectContentType,
+			Value: strconv.FormatBool(podEnvVar.detectContentType),
+		})
+	}
+	if podEnvVar.blankZeroEdges {
+		env = append(env, corev1.EnvVar{
+			Name:  common.ImporterBlankZeroEdges,
+			Value: strconv.FormatBool(podEnvVar.blankZeroEdges),
+		})
+	}
+	if podEnvVar.sparseThreshold != "" {
+		env = append(env, corev1.EnvVar{
+			Name:  common.ImporterSparseThreshold,
+			Value: podEnvVar.sparseThreshold,
+		})
+	}
+	if podEnvVar.coroutines != "" {
+		env = append(env, corev1.EnvVar{
+			Name:  common.ImporterCoroutines,
+			Value: podEnvVar.coroutines,
+		})
+	}
+	if podEnvVar.preallocationMethod != "" {
+		env = append(env, corev1.EnvVar{
+			Name:  common.ImporterPreallocationMethod,
+			Value: podEnvVar.preallocationMethod,
+		})
+	}
+	if podEnvVar.expandFilesystem {
+		env = append(env, corev1.EnvVar{
+			Name:  common.ImporterExpandFilesystem,
+			Value: strconv.FormatBool(podEnvVar.expandFilesystem),
+		})
+	}
+	if podEnvVar.verify {
+		env = append(env, corev1.EnvVar{
+			Name:  common.ImporterVerify,
+			Value: strconv.FormatBool(podEnvVar.verify),
+		})
+	}
+	if podEnvVar.verifyChecksum {
+		env = append(env, corev1.EnvVar{
+			Name:  common.ImporterVerifyChecksum,
+			Value: strconv.FormatBool(podEnvVar.verifyChecksum),
+		})
+	}
+	if podEnvVar.sparsify {
+		env = append(env, corev1.EnvVar{
+			Name:  common.ImporterSparsify,
+			Value: strconv.FormatBool(podEnvVar.sparsify),
+		})
+	}
+	if podEnvVar.expandFilesystem || podEnvVar.sparsify {
+		// libguestfs runs the appliance directly in the importer pod, without libvirt
+		env = append(env, corev1.EnvVar{
+			Name:  "LIBGUESTFS_BACKEND",
+			Value: "direct",
+		})
+	}
+	if podEnvVar.encryptionSecret != "" {
+		env = append(env, corev1.EnvVar{
+			Name:  common.ImporterEncryptionPassphraseFile,
+			Value: path.Join(common.ImporterEncryptionDir, common.KeyEncryptionPassphrase),
+		})
+	}
+	if podEnvVar.downloadParallelism != "" {
+		env = append(env, corev1.EnvVar{
+			Name:  common.ImporterDownloadParallelism,
+			Value: podEnvVar.downloadParallelism,
+		}, corev1.EnvVar{
+			// The parts downloaded in parallel are buffered, the importer keeps them within its memory limit
+			Name: common.ImporterMemoryLimit,
+			ValueFrom: &corev1.EnvVarSource{
+				ResourceFieldRef: &corev1.ResourceFieldSelector{Resource: "limits.memory"},
+			},
+		})
+	}
+	if podEnvVar.downloadPartSize != "" {
+		env = append(env, corev1.EnvVar{
+			Name:  common.ImporterDownloadPartSize,
+			Value: podEnvVar.downloadPartSize,
+		})
+	}
+	if podEnvVar.curlConnections != "" {
+		env = append(env, corev1.EnvVar{
+			Name:  common.ImporterCurlConnections,
+			Value: podEnvVar.curlConnections,
+		})
+	}
+	if podEnvVar.curlHTTPVersion != "" {
+		env = append(env, corev1.EnvVar{
+			Name:  common.ImporterCurlHTTPVersion,
+			Value: podEnvVar.curlHTTPVersion,
+		})
+	}
+	if podEnvVar.curlTLS13Ciphers != "" {
+		env = append(env, corev1.EnvVar{
+			Name:  common.ImporterCurlTLS13Ciphers,
+			Value: podEnvVar.curlTLS13Ciphers,
+		})
+	}
+	if podEnvVar.curlTimeout != "" {
+		env = append(env, corev1.EnvVar{
+			Name:  common.ImporterCurlTimeout,
+			Value: podEnvVar.curlTimeout,
+		})
+	}
+	if podEnvVar.ovaDisk != "" {
+		env = append(env, corev1.EnvVar{
+			Name:  common.ImporterOVADisk,
+			Value: podEnvVar.ovaDisk,
+		})
+	}
+	if podEnvVar.xvaDisk != "" {
+		env = append(env, corev1.EnvVar{
+			Name:  common.ImporterXVADisk,
+			Value: podEnvVar.xvaDisk,
+		})
+	}
+	if podEnvVar.checksum != "" {
+		env = append(env, corev1.EnvVar{
+			Name:  common.ImporterChecksum,
+			Value: podEnvVar.checksum,
+		})
+	}
+	if podEnvVar.signatureURL != "" {
+		env = append(env, corev1.EnvVar{
+			Name:  common.ImporterSignatureURL,
+			Value: podEnvVar.signatureURL,
+		})
+	}
+	if podEnvVar.imageVerificationPolicies != "" {
+		env = append(env, corev1.EnvVar{
+			Name:  common.ImporterImageVerificationPolicies,
+			Value: podEnvVar.imageVerificationPolicies,
+		})
+	}
+	if podEnvVar.maxBandwidth != "" {
+		env = append(env, corev1.EnvVar{
+			Name:  common.ImporterMaxBandwidth,
+			Value: podEnvVar.maxBandwidth,
+		})
+	}
+	if podEnvVar.storageFormat != "" {
+		env = append(env, corev1.EnvVar{
+			Name:  common.ImporterStorageFormat,
+			Value: podEnvVar.storageFormat,
+		})
+	}
+	if podEnvVar.layerCacheHostPath != "" {
+		env = append(env, corev1.EnvVar{
+			Name:  common.ImporterLayerCacheMaxSize,
+			Value: podEnvVar.layerCacheMaxSize,
+		})
+	}
 	for index, header := range podEnvVar.extraHeaders {
 		env = append(env, corev1.EnvVar{
 			Name:  fmt.Sprintf("%s%d", common.ImporterExtraHeader, index),
@@ -1379,6 +2722,150 @@ func makeImportEnv(podEnvVar *importPodEnvVar, uid types.UID) []corev1.EnvVar {
 	return env
 }
 
+// isImportFailure returns true if the importer exited with an error, restarting or not
+func isImportFailure(status v1.ContainerStatus) bool {
+	for _, terminated := range []*v1.ContainerStateTerminated{status.State.Terminated, status.LastTerminationState.Terminated} {
+		if terminated != nil && terminated.ExitCode > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// useNextSourceFallback moves the source annotations to the next fallback source of the import, returns false once
+// the import has no fallback left
+func useNextSourceFallback(anno map[string]string) (bool, error) {
+	value, ok := anno[cc.AnnSourceFallbacks]
+	if !ok {
+		return false, nil
+	}
+	var fallbacks []cdiv1.DataVolumeSourceFallback
+	if err := json.Unmarshal([]byte(value), &fallbacks); err != nil {
+		return false, err
+	}
+	index := 0
+	if current, ok := anno[cc.AnnSourceFallbackIndex]; ok {
+		var err error
+		if index, err = strconv.Atoi(current); err != nil {
+			return false, err
+		}
+	}
+	if index >= len(fallbacks) {
+		return false, nil
+	}
+	cc.UpdateSourceFallbackAnnotations(anno, &fallbacks[index])
+	anno[cc.AnnSourceFallbackIndex] = strconv.Itoa(index + 1)
+	return true, nil
+}
+
+// scheduleImportRetry decides whether the failed import is retried following its retry policy, returns whether a retry
+// is pending and whether it is due, in which case the retry count is incremented
+func scheduleImportRetry(anno map[string]string, pod *corev1.Pod, now time.Time) (bool, bool, error) {
+	value, ok := anno[cc.AnnRetryPolicy]
+	if !ok {
+		return false, false, nil
+	}
+	policy := &cdiv1.DataVolumeRetryPolicy{}
+	if err := json.Unmarshal([]byte(value), policy); err != nil {
+		return false, false, err
+	}
+	count := 0
+	if current, ok := anno[cc.AnnImportRetryCount]; ok {
+		var err error
+		if count, err = strconv.Atoi(current); err != nil {
+			return false, false, err
+		}
+	}
+	if policy.MaxRetries != nil && count >= int(*policy.MaxRetries) {
+		return false, false, nil
+	}
+	var terminated *corev1.ContainerStateTerminated
+	if statuses := pod.Status.ContainerStatuses; len(statuses) > 0 {
+		terminated = statuses[0].State.Terminated
+	}
+	if !retriesOn(policy, classifyImportError(terminated)) {
+		return false, false, nil
+	}
+
+	next, err := time.Parse(time.RFC3339, anno[cc.AnnImportNextRetryTime])
+	if err != nil {
+		finished := now
+		if terminated != nil && !terminated.FinishedAt.IsZero() {
+			finished = terminated.FinishedAt.Time
+		}
+		next = finished.Add(importRetryBackoff(policy, count))
+		anno[cc.AnnImportNextRetryTime] = next.UTC().Format(time.RFC3339)
+	}
+	if now.Before(next) {
+		return true, false, nil
+	}
+	anno[cc.AnnImportRetryCount] = strconv.Itoa(count + 1)
+	delete(anno, cc.AnnImportNextRetryTime)
+	return true, true, nil
+}
+
+// importRetryBackoff returns the delay before the given retry, doubling the base delay for each retry up to the cap
+func importRetryBackoff(policy *cdiv1.DataVolumeRetryPolicy, count int) time.Duration {
+	base, limit := 10*time.Second, 5*time.Minute
+	if policy.BackoffBase != nil {
+		base = policy.BackoffBase.Duration
+	}
+	if policy.BackoffCap != nil {
+		limit = policy.BackoffCap.Duration
+	}
+	backoff := base
+	for i := 0; i < count && backoff < limit; i++ {
+		backoff *= 2
+	}
+	if backoff > limit {
+		return limit
+	}
+	return backoff
+}
+
+func retriesOn(policy *cdiv1.DataVolumeRetryPolicy, class cdiv1.DataVolumeRetryErrorClass) bool {
+	if len(policy.RetryOn) == 0 {
+		return true
+	}
+	for _, c := range policy.RetryOn {
+		if c == class {
+			return true
+		}
+	}
+	return false
+}
+
+// failureReasonClasses are the classes of the failures the importer reported the reason of, but not the class. Older
+// importers do not report the class, unreachable sources are then retried as network errors.
+var failureReasonClasses = map[string]cdiv1.DataVolumeRetryErrorClass{
+	cdiv1.DataVolumeReasonInsufficientSpace:  cdiv1.RetryOnStorage,
+	cdiv1.DataVolumeReasonQuotaExceeded:      cdiv1.RetryOnStorage,
+	cdiv1.DataVolumeReasonVerificationFailed: cdiv1.RetryOnStorage,
+	cdiv1.DataVolumeReasonThrottled:          cdiv1.RetryOnNetwork,
+	cdiv1.DataVolumeReasonSourceUnreachable:  cdiv1.RetryOnNetwork,
+	cdiv1.DataVolumeReasonAuthFailed:         cdiv1.RetryOnSource,
+	cdiv1.DataVolumeReasonCorrupt:            cdiv1.RetryOnSource,
+}
+
+// classifyImportError sorts the error the importer terminated with into the classes a retry policy retries on, from
+// the class or the reason the importer reported in its termination message. Failures the importer did not classify
+// are other errors.
+func classifyImportError(terminated *corev1.ContainerStateTerminated) cdiv1.DataVolumeRetryErrorClass {
+	termMsg, _ := parseTerminatedMessage(terminated)
+	if termMsg == nil {
+		return cdiv1.RetryOnOther
+	}
+	if termMsg.FailureClass != nil {
+		return cdiv1.DataVolumeRetryErrorClass(*termMsg.FailureClass)
+	}
+	if termMsg.FailureReason != nil {
+		if class, ok := failureReasonClasses[*termMsg.FailureReason]; ok {
+			return class
+		}
+	}
+	return cdiv1.RetryOnOther
+}
+
 func isOOMKilled(status v1.ContainerStatus) bool {
 	if terminated := status.State.Terminated; terminated != nil {
 		if terminated.Reason == cc.OOMKilledReason {
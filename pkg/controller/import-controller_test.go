@@ -22,6 +22,7 @@ import (
 	"reflect"
 	"strconv"
 	"strings"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -29,13 +30,16 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	kvalidation "k8s.io/apimachinery/pkg/util/validation"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/record"
 	bootstrapapi "k8s.io/cluster-bootstrap/token/api"
+	"k8s.io/utils/ptr"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -494,6 +498,41 @@ var _ = Describe("Update PVC from POD", func() {
 		Entry("should not", v1.PodFailed, false),
 	)
 
+	It("Should record the preallocation method of a completed import on the StorageProfile", func() {
+		termMsgBytes, err := json.Marshal(common.TerminationMessage{
+			PreallocationApplied: ptr.To(true),
+			PreallocationMethod:  ptr.To(common.PreallocationMethodFull),
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		pvc := cc.CreatePvcInStorageClass("testPvc1", "default", &testStorageClass, map[string]string{cc.AnnPreallocationRequested: "true"}, nil, corev1.ClaimBound)
+		pod := cc.CreateImporterTestPod(pvc, "testPvc1", nil)
+		pod.Status = corev1.PodStatus{
+			Phase: corev1.PodSucceeded,
+			ContainerStatuses: []v1.ContainerStatus{
+				{
+					State: v1.ContainerState{
+						Terminated: &v1.ContainerStateTerminated{
+							Message: string(termMsgBytes),
+						},
+					},
+				},
+			},
+		}
+		storageProfile := &cdiv1.StorageProfile{ObjectMeta: metav1.ObjectMeta{Name: testStorageClass}}
+		reconciler = createImportReconciler(pvc, pod, cc.CreateStorageClass(testStorageClass, nil), storageProfile)
+		Expect(reconciler.updatePvcFromPod(pvc, pod, reconciler.log)).To(Succeed())
+
+		Expect(reconciler.client.Get(context.TODO(), types.NamespacedName{Name: testStorageClass}, storageProfile)).To(Succeed())
+		Expect(storageProfile.Annotations).To(HaveKeyWithValue(cc.AnnPreallocationMethod, common.PreallocationMethodFull))
+
+		By("Passing the recorded method to the next preallocated import to the storage class")
+		next := cc.CreatePvcInStorageClass("testPvc2", "default", &testStorageClass, map[string]string{cc.AnnEndpoint: testEndPoint, cc.AnnPreallocationRequested: "true"}, nil, corev1.ClaimBound)
+		podEnvVar, err := reconciler.createImportEnvVar(next)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(makeImportEnv(podEnvVar, "1111-1111-1111-1111")).To(ContainElement(corev1.EnvVar{Name: common.ImporterPreallocationMethod, Value: common.PreallocationMethodFull}))
+	})
+
 	It("Should update the PVC status to running, if pod is running", func() {
 		pvc := cc.CreatePvc("testPvc1", "default", map[string]string{cc.AnnEndpoint: testEndPoint, cc.AnnPodPhase: string(corev1.PodPending)}, nil)
 		pod := cc.CreateImporterTestPod(pvc, "testPvc1", nil)
@@ -679,6 +718,125 @@ var _ = Describe("Update PVC from POD", func() {
 		Expect(reconciler.createImporterPod(pvc)).To(Succeed())
 	})
 
+	It("Should mark PVC as waiting for its source plugin, if not registered", func() {
+		pvc := cc.CreatePvcInStorageClass("testPvc1", "default", &testStorageClass, map[string]string{cc.AnnEndpoint: "tape", cc.AnnImportPod: "testpod", cc.AnnSource: cc.SourcePlugin}, nil, corev1.ClaimPending)
+		reconciler = createImportReconciler(pvc)
+		err := reconciler.createImporterPod(pvc)
+		By("Checking importer pod creation returned an error")
+		Expect(err).To(HaveOccurred())
+		By("Checking pvc annotations have been updated")
+		resPvc := &corev1.PersistentVolumeClaim{}
+		err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "testPvc1", Namespace: "default"}, resPvc)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(resPvc.GetAnnotations()[cc.AnnBoundCondition]).To(Equal("false"))
+		Expect(resPvc.GetAnnotations()[cc.AnnBoundConditionReason]).To(Equal(common.AwaitingSourcePlugin))
+
+		By("Checking again after registering the plugin")
+		configmap := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      common.SourcePluginConfigMap,
+				Namespace: "cdi",
+			},
+			Data: map[string]string{
+				"tape": "quay.io/example/tape-plugin:v1",
+			},
+		}
+		Expect(reconciler.client.Create(context.TODO(), configmap)).To(Succeed())
+		Expect(reconciler.createImporterPod(pvc)).To(Succeed())
+	})
+
+	It("Should propagate the labels and annotations allowed by the CDIConfig to the importer pod", func() {
+		pvc := cc.CreatePvcInStorageClass("testPvc1", "default", &testStorageClass,
+			map[string]string{cc.AnnEndpoint: testEndPoint, cc.AnnImportPod: "testpod", cc.AnnSource: cc.SourceHTTP, "traffic.sidecar.istio.io/excludeOutboundPorts": "443", "owner": "team-a"},
+			map[string]string{"cost.example.com/center": "42"}, corev1.ClaimBound)
+		reconciler = createImportReconciler(pvc)
+		config := &cdiv1.CDIConfig{}
+		Expect(reconciler.client.Get(context.TODO(), types.NamespacedName{Name: common.ConfigName}, config)).To(Succeed())
+		config.Spec.MetadataPropagation = &cdiv1.MetadataPropagationPolicy{
+			Labels:      []string{"cost.example.com/*"},
+			Annotations: []string{"traffic.sidecar.istio.io/excludeOutboundPorts"},
+		}
+		Expect(reconciler.client.Update(context.TODO(), config)).To(Succeed())
+		Expect(reconciler.createImporterPod(pvc)).To(Succeed())
+
+		pod := &corev1.Pod{}
+		Expect(reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "testpod", Namespace: "default"}, pod)).To(Succeed())
+		Expect(pod.Labels).To(HaveKeyWithValue("cost.example.com/center", "42"))
+		Expect(pod.Annotations).To(HaveKeyWithValue("traffic.sidecar.istio.io/excludeOutboundPorts", "443"))
+		Expect(pod.Annotations).ToNot(HaveKey("owner"))
+		Expect(pod.Annotations).ToNot(HaveKey(cc.AnnEndpoint))
+	})
+
+	It("Should inject the containers of the CDIConfig in the importer pod", func() {
+		pvc := cc.CreatePvcInStorageClass("testPvc1", "default", &testStorageClass,
+			map[string]string{cc.AnnEndpoint: testEndPoint, cc.AnnImportPod: "testpod", cc.AnnSource: cc.SourceHTTP}, nil, corev1.ClaimBound)
+		reconciler = createImportReconciler(pvc)
+		config := &cdiv1.CDIConfig{}
+		Expect(reconciler.client.Get(context.TODO(), types.NamespacedName{Name: common.ConfigName}, config)).To(Succeed())
+		config.Spec.TransferPodInjection = &cdiv1.TransferPodInjection{
+			Sidecars: []corev1.Container{{Name: "egress-proxy", Image: "example.com/egress-proxy"}},
+			Volumes:  []corev1.Volume{{Name: "egress-proxy-config"}},
+		}
+		Expect(reconciler.client.Update(context.TODO(), config)).To(Succeed())
+		Expect(reconciler.createImporterPod(pvc)).To(Succeed())
+
+		pod := &corev1.Pod{}
+		Expect(reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "testpod", Namespace: "default"}, pod)).To(Succeed())
+		Expect(pod.Spec.InitContainers).To(HaveLen(1))
+		Expect(pod.Spec.InitContainers[0].Name).To(Equal("egress-proxy"))
+		Expect(pod.Spec.InitContainers[0].RestartPolicy).To(HaveValue(Equal(corev1.ContainerRestartPolicyAlways)))
+		Expect(pod.Spec.Containers[0].Name).To(Equal(common.ImporterPodName))
+		Expect(pod.Spec.Volumes).To(ContainElement(HaveField("Name", "egress-proxy-config")))
+	})
+
+	It("Should use the proxy of the DataVolume instead of the one of the CDIConfig", func() {
+		proxy := `{"httpsProxy":"https://proxy.example.com:3128","noProxy":".cluster.local","trustedCAProxy":"proxy-ca"}`
+		pvc := cc.CreatePvcInStorageClass("testPvc1", "default", &testStorageClass,
+			map[string]string{cc.AnnEndpoint: testEndPoint, cc.AnnImportPod: "testpod", cc.AnnSource: cc.SourceHTTP, cc.AnnImportProxy: proxy}, nil, corev1.ClaimBound)
+		reconciler = createImportReconciler(pvc)
+		config := &cdiv1.CDIConfig{}
+		Expect(reconciler.client.Get(context.TODO(), types.NamespacedName{Name: common.ConfigName}, config)).To(Succeed())
+		config.Status.ImportProxy = &cdiv1.ImportProxy{
+			HTTPProxy:      ptr.To("http://cluster-proxy.example.com"),
+			HTTPSProxy:     ptr.To("https://cluster-proxy.example.com"),
+			TrustedCAProxy: ptr.To("cluster-proxy-ca"),
+		}
+		Expect(reconciler.client.Update(context.TODO(), config)).To(Succeed())
+
+		podEnvVar, err := reconciler.createImportEnvVar(pvc)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(podEnvVar.httpProxy).To(BeEmpty())
+		Expect(podEnvVar.httpsProxy).To(Equal("https://proxy.example.com:3128"))
+		Expect(podEnvVar.noProxy).To(Equal(".cluster.local"))
+		Expect(podEnvVar.certConfigMapProxy).To(Equal("proxy-ca"))
+
+		Expect(reconciler.createImporterPod(pvc)).To(Succeed())
+		pod := &corev1.Pod{}
+		Expect(reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "testpod", Namespace: "default"}, pod)).To(Succeed())
+		Expect(pod.Spec.Volumes).To(ContainElement(HaveField("VolumeSource.ConfigMap.LocalObjectReference.Name", "proxy-ca")))
+
+		// The CA bundle of the CDIConfig is not copied
+		Expect(reconciler.copyImportProxyConfigMap(pvc, pod)).To(Succeed())
+		configMap := &corev1.ConfigMap{}
+		err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: GetImportProxyConfigMapName("testPvc1"), Namespace: "default"}, configMap)
+		Expect(errors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("Should have the import go direct with an empty proxy of the DataVolume", func() {
+		pvc := cc.CreatePvcInStorageClass("testPvc1", "default", &testStorageClass,
+			map[string]string{cc.AnnEndpoint: testEndPoint, cc.AnnImportPod: "testpod", cc.AnnSource: cc.SourceHTTP, cc.AnnImportProxy: "{}"}, nil, corev1.ClaimBound)
+		reconciler = createImportReconciler(pvc)
+		config := &cdiv1.CDIConfig{}
+		Expect(reconciler.client.Get(context.TODO(), types.NamespacedName{Name: common.ConfigName}, config)).To(Succeed())
+		config.Status.ImportProxy = &cdiv1.ImportProxy{HTTPProxy: ptr.To("http://cluster-proxy.example.com")}
+		Expect(reconciler.client.Update(context.TODO(), config)).To(Succeed())
+
+		podEnvVar, err := reconciler.createImportEnvVar(pvc)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(podEnvVar.httpProxy).To(BeEmpty())
+		Expect(podEnvVar.certConfigMapProxy).To(BeEmpty())
+	})
+
 	It("Should not mark PVC as waiting for VDDK configmap, if already present", func() {
 		configmap := &corev1.ConfigMap{
 			ObjectMeta: metav1.ObjectMeta{
@@ -742,6 +900,118 @@ var _ = Describe("Update PVC from POD", func() {
 		Expect(resPvc.GetAnnotations()[cc.AnnVddkVersion]).To(Equal("1.0.0"))
 	})
 
+	It("Should record the digest the registry image resolved to on the PVC", func() {
+		pvc := cc.CreatePvcInStorageClass("testPvc1", "default", &testStorageClass, map[string]string{cc.AnnEndpoint: testEndPoint, cc.AnnPodPhase: string(corev1.PodRunning), cc.AnnSource: cc.SourceRegistry}, nil, corev1.ClaimBound)
+		pod := cc.CreateImporterTestPod(pvc, "testPvc1", nil)
+		pod.Status = corev1.PodStatus{
+			Phase: corev1.PodSucceeded,
+			ContainerStatuses: []corev1.ContainerStatus{
+				{
+					State: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{
+							ExitCode: 0,
+							Message:  `{"sourceDigest": "sha256:12345678"}`,
+							Reason:   "Completed",
+						},
+					},
+				},
+			},
+		}
+		reconciler = createImportReconciler(pvc, pod)
+		err := reconciler.updatePvcFromPod(pvc, pod, reconciler.log)
+		Expect(err).ToNot(HaveOccurred())
+		resPvc := &corev1.PersistentVolumeClaim{}
+		err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "testPvc1", Namespace: "default"}, resPvc)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(resPvc.GetAnnotations()[cc.AnnSourceDigest]).To(Equal("sha256:12345678"))
+	})
+
+	DescribeTable("Should record the detected content type on the PVC", func(detect string, expectedContentType cdiv1.DataVolumeContentType) {
+		pvc := cc.CreatePvcInStorageClass("testPvc1", "default", &testStorageClass, map[string]string{cc.AnnEndpoint: testEndPoint, cc.AnnPodPhase: string(corev1.PodRunning), cc.AnnSource: cc.SourceHTTP, cc.AnnContentType: string(cdiv1.DataVolumeKubeVirt), cc.AnnDetectContentType: detect}, nil, corev1.ClaimBound)
+		pod := cc.CreateImporterTestPod(pvc, "testPvc1", nil)
+		pod.Status = corev1.PodStatus{
+			Phase: corev1.PodSucceeded,
+			ContainerStatuses: []corev1.ContainerStatus{
+				{
+					State: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{
+							ExitCode: 0,
+							Message:  `{"detectedContentType": "archive"}`,
+							Reason:   "Completed",
+						},
+					},
+				},
+			},
+		}
+		reconciler = createImportReconciler(pvc, pod)
+		err := reconciler.updatePvcFromPod(pvc, pod, reconciler.log)
+		Expect(err).ToNot(HaveOccurred())
+		resPvc := &corev1.PersistentVolumeClaim{}
+		err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "testPvc1", Namespace: "default"}, resPvc)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(resPvc.GetAnnotations()[cc.AnnDetectedContentType]).To(Equal("archive"))
+		Expect(resPvc.GetAnnotations()[cc.AnnContentType]).To(Equal(string(expectedContentType)))
+	},
+		Entry("switching to archive when no content type was declared", "true", cdiv1.DataVolumeArchive),
+		Entry("keeping the declared content type", "", cdiv1.DataVolumeKubeVirt),
+	)
+
+	It("Should record the phase durations of the importer on the PVC", func() {
+		pvc := cc.CreatePvcInStorageClass("testPvc1", "default", &testStorageClass, map[string]string{cc.AnnEndpoint: testEndPoint, cc.AnnPodPhase: string(corev1.PodRunning), cc.AnnSource: cc.SourceHTTP}, nil, corev1.ClaimBound)
+		pod := cc.CreateImporterTestPod(pvc, "testPvc1", nil)
+		pod.Status = corev1.PodStatus{
+			Phase: corev1.PodSucceeded,
+			ContainerStatuses: []corev1.ContainerStatus{
+				{
+					State: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{
+							ExitCode: 0,
+							Message:  `{"phaseDurations": {"download": "1m2s", "resize": "150ms"}}`,
+							Reason:   "Completed",
+						},
+					},
+				},
+			},
+		}
+		reconciler = createImportReconciler(pvc, pod)
+		err := reconciler.updatePvcFromPod(pvc, pod, reconciler.log)
+		Expect(err).ToNot(HaveOccurred())
+		resPvc := &corev1.PersistentVolumeClaim{}
+		err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "testPvc1", Namespace: "default"}, resPvc)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(resPvc.GetAnnotations()[cc.AnnImportPhaseDurations]).To(MatchJSON(`{"download": "1m2s", "resize": "150ms"}`))
+	})
+
+	It("Should record the digest of the image pulled by the node on the PVC", func() {
+		pvc := cc.CreatePvcInStorageClass("testPvc1", "default", &testStorageClass, map[string]string{cc.AnnEndpoint: testEndPoint, cc.AnnPodPhase: string(corev1.PodRunning), cc.AnnSource: cc.SourceRegistry, cc.AnnRegistryImportMethod: string(cdiv1.RegistryPullNode)}, nil, corev1.ClaimBound)
+		pod := cc.CreateImporterTestPod(pvc, "testPvc1", nil)
+		pod.Status = corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			ContainerStatuses: []corev1.ContainerStatus{
+				{
+					Name: common.ImporterPodName,
+					State: corev1.ContainerState{
+						Running: &corev1.ContainerStateRunning{},
+					},
+				},
+				{
+					Name:    "server",
+					ImageID: "quay.io/kubevirt/fedora@sha256:87654321",
+					State: corev1.ContainerState{
+						Running: &corev1.ContainerStateRunning{},
+					},
+				},
+			},
+		}
+		reconciler = createImportReconciler(pvc, pod)
+		err := reconciler.updatePvcFromPod(pvc, pod, reconciler.log)
+		Expect(err).ToNot(HaveOccurred())
+		resPvc := &corev1.PersistentVolumeClaim{}
+		err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "testPvc1", Namespace: "default"}, resPvc)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(resPvc.GetAnnotations()[cc.AnnSourceDigest]).To(Equal("sha256:87654321"))
+	})
+
 	It("Should delete pod for scratch space even if retainAfterCompletion is set", func() {
 		annotations := map[string]string{
 			cc.AnnEndpoint:  testEndPoint,
@@ -767,19 +1037,341 @@ var _ = Describe("Update PVC from POD", func() {
 			},
 		}
 
-		reconciler = createImportReconciler(pvc, pod)
-		initPod := &corev1.Pod{}
-		err := reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "importer-testPvc1", Namespace: "default"}, initPod)
-		Expect(err).ToNot(HaveOccurred())
-		Expect(initPod).ToNot(BeNil())
+		reconciler = createImportReconciler(pvc, pod)
+		initPod := &corev1.Pod{}
+		err := reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "importer-testPvc1", Namespace: "default"}, initPod)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(initPod).ToNot(BeNil())
+
+		err = reconciler.updatePvcFromPod(pvc, pod, reconciler.log)
+		Expect(err).ToNot(HaveOccurred())
+
+		resPod := &corev1.Pod{}
+		err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "importer-testPvc1", Namespace: "default"}, resPod)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("\"importer-testPvc1\" not found"))
+	})
+
+	It("Should delete pod in favor of recreating from the next fallback source when the import fails", func() {
+		annotations := map[string]string{
+			cc.AnnEndpoint:                 testEndPoint,
+			cc.AnnSource:                   cc.SourceHTTP,
+			cc.AnnSecret:                   "mirror-credentials",
+			cc.AnnSourceFallbacks:          `[{"http":{"url":"https://mirror.example.com/disk.img"}}]`,
+			cc.AnnPodRetainAfterCompletion: "true",
+		}
+		pvc := cc.CreatePvcInStorageClass("testPvc1", "default", &testStorageClass, annotations, nil, corev1.ClaimPending)
+		reconciler = createImportReconciler(pvc)
+
+		_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "testPvc1", Namespace: "default"}})
+		Expect(err).ToNot(HaveOccurred())
+		_, err = reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "testPvc1", Namespace: "default"}})
+		Expect(err).ToNot(HaveOccurred())
+
+		failPod := func() {
+			resPod := &corev1.Pod{}
+			err := reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "importer-testPvc1", Namespace: "default"}, resPod)
+			Expect(err).ToNot(HaveOccurred())
+			resPod.Status = corev1.PodStatus{
+				Phase: corev1.PodRunning,
+				ContainerStatuses: []corev1.ContainerStatus{
+					{
+						State: corev1.ContainerState{
+							Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"},
+						},
+						LastTerminationState: corev1.ContainerState{
+							Terminated: &corev1.ContainerStateTerminated{
+								ExitCode: 1,
+								Message:  "Unable to connect to http data source",
+							},
+						},
+					},
+				},
+			}
+			err = reconciler.client.Status().Update(context.TODO(), resPod)
+			Expect(err).ToNot(HaveOccurred())
+			_, err = reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "testPvc1", Namespace: "default"}})
+			Expect(err).ToNot(HaveOccurred())
+		}
+
+		// Reconcile picks the failure and deletes pod
+		failPod()
+		resPvc := &corev1.PersistentVolumeClaim{}
+		err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "testPvc1", Namespace: "default"}, resPvc)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(resPvc.Annotations[cc.AnnEndpoint]).To(Equal("https://mirror.example.com/disk.img"))
+		Expect(resPvc.Annotations[cc.AnnSourceFallbackIndex]).To(Equal("1"))
+		Expect(resPvc.Annotations).ToNot(HaveKey(cc.AnnSecret))
+		resPod := &corev1.Pod{}
+		err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "importer-testPvc1", Namespace: "default"}, resPod)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("\"importer-testPvc1\" not found"))
+
+		// Next reconcile recreates pod from the fallback source
+		_, err = reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "testPvc1", Namespace: "default"}})
+		Expect(err).ToNot(HaveOccurred())
+		err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "importer-testPvc1", Namespace: "default"}, resPod)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(resPod.Annotations[cc.AnnSourceFallbackIndex]).To(Equal("1"))
+		Expect(resPod.Spec.Containers[0].Env).To(ContainElement(
+			corev1.EnvVar{
+				Name:  common.ImporterEndpoint,
+				Value: "https://mirror.example.com/disk.img",
+			},
+		))
+
+		// The fallbacks are exhausted, the pod keeps retrying the last one
+		failPod()
+		err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "importer-testPvc1", Namespace: "default"}, resPod)
+		Expect(err).ToNot(HaveOccurred())
+		err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "testPvc1", Namespace: "default"}, resPvc)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(resPvc.Annotations[cc.AnnSourceFallbackIndex]).To(Equal("1"))
+	})
+
+	It("Should collect the artifacts of the failed import in a ConfigMap when requested", func() {
+		annotations := map[string]string{
+			cc.AnnEndpoint:                testEndPoint,
+			cc.AnnSource:                  cc.SourceHTTP,
+			cc.AnnCollectFailureArtifacts: "true",
+		}
+		pvc := cc.CreatePvcInStorageClass("testPvc1", "default", &testStorageClass, annotations, nil, corev1.ClaimPending)
+		pod := cc.CreateImporterTestPod(pvc, "testPvc1", nil)
+		pod.Status = corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			ContainerStatuses: []corev1.ContainerStatus{
+				{
+					State: corev1.ContainerState{
+						Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"},
+					},
+					LastTerminationState: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{
+							ExitCode:    1,
+							Message:     "Unable to connect to http data source",
+							ContainerID: "containerd://failed",
+						},
+					},
+				},
+			},
+		}
+		reconciler = createImportReconciler(pvc, pod)
+
+		err := reconciler.updatePvcFromPod(pvc, pod, reconciler.log)
+		Expect(err).ToNot(HaveOccurred())
+
+		resPvc := &corev1.PersistentVolumeClaim{}
+		err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "testPvc1", Namespace: "default"}, resPvc)
+		Expect(err).ToNot(HaveOccurred())
+		name := GetFailureArtifactsConfigMapName("testPvc1")
+		Expect(resPvc.Annotations[cc.AnnFailureArtifacts]).To(Equal(name))
+
+		configMap := &corev1.ConfigMap{}
+		err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: "default"}, configMap)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(configMap.Data).To(HaveKeyWithValue(FailureArtifactsTerminationMessage, "Unable to connect to http data source"))
+		// The fake clientset returns the same log for every pod
+		Expect(configMap.Data).To(HaveKeyWithValue(FailureArtifactsImporterLog, "fake logs"))
+		Expect(metav1.IsControlledBy(configMap, resPvc)).To(BeTrue())
+	})
+
+	It("Should not collect the artifacts of the failed import unless requested", func() {
+		pvc := cc.CreatePvcInStorageClass("testPvc1", "default", &testStorageClass, map[string]string{cc.AnnEndpoint: testEndPoint}, nil, corev1.ClaimPending)
+		pod := cc.CreateImporterTestPod(pvc, "testPvc1", nil)
+		pod.Status = corev1.PodStatus{
+			Phase: corev1.PodFailed,
+			ContainerStatuses: []corev1.ContainerStatus{
+				{
+					State: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{ExitCode: 1, Message: "Unable to connect to http data source"},
+					},
+				},
+			},
+		}
+		reconciler = createImportReconciler(pvc, pod)
+
+		err := reconciler.updatePvcFromPod(pvc, pod, reconciler.log)
+		Expect(err).ToNot(HaveOccurred())
+		configMap := &corev1.ConfigMap{}
+		err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: GetFailureArtifactsConfigMapName("testPvc1"), Namespace: "default"}, configMap)
+		Expect(errors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("Should keep the scratch space of a paused import until it is resumed", func() {
+		annotations := map[string]string{
+			cc.AnnEndpoint:        testEndPoint,
+			cc.AnnSource:          cc.SourceHTTP,
+			cc.AnnRequiresScratch: "true",
+			cc.AnnPodRestarts:     "0",
+			cc.AnnImportPod:       "importer-testPvc1",
+			cc.AnnContentType:     string(cdiv1.DataVolumeKubeVirt),
+		}
+		pvc := cc.CreatePvcInStorageClass("testPvc1", "default", &testStorageClass, annotations, nil, corev1.ClaimBound)
+		reconciler = createImportReconciler(pvc)
+		request := reconcile.Request{NamespacedName: types.NamespacedName{Name: "testPvc1", Namespace: "default"}}
+
+		schedulePod := func() *corev1.Pod {
+			_, err := reconciler.Reconcile(context.TODO(), request)
+			Expect(err).ToNot(HaveOccurred())
+			pod := &corev1.Pod{}
+			err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "importer-testPvc1", Namespace: "default"}, pod)
+			Expect(err).ToNot(HaveOccurred())
+			pod.Status.Phase = corev1.PodPending
+			err = reconciler.client.Status().Update(context.TODO(), pod)
+			Expect(err).ToNot(HaveOccurred())
+			// Reconcile creates the scratch space of the pending pod
+			_, err = reconciler.Reconcile(context.TODO(), request)
+			Expect(err).ToNot(HaveOccurred())
+			return pod
+		}
+		getScratch := func() *corev1.PersistentVolumeClaim {
+			scratchPvc := &corev1.PersistentVolumeClaim{}
+			err := reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "testPvc1-scratch", Namespace: "default"}, scratchPvc)
+			Expect(err).ToNot(HaveOccurred())
+			return scratchPvc
+		}
+		setPaused := func(paused bool) {
+			resPvc := &corev1.PersistentVolumeClaim{}
+			err := reconciler.client.Get(context.TODO(), request.NamespacedName, resPvc)
+			Expect(err).ToNot(HaveOccurred())
+			if paused {
+				resPvc.Annotations[cc.AnnImportPaused] = "true"
+			} else {
+				delete(resPvc.Annotations, cc.AnnImportPaused)
+			}
+			err = reconciler.client.Update(context.TODO(), resPvc)
+			Expect(err).ToNot(HaveOccurred())
+			_, err = reconciler.Reconcile(context.TODO(), request)
+			Expect(err).ToNot(HaveOccurred())
+		}
+
+		pod := schedulePod()
+		Expect(metav1.IsControlledBy(getScratch(), pod)).To(BeTrue())
+
+		// Pausing deletes the pod and hands the scratch space to the PVC
+		setPaused(true)
+		err := reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "importer-testPvc1", Namespace: "default"}, &corev1.Pod{})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("\"importer-testPvc1\" not found"))
+		resPvc := &corev1.PersistentVolumeClaim{}
+		err = reconciler.client.Get(context.TODO(), request.NamespacedName, resPvc)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(metav1.IsControlledBy(getScratch(), resPvc)).To(BeTrue())
+		Expect(resPvc.Annotations[cc.AnnRequiresScratch]).To(Equal("true"))
+		Expect(resPvc.Annotations[cc.AnnRunningConditionReason]).To(Equal(ImportPausedReason))
+
+		// No pod is created while the import is paused
+		_, err = reconciler.Reconcile(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+		err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "importer-testPvc1", Namespace: "default"}, &corev1.Pod{})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("\"importer-testPvc1\" not found"))
+
+		// Resuming recreates the pod, which owns the scratch space again
+		setPaused(false)
+		pod = schedulePod()
+		Expect(metav1.IsControlledBy(getScratch(), pod)).To(BeTrue())
+	})
+
+	Context("with a retry policy", func() {
+		var reconciler *ImportReconciler
+
+		createFailingImport := func(policy string) {
+			annotations := map[string]string{
+				cc.AnnEndpoint:    testEndPoint,
+				cc.AnnSource:      cc.SourceHTTP,
+				cc.AnnRetryPolicy: policy,
+			}
+			pvc := cc.CreatePvcInStorageClass("testPvc1", "default", &testStorageClass, annotations, nil, corev1.ClaimPending)
+			reconciler = createImportReconciler(pvc)
+			// Each failure records the failure and the retry
+			reconciler.recorder = record.NewFakeRecorder(10)
+			// First reconcile decides pods name, second creates it
+			_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "testPvc1", Namespace: "default"}})
+			Expect(err).ToNot(HaveOccurred())
+			_, err = reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "testPvc1", Namespace: "default"}})
+			Expect(err).ToNot(HaveOccurred())
+		}
+
+		failPod := func(finishedAt time.Time) {
+			resPod := &corev1.Pod{}
+			err := reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "importer-testPvc1", Namespace: "default"}, resPod)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resPod.Spec.RestartPolicy).To(Equal(corev1.RestartPolicyNever))
+			resPod.Status = corev1.PodStatus{
+				Phase: corev1.PodFailed,
+				ContainerStatuses: []corev1.ContainerStatus{
+					{
+						State: corev1.ContainerState{
+							Terminated: &corev1.ContainerStateTerminated{
+								ExitCode:   1,
+								Message:    "Unable to connect to http data source: dial tcp: i/o timeout",
+								FinishedAt: metav1.NewTime(finishedAt),
+							},
+						},
+					},
+				},
+			}
+			err = reconciler.client.Status().Update(context.TODO(), resPod)
+			Expect(err).ToNot(HaveOccurred())
+			_, err = reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "testPvc1", Namespace: "default"}})
+			Expect(err).ToNot(HaveOccurred())
+		}
+
+		getPvc := func() *corev1.PersistentVolumeClaim {
+			resPvc := &corev1.PersistentVolumeClaim{}
+			err := reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "testPvc1", Namespace: "default"}, resPvc)
+			Expect(err).ToNot(HaveOccurred())
+			return resPvc
+		}
+
+		It("Should keep the failed pod until the backoff expires", func() {
+			createFailingImport(`{"backoffBase":"1h"}`)
+			failPod(time.Now())
+
+			resPvc := getPvc()
+			Expect(resPvc.Annotations[cc.AnnPodPhase]).To(Equal(string(corev1.PodPending)))
+			Expect(resPvc.Annotations).To(HaveKey(cc.AnnImportNextRetryTime))
+			Expect(resPvc.Annotations).ToNot(HaveKey(cc.AnnImportRetryCount))
+			resPod := &corev1.Pod{}
+			err := reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "importer-testPvc1", Namespace: "default"}, resPod)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("Should recreate the failed pod until the retries are exhausted", func() {
+			createFailingImport(`{"maxRetries":1,"backoffBase":"1s"}`)
+			failPod(time.Now().Add(-time.Minute))
+
+			resPvc := getPvc()
+			Expect(resPvc.Annotations[cc.AnnPodPhase]).To(Equal(string(corev1.PodPending)))
+			Expect(resPvc.Annotations[cc.AnnImportRetryCount]).To(Equal("1"))
+			Expect(resPvc.Annotations).ToNot(HaveKey(cc.AnnImportNextRetryTime))
+			resPod := &corev1.Pod{}
+			err := reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "importer-testPvc1", Namespace: "default"}, resPod)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("\"importer-testPvc1\" not found"))
 
-		err = reconciler.updatePvcFromPod(pvc, pod, reconciler.log)
-		Expect(err).ToNot(HaveOccurred())
+			// Next reconcile recreates the pod for the retry
+			_, err = reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "testPvc1", Namespace: "default"}})
+			Expect(err).ToNot(HaveOccurred())
+			err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "importer-testPvc1", Namespace: "default"}, resPod)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resPod.Annotations[cc.AnnImportRetryCount]).To(Equal("1"))
 
-		resPod := &corev1.Pod{}
-		err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "importer-testPvc1", Namespace: "default"}, resPod)
-		Expect(err).To(HaveOccurred())
-		Expect(err.Error()).To(ContainSubstring("\"importer-testPvc1\" not found"))
+			// The retries are exhausted, the failure reaches the PVC
+			failPod(time.Now().Add(-time.Minute))
+			resPvc = getPvc()
+			Expect(resPvc.Annotations[cc.AnnPodPhase]).To(Equal(string(corev1.PodFailed)))
+			Expect(resPvc.Annotations[cc.AnnImportRetryCount]).To(Equal("1"))
+		})
+
+		It("Should not retry errors of classes the policy does not retry on", func() {
+			createFailingImport(`{"retryOn":["Storage"]}`)
+			failPod(time.Now().Add(-time.Minute))
+
+			resPvc := getPvc()
+			Expect(resPvc.Annotations[cc.AnnPodPhase]).To(Equal(string(corev1.PodFailed)))
+			Expect(resPvc.Annotations).ToNot(HaveKey(cc.AnnImportNextRetryTime))
+		})
 	})
 
 	It("Should delete pod in favor of recreating with cache=trynone in case of OOMKilled", func() {
@@ -857,13 +1449,14 @@ var _ = Describe("Create Importer Pod", func() {
 			insecureTLS:        false,
 		}
 		podArgs := &importerPodArgs{
-			image:             testImage,
-			verbose:           "5",
-			pullPolicy:        testPullPolicy,
-			podEnvVar:         podEnvVar,
-			pvc:               pvc,
-			scratchPvcName:    scratchPvcName,
-			priorityClassName: pvc.Annotations[cc.AnnPriorityClassName],
+			image:              testImage,
+			verbose:            "5",
+			pullPolicy:         testPullPolicy,
+			podEnvVar:          podEnvVar,
+			pvc:                pvc,
+			scratchPvcName:     scratchPvcName,
+			priorityClassName:  pvc.Annotations[cc.AnnPriorityClassName],
+			serviceAccountName: pvc.Annotations[cc.AnnImportServiceAccount],
 		}
 		pod, err := createImporterPod(context.TODO(), reconciler.log, reconciler.client, podArgs, map[string]string{})
 		Expect(err).ToNot(HaveOccurred())
@@ -896,11 +1489,13 @@ var _ = Describe("Create Importer Pod", func() {
 		Expect(pod.Spec.Containers[0].Args[0]).To(Equal("-v=5"))
 		Expect(pod.Spec.Containers[0].TerminationMessagePolicy).To(Equal(corev1.TerminationMessageFallbackToLogsOnError))
 		Expect(pod.Spec.PriorityClassName).To(Equal(pvc.Annotations[cc.AnnPriorityClassName]))
+		Expect(pod.Spec.ServiceAccountName).To(Equal(pvc.Annotations[cc.AnnImportServiceAccount]))
 	},
 		Entry("should create pod with file system volume mode", cc.CreatePvc("testPvc1", "default", map[string]string{cc.AnnEndpoint: testEndPoint, cc.AnnPodPhase: string(corev1.PodPending), cc.AnnImportPod: "podName", cc.AnnPriorityClassName: "p0"}, nil), nil),
 		Entry("should create pod with block volume mode", createBlockPvc("testBlockPvc1", "default", map[string]string{cc.AnnEndpoint: testEndPoint, cc.AnnPodPhase: string(corev1.PodPending), cc.AnnImportPod: "podName", cc.AnnPriorityClassName: "p0"}, nil), nil),
 		Entry("should create pod with file system volume mode and scratchspace", cc.CreatePvc("testPvc1", "default", map[string]string{cc.AnnEndpoint: testEndPoint, cc.AnnPodPhase: string(corev1.PodPending), cc.AnnImportPod: "podName", cc.AnnPriorityClassName: "p0"}, nil), &scratchPvcName),
 		Entry("should create pod with block volume mode and scratchspace", createBlockPvc("testBlockPvc1", "default", map[string]string{cc.AnnEndpoint: testEndPoint, cc.AnnPodPhase: string(corev1.PodPending), cc.AnnImportPod: "podName", cc.AnnPriorityClassName: "p0"}, nil), &scratchPvcName),
+		Entry("should create pod with a service account", cc.CreatePvc("testPvc1", "default", map[string]string{cc.AnnEndpoint: testEndPoint, cc.AnnPodPhase: string(corev1.PodPending), cc.AnnImportPod: "podName", cc.AnnImportServiceAccount: "s3-reader"}, nil), nil),
 	)
 
 	DescribeTable("should append current checkpoint name to importer pod", func(pvcName, checkpointID string) {
@@ -968,6 +1563,521 @@ var _ = Describe("Create Importer Pod", func() {
 		Expect(found).To(BeTrue())
 	})
 
+	Context("with a registry layer cache", func() {
+		createRegistryPvcAndReconciler := func(layerCache *cdiv1.RegistryLayerCache, importMethod cdiv1.RegistryPullMethod) (*corev1.PersistentVolumeClaim, *ImportReconciler) {
+			annotations := map[string]string{
+				cc.AnnEndpoint:             "docker://quay.io/containerdisks/fedora:40",
+				cc.AnnImportPod:            "testpod",
+				cc.AnnSource:               cc.SourceRegistry,
+				cc.AnnRegistryImportMethod: string(importMethod),
+			}
+			pvc := cc.CreatePvcInStorageClass("testPvc1", "default", &testStorageClass, annotations, nil, corev1.ClaimBound)
+			reconciler := createImportReconciler(pvc)
+			cdiConfig := &cdiv1.CDIConfig{}
+			Expect(reconciler.client.Get(context.TODO(), types.NamespacedName{Name: common.ConfigName}, cdiConfig)).To(Succeed())
+			cdiConfig.Spec.RegistryLayerCache = layerCache
+			Expect(reconciler.client.Update(context.TODO(), cdiConfig)).To(Succeed())
+			return pvc, reconciler
+		}
+
+		DescribeTable("Should mount the cache directory of the node", func(maxSize *resource.Quantity, expectedSize string) {
+			pvc, reconciler := createRegistryPvcAndReconciler(&cdiv1.RegistryLayerCache{HostPath: "/var/lib/cdi/layers", MaxSize: maxSize}, cdiv1.RegistryPullPod)
+			Expect(reconciler.createImporterPod(pvc)).To(Succeed())
+
+			pod := &corev1.Pod{}
+			Expect(reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "testpod", Namespace: "default"}, pod)).To(Succeed())
+			Expect(pod.Spec.Volumes).To(ContainElement(corev1.Volume{
+				Name: layerCacheVolumeName,
+				VolumeSource: corev1.VolumeSource{
+					HostPath: &corev1.HostPathVolumeSource{Path: "/var/lib/cdi/layers", Type: ptr.To(corev1.HostPathDirectoryOrCreate)},
+				},
+			}))
+			Expect(pod.Spec.Containers[0].VolumeMounts).To(ContainElement(corev1.VolumeMount{
+				Name:      layerCacheVolumeName,
+				MountPath: common.ImporterLayerCacheDir,
+			}))
+			Expect(pod.Spec.Containers[0].Env).To(ContainElement(corev1.EnvVar{Name: common.ImporterLayerCacheMaxSize, Value: expectedSize}))
+		},
+			Entry("of the default size", nil, "10737418240"),
+			Entry("of the configured size", ptr.To(resource.MustParse("50Gi")), "53687091200"),
+		)
+
+		DescribeTable("Should not use the cache", func(layerCache *cdiv1.RegistryLayerCache, importMethod cdiv1.RegistryPullMethod) {
+			pvc, reconciler := createRegistryPvcAndReconciler(layerCache, importMethod)
+			Expect(reconciler.createImporterPod(pvc)).To(Succeed())
+
+			pod := &corev1.Pod{}
+			Expect(reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "testpod", Namespace: "default"}, pod)).To(Succeed())
+			Expect(pod.Spec.Volumes).ToNot(ContainElement(HaveField("Name", layerCacheVolumeName)))
+			Expect(pod.Spec.Containers[0].Env).ToNot(ContainElement(HaveField("Name", common.ImporterLayerCacheMaxSize)))
+		},
+			Entry("when it is not configured", nil, cdiv1.RegistryPullPod),
+			Entry("with a relative hostPath", &cdiv1.RegistryLayerCache{HostPath: "cdi/layers"}, cdiv1.RegistryPullPod),
+			Entry("when the node pulls the image", &cdiv1.RegistryLayerCache{HostPath: "/var/lib/cdi/layers"}, cdiv1.RegistryPullNode),
+		)
+	})
+
+	Context("with pod overrides", func() {
+		It("Should replace the CDI-wide settings of the importer pod with the overridden ones", func() {
+			overrides := cdiv1.DataVolumePodOverrides{
+				Resources: &corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("4")},
+				},
+				NodeSelector: map[string]string{"node-role.kubernetes.io/import": ""},
+				Tolerations:  []corev1.Toleration{{Key: "import", Operator: corev1.TolerationOpExists}},
+			}
+			value, err := json.Marshal(overrides)
+			Expect(err).ToNot(HaveOccurred())
+			annotations := map[string]string{
+				cc.AnnEndpoint:          testEndPoint,
+				cc.AnnImportPod:         "testpod",
+				cc.AnnPriorityClassName: "p0",
+				cc.AnnPodOverrides:      string(value),
+			}
+			pvc := cc.CreatePvcInStorageClass("testPvc1", "default", &testStorageClass, annotations, nil, corev1.ClaimBound)
+			reconciler := createImportReconciler(pvc)
+			placement := updateCdiWithTestNodePlacement(reconciler.client)
+			updateCDIConfig(reconciler.client, func(spec *cdiv1.CDIConfigSpec) {
+				spec.PodOverrideTolerations = overrides.Tolerations
+				spec.ImporterPriorityClasses = []string{"p0"}
+			})
+			Expect(reconciler.createImporterPod(pvc)).To(Succeed())
+
+			pod := &corev1.Pod{}
+			Expect(reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "testpod", Namespace: "default"}, pod)).To(Succeed())
+			Expect(pod.Spec.NodeSelector).To(Equal(overrides.NodeSelector))
+			Expect(pod.Spec.Tolerations).To(Equal(overrides.Tolerations))
+			Expect(pod.Spec.Affinity).To(Equal(placement.Affinity))
+			Expect(pod.Spec.PriorityClassName).To(Equal("p0"))
+			Expect(pod.Spec.Containers[0].Resources).To(Equal(*overrides.Resources))
+		})
+
+		It("Should override the affinity with the node label and topology keys the CDIConfig allows", func() {
+			overrides := cdiv1.DataVolumePodOverrides{
+				Affinity: &corev1.Affinity{
+					NodeAffinity: &corev1.NodeAffinity{
+						PreferredDuringSchedulingIgnoredDuringExecution: []corev1.PreferredSchedulingTerm{{
+							Weight: 1,
+							Preference: corev1.NodeSelectorTerm{MatchExpressions: []corev1.NodeSelectorRequirement{
+								{Key: "node-role.kubernetes.io/import", Operator: corev1.NodeSelectorOpExists},
+							}},
+						}},
+					},
+					PodAntiAffinity: &corev1.PodAntiAffinity{
+						RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{{TopologyKey: "kubernetes.io/hostname"}},
+					},
+				},
+			}
+			value, err := json.Marshal(overrides)
+			Expect(err).ToNot(HaveOccurred())
+			annotations := map[string]string{
+				cc.AnnEndpoint:     testEndPoint,
+				cc.AnnImportPod:    "testpod",
+				cc.AnnPodOverrides: string(value),
+			}
+			pvc := cc.CreatePvcInStorageClass("testPvc1", "default", &testStorageClass, annotations, nil, corev1.ClaimBound)
+			reconciler := createImportReconciler(pvc)
+			updateCDIConfig(reconciler.client, func(spec *cdiv1.CDIConfigSpec) {
+				spec.PodOverrideAffinityKeys = []string{"node-role.kubernetes.io/import", "kubernetes.io/hostname"}
+			})
+			Expect(reconciler.createImporterPod(pvc)).To(Succeed())
+
+			pod := &corev1.Pod{}
+			Expect(reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "testpod", Namespace: "default"}, pod)).To(Succeed())
+			Expect(pod.Spec.Affinity).To(Equal(overrides.Affinity))
+		})
+
+		DescribeTable("Should refuse importer pod settings the CDIConfig does not allow", func(overrides *cdiv1.DataVolumePodOverrides, priorityClassName string, expected string) {
+			annotations := map[string]string{
+				cc.AnnEndpoint:  testEndPoint,
+				cc.AnnImportPod: "testpod",
+			}
+			if overrides != nil {
+				value, err := json.Marshal(overrides)
+				Expect(err).ToNot(HaveOccurred())
+				annotations[cc.AnnPodOverrides] = string(value)
+			}
+			if priorityClassName != "" {
+				annotations[cc.AnnPriorityClassName] = priorityClassName
+			}
+			pvc := cc.CreatePvcInStorageClass("testPvc1", "default", &testStorageClass, annotations, nil, corev1.ClaimBound)
+			reconciler := createImportReconciler(pvc)
+			updateCDIConfig(reconciler.client, func(spec *cdiv1.CDIConfigSpec) {
+				spec.PodOverrideTolerations = []corev1.Toleration{{Key: "import", Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoSchedule}}
+				spec.ImporterPriorityClasses = []string{"batch-low"}
+			})
+			Expect(reconciler.createImporterPod(pvc)).To(MatchError(ContainSubstring(expected)))
+			Expect(reconciler.recorder.(*record.FakeRecorder).Events).To(Receive(ContainSubstring(ImporterPodSettingsNotAllowed)))
+
+			pod := &corev1.Pod{}
+			err := reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "testpod", Namespace: "default"}, pod)
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+		},
+			Entry("with a toleration of all taints",
+				&cdiv1.DataVolumePodOverrides{Tolerations: []corev1.Toleration{{Operator: corev1.TolerationOpExists}}}, "",
+				"is not one of the podOverrideTolerations"),
+			Entry("with a toleration of another effect",
+				&cdiv1.DataVolumePodOverrides{Tolerations: []corev1.Toleration{{Key: "import", Operator: corev1.TolerationOpExists}}}, "",
+				"toleration of taint import is not one of the podOverrideTolerations"),
+			Entry("with an affinity and no allowed keys",
+				&cdiv1.DataVolumePodOverrides{Affinity: &corev1.Affinity{}}, "",
+				"affinity can not be overridden"),
+			Entry("with a priority class not allowed",
+				nil, "system-cluster-critical",
+				"priority class system-cluster-critical is not one of the importerPriorityClasses"),
+		)
+
+		It("Should refuse an affinity key the CDIConfig does not allow", func() {
+			overrides := cdiv1.DataVolumePodOverrides{
+				Affinity: &corev1.Affinity{
+					PodAffinity: &corev1.PodAffinity{
+						PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{
+							{Weight: 1, PodAffinityTerm: corev1.PodAffinityTerm{TopologyKey: "topology.kubernetes.io/zone"}},
+						},
+					},
+				},
+			}
+			value, err := json.Marshal(overrides)
+			Expect(err).ToNot(HaveOccurred())
+			pvc := cc.CreatePvc("testPvc1", "default", map[string]string{cc.AnnPodOverrides: string(value)}, nil)
+			cdiConfig := &cdiv1.CDIConfig{Spec: cdiv1.CDIConfigSpec{PodOverrideAffinityKeys: []string{"kubernetes.io/hostname"}}}
+			Expect(checkImporterPodSettings(pvc, cdiConfig)).To(MatchError("affinity key topology.kubernetes.io/zone is not one of the podOverrideAffinityKeys of the CDIConfig"))
+		})
+
+		DescribeTable("Should run the importer pod with the image the CDIConfig sets for its source", func(source, expected string) {
+			annotations := map[string]string{
+				cc.AnnEndpoint:  testEndPoint,
+				cc.AnnImportPod: "testpod",
+				cc.AnnSource:    source,
+			}
+			pvc := cc.CreatePvcInStorageClass("testPvc1", "default", &testStorageClass, annotations, nil, corev1.ClaimBound)
+			reconciler := createImportReconciler(pvc)
+			cdiConfig := &cdiv1.CDIConfig{}
+			Expect(reconciler.client.Get(context.TODO(), types.NamespacedName{Name: common.ConfigName}, cdiConfig)).To(Succeed())
+			cdiConfig.Spec.TransferPodImages = &cdiv1.TransferPodImages{
+				Importer: "registry/importer-custom",
+				Sources:  map[string]string{cc.SourceS3: "registry/importer-s3"},
+			}
+			Expect(reconciler.client.Update(context.TODO(), cdiConfig)).To(Succeed())
+			Expect(reconciler.createImporterPod(pvc)).To(Succeed())
+
+			pod := &corev1.Pod{}
+			Expect(reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "testpod", Namespace: "default"}, pod)).To(Succeed())
+			Expect(pod.Spec.Containers[0].Image).To(Equal(expected))
+		},
+			Entry("of the source", cc.SourceS3, "registry/importer-s3"),
+			Entry("of all importers", cc.SourceHTTP, "registry/importer-custom"),
+		)
+
+		It("Should fail to create the importer pod with invalid overrides", func() {
+			annotations := map[string]string{
+				cc.AnnEndpoint:     testEndPoint,
+				cc.AnnImportPod:    "testpod",
+				cc.AnnPodOverrides: "{",
+			}
+			pvc := cc.CreatePvcInStorageClass("testPvc1", "default", &testStorageClass, annotations, nil, corev1.ClaimBound)
+			reconciler := createImportReconciler(pvc)
+			Expect(reconciler.createImporterPod(pvc)).ToNot(Succeed())
+		})
+	})
+
+	Context("with a topology placement", func() {
+		It("Should schedule the importer pod in the topology domain on top of the workload placement", func() {
+			topology := cc.TopologyPlacement{
+				NodeSelector: map[string]string{"topology.kubernetes.io/zone": "zone-a"},
+				NodeAffinity: &corev1.NodeSelector{
+					NodeSelectorTerms: []corev1.NodeSelectorTerm{
+						{MatchExpressions: []corev1.NodeSelectorRequirement{{Key: "rack", Operator: corev1.NodeSelectorOpIn, Values: []string{"r1"}}}},
+						{MatchExpressions: []corev1.NodeSelectorRequirement{{Key: "rack", Operator: corev1.NodeSelectorOpIn, Values: []string{"r2"}}}},
+					},
+				},
+			}
+			value, err := json.Marshal(topology)
+			Expect(err).ToNot(HaveOccurred())
+			annotations := map[string]string{
+				cc.AnnEndpoint:          testEndPoint,
+				cc.AnnImportPod:         "testpod",
+				cc.AnnTopologyPlacement: string(value),
+			}
+			pvc := cc.CreatePvcInStorageClass("testPvc1", "default", &testStorageClass, annotations, nil, corev1.ClaimPending)
+			reconciler := createImportReconciler(pvc)
+			placement := updateCdiWithTestNodePlacement(reconciler.client)
+			Expect(reconciler.createImporterPod(pvc)).To(Succeed())
+
+			pod := &corev1.Pod{}
+			Expect(reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "testpod", Namespace: "default"}, pod)).To(Succeed())
+			Expect(pod.Spec.NodeSelector).To(Equal(map[string]string{
+				"kubernetes.io/arch":          "amd64",
+				"topology.kubernetes.io/zone": "zone-a",
+			}))
+			workloadExpressions := placement.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[0].MatchExpressions
+			terms := pod.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+			Expect(terms).To(HaveLen(2))
+			for i, term := range terms {
+				Expect(term.MatchExpressions).To(Equal(append(workloadExpressions, topology.NodeAffinity.NodeSelectorTerms[i].MatchExpressions...)))
+			}
+		})
+
+		It("Should fail to create the importer pod with an invalid placement", func() {
+			annotations := map[string]string{
+				cc.AnnEndpoint:          testEndPoint,
+				cc.AnnImportPod:         "testpod",
+				cc.AnnTopologyPlacement: "{",
+			}
+			pvc := cc.CreatePvcInStorageClass("testPvc1", "default", &testStorageClass, annotations, nil, corev1.ClaimPending)
+			reconciler := createImportReconciler(pvc)
+			Expect(reconciler.createImporterPod(pvc)).ToNot(Succeed())
+		})
+	})
+
+	Context("with an SMB source", func() {
+		createSMBPvcAndReconciler := func(endpoint, secret string) (*corev1.PersistentVolumeClaim, *ImportReconciler) {
+			annotations := map[string]string{
+				cc.AnnEndpoint:  endpoint,
+				cc.AnnImportPod: "testpod",
+				cc.AnnSource:    cc.SourceSMB,
+			}
+			if secret != "" {
+				annotations[cc.AnnSecret] = secret
+			}
+			pvc := cc.CreatePvcInStorageClass("testPvc1", "default", &testStorageClass, annotations, nil, corev1.ClaimBound)
+			return pvc, createImportReconciler(pvc)
+		}
+
+		getSMBVolume := func(reconciler *ImportReconciler) (*corev1.Pod, *corev1.CSIVolumeSource) {
+			pod := &corev1.Pod{}
+			Expect(reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "testpod", Namespace: "default"}, pod)).To(Succeed())
+			Expect(pod.Spec.Containers[0].VolumeMounts).To(ContainElement(corev1.VolumeMount{
+				Name:      smbSourceVolumeName,
+				MountPath: common.ImporterSMBDir,
+				ReadOnly:  true,
+			}))
+			for _, volume := range pod.Spec.Volumes {
+				if volume.Name == smbSourceVolumeName {
+					return pod, volume.CSI
+				}
+			}
+			Fail("the importer pod does not mount the share")
+			return nil, nil
+		}
+
+		It("Should mount the share with the SMB CSI driver and the secret", func() {
+			pvc, reconciler := createSMBPvcAndReconciler("smb://fileserver/images/windows/disk.vhdx", "smb-secret")
+			Expect(reconciler.createImporterPod(pvc)).To(Succeed())
+			pod, csi := getSMBVolume(reconciler)
+			Expect(csi).To(Equal(&corev1.CSIVolumeSource{
+				Driver:               cc.SMBCSIDriver,
+				ReadOnly:             ptr.To(true),
+				VolumeAttributes:     map[string]string{"source": "//fileserver/images"},
+				NodePublishSecretRef: &corev1.LocalObjectReference{Name: "smb-secret"},
+			}))
+			for _, env := range pod.Spec.Containers[0].Env {
+				Expect(env.ValueFrom).To(Or(BeNil(), WithTransform(func(source *corev1.EnvVarSource) *corev1.SecretKeySelector {
+					return source.SecretKeyRef
+				}, BeNil())), "the secret must not be read by the importer")
+			}
+		})
+
+		It("Should mount the share as guest, with the port and the encryption of smbs urls", func() {
+			pvc, reconciler := createSMBPvcAndReconciler("smbs://fileserver:4445/images/windows/disk.vhdx", "")
+			Expect(reconciler.createImporterPod(pvc)).To(Succeed())
+			_, csi := getSMBVolume(reconciler)
+			Expect(csi.NodePublishSecretRef).To(BeNil())
+			Expect(csi.VolumeAttributes).To(Equal(map[string]string{
+				"source":       "//fileserver/images",
+				"mountOptions": "port=4445,seal,guest",
+			}))
+		})
+	})
+
+	Context("with a hostpath source", func() {
+		const filePath = "/var/lib/images/fedora.qcow2"
+
+		createHostPathPvcAndReconciler := func(importDirectories ...string) (*corev1.PersistentVolumeClaim, *ImportReconciler) {
+			annotations := map[string]string{
+				cc.AnnEndpoint:     filePath,
+				cc.AnnImportPod:    "testpod",
+				cc.AnnSource:       cc.SourceHostPath,
+				cc.AnnHostPathNode: "node02",
+			}
+			pvc := cc.CreatePvcInStorageClass("testPvc1", "default", &testStorageClass, annotations, nil, corev1.ClaimBound)
+			reconciler := createImportReconciler(pvc)
+			cdiConfig := &cdiv1.CDIConfig{}
+			Expect(reconciler.client.Get(context.TODO(), types.NamespacedName{Name: common.ConfigName}, cdiConfig)).To(Succeed())
+			cdiConfig.Spec.HostPathImportDirectories = importDirectories
+			Expect(reconciler.client.Update(context.TODO(), cdiConfig)).To(Succeed())
+			return pvc, reconciler
+		}
+
+		It("Should mount the file read-only on the node holding it", func() {
+			pvc, reconciler := createHostPathPvcAndReconciler("/srv/images", "/var/lib/images/")
+			placement := updateCdiWithTestNodePlacement(reconciler.client)
+			Expect(reconciler.createImporterPod(pvc)).To(Succeed())
+
+			pod := &corev1.Pod{}
+			Expect(reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "testpod", Namespace: "default"}, pod)).To(Succeed())
+			Expect(pod.Spec.Volumes).To(ContainElement(corev1.Volume{
+				Name: hostPathSourceVolumeName,
+				VolumeSource: corev1.VolumeSource{
+					HostPath: &corev1.HostPathVolumeSource{Path: filePath, Type: ptr.To(corev1.HostPathFile)},
+				},
+			}))
+			Expect(pod.Spec.Containers[0].VolumeMounts).To(ContainElement(corev1.VolumeMount{
+				Name:      hostPathSourceVolumeName,
+				MountPath: common.ImporterHostPathDir + "/fedora.qcow2",
+				ReadOnly:  true,
+			}))
+			terms := pod.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+			Expect(terms).To(HaveLen(1))
+			Expect(terms[0].MatchExpressions).To(Equal(placement.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[0].MatchExpressions))
+			Expect(terms[0].MatchFields).To(Equal([]corev1.NodeSelectorRequirement{
+				{Key: "metadata.name", Operator: corev1.NodeSelectorOpIn, Values: []string{"node02"}},
+			}))
+		})
+
+		It("Should refuse a file outside the allowed directories", func() {
+			pvc, reconciler := createHostPathPvcAndReconciler("/var/lib/image")
+			err := reconciler.createImporterPod(pvc)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("is not under any of the hostPathImportDirectories"))
+			pod := &corev1.Pod{}
+			Expect(errors.IsNotFound(reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "testpod", Namespace: "default"}, pod))).To(BeTrue())
+		})
+
+		It("Should refuse any file without allowed directories", func() {
+			pvc, reconciler := createHostPathPvcAndReconciler()
+			Expect(reconciler.createImporterPod(pvc)).ToNot(Succeed())
+		})
+	})
+
+	It("Should pass the maximum bandwidth of the import in bytes per second", func() {
+		annotations := map[string]string{cc.AnnEndpoint: testEndPoint, cc.AnnImportPod: "testpod", cc.AnnMaxBandwidth: "10Mi"}
+		pvc := cc.CreatePvcInStorageClass("testPvc1", "default", &testStorageClass, annotations, nil, corev1.ClaimBound)
+		reconciler := createImportReconciler(pvc)
+		podEnvVar, err := reconciler.createImportEnvVar(pvc)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(podEnvVar.maxBandwidth).To(Equal("10485760"))
+		Expect(makeImportEnv(podEnvVar, "1111-1111-1111-1111")).To(ContainElement(corev1.EnvVar{Name: common.ImporterMaxBandwidth, Value: "10485760"}))
+	})
+
+	DescribeTable("Should refuse a maximum bandwidth that is not a positive quantity", func(value string) {
+		annotations := map[string]string{cc.AnnEndpoint: testEndPoint, cc.AnnImportPod: "testpod", cc.AnnMaxBandwidth: value}
+		pvc := cc.CreatePvcInStorageClass("testPvc1", "default", &testStorageClass, annotations, nil, corev1.ClaimBound)
+		reconciler := createImportReconciler(pvc)
+		_, err := reconciler.createImportEnvVar(pvc)
+		Expect(err).To(MatchError(ContainSubstring("is not a positive quantity of bytes per second")))
+		event := <-reconciler.recorder.(*record.FakeRecorder).Events
+		Expect(event).To(ContainSubstring(MaxBandwidthNotValid))
+	},
+		Entry("with a rate", "10MB/s"),
+		Entry("with zero", "0"),
+	)
+
+	It("Should pass the qcow2 storage format of the import", func() {
+		annotations := map[string]string{cc.AnnEndpoint: testEndPoint, cc.AnnImportPod: "testpod", cc.AnnStorageFormat: common.StorageFormatQcow2}
+		pvc := cc.CreatePvcInStorageClass("testPvc1", "default", &testStorageClass, annotations, nil, corev1.ClaimBound)
+		reconciler := createImportReconciler(pvc)
+		podEnvVar, err := reconciler.createImportEnvVar(pvc)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(makeImportEnv(podEnvVar, "1111-1111-1111-1111")).To(ContainElement(corev1.EnvVar{Name: common.ImporterStorageFormat, Value: common.StorageFormatQcow2}))
+	})
+
+	DescribeTable("Should refuse a storage format the target does not support", func(format string, volumeMode corev1.PersistentVolumeMode, contentType cdiv1.DataVolumeContentType, message string) {
+		annotations := map[string]string{
+			cc.AnnEndpoint:      testEndPoint,
+			cc.AnnImportPod:     "testpod",
+			cc.AnnStorageFormat: format,
+			cc.AnnContentType:   string(contentType),
+		}
+		pvc := cc.CreatePvcInStorageClass("testPvc1", "default", &testStorageClass, annotations, nil, corev1.ClaimBound)
+		pvc.Spec.VolumeMode = &volumeMode
+		reconciler := createImportReconciler(pvc)
+		_, err := reconciler.createImportEnvVar(pvc)
+		Expect(err).To(MatchError(ContainSubstring(message)))
+		event := <-reconciler.recorder.(*record.FakeRecorder).Events
+		Expect(event).To(ContainSubstring(StorageFormatNotValid))
+	},
+		Entry("with an unknown format", "vmdk", corev1.PersistentVolumeFilesystem, cdiv1.DataVolumeKubeVirt, "is not supported"),
+		Entry("with a block volume", common.StorageFormatQcow2, corev1.PersistentVolumeBlock, cdiv1.DataVolumeKubeVirt, "requires a filesystem volume"),
+		Entry("with an archive", common.StorageFormatQcow2, corev1.PersistentVolumeFilesystem, cdiv1.DataVolumeArchive, "requires the kubevirt content type"),
+	)
+
+	Context("with ImageVerificationPolicies", func() {
+		policy := func(name string, images ...string) *cdiv1.ImageVerificationPolicy {
+			return &cdiv1.ImageVerificationPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: name},
+				Spec: cdiv1.ImageVerificationPolicySpec{
+					Images:      images,
+					Authorities: []cdiv1.ImageVerificationAuthority{{Name: "release", Key: &cdiv1.ImageVerificationKey{Data: "key"}}},
+				},
+			}
+		}
+
+		createRegistryPvc := func(ep string, annotations map[string]string) *corev1.PersistentVolumeClaim {
+			if annotations == nil {
+				annotations = map[string]string{}
+			}
+			annotations[cc.AnnEndpoint] = ep
+			annotations[cc.AnnImportPod] = "testpod"
+			annotations[cc.AnnSource] = cc.SourceRegistry
+			return cc.CreatePvcInStorageClass("testPvc1", "default", &testStorageClass, annotations, nil, corev1.ClaimBound)
+		}
+
+		It("Should match the policies of the repository of the image", func() {
+			reconciler := createImportReconciler(policy("disks", "quay.io/disks/*"), policy("fedora", "docker.io/library/fedora"), policy("other", "quay.io/other/*"))
+			policies, err := matchImageVerificationPolicies(context.TODO(), reconciler.client, "docker://fedora:39")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(policies).To(HaveLen(1))
+			Expect(policies[0].Name).To(Equal("fedora"))
+			policies, err = matchImageVerificationPolicies(context.TODO(), reconciler.client, "docker://quay.io/disks/fedora@sha256:"+strings.Repeat("a", 64))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(policies).To(HaveLen(1))
+			Expect(policies[0].Name).To(Equal("disks"))
+			Expect(policies[0].Spec.Images).To(Equal([]string{"quay.io/disks/*"}))
+			policies, err = matchImageVerificationPolicies(context.TODO(), reconciler.client, "oci-archive:///images/fedora.tar")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(policies).To(BeEmpty())
+		})
+
+		It("Should pass the policies of the image to the importer", func() {
+			pvc := createRegistryPvc("docker://quay.io/disks/fedora:39", nil)
+			reconciler := createImportReconciler(pvc, policy("disks", "quay.io/disks/*"))
+			Expect(reconciler.createImporterPod(pvc)).To(Succeed())
+
+			pod := &corev1.Pod{}
+			Expect(reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "testpod", Namespace: "default"}, pod)).To(Succeed())
+			var policies []cdiv1.ImageVerificationPolicy
+			for _, env := range pod.Spec.Containers[0].Env {
+				if env.Name == common.ImporterImageVerificationPolicies {
+					Expect(json.Unmarshal([]byte(env.Value), &policies)).To(Succeed())
+				}
+			}
+			Expect(policies).To(HaveLen(1))
+			Expect(policies[0].Name).To(Equal("disks"))
+			Expect(policies[0].Spec.Authorities[0].Key.Data).To(Equal("key"))
+		})
+
+		It("Should not pass policies to the importer of an image none applies to", func() {
+			pvc := createRegistryPvc("docker://quay.io/other/fedora:39", nil)
+			reconciler := createImportReconciler(pvc, policy("disks", "quay.io/disks/*"))
+			Expect(reconciler.createImporterPod(pvc)).To(Succeed())
+
+			pod := &corev1.Pod{}
+			Expect(reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "testpod", Namespace: "default"}, pod)).To(Succeed())
+			Expect(pod.Spec.Containers[0].Env).ToNot(ContainElement(HaveField("Name", common.ImporterImageVerificationPolicies)))
+		})
+
+		It("Should refuse an image that must be verified pulled by the node", func() {
+			pvc := createRegistryPvc("docker://quay.io/disks/fedora:39", map[string]string{cc.AnnRegistryImportMethod: string(cdiv1.RegistryPullNode)})
+			reconciler := createImportReconciler(pvc, policy("disks", "quay.io/disks/*"))
+			err := reconciler.createImporterPod(pvc)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("must meet the ImageVerificationPolicy disks, images pulled by the node can not be verified"))
+			event := <-reconciler.recorder.(*record.FakeRecorder).Events
+			Expect(event).To(ContainSubstring(ImageVerificationNotSupported))
+		})
+	})
+
 	It("Should create relevant containers and init containers when source is registry and pull method is node", func() {
 		pvcName := "testPvc1"
 		podName := "testpod"
@@ -1022,6 +2132,449 @@ var _ = Describe("Import test env", func() {
 		}
 		Expect(reflect.DeepEqual(makeImportEnv(testEnvVar, mockUID), createImportTestEnv(testEnvVar, mockUID))).To(BeTrue())
 	})
+
+	It("Should request zeroing blank block edges only when asked to", func() {
+		testEnvVar := &importPodEnvVar{source: cc.SourceNone}
+		Expect(makeImportEnv(testEnvVar, mockUID)).ToNot(ContainElement(HaveField("Name", common.ImporterBlankZeroEdges)))
+		testEnvVar.blankZeroEdges = true
+		Expect(makeImportEnv(testEnvVar, mockUID)).To(ContainElement(corev1.EnvVar{Name: common.ImporterBlankZeroEdges, Value: "true"}))
+	})
+
+	It("Should ask to expand the filesystem only when asked to", func() {
+		testEnvVar := &importPodEnvVar{source: cc.SourceHTTP}
+		Expect(makeImportEnv(testEnvVar, mockUID)).ToNot(ContainElement(HaveField("Name", common.ImporterExpandFilesystem)))
+		testEnvVar.expandFilesystem = true
+		Expect(makeImportEnv(testEnvVar, mockUID)).To(ContainElements(
+			corev1.EnvVar{Name: common.ImporterExpandFilesystem, Value: "true"},
+			corev1.EnvVar{Name: "LIBGUESTFS_BACKEND", Value: "direct"},
+		))
+	})
+
+	It("Should ask to verify the image only when asked to", func() {
+		testEnvVar := &importPodEnvVar{source: cc.SourceHTTP}
+		Expect(makeImportEnv(testEnvVar, mockUID)).ToNot(ContainElement(HaveField("Name", common.ImporterVerify)))
+		testEnvVar.verify = true
+		testEnvVar.verifyChecksum = true
+		Expect(makeImportEnv(testEnvVar, mockUID)).To(ContainElements(
+			corev1.EnvVar{Name: common.ImporterVerify, Value: "true"},
+			corev1.EnvVar{Name: common.ImporterVerifyChecksum, Value: "true"},
+		))
+	})
+
+	It("Should ask to detect the content type only when none was declared", func() {
+		testEnvVar := &importPodEnvVar{source: cc.SourceHTTP, contentType: string(cdiv1.DataVolumeKubeVirt)}
+		Expect(makeImportEnv(testEnvVar, mockUID)).ToNot(ContainElement(HaveField("Name", common.ImporterDetectContentType)))
+		testEnvVar.detectContentType = true
+		Expect(makeImportEnv(testEnvVar, mockUID)).To(ContainElement(corev1.EnvVar{Name: common.ImporterDetectContentType, Value: "true"}))
+	})
+
+	It("Should tune the conversion with the defaults of the StorageProfile", func() {
+		mode := cdiv1.ConversionCacheModeNone
+		storageProfile := &cdiv1.StorageProfile{
+			ObjectMeta: metav1.ObjectMeta{Name: testStorageClass},
+			Status: cdiv1.StorageProfileStatus{
+				ConversionDefaults: &cdiv1.ConversionDefaults{
+					CacheMode:       &mode,
+					SparseThreshold: ptr.To(resource.MustParse("64Ki")),
+					Coroutines:      ptr.To[int32](16),
+				},
+			},
+		}
+		pvc := cc.CreatePvcInStorageClass("testPvc1", "default", &testStorageClass, map[string]string{cc.AnnEndpoint: testEndPoint, cc.AnnImportPod: "testpod"}, nil, corev1.ClaimBound)
+		reconciler := createImportReconciler(pvc, cc.CreateStorageClass(testStorageClass, nil), storageProfile)
+		podEnvVar, err := reconciler.createImportEnvVar(pvc)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(makeImportEnv(podEnvVar, mockUID)).To(ContainElements(
+			corev1.EnvVar{Name: common.CacheMode, Value: common.CacheModeTryNone},
+			corev1.EnvVar{Name: common.ImporterSparseThreshold, Value: "65536"},
+			corev1.EnvVar{Name: common.ImporterCoroutines, Value: "16"},
+		))
+	})
+
+	It("Should pass the download parallelism and part size only when set", func() {
+		testEnvVar := &importPodEnvVar{source: cc.SourceHTTP}
+		Expect(makeImportEnv(testEnvVar, mockUID)).ToNot(ContainElement(HaveField("Name", common.ImporterDownloadParallelism)))
+		Expect(makeImportEnv(testEnvVar, mockUID)).ToNot(ContainElement(HaveField("Name", common.ImporterDownloadPartSize)))
+		testEnvVar.downloadParallelism = "8"
+		testEnvVar.downloadPartSize = "32Mi"
+		env := makeImportEnv(testEnvVar, mockUID)
+		Expect(env).To(ContainElement(corev1.EnvVar{Name: common.ImporterDownloadParallelism, Value: "8"}))
+		Expect(env).To(ContainElement(corev1.EnvVar{Name: common.ImporterDownloadPartSize, Value: "32Mi"}))
+		Expect(env).To(ContainElement(corev1.EnvVar{Name: common.ImporterMemoryLimit, ValueFrom: &corev1.EnvVarSource{
+			ResourceFieldRef: &corev1.ResourceFieldSelector{Resource: "limits.memory"},
+		}}))
+	})
+
+	It("Should pass the curl options only when set", func() {
+		testEnvVar := &importPodEnvVar{source: cc.SourceHTTP}
+		Expect(makeImportEnv(testEnvVar, mockUID)).ToNot(ContainElement(HaveField("Name", common.ImporterCurlConnections)))
+		Expect(makeImportEnv(testEnvVar, mockUID)).ToNot(ContainElement(HaveField("Name", common.ImporterCurlTimeout)))
+		testEnvVar.curlConnections = "8"
+		testEnvVar.curlHTTPVersion = "2TLS"
+		testEnvVar.curlTLS13Ciphers = "TLS_AES_128_GCM_SHA256"
+		testEnvVar.curlTimeout = "5m"
+		env := makeImportEnv(testEnvVar, mockUID)
+		Expect(env).To(ContainElement(corev1.EnvVar{Name: common.ImporterCurlConnections, Value: "8"}))
+		Expect(env).To(ContainElement(corev1.EnvVar{Name: common.ImporterCurlHTTPVersion, Value: "2TLS"}))
+		Expect(env).To(ContainElement(corev1.EnvVar{Name: common.ImporterCurlTLS13Ciphers, Value: "TLS_AES_128_GCM_SHA256"}))
+		Expect(env).To(ContainElement(corev1.EnvVar{Name: common.ImporterCurlTimeout, Value: "5m"}))
+	})
+
+	It("Should pass the Azure secret as a credential directory", func() {
+		testEnvVar := &importPodEnvVar{source: cc.SourceAzure, secretName: "azure-secret"}
+		env := makeImportEnv(testEnvVar, mockUID)
+		Expect(env).To(ContainElement(corev1.EnvVar{Name: common.ImporterAzureCredentialDirVar, Value: common.ImporterAzureCredentialDir}))
+		Expect(env).ToNot(ContainElement(HaveField("Name", common.ImporterAccessKeyID)))
+	})
+
+	It("Should pass the SFTP secret as a credential directory", func() {
+		testEnvVar := &importPodEnvVar{source: cc.SourceSFTP, secretName: "sftp-secret"}
+		env := makeImportEnv(testEnvVar, mockUID)
+		Expect(env).To(ContainElement(corev1.EnvVar{Name: common.ImporterSFTPCredentialDirVar, Value: common.ImporterSFTPCredentialDir}))
+		Expect(env).ToNot(ContainElement(HaveField("Name", common.ImporterAccessKeyID)))
+	})
+
+	It("Should pass the OVA disk index of an http source", func() {
+		testEnvVar := &importPodEnvVar{source: cc.SourceHTTP, ovaDisk: "1"}
+		Expect(makeImportEnv(testEnvVar, mockUID)).To(ContainElement(corev1.EnvVar{Name: common.ImporterOVADisk, Value: "1"}))
+		testEnvVar.ovaDisk = ""
+		Expect(makeImportEnv(testEnvVar, mockUID)).ToNot(ContainElement(HaveField("Name", common.ImporterOVADisk)))
+	})
+
+	It("Should pass the XVA disk index of an http source", func() {
+		testEnvVar := &importPodEnvVar{source: cc.SourceHTTP, xvaDisk: "0"}
+		Expect(makeImportEnv(testEnvVar, mockUID)).To(ContainElement(corev1.EnvVar{Name: common.ImporterXVADisk, Value: "0"}))
+		testEnvVar.xvaDisk = ""
+		Expect(makeImportEnv(testEnvVar, mockUID)).ToNot(ContainElement(HaveField("Name", common.ImporterXVADisk)))
+	})
+
+	It("Should pass the checksum of the source", func() {
+		testEnvVar := &importPodEnvVar{source: cc.SourceGCS, checksum: "md5:0123456789abcdef0123456789abcdef"}
+		Expect(makeImportEnv(testEnvVar, mockUID)).To(ContainElement(corev1.EnvVar{Name: common.ImporterChecksum, Value: "md5:0123456789abcdef0123456789abcdef"}))
+		testEnvVar.checksum = ""
+		Expect(makeImportEnv(testEnvVar, mockUID)).ToNot(ContainElement(HaveField("Name", common.ImporterChecksum)))
+	})
+
+	It("Should mount the OAuth2 client secret of an http source", func() {
+		args := &importerPodArgs{
+			podEnvVar: &importPodEnvVar{source: cc.SourceHTTP, oauth2SecretName: "oauth2-client"},
+			pvc:       cc.CreatePvc("testPvc1", "default", nil, nil),
+		}
+		Expect(makeImporterVolumeSpec(args)).To(ContainElement(createSecretVolume(oauth2SecretVolumeName, "oauth2-client")))
+		Expect(makeImporterContainerSpec(args)[0].VolumeMounts).To(ContainElement(corev1.VolumeMount{
+			Name:      oauth2SecretVolumeName,
+			MountPath: common.ImporterOAuth2CredentialDir,
+		}))
+	})
+
+	It("Should mount the signature keyring of a source", func() {
+		args := &importerPodArgs{
+			podEnvVar: &importPodEnvVar{source: cc.SourceHTTP, signatureURL: "disk.img.sig", signatureKeyringSecret: "image-keys"},
+			pvc:       cc.CreatePvc("testPvc1", "default", nil, nil),
+		}
+		Expect(makeImportEnv(args.podEnvVar, mockUID)).To(ContainElement(corev1.EnvVar{Name: common.ImporterSignatureURL, Value: "disk.img.sig"}))
+		Expect(makeImporterVolumeSpec(args)).To(ContainElement(createSecretVolume(signatureKeyringVolumeName, "image-keys")))
+		Expect(makeImporterContainerSpec(args)[0].VolumeMounts).To(ContainElement(corev1.VolumeMount{
+			Name:      signatureKeyringVolumeName,
+			MountPath: common.ImporterSignatureKeyringDir,
+		}))
+	})
+
+	DescribeTable("Should mount the client certificate of a source", func(source string) {
+		args := &importerPodArgs{
+			podEnvVar: &importPodEnvVar{source: source, clientCertSecret: "client-cert"},
+			pvc:       cc.CreatePvc("testPvc1", "default", nil, nil),
+		}
+		Expect(makeImporterVolumeSpec(args)).To(ContainElement(createSecretVolume(clientCertVolumeName, "client-cert")))
+		Expect(makeImporterContainerSpec(args)[0].VolumeMounts).To(ContainElement(corev1.VolumeMount{
+			Name:      clientCertVolumeName,
+			MountPath: common.ImporterClientCertDir,
+		}))
+	},
+		Entry("http", cc.SourceHTTP),
+		Entry("s3", cc.SourceS3),
+		Entry("registry", cc.SourceRegistry),
+	)
+
+	It("Should pass the RBD secret as a credential directory", func() {
+		testEnvVar := &importPodEnvVar{source: cc.SourceRBD, secretName: "ceph-creds", rbdImage: "vms/disk@base"}
+		env := makeImportEnv(testEnvVar, mockUID)
+		Expect(env).To(ContainElement(corev1.EnvVar{Name: common.ImporterRBDCredentialDirVar, Value: common.ImporterRBDCredentialDir}))
+		Expect(env).To(ContainElement(corev1.EnvVar{Name: common.ImporterRBDImage, Value: "vms/disk@base"}))
+		Expect(env).ToNot(ContainElement(HaveField("Name", common.ImporterAccessKeyID)))
+	})
+
+	It("Should mount the export secret as a credential directory", func() {
+		args := &importerPodArgs{
+			podEnvVar: &importPodEnvVar{source: cc.SourceExport, secretName: "export-token"},
+			pvc:       cc.CreatePvc("testPvc1", "default", nil, nil),
+		}
+		env := makeImportEnv(args.podEnvVar, mockUID)
+		Expect(env).To(ContainElement(corev1.EnvVar{Name: common.ImporterExportCredentialDirVar, Value: common.ImporterExportCredentialDir}))
+		Expect(env).ToNot(ContainElement(HaveField("Name", common.ImporterAccessKeyID)))
+		Expect(makeImporterVolumeSpec(args)).To(ContainElement(createSecretVolume(SecretVolName, "export-token")))
+		Expect(makeImporterContainerSpec(args)[0].VolumeMounts).To(ContainElement(corev1.VolumeMount{
+			Name:      SecretVolName,
+			MountPath: common.ImporterExportCredentialDir,
+		}))
+	})
+
+	It("Should run the source plugin as a sidecar sharing its socket", func() {
+		image := "quay.io/example/tape-plugin:v1"
+		args := &importerPodArgs{
+			podEnvVar: &importPodEnvVar{
+				source:           cc.SourcePlugin,
+				secretName:       "tape-credentials",
+				pluginParameters: `{"volume":"vm-backup-0042"}`,
+			},
+			pvc:             cc.CreatePvc("testPvc1", "default", nil, nil),
+			pluginImageName: &image,
+		}
+		env := makeImportEnv(args.podEnvVar, mockUID)
+		Expect(env).To(ContainElement(corev1.EnvVar{Name: common.ImporterPluginParameters, Value: `{"volume":"vm-backup-0042"}`}))
+		Expect(env).ToNot(ContainElement(HaveField("Name", common.ImporterAccessKeyID)))
+		Expect(makeImporterVolumeSpec(args)).To(ContainElement(createSecretVolume(SecretVolName, "tape-credentials")))
+		socketMount := corev1.VolumeMount{
+			Name:      pluginSocketVolumeName,
+			MountPath: common.ImporterPluginSocketDir,
+		}
+		Expect(makeImporterContainerSpec(args)[0].VolumeMounts).To(ContainElement(socketMount))
+		initContainers := makeImporterInitContainersSpec(args)
+		Expect(initContainers).To(HaveLen(1))
+		Expect(initContainers[0].Image).To(Equal(image))
+		Expect(initContainers[0].RestartPolicy).To(HaveValue(Equal(corev1.ContainerRestartPolicyAlways)))
+		Expect(initContainers[0].Env).To(ContainElements(
+			corev1.EnvVar{Name: common.PluginSocketVar, Value: common.ImporterPluginSocket},
+			corev1.EnvVar{Name: common.PluginSecretDirVar, Value: common.ImporterPluginSecretDir},
+		))
+		Expect(initContainers[0].VolumeMounts).To(ContainElements(socketMount, corev1.VolumeMount{
+			Name:      SecretVolName,
+			MountPath: common.ImporterPluginSecretDir,
+		}))
+	})
+
+	It("Should mount the Proxmox secret as a credential directory", func() {
+		args := &importerPodArgs{
+			podEnvVar: &importPodEnvVar{
+				source:      cc.SourceProxmox,
+				secretName:  "proxmox-credentials",
+				proxmoxNode: "pve2",
+				proxmoxVMID: "100",
+				proxmoxDisk: "scsi0",
+			},
+			pvc: cc.CreatePvc("testPvc1", "default", nil, nil),
+		}
+		env := makeImportEnv(args.podEnvVar, mockUID)
+		Expect(env).To(ContainElements(
+			corev1.EnvVar{Name: common.ImporterProxmoxCredentialDirVar, Value: common.ImporterProxmoxCredentialDir},
+			corev1.EnvVar{Name: common.ImporterProxmoxNode, Value: "pve2"},
+			corev1.EnvVar{Name: common.ImporterProxmoxVMID, Value: "100"},
+			corev1.EnvVar{Name: common.ImporterProxmoxDisk, Value: "scsi0"},
+		))
+		Expect(env).ToNot(ContainElement(HaveField("Name", common.ImporterAccessKeyID)))
+		Expect(makeImporterVolumeSpec(args)).To(ContainElement(createSecretVolume(SecretVolName, "proxmox-credentials")))
+		Expect(makeImporterContainerSpec(args)[0].VolumeMounts).To(ContainElement(corev1.VolumeMount{
+			Name:      SecretVolName,
+			MountPath: common.ImporterProxmoxCredentialDir,
+		}))
+	})
+
+	It("Should pass the v2v options and mount the virtio-win PVC", func() {
+		args := &importerPodArgs{
+			podEnvVar: &importPodEnvVar{
+				source:          cc.SourceV2V,
+				secretName:      "vsphere-creds",
+				v2vVM:           "win2019",
+				v2vDiskIndex:    "0",
+				v2vBlockDriver:  "virtio-scsi",
+				v2vMACMappings:  "00:50:56:aa:bb:01:network:pod",
+				v2vVirtioWinPVC: "virtio-win",
+			},
+			pvc: cc.CreatePvc("testPvc1", "default", nil, nil),
+		}
+		env := makeImportEnv(args.podEnvVar, mockUID)
+		Expect(env).To(ContainElements(
+			corev1.EnvVar{Name: common.ImporterV2VVM, Value: "win2019"},
+			corev1.EnvVar{Name: common.ImporterV2VDiskIndex, Value: "0"},
+			corev1.EnvVar{Name: common.ImporterV2VBlockDriver, Value: "virtio-scsi"},
+			corev1.EnvVar{Name: common.ImporterV2VMACMappings, Value: "00:50:56:aa:bb:01:network:pod"},
+			corev1.EnvVar{Name: common.ImporterV2VVirtioWin, Value: "/virtio-win/disk.img"},
+		))
+		Expect(env).To(ContainElement(HaveField("Name", common.ImporterAccessKeyID)))
+		Expect(makeImporterVolumeSpec(args)).To(ContainElement(corev1.Volume{
+			Name: virtioWinVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "virtio-win", ReadOnly: true},
+			},
+		}))
+		Expect(makeImporterContainerSpec(args)[0].VolumeMounts).To(ContainElement(corev1.VolumeMount{
+			Name:      virtioWinVolumeName,
+			MountPath: common.ImporterV2VVirtioWinDir,
+			ReadOnly:  true,
+		}))
+	})
+
+	DescribeTable("Should mount the PVC of the source DataVolume of a chained import", func(volumeMode corev1.PersistentVolumeMode, ep string) {
+		sourcePvc := cc.CreatePvc("source-dv", "default", nil, nil)
+		sourcePvc.Spec.VolumeMode = &volumeMode
+		annotations := map[string]string{cc.AnnEndpoint: "source-dv", cc.AnnSource: cc.SourceDataVolume, cc.AnnImportPod: "testpod"}
+		pvc := cc.CreatePvcInStorageClass("testPvc1", "default", &testStorageClass, annotations, nil, corev1.ClaimBound)
+		reconciler := createImportReconciler(pvc, sourcePvc)
+		podEnvVar, err := reconciler.createImportEnvVar(pvc)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(podEnvVar.ep).To(Equal(ep))
+		Expect(podEnvVar.sourceClaimName).To(Equal("source-dv"))
+
+		args := &importerPodArgs{podEnvVar: podEnvVar, pvc: pvc}
+		Expect(makeImporterVolumeSpec(args)).To(ContainElement(corev1.Volume{
+			Name: sourceDataVolumeVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "source-dv", ReadOnly: true},
+			},
+		}))
+		container := makeImporterContainerSpec(args)[0]
+		if volumeMode == corev1.PersistentVolumeBlock {
+			Expect(container.VolumeDevices).To(ContainElement(corev1.VolumeDevice{
+				Name:       sourceDataVolumeVolumeName,
+				DevicePath: common.ImporterSourceDataVolumeDevice,
+			}))
+		} else {
+			Expect(container.VolumeMounts).To(ContainElement(corev1.VolumeMount{
+				Name:      sourceDataVolumeVolumeName,
+				MountPath: common.ImporterSourceDataVolumeDir,
+				ReadOnly:  true,
+			}))
+		}
+	},
+		Entry("filesystem", corev1.PersistentVolumeFilesystem, "/source-datavolume/disk.img"),
+		Entry("block", corev1.PersistentVolumeBlock, common.ImporterSourceDataVolumeDevice),
+	)
+
+	It("Should sparsify and encrypt the image when asked to", func() {
+		testEnvVar := &importPodEnvVar{source: cc.SourceDataVolume}
+		Expect(makeImportEnv(testEnvVar, mockUID)).ToNot(ContainElement(HaveField("Name", common.ImporterSparsify)))
+		Expect(makeImportEnv(testEnvVar, mockUID)).ToNot(ContainElement(HaveField("Name", common.ImporterEncryptionPassphraseFile)))
+		testEnvVar.sparsify = true
+		testEnvVar.encryptionSecret = "passphrase-secret"
+		Expect(makeImportEnv(testEnvVar, mockUID)).To(ContainElements(
+			corev1.EnvVar{Name: common.ImporterSparsify, Value: "true"},
+			corev1.EnvVar{Name: "LIBGUESTFS_BACKEND", Value: "direct"},
+			corev1.EnvVar{Name: common.ImporterEncryptionPassphraseFile, Value: "/encryption/passphrase"},
+		))
+		args := &importerPodArgs{podEnvVar: testEnvVar, pvc: cc.CreatePvc("testPvc1", "default", nil, nil)}
+		Expect(makeImporterVolumeSpec(args)).To(ContainElement(createSecretVolume(encryptionSecretVolumeName, "passphrase-secret")))
+		Expect(makeImporterContainerSpec(args)[0].VolumeMounts).To(ContainElement(corev1.VolumeMount{
+			Name:      encryptionSecretVolumeName,
+			MountPath: common.ImporterEncryptionDir,
+		}))
+	})
+
+	It("Should not encrypt images stored as qcow2", func() {
+		annotations := map[string]string{
+			cc.AnnEndpoint:         testEndPoint,
+			cc.AnnImportPod:        "testpod",
+			cc.AnnStorageFormat:    common.StorageFormatQcow2,
+			cc.AnnEncryptionSecret: "passphrase-secret",
+		}
+		pvc := cc.CreatePvcInStorageClass("testPvc1", "default", &testStorageClass, annotations, nil, corev1.ClaimBound)
+		reconciler := createImportReconciler(pvc)
+		_, err := reconciler.createImportEnvVar(pvc)
+		Expect(err).To(MatchError("encryption can not be combined with a storage format"))
+		event := <-reconciler.recorder.(*record.FakeRecorder).Events
+		Expect(event).To(ContainSubstring(EncryptionNotValid))
+	})
+
+	It("Should pass the web seeds and the file of a torrent", func() {
+		testEnvVar := &importPodEnvVar{
+			source:          cc.SourceTorrent,
+			torrentWebSeeds: "https://mirror-a.example.com/fedora.qcow2 https://mirror-b.example.com/fedora.qcow2",
+			torrentFile:     "fedora/fedora.qcow2",
+		}
+		env := makeImportEnv(testEnvVar, mockUID)
+		Expect(env).To(ContainElements(
+			corev1.EnvVar{Name: common.ImporterTorrentWebSeeds, Value: "https://mirror-a.example.com/fedora.qcow2 https://mirror-b.example.com/fedora.qcow2"},
+			corev1.EnvVar{Name: common.ImporterTorrentFile, Value: "fedora/fedora.qcow2"},
+		))
+	})
+
+	It("Should pass the Glance secret as a credential directory", func() {
+		testEnvVar := &importPodEnvVar{source: cc.SourceGlance, secretName: "openstack-creds", glanceImage: "fedora-39"}
+		env := makeImportEnv(testEnvVar, mockUID)
+		Expect(env).To(ContainElement(corev1.EnvVar{Name: common.ImporterGlanceCredentialDirVar, Value: common.ImporterGlanceCredentialDir}))
+		Expect(env).To(ContainElement(corev1.EnvVar{Name: common.ImporterGlanceImage, Value: "fedora-39"}))
+		Expect(env).ToNot(ContainElement(HaveField("Name", common.ImporterAccessKeyID)))
+	})
+
+	It("Should take all the S3 secret keys as optional", func() {
+		testEnvVar := &importPodEnvVar{source: cc.SourceS3, secretName: "s3-secret"}
+		env := makeImportEnv(testEnvVar, mockUID)
+		keys := map[string]string{}
+		for _, e := range env {
+			if e.ValueFrom != nil && e.ValueFrom.SecretKeyRef != nil {
+				Expect(e.ValueFrom.SecretKeyRef.Name).To(Equal("s3-secret"))
+				Expect(e.ValueFrom.SecretKeyRef.Optional).To(HaveValue(BeTrue()))
+				keys[e.Name] = e.ValueFrom.SecretKeyRef.Key
+			}
+		}
+		Expect(keys).To(Equal(map[string]string{
+			common.ImporterAccessKeyID:  common.KeyAccess,
+			common.ImporterSecretKey:    common.KeySecret,
+			common.ImporterSessionToken: common.KeySessionToken,
+			common.ImporterRoleARN:      common.KeyRoleARN,
+			common.ImporterExternalID:   common.KeyExternalID,
+		}))
+	})
+})
+
+var _ = Describe("getImportServiceAccount", func() {
+	newS3Source := func(serviceAccountName string) *cdiv1.DataVolumeSourceS3 {
+		return &cdiv1.DataVolumeSourceS3{URL: "https://s3.example.com/bucket/disk.img", ServiceAccountName: serviceAccountName}
+	}
+
+	It("should honor the service account of the DataVolume owning the PVC", func() {
+		dv := cc.NewImportDataVolume("test-dv")
+		dv.Spec.Source = &cdiv1.DataVolumeSource{S3: newS3Source("s3-reader")}
+		pvc := cc.CreatePvc("test-dv", dv.Namespace, map[string]string{cc.AnnImportServiceAccount: "s3-reader"}, nil)
+		pvc.OwnerReferences = []metav1.OwnerReference{*metav1.NewControllerRef(dv, cdiv1.SchemeGroupVersion.WithKind("DataVolume"))}
+		reconciler := createImportReconciler(dv, pvc)
+		serviceAccountName, err := reconciler.getImportServiceAccount(pvc)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(serviceAccountName).To(Equal("s3-reader"))
+	})
+
+	It("should honor the service account of the VolumeImportSource populating the target of a prime PVC", func() {
+		volumeImportSource := &cdiv1.VolumeImportSource{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-source", Namespace: "default"},
+			Spec:       cdiv1.VolumeImportSourceSpec{Source: &cdiv1.ImportSourceType{S3: newS3Source("s3-reader")}},
+		}
+		target := cc.CreatePvc("target", "default", nil, nil)
+		target.UID = "target-uid"
+		target.Spec.DataSourceRef = &corev1.TypedObjectReference{APIGroup: ptr.To(cc.AnnAPIGroup), Kind: cdiv1.VolumeImportSourceRef, Name: "test-source"}
+		pvc := cc.CreatePvc("prime-target", "default", map[string]string{cc.AnnImportServiceAccount: "s3-reader"}, nil)
+		pvc.OwnerReferences = []metav1.OwnerReference{*metav1.NewControllerRef(target, corev1.SchemeGroupVersion.WithKind("PersistentVolumeClaim"))}
+		reconciler := createImportReconciler(volumeImportSource, target, pvc)
+		serviceAccountName, err := reconciler.getImportServiceAccount(pvc)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(serviceAccountName).To(Equal("s3-reader"))
+	})
+
+	It("should refuse the service account of a PVC not populated from a DataVolume or VolumeImportSource", func() {
+		pvc := cc.CreatePvc("test-pvc", "default", map[string]string{cc.AnnImportServiceAccount: "s3-reader"}, nil)
+		reconciler := createImportReconciler(pvc)
+		_, err := reconciler.getImportServiceAccount(pvc)
+		Expect(err).To(MatchError(ContainSubstring("service account s3-reader of PVC default/test-pvc is not named")))
+		Expect(reconciler.recorder.(*record.FakeRecorder).Events).To(Receive(ContainSubstring(ImportServiceAccountNotAuthorized)))
+	})
+
+	It("should refuse a service account the DataVolume owning the PVC does not name", func() {
+		dv := cc.NewImportDataVolume("test-dv")
+		dv.Spec.Source = &cdiv1.DataVolumeSource{S3: newS3Source("s3-reader")}
+		pvc := cc.CreatePvc("test-dv", dv.Namespace, map[string]string{cc.AnnImportServiceAccount: "cluster-admin"}, nil)
+		pvc.OwnerReferences = []metav1.OwnerReference{*metav1.NewControllerRef(dv, cdiv1.SchemeGroupVersion.WithKind("DataVolume"))}
+		reconciler := createImportReconciler(dv, pvc)
+		_, err := reconciler.getImportServiceAccount(pvc)
+		Expect(err).To(HaveOccurred())
+	})
 })
 
 var _ = Describe("getSecretName", func() {
@@ -1175,6 +2728,25 @@ var _ = Describe("GetEndpoint", func() {
 	)
 })
 
+var _ = Describe("splitImporterLog", func() {
+	It("should extract the nbdkit log and the qemu-img output", func() {
+		importerLog := strings.Join([]string{
+			"I1015 10:00:00.000000       1 importer.go:103] Starting importer",
+			"I1015 10:00:01.000000       1 nbdkit.go:562] Log line from nbdkit: curl: connecting",
+			"E1015 10:00:02.000000       1 prlimit.go:178] qemu-img failed output is:",
+			"E1015 10:00:02.000000       1 prlimit.go:179] ",
+			"E1015 10:00:02.000000       1 prlimit.go:180] qemu-img: Could not open 'nbd+unix:///': Failed to read data",
+			"Unexpected end-of-file",
+			"E1015 10:00:03.000000       1 data-processor.go:251] exit status 1",
+		}, "\n")
+		nbdkit, qemuImg := splitImporterLog(importerLog)
+		Expect(nbdkit).To(Equal("curl: connecting\n"))
+		Expect(qemuImg).To(Equal("E1015 10:00:02.000000       1 prlimit.go:179] \n" +
+			"E1015 10:00:02.000000       1 prlimit.go:180] qemu-img: Could not open 'nbd+unix:///': Failed to read data\n" +
+			"Unexpected end-of-file\n"))
+	})
+})
+
 func createImportReconciler(objects ...runtime.Object) *ImportReconciler {
 	objs := []runtime.Object{}
 	objs = append(objs, objects...)
@@ -1200,6 +2772,7 @@ func createImportReconciler(objects ...runtime.Object) *ImportReconciler {
 	r := &ImportReconciler{
 		client:         cl,
 		uncachedClient: cl,
+		k8sClient:      k8sfake.NewSimpleClientset(),
 		scheme:         s,
 		log:            importLog,
 		recorder:       rec,
@@ -1372,6 +2945,13 @@ func createSecret(name, ns, accessKey, secretKey string, labels map[string]strin
 	}
 }
 
+func updateCDIConfig(c client.Client, update func(*cdiv1.CDIConfigSpec)) {
+	cdiConfig := &cdiv1.CDIConfig{}
+	Expect(c.Get(context.TODO(), types.NamespacedName{Name: common.ConfigName}, cdiConfig)).To(Succeed())
+	update(&cdiConfig.Spec)
+	Expect(c.Update(context.TODO(), cdiConfig)).To(Succeed())
+}
+
 func updateCdiWithTestNodePlacement(c client.Client) sdkapi.NodePlacement {
 	cr := &cdiv1.CDI{}
 	err := c.Get(context.TODO(), types.NamespacedName{Name: "cdi"}, cr)
@@ -1405,3 +2985,28 @@ func updateCdiWithTestNodePlacement(c client.Client) sdkapi.NodePlacement {
 
 	return workloads
 }
+
+var _ = Describe("Import retry policy", func() {
+	DescribeTable("classifyImportError should classify", func(message string, expected cdiv1.DataVolumeRetryErrorClass) {
+		Expect(classifyImportError(&corev1.ContainerStateTerminated{Message: message})).To(Equal(expected))
+	},
+		Entry("failures by the class the importer reported", `{"message":"expected status code 200, got 404.","failureReason":"SourceUnreachable","failureClass":"Source"}`, cdiv1.RetryOnSource),
+		Entry("failures without a reason by their class", `{"message":"checksum mismatch","failureClass":"Source"}`, cdiv1.RetryOnSource),
+		Entry("failures of older importers by their reason", `{"message":"expected status code 200, got 429.","failureReason":"Throttled"}`, cdiv1.RetryOnNetwork),
+		Entry("unreachable sources of older importers as network errors", `{"message":"dial tcp: i/o timeout","failureReason":"SourceUnreachable"}`, cdiv1.RetryOnNetwork),
+		Entry("unclassified failures as other errors", `{"message":"exit status 1","observedSourceDigest":"sha256:1234"}`, cdiv1.RetryOnOther),
+		Entry("plain messages as other errors, whatever they say", "write /data/disk.img: no space left on device", cdiv1.RetryOnOther),
+	)
+
+	DescribeTable("importRetryBackoff should", func(policy *cdiv1.DataVolumeRetryPolicy, count int, expected time.Duration) {
+		Expect(importRetryBackoff(policy, count)).To(Equal(expected))
+	},
+		Entry("default to 10s", &cdiv1.DataVolumeRetryPolicy{}, 0, 10*time.Second),
+		Entry("double for each retry", &cdiv1.DataVolumeRetryPolicy{}, 2, 40*time.Second),
+		Entry("stop at the default cap", &cdiv1.DataVolumeRetryPolicy{}, 10, 5*time.Minute),
+		Entry("stop at the cap", &cdiv1.DataVolumeRetryPolicy{
+			BackoffBase: &metav1.Duration{Duration: time.Minute},
+			BackoffCap:  &metav1.Duration{Duration: 3 * time.Minute},
+		}, 2, 3*time.Minute),
+	)
+})
@@ -0,0 +1,167 @@
+/*
+Copyright 2026 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/go-logr/logr"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+
+	"kubevirt.io/containerized-data-importer/pkg/common"
+	cc "kubevirt.io/containerized-data-importer/pkg/controller/common"
+	"kubevirt.io/containerized-data-importer/pkg/util/naming"
+)
+
+const (
+	// FailureArtifactsTerminationMessage is the key of the termination message of the failed importer in the failure artifacts ConfigMap
+	FailureArtifactsTerminationMessage = "terminationMessage"
+	// FailureArtifactsImporterLog is the key of the end of the log of the failed importer in the failure artifacts ConfigMap
+	FailureArtifactsImporterLog = "importer.log"
+	// FailureArtifactsNbdkitLog is the key of the nbdkit log lines of the failed importer in the failure artifacts ConfigMap
+	FailureArtifactsNbdkitLog = "nbdkit.log"
+	// FailureArtifactsQemuImgOutput is the key of the output of the failed qemu-img commands in the failure artifacts ConfigMap
+	FailureArtifactsQemuImgOutput = "qemu-img.log"
+
+	// annFailedContainerID is the ID of the importer container the failure artifacts were collected from
+	annFailedContainerID = cc.AnnAPIGroup + "/storage.import.failedContainerID"
+
+	// failureArtifactsLogLines is how many lines are read from the end of the log of the failed importer
+	failureArtifactsLogLines = 1000
+	// failureArtifactsMaxLogSize keeps the artifacts well below the size limit of a ConfigMap
+	failureArtifactsMaxLogSize = 256 * 1024
+
+	nbdkitLogMarker     = "Log line from nbdkit: "
+	qemuImgOutputMarker = "qemu-img failed output is:"
+)
+
+// klogHeader matches the header of the lines logged by klog, the lines without it continue the previous message
+var klogHeader = regexp.MustCompile(`^[IWEF]\d{4} `)
+
+// GetFailureArtifactsConfigMapName returns the name of the ConfigMap holding the failure artifacts of the imports of a PVC
+func GetFailureArtifactsConfigMapName(pvcName string) string {
+	return naming.GetResourceName("import-failure", pvcName)
+}
+
+// collectFailureArtifacts keeps the termination message and the log of the failed importer container in a ConfigMap
+// owned by the PVC, so they outlive the pod. The ConfigMap of a PVC' is owned by its target PVC, which it is named after.
+func (r *ImportReconciler) collectFailureArtifacts(pvc *corev1.PersistentVolumeClaim, pod *corev1.Pod, status corev1.ContainerStatus, log logr.Logger) error {
+	anno := pvc.GetAnnotations()
+	if anno[cc.AnnCollectFailureArtifacts] != "true" {
+		return nil
+	}
+	// The container restarted by the kubelet keeps the log of its failed run until it terminates again
+	terminated, previous := status.State.Terminated, false
+	if terminated == nil {
+		terminated, previous = status.LastTerminationState.Terminated, true
+	}
+	if terminated == nil || terminated.ExitCode == 0 {
+		return nil
+	}
+	owner := metav1.NewControllerRef(pvc, corev1.SchemeGroupVersion.WithKind("PersistentVolumeClaim"))
+	if ref := metav1.GetControllerOf(pvc); ref != nil && anno[cc.AnnPopulatorKind] != "" {
+		owner = ref
+	}
+	name := GetFailureArtifactsConfigMapName(owner.Name)
+
+	// ConfigMaps are only cached in the CDI namespace
+	configMap := &corev1.ConfigMap{}
+	err := r.uncachedClient.Get(context.TODO(), types.NamespacedName{Namespace: pvc.Namespace, Name: name}, configMap)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return err
+	}
+	exists := err == nil
+	if exists && configMap.Annotations[annFailedContainerID] == terminated.ContainerID {
+		anno[cc.AnnFailureArtifacts] = name
+		return nil
+	}
+
+	data := map[string]string{FailureArtifactsTerminationMessage: terminated.Message}
+	if importerLog, err := r.getImporterLog(pod, previous); err != nil {
+		// The termination message is kept anyway
+		log.Error(err, "Unable to read the log of the failed importer", "pod.Name", pod.Name)
+	} else {
+		data[FailureArtifactsImporterLog] = importerLog
+		data[FailureArtifactsNbdkitLog], data[FailureArtifactsQemuImgOutput] = splitImporterLog(importerLog)
+	}
+
+	configMap.Name = name
+	configMap.Namespace = pvc.Namespace
+	configMap.OwnerReferences = []metav1.OwnerReference{*owner}
+	cc.AddLabel(configMap, common.CDILabelKey, common.CDILabelValue)
+	cc.AddAnnotation(configMap, annFailedContainerID, terminated.ContainerID)
+	configMap.Data = data
+	if exists {
+		err = r.client.Update(context.TODO(), configMap)
+	} else {
+		err = r.client.Create(context.TODO(), configMap)
+	}
+	if err != nil {
+		return err
+	}
+	log.V(1).Info("Collected the artifacts of the failed import", "configMap.Name", name)
+	anno[cc.AnnFailureArtifacts] = name
+	return nil
+}
+
+// getImporterLog returns the end of the log of the importer container, or of its previous run, truncated to fit in a
+// ConfigMap
+func (r *ImportReconciler) getImporterLog(pod *corev1.Pod, previous bool) (string, error) {
+	req := r.k8sClient.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &corev1.PodLogOptions{
+		Container: common.ImporterPodName,
+		Previous:  previous,
+		TailLines: ptr.To[int64](failureArtifactsLogLines),
+	})
+	raw, err := req.DoRaw(context.TODO())
+	if err != nil {
+		return "", err
+	}
+	if len(raw) > failureArtifactsMaxLogSize {
+		raw = raw[len(raw)-failureArtifactsMaxLogSize:]
+	}
+	return string(raw), nil
+}
+
+// splitImporterLog returns the lines nbdkit logged and the output of the failed qemu-img commands from the importer log
+func splitImporterLog(importerLog string) (string, string) {
+	var nbdkit, qemuImg strings.Builder
+	inQemuImgOutput := false
+	for _, line := range strings.Split(importerLog, "\n") {
+		if i := strings.Index(line, nbdkitLogMarker); i >= 0 {
+			nbdkit.WriteString(line[i+len(nbdkitLogMarker):] + "\n")
+		}
+		if strings.Contains(line, qemuImgOutputMarker) {
+			inQemuImgOutput = true
+			continue
+		}
+		// The output of the failed command is logged in the two next messages
+		if inQemuImgOutput && klogHeader.MatchString(line) && !strings.Contains(line, "prlimit.go") {
+			inQemuImgOutput = false
+		}
+		if inQemuImgOutput && strings.TrimSpace(line) != "" {
+			qemuImg.WriteString(line + "\n")
+		}
+	}
+	return nbdkit.String(), qemuImg.String()
+}
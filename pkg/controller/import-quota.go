@@ -0,0 +1,196 @@
+/*
+Copyright 2026 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+	"kubevirt.io/containerized-data-importer/pkg/common"
+	cc "kubevirt.io/containerized-data-importer/pkg/controller/common"
+)
+
+// importQuotaRequeue is how often an import over an ImportQuota checks whether it may start
+const importQuotaRequeue = 10 * time.Second
+
+// importQuotaUsage is the usage of the ImportQuotas of a namespace
+type importQuotaUsage struct {
+	concurrentImports int32
+	importedBytes     resource.Quantity
+	scratchSpace      resource.Quantity
+}
+
+// checkImportQuotas returns whether the importer pod of the pvc has to wait for the ImportQuotas of its namespace, in
+// which case the running condition of the pvc says which quota is exceeded. The usage of the quotas is updated on the
+// way.
+func checkImportQuotas(ctx context.Context, c client.Client, recorder record.EventRecorder, pvc *corev1.PersistentVolumeClaim, pod types.NamespacedName, requiresScratch bool, log logr.Logger) (bool, error) {
+	quotas := &cdiv1.ImportQuotaList{}
+	if err := c.List(ctx, quotas, client.InNamespace(pvc.Namespace)); err != nil {
+		return false, err
+	}
+	if len(quotas.Items) == 0 {
+		return false, nil
+	}
+
+	usage, self, err := getImportQuotaUsage(ctx, c, pvc, pod, requiresScratch)
+	if err != nil {
+		return false, err
+	}
+	exceeded := ""
+	for i := range quotas.Items {
+		quota := &quotas.Items[i]
+		if err := updateImportQuotaStatus(ctx, c, quota, usage); err != nil {
+			return false, err
+		}
+		if reason := importQuotaExceeded(&quota.Spec, usage, self); reason != "" && exceeded == "" {
+			exceeded = fmt.Sprintf("Import quota %s exceeded: %s", quota.Name, reason)
+		}
+	}
+	if exceeded == "" {
+		return false, nil
+	}
+
+	anno := pvc.GetAnnotations()
+	if anno[cc.AnnRunningConditionReason] != ImportQuotaExceededReason || anno[cc.AnnRunningConditionMessage] != exceeded {
+		log.V(1).Info("Import quota exceeded, waiting", "pod.Name", pod.Name, "reason", exceeded)
+		anno[cc.AnnRunningCondition] = "false"
+		anno[cc.AnnRunningConditionMessage] = exceeded
+		anno[cc.AnnRunningConditionReason] = ImportQuotaExceededReason
+		if err := c.Update(ctx, pvc); err != nil {
+			return false, err
+		}
+		recorder.Event(pvc, corev1.EventTypeWarning, ImportQuotaExceededReason, exceeded)
+	}
+	return true, nil
+}
+
+// getImportQuotaUsage returns the usage of the namespace of the pvc without its import, and what its import adds to it
+func getImportQuotaUsage(ctx context.Context, c client.Client, pvc *corev1.PersistentVolumeClaim, pod types.NamespacedName, requiresScratch bool) (importQuotaUsage, importQuotaUsage, error) {
+	usage := importQuotaUsage{}
+	pods := &corev1.PodList{}
+	if err := c.List(ctx, pods, client.InNamespace(pvc.Namespace), client.MatchingLabels{
+		common.CDILabelKey:       common.CDILabelValue,
+		common.CDIComponentLabel: common.ImporterPodName,
+	}); err != nil {
+		return usage, usage, err
+	}
+	for _, p := range pods.Items {
+		if p.Name == pod.Name || p.DeletionTimestamp != nil || p.Status.Phase == corev1.PodSucceeded || p.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		usage.concurrentImports++
+	}
+
+	pvcs := &corev1.PersistentVolumeClaimList{}
+	if err := c.List(ctx, pvcs, client.InNamespace(pvc.Namespace)); err != nil {
+		return usage, usage, err
+	}
+	selfClaim, _ := importQuotaClaim(pvc)
+	selfScratch := createScratchNameFromPvc(pvc)
+	scratchExists := false
+	for i := range pvcs.Items {
+		claim := &pvcs.Items[i]
+		size := claim.Spec.Resources.Requests[corev1.ResourceStorage]
+		if claim.Labels[common.CDIComponentLabel] == common.ScratchNameSuffix {
+			usage.scratchSpace.Add(size)
+			scratchExists = scratchExists || claim.Name == selfScratch
+			continue
+		}
+		if name, ok := importQuotaClaim(claim); ok && name != selfClaim && name == claim.Name {
+			usage.importedBytes.Add(size)
+		}
+	}
+
+	self := importQuotaUsage{concurrentImports: 1}
+	self.importedBytes = pvc.Spec.Resources.Requests[corev1.ResourceStorage].DeepCopy()
+	// The scratch space is created along with the importer pod, as large as its PVC unless its DataVolume says otherwise
+	if requiresScratch && !scratchExists {
+		self.scratchSpace = self.importedBytes.DeepCopy()
+		overrides, err := getScratchSpaceOverrides(pvc)
+		if err != nil {
+			return usage, self, err
+		}
+		if overrides.Size != nil {
+			self.scratchSpace = overrides.Size.DeepCopy()
+		} else if multiplier, err := strconv.ParseFloat(overrides.SizeMultiplier, 64); err == nil {
+			self.scratchSpace = *resource.NewQuantity(int64(float64(self.scratchSpace.Value())*multiplier), resource.BinarySI)
+		}
+	}
+	return usage, self, nil
+}
+
+// importQuotaClaim returns the name of the PVC an import counts against, the target of a PVC prime, and whether the
+// PVC is imported
+func importQuotaClaim(pvc *corev1.PersistentVolumeClaim) (string, bool) {
+	if owner := metav1.GetControllerOf(pvc); owner != nil && owner.Kind == "PersistentVolumeClaim" {
+		return owner.Name, true
+	}
+	_, imported := pvc.Annotations[cc.AnnImportPod]
+	return pvc.Name, imported || pvc.Annotations[cc.AnnPopulatorKind] == cdiv1.VolumeImportSourceRef
+}
+
+// importQuotaExceeded returns which limit of the quota the import would exceed, empty if none
+func importQuotaExceeded(spec *cdiv1.ImportQuotaSpec, usage, self importQuotaUsage) string {
+	if limit := spec.MaxConcurrentImports; limit != nil && usage.concurrentImports+self.concurrentImports > *limit {
+		return fmt.Sprintf("%d imports running, the limit is %d", usage.concurrentImports, *limit)
+	}
+	exceeds := func(used, added resource.Quantity, limit *resource.Quantity) bool {
+		if limit == nil || added.IsZero() {
+			return false
+		}
+		used.Add(added)
+		return used.Cmp(*limit) > 0
+	}
+	if limit := spec.MaxImportedBytes; exceeds(usage.importedBytes, self.importedBytes, limit) {
+		return fmt.Sprintf("%s imported, importing %s more would exceed the limit of %s", usage.importedBytes.String(), self.importedBytes.String(), limit.String())
+	}
+	if limit := spec.MaxScratchSpace; exceeds(usage.scratchSpace, self.scratchSpace, limit) {
+		return fmt.Sprintf("%s of scratch space used, %s more would exceed the limit of %s", usage.scratchSpace.String(), self.scratchSpace.String(), limit.String())
+	}
+	return ""
+}
+
+// updateImportQuotaStatus reports the usage of the namespace in the status of the quota
+func updateImportQuotaStatus(ctx context.Context, c client.Client, quota *cdiv1.ImportQuota, usage importQuotaUsage) error {
+	status := &quota.Status
+	sameQuantity := func(a *resource.Quantity, b resource.Quantity) bool {
+		return a != nil && a.Cmp(b) == 0
+	}
+	if status.ConcurrentImports == usage.concurrentImports && sameQuantity(status.ImportedBytes, usage.importedBytes) &&
+		sameQuantity(status.ScratchSpace, usage.scratchSpace) {
+		return nil
+	}
+	status.ConcurrentImports = usage.concurrentImports
+	importedBytes := usage.importedBytes.DeepCopy()
+	status.ImportedBytes = &importedBytes
+	scratchSpace := usage.scratchSpace.DeepCopy()
+	status.ScratchSpace = &scratchSpace
+	return c.Update(ctx, quota)
+}
@@ -0,0 +1,174 @@
+/*
+Copyright 2026 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+	"kubevirt.io/containerized-data-importer/pkg/common"
+	cc "kubevirt.io/containerized-data-importer/pkg/controller/common"
+)
+
+var _ = Describe("Import quotas", func() {
+	createClient := func(objects ...runtime.Object) client.Client {
+		s := scheme.Scheme
+		_ = cdiv1.AddToScheme(s)
+		return fake.NewClientBuilder().WithScheme(s).WithRuntimeObjects(objects...).Build()
+	}
+
+	importQuota := func(spec cdiv1.ImportQuotaSpec) *cdiv1.ImportQuota {
+		return &cdiv1.ImportQuota{
+			ObjectMeta: metav1.ObjectMeta{Name: "quota", Namespace: "default"},
+			Spec:       spec,
+		}
+	}
+
+	importPvc := func(name, size string) *corev1.PersistentVolumeClaim {
+		pvc := cc.CreatePvc(name, "default", map[string]string{cc.AnnImportPod: "importer-" + name}, nil)
+		pvc.Spec.Resources.Requests = corev1.ResourceList{corev1.ResourceStorage: resource.MustParse(size)}
+		return pvc
+	}
+
+	scratchPvc := func(name, size string) *corev1.PersistentVolumeClaim {
+		pvc := cc.CreatePvc(name, "default", nil, map[string]string{common.CDIComponentLabel: common.ScratchNameSuffix})
+		pvc.Spec.Resources.Requests = corev1.ResourceList{corev1.ResourceStorage: resource.MustParse(size)}
+		return pvc
+	}
+
+	importerPod := func(name string, phase corev1.PodPhase) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+				Labels: map[string]string{
+					common.CDILabelKey:       common.CDILabelValue,
+					common.CDIComponentLabel: common.ImporterPodName,
+				},
+			},
+			Status: corev1.PodStatus{Phase: phase},
+		}
+	}
+
+	check := func(c client.Client, pvc *corev1.PersistentVolumeClaim, requiresScratch bool) (bool, *record.FakeRecorder) {
+		recorder := record.NewFakeRecorder(1)
+		podKey := types.NamespacedName{Namespace: pvc.Namespace, Name: pvc.Annotations[cc.AnnImportPod]}
+		exceeded, err := checkImportQuotas(context.TODO(), c, recorder, pvc, podKey, requiresScratch, importLog)
+		Expect(err).ToNot(HaveOccurred())
+		return exceeded, recorder
+	}
+
+	It("Should admit all imports without quota", func() {
+		pvc := importPvc("target", "10Gi")
+		c := createClient(pvc, importerPod("importer-other", corev1.PodRunning))
+		exceeded, _ := check(c, pvc, false)
+		Expect(exceeded).To(BeFalse())
+	})
+
+	It("Should hold imports over the concurrent imports of the quota", func() {
+		pvc := importPvc("target", "10Gi")
+		c := createClient(importQuota(cdiv1.ImportQuotaSpec{MaxConcurrentImports: ptr.To[int32](1)}), pvc,
+			importerPod("importer-other", corev1.PodRunning), importerPod("importer-done", corev1.PodSucceeded))
+		exceeded, recorder := check(c, pvc, false)
+		Expect(exceeded).To(BeTrue())
+		Expect(pvc.Annotations[cc.AnnRunningCondition]).To(Equal("false"))
+		Expect(pvc.Annotations[cc.AnnRunningConditionReason]).To(Equal(ImportQuotaExceededReason))
+		Expect(pvc.Annotations[cc.AnnRunningConditionMessage]).To(Equal("Import quota quota exceeded: 1 imports running, the limit is 1"))
+		Expect(<-recorder.Events).To(ContainSubstring(ImportQuotaExceededReason))
+
+		Expect(c.Delete(context.TODO(), importerPod("importer-other", corev1.PodRunning))).To(Succeed())
+		exceeded, _ = check(c, pvc, false)
+		Expect(exceeded).To(BeFalse())
+	})
+
+	It("Should not count the importer pod of the PVC", func() {
+		pvc := importPvc("target", "10Gi")
+		c := createClient(importQuota(cdiv1.ImportQuotaSpec{MaxConcurrentImports: ptr.To[int32](1)}), pvc,
+			importerPod("importer-target", corev1.PodPending))
+		exceeded, _ := check(c, pvc, false)
+		Expect(exceeded).To(BeFalse())
+	})
+
+	It("Should hold imports over the imported bytes of the quota and report the usage", func() {
+		pvc := importPvc("target", "10Gi")
+		other := importPvc("other", "15Gi")
+		plain := cc.CreatePvc("plain", "default", nil, nil)
+		plain.Spec.Resources.Requests = corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("100Gi")}
+		quota := importQuota(cdiv1.ImportQuotaSpec{MaxImportedBytes: ptr.To(resource.MustParse("20Gi"))})
+		c := createClient(quota, pvc, other, plain)
+		exceeded, _ := check(c, pvc, false)
+		Expect(exceeded).To(BeTrue())
+		Expect(pvc.Annotations[cc.AnnRunningConditionMessage]).To(ContainSubstring("15Gi imported, importing 10Gi more would exceed the limit of 20Gi"))
+
+		Expect(c.Get(context.TODO(), client.ObjectKeyFromObject(quota), quota)).To(Succeed())
+		Expect(quota.Status.ImportedBytes.String()).To(Equal("15Gi"))
+		Expect(quota.Status.ScratchSpace.IsZero()).To(BeTrue())
+	})
+
+	It("Should count a PVC prime for its target", func() {
+		target := importPvc("target", "10Gi")
+		delete(target.Annotations, cc.AnnImportPod)
+		target.Annotations[cc.AnnPopulatorKind] = cdiv1.VolumeImportSourceRef
+		prime := importPvc("prime-target", "10Gi")
+		prime.OwnerReferences = []metav1.OwnerReference{{Kind: "PersistentVolumeClaim", Name: "target", Controller: ptr.To(true)}}
+		c := createClient(importQuota(cdiv1.ImportQuotaSpec{MaxImportedBytes: ptr.To(resource.MustParse("10Gi"))}), target, prime)
+		exceeded, _ := check(c, prime, false)
+		Expect(exceeded).To(BeFalse())
+	})
+
+	It("Should hold imports requiring scratch space over the scratch space of the quota", func() {
+		pvc := importPvc("target", "10Gi")
+		quota := importQuota(cdiv1.ImportQuotaSpec{MaxScratchSpace: ptr.To(resource.MustParse("15Gi"))})
+		c := createClient(quota, pvc, scratchPvc("other-scratch", "10Gi"))
+		exceeded, _ := check(c, pvc, false)
+		Expect(exceeded).To(BeFalse())
+		exceeded, _ = check(c, pvc, true)
+		Expect(exceeded).To(BeTrue())
+		Expect(pvc.Annotations[cc.AnnRunningConditionMessage]).To(ContainSubstring("10Gi of scratch space used, 10Gi more would exceed the limit of 15Gi"))
+	})
+
+	It("Should count the scratch space overrides of the DataVolume", func() {
+		pvc := importPvc("target", "10Gi")
+		pvc.Annotations[cc.AnnScratchSpace] = `{"sizeMultiplier":"0.5"}`
+		c := createClient(importQuota(cdiv1.ImportQuotaSpec{MaxScratchSpace: ptr.To(resource.MustParse("15Gi"))}), pvc, scratchPvc("other-scratch", "10Gi"))
+		exceeded, _ := check(c, pvc, true)
+		Expect(exceeded).To(BeFalse())
+	})
+
+	It("Should not count the scratch space kept by a paused import twice", func() {
+		pvc := importPvc("target", "10Gi")
+		quota := importQuota(cdiv1.ImportQuotaSpec{MaxScratchSpace: ptr.To(resource.MustParse("10Gi"))})
+		c := createClient(quota, pvc, scratchPvc(createScratchNameFromPvc(pvc), "10Gi"))
+		exceeded, _ := check(c, pvc, true)
+		Expect(exceeded).To(BeFalse())
+	})
+})
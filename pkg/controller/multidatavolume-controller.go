@@ -0,0 +1,317 @@
+/*
+Copyright 2024 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+	"kubevirt.io/containerized-data-importer/pkg/common"
+	"kubevirt.io/containerized-data-importer/pkg/util"
+)
+
+const (
+	multiDataVolumeControllerName = "multidatavolume-controller"
+
+	// ErrInvalidMultiDataVolumeDisk provides a const to indicate a disk of a MultiDataVolume cannot be imported
+	ErrInvalidMultiDataVolumeDisk = "ErrInvalidMultiDataVolumeDisk"
+)
+
+// MultiDataVolumeReconciler members
+type MultiDataVolumeReconciler struct {
+	client          client.Client
+	recorder        record.EventRecorder
+	scheme          *runtime.Scheme
+	log             logr.Logger
+	installerLabels map[string]string
+}
+
+// Reconcile loop for MultiDataVolumeReconciler
+func (r *MultiDataVolumeReconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	mdv := &cdiv1.MultiDataVolume{}
+	if err := r.client.Get(ctx, req.NamespacedName, mdv); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+	if mdv.DeletionTimestamp != nil {
+		return reconcile.Result{}, nil
+	}
+	if err := r.update(ctx, mdv); err != nil {
+		return reconcile.Result{}, err
+	}
+	return reconcile.Result{}, nil
+}
+
+// update creates the missing DataVolumes of the disks, and reports their status. DataVolumes are never updated, as
+// their spec cannot change once they are created.
+func (r *MultiDataVolumeReconciler) update(ctx context.Context, mdv *cdiv1.MultiDataVolume) error {
+	mdvCopy := mdv.DeepCopy()
+	disks := make([]cdiv1.MultiDataVolumeDiskStatus, 0, len(mdv.Spec.Disks))
+	names := make(map[string]bool, len(mdv.Spec.Disks))
+	for i := range mdv.Spec.Disks {
+		dv, err := r.newDiskDataVolume(mdv, i)
+		if err == nil && names[dv.Name] {
+			err = &invalidDiskError{name: dv.Name, err: errors.Errorf("disk %d has the name %s of another disk", i, dv.Name)}
+		}
+		if err == nil {
+			names[dv.Name] = true
+			err = r.getOrCreateDiskDataVolume(ctx, mdv, dv)
+		}
+		if err != nil {
+			var invalid *invalidDiskError
+			if !errors.As(err, &invalid) {
+				return err
+			}
+			r.recorder.Event(mdv, corev1.EventTypeWarning, ErrInvalidMultiDataVolumeDisk, err.Error())
+			disks = append(disks, cdiv1.MultiDataVolumeDiskStatus{DataVolumeName: invalid.name, Phase: cdiv1.Failed})
+			continue
+		}
+		disks = append(disks, cdiv1.MultiDataVolumeDiskStatus{
+			DataVolumeName: dv.Name,
+			Phase:          dv.Status.Phase,
+			Progress:       dv.Status.Progress,
+		})
+	}
+	mdv.Status.Disks = disks
+	mdv.Status.Phase = multiDataVolumePhase(disks)
+	mdv.Status.Progress = multiDataVolumeProgress(disks)
+
+	if !reflect.DeepEqual(mdv, mdvCopy) {
+		if err := r.client.Update(ctx, mdv); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// invalidDiskError is returned for the disks whose DataVolume cannot be created until the MultiDataVolume is fixed
+type invalidDiskError struct {
+	name string
+	err  error
+}
+
+func (e *invalidDiskError) Error() string {
+	return e.err.Error()
+}
+
+// newDiskDataVolume creates the DataVolume of a disk of the MultiDataVolume from its template
+func (r *MultiDataVolumeReconciler) newDiskDataVolume(mdv *cdiv1.MultiDataVolume, i int) (*cdiv1.DataVolume, error) {
+	disk := mdv.Spec.Disks[i]
+	template := &mdv.Spec.Template
+	dv := &cdiv1.DataVolume{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        disk.Name,
+			Namespace:   mdv.Namespace,
+			Labels:      make(map[string]string),
+			Annotations: make(map[string]string),
+		},
+		Spec: *template.Spec.DeepCopy(),
+	}
+	if dv.Name == "" {
+		dv.Name = fmt.Sprintf("%s-disk-%d", mdv.Name, i)
+	}
+	for k, v := range template.Labels {
+		dv.Labels[k] = v
+	}
+	for k, v := range template.Annotations {
+		dv.Annotations[k] = v
+	}
+	util.SetRecommendedLabels(dv, r.installerLabels, common.CDIControllerName)
+	dv.Labels[common.MultiDataVolumeLabel] = mdv.Name
+	if err := selectMultiDataVolumeDisk(&dv.Spec, disk); err != nil {
+		return nil, &invalidDiskError{name: dv.Name, err: errors.Wrapf(err, "disk %d", i)}
+	}
+	if disk.Storage != nil {
+		dv.Spec.Storage = disk.Storage.DeepCopy()
+		dv.Spec.PVC = nil
+	}
+	if err := controllerutil.SetControllerReference(mdv, dv, r.scheme); err != nil {
+		return nil, err
+	}
+	return dv, nil
+}
+
+// selectMultiDataVolumeDisk selects the disk of the source of the DataVolume of a disk
+func selectMultiDataVolumeDisk(spec *cdiv1.DataVolumeSpec, disk cdiv1.MultiDataVolumeDisk) error {
+	source := spec.Source
+	if source == nil {
+		return errors.New("the template has no source")
+	}
+	requireIndex := func() (int32, error) {
+		if disk.Index == nil {
+			return 0, errors.New("the index of the disk is required")
+		}
+		return *disk.Index, nil
+	}
+	requireID := func() error {
+		if disk.ID == "" {
+			return errors.New("the id of the disk is required")
+		}
+		return nil
+	}
+	switch {
+	case source.HTTP != nil && source.HTTP.OVA != nil:
+		index, err := requireIndex()
+		if err != nil {
+			return err
+		}
+		source.HTTP.OVA.DiskIndex = &index
+	case source.HTTP != nil && source.HTTP.XVA != nil:
+		index, err := requireIndex()
+		if err != nil {
+			return err
+		}
+		source.HTTP.XVA.DiskIndex = &index
+	case source.V2V != nil:
+		index, err := requireIndex()
+		if err != nil {
+			return err
+		}
+		source.V2V.DiskIndex = index
+	case source.Imageio != nil:
+		if err := requireID(); err != nil {
+			return err
+		}
+		source.Imageio.DiskID = disk.ID
+	case source.VDDK != nil:
+		if err := requireID(); err != nil {
+			return err
+		}
+		source.VDDK.BackingFile = disk.ID
+	case source.Proxmox != nil:
+		if err := requireID(); err != nil {
+			return err
+		}
+		source.Proxmox.Disk = disk.ID
+	default:
+		return errors.New("the source of the template does not hold several disks")
+	}
+	return nil
+}
+
+// getOrCreateDiskDataVolume creates the DataVolume of a disk unless it exists, and reads its status
+func (r *MultiDataVolumeReconciler) getOrCreateDiskDataVolume(ctx context.Context, mdv *cdiv1.MultiDataVolume, dv *cdiv1.DataVolume) error {
+	existing := &cdiv1.DataVolume{}
+	if err := r.client.Get(ctx, types.NamespacedName{Namespace: dv.Namespace, Name: dv.Name}, existing); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return err
+		}
+		r.log.V(1).Info("Creating DataVolume of disk", "multiDataVolume", mdv.Name, "dataVolume", dv.Name)
+		return r.client.Create(ctx, dv)
+	}
+	if !metav1.IsControlledBy(existing, mdv) {
+		return &invalidDiskError{name: dv.Name, err: errors.Errorf("DataVolume %s already exists and is not owned by the MultiDataVolume", dv.Name)}
+	}
+	existing.Status.DeepCopyInto(&dv.Status)
+	return nil
+}
+
+// multiDataVolumePhase returns Failed once a disk failed, Succeeded once all the disks succeeded, ImportInProgress
+// while disks are imported and Pending otherwise
+func multiDataVolumePhase(disks []cdiv1.MultiDataVolumeDiskStatus) cdiv1.DataVolumePhase {
+	phase := cdiv1.Succeeded
+	for _, disk := range disks {
+		switch disk.Phase {
+		case cdiv1.Failed:
+			return cdiv1.Failed
+		case cdiv1.Succeeded:
+		case cdiv1.PhaseUnset, cdiv1.Pending, cdiv1.PendingPopulation, cdiv1.WaitForFirstConsumer:
+			if phase == cdiv1.Succeeded {
+				phase = cdiv1.Pending
+			}
+		default:
+			phase = cdiv1.ImportInProgress
+		}
+	}
+	return phase
+}
+
+// multiDataVolumeProgress averages the progress of the disks, counting the disks without progress as not started
+func multiDataVolumeProgress(disks []cdiv1.MultiDataVolumeDiskStatus) cdiv1.DataVolumeProgress {
+	if len(disks) == 0 {
+		return ""
+	}
+	var total float64
+	for _, disk := range disks {
+		if disk.Phase == cdiv1.Succeeded {
+			total += 100
+			continue
+		}
+		if progress, err := strconv.ParseFloat(strings.TrimSuffix(string(disk.Progress), "%"), 64); err == nil {
+			total += progress
+		}
+	}
+	return cdiv1.DataVolumeProgress(fmt.Sprintf("%.2f%%", total/float64(len(disks))))
+}
+
+// NewMultiDataVolumeController creates a new instance of the MultiDataVolume controller
+func NewMultiDataVolumeController(mgr manager.Manager, log logr.Logger, installerLabels map[string]string) (controller.Controller, error) {
+	reconciler := &MultiDataVolumeReconciler{
+		client:          mgr.GetClient(),
+		recorder:        mgr.GetEventRecorderFor(multiDataVolumeControllerName),
+		scheme:          mgr.GetScheme(),
+		log:             log.WithName(multiDataVolumeControllerName),
+		installerLabels: installerLabels,
+	}
+	multiDataVolumeController, err := controller.New(multiDataVolumeControllerName, mgr, controller.Options{
+		MaxConcurrentReconciles: 3,
+		Reconciler:              reconciler,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := addMultiDataVolumeControllerWatches(mgr, multiDataVolumeController); err != nil {
+		return nil, err
+	}
+	log.Info("Initialized MultiDataVolume controller")
+	return multiDataVolumeController, nil
+}
+
+func addMultiDataVolumeControllerWatches(mgr manager.Manager, c controller.Controller) error {
+	if err := c.Watch(source.Kind(mgr.GetCache(), &cdiv1.MultiDataVolume{}, &handler.TypedEnqueueRequestForObject[*cdiv1.MultiDataVolume]{})); err != nil {
+		return err
+	}
+	if err := c.Watch(source.Kind(mgr.GetCache(), &cdiv1.DataVolume{}, handler.TypedEnqueueRequestForOwner[*cdiv1.DataVolume](
+		mgr.GetScheme(), mgr.GetClient().RESTMapper(), &cdiv1.MultiDataVolume{}, handler.OnlyControllerOwner()))); err != nil {
+		return err
+	}
+	return nil
+}
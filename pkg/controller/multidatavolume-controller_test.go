@@ -0,0 +1,243 @@
+/*
+Copyright 2024 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+	"kubevirt.io/containerized-data-importer/pkg/common"
+	. "kubevirt.io/containerized-data-importer/pkg/controller/common"
+)
+
+const mdvName = "test-mdv"
+
+var _ = Describe("MultiDataVolume controller reconcile loop", func() {
+	reconcileMultiDataVolume := func(reconciler *MultiDataVolumeReconciler) *cdiv1.MultiDataVolume {
+		key := types.NamespacedName{Name: mdvName, Namespace: metav1.NamespaceDefault}
+		_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: key})
+		Expect(err).ToNot(HaveOccurred())
+		mdv := &cdiv1.MultiDataVolume{}
+		Expect(reconciler.client.Get(context.TODO(), key, mdv)).To(Succeed())
+		return mdv
+	}
+
+	getDataVolume := func(reconciler *MultiDataVolumeReconciler, name string) *cdiv1.DataVolume {
+		dv := &cdiv1.DataVolume{}
+		Expect(reconciler.client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: metav1.NamespaceDefault}, dv)).To(Succeed())
+		return dv
+	}
+
+	setDataVolumeStatus := func(reconciler *MultiDataVolumeReconciler, name string, phase cdiv1.DataVolumePhase, progress cdiv1.DataVolumeProgress) {
+		dv := getDataVolume(reconciler, name)
+		dv.Status.Phase = phase
+		dv.Status.Progress = progress
+		Expect(reconciler.client.Update(context.TODO(), dv)).To(Succeed())
+	}
+
+	It("Should do nothing and return nil when no MultiDataVolume exists", func() {
+		reconciler := createMultiDataVolumeReconciler()
+		_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: mdvName, Namespace: metav1.NamespaceDefault}})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("Should create a DataVolume for each disk of an OVA", func() {
+		mdv := createOVAMultiDataVolume()
+		mdv.Spec.Disks[1].Name = "data"
+		mdv.Spec.Disks[1].Storage = &cdiv1.StorageSpec{
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("20Gi")},
+			},
+		}
+		reconciler := createMultiDataVolumeReconciler(mdv)
+		mdv = reconcileMultiDataVolume(reconciler)
+		Expect(mdv.Status.Disks).To(Equal([]cdiv1.MultiDataVolumeDiskStatus{
+			{DataVolumeName: mdvName + "-disk-0"},
+			{DataVolumeName: "data"},
+		}))
+		Expect(mdv.Status.Phase).To(Equal(cdiv1.Pending))
+		Expect(mdv.Status.Progress).To(Equal(cdiv1.DataVolumeProgress("0.00%")))
+
+		dv := getDataVolume(reconciler, mdvName+"-disk-0")
+		Expect(dv.Spec.Source.HTTP.OVA.DiskIndex).To(Equal(ptr.To[int32](0)))
+		Expect(dv.Spec.Storage.Resources.Requests.Storage().String()).To(Equal("10Gi"))
+		Expect(dv.Labels).To(HaveKeyWithValue(common.MultiDataVolumeLabel, mdvName))
+		Expect(dv.Labels).To(HaveKeyWithValue("template-label", "value"))
+		Expect(metav1.IsControlledBy(dv, mdv)).To(BeTrue())
+
+		dv = getDataVolume(reconciler, "data")
+		Expect(dv.Spec.Source.HTTP.OVA.DiskIndex).To(Equal(ptr.To[int32](2)))
+		Expect(dv.Spec.Storage.Resources.Requests.Storage().String()).To(Equal("20Gi"))
+		Expect(mdv.Spec.Template.Spec.Source.HTTP.OVA.DiskIndex).To(BeNil())
+	})
+
+	It("Should report the phase and the progress of the disks", func() {
+		reconciler := createMultiDataVolumeReconciler(createOVAMultiDataVolume())
+		reconcileMultiDataVolume(reconciler)
+
+		setDataVolumeStatus(reconciler, mdvName+"-disk-0", cdiv1.Succeeded, "100.0%")
+		setDataVolumeStatus(reconciler, mdvName+"-disk-1", cdiv1.ImportInProgress, "50.00%")
+		mdv := reconcileMultiDataVolume(reconciler)
+		Expect(mdv.Status.Phase).To(Equal(cdiv1.ImportInProgress))
+		Expect(mdv.Status.Progress).To(Equal(cdiv1.DataVolumeProgress("75.00%")))
+		Expect(mdv.Status.Disks[1]).To(Equal(cdiv1.MultiDataVolumeDiskStatus{
+			DataVolumeName: mdvName + "-disk-1",
+			Phase:          cdiv1.ImportInProgress,
+			Progress:       "50.00%",
+		}))
+
+		setDataVolumeStatus(reconciler, mdvName+"-disk-1", cdiv1.Succeeded, "100.0%")
+		mdv = reconcileMultiDataVolume(reconciler)
+		Expect(mdv.Status.Phase).To(Equal(cdiv1.Succeeded))
+		Expect(mdv.Status.Progress).To(Equal(cdiv1.DataVolumeProgress("100.00%")))
+
+		setDataVolumeStatus(reconciler, mdvName+"-disk-1", cdiv1.Failed, "N/A")
+		mdv = reconcileMultiDataVolume(reconciler)
+		Expect(mdv.Status.Phase).To(Equal(cdiv1.Failed))
+	})
+
+	It("Should not take over a DataVolume it does not own", func() {
+		dv := NewImportDataVolume(mdvName + "-disk-1")
+		reconciler := createMultiDataVolumeReconciler(createOVAMultiDataVolume(), dv)
+		mdv := reconcileMultiDataVolume(reconciler)
+		Expect(mdv.Status.Phase).To(Equal(cdiv1.Failed))
+		Expect(mdv.Status.Disks[1].Phase).To(Equal(cdiv1.Failed))
+		event := <-reconciler.recorder.(*record.FakeRecorder).Events
+		Expect(event).To(ContainSubstring(ErrInvalidMultiDataVolumeDisk))
+		Expect(event).To(ContainSubstring("is not owned by the MultiDataVolume"))
+		Expect(getDataVolume(reconciler, mdvName+"-disk-1").OwnerReferences).To(BeEmpty())
+	})
+
+	It("Should refuse disks with the same name", func() {
+		mdv := createOVAMultiDataVolume()
+		mdv.Spec.Disks[0].Name = "disk"
+		mdv.Spec.Disks[1].Name = "disk"
+		reconciler := createMultiDataVolumeReconciler(mdv)
+		mdv = reconcileMultiDataVolume(reconciler)
+		Expect(mdv.Status.Phase).To(Equal(cdiv1.Failed))
+		Expect(<-reconciler.recorder.(*record.FakeRecorder).Events).To(ContainSubstring("has the name disk of another disk"))
+	})
+
+	DescribeTable("Should select the disk of the source", func(source *cdiv1.DataVolumeSource, disk cdiv1.MultiDataVolumeDisk, expected *cdiv1.DataVolumeSource) {
+		spec := &cdiv1.DataVolumeSpec{Source: source}
+		Expect(selectMultiDataVolumeDisk(spec, disk)).To(Succeed())
+		Expect(spec.Source).To(Equal(expected))
+	},
+		Entry("of an XVA",
+			&cdiv1.DataVolumeSource{HTTP: &cdiv1.DataVolumeSourceHTTP{URL: "http://example.com/vm.xva", XVA: &cdiv1.DataVolumeSourceXVA{}}},
+			cdiv1.MultiDataVolumeDisk{Index: ptr.To[int32](1)},
+			&cdiv1.DataVolumeSource{HTTP: &cdiv1.DataVolumeSourceHTTP{URL: "http://example.com/vm.xva", XVA: &cdiv1.DataVolumeSourceXVA{DiskIndex: ptr.To[int32](1)}}}),
+		Entry("of a vSphere VM converted by virt-v2v",
+			&cdiv1.DataVolumeSource{V2V: &cdiv1.DataVolumeSourceV2V{VMName: "vm"}},
+			cdiv1.MultiDataVolumeDisk{Index: ptr.To[int32](1)},
+			&cdiv1.DataVolumeSource{V2V: &cdiv1.DataVolumeSourceV2V{VMName: "vm", DiskIndex: 1}}),
+		Entry("of an oVirt VM",
+			&cdiv1.DataVolumeSource{Imageio: &cdiv1.DataVolumeSourceImageIO{URL: "https://engine/ovirt-engine/api"}},
+			cdiv1.MultiDataVolumeDisk{ID: "disk-id"},
+			&cdiv1.DataVolumeSource{Imageio: &cdiv1.DataVolumeSourceImageIO{URL: "https://engine/ovirt-engine/api", DiskID: "disk-id"}}),
+		Entry("of a vSphere VM read with VDDK",
+			&cdiv1.DataVolumeSource{VDDK: &cdiv1.DataVolumeSourceVDDK{UUID: "uuid"}},
+			cdiv1.MultiDataVolumeDisk{ID: "[datastore] vm/vm_1.vmdk"},
+			&cdiv1.DataVolumeSource{VDDK: &cdiv1.DataVolumeSourceVDDK{UUID: "uuid", BackingFile: "[datastore] vm/vm_1.vmdk"}}),
+		Entry("of a Proxmox VE VM",
+			&cdiv1.DataVolumeSource{Proxmox: &cdiv1.DataVolumeSourceProxmox{VMID: 100}},
+			cdiv1.MultiDataVolumeDisk{ID: "scsi1"},
+			&cdiv1.DataVolumeSource{Proxmox: &cdiv1.DataVolumeSourceProxmox{VMID: 100, Disk: "scsi1"}}),
+	)
+
+	DescribeTable("Should refuse disks it cannot select", func(source *cdiv1.DataVolumeSource, disk cdiv1.MultiDataVolumeDisk, expected string) {
+		err := selectMultiDataVolumeDisk(&cdiv1.DataVolumeSpec{Source: source}, disk)
+		Expect(err).To(MatchError(expected))
+	},
+		Entry("of a source holding a single disk",
+			&cdiv1.DataVolumeSource{HTTP: &cdiv1.DataVolumeSourceHTTP{URL: "http://example.com/disk.img"}},
+			cdiv1.MultiDataVolumeDisk{Index: ptr.To[int32](1)},
+			"the source of the template does not hold several disks"),
+		Entry("without index",
+			&cdiv1.DataVolumeSource{HTTP: &cdiv1.DataVolumeSourceHTTP{URL: "http://example.com/vm.ova", OVA: &cdiv1.DataVolumeSourceOVA{}}},
+			cdiv1.MultiDataVolumeDisk{ID: "disk-id"},
+			"the index of the disk is required"),
+		Entry("without id",
+			&cdiv1.DataVolumeSource{Imageio: &cdiv1.DataVolumeSourceImageIO{URL: "https://engine/ovirt-engine/api"}},
+			cdiv1.MultiDataVolumeDisk{Index: ptr.To[int32](1)},
+			"the id of the disk is required"),
+		Entry("without source", nil, cdiv1.MultiDataVolumeDisk{}, "the template has no source"),
+	)
+})
+
+func createMultiDataVolumeReconciler(objects ...runtime.Object) *MultiDataVolumeReconciler {
+	s := scheme.Scheme
+	_ = cdiv1.AddToScheme(s)
+	cl := fake.NewClientBuilder().WithScheme(s).WithRuntimeObjects(objects...).Build()
+	r := &MultiDataVolumeReconciler{
+		client:   cl,
+		recorder: record.NewFakeRecorder(10),
+		scheme:   s,
+		log:      cronLog,
+	}
+	return r
+}
+
+func createOVAMultiDataVolume() *cdiv1.MultiDataVolume {
+	return &cdiv1.MultiDataVolume{
+		TypeMeta: metav1.TypeMeta{APIVersion: cdiv1.SchemeGroupVersion.String(), Kind: "MultiDataVolume"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      mdvName,
+			Namespace: metav1.NamespaceDefault,
+			UID:       "mdv-uid",
+		},
+		Spec: cdiv1.MultiDataVolumeSpec{
+			Template: cdiv1.DataVolume{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"template-label": "value"},
+				},
+				Spec: cdiv1.DataVolumeSpec{
+					Source: &cdiv1.DataVolumeSource{
+						HTTP: &cdiv1.DataVolumeSourceHTTP{
+							URL: "http://example.com/vm.ova",
+							OVA: &cdiv1.DataVolumeSourceOVA{},
+						},
+					},
+					Storage: &cdiv1.StorageSpec{
+						Resources: corev1.VolumeResourceRequirements{
+							Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("10Gi")},
+						},
+					},
+				},
+			},
+			Disks: []cdiv1.MultiDataVolumeDisk{
+				{Index: ptr.To[int32](0)},
+				{Index: ptr.To[int32](2)},
+			},
+		},
+	}
+}
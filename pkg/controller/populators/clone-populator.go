@@ -18,7 +18,7 @@ package populators
 
 import (
 	"context"
-	"crypto/rsa"
+	"encoding/json"
 	"fmt"
 	"time"
 
@@ -42,9 +42,11 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+	"kubevirt.io/containerized-data-importer/pkg/common"
 	"kubevirt.io/containerized-data-importer/pkg/controller/clone"
 	cc "kubevirt.io/containerized-data-importer/pkg/controller/common"
 	featuregates "kubevirt.io/containerized-data-importer/pkg/feature-gates"
+	"kubevirt.io/containerized-data-importer/pkg/token"
 )
 
 const (
@@ -60,6 +62,9 @@ const (
 	// AnnCloneFallbackReason has the host-assisted clone fallback reason
 	AnnCloneFallbackReason = "cdi.kubevirt.io/cloneFallbackReason"
 
+	// AnnCloneStrategyFallbacks has the JSON encoded clone strategies the clone fell back from
+	AnnCloneStrategyFallbacks = "cdi.kubevirt.io/cloneStrategyFallbacks"
+
 	// AnnDataSourceNamespace has the namespace of the DataSource
 	// this will be deprecated when cross namespace datasource goes beta
 	AnnDataSourceNamespace = "cdi.kubevirt.io/dataSourceNamespace"
@@ -70,8 +75,11 @@ const (
 )
 
 var desiredCloneAnnotations = map[string]struct{}{
-	cc.AnnPreallocationApplied: {},
-	cc.AnnCloneOf:              {},
+	cc.AnnPreallocationApplied:  {},
+	cc.AnnCloneOf:               {},
+	cc.AnnCloneTransferredBytes: {},
+	cc.AnnCloneTotalBytes:       {},
+	cc.AnnCloneThroughput:       {},
 }
 
 // Planner is an interface to mock out planner implementation for testing
@@ -193,7 +201,7 @@ func NewClonePopulator(
 	clonerImage string,
 	pullPolicy string,
 	installerLabels map[string]string,
-	publicKey *rsa.PublicKey,
+	tokenKeys token.KeySet,
 ) (controller.Controller, error) {
 	client := mgr.GetClient()
 	reconciler := &ClonePopulatorReconciler{
@@ -201,12 +209,12 @@ func NewClonePopulator(
 			client:          client,
 			scheme:          mgr.GetScheme(),
 			log:             log.WithName(clonePopulatorName),
-			recorder:        mgr.GetEventRecorderFor(clonePopulatorName),
+			recorder:        cc.NewEventAggregator(mgr.GetEventRecorderFor(clonePopulatorName), client),
 			featureGates:    featuregates.NewFeatureGates(client),
 			sourceKind:      cdiv1.VolumeCloneSourceRef,
 			installerLabels: installerLabels,
 		},
-		multiTokenValidator: cc.NewMultiTokenValidator(publicKey),
+		multiTokenValidator: cc.NewMultiTokenValidator(tokenKeys, cc.NewTokenConfigFunc(client)),
 	}
 
 	clonePopulator, err := controller.New(clonePopulatorName, mgr, controller.Options{
@@ -389,7 +397,11 @@ func (r *ClonePopulatorReconciler) planAndExecute(ctx context.Context, log logr.
 
 		if result != nil {
 			log.V(1).Info("currently in phase, returning", "name", p.Name(), "progress", progress)
-			return *result, r.updateClonePhase(ctx, log, pvc, p.Name(), statusResults)
+			var progressPhase cdiv1.DataVolumeClonePhase
+			if pp, ok := p.(clone.ProgressPhaser); ok {
+				progressPhase = pp.ProgressPhase()
+			}
+			return *result, r.updateClonePhase(ctx, log, pvc, p.Name(), progressPhase, statusResults)
 		}
 	}
 
@@ -443,6 +455,17 @@ func (r *ClonePopulatorReconciler) initTargetClaim(ctx context.Context, log logr
 	if claimCpy.Annotations[AnnCloneFallbackReason] == "" && csr.FallbackReason != nil {
 		cc.AddAnnotation(claimCpy, AnnCloneFallbackReason, *csr.FallbackReason)
 	}
+	if vcs.Spec.Format != nil && *vcs.Spec.Format == cdiv1.TransformationFormatQcow2 {
+		// exports of the claim read the image as qcow2
+		cc.AddAnnotation(claimCpy, cc.AnnStorageFormat, common.StorageFormatQcow2)
+	}
+	if claimCpy.Annotations[AnnCloneStrategyFallbacks] == "" && len(csr.Fallbacks) > 0 {
+		fallbacks, err := json.Marshal(csr.Fallbacks)
+		if err != nil {
+			return false, err
+		}
+		cc.AddAnnotation(claimCpy, AnnCloneStrategyFallbacks, string(fallbacks))
+	}
 	cc.AddFinalizer(claimCpy, cloneFinalizer)
 
 	if !apiequality.Semantic.DeepEqual(pvc, claimCpy) {
@@ -457,7 +480,7 @@ func (r *ClonePopulatorReconciler) initTargetClaim(ctx context.Context, log logr
 }
 
 func (r *ClonePopulatorReconciler) updateClonePhasePending(ctx context.Context, log logr.Logger, pvc *corev1.PersistentVolumeClaim) error {
-	return r.updateClonePhase(ctx, log, pvc, clone.PendingPhaseName, nil)
+	return r.updateClonePhase(ctx, log, pvc, clone.PendingPhaseName, "", nil)
 }
 
 func (r *ClonePopulatorReconciler) updateClonePhaseSucceeded(ctx context.Context, log logr.Logger, pvc *corev1.PersistentVolumeClaim, status []*clone.PhaseStatus) error {
@@ -465,13 +488,20 @@ func (r *ClonePopulatorReconciler) updateClonePhaseSucceeded(ctx context.Context
 		status = []*clone.PhaseStatus{{}}
 	}
 	status[len(status)-1].Progress = cc.ProgressDone
-	return r.updateClonePhase(ctx, log, pvc, clone.SucceededPhaseName, status)
+	return r.updateClonePhase(ctx, log, pvc, clone.SucceededPhaseName, "", status)
 }
 
-func (r *ClonePopulatorReconciler) updateClonePhase(ctx context.Context, log logr.Logger, pvc *corev1.PersistentVolumeClaim, phase string, status []*clone.PhaseStatus) error {
+// updateClonePhase records the phase of the clone on the claim, along with the phase of the clone progress, unset when
+// the phase is not reported
+func (r *ClonePopulatorReconciler) updateClonePhase(ctx context.Context, log logr.Logger, pvc *corev1.PersistentVolumeClaim, phase string, progressPhase cdiv1.DataVolumeClonePhase, status []*clone.PhaseStatus) error {
 	claimCpy := pvc.DeepCopy()
 	delete(claimCpy.Annotations, AnnCloneError)
 	cc.AddAnnotation(claimCpy, AnnClonePhase, phase)
+	if progressPhase != "" {
+		cc.AddAnnotation(claimCpy, cc.AnnCloneProgressPhase, string(progressPhase))
+	} else {
+		delete(claimCpy.Annotations, cc.AnnCloneProgressPhase)
+	}
 
 	var mergedAnnotations = make(map[string]string)
 	for _, ps := range status {
@@ -499,6 +529,7 @@ func (r *ClonePopulatorReconciler) updateClonePhaseError(ctx context.Context, lo
 	claimCpy := pvc.DeepCopy()
 	cc.AddAnnotation(claimCpy, AnnClonePhase, clone.ErrorPhaseName)
 	cc.AddAnnotation(claimCpy, AnnCloneError, lastError.Error())
+	delete(claimCpy.Annotations, cc.AnnCloneProgressPhase)
 
 	r.addRunningAnnotations(claimCpy, clone.ErrorPhaseName, nil)
 
@@ -317,8 +317,12 @@ var _ = Describe("Clone populator tests", func() {
 							cc.AnnRunningCondition:        "true",
 							cc.AnnRunningConditionMessage: "message",
 							cc.AnnRunningConditionReason:  "reason",
+							cc.AnnCloneTransferredBytes:   "512",
+							cc.AnnCloneTotalBytes:         "1024",
+							cc.AnnCloneThroughput:         "256",
 						},
 					},
+					progressPhase: cdiv1.ClonePhaseTransfer,
 				},
 			},
 		}
@@ -328,6 +332,10 @@ var _ = Describe("Clone populator tests", func() {
 		Expect(pvc.Annotations[AnnClonePhase]).To(Equal("phase2"))
 		Expect(pvc.Annotations[cc.AnnPopulatorProgress]).To(Equal("50.0%"))
 		Expect(pvc.Annotations).ToNot(HaveKey("foo"))
+		Expect(pvc.Annotations).To(HaveKeyWithValue(cc.AnnCloneProgressPhase, string(cdiv1.ClonePhaseTransfer)))
+		Expect(pvc.Annotations).To(HaveKeyWithValue(cc.AnnCloneTransferredBytes, "512"))
+		Expect(pvc.Annotations).To(HaveKeyWithValue(cc.AnnCloneTotalBytes, "1024"))
+		Expect(pvc.Annotations).To(HaveKeyWithValue(cc.AnnCloneThroughput, "256"))
 		if ownedByDataVolume {
 			Expect(pvc.Annotations).To(HaveKey(cc.AnnRunningCondition))
 			Expect(pvc.Annotations).To(HaveKey(cc.AnnRunningConditionMessage))
@@ -380,6 +388,7 @@ var _ = Describe("Clone populator tests", func() {
 		isDefaultResult(result, err)
 		pvc := getTarget(reconciler.client)
 		Expect(pvc.Annotations[AnnClonePhase]).To(Equal("Succeeded"))
+		Expect(pvc.Annotations).ToNot(HaveKey(cc.AnnCloneProgressPhase))
 	})
 
 	It("should remove finalizer and call cleanup when succeeded", func() {
@@ -433,14 +442,19 @@ func (p *fakePhase) Reconcile(ctx context.Context) (*reconcile.Result, error) {
 
 type fakePhaseWithStatus struct {
 	fakePhase
-	status    *clone.PhaseStatus
-	statusErr error
+	status        *clone.PhaseStatus
+	statusErr     error
+	progressPhase cdiv1.DataVolumeClonePhase
 }
 
 func (p *fakePhaseWithStatus) Status(ctx context.Context) (*clone.PhaseStatus, error) {
 	return p.status, p.statusErr
 }
 
+func (p *fakePhaseWithStatus) ProgressPhase() cdiv1.DataVolumeClonePhase {
+	return p.progressPhase
+}
+
 func createClonePopulatorReconciler(objects ...runtime.Object) *ClonePopulatorReconciler {
 	cdiConfig := cc.MakeEmptyCDIConfigSpec(common.ConfigName)
 	cdiConfig.Status = cdiv1.CDIConfigStatus{}
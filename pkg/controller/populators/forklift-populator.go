@@ -71,7 +71,7 @@ func NewForkliftPopulator(
 			client:          client,
 			scheme:          mgr.GetScheme(),
 			log:             log.WithName(forkliftPopulatorName),
-			recorder:        mgr.GetEventRecorderFor(forkliftPopulatorName),
+			recorder:        cc.NewEventAggregator(mgr.GetEventRecorderFor(forkliftPopulatorName), client),
 			featureGates:    featuregates.NewFeatureGates(client),
 			installerLabels: installerLabels,
 		},
@@ -80,7 +80,7 @@ func NewImportPopulator(
 			client:          client,
 			scheme:          mgr.GetScheme(),
 			log:             log.WithName(importPopulatorName),
-			recorder:        mgr.GetEventRecorderFor(importPopulatorName),
+			recorder:        cc.NewEventAggregator(mgr.GetEventRecorderFor(importPopulatorName), client),
 			featureGates:    featuregates.NewFeatureGates(client),
 			sourceKind:      cdiv1.VolumeImportSourceRef,
 			installerLabels: installerLabels,
@@ -164,6 +164,11 @@ func (r *ImportPopulatorReconciler) reconcileTargetPVC(pvc, pvcPrime *corev1.Per
 		return reconcile.Result{}, err
 	}
 
+	// Pausing the import of the target PVC pauses the importer populating PVC'
+	if err := r.syncPausedAnnotation(pvc, pvcPrime); err != nil {
+		return reconcile.Result{}, err
+	}
+
 	// copy over any new events from pvcPrime to pvc
 	r.copyEvents(pvcPrime, pvcCopy)
 
@@ -224,7 +229,10 @@ func (r *ImportPopulatorReconciler) updatePVCForPopulation(pvc *corev1.Persisten
 	annotations := pvc.Annotations
 	annotations[cc.AnnPopulatorKind] = cdiv1.VolumeImportSourceRef
 	annotations[cc.AnnContentType] = string(cc.GetContentType(volumeImportSource.Spec.ContentType))
-	annotations[cc.AnnPreallocationRequested] = strconv.FormatBool(cc.GetPreallocation(context.TODO(), r.client, volumeImportSource.Spec.Preallocation))
+	if volumeImportSource.Spec.ContentType == "" {
+		annotations[cc.AnnDetectContentType] = "true"
+	}
+	annotations[cc.AnnPreallocationRequested] = strconv.FormatBool(cc.GetPreallocation(context.TODO(), r.client, volumeImportSource.Spec.Preallocation, pvc.Spec.StorageClassName))
 
 	if checkpoint := cc.GetNextCheckpoint(pvc, r.getCheckpointArgs(source)); checkpoint != nil {
 		annotations[cc.AnnCurrentCheckpoint] = checkpoint.Current
@@ -244,6 +252,54 @@ func (r *ImportPopulatorReconciler) updatePVCForPopulation(pvc *corev1.Persisten
 		cc.UpdateGCSAnnotations(annotations, gcs)
 		return
 	}
+	if azure := volumeImportSource.Spec.Source.Azure; azure != nil {
+		cc.UpdateAzureAnnotations(annotations, azure)
+		return
+	}
+	if sftp := volumeImportSource.Spec.Source.SFTP; sftp != nil {
+		cc.UpdateSFTPAnnotations(annotations, sftp)
+		return
+	}
+	if smb := volumeImportSource.Spec.Source.SMB; smb != nil {
+		cc.UpdateSMBAnnotations(annotations, smb)
+		return
+	}
+	if glance := volumeImportSource.Spec.Source.Glance; glance != nil {
+		cc.UpdateGlanceAnnotations(annotations, glance)
+		return
+	}
+	if rbd := volumeImportSource.Spec.Source.RBD; rbd != nil {
+		cc.UpdateRBDAnnotations(annotations, rbd)
+		return
+	}
+	if iscsi := volumeImportSource.Spec.Source.ISCSI; iscsi != nil {
+		cc.UpdateISCSIAnnotations(annotations, iscsi)
+		return
+	}
+	if hostPath := volumeImportSource.Spec.Source.HostPath; hostPath != nil {
+		cc.UpdateHostPathAnnotations(annotations, hostPath)
+		return
+	}
+	if export := volumeImportSource.Spec.Source.Export; export != nil {
+		cc.UpdateExportAnnotations(annotations, export)
+		return
+	}
+	if v2v := volumeImportSource.Spec.Source.V2V; v2v != nil {
+		cc.UpdateV2VAnnotations(annotations, v2v)
+		return
+	}
+	if proxmox := volumeImportSource.Spec.Source.Proxmox; proxmox != nil {
+		cc.UpdateProxmoxAnnotations(annotations, proxmox)
+		return
+	}
+	if plugin := volumeImportSource.Spec.Source.Plugin; plugin != nil {
+		cc.UpdatePluginAnnotations(annotations, plugin)
+		return
+	}
+	if torrent := volumeImportSource.Spec.Source.Torrent; torrent != nil {
+		cc.UpdateTorrentAnnotations(annotations, torrent)
+		return
+	}
 	if registry := volumeImportSource.Spec.Source.Registry; registry != nil {
 		cc.UpdateRegistryAnnotations(annotations, registry)
 		return
@@ -279,10 +335,35 @@ func (r *ImportPopulatorReconciler) updateImportAnnotations(pvc, pvcPrime *corev
 		r.log.Error(err, fmt.Sprintf("Failed to update import progress for pvc %s/%s", pvc.Namespace, pvc.Name))
 	}
 	updateVddkAnnotations(pvc, pvcPrime)
+	updateContentTypeAnnotation(pvc, pvcPrime)
+}
+
+// updateContentTypeAnnotation passes the content type the importer picked from the data to the target PVC
+func updateContentTypeAnnotation(pvc, pvcPrime *corev1.PersistentVolumeClaim) {
+	if pvcPrime.Annotations[cc.AnnDetectContentType] != "true" {
+		return
+	}
+	if contentType := cc.GetPVCContentType(pvcPrime); contentType != cc.GetPVCContentType(pvc) {
+		cc.AddAnnotation(pvc, cc.AnnContentType, string(contentType))
+	}
 }
 
 // Progress reporting
 
+func (r *ImportPopulatorReconciler) syncPausedAnnotation(pvc, pvcPrime *corev1.PersistentVolumeClaim) error {
+	paused, ok := pvc.Annotations[cc.AnnImportPaused]
+	primePaused, primeOk := pvcPrime.Annotations[cc.AnnImportPaused]
+	if ok == primeOk && paused == primePaused {
+		return nil
+	}
+	if ok {
+		cc.AddAnnotation(pvcPrime, cc.AnnImportPaused, paused)
+	} else {
+		delete(pvcPrime.Annotations, cc.AnnImportPaused)
+	}
+	return r.client.Update(context.TODO(), pvcPrime)
+}
+
 func (r *ImportPopulatorReconciler) updateImportProgress(podPhase string, pvc, pvcPrime *corev1.PersistentVolumeClaim) error {
 	// Just set 100.0% if pod is succeeded
 	if podPhase == string(corev1.PodSucceeded) {
@@ -321,10 +402,12 @@ func (r *ImportPopulatorReconciler) updateImportProgress(podPhase string, pvc, p
 
 	// We fetch the import progress from the import pod metrics
 	httpClient = cc.BuildHTTPClient(httpClient)
-	progressReport, err := cc.GetProgressReportFromURL(context.TODO(), url, httpClient, importMetrics.ImportProgressMetricName, string(pvc.UID))
+	metrics, err := cc.GetMetricsFromURL(context.TODO(), url, httpClient)
 	if err != nil {
 		return err
 	}
+	cc.SetImportReportAnnotations(pvc.Annotations, cc.ParseImportReport(metrics, string(pvc.UID)))
+	progressReport := cc.ParseProgressReport(metrics, importMetrics.ImportProgressMetricName, string(pvc.UID))
 	if progressReport != "" {
 		if strings.HasPrefix(progressReport, "100") {
 			// Hold on with reporting 100% since that may not be accounting for resize/convert etc
@@ -42,6 +42,7 @@ import (
 	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/ptr"
 
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -282,6 +283,12 @@ var _ = Describe("Import populator tests", func() {
 			Entry("linkerd side car injection is passed", AnnPodSidecarInjectionLinkerd, AnnPodSidecarInjectionLinkerdDefault, AnnPodSidecarInjectionLinkerdDefault),
 			Entry("multus default network is passed", AnnPodMultusDefaultNetwork, "test", "test"),
 			Entry("retain pod annotation is passed", AnnPodRetainAfterCompletion, "true", "true"),
+			Entry("maximum bandwidth is passed", AnnMaxBandwidth, "10Mi", "10Mi"),
+			Entry("curl connections are passed", AnnCurlConnections, "8", "8"),
+			Entry("curl HTTP version is passed", AnnCurlHTTPVersion, "2.0", "2.0"),
+			Entry("pod overrides are passed", AnnPodOverrides, `{"priorityClassName":"import"}`, `{"priorityClassName":"import"}`),
+			Entry("proxy is passed", AnnImportProxy, `{"httpsProxy":"https://proxy.example.com"}`, `{"httpsProxy":"https://proxy.example.com"}`),
+			Entry("scratch space overrides are passed", AnnScratchSpace, `{"storageClassName":"fast"}`, `{"storageClassName":"fast"}`),
 		)
 
 		It("should trigger appropriate event when using AnnPodRetainAfterCompletion", func() {
@@ -557,6 +564,143 @@ var _ = Describe("Import populator tests", func() {
 			Expect(pvcPrime.GetAnnotations()[AnnVddkExtraArgs]).To(Equal("vddk-extras"))
 		})
 
+		// The same sources are populated as through a DataVolume: PVC' gets the annotations the import controller sets on
+		// the PVC of the DataVolume, and the target PVC those the importer reports once PVC' is rebound
+		DescribeTable("Should populate the PVC from the import source", func(source *cdiv1.ImportSourceType, contentType cdiv1.DataVolumeContentType, expectedAnnotations, reportedAnnotations map[string]string) {
+			targetPvc := CreatePvcInStorageClass(targetPvcName, metav1.NamespaceDefault, &sc.Name, map[string]string{}, nil, corev1.ClaimPending)
+			targetPvc.UID = "target-uid"
+			targetPvc.Spec.DataSourceRef = dataSourceRef
+			volumeImportSource := getVolumeImportSource(true, metav1.NamespaceDefault)
+			volumeImportSource.Spec.Source = source
+			volumeImportSource.Spec.ContentType = contentType
+			request := reconcile.Request{NamespacedName: types.NamespacedName{Name: targetPvcName, Namespace: metav1.NamespaceDefault}}
+
+			By("Reconcile")
+			reconciler = createImportPopulatorReconciler(targetPvc, volumeImportSource, sc)
+			_, err := reconciler.Reconcile(context.TODO(), request)
+			Expect(err).To(Not(HaveOccurred()))
+
+			By("Checking PVC' annotations")
+			pvcPrime, err := reconciler.getPVCPrime(targetPvc)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pvcPrime).ToNot(BeNil())
+			Expect(pvcPrime.GetAnnotations()[AnnPopulatorKind]).To(Equal(cdiv1.VolumeImportSourceRef))
+			for key, value := range expectedAnnotations {
+				Expect(pvcPrime.GetAnnotations()).To(HaveKeyWithValue(key, value))
+			}
+
+			By("Completing the import of PVC'")
+			pv := &corev1.PersistentVolume{
+				ObjectMeta: metav1.ObjectMeta{Name: "pv"},
+				Spec: corev1.PersistentVolumeSpec{
+					ClaimRef: &corev1.ObjectReference{Namespace: pvcPrime.Namespace, Name: pvcPrime.Name, UID: pvcPrime.UID},
+				},
+			}
+			Expect(reconciler.client.Create(context.TODO(), pv)).To(Succeed())
+			pvcPrime.Spec.VolumeName = pv.Name
+			pvcPrime.Annotations[AnnPodPhase] = string(corev1.PodSucceeded)
+			for key, value := range reportedAnnotations {
+				pvcPrime.Annotations[key] = value
+			}
+			Expect(reconciler.client.Update(context.TODO(), pvcPrime)).To(Succeed())
+
+			By("Reconcile")
+			_, err = reconciler.Reconcile(context.TODO(), request)
+			Expect(err).To(Not(HaveOccurred()))
+
+			By("Checking the PV was rebound to the target PVC")
+			Expect(reconciler.client.Get(context.TODO(), client.ObjectKeyFromObject(pv), pv)).To(Succeed())
+			Expect(pv.Spec.ClaimRef.Name).To(Equal(targetPvcName))
+			Expect(pv.Spec.ClaimRef.UID).To(Equal(targetPvc.UID))
+
+			By("Checking the target PVC")
+			Expect(reconciler.client.Get(context.TODO(), request.NamespacedName, targetPvc)).To(Succeed())
+			Expect(targetPvc.GetAnnotations()).To(HaveKeyWithValue(AnnPodPhase, string(corev1.PodSucceeded)))
+			Expect(targetPvc.GetAnnotations()).To(HaveKeyWithValue(AnnPopulatorProgress, "100.0%"))
+			for key, value := range reportedAnnotations {
+				Expect(targetPvc.GetAnnotations()).To(HaveKeyWithValue(key, value))
+			}
+
+			By("Checking the VolumeImportSource status")
+			Expect(reconciler.client.Get(context.TODO(), client.ObjectKeyFromObject(volumeImportSource), volumeImportSource)).To(Succeed())
+			Expect(volumeImportSource.Status.Claims).To(Equal([]cdiv1.VolumePopulationStatus{
+				{Name: targetPvcName, Phase: cdiv1.VolumePopulationSucceeded, Progress: "100.0%"},
+			}))
+		},
+			Entry("imageio", &cdiv1.ImportSourceType{
+				Imageio: &cdiv1.DataVolumeSourceImageIO{URL: "http://imageio", DiskID: "disk", SecretRef: "secret", CertConfigMap: "certs"},
+			}, cdiv1.DataVolumeKubeVirt, map[string]string{
+				AnnSource: SourceImageio, AnnEndpoint: "http://imageio", AnnDiskID: "disk", AnnSecret: "secret",
+				AnnCertConfigMap: "certs", AnnContentType: string(cdiv1.DataVolumeKubeVirt),
+			}, nil),
+			Entry("vddk", &cdiv1.ImportSourceType{
+				VDDK: &cdiv1.DataVolumeSourceVDDK{
+					URL: "https://vcenter", UUID: "uuid", BackingFile: "[datastore] vm/vm.vmdk", Thumbprint: "thumbprint",
+					SecretRef: "secret", InitImageURL: "vddk-init",
+				},
+			}, cdiv1.DataVolumeKubeVirt, map[string]string{
+				AnnSource: SourceVDDK, AnnEndpoint: "https://vcenter", AnnUUID: "uuid", AnnBackingFile: "[datastore] vm/vm.vmdk",
+				AnnThumbprint: "thumbprint", AnnSecret: "secret", AnnVddkInitImageURL: "vddk-init",
+				AnnContentType: string(cdiv1.DataVolumeKubeVirt),
+			}, map[string]string{
+				// reported in the status of DataVolumes, and on the target PVC of populators
+				AnnVddkHostConnection: "esx.corp.com", AnnVddkVersion: "8.0.0",
+			}),
+			Entry("blank", &cdiv1.ImportSourceType{
+				Blank: &cdiv1.DataVolumeBlankImage{},
+			}, cdiv1.DataVolumeKubeVirt, map[string]string{
+				AnnSource: SourceNone, AnnContentType: string(cdiv1.DataVolumeKubeVirt),
+			}, nil),
+			Entry("http archive", &cdiv1.ImportSourceType{
+				HTTP: &cdiv1.DataVolumeSourceHTTP{URL: "http://example.com/data.tar"},
+			}, cdiv1.DataVolumeArchive, map[string]string{
+				AnnSource: SourceHTTP, AnnEndpoint: "http://example.com/data.tar", AnnContentType: string(cdiv1.DataVolumeArchive),
+			}, nil),
+		)
+
+		It("Should report the PVC as pending in the VolumeImportSource status once PVC prime is created", func() {
+			targetPvc := CreatePvcInStorageClass(targetPvcName, metav1.NamespaceDefault, &sc.Name, map[string]string{}, nil, corev1.ClaimPending)
+			targetPvc.Spec.DataSourceRef = dataSourceRef
+			volumeImportSource := getVolumeImportSource(true, metav1.NamespaceDefault)
+
+			By("Reconcile")
+			reconciler = createImportPopulatorReconciler(targetPvc, volumeImportSource, sc)
+			_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: targetPvcName, Namespace: metav1.NamespaceDefault}})
+			Expect(err).To(Not(HaveOccurred()))
+
+			By("Checking the VolumeImportSource status")
+			Expect(reconciler.client.Get(context.TODO(), client.ObjectKeyFromObject(volumeImportSource), volumeImportSource)).To(Succeed())
+			Expect(volumeImportSource.Status.Claims).To(Equal([]cdiv1.VolumePopulationStatus{
+				{Name: targetPvcName, Phase: cdiv1.VolumePopulationPending},
+			}))
+		})
+
+		DescribeTable("Should report the population in the VolumeImportSource status", func(annotations map[string]string, expectedStatus cdiv1.VolumePopulationStatus) {
+			targetPvc := CreatePvcInStorageClass(targetPvcName, metav1.NamespaceDefault, &sc.Name,
+				map[string]string{AnnPopulatorProgress: "45.00%"}, nil, corev1.ClaimPending)
+			targetPvc.Spec.DataSourceRef = dataSourceRef
+			volumeImportSource := getVolumeImportSource(true, metav1.NamespaceDefault)
+			// PVCs which no longer exist are dropped from the status
+			volumeImportSource.Status.Claims = []cdiv1.VolumePopulationStatus{
+				{Name: "deleted-pvc", Phase: cdiv1.VolumePopulationInProgress},
+			}
+			pvcPrime := getPVCPrime(targetPvc, annotations)
+
+			By("Reconcile")
+			reconciler = createImportPopulatorReconciler(targetPvc, pvcPrime, volumeImportSource, sc)
+			_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: targetPvcName, Namespace: metav1.NamespaceDefault}})
+			Expect(err).To(Not(HaveOccurred()))
+
+			By("Checking the VolumeImportSource status")
+			Expect(reconciler.client.Get(context.TODO(), client.ObjectKeyFromObject(volumeImportSource), volumeImportSource)).To(Succeed())
+			Expect(volumeImportSource.Status.Claims).To(Equal([]cdiv1.VolumePopulationStatus{expectedStatus}))
+		},
+			Entry("running", map[string]string{AnnPodPhase: string(corev1.PodRunning)},
+				cdiv1.VolumePopulationStatus{Name: targetPvcName, Phase: cdiv1.VolumePopulationInProgress, Progress: "45.00%"}),
+			Entry("failed", map[string]string{AnnPodPhase: string(corev1.PodFailed), AnnRunningConditionMessage: "Unable to connect"},
+				cdiv1.VolumePopulationStatus{Name: targetPvcName, Phase: cdiv1.VolumePopulationFailed, Message: "Unable to connect"}),
+		)
+
 	})
 
 	var _ = Describe("Import populator progress report", func() {
@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"sort"
 
 	"github.com/go-logr/logr"
 
@@ -38,6 +39,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
 	"kubevirt.io/containerized-data-importer/pkg/common"
 	cc "kubevirt.io/containerized-data-importer/pkg/controller/common"
 	featuregates "kubevirt.io/containerized-data-importer/pkg/feature-gates"
@@ -204,6 +206,55 @@ func (r *ReconcilerBase) createPVCPrime(pvc *corev1.PersistentVolumeClaim, sourc
 	if vddkExtraArgs, ok := pvc.Annotations[cc.AnnVddkExtraArgs]; ok && vddkExtraArgs != "" {
 		annotations[cc.AnnVddkExtraArgs] = vddkExtraArgs
 	}
+	if maxBandwidth, ok := pvc.Annotations[cc.AnnMaxBandwidth]; ok && maxBandwidth != "" {
+		annotations[cc.AnnMaxBandwidth] = maxBandwidth
+	}
+	if format, ok := pvc.Annotations[cc.AnnStorageFormat]; ok && format != "" {
+		annotations[cc.AnnStorageFormat] = format
+	}
+	if fallbacks, ok := pvc.Annotations[cc.AnnSourceFallbacks]; ok && fallbacks != "" {
+		annotations[cc.AnnSourceFallbacks] = fallbacks
+	}
+	if policy, ok := pvc.Annotations[cc.AnnRetryPolicy]; ok && policy != "" {
+		annotations[cc.AnnRetryPolicy] = policy
+	}
+	if overrides, ok := pvc.Annotations[cc.AnnPodOverrides]; ok && overrides != "" {
+		annotations[cc.AnnPodOverrides] = overrides
+	}
+	if proxy, ok := pvc.Annotations[cc.AnnImportProxy]; ok && proxy != "" {
+		annotations[cc.AnnImportProxy] = proxy
+	}
+	if scratchSpace, ok := pvc.Annotations[cc.AnnScratchSpace]; ok && scratchSpace != "" {
+		annotations[cc.AnnScratchSpace] = scratchSpace
+	}
+	if collect, ok := pvc.Annotations[cc.AnnCollectFailureArtifacts]; ok && collect != "" {
+		annotations[cc.AnnCollectFailureArtifacts] = collect
+	}
+	if paused, ok := pvc.Annotations[cc.AnnImportPaused]; ok {
+		annotations[cc.AnnImportPaused] = paused
+	}
+	if priority, ok := pvc.Annotations[cc.AnnTransferPriority]; ok && priority != "" {
+		annotations[cc.AnnTransferPriority] = priority
+	}
+	if expand, ok := pvc.Annotations[cc.AnnExpandFilesystem]; ok && expand != "" {
+		annotations[cc.AnnExpandFilesystem] = expand
+	}
+	for _, ann := range []string{cc.AnnVerify, cc.AnnVerifyChecksum} {
+		if value, ok := pvc.Annotations[ann]; ok && value != "" {
+			annotations[ann] = value
+		}
+	}
+	if placement, ok := pvc.Annotations[cc.AnnTopologyPlacement]; ok && placement != "" {
+		annotations[cc.AnnTopologyPlacement] = placement
+	}
+	if architecture, ok := pvc.Annotations[cc.AnnRegistryImageArchitecture]; ok && architecture != "" {
+		annotations[cc.AnnRegistryImageArchitecture] = architecture
+	}
+	for _, ann := range []string{cc.AnnCurlConnections, cc.AnnCurlHTTPVersion, cc.AnnCurlTLS13Ciphers, cc.AnnCurlTimeout} {
+		if value, ok := pvc.Annotations[ann]; ok && value != "" {
+			annotations[ann] = value
+		}
+	}
 
 	// Assemble PVC' spec
 	pvcPrime := &corev1.PersistentVolumeClaim{
@@ -230,6 +281,11 @@ func (r *ReconcilerBase) createPVCPrime(pvc *corev1.PersistentVolumeClaim, sourc
 	cc.CopyAllowedAnnotations(pvc, pvcPrime)
 	util.SetRecommendedLabels(pvcPrime, r.installerLabels, "cdi-controller")
 	util.MergeLabels(pvc.Labels, pvcPrime.Labels)
+	policy, err := cc.GetMetadataPropagationPolicy(context.TODO(), r.client)
+	if err != nil {
+		return nil, err
+	}
+	cc.PropagateMetadata(policy, pvc, pvcPrime)
 
 	// We use the populator-specific pvcModifierFunc to add required annotations
 	if updatePVCForPopulation != nil {
@@ -247,7 +303,9 @@ type updatePVCAnnotationsFunc func(pvc, pvcPrime *corev1.PersistentVolumeClaim)
 
 var desiredAnnotations = []string{cc.AnnPodPhase, cc.AnnPodReady, cc.AnnPodRestarts,
 	cc.AnnPreallocationRequested, cc.AnnPreallocationApplied, cc.AnnCurrentCheckpoint, cc.AnnMultiStageImportDone,
-	cc.AnnRunningCondition, cc.AnnRunningConditionMessage, cc.AnnRunningConditionReason, cc.AnnPodSchedulable}
+	cc.AnnRunningCondition, cc.AnnRunningConditionMessage, cc.AnnRunningConditionReason, cc.AnnPodSchedulable,
+	cc.AnnSourceDigest, cc.AnnObservedSourceDigest, cc.AnnSourceFallbackIndex, cc.AnnDetectedContentType, cc.AnnUploadDigest,
+	cc.AnnImportRetryCount, cc.AnnImportNextRetryTime, cc.AnnImportPhaseDurations, cc.AnnImportedPlatform, cc.AnnFailureArtifacts}
 
 func (r *ReconcilerBase) updatePVCWithPVCPrimeAnnotations(pvc, pvcPrime *corev1.PersistentVolumeClaim, updateFunc updatePVCAnnotationsFunc) (*corev1.PersistentVolumeClaim, error) {
 	pvcCopy := pvc.DeepCopy()
@@ -373,6 +431,10 @@ func (r *ReconcilerBase) reconcile(req reconcile.Request, populator populatorCon
 		return res, err
 	}
 
+	if err := r.reportPopulationStatus(req.NamespacedName, pvcPrime, populator); err != nil {
+		return res, err
+	}
+
 	// Making sure to clean PVC' once population is completed
 	if cc.IsPVCComplete(pvc) && cc.IsBound(pvc) && !cc.IsMultiStageImportInProgress(pvc) {
 		res, err = r.reconcileCleanup(pvcPrime)
@@ -428,11 +490,89 @@ func (r *ReconcilerBase) reconcileCommon(pvc *corev1.PersistentVolumeClaim, popu
 			r.recorder.Eventf(pvc, corev1.EventTypeWarning, errCreatingPVCPrime, err.Error())
 			return nil, err
 		}
+		return nil, r.updatePopulationSourceStatus(populationSource, populationStatus(pvc, nil))
 	}
 
 	return nil, nil
 }
 
+// reportPopulationStatus reports the population of the PVC by its PVC' in the status of its population source
+func (r *ReconcilerBase) reportPopulationStatus(pvcKey types.NamespacedName, pvcPrime *corev1.PersistentVolumeClaim, populator populatorController) error {
+	// The target PVC was updated by the populator, its progress is read again
+	pvc := &corev1.PersistentVolumeClaim{}
+	if err := r.client.Get(context.TODO(), pvcKey, pvc); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	source, err := populator.getPopulationSource(pvc)
+	if source == nil {
+		return err
+	}
+	return r.updatePopulationSourceStatus(source, populationStatus(pvc, pvcPrime))
+}
+
+// populationStatus returns the population status of the PVC from the phase of the pod populating its PVC'
+func populationStatus(pvc, pvcPrime *corev1.PersistentVolumeClaim) cdiv1.VolumePopulationStatus {
+	status := cdiv1.VolumePopulationStatus{Name: pvc.Name, Phase: cdiv1.VolumePopulationPending}
+	if pvcPrime == nil {
+		return status
+	}
+	switch pvcPrime.Annotations[cc.AnnPodPhase] {
+	case string(corev1.PodRunning):
+		status.Phase = cdiv1.VolumePopulationInProgress
+		status.Progress = cdiv1.DataVolumeProgress(pvc.Annotations[cc.AnnPopulatorProgress])
+	case string(corev1.PodFailed):
+		status.Phase = cdiv1.VolumePopulationFailed
+		status.Message = pvcPrime.Annotations[cc.AnnRunningConditionMessage]
+	case string(corev1.PodSucceeded):
+		if cc.IsMultiStageImportInProgress(pvc) {
+			// The next checkpoint of the multi-stage import is waited for
+			status.Phase = cdiv1.VolumePopulationInProgress
+			status.Progress = cdiv1.DataVolumeProgress(pvc.Annotations[cc.AnnPopulatorProgress])
+		} else {
+			status.Phase = cdiv1.VolumePopulationSucceeded
+			status.Progress = "100.0%"
+		}
+	}
+	return status
+}
+
+// updatePopulationSourceStatus sets the population status of a PVC in the status of the VolumeImportSource or
+// VolumeUploadSource populating it, dropping the PVCs which were deleted or no longer refer to it
+func (r *ReconcilerBase) updatePopulationSourceStatus(source client.Object, status cdiv1.VolumePopulationStatus) error {
+	var claims *[]cdiv1.VolumePopulationStatus
+	switch s := source.(type) {
+	case *cdiv1.VolumeImportSource:
+		claims = &s.Status.Claims
+	case *cdiv1.VolumeUploadSource:
+		claims = &s.Status.Claims
+	default:
+		return nil
+	}
+
+	updated := []cdiv1.VolumePopulationStatus{status}
+	for _, claim := range *claims {
+		if claim.Name == status.Name {
+			continue
+		}
+		pvc := &corev1.PersistentVolumeClaim{}
+		if err := r.client.Get(context.TODO(), types.NamespacedName{Namespace: source.GetNamespace(), Name: claim.Name}, pvc); err != nil {
+			if !k8serrors.IsNotFound(err) {
+				return err
+			}
+			continue
+		}
+		if IsPVCDataSourceRefKind(pvc, r.sourceKind) && pvc.Spec.DataSourceRef.Name == source.GetName() {
+			updated = append(updated, claim)
+		}
+	}
+	sort.Slice(updated, func(i, j int) bool { return updated[i].Name < updated[j].Name })
+	if reflect.DeepEqual(updated, *claims) {
+		return nil
+	}
+	*claims = updated
+	return r.client.Update(context.TODO(), source)
+}
+
 func (r *ReconcilerBase) reconcileCleanup(pvcPrime *corev1.PersistentVolumeClaim) (reconcile.Result, error) {
 	// If exists, make sure PVC' is rebound before deletion
 	if pvcPrime == nil || pvcPrime.Status.Phase != corev1.ClaimLost {
@@ -20,11 +20,14 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -33,8 +36,10 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+	"kubevirt.io/containerized-data-importer/pkg/common"
 	cc "kubevirt.io/containerized-data-importer/pkg/controller/common"
 	featuregates "kubevirt.io/containerized-data-importer/pkg/feature-gates"
+	importMetrics "kubevirt.io/containerized-data-importer/pkg/monitoring/metrics/cdi-importer"
 )
 
 const (
@@ -69,7 +74,7 @@ func NewUploadPopulator(
 			client:          client,
 			scheme:          mgr.GetScheme(),
 			log:             log.WithName(uploadPopulatorName),
-			recorder:        mgr.GetEventRecorderFor(uploadPopulatorName),
+			recorder:        cc.NewEventAggregator(mgr.GetEventRecorderFor(uploadPopulatorName), client),
 			featureGates:    featuregates.NewFeatureGates(client),
 			installerLabels: installerLabels,
 			sourceKind:      cdiv1.VolumeUploadSourceRef,
@@ -118,13 +123,16 @@ func (r *UploadPopulatorReconciler) updatePVCForPopulation(pvc *corev1.Persisten
 	uploadSource := source.(*cdiv1.VolumeUploadSource)
 	pvc.Annotations[cc.AnnContentType] = string(cc.GetContentType(uploadSource.Spec.ContentType))
 	pvc.Annotations[cc.AnnPopulatorKind] = cdiv1.VolumeUploadSourceRef
-	pvc.Annotations[cc.AnnPreallocationRequested] = strconv.FormatBool(cc.GetPreallocation(context.TODO(), r.client, uploadSource.Spec.Preallocation))
+	pvc.Annotations[cc.AnnPreallocationRequested] = strconv.FormatBool(cc.GetPreallocation(context.TODO(), r.client, uploadSource.Spec.Preallocation, pvc.Spec.StorageClassName))
 }
 
 func (r *UploadPopulatorReconciler) updateUploadAnnotations(pvc *corev1.PersistentVolumeClaim, pvcPrime *corev1.PersistentVolumeClaim) {
 	if _, ok := pvc.Annotations[cc.AnnPVCPrimeName]; !ok {
 		return
 	}
+	if err := r.updateUploadProgress(pvcPrime.Annotations[cc.AnnPodPhase], pvc, pvcPrime); err != nil {
+		r.log.Error(err, fmt.Sprintf("Failed to update upload progress for pvc %s/%s", pvc.Namespace, pvc.Name))
+	}
 	// Delete the PVC Prime annotation once the pod is succeeded
 	if pvcPrime.Annotations[cc.AnnPodPhase] == string(corev1.PodSucceeded) {
 		delete(pvc.Annotations, cc.AnnPVCPrimeName)
@@ -172,6 +180,83 @@ func (r *UploadPopulatorReconciler) reconcileTargetPVC(pvc, pvcPrime *corev1.Per
 	if cc.IsPVCComplete(pvcPrime) {
 		r.recorder.Eventf(pvc, corev1.EventTypeNormal, uploadSucceeded, fmt.Sprintf(messageUploadSucceeded, pvc.Name))
 	}
+	if phase == string(corev1.PodRunning) && pvcPrime.Annotations[cc.AnnPodReady] == "true" {
+		// We requeue to keep reporting the progress of the conversion
+		return reconcile.Result{RequeueAfter: 2 * time.Second}, nil
+	}
 
 	return reconcile.Result{}, nil
 }
+
+// Progress reporting
+
+// updateUploadProgress reports the progress of the conversion of the upload, which may continue after the client got
+// its response with asynchronous uploads
+func (r *UploadPopulatorReconciler) updateUploadProgress(podPhase string, pvc, pvcPrime *corev1.PersistentVolumeClaim) error {
+	// Just set 100.0% if pod is succeeded
+	if podPhase == string(corev1.PodSucceeded) {
+		cc.AddAnnotation(pvc, cc.AnnPopulatorProgress, "100.0%")
+		return nil
+	}
+
+	uploadPod, err := r.getUploadPod(pvcPrime)
+	if err != nil {
+		return err
+	}
+
+	if uploadPod == nil {
+		_, ok := pvc.Annotations[cc.AnnPopulatorProgress]
+		// Initialize the progress once PVC Prime is bound
+		if !ok && pvcPrime.Status.Phase == corev1.ClaimBound {
+			cc.AddAnnotation(pvc, cc.AnnPopulatorProgress, "N/A")
+		}
+		return nil
+	}
+
+	// This will only work when the upload pod is running
+	if uploadPod.Status.Phase != corev1.PodRunning {
+		return nil
+	}
+
+	// Upload pods of older versions don't expose their metrics
+	if _, err := cc.GetPodMetricsPort(uploadPod); err != nil {
+		return nil
+	}
+	url, err := cc.GetMetricsURL(uploadPod)
+	if url == "" || err != nil {
+		return err
+	}
+
+	// We fetch the conversion progress from the upload pod metrics, reported like the one of imports
+	httpClient = cc.BuildHTTPClient(httpClient)
+	progressReport, err := cc.GetProgressReportFromURL(context.TODO(), url, httpClient, importMetrics.ImportProgressMetricName, string(pvc.UID))
+	if err != nil {
+		return err
+	}
+	if progressReport != "" {
+		if strings.HasPrefix(progressReport, "100") {
+			// Hold on with reporting 100% since that may not be accounting for resize etc
+			return nil
+		}
+		if f, err := strconv.ParseFloat(progressReport, 64); err == nil {
+			cc.AddAnnotation(pvc, cc.AnnPopulatorProgress, fmt.Sprintf("%.2f%%", f))
+		}
+	}
+
+	return nil
+}
+
+// getUploadPod returns the upload pod of PVC Prime, nil if it doesn't exist
+func (r *UploadPopulatorReconciler) getUploadPod(pvcPrime *corev1.PersistentVolumeClaim) (*corev1.Pod, error) {
+	pods := &corev1.PodList{}
+	if err := r.client.List(context.TODO(), pods, client.InNamespace(pvcPrime.Namespace),
+		client.MatchingLabels{common.UploadTargetLabel: string(pvcPrime.UID)}); err != nil {
+		return nil, err
+	}
+	for i := range pods.Items {
+		if metav1.IsControlledBy(&pods.Items[i], pvcPrime) {
+			return &pods.Items[i], nil
+		}
+	}
+	return nil, nil
+}
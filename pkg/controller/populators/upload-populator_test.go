@@ -18,6 +18,10 @@ package populators
 
 import (
 	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"strconv"
 	"strings"
 
@@ -165,6 +169,50 @@ var _ = Describe("Datavolume controller reconcile loop", func() {
 		expectEvent(r, uploadSucceeded)
 	})
 
+	// Archives are uploaded as through a DataVolume: PVC' gets the annotations the upload controller sets on the PVC of
+	// the DataVolume, and the target PVC gets the PV once PVC' is populated
+	It("should populate the PVC from an archive upload", func() {
+		pvc := newUploadPopulatorPVC("test-pvc")
+		uploadPV := uploadPV(pvc)
+		volumeUploadSourceCR := newUploadPopulatorCR(string(cdiv1.DataVolumeArchive), false)
+		sc := cc.CreateStorageClassWithProvisioner(scName, map[string]string{cc.AnnDefaultStorageClass: "true"}, map[string]string{}, "csi-plugin")
+		r := createUploadPopulatorReconciler(pvc, volumeUploadSourceCR, sc, uploadPV)
+		request := reconcile.Request{NamespacedName: types.NamespacedName{Name: "test-pvc", Namespace: metav1.NamespaceDefault}}
+
+		_, err := r.Reconcile(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+		pvcPrime, err := r.getPVCPrime(pvc)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(pvcPrime).ToNot(BeNil())
+		Expect(pvcPrime.GetAnnotations()).To(HaveKey(cc.AnnUploadRequest))
+		Expect(pvcPrime.GetAnnotations()).To(HaveKeyWithValue(cc.AnnContentType, string(cdiv1.DataVolumeArchive)))
+		Expect(pvcPrime.GetAnnotations()).To(HaveKeyWithValue(cc.AnnPreallocationRequested, "false"))
+		_, err = r.Reconcile(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+
+		cc.AddAnnotation(pvcPrime, cc.AnnPodPhase, string(corev1.PodSucceeded))
+		pvcPrime.Spec.VolumeName = uploadPV.Name
+		pvcPrime.UID = pvcPrimeUID
+		Expect(r.client.Update(context.TODO(), pvcPrime)).To(Succeed())
+
+		_, err = r.Reconcile(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+
+		updatedPV, err := getPV(r.client, uploadPV.Name)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(updatedPV.Spec.ClaimRef.Name).To(Equal("test-pvc"))
+		updatedPVC := &corev1.PersistentVolumeClaim{}
+		Expect(r.client.Get(context.TODO(), request.NamespacedName, updatedPVC)).To(Succeed())
+		Expect(updatedPVC.GetAnnotations()).To(HaveKeyWithValue(cc.AnnPodPhase, string(corev1.PodSucceeded)))
+		Expect(updatedPVC.GetAnnotations()).To(HaveKeyWithValue(cc.AnnPopulatorProgress, "100.0%"))
+
+		Expect(r.client.Get(context.TODO(), client.ObjectKeyFromObject(volumeUploadSourceCR), volumeUploadSourceCR)).To(Succeed())
+		Expect(volumeUploadSourceCR.Status.Claims).To(Equal([]cdiv1.VolumePopulationStatus{
+			{Name: "test-pvc", Phase: cdiv1.VolumePopulationSucceeded, Progress: "100.0%"},
+		}))
+		expectEvent(r, uploadSucceeded)
+	})
+
 	It("should clean PVCPrime when targetPVC bound and succeeded", func() {
 		pvc := newUploadPopulatorPVC("test-pvc")
 		pvc.Spec.VolumeName = "test-pv"
@@ -340,8 +388,96 @@ var _ = Describe("Datavolume controller reconcile loop", func() {
 		Entry("linkerd side car injection is passed", cc.AnnPodSidecarInjectionLinkerd, cc.AnnPodSidecarInjectionLinkerdDefault, cc.AnnPodSidecarInjectionLinkerdDefault),
 		Entry("multus default network is passed", cc.AnnPodMultusDefaultNetwork, "test", "test"),
 	)
+
+	var _ = Describe("Upload populator progress report", func() {
+		It("should set 100.0% if pod phase is succeeded", func() {
+			pvc := newUploadPopulatorPVC("test-pvc")
+			pvcPrime := newUploadPopulatorPVC(PVCPrimeName(pvc))
+
+			r := createUploadPopulatorReconciler(pvc, pvcPrime)
+			err := r.updateUploadProgress(string(corev1.PodSucceeded), pvc, pvcPrime)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pvc.Annotations[cc.AnnPopulatorProgress]).To(Equal("100.0%"))
+		})
+
+		It("should set N/A once PVC Prime is bound", func() {
+			pvc := newUploadPopulatorPVC("test-pvc")
+			pvcPrime := newUploadPopulatorPVC(PVCPrimeName(pvc))
+			pvcPrime.Status.Phase = corev1.ClaimBound
+
+			r := createUploadPopulatorReconciler(pvc, pvcPrime)
+			err := r.updateUploadProgress("", pvc, pvcPrime)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pvc.Annotations[cc.AnnPopulatorProgress]).To(Equal("N/A"))
+		})
+
+		It("should not report progress of upload pods without metrics", func() {
+			pvc := newUploadPopulatorPVC("test-pvc")
+			pvcPrime := newUploadPopulatorPVC(PVCPrimeName(pvc))
+			pvcPrime.UID = pvcPrimeUID
+			pod := newUploadPopulatorPod(pvcPrime, "127.0.0.1", 0)
+			pod.Spec.Containers[0].Ports = nil
+
+			r := createUploadPopulatorReconciler(pvc, pvcPrime, pod)
+			err := r.updateUploadProgress(string(corev1.PodRunning), pvc, pvcPrime)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pvc.Annotations).ToNot(HaveKey(cc.AnnPopulatorProgress))
+		})
+
+		DescribeTable("should report the conversion progress of the upload pod", func(reported, expected string) {
+			pvc := newUploadPopulatorPVC("test-pvc")
+			pvc.SetUID("b856691e-1038-11e9-a5ab-525500d15501")
+			pvcPrime := newUploadPopulatorPVC(PVCPrimeName(pvc))
+			pvcPrime.UID = pvcPrimeUID
+
+			ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(fmt.Sprintf("kubevirt_cdi_import_progress_total{ownerUID=\"%v\"} %s", pvc.GetUID(), reported)))
+			}))
+			defer ts.Close()
+			ep, err := url.Parse(ts.URL)
+			Expect(err).ToNot(HaveOccurred())
+			port, err := strconv.ParseInt(ep.Port(), 10, 32)
+			Expect(err).ToNot(HaveOccurred())
+			pod := newUploadPopulatorPod(pvcPrime, ep.Hostname(), int32(port))
+
+			r := createUploadPopulatorReconciler(pvc, pvcPrime, pod)
+			err = r.updateUploadProgress(string(corev1.PodRunning), pvc, pvcPrime)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pvc.Annotations[cc.AnnPopulatorProgress]).To(Equal(expected))
+		},
+			Entry("while converting", "13.45", "13.45%"),
+			Entry("holding on 100% until the pod succeeds", "100", ""),
+		)
+	})
 })
 
+func newUploadPopulatorPod(pvcPrime *corev1.PersistentVolumeClaim, podIP string, port int32) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "cdi-upload-" + pvcPrime.Name,
+			Namespace: pvcPrime.Namespace,
+			Labels: map[string]string{
+				common.UploadTargetLabel: string(pvcPrime.UID),
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(pvcPrime, corev1.SchemeGroupVersion.WithKind("PersistentVolumeClaim")),
+			},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name:  common.UploadServerPodname,
+					Ports: []corev1.ContainerPort{{Name: "metrics", ContainerPort: port, Protocol: corev1.ProtocolTCP}},
+				},
+			},
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			PodIP: podIP,
+		},
+	}
+}
+
 func newUploadPopulatorPVC(name string) *corev1.PersistentVolumeClaim {
 	apiGroup := cc.AnnAPIGroup
 	return &corev1.PersistentVolumeClaim{
@@ -120,6 +120,16 @@ func (r *StorageProfileReconciler) reconcileStorageProfile(sc *storagev1.Storage
 
 	storageProfile.Status.ClaimPropertySets = claimPropertySets
 
+	if defaults := storageProfile.Spec.ConversionDefaults; defaults != nil && defaults.SparseThreshold != nil {
+		if threshold := defaults.SparseThreshold.Value(); threshold < 0 || threshold%512 != 0 {
+			err = errors.New("the sparse threshold of the conversion defaults must be a multiple of 512 bytes")
+			log.Error(err, "Unable to update StorageProfile")
+			return reconcile.Result{}, err
+		}
+	}
+	storageProfile.Status.ConversionDefaults = storageProfile.Spec.ConversionDefaults
+	storageProfile.Status.CloneCompression = storageProfile.Spec.CloneCompression
+
 	util.SetRecommendedLabels(storageProfile, r.installerLabels, "cdi-controller")
 	if err := r.updateStorageProfile(prevStorageProfile, storageProfile, log); err != nil {
 		return reconcile.Result{}, err
@@ -213,6 +223,8 @@ func (r *StorageProfileReconciler) getCloneStrategyFromStorageClass(annStrategyV
 		strategy = cdiv1.CloneStrategySnapshot
 	case "csi-clone":
 		strategy = cdiv1.CloneStrategyCsiClone
+	case "reflink":
+		strategy = cdiv1.CloneStrategyReflink
 	}
 
 	return &strategy
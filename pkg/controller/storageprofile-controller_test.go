@@ -320,6 +320,53 @@ var _ = Describe("Storage profile controller reconcile loop", func() {
 		Entry("both volume mode and access modes", false, false),
 	)
 
+	DescribeTable("Should update storage profile with the conversion defaults", func(sparseThreshold string, valid bool) {
+		reconciler = createStorageProfileReconciler(CreateStorageClass(storageClassName, map[string]string{AnnDefaultStorageClass: "true"}))
+		_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: storageClassName}})
+		Expect(err).ToNot(HaveOccurred())
+		sp := &cdiv1.StorageProfile{}
+		Expect(reconciler.client.Get(context.TODO(), types.NamespacedName{Name: storageClassName}, sp)).To(Succeed())
+		Expect(sp.Status.ConversionDefaults).To(BeNil())
+
+		mode := cdiv1.ConversionCacheModeNone
+		conversionDefaults := &cdiv1.ConversionDefaults{
+			Preallocation:   ptr.To(true),
+			CacheMode:       &mode,
+			SparseThreshold: ptr.To(resource.MustParse(sparseThreshold)),
+			Coroutines:      ptr.To[int32](8),
+		}
+		sp.Spec.ConversionDefaults = conversionDefaults
+		Expect(reconciler.client.Update(context.TODO(), sp)).To(Succeed())
+		_, err = reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: storageClassName}})
+		Expect(reconciler.client.Get(context.TODO(), types.NamespacedName{Name: storageClassName}, sp)).To(Succeed())
+		if valid {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(sp.Status.ConversionDefaults).To(Equal(conversionDefaults))
+		} else {
+			Expect(err).To(MatchError(ContainSubstring("must be a multiple of 512 bytes")))
+			Expect(sp.Status.ConversionDefaults).To(BeNil())
+		}
+	},
+		Entry("valid sparse threshold", "64Ki", true),
+		Entry("sparse threshold not a multiple of 512 bytes", "1000", false),
+	)
+
+	It("Should update storage profile with the clone compression", func() {
+		reconciler = createStorageProfileReconciler(CreateStorageClass(storageClassName, map[string]string{AnnDefaultStorageClass: "true"}))
+		_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: storageClassName}})
+		Expect(err).ToNot(HaveOccurred())
+		sp := &cdiv1.StorageProfile{}
+		Expect(reconciler.client.Get(context.TODO(), types.NamespacedName{Name: storageClassName}, sp)).To(Succeed())
+		Expect(sp.Status.CloneCompression).To(BeNil())
+
+		sp.Spec.CloneCompression = ptr.To(cdiv1.CloneCompressionZstd)
+		Expect(reconciler.client.Update(context.TODO(), sp)).To(Succeed())
+		_, err = reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: storageClassName}})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(reconciler.client.Get(context.TODO(), types.NamespacedName{Name: storageClassName}, sp)).To(Succeed())
+		Expect(sp.Status.CloneCompression).To(HaveValue(Equal(cdiv1.CloneCompressionZstd)))
+	})
+
 	DescribeTable("should create clone strategy", func(cloneStrategy cdiv1.CDICloneStrategy) {
 		storageClass := CreateStorageClass(storageClassName, map[string]string{AnnDefaultStorageClass: "true"})
 
@@ -341,6 +388,7 @@ var _ = Describe("Storage profile controller reconcile loop", func() {
 		Entry("None", cdiv1.CloneStrategyHostAssisted),
 		Entry("Snapshot", cdiv1.CloneStrategySnapshot),
 		Entry("Clone", cdiv1.CloneStrategyCsiClone),
+		Entry("Reflink", cdiv1.CloneStrategyReflink),
 	)
 
 	It("Should succeed when updating storage profile with specific SnapshotClass", func() {
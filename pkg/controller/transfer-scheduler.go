@@ -0,0 +1,287 @@
+/*
+Copyright 2026 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+	"kubevirt.io/containerized-data-importer/pkg/common"
+	cc "kubevirt.io/containerized-data-importer/pkg/controller/common"
+)
+
+const (
+	// transferQueuedRequeue is how often a queued PVC checks whether its transfer may start
+	transferQueuedRequeue = 5 * time.Second
+	// admittedTransferGracePeriod is how long an admitted transfer counts as running before its pod shows up in the cache
+	admittedTransferGracePeriod = time.Minute
+)
+
+// transfers admits the importer and clone source pods of all the controllers of the process
+var transfers = newTransferScheduler()
+
+// transferScheduler admits the importer and clone source pods within the transfer limits of the CDIConfig. The PVCs
+// over the limits are queued until a slot frees up, then admitted by priority, the namespaces running the fewest
+// transfers first so one namespace creating many DataVolumes does not starve the others.
+type transferScheduler struct {
+	mutex sync.Mutex
+	// admitted holds the namespace of the transfers admitted recently, keyed by pod, as the cache may not list their
+	// pods yet
+	admitted map[types.NamespacedName]admittedTransfer
+}
+
+type admittedTransfer struct {
+	pvc       types.NamespacedName
+	namespace string
+	time      time.Time
+}
+
+type queuedTransfer struct {
+	pvc      types.NamespacedName
+	priority int32
+	queued   time.Time
+}
+
+func newTransferScheduler() *transferScheduler {
+	return &transferScheduler{admitted: map[types.NamespacedName]admittedTransfer{}}
+}
+
+// queueTransfer returns whether the transfer pod of the pvc has to wait for a free slot, in which case the pvc is
+// marked as queued, and unmarks it once admitted
+func queueTransfer(ctx context.Context, c client.Client, recorder record.EventRecorder, pvc *corev1.PersistentVolumeClaim, pod types.NamespacedName, log logr.Logger) (bool, error) {
+	admitted, err := transfers.admit(ctx, c, pvc, pod, time.Now())
+	if err != nil {
+		return false, err
+	}
+	anno := pvc.GetAnnotations()
+	_, queued := anno[cc.AnnTransferQueued]
+	if admitted {
+		if queued {
+			delete(anno, cc.AnnTransferQueued)
+			if err := c.Update(ctx, pvc); err != nil {
+				return false, err
+			}
+		}
+		return false, nil
+	}
+	if !queued {
+		log.V(1).Info("Transfer limits reached, queueing transfer", "pod.Name", pod.Name)
+		anno[cc.AnnTransferQueued] = time.Now().UTC().Format(time.RFC3339)
+		anno[cc.AnnRunningCondition] = "false"
+		anno[cc.AnnRunningConditionMessage] = "Waiting for a free transfer slot"
+		anno[cc.AnnRunningConditionReason] = TransferQueuedReason
+		if err := c.Update(ctx, pvc); err != nil {
+			return false, err
+		}
+		recorder.Event(pvc, corev1.EventTypeNormal, TransferQueuedReason, "Transfer queued, the transfer limits are reached")
+	}
+	return true, nil
+}
+
+// admit returns whether the transfer pod of the pvc may be created now
+func (s *transferScheduler) admit(ctx context.Context, c client.Client, pvc *corev1.PersistentVolumeClaim, pod types.NamespacedName, now time.Time) (bool, error) {
+	maxTotal, maxPerNamespace, err := getTransferLimits(ctx, c)
+	if err != nil {
+		return false, err
+	}
+	if maxTotal == 0 && maxPerNamespace == 0 {
+		return true, nil
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	running, err := s.runningTransfers(ctx, c, now)
+	if err != nil {
+		return false, err
+	}
+	if _, ok := running[pod]; ok {
+		return true, nil
+	}
+	perNamespace := map[string]int{}
+	for _, namespace := range running {
+		perNamespace[namespace]++
+	}
+
+	self := newQueuedTransfer(pvc, now)
+	listed, err := queuedTransfers(ctx, c, self.pvc)
+	if err != nil {
+		return false, err
+	}
+	// The PVCs admitted recently may still be listed as queued
+	var queue []queuedTransfer
+	for _, t := range listed {
+		if !s.isAdmitted(t.pvc) {
+			queue = append(queue, t)
+		}
+	}
+	queue = append(queue, self)
+
+	free := len(queue)
+	if maxTotal > 0 {
+		free = maxTotal - len(running)
+	}
+	for ; free > 0; free-- {
+		next := -1
+		for i, t := range queue {
+			if maxPerNamespace > 0 && perNamespace[t.pvc.Namespace] >= maxPerNamespace {
+				continue
+			}
+			if next < 0 || transferAhead(t, queue[next], perNamespace) {
+				next = i
+			}
+		}
+		if next < 0 {
+			break
+		}
+		if queue[next].pvc == self.pvc {
+			s.admitted[pod] = admittedTransfer{pvc: self.pvc, namespace: pvc.Namespace, time: now}
+			return true, nil
+		}
+		perNamespace[queue[next].pvc.Namespace]++
+		queue = append(queue[:next], queue[next+1:]...)
+	}
+	return false, nil
+}
+
+func (s *transferScheduler) isAdmitted(pvc types.NamespacedName) bool {
+	for _, admitted := range s.admitted {
+		if admitted.pvc == pvc {
+			return true
+		}
+	}
+	return false
+}
+
+// transferAhead returns whether a is admitted before b: from the namespace running fewer transfers, then with a
+// higher priority, then queued earlier
+func transferAhead(a, b queuedTransfer, perNamespace map[string]int) bool {
+	if perNamespace[a.pvc.Namespace] != perNamespace[b.pvc.Namespace] {
+		return perNamespace[a.pvc.Namespace] < perNamespace[b.pvc.Namespace]
+	}
+	if a.priority != b.priority {
+		return a.priority > b.priority
+	}
+	if !a.queued.Equal(b.queued) {
+		return a.queued.Before(b.queued)
+	}
+	return a.pvc.String() < b.pvc.String()
+}
+
+// runningTransfers returns the namespace of the running importer and clone source pods, along with the ones admitted
+// but not listed yet, keyed by pod
+func (s *transferScheduler) runningTransfers(ctx context.Context, c client.Client, now time.Time) (map[types.NamespacedName]string, error) {
+	pods := &corev1.PodList{}
+	if err := c.List(ctx, pods, client.MatchingLabels{common.CDILabelKey: common.CDILabelValue}); err != nil {
+		return nil, err
+	}
+	running := map[types.NamespacedName]string{}
+	for _, pod := range pods.Items {
+		component := pod.Labels[common.CDIComponentLabel]
+		if component != common.ImporterPodName && component != common.ClonerSourcePodName {
+			continue
+		}
+		if pod.DeletionTimestamp != nil || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		namespace := pod.Namespace
+		// Clone source pods run in the namespace of the source, the transfer counts for the one of the target
+		if owner, ok := pod.Annotations[AnnOwnerRef]; ok && component == common.ClonerSourcePodName {
+			if ns, _, err := cache.SplitMetaNamespaceKey(owner); err == nil && ns != "" {
+				namespace = ns
+			}
+		}
+		running[types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name}] = namespace
+	}
+	for key, admitted := range s.admitted {
+		if _, ok := running[key]; ok || now.Sub(admitted.time) > admittedTransferGracePeriod {
+			delete(s.admitted, key)
+			continue
+		}
+		running[key] = admitted.namespace
+	}
+	return running, nil
+}
+
+// queuedTransfers returns the PVCs waiting for a transfer slot, but the one of the caller
+func queuedTransfers(ctx context.Context, c client.Client, self types.NamespacedName) ([]queuedTransfer, error) {
+	pvcs := &corev1.PersistentVolumeClaimList{}
+	if err := c.List(ctx, pvcs); err != nil {
+		return nil, err
+	}
+	var queue []queuedTransfer
+	for i := range pvcs.Items {
+		pvc := &pvcs.Items[i]
+		if _, ok := pvc.Annotations[cc.AnnTransferQueued]; !ok {
+			continue
+		}
+		if pvc.DeletionTimestamp != nil || isImportPaused(pvc) || cc.IsPVCComplete(pvc) {
+			continue
+		}
+		t := newQueuedTransfer(pvc, time.Time{})
+		if t.pvc != self {
+			queue = append(queue, t)
+		}
+	}
+	return queue, nil
+}
+
+func newQueuedTransfer(pvc *corev1.PersistentVolumeClaim, now time.Time) queuedTransfer {
+	t := queuedTransfer{
+		pvc:    types.NamespacedName{Namespace: pvc.Namespace, Name: pvc.Name},
+		queued: now,
+	}
+	if priority, err := strconv.ParseInt(pvc.Annotations[cc.AnnTransferPriority], 10, 32); err == nil {
+		t.priority = int32(priority)
+	}
+	if queued, err := time.Parse(time.RFC3339, pvc.Annotations[cc.AnnTransferQueued]); err == nil {
+		t.queued = queued
+	}
+	return t
+}
+
+// getTransferLimits returns the transfer limits of the CDIConfig, 0 when not enforced
+func getTransferLimits(ctx context.Context, c client.Client) (int, int, error) {
+	config := &cdiv1.CDIConfig{}
+	if err := c.Get(ctx, types.NamespacedName{Name: common.ConfigName}, config); err != nil {
+		return 0, 0, err
+	}
+	limits := config.Spec.TransferLimits
+	if limits == nil {
+		return 0, 0, nil
+	}
+	maxTotal, maxPerNamespace := 0, 0
+	if limits.MaxConcurrentTransfers != nil && *limits.MaxConcurrentTransfers > 0 {
+		maxTotal = int(*limits.MaxConcurrentTransfers)
+	}
+	if limits.MaxConcurrentTransfersPerNamespace != nil && *limits.MaxConcurrentTransfersPerNamespace > 0 {
+		maxPerNamespace = int(*limits.MaxConcurrentTransfersPerNamespace)
+	}
+	return maxTotal, maxPerNamespace, nil
+}
@@ -0,0 +1,188 @@
+/*
+Copyright 2026 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+	"kubevirt.io/containerized-data-importer/pkg/common"
+	cc "kubevirt.io/containerized-data-importer/pkg/controller/common"
+)
+
+var _ = Describe("Transfer scheduler", func() {
+	var (
+		scheduler *transferScheduler
+		now       time.Time
+	)
+
+	BeforeEach(func() {
+		scheduler = newTransferScheduler()
+		transfers = scheduler
+		now = time.Now()
+	})
+
+	createClient := func(limits *cdiv1.TransferLimits, objects ...runtime.Object) client.Client {
+		s := scheme.Scheme
+		_ = cdiv1.AddToScheme(s)
+		cdiConfig := cc.MakeEmptyCDIConfigSpec(common.ConfigName)
+		cdiConfig.Spec.TransferLimits = limits
+		objs := append([]runtime.Object{cdiConfig}, objects...)
+		return fake.NewClientBuilder().WithScheme(s).WithRuntimeObjects(objs...).Build()
+	}
+
+	transferPvc := func(namespace, name string, annotations map[string]string) *corev1.PersistentVolumeClaim {
+		anno := map[string]string{cc.AnnImportPod: "importer-" + name}
+		for k, v := range annotations {
+			anno[k] = v
+		}
+		return cc.CreatePvc(name, namespace, anno, nil)
+	}
+
+	transferPod := func(namespace, name, component string, phase corev1.PodPhase) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+				Labels: map[string]string{
+					common.CDILabelKey:       common.CDILabelValue,
+					common.CDIComponentLabel: component,
+				},
+			},
+			Status: corev1.PodStatus{Phase: phase},
+		}
+	}
+
+	podKey := func(pvc *corev1.PersistentVolumeClaim) types.NamespacedName {
+		return types.NamespacedName{Namespace: pvc.Namespace, Name: pvc.Annotations[cc.AnnImportPod]}
+	}
+
+	admit := func(c client.Client, pvc *corev1.PersistentVolumeClaim) bool {
+		admitted, err := scheduler.admit(context.TODO(), c, pvc, podKey(pvc), now)
+		Expect(err).ToNot(HaveOccurred())
+		return admitted
+	}
+
+	queuedAt := func(t time.Time) string {
+		return t.UTC().Format(time.RFC3339)
+	}
+
+	It("Should admit all transfers without limits", func() {
+		pvc := transferPvc("default", "target", nil)
+		c := createClient(nil, pvc, transferPod("default", "importer-other", common.ImporterPodName, corev1.PodRunning))
+		Expect(admit(c, pvc)).To(BeTrue())
+	})
+
+	It("Should queue transfers over the limit and admit them once a slot frees up", func() {
+		pvc := transferPvc("default", "target", nil)
+		running := transferPod("default", "importer-other", common.ImporterPodName, corev1.PodRunning)
+		c := createClient(&cdiv1.TransferLimits{MaxConcurrentTransfers: ptr.To[int32](1)}, pvc, running)
+		Expect(admit(c, pvc)).To(BeFalse())
+
+		running.Status.Phase = corev1.PodSucceeded
+		Expect(c.Status().Update(context.TODO(), running)).To(Succeed())
+		Expect(admit(c, pvc)).To(BeTrue())
+	})
+
+	It("Should count the admitted transfers the cache does not list yet", func() {
+		first := transferPvc("default", "first", nil)
+		second := transferPvc("default", "second", nil)
+		c := createClient(&cdiv1.TransferLimits{MaxConcurrentTransfers: ptr.To[int32](1)}, first, second)
+		Expect(admit(c, first)).To(BeTrue())
+		Expect(admit(c, second)).To(BeFalse())
+
+		now = now.Add(2 * admittedTransferGracePeriod)
+		Expect(admit(c, second)).To(BeTrue())
+	})
+
+	It("Should admit the queued transfers by priority, then in order", func() {
+		early := transferPvc("default", "early", map[string]string{cc.AnnTransferQueued: queuedAt(now.Add(-time.Hour))})
+		urgent := transferPvc("default", "urgent", map[string]string{
+			cc.AnnTransferQueued:   queuedAt(now.Add(-time.Minute)),
+			cc.AnnTransferPriority: "10",
+		})
+		late := transferPvc("default", "late", map[string]string{cc.AnnTransferQueued: queuedAt(now.Add(-time.Minute))})
+		c := createClient(&cdiv1.TransferLimits{MaxConcurrentTransfers: ptr.To[int32](2)}, early, urgent, late)
+		Expect(admit(c, late)).To(BeFalse())
+		Expect(admit(c, urgent)).To(BeTrue())
+		Expect(admit(c, early)).To(BeTrue())
+	})
+
+	It("Should admit the transfers of the namespaces running fewer transfers first", func() {
+		busy := transferPvc("busy", "target", map[string]string{
+			cc.AnnTransferQueued:   queuedAt(now.Add(-time.Hour)),
+			cc.AnnTransferPriority: "10",
+		})
+		quiet := transferPvc("quiet", "target", map[string]string{cc.AnnTransferQueued: queuedAt(now.Add(-time.Minute))})
+		running := transferPod("busy", "importer-other", common.ImporterPodName, corev1.PodRunning)
+		c := createClient(&cdiv1.TransferLimits{MaxConcurrentTransfers: ptr.To[int32](2)}, busy, quiet, running)
+		Expect(admit(c, busy)).To(BeFalse())
+		Expect(admit(c, quiet)).To(BeTrue())
+	})
+
+	It("Should enforce the limit of each namespace", func() {
+		busy := transferPvc("busy", "target", nil)
+		quiet := transferPvc("quiet", "target", nil)
+		running := transferPod("busy", "importer-other", common.ImporterPodName, corev1.PodRunning)
+		c := createClient(&cdiv1.TransferLimits{MaxConcurrentTransfersPerNamespace: ptr.To[int32](1)}, busy, quiet, running)
+		Expect(admit(c, busy)).To(BeFalse())
+		Expect(admit(c, quiet)).To(BeTrue())
+	})
+
+	It("Should count the clone source pods for the namespace of their target", func() {
+		pvc := transferPvc("target-ns", "target", nil)
+		source := transferPod("source-ns", "clone-source", common.ClonerSourcePodName, corev1.PodRunning)
+		source.Annotations = map[string]string{AnnOwnerRef: "target-ns/other"}
+		c := createClient(&cdiv1.TransferLimits{MaxConcurrentTransfersPerNamespace: ptr.To[int32](1)}, pvc, source)
+		Expect(admit(c, pvc)).To(BeFalse())
+	})
+
+	It("Should mark the queued PVC and unmark it once admitted", func() {
+		pvc := transferPvc("default", "target", nil)
+		running := transferPod("default", "importer-other", common.ImporterPodName, corev1.PodRunning)
+		c := createClient(&cdiv1.TransferLimits{MaxConcurrentTransfers: ptr.To[int32](1)}, pvc, running)
+		recorder := record.NewFakeRecorder(1)
+		queued, err := queueTransfer(context.TODO(), c, recorder, pvc, podKey(pvc), importLog)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(queued).To(BeTrue())
+		Expect(pvc.Annotations).To(HaveKey(cc.AnnTransferQueued))
+		Expect(pvc.Annotations[cc.AnnRunningConditionReason]).To(Equal(TransferQueuedReason))
+		Expect(<-recorder.Events).To(ContainSubstring(TransferQueuedReason))
+
+		Expect(c.Delete(context.TODO(), running)).To(Succeed())
+		Expect(c.Get(context.TODO(), client.ObjectKeyFromObject(pvc), pvc)).To(Succeed())
+		queued, err = queueTransfer(context.TODO(), c, recorder, pvc, podKey(pvc), importLog)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(queued).To(BeFalse())
+		Expect(pvc.Annotations).ToNot(HaveKey(cc.AnnTransferQueued))
+	})
+})
@@ -18,6 +18,7 @@ package controller
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"reflect"
 	"strconv"
@@ -39,8 +40,10 @@ import (
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
@@ -74,6 +77,15 @@ const (
 	// UploadTargetInUse is reason for event created when an upload pvc is in use
 	UploadTargetInUse = "UploadTargetInUse"
 
+	// UploadCertRotated is reason for event created when the cert of the upload server is rotated
+	UploadCertRotated = "UploadCertRotated"
+
+	// annUploadCertIssuedAt is the PVC annotation holding the time the cert of its upload server was issued
+	annUploadCertIssuedAt = "cdi.kubevirt.io/storage.upload.certIssuedAt"
+
+	// defaultUploadCertRotationInterval is the lifetime of the rotated upload server certs by default
+	defaultUploadCertRotationInterval = 24 * time.Hour
+
 	certVolName = "tls-config"
 
 	certMountPath = "/etc/tls/"
@@ -111,6 +123,8 @@ type UploadPodArgs struct {
 	Preallocation                   string
 	CryptoEnvVars                   CryptoEnvVars
 	Deadline                        *time.Time
+	PriorityClassName               string
+	Image                           string
 }
 
 // CryptoEnvVars holds the TLS crypto-related configurables for the upload server
@@ -186,21 +200,25 @@ func (r *UploadReconciler) reconcilePVC(log logr.Logger, pvc *corev1.PersistentV
 	anno := pvcCopy.Annotations
 
 	if isCloneTarget {
-		source, err := r.getCloneRequestSourcePVC(pvc)
-		if err != nil {
-			return reconcile.Result{}, err
-		}
-		contentType, err := ValidateCanCloneSourceAndTargetContentType(source, pvc)
-		if err != nil {
-			return reconcile.Result{}, err
-		}
-		if err = ValidateCanCloneSourceAndTargetSpec(context.TODO(), r.client, source, pvc, contentType); err != nil {
-			log.Error(err, "Error validating clone spec, ignoring")
-			r.recorder.Eventf(pvc, corev1.EventTypeWarning, cc.ErrIncompatiblePVC, err.Error())
-			return reconcile.Result{}, nil
+		// the source of a clone of a volume exported by another cluster is not in this cluster to validate
+		if _, isExportClone := pvc.Annotations[cc.AnnCloneExportURL]; !isExportClone {
+			source, err := r.getCloneRequestSourcePVC(pvc)
+			if err != nil {
+				return reconcile.Result{}, err
+			}
+			contentType, err := ValidateCanCloneSourceAndTargetContentType(source, pvc)
+			if err != nil {
+				return reconcile.Result{}, err
+			}
+			if err = ValidateCanCloneSourceAndTargetSpec(context.TODO(), r.client, source, pvc, contentType); err != nil {
+				log.Error(err, "Error validating clone spec, ignoring")
+				r.recorder.Eventf(pvc, corev1.EventTypeWarning, cc.ErrIncompatiblePVC, err.Error())
+				return reconcile.Result{}, nil
+			}
 		}
 
-		uploadClientName = fmt.Sprintf("%s/%s-%s/%s", source.Namespace, source.Name, pvc.Namespace, pvc.Name)
+		_, sourceNamespace, sourceName := ParseCloneRequestAnnotation(pvc)
+		uploadClientName = fmt.Sprintf("%s/%s-%s/%s", sourceNamespace, sourceName, pvc.Namespace, pvc.Name)
 		anno[AnnUploadClientName] = uploadClientName
 	} else {
 		uploadClientName = uploadServerClientName
@@ -245,6 +263,7 @@ func (r *UploadReconciler) reconcilePVC(log logr.Logger, pvc *corev1.PersistentV
 		if err != nil {
 			return reconcile.Result{}, err
 		}
+		anno[annUploadCertIssuedAt] = time.Now().UTC().Format(time.RFC3339Nano)
 	}
 
 	// Always try to get or create the scratch PVC for a pod that is not successful yet, if it exists nothing happens otherwise attempt to create.
@@ -266,6 +285,7 @@ func (r *UploadReconciler) reconcilePVC(log logr.Logger, pvc *corev1.PersistentV
 		return reconcile.Result{}, err
 	}
 
+	var requeueAfter time.Duration
 	deadlinePassed := termMsg != nil && termMsg.DeadlinePassed != nil && *termMsg.DeadlinePassed
 	if deadlinePassed {
 		if pod.DeletionTimestamp == nil {
@@ -280,6 +300,10 @@ func (r *UploadReconciler) reconcilePVC(log logr.Logger, pvc *corev1.PersistentV
 	} else {
 		anno[cc.AnnPodPhase] = string(pod.Status.Phase)
 		anno[cc.AnnPodReady] = strconv.FormatBool(isPodReady(pod))
+
+		if requeueAfter, err = r.rotateCertSecret(log, pvcCopy, pod); err != nil {
+			return reconcile.Result{}, err
+		}
 	}
 
 	setAnnotationsFromPodWithPrefix(anno, pod, termMsg, cc.AnnRunningCondition)
@@ -294,7 +318,80 @@ func (r *UploadReconciler) reconcilePVC(log logr.Logger, pvc *corev1.PersistentV
 		}
 	}
 
-	return reconcile.Result{}, nil
+	return reconcile.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// rotateCertSecret reissues the cert of the upload server of pvc in its secret once it is due for renewal, or if it was
+// issued before the last rotation requested, and returns when it is next due. The upload server reloads the certs of
+// the secret for every connection, so it keeps serving the uploads in progress. Certs are only rotated while the
+// uploadCertRotation of the CDIConfig is set.
+func (r *UploadReconciler) rotateCertSecret(log logr.Logger, pvc *corev1.PersistentVolumeClaim, pod *corev1.Pod) (time.Duration, error) {
+	config := &cdiv1.CDIConfig{}
+	if err := r.client.Get(context.TODO(), types.NamespacedName{Name: common.ConfigName}, config); err != nil {
+		return 0, err
+	}
+	if config.Spec.UploadCertRotation == nil || pod.DeletionTimestamp != nil {
+		return 0, nil
+	}
+	lifetime, refresh := uploadCertRotationTimes(config.Spec.UploadCertRotation)
+
+	issuedAt := pod.CreationTimestamp.Time
+	if t, err := time.Parse(time.RFC3339Nano, pvc.Annotations[annUploadCertIssuedAt]); err == nil {
+		issuedAt = t
+	}
+	requestedAt, err := time.Parse(time.RFC3339Nano, config.Annotations[common.AnnUploadCertRotationRequested])
+	rotationRequested := err == nil && requestedAt.After(issuedAt)
+	if renewAt := issuedAt.Add(refresh); !rotationRequested && time.Now().Before(renewAt) {
+		return time.Until(renewAt), nil
+	}
+
+	serverCert, serverKey, err := r.serverCertGenerator.MakeServerCert(
+		pod.Namespace,
+		naming.GetServiceNameFromResourceName(pod.Name),
+		lifetime,
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	clientCA, err := r.clientCAFetcher.BundleBytes()
+	if err != nil {
+		return 0, err
+	}
+
+	patch, err := json.Marshal(map[string]any{
+		"data": map[string][]byte{
+			"tls.key": serverKey,
+			"tls.crt": serverCert,
+			"ca.crt":  clientCA,
+		},
+	})
+	if err != nil {
+		return 0, err
+	}
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: pod.Name, Namespace: pod.Namespace}}
+	if err := r.client.Patch(context.TODO(), secret, client.RawPatch(types.MergePatchType, patch)); err != nil {
+		return 0, errors.Wrap(err, "error rotating cert secret")
+	}
+
+	log.V(1).Info("Rotated upload server cert", "requested", rotationRequested)
+	pvc.Annotations[annUploadCertIssuedAt] = time.Now().UTC().Format(time.RFC3339Nano)
+	r.recorder.Event(pvc, corev1.EventTypeNormal, UploadCertRotated, "Upload server certificate rotated")
+	return refresh, nil
+}
+
+// uploadCertRotationTimes returns the lifetime of the rotated upload server certs, and how long after their issuance
+// they are renewed
+func uploadCertRotationTimes(rotation *cdiv1.UploadCertRotationConfig) (time.Duration, time.Duration) {
+	lifetime := defaultUploadCertRotationInterval
+	if rotation.RotationInterval != nil && rotation.RotationInterval.Duration > 0 {
+		lifetime = rotation.RotationInterval.Duration
+	}
+	overlap := lifetime / 2
+	if rotation.RenewalOverlap != nil && rotation.RenewalOverlap.Duration > 0 && rotation.RenewalOverlap.Duration < lifetime {
+		overlap = rotation.RenewalOverlap.Duration
+	}
+	return lifetime, lifetime - overlap
 }
 
 func (r *UploadReconciler) updatePvcPodName(pvc *corev1.PersistentVolumeClaim, podName string, log logr.Logger) error {
@@ -397,10 +494,25 @@ func (r *UploadReconciler) createUploadPodForPvc(pvc *corev1.PersistentVolumeCla
 		return nil, err
 	}
 
+	config := &cdiv1.CDIConfig{}
+	if err := r.client.Get(context.TODO(), types.NamespacedName{Name: common.ConfigName}, config); err != nil {
+		return nil, err
+	}
+
+	serverRefresh := certConfig.Server.Duration.Duration - certConfig.Server.RenewBefore.Duration
+	clientRefresh := certConfig.Client.Duration.Duration - certConfig.Client.RenewBefore.Duration
+	serverCertLifetime := certConfig.Server.Duration.Duration
+	deadline := ptr.To(time.Now().Add(min(serverRefresh, clientRefresh)))
+	if rotation := config.Spec.UploadCertRotation; rotation != nil {
+		// the certs are rotated in place, so the upload server doesn't have to be restarted to renew them
+		serverCertLifetime, _ = uploadCertRotationTimes(rotation)
+		deadline = nil
+	}
+
 	serverCert, serverKey, err := r.serverCertGenerator.MakeServerCert(
 		pvc.Namespace,
 		naming.GetServiceNameFromResourceName(podName),
-		certConfig.Server.Duration.Duration,
+		serverCertLifetime,
 	)
 	if err != nil {
 		return nil, err
@@ -421,18 +533,24 @@ func (r *UploadReconciler) createUploadPodForPvc(pvc *corev1.PersistentVolumeCla
 		preallocationRequested = preallocation
 	}
 
-	config := &cdiv1.CDIConfig{}
-	if err := r.client.Get(context.TODO(), types.NamespacedName{Name: common.ConfigName}, config); err != nil {
-		return nil, err
-	}
 	ciphers, minTLSVersion := cryptowatch.SelectCipherSuitesAndMinTLSVersion(config.Spec.TLSSecurityProfile)
 	cryptoVars := CryptoEnvVars{
 		Ciphers:       strings.Join(ciphers, ","),
 		MinTLSVersion: string(minTLSVersion),
 	}
 
-	serverRefresh := certConfig.Server.Duration.Duration - certConfig.Server.RenewBefore.Duration
-	clientRefresh := certConfig.Client.Duration.Duration - certConfig.Client.RenewBefore.Duration
+	selectPriorityClass := cc.UploadPriorityClass
+	if isCloneTarget {
+		selectPriorityClass = cc.CloneTargetPriorityClass
+	}
+	priorityClassName, err := cc.GetTransferPodPriorityClass(context.TODO(), r.client, cc.GetPriorityClass(pvc), selectPriorityClass)
+	if err != nil {
+		return nil, err
+	}
+	image, err := cc.GetUploadServerImage(context.TODO(), r.client, r.image)
+	if err != nil {
+		return nil, err
+	}
 
 	args := UploadPodArgs{
 		Name:               podName,
@@ -445,7 +563,9 @@ func (r *UploadReconciler) createUploadPodForPvc(pvc *corev1.PersistentVolumeCla
 		ClientCA:           clientCA,
 		Preallocation:      strconv.FormatBool(preallocationRequested),
 		CryptoEnvVars:      cryptoVars,
-		Deadline:           ptr.To(time.Now().Add(min(serverRefresh, clientRefresh))),
+		Deadline:           deadline,
+		PriorityClassName:  priorityClassName,
+		Image:              image,
 	}
 
 	r.log.V(3).Info("Creating upload pod")
@@ -629,8 +749,20 @@ func (r *UploadReconciler) createUploadPod(args UploadPodArgs) (*corev1.Pod, err
 		return nil, err
 	}
 
+	policy, err := cc.GetMetadataPropagationPolicy(context.TODO(), r.client)
+	if err != nil {
+		return nil, err
+	}
+
+	injection, err := cc.GetTransferPodInjection(context.TODO(), r.client)
+	if err != nil {
+		return nil, err
+	}
+
 	pod := r.makeUploadPodSpec(args, podResourceRequirements, imagePullSecrets, workloadNodePlacement)
 	util.SetRecommendedLabels(pod, r.installerLabels, "cdi-controller")
+	cc.PropagateMetadata(policy, args.PVC, pod)
+	cc.InjectTransferPodContainers(injection, &pod.Spec)
 
 	if err := r.client.Get(context.TODO(), types.NamespacedName{Name: args.Name, Namespace: ns}, pod); err != nil {
 		if !k8serrors.IsNotFound(err) {
@@ -641,7 +773,7 @@ func (r *UploadReconciler) createUploadPod(args UploadPodArgs) (*corev1.Pod, err
 		}
 	}
 
-	r.log.V(1).Info("upload pod created\n", "Namespace", pod.Namespace, "Name", pod.Name, "Image name", r.image)
+	r.log.V(1).Info("upload pod created\n", "Namespace", pod.Namespace, "Name", pod.Name, "Image name", args.Image)
 	return pod, nil
 }
 
@@ -692,7 +824,7 @@ func NewUploadController(mgr manager.Manager, log logr.Logger, uploadImage, pull
 		image:               uploadImage,
 		verbose:             verbose,
 		pullPolicy:          pullPolicy,
-		recorder:            mgr.GetEventRecorderFor("upload-controller"),
+		recorder:            cc.NewEventAggregator(mgr.GetEventRecorderFor("upload-controller"), client),
 		serverCertGenerator: serverCertGenerator,
 		clientCAFetcher:     clientCAFetcher,
 		featureGates:        featuregates.NewFeatureGates(client),
@@ -725,18 +857,55 @@ func addUploadControllerWatches(mgr manager.Manager, uploadController controller
 		mgr.GetScheme(), mgr.GetClient().RESTMapper(), &corev1.PersistentVolumeClaim{}, handler.OnlyControllerOwner()))); err != nil {
 		return err
 	}
+	// the certs of the upload servers are rotated when the rotation config changes or a rotation is requested
+	if err := uploadController.Watch(source.Kind(mgr.GetCache(), &cdiv1.CDIConfig{}, handler.TypedEnqueueRequestsFromMapFunc[*cdiv1.CDIConfig](
+		func(ctx context.Context, _ *cdiv1.CDIConfig) []reconcile.Request {
+			return mapUploadPodsToPVCs(ctx, mgr.GetClient())
+		}),
+		predicate.TypedFuncs[*cdiv1.CDIConfig]{
+			CreateFunc: func(e event.TypedCreateEvent[*cdiv1.CDIConfig]) bool { return false },
+			DeleteFunc: func(e event.TypedDeleteEvent[*cdiv1.CDIConfig]) bool { return false },
+			UpdateFunc: func(e event.TypedUpdateEvent[*cdiv1.CDIConfig]) bool {
+				return !reflect.DeepEqual(e.ObjectOld.Spec.UploadCertRotation, e.ObjectNew.Spec.UploadCertRotation) ||
+					e.ObjectOld.Annotations[common.AnnUploadCertRotationRequested] != e.ObjectNew.Annotations[common.AnnUploadCertRotationRequested]
+			},
+		})); err != nil {
+		return err
+	}
 
 	return nil
 }
 
+// mapUploadPodsToPVCs returns the requests of the PVCs of all the upload pods
+func mapUploadPodsToPVCs(ctx context.Context, c client.Client) []reconcile.Request {
+	pods := &corev1.PodList{}
+	if err := c.List(ctx, pods, client.MatchingLabels{common.CDIComponentLabel: common.UploadServerCDILabel}); err != nil {
+		return nil
+	}
+	var reqs []reconcile.Request
+	for _, pod := range pods.Items {
+		if owner := metav1.GetControllerOf(&pod); owner != nil && owner.Kind == "PersistentVolumeClaim" {
+			reqs = append(reqs, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: pod.Namespace, Name: owner.Name}})
+		}
+	}
+	return reqs
+}
+
 func createScratchPvcNameFromPvc(pvc *corev1.PersistentVolumeClaim, isCloneTarget bool) string {
-	if isCloneTarget {
+	if isCloneTarget || streamingConversionRequested(pvc) {
 		return ""
 	}
 
 	return naming.GetResourceName(pvc.Name, common.ScratchNameSuffix)
 }
 
+// streamingConversionRequested returns whether the uploads to the PVC are converted as they are streamed, the upload
+// pod gets no scratch space then
+func streamingConversionRequested(pvc *corev1.PersistentVolumeClaim) bool {
+	streaming, _ := strconv.ParseBool(pvc.Annotations[cc.AnnUploadStreamingConversion])
+	return streaming
+}
+
 // getUploadResourceName returns the name given to upload resources
 func getUploadResourceNameFromPvc(pvc *corev1.PersistentVolumeClaim) string {
 	podName, ok := pvc.Annotations[AnnUploadPod]
@@ -768,6 +937,15 @@ func GetUploadServerURL(namespace, pvc, uploadPath string) string {
 	return fmt.Sprintf("https://%s.%s.svc%s", serviceName, namespace, uploadPath)
 }
 
+// getUploadOwnerUID returns the UID of the DataVolume or PVC owning the PVC of the upload, matching the progress
+// reported by the import progress metric like importer pods do
+func getUploadOwnerUID(pvc *corev1.PersistentVolumeClaim) types.UID {
+	if len(pvc.OwnerReferences) == 1 {
+		return pvc.OwnerReferences[0].UID
+	}
+	return pvc.UID
+}
+
 // createUploadServiceName returns the name given to upload service shortened if needed
 func createUploadServiceNameFromPvcName(pvc string) string {
 	return naming.GetServiceNameFromResourceName(createUploadResourceName(pvc))
@@ -786,6 +964,7 @@ func (r *UploadReconciler) makeUploadPodSpec(args UploadPodArgs, resourceRequire
 				common.CDIComponentLabel:        common.UploadServerCDILabel,
 				common.UploadServerServiceLabel: naming.GetServiceNameFromResourceName(args.Name),
 				common.UploadTargetLabel:        string(args.PVC.UID),
+				common.PrometheusLabelKey:       common.PrometheusLabelValue,
 			},
 			OwnerReferences: []metav1.OwnerReference{
 				MakePVCOwnerReference(args.PVC),
@@ -798,7 +977,7 @@ func (r *UploadReconciler) makeUploadPodSpec(args UploadPodArgs, resourceRequire
 			NodeSelector:      workloadNodePlacement.NodeSelector,
 			Tolerations:       workloadNodePlacement.Tolerations,
 			Affinity:          workloadNodePlacement.Affinity,
-			PriorityClassName: cc.GetPriorityClass(args.PVC),
+			PriorityClassName: args.PriorityClassName,
 			ImagePullSecrets:  imagePullSecrets,
 		},
 	}
@@ -815,7 +994,7 @@ func (r *UploadReconciler) makeUploadPodContainers(args UploadPodArgs, resourceR
 	containers := []corev1.Container{
 		{
 			Name:            common.UploadServerPodname,
-			Image:           r.image,
+			Image:           args.Image,
 			ImagePullPolicy: corev1.PullPolicy(r.pullPolicy),
 			Env: []corev1.EnvVar{
 				{
@@ -854,8 +1033,20 @@ func (r *UploadReconciler) makeUploadPodContainers(args UploadPodArgs, resourceR
 					Name:  common.MinVersionTLSVar,
 					Value: args.CryptoEnvVars.MinTLSVersion,
 				},
+				{
+					// The conversion progress of the upload is reported to the progress metric of the owner of the PVC
+					Name:  common.OwnerUID,
+					Value: string(getUploadOwnerUID(args.PVC)),
+				},
 			},
 			Args: []string{"-v=" + r.verbose},
+			Ports: []corev1.ContainerPort{
+				{
+					Name:          "metrics",
+					ContainerPort: 8443,
+					Protocol:      corev1.ProtocolTCP,
+				},
+			},
 			ReadinessProbe: &corev1.Probe{
 				ProbeHandler: corev1.ProbeHandler{
 					HTTPGet: &corev1.HTTPGetAction{
@@ -879,6 +1070,18 @@ func (r *UploadReconciler) makeUploadPodContainers(args UploadPodArgs, resourceR
 			TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
 		},
 	}
+	if streamingConversionRequested(args.PVC) {
+		containers[0].Env = append(containers[0].Env, corev1.EnvVar{
+			Name:  common.UploadStreamingConversion,
+			Value: "true",
+		})
+	}
+	if format := args.PVC.Annotations[cc.AnnCloneFormat]; format != "" {
+		containers[0].Env = append(containers[0].Env, corev1.EnvVar{
+			Name:  common.UploadStorageFormat,
+			Value: format,
+		})
+	}
 	if args.Deadline != nil {
 		containers[0].Env = append(containers[0].Env, corev1.EnvVar{
 			Name:  "DEADLINE",
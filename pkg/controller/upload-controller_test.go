@@ -276,6 +276,42 @@ var _ = Describe("Upload controller reconcile loop", func() {
 		Expect(err.Error()).To(ContainSubstring("Source and target volume modes do not match, and content type is not kubevirt"))
 	})
 
+	DescribeTable("Should give the upload pod the priority class of the CDIConfig", func(annotations map[string]string, expected string) {
+		annotations[AnnUploadPod] = createUploadResourceName("testPvc1")
+		testPvc := cc.CreatePvc("testPvc1", "default", annotations, nil)
+		testPvcSource := cc.CreatePvc("testPvc2", "default", map[string]string{}, nil)
+		reconciler := createUploadReconciler(testPvc, testPvcSource)
+		config := &cdiv1.CDIConfig{}
+		Expect(reconciler.client.Get(context.TODO(), types.NamespacedName{Name: common.ConfigName}, config)).To(Succeed())
+		config.Spec.TransferPodPriorityClasses = &cdiv1.TransferPodPriorityClasses{Upload: "upload-priority", CloneTarget: "clone-priority"}
+		Expect(reconciler.client.Update(context.TODO(), config)).To(Succeed())
+
+		_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "testPvc1", Namespace: "default"}})
+		Expect(err).ToNot(HaveOccurred())
+		uploadPod := &corev1.Pod{}
+		Expect(reconciler.client.Get(context.TODO(), types.NamespacedName{Name: createUploadResourceName("testPvc1"), Namespace: "default"}, uploadPod)).To(Succeed())
+		Expect(uploadPod.Spec.PriorityClassName).To(Equal(expected))
+	},
+		Entry("of uploads", map[string]string{cc.AnnUploadRequest: ""}, "upload-priority"),
+		Entry("of clone targets", map[string]string{cc.AnnCloneRequest: "default/testPvc2"}, "clone-priority"),
+		Entry("unless the PVC has one", map[string]string{cc.AnnUploadRequest: "", cc.AnnPriorityClassName: "p0"}, "p0"),
+	)
+
+	It("Should run the upload pod with the image of the CDIConfig", func() {
+		testPvc := cc.CreatePvc("testPvc1", "default", map[string]string{cc.AnnUploadRequest: "", AnnUploadPod: createUploadResourceName("testPvc1")}, nil)
+		reconciler := createUploadReconciler(testPvc)
+		config := &cdiv1.CDIConfig{}
+		Expect(reconciler.client.Get(context.TODO(), types.NamespacedName{Name: common.ConfigName}, config)).To(Succeed())
+		config.Spec.TransferPodImages = &cdiv1.TransferPodImages{Uploader: "registry/uploadserver-custom"}
+		Expect(reconciler.client.Update(context.TODO(), config)).To(Succeed())
+
+		_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "testPvc1", Namespace: "default"}})
+		Expect(err).ToNot(HaveOccurred())
+		uploadPod := &corev1.Pod{}
+		Expect(reconciler.client.Get(context.TODO(), types.NamespacedName{Name: createUploadResourceName("testPvc1"), Namespace: "default"}, uploadPod)).To(Succeed())
+		Expect(uploadPod.Spec.Containers[0].Image).To(Equal("registry/uploadserver-custom"))
+	})
+
 	It("Should return nil and create a pod and service when a clone pvc", func() {
 		testPvc := cc.CreatePvc("testPvc1", "default", map[string]string{cc.AnnCloneRequest: "default/testPvc2", AnnUploadPod: createUploadResourceName("testPvc1"), cc.AnnPriorityClassName: "p0"}, nil)
 		testPvcSource := cc.CreatePvc("testPvc2", "default", map[string]string{}, nil)
@@ -359,6 +395,26 @@ var _ = Describe("reconcilePVC loop", func() {
 			Expect(resultPvc.GetAnnotations()[cc.AnnPodPhase]).To(BeEquivalentTo(uploadPod.Status.Phase))
 		})
 
+		It("Should create the pod of clones of exported volumes without a source PVC", func() {
+			testPvc := cc.CreatePvc(testPvcName, "default", map[string]string{
+				cc.AnnCloneRequest:   "default/testPvc2",
+				cc.AnnCloneExportURL: "https://export.example.com/volumes/testPvc2/disk.img.gz",
+				AnnUploadPod:         uploadResourceName,
+			}, nil)
+			reconciler := createUploadReconciler(testPvc)
+
+			_, err := reconciler.reconcilePVC(reconciler.log, testPvc, isClone)
+			Expect(err).ToNot(HaveOccurred())
+
+			uploadPod := &corev1.Pod{}
+			err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: uploadResourceName, Namespace: "default"}, uploadPod)
+			Expect(err).ToNot(HaveOccurred())
+			resultPvc := &corev1.PersistentVolumeClaim{}
+			err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: testPvcName, Namespace: "default"}, resultPvc)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resultPvc.GetAnnotations()[AnnUploadClientName]).To(Equal("default/testPvc2-default/testPvc1"))
+		})
+
 		It("Should create the service and pod with passed annotations", func() {
 			testPvc := cc.CreatePvc(testPvcName, "default", map[string]string{cc.AnnCloneRequest: "default/testPvc2", AnnUploadPod: uploadResourceName, cc.AnnPodNetwork: "net1"}, nil)
 			testPvcSource := cc.CreatePvc("testPvc2", "default", map[string]string{}, nil)
@@ -499,6 +555,100 @@ var _ = Describe("reconcilePVC loop", func() {
 			Expect(err).ToNot(HaveOccurred())
 		})
 
+		It("Should expose the conversion progress of the owner of the pvc in the metrics of the pod", func() {
+			testPvc := cc.CreatePvc(testPvcName, "default", map[string]string{cc.AnnUploadRequest: "", AnnUploadPod: uploadResourceName}, nil)
+			testPvc.OwnerReferences = []metav1.OwnerReference{{Kind: "DataVolume", Name: "test-dv", UID: "dv-uid"}}
+			reconciler := createUploadReconciler(testPvc)
+
+			_, err := reconciler.reconcilePVC(reconciler.log, testPvc, isClone)
+			Expect(err).ToNot(HaveOccurred())
+			uploadPod := &corev1.Pod{}
+			err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: uploadResourceName, Namespace: "default"}, uploadPod)
+			Expect(err).ToNot(HaveOccurred())
+			port, err := cc.GetPodMetricsPort(uploadPod)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(port).To(Equal(8443))
+			Expect(uploadPod.Spec.Containers[0].Env).To(ContainElement(corev1.EnvVar{Name: common.OwnerUID, Value: "dv-uid"}))
+		})
+
+		It("Should create the pod without scratch space when streaming conversion is requested", func() {
+			testPvc := cc.CreatePvc(testPvcName, "default", map[string]string{cc.AnnUploadRequest: "", AnnUploadPod: uploadResourceName, cc.AnnUploadStreamingConversion: "true"}, nil)
+			reconciler := createUploadReconciler(testPvc)
+
+			_, err := reconciler.reconcilePVC(reconciler.log, testPvc, isClone)
+			Expect(err).ToNot(HaveOccurred())
+			uploadPod := &corev1.Pod{}
+			err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: uploadResourceName, Namespace: "default"}, uploadPod)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(uploadPod.Spec.Containers[0].Env).To(ContainElement(corev1.EnvVar{Name: common.UploadStreamingConversion, Value: "true"}))
+			for _, volume := range uploadPod.Spec.Volumes {
+				Expect(volume.Name).ToNot(Equal(cc.ScratchVolName))
+			}
+
+			scratchPvc := &corev1.PersistentVolumeClaim{}
+			err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "testPvc1-scratch", Namespace: "default"}, scratchPvc)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should pass the format of a clone to the pod", func() {
+			testPvc := cc.CreatePvc(testPvcName, "default", map[string]string{cc.AnnUploadRequest: "", AnnUploadPod: uploadResourceName, cc.AnnCloneFormat: "qcow2"}, nil)
+			reconciler := createUploadReconciler(testPvc)
+
+			_, err := reconciler.reconcilePVC(reconciler.log, testPvc, isClone)
+			Expect(err).ToNot(HaveOccurred())
+			uploadPod := &corev1.Pod{}
+			err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: uploadResourceName, Namespace: "default"}, uploadPod)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(uploadPod.Spec.Containers[0].Env).To(ContainElement(corev1.EnvVar{Name: common.UploadStorageFormat, Value: "qcow2"}))
+		})
+
+		It("Should rotate the server cert in place when a rotation is requested", func() {
+			testPvc := cc.CreatePvc(testPvcName, "default", map[string]string{cc.AnnUploadRequest: "", AnnUploadPod: uploadResourceName}, nil)
+			reconciler := createUploadReconciler(testPvc)
+			certGenerator := &fakeCertGenerator{expectedDuration: 2 * time.Hour}
+			reconciler.serverCertGenerator = certGenerator
+			cdiConfig := &cdiv1.CDIConfig{}
+			Expect(reconciler.client.Get(context.TODO(), types.NamespacedName{Name: common.ConfigName}, cdiConfig)).To(Succeed())
+			cdiConfig.Spec.UploadCertRotation = &cdiv1.UploadCertRotationConfig{
+				RotationInterval: &metav1.Duration{Duration: 2 * time.Hour},
+				RenewalOverlap:   &metav1.Duration{Duration: 30 * time.Minute},
+			}
+			Expect(reconciler.client.Update(context.TODO(), cdiConfig)).To(Succeed())
+
+			result, err := reconciler.reconcilePVC(reconciler.log, testPvc, isClone)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.RequeueAfter).To(BeNumerically("~", 90*time.Minute, time.Minute))
+			Expect(certGenerator.calls).To(Equal(1))
+			uploadPod := &corev1.Pod{}
+			Expect(reconciler.client.Get(context.TODO(), types.NamespacedName{Name: uploadResourceName, Namespace: "default"}, uploadPod)).To(Succeed())
+			for _, env := range uploadPod.Spec.Containers[0].Env {
+				Expect(env.Name).ToNot(Equal("DEADLINE"))
+			}
+			Expect(testPvc.Annotations).ToNot(HaveKey(annUploadCertIssuedAt))
+			Expect(reconciler.client.Get(context.TODO(), client.ObjectKeyFromObject(testPvc), testPvc)).To(Succeed())
+			issuedAt := testPvc.Annotations[annUploadCertIssuedAt]
+			Expect(issuedAt).ToNot(BeEmpty())
+
+			By("Not rotating the cert before it is due")
+			_, err = reconciler.reconcilePVC(reconciler.log, testPvc, isClone)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(certGenerator.calls).To(Equal(1))
+
+			By("Rotating the cert once a rotation is requested")
+			cdiConfig.Annotations = map[string]string{common.AnnUploadCertRotationRequested: time.Now().UTC().Format(time.RFC3339Nano)}
+			Expect(reconciler.client.Update(context.TODO(), cdiConfig)).To(Succeed())
+			result, err = reconciler.reconcilePVC(reconciler.log, testPvc, isClone)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(90 * time.Minute))
+			Expect(certGenerator.calls).To(Equal(2))
+			Expect(reconciler.client.Get(context.TODO(), client.ObjectKeyFromObject(testPvc), testPvc)).To(Succeed())
+			Expect(testPvc.Annotations[annUploadCertIssuedAt]).ToNot(Equal(issuedAt))
+			secret := &corev1.Secret{}
+			Expect(reconciler.client.Get(context.TODO(), types.NamespacedName{Name: uploadResourceName, Namespace: "default"}, secret)).To(Succeed())
+			Expect(secret.Data).To(HaveKeyWithValue("ca.crt", []byte("baz")))
+			Expect(reconciler.recorder.(*record.FakeRecorder).Events).To(Receive(ContainSubstring(UploadCertRotated)))
+		})
+
 		DescribeTable("should pass correct crypto config to created pod", func(profile *cdiv1.TLSSecurityProfile) {
 			testPvc := cc.CreatePvc(testPvcName, "default", map[string]string{cc.AnnUploadRequest: "", AnnUploadPod: uploadResourceName}, nil)
 			reconciler := createUploadReconciler(testPvc)
@@ -576,6 +726,19 @@ var _ = Describe("reconcilePVC loop", func() {
 	})
 })
 
+var _ = Describe("uploadCertRotationTimes", func() {
+	DescribeTable("should return the lifetime and refresh of the rotated certs", func(interval, overlap *metav1.Duration, expectedLifetime, expectedRefresh time.Duration) {
+		lifetime, refresh := uploadCertRotationTimes(&cdiv1.UploadCertRotationConfig{RotationInterval: interval, RenewalOverlap: overlap})
+		Expect(lifetime).To(Equal(expectedLifetime))
+		Expect(refresh).To(Equal(expectedRefresh))
+	},
+		Entry("by default", nil, nil, 24*time.Hour, 12*time.Hour),
+		Entry("with an interval", &metav1.Duration{Duration: time.Hour}, nil, time.Hour, 30*time.Minute),
+		Entry("with an interval and overlap", &metav1.Duration{Duration: time.Hour}, &metav1.Duration{Duration: 10 * time.Minute}, time.Hour, 50*time.Minute),
+		Entry("with an overlap longer than the interval", &metav1.Duration{Duration: time.Hour}, &metav1.Duration{Duration: 2 * time.Hour}, time.Hour, 30*time.Minute),
+	)
+})
+
 var _ = Describe("Update PVC", func() {
 
 	It("Should update cc.AnnPodRestarts on pvc from upload pod restarts", func() {
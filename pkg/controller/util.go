@@ -64,6 +64,24 @@ const (
 	// ImagePullFailedReason is a const that defines the pod exited due to failure when pulling image
 	ImagePullFailedReason = "ImagePullFailed"
 
+	// ChecksumMismatchReason is a const that defines the pod exited because the data imported did not match the checksum of the source
+	ChecksumMismatchReason = "ChecksumMismatch"
+
+	// SignatureVerificationFailedReason is a const that defines the pod exited because the data imported was not signed by a key of the keyring of the source
+	SignatureVerificationFailedReason = "SignatureVerificationFailed"
+
+	// ImageVerificationFailedReason is a const that defines the pod exited because the registry image imported did not meet its image verification policies
+	ImageVerificationFailedReason = "ImageVerificationFailed"
+
+	// ImportPausedReason is a const that defines the pod was deleted because the import is paused
+	ImportPausedReason = "ImportPaused"
+
+	// TransferQueuedReason is a const that defines the pod is not created yet because the transfer limits are reached
+	TransferQueuedReason = "TransferQueued"
+
+	// ImportQuotaExceededReason is a const that defines the pod is not created yet because an ImportQuota of the namespace is exceeded
+	ImportQuotaExceededReason = cdiv1.DataVolumeReasonQuotaExceeded
+
 	// ImportCompleteMessage is a const that defines the pod completeded the import successfully
 	ImportCompleteMessage = "Import Complete"
 
@@ -127,7 +145,8 @@ func checkIfLabelExists(pvc *corev1.PersistentVolumeClaim, lbl string, val strin
 // which allows handleObject to discover the pod resource that 'owns' it, and clean up when needed.
 func newScratchPersistentVolumeClaimSpec(pvc *corev1.PersistentVolumeClaim, pod *corev1.Pod, name, storageClassName string) *corev1.PersistentVolumeClaim {
 	labels := map[string]string{
-		"app": "containerized-data-importer",
+		"app":                    "containerized-data-importer",
+		common.CDIComponentLabel: common.ScratchNameSuffix,
 	}
 
 	annotations := make(map[string]string)
@@ -171,6 +190,10 @@ func newScratchPersistentVolumeClaimSpec(pvc *corev1.PersistentVolumeClaim, pod
 // createScratchPersistentVolumeClaim creates and returns a pointer to a scratch PVC which is created based on the passed-in pvc and storage class name.
 func createScratchPersistentVolumeClaim(client client.Client, pvc *corev1.PersistentVolumeClaim, pod *corev1.Pod, name, storageClassName string, installerLabels map[string]string, recorder record.EventRecorder) (*corev1.PersistentVolumeClaim, error) {
 	scratchPvcSpec := newScratchPersistentVolumeClaimSpec(pvc, pod, name, storageClassName)
+	overrides, err := getScratchSpaceOverrides(pvc)
+	if err != nil {
+		return nil, err
+	}
 
 	sizeRequest := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
 	scratchFsOverhead, err := GetFilesystemOverhead(context.TODO(), client, scratchPvcSpec)
@@ -194,10 +217,27 @@ func createScratchPersistentVolumeClaim(client client.Client, pvc *corev1.Persis
 	// it's good practice round up here to ensure we don't end up with a scratch PVC that is smaller than the original PVC.
 	usableSpaceRaw = util.RoundUp(usableSpaceRaw, util.DefaultAlignBlockSize)
 
-	scratchPvcSpec.Spec.Resources.Requests[corev1.ResourceStorage] = *resource.NewScaledQuantity(usableSpaceRaw, 0)
+	switch {
+	case overrides.Size != nil:
+		scratchPvcSpec.Spec.Resources.Requests[corev1.ResourceStorage] = overrides.Size.DeepCopy()
+	case overrides.SizeMultiplier != "":
+		multiplier, err := strconv.ParseFloat(overrides.SizeMultiplier, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid scratch space size multiplier %q", overrides.SizeMultiplier)
+		}
+		usableSpaceRaw = util.RoundUp(int64(float64(usableSpaceRaw)*multiplier), util.DefaultAlignBlockSize)
+		scratchPvcSpec.Spec.Resources.Requests[corev1.ResourceStorage] = *resource.NewScaledQuantity(usableSpaceRaw, 0)
+	default:
+		scratchPvcSpec.Spec.Resources.Requests[corev1.ResourceStorage] = *resource.NewScaledQuantity(usableSpaceRaw, 0)
+	}
 
 	util.SetRecommendedLabels(scratchPvcSpec, installerLabels, "cdi-controller")
 	cc.AddLabel(scratchPvcSpec, cc.LabelExcludeFromVeleroBackup, "true")
+	policy, err := cc.GetMetadataPropagationPolicy(context.TODO(), client)
+	if err != nil {
+		return nil, err
+	}
+	cc.PropagateMetadata(policy, pvc, scratchPvcSpec)
 	if err := client.Create(context.TODO(), scratchPvcSpec); err != nil {
 		if cc.ErrQuotaExceeded(err) {
 			recorder.Event(pvc, corev1.EventTypeWarning, cc.ErrExceededQuota, err.Error())
@@ -224,12 +264,29 @@ func GetFilesystemOverhead(ctx context.Context, client client.Client, pvc *corev
 	return cc.GetFilesystemOverheadForStorageClass(ctx, client, pvc.Spec.StorageClassName)
 }
 
+// getScratchSpaceOverrides returns the scratch space settings of the DataVolume of the pvc, empty if it has none
+func getScratchSpaceOverrides(pvc *corev1.PersistentVolumeClaim) (*cdiv1.DataVolumeScratchSpace, error) {
+	overrides := &cdiv1.DataVolumeScratchSpace{}
+	value, ok := pvc.Annotations[cc.AnnScratchSpace]
+	if !ok || value == "" {
+		return overrides, nil
+	}
+	if err := json.Unmarshal([]byte(value), overrides); err != nil {
+		return nil, errors.Wrapf(err, "invalid %s annotation", cc.AnnScratchSpace)
+	}
+	return overrides, nil
+}
+
 // GetScratchPvcStorageClass tries to determine which storage class to use for use with a scratch persistent
 // volume claim. The order of preference is the following:
-// 1. Defined value in CDI Config field scratchSpaceStorageClass.
-// 2. If 1 is not available, use the storage class name of the original pvc that will own the scratch pvc.
-// 3. If none of those are available, return blank.
+// 1. The storage class of the scratch space settings of the DataVolume of the pvc.
+// 2. Defined value in CDI Config field scratchSpaceStorageClass.
+// 3. If 2 is not available, use the storage class name of the original pvc that will own the scratch pvc.
+// 4. If none of those are available, return blank.
 func GetScratchPvcStorageClass(client client.Client, pvc *corev1.PersistentVolumeClaim) string {
+	if overrides, err := getScratchSpaceOverrides(pvc); err == nil && overrides.StorageClassName != nil && *overrides.StorageClassName != "" {
+		return *overrides.StorageClassName
+	}
 	config := &cdiv1.CDIConfig{}
 	if err := client.Get(context.TODO(), types.NamespacedName{Name: common.ConfigName}, config); err != nil {
 		return ""
@@ -354,6 +411,20 @@ func setAnnotationsFromPodWithPrefix(anno map[string]string, pod *corev1.Pod, te
 			if termMsg.Message != nil {
 				anno[prefix+".message"] = *termMsg.Message
 			}
+			if termMsg.ObservedSourceDigest != nil {
+				anno[cc.AnnObservedSourceDigest] = *termMsg.ObservedSourceDigest
+			}
+			if containerState.Terminated.ExitCode != 0 {
+				anno[prefix+".message"] = simplifyKnownMessage(anno[prefix+".message"])
+				if termMsg.FailureReason != nil {
+					anno[prefix+".reason"] = *termMsg.FailureReason
+					return
+				}
+				if reason := knownFailureReason(anno[prefix+".message"]); reason != "" {
+					anno[prefix+".reason"] = reason
+					return
+				}
+			}
 			if termMsg.VddkInfo != nil {
 				if termMsg.VddkInfo.Host != "" {
 					anno[cc.AnnVddkHostConnection] = termMsg.VddkInfo.Host
@@ -365,6 +436,27 @@ func setAnnotationsFromPodWithPrefix(anno map[string]string, pod *corev1.Pod, te
 			if termMsg.PreallocationApplied != nil && *termMsg.PreallocationApplied {
 				anno[cc.AnnPreallocationApplied] = "true"
 			}
+			if termMsg.SourceDigest != nil {
+				anno[cc.AnnSourceDigest] = *termMsg.SourceDigest
+			}
+			if termMsg.ImportedPlatform != nil {
+				if platform, err := json.Marshal(termMsg.ImportedPlatform); err == nil {
+					anno[cc.AnnImportedPlatform] = string(platform)
+				}
+			}
+			if termMsg.UploadDigest != nil {
+				anno[cc.AnnUploadDigest] = *termMsg.UploadDigest
+			}
+			if len(termMsg.PhaseDurations) > 0 {
+				cc.SetImportReportAnnotations(anno, &cc.ImportReport{PhaseDurations: termMsg.PhaseDurations})
+			}
+			if termMsg.DetectedContentType != nil {
+				anno[cc.AnnDetectedContentType] = *termMsg.DetectedContentType
+				// Without a declared content type, the importer extracts the archives it finds
+				if anno[cc.AnnDetectContentType] == "true" && *termMsg.DetectedContentType == string(cdiv1.DataVolumeArchive) {
+					anno[cc.AnnContentType] = string(cdiv1.DataVolumeArchive)
+				}
+			}
 		} else {
 			// Handle plain termination message (legacy)
 			anno[prefix+".message"] = simplifyKnownMessage(containerState.Terminated.Message)
@@ -372,8 +464,8 @@ func setAnnotationsFromPodWithPrefix(anno map[string]string, pod *corev1.Pod, te
 				anno[cc.AnnPreallocationApplied] = "true"
 			}
 
-			if strings.Contains(containerState.Terminated.Message, common.ImagePullFailureText) {
-				anno[prefix+".reason"] = ImagePullFailedReason
+			if reason := knownFailureReason(containerState.Terminated.Message); reason != "" {
+				anno[prefix+".reason"] = reason
 				return
 			}
 		}
@@ -396,6 +488,32 @@ func addLabelsFromTerminationMessage(labels map[string]string, termMsg *common.T
 	return newLabels
 }
 
+// knownFailureReason returns the reason of the failure the message of a failed pod reports, empty if unknown
+func knownFailureReason(msg string) string {
+	switch {
+	case strings.Contains(msg, common.ImagePullFailureText):
+		return ImagePullFailedReason
+	case strings.Contains(msg, common.ChecksumMismatchText):
+		return ChecksumMismatchReason
+	case strings.Contains(msg, common.SignatureVerificationFailureText):
+		return SignatureVerificationFailedReason
+	case strings.Contains(msg, common.ImageVerificationFailureText):
+		return ImageVerificationFailedReason
+	}
+	return ""
+}
+
+// isImportFailureReason returns whether reason is the reason of a failure the importer classified. QuotaExceeded is
+// left out, imports held by an ImportQuota report it as well.
+func isImportFailureReason(reason string) bool {
+	switch reason {
+	case cdiv1.DataVolumeReasonSourceUnreachable, cdiv1.DataVolumeReasonInsufficientSpace, cdiv1.DataVolumeReasonAuthFailed,
+		cdiv1.DataVolumeReasonCorrupt, cdiv1.DataVolumeReasonThrottled, cdiv1.DataVolumeReasonVerificationFailed:
+		return true
+	}
+	return false
+}
+
 func simplifyKnownMessage(msg string) string {
 	if strings.Contains(msg, "is larger than the reported available") ||
 		strings.Contains(msg, "no space left on device") ||
@@ -412,13 +530,21 @@ func parseTerminationMessage(pod *corev1.Pod) (*common.TerminationMessage, error
 		return nil, nil
 	}
 
-	state := pod.Status.ContainerStatuses[0].State
-	if state.Terminated == nil || state.Terminated.ExitCode != 0 {
+	return parseTerminatedMessage(pod.Status.ContainerStatuses[0].State.Terminated)
+}
+
+// parseTerminatedMessage parses the termination message of a terminated container. Failures are reported with
+// plain messages unless the importer classified them, those are ignored.
+func parseTerminatedMessage(terminated *corev1.ContainerStateTerminated) (*common.TerminationMessage, error) {
+	if terminated == nil {
 		return nil, nil
 	}
 
 	termMsg := &common.TerminationMessage{}
-	if err := json.Unmarshal([]byte(state.Terminated.Message), termMsg); err != nil {
+	if err := json.Unmarshal([]byte(terminated.Message), termMsg); err != nil {
+		if terminated.ExitCode != 0 {
+			return nil, nil
+		}
 		return nil, err
 	}
 
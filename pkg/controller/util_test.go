@@ -94,6 +94,13 @@ var _ = Describe("GetScratchPVCStorageClass", func() {
 		pvc := CreatePvcInStorageClass("test", "test", &storageClassName, nil, nil, v1.ClaimBound)
 		Expect(GetScratchPvcStorageClass(client, pvc)).To(Equal(""))
 	})
+
+	It("Should return the storage class of the scratch space overrides of the DataVolume", func() {
+		config := createCDIConfigWithStorageClass(common.ConfigName, "test1")
+		client := CreateClient(CreateStorageClass("test1", nil), config)
+		pvc := CreatePvc("test", "test", map[string]string{AnnScratchSpace: `{"storageClassName":"local-nvme"}`}, nil)
+		Expect(GetScratchPvcStorageClass(client, pvc)).To(Equal("local-nvme"))
+	})
 })
 
 var _ = Describe("GetWorkloadNodePlacement", func() {
@@ -212,6 +219,39 @@ var _ = Describe("setAnnotationsFromPod", func() {
 		Expect(result[AnnPreallocationApplied]).To(Equal("true"))
 	})
 
+	It("Should set the upload digest", func() {
+		result := make(map[string]string)
+		testPod := createUploadPod(CreatePvc("test", metav1.NamespaceDefault, nil, nil))
+		testPod.Status = v1.PodStatus{
+			ContainerStatuses: []v1.ContainerStatus{
+				{
+					State: v1.ContainerState{
+						Terminated: &v1.ContainerStateTerminated{},
+					},
+				},
+			},
+		}
+		setAnnotationsFromPodWithPrefix(result, testPod, &common.TerminationMessage{UploadDigest: ptr.To("sha256:1234")}, AnnRunningCondition)
+		Expect(result[AnnUploadDigest]).To(Equal("sha256:1234"))
+	})
+
+	It("Should set the imported platform", func() {
+		result := make(map[string]string)
+		testPod := CreateImporterTestPod(CreatePvc("test", metav1.NamespaceDefault, nil, nil), "test", nil)
+		testPod.Status = v1.PodStatus{
+			ContainerStatuses: []v1.ContainerStatus{
+				{
+					State: v1.ContainerState{
+						Terminated: &v1.ContainerStateTerminated{},
+					},
+				},
+			},
+		}
+		termMsg := &common.TerminationMessage{ImportedPlatform: &common.ImportedPlatform{Architecture: "arm64", Digest: "sha256:1234"}}
+		setAnnotationsFromPodWithPrefix(result, testPod, termMsg, AnnRunningCondition)
+		Expect(result[AnnImportedPlatform]).To(Equal(`{"architecture":"arm64","digest":"sha256:1234"}`))
+	})
+
 	It("Should set scratch space required status", func() {
 		result := make(map[string]string)
 		testPod := CreateImporterTestPod(CreatePvc("test", metav1.NamespaceDefault, nil, nil), "test", nil)
@@ -256,6 +296,119 @@ in quay.io/myproject/myimage: manifest unknown`
 		Expect(result[AnnRequiresScratch]).To(BeEmpty())
 	})
 
+	It("Should set the checksum mismatch reason", func() {
+		const errorIncludesChecksumMismatchText = `Unable to process data: ` + common.ChecksumMismatchText + `: the sha256 digest of the source is 01, expected 02`
+
+		result := make(map[string]string)
+		testPod := CreateImporterTestPod(CreatePvc("test", metav1.NamespaceDefault, nil, nil), "test", nil)
+		testPod.Status = v1.PodStatus{
+			ContainerStatuses: []v1.ContainerStatus{
+				{
+					State: v1.ContainerState{
+						Terminated: &v1.ContainerStateTerminated{
+							Message: errorIncludesChecksumMismatchText,
+							Reason:  common.GenericError,
+						},
+					},
+				},
+			},
+		}
+		setAnnotationsFromPodWithPrefix(result, testPod, nil, AnnRunningCondition)
+		Expect(result[AnnRunningCondition]).To(Equal("false"))
+		Expect(result[AnnRunningConditionMessage]).To(Equal(errorIncludesChecksumMismatchText))
+		Expect(result[AnnRunningConditionReason]).To(Equal(ChecksumMismatchReason))
+	})
+
+	It("Should set the signature verification failure reason", func() {
+		const errorIncludesSignatureVerificationFailureText = `Unable to process data: ` + common.SignatureVerificationFailureText + `: the source does not match the signature https://images.example.com/disk.img.sig of key 0123456789ABCDEF: RSA verification failure`
+
+		result := make(map[string]string)
+		testPod := CreateImporterTestPod(CreatePvc("test", metav1.NamespaceDefault, nil, nil), "test", nil)
+		testPod.Status = v1.PodStatus{
+			ContainerStatuses: []v1.ContainerStatus{
+				{
+					State: v1.ContainerState{
+						Terminated: &v1.ContainerStateTerminated{
+							Message: errorIncludesSignatureVerificationFailureText,
+							Reason:  common.GenericError,
+						},
+					},
+				},
+			},
+		}
+		setAnnotationsFromPodWithPrefix(result, testPod, nil, AnnRunningCondition)
+		Expect(result[AnnRunningCondition]).To(Equal("false"))
+		Expect(result[AnnRunningConditionMessage]).To(Equal(errorIncludesSignatureVerificationFailureText))
+		Expect(result[AnnRunningConditionReason]).To(Equal(SignatureVerificationFailedReason))
+	})
+
+	It("Should set the image verification failure reason", func() {
+		const errorIncludesImageVerificationFailureText = `Unable to process data: Failed to read registry image: ` + common.ImageVerificationFailureText + `: quay.io/disks/fedora@sha256:0123 does not meet the ImageVerificationPolicy disks: the image has no signatures`
+
+		result := make(map[string]string)
+		testPod := CreateImporterTestPod(CreatePvc("test", metav1.NamespaceDefault, nil, nil), "test", nil)
+		testPod.Status = v1.PodStatus{
+			ContainerStatuses: []v1.ContainerStatus{
+				{
+					State: v1.ContainerState{
+						Terminated: &v1.ContainerStateTerminated{
+							Message: errorIncludesImageVerificationFailureText,
+							Reason:  common.GenericError,
+						},
+					},
+				},
+			},
+		}
+		setAnnotationsFromPodWithPrefix(result, testPod, nil, AnnRunningCondition)
+		Expect(result[AnnRunningCondition]).To(Equal("false"))
+		Expect(result[AnnRunningConditionMessage]).To(Equal(errorIncludesImageVerificationFailureText))
+		Expect(result[AnnRunningConditionReason]).To(Equal(ImageVerificationFailedReason))
+	})
+
+	It("Should set the failure reason and the source digest found by the importer", func() {
+		testPod := CreateImporterTestPod(CreatePvc("test", metav1.NamespaceDefault, nil, nil), "test", nil)
+		testPod.Status = v1.PodStatus{
+			ContainerStatuses: []v1.ContainerStatus{
+				{
+					State: v1.ContainerState{
+						Terminated: &v1.ContainerStateTerminated{
+							ExitCode: 1,
+							Message:  `{"message":"Unable to process data: no space left on device","failureReason":"InsufficientSpace","observedSourceDigest":"sha256:1234"}`,
+							Reason:   common.GenericError,
+						},
+					},
+				},
+			},
+		}
+		termMsg, err := parseTerminationMessage(testPod)
+		Expect(err).ToNot(HaveOccurred())
+		result := make(map[string]string)
+		setAnnotationsFromPodWithPrefix(result, testPod, termMsg, AnnRunningCondition)
+		Expect(result[AnnRunningCondition]).To(Equal("false"))
+		Expect(result[AnnRunningConditionMessage]).To(Equal("DataVolume too small to contain image"))
+		Expect(result[AnnRunningConditionReason]).To(Equal(cdiv1.DataVolumeReasonInsufficientSpace))
+		Expect(result[AnnObservedSourceDigest]).To(Equal("sha256:1234"))
+	})
+
+	It("Should ignore plain termination messages of failed pods", func() {
+		testPod := CreateImporterTestPod(CreatePvc("test", metav1.NamespaceDefault, nil, nil), "test", nil)
+		testPod.Status = v1.PodStatus{
+			ContainerStatuses: []v1.ContainerStatus{
+				{
+					State: v1.ContainerState{
+						Terminated: &v1.ContainerStateTerminated{
+							ExitCode: 1,
+							Message:  "Unable to process data: unexpected EOF",
+						},
+					},
+				},
+			},
+		}
+		termMsg, err := parseTerminationMessage(testPod)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(termMsg).To(BeNil())
+	})
+
 	It("Should set running reason as error for general errors", func() {
 		const errorMessage = `just a fake error text to check in this test`
 
@@ -342,39 +495,80 @@ var _ = Describe("GetPreallocation", func() {
 	It("Should return preallocation for DataVolume if specified", func() {
 		client := CreateClient()
 		dv := createDataVolumeWithPreallocation("test-dv", "test-ns", true)
-		preallocation := GetPreallocation(context.Background(), client, dv.Spec.Preallocation)
+		preallocation := GetPreallocation(context.Background(), client, dv.Spec.Preallocation, GetStorageClassFromDVSpec(dv))
 		Expect(preallocation).To(BeTrue())
 
 		dv = createDataVolumeWithPreallocation("test-dv", "test-ns", false)
-		preallocation = GetPreallocation(context.Background(), client, dv.Spec.Preallocation)
+		preallocation = GetPreallocation(context.Background(), client, dv.Spec.Preallocation, GetStorageClassFromDVSpec(dv))
 		Expect(preallocation).To(BeFalse())
 
 		// global: true, data volume overrides to false
 		client = CreateClient(createCDIConfigWithGlobalPreallocation(true))
 		dv = createDataVolumeWithStorageClassPreallocation("test-dv", "test-ns", "test-class", false)
-		preallocation = GetPreallocation(context.Background(), client, dv.Spec.Preallocation)
+		preallocation = GetPreallocation(context.Background(), client, dv.Spec.Preallocation, GetStorageClassFromDVSpec(dv))
 		Expect(preallocation).To(BeFalse())
 	})
 
 	It("Should return global preallocation setting if not defined in DV or SC", func() {
 		client := CreateClient(createCDIConfigWithGlobalPreallocation(true))
 		dv := createDataVolumeWithStorageClass("test-dv", "test-ns", "test-class")
-		preallocation := GetPreallocation(context.Background(), client, dv.Spec.Preallocation)
+		preallocation := GetPreallocation(context.Background(), client, dv.Spec.Preallocation, GetStorageClassFromDVSpec(dv))
 		Expect(preallocation).To(BeTrue())
 
 		client = CreateClient(createCDIConfigWithGlobalPreallocation(false))
-		preallocation = GetPreallocation(context.Background(), client, dv.Spec.Preallocation)
+		preallocation = GetPreallocation(context.Background(), client, dv.Spec.Preallocation, GetStorageClassFromDVSpec(dv))
 		Expect(preallocation).To(BeFalse())
 	})
 
+	It("Should return the preallocation of the StorageProfile over the global one", func() {
+		storageProfile := &cdiv1.StorageProfile{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-class"},
+			Status: cdiv1.StorageProfileStatus{
+				ConversionDefaults: &cdiv1.ConversionDefaults{Preallocation: ptr.To(true)},
+			},
+		}
+		client := CreateClient(createCDIConfigWithGlobalPreallocation(false), CreateStorageClass("test-class", nil), storageProfile)
+		dv := createDataVolumeWithStorageClass("test-dv", "test-ns", "test-class")
+		Expect(GetPreallocation(context.Background(), client, dv.Spec.Preallocation, GetStorageClassFromDVSpec(dv))).To(BeTrue())
+
+		// data volume overrides the StorageProfile
+		dv.Spec.Preallocation = ptr.To(false)
+		Expect(GetPreallocation(context.Background(), client, dv.Spec.Preallocation, GetStorageClassFromDVSpec(dv))).To(BeFalse())
+	})
+
 	It("Should be false when niether DV nor Config defines preallocation", func() {
 		client := CreateClient(createCDIConfig("test"))
 		dv := createDataVolumeWithStorageClass("test-dv", "test-ns", "test-class")
-		preallocation := GetPreallocation(context.Background(), client, dv.Spec.Preallocation)
+		preallocation := GetPreallocation(context.Background(), client, dv.Spec.Preallocation, GetStorageClassFromDVSpec(dv))
 		Expect(preallocation).To(BeFalse())
 	})
 })
 
+var _ = Describe("GetCloneCompression", func() {
+	storageProfile := &cdiv1.StorageProfile{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-class"},
+		Status: cdiv1.StorageProfileStatus{
+			CloneCompression: ptr.To(cdiv1.CloneCompressionZstd),
+		},
+	}
+
+	It("Should return the compression of the clone over the StorageProfile one", func() {
+		client := CreateClient(CreateStorageClass("test-class", nil), storageProfile)
+		compression := GetCloneCompression(context.Background(), client, ptr.To(cdiv1.CloneCompressionSnappy), ptr.To("test-class"))
+		Expect(compression).To(Equal(cdiv1.CloneCompressionSnappy))
+	})
+
+	It("Should return the compression of the StorageProfile if not defined by the clone", func() {
+		client := CreateClient(CreateStorageClass("test-class", nil), storageProfile)
+		Expect(GetCloneCompression(context.Background(), client, nil, ptr.To("test-class"))).To(Equal(cdiv1.CloneCompressionZstd))
+	})
+
+	It("Should be snappy when neither the clone nor the StorageProfile defines the compression", func() {
+		client := CreateClient(CreateStorageClass("test-class", nil))
+		Expect(GetCloneCompression(context.Background(), client, nil, ptr.To("test-class"))).To(Equal(cdiv1.CloneCompressionSnappy))
+	})
+})
+
 var _ = Describe("HandleFailedPod", func() {
 	pvc := CreatePvc("test-pvc", "test-ns", nil, nil)
 	podName := "test-pod"
@@ -480,6 +674,33 @@ var _ = Describe("createScratchPersistentVolumeClaim", func() {
 		Expect(scratchPVCSize.Value()).To(Equal(int64(1076 * 1024 * 1024)))
 	})
 
+	DescribeTable("Should size the scratch PVC following the scratch space overrides of the DataVolume", func(overrides string, expectedValue int64) {
+		cdiConfig := createCDIConfigWithStorageClass(common.ConfigName, scratchStorageClassName)
+		cdiConfig.Status.FilesystemOverhead = &cdiv1.FilesystemOverhead{
+			Global: "0.05",
+		}
+		cl := CreateClient(cdiConfig)
+		rec := record.NewFakeRecorder(10)
+		testPvc := CreatePvcInStorageClass("testPvc", "default", ptr.To[string](storageClassName), map[string]string{AnnScratchSpace: overrides}, nil, v1.ClaimBound)
+		testPvc.Spec.Resources.Requests[v1.ResourceStorage] = resource.MustParse("1Gi")
+		testPvc.Spec.VolumeMode = ptr.To[v1.PersistentVolumeMode](v1.PersistentVolumeBlock)
+		res, err := createScratchPersistentVolumeClaim(cl, testPvc, &v1.Pod{}, "test-scratchspace-pvc", scratchStorageClassName, nil, rec)
+		Expect(err).ToNot(HaveOccurred())
+		scratchPVCSize := *res.Spec.Resources.Requests.Storage()
+		Expect(scratchPVCSize.Value()).To(Equal(expectedValue * 1024 * 1024))
+	},
+		Entry("with an explicit size", `{"size":"5Gi"}`, int64(5120)),
+		Entry("with a size multiplier", `{"sizeMultiplier":"2"}`, int64(2152)),
+		Entry("with a storage class only", `{"storageClassName":"local-nvme"}`, int64(1076)),
+	)
+
+	It("Should fail with invalid scratch space overrides", func() {
+		cl := CreateClient(createCDIConfigWithStorageClass(common.ConfigName, scratchStorageClassName))
+		testPvc := CreatePvcInStorageClass("testPvc", "default", ptr.To[string](storageClassName), map[string]string{AnnScratchSpace: "invalid"}, nil, v1.ClaimBound)
+		_, err := createScratchPersistentVolumeClaim(cl, testPvc, &v1.Pod{}, "test-scratchspace-pvc", scratchStorageClassName, nil, record.NewFakeRecorder(10))
+		Expect(err).To(HaveOccurred())
+	})
+
 	It("Should add skip velero backup label", func() {
 		cdiConfig := createCDIConfigWithStorageClass(common.ConfigName, scratchStorageClassName)
 		cdiConfig.Status.FilesystemOverhead = &cdiv1.FilesystemOverhead{
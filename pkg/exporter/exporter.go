@@ -0,0 +1,206 @@
+/*
+Copyright 2026 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exporter
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+
+	"k8s.io/klog/v2"
+
+	"kubevirt.io/containerized-data-importer/pkg/image"
+)
+
+const (
+	// TargetHTTP uploads the image with an HTTP PUT request
+	TargetHTTP = "http"
+	// TargetS3 uploads the image as an object of a S3 bucket
+	TargetS3 = "s3"
+	// TargetRegistry pushes the image to a registry as a containerDisk
+	TargetRegistry = "registry"
+
+	// exportedImageName is the name of the qcow2 image in the scratch space
+	exportedImageName = "disk.qcow2"
+)
+
+// may be overridden in tests
+var convertToQcow2 = image.ConvertToQcow2
+
+// Options are the parameters of an export
+type Options struct {
+	// SourcePath is the raw disk image or the block device exported
+	SourcePath string
+	// SourceFormat is the format of the source, raw or qcow2
+	SourceFormat string
+	// ScratchDir is where the qcow2 image is converted to before its upload
+	ScratchDir string
+	// Compress compresses the clusters of the qcow2 image
+	Compress bool
+	// Target is one of TargetHTTP, TargetS3 and TargetRegistry
+	Target string
+	// Endpoint is the url of the target
+	Endpoint string
+	// AccessKey and SecretKey are the credentials of the target, optional
+	AccessKey string
+	SecretKey string
+	// CertDir holds the CA bundle of the target, optional
+	CertDir string
+}
+
+// uploader uploads the qcow2 image to a target, and returns the digest of what it uploaded
+type uploader interface {
+	upload(ctx context.Context, image string) (string, error)
+}
+
+// Export converts the source to qcow2 and uploads it to the target, returning the digest of the exported image
+func Export(ctx context.Context, opts Options) (string, error) {
+	up, err := newUploader(opts)
+	if err != nil {
+		return "", err
+	}
+	format := opts.SourceFormat
+	if format == "" {
+		format = "raw"
+	}
+	qcow2 := filepath.Join(opts.ScratchDir, exportedImageName)
+	if err := convertToQcow2(opts.SourcePath, qcow2, format, opts.Compress); err != nil {
+		return "", err
+	}
+	defer os.Remove(qcow2)
+	klog.Infof("Uploading %s to the %s target %s", qcow2, opts.Target, opts.Endpoint)
+	return up.upload(ctx, qcow2)
+}
+
+func newUploader(opts Options) (uploader, error) {
+	switch opts.Target {
+	case TargetHTTP:
+		return newHTTPUploader(opts)
+	case TargetS3:
+		return newS3Uploader(opts)
+	case TargetRegistry:
+		return newRegistryUploader(opts)
+	}
+	return nil, errors.Errorf("unknown export target %q", opts.Target)
+}
+
+// httpUploader uploads the image with a PUT request, with basic authentication if credentials are set
+type httpUploader struct {
+	endpoint  string
+	accessKey string
+	secretKey string
+	client    *http.Client
+}
+
+func newHTTPUploader(opts Options) (*httpUploader, error) {
+	client, err := createHTTPClient(opts.CertDir)
+	if err != nil {
+		return nil, err
+	}
+	return &httpUploader{
+		endpoint:  opts.Endpoint,
+		accessKey: opts.AccessKey,
+		secretKey: opts.SecretKey,
+		client:    client,
+	}, nil
+}
+
+func (u *httpUploader) upload(ctx context.Context, image string) (string, error) {
+	f, err := os.Open(image)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.New()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, u.endpoint, io.TeeReader(f, hash))
+	if err != nil {
+		return "", errors.Wrapf(err, "could not create request for %s", u.endpoint)
+	}
+	req.ContentLength = info.Size()
+	req.Header.Set("Content-Type", "application/octet-stream")
+	if u.accessKey != "" || u.secretKey != "" {
+		req.SetBasicAuth(u.accessKey, u.secretKey)
+	}
+	resp, err := u.client.Do(req)
+	if err != nil {
+		return "", errors.Wrapf(err, "could not upload to %s", u.endpoint)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return "", errors.Errorf("could not upload to %s: %s", u.endpoint, resp.Status)
+	}
+	return fmt.Sprintf("sha256:%x", hash.Sum(nil)), nil
+}
+
+// fileDigest returns the sha256 digest of a file
+func fileDigest(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	hash := sha256.New()
+	if _, err := io.Copy(hash, f); err != nil {
+		return "", errors.Wrapf(err, "could not compute the digest of %s", path)
+	}
+	return fmt.Sprintf("sha256:%x", hash.Sum(nil)), nil
+}
+
+// createHTTPClient creates a client trusting the system certificates, and the ones of certDir if set
+func createHTTPClient(certDir string) (*http.Client, error) {
+	if certDir == "" {
+		return &http.Client{}, nil
+	}
+	certPool, err := x509.SystemCertPool()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get system certs")
+	}
+	files, err := os.ReadDir(certDir)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not list files in %s", certDir)
+	}
+	for _, file := range files {
+		if file.IsDir() || file.Name()[0] == '.' {
+			continue
+		}
+		certs, err := os.ReadFile(filepath.Join(certDir, file.Name()))
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not read %s", file.Name())
+		}
+		if ok := certPool.AppendCertsFromPEM(certs); !ok {
+			klog.Warningf("No certs in %s", file.Name())
+		}
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{
+		RootCAs:    certPool,
+		MinVersion: tls.VersionTLS12,
+	}
+	return &http.Client{Transport: transport}, nil
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2026 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exporter
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Export", func() {
+	var (
+		scratch   string
+		converted []string
+	)
+
+	BeforeEach(func() {
+		scratch = GinkgoT().TempDir()
+		converted = nil
+		origConvertToQcow2 := convertToQcow2
+		DeferCleanup(func() {
+			convertToQcow2 = origConvertToQcow2
+		})
+		convertToQcow2 = func(src, dest, srcFormat string, compress bool) error {
+			converted = []string{src, dest, srcFormat, fmt.Sprint(compress)}
+			return os.WriteFile(dest, []byte("qcow2 image"), 0600)
+		}
+	})
+
+	imageDigest := fmt.Sprintf("sha256:%x", sha256.Sum256([]byte("qcow2 image")))
+
+	It("Should convert the source and upload it with a PUT request", func() {
+		var body []byte
+		var user, password string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer GinkgoRecover()
+			Expect(r.Method).To(Equal(http.MethodPut))
+			user, password, _ = r.BasicAuth()
+			body, _ = io.ReadAll(r.Body)
+			w.WriteHeader(http.StatusCreated)
+		}))
+		defer server.Close()
+
+		digest, err := Export(context.Background(), Options{
+			SourcePath: "/dev/cdi-block-volume",
+			ScratchDir: scratch,
+			Compress:   true,
+			Target:     TargetHTTP,
+			Endpoint:   server.URL + "/fedora.qcow2",
+			AccessKey:  "user",
+			SecretKey:  "password",
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(digest).To(Equal(imageDigest))
+		Expect(converted).To(Equal([]string{"/dev/cdi-block-volume", filepath.Join(scratch, "disk.qcow2"), "raw", "true"}))
+		Expect(body).To(Equal([]byte("qcow2 image")))
+		Expect(user).To(Equal("user"))
+		Expect(password).To(Equal("password"))
+		Expect(filepath.Join(scratch, "disk.qcow2")).ToNot(BeAnExistingFile())
+	})
+
+	It("Should convert qcow2 sources as qcow2", func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = io.Copy(io.Discard, r.Body)
+		}))
+		defer server.Close()
+
+		_, err := Export(context.Background(), Options{
+			SourcePath:   "/data/disk.img",
+			SourceFormat: "qcow2",
+			ScratchDir:   scratch,
+			Target:       TargetHTTP,
+			Endpoint:     server.URL,
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(converted).To(Equal([]string{"/data/disk.img", filepath.Join(scratch, "disk.qcow2"), "qcow2", "false"}))
+	})
+
+	It("Should fail if the server rejects the upload", func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+		}))
+		defer server.Close()
+
+		_, err := Export(context.Background(), Options{ScratchDir: scratch, Target: TargetHTTP, Endpoint: server.URL})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("403 Forbidden"))
+	})
+
+	It("Should fail on unknown targets before converting the source", func() {
+		_, err := Export(context.Background(), Options{ScratchDir: scratch, Target: "ftp", Endpoint: "ftp://example.com"})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("unknown export target"))
+		Expect(converted).To(BeNil())
+	})
+
+	It("Should fail if the conversion fails", func() {
+		convertToQcow2 = func(src, dest, srcFormat string, compress bool) error {
+			return fmt.Errorf("could not convert image to qcow2")
+		}
+		_, err := Export(context.Background(), Options{ScratchDir: scratch, Target: TargetHTTP, Endpoint: "http://example.com"})
+		Expect(err).To(MatchError("could not convert image to qcow2"))
+	})
+})
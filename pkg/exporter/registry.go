@@ -0,0 +1,183 @@
+/*
+Copyright 2026 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exporter
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/containers/image/v5/docker"
+	"github.com/containers/image/v5/manifest"
+	"github.com/containers/image/v5/pkg/blobinfocache/none"
+	"github.com/containers/image/v5/types"
+	"github.com/opencontainers/go-digest"
+	imgspecv1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+
+	"k8s.io/utils/ptr"
+
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+)
+
+const (
+	// containerDiskDir is where KubeVirt looks for the disk of containerDisks
+	containerDiskDir = "disk"
+	// containerDiskOwner is the qemu user of virt-launcher, which reads the disk
+	containerDiskOwner = 107
+)
+
+// may be overridden in tests
+var newImageDestination = func(ctx context.Context, sys *types.SystemContext, ref types.ImageReference) (types.ImageDestination, error) {
+	return ref.NewImageDestination(ctx, sys)
+}
+
+// registryUploader pushes the image as a containerDisk, a single layer image holding it in the disk directory
+type registryUploader struct {
+	ref types.ImageReference
+	sys *types.SystemContext
+}
+
+func newRegistryUploader(opts Options) (*registryUploader, error) {
+	name, ok := strings.CutPrefix(opts.Endpoint, cdiv1.RegistrySchemeDocker+":")
+	if !ok {
+		return nil, errors.Errorf("endpoint %q is not a %s:// image", opts.Endpoint, cdiv1.RegistrySchemeDocker)
+	}
+	ref, err := docker.ParseReference(name)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not parse image %q", opts.Endpoint)
+	}
+	sys := &types.SystemContext{}
+	if opts.AccessKey != "" && opts.SecretKey != "" {
+		sys.DockerAuthConfig = &types.DockerAuthConfig{
+			Username: opts.AccessKey,
+			Password: opts.SecretKey,
+		}
+	}
+	if opts.CertDir != "" {
+		sys.DockerCertPath = opts.CertDir
+	}
+	return &registryUploader{ref: ref, sys: sys}, nil
+}
+
+func (u *registryUploader) upload(ctx context.Context, image string) (string, error) {
+	dest, err := newImageDestination(ctx, u.sys, u.ref)
+	if err != nil {
+		return "", errors.Wrapf(err, "could not open image destination %s", u.ref.StringWithinTransport())
+	}
+	defer dest.Close()
+
+	layer, err := u.putLayer(ctx, dest, image)
+	if err != nil {
+		return "", err
+	}
+	config, err := json.Marshal(imgspecv1.Image{
+		Created: ptr.To(time.Now().UTC()),
+		Platform: imgspecv1.Platform{
+			Architecture: runtime.GOARCH,
+			OS:           "linux",
+		},
+		RootFS: imgspecv1.RootFS{
+			Type:    "layers",
+			DiffIDs: []digest.Digest{layer.Digest},
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+	configInfo, err := dest.PutBlob(ctx, bytes.NewReader(config), types.BlobInfo{Digest: digest.FromBytes(config), Size: int64(len(config))}, none.NoCache, true)
+	if err != nil {
+		return "", errors.Wrap(err, "could not push the image config")
+	}
+	m, err := manifest.OCI1FromComponents(
+		imgspecv1.Descriptor{MediaType: imgspecv1.MediaTypeImageConfig, Digest: configInfo.Digest, Size: configInfo.Size},
+		[]imgspecv1.Descriptor{{MediaType: imgspecv1.MediaTypeImageLayer, Digest: layer.Digest, Size: layer.Size}},
+	).Serialize()
+	if err != nil {
+		return "", err
+	}
+	if err := dest.PutManifest(ctx, m, nil); err != nil {
+		return "", errors.Wrap(err, "could not push the image manifest")
+	}
+	if err := dest.Commit(ctx, nil); err != nil {
+		return "", errors.Wrap(err, "could not commit the image")
+	}
+	manifestDigest, err := manifest.Digest(m)
+	if err != nil {
+		return "", err
+	}
+	return manifestDigest.String(), nil
+}
+
+// putLayer pushes the uncompressed layer holding the image as disk/disk.qcow2, owned by the qemu user
+func (u *registryUploader) putLayer(ctx context.Context, dest types.ImageDestination, image string) (types.BlobInfo, error) {
+	f, err := os.Open(image)
+	if err != nil {
+		return types.BlobInfo{}, err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return types.BlobInfo{}, err
+	}
+
+	reader, writer := io.Pipe()
+	go func() {
+		writer.CloseWithError(writeLayer(writer, f, info.Size(), info.ModTime()))
+	}()
+	layer, err := dest.PutBlob(ctx, reader, types.BlobInfo{Size: -1}, none.NoCache, false)
+	reader.Close()
+	if err != nil {
+		return types.BlobInfo{}, errors.Wrap(err, "could not push the image layer")
+	}
+	return layer, nil
+}
+
+func writeLayer(w io.Writer, image io.Reader, size int64, modTime time.Time) error {
+	tw := tar.NewWriter(w)
+	if err := tw.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeDir,
+		Name:     containerDiskDir + "/",
+		Mode:     0555,
+		Uid:      containerDiskOwner,
+		Gid:      containerDiskOwner,
+		ModTime:  modTime,
+	}); err != nil {
+		return err
+	}
+	if err := tw.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     containerDiskDir + "/" + exportedImageName,
+		Mode:     0440,
+		Size:     size,
+		Uid:      containerDiskOwner,
+		Gid:      containerDiskOwner,
+		ModTime:  modTime,
+	}); err != nil {
+		return err
+	}
+	if _, err := io.Copy(tw, image); err != nil {
+		return err
+	}
+	return tw.Close()
+}
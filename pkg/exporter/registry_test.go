@@ -0,0 +1,135 @@
+/*
+Copyright 2026 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exporter
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/containers/image/v5/manifest"
+	"github.com/containers/image/v5/types"
+	"github.com/opencontainers/go-digest"
+	imgspecv1 "github.com/opencontainers/image-spec/specs-go/v1"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Registry uploader", func() {
+	var (
+		dest  *fakeImageDestination
+		image string
+	)
+
+	BeforeEach(func() {
+		dest = &fakeImageDestination{blobs: map[digest.Digest][]byte{}}
+		origNewImageDestination := newImageDestination
+		DeferCleanup(func() {
+			newImageDestination = origNewImageDestination
+		})
+		newImageDestination = func(ctx context.Context, sys *types.SystemContext, ref types.ImageReference) (types.ImageDestination, error) {
+			dest.sys = sys
+			dest.ref = ref.DockerReference().String()
+			return dest, nil
+		}
+		image = filepath.Join(GinkgoT().TempDir(), "disk.qcow2")
+		Expect(os.WriteFile(image, []byte("qcow2 image"), 0600)).To(Succeed())
+	})
+
+	It("Should push a containerDisk holding the image", func() {
+		u, err := newRegistryUploader(Options{Endpoint: "docker://registry.example.com/images/fedora:latest", AccessKey: "user", SecretKey: "password", CertDir: "/certs"})
+		Expect(err).ToNot(HaveOccurred())
+		manifestDigest, err := u.upload(context.Background(), image)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(dest.ref).To(Equal("registry.example.com/images/fedora:latest"))
+		Expect(dest.sys.DockerAuthConfig.Username).To(Equal("user"))
+		Expect(dest.sys.DockerAuthConfig.Password).To(Equal("password"))
+		Expect(dest.sys.DockerCertPath).To(Equal("/certs"))
+		Expect(dest.committed).To(BeTrue())
+		Expect(manifestDigest).To(Equal(digest.FromBytes(dest.manifest).String()))
+
+		m, err := manifest.OCI1FromManifest(dest.manifest)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(m.Layers).To(HaveLen(1))
+		layer := dest.blobs[m.Layers[0].Digest]
+		Expect(layer).ToNot(BeNil())
+
+		var config imgspecv1.Image
+		Expect(json.Unmarshal(dest.blobs[m.Config.Digest], &config)).To(Succeed())
+		Expect(config.OS).To(Equal("linux"))
+		Expect(config.RootFS.DiffIDs).To(Equal([]digest.Digest{m.Layers[0].Digest}))
+
+		tr := tar.NewReader(bytes.NewReader(layer))
+		hdr, err := tr.Next()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(hdr.Name).To(Equal("disk/"))
+		Expect(hdr.Typeflag).To(BeEquivalentTo(tar.TypeDir))
+		hdr, err = tr.Next()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(hdr.Name).To(Equal("disk/disk.qcow2"))
+		Expect(hdr.Uid).To(Equal(107))
+		Expect(hdr.Gid).To(Equal(107))
+		Expect(hdr.Mode).To(BeEquivalentTo(0440))
+		data, err := io.ReadAll(tr)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(data).To(Equal([]byte("qcow2 image")))
+	})
+
+	It("Should reject endpoints which are not docker images", func() {
+		_, err := newRegistryUploader(Options{Endpoint: "oci-archive://fedora.tar"})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("is not a docker:// image"))
+	})
+})
+
+// fakeImageDestination stores the pushed blobs and manifest, the other methods are not used by the uploader
+type fakeImageDestination struct {
+	types.ImageDestination
+	sys       *types.SystemContext
+	ref       string
+	blobs     map[digest.Digest][]byte
+	manifest  []byte
+	committed bool
+}
+
+func (d *fakeImageDestination) PutBlob(ctx context.Context, stream io.Reader, inputInfo types.BlobInfo, cache types.BlobInfoCache, isConfig bool) (types.BlobInfo, error) {
+	data, err := io.ReadAll(stream)
+	if err != nil {
+		return types.BlobInfo{}, err
+	}
+	d.blobs[digest.FromBytes(data)] = data
+	return types.BlobInfo{Digest: digest.FromBytes(data), Size: int64(len(data))}, nil
+}
+
+func (d *fakeImageDestination) PutManifest(ctx context.Context, m []byte, instanceDigest *digest.Digest) error {
+	d.manifest = m
+	return nil
+}
+
+func (d *fakeImageDestination) Commit(ctx context.Context, unparsedToplevel types.UnparsedImage) error {
+	d.committed = true
+	return nil
+}
+
+func (d *fakeImageDestination) Close() error {
+	return nil
+}
@@ -0,0 +1,193 @@
+/*
+Copyright 2026 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exporter
+
+import (
+	"context"
+	"io"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/pkg/errors"
+
+	"k8s.io/klog/v2"
+)
+
+// s3MaxParts is the maximum number of parts of a multipart upload
+const s3MaxParts = 10000
+
+// s3Client is the interface to the used S3 client
+type s3Client interface {
+	PutObjectWithContext(ctx aws.Context, input *s3.PutObjectInput, opts ...request.Option) (*s3.PutObjectOutput, error)
+	CreateMultipartUploadWithContext(ctx aws.Context, input *s3.CreateMultipartUploadInput, opts ...request.Option) (*s3.CreateMultipartUploadOutput, error)
+	UploadPartWithContext(ctx aws.Context, input *s3.UploadPartInput, opts ...request.Option) (*s3.UploadPartOutput, error)
+	CompleteMultipartUploadWithContext(ctx aws.Context, input *s3.CompleteMultipartUploadInput, opts ...request.Option) (*s3.CompleteMultipartUploadOutput, error)
+	AbortMultipartUploadWithContext(ctx aws.Context, input *s3.AbortMultipartUploadInput, opts ...request.Option) (*s3.AbortMultipartUploadOutput, error)
+}
+
+// may be overridden in tests
+var (
+	newS3ClientFunc = newS3Client
+	// s3PartSize is the size of the parts of multipart uploads, the images smaller than a part are uploaded at once
+	s3PartSize int64 = 64 * 1024 * 1024
+)
+
+// s3Uploader uploads the image as an object, in parts if it is larger than a part
+type s3Uploader struct {
+	bucket string
+	object string
+	client s3Client
+}
+
+func newS3Uploader(opts Options) (*s3Uploader, error) {
+	ep, err := url.Parse(opts.Endpoint)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to parse endpoint %q", opts.Endpoint)
+	}
+	bucket, object, _ := strings.Cut(strings.Trim(ep.Path, "/"), "/")
+	if bucket == "" || object == "" {
+		return nil, errors.Errorf("endpoint %q has no bucket or object", opts.Endpoint)
+	}
+	client, err := newS3ClientFunc(ep, opts.AccessKey, opts.SecretKey, opts.CertDir)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not build s3 client for %q", ep.Host)
+	}
+	return &s3Uploader{bucket: bucket, object: object, client: client}, nil
+}
+
+func (u *s3Uploader) upload(ctx context.Context, image string) (string, error) {
+	digest, err := fileDigest(image)
+	if err != nil {
+		return "", err
+	}
+	f, err := os.Open(image)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return "", err
+	}
+	if info.Size() <= s3PartSize {
+		if _, err := u.client.PutObjectWithContext(ctx, &s3.PutObjectInput{
+			Bucket: aws.String(u.bucket),
+			Key:    aws.String(u.object),
+			Body:   f,
+		}); err != nil {
+			return "", errors.Wrapf(err, "could not put s3 object \"%s/%s\"", u.bucket, u.object)
+		}
+		return digest, nil
+	}
+	if err := u.uploadParts(ctx, f, info.Size()); err != nil {
+		return "", err
+	}
+	return digest, nil
+}
+
+// uploadParts uploads the image with a multipart upload, which is aborted on failure
+func (u *s3Uploader) uploadParts(ctx context.Context, f io.ReaderAt, size int64) error {
+	created, err := u.client.CreateMultipartUploadWithContext(ctx, &s3.CreateMultipartUploadInput{
+		Bucket: aws.String(u.bucket),
+		Key:    aws.String(u.object),
+	})
+	if err != nil {
+		return errors.Wrapf(err, "could not create multipart upload of s3 object \"%s/%s\"", u.bucket, u.object)
+	}
+	partSize := s3PartSize
+	if minPartSize := (size + s3MaxParts - 1) / s3MaxParts; minPartSize > partSize {
+		partSize = minPartSize
+	}
+	var parts []*s3.CompletedPart
+	for offset, number := int64(0), int64(1); offset < size; offset, number = offset+partSize, number+1 {
+		length := partSize
+		if offset+length > size {
+			length = size - offset
+		}
+		klog.V(3).Infof("Uploading part %d of s3 object \"%s/%s\"", number, u.bucket, u.object)
+		uploaded, err := u.client.UploadPartWithContext(ctx, &s3.UploadPartInput{
+			Bucket:     aws.String(u.bucket),
+			Key:        aws.String(u.object),
+			UploadId:   created.UploadId,
+			PartNumber: aws.Int64(number),
+			Body:       io.NewSectionReader(f, offset, length),
+		})
+		if err != nil {
+			u.abort(created.UploadId)
+			return errors.Wrapf(err, "could not upload part %d of s3 object \"%s/%s\"", number, u.bucket, u.object)
+		}
+		parts = append(parts, &s3.CompletedPart{ETag: uploaded.ETag, PartNumber: aws.Int64(number)})
+	}
+	if _, err := u.client.CompleteMultipartUploadWithContext(ctx, &s3.CompleteMultipartUploadInput{
+		Bucket:          aws.String(u.bucket),
+		Key:             aws.String(u.object),
+		UploadId:        created.UploadId,
+		MultipartUpload: &s3.CompletedMultipartUpload{Parts: parts},
+	}); err != nil {
+		u.abort(created.UploadId)
+		return errors.Wrapf(err, "could not complete multipart upload of s3 object \"%s/%s\"", u.bucket, u.object)
+	}
+	return nil
+}
+
+func (u *s3Uploader) abort(uploadID *string) {
+	if _, err := u.client.AbortMultipartUploadWithContext(context.Background(), &s3.AbortMultipartUploadInput{
+		Bucket:   aws.String(u.bucket),
+		Key:      aws.String(u.object),
+		UploadId: uploadID,
+	}); err != nil {
+		klog.Warningf("Could not abort multipart upload of s3 object \"%s/%s\": %v", u.bucket, u.object, err)
+	}
+}
+
+func newS3Client(ep *url.URL, accessKey, secretKey, certDir string) (s3Client, error) {
+	httpClient, err := createHTTPClient(certDir)
+	if err != nil {
+		return nil, err
+	}
+	config := &aws.Config{
+		Region:           aws.String(extractRegion(ep.Host)),
+		Endpoint:         aws.String(ep.Host),
+		S3ForcePathStyle: aws.Bool(true),
+		HTTPClient:       httpClient,
+		DisableSSL:       aws.Bool(ep.Scheme == "http"),
+	}
+	// Without access keys the default AWS credential chain is used
+	if accessKey != "" || secretKey != "" {
+		config.Credentials = credentials.NewStaticCredentials(accessKey, secretKey, "")
+	}
+	sess, err := session.NewSession(config)
+	if err != nil {
+		return nil, err
+	}
+	return s3.New(sess), nil
+}
+
+// extractRegion returns the region of AWS endpoints, and the first label of the host of other ones
+func extractRegion(host string) string {
+	if matches := regexp.MustCompile(`s3\.(.+)\.amazonaws\.com`).FindStringSubmatch(host); matches != nil {
+		return matches[1]
+	}
+	return strings.Split(host, ".")[0]
+}
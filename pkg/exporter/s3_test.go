@@ -0,0 +1,140 @@
+/*
+Copyright 2026 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exporter
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/s3"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("S3 uploader", func() {
+	var (
+		fake  *fakeS3Client
+		image string
+	)
+
+	BeforeEach(func() {
+		fake = &fakeS3Client{}
+		origNewS3ClientFunc, origPartSize := newS3ClientFunc, s3PartSize
+		DeferCleanup(func() {
+			newS3ClientFunc, s3PartSize = origNewS3ClientFunc, origPartSize
+		})
+		newS3ClientFunc = func(ep *url.URL, accessKey, secretKey, certDir string) (s3Client, error) {
+			fake.endpoint, fake.accessKey, fake.secretKey = ep.Host, accessKey, secretKey
+			return fake, nil
+		}
+		image = filepath.Join(GinkgoT().TempDir(), "disk.qcow2")
+		Expect(os.WriteFile(image, []byte("0123456789"), 0600)).To(Succeed())
+	})
+
+	upload := func() (string, error) {
+		u, err := newS3Uploader(Options{Endpoint: "https://s3.us-east-1.amazonaws.com/bucket/images/fedora.qcow2", AccessKey: "key", SecretKey: "secret"})
+		Expect(err).ToNot(HaveOccurred())
+		return u.upload(context.Background(), image)
+	}
+
+	It("Should put the images smaller than a part at once", func() {
+		digest, err := upload()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(digest).To(Equal(fmt.Sprintf("sha256:%x", sha256.Sum256([]byte("0123456789")))))
+		Expect(fake.endpoint).To(Equal("s3.us-east-1.amazonaws.com"))
+		Expect(fake.accessKey).To(Equal("key"))
+		Expect(fake.secretKey).To(Equal("secret"))
+		Expect(fake.bucket).To(Equal("bucket"))
+		Expect(fake.key).To(Equal("images/fedora.qcow2"))
+		Expect(fake.put).To(Equal("0123456789"))
+		Expect(fake.parts).To(BeEmpty())
+	})
+
+	It("Should upload the larger images in parts", func() {
+		s3PartSize = 4
+		_, err := upload()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fake.parts).To(Equal([]string{"0123", "4567", "89"}))
+		Expect(fake.completed).To(BeTrue())
+		Expect(fake.aborted).To(BeFalse())
+	})
+
+	It("Should abort the multipart upload if a part fails", func() {
+		s3PartSize = 4
+		fake.partErr = fmt.Errorf("part failed")
+		_, err := upload()
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("could not upload part 1"))
+		Expect(fake.completed).To(BeFalse())
+		Expect(fake.aborted).To(BeTrue())
+	})
+
+	It("Should reject endpoints without an object", func() {
+		_, err := newS3Uploader(Options{Endpoint: "https://s3.example.com/bucket"})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("has no bucket or object"))
+	})
+})
+
+type fakeS3Client struct {
+	endpoint, accessKey, secretKey string
+	bucket, key                    string
+	put                            string
+	parts                          []string
+	partErr                        error
+	completed                      bool
+	aborted                        bool
+}
+
+func (c *fakeS3Client) PutObjectWithContext(ctx aws.Context, input *s3.PutObjectInput, opts ...request.Option) (*s3.PutObjectOutput, error) {
+	c.bucket, c.key = *input.Bucket, *input.Key
+	data, err := io.ReadAll(input.Body)
+	c.put = string(data)
+	return &s3.PutObjectOutput{}, err
+}
+
+func (c *fakeS3Client) CreateMultipartUploadWithContext(ctx aws.Context, input *s3.CreateMultipartUploadInput, opts ...request.Option) (*s3.CreateMultipartUploadOutput, error) {
+	c.bucket, c.key = *input.Bucket, *input.Key
+	return &s3.CreateMultipartUploadOutput{UploadId: aws.String("upload")}, nil
+}
+
+func (c *fakeS3Client) UploadPartWithContext(ctx aws.Context, input *s3.UploadPartInput, opts ...request.Option) (*s3.UploadPartOutput, error) {
+	if c.partErr != nil {
+		return nil, c.partErr
+	}
+	data, err := io.ReadAll(input.Body)
+	c.parts = append(c.parts, string(data))
+	return &s3.UploadPartOutput{ETag: aws.String(fmt.Sprint(*input.PartNumber))}, err
+}
+
+func (c *fakeS3Client) CompleteMultipartUploadWithContext(ctx aws.Context, input *s3.CompleteMultipartUploadInput, opts ...request.Option) (*s3.CompleteMultipartUploadOutput, error) {
+	c.completed = len(input.MultipartUpload.Parts) == len(c.parts)
+	return &s3.CompleteMultipartUploadOutput{}, nil
+}
+
+func (c *fakeS3Client) AbortMultipartUploadWithContext(ctx aws.Context, input *s3.AbortMultipartUploadInput, opts ...request.Option) (*s3.AbortMultipartUploadOutput, error) {
+	c.aborted = true
+	return &s3.AbortMultipartUploadOutput{}, nil
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2024 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package image
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	"github.com/docker/go-units"
+	"github.com/pkg/errors"
+	"golang.org/x/sys/unix"
+
+	"k8s.io/klog/v2"
+)
+
+const (
+	// partitionTableRegion covers MBR and both the primary and backup GPT, with 512 byte or 4KiB sectors
+	partitionTableRegion = 64 * units.KiB
+	// edgeRegion is the amount zeroed at both ends of the device when requested
+	edgeRegion = units.MiB
+)
+
+// InitializeBlankBlock prepares the block device at dest, of size bytes, to be handed out as a blank disk.
+// It wipes the partition tables at both ends of the device, or the first and last MiB when zeroEdges is set,
+// and reads the wiped regions back from the device so a device that silently drops writes is not declared ready.
+func InitializeBlankBlock(dest string, size int64, zeroEdges bool) error {
+	region := int64(partitionTableRegion)
+	if zeroEdges {
+		region = edgeRegion
+	}
+	if size < region*2 {
+		region = size / 2
+	}
+	klog.V(1).Infof("Wiping %d bytes at both ends of blank block volume %s", region, dest)
+
+	f, err := os.OpenFile(dest, os.O_RDWR, 0)
+	if err != nil {
+		return errors.Wrapf(err, "could not open blank block volume %s", dest)
+	}
+	defer closeIOAndCheckErr(f)
+
+	offsets := []int64{0, size - region}
+	zeroes := make([]byte, region)
+	for _, offset := range offsets {
+		if _, err := f.WriteAt(zeroes, offset); err != nil {
+			return errors.Wrapf(err, "could not wipe blank block volume %s at offset %d", dest, offset)
+		}
+	}
+	if err := f.Sync(); err != nil {
+		return errors.Wrapf(err, "could not sync blank block volume %s", dest)
+	}
+	// Drop the synced pages from the page cache, the read back would be served from it instead of the device
+	if err := unix.Fadvise(int(f.Fd()), 0, 0, unix.FADV_DONTNEED); err != nil {
+		return errors.Wrapf(err, "could not drop the cached pages of blank block volume %s", dest)
+	}
+
+	readBack := make([]byte, region)
+	for _, offset := range offsets {
+		if _, err := f.ReadAt(readBack, offset); err != nil {
+			return errors.Wrapf(err, "could not read back blank block volume %s at offset %d", dest, offset)
+		}
+		if !bytes.Equal(readBack, zeroes) {
+			return fmt.Errorf("blank block volume %s still contains data at offset %d after wiping", dest, offset)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2024 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package image
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+
+	"github.com/docker/go-units"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Initialize blank block", func() {
+	const size = 4 * units.MiB
+	var tmpDir, dest string
+
+	BeforeEach(func() {
+		var err error
+		tmpDir, err = os.MkdirTemp("", "blankblock")
+		Expect(err).NotTo(HaveOccurred())
+		dest = filepath.Join(tmpDir, "dest")
+		Expect(os.WriteFile(dest, bytes.Repeat([]byte{0xff}, size), 0600)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(tmpDir)
+	})
+
+	readDest := func() []byte {
+		content, err := os.ReadFile(dest)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(content).To(HaveLen(size))
+		return content
+	}
+
+	It("Should wipe the partition tables only by default", func() {
+		Expect(InitializeBlankBlock(dest, size, false)).To(Succeed())
+		content := readDest()
+		Expect(content[:partitionTableRegion]).To(Equal(make([]byte, partitionTableRegion)))
+		Expect(content[size-partitionTableRegion:]).To(Equal(make([]byte, partitionTableRegion)))
+		Expect(content[partitionTableRegion]).To(Equal(byte(0xff)))
+		Expect(content[size-partitionTableRegion-1]).To(Equal(byte(0xff)))
+	})
+
+	It("Should zero the first and last MiB if requested", func() {
+		Expect(InitializeBlankBlock(dest, size, true)).To(Succeed())
+		content := readDest()
+		Expect(content[:units.MiB]).To(Equal(make([]byte, units.MiB)))
+		Expect(content[size-units.MiB:]).To(Equal(make([]byte, units.MiB)))
+		Expect(content[units.MiB]).To(Equal(byte(0xff)))
+	})
+
+	It("Should fail if the device cannot be opened", func() {
+		err := InitializeBlankBlock(filepath.Join(tmpDir, "missing"), size, false)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("could not open blank block volume"))
+	})
+})
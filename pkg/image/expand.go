@@ -0,0 +1,121 @@
+/*
+Copyright 2026 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package image
+
+import (
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"k8s.io/klog/v2"
+)
+
+const (
+	// guestfishDisk is the name guestfish gives to the only disk it is handed
+	guestfishDisk = "/dev/sda"
+	// maxPrimaryPartition is the last MBR partition that is not a logical partition of an extended one
+	maxPrimaryPartition = 4
+	// gptBackupSectors is the space the backup GPT header and partition entries take at the end of the disk
+	gptBackupSectors = 34
+)
+
+// may be overridden in tests
+var guestfishLookPath = exec.LookPath
+
+// ExpandFilesystem grows the last partition of the raw image, or block device, and the ext2/3/4, xfs or btrfs
+// filesystem it holds to fill the image. Layouts that can not be grown safely, like LVM, logical partitions or
+// other filesystems, are left untouched.
+func (o *qemuOperations) ExpandFilesystem(image string) error {
+	if _, err := guestfishLookPath("guestfish"); err != nil {
+		klog.Warningf("guestfish is not available, not expanding the filesystem of %s", image)
+		return nil
+	}
+	klog.V(1).Infof("Expanding the last partition and filesystem of %s", image)
+	output, err := guestfish(image, false, "list-partitions")
+	if err != nil {
+		return errors.Wrap(err, "could not list the partitions of the image")
+	}
+	partitions := strings.Fields(string(output))
+	device := guestfishDisk
+	partNum := 0
+	partType := ""
+	if len(partitions) > 0 {
+		device = partitions[len(partitions)-1]
+		if partNum, err = strconv.Atoi(strings.TrimPrefix(device, guestfishDisk)); err != nil {
+			return errors.Errorf("unexpected partition %s", device)
+		}
+		output, err = guestfish(image, false, "part-get-parttype", guestfishDisk)
+		if err != nil {
+			return errors.Wrap(err, "could not read the partition table of the image")
+		}
+		partType = strings.TrimSpace(string(output))
+		if partType == "msdos" && partNum > maxPrimaryPartition {
+			klog.Warningf("Last partition %s of %s is a logical partition, not expanding it", device, image)
+			return nil
+		}
+	}
+	output, err = guestfish(image, false, "vfs-type", device)
+	if err != nil {
+		return errors.Wrapf(err, "could not read the filesystem type of %s", device)
+	}
+	growArgs := growFilesystemArgs(strings.TrimSpace(string(output)), device)
+	if growArgs == nil {
+		klog.Warningf("Filesystem %q of %s can not be expanded", strings.TrimSpace(string(output)), device)
+		return nil
+	}
+
+	var args []string
+	if partNum > 0 {
+		endSector := "-1"
+		if partType == "gpt" {
+			// Move the backup GPT to the end of the grown image first
+			args = append(args, "part-expand-gpt", guestfishDisk, ":")
+			endSector = strconv.Itoa(-gptBackupSectors)
+		}
+		args = append(args, "part-resize", guestfishDisk, strconv.Itoa(partNum), endSector, ":")
+	}
+	args = append(args, growArgs...)
+	if _, err := guestfish(image, true, args...); err != nil {
+		return errors.Wrapf(err, "could not expand %s", device)
+	}
+	return nil
+}
+
+// growFilesystemArgs returns the guestfish commands growing the filesystem of type fsType on device to fill it,
+// nil if it can not be grown
+func growFilesystemArgs(fsType, device string) []string {
+	switch fsType {
+	case "ext2", "ext3", "ext4":
+		return []string{"e2fsck-f", device, ":", "resize2fs", device}
+	case "xfs":
+		return []string{"mount", device, "/", ":", "xfs-growfs", "/", ":", "umount", "/"}
+	case "btrfs":
+		return []string{"mount", device, "/", ":", "btrfs-filesystem-resize", "/", ":", "umount", "/"}
+	}
+	return nil
+}
+
+func guestfish(image string, write bool, commands ...string) ([]byte, error) {
+	mode := "--ro"
+	if write {
+		mode = "--rw"
+	}
+	args := append([]string{mode, "--format=raw", "-a", image, "run", ":"}, commands...)
+	return qemuExecFunction(nil, nil, "guestfish", args...)
+}
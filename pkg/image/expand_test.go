@@ -0,0 +1,114 @@
+/*
+Copyright 2026 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package image
+
+import (
+	"errors"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"kubevirt.io/containerized-data-importer/pkg/system"
+)
+
+var _ = Describe("Expand filesystem", func() {
+	var writes [][]string
+
+	BeforeEach(func() {
+		writes = nil
+		origLookPath := guestfishLookPath
+		guestfishLookPath = func(string) (string, error) { return "/usr/bin/guestfish", nil }
+		DeferCleanup(func() { guestfishLookPath = origLookPath })
+	})
+
+	// mockGuestfish answers the read only guestfish commands from outputs, and records the write ones
+	mockGuestfish := func(outputs map[string]string) execFunctionType {
+		return func(limits *system.ProcessLimitValues, f func(string), cmd string, args ...string) ([]byte, error) {
+			Expect(cmd).To(Equal("guestfish"))
+			Expect(args[1:6]).To(Equal([]string{"--format=raw", "-a", "disk.img", "run", ":"}))
+			if args[0] == "--rw" {
+				writes = append(writes, args[6:])
+				return nil, nil
+			}
+			output, ok := outputs[strings.Join(args[6:], " ")]
+			if !ok {
+				return nil, errors.New("unexpected command")
+			}
+			return []byte(output), nil
+		}
+	}
+
+	DescribeTable("should grow the last partition and its filesystem", func(outputs map[string]string, expected []string) {
+		replaceExecFunction(mockGuestfish(outputs), func() {
+			Expect(NewQEMUOperations().ExpandFilesystem("disk.img")).To(Succeed())
+		})
+		Expect(writes).To(Equal([][]string{expected}))
+	},
+		Entry("ext4 on GPT", map[string]string{
+			"list-partitions":            "/dev/sda1\n/dev/sda2\n",
+			"part-get-parttype /dev/sda": "gpt\n",
+			"vfs-type /dev/sda2":         "ext4\n",
+		}, []string{"part-expand-gpt", "/dev/sda", ":", "part-resize", "/dev/sda", "2", "-34", ":", "e2fsck-f", "/dev/sda2", ":", "resize2fs", "/dev/sda2"}),
+		Entry("xfs on MBR", map[string]string{
+			"list-partitions":            "/dev/sda1\n",
+			"part-get-parttype /dev/sda": "msdos\n",
+			"vfs-type /dev/sda1":         "xfs\n",
+		}, []string{"part-resize", "/dev/sda", "1", "-1", ":", "mount", "/dev/sda1", "/", ":", "xfs-growfs", "/", ":", "umount", "/"}),
+		Entry("btrfs without partitions", map[string]string{
+			"list-partitions":   "",
+			"vfs-type /dev/sda": "btrfs\n",
+		}, []string{"mount", "/dev/sda", "/", ":", "btrfs-filesystem-resize", "/", ":", "umount", "/"}),
+	)
+
+	DescribeTable("should leave layouts that can not be grown untouched", func(outputs map[string]string) {
+		replaceExecFunction(mockGuestfish(outputs), func() {
+			Expect(NewQEMUOperations().ExpandFilesystem("disk.img")).To(Succeed())
+		})
+		Expect(writes).To(BeEmpty())
+	},
+		Entry("LVM", map[string]string{
+			"list-partitions":            "/dev/sda1\n/dev/sda2\n",
+			"part-get-parttype /dev/sda": "msdos\n",
+			"vfs-type /dev/sda2":         "LVM2_member\n",
+		}),
+		Entry("logical partition", map[string]string{
+			"list-partitions":            "/dev/sda1\n/dev/sda5\n",
+			"part-get-parttype /dev/sda": "msdos\n",
+		}),
+		Entry("unsupported filesystem", map[string]string{
+			"list-partitions":            "/dev/sda1\n",
+			"part-get-parttype /dev/sda": "gpt\n",
+			"vfs-type /dev/sda1":         "ntfs\n",
+		}),
+	)
+
+	It("should not expand without guestfish", func() {
+		guestfishLookPath = func(string) (string, error) { return "", errors.New("not found") }
+		replaceExecFunction(mockGuestfish(nil), func() {
+			Expect(NewQEMUOperations().ExpandFilesystem("disk.img")).To(Succeed())
+		})
+		Expect(writes).To(BeEmpty())
+	})
+
+	It("should fail when the image can not be read", func() {
+		replaceExecFunction(mockGuestfish(nil), func() {
+			err := NewQEMUOperations().ExpandFilesystem("disk.img")
+			Expect(err).To(MatchError(ContainSubstring("could not list the partitions of the image")))
+		})
+	})
+})
@@ -3,6 +3,8 @@ package image
 import (
 	"bytes"
 	"encoding/hex"
+	"io"
+	"os"
 	"strconv"
 
 	"github.com/pkg/errors"
@@ -10,6 +12,11 @@ import (
 	"k8s.io/klog/v2"
 )
 
+// isoMagicOffset is the offset of the magic number of the first volume descriptor of ISO 9660 images
+const isoMagicOffset = 0x8001
+
+var isoMagicNumber = []byte("CD001")
+
 // MaxExpectedHdrSize defines the Size of buffer used to read file headers.
 // Note: this is the size of tar's header. If a larger number is used the tar unarchive operation
 //
@@ -73,6 +80,12 @@ var knownHeaders = Headers{
 		SizeOff:     0,
 		SizeLen:     0,
 	},
+	"zip": Header{
+		Format:      "zip",
+		magicNumber: []byte{'P', 'K', 0x03, 0x04},
+		SizeOff:     0,
+		SizeLen:     0,
+	},
 	"vhdx": Header{
 		Format:      "vhdx",
 		magicNumber: []byte("vhdxfile"),
@@ -118,3 +131,21 @@ func (h Header) Size(b []byte) (int64, error) {
 	klog.V(3).Infof("Size: %q size in bytes (at off %d:%d): %d", h.Format, h.SizeOff, h.SizeOff+h.SizeLen, size)
 	return size, nil
 }
+
+// IsISO checks whether the file or device at path holds an ISO 9660 image. The magic number of ISO images is past
+// MaxExpectedHdrSize, so they are not among the known headers.
+func IsISO(path string) (bool, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return false, errors.Wrapf(err, "could not open %s", path)
+	}
+	defer f.Close()
+	b := make([]byte, len(isoMagicNumber))
+	if _, err := f.ReadAt(b, isoMagicOffset); err != nil {
+		if errors.Is(err, io.EOF) {
+			return false, nil
+		}
+		return false, errors.Wrapf(err, "could not read %s", path)
+	}
+	return bytes.Equal(b, isoMagicNumber), nil
+}
@@ -2,6 +2,8 @@ package image
 
 import (
 	rand "crypto/rand"
+	"os"
+	"path/filepath"
 	"reflect"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -57,6 +59,10 @@ var _ = Describe("File format tests", func() {
 			Header{"vpc", []byte("connectix"), 0, 24, 8},
 			[]byte("connectix"),
 			true),
+		Entry("match zip",
+			Header{"zip", []byte{'P', 'K', 0x03, 0x04}, 0, 0, 0},
+			[]byte{'P', 'K', 0x03, 0x04},
+			true),
 		Entry("match vhdx",
 			Header{"vhdx", []byte("vhdxfile"), 0, 24, 8},
 			[]byte("vhdxfile"),
@@ -91,4 +97,18 @@ var _ = Describe("File format tests", func() {
 			int64(0),
 			false),
 	)
+
+	DescribeTable("ISO check", func(size int, magic []byte, want bool) {
+		data := make([]byte, size)
+		copy(data[isoMagicOffset:], magic)
+		path := filepath.Join(GinkgoT().TempDir(), "disk.img")
+		Expect(os.WriteFile(path, data, 0600)).To(Succeed())
+		got, err := IsISO(path)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(got).To(Equal(want))
+	},
+		Entry("of an ISO image", 0x10000, isoMagicNumber, true),
+		Entry("of a raw image", 0x10000, []byte{0, 0, 0, 0, 0}, false),
+		Entry("of a file smaller than the volume descriptor", isoMagicOffset, []byte{}, false),
+	)
 })
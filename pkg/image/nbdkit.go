@@ -37,10 +37,12 @@ type NbdkitLogWatcher interface {
 
 // Nbdkit plugins
 const (
-	NbdkitCurlPlugin     NbdkitPlugin = "curl"
-	NbdkitFilePlugin     NbdkitPlugin = "file"
-	NbdkitVddkPlugin     NbdkitPlugin = "vddk"
-	NbdkitVddkMockPlugin NbdkitPlugin = "/opt/testing/libvddk-test-plugin.so"
+	NbdkitCurlPlugin      NbdkitPlugin = "curl"
+	NbdkitFilePlugin      NbdkitPlugin = "file"
+	NbdkitSSHPlugin       NbdkitPlugin = "ssh"
+	NbdkitStreamingPlugin NbdkitPlugin = "streaming"
+	NbdkitVddkPlugin      NbdkitPlugin = "vddk"
+	NbdkitVddkMockPlugin  NbdkitPlugin = "/opt/testing/libvddk-test-plugin.so"
 )
 
 // Nbdkit filters
@@ -51,6 +53,8 @@ const (
 	NbdkitRetryFilter        NbdkitFilter = "retry"
 	NbdkitCacheExtentsFilter NbdkitFilter = "cacheextents"
 	NbdkitReadAheadFilter    NbdkitFilter = "readahead"
+	NbdkitTruncateFilter     NbdkitFilter = "truncate"
+	NbdkitRateFilter         NbdkitFilter = "rate"
 )
 
 // Nbdkit represents struct for an nbdkit instance
@@ -65,6 +69,9 @@ type Nbdkit struct {
 	Socket     string
 	Env        []string
 	LogWatcher NbdkitLogWatcher
+
+	// redactSource hides the source from the logs, for urls carrying credentials
+	redactSource bool
 }
 
 // NbdkitOperation defines the interface for executing nbdkit
@@ -73,6 +80,8 @@ type NbdkitOperation interface {
 	KillNbdkit() error
 	AddEnvVariable(v string)
 	AddFilter(filter NbdkitFilter)
+	SetMaxBandwidth(bytesPerSecond int64)
+	SetCurlOptions(options NbdkitCurlOptions)
 }
 
 // NewNbdkit creates a new Nbdkit instance with an nbdkit plugin and pid file
@@ -126,6 +135,132 @@ func NewNbdkitCurl(nbdkitPidFile, user, password, certDir, socket string, extraH
 	return n, nil
 }
 
+// NewNbdkitCurlWithHeaderFile creates a new Nbdkit instance with the curl plugin, that also sends the headers in headerFile.
+// The file is read again every renewInterval, so headers like a bearer token can be refreshed while nbdkit runs.
+func NewNbdkitCurlWithHeaderFile(nbdkitPidFile, certDir, socket, headerFile string, renewInterval time.Duration, extraHeaders, secretExtraHeaders []string) (NbdkitOperation, error) {
+	op, err := NewNbdkitCurl(nbdkitPidFile, "", "", certDir, socket, extraHeaders, secretExtraHeaders)
+	if err != nil {
+		return nil, err
+	}
+	n := op.(*Nbdkit)
+	n.addHeaderScript(headerFile, renewInterval)
+	return n, nil
+}
+
+// addHeaderScript makes the curl plugin send the headers in headerFile, read again every renewInterval
+func (n *Nbdkit) addHeaderScript(headerFile string, renewInterval time.Duration) {
+	n.pluginArgs = append(n.pluginArgs,
+		"header-script=cat "+headerFile,
+		fmt.Sprintf("header-script-renew=%d", int(renewInterval.Seconds())))
+}
+
+// NbdkitAzureBlobArgs holds the options of an nbdkit instance serving an Azure blob
+type NbdkitAzureBlobArgs struct {
+	CertDir string
+	// Headers are sent with every request, SecretHeaders too but they are never logged
+	Headers       []string
+	SecretHeaders []string
+	// HeaderFile, when set, holds headers that are read again every HeaderRenewInterval, like refreshed tokens
+	HeaderFile          string
+	HeaderRenewInterval time.Duration
+	// Size, when set, only exposes the first Size bytes of the blob
+	Size int64
+}
+
+// NewNbdkitAzureBlob creates a new Nbdkit instance reading an Azure blob with ranged requests through the curl plugin.
+// The blob url may contain a SAS token, so it is never logged.
+func NewNbdkitAzureBlob(nbdkitPidFile, socket string, args NbdkitAzureBlobArgs) (NbdkitOperation, error) {
+	op, err := NewNbdkitCurl(nbdkitPidFile, "", "", args.CertDir, socket, args.Headers, args.SecretHeaders)
+	if err != nil {
+		return nil, err
+	}
+	n := op.(*Nbdkit)
+	n.redactSource = true
+	if args.HeaderFile != "" {
+		n.addHeaderScript(args.HeaderFile, args.HeaderRenewInterval)
+	}
+	if args.Size > 0 {
+		// Outermost filter, so the others never see the part of the blob that is cut off
+		n.filters = append([]NbdkitFilter{NbdkitTruncateFilter}, n.filters...)
+		n.pluginArgs = append(n.pluginArgs, fmt.Sprintf("truncate=%d", args.Size))
+	}
+	return n, nil
+}
+
+// NbdkitSSHArgs holds the options of an nbdkit instance reading a file over SFTP
+type NbdkitSSHArgs struct {
+	Host string
+	Port string
+	User string
+	// Path of the file on the server, files ending in .xz or .gz are decompressed
+	Path string
+	// Password, used if no IdentityFile is given
+	Password     string
+	IdentityFile string
+	// KnownHostsFile holds the keys the server has to present
+	KnownHostsFile string
+}
+
+// NewNbdkitSSH creates a new Nbdkit instance reading a file over SFTP through the ssh plugin.
+// The server key is always verified against the known hosts file.
+func NewNbdkitSSH(nbdkitPidFile, socket string, args NbdkitSSHArgs) (NbdkitOperation, error) {
+	pluginArgs := []string{
+		"host=" + args.Host,
+		"user=" + args.User,
+		"known-hosts=" + args.KnownHostsFile,
+		"verify-remote-host=true",
+	}
+	if args.Port != "" {
+		pluginArgs = append(pluginArgs, "port="+args.Port)
+	}
+	if args.IdentityFile != "" {
+		pluginArgs = append(pluginArgs, "identity="+args.IdentityFile)
+	} else if args.Password != "" {
+		passwordfile, err := writePasswordFile(args.Password)
+		if err != nil {
+			return nil, err
+		}
+		pluginArgs = append(pluginArgs, "password=+"+passwordfile)
+	}
+	n := &Nbdkit{
+		NbdPidFile: nbdkitPidFile,
+		plugin:     NbdkitSSHPlugin,
+		pluginArgs: pluginArgs,
+		Socket:     socket,
+	}
+	n.addDecompressFilter(args.Path)
+	n.AddFilter(NbdkitReadAheadFilter)
+	// Should be last filter
+	n.AddFilter(NbdkitRetryFilter)
+	return n, nil
+}
+
+// NewNbdkitStreaming creates a new Nbdkit instance serving the data written to a pipe through the streaming plugin.
+// Clients have to read it mostly sequentially, the plugin only keeps a window of the data read last.
+func NewNbdkitStreaming(nbdkitPidFile, socket string) (NbdkitOperation, error) {
+	n := &Nbdkit{
+		NbdPidFile: nbdkitPidFile,
+		plugin:     NbdkitStreamingPlugin,
+		Socket:     socket,
+	}
+	return n, nil
+}
+
+// addDecompressFilter adds the filter decompressing files ending in .xz or .gz
+func (n *Nbdkit) addDecompressFilter(path string) {
+	var filter NbdkitFilter
+	switch {
+	case strings.HasSuffix(path, ".xz"):
+		filter = NbdkitXzFilter
+	case strings.HasSuffix(path, ".gz"):
+		filter = NbdkitGzipFilter
+	default:
+		return
+	}
+	// Outermost filter, the compressed file is read through the others
+	n.filters = append([]NbdkitFilter{filter}, n.filters...)
+}
+
 // Keep these in a struct to keep NewNbdkitVddk from going over the argument limit
 type NbdKitVddkPluginArgs struct {
 	Server     string
@@ -227,6 +362,61 @@ func (n *Nbdkit) AddFilter(filter NbdkitFilter) {
 	n.filters = append(n.filters, filter)
 }
 
+// SetMaxBandwidth throttles the reads of nbdkit from its source to bytesPerSecond with the rate filter
+func (n *Nbdkit) SetMaxBandwidth(bytesPerSecond int64) {
+	// Under the readahead filter, so the data read ahead is throttled too, and over the retry filter
+	filters := []NbdkitFilter{}
+	for _, f := range n.filters {
+		if f == NbdkitRetryFilter {
+			filters = append(filters, NbdkitRateFilter)
+		}
+		filters = append(filters, f)
+	}
+	if len(filters) == len(n.filters) {
+		filters = append(filters, NbdkitRateFilter)
+	}
+	n.filters = filters
+	// The rate filter takes bits per second
+	n.pluginArgs = append(n.pluginArgs, fmt.Sprintf("rate=%d", bytesPerSecond*8))
+}
+
+// NbdkitCurlOptions tunes the connections of the curl plugin to its source
+type NbdkitCurlOptions struct {
+	// Connections is the size of the pool of connections the concurrent requests of qemu-img are spread over
+	Connections int
+	// HTTPVersion is the HTTP version requested, as taken by the http-version option of the plugin, like 2.0 or 2TLS
+	HTTPVersion string
+	// TLS13Ciphers is the colon separated list of the TLS 1.3 cipher suites offered
+	TLS13Ciphers string
+	// Timeout is the longest a request may take, including connecting
+	Timeout time.Duration
+	// LogConnections has curl log the connections it opens and reuses as debug messages. curl logs the headers it
+	// sends along with them, so the LogWatcher of the instance has to filter the debug messages out of the logs.
+	LogConnections bool
+}
+
+// SetCurlOptions tunes the connections of the curl plugin, the options left unset keep the defaults of the plugin
+func (n *Nbdkit) SetCurlOptions(options NbdkitCurlOptions) {
+	if options.Connections > 0 {
+		n.pluginArgs = append(n.pluginArgs, fmt.Sprintf("connections=%d", options.Connections))
+	}
+	if options.HTTPVersion != "" {
+		n.pluginArgs = append(n.pluginArgs, "http-version="+options.HTTPVersion)
+	}
+	if options.TLS13Ciphers != "" {
+		n.pluginArgs = append(n.pluginArgs, "tls13-ciphers="+options.TLS13Ciphers)
+	}
+	if options.Timeout > 0 {
+		// The plugin takes whole seconds
+		n.pluginArgs = append(n.pluginArgs, fmt.Sprintf("timeout=%d", int((options.Timeout+time.Second-1)/time.Second)))
+	}
+	if options.LogConnections {
+		// Debug messages of the data path are left out, only those of curl are wanted
+		n.nbdkitArgs = append(n.nbdkitArgs, "--verbose", "-D", "nbdkit.backend.datapath=0")
+		n.pluginArgs = append(n.pluginArgs, "verbose=true")
+	}
+}
+
 func getVddkPluginPath() NbdkitPlugin {
 	_, err := os.Stat(string(NbdkitVddkMockPlugin))
 	if !os.IsNotExist(err) {
@@ -258,6 +448,10 @@ func (n *Nbdkit) getSourceArg(s string) string {
 		source = fmt.Sprintf("url=%s", s)
 	case NbdkitVddkPlugin, NbdkitVddkMockPlugin:
 		source = fmt.Sprintf("file=%s", s)
+	case NbdkitSSHPlugin:
+		source = fmt.Sprintf("path=%s", s)
+	case NbdkitStreamingPlugin:
+		source = fmt.Sprintf("read=%s", s)
 	default:
 		source = s
 	}
@@ -298,7 +492,9 @@ func (n *Nbdkit) StartNbdkit(source string) error {
 
 	quotedArgs := make([]string, len(argsNbdkit))
 	for index, value := range argsNbdkit {
-		if isRedacted(value) {
+		if n.redactSource && index == len(argsNbdkit)-1 {
+			quotedArgs[index] = "'url=/secret redacted/'"
+		} else if isRedacted(value) {
 			if strings.HasPrefix(value, "header=") {
 				quotedArgs[index] = "'header=/secret redacted/'"
 			} else {
@@ -465,6 +661,26 @@ func NewMockNbdkitCurl(nbdkitPidFile, user, password, certDir, socket string, ex
 	return &mockNbdkit{}, nil
 }
 
+// NewMockNbdkitCurlWithHeaderFile creates a mock nbdkit curl plugin sending the headers of a file for testing
+func NewMockNbdkitCurlWithHeaderFile(nbdkitPidFile, certDir, socket, headerFile string, renewInterval time.Duration, extraHeaders, secretExtraHeaders []string) (NbdkitOperation, error) {
+	return &mockNbdkit{}, nil
+}
+
+// NewMockNbdkitAzureBlob creates a mock nbdkit Azure blob reader for testing
+func NewMockNbdkitAzureBlob(nbdkitPidFile, socket string, args NbdkitAzureBlobArgs) (NbdkitOperation, error) {
+	return &mockNbdkit{}, nil
+}
+
+// NewMockNbdkitSSH creates a mock nbdkit SFTP reader for testing
+func NewMockNbdkitSSH(nbdkitPidFile, socket string, args NbdkitSSHArgs) (NbdkitOperation, error) {
+	return &mockNbdkit{}, nil
+}
+
+// NewMockNbdkitStreaming creates a mock nbdkit streaming reader for testing
+func NewMockNbdkitStreaming(nbdkitPidFile, socket string) (NbdkitOperation, error) {
+	return &mockNbdkit{}, nil
+}
+
 func (m *mockNbdkit) StartNbdkit(source string) error {
 	return nil
 }
@@ -473,3 +689,7 @@ func (m *mockNbdkit) KillNbdkit() error {
 }
 func (m *mockNbdkit) AddEnvVariable(v string)       {}
 func (m *mockNbdkit) AddFilter(filter NbdkitFilter) {}
+
+func (m *mockNbdkit) SetMaxBandwidth(bytesPerSecond int64) {}
+
+func (m *mockNbdkit) SetCurlOptions(options NbdkitCurlOptions) {}
@@ -0,0 +1,88 @@
+/*
+Copyright 2024 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package image
+
+import (
+	"strings"
+	"sync"
+)
+
+// preallocationMethod is a qemu-img preallocation option, named after the preallocation mode it applies
+type preallocationMethod struct {
+	name    string
+	options []string
+}
+
+// preallocationMethods is an ordered list of qemu-img preallocation options, from most to least preferred
+type preallocationMethods []preallocationMethod
+
+var (
+	preallocationMutex sync.Mutex
+	// preferredPreallocation is the method found supported by the storage class of the destination, by an earlier
+	// import recorded on its StorageProfile or earlier in this one. The methods before it are skipped.
+	preferredPreallocation string
+	// appliedPreallocation is the method that preallocated the destination
+	appliedPreallocation string
+)
+
+// first returns the index of the first method worth trying
+func (p preallocationMethods) first() int {
+	preallocationMutex.Lock()
+	defer preallocationMutex.Unlock()
+	for i, method := range p {
+		if method.name == preferredPreallocation {
+			return i
+		}
+	}
+	return 0
+}
+
+// applied records method as supported by the storage of the destination
+func (p preallocationMethods) applied(method preallocationMethod) {
+	preallocationMutex.Lock()
+	defer preallocationMutex.Unlock()
+	preferredPreallocation = method.name
+	appliedPreallocation = method.name
+}
+
+// SetPreferredPreallocationMethod sets the preallocation method tried first, the one found supported by the storage
+// class of the destination
+func SetPreferredPreallocationMethod(name string) {
+	preallocationMutex.Lock()
+	defer preallocationMutex.Unlock()
+	preferredPreallocation = name
+}
+
+// PreallocationMethod returns the preallocation method applied to the destination, empty if it was not preallocated
+func PreallocationMethod() string {
+	preallocationMutex.Lock()
+	defer preallocationMutex.Unlock()
+	return appliedPreallocation
+}
+
+// resetPreallocationMethods forgets the preferred and applied methods
+func resetPreallocationMethods() {
+	preallocationMutex.Lock()
+	defer preallocationMutex.Unlock()
+	preferredPreallocation = ""
+	appliedPreallocation = ""
+}
+
+// preallocationMethodName returns a human readable name of a preallocation option
+func preallocationMethodName(option []string) string {
+	return strings.Join(option, " ")
+}
@@ -0,0 +1,175 @@
+/*
+Copyright 2024 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package image
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/sys/unix"
+
+	"k8s.io/klog/v2"
+
+	metrics "kubevirt.io/containerized-data-importer/pkg/monitoring/metrics/cdi-importer"
+)
+
+const (
+	// sectorSize is the unit used by both st_blocks and /sys/dev/block/*/stat
+	sectorSize = 512
+	// sectorsWrittenField is the index of the "write sectors" column in /sys/dev/block/*/stat
+	sectorsWrittenField = 6
+)
+
+var (
+	progressPollInterval = 2 * time.Second
+	sysDevBlockPath      = "/sys/dev/block"
+)
+
+// bytesWrittenFunc returns the amount of data written to a destination so far
+type bytesWrittenFunc func() (int64, error)
+
+// progressPoller periodically samples how much data has landed on a destination
+// and reports it as a percentage of the expected total.
+type progressPoller struct {
+	written  bytesWrittenFunc
+	total    int64
+	interval time.Duration
+	done     chan struct{}
+	wg       sync.WaitGroup
+}
+
+// newDestinationProgress returns a poller tracking the destination of a conversion,
+// or nil if progress cannot be measured on that destination.
+func newDestinationProgress(dest string, total int64, preallocate bool) *progressPoller {
+	if total <= 0 {
+		return nil
+	}
+	// A missing destination is a file qemu-img has not created yet, block devices always exist
+	var stat unix.Stat_t
+	if err := unix.Stat(dest, &stat); err != nil && !errors.Is(err, unix.ENOENT) {
+		klog.V(3).Infof("Unable to stat %s for progress reporting: %v", dest, err)
+		return nil
+	}
+
+	var written bytesWrittenFunc
+	if (stat.Mode & unix.S_IFMT) == unix.S_IFBLK {
+		statPath := fmt.Sprintf("%s/%d:%d/stat", sysDevBlockPath, unix.Major(stat.Rdev), unix.Minor(stat.Rdev))
+		baseline, err := readSectorsWritten(statPath)
+		if err != nil {
+			klog.V(3).Infof("Unable to read block device statistics for progress reporting: %v", err)
+			return nil
+		}
+		written = func() (int64, error) {
+			sectors, err := readSectorsWritten(statPath)
+			if err != nil {
+				return 0, err
+			}
+			return (sectors - baseline) * sectorSize, nil
+		}
+	} else {
+		// A preallocated file is fully allocated from the start, so its allocation says nothing about progress
+		if preallocate {
+			return nil
+		}
+		written = func() (int64, error) {
+			var st unix.Stat_t
+			if err := unix.Stat(dest, &st); errors.Is(err, unix.ENOENT) {
+				return 0, nil
+			} else if err != nil {
+				return 0, err
+			}
+			return st.Blocks * sectorSize, nil
+		}
+	}
+
+	return &progressPoller{
+		written:  written,
+		total:    total,
+		interval: progressPollInterval,
+		done:     make(chan struct{}),
+	}
+}
+
+func readSectorsWritten(statPath string) (int64, error) {
+	content, err := os.ReadFile(statPath)
+	if err != nil {
+		return 0, err
+	}
+	fields := strings.Fields(string(content))
+	if len(fields) <= sectorsWrittenField {
+		return 0, fmt.Errorf("unexpected format of %s", statPath)
+	}
+	return strconv.ParseInt(fields[sectorsWrittenField], 10, 64)
+}
+
+func (p *progressPoller) start() {
+	if p == nil {
+		return
+	}
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		ticker := time.NewTicker(p.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-p.done:
+				return
+			case <-ticker.C:
+				p.poll()
+			}
+		}
+	}()
+}
+
+func (p *progressPoller) poll() {
+	written, err := p.written()
+	if err != nil {
+		klog.V(3).Infof("Unable to sample destination progress: %v", err)
+		return
+	}
+	reportProgress(float64(written) / float64(p.total) * 100)
+}
+
+func (p *progressPoller) stop() {
+	if p == nil {
+		return
+	}
+	close(p.done)
+	p.wg.Wait()
+}
+
+// reportProgress raises the import progress metric to value percent. The metric
+// is a counter, so progress never goes backwards, and it is capped at 100.
+func reportProgress(value float64) {
+	if ownerUID == "" {
+		return
+	}
+	if value > 100 {
+		value = 100
+	}
+	progress, err := metrics.Progress(ownerUID).Get()
+	if err == nil && value > 0 && value > progress {
+		klog.V(1).Infof("%.2f", value)
+		metrics.Progress(ownerUID).Add(value - progress)
+	}
+}
@@ -22,7 +22,6 @@ import (
 	"io/fs"
 	"net/url"
 	"os"
-	"regexp"
 	"strconv"
 	"strings"
 
@@ -30,6 +29,7 @@ import (
 	"github.com/pkg/errors"
 	"golang.org/x/sys/unix"
 
+	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/klog/v2"
 
@@ -43,7 +43,6 @@ const (
 	networkTimeoutSecs = 3600    //max is 10000
 	maxMemory          = 1 << 30 //value from OpenStack Nova
 	maxCPUSecs         = 30      //value from OpenStack Nova
-	matcherString      = "\\((\\d?\\d\\.\\d\\d)\\/100%\\)"
 )
 
 // ImgInfo contains the virtual image information.
@@ -61,33 +60,43 @@ type ImgInfo struct {
 // QEMUOperations defines the interface for executing qemu subprocesses
 type QEMUOperations interface {
 	ConvertToRawStream(*url.URL, string, bool, string) error
+	CloneWithConversion(*url.URL, string, resource.Quantity, bool, string) error
 	Resize(string, resource.Quantity, bool) error
 	Info(url *url.URL) (*ImgInfo, error)
-	Validate(*url.URL, int64) error
+	Validate(*url.URL, int64, float64, v1.PersistentVolumeMode) error
 	CreateBlankImage(string, resource.Quantity, bool) error
 	Rebase(backingFile string, delta string) error
 	Commit(image string) error
+	Compress(image string) error
+	ExpandFilesystem(image string) error
+	Sparsify(image string) error
+	Encrypt(image, passphraseFile string) error
+	Check(image string) error
+	Compare(source, image string) error
 }
 
 type qemuOperations struct{}
 
 var (
 	ErrLargerPVCRequired = errors.New("A larger PVC is required")
+	// ErrInvalidImage is matched by the errors of images qemu-img finds invalid
+	ErrInvalidImage = errors.New("invalid image")
 
 	qemuExecFunction = system.ExecWithLimits
 	qemuInfoLimits   = &system.ProcessLimitValues{AddressSpaceLimit: maxMemory, CPUTimeLimit: maxCPUSecs}
 	qemuIterface     = NewQEMUOperations()
-	re               = regexp.MustCompile(matcherString)
 
 	ownerUID                    string
-	convertPreallocationMethods = [][]string{
-		{"-o", "preallocation=falloc"},
-		{"-o", "preallocation=full"},
-		{"-S", "0"},
+	sparseThreshold             string
+	coroutines                  string
+	convertPreallocationMethods = preallocationMethods{
+		{name: common.PreallocationMethodFalloc, options: []string{"-o", "preallocation=falloc"}},
+		{name: common.PreallocationMethodFull, options: []string{"-o", "preallocation=full"}},
+		{name: common.PreallocationMethodZero, options: []string{"-S", "0"}},
 	}
-	resizePreallocationMethods = [][]string{
-		{"--preallocation=falloc"},
-		{"--preallocation=full"},
+	resizePreallocationMethods = preallocationMethods{
+		{name: common.PreallocationMethodFalloc, options: []string{"--preallocation=falloc"}},
+		{name: common.PreallocationMethodFull, options: []string{"--preallocation=full"}},
 	}
 	odirectChecker = NewDirectIOChecker(RealOS{})
 )
@@ -97,6 +106,11 @@ func init() {
 		klog.Errorf("Unable to create prometheus progress counter: %v", err)
 	}
 	ownerUID, _ = util.ParseEnvVar(common.OwnerUID, false)
+	sparseThreshold, _ = util.ParseEnvVar(common.ImporterSparseThreshold, false)
+	coroutines, _ = util.ParseEnvVar(common.ImporterCoroutines, false)
+	if method, _ := util.ParseEnvVar(common.ImporterPreallocationMethod, false); method != "" {
+		SetPreferredPreallocationMethod(method)
+	}
 }
 
 // NewQEMUOperations returns the default implementation of QEMUOperations
@@ -104,21 +118,28 @@ func NewQEMUOperations() QEMUOperations {
 	return &qemuOperations{}
 }
 
-func convertToRaw(src, dest string, preallocate bool, cacheMode string) error {
+// convertToRaw converts src into a raw image at dest. virtualSize is the expected
+// size of the result and is only used for progress reporting, 0 disables it.
+func convertToRaw(src, dest string, preallocate bool, cacheMode string, virtualSize int64) error {
 	cacheMode, err := getCacheMode(dest, cacheMode)
 	if err != nil {
 		return err
 	}
-	args := []string{"convert", "-t", cacheMode, "-p", "-O", "raw", src, dest}
+	args := []string{"convert", "-t", cacheMode}
+	args = append(args, conversionTuningArgs(preallocate)...)
+	args = append(args, "-O", "raw", src, dest)
 
+	progress := newDestinationProgress(dest, virtualSize, preallocate)
+	progress.start()
 	if preallocate {
 		err = addPreallocation(args, convertPreallocationMethods, func(args []string) ([]byte, error) {
-			return qemuExecFunction(nil, reportProgress, "qemu-img", args...)
+			return qemuExecFunction(nil, nil, "qemu-img", args...)
 		})
 	} else {
 		klog.V(1).Infof("Running qemu-img with args: %v", args)
-		_, err = qemuExecFunction(nil, reportProgress, "qemu-img", args...)
+		_, err = qemuExecFunction(nil, nil, "qemu-img", args...)
 	}
+	progress.stop()
 	if err != nil {
 		os.Remove(dest)
 		errorMsg := "could not convert image to raw"
@@ -127,10 +148,24 @@ func convertToRaw(src, dest string, preallocate bool, cacheMode string) error {
 		}
 		return errors.Wrap(err, errorMsg)
 	}
+	reportProgress(100)
 
 	return nil
 }
 
+// conversionTuningArgs returns the qemu-img convert options tuning the conversion to the storage of the target.
+// The sparse threshold is left out when preallocating, as it conflicts with the preallocation options
+func conversionTuningArgs(preallocate bool) []string {
+	var args []string
+	if sparseThreshold != "" && !preallocate {
+		args = append(args, "-S", sparseThreshold)
+	}
+	if coroutines != "" {
+		args = append(args, "-m", coroutines)
+	}
+	return args
+}
+
 func getCacheMode(path string, cacheMode string) (string, error) {
 	if cacheMode != common.CacheModeTryNone {
 		return "writeback", nil
@@ -162,11 +197,120 @@ func getCacheMode(path string, cacheMode string) (string, error) {
 	return "writeback", nil
 }
 
+// qemuSourceString validates url against the schemes accepted for an operation and
+// returns the filename to hand to qemu-img.
+func qemuSourceString(url *url.URL, schemes ...string) (string, error) {
+	if len(url.Scheme) == 0 {
+		return url.String(), nil
+	}
+	valid := false
+	for _, scheme := range schemes {
+		if url.Scheme == scheme {
+			valid = true
+			break
+		}
+	}
+	if !valid {
+		return "", fmt.Errorf("not valid schema %s", url.Scheme)
+	}
+
+	switch url.Scheme {
+	case "ssh":
+		// qemu's ssh driver authenticates with ssh-agent or keys only
+		if _, hasPassword := url.User.Password(); hasPassword {
+			return "", errors.New("password authentication is not supported for ssh sources")
+		}
+		if url.Host == "" || url.Path == "" {
+			return "", fmt.Errorf("ssh source %s must specify a host and a path", url.Redacted())
+		}
+	case "nfs":
+		if url.Host == "" || url.Path == "" {
+			return "", fmt.Errorf("nfs source %s must specify a server and a path", url.Redacted())
+		}
+	case "rbd":
+		// qemu's rbd driver takes rbd:pool/image[@snapshot][:option=value...]
+		if pool, image, _ := strings.Cut(url.Opaque, "/"); pool == "" || image == "" {
+			return "", fmt.Errorf("rbd source %s must specify a pool and an image", url.String())
+		}
+	case "iscsi":
+		// CHAP credentials are passed to libiscsi in the environment, not in the url
+		if url.User != nil {
+			return "", fmt.Errorf("iscsi source %s must not contain credentials", url.Redacted())
+		}
+		if target, lun, _ := strings.Cut(strings.TrimPrefix(url.Path, "/"), "/"); url.Host == "" || target == "" || lun == "" {
+			return "", fmt.Errorf("iscsi source %s must specify a portal, a target and a LUN", url.Redacted())
+		}
+	}
+	return url.String(), nil
+}
+
 func (o *qemuOperations) ConvertToRawStream(url *url.URL, dest string, preallocate bool, cacheMode string) error {
-	if len(url.Scheme) > 0 && url.Scheme != "nbd+unix" {
-		return fmt.Errorf("not valid schema %s", url.Scheme)
+	src, err := qemuSourceString(url, "nbd+unix", "ssh", "nfs", "rbd", "iscsi")
+	if err != nil {
+		return err
+	}
+	var virtualSize int64
+	if info, err := o.Info(url); err == nil {
+		virtualSize = info.VirtualSize
+	} else {
+		klog.V(1).Infof("Unable to determine virtual size, progress will not be reported: %v", err)
+	}
+	return convertToRaw(src, dest, preallocate, cacheMode, virtualSize)
+}
+
+// CloneWithConversion converts the image at url to raw and grows it to size in a single pass over the data.
+// The target is created at its final size first, which only touches metadata, and the image is then
+// converted into it.
+func (o *qemuOperations) CloneWithConversion(url *url.URL, dest string, size resource.Quantity, preallocate bool, cacheMode string) error {
+	src, err := qemuSourceString(url, "nbd+unix", "file", "ssh", "nfs", "rbd", "iscsi")
+	if err != nil {
+		return err
+	}
+	info, err := o.Info(url)
+	if err != nil {
+		return err
+	}
+	if size.CmpInt64(info.VirtualSize) < 0 {
+		return errors.Errorf("requested size %s is smaller than the virtual size %d of image %s", size.String(), info.VirtualSize, src)
+	}
+
+	var stat unix.Stat_t
+	isBlock := unix.Stat(dest, &stat) == nil && (stat.Mode&unix.S_IFMT) == unix.S_IFBLK
+	if !isBlock {
+		if err := o.CreateBlankImage(dest, size, preallocate); err != nil {
+			return err
+		}
+	}
+
+	cacheMode, err = getCacheMode(dest, cacheMode)
+	if err != nil {
+		return err
 	}
-	return convertToRaw(url.String(), dest, preallocate, cacheMode)
+	// -n reuses the target created above, preallocation options can't be combined with it
+	args := []string{"convert", "-n", "-t", cacheMode, "-O", "raw"}
+	if preallocate && isBlock {
+		args = append(args, "-S", "0")
+	}
+	args = append(args, src, dest)
+
+	progress := newDestinationProgress(dest, info.VirtualSize, preallocate)
+	progress.start()
+	klog.V(1).Infof("Running qemu-img with args: %v", args)
+	_, err = qemuExecFunction(nil, nil, "qemu-img", args...)
+	progress.stop()
+	if err != nil {
+		if !isBlock {
+			os.Remove(dest)
+		}
+		return errors.Wrap(err, "could not convert image to raw")
+	}
+	reportProgress(100)
+	return nil
+}
+
+// CloneWithConversion converts the image at url to a raw image of the requested size at dest
+func CloneWithConversion(url *url.URL, dest string, size resource.Quantity, preallocate bool, cacheMode string) error {
+	return qemuIterface.CloneWithConversion(url, dest, size, preallocate, cacheMode)
 }
 
 // convertQuantityToQemuSize translates a quantity string into a Qemu compatible string.
@@ -216,10 +360,11 @@ func Info(url *url.URL) (*ImgInfo, error) {
 }
 
 func (o *qemuOperations) Info(url *url.URL) (*ImgInfo, error) {
-	if len(url.Scheme) > 0 && url.Scheme != "nbd+unix" && url.Scheme != "file" {
-		return nil, fmt.Errorf("not valid schema %s", url.Scheme)
+	src, err := qemuSourceString(url, "nbd+unix", "file", "ssh", "nfs", "rbd", "iscsi")
+	if err != nil {
+		return nil, err
 	}
-	output, err := qemuExecFunction(qemuInfoLimits, nil, "qemu-img", "info", "--output=json", url.String())
+	output, err := qemuExecFunction(qemuInfoLimits, nil, "qemu-img", "info", "--output=json", src)
 	if err != nil {
 		errorMsg := fmt.Sprintf("%s, %s", output, err.Error())
 		if url.Scheme == "nbd+unix" {
@@ -229,7 +374,7 @@ func (o *qemuOperations) Info(url *url.URL) (*ImgInfo, error) {
 		}
 		return nil, errors.New(errorMsg)
 	}
-	return checkOutputQemuImgInfo(output, url.String())
+	return checkOutputQemuImgInfo(output, src)
 }
 
 func isSupportedFormat(value string) bool {
@@ -241,29 +386,59 @@ func isSupportedFormat(value string) bool {
 	}
 }
 
-func checkIfURLIsValid(info *ImgInfo, availableSize int64, image string) error {
+// requiredSpace returns how much storage an image of virtualSize bytes needs on a volume of the given mode
+func requiredSpace(virtualSize int64, filesystemOverhead float64, volumeMode v1.PersistentVolumeMode) int64 {
+	if volumeMode == v1.PersistentVolumeBlock {
+		return virtualSize
+	}
+	return util.GetRequiredSpace(filesystemOverhead, virtualSize)
+}
+
+// usableSpace returns how much of availableSize an image may occupy on a volume of the given mode
+func usableSpace(availableSize int64, filesystemOverhead float64, volumeMode v1.PersistentVolumeMode) int64 {
+	if volumeMode == v1.PersistentVolumeBlock {
+		return availableSize
+	}
+	return util.GetUsableSpace(filesystemOverhead, availableSize)
+}
+
+// invalidImageError is the error of an invalid image, matching ErrInvalidImage
+type invalidImageError struct {
+	error
+}
+
+func (e invalidImageError) Is(target error) bool {
+	return target == ErrInvalidImage
+}
+
+func (e invalidImageError) Unwrap() error {
+	return e.error
+}
+
+func checkIfURLIsValid(info *ImgInfo, availableSize int64, filesystemOverhead float64, volumeMode v1.PersistentVolumeMode, image string) error {
 	if !isSupportedFormat(info.Format) {
-		return errors.Errorf("Invalid format %s for image %s", info.Format, image)
+		return invalidImageError{errors.Errorf("Invalid format %s for image %s", info.Format, image)}
 	}
 
 	if len(info.BackingFile) > 0 {
 		if _, err := os.Stat(info.BackingFile); err != nil {
-			return errors.Errorf("Image %s is invalid because it has invalid backing file %s", image, info.BackingFile)
+			return invalidImageError{errors.Errorf("Image %s is invalid because it has invalid backing file %s", image, info.BackingFile)}
 		}
 	}
 
-	if availableSize < info.VirtualSize {
-		return fmt.Errorf("virtual image size %d is larger than the reported available storage %d. %w", info.VirtualSize, availableSize, ErrLargerPVCRequired)
+	if usable := usableSpace(availableSize, filesystemOverhead, volumeMode); usable < info.VirtualSize {
+		return fmt.Errorf("virtual image size %d is larger than the usable storage %d (available %d), %d bytes are required. %w",
+			info.VirtualSize, usable, availableSize, requiredSpace(info.VirtualSize, filesystemOverhead, volumeMode), ErrLargerPVCRequired)
 	}
 	return nil
 }
 
-func (o *qemuOperations) Validate(url *url.URL, availableSize int64) error {
+func (o *qemuOperations) Validate(url *url.URL, availableSize int64, filesystemOverhead float64, volumeMode v1.PersistentVolumeMode) error {
 	info, err := o.Info(url)
 	if err != nil {
 		return err
 	}
-	return checkIfURLIsValid(info, availableSize, url.String())
+	return checkIfURLIsValid(info, availableSize, filesystemOverhead, volumeMode, url.String())
 }
 
 // ConvertToRawStream converts an http accessible image to raw format without locally caching the image
@@ -271,23 +446,10 @@ func ConvertToRawStream(url *url.URL, dest string, preallocate bool, cacheMode s
 	return qemuIterface.ConvertToRawStream(url, dest, preallocate, cacheMode)
 }
 
-// Validate does basic validation of a qemu image
-func Validate(url *url.URL, availableSize int64) error {
-	return qemuIterface.Validate(url, availableSize)
-}
-
-func reportProgress(line string) {
-	// (45.34/100%)
-	matches := re.FindStringSubmatch(line)
-	if len(matches) == 2 && ownerUID != "" {
-		klog.V(1).Info(matches[1])
-		// Don't need to check for an error, the regex made sure its a number we can parse.
-		v, _ := strconv.ParseFloat(matches[1], 64)
-		progress, err := metrics.Progress(ownerUID).Get()
-		if err == nil && v > 0 && v > progress {
-			metrics.Progress(ownerUID).Add(v - progress)
-		}
-	}
+// Validate does basic validation of a qemu image, and checks it fits in availableSize bytes of
+// a volume with the given mode. Filesystem volumes reserve filesystemOverhead of the space for metadata.
+func Validate(url *url.URL, availableSize int64, filesystemOverhead float64, volumeMode v1.PersistentVolumeMode) error {
+	return qemuIterface.Validate(url, availableSize, filesystemOverhead, volumeMode)
 }
 
 // CreateBlankImage creates empty raw image
@@ -356,23 +518,33 @@ func PreallocateBlankBlock(dest string, size resource.Quantity) error {
 	return nil
 }
 
-func addPreallocation(args []string, preallocationMethods [][]string, qemuFn func(args []string) ([]byte, error)) error {
+// addPreallocation runs qemuFn with the first preallocation method supported by the storage holding dest.
+// Methods found to be unsupported are skipped on later calls for the same kind of storage.
+func addPreallocation(args []string, preallocationMethods preallocationMethods, qemuFn func(args []string) ([]byte, error)) error {
 	var err error
-	for _, preallocationMethod := range preallocationMethods {
+	for i := preallocationMethods.first(); i < len(preallocationMethods); i++ {
 		var output []byte
+		preallocationMethod := preallocationMethods[i]
 
-		klog.V(1).Infof("Adding preallocation method: %v", preallocationMethod)
+		klog.V(1).Infof("Adding preallocation method: %v", preallocationMethod.options)
 		// For some subcommands (e.g. resize), preallocation optinos must come before other options
-		argsToTry := append([]string{args[0]}, preallocationMethod...)
+		argsToTry := append([]string{args[0]}, preallocationMethod.options...)
 		argsToTry = append(argsToTry, args[1:]...)
 		klog.V(1).Infof("Attempting preallocation method, qemu-img args: %v", argsToTry)
 
 		output, err = qemuFn(argsToTry)
 		if err != nil && strings.Contains(string(output), "Unsupported preallocation mode") {
 			klog.V(1).Infof("Unsupported preallocation mode. Retrying")
-		} else {
-			break
+			continue
+		}
+		if err == nil {
+			preallocationMethods.applied(preallocationMethod)
+			klog.V(1).Infof("Preallocation method %s is supported", preallocationMethod.name)
+			if ownerUID != "" {
+				metrics.SetPreallocationMethod(ownerUID, preallocationMethodName(preallocationMethod.options))
+			}
 		}
+		break
 	}
 
 	return err
@@ -382,15 +554,61 @@ func addPreallocation(args []string, preallocationMethods [][]string, qemuFn fun
 // Depends on original image having been downloaded as raw.
 func (o *qemuOperations) Rebase(backingFile string, delta string) error {
 	klog.V(1).Infof("Rebasing %s onto %s", delta, backingFile)
-	args := []string{"rebase", "-p", "-u", "-F", "raw", "-b", backingFile, delta}
-	_, err := qemuExecFunction(nil, reportProgress, "qemu-img", args...)
+	args := []string{"rebase", "-u", "-F", "raw", "-b", backingFile, delta}
+	_, err := qemuExecFunction(nil, nil, "qemu-img", args...)
 	return err
 }
 
 // Commit takes the changes written to a QCOW and applies them to its raw backing file.
 func (o *qemuOperations) Commit(image string) error {
 	klog.V(1).Infof("Committing %s to backing file...", image)
-	args := []string{"commit", "-p", image}
-	_, err := qemuExecFunction(nil, reportProgress, "qemu-img", args...)
+	args := []string{"commit", image}
+	_, err := qemuExecFunction(nil, nil, "qemu-img", args...)
+	return err
+}
+
+// Compress rewrites a raw image as a compressed qcow2 image in place.
+func (o *qemuOperations) Compress(image string) error {
+	klog.V(1).Infof("Compressing %s to qcow2", image)
+	compressed := image + ".qcow2"
+	if err := convertToQcow2(image, compressed, "raw", true); err != nil {
+		os.Remove(compressed)
+		return errors.Wrap(err, "could not compress image to qcow2")
+	}
+	return os.Rename(compressed, image)
+}
+
+// Encrypt rewrites a raw image as a LUKS encrypted qcow2 image in place, its key is the passphrase read from
+// passphraseFile.
+func (o *qemuOperations) Encrypt(image, passphraseFile string) error {
+	klog.V(1).Infof("Encrypting %s to qcow2", image)
+	encrypted := image + ".qcow2"
+	args := []string{"convert", "-f", "raw", "-O", "qcow2",
+		"--object", "secret,id=sec0,file=" + passphraseFile,
+		"-o", "encrypt.format=luks,encrypt.key-secret=sec0",
+		image, encrypted}
+	if _, err := qemuExecFunction(nil, nil, "qemu-img", args...); err != nil {
+		os.Remove(encrypted)
+		return errors.Wrap(err, "could not encrypt image")
+	}
+	return os.Rename(encrypted, image)
+}
+
+// ConvertToQcow2 converts the src image in srcFormat to a qcow2 image, whose clusters are compressed if compress is set.
+func ConvertToQcow2(src, dest, srcFormat string, compress bool) error {
+	klog.V(1).Infof("Converting %s image %s to qcow2, compressed %v", srcFormat, src, compress)
+	if err := convertToQcow2(src, dest, srcFormat, compress); err != nil {
+		return errors.Wrap(err, "could not convert image to qcow2")
+	}
+	return nil
+}
+
+func convertToQcow2(src, dest, srcFormat string, compress bool) error {
+	args := []string{"convert"}
+	if compress {
+		args = append(args, "-c")
+	}
+	args = append(args, "-f", srcFormat, "-O", "qcow2", src, dest)
+	_, err := qemuExecFunction(nil, nil, "qemu-img", args...)
 	return err
 }
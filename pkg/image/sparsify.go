@@ -0,0 +1,42 @@
+/*
+Copyright 2026 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package image
+
+import (
+	"os/exec"
+
+	"github.com/pkg/errors"
+
+	"k8s.io/klog/v2"
+)
+
+// may be overridden in tests
+var virtSparsifyLookPath = exec.LookPath
+
+// Sparsify discards the unused blocks of the filesystems of the raw image, or block device, in place so that they
+// no longer take space on the target. Images are left untouched when virt-sparsify is not available.
+func (o *qemuOperations) Sparsify(image string) error {
+	if _, err := virtSparsifyLookPath("virt-sparsify"); err != nil {
+		klog.Warningf("virt-sparsify is not available, not sparsifying %s", image)
+		return nil
+	}
+	klog.V(1).Infof("Sparsifying %s", image)
+	if _, err := qemuExecFunction(nil, nil, "virt-sparsify", "--in-place", "--format", "raw", image); err != nil {
+		return errors.Wrap(err, "could not sparsify the image")
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2026 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package image
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"kubevirt.io/containerized-data-importer/pkg/system"
+)
+
+var _ = Describe("Sparsify", func() {
+	var calls [][]string
+
+	BeforeEach(func() {
+		calls = nil
+		origLookPath := virtSparsifyLookPath
+		virtSparsifyLookPath = func(string) (string, error) { return "/usr/bin/virt-sparsify", nil }
+		DeferCleanup(func() { virtSparsifyLookPath = origLookPath })
+	})
+
+	mockVirtSparsify := func(err error) execFunctionType {
+		return func(limits *system.ProcessLimitValues, f func(string), cmd string, args ...string) ([]byte, error) {
+			Expect(cmd).To(Equal("virt-sparsify"))
+			calls = append(calls, args)
+			return nil, err
+		}
+	}
+
+	It("should sparsify the image in place", func() {
+		replaceExecFunction(mockVirtSparsify(nil), func() {
+			Expect(NewQEMUOperations().Sparsify("disk.img")).To(Succeed())
+		})
+		Expect(calls).To(Equal([][]string{{"--in-place", "--format", "raw", "disk.img"}}))
+	})
+
+	It("should not sparsify without virt-sparsify", func() {
+		virtSparsifyLookPath = func(string) (string, error) { return "", errors.New("not found") }
+		replaceExecFunction(mockVirtSparsify(nil), func() {
+			Expect(NewQEMUOperations().Sparsify("disk.img")).To(Succeed())
+		})
+		Expect(calls).To(BeEmpty())
+	})
+
+	It("should fail when virt-sparsify fails", func() {
+		replaceExecFunction(mockVirtSparsify(errors.New("exit 1")), func() {
+			err := NewQEMUOperations().Sparsify("disk.img")
+			Expect(err).To(MatchError(ContainSubstring("could not sparsify the image")))
+		})
+	})
+})
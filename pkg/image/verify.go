@@ -0,0 +1,77 @@
+/*
+Copyright 2026 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package image
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/pkg/errors"
+
+	"k8s.io/klog/v2"
+)
+
+// ErrVerificationFailed is matched by the errors of images failing their verification
+var ErrVerificationFailed = errors.New("verification failed")
+
+// checkResult is the JSON output of qemu-img check
+type checkResult struct {
+	CheckErrors int `json:"check-errors"`
+	Corruptions int `json:"corruptions"`
+	Leaks       int `json:"leaks"`
+}
+
+// Check checks the consistency of the metadata of the image with qemu-img check. Raw images have no metadata, they are
+// only opened. Leaked clusters waste space but do not corrupt the image, they are only logged.
+func (o *qemuOperations) Check(image string) error {
+	info, err := o.Info(&url.URL{Path: image})
+	if err != nil {
+		return fmt.Errorf("could not open %s: %v: %w", image, err, ErrVerificationFailed)
+	}
+	if info.Format == "raw" {
+		klog.V(1).Infof("%s is a raw image, it has no metadata to check", image)
+		return nil
+	}
+	klog.V(1).Infof("Checking %s image %s", info.Format, image)
+	// qemu-img check exits with a non zero status when it finds errors, the JSON output reports them
+	output, execErr := qemuExecFunction(nil, nil, "qemu-img", "check", "--output=json", "-f", info.Format, image)
+	var result checkResult
+	if err := json.Unmarshal(output, &result); err != nil {
+		if execErr != nil {
+			return errors.Wrap(execErr, "could not check the image")
+		}
+		return errors.Wrapf(err, "invalid qemu-img check output for image %s", image)
+	}
+	if result.Corruptions > 0 || result.CheckErrors > 0 {
+		return fmt.Errorf("%s has %d corruptions and %d check errors: %w", image, result.Corruptions, result.CheckErrors, ErrVerificationFailed)
+	}
+	if result.Leaks > 0 {
+		klog.Warningf("%s has %d leaked clusters", image, result.Leaks)
+	}
+	return nil
+}
+
+// Compare compares the content the guest sees of the image with the one of its source, whatever their formats. The
+// larger one may only have zeroes past the end of the other.
+func (o *qemuOperations) Compare(source, image string) error {
+	klog.V(1).Infof("Comparing %s with its source %s", image, source)
+	if output, err := qemuExecFunction(nil, nil, "qemu-img", "compare", source, image); err != nil {
+		return fmt.Errorf("%s does not match its source: %s %v: %w", image, output, err, ErrVerificationFailed)
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2026 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package image
+
+import (
+	"errors"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"kubevirt.io/containerized-data-importer/pkg/system"
+)
+
+var _ = Describe("Verify", func() {
+	var calls [][]string
+
+	BeforeEach(func() {
+		calls = nil
+	})
+
+	mockQemuImg := func(format, checkOutput string, err error) execFunctionType {
+		return func(limits *system.ProcessLimitValues, f func(string), cmd string, args ...string) ([]byte, error) {
+			Expect(cmd).To(Equal("qemu-img"))
+			calls = append(calls, args)
+			if args[0] == "info" {
+				return []byte(fmt.Sprintf(`{"format": %q, "virtual-size": 1024}`, format)), nil
+			}
+			return []byte(checkOutput), err
+		}
+	}
+
+	It("should only open raw images", func() {
+		replaceExecFunction(mockQemuImg("raw", "", nil), func() {
+			Expect(NewQEMUOperations().Check("disk.img")).To(Succeed())
+		})
+		Expect(calls).To(HaveLen(1))
+	})
+
+	It("should check qcow2 images and tolerate leaks", func() {
+		replaceExecFunction(mockQemuImg("qcow2", `{"check-errors": 0, "leaks": 2}`, errors.New("exit status 3")), func() {
+			Expect(NewQEMUOperations().Check("disk.img")).To(Succeed())
+		})
+		Expect(calls).To(ContainElement([]string{"check", "--output=json", "-f", "qcow2", "disk.img"}))
+	})
+
+	It("should fail corrupted images", func() {
+		replaceExecFunction(mockQemuImg("qcow2", `{"check-errors": 0, "corruptions": 3}`, errors.New("exit status 2")), func() {
+			err := NewQEMUOperations().Check("disk.img")
+			Expect(err).To(MatchError(ErrVerificationFailed))
+			Expect(err).To(MatchError(ContainSubstring("3 corruptions")))
+		})
+	})
+
+	It("should fail when qemu-img check fails", func() {
+		replaceExecFunction(mockQemuImg("qcow2", "", errors.New("exit status 1")), func() {
+			Expect(NewQEMUOperations().Check("disk.img")).To(MatchError(ContainSubstring("could not check the image")))
+		})
+	})
+
+	It("should compare the image with its source", func() {
+		replaceExecFunction(mockQemuImg("", "Images are identical.", nil), func() {
+			Expect(NewQEMUOperations().Compare("/scratch/tmpimage", "disk.img")).To(Succeed())
+		})
+		Expect(calls).To(Equal([][]string{{"compare", "/scratch/tmpimage", "disk.img"}}))
+	})
+
+	It("should fail images not matching their source", func() {
+		replaceExecFunction(mockQemuImg("", "Content mismatch at offset 0!", errors.New("exit status 1")), func() {
+			err := NewQEMUOperations().Compare("/scratch/tmpimage", "disk.img")
+			Expect(err).To(MatchError(ErrVerificationFailed))
+			Expect(err).To(MatchError(ContainSubstring("Content mismatch at offset 0!")))
+		})
+	})
+})
@@ -0,0 +1,394 @@
+/*
+Copyright 2024 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package importer
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+
+	"k8s.io/klog/v2"
+
+	"kubevirt.io/containerized-data-importer/pkg/common"
+	"kubevirt.io/containerized-data-importer/pkg/image"
+)
+
+const (
+	// azureAPIVersion is the Blob service version requested, bearer tokens require 2017-11-09 or later
+	azureAPIVersion   = "2021-08-06"
+	azureStorageScope = "https://storage.azure.com/.default"
+	azurePageBlob     = "PageBlob"
+
+	// A fixed VHD is the raw disk followed by a 512 byte footer
+	vhdFooterSize    = 512
+	vhdFooterCookie  = "conectix"
+	vhdDiskTypeFixed = 2
+)
+
+// Helpers for unit-testing
+var (
+	azureAuthorityHost    = "https://login.microsoftonline.com"
+	createNbdkitAzureBlob = image.NewNbdkitAzureBlob
+)
+
+// azureCredentials are read from the files of the secret mounted in the importer pod
+type azureCredentials struct {
+	sasToken     string
+	tenantID     string
+	clientID     string
+	clientSecret string
+}
+
+// AzureBlobDataSource is the data provider for Azure Blob Storage. The blob is read with ranged
+// requests through nbdkit, so qemu-img converts it without staging it in scratch space. Blobs
+// requiring a client certificate, which nbdkit cannot present, are downloaded instead.
+// Sequence of phases:
+// 1a. Info -> Convert
+// 1b. Info -> Transfer, if the blob is downloaded
+// 2. Transfer -> Convert
+type AzureBlobDataSource struct {
+	// Blob url, including the SAS token if one is used
+	endpoint *url.URL
+	// nbdkit serving the blob
+	n image.NbdkitOperation
+	// The downloaded blob, nil if it is read through nbdkit
+	blobReader io.ReadCloser
+	// The size of the downloaded blob
+	size uint64
+	// stack of readers
+	readers *FormatReaders
+	// The url qemu-img reads from
+	url *url.URL
+	// stops refreshing the access token nbdkit sends
+	cancelHeaderRefresh context.CancelFunc
+}
+
+// NewAzureBlobDataSource creates a new instance of the AzureBlobDataSource. Credentials are read from
+// credentialDir, anonymous access is used if it is empty.
+func NewAzureBlobDataSource(endpoint, credentialDir, certDir string) (*AzureBlobDataSource, error) {
+	ep, err := ParseEndpoint(endpoint)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to parse endpoint %q", endpoint)
+	}
+	if ep.Scheme != "https" && ep.Scheme != "http" {
+		return nil, errors.Errorf("invalid Azure blob url scheme %q", ep.Scheme)
+	}
+	creds, err := readAzureCredentials(credentialDir)
+	if err != nil {
+		return nil, err
+	}
+	client, err := createHTTPClient(certDir)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating http client")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	headers := []string{"x-ms-version: " + azureAPIVersion}
+	var secretHeaders []string
+	var tokenSource oauth2.TokenSource
+	switch {
+	case creds.sasToken != "":
+		klog.V(3).Infoln("Azure Importer: Authentication: SAS token")
+		sas := strings.TrimPrefix(creds.sasToken, "?")
+		if ep.RawQuery != "" {
+			sas = ep.RawQuery + "&" + sas
+		}
+		ep.RawQuery = sas
+	case creds.clientID != "":
+		klog.V(3).Infoln("Azure Importer: Authentication: service principal")
+		tokenSource = getAzureTokenSource(client, creds)
+		token, err := tokenSource.Token()
+		if err != nil {
+			return nil, errors.Wrap(err, "Azure token request failed")
+		}
+		secretHeaders = append(secretHeaders, "Authorization: Bearer "+token.AccessToken)
+	default:
+		klog.V(3).Infoln("Azure Importer: Authentication: Anonymous")
+	}
+
+	size, err := getAzureBlobDiskSize(ctx, client, ep, append(headers, secretHeaders...))
+	if err != nil {
+		return nil, err
+	}
+
+	if hasClientCertificate() {
+		// nbdkit cannot present the client certificate, the blob is downloaded instead
+		return newAzureBlobDownload(ep, client, append(headers, secretHeaders...), size)
+	}
+
+	args := image.NbdkitAzureBlobArgs{
+		CertDir:       certDir,
+		Headers:       headers,
+		SecretHeaders: secretHeaders,
+		Size:          size,
+	}
+	cancelHeaderRefresh := func() {}
+	if tokenSource != nil {
+		// Access tokens expire after about an hour, nbdkit reads the refreshed one from the header file
+		var refreshCtx context.Context
+		refreshCtx, cancelHeaderRefresh = context.WithCancel(context.Background())
+		if err := startOAuth2HeaderRefresh(refreshCtx, tokenSource, oauth2HeaderFile, oauth2RenewInterval); err != nil {
+			cancelHeaderRefresh()
+			return nil, err
+		}
+		args.SecretHeaders = nil
+		args.HeaderFile = oauth2HeaderFile
+		args.HeaderRenewInterval = oauth2RenewInterval
+	}
+	n, err := createNbdkitAzureBlob(nbdkitPid, nbdkitSocket, args)
+	if err != nil {
+		cancelHeaderRefresh()
+		return nil, err
+	}
+	setCurlOptions(n)
+	return &AzureBlobDataSource{
+		endpoint:            ep,
+		n:                   n,
+		cancelHeaderRefresh: cancelHeaderRefresh,
+	}, nil
+}
+
+// newAzureBlobDownload returns the data source downloading the blob, up to size bytes if size is set
+func newAzureBlobDownload(ep *url.URL, client *http.Client, headers []string, size int64) (*AzureBlobDataSource, error) {
+	resp, err := doAzureBlobRequest(context.Background(), client, http.MethodGet, ep, headers)
+	if err != nil {
+		return nil, err
+	}
+	var blobReader io.ReadCloser = resp.Body
+	if size > 0 {
+		// Cut the VHD footer off
+		blobReader = struct {
+			io.Reader
+			io.Closer
+		}{io.LimitReader(resp.Body, size), resp.Body}
+	} else if resp.ContentLength > 0 {
+		size = resp.ContentLength
+	}
+	return &AzureBlobDataSource{
+		endpoint:   ep,
+		blobReader: blobReader,
+		size:       uint64(size),
+	}, nil
+}
+
+// Info is called to get initial information about the data.
+func (ad *AzureBlobDataSource) Info() (ProcessingPhase, error) {
+	if ad.blobReader != nil {
+		var err error
+		ad.readers, err = NewFormatReaders(ad.blobReader, ad.size)
+		if err != nil {
+			klog.Errorf("Azure Importer: Error creating readers: %v", err)
+			return ProcessingPhaseError, err
+		}
+		if !ad.readers.Convert {
+			// Downloading a raw blob, we can write that directly to the target.
+			return ProcessingPhaseTransferDataFile, nil
+		}
+		return ProcessingPhaseTransferScratch, nil
+	}
+	ad.url, _ = url.Parse(fmt.Sprintf("nbd+unix:///?socket=%s", nbdkitSocket))
+	if err := ad.n.StartNbdkit(ad.endpoint.String()); err != nil {
+		return ProcessingPhaseError, err
+	}
+	return ProcessingPhaseConvert, nil
+}
+
+// Transfer is called to download the blob to scratch space, blobs read through nbdkit are converted straight from it.
+func (ad *AzureBlobDataSource) Transfer(path string, preallocation bool) (ProcessingPhase, error) {
+	if ad.readers == nil {
+		return ProcessingPhaseError, errors.New("transfer is not supported for Azure blob sources read through nbdkit")
+	}
+	file := filepath.Join(path, tempFile)
+	if err := CleanAll(file); err != nil {
+		return ProcessingPhaseError, err
+	}
+	if size, _ := GetAvailableSpace(path); size <= int64(0) {
+		return ProcessingPhaseError, ErrInvalidPath
+	}
+	if _, _, err := StreamDataToFile(ad.readers.TopReader(), file, preallocation); err != nil {
+		return ProcessingPhaseError, err
+	}
+	// If streaming succeeded, then parsing the file into URL will also succeed, no need to check error status
+	ad.url, _ = url.Parse(file)
+	return ProcessingPhaseConvert, nil
+}
+
+// TransferFile is called to download a raw blob to the passed in file.
+func (ad *AzureBlobDataSource) TransferFile(fileName string, preallocation bool) (ProcessingPhase, error) {
+	if ad.readers == nil {
+		return ProcessingPhaseError, errors.New("transfer is not supported for Azure blob sources read through nbdkit")
+	}
+	if err := CleanAll(fileName); err != nil {
+		return ProcessingPhaseError, err
+	}
+	if _, _, err := StreamDataToFile(ad.readers.TopReader(), fileName, preallocation); err != nil {
+		return ProcessingPhaseError, err
+	}
+	return ProcessingPhaseResize, nil
+}
+
+// GetURL returns the url that the data processor can use when converting the data.
+func (ad *AzureBlobDataSource) GetURL() *url.URL {
+	return ad.url
+}
+
+// GetTerminationMessage returns data to be serialized and used as the termination message of the importer.
+func (ad *AzureBlobDataSource) GetTerminationMessage() *common.TerminationMessage {
+	return nil
+}
+
+// Close stops nbdkit, or closes the downloaded blob.
+func (ad *AzureBlobDataSource) Close() error {
+	if ad.cancelHeaderRefresh != nil {
+		ad.cancelHeaderRefresh()
+	}
+	if ad.n != nil {
+		return ad.n.KillNbdkit()
+	}
+	if ad.readers != nil {
+		return ad.readers.Close()
+	}
+	if ad.blobReader != nil {
+		return ad.blobReader.Close()
+	}
+	return nil
+}
+
+func readAzureCredentials(credentialDir string) (*azureCredentials, error) {
+	creds := &azureCredentials{}
+	if credentialDir == "" {
+		return creds, nil
+	}
+	for key, value := range map[string]*string{
+		common.KeyAzureSASToken:     &creds.sasToken,
+		common.KeyAzureTenantID:     &creds.tenantID,
+		common.KeyAzureClientID:     &creds.clientID,
+		common.KeyAzureClientSecret: &creds.clientSecret,
+	} {
+		content, err := os.ReadFile(filepath.Join(credentialDir, key))
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, errors.Wrapf(err, "unable to read Azure credential %s", key)
+		}
+		*value = strings.TrimSpace(string(content))
+	}
+	if creds.clientID != "" && (creds.tenantID == "" || creds.clientSecret == "") {
+		return nil, errors.Errorf("Azure service principal credentials need %s, %s and %s",
+			common.KeyAzureTenantID, common.KeyAzureClientID, common.KeyAzureClientSecret)
+	}
+	return creds, nil
+}
+
+// getAzureTokenSource returns the access tokens of the storage service for the service principal, obtained with
+// the client credentials flow and replaced shortly before they expire
+func getAzureTokenSource(client *http.Client, creds *azureCredentials) oauth2.TokenSource {
+	config := &clientcredentials.Config{
+		TokenURL:     fmt.Sprintf("%s/%s/oauth2/v2.0/token", azureAuthorityHost, url.PathEscape(creds.tenantID)),
+		ClientID:     creds.clientID,
+		ClientSecret: creds.clientSecret,
+		Scopes:       []string{azureStorageScope},
+		AuthStyle:    oauth2.AuthStyleInParams,
+	}
+	// Not bound to a request, tokens are refreshed for as long as the import runs
+	return config.TokenSource(context.WithValue(context.Background(), oauth2.HTTPClient, client))
+}
+
+// getAzureBlobDiskSize returns how much of the blob holds the disk, or 0 if it is the whole blob.
+// Page blobs holding a fixed VHD end with the VHD footer, which is cut off so the disk can be
+// read as raw.
+func getAzureBlobDiskSize(ctx context.Context, client *http.Client, ep *url.URL, headers []string) (int64, error) {
+	resp, err := doAzureBlobRequest(ctx, client, http.MethodHead, ep, headers)
+	if err != nil {
+		return 0, err
+	}
+	resp.Body.Close()
+	if resp.Header.Get("x-ms-blob-type") != azurePageBlob {
+		return 0, nil
+	}
+	length := resp.ContentLength
+	if length < vhdFooterSize {
+		return 0, nil
+	}
+
+	footer, err := readAzureBlobRange(ctx, client, ep, headers, length-vhdFooterSize, vhdFooterSize)
+	if err != nil {
+		return 0, err
+	}
+	if !bytes.HasPrefix(footer, []byte(vhdFooterCookie)) {
+		klog.V(1).Infoln("Azure Importer: page blob has no VHD footer, reading it as raw")
+		return 0, nil
+	}
+	if diskType := binary.BigEndian.Uint32(footer[60:64]); diskType != vhdDiskTypeFixed {
+		return 0, errors.Errorf("page blob holds a VHD of type %d, only fixed VHDs are supported", diskType)
+	}
+	klog.V(1).Infof("Azure Importer: page blob holds a fixed VHD of %d bytes", length-vhdFooterSize)
+	return length - vhdFooterSize, nil
+}
+
+func readAzureBlobRange(ctx context.Context, client *http.Client, ep *url.URL, headers []string, offset, length int64) ([]byte, error) {
+	rangeHeader := fmt.Sprintf("Range: bytes=%d-%d", offset, offset+length-1)
+	resp, err := doAzureBlobRequest(ctx, client, http.MethodGet, ep, append(headers, rangeHeader))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	data := make([]byte, length)
+	if _, err := io.ReadFull(resp.Body, data); err != nil {
+		return nil, errors.Wrap(err, "could not read the end of the page blob")
+	}
+	return data, nil
+}
+
+func doAzureBlobRequest(ctx context.Context, client *http.Client, method string, ep *url.URL, headers []string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, ep.String(), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create Azure blob request")
+	}
+	addExtraheaders(req, headers)
+	resp, err := client.Do(req)
+	if err != nil {
+		// Drop the url, it may hold the SAS token
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return nil, errors.Wrapf(err, "Azure blob %s request failed", method)
+	}
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusPartialContent {
+		resp.Body.Close()
+		return nil, errors.Errorf("Azure blob %s request failed with status %s", method, resp.Status)
+	}
+	return resp, nil
+}
+
+var _ DataSourceInterface = &AzureBlobDataSource{}
@@ -0,0 +1,195 @@
+package importer
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"kubevirt.io/containerized-data-importer/pkg/common"
+	"kubevirt.io/containerized-data-importer/pkg/image"
+	"kubevirt.io/containerized-data-importer/pkg/util/cert/triple"
+)
+
+const testBlobSize = 1024 * 1024
+
+var _ = Describe("Azure blob data source", func() {
+	var (
+		ts             *httptest.Server
+		tmpDir         string
+		blobType       string
+		blob           []byte
+		requests       []*http.Request
+		nbdkitArgs     image.NbdkitAzureBlobArgs
+		origAuthority  string
+		origNbdkitFunc func(string, string, image.NbdkitAzureBlobArgs) (image.NbdkitOperation, error)
+	)
+
+	vhdFooter := func(diskType uint32) []byte {
+		footer := make([]byte, vhdFooterSize)
+		copy(footer, vhdFooterCookie)
+		binary.BigEndian.PutUint32(footer[60:64], diskType)
+		return footer
+	}
+
+	writeCredential := func(key, value string) {
+		Expect(os.WriteFile(filepath.Join(tmpDir, key), []byte(value), 0600)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		var err error
+		tmpDir, err = os.MkdirTemp("", "azure")
+		Expect(err).NotTo(HaveOccurred())
+		blobType = "BlockBlob"
+		blob = make([]byte, testBlobSize)
+		requests = nil
+		ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests = append(requests, r)
+			if r.Method == http.MethodPost {
+				_ = r.ParseForm()
+				if r.Form.Get("client_secret") != "secret" {
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+				w.Header().Set("Content-Type", "application/json")
+				fmt.Fprint(w, `{"access_token": "token", "token_type": "Bearer", "expires_in": 3599}`)
+				return
+			}
+			w.Header().Set("x-ms-blob-type", blobType)
+			http.ServeContent(w, r, "blob", time.Time{}, strings.NewReader(string(blob)))
+		}))
+		origAuthority = azureAuthorityHost
+		azureAuthorityHost = ts.URL
+		origNbdkitFunc = createNbdkitAzureBlob
+		createNbdkitAzureBlob = func(pidFile, socket string, args image.NbdkitAzureBlobArgs) (image.NbdkitOperation, error) {
+			nbdkitArgs = args
+			return image.NewMockNbdkitAzureBlob(pidFile, socket, args)
+		}
+	})
+
+	AfterEach(func() {
+		ts.Close()
+		azureAuthorityHost = origAuthority
+		createNbdkitAzureBlob = origNbdkitFunc
+		os.Remove(oauth2HeaderFile)
+		os.RemoveAll(tmpDir)
+	})
+
+	It("Should read a block blob anonymously", func() {
+		ds, err := NewAzureBlobDataSource(ts.URL+"/container/disk.img", "", "")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nbdkitArgs.Size).To(BeZero())
+		Expect(nbdkitArgs.Headers).To(ConsistOf("x-ms-version: " + azureAPIVersion))
+		Expect(nbdkitArgs.SecretHeaders).To(BeEmpty())
+		phase, err := ds.Info()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(phase).To(Equal(ProcessingPhaseConvert))
+		Expect(ds.GetURL().String()).To(Equal("nbd+unix:///?socket=" + nbdkitSocket))
+	})
+
+	It("Should append the SAS token to the blob url", func() {
+		writeCredential(common.KeyAzureSASToken, "?sv=2021&sig=abc\n")
+		ds, err := NewAzureBlobDataSource(ts.URL+"/container/disk.img", tmpDir, "")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ds.endpoint.RawQuery).To(Equal("sv=2021&sig=abc"))
+		Expect(requests[0].URL.Query().Get("sig")).To(Equal("abc"))
+	})
+
+	It("Should authenticate with a service principal", func() {
+		writeCredential(common.KeyAzureTenantID, "tenant")
+		writeCredential(common.KeyAzureClientID, "client")
+		writeCredential(common.KeyAzureClientSecret, "secret")
+		_, err := NewAzureBlobDataSource(ts.URL+"/container/disk.img", tmpDir, "")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(requests[0].URL.Path).To(Equal("/tenant/oauth2/v2.0/token"))
+		Expect(requests[0].Form.Get("scope")).To(Equal(azureStorageScope))
+		Expect(requests[1].Header.Get("Authorization")).To(Equal("Bearer token"))
+		Expect(nbdkitArgs.SecretHeaders).To(BeEmpty())
+		Expect(nbdkitArgs.HeaderFile).To(Equal(oauth2HeaderFile))
+		Expect(nbdkitArgs.HeaderRenewInterval).To(Equal(oauth2RenewInterval))
+		header, err := os.ReadFile(oauth2HeaderFile)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(header)).To(Equal("Authorization: Bearer token\n"))
+	})
+
+	It("Should fail if the service principal is rejected", func() {
+		writeCredential(common.KeyAzureTenantID, "tenant")
+		writeCredential(common.KeyAzureClientID, "client")
+		writeCredential(common.KeyAzureClientSecret, "wrong")
+		_, err := NewAzureBlobDataSource(ts.URL+"/container/disk.img", tmpDir, "")
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("Azure token request failed"))
+	})
+
+	It("Should fail on incomplete service principal credentials", func() {
+		writeCredential(common.KeyAzureClientID, "client")
+		_, err := NewAzureBlobDataSource(ts.URL+"/container/disk.img", tmpDir, "")
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("service principal credentials need"))
+	})
+
+	It("Should cut the footer off a fixed VHD page blob", func() {
+		blobType = azurePageBlob
+		copy(blob[testBlobSize-vhdFooterSize:], vhdFooter(vhdDiskTypeFixed))
+		_, err := NewAzureBlobDataSource(ts.URL+"/container/disk.vhd", "", "")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nbdkitArgs.Size).To(Equal(int64(testBlobSize - vhdFooterSize)))
+	})
+
+	It("Should read a page blob without a VHD footer as raw", func() {
+		blobType = azurePageBlob
+		_, err := NewAzureBlobDataSource(ts.URL+"/container/disk.raw", "", "")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nbdkitArgs.Size).To(BeZero())
+	})
+
+	It("Should reject a dynamic VHD page blob", func() {
+		blobType = azurePageBlob
+		copy(blob[testBlobSize-vhdFooterSize:], vhdFooter(3))
+		_, err := NewAzureBlobDataSource(ts.URL+"/container/disk.vhd", "", "")
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("only fixed VHDs are supported"))
+	})
+
+	It("Should fail if the blob does not exist", func() {
+		ts.Config.Handler = http.NotFoundHandler()
+		_, err := NewAzureBlobDataSource(ts.URL+"/container/missing", "", "")
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("404"))
+	})
+
+	It("Should download the blob when a client certificate is required", func() {
+		ca, err := triple.NewCA("azure.cdi.kubevirt.io")
+		Expect(err).NotTo(HaveOccurred())
+		useTestClientCert(ca)
+		blobType = azurePageBlob
+		copy(blob[testBlobSize-vhdFooterSize:], vhdFooter(vhdDiskTypeFixed))
+		nbdkitArgs = image.NbdkitAzureBlobArgs{}
+		ds, err := NewAzureBlobDataSource(ts.URL+"/container/disk.vhd", "", "")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nbdkitArgs).To(BeZero())
+		phase, err := ds.Info()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(phase).To(Equal(ProcessingPhaseTransferDataFile))
+		target := filepath.Join(tmpDir, "disk.img")
+		phase, err = ds.TransferFile(target, false)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(phase).To(Equal(ProcessingPhaseResize))
+		Expect(ds.Close()).To(Succeed())
+		info, err := os.Stat(target)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(info.Size()).To(Equal(int64(testBlobSize - vhdFooterSize)))
+	})
+
+	It("Should reject non http urls", func() {
+		_, err := NewAzureBlobDataSource("gs://container/disk.img", "", "")
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,143 @@
+/*
+Copyright 2024 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package importer
+
+import (
+	"context"
+	"io"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/containers/image/v5/types"
+	"github.com/pkg/errors"
+	"golang.org/x/time/rate"
+
+	"k8s.io/klog/v2"
+
+	"kubevirt.io/containerized-data-importer/pkg/common"
+	metrics "kubevirt.io/containerized-data-importer/pkg/monitoring/metrics/cdi-importer"
+	"kubevirt.io/containerized-data-importer/pkg/util"
+)
+
+const (
+	// maxBandwidthBurst is the most data of a throttled source read at once
+	maxBandwidthBurst = 1024 * 1024
+	// throughputInterval is the interval the throughput of a throttled source is measured over
+	throughputInterval = time.Second
+)
+
+// bandwidthLimiter throttles the data read from a source to its maximum bandwidth with a token bucket, and measures
+// the throughput of the source
+type bandwidthLimiter struct {
+	limiter      *rate.Limiter
+	maxBandwidth int64
+
+	mutex sync.Mutex
+	read  int64
+	since time.Time
+}
+
+// getBandwidthLimiter returns the limiter of the maximum bandwidth of the source if it is set, nil otherwise. The
+// bandwidth is passed in bytes per second.
+func getBandwidthLimiter() (*bandwidthLimiter, error) {
+	value, _ := util.ParseEnvVar(common.ImporterMaxBandwidth, false)
+	if value == "" {
+		return nil, nil
+	}
+	maxBandwidth, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || maxBandwidth <= 0 {
+		return nil, errors.Errorf("invalid maximum bandwidth %q", value)
+	}
+	burst := maxBandwidthBurst
+	if maxBandwidth < int64(burst) {
+		burst = int(maxBandwidth)
+	}
+	klog.Infof("Throttling the source to %d bytes per second", maxBandwidth)
+	metrics.SetMaxBandwidth(ownerUID, float64(maxBandwidth))
+	return &bandwidthLimiter{
+		limiter:      rate.NewLimiter(rate.Limit(maxBandwidth), burst),
+		maxBandwidth: maxBandwidth,
+		since:        time.Now(),
+	}, nil
+}
+
+// wait blocks until the n bytes read from the source fit in its bandwidth
+func (b *bandwidthLimiter) wait(n int) {
+	b.record(n)
+	for n > 0 {
+		chunk := min(n, b.limiter.Burst())
+		// WaitN only fails for more than the burst, or once its context is done
+		_ = b.limiter.WaitN(context.Background(), chunk)
+		n -= chunk
+	}
+}
+
+func (b *bandwidthLimiter) record(n int) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.read += int64(n)
+	if elapsed := time.Since(b.since); elapsed >= throughputInterval {
+		metrics.SetThroughput(ownerUID, float64(b.read)/elapsed.Seconds())
+		b.read = 0
+		b.since = time.Now()
+	}
+}
+
+// wrap returns the reader throttling r, or r itself if the source is not throttled
+func (b *bandwidthLimiter) wrap(r io.ReadCloser) io.ReadCloser {
+	if b == nil || r == nil {
+		return r
+	}
+	return &throttledReader{ReadCloser: r, bandwidth: b}
+}
+
+// wrapImageSource returns the image source throttling the blobs of src, or src itself if the source is not
+// throttled
+func (b *bandwidthLimiter) wrapImageSource(src types.ImageSource) types.ImageSource {
+	if b == nil {
+		return src
+	}
+	return &throttledImageSource{ImageSource: src, bandwidth: b}
+}
+
+type throttledReader struct {
+	io.ReadCloser
+	bandwidth *bandwidthLimiter
+}
+
+func (r *throttledReader) Read(p []byte) (int, error) {
+	if len(p) > r.bandwidth.limiter.Burst() {
+		p = p[:r.bandwidth.limiter.Burst()]
+	}
+	n, err := r.ReadCloser.Read(p)
+	r.bandwidth.wait(n)
+	return n, err
+}
+
+type throttledImageSource struct {
+	types.ImageSource
+	bandwidth *bandwidthLimiter
+}
+
+func (s *throttledImageSource) GetBlob(ctx context.Context, info types.BlobInfo, cache types.BlobInfoCache) (io.ReadCloser, int64, error) {
+	reader, size, err := s.ImageSource.GetBlob(ctx, info, cache)
+	if err != nil {
+		return nil, 0, err
+	}
+	return s.bandwidth.wrap(reader), size, nil
+}
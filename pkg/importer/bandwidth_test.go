@@ -0,0 +1,74 @@
+package importer
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/containers/image/v5/types"
+
+	"kubevirt.io/containerized-data-importer/pkg/common"
+)
+
+type fakeBlobSource struct {
+	types.ImageSource
+	blob []byte
+}
+
+func (s *fakeBlobSource) GetBlob(ctx context.Context, info types.BlobInfo, cache types.BlobInfoCache) (io.ReadCloser, int64, error) {
+	return io.NopCloser(bytes.NewReader(s.blob)), int64(len(s.blob)), nil
+}
+
+var _ = Describe("Bandwidth", func() {
+	content := bytes.Repeat([]byte("disk image "), 15000)
+
+	It("Should not throttle sources without a maximum bandwidth", func() {
+		bandwidth, err := getBandwidthLimiter()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(bandwidth).To(BeNil())
+		reader := io.NopCloser(bytes.NewReader(content))
+		Expect(bandwidth.wrap(reader)).To(BeIdenticalTo(reader))
+	})
+
+	DescribeTable("Should refuse the maximum bandwidth", func(value string) {
+		GinkgoT().Setenv(common.ImporterMaxBandwidth, value)
+		_, err := getBandwidthLimiter()
+		Expect(err).To(MatchError(ContainSubstring("invalid maximum bandwidth")))
+	},
+		Entry("of a quantity", "10Mi"),
+		Entry("of zero", "0"),
+		Entry("of a negative value", "-1"),
+	)
+
+	It("Should read a source at its maximum bandwidth", func() {
+		GinkgoT().Setenv(common.ImporterMaxBandwidth, "100000")
+		bandwidth, err := getBandwidthLimiter()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(bandwidth.limiter.Burst()).To(Equal(100000))
+		start := time.Now()
+		data, err := io.ReadAll(bandwidth.wrap(io.NopCloser(bytes.NewReader(content))))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(data).To(Equal(content))
+		// The first second of data is the burst, the rest is read at the bandwidth
+		Expect(time.Since(start)).To(BeNumerically(">=", 600*time.Millisecond))
+	})
+
+	It("Should throttle the blobs of an image source", func() {
+		GinkgoT().Setenv(common.ImporterMaxBandwidth, "100000")
+		bandwidth, err := getBandwidthLimiter()
+		Expect(err).NotTo(HaveOccurred())
+		src := bandwidth.wrapImageSource(&fakeBlobSource{blob: content})
+		start := time.Now()
+		reader, size, err := src.GetBlob(context.Background(), types.BlobInfo{}, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(size).To(Equal(int64(len(content))))
+		data, err := io.ReadAll(reader)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(data).To(Equal(content))
+		Expect(time.Since(start)).To(BeNumerically(">=", 600*time.Millisecond))
+	})
+})
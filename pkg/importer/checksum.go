@@ -0,0 +1,100 @@
+/*
+Copyright 2024 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package importer
+
+import (
+	"bytes"
+	"crypto/md5"  //nolint:gosec // md5 digests are still published next to many images
+	"crypto/sha1" //nolint:gosec // sha1 digests are still published next to many images
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"k8s.io/klog/v2"
+
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+	"kubevirt.io/containerized-data-importer/pkg/common"
+	"kubevirt.io/containerized-data-importer/pkg/util"
+)
+
+var checksumHashes = map[cdiv1.DataVolumeChecksumAlgorithm]func() hash.Hash{
+	cdiv1.ChecksumMD5:    md5.New,
+	cdiv1.ChecksumSHA1:   sha1.New,
+	cdiv1.ChecksumSHA256: sha256.New,
+	cdiv1.ChecksumSHA512: sha512.New,
+}
+
+// checksumCheck checks the digest of a source against its checksum
+type checksumCheck struct {
+	hash.Hash
+	algorithm cdiv1.DataVolumeChecksumAlgorithm
+	expected  []byte
+	// observed is the digest of the source as algorithm:hex, set once all of it is read
+	observed string
+}
+
+// isComparedWithSource returns true if the image written to the target is compared with its source once imported. The
+// source is then always downloaded to scratch space and checked against its checksum, instead of being streamed or
+// written to the target directly.
+func isComparedWithSource() bool {
+	value, _ := util.ParseEnvVar(common.ImporterVerifyChecksum, false)
+	compare, _ := strconv.ParseBool(value)
+	return compare
+}
+
+// getChecksum returns the check of the checksum of the source if it is set, nil otherwise. The checksum is passed
+// as algorithm:digest.
+func getChecksum() (*checksumCheck, error) {
+	value, _ := util.ParseEnvVar(common.ImporterChecksum, false)
+	if value == "" {
+		return nil, nil
+	}
+	algorithm, digest, _ := strings.Cut(value, ":")
+	newHash, ok := checksumHashes[cdiv1.DataVolumeChecksumAlgorithm(algorithm)]
+	if !ok {
+		return nil, errors.Errorf("unsupported checksum algorithm %q", algorithm)
+	}
+	expected, err := hex.DecodeString(digest)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid %s checksum %q", algorithm, digest)
+	}
+	h := newHash()
+	if len(expected) != h.Size() {
+		return nil, errors.Errorf("invalid %s checksum %q, it has %d bytes instead of %d", algorithm, digest, len(expected), h.Size())
+	}
+	return &checksumCheck{
+		Hash:      h,
+		algorithm: cdiv1.DataVolumeChecksumAlgorithm(algorithm),
+		expected:  expected,
+	}, nil
+}
+
+func (c *checksumCheck) check() error {
+	actual := c.Sum(nil)
+	c.observed = fmt.Sprintf("%s:%x", c.algorithm, actual)
+	if !bytes.Equal(actual, c.expected) {
+		return newSourceVerificationError(errors.Errorf("%s: the %s digest of the source is %x, expected %x", common.ChecksumMismatchText, c.algorithm, actual, c.expected))
+	}
+	klog.Infof("The %s digest of the source matches its checksum", c.algorithm)
+	return nil
+}
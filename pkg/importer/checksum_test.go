@@ -0,0 +1,91 @@
+package importer
+
+import (
+	"bytes"
+	"crypto/md5" //nolint:gosec
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"kubevirt.io/containerized-data-importer/pkg/common"
+)
+
+var _ = Describe("Checksum", func() {
+	content := bytes.Repeat([]byte("disk image "), 10000)
+	sha256Checksum := fmt.Sprintf("sha256:%x", sha256.Sum256(content))
+
+	newChecksumReader := func(checksum string) *downloadVerifier {
+		GinkgoT().Setenv(common.ImporterChecksum, checksum)
+		cr, err := getDownloadVerifier(nil, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cr).NotTo(BeNil())
+		return cr
+	}
+
+	It("Should not check sources without a checksum", func() {
+		cr, err := getDownloadVerifier(nil, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cr).To(BeNil())
+		reader := io.NopCloser(bytes.NewReader(content))
+		Expect(cr.wrap(reader)).To(BeIdenticalTo(reader))
+		Expect(cr.verify()).To(Succeed())
+		Expect(cr.observedDigest()).To(BeEmpty())
+	})
+
+	DescribeTable("Should verify the digest of", func(checksum string) {
+		cr := newChecksumReader(checksum)
+		Expect(io.ReadAll(cr.wrap(io.NopCloser(bytes.NewReader(content))))).To(Equal(content))
+		Expect(cr.observedDigest()).To(BeEmpty())
+		Expect(cr.verify()).To(Succeed())
+		Expect(cr.observedDigest()).To(Equal(checksum))
+	},
+		Entry("md5", fmt.Sprintf("md5:%x", md5.Sum(content))), //nolint:gosec
+		Entry("sha256", sha256Checksum),
+	)
+
+	It("Should read what the readers of the source left unread", func() {
+		cr := newChecksumReader(sha256Checksum)
+		reader := cr.wrap(io.NopCloser(bytes.NewReader(content)))
+		_, err := io.CopyN(io.Discard, reader, 1000)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cr.verify()).To(Succeed())
+	})
+
+	It("Should fail if the source does not match its checksum", func() {
+		cr := newChecksumReader(sha256Checksum)
+		_, err := io.ReadAll(cr.wrap(io.NopCloser(bytes.NewReader(content[1:]))))
+		Expect(err).NotTo(HaveOccurred())
+		err = cr.verify()
+		Expect(err).To(MatchError(ContainSubstring(common.ChecksumMismatchText)))
+		Expect(err).To(MatchError(ContainSubstring(fmt.Sprintf("the sha256 digest of the source is %x, expected %x", sha256.Sum256(content[1:]), sha256.Sum256(content)))))
+		Expect(cr.observedDigest()).To(Equal(fmt.Sprintf("sha256:%x", sha256.Sum256(content[1:]))))
+	})
+
+	It("Should hash the part of a download already written", func() {
+		file := filepath.Join(GinkgoT().TempDir(), "disk.img")
+		Expect(os.WriteFile(file, content[:5000], 0600)).To(Succeed())
+		cr := newChecksumReader(sha256Checksum)
+		reader := cr.wrap(io.NopCloser(bytes.NewReader(content)))
+		_, err := io.CopyN(io.Discard, reader, 512)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cr.hashFile(file, 512, 5000)).To(Succeed())
+		// The download continues at the offset of the file
+		cr.ReadCloser = io.NopCloser(bytes.NewReader(content[5000:]))
+		Expect(cr.verify()).To(Succeed())
+	})
+
+	DescribeTable("Should fail with an invalid checksum", func(checksum, errSubstring string) {
+		GinkgoT().Setenv(common.ImporterChecksum, checksum)
+		_, err := getChecksum()
+		Expect(err).To(MatchError(ContainSubstring(errSubstring)))
+	},
+		Entry("algorithm", "crc32:00000000", `unsupported checksum algorithm "crc32"`),
+		Entry("digest", "sha1:xyz", `invalid sha1 checksum "xyz"`),
+		Entry("digest length", "md5:0123", `invalid md5 checksum "0123", it has 2 bytes instead of 16`),
+	)
+})
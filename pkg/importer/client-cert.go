@@ -0,0 +1,70 @@
+/*
+Copyright 2024 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package importer
+
+import (
+	"crypto/tls"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"kubevirt.io/containerized-data-importer/pkg/common"
+)
+
+const (
+	// registryClientCert and registryClientKey are the names the client certificate of a registry is looked up by
+	// in its certificate directory
+	registryClientCert = "client.cert"
+	registryClientKey  = "client.key"
+)
+
+// may be overridden in tests
+var clientCertDir = common.ImporterClientCertDir
+
+// hasClientCertificate returns true if the source has a client certificate to present when it requires mutual TLS
+func hasClientCertificate() bool {
+	_, err := os.Stat(filepath.Join(clientCertDir, corev1.TLSCertKey))
+	return err == nil
+}
+
+// loadClientCertificate returns the client certificate of the source if it has one, nil otherwise. The certificate and
+// its key are read from the kubernetes.io/tls secret mounted in clientCertDir.
+func loadClientCertificate() (*tls.Certificate, error) {
+	if !hasClientCertificate() {
+		return nil, nil
+	}
+	cert, err := tls.LoadX509KeyPair(filepath.Join(clientCertDir, corev1.TLSCertKey), filepath.Join(clientCertDir, corev1.TLSPrivateKeyKey))
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to load the client certificate")
+	}
+	return &cert, nil
+}
+
+// linkClientCertificate links the client certificate of the source, if it has one, into the registry certificate
+// directory targetDir
+func linkClientCertificate(targetDir string) error {
+	if !hasClientCertificate() {
+		return nil
+	}
+	if err := LinkFile(filepath.Join(clientCertDir, corev1.TLSCertKey), filepath.Join(targetDir, registryClientCert)); err != nil {
+		return err
+	}
+	return LinkFile(filepath.Join(clientCertDir, corev1.TLSPrivateKeyKey), filepath.Join(targetDir, registryClientKey))
+}
@@ -0,0 +1,115 @@
+package importer
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+	"kubevirt.io/containerized-data-importer/pkg/common"
+	"kubevirt.io/containerized-data-importer/pkg/util/cert"
+	"kubevirt.io/containerized-data-importer/pkg/util/cert/triple"
+)
+
+// useTestClientCert mounts a client certificate issued by ca for the rest of the spec
+func useTestClientCert(ca *triple.KeyPair) {
+	clientCertDir = GinkgoT().TempDir()
+	DeferCleanup(func() {
+		clientCertDir = common.ImporterClientCertDir
+	})
+	client, err := triple.NewClientKeyPair(ca, "importer", nil)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(os.WriteFile(filepath.Join(clientCertDir, corev1.TLSCertKey), cert.EncodeCertPEM(client.Cert), 0600)).To(Succeed())
+	Expect(os.WriteFile(filepath.Join(clientCertDir, corev1.TLSPrivateKeyKey), cert.EncodePrivateKeyPEM(client.Key), 0600)).To(Succeed())
+}
+
+var _ = Describe("Client certificate", func() {
+	var (
+		ts      *httptest.Server
+		ca      *triple.KeyPair
+		certDir string
+	)
+
+	BeforeEach(func() {
+		var err error
+		ca, err = triple.NewCA("mtls.cdi.kubevirt.io")
+		Expect(err).NotTo(HaveOccurred())
+		server, err := triple.NewServerKeyPair(ca, "127.0.0.1", "artifacts", "default", "cluster.local", []string{"127.0.0.1"}, nil)
+		Expect(err).NotTo(HaveOccurred())
+		serverCert, err := tls.X509KeyPair(cert.EncodeCertPEM(server.Cert), cert.EncodePrivateKeyPEM(server.Key))
+		Expect(err).NotTo(HaveOccurred())
+		clientCAs := x509.NewCertPool()
+		clientCAs.AddCert(ca.Cert)
+		ts = httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.ServeFile(w, r, cirrosFilePath)
+		}))
+		ts.TLS = &tls.Config{
+			Certificates: []tls.Certificate{serverCert},
+			ClientCAs:    clientCAs,
+			ClientAuth:   tls.RequireAndVerifyClientCert,
+			MinVersion:   tls.VersionTLS12,
+		}
+		ts.StartTLS()
+		certDir = GinkgoT().TempDir()
+		Expect(os.WriteFile(filepath.Join(certDir, "ca.crt"), cert.EncodeCertPEM(ca.Cert), 0600)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		ts.Close()
+	})
+
+	It("Should not load a client certificate without its secret", func() {
+		clientCert, err := loadClientCertificate()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(clientCert).To(BeNil())
+	})
+
+	It("Should present the client certificate to a server requiring mutual TLS", func() {
+		client, err := createHTTPClient(certDir)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = client.Get(ts.URL)
+		Expect(err).To(HaveOccurred())
+
+		useTestClientCert(ca)
+		client, err = createHTTPClient(certDir)
+		Expect(err).NotTo(HaveOccurred())
+		resp, err := client.Get(ts.URL)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.Body.Close()).To(Succeed())
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+	})
+
+	It("Should fail on a client certificate without its key", func() {
+		useTestClientCert(ca)
+		Expect(os.Remove(filepath.Join(clientCertDir, corev1.TLSPrivateKeyKey))).To(Succeed())
+		_, err := createHTTPClient(certDir)
+		Expect(err).To(MatchError(ContainSubstring("unable to load the client certificate")))
+	})
+
+	It("Should download http sources requiring a client certificate", func() {
+		useTestClientCert(ca)
+		hs, err := NewHTTPDataSource(ts.URL+"/"+cirrosFileName, "", "", certDir, cdiv1.DataVolumeKubeVirt)
+		Expect(err).NotTo(HaveOccurred())
+		defer hs.Close()
+		Expect(hs.brokenForQemuImg).To(BeTrue())
+	})
+
+	It("Should link the client certificate into the registry certificate directory", func() {
+		targetDir := GinkgoT().TempDir()
+		Expect(linkClientCertificate(targetDir)).To(Succeed())
+		Expect(filepath.Join(targetDir, registryClientCert)).NotTo(BeAnExistingFile())
+
+		useTestClientCert(ca)
+		Expect(linkClientCertificate(targetDir)).To(Succeed())
+		Expect(os.Readlink(filepath.Join(targetDir, registryClientCert))).To(Equal(filepath.Join(clientCertDir, corev1.TLSCertKey)))
+		Expect(os.Readlink(filepath.Join(targetDir, registryClientKey))).To(Equal(filepath.Join(clientCertDir, corev1.TLSPrivateKeyKey)))
+	})
+})
@@ -0,0 +1,102 @@
+/*
+Copyright 2024 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package importer
+
+import (
+	"os"
+	"slices"
+	"strconv"
+
+	"github.com/pkg/errors"
+
+	"k8s.io/klog/v2"
+
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+	"kubevirt.io/containerized-data-importer/pkg/common"
+	"kubevirt.io/containerized-data-importer/pkg/image"
+)
+
+const (
+	detectedArchive = "archive"
+	detectedISO     = "iso"
+	detectedRaw     = "raw"
+)
+
+// diskImageTypes are the detected types of the data that hold a disk image rather than files
+var diskImageTypes = []string{"qcow2", "vmdk", "vdi", "vhd", "vhdx"}
+
+// ContentTypeDetector is implemented by the data sources finding the type of their data by its magic bytes
+type ContentTypeDetector interface {
+	// DetectedContentType returns the type of the data, empty while it is unknown
+	DetectedContentType() string
+	// ContentType returns the content type the data is imported as
+	ContentType() cdiv1.DataVolumeContentType
+}
+
+// detectType finds the type of the data from the format of the last header found in it. The data of a zip archive
+// is left unknown, the type of its file is only found once it is extracted. ISO images are raw until the target is
+// checked by DetectImportedContentType, their magic bytes are past the headers read from the source.
+func (fr *FormatReaders) detectType(format string) {
+	switch format {
+	case "qcow2", "vmdk", "vdi", "vhd", "vhdx":
+		fr.DetectedType = format
+	case "tar":
+		fr.DetectedType = detectedArchive
+	case "zip":
+	default:
+		fr.DetectedType = detectedRaw
+	}
+}
+
+// DetectImportedContentType returns the type of the data imported by the data source, once it is written to the
+// target. Raw data turns out to be an ISO image when the target holds its volume descriptor.
+func DetectImportedContentType(detector ContentTypeDetector, target string) string {
+	detected := detector.DetectedContentType()
+	if detected != detectedRaw || detector.ContentType() != cdiv1.DataVolumeKubeVirt {
+		return detected
+	}
+	iso, err := image.IsISO(target)
+	if err != nil {
+		klog.Warningf("Unable to check whether the target holds an ISO image: %v", err)
+	} else if iso {
+		return detectedISO
+	}
+	return detected
+}
+
+// resolveContentType checks the content type of a source against the type detected from its data. A declared content
+// type conflicting with the data is an error, while without one archives are extracted to filesystem volumes.
+func resolveContentType(contentType cdiv1.DataVolumeContentType, detected string) (cdiv1.DataVolumeContentType, error) {
+	if detect, _ := strconv.ParseBool(os.Getenv(common.ImporterDetectContentType)); detect {
+		if detected != detectedArchive {
+			return contentType, nil
+		}
+		if _, err := os.Stat(common.WriteBlockPath); err == nil {
+			klog.Warningf("The data is a tar archive, which cannot be extracted to a block volume, importing it as a disk image")
+			return contentType, nil
+		}
+		klog.Infof("The data is a tar archive, extracting it")
+		return cdiv1.DataVolumeArchive, nil
+	}
+	if contentType == cdiv1.DataVolumeKubeVirt && detected == detectedArchive {
+		return contentType, errors.Errorf("content type %s conflicts with the data, which is a tar archive", contentType)
+	}
+	if contentType == cdiv1.DataVolumeArchive && slices.Contains(diskImageTypes, detected) {
+		return contentType, errors.Errorf("content type %s conflicts with the data, which is a disk image in %s format", contentType, detected)
+	}
+	return contentType, nil
+}
@@ -0,0 +1,85 @@
+package importer
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+	"kubevirt.io/containerized-data-importer/pkg/common"
+)
+
+var _ = Describe("Content type detection", func() {
+	AfterEach(func() {
+		os.Unsetenv(common.ImporterDetectContentType)
+	})
+
+	DescribeTable("Should detect the type of the data", func(fileName, expected string) {
+		file, err := os.Open(filepath.Join(imageDir, fileName))
+		Expect(err).NotTo(HaveOccurred())
+		fr, err := NewFormatReaders(file, 0)
+		Expect(err).NotTo(HaveOccurred())
+		defer fr.Close()
+		Expect(fr.DetectedType).To(Equal(expected))
+	},
+		Entry("of a raw image", "cirros.raw", "raw"),
+		Entry("of a qcow2 image", "cirros-qcow2.img", "qcow2"),
+		Entry("of an ISO image, found raw until it is imported", "tinyCore.iso", "raw"),
+		Entry("of a vdi image", "tinyCore.vdi", "vdi"),
+		Entry("of a tar archive", "archive.tar", "archive"),
+	)
+
+	It("Should find ISO images once they are imported", func() {
+		hd, err := NewHostPathDataSource(filepath.Join(imageDir, "tinyCore.iso"), cdiv1.DataVolumeKubeVirt)
+		Expect(err).NotTo(HaveOccurred())
+		defer hd.Close()
+		_, err = hd.Info()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(DetectImportedContentType(hd, filepath.Join(imageDir, "tinyCore.iso"))).To(Equal("iso"))
+		Expect(DetectImportedContentType(hd, filepath.Join(imageDir, "cirros.raw"))).To(Equal("raw"))
+	})
+
+	DescribeTable("Should check the declared content type", func(contentType cdiv1.DataVolumeContentType, detected string, expectedErr string) {
+		resolved, err := resolveContentType(contentType, detected)
+		if expectedErr != "" {
+			Expect(err).To(MatchError(ContainSubstring(expectedErr)))
+			return
+		}
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resolved).To(Equal(contentType))
+	},
+		Entry("accepting disk images", cdiv1.DataVolumeKubeVirt, "qcow2", ""),
+		Entry("accepting archives", cdiv1.DataVolumeArchive, "archive", ""),
+		Entry("accepting archives of unknown format", cdiv1.DataVolumeArchive, "raw", ""),
+		Entry("refusing archives as disk images", cdiv1.DataVolumeKubeVirt, "archive", "which is a tar archive"),
+		Entry("refusing disk images as archives", cdiv1.DataVolumeArchive, "qcow2", "which is a disk image in qcow2 format"),
+	)
+
+	It("Should extract archives when no content type was declared", func() {
+		os.Setenv(common.ImporterDetectContentType, "true")
+		Expect(resolveContentType(cdiv1.DataVolumeKubeVirt, "archive")).To(Equal(cdiv1.DataVolumeArchive))
+		Expect(resolveContentType(cdiv1.DataVolumeKubeVirt, "iso")).To(Equal(cdiv1.DataVolumeKubeVirt))
+	})
+
+	It("Should extract a tar from the node when no content type was declared", func() {
+		os.Setenv(common.ImporterDetectContentType, "true")
+		hd, err := NewHostPathDataSource(filepath.Join(imageDir, "archive.tar"), cdiv1.DataVolumeKubeVirt)
+		Expect(err).NotTo(HaveOccurred())
+		defer hd.Close()
+		phase, err := hd.Info()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(phase).To(Equal(ProcessingPhaseTransferDataDir))
+		Expect(hd.DetectedContentType()).To(Equal("archive"))
+		Expect(hd.ContentType()).To(Equal(cdiv1.DataVolumeArchive))
+	})
+
+	It("Should refuse a tar from the node declared as a disk image", func() {
+		hd, err := NewHostPathDataSource(filepath.Join(imageDir, "archive.tar"), cdiv1.DataVolumeKubeVirt)
+		Expect(err).NotTo(HaveOccurred())
+		defer hd.Close()
+		_, err = hd.Info()
+		Expect(err).To(MatchError(ContainSubstring("conflicts with the data")))
+	})
+})
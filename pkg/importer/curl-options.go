@@ -0,0 +1,152 @@
+/*
+Copyright 2024 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package importer
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/klog/v2"
+
+	"kubevirt.io/containerized-data-importer/pkg/common"
+	"kubevirt.io/containerized-data-importer/pkg/image"
+	metrics "kubevirt.io/containerized-data-importer/pkg/monitoring/metrics/cdi-importer"
+)
+
+const (
+	// curlDebugPrefix marks the debug messages of nbdkit, curl logs the headers it sends among them
+	curlDebugPrefix = ": debug: "
+	// curlConnectedMessage is logged by curl when it opens a connection
+	curlConnectedMessage = "Connected to "
+	// curlReusedMessage is logged by curl when it sends a request over a connection it already opened
+	curlReusedMessage = "Re-using existing "
+)
+
+// curlHTTPVersions are the values the http-version option of the curl plugin takes
+var curlHTTPVersions = []string{"none", "1.0", "1.1", "2.0", "2TLS", "2-prior-knowledge", "3", "3only"}
+
+// getCurlOptions returns the options of the connections of the curl plugin set on the importer pod, invalid
+// options are left to the defaults of the plugin.
+func getCurlOptions() image.NbdkitCurlOptions {
+	var options image.NbdkitCurlOptions
+	if value := os.Getenv(common.ImporterCurlConnections); value != "" {
+		connections, err := strconv.Atoi(value)
+		if err != nil || connections < 1 {
+			klog.Warningf("Ignoring invalid curl connections %q", value)
+		} else {
+			options.Connections = connections
+		}
+	}
+	if value := os.Getenv(common.ImporterCurlHTTPVersion); value != "" {
+		if !slices.Contains(curlHTTPVersions, value) {
+			klog.Warningf("Ignoring invalid curl HTTP version %q, it must be one of %v", value, curlHTTPVersions)
+		} else {
+			options.HTTPVersion = value
+		}
+	}
+	if value := os.Getenv(common.ImporterCurlTLS13Ciphers); value != "" {
+		if strings.ContainsAny(value, " \t\n") {
+			klog.Warningf("Ignoring invalid curl TLS 1.3 ciphers %q, they must be separated by colons", value)
+		} else {
+			options.TLS13Ciphers = value
+		}
+	}
+	if value := os.Getenv(common.ImporterCurlTimeout); value != "" {
+		timeout, err := time.ParseDuration(value)
+		if err != nil || timeout <= 0 {
+			klog.Warningf("Ignoring invalid curl timeout %q", value)
+		} else {
+			options.Timeout = timeout
+		}
+	}
+	return options
+}
+
+// setCurlOptions tunes the connections of an nbdkit instance reading its source through the curl plugin, and has
+// the connections it opens and reuses counted.
+func setCurlOptions(n image.NbdkitOperation) {
+	options := getCurlOptions()
+	if nbdkit, ok := n.(*image.Nbdkit); ok && nbdkit.LogWatcher == nil {
+		nbdkit.LogWatcher = &curlConnectionWatcher{}
+		options.LogConnections = true
+	}
+	n.SetCurlOptions(options)
+}
+
+// curlConnectionWatcher logs the messages of nbdkit like the default watcher, and counts the connections curl opens
+// to the source out of its debug messages. The other debug messages are dropped, they hold the headers sent to the
+// source.
+type curlConnectionWatcher struct {
+	connections int
+	reused      int
+	done        chan struct{}
+}
+
+// Start watches the output of nbdkit in the background
+func (w *curlConnectionWatcher) Start(output *bufio.Reader) {
+	w.done = make(chan struct{})
+	go func() {
+		defer close(w.done)
+		f, err := os.Create(common.NbdkitLogPath)
+		if err != nil {
+			klog.Errorf("Error writing nbdkit log to file: %v", err)
+		}
+		defer f.Close()
+		w.watch(output, f)
+	}()
+}
+
+// Stop waits for the watcher to reach the end of the output, nbdkit has to be stopped first
+func (w *curlConnectionWatcher) Stop() {
+	if w.done != nil {
+		<-w.done
+	}
+	klog.Infof("nbdkit opened %d connections to the source, and reused them %d times", w.connections, w.reused)
+}
+
+func (w *curlConnectionWatcher) watch(output io.Reader, logFile io.StringWriter) {
+	scanner := bufio.NewScanner(output)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.Contains(line, curlDebugPrefix) {
+			if strings.Contains(line, curlConnectedMessage) {
+				w.connections++
+				metrics.AddSourceConnection(ownerUID)
+				klog.V(1).Infof("Log line from nbdkit: %s", line)
+			} else if strings.Contains(line, curlReusedMessage) {
+				w.reused++
+				metrics.AddSourceConnectionReused(ownerUID)
+			}
+			continue
+		}
+		logLine := fmt.Sprintf("Log line from nbdkit: %s", line)
+		klog.Info(logLine)
+		if _, err := logFile.WriteString(logLine); err != nil {
+			klog.Errorf("failed to write log line; %v", err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		klog.Errorf("Error watching nbdkit log: %v", err)
+	}
+	klog.Infof("Stopped watching nbdkit log.")
+}
@@ -0,0 +1,71 @@
+package importer
+
+import (
+	"bufio"
+	"os"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"kubevirt.io/containerized-data-importer/pkg/common"
+	"kubevirt.io/containerized-data-importer/pkg/image"
+)
+
+var _ = Describe("Curl options", func() {
+	curlEnv := []string{common.ImporterCurlConnections, common.ImporterCurlHTTPVersion, common.ImporterCurlTLS13Ciphers, common.ImporterCurlTimeout}
+
+	AfterEach(func() {
+		for _, name := range curlEnv {
+			os.Unsetenv(name)
+		}
+	})
+
+	It("Should leave the defaults of the plugin without options", func() {
+		Expect(getCurlOptions()).To(Equal(image.NbdkitCurlOptions{}))
+	})
+
+	It("Should read the options of the importer pod", func() {
+		os.Setenv(common.ImporterCurlConnections, "16")
+		os.Setenv(common.ImporterCurlHTTPVersion, "2TLS")
+		os.Setenv(common.ImporterCurlTLS13Ciphers, "TLS_AES_256_GCM_SHA384:TLS_CHACHA20_POLY1305_SHA256")
+		os.Setenv(common.ImporterCurlTimeout, "90s")
+		Expect(getCurlOptions()).To(Equal(image.NbdkitCurlOptions{
+			Connections:  16,
+			HTTPVersion:  "2TLS",
+			TLS13Ciphers: "TLS_AES_256_GCM_SHA384:TLS_CHACHA20_POLY1305_SHA256",
+			Timeout:      90 * time.Second,
+		}))
+	})
+
+	DescribeTable("Should ignore invalid options", func(name, value string) {
+		os.Setenv(name, value)
+		Expect(getCurlOptions()).To(Equal(image.NbdkitCurlOptions{}))
+	},
+		Entry("connections that are not a number", common.ImporterCurlConnections, "many"),
+		Entry("no connections", common.ImporterCurlConnections, "0"),
+		Entry("an unknown HTTP version", common.ImporterCurlHTTPVersion, "2.5"),
+		Entry("ciphers separated by spaces", common.ImporterCurlTLS13Ciphers, "TLS_AES_256_GCM_SHA384 TLS_CHACHA20_POLY1305_SHA256"),
+		Entry("a timeout without unit", common.ImporterCurlTimeout, "90"),
+		Entry("a negative timeout", common.ImporterCurlTimeout, "-1m"),
+	)
+
+	It("Should count the connections and keep the debug messages out of the log", func() {
+		output := strings.Join([]string{
+			"nbdkit: curl[1]: debug: curl: config key=header, value=Authorization: Bearer secret",
+			"nbdkit: curl[1]: debug: curl: * Connected to images.example.com (192.0.2.1) port 443",
+			"nbdkit: curl[1]: debug: curl: C: Authorization: Bearer secret",
+			"nbdkit: curl[2]: debug: curl: * Re-using existing connection with host images.example.com",
+			"nbdkit: curl[3]: debug: curl: * Re-using existing connection with host images.example.com",
+			"nbdkit: curl[1]: error: problem doing HEAD request to fetch size of URL",
+		}, "\n")
+		var log strings.Builder
+		watcher := &curlConnectionWatcher{}
+		watcher.watch(bufio.NewReader(strings.NewReader(output)), &log)
+		Expect(watcher.connections).To(Equal(1))
+		Expect(watcher.reused).To(Equal(2))
+		Expect(log.String()).To(ContainSubstring("problem doing HEAD request"))
+		Expect(log.String()).NotTo(ContainSubstring("secret"))
+	})
+})
@@ -19,14 +19,17 @@ package importer
 import (
 	"net/url"
 	"os"
+	"time"
 
 	"github.com/pkg/errors"
 
+	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/klog/v2"
 
 	"kubevirt.io/containerized-data-importer/pkg/common"
 	"kubevirt.io/containerized-data-importer/pkg/image"
+	metrics "kubevirt.io/containerized-data-importer/pkg/monitoring/metrics/cdi-importer"
 	"kubevirt.io/containerized-data-importer/pkg/util"
 )
 
@@ -61,6 +64,16 @@ const (
 	ProcessingPhaseError ProcessingPhase = common.GenericError
 	// ProcessingPhaseMergeDelta is the phase in a multi-stage import where a delta image downloaded to scratch is applied to the base image
 	ProcessingPhaseMergeDelta ProcessingPhase = "MergeDelta"
+	// ProcessingPhaseCompress is the phase in which the resized raw image is rewritten as compressed qcow2, when that is the storage format
+	ProcessingPhaseCompress ProcessingPhase = "Compress"
+	// ProcessingPhaseExpandFilesystem is the phase in which the last partition and filesystem of the resized image are grown to fill it
+	ProcessingPhaseExpandFilesystem ProcessingPhase = "ExpandFilesystem"
+	// ProcessingPhaseSparsify is the phase in which the unused blocks of the filesystems of the resized image are discarded
+	ProcessingPhaseSparsify ProcessingPhase = "Sparsify"
+	// ProcessingPhaseEncrypt is the phase in which the resized raw image is rewritten as LUKS encrypted qcow2
+	ProcessingPhaseEncrypt ProcessingPhase = "Encrypt"
+	// ProcessingPhaseVerify is the phase checking the image written to the target, and comparing it with its source
+	ProcessingPhaseVerify ProcessingPhase = "Verify"
 )
 
 // may be overridden in tests
@@ -89,6 +102,23 @@ type ResumableDataSource interface {
 	GetResumePhase() ProcessingPhase
 }
 
+// reportedPhases maps the processing phases to the importer phases whose durations are reported
+var reportedPhases = map[ProcessingPhase]common.ImportPhase{
+	ProcessingPhaseTransferScratch:    common.ImportPhaseDownload,
+	ProcessingPhaseTransferDataDir:    common.ImportPhaseDownload,
+	ProcessingPhaseTransferDataFile:   common.ImportPhaseDownload,
+	ProcessingPhaseConvert:            common.ImportPhaseConvert,
+	ProcessingPhaseMergeDelta:         common.ImportPhaseConvert,
+	ProcessingPhaseCompress:           common.ImportPhaseConvert,
+	ProcessingPhaseEncrypt:            common.ImportPhaseConvert,
+	ProcessingPhaseResize:             common.ImportPhaseResize,
+	ProcessingPhaseExpandFilesystem:   common.ImportPhaseResize,
+	ProcessingPhaseSparsify:           common.ImportPhaseResize,
+	ProcessingPhaseValidatePause:      common.ImportPhaseVerify,
+	ProcessingPhaseValidatePreScratch: common.ImportPhaseVerify,
+	ProcessingPhaseVerify:             common.ImportPhaseVerify,
+}
+
 // DataProcessor holds the fields needed to process data from a data provider.
 type DataProcessor struct {
 	// currentPhase is the phase the processing is in currently.
@@ -116,6 +146,20 @@ type DataProcessor struct {
 	// cacheMode is the mode in which we choose the qemu-img cache mode:
 	// TRY_NONE = bypass page cache if the target supports it, otherwise, fall back to using page cache
 	cacheMode string
+	// phaseDurations is the time spent in each of the reported importer phases
+	phaseDurations map[common.ImportPhase]time.Duration
+	// storageFormat is the format the image is stored in on a filesystem target, raw if empty
+	storageFormat string
+	// expandFilesystem grows the last partition and filesystem of the image to fill the target
+	expandFilesystem bool
+	// sparsify discards the unused blocks of the filesystems of the image
+	sparsify bool
+	// encryptionPassphraseFile holds the passphrase the image is encrypted with on a filesystem target, not encrypted if empty
+	encryptionPassphraseFile string
+	// verify checks the image written to the target once it is complete
+	verify bool
+	// verifyChecksum compares the image written to the target with its source downloaded to scratch space
+	verifyChecksum bool
 }
 
 // NewDataProcessor create a new instance of a data processor using the passed in data provider.
@@ -228,6 +272,41 @@ func (dp *DataProcessor) initDefaultPhases() {
 		}
 		return pp, err
 	})
+	dp.RegisterPhaseExecutor(ProcessingPhaseExpandFilesystem, func() (ProcessingPhase, error) {
+		pp, err := dp.expandFilesystemPhase()
+		if err != nil {
+			err = errors.Wrap(err, "Unable to expand the filesystem of the disk image")
+		}
+		return pp, err
+	})
+	dp.RegisterPhaseExecutor(ProcessingPhaseSparsify, func() (ProcessingPhase, error) {
+		pp, err := dp.sparsifyPhase()
+		if err != nil {
+			err = errors.Wrap(err, "Unable to sparsify the disk image")
+		}
+		return pp, err
+	})
+	dp.RegisterPhaseExecutor(ProcessingPhaseEncrypt, func() (ProcessingPhase, error) {
+		pp, err := dp.encrypt()
+		if err != nil {
+			err = errors.Wrap(err, "Unable to encrypt disk image")
+		}
+		return pp, err
+	})
+	dp.RegisterPhaseExecutor(ProcessingPhaseCompress, func() (ProcessingPhase, error) {
+		pp, err := dp.compress()
+		if err != nil {
+			err = errors.Wrap(err, "Unable to compress disk image")
+		}
+		return pp, err
+	})
+	dp.RegisterPhaseExecutor(ProcessingPhaseVerify, func() (ProcessingPhase, error) {
+		pp, err := dp.verifyPhase()
+		if err != nil {
+			err = errors.Wrap(err, "Unable to verify the disk image")
+		}
+		return pp, err
+	})
 	dp.RegisterPhaseExecutor(ProcessingPhaseMergeDelta, func() (ProcessingPhase, error) {
 		pp, err := dp.merge()
 		if err != nil {
@@ -250,7 +329,9 @@ func (dp *DataProcessor) ProcessDataWithPause() error {
 		if !ok {
 			return errors.Errorf("Unknown processing phase %s", dp.currentPhase)
 		}
+		start := time.Now()
 		nextPhase, err := executor()
+		dp.recordPhaseDuration(dp.currentPhase, time.Since(start))
 		visited[dp.currentPhase] = true
 		if err != nil {
 			klog.Errorf("%+v", err)
@@ -264,7 +345,11 @@ func (dp *DataProcessor) ProcessDataWithPause() error {
 
 func (dp *DataProcessor) validate(url *url.URL) error {
 	klog.V(1).Infoln("Validating image")
-	err := qemuOperations.Validate(url, dp.availableSpace)
+	volumeMode := v1.PersistentVolumeFilesystem
+	if size, _ := getAvailableSpaceBlockFunc(dp.dataFile); size >= int64(0) {
+		volumeMode = v1.PersistentVolumeBlock
+	}
+	err := qemuOperations.Validate(url, dp.availableSpace, dp.filesystemOverhead, volumeMode)
 	if err != nil {
 		return ValidationSizeError{err: err}
 	}
@@ -282,7 +367,12 @@ func (dp *DataProcessor) convert(url *url.URL) (ProcessingPhase, error) {
 		return ProcessingPhaseError, err
 	}
 	klog.V(3).Infoln("Converting to Raw")
-	err = qemuOperations.ConvertToRawStream(url, dp.dataFile, dp.preallocation, dp.cacheMode)
+	if size := dp.convertedSize(url); size != nil {
+		// The image is converted into a target created at its final size, growing it in the same pass over the data
+		err = qemuOperations.CloneWithConversion(url, dp.dataFile, *size, dp.preallocation, dp.cacheMode)
+	} else {
+		err = qemuOperations.ConvertToRawStream(url, dp.dataFile, dp.preallocation, dp.cacheMode)
+	}
 	if err != nil {
 		return ProcessingPhaseError, errors.Wrap(err, "Conversion to Raw failed")
 	}
@@ -291,6 +381,27 @@ func (dp *DataProcessor) convert(url *url.URL) (ProcessingPhase, error) {
 	return ProcessingPhaseResize, nil
 }
 
+// convertedSize returns the size the image at url is grown to by its conversion onto a filesystem target, the size the
+// resize phase would grow it to. It returns nil if the image is not grown.
+func (dp *DataProcessor) convertedSize(url *url.URL) *resource.Quantity {
+	if dp.requestImageSize == "" || dp.dataFile == "" {
+		return nil
+	}
+	if size, _ := getAvailableSpaceBlockFunc(dp.dataFile); size >= int64(0) {
+		return nil
+	}
+	requested, err := resource.ParseQuantity(dp.requestImageSize)
+	if err != nil {
+		return nil
+	}
+	size := util.MinQuantity(resource.NewScaledQuantity(dp.getUsableSpace(), 0), &requested)
+	info, err := qemuOperations.Info(url)
+	if err != nil || size.CmpInt64(info.VirtualSize) <= 0 {
+		return nil
+	}
+	return &size
+}
+
 func (dp *DataProcessor) resize() (ProcessingPhase, error) {
 	size, _ := getAvailableSpaceBlockFunc(dp.dataFile)
 	klog.V(3).Infof("Available space in dataFile: %d", size)
@@ -321,7 +432,98 @@ func (dp *DataProcessor) resize() (ProcessingPhase, error) {
 			return ProcessingPhaseError, errors.Wrap(err, "Unable to change permissions of target file")
 		}
 	}
+	if dp.expandFilesystem {
+		return ProcessingPhaseExpandFilesystem, nil
+	}
+	if dp.sparsify {
+		return ProcessingPhaseSparsify, nil
+	}
+
+	return dp.afterResize(isBlockDev), nil
+}
 
+// afterResize returns the phase following the resize of the image
+func (dp *DataProcessor) afterResize(isBlockDev bool) ProcessingPhase {
+	if dp.storageFormat == common.StorageFormatQcow2 && !isBlockDev {
+		return ProcessingPhaseCompress
+	}
+	if dp.encryptionPassphraseFile != "" && !isBlockDev {
+		return ProcessingPhaseEncrypt
+	}
+	return dp.complete()
+}
+
+// complete returns the phase following the last change to the image
+func (dp *DataProcessor) complete() ProcessingPhase {
+	if dp.verify {
+		return ProcessingPhaseVerify
+	}
+	return ProcessingPhaseComplete
+}
+
+// expandFilesystemPhase grows the last partition and filesystem of the raw image, before it is compressed
+func (dp *DataProcessor) expandFilesystemPhase() (ProcessingPhase, error) {
+	if err := qemuOperations.ExpandFilesystem(dp.dataFile); err != nil {
+		return ProcessingPhaseError, err
+	}
+	if dp.sparsify {
+		return ProcessingPhaseSparsify, nil
+	}
+	size, _ := getAvailableSpaceBlockFunc(dp.dataFile)
+	return dp.afterResize(size >= int64(0)), nil
+}
+
+// sparsifyPhase discards the unused blocks of the filesystems of the raw image, before it is compressed or encrypted
+func (dp *DataProcessor) sparsifyPhase() (ProcessingPhase, error) {
+	if err := qemuOperations.Sparsify(dp.dataFile); err != nil {
+		return ProcessingPhaseError, err
+	}
+	size, _ := getAvailableSpaceBlockFunc(dp.dataFile)
+	return dp.afterResize(size >= int64(0)), nil
+}
+
+// encrypt rewrites the raw image as LUKS encrypted qcow2, dropping its preallocation
+func (dp *DataProcessor) encrypt() (ProcessingPhase, error) {
+	klog.V(1).Infoln("Encrypting image to qcow2")
+	if err := qemuOperations.Encrypt(dp.dataFile, dp.encryptionPassphraseFile); err != nil {
+		return ProcessingPhaseError, err
+	}
+	if err := os.Chmod(dp.dataFile, 0660); err != nil {
+		return ProcessingPhaseError, errors.Wrap(err, "Unable to change permissions of target file")
+	}
+	dp.preallocationApplied = false
+	return dp.complete(), nil
+}
+
+// compress rewrites the raw image as compressed qcow2, dropping its preallocation
+func (dp *DataProcessor) compress() (ProcessingPhase, error) {
+	klog.V(1).Infoln("Compressing image to qcow2")
+	if err := qemuOperations.Compress(dp.dataFile); err != nil {
+		return ProcessingPhaseError, err
+	}
+	if err := os.Chmod(dp.dataFile, 0660); err != nil {
+		return ProcessingPhaseError, errors.Wrap(err, "Unable to change permissions of target file")
+	}
+	dp.preallocationApplied = false
+	return dp.complete(), nil
+}
+
+// verifyPhase checks the image written to the target, and compares it with its source downloaded to scratch space
+func (dp *DataProcessor) verifyPhase() (ProcessingPhase, error) {
+	klog.V(1).Infoln("Verifying image")
+	if err := qemuOperations.Check(dp.dataFile); err != nil {
+		return ProcessingPhaseError, err
+	}
+	if !dp.verifyChecksum {
+		return ProcessingPhaseComplete, nil
+	}
+	source := dp.source.GetURL()
+	if source == nil || source.Scheme != "" {
+		return ProcessingPhaseError, errors.New("the source was not downloaded to scratch space, it cannot be compared with the image")
+	}
+	if err := qemuOperations.Compare(source.Path, dp.dataFile); err != nil {
+		return ProcessingPhaseError, err
+	}
 	return ProcessingPhaseComplete, nil
 }
 
@@ -388,6 +590,51 @@ func (dp *DataProcessor) calculateTargetSize() int64 {
 	return targetSize
 }
 
+// PhaseDurations returns the time spent in each of the reported importer phases
+func (dp *DataProcessor) PhaseDurations() map[common.ImportPhase]time.Duration {
+	return dp.phaseDurations
+}
+
+func (dp *DataProcessor) recordPhaseDuration(pp ProcessingPhase, d time.Duration) {
+	phase, ok := reportedPhases[pp]
+	if !ok {
+		return
+	}
+	if dp.phaseDurations == nil {
+		dp.phaseDurations = make(map[common.ImportPhase]time.Duration)
+	}
+	dp.phaseDurations[phase] += d
+	metrics.AddPhaseDuration(ownerUID, string(phase), d.Seconds())
+}
+
+// SetStorageFormat sets the format the image is stored in on a filesystem target, raw if empty
+func (dp *DataProcessor) SetStorageFormat(format string) {
+	dp.storageFormat = format
+}
+
+// SetExpandFilesystem sets whether the last partition and filesystem of the image are grown to fill the target
+func (dp *DataProcessor) SetExpandFilesystem(expand bool) {
+	dp.expandFilesystem = expand
+}
+
+// SetSparsify sets whether the unused blocks of the filesystems of the image are discarded
+func (dp *DataProcessor) SetSparsify(sparsify bool) {
+	dp.sparsify = sparsify
+}
+
+// SetEncryptionPassphraseFile sets the file holding the passphrase the image is encrypted with on a filesystem target,
+// not encrypted if empty
+func (dp *DataProcessor) SetEncryptionPassphraseFile(passphraseFile string) {
+	dp.encryptionPassphraseFile = passphraseFile
+}
+
+// SetVerification sets whether the image written to the target is checked, and whether it is compared with its
+// source downloaded to scratch space
+func (dp *DataProcessor) SetVerification(verify, checksum bool) {
+	dp.verify = verify
+	dp.verifyChecksum = checksum
+}
+
 // PreallocationApplied returns true if data processing path included preallocation step
 func (dp *DataProcessor) PreallocationApplied() bool {
 	return dp.preallocationApplied
@@ -4,12 +4,14 @@ import (
 	"fmt"
 	"net/url"
 	"os"
+	"path/filepath"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
 	"github.com/pkg/errors"
 
+	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 
 	"kubevirt.io/containerized-data-importer/pkg/common"
@@ -274,6 +276,18 @@ var _ = Describe("Data Processor", func() {
 		})
 	})
 
+	It("should record the duration of the reported phases", func() {
+		mdp := &MockDataProvider{
+			infoResponse:     ProcessingPhaseTransferDataDir,
+			transferResponse: ProcessingPhaseComplete,
+		}
+		dp := NewDataProcessor(mdp, "dest", "dataDir", "scratchDataDir", "1G", 0.06, false, "")
+		err := dp.ProcessData()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(dp.PhaseDurations()).To(HaveLen(1))
+		Expect(dp.PhaseDurations()).To(HaveKey(common.ImportPhaseDownload))
+	})
+
 	It("should allow phase registry", func() {
 		mcdp := &MockCustomizedDataProvider{
 			MockDataProvider: MockDataProvider{
@@ -369,6 +383,67 @@ var _ = Describe("Convert", func() {
 	})
 })
 
+// cloneWithConversionQEMUOperations records the size images are grown to while converted
+type cloneWithConversionQEMUOperations struct {
+	image.QEMUOperations
+	size *resource.Quantity
+}
+
+func (o *cloneWithConversionQEMUOperations) CloneWithConversion(url *url.URL, dest string, size resource.Quantity, preallocate bool, cacheMode string) error {
+	o.size = &size
+	return nil
+}
+
+var _ = Describe("Convert and grow", func() {
+	var tempDir string
+
+	BeforeEach(func() {
+		var err error
+		tempDir, err = os.MkdirTemp(os.TempDir(), "dest")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(tempDir)
+	})
+
+	convert := func(requestImageSize string, virtualSize int64) *resource.Quantity {
+		url, err := url.Parse("nbd+unix:///?socket=/tmp/nbd.sock")
+		Expect(err).ToNot(HaveOccurred())
+		mdp := &MockDataProvider{url: url}
+		dp := NewDataProcessor(mdp, filepath.Join(tempDir, "disk.img"), tempDir, "scratchDataDir", requestImageSize, 0, false, "")
+		dp.availableSpace = int64(10 * 1024 * 1024 * 1024)
+		ops := &cloneWithConversionQEMUOperations{
+			QEMUOperations: NewFakeQEMUOperations(nil, nil, fakeInfoOpRetVal{&image.ImgInfo{Format: "qcow2", VirtualSize: virtualSize}, nil}, nil, nil, nil),
+		}
+		replaceQEMUOperations(ops, func() {
+			replaceAvailableSpaceBlockFunc(func(string) (int64, error) { return -1, nil }, func() {
+				nextPhase, err := dp.convert(mdp.GetURL())
+				Expect(err).ToNot(HaveOccurred())
+				Expect(nextPhase).To(Equal(ProcessingPhaseResize))
+			})
+		})
+		return ops.size
+	}
+
+	It("Should grow the image to the requested size while converting it", func() {
+		size := convert("2Gi", 1024*1024*1024)
+		Expect(size).ToNot(BeNil())
+		Expect(size.Value()).To(Equal(int64(2 * 1024 * 1024 * 1024)))
+	})
+
+	It("Should grow the image to the available space if smaller than the requested size", func() {
+		size := convert("20Gi", 1024*1024*1024)
+		Expect(size).ToNot(BeNil())
+		Expect(size.Value()).To(Equal(int64(10 * 1024 * 1024 * 1024)))
+	})
+
+	It("Should only convert images that are not grown", func() {
+		Expect(convert("1Gi", 1024*1024*1024)).To(BeNil())
+		Expect(convert("", 1024*1024*1024)).To(BeNil())
+	})
+})
+
 var _ = Describe("Resize", func() {
 	It("Should not resize and return complete, when requestedSize is blank", func() {
 		tempDir, err := os.MkdirTemp(os.TempDir(), "dest")
@@ -427,6 +502,24 @@ var _ = Describe("Resize", func() {
 		})
 	})
 
+	It("Should return compress, when the storage format is qcow2 and datadir exists", func() {
+		tmpDir, err := os.MkdirTemp(os.TempDir(), "data")
+		Expect(err).ToNot(HaveOccurred())
+		url, err := url.Parse("http://fakeurl-notreal.fake")
+		Expect(err).ToNot(HaveOccurred())
+		mdp := &MockDataProvider{
+			url: url,
+		}
+		dp := NewDataProcessor(mdp, tmpDir, tmpDir, "scratchDataDir", "1G", 0.06, false, "")
+		dp.SetStorageFormat(common.StorageFormatQcow2)
+		qemuOperations := NewFakeQEMUOperations(nil, nil, fakeInfoOpRetVal{&fakeZeroImageInfo, nil}, nil, nil, nil)
+		replaceQEMUOperations(qemuOperations, func() {
+			nextPhase, err := dp.resize()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ProcessingPhaseCompress).To(Equal(nextPhase))
+		})
+	})
+
 	It("Should not resize and return error, when ResizeImage fails", func() {
 		tmpDir, err := os.MkdirTemp(os.TempDir(), "data")
 		Expect(err).ToNot(HaveOccurred())
@@ -467,6 +560,210 @@ var _ = Describe("Resize", func() {
 	})
 })
 
+var _ = Describe("Expand filesystem", func() {
+	It("Should return expand filesystem from resize, when requested", func() {
+		tmpDir := GinkgoT().TempDir()
+		dp := NewDataProcessor(&MockDataProvider{}, tmpDir, tmpDir, "scratchDataDir", "1G", 0.06, false, "")
+		dp.SetExpandFilesystem(true)
+		dp.SetStorageFormat(common.StorageFormatQcow2)
+		qemuOperations := NewFakeQEMUOperations(nil, nil, fakeInfoOpRetVal{&fakeZeroImageInfo, nil}, nil, nil, nil)
+		replaceQEMUOperations(qemuOperations, func() {
+			nextPhase, err := dp.resize()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(nextPhase).To(Equal(ProcessingPhaseExpandFilesystem))
+		})
+	})
+
+	DescribeTable("Should expand and return the phase following the resize", func(storageFormat string, blockSize int64, expected ProcessingPhase) {
+		replaceAvailableSpaceBlockFunc(func(string) (int64, error) {
+			return blockSize, nil
+		}, func() {
+			dp := NewDataProcessor(&MockDataProvider{}, "dest", "dataDir", "scratchDataDir", "", 0.06, false, "")
+			dp.SetStorageFormat(storageFormat)
+			qemuOperations := NewFakeQEMUOperations(nil, nil, fakeInfoOpRetVal{&fakeZeroImageInfo, nil}, nil, nil, nil)
+			replaceQEMUOperations(qemuOperations, func() {
+				nextPhase, err := dp.expandFilesystemPhase()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(nextPhase).To(Equal(expected))
+			})
+		})
+	},
+		Entry("raw image", "", int64(-1), ProcessingPhaseComplete),
+		Entry("qcow2 image", common.StorageFormatQcow2, int64(-1), ProcessingPhaseCompress),
+		Entry("block device", common.StorageFormatQcow2, int64(100000), ProcessingPhaseComplete),
+	)
+
+	It("Should return error, when the expansion fails", func() {
+		dp := NewDataProcessor(&MockDataProvider{}, "dest", "dataDir", "scratchDataDir", "", 0.06, false, "")
+		replaceQEMUOperations(NewQEMUAllErrors(), func() {
+			nextPhase, err := dp.expandFilesystemPhase()
+			Expect(err).To(HaveOccurred())
+			Expect(nextPhase).To(Equal(ProcessingPhaseError))
+		})
+	})
+})
+
+var _ = Describe("Sparsify", func() {
+	It("Should return sparsify from resize and expand filesystem, when requested", func() {
+		tmpDir := GinkgoT().TempDir()
+		dp := NewDataProcessor(&MockDataProvider{}, tmpDir, tmpDir, "scratchDataDir", "1G", 0.06, false, "")
+		dp.SetSparsify(true)
+		qemuOperations := NewFakeQEMUOperations(nil, nil, fakeInfoOpRetVal{&fakeZeroImageInfo, nil}, nil, nil, nil)
+		replaceQEMUOperations(qemuOperations, func() {
+			nextPhase, err := dp.resize()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(nextPhase).To(Equal(ProcessingPhaseSparsify))
+			nextPhase, err = dp.expandFilesystemPhase()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(nextPhase).To(Equal(ProcessingPhaseSparsify))
+		})
+	})
+
+	DescribeTable("Should sparsify and return the phase following the resize", func(passphraseFile string, blockSize int64, expected ProcessingPhase) {
+		replaceAvailableSpaceBlockFunc(func(string) (int64, error) {
+			return blockSize, nil
+		}, func() {
+			dp := NewDataProcessor(&MockDataProvider{}, "dest", "dataDir", "scratchDataDir", "", 0.06, false, "")
+			dp.SetEncryptionPassphraseFile(passphraseFile)
+			qemuOperations := NewFakeQEMUOperations(nil, nil, fakeInfoOpRetVal{&fakeZeroImageInfo, nil}, nil, nil, nil)
+			replaceQEMUOperations(qemuOperations, func() {
+				nextPhase, err := dp.sparsifyPhase()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(nextPhase).To(Equal(expected))
+			})
+		})
+	},
+		Entry("raw image", "", int64(-1), ProcessingPhaseComplete),
+		Entry("encrypted image", "/encryption/passphrase", int64(-1), ProcessingPhaseEncrypt),
+		Entry("block device", "/encryption/passphrase", int64(100000), ProcessingPhaseComplete),
+	)
+
+	It("Should return error, when sparsifying fails", func() {
+		dp := NewDataProcessor(&MockDataProvider{}, "dest", "dataDir", "scratchDataDir", "", 0.06, false, "")
+		replaceQEMUOperations(NewQEMUAllErrors(), func() {
+			nextPhase, err := dp.sparsifyPhase()
+			Expect(err).To(HaveOccurred())
+			Expect(nextPhase).To(Equal(ProcessingPhaseError))
+		})
+	})
+})
+
+var _ = Describe("Encrypt", func() {
+	It("Should encrypt and return complete, without preallocation", func() {
+		dataFile := filepath.Join(GinkgoT().TempDir(), "disk.img")
+		Expect(os.WriteFile(dataFile, nil, 0600)).To(Succeed())
+		dp := NewDataProcessor(&MockDataProvider{}, dataFile, "dataDir", "scratchDataDir", "", 0.06, true, "")
+		dp.SetEncryptionPassphraseFile("/encryption/passphrase")
+		dp.preallocationApplied = true
+		qemuOperations := NewFakeQEMUOperations(nil, nil, fakeInfoOpRetVal{&fakeZeroImageInfo, nil}, nil, nil, nil)
+		replaceQEMUOperations(qemuOperations, func() {
+			nextPhase, err := dp.encrypt()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(nextPhase).To(Equal(ProcessingPhaseComplete))
+		})
+		Expect(dp.PreallocationApplied()).To(BeFalse())
+	})
+
+	It("Should return error, when the encryption fails", func() {
+		dp := NewDataProcessor(&MockDataProvider{}, "dest", "dataDir", "scratchDataDir", "", 0.06, false, "")
+		replaceQEMUOperations(NewQEMUAllErrors(), func() {
+			nextPhase, err := dp.encrypt()
+			Expect(err).To(HaveOccurred())
+			Expect(nextPhase).To(Equal(ProcessingPhaseError))
+		})
+	})
+})
+
+var _ = Describe("Compress", func() {
+	It("Should compress and return complete, without preallocation", func() {
+		dataFile := filepath.Join(GinkgoT().TempDir(), "disk.img")
+		Expect(os.WriteFile(dataFile, nil, 0600)).To(Succeed())
+		dp := NewDataProcessor(&MockDataProvider{}, dataFile, "dataDir", "scratchDataDir", "", 0.06, true, "")
+		dp.preallocationApplied = true
+		qemuOperations := NewFakeQEMUOperations(nil, nil, fakeInfoOpRetVal{&fakeZeroImageInfo, nil}, nil, nil, nil)
+		replaceQEMUOperations(qemuOperations, func() {
+			nextPhase, err := dp.compress()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ProcessingPhaseComplete).To(Equal(nextPhase))
+		})
+		Expect(dp.PreallocationApplied()).To(BeFalse())
+	})
+
+	It("Should return error, when the compression fails", func() {
+		dp := NewDataProcessor(&MockDataProvider{}, "dest", "dataDir", "scratchDataDir", "", 0.06, false, "")
+		replaceQEMUOperations(NewQEMUAllErrors(), func() {
+			nextPhase, err := dp.compress()
+			Expect(err).To(HaveOccurred())
+			Expect(ProcessingPhaseError).To(Equal(nextPhase))
+		})
+	})
+})
+
+var _ = Describe("Verify", func() {
+	DescribeTable("Should return verify once the image is complete, when requested", func(blockSize int64) {
+		replaceAvailableSpaceBlockFunc(func(string) (int64, error) {
+			return blockSize, nil
+		}, func() {
+			dp := NewDataProcessor(&MockDataProvider{}, "dest", "dataDir", "scratchDataDir", "", 0.06, false, "")
+			dp.SetVerification(true, false)
+			qemuOperations := NewFakeQEMUOperations(nil, nil, fakeInfoOpRetVal{&fakeZeroImageInfo, nil}, nil, nil, nil)
+			replaceQEMUOperations(qemuOperations, func() {
+				nextPhase, err := dp.expandFilesystemPhase()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(nextPhase).To(Equal(ProcessingPhaseVerify))
+			})
+		})
+	},
+		Entry("raw image", int64(-1)),
+		Entry("block device", int64(100000)),
+	)
+
+	It("Should check the image and return complete", func() {
+		dp := NewDataProcessor(&MockDataProvider{}, "dest", "dataDir", "scratchDataDir", "", 0.06, false, "")
+		dp.SetVerification(true, false)
+		qemuOperations := NewFakeQEMUOperations(nil, nil, fakeInfoOpRetVal{&fakeZeroImageInfo, nil}, nil, nil, nil)
+		replaceQEMUOperations(qemuOperations, func() {
+			nextPhase, err := dp.verifyPhase()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(nextPhase).To(Equal(ProcessingPhaseComplete))
+		})
+	})
+
+	It("Should compare the image with the source downloaded to scratch space", func() {
+		source, _ := url.Parse("scratchDataDir/tmpimage")
+		dp := NewDataProcessor(&MockDataProvider{url: source}, "dest", "dataDir", "scratchDataDir", "", 0.06, false, "")
+		dp.SetVerification(true, true)
+		qemuOperations := NewFakeQEMUOperations(nil, nil, fakeInfoOpRetVal{&fakeZeroImageInfo, nil}, nil, nil, nil)
+		replaceQEMUOperations(qemuOperations, func() {
+			nextPhase, err := dp.verifyPhase()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(nextPhase).To(Equal(ProcessingPhaseComplete))
+		})
+	})
+
+	It("Should fail comparing the image with a source that was not downloaded", func() {
+		source, _ := url.Parse("nbd+unix:///?socket=/tmp/nbdkit.sock")
+		dp := NewDataProcessor(&MockDataProvider{url: source}, "dest", "dataDir", "scratchDataDir", "", 0.06, false, "")
+		dp.SetVerification(true, true)
+		qemuOperations := NewFakeQEMUOperations(nil, nil, fakeInfoOpRetVal{&fakeZeroImageInfo, nil}, nil, nil, nil)
+		replaceQEMUOperations(qemuOperations, func() {
+			nextPhase, err := dp.verifyPhase()
+			Expect(err).To(MatchError(ContainSubstring("was not downloaded to scratch space")))
+			Expect(nextPhase).To(Equal(ProcessingPhaseError))
+		})
+	})
+
+	It("Should return error, when the verification fails", func() {
+		dp := NewDataProcessor(&MockDataProvider{}, "dest", "dataDir", "scratchDataDir", "", 0.06, false, "")
+		dp.SetVerification(true, false)
+		replaceQEMUOperations(NewQEMUAllErrors(), func() {
+			nextPhase, err := dp.verifyPhase()
+			Expect(err).To(HaveOccurred())
+			Expect(nextPhase).To(Equal(ProcessingPhaseError))
+		})
+	})
+})
+
 var _ = Describe("ResizeImage", func() {
 	//fakeInfoRet has info.VirtualSize=1024
 	DescribeTable("calling ResizeImage", func(qemuOperations image.QEMUOperations, imageSize string, totalSpace int64, wantErr bool) {
@@ -589,7 +886,11 @@ func (o *fakeQEMUOperations) ConvertToRawStream(*url.URL, string, bool, string)
 	return o.e2
 }
 
-func (o *fakeQEMUOperations) Validate(*url.URL, int64) error {
+func (o *fakeQEMUOperations) CloneWithConversion(*url.URL, string, resource.Quantity, bool, string) error {
+	return o.e2
+}
+
+func (o *fakeQEMUOperations) Validate(*url.URL, int64, float64, v1.PersistentVolumeMode) error {
 	return o.e5
 }
 
@@ -626,6 +927,30 @@ func (o *fakeQEMUOperations) Commit(image string) error {
 	return nil
 }
 
+func (o *fakeQEMUOperations) Compress(image string) error {
+	return o.e3
+}
+
+func (o *fakeQEMUOperations) ExpandFilesystem(image string) error {
+	return o.e3
+}
+
+func (o *fakeQEMUOperations) Sparsify(image string) error {
+	return o.e3
+}
+
+func (o *fakeQEMUOperations) Encrypt(image, passphraseFile string) error {
+	return o.e3
+}
+
+func (o *fakeQEMUOperations) Check(image string) error {
+	return o.e3
+}
+
+func (o *fakeQEMUOperations) Compare(source, image string) error {
+	return o.e3
+}
+
 func NewQEMUAllErrors() image.QEMUOperations {
 	err := errors.New("qemu should not be called from this test override with replaceQEMUOperations")
 	return NewFakeQEMUOperations(err, err, fakeInfoOpRetVal{nil, err}, err, err, nil)
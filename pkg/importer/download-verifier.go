@@ -0,0 +1,141 @@
+/*
+Copyright 2024 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package importer
+
+import (
+	"io"
+	"net/url"
+	"os"
+
+	"github.com/pkg/errors"
+)
+
+// downloadCheck hashes the data of a source, and checks the digest once all of it is read
+type downloadCheck interface {
+	io.Writer
+	check() error
+}
+
+// SourceDigestReporter is implemented by the data sources finding the digest of the source they read
+type SourceDigestReporter interface {
+	// ObservedSourceDigest returns the digest of the source as algorithm:hex, empty while it is unknown
+	ObservedSourceDigest() string
+}
+
+// signatureFetcher downloads the signature of a source, from a url resolved against the url of the source
+type signatureFetcher func(signatureURL *url.URL) (io.ReadCloser, error)
+
+// downloadVerifier hashes the data of a source as it is downloaded, before any of it is decompressed or extracted,
+// and checks it against the checksum and the signature of the source, so a truncated, corrupted or tampered
+// download is caught before the image is converted.
+type downloadVerifier struct {
+	io.ReadCloser
+	checks []downloadCheck
+	hashes io.Writer
+}
+
+// getDownloadVerifier returns the verifier of the checksum and the signature of the source at ep, nil if neither is
+// set. Sources without signatures pass a nil fetchSignature.
+func getDownloadVerifier(ep *url.URL, fetchSignature signatureFetcher) (*downloadVerifier, error) {
+	checks := []downloadCheck{}
+	checksum, err := getChecksum()
+	if err != nil {
+		return nil, err
+	}
+	if checksum != nil {
+		checks = append(checks, checksum)
+	}
+	signature, err := getSignature(ep, fetchSignature)
+	if err != nil {
+		return nil, err
+	}
+	if signature != nil {
+		checks = append(checks, signature)
+	}
+	if len(checks) == 0 {
+		return nil, nil
+	}
+	writers := make([]io.Writer, len(checks))
+	for i, c := range checks {
+		writers[i] = c
+	}
+	return &downloadVerifier{checks: checks, hashes: io.MultiWriter(writers...)}, nil
+}
+
+// wrap returns the reader hashing r, or r itself if nothing is verified
+func (v *downloadVerifier) wrap(r io.ReadCloser) io.ReadCloser {
+	if v == nil {
+		return r
+	}
+	v.ReadCloser = r
+	return v
+}
+
+func (v *downloadVerifier) Read(p []byte) (int, error) {
+	n, err := v.ReadCloser.Read(p)
+	// Hashes never fail to write
+	_, _ = v.hashes.Write(p[:n])
+	return n, err
+}
+
+// hashFile hashes the bytes of file from start up to end, the part of a resumed download that was written before
+// the importer restarted
+func (v *downloadVerifier) hashFile(file string, start, end int64) error {
+	if v == nil {
+		return nil
+	}
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if _, err := io.Copy(v.hashes, io.NewSectionReader(f, start, end-start)); err != nil {
+		return errors.Wrap(err, "unable to hash the part of the download already written")
+	}
+	return nil
+}
+
+// observedDigest returns the digest of the source found by its checksum, empty until the source is verified or if
+// it has no checksum
+func (v *downloadVerifier) observedDigest() string {
+	if v == nil {
+		return ""
+	}
+	for _, c := range v.checks {
+		if checksum, ok := c.(*checksumCheck); ok {
+			return checksum.observed
+		}
+	}
+	return ""
+}
+
+// verify reads what the readers of the source left unread, like the padding of an archive or the other files
+// of an OVA, and checks the digests of the source. verify does nothing if nothing is verified.
+func (v *downloadVerifier) verify() error {
+	if v == nil {
+		return nil
+	}
+	if _, err := io.Copy(io.Discard, v); err != nil {
+		return errors.Wrap(err, "unable to read the end of the source")
+	}
+	for _, c := range v.checks {
+		if err := c.check(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -1,11 +1,19 @@
 package importer
 
 import (
+	"compress/gzip"
 	"errors"
 	"fmt"
+	"io"
+	"net"
+	"net/http"
 	"syscall"
 
+	"github.com/containers/image/v5/docker"
+
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
 	"kubevirt.io/containerized-data-importer/pkg/common"
+	"kubevirt.io/containerized-data-importer/pkg/image"
 )
 
 // ValidationSizeError is an error indication size validation failure.
@@ -48,3 +56,106 @@ func IsNoCapacityError(err error) bool {
 		errors.Is(err, syscall.EDQUOT) ||
 		errors.As(err, &ValidationSizeError{})
 }
+
+// ErrSourceVerificationFailed is matched by the errors of sources failing their checksum, signature or image verification
+var ErrSourceVerificationFailed = errors.New("source verification failed")
+
+// sourceVerificationError is the error of a source failing its verification, matching ErrSourceVerificationFailed
+type sourceVerificationError struct {
+	error
+}
+
+func newSourceVerificationError(err error) error {
+	return sourceVerificationError{err}
+}
+
+func (e sourceVerificationError) Is(target error) bool {
+	return target == ErrSourceVerificationFailed
+}
+
+func (e sourceVerificationError) Unwrap() error {
+	return e.error
+}
+
+// HTTPStatusError is the error of a source answering with another status than the expected one
+type HTTPStatusError struct {
+	Expected   int
+	StatusCode int
+	Status     string
+}
+
+func newHTTPStatusError(expected int, resp *http.Response) *HTTPStatusError {
+	return &HTTPStatusError{Expected: expected, StatusCode: resp.StatusCode, Status: resp.Status}
+}
+
+func (err *HTTPStatusError) Error() string {
+	return fmt.Sprintf("expected status code %d, got %d. Status: %s", err.Expected, err.StatusCode, err.Status)
+}
+
+// FailureReason returns the reason of the Running condition of the DataVolume an import failing with err reports,
+// empty if the failure is not one of the known ones
+func FailureReason(err error) string {
+	var statusErr *HTTPStatusError
+	var netErr net.Error
+	switch {
+	case err == nil:
+		return ""
+	case errors.Is(err, syscall.EDQUOT):
+		return cdiv1.DataVolumeReasonQuotaExceeded
+	case IsNoCapacityError(err), errors.Is(err, image.ErrLargerPVCRequired):
+		return cdiv1.DataVolumeReasonInsufficientSpace
+	case errors.As(err, &statusErr):
+		switch statusErr.StatusCode {
+		case http.StatusUnauthorized, http.StatusForbidden:
+			return cdiv1.DataVolumeReasonAuthFailed
+		case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+			return cdiv1.DataVolumeReasonThrottled
+		}
+		return cdiv1.DataVolumeReasonSourceUnreachable
+	case errors.As(err, &docker.ErrUnauthorizedForCredentials{}):
+		return cdiv1.DataVolumeReasonAuthFailed
+	case errors.Is(err, docker.ErrTooManyRequests):
+		return cdiv1.DataVolumeReasonThrottled
+	case errors.Is(err, image.ErrVerificationFailed):
+		return cdiv1.DataVolumeReasonVerificationFailed
+	case errors.Is(err, image.ErrInvalidImage), errors.Is(err, gzip.ErrHeader), errors.Is(err, gzip.ErrChecksum):
+		return cdiv1.DataVolumeReasonCorrupt
+	case errors.As(err, &netErr):
+		return cdiv1.DataVolumeReasonSourceUnreachable
+	}
+	return ""
+}
+
+// FailureClass returns the class of the retry policy of DataVolumes an import failing with err is retried on, empty
+// if the failure is not one of the known ones
+func FailureClass(err error) cdiv1.DataVolumeRetryErrorClass {
+	var statusErr *HTTPStatusError
+	if errors.Is(err, syscall.EROFS) || errors.Is(err, syscall.EIO) {
+		// Checked first, errnos are net errors as well, the connection errors are then classified by their reason
+		return cdiv1.RetryOnStorage
+	}
+	switch FailureReason(err) {
+	case cdiv1.DataVolumeReasonInsufficientSpace, cdiv1.DataVolumeReasonQuotaExceeded, cdiv1.DataVolumeReasonVerificationFailed:
+		return cdiv1.RetryOnStorage
+	case cdiv1.DataVolumeReasonThrottled:
+		return cdiv1.RetryOnNetwork
+	case cdiv1.DataVolumeReasonAuthFailed, cdiv1.DataVolumeReasonCorrupt:
+		return cdiv1.RetryOnSource
+	case cdiv1.DataVolumeReasonSourceUnreachable:
+		// Sources answering with a client error are missing, server errors are transient like network errors
+		if errors.As(err, &statusErr) && statusErr.StatusCode < http.StatusInternalServerError {
+			return cdiv1.RetryOnSource
+		}
+		return cdiv1.RetryOnNetwork
+	}
+	switch {
+	case err == nil:
+		return ""
+	case errors.Is(err, ErrSourceVerificationFailed):
+		return cdiv1.RetryOnSource
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		// The connection was dropped in the middle of the download
+		return cdiv1.RetryOnNetwork
+	}
+	return ""
+}
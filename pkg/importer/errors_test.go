@@ -0,0 +1,71 @@
+package importer
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/containers/image/v5/docker"
+	"github.com/pkg/errors"
+
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+	"kubevirt.io/containerized-data-importer/pkg/image"
+)
+
+var _ = Describe("FailureReason", func() {
+	statusError := func(code int) error {
+		return errors.Wrap(&HTTPStatusError{Expected: http.StatusOK, StatusCode: code, Status: http.StatusText(code)}, "HTTP request errored")
+	}
+
+	DescribeTable("Should classify", func(err error, expected string) {
+		Expect(FailureReason(err)).To(Equal(expected))
+	},
+		Entry("missing sources as unreachable", statusError(http.StatusNotFound), cdiv1.DataVolumeReasonSourceUnreachable),
+		Entry("refused connections as unreachable", &url.Error{Op: "Get", URL: "https://example.com/disk.img",
+			Err: &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}}, cdiv1.DataVolumeReasonSourceUnreachable),
+		Entry("rejected credentials as auth failures", statusError(http.StatusUnauthorized), cdiv1.DataVolumeReasonAuthFailed),
+		Entry("forbidden sources as auth failures", statusError(http.StatusForbidden), cdiv1.DataVolumeReasonAuthFailed),
+		Entry("rejected registry credentials as auth failures", NewImagePullFailedError(docker.ErrUnauthorizedForCredentials{Err: errors.New("denied")}), cdiv1.DataVolumeReasonAuthFailed),
+		Entry("rate limits as throttling", statusError(http.StatusTooManyRequests), cdiv1.DataVolumeReasonThrottled),
+		Entry("registry rate limits as throttling", NewImagePullFailedError(docker.ErrTooManyRequests), cdiv1.DataVolumeReasonThrottled),
+		Entry("full volumes as insufficient space", errors.Wrap(syscall.ENOSPC, "write failed"), cdiv1.DataVolumeReasonInsufficientSpace),
+		Entry("too small volumes as insufficient space", fmt.Errorf("virtual size too large: %w", image.ErrLargerPVCRequired), cdiv1.DataVolumeReasonInsufficientSpace),
+		Entry("exceeded disk quotas as exceeded quotas", errors.Wrap(syscall.EDQUOT, "write failed"), cdiv1.DataVolumeReasonQuotaExceeded),
+		Entry("invalid images as corrupt", fmt.Errorf("validation failed: %w", image.ErrInvalidImage), cdiv1.DataVolumeReasonCorrupt),
+		Entry("truncated archives as corrupt", errors.Wrap(gzip.ErrHeader, "could not read archive"), cdiv1.DataVolumeReasonCorrupt),
+		Entry("failed verifications", errors.Wrap(fmt.Errorf("%w: 2 corruptions", image.ErrVerificationFailed), "Unable to verify the disk image"), cdiv1.DataVolumeReasonVerificationFailed),
+		Entry("other errors as unknown", errors.New("unexpected"), ""),
+		Entry("no error as unknown", nil, ""),
+	)
+})
+
+var _ = Describe("FailureClass", func() {
+	statusError := func(code int) error {
+		return errors.Wrap(&HTTPStatusError{Expected: http.StatusOK, StatusCode: code, Status: http.StatusText(code)}, "HTTP request errored")
+	}
+
+	DescribeTable("Should classify", func(err error, expected cdiv1.DataVolumeRetryErrorClass) {
+		Expect(FailureClass(err)).To(Equal(expected))
+	},
+		Entry("missing sources as source errors", statusError(http.StatusNotFound), cdiv1.RetryOnSource),
+		Entry("rejected credentials as source errors", statusError(http.StatusUnauthorized), cdiv1.RetryOnSource),
+		Entry("server errors as network errors", statusError(http.StatusBadGateway), cdiv1.RetryOnNetwork),
+		Entry("rate limits as network errors", statusError(http.StatusTooManyRequests), cdiv1.RetryOnNetwork),
+		Entry("refused connections as network errors", &url.Error{Op: "Get", URL: "https://example.com/disk.img",
+			Err: &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}}, cdiv1.RetryOnNetwork),
+		Entry("dropped connections as network errors", NewImagePullFailedError(io.ErrUnexpectedEOF), cdiv1.RetryOnNetwork),
+		Entry("full volumes as storage errors", errors.Wrap(syscall.ENOSPC, "write failed"), cdiv1.RetryOnStorage),
+		Entry("read-only volumes as storage errors", errors.Wrap(syscall.EROFS, "write failed"), cdiv1.RetryOnStorage),
+		Entry("invalid images as source errors", fmt.Errorf("validation failed: %w", image.ErrInvalidImage), cdiv1.RetryOnSource),
+		Entry("checksum mismatches as source errors", errors.Wrap(newSourceVerificationError(errors.New("checksum mismatch")), "download failed"), cdiv1.RetryOnSource),
+		Entry("other errors as unknown", errors.New("connection reset by peer"), cdiv1.DataVolumeRetryErrorClass("")),
+		Entry("no error as unknown", nil, cdiv1.DataVolumeRetryErrorClass("")),
+	)
+})
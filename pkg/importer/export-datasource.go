@@ -0,0 +1,230 @@
+/*
+Copyright 2024 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package importer
+
+import (
+	"crypto/tls"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"k8s.io/klog/v2"
+
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+	"kubevirt.io/containerized-data-importer/pkg/common"
+)
+
+// exportTokenHeader is the header the export server of the source cluster reads the export token from
+const exportTokenHeader = "x-kubevirt-export-token"
+
+// ExportDataSource is the data provider for volumes exported by another cluster. The importer downloads
+// the volume from the export server with the export token, presenting a client certificate if the
+// secret holds one, and streams it into the target.
+// Sequence of phases:
+// 1a. Info -> TransferDataFile if the volume is raw, compressed or not.
+// 1b. Info -> TransferDataDir if the content type is archive.
+// 1c. Info -> Transfer in all other cases.
+// 2.  Transfer -> Convert
+type ExportDataSource struct {
+	// Reader of the volume
+	exportReader io.ReadCloser
+	// size of the volume reported by the export server, 0 if unknown
+	size uint64
+	// stack of readers
+	readers *FormatReaders
+	// The image file in scratch space.
+	url *url.URL
+	// contentType expected from the export
+	contentType cdiv1.DataVolumeContentType
+}
+
+// NewExportDataSource creates a new instance of the ExportDataSource. endpoint is the url of the exported
+// volume, and the export token and optional client certificate are read from credentialDir.
+func NewExportDataSource(endpoint, credentialDir, certDir string, contentType cdiv1.DataVolumeContentType) (*ExportDataSource, error) {
+	ep, err := ParseEndpoint(endpoint)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to parse endpoint %q", endpoint)
+	}
+	if ep.Scheme != "https" {
+		return nil, errors.Errorf("export url %s must be https", ep.Redacted())
+	}
+	if credentialDir == "" {
+		return nil, errors.New("export sources need a secret holding the export token")
+	}
+	content, err := os.ReadFile(filepath.Join(credentialDir, common.KeyExportToken))
+	if err != nil {
+		return nil, errors.Wrapf(err, "export secret has no %s", common.KeyExportToken)
+	}
+	token := strings.TrimSpace(string(content))
+	if token == "" {
+		return nil, errors.New("export token is empty")
+	}
+	client, err := createExportHTTPClient(credentialDir, certDir)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest(http.MethodGet, ep.String(), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create export request")
+	}
+	addExtraheaders(req, nil)
+	req.Header.Set(exportTokenHeader, token)
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "export request errored")
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, errors.Errorf("unable to download the exported volume %s: %s", ep.Redacted(), resp.Status)
+	}
+	size := uint64(0)
+	if resp.ContentLength > 0 {
+		size = uint64(resp.ContentLength)
+	}
+	klog.V(3).Infof("Export Importer: importing %s, %d bytes", ep.Redacted(), size)
+	return &ExportDataSource{
+		exportReader: resp.Body,
+		size:         size,
+		contentType:  contentType,
+	}, nil
+}
+
+// createExportHTTPClient returns an http client trusting the CAs in certDir, presenting the client
+// certificate of the export secret for mutual TLS when the secret holds one.
+func createExportHTTPClient(credentialDir, certDir string) (*http.Client, error) {
+	client, err := createHTTPClient(certDir)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error creating http client for export")
+	}
+	certFile := filepath.Join(credentialDir, common.KeyExportClientCert)
+	if _, err := os.Stat(certFile); os.IsNotExist(err) {
+		return client, nil
+	}
+	clientCert, err := tls.LoadX509KeyPair(certFile, filepath.Join(credentialDir, common.KeyExportClientKey))
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to load the client certificate of the export secret")
+	}
+	transport, ok := client.Transport.(*http.Transport)
+	if !ok {
+		transport = http.DefaultTransport.(*http.Transport).Clone()
+		transport.TLSClientConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+		client.Transport = transport
+	}
+	transport.TLSClientConfig.Certificates = []tls.Certificate{clientCert}
+	return client, nil
+}
+
+// Info is called to get initial information about the data.
+func (ed *ExportDataSource) Info() (ProcessingPhase, error) {
+	var err error
+	ed.readers, err = NewFormatReaders(ed.exportReader, ed.size)
+	if err != nil {
+		klog.Errorf("Error creating readers: %v", err)
+		return ProcessingPhaseError, err
+	}
+	if ed.contentType, err = resolveContentType(ed.contentType, ed.readers.DetectedType); err != nil {
+		return ProcessingPhaseError, err
+	}
+	if ed.contentType == cdiv1.DataVolumeArchive {
+		return ProcessingPhaseTransferDataDir, nil
+	}
+	if !ed.readers.Convert {
+		// Downloading a raw file, we can write that directly to the target.
+		return ProcessingPhaseTransferDataFile, nil
+	}
+	return ProcessingPhaseTransferScratch, nil
+}
+
+// DetectedContentType returns the type of the data found by its magic bytes
+func (ed *ExportDataSource) DetectedContentType() string {
+	if ed.readers == nil {
+		return ""
+	}
+	return ed.readers.DetectedType
+}
+
+// ContentType returns the content type the data is imported as
+func (ed *ExportDataSource) ContentType() cdiv1.DataVolumeContentType {
+	return ed.contentType
+}
+
+// Transfer is called to transfer the data from the source to a temporary location.
+func (ed *ExportDataSource) Transfer(path string, preallocation bool) (ProcessingPhase, error) {
+	ed.readers.StartProgressUpdate()
+	if ed.contentType == cdiv1.DataVolumeArchive {
+		if err := ed.readers.UnArchive(path); err != nil {
+			return ProcessingPhaseError, errors.Wrap(err, "unable to extract files from the export")
+		}
+		ed.url = nil
+		return ProcessingPhaseComplete, nil
+	}
+	file := filepath.Join(path, tempFile)
+	if err := CleanAll(file); err != nil {
+		return ProcessingPhaseError, err
+	}
+	size, _ := GetAvailableSpace(path)
+	if size <= int64(0) {
+		//Path provided is invalid.
+		return ProcessingPhaseError, ErrInvalidPath
+	}
+	if _, _, err := StreamDataToFile(ed.readers.TopReader(), file, preallocation); err != nil {
+		return ProcessingPhaseError, err
+	}
+	// If streaming succeeded, then parsing the file into URL will also succeed, no need to check error status
+	ed.url, _ = url.Parse(file)
+	return ProcessingPhaseConvert, nil
+}
+
+// TransferFile is called to transfer the data from the source to the passed in file.
+func (ed *ExportDataSource) TransferFile(fileName string, preallocation bool) (ProcessingPhase, error) {
+	if err := CleanAll(fileName); err != nil {
+		return ProcessingPhaseError, err
+	}
+	ed.readers.StartProgressUpdate()
+	if _, _, err := StreamDataToFile(ed.readers.TopReader(), fileName, preallocation); err != nil {
+		return ProcessingPhaseError, err
+	}
+	return ProcessingPhaseResize, nil
+}
+
+// GetURL returns the url that the data processor can use when converting the data.
+func (ed *ExportDataSource) GetURL() *url.URL {
+	return ed.url
+}
+
+// GetTerminationMessage returns data to be serialized and used as the termination message of the importer.
+func (ed *ExportDataSource) GetTerminationMessage() *common.TerminationMessage {
+	return nil
+}
+
+// Close closes any readers or other open resources.
+func (ed *ExportDataSource) Close() error {
+	if ed.readers != nil {
+		return ed.readers.Close()
+	}
+	if ed.exportReader != nil {
+		return ed.exportReader.Close()
+	}
+	return nil
+}
+
+var _ DataSourceInterface = &ExportDataSource{}
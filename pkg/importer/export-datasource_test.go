@@ -0,0 +1,159 @@
+package importer
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+	"kubevirt.io/containerized-data-importer/pkg/common"
+	"kubevirt.io/containerized-data-importer/pkg/util/cert"
+	"kubevirt.io/containerized-data-importer/pkg/util/cert/triple"
+)
+
+const testExportToken = "export-token"
+
+var _ = Describe("Export data source", func() {
+	var (
+		ts        *httptest.Server
+		ca        *triple.KeyPair
+		credDir   string
+		certDir   string
+		tmpDir    string
+		volume    = bytes.Repeat([]byte{0x55}, 1024*1024)
+		gzVolume  []byte
+		writeFile = func(dir, name string, content []byte) {
+			Expect(os.WriteFile(filepath.Join(dir, name), content, 0600)).To(Succeed())
+		}
+	)
+
+	BeforeEach(func() {
+		var err error
+		ca, err = triple.NewCA("export.cdi.kubevirt.io")
+		Expect(err).NotTo(HaveOccurred())
+		server, err := triple.NewServerKeyPair(ca, "127.0.0.1", "export", "source", "cluster.local", []string{"127.0.0.1"}, nil)
+		Expect(err).NotTo(HaveOccurred())
+		serverCert, err := tls.X509KeyPair(cert.EncodeCertPEM(server.Cert), cert.EncodePrivateKeyPEM(server.Key))
+		Expect(err).NotTo(HaveOccurred())
+		clientCAs := x509.NewCertPool()
+		clientCAs.AddCert(ca.Cert)
+
+		var gz bytes.Buffer
+		w := gzip.NewWriter(&gz)
+		_, err = w.Write(volume)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(w.Close()).To(Succeed())
+		gzVolume = gz.Bytes()
+
+		ts = httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get(exportTokenHeader) != testExportToken {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			switch r.URL.Path {
+			case "/volumes/disk/disk.img.gz":
+				_, _ = w.Write(gzVolume)
+			case "/volumes/disk/disk.tar":
+				tarFile, err := os.ReadFile(archiveFilePath)
+				Expect(err).NotTo(HaveOccurred())
+				_, _ = w.Write(tarFile)
+			default:
+				http.NotFound(w, r)
+			}
+		}))
+		ts.TLS = &tls.Config{
+			Certificates: []tls.Certificate{serverCert},
+			ClientCAs:    clientCAs,
+			ClientAuth:   tls.VerifyClientCertIfGiven,
+			MinVersion:   tls.VersionTLS12,
+		}
+		ts.StartTLS()
+
+		credDir, err = os.MkdirTemp("", "export-creds")
+		Expect(err).NotTo(HaveOccurred())
+		certDir, err = os.MkdirTemp("", "export-ca")
+		Expect(err).NotTo(HaveOccurred())
+		tmpDir, err = os.MkdirTemp("", "export")
+		Expect(err).NotTo(HaveOccurred())
+		writeFile(credDir, common.KeyExportToken, []byte(testExportToken+"\n"))
+		writeFile(certDir, "ca.pem", cert.EncodeCertPEM(ca.Cert))
+	})
+
+	AfterEach(func() {
+		ts.Close()
+		os.RemoveAll(credDir)
+		os.RemoveAll(certDir)
+		os.RemoveAll(tmpDir)
+	})
+
+	It("Should stream a compressed volume into the target", func() {
+		ed, err := NewExportDataSource(ts.URL+"/volumes/disk/disk.img.gz", credDir, certDir, cdiv1.DataVolumeKubeVirt)
+		Expect(err).NotTo(HaveOccurred())
+		defer ed.Close()
+		phase, err := ed.Info()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(phase).To(Equal(ProcessingPhaseTransferDataFile))
+		fileName := filepath.Join(tmpDir, "disk.img")
+		phase, err = ed.TransferFile(fileName, false)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(phase).To(Equal(ProcessingPhaseResize))
+		Expect(os.ReadFile(fileName)).To(Equal(volume))
+	})
+
+	It("Should extract an exported filesystem volume", func() {
+		ed, err := NewExportDataSource(ts.URL+"/volumes/disk/disk.tar", credDir, certDir, cdiv1.DataVolumeArchive)
+		Expect(err).NotTo(HaveOccurred())
+		defer ed.Close()
+		phase, err := ed.Info()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(phase).To(Equal(ProcessingPhaseTransferDataDir))
+		phase, err = ed.Transfer(tmpDir, false)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(phase).To(Equal(ProcessingPhaseComplete))
+		Expect(filepath.Join(tmpDir, tinyCoreFileName)).To(BeAnExistingFile())
+	})
+
+	It("Should present the client certificate of the secret", func() {
+		ts.TLS.ClientAuth = tls.RequireAndVerifyClientCert
+		_, err := NewExportDataSource(ts.URL+"/volumes/disk/disk.img.gz", credDir, certDir, cdiv1.DataVolumeKubeVirt)
+		Expect(err).To(HaveOccurred())
+
+		client, err := triple.NewClientKeyPair(ca, "target-cluster", nil)
+		Expect(err).NotTo(HaveOccurred())
+		writeFile(credDir, common.KeyExportClientCert, cert.EncodeCertPEM(client.Cert))
+		writeFile(credDir, common.KeyExportClientKey, cert.EncodePrivateKeyPEM(client.Key))
+		ed, err := NewExportDataSource(ts.URL+"/volumes/disk/disk.img.gz", credDir, certDir, cdiv1.DataVolumeKubeVirt)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ed.Close()).To(Succeed())
+	})
+
+	DescribeTable("Should fail with", func(endpoint string, update func(), errSubstring string) {
+		if update != nil {
+			update()
+		}
+		_, err := NewExportDataSource(ts.URL+endpoint, credDir, certDir, cdiv1.DataVolumeKubeVirt)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring(errSubstring))
+	},
+		Entry("a wrong token", "/volumes/disk/disk.img.gz", func() { writeFile(credDir, common.KeyExportToken, []byte("other")) }, "401 Unauthorized"),
+		Entry("an empty token", "/volumes/disk/disk.img.gz", func() { writeFile(credDir, common.KeyExportToken, []byte("\n")) }, "export token is empty"),
+		Entry("a missing volume", "/volumes/other/disk.img.gz", nil, "404 Not Found"),
+		Entry("an untrusted server", "/volumes/disk/disk.img.gz", func() { Expect(os.Remove(filepath.Join(certDir, "ca.pem"))).To(Succeed()) }, "certificate"),
+		Entry("a client certificate without its key", "/volumes/disk/disk.img.gz", func() {
+			writeFile(credDir, common.KeyExportClientCert, cert.EncodeCertPEM(ca.Cert))
+		}, "unable to load the client certificate"),
+	)
+
+	It("Should refuse to send the token over plain http", func() {
+		_, err := NewExportDataSource("http://127.0.0.1/volumes/disk/disk.img.gz", credDir, certDir, cdiv1.DataVolumeKubeVirt)
+		Expect(err).To(MatchError(ContainSubstring("must be https")))
+	})
+})
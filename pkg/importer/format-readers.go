@@ -54,14 +54,19 @@ type reader struct {
 
 // FormatReaders contains the stack of readers needed to get information from the input stream (io.ReadCloser)
 type FormatReaders struct {
-	readers        []reader
-	buf            []byte // holds file headers
-	Convert        bool
-	Archived       bool
-	ArchiveXz      bool
-	ArchiveGz      bool
-	ArchiveZstd    bool
+	readers     []reader
+	buf         []byte // holds file headers
+	Convert     bool
+	Archived    bool
+	ArchiveXz   bool
+	ArchiveGz   bool
+	ArchiveZstd bool
+	ArchiveZip  bool
+	// DetectedType is the type of the data found by its magic bytes, see detectType
+	DetectedType   string
 	progressReader *prometheusutil.ProgressReader
+	// zip reads the entries of a zip archive, for the archive content type
+	zip *zipReader
 }
 
 const (
@@ -69,6 +74,7 @@ const (
 	rdrMulti
 	rdrXz
 	rdrStream
+	rdrZip
 )
 
 // map scheme and format to rdrType
@@ -76,6 +82,7 @@ var rdrTypM = map[string]int{
 	"gz":     rdrGz,
 	"xz":     rdrXz,
 	"stream": rdrStream,
+	"zip":    rdrZip,
 }
 
 // NewFormatReaders creates a new instance of FormatReaders using the input stream and content type passed in.
@@ -97,6 +104,7 @@ func (fr *FormatReaders) constructReaders(r io.ReadCloser) error {
 	fr.appendReader(rdrTypM["stream"], r)
 	knownHdrs := image.CopyKnownHdrs() // need local copy since keys are removed
 	klog.V(3).Infof("constructReaders: checking compression and archive formats\n")
+	var format string
 	for {
 		hdr, err := fr.matchHeader(&knownHdrs)
 		if err != nil {
@@ -106,14 +114,16 @@ func (fr *FormatReaders) constructReaders(r io.ReadCloser) error {
 			break // done processing headers, we have the orig source file
 		}
 		klog.V(2).Infof("found header of type %q\n", hdr.Format)
+		format = hdr.Format
 		// create format-specific reader and append it to dataStream readers stack
 		fr.fileFormatSelector(hdr)
-		// exit loop if hdr is qcow2
-		if hdr.Format == "qcow2" {
+		// exit loop if hdr is qcow2, or a zip archive, as the header of its file is not at the start of it
+		if hdr.Format == "qcow2" || hdr.Format == "zip" {
 			break
 		}
 	}
 
+	fr.detectType(format)
 	return nil
 }
 
@@ -168,6 +178,13 @@ func (fr *FormatReaders) fileFormatSelector(hdr *image.Header) {
 			fr.Archived = true
 			fr.ArchiveXz = true
 		}
+	case "zip":
+		fr.zip = newZipReader(fr.TopReader())
+		r = &zipFileReader{zr: fr.zip}
+		fr.Archived = true
+		fr.ArchiveZip = true
+		// qemu-img finds the format of the file of the archive once it is extracted
+		fr.Convert = true
 	case "qcow2":
 		r, err = fr.qcow2NopReader(hdr)
 		fr.Convert = true
@@ -283,6 +300,14 @@ func (fr *FormatReaders) Close() (rtnerr error) {
 	return rtnerr
 }
 
+// UnArchive extracts the files of the tar or zip archive to path, for the archive content type.
+func (fr *FormatReaders) UnArchive(path string) error {
+	if fr.ArchiveZip {
+		return fr.zip.extract(path)
+	}
+	return util.UnArchiveTar(fr.TopReader(), path)
+}
+
 // StartProgressUpdate starts the go routine to automatically update the progress on a set interval.
 func (fr *FormatReaders) StartProgressUpdate() {
 	if fr.progressReader != nil {
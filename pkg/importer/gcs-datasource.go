@@ -2,7 +2,9 @@ package importer
 
 import (
 	"context"
+	"fmt"
 	"io"
+	"net/http"
 	"net/url"
 	"path/filepath"
 	"strings"
@@ -10,44 +12,70 @@ import (
 
 	"cloud.google.com/go/storage"
 	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
 	"google.golang.org/api/option"
 
 	"k8s.io/klog/v2"
 
 	"kubevirt.io/containerized-data-importer/pkg/common"
+	"kubevirt.io/containerized-data-importer/pkg/image"
 )
 
 const (
 	gcsFolderSep = "/"
 	gcsScheme    = "gs"
+	// gcsXMLEndpoint serves objects of gs:// urls to plain http clients, with support for ranged reads
+	gcsXMLEndpoint = "https://storage.googleapis.com"
 )
 
-// Helper for unit-testing
-var newReaderFunc = getGcsObjectReader
+// Helpers for unit-testing
+var (
+	newReaderFunc          = getGcsObjectReader
+	findDefaultCredentials = google.FindDefaultCredentials
+	createGcsNbdkit        = image.NewNbdkitCurl
+	// nbdkit reading the Authorization header of the current access token from a file
+	createGcsNbdkitWithHeaderFile = image.NewNbdkitCurlWithHeaderFile
+)
 
 // GCSDataSource is the struct containing the information needed to import from a GCS data source.
+// Objects qemu-img can read as they are are streamed through nbdkit with ranged reads, others, and the objects
+// checked against a checksum, are downloaded.
 // Sequence of phases:
-// 1. Info -> Transfer
+// 1a. Info -> Convert, if the object is streamed
+// 1b. Info -> Transfer
 // 2. Transfer -> Convert
 type GCSDataSource struct {
 	// GCS end point
 	ep *url.URL
 	// Key File
 	keyFile string
+	// The directory of the CAs of a private endpoint
+	certDir string
+	// Credentials, nil for anonymous access
+	creds *google.Credentials
 	// Reader
 	gcsReader io.ReadCloser
 	// stack of readers
 	readers *FormatReaders
-	// The image file in scratch space.
+	// The url of the object for plain http clients
+	objectURL string
+	// nbdkit streaming the object
+	n image.NbdkitOperation
+	// stops refreshing the access token nbdkit sends
+	cancelHeaderRefresh context.CancelFunc
+	// The image file in scratch space, or the nbdkit socket.
 	url *url.URL
+	// verifies the object if its checksum is set, nil otherwise
+	verifier *downloadVerifier
 }
 
 // NewGCSDataSource creates a new instance of the GCSDataSource
-func NewGCSDataSource(endpoint, keyFile string) (*GCSDataSource, error) {
+func NewGCSDataSource(endpoint, keyFile, certDir string) (*GCSDataSource, error) {
 	klog.V(3).Infoln("GCS Importer: New Data Source")
 
 	// Placeholders
-	var bucket, object, host string
+	var bucket, object, host, objectURL string
 	var options []option.ClientOption
 
 	// Parsing Endpoint
@@ -63,14 +91,37 @@ func NewGCSDataSource(endpoint, keyFile string) (*GCSDataSource, error) {
 	if ep.Scheme == "gs" {
 		// Using gs:// endpoint and extracting bucket and object name
 		bucket, object = extractGcsBucketAndObject(endpoint)
+		objectURL = gcsXMLEndpoint + gcsFolderSep + bucket + gcsFolderSep + escapeGcsObject(object)
 	} else if ep.Scheme == "http" || ep.Scheme == "https" {
 		// Using http(s):// endpoint and extracting bucket, object name and host
 		bucket, object, host = extractGcsBucketObjectAndHost(endpoint)
 		options = append(options, option.WithEndpoint(host))
+		objectURL = endpoint
+	}
+
+	// The signatures of GCS objects are not supported
+	verifier, err := getDownloadVerifier(ep, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	creds, err := getGcsCredentials(ctx, keyFile)
+	if err != nil {
+		klog.Errorf("GCS Importer: Error finding credentials")
+		return nil, err
+	}
+
+	// A private endpoint may be served with a custom CA, or require a client certificate
+	var httpClient *http.Client
+	if certDir != "" || hasClientCertificate() {
+		httpClient, err = createHTTPClient(certDir)
+		if err != nil {
+			return nil, errors.Wrap(err, "GCS Importer: Error creating http client")
+		}
 	}
 
 	// Creating GCS Client
-	client, err := getGcsClient(ctx, keyFile, options...)
+	client, err := getGcsClient(ctx, creds, httpClient, options...)
 
 	if err != nil {
 		klog.Errorf("GCS Importer: Error creating GCS Client")
@@ -87,19 +138,32 @@ func NewGCSDataSource(endpoint, keyFile string) (*GCSDataSource, error) {
 	return &GCSDataSource{
 		ep:        ep,
 		keyFile:   keyFile,
+		certDir:   certDir,
+		creds:     creds,
 		gcsReader: gcsReader,
+		objectURL: objectURL,
+		verifier:  verifier,
 	}, nil
 }
 
 // Info is called to get initial information about the data.
 func (sd *GCSDataSource) Info() (ProcessingPhase, error) {
 	var err error
-	sd.readers, err = NewFormatReaders(sd.gcsReader, uint64(0))
+	sd.readers, err = NewFormatReaders(sd.verifier.wrap(sd.gcsReader), uint64(0))
 	if err != nil {
 		klog.Errorf("GCS Importer: Error creating readers: %v", err)
 		return ProcessingPhaseError, err
 	}
-	if !sd.readers.Convert {
+	if !sd.readers.Archived && sd.verifier == nil && !hasClientCertificate() {
+		// qemu-img can read the object directly, no need to download it first, unless it is checked against its checksum,
+		// or a client certificate nbdkit cannot present is required
+		err = sd.startNbdkit()
+		if err == nil {
+			return ProcessingPhaseConvert, nil
+		}
+		klog.Warningf("GCS Importer: Unable to stream the object, downloading it instead: %v", err)
+	}
+	if !sd.readers.Convert && !isComparedWithSource() {
 		// Downloading a raw file, we can write that directly to the target.
 		return ProcessingPhaseTransferDataFile, nil
 	}
@@ -129,6 +193,9 @@ func (sd *GCSDataSource) Transfer(path string, preallocation bool) (ProcessingPh
 		klog.V(3).Infoln("GCS Importer: Transfer Error: ", err)
 		return ProcessingPhaseError, err
 	}
+	if err := sd.verifier.verify(); err != nil {
+		return ProcessingPhaseError, err
+	}
 	// If streaming succeeded, then parsing the file into URL will also succeed, no need to check error status
 	sd.url, _ = url.Parse(file)
 	return ProcessingPhaseConvert, nil
@@ -144,6 +211,9 @@ func (sd *GCSDataSource) TransferFile(fileName string, preallocation bool) (Proc
 	if err != nil {
 		return ProcessingPhaseError, err
 	}
+	if err := sd.verifier.verify(); err != nil {
+		return ProcessingPhaseError, err
+	}
 	return ProcessingPhaseResize, nil
 }
 
@@ -152,6 +222,11 @@ func (sd *GCSDataSource) GetURL() *url.URL {
 	return sd.url
 }
 
+// ObservedSourceDigest returns the digest of the download found by the checksum of the source
+func (sd *GCSDataSource) ObservedSourceDigest() string {
+	return sd.verifier.observedDigest()
+}
+
 // GetTerminationMessage returns data to be serialized and used as the termination message of the importer.
 func (sd *GCSDataSource) GetTerminationMessage() *common.TerminationMessage {
 	return nil
@@ -163,15 +238,78 @@ func (sd *GCSDataSource) Close() error {
 	if sd.readers != nil {
 		err = sd.readers.Close()
 	}
+	if sd.n != nil {
+		if killErr := sd.n.KillNbdkit(); err == nil {
+			err = killErr
+		}
+	}
+	if sd.cancelHeaderRefresh != nil {
+		sd.cancelHeaderRefresh()
+	}
 	return err
 }
 
-// Create a Cloud Storage Client
-func getGcsClient(ctx context.Context, keyFile string, options ...option.ClientOption) (*storage.Client, error) {
-	klog.V(3).Infoln("GCS Importer: Creating Client")
+// startNbdkit serves the object through nbdkit, so qemu-img reads only the ranges it needs
+func (sd *GCSDataSource) startNbdkit() error {
+	var n image.NbdkitOperation
+	var err error
+	if sd.creds != nil {
+		// Access tokens expire after about an hour, nbdkit reads the refreshed one from the header file
+		ctx, cancel := context.WithCancel(context.Background())
+		if err = startOAuth2HeaderRefresh(ctx, sd.creds.TokenSource, oauth2HeaderFile, oauth2RenewInterval); err == nil {
+			n, err = createGcsNbdkitWithHeaderFile(nbdkitPid, sd.certDir, nbdkitSocket, oauth2HeaderFile, oauth2RenewInterval, nil, nil)
+		}
+		if err != nil {
+			cancel()
+			return errors.Wrap(err, "unable to pass the access token to nbdkit")
+		}
+		sd.cancelHeaderRefresh = cancel
+	} else {
+		n, err = createGcsNbdkit(nbdkitPid, "", "", sd.certDir, nbdkitSocket, nil, nil)
+		if err != nil {
+			return err
+		}
+	}
+	setCurlOptions(n)
+	if err := n.StartNbdkit(sd.objectURL); err != nil {
+		return err
+	}
+	sd.n = n
+	sd.url, _ = url.Parse(fmt.Sprintf("nbd+unix:///?socket=%s", nbdkitSocket))
+	return nil
+}
+
+// Find the credentials to access the bucket with. Without a key file, the credentials of the environment,
+// like workload identity, are used if there are any, and the bucket is accessed anonymously otherwise.
+func getGcsCredentials(ctx context.Context, keyFile string) (*google.Credentials, error) {
+	creds, err := findDefaultCredentials(ctx, storage.ScopeReadOnly)
+	if err != nil {
+		if keyFile != "" {
+			return nil, err
+		}
+		klog.V(3).Infoln("GCS Importer: Authentication: Anonymous")
+		return nil, nil
+	}
 	if keyFile == "" {
+		klog.V(3).Infoln("GCS Importer: Authentication: Application default credentials")
+	}
+	return creds, nil
+}
+
+// Create a Cloud Storage Client, sending its requests with httpClient if it is set
+func getGcsClient(ctx context.Context, creds *google.Credentials, httpClient *http.Client, options ...option.ClientOption) (*storage.Client, error) {
+	klog.V(3).Infoln("GCS Importer: Creating Client")
+	switch {
+	case httpClient != nil:
+		// The http client takes precedence over the other options, so it authenticates the requests itself
+		if creds != nil {
+			httpClient.Transport = &oauth2.Transport{Source: creds.TokenSource, Base: httpClient.Transport}
+		}
+		options = append(options, option.WithHTTPClient(httpClient))
+	case creds == nil:
 		options = append(options, option.WithoutAuthentication())
-		klog.V(3).Infoln("GCS Importer: Authentication: Anonymous")
+	default:
+		options = append(options, option.WithCredentials(creds))
 	}
 	return storage.NewClient(ctx, options...)
 }
@@ -193,6 +331,15 @@ func extractGcsBucketAndObject(s string) (string, string) {
 	return bucket, object
 }
 
+// Escape the object name for use in an url path, keeping the folder separators
+func escapeGcsObject(object string) string {
+	parts := strings.Split(object, gcsFolderSep)
+	for i, part := range parts {
+		parts[i] = url.PathEscape(part)
+	}
+	return strings.Join(parts, gcsFolderSep)
+}
+
 // Extract url in format https://storage.cloud.google.com/bucket/filename
 func extractGcsBucketObjectAndHost(s string) (string, string, string) {
 	klog.V(3).Infoln("GCS Importer: Extracting GCS Bucket, Object and Host")
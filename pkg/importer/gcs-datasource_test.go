@@ -1,15 +1,26 @@
 package importer
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
 	"cloud.google.com/go/storage"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+
+	"kubevirt.io/containerized-data-importer/pkg/common"
+	"kubevirt.io/containerized-data-importer/pkg/image"
+	"kubevirt.io/containerized-data-importer/pkg/util/cert/triple"
 )
 
 var _ = Describe("Google Cloud Storage data source", func() {
@@ -21,6 +32,18 @@ var _ = Describe("Google Cloud Storage data source", func() {
 
 	BeforeEach(func() {
 		newReaderFunc = mockGcsObjectReader
+		os.Unsetenv("GOOGLE_APPLICATION_CREDENTIALS")
+		findDefaultCredentials = func(ctx context.Context, scopes ...string) (*google.Credentials, error) {
+			// Only use the credentials of the test, never the ones of the machine running it
+			if os.Getenv("GOOGLE_APPLICATION_CREDENTIALS") == "" {
+				return nil, errors.New("no credentials")
+			}
+			return google.FindDefaultCredentials(ctx, scopes...)
+		}
+		// Unless a test says otherwise, objects can not be streamed and are downloaded
+		gcsNbdkit = &fakeNbdkit{startErr: errors.New("nbdkit unavailable")}
+		createGcsNbdkit = createFakeGcsNbdkit
+		createGcsNbdkitWithHeaderFile = createFakeGcsNbdkitWithHeaderFile
 		tmpDir, err = os.MkdirTemp("", "scratch")
 		Expect(err).NotTo(HaveOccurred())
 		By("tmpDir: " + tmpDir)
@@ -30,9 +53,131 @@ var _ = Describe("Google Cloud Storage data source", func() {
 		if sd != nil {
 			sd.Close()
 		}
+		findDefaultCredentials = google.FindDefaultCredentials
+		createGcsNbdkit = image.NewNbdkitCurl
+		createGcsNbdkitWithHeaderFile = image.NewNbdkitCurlWithHeaderFile
+		os.Remove(oauth2HeaderFile)
 		os.RemoveAll(tmpDir)
 	})
 
+	Context("streaming through nbdkit", func() {
+		BeforeEach(func() {
+			gcsNbdkit = &fakeNbdkit{}
+		})
+
+		DescribeTable("Info should return Convert for images qemu-img can read", func(endpoint, fileName, objectURL string) {
+			file, err := os.Open(filepath.Join(imageDir, fileName))
+			Expect(err).NotTo(HaveOccurred())
+			sd, err = NewGCSDataSource(endpoint, "", "")
+			Expect(err).NotTo(HaveOccurred())
+			sd.gcsReader = file
+			result, err := sd.Info()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(ProcessingPhaseConvert))
+			Expect(sd.GetURL().String()).To(Equal("nbd+unix:///?socket=" + nbdkitSocket))
+			Expect(gcsNbdkit.source).To(Equal(objectURL))
+			Expect(gcsNbdkit.secretHeaders).To(BeEmpty())
+		},
+			Entry("raw from a gs url", "gs://Bucket1/Folder 1/cirros.raw", "cirros.raw", "https://storage.googleapis.com/Bucket1/Folder%201/cirros.raw"),
+			Entry("qcow2 from a gs url", "gs://Bucket1/cirros-qcow2.img", "cirros-qcow2.img", "https://storage.googleapis.com/Bucket1/cirros-qcow2.img"),
+			Entry("raw from an https url", "https://storage.cloud.google.com/Bucket1/cirros.raw", "cirros.raw", "https://storage.cloud.google.com/Bucket1/cirros.raw"),
+		)
+
+		It("Info should download compressed images", func() {
+			file, err := os.Open(tinyCoreGzFilePath)
+			Expect(err).NotTo(HaveOccurred())
+			sd, err = NewGCSDataSource("gs://Bucket1/tinyCore.iso.gz", "", "")
+			Expect(err).NotTo(HaveOccurred())
+			sd.gcsReader = file
+			result, err := sd.Info()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(ProcessingPhaseTransferDataFile))
+			Expect(gcsNbdkit.source).To(BeEmpty())
+		})
+
+		It("Info should download objects checked against a checksum", func() {
+			content, err := os.ReadFile(filepath.Join(imageDir, "cirros.raw"))
+			Expect(err).NotTo(HaveOccurred())
+			GinkgoT().Setenv(common.ImporterChecksum, fmt.Sprintf("sha256:%x", sha256.Sum256(content)))
+			sd, err = NewGCSDataSource("gs://Bucket1/cirros.raw", "", "")
+			Expect(err).NotTo(HaveOccurred())
+			sd.gcsReader = io.NopCloser(bytes.NewReader(content))
+			result, err := sd.Info()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(ProcessingPhaseTransferDataFile))
+			Expect(gcsNbdkit.source).To(BeEmpty())
+			result, err = sd.TransferFile(filepath.Join(tmpDir, "file"), false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(ProcessingPhaseResize))
+		})
+
+		It("Info should pass the CAs of a private endpoint to nbdkit", func() {
+			certDir := GinkgoT().TempDir()
+			file, err := os.Open(filepath.Join(imageDir, "cirros.raw"))
+			Expect(err).NotTo(HaveOccurred())
+			sd, err = NewGCSDataSource("https://storage.example.com/Bucket1/cirros.raw", "", certDir)
+			Expect(err).NotTo(HaveOccurred())
+			sd.gcsReader = file
+			result, err := sd.Info()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(ProcessingPhaseConvert))
+			Expect(gcsNbdkit.certDir).To(Equal(certDir))
+		})
+
+		It("Info should pass the curl options to nbdkit", func() {
+			os.Setenv(common.ImporterCurlConnections, "8")
+			defer os.Unsetenv(common.ImporterCurlConnections)
+			file, err := os.Open(filepath.Join(imageDir, "cirros.raw"))
+			Expect(err).NotTo(HaveOccurred())
+			sd, err = NewGCSDataSource("gs://Bucket1/cirros.raw", "", "")
+			Expect(err).NotTo(HaveOccurred())
+			sd.gcsReader = file
+			_, err = sd.Info()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(gcsNbdkit.curlOptions).To(Equal(image.NbdkitCurlOptions{Connections: 8}))
+		})
+
+		It("Info should download objects when a client certificate is required", func() {
+			ca, err := triple.NewCA("gcs.cdi.kubevirt.io")
+			Expect(err).NotTo(HaveOccurred())
+			useTestClientCert(ca)
+			file, err := os.Open(filepath.Join(imageDir, "cirros.raw"))
+			Expect(err).NotTo(HaveOccurred())
+			sd, err = NewGCSDataSource("https://storage.example.com/Bucket1/cirros.raw", "", "")
+			Expect(err).NotTo(HaveOccurred())
+			sd.gcsReader = file
+			result, err := sd.Info()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(ProcessingPhaseTransferDataFile))
+			Expect(gcsNbdkit.source).To(BeEmpty())
+		})
+
+		It("Info should pass the refreshed access token of the default credentials to nbdkit", func() {
+			findDefaultCredentials = func(ctx context.Context, scopes ...string) (*google.Credentials, error) {
+				return &google.Credentials{TokenSource: oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "token"})}, nil
+			}
+			file, err := os.Open(filepath.Join(imageDir, "cirros.raw"))
+			Expect(err).NotTo(HaveOccurred())
+			sd, err = NewGCSDataSource("gs://Bucket1/cirros.raw", "", "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(sd.creds).ToNot(BeNil())
+			sd.gcsReader = file
+			result, err := sd.Info()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(ProcessingPhaseConvert))
+			Expect(gcsNbdkit.secretHeaders).To(BeEmpty())
+			Expect(gcsNbdkit.headerFile).To(Equal(oauth2HeaderFile))
+			header, err := os.ReadFile(oauth2HeaderFile)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(header)).To(Equal("Authorization: Bearer token\n"))
+		})
+	})
+
+	It("NewGCSDataSource should Error, when passed in a key file but no credentials can be found", func() {
+		sd, err = NewGCSDataSource("gs://Bucket1/Object.tmp", "gcs-secret", "")
+		Expect(err).To(HaveOccurred())
+	})
+
 	It("Should Extract Bucket and Object form the GCS URL", func() {
 		bucket, object := extractGcsBucketAndObject("gs://Bucket1/Object.tmp")
 		Expect(bucket).Should(Equal("Bucket1"))
@@ -54,31 +199,31 @@ var _ = Describe("Google Cloud Storage data source", func() {
 	})
 
 	It("NewGCSDataSource should Error, when passed in an invalid endpoint", func() {
-		sd, err = NewGCSDataSource("thisisinvalid#$%#ep", "")
+		sd, err = NewGCSDataSource("thisisinvalid#$%#ep", "", "")
 		Expect(err).To(HaveOccurred())
 	})
 
 	It("NewGCSDataSource should Pass, when passed in an valid https endpoint without authentication", func() {
-		sd, err = NewGCSDataSource("https://storage.cloud.google.com/Bucket1/Object.tmp", "")
+		sd, err = NewGCSDataSource("https://storage.cloud.google.com/Bucket1/Object.tmp", "", "")
 		Expect(err).NotTo(HaveOccurred())
 	})
 
 	It("NewGCSDataSource should Pass, when passed in an valid gs endpoint without authentication", func() {
-		sd, err = NewGCSDataSource("gs://Bucket1/Object.tmp", "")
+		sd, err = NewGCSDataSource("gs://Bucket1/Object.tmp", "", "")
 		Expect(err).NotTo(HaveOccurred())
 	})
 
 	It("NewGCSDataSource should Pass, when passed in an valid https endpoint with authentication", func() {
 		var sampleCredential = filepath.Join(imageDir, "gcs-secret.txt")
 		os.Setenv("GOOGLE_APPLICATION_CREDENTIALS", sampleCredential)
-		sd, err = NewGCSDataSource("https://storage.cloud.google.com/Bucket1/Object.tmp", "gcs-secret")
+		sd, err = NewGCSDataSource("https://storage.cloud.google.com/Bucket1/Object.tmp", "gcs-secret", "")
 		Expect(err).NotTo(HaveOccurred())
 	})
 
 	It("NewGCSDataSource should Pass, when passed in an valid gs endpoint with authentication", func() {
 		var sampleCredential = filepath.Join(imageDir, "gcs-secret.txt")
 		os.Setenv("GOOGLE_APPLICATION_CREDENTIALS", sampleCredential)
-		sd, err = NewGCSDataSource("gs://Bucket1/Object.tmp", "gcs-secret")
+		sd, err = NewGCSDataSource("gs://Bucket1/Object.tmp", "gcs-secret", "")
 		Expect(err).NotTo(HaveOccurred())
 	})
 
@@ -87,7 +232,7 @@ var _ = Describe("Google Cloud Storage data source", func() {
 		Expect(err).NotTo(HaveOccurred())
 		err = file.Close()
 		Expect(err).NotTo(HaveOccurred())
-		sd, err = NewGCSDataSource("gs://Bucket1/content.tar", "")
+		sd, err = NewGCSDataSource("gs://Bucket1/content.tar", "", "")
 		Expect(err).NotTo(HaveOccurred())
 		sd.gcsReader = file
 		result, err := sd.Info()
@@ -98,7 +243,7 @@ var _ = Describe("Google Cloud Storage data source", func() {
 	It("Info should return TransferDataFile, when passed in a valid RAW image using anonymous client and GCS endpoint", func() {
 		file, err := os.Open(filepath.Join(imageDir, "cirros.raw"))
 		Expect(err).NotTo(HaveOccurred())
-		sd, err = NewGCSDataSource("gs://Bucket1/cirros.raw", "")
+		sd, err = NewGCSDataSource("gs://Bucket1/cirros.raw", "", "")
 		Expect(err).NotTo(HaveOccurred())
 		sd.gcsReader = file
 		result, err := sd.Info()
@@ -109,7 +254,7 @@ var _ = Describe("Google Cloud Storage data source", func() {
 	It("Info should return TransferScratch, when passed in a valid QCOW2 image using anonymous client and GCS endpoint", func() {
 		file, err := os.Open(filepath.Join(imageDir, "cirros-qcow2.img"))
 		Expect(err).NotTo(HaveOccurred())
-		sd, err = NewGCSDataSource("gs://Bucket1/cirros-qcow2.img", "")
+		sd, err = NewGCSDataSource("gs://Bucket1/cirros-qcow2.img", "", "")
 		Expect(err).NotTo(HaveOccurred())
 		sd.gcsReader = file
 		result, err := sd.Info()
@@ -124,7 +269,7 @@ var _ = Describe("Google Cloud Storage data source", func() {
 		Expect(err).NotTo(HaveOccurred())
 		err = file.Close()
 		Expect(err).NotTo(HaveOccurred())
-		sd, err = NewGCSDataSource("gs://Bucket1/content.tar", "gcs-secret")
+		sd, err = NewGCSDataSource("gs://Bucket1/content.tar", "gcs-secret", "")
 		Expect(err).NotTo(HaveOccurred())
 		sd.gcsReader = file
 		result, err := sd.Info()
@@ -137,7 +282,7 @@ var _ = Describe("Google Cloud Storage data source", func() {
 		os.Setenv("GOOGLE_APPLICATION_CREDENTIALS", sampleCredential)
 		file, err := os.Open(filepath.Join(imageDir, "cirros.raw"))
 		Expect(err).NotTo(HaveOccurred())
-		sd, err = NewGCSDataSource("gs://Bucket1/cirros.raw", "gcs-secret")
+		sd, err = NewGCSDataSource("gs://Bucket1/cirros.raw", "gcs-secret", "")
 		Expect(err).NotTo(HaveOccurred())
 		sd.gcsReader = file
 		result, err := sd.Info()
@@ -150,7 +295,7 @@ var _ = Describe("Google Cloud Storage data source", func() {
 		os.Setenv("GOOGLE_APPLICATION_CREDENTIALS", sampleCredential)
 		file, err := os.Open(filepath.Join(imageDir, "cirros-qcow2.img"))
 		Expect(err).NotTo(HaveOccurred())
-		sd, err = NewGCSDataSource("gs://Bucket1/cirros-qcow2.img", "gcs-secret")
+		sd, err = NewGCSDataSource("gs://Bucket1/cirros-qcow2.img", "gcs-secret", "")
 		Expect(err).NotTo(HaveOccurred())
 		sd.gcsReader = file
 		result, err := sd.Info()
@@ -163,7 +308,7 @@ var _ = Describe("Google Cloud Storage data source", func() {
 		Expect(err).NotTo(HaveOccurred())
 		err = file.Close()
 		Expect(err).NotTo(HaveOccurred())
-		sd, err = NewGCSDataSource("https://storage.cloud.google.com/Bucket1/content.tar", "")
+		sd, err = NewGCSDataSource("https://storage.cloud.google.com/Bucket1/content.tar", "", "")
 		Expect(err).NotTo(HaveOccurred())
 		sd.gcsReader = file
 		result, err := sd.Info()
@@ -174,7 +319,7 @@ var _ = Describe("Google Cloud Storage data source", func() {
 	It("Info should return TransferDataFile, when passed in a valid RAW image using anonymous client and HTTP(s) endpoint", func() {
 		file, err := os.Open(filepath.Join(imageDir, "cirros.raw"))
 		Expect(err).NotTo(HaveOccurred())
-		sd, err = NewGCSDataSource("https://storage.cloud.google.com/Bucket1/cirros.raw", "")
+		sd, err = NewGCSDataSource("https://storage.cloud.google.com/Bucket1/cirros.raw", "", "")
 		Expect(err).NotTo(HaveOccurred())
 		sd.gcsReader = file
 		result, err := sd.Info()
@@ -185,7 +330,7 @@ var _ = Describe("Google Cloud Storage data source", func() {
 	It("Info should return TransferScratch, when passed in a valid QCOW2 image using anonymous client and HTTP(s) endpoint", func() {
 		file, err := os.Open(filepath.Join(imageDir, "cirros-qcow2.img"))
 		Expect(err).NotTo(HaveOccurred())
-		sd, err = NewGCSDataSource("https://storage.cloud.google.com/Bucket1/cirros-qcow2.img", "")
+		sd, err = NewGCSDataSource("https://storage.cloud.google.com/Bucket1/cirros-qcow2.img", "", "")
 		Expect(err).NotTo(HaveOccurred())
 		sd.gcsReader = file
 		result, err := sd.Info()
@@ -200,7 +345,7 @@ var _ = Describe("Google Cloud Storage data source", func() {
 		Expect(err).NotTo(HaveOccurred())
 		err = file.Close()
 		Expect(err).NotTo(HaveOccurred())
-		sd, err = NewGCSDataSource("https://storage.cloud.google.com/Bucket1/content.tar", "gcs-secret")
+		sd, err = NewGCSDataSource("https://storage.cloud.google.com/Bucket1/content.tar", "gcs-secret", "")
 		Expect(err).NotTo(HaveOccurred())
 		sd.gcsReader = file
 		result, err := sd.Info()
@@ -213,7 +358,7 @@ var _ = Describe("Google Cloud Storage data source", func() {
 		os.Setenv("GOOGLE_APPLICATION_CREDENTIALS", sampleCredential)
 		file, err := os.Open(filepath.Join(imageDir, "cirros.raw"))
 		Expect(err).NotTo(HaveOccurred())
-		sd, err = NewGCSDataSource("https://storage.cloud.google.com/Bucket1/cirros.raw", "gcs-secret")
+		sd, err = NewGCSDataSource("https://storage.cloud.google.com/Bucket1/cirros.raw", "gcs-secret", "")
 		Expect(err).NotTo(HaveOccurred())
 		sd.gcsReader = file
 		result, err := sd.Info()
@@ -226,7 +371,7 @@ var _ = Describe("Google Cloud Storage data source", func() {
 		os.Setenv("GOOGLE_APPLICATION_CREDENTIALS", sampleCredential)
 		file, err := os.Open(filepath.Join(imageDir, "cirros-qcow2.img"))
 		Expect(err).NotTo(HaveOccurred())
-		sd, err = NewGCSDataSource("https://storage.cloud.google.com/Bucket1/cirros-qcow2.img", "gcs-secret")
+		sd, err = NewGCSDataSource("https://storage.cloud.google.com/Bucket1/cirros-qcow2.img", "gcs-secret", "")
 		Expect(err).NotTo(HaveOccurred())
 		sd.gcsReader = file
 		result, err := sd.Info()
@@ -237,7 +382,7 @@ var _ = Describe("Google Cloud Storage data source", func() {
 	It("TransferFile using anonymous client and GCS URL should succeed reading RAW image when writing to valid file", func() {
 		file, err := os.Open(filepath.Join(imageDir, "cirros.raw"))
 		Expect(err).NotTo(HaveOccurred())
-		sd, err = NewGCSDataSource("gs://Bucket1/cirros.raw", "")
+		sd, err = NewGCSDataSource("gs://Bucket1/cirros.raw", "", "")
 		Expect(err).NotTo(HaveOccurred())
 		sd.gcsReader = file
 		result, err := sd.Info()
@@ -251,7 +396,7 @@ var _ = Describe("Google Cloud Storage data source", func() {
 	It("TransferFile using anonymous client and GCS URL should succeed reading QCOW2 image when writing to valid file", func() {
 		file, err := os.Open(filepath.Join(imageDir, "cirros-qcow2.img"))
 		Expect(err).NotTo(HaveOccurred())
-		sd, err = NewGCSDataSource("gs://Bucket1/cirros-qcow2.img", "")
+		sd, err = NewGCSDataSource("gs://Bucket1/cirros-qcow2.img", "", "")
 		Expect(err).NotTo(HaveOccurred())
 		sd.gcsReader = file
 		result, err := sd.Info()
@@ -265,7 +410,7 @@ var _ = Describe("Google Cloud Storage data source", func() {
 	It("TransferFile using anonymous client and GCS should fail reading RAW image on streaming error", func() {
 		file, err := os.Open(filepath.Join(imageDir, "cirros.raw"))
 		Expect(err).NotTo(HaveOccurred())
-		sd, err = NewGCSDataSource("gs://Bucket1/cirros.raw", "")
+		sd, err = NewGCSDataSource("gs://Bucket1/cirros.raw", "", "")
 		Expect(err).NotTo(HaveOccurred())
 		sd.gcsReader = file
 		result, err := sd.Info()
@@ -279,7 +424,7 @@ var _ = Describe("Google Cloud Storage data source", func() {
 	It("TransferFile using anonymous client and GCS should fail reading QCOW2 image on streaming error", func() {
 		file, err := os.Open(filepath.Join(imageDir, "cirros-qcow2.img"))
 		Expect(err).NotTo(HaveOccurred())
-		sd, err = NewGCSDataSource("gs://Bucket1/cirros-qcow2.img", "")
+		sd, err = NewGCSDataSource("gs://Bucket1/cirros-qcow2.img", "", "")
 		Expect(err).NotTo(HaveOccurred())
 		sd.gcsReader = file
 		result, err := sd.Info()
@@ -295,7 +440,7 @@ var _ = Describe("Google Cloud Storage data source", func() {
 		os.Setenv("GOOGLE_APPLICATION_CREDENTIALS", sampleCredential)
 		file, err := os.Open(filepath.Join(imageDir, "cirros.raw"))
 		Expect(err).NotTo(HaveOccurred())
-		sd, err = NewGCSDataSource("gs://Bucket1/cirros.raw", "gcs-secret")
+		sd, err = NewGCSDataSource("gs://Bucket1/cirros.raw", "gcs-secret", "")
 		Expect(err).NotTo(HaveOccurred())
 		sd.gcsReader = file
 		result, err := sd.Info()
@@ -311,7 +456,7 @@ var _ = Describe("Google Cloud Storage data source", func() {
 		os.Setenv("GOOGLE_APPLICATION_CREDENTIALS", sampleCredential)
 		file, err := os.Open(filepath.Join(imageDir, "cirros-qcow2.img"))
 		Expect(err).NotTo(HaveOccurred())
-		sd, err = NewGCSDataSource("gs://Bucket1/cirros-qcow2.img", "gcs-secret")
+		sd, err = NewGCSDataSource("gs://Bucket1/cirros-qcow2.img", "gcs-secret", "")
 		Expect(err).NotTo(HaveOccurred())
 		sd.gcsReader = file
 		result, err := sd.Info()
@@ -327,7 +472,7 @@ var _ = Describe("Google Cloud Storage data source", func() {
 		os.Setenv("GOOGLE_APPLICATION_CREDENTIALS", sampleCredential)
 		file, err := os.Open(filepath.Join(imageDir, "cirros.raw"))
 		Expect(err).NotTo(HaveOccurred())
-		sd, err = NewGCSDataSource("gs://Bucket1/cirros.raw", "gcs-secret")
+		sd, err = NewGCSDataSource("gs://Bucket1/cirros.raw", "gcs-secret", "")
 		Expect(err).NotTo(HaveOccurred())
 		sd.gcsReader = file
 		result, err := sd.Info()
@@ -343,7 +488,7 @@ var _ = Describe("Google Cloud Storage data source", func() {
 		os.Setenv("GOOGLE_APPLICATION_CREDENTIALS", sampleCredential)
 		file, err := os.Open(filepath.Join(imageDir, "cirros-qcow2.img"))
 		Expect(err).NotTo(HaveOccurred())
-		sd, err = NewGCSDataSource("gs://Bucket1/cirros-qcow2.img", "gcs-secret")
+		sd, err = NewGCSDataSource("gs://Bucket1/cirros-qcow2.img", "gcs-secret", "")
 		Expect(err).NotTo(HaveOccurred())
 		sd.gcsReader = file
 		result, err := sd.Info()
@@ -357,7 +502,7 @@ var _ = Describe("Google Cloud Storage data source", func() {
 	It("TransferFile using anonymous client and HTTP(s) URL should succeed reading RAW image when writing to valid file", func() {
 		file, err := os.Open(filepath.Join(imageDir, "cirros.raw"))
 		Expect(err).NotTo(HaveOccurred())
-		sd, err = NewGCSDataSource("https://storage.cloud.google.com/Bucket1/cirros.raw", "")
+		sd, err = NewGCSDataSource("https://storage.cloud.google.com/Bucket1/cirros.raw", "", "")
 		Expect(err).NotTo(HaveOccurred())
 		sd.gcsReader = file
 		result, err := sd.Info()
@@ -371,7 +516,7 @@ var _ = Describe("Google Cloud Storage data source", func() {
 	It("TransferFile using anonymous client and HTTP(s) URL should succeed reading QCOW2 image when writing to valid file", func() {
 		file, err := os.Open(filepath.Join(imageDir, "cirros-qcow2.img"))
 		Expect(err).NotTo(HaveOccurred())
-		sd, err = NewGCSDataSource("https://storage.cloud.google.com/Bucket1/cirros-qcow2.img", "")
+		sd, err = NewGCSDataSource("https://storage.cloud.google.com/Bucket1/cirros-qcow2.img", "", "")
 		Expect(err).NotTo(HaveOccurred())
 		sd.gcsReader = file
 		result, err := sd.Info()
@@ -385,7 +530,7 @@ var _ = Describe("Google Cloud Storage data source", func() {
 	It("TransferFile using anonymous client and HTTP(s) should fail reading RAW image on streaming error", func() {
 		file, err := os.Open(filepath.Join(imageDir, "cirros.raw"))
 		Expect(err).NotTo(HaveOccurred())
-		sd, err = NewGCSDataSource("https://storage.cloud.google.com/Bucket1/cirros.raw", "")
+		sd, err = NewGCSDataSource("https://storage.cloud.google.com/Bucket1/cirros.raw", "", "")
 		Expect(err).NotTo(HaveOccurred())
 		sd.gcsReader = file
 		result, err := sd.Info()
@@ -399,7 +544,7 @@ var _ = Describe("Google Cloud Storage data source", func() {
 	It("TransferFile using anonymous client and HTTP(s) should fail reading QCOW2 image on streaming error", func() {
 		file, err := os.Open(filepath.Join(imageDir, "cirros-qcow2.img"))
 		Expect(err).NotTo(HaveOccurred())
-		sd, err = NewGCSDataSource("https://storage.cloud.google.com/Bucket1/cirros-qcow2.img", "")
+		sd, err = NewGCSDataSource("https://storage.cloud.google.com/Bucket1/cirros-qcow2.img", "", "")
 		Expect(err).NotTo(HaveOccurred())
 		sd.gcsReader = file
 		result, err := sd.Info()
@@ -415,7 +560,7 @@ var _ = Describe("Google Cloud Storage data source", func() {
 		os.Setenv("GOOGLE_APPLICATION_CREDENTIALS", sampleCredential)
 		file, err := os.Open(filepath.Join(imageDir, "cirros.raw"))
 		Expect(err).NotTo(HaveOccurred())
-		sd, err = NewGCSDataSource("https://storage.cloud.google.com/Bucket1/cirros.raw", "gcs-secret")
+		sd, err = NewGCSDataSource("https://storage.cloud.google.com/Bucket1/cirros.raw", "gcs-secret", "")
 		Expect(err).NotTo(HaveOccurred())
 		sd.gcsReader = file
 		result, err := sd.Info()
@@ -431,7 +576,7 @@ var _ = Describe("Google Cloud Storage data source", func() {
 		os.Setenv("GOOGLE_APPLICATION_CREDENTIALS", sampleCredential)
 		file, err := os.Open(filepath.Join(imageDir, "cirros-qcow2.img"))
 		Expect(err).NotTo(HaveOccurred())
-		sd, err = NewGCSDataSource("https://storage.cloud.google.com/Bucket1/cirros-qcow2.img", "gcs-secret")
+		sd, err = NewGCSDataSource("https://storage.cloud.google.com/Bucket1/cirros-qcow2.img", "gcs-secret", "")
 		Expect(err).NotTo(HaveOccurred())
 		sd.gcsReader = file
 		result, err := sd.Info()
@@ -447,7 +592,7 @@ var _ = Describe("Google Cloud Storage data source", func() {
 		os.Setenv("GOOGLE_APPLICATION_CREDENTIALS", sampleCredential)
 		file, err := os.Open(filepath.Join(imageDir, "cirros.raw"))
 		Expect(err).NotTo(HaveOccurred())
-		sd, err = NewGCSDataSource("https://storage.cloud.google.com/Bucket1/cirros.raw", "gcs-secret")
+		sd, err = NewGCSDataSource("https://storage.cloud.google.com/Bucket1/cirros.raw", "gcs-secret", "")
 		Expect(err).NotTo(HaveOccurred())
 		sd.gcsReader = file
 		result, err := sd.Info()
@@ -463,7 +608,7 @@ var _ = Describe("Google Cloud Storage data source", func() {
 		os.Setenv("GOOGLE_APPLICATION_CREDENTIALS", sampleCredential)
 		file, err := os.Open(filepath.Join(imageDir, "cirros-qcow2.img"))
 		Expect(err).NotTo(HaveOccurred())
-		sd, err = NewGCSDataSource("https://storage.cloud.google.com/Bucket1/cirros-qcow2.img", "gcs-secret")
+		sd, err = NewGCSDataSource("https://storage.cloud.google.com/Bucket1/cirros-qcow2.img", "gcs-secret", "")
 		Expect(err).NotTo(HaveOccurred())
 		sd.gcsReader = file
 		result, err := sd.Info()
@@ -476,6 +621,50 @@ var _ = Describe("Google Cloud Storage data source", func() {
 })
 
 // Create Cloud Storage Object Reader pointing to a sample image
+var gcsNbdkit *fakeNbdkit
+
+type fakeNbdkit struct {
+	startErr      error
+	source        string
+	certDir       string
+	secretHeaders []string
+	headerFile    string
+	curlOptions   image.NbdkitCurlOptions
+}
+
+func createFakeGcsNbdkit(nbdkitPidFile, user, password, certDir, socket string, extraHeaders, secretExtraHeaders []string) (image.NbdkitOperation, error) {
+	gcsNbdkit.certDir = certDir
+	gcsNbdkit.secretHeaders = secretExtraHeaders
+	return gcsNbdkit, nil
+}
+
+func createFakeGcsNbdkitWithHeaderFile(nbdkitPidFile, certDir, socket, headerFile string, renewInterval time.Duration, extraHeaders, secretExtraHeaders []string) (image.NbdkitOperation, error) {
+	gcsNbdkit.headerFile = headerFile
+	return createFakeGcsNbdkit(nbdkitPidFile, "", "", certDir, socket, extraHeaders, secretExtraHeaders)
+}
+
+func (f *fakeNbdkit) StartNbdkit(source string) error {
+	if f.startErr != nil {
+		return f.startErr
+	}
+	f.source = source
+	return nil
+}
+
+func (f *fakeNbdkit) KillNbdkit() error {
+	return nil
+}
+
+func (f *fakeNbdkit) AddEnvVariable(v string) {}
+
+func (f *fakeNbdkit) AddFilter(filter image.NbdkitFilter) {}
+
+func (f *fakeNbdkit) SetMaxBandwidth(bytesPerSecond int64) {}
+
+func (f *fakeNbdkit) SetCurlOptions(options image.NbdkitCurlOptions) {
+	f.curlOptions = options
+}
+
 func mockGcsObjectReader(ctx context.Context, client *storage.Client, bucket, object string) (io.ReadCloser, error) {
 	var sampleImage = filepath.Join(imageDir, "cirros.raw")
 	return os.Open(sampleImage)
@@ -0,0 +1,454 @@
+/*
+Copyright 2024 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package importer
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"k8s.io/klog/v2"
+
+	"kubevirt.io/containerized-data-importer/pkg/common"
+)
+
+const (
+	keystoneDefaultDomain = "Default"
+	keystoneTokenHeader   = "X-Subject-Token"
+	glanceTokenHeader     = "X-Auth-Token"
+	glanceImageActive     = "active"
+)
+
+var glanceImageID = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// GlanceDataSource is the data provider for images of the OpenStack Image service. The importer signs
+// in to Keystone, finds the Image service in the catalog of the token and downloads the image data.
+// Sequence of phases:
+// 1a. Info -> TransferDataFile if the image is raw.
+// 1b. Info -> Transfer in all other cases.
+// 2.  Transfer -> Convert
+type GlanceDataSource struct {
+	// Reader of the image data
+	glanceReader io.ReadCloser
+	// total size of the image data, 0 if unknown
+	size uint64
+	// stack of readers
+	readers *FormatReaders
+	// The image file in scratch space.
+	url *url.URL
+}
+
+// glanceCredentials holds the values of the Glance secret, either an application credential or a
+// user and the project to scope the token to
+type glanceCredentials struct {
+	applicationCredentialID     string
+	applicationCredentialSecret string
+	username                    string
+	password                    string
+	userDomainName              string
+	projectName                 string
+	projectDomainName           string
+	regionName                  string
+}
+
+type keystoneCatalog []struct {
+	Type      string `json:"type"`
+	Endpoints []struct {
+		Interface string `json:"interface"`
+		Region    string `json:"region"`
+		RegionID  string `json:"region_id"`
+		URL       string `json:"url"`
+	} `json:"endpoints"`
+}
+
+type glanceImage struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Status    string `json:"status"`
+	Size      int64  `json:"size"`
+	DirectURL string `json:"direct_url"`
+	Locations []struct {
+		URL string `json:"url"`
+	} `json:"locations"`
+}
+
+// NewGlanceDataSource creates a new instance of the GlanceDataSource. endpoint is the url of Keystone, image
+// the name or the UUID of the image, and the Keystone credentials are read from credentialDir.
+func NewGlanceDataSource(endpoint, image, credentialDir, certDir string) (*GlanceDataSource, error) {
+	ep, err := ParseEndpoint(endpoint)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to parse endpoint %q", endpoint)
+	}
+	if image == "" {
+		return nil, errors.New("Glance source has no image")
+	}
+	creds, err := readGlanceCredentials(credentialDir)
+	if err != nil {
+		return nil, err
+	}
+	client, err := createHTTPClient(certDir)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error creating http client for glance")
+	}
+	token, catalog, err := keystoneAuthenticate(client, ep, creds)
+	if err != nil {
+		return nil, err
+	}
+	glanceURL, err := getGlanceEndpoint(catalog, creds.regionName)
+	if err != nil {
+		return nil, err
+	}
+	img, err := findGlanceImage(client, glanceURL, token, image)
+	if err != nil {
+		return nil, err
+	}
+	reader, err := downloadGlanceImage(client, glanceURL, token, img)
+	if err != nil {
+		return nil, err
+	}
+	size := uint64(0)
+	if img.Size > 0 {
+		size = uint64(img.Size)
+	}
+	return &GlanceDataSource{
+		glanceReader: reader,
+		size:         size,
+	}, nil
+}
+
+// Info is called to get initial information about the data.
+func (gd *GlanceDataSource) Info() (ProcessingPhase, error) {
+	var err error
+	gd.readers, err = NewFormatReaders(gd.glanceReader, gd.size)
+	if err != nil {
+		klog.Errorf("Error creating readers: %v", err)
+		return ProcessingPhaseError, err
+	}
+	if !gd.readers.Convert {
+		// Downloading a raw file, we can write that directly to the target.
+		return ProcessingPhaseTransferDataFile, nil
+	}
+	return ProcessingPhaseTransferScratch, nil
+}
+
+// Transfer is called to transfer the data from the source to a temporary location.
+func (gd *GlanceDataSource) Transfer(path string, preallocation bool) (ProcessingPhase, error) {
+	file := filepath.Join(path, tempFile)
+	if err := CleanAll(file); err != nil {
+		return ProcessingPhaseError, err
+	}
+	size, _ := GetAvailableSpace(path)
+	if size <= int64(0) {
+		//Path provided is invalid.
+		return ProcessingPhaseError, ErrInvalidPath
+	}
+	gd.readers.StartProgressUpdate()
+	if _, _, err := StreamDataToFile(gd.readers.TopReader(), file, preallocation); err != nil {
+		return ProcessingPhaseError, err
+	}
+	// If streaming succeeded, then parsing the file into URL will also succeed, no need to check error status
+	gd.url, _ = url.Parse(file)
+	return ProcessingPhaseConvert, nil
+}
+
+// TransferFile is called to transfer the data from the source to the passed in file.
+func (gd *GlanceDataSource) TransferFile(fileName string, preallocation bool) (ProcessingPhase, error) {
+	if err := CleanAll(fileName); err != nil {
+		return ProcessingPhaseError, err
+	}
+	gd.readers.StartProgressUpdate()
+	if _, _, err := StreamDataToFile(gd.readers.TopReader(), fileName, preallocation); err != nil {
+		return ProcessingPhaseError, err
+	}
+	return ProcessingPhaseResize, nil
+}
+
+// GetURL returns the url that the data processor can use when converting the data.
+func (gd *GlanceDataSource) GetURL() *url.URL {
+	return gd.url
+}
+
+// GetTerminationMessage returns data to be serialized and used as the termination message of the importer.
+func (gd *GlanceDataSource) GetTerminationMessage() *common.TerminationMessage {
+	return nil
+}
+
+// Close closes any readers or other open resources.
+func (gd *GlanceDataSource) Close() error {
+	if gd.readers != nil {
+		return gd.readers.Close()
+	}
+	if gd.glanceReader != nil {
+		return gd.glanceReader.Close()
+	}
+	return nil
+}
+
+// readGlanceCredentials reads the Glance secret mounted in credentialDir. An application credential is
+// preferred over a username and password.
+func readGlanceCredentials(credentialDir string) (*glanceCredentials, error) {
+	if credentialDir == "" {
+		return nil, errors.New("Glance sources need a secret holding the Keystone credentials")
+	}
+	var readErr error
+	read := func(key string) string {
+		value, err := os.ReadFile(filepath.Join(credentialDir, key))
+		if err != nil {
+			if !os.IsNotExist(err) && readErr == nil {
+				readErr = errors.Wrapf(err, "unable to read Glance credential %s", key)
+			}
+			return ""
+		}
+		return strings.TrimRight(string(value), "\r\n")
+	}
+	creds := &glanceCredentials{
+		applicationCredentialID:     read(common.KeyGlanceApplicationCredentialID),
+		applicationCredentialSecret: read(common.KeyGlanceApplicationCredentialSecret),
+		username:                    read(common.KeyGlanceUsername),
+		password:                    read(common.KeyGlancePassword),
+		userDomainName:              read(common.KeyGlanceUserDomainName),
+		projectName:                 read(common.KeyGlanceProjectName),
+		projectDomainName:           read(common.KeyGlanceProjectDomainName),
+		regionName:                  read(common.KeyGlanceRegionName),
+	}
+	if readErr != nil {
+		return nil, readErr
+	}
+	if (creds.applicationCredentialID == "" || creds.applicationCredentialSecret == "") && (creds.username == "" || creds.password == "") {
+		return nil, errors.Errorf("Glance secret needs %s and %s, or %s and %s", common.KeyGlanceApplicationCredentialID,
+			common.KeyGlanceApplicationCredentialSecret, common.KeyGlanceUsername, common.KeyGlancePassword)
+	}
+	return creds, nil
+}
+
+// keystoneAuthRequest returns the body of a Keystone v3 token request for creds
+func keystoneAuthRequest(creds *glanceCredentials) map[string]interface{} {
+	if creds.applicationCredentialID != "" && creds.applicationCredentialSecret != "" {
+		klog.V(3).Infoln("Glance Importer: Authentication: application credential")
+		// Application credentials are scoped to their project already
+		return map[string]interface{}{"auth": map[string]interface{}{
+			"identity": map[string]interface{}{
+				"methods": []string{"application_credential"},
+				"application_credential": map[string]string{
+					"id":     creds.applicationCredentialID,
+					"secret": creds.applicationCredentialSecret,
+				},
+			},
+		}}
+	}
+	klog.V(3).Infoln("Glance Importer: Authentication: password")
+	domainOrDefault := func(domain string) string {
+		if domain == "" {
+			return keystoneDefaultDomain
+		}
+		return domain
+	}
+	auth := map[string]interface{}{
+		"identity": map[string]interface{}{
+			"methods": []string{"password"},
+			"password": map[string]interface{}{
+				"user": map[string]interface{}{
+					"name":     creds.username,
+					"password": creds.password,
+					"domain":   map[string]string{"name": domainOrDefault(creds.userDomainName)},
+				},
+			},
+		},
+	}
+	if creds.projectName != "" {
+		auth["scope"] = map[string]interface{}{
+			"project": map[string]interface{}{
+				"name":   creds.projectName,
+				"domain": map[string]string{"name": domainOrDefault(creds.projectDomainName)},
+			},
+		}
+	}
+	return map[string]interface{}{"auth": auth}
+}
+
+// keystoneAuthenticate requests a token from Keystone, it returns the token and the service catalog
+func keystoneAuthenticate(client *http.Client, ep *url.URL, creds *glanceCredentials) (string, keystoneCatalog, error) {
+	tokensURL := *ep
+	tokensURL.Path = strings.TrimSuffix(tokensURL.Path, "/")
+	if !strings.HasSuffix(tokensURL.Path, "/v3") {
+		tokensURL.Path += "/v3"
+	}
+	tokensURL.Path += "/auth/tokens"
+	body, err := json.Marshal(keystoneAuthRequest(creds))
+	if err != nil {
+		return "", nil, errors.Wrap(err, "unable to create Keystone token request")
+	}
+	resp, err := client.Post(tokensURL.String(), "application/json", bytes.NewReader(body))
+	if err != nil {
+		return "", nil, errors.Wrap(err, "Keystone token request errored")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		return "", nil, errors.Errorf("Keystone token request failed: %s", resp.Status)
+	}
+	token := resp.Header.Get(keystoneTokenHeader)
+	if token == "" {
+		return "", nil, errors.New("Keystone did not return a token")
+	}
+	var tokenResp struct {
+		Token struct {
+			Catalog keystoneCatalog `json:"catalog"`
+		} `json:"token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&tokenResp); err != nil {
+		return "", nil, errors.Wrap(err, "unable to parse Keystone token")
+	}
+	return token, tokenResp.Token.Catalog, nil
+}
+
+// getGlanceEndpoint returns the v2 API url of the public Image service endpoint in region, any region if empty
+func getGlanceEndpoint(catalog keystoneCatalog, region string) (string, error) {
+	for _, service := range catalog {
+		if service.Type != "image" {
+			continue
+		}
+		for _, endpoint := range service.Endpoints {
+			if endpoint.Interface != "public" || (region != "" && endpoint.Region != region && endpoint.RegionID != region) {
+				continue
+			}
+			glanceURL := strings.TrimSuffix(endpoint.URL, "/")
+			if !strings.HasSuffix(glanceURL, "/v2") {
+				glanceURL += "/v2"
+			}
+			return glanceURL, nil
+		}
+	}
+	if region != "" {
+		return "", errors.Errorf("Keystone catalog has no public Image service endpoint in region %s", region)
+	}
+	return "", errors.New("Keystone catalog has no public Image service endpoint")
+}
+
+func glanceRequest(client *http.Client, method, url, token string) (*http.Response, error) {
+	req, err := http.NewRequest(method, url, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create Glance request")
+	}
+	req.Header.Set(glanceTokenHeader, token)
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "Glance request errored")
+	}
+	return resp, nil
+}
+
+// findGlanceImage returns the image with the UUID image, or the single image named image
+func findGlanceImage(client *http.Client, glanceURL, token, image string) (*glanceImage, error) {
+	var found *glanceImage
+	if glanceImageID.MatchString(image) {
+		resp, err := glanceRequest(client, http.MethodGet, glanceURL+"/images/"+image, token)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return nil, errors.Errorf("unable to get Glance image %s: %s", image, resp.Status)
+		}
+		found = &glanceImage{}
+		if err := json.NewDecoder(resp.Body).Decode(found); err != nil {
+			return nil, errors.Wrapf(err, "unable to parse Glance image %s", image)
+		}
+	} else {
+		resp, err := glanceRequest(client, http.MethodGet, glanceURL+"/images?name="+url.QueryEscape(image), token)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return nil, errors.Errorf("unable to list Glance images named %s: %s", image, resp.Status)
+		}
+		var list struct {
+			Images []glanceImage `json:"images"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&list); err != nil {
+			return nil, errors.Wrapf(err, "unable to parse Glance images named %s", image)
+		}
+		switch len(list.Images) {
+		case 0:
+			return nil, errors.Errorf("there is no Glance image named %s", image)
+		case 1:
+			found = &list.Images[0]
+		default:
+			return nil, errors.Errorf("there are %d Glance images named %s, use the UUID of the image", len(list.Images), image)
+		}
+	}
+	if found.Status != glanceImageActive {
+		return nil, errors.Errorf("Glance image %s is %s, not %s", image, found.Status, glanceImageActive)
+	}
+	klog.Infof("Importing Glance image %s (%s)", found.Name, found.ID)
+	return found, nil
+}
+
+// downloadGlanceImage returns a reader of the image data. Images whose data Glance does not serve, like
+// images registered with an external location, are downloaded from their http location instead.
+func downloadGlanceImage(client *http.Client, glanceURL, token string, img *glanceImage) (io.ReadCloser, error) {
+	resp, err := glanceRequest(client, http.MethodGet, glanceURL+"/images/"+img.ID+"/file", token)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusOK {
+		return resp.Body, nil
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusForbidden {
+		return nil, errors.Errorf("unable to download Glance image %s: %s", img.ID, resp.Status)
+	}
+	location := getGlanceImageLocation(img)
+	if location == "" {
+		return nil, errors.Errorf("Glance does not serve the data of image %s (%s) and it has no http location", img.ID, resp.Status)
+	}
+	klog.Infof("Glance does not serve the data of image %s (%s), downloading it from its location", img.ID, resp.Status)
+	// The token is for OpenStack services only, it is not sent to the location
+	resp, err = client.Get(location)
+	if err != nil {
+		return nil, errors.Wrap(err, "HTTP request errored")
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, errors.Errorf("unable to download Glance image %s from its location: %s", img.ID, resp.Status)
+	}
+	return resp.Body, nil
+}
+
+// getGlanceImageLocation returns the first http location of img, empty if it has none
+func getGlanceImageLocation(img *glanceImage) string {
+	locations := []string{img.DirectURL}
+	for _, location := range img.Locations {
+		locations = append(locations, location.URL)
+	}
+	for _, location := range locations {
+		if strings.HasPrefix(location, "http://") || strings.HasPrefix(location, "https://") {
+			return location
+		}
+	}
+	return ""
+}
+
+var _ DataSourceInterface = &GlanceDataSource{}
@@ -0,0 +1,209 @@
+package importer
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"kubevirt.io/containerized-data-importer/pkg/common"
+)
+
+const (
+	testGlanceImageID = "4b434528-b2d8-4d3b-8c7d-da5d5e8c7a1f"
+	testGlanceToken   = "gAAAAABtoken"
+)
+
+var _ = Describe("Glance data source", func() {
+	var (
+		ts          *httptest.Server
+		credDir     string
+		tmpDir      string
+		authBody    map[string]interface{}
+		images      []map[string]interface{}
+		serveData   bool
+		sentTokens  []string
+		imageData   = bytes.Repeat([]byte{0xaa}, 1024*1024)
+		writeSecret = func(values map[string]string) {
+			for key, value := range values {
+				Expect(os.WriteFile(filepath.Join(credDir, key), []byte(value), 0600)).To(Succeed())
+			}
+		}
+	)
+
+	BeforeEach(func() {
+		var err error
+		credDir, err = os.MkdirTemp("", "glance-creds")
+		Expect(err).NotTo(HaveOccurred())
+		tmpDir, err = os.MkdirTemp("", "glance")
+		Expect(err).NotTo(HaveOccurred())
+		authBody = nil
+		serveData = true
+		sentTokens = nil
+		images = []map[string]interface{}{{"id": testGlanceImageID, "name": "fedora-39", "status": "active", "size": len(imageData)}}
+
+		mux := http.NewServeMux()
+		mux.HandleFunc("/identity/v3/auth/tokens", func(w http.ResponseWriter, r *http.Request) {
+			defer GinkgoRecover()
+			Expect(r.Method).To(Equal(http.MethodPost))
+			Expect(json.NewDecoder(r.Body).Decode(&authBody)).To(Succeed())
+			w.Header().Set("X-Subject-Token", testGlanceToken)
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprintf(w, `{"token": {"catalog": [
+				{"type": "compute", "endpoints": [{"interface": "public", "region": "RegionOne", "url": "%[1]s/compute"}]},
+				{"type": "image", "endpoints": [
+					{"interface": "internal", "region": "RegionOne", "url": "http://glance.internal:9292"},
+					{"interface": "public", "region": "RegionOne", "url": "%[1]s/image"},
+					{"interface": "public", "region": "RegionTwo", "url": "%[1]s/image-two"}
+				]}
+			]}}`, ts.URL)
+		})
+		mux.HandleFunc("/image/v2/images", func(w http.ResponseWriter, r *http.Request) {
+			sentTokens = append(sentTokens, r.Header.Get("X-Auth-Token"))
+			var named []map[string]interface{}
+			for _, image := range images {
+				if image["name"] == r.URL.Query().Get("name") {
+					named = append(named, image)
+				}
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"images": named})
+		})
+		mux.HandleFunc("/image/v2/images/", func(w http.ResponseWriter, r *http.Request) {
+			sentTokens = append(sentTokens, r.Header.Get("X-Auth-Token"))
+			id, file := strings.CutPrefix(r.URL.Path, "/image/v2/images/")
+			id, file = strings.CutSuffix(id, "/file")
+			for _, image := range images {
+				if image["id"] != id {
+					continue
+				}
+				switch {
+				case !file:
+					_ = json.NewEncoder(w).Encode(image)
+				case serveData:
+					_, _ = w.Write(imageData)
+				default:
+					w.WriteHeader(http.StatusNoContent)
+				}
+				return
+			}
+			http.NotFound(w, r)
+		})
+		mux.HandleFunc("/external/disk.img", func(w http.ResponseWriter, r *http.Request) {
+			defer GinkgoRecover()
+			Expect(r.Header.Get("X-Auth-Token")).To(BeEmpty())
+			_, _ = w.Write(imageData)
+		})
+		ts = httptest.NewServer(mux)
+	})
+
+	AfterEach(func() {
+		ts.Close()
+		os.RemoveAll(credDir)
+		os.RemoveAll(tmpDir)
+	})
+
+	expectImported := func(gd *GlanceDataSource) {
+		defer gd.Close()
+		phase, err := gd.Info()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(phase).To(Equal(ProcessingPhaseTransferDataFile))
+		fileName := filepath.Join(tmpDir, "disk.img")
+		phase, err = gd.TransferFile(fileName, false)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(phase).To(Equal(ProcessingPhaseResize))
+		Expect(os.ReadFile(fileName)).To(Equal(imageData))
+	}
+
+	It("Should import an image found by name with an application credential", func() {
+		writeSecret(map[string]string{
+			common.KeyGlanceApplicationCredentialID:     "app-cred-id",
+			common.KeyGlanceApplicationCredentialSecret: "app-cred-secret\n",
+		})
+		gd, err := NewGlanceDataSource(ts.URL+"/identity/v3", "fedora-39", credDir, "")
+		Expect(err).NotTo(HaveOccurred())
+		expectImported(gd)
+		Expect(authBody).To(HaveKeyWithValue("auth", HaveKeyWithValue("identity", HaveKeyWithValue("application_credential",
+			map[string]interface{}{"id": "app-cred-id", "secret": "app-cred-secret"}))))
+		Expect(authBody["auth"]).ToNot(HaveKey("scope"))
+		Expect(sentTokens).To(HaveEach(testGlanceToken))
+	})
+
+	It("Should import an image found by UUID with a project scoped password", func() {
+		writeSecret(map[string]string{
+			common.KeyGlanceUsername:    "admin",
+			common.KeyGlancePassword:    "secret",
+			common.KeyGlanceProjectName: "migration",
+		})
+		gd, err := NewGlanceDataSource(ts.URL+"/identity", testGlanceImageID, credDir, "")
+		Expect(err).NotTo(HaveOccurred())
+		expectImported(gd)
+		Expect(authBody).To(HaveKeyWithValue("auth", HaveKeyWithValue("scope", map[string]interface{}{
+			"project": map[string]interface{}{"name": "migration", "domain": map[string]interface{}{"name": "Default"}},
+		})))
+	})
+
+	It("Should download the image from its location if Glance does not serve its data", func() {
+		writeSecret(map[string]string{common.KeyGlanceApplicationCredentialID: "id", common.KeyGlanceApplicationCredentialSecret: "secret"})
+		serveData = false
+		images[0]["locations"] = []map[string]string{{"url": "rbd://pool/image"}, {"url": ts.URL + "/external/disk.img"}}
+		gd, err := NewGlanceDataSource(ts.URL+"/identity/v3", "fedora-39", credDir, "")
+		Expect(err).NotTo(HaveOccurred())
+		expectImported(gd)
+	})
+
+	DescribeTable("Should fail to find", func(image string, update func(), errSubstring string) {
+		writeSecret(map[string]string{common.KeyGlanceApplicationCredentialID: "id", common.KeyGlanceApplicationCredentialSecret: "secret"})
+		if update != nil {
+			update()
+		}
+		_, err := NewGlanceDataSource(ts.URL+"/identity/v3", image, credDir, "")
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring(errSubstring))
+	},
+		Entry("a missing image", "centos", nil, "there is no Glance image named centos"),
+		Entry("an ambiguous name", "fedora-39", func() {
+			images = append(images, map[string]interface{}{"id": "8f2b0042-9d4c-4a4e-b0a1-5c4b2f7c0d11", "name": "fedora-39", "status": "active"})
+		}, "there are 2 Glance images named fedora-39"),
+		Entry("an image that is not active", "fedora-39", func() { images[0]["status"] = "queued" }, "is queued"),
+		Entry("an image without data nor location", "fedora-39", func() { serveData = false }, "has no http location"),
+		Entry("a region without Image service", "fedora-39", func() { writeSecret(map[string]string{common.KeyGlanceRegionName: "RegionThree"}) }, "in region RegionThree"),
+	)
+
+	It("Should pick the Image service of the region", func() {
+		writeSecret(map[string]string{common.KeyGlanceApplicationCredentialID: "id", common.KeyGlanceApplicationCredentialSecret: "secret"})
+		var catalog keystoneCatalog
+		Expect(json.Unmarshal([]byte(`[{"type": "image", "endpoints": [
+			{"interface": "public", "region": "RegionOne", "url": "https://one.example.com:9292"},
+			{"interface": "public", "region_id": "RegionTwo", "url": "https://two.example.com:9292/v2/"}
+		]}]`), &catalog)).To(Succeed())
+		Expect(getGlanceEndpoint(catalog, "")).To(Equal("https://one.example.com:9292/v2"))
+		Expect(getGlanceEndpoint(catalog, "RegionTwo")).To(Equal("https://two.example.com:9292/v2"))
+	})
+
+	It("Should fail without credentials", func() {
+		writeSecret(map[string]string{common.KeyGlanceUsername: "admin"})
+		_, err := NewGlanceDataSource(ts.URL+"/identity/v3", "fedora-39", credDir, "")
+		Expect(err).To(HaveOccurred())
+		_, err = NewGlanceDataSource(ts.URL+"/identity/v3", "fedora-39", "", "")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("Should fail if Keystone rejects the credentials", func() {
+		writeSecret(map[string]string{common.KeyGlanceApplicationCredentialID: "id", common.KeyGlanceApplicationCredentialSecret: "secret"})
+		ts.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = io.Copy(io.Discard, r.Body)
+			w.WriteHeader(http.StatusUnauthorized)
+		})
+		_, err := NewGlanceDataSource(ts.URL+"/identity/v3", "fedora-39", credDir, "")
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("401"))
+	})
+})
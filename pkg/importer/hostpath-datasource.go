@@ -0,0 +1,171 @@
+/*
+Copyright 2024 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package importer
+
+import (
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+
+	"k8s.io/klog/v2"
+
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+	"kubevirt.io/containerized-data-importer/pkg/common"
+)
+
+// HostPathDataSource is the data provider for files present on the node the importer runs on,
+// mounted read-only into the importer pod. Images that are not compressed are converted in place.
+// Sequence of phases:
+// 1a. Info -> Convert, for images qemu-img can read from the file
+// 1b. Info -> TransferScratch -> Convert, for compressed images
+// 1c. Info -> TransferDataFile -> Resize, for raw files
+// 1d. Info -> TransferDataDir, for archive content
+type HostPathDataSource struct {
+	// Path of the mounted file
+	filePath string
+	// The open file
+	file *os.File
+	// stack of readers
+	readers *FormatReaders
+	// url of the file qemu-img converts
+	url *url.URL
+	// contentType expected from the file
+	contentType cdiv1.DataVolumeContentType
+}
+
+// NewHostPathDataSource creates a new instance of the HostPathDataSource reading filePath, a regular file or the
+// block device of a mounted PVC.
+func NewHostPathDataSource(filePath string, contentType cdiv1.DataVolumeContentType) (*HostPathDataSource, error) {
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to access %s on the node", filePath)
+	}
+	if !info.Mode().IsRegular() && info.Mode()&os.ModeDevice == 0 {
+		return nil, errors.Errorf("%s is neither a regular file nor a device", filePath)
+	}
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to open %s", filePath)
+	}
+	klog.V(3).Infof("HostPath Importer: importing %s, %d bytes", filePath, info.Size())
+	return &HostPathDataSource{
+		filePath:    filePath,
+		file:        file,
+		contentType: contentType,
+	}, nil
+}
+
+// Info is called to get initial information about the data.
+func (hd *HostPathDataSource) Info() (ProcessingPhase, error) {
+	var err error
+	hd.readers, err = NewFormatReaders(hd.file, uint64(0))
+	if err != nil {
+		klog.Errorf("Error creating readers: %v", err)
+		return ProcessingPhaseError, err
+	}
+	if hd.contentType, err = resolveContentType(hd.contentType, hd.readers.DetectedType); err != nil {
+		return ProcessingPhaseError, err
+	}
+	if hd.contentType == cdiv1.DataVolumeArchive {
+		return ProcessingPhaseTransferDataDir, nil
+	}
+	if !hd.readers.Convert {
+		// Raw files, compressed or not, are written directly to the target.
+		return ProcessingPhaseTransferDataFile, nil
+	}
+	if !hd.readers.Archived {
+		// qemu-img can read the file where it is, no need to copy it to scratch space first
+		hd.url = &url.URL{Path: hd.filePath}
+		return ProcessingPhaseConvert, nil
+	}
+	return ProcessingPhaseTransferScratch, nil
+}
+
+// DetectedContentType returns the type of the data found by its magic bytes
+func (hd *HostPathDataSource) DetectedContentType() string {
+	if hd.readers == nil {
+		return ""
+	}
+	return hd.readers.DetectedType
+}
+
+// ContentType returns the content type the data is imported as
+func (hd *HostPathDataSource) ContentType() cdiv1.DataVolumeContentType {
+	return hd.contentType
+}
+
+// Transfer is called to transfer the data from the source to the passed in path.
+func (hd *HostPathDataSource) Transfer(path string, preallocation bool) (ProcessingPhase, error) {
+	if hd.contentType == cdiv1.DataVolumeArchive {
+		if err := hd.readers.UnArchive(path); err != nil {
+			return ProcessingPhaseError, errors.Wrap(err, "unable to extract files from the node")
+		}
+		hd.url = nil
+		return ProcessingPhaseComplete, nil
+	}
+	file := filepath.Join(path, tempFile)
+	if err := CleanAll(file); err != nil {
+		return ProcessingPhaseError, err
+	}
+	size, err := GetAvailableSpace(path)
+	if err != nil {
+		return ProcessingPhaseError, err
+	}
+	if size <= int64(0) {
+		//Path provided is invalid.
+		return ProcessingPhaseError, ErrInvalidPath
+	}
+	if _, _, err := StreamDataToFile(hd.readers.TopReader(), file, preallocation); err != nil {
+		return ProcessingPhaseError, err
+	}
+	// If we successfully wrote to the file, then the parse will succeed.
+	hd.url, _ = url.Parse(file)
+	return ProcessingPhaseConvert, nil
+}
+
+// TransferFile is called to transfer the data from the source to the passed in file.
+func (hd *HostPathDataSource) TransferFile(fileName string, preallocation bool) (ProcessingPhase, error) {
+	if err := CleanAll(fileName); err != nil {
+		return ProcessingPhaseError, err
+	}
+	if _, _, err := StreamDataToFile(hd.readers.TopReader(), fileName, preallocation); err != nil {
+		return ProcessingPhaseError, err
+	}
+	return ProcessingPhaseResize, nil
+}
+
+// GetURL returns the url that the data processor can use when converting the data.
+func (hd *HostPathDataSource) GetURL() *url.URL {
+	return hd.url
+}
+
+// GetTerminationMessage returns data to be serialized and used as the termination message of the importer.
+func (hd *HostPathDataSource) GetTerminationMessage() *common.TerminationMessage {
+	return nil
+}
+
+// Close closes the file and its readers.
+func (hd *HostPathDataSource) Close() error {
+	if hd.readers != nil {
+		return hd.readers.Close()
+	}
+	return hd.file.Close()
+}
+
+var _ DataSourceInterface = &HostPathDataSource{}
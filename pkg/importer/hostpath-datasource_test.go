@@ -0,0 +1,100 @@
+package importer
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"kubevirt.io/containerized-data-importer/pkg/image"
+	"kubevirt.io/containerized-data-importer/tests/utils"
+)
+
+var _ = Describe("HostPath data source", func() {
+	var (
+		hd     *HostPathDataSource
+		tmpDir string
+	)
+
+	BeforeEach(func() {
+		var err error
+		tmpDir, err = os.MkdirTemp("", "hostpath")
+		Expect(err).NotTo(HaveOccurred())
+		hd = nil
+	})
+
+	AfterEach(func() {
+		if hd != nil {
+			hd.Close()
+		}
+		os.RemoveAll(tmpDir)
+	})
+
+	It("Should convert an uncompressed image where it is", func() {
+		var err error
+		hd, err = NewHostPathDataSource(cirrosFilePath, dvKubevirt)
+		Expect(err).NotTo(HaveOccurred())
+		phase, err := hd.Info()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(phase).To(Equal(ProcessingPhaseConvert))
+		Expect(hd.GetURL().String()).To(Equal(cirrosFilePath))
+	})
+
+	It("Should write a compressed raw file to the target", func() {
+		var err error
+		hd, err = NewHostPathDataSource(tinyCoreXzFilePath, dvKubevirt)
+		Expect(err).NotTo(HaveOccurred())
+		phase, err := hd.Info()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(phase).To(Equal(ProcessingPhaseTransferDataFile))
+		fileName := filepath.Join(tmpDir, "disk.img")
+		phase, err = hd.TransferFile(fileName, false)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(phase).To(Equal(ProcessingPhaseResize))
+		expected, err := os.ReadFile(tinyCoreFilePath)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(os.ReadFile(fileName)).To(Equal(expected))
+	})
+
+	It("Should copy a compressed image to scratch space", func() {
+		compressed, err := os.MkdirTemp("", "hostpath-gz")
+		Expect(err).NotTo(HaveOccurred())
+		defer os.RemoveAll(compressed)
+		cirrosGzFilePath, err := utils.FormatTestData(cirrosFilePath, compressed, image.ExtGz)
+		Expect(err).NotTo(HaveOccurred())
+		hd, err = NewHostPathDataSource(cirrosGzFilePath, dvKubevirt)
+		Expect(err).NotTo(HaveOccurred())
+		phase, err := hd.Info()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(phase).To(Equal(ProcessingPhaseTransferScratch))
+		phase, err = hd.Transfer(tmpDir, false)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(phase).To(Equal(ProcessingPhaseConvert))
+		Expect(hd.GetURL().String()).To(Equal(filepath.Join(tmpDir, tempFile)))
+	})
+
+	It("Should extract archive content", func() {
+		var err error
+		hd, err = NewHostPathDataSource(archiveFilePath, dvArchive)
+		Expect(err).NotTo(HaveOccurred())
+		phase, err := hd.Info()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(phase).To(Equal(ProcessingPhaseTransferDataDir))
+		phase, err = hd.Transfer(tmpDir, false)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(phase).To(Equal(ProcessingPhaseComplete))
+		Expect(filepath.Join(tmpDir, tinyCoreFileName)).To(BeAnExistingFile())
+		Expect(filepath.Join(tmpDir, cirrosFileName)).To(BeAnExistingFile())
+	})
+
+	It("Should fail if the file is not on the node", func() {
+		_, err := NewHostPathDataSource(filepath.Join(tmpDir, "missing.qcow2"), dvKubevirt)
+		Expect(err).To(MatchError(ContainSubstring("unable to access")))
+	})
+
+	It("Should fail on a directory", func() {
+		_, err := NewHostPathDataSource(tmpDir, dvKubevirt)
+		Expect(err).To(MatchError(ContainSubstring("is neither a regular file nor a device")))
+	})
+})
@@ -35,7 +35,9 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
 
+	v1 "k8s.io/api/core/v1"
 	"k8s.io/klog/v2"
 
 	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
@@ -58,7 +60,10 @@ const (
 // 1a. Info -> Convert (In Info phase the format readers are configured), if the source Reader image is not archived, and no custom CA is used, and can be converted by QEMU-IMG (RAW/QCOW2).
 // 1b. Info -> TransferArchive if the content type is archive.
 // 1c. Info -> ValidatePreScratch if image size validation using nbdkit prior to Transfer is possible.
-// 1d. Info -> Transfer in all other cases.
+// 1d. Info -> TransferScratch if the endpoint is an OVA or a VMDK descriptor, the disk is put together in scratch space.
+// 1e. Info -> TransferDataFile if the endpoint is an XVA, the raw disk is written to the target while reading the XVA.
+// 1f. Info -> TransferDataFile if the endpoint is an uncompressed raw image that can be resumed, it is written to a filesystem target.
+// 1g. Info -> Transfer in all other cases.
 // 2.  ValidatePreScratch -> TransferScratch.
 // 3a. Transfer -> Convert if content type is kubevirt
 // 3b. Transfer -> Complete if content type is archive (Transfer is called with the target instead of the scratch space). Non block PVCs only.
@@ -81,11 +86,33 @@ type HTTPDataSource struct {
 	brokenForQemuImg bool
 	// the content length reported by the http server.
 	contentLength uint64
+	// set if the download can be resumed after a restart of the importer
+	resume *httpResume
+	// index of the disk to extract if the endpoint is an OVA, nil otherwise
+	ovaDisk *int
+	// index of the disk to read if the endpoint is an XVA, nil otherwise
+	xvaDisk *int
+	// set if the endpoint is a VMDK descriptor, its extents are then fetched with fetchExtent
+	vmdkDescriptor bool
+	fetchExtent    vmdkExtentFetcher
+	// verifies the download if the checksum or the signature of the endpoint is set, nil otherwise
+	verifier *downloadVerifier
+	// throttles the download if the maximum bandwidth of the import is set, nil otherwise
+	bandwidth *bandwidthLimiter
 
 	n image.NbdkitOperation
 }
 
+// httpResume holds what is needed to continue a download where it stopped
+type httpResume struct {
+	// validator is the ETag, or the Last-Modified date if there is no strong ETag, of the endpoint
+	validator string
+	// fetch only returns the range if the endpoint still matches the validator
+	fetch rangeFetcher
+}
+
 var createNbdkitCurl = image.NewNbdkitCurl
+var createNbdkitCurlWithHeaderFile = image.NewNbdkitCurlWithHeaderFile
 
 // NewHTTPDataSource creates a new instance of the http data provider.
 func NewHTTPDataSource(endpoint, accessKey, secKey, certDir string, contentType cdiv1.DataVolumeContentType) (*HTTPDataSource, error) {
@@ -101,12 +128,49 @@ func NewHTTPDataSource(endpoint, accessKey, secKey, certDir string, contentType
 		return nil, errors.Wrap(err, "Error getting extra headers for HTTP client")
 	}
 
-	httpReader, contentLength, brokenForQemuImg, err := createHTTPReader(ctx, ep, accessKey, secKey, certDir, extraHeaders, secretExtraHeaders, contentType)
+	tokenSource, err := getOAuth2TokenSource(ctx, certDir)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	ovaDisk, err := getOVADisk()
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	xvaDisk, err := getXVADisk()
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	bandwidth, err := getBandwidthLimiter()
 	if err != nil {
 		cancel()
 		return nil, err
 	}
 
+	verifier, err := getDownloadVerifier(ep, func(signatureURL *url.URL) (io.ReadCloser, error) {
+		reader, _, _, _, err := createHTTPReader(ctx, signatureURL, accessKey, secKey, certDir, extraHeaders, secretExtraHeaders, tokenSource, contentType)
+		return reader, err
+	})
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	httpReader, contentLength, brokenForQemuImg, resume, err := createHTTPReader(ctx, ep, accessKey, secKey, certDir, extraHeaders, secretExtraHeaders, tokenSource, contentType)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	if hasClientCertificate() {
+		// nbdkit cannot present the client certificate, the endpoint is downloaded instead
+		brokenForQemuImg = true
+	}
+
 	httpSource := &HTTPDataSource{
 		ctx:              ctx,
 		cancel:           cancel,
@@ -116,12 +180,33 @@ func NewHTTPDataSource(endpoint, accessKey, secKey, certDir string, contentType
 		customCA:         certDir,
 		brokenForQemuImg: brokenForQemuImg,
 		contentLength:    contentLength,
+		resume:           resume,
+		ovaDisk:          ovaDisk,
+		xvaDisk:          xvaDisk,
+		verifier:         verifier,
+		bandwidth:        bandwidth,
+	}
+	httpSource.fetchExtent = func(name string) (io.ReadCloser, error) {
+		extentURL := ep.ResolveReference(&url.URL{Path: name})
+		reader, _, _, _, err := createHTTPReader(ctx, extentURL, accessKey, secKey, certDir, extraHeaders, secretExtraHeaders, tokenSource, contentType)
+		return bandwidth.wrap(reader), err
+	}
+	if tokenSource != nil {
+		if err = startOAuth2HeaderRefresh(ctx, tokenSource, oauth2HeaderFile, oauth2RenewInterval); err == nil {
+			httpSource.n, err = createNbdkitCurlWithHeaderFile(nbdkitPid, certDir, nbdkitSocket, oauth2HeaderFile, oauth2RenewInterval, extraHeaders, secretExtraHeaders)
+		}
+	} else {
+		httpSource.n, err = createNbdkitCurl(nbdkitPid, accessKey, secKey, certDir, nbdkitSocket, extraHeaders, secretExtraHeaders)
 	}
-	httpSource.n, err = createNbdkitCurl(nbdkitPid, accessKey, secKey, certDir, nbdkitSocket, extraHeaders, secretExtraHeaders)
 	if err != nil {
 		cancel()
 		return nil, err
 	}
+	setCurlOptions(httpSource.n)
+	if bandwidth != nil {
+		// qemu-img reads the endpoint through nbdkit when it converts it directly
+		httpSource.n.SetMaxBandwidth(bandwidth.maxBandwidth)
+	}
 	// We know this is a counting reader, so no need to check.
 	countingReader := httpReader.(*util.CountingReader)
 	go httpSource.pollProgress(countingReader, 10*time.Minute, time.Second)
@@ -131,14 +216,37 @@ func NewHTTPDataSource(endpoint, accessKey, secKey, certDir string, contentType
 // Info is called to get initial information about the data.
 func (hs *HTTPDataSource) Info() (ProcessingPhase, error) {
 	var err error
-	hs.readers, err = NewFormatReaders(hs.httpReader, hs.contentLength)
+	hs.readers, err = NewFormatReaders(hs.bandwidth.wrap(hs.verifier.wrap(hs.httpReader)), hs.contentLength)
 	if err != nil {
 		klog.Errorf("Error creating readers: %v", err)
 		return ProcessingPhaseError, err
 	}
+	if hs.contentType, err = resolveContentType(hs.contentType, hs.readers.DetectedType); err != nil {
+		return ProcessingPhaseError, err
+	}
 	if hs.contentType == cdiv1.DataVolumeArchive {
 		return ProcessingPhaseTransferDataDir, nil
 	}
+	if hs.ovaDisk != nil {
+		// qemu-img cannot read the disk inside the OVA, it has to be extracted first
+		hs.url = nil
+		return ProcessingPhaseTransferScratch, nil
+	}
+	if hs.xvaDisk != nil {
+		// The disks of an XVA are raw, the disk is written to the target while the XVA is read
+		hs.url = nil
+		return ProcessingPhaseTransferDataFile, nil
+	}
+	if hs.contentType == cdiv1.DataVolumeKubeVirt && isVMDKDescriptor(hs.readers.buf) {
+		// qemu-img reads the extents from the directory of the descriptor, they have to be fetched first
+		hs.vmdkDescriptor = true
+		hs.url = nil
+		return ProcessingPhaseTransferScratch, nil
+	}
+	if isComparedWithSource() {
+		hs.url = nil
+		return ProcessingPhaseTransferScratch, nil
+	}
 	if pullMethod, _ := util.ParseEnvVar(common.ImporterPullMethod, false); pullMethod == string(cdiv1.RegistryPullNode) {
 		if err := hs.startNbdKit(); err != nil {
 			return ProcessingPhaseError, err
@@ -146,6 +254,10 @@ func (hs *HTTPDataSource) Info() (ProcessingPhase, error) {
 		return ProcessingPhaseConvert, nil
 	}
 	if err := hs.startNbdKit(); err == nil && !hs.brokenForQemuImg {
+		if hs.resumesToTarget() {
+			// Raw images need no conversion, they are written to the target where the download can be resumed
+			return ProcessingPhaseTransferDataFile, nil
+		}
 		// Validate that target volume size is sufficient early.
 		return ProcessingPhaseValidatePreScratch, nil
 	}
@@ -153,13 +265,56 @@ func (hs *HTTPDataSource) Info() (ProcessingPhase, error) {
 	return ProcessingPhaseTransferScratch, nil
 }
 
+// DetectedContentType returns the type of the data found by its magic bytes
+func (hs *HTTPDataSource) DetectedContentType() string {
+	if hs.readers == nil {
+		return ""
+	}
+	return hs.readers.DetectedType
+}
+
+// ContentType returns the content type the data is imported as
+func (hs *HTTPDataSource) ContentType() cdiv1.DataVolumeContentType {
+	return hs.contentType
+}
+
 // Transfer is called to transfer the data from the source to a scratch location.
 func (hs *HTTPDataSource) Transfer(path string, preallocation bool) (ProcessingPhase, error) {
-	if hs.contentType == cdiv1.DataVolumeKubeVirt {
+	if hs.contentType == cdiv1.DataVolumeKubeVirt && hs.ovaDisk != nil {
 		file := filepath.Join(path, tempFile)
 		if err := CleanAll(file); err != nil {
 			return ProcessingPhaseError, err
 		}
+		hs.readers.StartProgressUpdate()
+		if err := extractOVADisk(hs.readers.TopReader(), ovaDiskSelector{index: *hs.ovaDisk}, file, preallocation); err != nil {
+			return ProcessingPhaseError, err
+		}
+		if err := hs.verifier.verify(); err != nil {
+			return ProcessingPhaseError, err
+		}
+		hs.url, _ = url.Parse(file)
+		return ProcessingPhaseConvert, nil
+	} else if hs.contentType == cdiv1.DataVolumeKubeVirt && hs.vmdkDescriptor {
+		file, err := transferVMDKDescriptor(hs.readers.TopReader(), path, hs.fetchExtent, preallocation)
+		if err != nil {
+			return ProcessingPhaseError, err
+		}
+		if err := hs.verifier.verify(); err != nil {
+			return ProcessingPhaseError, err
+		}
+		hs.url, _ = url.Parse(file)
+		return ProcessingPhaseConvert, nil
+	} else if hs.contentType == cdiv1.DataVolumeKubeVirt {
+		file := filepath.Join(path, tempFile)
+		state, resumable := hs.getDownloadState()
+		if resumable {
+			state.Offset = getResumeOffset(file, state)
+		}
+		if state.Offset == 0 {
+			if err := CleanAll(file, downloadStateFile(file)); err != nil {
+				return ProcessingPhaseError, err
+			}
+		}
 		size, err := GetAvailableSpace(path)
 		if err != nil || size <= 0 {
 			return ProcessingPhaseError, ErrInvalidPath
@@ -171,21 +326,35 @@ func (hs *HTTPDataSource) Transfer(path string, preallocation bool) (ProcessingP
 			// is a best effort attempt and should not fail the import.
 			// HTTPs Proxy CA is currently unsupported until nbdkit adds support for the relevant flags
 			// https://gitlab.com/nbdkit/nbdkit/-/merge_requests/87
-			if err = qemuOperations.Validate(hs.url, size); errors.Is(err, image.ErrLargerPVCRequired) {
+			if err = qemuOperations.Validate(hs.url, size, 0, v1.PersistentVolumeFilesystem); errors.Is(err, image.ErrLargerPVCRequired) {
 				return ProcessingPhaseError, err
 			}
 		}
 		hs.readers.StartProgressUpdate()
-		_, _, err = StreamDataToFile(hs.readers.TopReader(), file, preallocation)
+		if resumable {
+			err = hs.transferResumable(file, state, !preallocation)
+		} else {
+			_, _, err = StreamDataToFile(hs.readers.TopReader(), file, preallocation)
+		}
 		if err != nil {
 			return ProcessingPhaseError, err
 		}
+		if err := hs.verifier.verify(); err != nil {
+			// The download is corrupted, it is started over rather than resumed
+			if cleanErr := CleanAll(file, downloadStateFile(file)); cleanErr != nil {
+				klog.Warningf("Unable to remove the corrupted download: %v", cleanErr)
+			}
+			return ProcessingPhaseError, err
+		}
 		// If we successfully wrote to the file, then the parse will succeed.
 		hs.url, _ = url.Parse(file)
 		return ProcessingPhaseConvert, nil
 	} else if hs.contentType == cdiv1.DataVolumeArchive {
-		if err := util.UnArchiveTar(hs.readers.TopReader(), path); err != nil {
-			return ProcessingPhaseError, errors.Wrap(err, "unable to untar files from endpoint")
+		if err := hs.readers.UnArchive(path); err != nil {
+			return ProcessingPhaseError, errors.Wrap(err, "unable to extract files from endpoint")
+		}
+		if err := hs.verifier.verify(); err != nil {
+			return ProcessingPhaseError, err
 		}
 		hs.url = nil
 		return ProcessingPhaseComplete, nil
@@ -195,14 +364,27 @@ func (hs *HTTPDataSource) Transfer(path string, preallocation bool) (ProcessingP
 
 // TransferFile is called to transfer the data from the source to the passed in file.
 func (hs *HTTPDataSource) TransferFile(fileName string, preallocation bool) (ProcessingPhase, error) {
+	if hs.resumesToTarget() {
+		return hs.transferFileResumable(fileName, preallocation)
+	}
 	if err := CleanAll(fileName); err != nil {
 		return ProcessingPhaseError, err
 	}
 	hs.readers.StartProgressUpdate()
-	_, _, err := StreamDataToFile(hs.readers.TopReader(), fileName, preallocation)
+	reader := io.Reader(hs.readers.TopReader())
+	if hs.xvaDisk != nil {
+		var err error
+		if reader, _, err = newXVADiskReader(reader, *hs.xvaDisk); err != nil {
+			return ProcessingPhaseError, err
+		}
+	}
+	_, _, err := StreamDataToFile(reader, fileName, preallocation)
 	if err != nil {
 		return ProcessingPhaseError, err
 	}
+	if err := hs.verifier.verify(); err != nil {
+		return ProcessingPhaseError, err
+	}
 	return ProcessingPhaseResize, nil
 }
 
@@ -211,6 +393,11 @@ func (hs *HTTPDataSource) GetURL() *url.URL {
 	return hs.url
 }
 
+// ObservedSourceDigest returns the digest of the download found by the checksum of the source
+func (hs *HTTPDataSource) ObservedSourceDigest() string {
+	return hs.verifier.observedDigest()
+}
+
 // GetTerminationMessage returns data to be serialized and used as the termination message of the importer.
 func (hs *HTTPDataSource) GetTerminationMessage() *common.TerminationMessage {
 	if pullMethod, _ := util.ParseEnvVar(common.ImporterPullMethod, false); pullMethod != string(cdiv1.RegistryPullNode) {
@@ -243,6 +430,103 @@ func (hs *HTTPDataSource) Close() error {
 	return err
 }
 
+// getDownloadState returns the state a download of the endpoint starts with, and false if it cannot be
+// resumed. Only uncompressed images can be, what is written is then exactly what was downloaded.
+func (hs *HTTPDataSource) getDownloadState() (downloadState, bool) {
+	if hs.resume == nil || hs.readers == nil || hs.readers.Archived {
+		return downloadState{}, false
+	}
+	return downloadState{
+		URL:       hs.endpoint.String(),
+		Validator: hs.resume.validator,
+		Size:      hs.contentLength,
+	}, true
+}
+
+// resumesToTarget returns true if the image is written as is to a filesystem target, its download state then being
+// saved next to it. Only uncompressed raw images can be, and not on block targets which have nowhere to save the state.
+func (hs *HTTPDataSource) resumesToTarget() bool {
+	if hs.contentType != cdiv1.DataVolumeKubeVirt || hs.xvaDisk != nil || hs.readers == nil || hs.readers.DetectedType != detectedRaw {
+		return false
+	}
+	if _, resumable := hs.getDownloadState(); !resumable {
+		return false
+	}
+	_, err := os.Stat(common.WriteBlockPath)
+	return os.IsNotExist(err)
+}
+
+// transferFileResumable writes the raw image to the target file, continuing the download a previous importer started
+func (hs *HTTPDataSource) transferFileResumable(fileName string, preallocation bool) (ProcessingPhase, error) {
+	state, _ := hs.getDownloadState()
+	state.Offset = getResumeOffset(fileName, state)
+	if state.Offset == 0 {
+		if err := CleanAll(fileName, downloadStateFile(fileName)); err != nil {
+			return ProcessingPhaseError, err
+		}
+	}
+	// Validate that target volume size is sufficient early, the resize validates it with the filesystem overhead.
+	if hs.url != nil {
+		if size, err := GetAvailableSpace(filepath.Dir(fileName)); err == nil && size > 0 {
+			if err := qemuOperations.Validate(hs.url, size, 0, v1.PersistentVolumeFilesystem); errors.Is(err, image.ErrLargerPVCRequired) {
+				return ProcessingPhaseError, err
+			}
+		}
+	}
+	hs.readers.StartProgressUpdate()
+	if err := hs.transferResumable(fileName, state, !preallocation); err != nil {
+		return ProcessingPhaseError, err
+	}
+	if err := hs.verifier.verify(); err != nil {
+		// The download is corrupted, it is started over rather than resumed
+		if cleanErr := CleanAll(fileName, downloadStateFile(fileName)); cleanErr != nil {
+			klog.Warningf("Unable to remove the corrupted download: %v", cleanErr)
+		}
+		return ProcessingPhaseError, err
+	}
+	return ProcessingPhaseResize, nil
+}
+
+// transferResumable writes the download to file, continuing at state.Offset if it is set. Blocks of zeros are
+// left as holes of the file when sparse is set.
+func (hs *HTTPDataSource) transferResumable(file string, state downloadState, sparse bool) error {
+	if state.Offset > 0 {
+		if err := hs.seekDownload(state.Offset); err != nil {
+			klog.Warningf("Unable to resume the download at byte %d, starting over: %v", state.Offset, err)
+			state.Offset = 0
+		} else if err := hs.verifier.hashFile(file, int64(len(hs.readers.buf)), state.Offset); err != nil {
+			// The download continues at the offset, its digest cannot be checked without what was written before
+			return err
+		}
+	}
+	return writeResumableFile(hs.readers.TopReader(), file, state, sparse)
+}
+
+// seekDownload makes the readers continue from offset. The readers of an uncompressed image only hold the
+// header they read in front of the download, so that is dropped and the download replaced by the rest
+// of the endpoint.
+func (hs *HTTPDataSource) seekDownload(offset int64) error {
+	if offset < int64(len(hs.readers.buf)) {
+		return errors.Errorf("the header of the image was not downloaded yet")
+	}
+	body, err := hs.resume.fetch(hs.ctx, offset, int64(hs.contentLength)-offset)
+	if err != nil {
+		return err
+	}
+	if _, err := io.CopyN(io.Discard, hs.readers.TopReader(), int64(len(hs.readers.buf))); err != nil {
+		body.Close()
+		return err
+	}
+	countingReader := hs.httpReader.(*util.CountingReader)
+	countingReader.Reader.Close()
+	countingReader.Reader = body
+	if hs.readers.progressReader != nil {
+		hs.readers.progressReader.Current = uint64(offset)
+	}
+	klog.Infof("Resuming the download at byte %d of %d", offset, hs.contentLength)
+	return nil
+}
+
 func createCertPool(certDir string) (*x509.CertPool, error) {
 	// let's get system certs as well
 	certPool, err := x509.SystemCertPool()
@@ -296,13 +580,20 @@ func createHTTPClient(certDir string) (*http.Client, error) {
 		// Don't set timeout here, since that will be an absolute timeout, we need a relative to last progress timeout.
 	}
 
-	if certDir == "" {
+	clientCert, err := loadClientCertificate()
+	if err != nil {
+		return nil, err
+	}
+	if certDir == "" && clientCert == nil {
 		return client, nil
 	}
 
-	certPool, err := createCertPool(certDir)
-	if err != nil {
-		return nil, err
+	var certPool *x509.CertPool
+	if certDir != "" {
+		certPool, err = createCertPool(certDir)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	// the default transport contains Proxy configurations to use environment variables and default timeouts
@@ -311,6 +602,10 @@ func createHTTPClient(certDir string) (*http.Client, error) {
 		RootCAs:    certPool,
 		MinVersion: tls.VersionTLS12,
 	}
+	if clientCert != nil {
+		// presented to sources requiring mutual TLS
+		transport.TLSClientConfig.Certificates = []tls.Certificate{*clientCert}
+	}
 	transport.GetProxyConnectHeader = func(ctx context.Context, proxyURL *url.URL, target string) (http.Header, error) {
 		h := http.Header{}
 		h.Add("User-Agent", defaultUserAgent)
@@ -331,11 +626,15 @@ func addExtraheaders(req *http.Request, extraHeaders []string) {
 	req.Header.Add("User-Agent", defaultUserAgent)
 }
 
-func createHTTPReader(ctx context.Context, ep *url.URL, accessKey, secKey, certDir string, extraHeaders, secretExtraHeaders []string, contentType cdiv1.DataVolumeContentType) (io.ReadCloser, uint64, bool, error) {
+func createHTTPReader(ctx context.Context, ep *url.URL, accessKey, secKey, certDir string, extraHeaders, secretExtraHeaders []string, tokenSource oauth2.TokenSource, contentType cdiv1.DataVolumeContentType) (io.ReadCloser, uint64, bool, *httpResume, error) {
 	var brokenForQemuImg bool
 	client, err := createHTTPClient(certDir)
 	if err != nil {
-		return nil, uint64(0), false, errors.Wrap(err, "Error creating http client")
+		return nil, uint64(0), false, nil, errors.Wrap(err, "Error creating http client")
+	}
+	if tokenSource != nil {
+		// Every request to the endpoint, including ranges, gets a bearer token that did not expire
+		client.Transport = newOAuth2HostTransport(tokenSource, ep.Host, client.Transport)
 	}
 
 	allExtraHeaders := append(extraHeaders, secretExtraHeaders...)
@@ -364,11 +663,11 @@ func createHTTPReader(ctx context.Context, ep *url.URL, accessKey, secKey, certD
 	klog.V(2).Infof("Attempting to get object %q via http client\n", ep.String())
 	resp, err := client.Do(req)
 	if err != nil {
-		return nil, uint64(0), true, errors.Wrap(err, "HTTP request errored")
+		return nil, uint64(0), true, nil, errors.Wrap(err, "HTTP request errored")
 	}
 	if want := http.StatusOK; resp.StatusCode != want {
 		klog.Errorf("http: expected status code %d, got %d", want, resp.StatusCode)
-		return nil, uint64(0), true, errors.Errorf("expected status code %d, got %d. Status: %s", want, resp.StatusCode, resp.Status)
+		return nil, uint64(0), true, nil, newHTTPStatusError(want, resp)
 	}
 
 	if contentType == cdiv1.DataVolumeKubeVirt {
@@ -391,11 +690,63 @@ func createHTTPReader(ctx context.Context, ep *url.URL, accessKey, secKey, certD
 		// The total seems bogus. Let's try the GET Content-Length header
 		total = parseHTTPHeader(resp)
 	}
+	var body io.ReadCloser = resp.Body
+	if sd := getSegmentedDownload(); !brokenForQemuImg && sd.enabled(int64(total)) {
+		fetch := func(ctx context.Context, offset, length int64) (io.ReadCloser, error) {
+			return getHTTPRange(ctx, client, ep, accessKey, secKey, allExtraHeaders, offset, length)
+		}
+		// Servers advertising ranges may still answer them with the whole endpoint, the response is kept then
+		if probeRanges(ctx, fetch) {
+			resp.Body.Close()
+			body = newSegmentedReader(ctx, int64(total), sd, fetch)
+		}
+	}
 	countingReader := &util.CountingReader{
-		Reader:  resp.Body,
+		Reader:  body,
 		Current: 0,
 	}
-	return countingReader, total, brokenForQemuImg, nil
+	var resume *httpResume
+	if validator := getHTTPValidator(resp); !brokenForQemuImg && total > 0 && validator != "" {
+		resumeHeaders := append(allExtraHeaders[:len(allExtraHeaders):len(allExtraHeaders)], "If-Range: "+validator)
+		resume = &httpResume{
+			validator: validator,
+			fetch: func(ctx context.Context, offset, length int64) (io.ReadCloser, error) {
+				return getHTTPRange(ctx, client, ep, accessKey, secKey, resumeHeaders, offset, length)
+			},
+		}
+	}
+	return countingReader, total, brokenForQemuImg, resume, nil
+}
+
+// getHTTPValidator returns what an If-Range request can check the endpoint did not change against
+func getHTTPValidator(resp *http.Response) string {
+	// Weak ETags cannot be used to combine ranges
+	if etag := resp.Header.Get("ETag"); etag != "" && !strings.HasPrefix(etag, "W/") {
+		return etag
+	}
+	return resp.Header.Get("Last-Modified")
+}
+
+// getHTTPRange returns a reader for length bytes of the endpoint, starting at offset
+func getHTTPRange(ctx context.Context, client *http.Client, ep *url.URL, accessKey, secKey string, extraHeaders []string, offset, length int64) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ep.String(), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create HTTP request")
+	}
+	addExtraheaders(req, extraHeaders)
+	if len(accessKey) > 0 && len(secKey) > 0 {
+		req.SetBasicAuth(accessKey, secKey)
+	}
+	req.Header.Set("Range", fmt.Sprintf("bytes=%d-%d", offset, offset+length-1))
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "HTTP request errored")
+	}
+	if want := http.StatusPartialContent; resp.StatusCode != want {
+		resp.Body.Close()
+		return nil, newHTTPStatusError(want, resp)
+	}
+	return resp.Body, nil
 }
 
 func (hs *HTTPDataSource) pollProgress(reader *util.CountingReader, idleTime, pollInterval time.Duration) {
@@ -444,7 +795,7 @@ func getContentLength(client *http.Client, ep *url.URL, accessKey, secKey string
 
 	if want := http.StatusOK; resp.StatusCode != want {
 		klog.Errorf("http: expected status code %d, got %d", want, resp.StatusCode)
-		return uint64(0), errors.Errorf("expected status code %d, got %d. Status: %s", want, resp.StatusCode, resp.Status)
+		return uint64(0), newHTTPStatusError(want, resp)
 	}
 
 	for k, v := range resp.Header {
@@ -474,7 +825,34 @@ func parseHTTPHeader(resp *http.Response) uint64 {
 	return total
 }
 
+// getOVADisk returns the index of the disk to extract if the endpoint is an OVA, nil otherwise
+func getOVADisk() (*int, error) {
+	value, _ := util.ParseEnvVar(common.ImporterOVADisk, false)
+	if value == "" {
+		return nil, nil
+	}
+	diskIndex, err := strconv.Atoi(value)
+	if err != nil || diskIndex < 0 {
+		return nil, errors.Errorf("invalid OVA disk index %q", value)
+	}
+	return &diskIndex, nil
+}
+
+// getXVADisk returns the index of the disk to read if the endpoint is an XVA, nil otherwise
+func getXVADisk() (*int, error) {
+	value, _ := util.ParseEnvVar(common.ImporterXVADisk, false)
+	if value == "" {
+		return nil, nil
+	}
+	diskIndex, err := strconv.Atoi(value)
+	if err != nil || diskIndex < 0 {
+		return nil, errors.Errorf("invalid XVA disk index %q", value)
+	}
+	return &diskIndex, nil
+}
+
 // Check for any extra headers to pass along. Return secret headers separately so callers can suppress logging them.
+
 func getExtraHeaders() ([]string, []string, error) {
 	extraHeaders := getExtraHeadersFromEnvironment()
 	secretExtraHeaders, err := getExtraHeadersFromSecrets()
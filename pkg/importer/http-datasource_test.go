@@ -1,9 +1,12 @@
 package importer
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
 	"crypto/x509"
 	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -12,12 +15,17 @@ import (
 	"path"
 	"path/filepath"
 	"reflect"
+	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"golang.org/x/crypto/openpgp"        //nolint:staticcheck
+	"golang.org/x/crypto/openpgp/packet" //nolint:staticcheck
+
 	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
 	"kubevirt.io/containerized-data-importer/pkg/common"
 	"kubevirt.io/containerized-data-importer/pkg/image"
@@ -101,7 +109,7 @@ var _ = Describe("Http data source", func() {
 	},
 		Entry("return ValidatePreScratch phase when image size can be validated", cirrosFileName, cdiv1.DataVolumeKubeVirt, ProcessingPhaseValidatePreScratch, cirrosData, false, false),
 		Entry("return TransferScratch phase when target server is broken for nbdkit+qemu-img", cirrosFileName, cdiv1.DataVolumeKubeVirt, ProcessingPhaseTransferScratch, cirrosData, false, true),
-		Entry("return TransferTarget with archive content type but not archive endpoint ", cirrosFileName, cdiv1.DataVolumeArchive, ProcessingPhaseTransferDataDir, cirrosData, false, false),
+		Entry("return Error with archive content type but a qcow2 endpoint", cirrosFileName, cdiv1.DataVolumeArchive, ProcessingPhaseError, cirrosData, true, false),
 		Entry("return TransferTarget with archive content type and archive endpoint ", diskimageTarFileName, cdiv1.DataVolumeArchive, ProcessingPhaseTransferDataDir, diskimageArchiveData, false, false),
 	)
 
@@ -184,6 +192,16 @@ var _ = Describe("Http data source", func() {
 		Expect(err).NotTo(HaveOccurred())
 	})
 
+	It("Info should download the source to scratch space when the image is compared with it", func() {
+		GinkgoT().Setenv(common.ImporterVerifyChecksum, "true")
+		dp, err = NewHTTPDataSource(ts.URL+"/"+tinyCoreGz, "", "", "", cdiv1.DataVolumeKubeVirt)
+		Expect(err).NotTo(HaveOccurred())
+		phase, err := dp.Info()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(phase).To(Equal(ProcessingPhaseTransferScratch))
+		Expect(dp.GetURL()).To(BeNil())
+	})
+
 	It("GetTerminationMessage should return nil when pullMethod is not node", func() {
 		Expect(os.Setenv(common.ImporterPullMethod, string(cdiv1.RegistryPullPod))).To(Succeed())
 		DeferCleanup(func() {
@@ -282,7 +300,7 @@ var _ = Describe("Http client", func() {
 
 var _ = Describe("Http reader", func() {
 	It("should fail when passed an invalid cert directory", func() {
-		_, total, _, err := createHTTPReader(context.Background(), nil, "", "", "/invalid", nil, nil, cdiv1.DataVolumeKubeVirt)
+		_, total, _, _, err := createHTTPReader(context.Background(), nil, "", "", "/invalid", nil, nil, nil, cdiv1.DataVolumeKubeVirt)
 		Expect(err).To(HaveOccurred())
 		Expect(uint64(0)).To(Equal(total))
 	})
@@ -299,7 +317,7 @@ var _ = Describe("Http reader", func() {
 		defer ts.Close()
 		ep, err := url.Parse(ts.URL)
 		Expect(err).ToNot(HaveOccurred())
-		r, total, _, err := createHTTPReader(context.Background(), ep, "user", "password", "", nil, nil, cdiv1.DataVolumeKubeVirt)
+		r, total, _, _, err := createHTTPReader(context.Background(), ep, "user", "password", "", nil, nil, nil, cdiv1.DataVolumeKubeVirt)
 		Expect(err).ToNot(HaveOccurred())
 		Expect(uint64(25)).To(Equal(total))
 		err = r.Close()
@@ -322,7 +340,7 @@ var _ = Describe("Http reader", func() {
 		defer ts.Close()
 		ep, err := url.Parse(ts.URL)
 		Expect(err).ToNot(HaveOccurred())
-		r, total, _, err := createHTTPReader(context.Background(), ep, "user", "password", "", nil, nil, cdiv1.DataVolumeKubeVirt)
+		r, total, _, _, err := createHTTPReader(context.Background(), ep, "user", "password", "", nil, nil, nil, cdiv1.DataVolumeKubeVirt)
 		Expect(err).ToNot(HaveOccurred())
 		Expect(uint64(25)).To(Equal(total))
 		err = r.Close()
@@ -344,7 +362,7 @@ var _ = Describe("Http reader", func() {
 		defer ts.Close()
 		ep, err := url.Parse(ts.URL)
 		Expect(err).ToNot(HaveOccurred())
-		r, total, brokenForQemuImg, err := createHTTPReader(context.Background(), ep, "", "", "", nil, nil, cdiv1.DataVolumeKubeVirt)
+		r, total, brokenForQemuImg, _, err := createHTTPReader(context.Background(), ep, "", "", "", nil, nil, nil, cdiv1.DataVolumeKubeVirt)
 		Expect(brokenForQemuImg).To(BeFalse())
 		Expect(err).ToNot(HaveOccurred())
 		Expect(uint64(25)).To(Equal(total))
@@ -365,7 +383,7 @@ var _ = Describe("Http reader", func() {
 		defer ts.Close()
 		ep, err := url.Parse(ts.URL)
 		Expect(err).ToNot(HaveOccurred())
-		r, total, _, err := createHTTPReader(context.Background(), ep, "", "", "", nil, nil, cdiv1.DataVolumeKubeVirt)
+		r, total, _, _, err := createHTTPReader(context.Background(), ep, "", "", "", nil, nil, nil, cdiv1.DataVolumeKubeVirt)
 		Expect(err).ToNot(HaveOccurred())
 		Expect(uint64(0)).To(Equal(total))
 		err = r.Close()
@@ -389,7 +407,7 @@ var _ = Describe("Http reader", func() {
 		defer ts.Close()
 		ep, err := url.Parse(ts.URL)
 		Expect(err).ToNot(HaveOccurred())
-		r, total, brokenForQemuImg, err := createHTTPReader(context.Background(), ep, "", "", "", nil, nil, cdiv1.DataVolumeKubeVirt)
+		r, total, brokenForQemuImg, _, err := createHTTPReader(context.Background(), ep, "", "", "", nil, nil, nil, cdiv1.DataVolumeKubeVirt)
 		Expect(brokenForQemuImg).To(BeTrue())
 		Expect(err).ToNot(HaveOccurred())
 		Expect(uint64(25)).To(Equal(total))
@@ -409,7 +427,7 @@ var _ = Describe("Http reader", func() {
 		defer ts.Close()
 		ep, err := url.Parse(ts.URL)
 		Expect(err).ToNot(HaveOccurred())
-		r, total, brokenForQemuImg, err := createHTTPReader(context.Background(), ep, "", "", "", nil, nil, cdiv1.DataVolumeKubeVirt)
+		r, total, brokenForQemuImg, _, err := createHTTPReader(context.Background(), ep, "", "", "", nil, nil, nil, cdiv1.DataVolumeKubeVirt)
 		Expect(brokenForQemuImg).To(BeTrue())
 		Expect(err).ToNot(HaveOccurred())
 		Expect(uint64(25)).To(Equal(total))
@@ -424,7 +442,7 @@ var _ = Describe("Http reader", func() {
 		defer ts.Close()
 		ep, err := url.Parse(ts.URL)
 		Expect(err).ToNot(HaveOccurred())
-		_, total, _, err := createHTTPReader(context.Background(), ep, "", "", "", nil, nil, cdiv1.DataVolumeKubeVirt)
+		_, total, _, _, err := createHTTPReader(context.Background(), ep, "", "", "", nil, nil, nil, cdiv1.DataVolumeKubeVirt)
 		Expect(err).To(HaveOccurred())
 		Expect(uint64(0)).To(Equal(total))
 		Expect("expected status code 200, got 500. Status: 500 Internal Server Error").To(Equal(err.Error()))
@@ -441,7 +459,7 @@ var _ = Describe("Http reader", func() {
 		defer ts.Close()
 		ep, err := url.Parse(ts.URL)
 		Expect(err).ToNot(HaveOccurred())
-		r, total, _, err := createHTTPReader(context.Background(), ep, "", "", "", []string{"Extra-Header: 123"}, nil, cdiv1.DataVolumeKubeVirt)
+		r, total, _, _, err := createHTTPReader(context.Background(), ep, "", "", "", []string{"Extra-Header: 123"}, nil, nil, cdiv1.DataVolumeKubeVirt)
 		Expect(err).ToNot(HaveOccurred())
 		Expect(uint64(0)).To(Equal(total))
 		err = r.Close()
@@ -449,6 +467,273 @@ var _ = Describe("Http reader", func() {
 	})
 })
 
+var _ = Describe("Http segmented download", func() {
+	var (
+		ts         *httptest.Server
+		ep         *url.URL
+		content    []byte
+		rangeCount int32
+	)
+
+	BeforeEach(func() {
+		content = []byte(strings.Repeat("0123456789abcdef", 3*1024*1024/16+100))
+		rangeCount = 0
+		ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Range") != "" {
+				atomic.AddInt32(&rangeCount, 1)
+			}
+			http.ServeContent(w, r, "disk.img", time.Time{}, bytes.NewReader(content))
+		}))
+		var err error
+		ep, err = url.Parse(ts.URL)
+		Expect(err).ToNot(HaveOccurred())
+		os.Setenv(common.ImporterDownloadPartSize, "1Mi")
+	})
+
+	AfterEach(func() {
+		os.Unsetenv(common.ImporterDownloadParallelism)
+		os.Unsetenv(common.ImporterDownloadPartSize)
+		ts.Close()
+	})
+
+	It("should download the ranges concurrently when parallelism is set", func() {
+		os.Setenv(common.ImporterDownloadParallelism, "3")
+		r, total, brokenForQemuImg, _, err := createHTTPReader(context.Background(), ep, "", "", "", nil, nil, nil, cdiv1.DataVolumeKubeVirt)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(brokenForQemuImg).To(BeFalse())
+		Expect(total).To(BeEquivalentTo(len(content)))
+		defer r.Close()
+		result, err := io.ReadAll(r)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(Equal(content))
+		// The range support is probed before the 4 parts are downloaded
+		Expect(atomic.LoadInt32(&rangeCount)).To(BeEquivalentTo(5))
+	})
+
+	It("should use a single request if the server answers ranges with the whole endpoint", func() {
+		os.Setenv(common.ImporterDownloadParallelism, "3")
+		ts.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Range") != "" {
+				atomic.AddInt32(&rangeCount, 1)
+			}
+			w.Header().Set("Accept-Ranges", "bytes")
+			w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+			_, _ = w.Write(content)
+		})
+		r, _, brokenForQemuImg, _, err := createHTTPReader(context.Background(), ep, "", "", "", nil, nil, nil, cdiv1.DataVolumeKubeVirt)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(brokenForQemuImg).To(BeFalse())
+		defer r.Close()
+		result, err := io.ReadAll(r)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(Equal(content))
+		Expect(atomic.LoadInt32(&rangeCount)).To(BeEquivalentTo(1))
+	})
+
+	It("should use a single request without parallelism", func() {
+		r, _, _, _, err := createHTTPReader(context.Background(), ep, "", "", "", nil, nil, nil, cdiv1.DataVolumeKubeVirt)
+		Expect(err).ToNot(HaveOccurred())
+		defer r.Close()
+		result, err := io.ReadAll(r)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(Equal(content))
+		Expect(atomic.LoadInt32(&rangeCount)).To(BeZero())
+	})
+})
+
+var _ = Describe("Http resumable download", func() {
+	var (
+		ts       *httptest.Server
+		tmpDir   string
+		fileName string
+		content  []byte
+		etag     string
+		ranges   []string
+	)
+
+	savePartialDownload := func(offset int64, validator string) {
+		Expect(os.WriteFile(fileName, content[:offset], 0600)).To(Succeed())
+		state, err := json.Marshal(downloadState{URL: ts.URL + "/disk.img", Validator: validator, Size: uint64(len(content)), Offset: offset})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(os.WriteFile(downloadStateFile(fileName), state, 0600)).To(Succeed())
+	}
+
+	transfer := func() {
+		dp, err := NewHTTPDataSource(ts.URL+"/disk.img", "", "", "", cdiv1.DataVolumeKubeVirt)
+		Expect(err).ToNot(HaveOccurred())
+		defer dp.Close()
+		// Skip the nbdkit validation
+		dp.brokenForQemuImg = true
+		phase, err := dp.Info()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(phase).To(Equal(ProcessingPhaseTransferScratch))
+		phase, err = dp.Transfer(tmpDir, false)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(phase).To(Equal(ProcessingPhaseConvert))
+		result, err := os.ReadFile(fileName)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(Equal(content))
+		_, err = os.Stat(downloadStateFile(fileName))
+		Expect(os.IsNotExist(err)).To(BeTrue())
+	}
+
+	BeforeEach(func() {
+		createNbdkitCurl = image.NewMockNbdkitCurl
+		content = []byte(strings.Repeat("0123456789abcdef", 64*1024))
+		etag = `"v1"`
+		ranges = nil
+		ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodGet && r.Header.Get("Range") != "" {
+				ranges = append(ranges, r.Header.Get("Range")+" "+r.Header.Get("If-Range"))
+			}
+			w.Header().Set("ETag", etag)
+			http.ServeContent(w, r, "disk.img", time.Time{}, bytes.NewReader(content))
+		}))
+		var err error
+		tmpDir, err = os.MkdirTemp("", "scratch")
+		Expect(err).ToNot(HaveOccurred())
+		fileName = filepath.Join(tmpDir, tempFile)
+	})
+
+	AfterEach(func() {
+		ts.Close()
+		os.RemoveAll(tmpDir)
+	})
+
+	It("should continue a partial download of the same endpoint", func() {
+		savePartialDownload(600*1024, etag)
+		transfer()
+		Expect(ranges).To(ConsistOf(`bytes=614400-1048575 "v1"`))
+	})
+
+	It("should start over if the endpoint changed", func() {
+		savePartialDownload(600*1024, `"v0"`)
+		transfer()
+		Expect(ranges).To(BeEmpty())
+	})
+
+	It("should start over if the endpoint changed after the import started", func() {
+		savePartialDownload(600*1024, `"v1"`)
+		// If-Range fails, the server answers the range request with the whole endpoint
+		ts.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("If-Range") != "" {
+				ranges = append(ranges, r.Header.Get("Range"))
+				w.Header().Set("ETag", `"v2"`)
+			} else {
+				w.Header().Set("ETag", etag)
+			}
+			http.ServeContent(w, r, "disk.img", time.Time{}, bytes.NewReader(content))
+		})
+		transfer()
+		Expect(ranges).To(HaveLen(1))
+	})
+
+	It("should not save a state for endpoints without a validator", func() {
+		ts.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.ServeContent(w, r, "disk.img", time.Time{}, bytes.NewReader(content))
+		})
+		savePartialDownload(600*1024, "")
+		transfer()
+		Expect(ranges).To(BeEmpty())
+	})
+
+	It("should verify the checksum of a resumed download", func() {
+		GinkgoT().Setenv(common.ImporterChecksum, fmt.Sprintf("sha256:%x", sha256.Sum256(content)))
+		savePartialDownload(600*1024, etag)
+		transfer()
+		Expect(ranges).To(ConsistOf(`bytes=614400-1048575 "v1"`))
+	})
+
+	It("should verify the signature of a resumed download, fetched next to the endpoint", func() {
+		signer, err := openpgp.NewEntity("signer", "", "signer@example.com", &packet.Config{RSABits: 1024})
+		Expect(err).ToNot(HaveOccurred())
+		key := &bytes.Buffer{}
+		Expect(signer.Serialize(key)).To(Succeed())
+		signatureKeyringDir = GinkgoT().TempDir()
+		DeferCleanup(func() {
+			signatureKeyringDir = common.ImporterSignatureKeyringDir
+		})
+		Expect(os.WriteFile(filepath.Join(signatureKeyringDir, "key"), key.Bytes(), 0600)).To(Succeed())
+		signature := &bytes.Buffer{}
+		Expect(openpgp.DetachSign(signature, signer, bytes.NewReader(content), nil)).To(Succeed())
+		handler := ts.Config.Handler
+		ts.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/disk.img.sig" {
+				_, _ = w.Write(signature.Bytes())
+				return
+			}
+			handler.ServeHTTP(w, r)
+		})
+		GinkgoT().Setenv(common.ImporterSignatureURL, "disk.img.sig")
+		savePartialDownload(600*1024, etag)
+		transfer()
+		Expect(ranges).To(ConsistOf(`bytes=614400-1048575 "v1"`))
+	})
+
+	It("should resume an import of a raw image written straight to the target", func() {
+		origCheckpointSize := downloadCheckpointSize
+		downloadCheckpointSize = 64 * 1024
+		DeferCleanup(func() {
+			downloadCheckpointSize = origCheckpointSize
+		})
+		target := filepath.Join(tmpDir, "disk.img")
+		// The connection of the first import is dropped once 600KiB were sent
+		handler := ts.Config.Handler
+		ts.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodGet || r.Header.Get("Range") != "" {
+				handler.ServeHTTP(w, r)
+				return
+			}
+			w.Header().Set("ETag", etag)
+			w.Header().Set("Accept-Ranges", "bytes")
+			w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+			_, _ = w.Write(content[:600*1024])
+			panic(http.ErrAbortHandler)
+		})
+		rawInfo := fakeInfoOpRetVal{&image.ImgInfo{Format: "raw", VirtualSize: int64(len(content))}, nil}
+		replaceQEMUOperations(NewFakeQEMUOperations(nil, nil, rawInfo, nil, nil, nil), func() {
+			dp, err := NewHTTPDataSource(ts.URL+"/disk.img", "", "", "", cdiv1.DataVolumeKubeVirt)
+			Expect(err).ToNot(HaveOccurred())
+			err = NewDataProcessor(dp, target, tmpDir, tmpDir, "", 0.055, false, "").ProcessData()
+			dp.Close()
+			Expect(err).To(HaveOccurred())
+			state := &downloadState{}
+			saved, err := os.ReadFile(downloadStateFile(target))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(json.Unmarshal(saved, state)).To(Succeed())
+			Expect(state.Offset).To(BeEquivalentTo(576 * 1024))
+
+			// The restarted importer continues from the last checkpoint
+			ts.Config.Handler = handler
+			dp, err = NewHTTPDataSource(ts.URL+"/disk.img", "", "", "", cdiv1.DataVolumeKubeVirt)
+			Expect(err).ToNot(HaveOccurred())
+			defer dp.Close()
+			Expect(NewDataProcessor(dp, target, tmpDir, tmpDir, "", 0.055, false, "").ProcessData()).To(Succeed())
+		})
+		Expect(ranges).To(ConsistOf(`bytes=589824-1048575 "v1"`))
+		result, err := os.ReadFile(target)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(Equal(content))
+		Expect(downloadStateFile(target)).ToNot(BeAnExistingFile())
+	})
+
+	It("should fail and start over if the download does not match its checksum", func() {
+		GinkgoT().Setenv(common.ImporterChecksum, fmt.Sprintf("sha256:%x", sha256.Sum256(content[1:])))
+		savePartialDownload(600*1024, etag)
+		dp, err := NewHTTPDataSource(ts.URL+"/disk.img", "", "", "", cdiv1.DataVolumeKubeVirt)
+		Expect(err).ToNot(HaveOccurred())
+		defer dp.Close()
+		dp.brokenForQemuImg = true
+		_, err = dp.Info()
+		Expect(err).ToNot(HaveOccurred())
+		phase, err := dp.Transfer(tmpDir, false)
+		Expect(err).To(MatchError(ContainSubstring(common.ChecksumMismatchText)))
+		Expect(phase).To(Equal(ProcessingPhaseError))
+		Expect(fileName).ToNot(BeAnExistingFile())
+		Expect(downloadStateFile(fileName)).ToNot(BeAnExistingFile())
+	})
+})
+
 var _ = Describe("http pollprogress", func() {
 	It("Should properly finish with valid reader", func() {
 		By("Creating context for the transfer, we have the ability to cancel it")
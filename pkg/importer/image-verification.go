@@ -0,0 +1,585 @@
+/*
+Copyright 2024 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package importer
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/containers/image/v5/docker"
+	"github.com/containers/image/v5/docker/reference"
+	"github.com/containers/image/v5/manifest"
+	"github.com/containers/image/v5/pkg/blobinfocache"
+	"github.com/containers/image/v5/types"
+	"github.com/opencontainers/go-digest"
+	"github.com/pkg/errors"
+
+	"k8s.io/klog/v2"
+
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+	"kubevirt.io/containerized-data-importer/pkg/common"
+	"kubevirt.io/containerized-data-importer/pkg/util"
+)
+
+const (
+	// The annotations cosign sets on the layers of signatures and attestations
+	cosignSignatureAnnotation   = "dev.cosignproject.cosign/signature"
+	cosignCertificateAnnotation = "dev.sigstore.cosign/certificate"
+	cosignChainAnnotation       = "dev.sigstore.cosign/chain"
+	cosignBundleAnnotation      = "dev.sigstore.cosign/bundle"
+
+	cosignSignatureType = "cosign container image signature"
+	dsseMediaType       = "application/vnd.dsse.envelope.v1+json"
+	inTotoPayloadType   = "application/vnd.in-toto+json"
+
+	// maxCosignLayerSize is the size the signatures and attestations of an image are read up to
+	maxCosignLayerSize = 4 * 1024 * 1024
+)
+
+var (
+	// The extensions of Fulcio certificates holding the OIDC issuer of the identity they are issued to
+	oidcIssuerExtension   = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 57264, 1, 1}
+	oidcIssuerV2Extension = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 57264, 1, 8}
+)
+
+// cosignLayer is a layer of the signature or the attestation image cosign stores next to an image
+type cosignLayer struct {
+	mediaType   string
+	annotations map[string]string
+	data        []byte
+}
+
+// cosignLayerReader reads the layers of the signature image, with a sig suffix, or of the attestation image, with an
+// att suffix, of an image
+type cosignLayerReader func(suffix string) ([]cosignLayer, error)
+
+// getImageVerificationPolicies returns the policies the image must meet, nil if none applies to it
+func getImageVerificationPolicies() ([]cdiv1.ImageVerificationPolicy, error) {
+	value, _ := util.ParseEnvVar(common.ImporterImageVerificationPolicies, false)
+	if value == "" {
+		return nil, nil
+	}
+	policies := []cdiv1.ImageVerificationPolicy{}
+	if err := json.Unmarshal([]byte(value), &policies); err != nil {
+		return nil, errors.Wrap(err, "invalid image verification policies")
+	}
+	return policies, nil
+}
+
+// verifyImage checks the image of src against the ImageVerificationPolicies it must meet, with the cosign signatures
+// and attestations stored next to it in its repository. The policies are checked against the manifest src resolved,
+// which is the one its layers are then read from.
+func verifyImage(ctx context.Context, sys *types.SystemContext, src types.ImageSource) error {
+	policies, err := getImageVerificationPolicies()
+	if err != nil || len(policies) == 0 {
+		return err
+	}
+	named := src.Reference().DockerReference()
+	if named == nil {
+		return newSourceVerificationError(errors.Errorf("%s: only docker images can be verified", common.ImageVerificationFailureText))
+	}
+	imageManifest, _, err := src.GetManifest(ctx, nil)
+	if err != nil {
+		return NewImagePullFailedError(err)
+	}
+	imageDigest, err := manifest.Digest(imageManifest)
+	if err != nil {
+		return err
+	}
+	read := func(suffix string) ([]cosignLayer, error) {
+		return readCosignLayers(ctx, sys, named, imageDigest, suffix)
+	}
+	if err := verifyImagePolicies(policies, imageDigest, read); err != nil {
+		return newSourceVerificationError(errors.Errorf("%s: %s@%s %v", common.ImageVerificationFailureText, named.Name(), imageDigest, err))
+	}
+	return nil
+}
+
+// verifyImagePolicies checks the image with imageDigest meets each of the policies. An image meets a policy if an
+// authority of the policy verifies one of its signatures, and for each attestation of the policy, an authority
+// verifies one of its attestations of that predicate type.
+func verifyImagePolicies(policies []cdiv1.ImageVerificationPolicy, imageDigest digest.Digest, read cosignLayerReader) error {
+	signatures, err := read("sig")
+	if err != nil {
+		return errors.Wrap(err, "unable to read the signatures of the image")
+	}
+	var attestations []cosignLayer
+	for _, policy := range policies {
+		authorities, err := newImageAuthorities(policy.Spec.Authorities)
+		if err != nil {
+			return errors.Wrapf(err, "invalid ImageVerificationPolicy %s", policy.Name)
+		}
+		if err := verifyImageSignatures(signatures, imageDigest, authorities); err != nil {
+			return errors.Wrapf(err, "does not meet the ImageVerificationPolicy %s", policy.Name)
+		}
+		if len(policy.Spec.Attestations) > 0 && attestations == nil {
+			if attestations, err = read("att"); err != nil {
+				return errors.Wrap(err, "unable to read the attestations of the image")
+			}
+		}
+		for _, attestation := range policy.Spec.Attestations {
+			if err := verifyImageAttestations(attestations, imageDigest, attestation.PredicateType, authorities); err != nil {
+				return errors.Wrapf(err, "does not meet the attestation %s of the ImageVerificationPolicy %s", attestation.Name, policy.Name)
+			}
+		}
+		klog.Infof("The image meets the ImageVerificationPolicy %s", policy.Name)
+	}
+	return nil
+}
+
+// readCosignLayers reads the layers of the image cosign tags with the digest of the image and suffix
+func readCosignLayers(ctx context.Context, sys *types.SystemContext, named reference.Named, imageDigest digest.Digest, suffix string) ([]cosignLayer, error) {
+	tagged, err := reference.WithTag(reference.TrimNamed(named), fmt.Sprintf("%s-%s.%s", imageDigest.Algorithm(), imageDigest.Encoded(), suffix))
+	if err != nil {
+		return nil, err
+	}
+	ref, err := docker.NewReference(tagged)
+	if err != nil {
+		return nil, err
+	}
+	src, err := ref.NewImageSource(ctx, sys)
+	if err != nil {
+		return nil, err
+	}
+	defer closeImage(src)
+	manifestBlob, mimeType, err := src.GetManifest(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to read %s", tagged)
+	}
+	m, err := manifest.FromBlob(manifestBlob, mimeType)
+	if err != nil {
+		return nil, err
+	}
+	cache := blobinfocache.DefaultCache(sys)
+	layers := []cosignLayer{}
+	for _, info := range m.LayerInfos() {
+		if info.Size > maxCosignLayerSize {
+			return nil, errors.Errorf("the layer %s of %s is too large", info.Digest, tagged)
+		}
+		reader, _, err := src.GetBlob(ctx, info.BlobInfo, cache)
+		if err != nil {
+			return nil, err
+		}
+		data, err := io.ReadAll(io.LimitReader(reader, maxCosignLayerSize))
+		reader.Close()
+		if err != nil {
+			return nil, err
+		}
+		if info.Digest.Validate() != nil || info.Digest.Algorithm().FromBytes(data) != info.Digest {
+			return nil, errors.Errorf("the layer %s of %s does not match its digest", info.Digest, tagged)
+		}
+		layers = append(layers, cosignLayer{mediaType: info.MediaType, annotations: info.Annotations, data: data})
+	}
+	return layers, nil
+}
+
+func verifyImageSignatures(signatures []cosignLayer, imageDigest digest.Digest, authorities []*imageAuthority) error {
+	if len(signatures) == 0 {
+		return errors.New("the image has no signatures")
+	}
+	failures := []string{}
+	for _, layer := range signatures {
+		payload := struct {
+			Critical struct {
+				Image struct {
+					DockerManifestDigest string `json:"docker-manifest-digest"`
+				} `json:"image"`
+				Type string `json:"type"`
+			} `json:"critical"`
+		}{}
+		if err := json.Unmarshal(layer.data, &payload); err != nil || payload.Critical.Type != cosignSignatureType {
+			failures = append(failures, "a signature is not a cosign signature")
+			continue
+		}
+		if payload.Critical.Image.DockerManifestDigest != imageDigest.String() {
+			failures = append(failures, fmt.Sprintf("a signature is of the image %s", payload.Critical.Image.DockerManifestDigest))
+			continue
+		}
+		signature, err := base64.StdEncoding.DecodeString(layer.annotations[cosignSignatureAnnotation])
+		if err != nil {
+			failures = append(failures, "a signature is not base64 encoded")
+			continue
+		}
+		for _, authority := range authorities {
+			err := authority.verify(layer.data, layer.data, signature, layer.annotations)
+			if err == nil {
+				klog.Infof("The image is signed by the authority %s", authority.name)
+				return nil
+			}
+			failures = append(failures, fmt.Sprintf("authority %s: %v", authority.name, err))
+		}
+	}
+	return errors.Errorf("no authority verifies a signature of the image: %s", strings.Join(failures, "; "))
+}
+
+func verifyImageAttestations(attestations []cosignLayer, imageDigest digest.Digest, predicateType string, authorities []*imageAuthority) error {
+	failures := []string{}
+	for _, layer := range attestations {
+		if layer.mediaType != dsseMediaType {
+			continue
+		}
+		envelope := struct {
+			PayloadType string `json:"payloadType"`
+			Payload     []byte `json:"payload"`
+			Signatures  []struct {
+				Sig []byte `json:"sig"`
+			} `json:"signatures"`
+		}{}
+		if err := json.Unmarshal(layer.data, &envelope); err != nil || envelope.PayloadType != inTotoPayloadType {
+			continue
+		}
+		statement := struct {
+			PredicateType string `json:"predicateType"`
+			Subject       []struct {
+				Digest map[string]string `json:"digest"`
+			} `json:"subject"`
+		}{}
+		if err := json.Unmarshal(envelope.Payload, &statement); err != nil || statement.PredicateType != predicateType {
+			continue
+		}
+		subject := false
+		for _, s := range statement.Subject {
+			subject = subject || s.Digest[imageDigest.Algorithm().String()] == imageDigest.Encoded()
+		}
+		if !subject {
+			failures = append(failures, "an attestation is not about the image")
+			continue
+		}
+		// The envelope is signed with its pre-authentication encoding
+		signed := []byte(fmt.Sprintf("DSSEv1 %d %s %d %s", len(envelope.PayloadType), envelope.PayloadType, len(envelope.Payload), envelope.Payload))
+		for _, signature := range envelope.Signatures {
+			for _, authority := range authorities {
+				err := authority.verify(signed, envelope.Payload, signature.Sig, layer.annotations)
+				if err == nil {
+					klog.Infof("The image has a %s attestation signed by the authority %s", predicateType, authority.name)
+					return nil
+				}
+				failures = append(failures, fmt.Sprintf("authority %s: %v", authority.name, err))
+			}
+		}
+	}
+	if len(failures) == 0 {
+		return errors.Errorf("the image has no %s attestation", predicateType)
+	}
+	return errors.Errorf("no authority verifies a %s attestation of the image: %s", predicateType, strings.Join(failures, "; "))
+}
+
+// imageAuthority verifies signatures with a key, or with the Fulcio certificates of keyless signatures
+type imageAuthority struct {
+	name    string
+	key     crypto.PublicKey
+	keyless *keylessAuthority
+}
+
+type keylessAuthority struct {
+	roots         *x509.CertPool
+	intermediates []*x509.Certificate
+	rekorKey      crypto.PublicKey
+	identities    []keylessIdentity
+}
+
+type keylessIdentity struct {
+	issuer        string
+	subject       string
+	subjectRegExp *regexp.Regexp
+}
+
+func newImageAuthorities(authorities []cdiv1.ImageVerificationAuthority) ([]*imageAuthority, error) {
+	if len(authorities) == 0 {
+		return nil, errors.New("the policy has no authorities")
+	}
+	result := []*imageAuthority{}
+	for _, a := range authorities {
+		authority, err := newImageAuthority(a)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid authority %s", a.Name)
+		}
+		result = append(result, authority)
+	}
+	return result, nil
+}
+
+func newImageAuthority(a cdiv1.ImageVerificationAuthority) (*imageAuthority, error) {
+	authority := &imageAuthority{name: a.Name}
+	var err error
+	switch {
+	case a.Key != nil && a.Keyless == nil:
+		authority.key, err = parsePublicKey(a.Key.Data)
+		return authority, err
+	case a.Keyless != nil && a.Key == nil:
+		keyless := &keylessAuthority{roots: x509.NewCertPool()}
+		certs, err := parseCertificates([]byte(a.Keyless.Roots))
+		if err != nil || len(certs) == 0 {
+			return nil, errors.Errorf("invalid roots: %v", err)
+		}
+		for _, cert := range certs {
+			if isSelfSigned(cert) {
+				keyless.roots.AddCert(cert)
+			} else {
+				keyless.intermediates = append(keyless.intermediates, cert)
+			}
+		}
+		if keyless.rekorKey, err = parsePublicKey(a.Keyless.RekorPublicKey); err != nil {
+			return nil, errors.Wrap(err, "invalid rekor public key")
+		}
+		if len(a.Keyless.Identities) == 0 {
+			return nil, errors.New("no identities")
+		}
+		for _, i := range a.Keyless.Identities {
+			identity := keylessIdentity{issuer: i.Issuer, subject: i.Subject}
+			if i.SubjectRegExp != "" {
+				if identity.subjectRegExp, err = regexp.Compile(i.SubjectRegExp); err != nil {
+					return nil, errors.Wrap(err, "invalid subject regular expression")
+				}
+			}
+			keyless.identities = append(keyless.identities, identity)
+		}
+		authority.keyless = keyless
+		return authority, nil
+	}
+	return nil, errors.New("exactly one of key and keyless must be set")
+}
+
+// verify checks signature is the signature of signed by the authority. payload is what the Rekor entry of a keyless
+// signature hashes, the payload of the signature or of the attestation.
+func (a *imageAuthority) verify(signed, payload, signature []byte, annotations map[string]string) error {
+	if a.keyless == nil {
+		return verifySignature(a.key, signed, signature)
+	}
+	certs, err := parseCertificates([]byte(annotations[cosignCertificateAnnotation]))
+	if err != nil || len(certs) == 0 {
+		return errors.New("the signature has no certificate")
+	}
+	cert := certs[0]
+	integratedTime, err := a.keyless.verifyBundle(annotations[cosignBundleAnnotation], payload, cert)
+	if err != nil {
+		return err
+	}
+	intermediates := x509.NewCertPool()
+	for _, c := range a.keyless.intermediates {
+		intermediates.AddCert(c)
+	}
+	// The chain of the signature can not add roots
+	chain, _ := parseCertificates([]byte(annotations[cosignChainAnnotation]))
+	for _, c := range chain {
+		if !isSelfSigned(c) {
+			intermediates.AddCert(c)
+		}
+	}
+	if _, err := cert.Verify(x509.VerifyOptions{
+		Roots:         a.keyless.roots,
+		Intermediates: intermediates,
+		CurrentTime:   integratedTime,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+	}); err != nil {
+		return errors.Wrap(err, "the certificate of the signature is not valid when it was logged")
+	}
+	if err := a.keyless.verifyIdentity(cert); err != nil {
+		return err
+	}
+	return verifySignature(cert.PublicKey, signed, signature)
+}
+
+func (k *keylessAuthority) verifyIdentity(cert *x509.Certificate) error {
+	issuer := ""
+	for _, ext := range cert.Extensions {
+		switch {
+		case ext.Id.Equal(oidcIssuerV2Extension):
+			_, _ = asn1.Unmarshal(ext.Value, &issuer)
+		case ext.Id.Equal(oidcIssuerExtension) && issuer == "":
+			issuer = string(ext.Value)
+		}
+	}
+	subjects := append([]string{}, cert.EmailAddresses...)
+	for _, uri := range cert.URIs {
+		subjects = append(subjects, uri.String())
+	}
+	for _, identity := range k.identities {
+		if identity.issuer != issuer {
+			continue
+		}
+		for _, subject := range subjects {
+			if subject == identity.subject || (identity.subjectRegExp != nil && identity.subjectRegExp.MatchString(subject)) {
+				return nil
+			}
+		}
+	}
+	return errors.Errorf("the certificate is issued to %s by %s, which is not an identity of the authority", strings.Join(subjects, ", "), issuer)
+}
+
+// verifyBundle checks the Rekor bundle of a keyless signature is signed by the log, and records the payload signed
+// with cert. It returns the time the log recorded the signature.
+func (k *keylessAuthority) verifyBundle(annotation string, payload []byte, cert *x509.Certificate) (time.Time, error) {
+	if annotation == "" {
+		return time.Time{}, errors.New("the signature is not recorded in a rekor transparency log")
+	}
+	bundle := struct {
+		SignedEntryTimestamp []byte `json:"SignedEntryTimestamp"`
+		Payload              struct {
+			Body           string `json:"body"`
+			IntegratedTime int64  `json:"integratedTime"`
+			LogIndex       int64  `json:"logIndex"`
+			LogID          string `json:"logID"`
+		} `json:"Payload"`
+	}{}
+	if err := json.Unmarshal([]byte(annotation), &bundle); err != nil {
+		return time.Time{}, errors.Wrap(err, "invalid rekor bundle")
+	}
+	// The log signs the canonical json of the entry, its keys sorted
+	entry, err := json.Marshal(struct {
+		Body           string `json:"body"`
+		IntegratedTime int64  `json:"integratedTime"`
+		LogID          string `json:"logID"`
+		LogIndex       int64  `json:"logIndex"`
+	}{bundle.Payload.Body, bundle.Payload.IntegratedTime, bundle.Payload.LogID, bundle.Payload.LogIndex})
+	if err != nil {
+		return time.Time{}, err
+	}
+	if err := verifySignature(k.rekorKey, entry, bundle.SignedEntryTimestamp); err != nil {
+		return time.Time{}, errors.Wrap(err, "the rekor bundle is not signed by the log")
+	}
+	body, err := base64.StdEncoding.DecodeString(bundle.Payload.Body)
+	if err != nil {
+		return time.Time{}, errors.Wrap(err, "invalid rekor entry")
+	}
+	if err := verifyRekorEntry(body, payload, cert); err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(bundle.Payload.IntegratedTime, 0), nil
+}
+
+// verifyRekorEntry checks the hashedrekord entry of a signature, or the intoto entry of an attestation, records the
+// payload signed with cert
+func verifyRekorEntry(body, payload []byte, cert *x509.Certificate) error {
+	type hash struct {
+		Algorithm string `json:"algorithm"`
+		Value     string `json:"value"`
+	}
+	entry := struct {
+		Kind string `json:"kind"`
+		Spec struct {
+			Data struct {
+				Hash hash `json:"hash"`
+			} `json:"data"`
+			Signature struct {
+				PublicKey struct {
+					Content []byte `json:"content"`
+				} `json:"publicKey"`
+			} `json:"signature"`
+			Content struct {
+				PayloadHash hash `json:"payloadHash"`
+				Envelope    struct {
+					Signatures []struct {
+						PublicKey []byte `json:"publicKey"`
+					} `json:"signatures"`
+				} `json:"envelope"`
+			} `json:"content"`
+		} `json:"spec"`
+	}{}
+	if err := json.Unmarshal(body, &entry); err != nil {
+		return errors.Wrap(err, "invalid rekor entry")
+	}
+	var recorded hash
+	var keys [][]byte
+	switch entry.Kind {
+	case "hashedrekord":
+		recorded = entry.Spec.Data.Hash
+		keys = append(keys, entry.Spec.Signature.PublicKey.Content)
+	case "intoto":
+		recorded = entry.Spec.Content.PayloadHash
+		for _, s := range entry.Spec.Content.Envelope.Signatures {
+			keys = append(keys, s.PublicKey)
+		}
+	default:
+		return errors.Errorf("unsupported rekor entry kind %q", entry.Kind)
+	}
+	sum := sha256.Sum256(payload)
+	if recorded.Algorithm != "sha256" || recorded.Value != hex.EncodeToString(sum[:]) {
+		return errors.New("the rekor entry does not record the signature")
+	}
+	for _, key := range keys {
+		if certs, _ := parseCertificates(key); len(certs) > 0 && certs[0].Equal(cert) {
+			return nil
+		}
+	}
+	return errors.New("the rekor entry does not record the certificate of the signature")
+}
+
+func verifySignature(key crypto.PublicKey, signed, signature []byte) error {
+	sum := sha256.Sum256(signed)
+	switch k := key.(type) {
+	case *ecdsa.PublicKey:
+		if !ecdsa.VerifyASN1(k, sum[:], signature) {
+			return errors.New("invalid ECDSA signature")
+		}
+	case *rsa.PublicKey:
+		if err := rsa.VerifyPKCS1v15(k, crypto.SHA256, sum[:], signature); err != nil {
+			return errors.Wrap(err, "invalid RSA signature")
+		}
+	case ed25519.PublicKey:
+		if !ed25519.Verify(k, signed, signature) {
+			return errors.New("invalid Ed25519 signature")
+		}
+	default:
+		return errors.Errorf("unsupported key type %T", key)
+	}
+	return nil
+}
+
+func parsePublicKey(data string) (crypto.PublicKey, error) {
+	block, _ := pem.Decode([]byte(data))
+	if block == nil {
+		return nil, errors.New("the key is not PEM encoded")
+	}
+	return x509.ParsePKIXPublicKey(block.Bytes)
+}
+
+func parseCertificates(data []byte) ([]*x509.Certificate, error) {
+	certs := []*x509.Certificate{}
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			return certs, nil
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		certs = append(certs, cert)
+	}
+}
+
+func isSelfSigned(cert *x509.Certificate) bool {
+	return bytes.Equal(cert.RawIssuer, cert.RawSubject) && cert.CheckSignatureFrom(cert) == nil
+}
@@ -0,0 +1,326 @@
+package importer
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/opencontainers/go-digest"
+	"github.com/pkg/errors"
+
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+	"kubevirt.io/containerized-data-importer/pkg/common"
+)
+
+var _ = Describe("Image verification", func() {
+	imageDigest := digest.FromString("image manifest")
+	var signer, rekor *ecdsa.PrivateKey
+	var ca *x509.Certificate
+	var caKey *ecdsa.PrivateKey
+	var layers map[string][]cosignLayer
+
+	newKey := func() *ecdsa.PrivateKey {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		Expect(err).NotTo(HaveOccurred())
+		return key
+	}
+
+	publicKeyPEM := func(key *ecdsa.PrivateKey) string {
+		der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+		Expect(err).NotTo(HaveOccurred())
+		return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+	}
+
+	certPEM := func(cert *x509.Certificate) []byte {
+		return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})
+	}
+
+	newCA := func() (*x509.Certificate, *ecdsa.PrivateKey) {
+		key := newKey()
+		template := &x509.Certificate{
+			SerialNumber:          big.NewInt(1),
+			Subject:               pkix.Name{CommonName: "sigstore"},
+			NotBefore:             time.Now().Add(-time.Hour),
+			NotAfter:              time.Now().Add(time.Hour),
+			IsCA:                  true,
+			BasicConstraintsValid: true,
+			KeyUsage:              x509.KeyUsageCertSign,
+		}
+		der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+		Expect(err).NotTo(HaveOccurred())
+		cert, err := x509.ParseCertificate(der)
+		Expect(err).NotTo(HaveOccurred())
+		return cert, key
+	}
+
+	// issue returns a short lived Fulcio like certificate of signer for email, issued by issuer
+	issue := func(parent *x509.Certificate, parentKey *ecdsa.PrivateKey, email, issuer string) *x509.Certificate {
+		value, err := asn1.MarshalWithParams(issuer, "utf8")
+		Expect(err).NotTo(HaveOccurred())
+		template := &x509.Certificate{
+			SerialNumber:    big.NewInt(2),
+			NotBefore:       time.Now().Add(-time.Minute),
+			NotAfter:        time.Now().Add(10 * time.Minute),
+			KeyUsage:        x509.KeyUsageDigitalSignature,
+			ExtKeyUsage:     []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+			EmailAddresses:  []string{email},
+			ExtraExtensions: []pkix.Extension{{Id: oidcIssuerV2Extension, Value: value}},
+		}
+		der, err := x509.CreateCertificate(rand.Reader, template, parent, &signer.PublicKey, parentKey)
+		Expect(err).NotTo(HaveOccurred())
+		cert, err := x509.ParseCertificate(der)
+		Expect(err).NotTo(HaveOccurred())
+		return cert
+	}
+
+	sign := func(key *ecdsa.PrivateKey, data []byte) []byte {
+		sum := sha256.Sum256(data)
+		signature, err := ecdsa.SignASN1(rand.Reader, key, sum[:])
+		Expect(err).NotTo(HaveOccurred())
+		return signature
+	}
+
+	signaturePayload := func(d digest.Digest) []byte {
+		return []byte(fmt.Sprintf(`{"critical":{"identity":{"docker-reference":"registry.example.com/disks/fedora"},"image":{"docker-manifest-digest":%q},"type":%q},"optional":null}`, d, cosignSignatureType))
+	}
+
+	signatureLayer := func(payload, signature []byte) cosignLayer {
+		return cosignLayer{
+			mediaType:   "application/vnd.dev.cosign.simplesigning.v1+json",
+			annotations: map[string]string{cosignSignatureAnnotation: base64.StdEncoding.EncodeToString(signature)},
+			data:        payload,
+		}
+	}
+
+	attestationLayer := func(predicateType string, d digest.Digest) cosignLayer {
+		statement := []byte(fmt.Sprintf(`{"_type":"https://in-toto.io/Statement/v0.1","predicateType":%q,"subject":[{"name":"registry.example.com/disks/fedora","digest":{"sha256":%q}}],"predicate":{}}`, predicateType, d.Encoded()))
+		signed := []byte(fmt.Sprintf("DSSEv1 %d %s %d %s", len(inTotoPayloadType), inTotoPayloadType, len(statement), statement))
+		envelope, err := json.Marshal(map[string]interface{}{
+			"payloadType": inTotoPayloadType,
+			"payload":     statement,
+			"signatures":  []map[string][]byte{{"sig": sign(signer, signed)}},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		return cosignLayer{mediaType: dsseMediaType, annotations: map[string]string{}, data: envelope}
+	}
+
+	// keylessLayer adds the certificate of the signature and its rekor bundle, signed by the log with logKey
+	keylessLayer := func(layer cosignLayer, cert *x509.Certificate, logKey *ecdsa.PrivateKey) cosignLayer {
+		sum := sha256.Sum256(layer.data)
+		body, err := json.Marshal(map[string]interface{}{
+			"apiVersion": "0.0.1",
+			"kind":       "hashedrekord",
+			"spec": map[string]interface{}{
+				"data":      map[string]interface{}{"hash": map[string]string{"algorithm": "sha256", "value": hex.EncodeToString(sum[:])}},
+				"signature": map[string]interface{}{"content": layer.annotations[cosignSignatureAnnotation], "publicKey": map[string][]byte{"content": certPEM(cert)}},
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		encoded := base64.StdEncoding.EncodeToString(body)
+		integratedTime := time.Now().Unix()
+		entry := fmt.Sprintf(`{"body":%q,"integratedTime":%d,"logID":"c0d23d6ad406973f9559f3ba2d1ca01f84147d8ffc5b8445c224f98b9591801d","logIndex":42}`, encoded, integratedTime)
+		bundle, err := json.Marshal(map[string]interface{}{
+			"SignedEntryTimestamp": sign(logKey, []byte(entry)),
+			"Payload": map[string]interface{}{
+				"body":           encoded,
+				"integratedTime": integratedTime,
+				"logIndex":       42,
+				"logID":          "c0d23d6ad406973f9559f3ba2d1ca01f84147d8ffc5b8445c224f98b9591801d",
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		layer.annotations[cosignCertificateAnnotation] = string(certPEM(cert))
+		layer.annotations[cosignBundleAnnotation] = string(bundle)
+		return layer
+	}
+
+	keyPolicy := func(name string, key *ecdsa.PrivateKey, attestations ...cdiv1.ImageVerificationAttestation) cdiv1.ImageVerificationPolicy {
+		policy := cdiv1.ImageVerificationPolicy{Spec: cdiv1.ImageVerificationPolicySpec{
+			Images:       []string{"registry.example.com/disks/*"},
+			Authorities:  []cdiv1.ImageVerificationAuthority{{Name: "key", Key: &cdiv1.ImageVerificationKey{Data: publicKeyPEM(key)}}},
+			Attestations: attestations,
+		}}
+		policy.Name = name
+		return policy
+	}
+
+	keylessPolicy := func(identity cdiv1.ImageVerificationIdentity) cdiv1.ImageVerificationPolicy {
+		policy := cdiv1.ImageVerificationPolicy{Spec: cdiv1.ImageVerificationPolicySpec{
+			Images: []string{"registry.example.com/disks/*"},
+			Authorities: []cdiv1.ImageVerificationAuthority{{Name: "sigstore", Keyless: &cdiv1.ImageVerificationKeyless{
+				Roots:          string(certPEM(ca)),
+				RekorPublicKey: publicKeyPEM(rekor),
+				Identities:     []cdiv1.ImageVerificationIdentity{identity},
+			}}},
+		}}
+		policy.Name = "keyless"
+		return policy
+	}
+
+	read := func(suffix string) ([]cosignLayer, error) {
+		result, ok := layers[suffix]
+		if !ok {
+			return nil, errors.New("manifest unknown")
+		}
+		return result, nil
+	}
+
+	BeforeEach(func() {
+		signer = newKey()
+		rekor = newKey()
+		ca, caKey = newCA()
+		layers = map[string][]cosignLayer{}
+	})
+
+	It("Should not verify images without policies", func() {
+		policies, err := getImageVerificationPolicies()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(policies).To(BeNil())
+	})
+
+	It("Should read the policies of the image", func() {
+		GinkgoT().Setenv(common.ImporterImageVerificationPolicies, `[{"metadata":{"name":"disks"},"spec":{"images":["registry.example.com/disks/*"],"authorities":[{"name":"key","key":{"data":"key"}}]}}]`)
+		policies, err := getImageVerificationPolicies()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(policies).To(HaveLen(1))
+		Expect(policies[0].Name).To(Equal("disks"))
+		Expect(policies[0].Spec.Authorities[0].Key.Data).To(Equal("key"))
+	})
+
+	It("Should verify the signature of a key", func() {
+		payload := signaturePayload(imageDigest)
+		layers["sig"] = []cosignLayer{signatureLayer(payload, sign(newKey(), payload)), signatureLayer(payload, sign(signer, payload))}
+		Expect(verifyImagePolicies([]cdiv1.ImageVerificationPolicy{keyPolicy("disks", signer)}, imageDigest, read)).To(Succeed())
+	})
+
+	It("Should fail if the image has no signatures", func() {
+		layers["sig"] = []cosignLayer{}
+		err := verifyImagePolicies([]cdiv1.ImageVerificationPolicy{keyPolicy("disks", signer)}, imageDigest, read)
+		Expect(err).To(MatchError("does not meet the ImageVerificationPolicy disks: the image has no signatures"))
+	})
+
+	It("Should fail if the signatures of the image can not be read", func() {
+		err := verifyImagePolicies([]cdiv1.ImageVerificationPolicy{keyPolicy("disks", signer)}, imageDigest, read)
+		Expect(err).To(MatchError("unable to read the signatures of the image: manifest unknown"))
+	})
+
+	It("Should fail if the signature is of another image", func() {
+		payload := signaturePayload(digest.FromString("other manifest"))
+		layers["sig"] = []cosignLayer{signatureLayer(payload, sign(signer, payload))}
+		err := verifyImagePolicies([]cdiv1.ImageVerificationPolicy{keyPolicy("disks", signer)}, imageDigest, read)
+		Expect(err).To(MatchError(ContainSubstring("a signature is of the image " + digest.FromString("other manifest").String())))
+	})
+
+	It("Should fail if the payload of the signature was tampered with", func() {
+		payload := signaturePayload(imageDigest)
+		signature := sign(signer, payload)
+		tampered := append([]byte{}, payload...)
+		tampered = append(tampered[:len(tampered)-1], []byte(`,"extra":1}`)...)
+		layers["sig"] = []cosignLayer{signatureLayer(tampered, signature)}
+		err := verifyImagePolicies([]cdiv1.ImageVerificationPolicy{keyPolicy("disks", signer)}, imageDigest, read)
+		Expect(err).To(MatchError(ContainSubstring("authority key: invalid ECDSA signature")))
+	})
+
+	It("Should check the image meets every policy", func() {
+		payload := signaturePayload(imageDigest)
+		layers["sig"] = []cosignLayer{signatureLayer(payload, sign(signer, payload))}
+		policies := []cdiv1.ImageVerificationPolicy{keyPolicy("disks", signer), keyPolicy("release", newKey())}
+		err := verifyImagePolicies(policies, imageDigest, read)
+		Expect(err).To(MatchError(ContainSubstring("does not meet the ImageVerificationPolicy release")))
+	})
+
+	Context("with attestations", func() {
+		attestation := cdiv1.ImageVerificationAttestation{Name: "sbom", PredicateType: "https://spdx.dev/Document"}
+
+		BeforeEach(func() {
+			payload := signaturePayload(imageDigest)
+			layers["sig"] = []cosignLayer{signatureLayer(payload, sign(signer, payload))}
+		})
+
+		It("Should verify the attestations of the policy", func() {
+			layers["att"] = []cosignLayer{attestationLayer("https://slsa.dev/provenance/v0.2", imageDigest), attestationLayer(attestation.PredicateType, imageDigest)}
+			Expect(verifyImagePolicies([]cdiv1.ImageVerificationPolicy{keyPolicy("disks", signer, attestation)}, imageDigest, read)).To(Succeed())
+		})
+
+		It("Should fail if the image has no attestation of the predicate type", func() {
+			layers["att"] = []cosignLayer{attestationLayer("https://slsa.dev/provenance/v0.2", imageDigest)}
+			err := verifyImagePolicies([]cdiv1.ImageVerificationPolicy{keyPolicy("disks", signer, attestation)}, imageDigest, read)
+			Expect(err).To(MatchError("does not meet the attestation sbom of the ImageVerificationPolicy disks: the image has no https://spdx.dev/Document attestation"))
+		})
+
+		It("Should fail if the attestation is about another image", func() {
+			layers["att"] = []cosignLayer{attestationLayer(attestation.PredicateType, digest.FromString("other manifest"))}
+			err := verifyImagePolicies([]cdiv1.ImageVerificationPolicy{keyPolicy("disks", signer, attestation)}, imageDigest, read)
+			Expect(err).To(MatchError(ContainSubstring("an attestation is not about the image")))
+		})
+	})
+
+	Context("with keyless signatures", func() {
+		identity := cdiv1.ImageVerificationIdentity{Issuer: "https://accounts.example.com", Subject: "release@example.com"}
+		var payload []byte
+
+		BeforeEach(func() {
+			payload = signaturePayload(imageDigest)
+		})
+
+		It("Should verify the certificate, identity and bundle of the signature", func() {
+			layers["sig"] = []cosignLayer{keylessLayer(signatureLayer(payload, sign(signer, payload)), issue(ca, caKey, identity.Subject, identity.Issuer), rekor)}
+			Expect(verifyImagePolicies([]cdiv1.ImageVerificationPolicy{keylessPolicy(identity)}, imageDigest, read)).To(Succeed())
+		})
+
+		It("Should match the subject of the identity with a regular expression", func() {
+			layers["sig"] = []cosignLayer{keylessLayer(signatureLayer(payload, sign(signer, payload)), issue(ca, caKey, identity.Subject, identity.Issuer), rekor)}
+			regExp := cdiv1.ImageVerificationIdentity{Issuer: identity.Issuer, SubjectRegExp: `^.*@example\.com$`}
+			Expect(verifyImagePolicies([]cdiv1.ImageVerificationPolicy{keylessPolicy(regExp)}, imageDigest, read)).To(Succeed())
+		})
+
+		It("Should fail if the certificate is issued to another identity", func() {
+			layers["sig"] = []cosignLayer{keylessLayer(signatureLayer(payload, sign(signer, payload)), issue(ca, caKey, "someone@example.com", identity.Issuer), rekor)}
+			err := verifyImagePolicies([]cdiv1.ImageVerificationPolicy{keylessPolicy(identity)}, imageDigest, read)
+			Expect(err).To(MatchError(ContainSubstring("the certificate is issued to someone@example.com by https://accounts.example.com, which is not an identity of the authority")))
+		})
+
+		It("Should fail if the certificate is not issued by the roots", func() {
+			other, otherKey := newCA()
+			layers["sig"] = []cosignLayer{keylessLayer(signatureLayer(payload, sign(signer, payload)), issue(other, otherKey, identity.Subject, identity.Issuer), rekor)}
+			err := verifyImagePolicies([]cdiv1.ImageVerificationPolicy{keylessPolicy(identity)}, imageDigest, read)
+			Expect(err).To(MatchError(ContainSubstring("the certificate of the signature is not valid when it was logged")))
+		})
+
+		It("Should not trust the roots of the chain of the signature", func() {
+			other, otherKey := newCA()
+			layer := keylessLayer(signatureLayer(payload, sign(signer, payload)), issue(other, otherKey, identity.Subject, identity.Issuer), rekor)
+			layer.annotations[cosignChainAnnotation] = string(certPEM(other))
+			layers["sig"] = []cosignLayer{layer}
+			err := verifyImagePolicies([]cdiv1.ImageVerificationPolicy{keylessPolicy(identity)}, imageDigest, read)
+			Expect(err).To(MatchError(ContainSubstring("the certificate of the signature is not valid when it was logged")))
+		})
+
+		It("Should fail if the bundle is not signed by the log", func() {
+			layers["sig"] = []cosignLayer{keylessLayer(signatureLayer(payload, sign(signer, payload)), issue(ca, caKey, identity.Subject, identity.Issuer), newKey())}
+			err := verifyImagePolicies([]cdiv1.ImageVerificationPolicy{keylessPolicy(identity)}, imageDigest, read)
+			Expect(err).To(MatchError(ContainSubstring("the rekor bundle is not signed by the log")))
+		})
+
+		It("Should fail if the signature is not in the log", func() {
+			layers["sig"] = []cosignLayer{signatureLayer(payload, sign(signer, payload))}
+			layers["sig"][0].annotations[cosignCertificateAnnotation] = string(certPEM(issue(ca, caKey, identity.Subject, identity.Issuer)))
+			err := verifyImagePolicies([]cdiv1.ImageVerificationPolicy{keylessPolicy(identity)}, imageDigest, read)
+			Expect(err).To(MatchError(ContainSubstring("the signature is not recorded in a rekor transparency log")))
+		})
+	})
+})
@@ -0,0 +1,126 @@
+/*
+Copyright 2024 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package importer
+
+import (
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"k8s.io/klog/v2"
+
+	"kubevirt.io/containerized-data-importer/pkg/common"
+)
+
+// libiscsi reads the CHAP credentials of urls without any from these variables
+const (
+	libiscsiCHAPUsername = "LIBISCSI_CHAP_USERNAME"
+	libiscsiCHAPPassword = "LIBISCSI_CHAP_PASSWORD"
+)
+
+// ISCSIDataSource is the data provider for iSCSI LUNs. qemu-img reads the LUN with its iscsi driver,
+// which only opens the source of a conversion read-only, so it is converted without staging it in
+// scratch space.
+// Sequence of phases:
+// 1. Info -> Convert
+type ISCSIDataSource struct {
+	// CHAP user and password, empty without authentication
+	user     string
+	password string
+	// The url qemu-img reads from
+	url *url.URL
+}
+
+// NewISCSIDataSource creates a new instance of the ISCSIDataSource. endpoint is an
+// iscsi://portal/target/lun url, user and password the optional CHAP credentials.
+func NewISCSIDataSource(endpoint, user, password string) (*ISCSIDataSource, error) {
+	ep, err := ParseEndpoint(endpoint)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to parse endpoint %q", endpoint)
+	}
+	if ep.Scheme != "iscsi" {
+		return nil, errors.Errorf("invalid iSCSI url scheme %q", ep.Scheme)
+	}
+	if ep.User != nil {
+		return nil, errors.New("iSCSI url must not contain credentials, put them in the secret")
+	}
+	target, lun, _ := strings.Cut(strings.TrimPrefix(ep.Path, "/"), "/")
+	if ep.Host == "" || target == "" {
+		return nil, errors.New("iSCSI url must name a portal and a target")
+	}
+	// LUNs are 14 bit numbers in the flat addressing of SAM
+	if n, err := strconv.ParseUint(lun, 10, 16); err != nil || n > 16383 {
+		return nil, errors.Errorf("iSCSI LUN %q is not valid", lun)
+	}
+	if (user == "") != (password == "") {
+		return nil, errors.New("iSCSI CHAP authentication needs both a user and a password")
+	}
+	klog.V(3).Infof("iSCSI Importer: importing LUN %s of %s from %s, CHAP: %v", lun, target, ep.Host, user != "")
+	return &ISCSIDataSource{
+		user:     user,
+		password: password,
+		url:      ep,
+	}, nil
+}
+
+// Info is called to get initial information about the data. It hands the CHAP credentials to
+// qemu-img in the environment, so they stay out of its command line.
+func (id *ISCSIDataSource) Info() (ProcessingPhase, error) {
+	if id.user != "" {
+		if err := os.Setenv(libiscsiCHAPUsername, id.user); err != nil {
+			return ProcessingPhaseError, errors.Wrap(err, "unable to set the iSCSI CHAP user")
+		}
+		if err := os.Setenv(libiscsiCHAPPassword, id.password); err != nil {
+			return ProcessingPhaseError, errors.Wrap(err, "unable to set the iSCSI CHAP password")
+		}
+	}
+	return ProcessingPhaseConvert, nil
+}
+
+// Transfer is not used, the LUN is converted straight from the target.
+func (id *ISCSIDataSource) Transfer(path string, preallocation bool) (ProcessingPhase, error) {
+	return ProcessingPhaseError, errors.New("transfer is not supported for iSCSI sources")
+}
+
+// TransferFile is not used, the LUN is converted straight from the target.
+func (id *ISCSIDataSource) TransferFile(fileName string, preallocation bool) (ProcessingPhase, error) {
+	return ProcessingPhaseError, errors.New("transfer is not supported for iSCSI sources")
+}
+
+// GetURL returns the url that the data processor can use when converting the data.
+func (id *ISCSIDataSource) GetURL() *url.URL {
+	return id.url
+}
+
+// GetTerminationMessage returns data to be serialized and used as the termination message of the importer.
+func (id *ISCSIDataSource) GetTerminationMessage() *common.TerminationMessage {
+	return nil
+}
+
+// Close clears the CHAP credentials from the environment.
+func (id *ISCSIDataSource) Close() error {
+	if id.user != "" {
+		os.Unsetenv(libiscsiCHAPUsername)
+		os.Unsetenv(libiscsiCHAPPassword)
+	}
+	return nil
+}
+
+var _ DataSourceInterface = &ISCSIDataSource{}
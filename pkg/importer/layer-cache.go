@@ -0,0 +1,270 @@
+/*
+Copyright 2024 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package importer
+
+import (
+	"context"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/containers/image/v5/types"
+	"github.com/opencontainers/go-digest"
+	"github.com/pkg/errors"
+
+	"k8s.io/klog/v2"
+
+	"kubevirt.io/containerized-data-importer/pkg/common"
+	"kubevirt.io/containerized-data-importer/pkg/util"
+)
+
+const (
+	// layerCacheBlobsDir is the directory of the cache holding the blobs, by algorithm and encoded digest
+	layerCacheBlobsDir = "blobs"
+	// layerCacheTmpDir is the directory of the cache holding the blobs being pulled
+	layerCacheTmpDir = "tmp"
+	// layerCacheStaleTmp is the age of the blobs being pulled after which they are left by a failed importer
+	layerCacheStaleTmp = 24 * time.Hour
+)
+
+// Helper for unit-testing
+var layerCacheDir = common.ImporterLayerCacheDir
+
+// layerCache is a node directory holding the blobs of registry images by digest, shared by the importers of the
+// node. Blobs are written to the cache while they are pulled, and only added once their digest is verified. The
+// least recently used blobs are evicted once the cache grows over its maximum size. Failing to use the cache
+// never fails the import, the blob is pulled from the registry instead.
+type layerCache struct {
+	dir     string
+	maxSize int64
+}
+
+// getLayerCache returns the layer cache of the node if the importer mounts one, nil otherwise
+func getLayerCache() (*layerCache, error) {
+	value, _ := util.ParseEnvVar(common.ImporterLayerCacheMaxSize, false)
+	if value == "" {
+		return nil, nil
+	}
+	maxSize, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || maxSize <= 0 {
+		return nil, errors.Errorf("invalid layer cache size %q", value)
+	}
+	for _, dir := range []string{layerCacheBlobsDir, layerCacheTmpDir} {
+		if err := os.MkdirAll(filepath.Join(layerCacheDir, dir), 0750); err != nil {
+			klog.Warningf("Not using the layer cache, unable to create its directories: %v", err)
+			return nil, nil
+		}
+	}
+	return &layerCache{dir: layerCacheDir, maxSize: maxSize}, nil
+}
+
+// wrapImageSource returns the image source reading the blobs of src from the cache, or src itself without a cache
+func (c *layerCache) wrapImageSource(src types.ImageSource) types.ImageSource {
+	if c == nil {
+		return src
+	}
+	return &cachedImageSource{ImageSource: src, cache: c}
+}
+
+func (c *layerCache) blobPath(d digest.Digest) string {
+	return filepath.Join(c.dir, layerCacheBlobsDir, d.Algorithm().String(), d.Encoded())
+}
+
+// open returns the cached blob of digest d, and marks it as recently used
+func (c *layerCache) open(d digest.Digest) (*os.File, int64, bool) {
+	blobPath := c.blobPath(d)
+	file, err := os.Open(blobPath)
+	if err != nil {
+		return nil, 0, false
+	}
+	fi, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, 0, false
+	}
+	now := time.Now()
+	if err := os.Chtimes(blobPath, now, now); err != nil {
+		klog.V(3).Infof("Unable to mark cached layer %s as used: %v", d, err)
+	}
+	return file, fi.Size(), true
+}
+
+// add moves the pulled blob of digest d to the cache, then evicts the least recently used blobs over its size
+func (c *layerCache) add(d digest.Digest, tmpPath string) {
+	blobPath := c.blobPath(d)
+	if err := os.MkdirAll(filepath.Dir(blobPath), 0750); err != nil {
+		klog.Warningf("Unable to add layer %s to the cache: %v", d, err)
+		os.Remove(tmpPath)
+		return
+	}
+	// Importers pulling the same layer at once each rename a complete copy over the other
+	if err := os.Rename(tmpPath, blobPath); err != nil {
+		klog.Warningf("Unable to add layer %s to the cache: %v", d, err)
+		os.Remove(tmpPath)
+		return
+	}
+	klog.Infof("Added layer %s to the cache", d)
+	c.evict()
+}
+
+type cachedBlob struct {
+	path    string
+	size    int64
+	modTime time.Time
+}
+
+// evict removes the least recently used blobs until the cache fits its maximum size, along with the blobs left
+// being pulled by failed importers. Blobs read by other importers remain readable until they close them.
+func (c *layerCache) evict() {
+	var blobs []cachedBlob
+	var size int64
+	err := filepath.WalkDir(c.dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		fi, err := entry.Info()
+		if err != nil {
+			// Removed by another importer
+			return nil
+		}
+		if filepath.Base(filepath.Dir(path)) == layerCacheTmpDir {
+			if time.Since(fi.ModTime()) > layerCacheStaleTmp {
+				os.Remove(path)
+			}
+			return nil
+		}
+		blobs = append(blobs, cachedBlob{path: path, size: fi.Size(), modTime: fi.ModTime()})
+		size += fi.Size()
+		return nil
+	})
+	if err != nil {
+		klog.Warningf("Unable to list the cached layers: %v", err)
+		return
+	}
+	sort.Slice(blobs, func(i, j int) bool {
+		return blobs[i].modTime.Before(blobs[j].modTime)
+	})
+	for _, blob := range blobs {
+		if size <= c.maxSize {
+			break
+		}
+		klog.V(1).Infof("Evicting cached layer %s", blob.path)
+		if err := os.Remove(blob.path); err != nil && !os.IsNotExist(err) {
+			klog.Warningf("Unable to evict cached layer %s: %v", blob.path, err)
+			continue
+		}
+		size -= blob.size
+	}
+}
+
+type cachedImageSource struct {
+	types.ImageSource
+	cache *layerCache
+}
+
+func (s *cachedImageSource) GetBlob(ctx context.Context, info types.BlobInfo, cache types.BlobInfoCache) (io.ReadCloser, int64, error) {
+	if info.Digest.Validate() != nil {
+		return s.ImageSource.GetBlob(ctx, info, cache)
+	}
+	if file, size, ok := s.cache.open(info.Digest); ok {
+		klog.Infof("Reusing cached layer %s", info.Digest)
+		return file, size, nil
+	}
+	reader, size, err := s.ImageSource.GetBlob(ctx, info, cache)
+	if err != nil {
+		return nil, 0, err
+	}
+	tmp, err := os.CreateTemp(filepath.Join(s.cache.dir, layerCacheTmpDir), info.Digest.Encoded())
+	if err != nil {
+		klog.Warningf("Not caching layer %s: %v", info.Digest, err)
+		return reader, size, nil
+	}
+	return &cachingReader{
+		ReadCloser: reader,
+		cache:      s.cache,
+		digest:     info.Digest,
+		verifier:   info.Digest.Verifier(),
+		tmp:        tmp,
+	}, size, nil
+}
+
+// cachingReader writes the blob read from the registry to a temporary file of the cache, added to the cache once
+// the whole blob is read and its digest verified
+type cachingReader struct {
+	io.ReadCloser
+	cache    *layerCache
+	digest   digest.Digest
+	verifier digest.Verifier
+	tmp      *os.File
+	eof      bool
+}
+
+func (r *cachingReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	if n > 0 && r.tmp != nil {
+		if _, werr := r.tmp.Write(p[:n]); werr != nil {
+			klog.Warningf("Not caching layer %s: %v", r.digest, werr)
+			r.discard()
+		} else {
+			// Writing to a digest.Verifier never fails
+			_, _ = r.verifier.Write(p[:n])
+		}
+	}
+	if err == io.EOF {
+		r.eof = true
+	}
+	return n, err
+}
+
+// Close adds the blob to the cache once it is read. The importer stops reading a layer once it found the disk
+// image, so the rest of the blob, usually the end of the tar archive, is read here to cache the whole blob.
+func (r *cachingReader) Close() error {
+	if r.tmp != nil && !r.eof {
+		if _, err := io.Copy(io.Discard, r); err != nil {
+			klog.Warningf("Not caching layer %s, unable to read its end: %v", r.digest, err)
+			r.discard()
+		}
+	}
+	err := r.ReadCloser.Close()
+	if r.tmp == nil {
+		return err
+	}
+	tmpPath := r.tmp.Name()
+	if cerr := r.tmp.Close(); cerr != nil {
+		klog.Warningf("Not caching layer %s: %v", r.digest, cerr)
+		os.Remove(tmpPath)
+	} else if !r.verifier.Verified() {
+		klog.Warningf("Not caching layer %s, its content does not match its digest", r.digest)
+		os.Remove(tmpPath)
+	} else {
+		r.cache.add(r.digest, tmpPath)
+	}
+	r.tmp = nil
+	return err
+}
+
+func (r *cachingReader) discard() {
+	if r.tmp != nil {
+		r.tmp.Close()
+		os.Remove(r.tmp.Name())
+		r.tmp = nil
+	}
+}
@@ -0,0 +1,129 @@
+package importer
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/containers/image/v5/types"
+	"github.com/opencontainers/go-digest"
+
+	"kubevirt.io/containerized-data-importer/pkg/common"
+)
+
+type countingBlobSource struct {
+	fakeBlobSource
+	pulls int
+}
+
+func (s *countingBlobSource) GetBlob(ctx context.Context, info types.BlobInfo, cache types.BlobInfoCache) (io.ReadCloser, int64, error) {
+	s.pulls++
+	return s.fakeBlobSource.GetBlob(ctx, info, cache)
+}
+
+var _ = Describe("Layer cache", func() {
+	var (
+		tmpDir    string
+		origDir   string
+		content   []byte
+		blobInfo  types.BlobInfo
+		registry  *countingBlobSource
+		readBlob  func(src types.ImageSource) []byte
+		cachePath func(d digest.Digest) string
+	)
+
+	BeforeEach(func() {
+		var err error
+		tmpDir, err = os.MkdirTemp("", "layer-cache")
+		Expect(err).NotTo(HaveOccurred())
+		origDir = layerCacheDir
+		layerCacheDir = tmpDir
+		content = bytes.Repeat([]byte("disk image layer "), 1000)
+		blobInfo = types.BlobInfo{Digest: digest.FromBytes(content)}
+		registry = &countingBlobSource{fakeBlobSource: fakeBlobSource{blob: content}}
+		readBlob = func(src types.ImageSource) []byte {
+			reader, _, err := src.GetBlob(context.Background(), blobInfo, nil)
+			Expect(err).NotTo(HaveOccurred())
+			data, err := io.ReadAll(reader)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reader.Close()).To(Succeed())
+			return data
+		}
+		cachePath = func(d digest.Digest) string {
+			return filepath.Join(tmpDir, layerCacheBlobsDir, d.Algorithm().String(), d.Encoded())
+		}
+	})
+
+	AfterEach(func() {
+		layerCacheDir = origDir
+		os.RemoveAll(tmpDir)
+	})
+
+	It("Should not cache layers without a cache size", func() {
+		cache, err := getLayerCache()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cache).To(BeNil())
+		Expect(cache.wrapImageSource(registry)).To(BeIdenticalTo(registry))
+	})
+
+	It("Should refuse an invalid cache size", func() {
+		GinkgoT().Setenv(common.ImporterLayerCacheMaxSize, "10Gi")
+		_, err := getLayerCache()
+		Expect(err).To(MatchError(ContainSubstring("invalid layer cache size")))
+	})
+
+	It("Should reuse a layer pulled by a previous import", func() {
+		GinkgoT().Setenv(common.ImporterLayerCacheMaxSize, "1000000")
+		cache, err := getLayerCache()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(readBlob(cache.wrapImageSource(registry))).To(Equal(content))
+		Expect(cachePath(blobInfo.Digest)).To(BeARegularFile())
+		Expect(readBlob(cache.wrapImageSource(registry))).To(Equal(content))
+		Expect(registry.pulls).To(Equal(1))
+	})
+
+	It("Should cache the whole layer when the import stops reading it early", func() {
+		GinkgoT().Setenv(common.ImporterLayerCacheMaxSize, "1000000")
+		cache, err := getLayerCache()
+		Expect(err).NotTo(HaveOccurred())
+		reader, _, err := cache.wrapImageSource(registry).GetBlob(context.Background(), blobInfo, nil)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = reader.Read(make([]byte, 10))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(reader.Close()).To(Succeed())
+		Expect(os.ReadFile(cachePath(blobInfo.Digest))).To(Equal(content))
+	})
+
+	It("Should not cache a layer not matching its digest", func() {
+		GinkgoT().Setenv(common.ImporterLayerCacheMaxSize, "1000000")
+		cache, err := getLayerCache()
+		Expect(err).NotTo(HaveOccurred())
+		blobInfo.Digest = digest.FromString("another layer")
+		Expect(readBlob(cache.wrapImageSource(registry))).To(Equal(content))
+		Expect(cachePath(blobInfo.Digest)).ToNot(BeAnExistingFile())
+		Expect(os.ReadDir(filepath.Join(tmpDir, layerCacheTmpDir))).To(BeEmpty())
+	})
+
+	It("Should evict the least recently used layers over the cache size", func() {
+		GinkgoT().Setenv(common.ImporterLayerCacheMaxSize, "40000")
+		cache, err := getLayerCache()
+		Expect(err).NotTo(HaveOccurred())
+		oldDigest := blobInfo.Digest
+		Expect(readBlob(cache.wrapImageSource(registry))).To(Equal(content))
+		past := time.Now().Add(-time.Hour)
+		Expect(os.Chtimes(cachePath(oldDigest), past, past)).To(Succeed())
+
+		content = bytes.Repeat([]byte("another layer "), 2000)
+		blobInfo.Digest = digest.FromBytes(content)
+		registry.blob = content
+		Expect(readBlob(cache.wrapImageSource(registry))).To(Equal(content))
+		Expect(cachePath(blobInfo.Digest)).To(BeARegularFile())
+		Expect(cachePath(oldDigest)).ToNot(BeAnExistingFile())
+	})
+})
@@ -0,0 +1,146 @@
+/*
+Copyright 2024 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package importer
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+
+	"k8s.io/klog/v2"
+
+	"kubevirt.io/containerized-data-importer/pkg/common"
+)
+
+const (
+	// oauth2HeaderFile holds the Authorization header of the current token, for nbdkit
+	oauth2HeaderFile = "/tmp/oauth2-header"
+	// oauth2RenewInterval is how often the header file is rewritten, and read again by nbdkit
+	oauth2RenewInterval = 30 * time.Second
+)
+
+// Where the OAuth2 client secret is mounted, a var for unit-testing
+var oauth2CredentialDir = common.ImporterOAuth2CredentialDir
+
+// getOAuth2TokenSource returns a token source for the OAuth2 client in the mounted secret, nil if there is no
+// secret. Tokens are obtained with the client credentials grant and replaced shortly before they expire.
+func getOAuth2TokenSource(ctx context.Context, certDir string) (oauth2.TokenSource, error) {
+	if _, err := os.Stat(filepath.Join(oauth2CredentialDir, common.KeyOAuth2TokenURL)); os.IsNotExist(err) {
+		return nil, nil
+	}
+	config := &clientcredentials.Config{}
+	for key, value := range map[string]*string{
+		common.KeyOAuth2TokenURL:     &config.TokenURL,
+		common.KeyOAuth2ClientID:     &config.ClientID,
+		common.KeyOAuth2ClientSecret: &config.ClientSecret,
+	} {
+		content, err := os.ReadFile(filepath.Join(oauth2CredentialDir, key))
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to read OAuth2 credential %s", key)
+		}
+		*value = strings.TrimSpace(string(content))
+	}
+	if scopes, err := os.ReadFile(filepath.Join(oauth2CredentialDir, common.KeyOAuth2Scopes)); err == nil {
+		config.Scopes = strings.Fields(string(scopes))
+	} else if !os.IsNotExist(err) {
+		return nil, errors.Wrapf(err, "unable to read OAuth2 credential %s", common.KeyOAuth2Scopes)
+	}
+
+	// The token endpoint is reached like the http source, trusting the same CA
+	client, err := createHTTPClient(certDir)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error creating http client")
+	}
+	tokenSource := config.TokenSource(context.WithValue(ctx, oauth2.HTTPClient, client))
+	// Fail early if the client is not granted a token
+	if _, err := tokenSource.Token(); err != nil {
+		return nil, errors.Wrapf(err, "unable to get an OAuth2 token from %s", config.TokenURL)
+	}
+	klog.V(3).Infof("HTTP Importer: Authentication: OAuth2 client %s", config.ClientID)
+	return tokenSource, nil
+}
+
+// oauth2HostTransport sends the bearer token to a single host. Redirects to other hosts, like the pre-signed
+// urls of object stores, are sent without it so the token never leaks to them.
+type oauth2HostTransport struct {
+	host       string
+	authorized http.RoundTripper
+	base       http.RoundTripper
+}
+
+func newOAuth2HostTransport(tokenSource oauth2.TokenSource, host string, base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &oauth2HostTransport{
+		host:       host,
+		authorized: &oauth2.Transport{Source: tokenSource, Base: base},
+		base:       base,
+	}
+}
+
+// RoundTrip adds the bearer token to the requests to the host of the endpoint
+func (t *oauth2HostTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.URL.Host == t.host {
+		return t.authorized.RoundTrip(req)
+	}
+	return t.base.RoundTrip(req)
+}
+
+// startOAuth2HeaderRefresh writes the Authorization header of the current token to headerFile, and keeps
+// rewriting it every interval until ctx is done, so nbdkit sends a token that did not expire.
+func startOAuth2HeaderRefresh(ctx context.Context, tokenSource oauth2.TokenSource, headerFile string, interval time.Duration) error {
+	if err := writeOAuth2Header(tokenSource, headerFile); err != nil {
+		return err
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := writeOAuth2Header(tokenSource, headerFile); err != nil {
+					klog.Warningf("Unable to refresh the OAuth2 token: %v", err)
+				}
+			}
+		}
+	}()
+	return nil
+}
+
+func writeOAuth2Header(tokenSource oauth2.TokenSource, headerFile string) error {
+	token, err := tokenSource.Token()
+	if err != nil {
+		return errors.Wrap(err, "unable to get an OAuth2 token")
+	}
+	header := fmt.Sprintf("Authorization: %s %s\n", token.Type(), token.AccessToken)
+	// Replace the file at once, nbdkit may read it any time
+	if err := os.WriteFile(headerFile+".tmp", []byte(header), 0600); err != nil {
+		return errors.Wrap(err, "could not write the OAuth2 header file")
+	}
+	return errors.Wrap(os.Rename(headerFile+".tmp", headerFile), "could not write the OAuth2 header file")
+}
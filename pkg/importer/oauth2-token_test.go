@@ -0,0 +1,187 @@
+package importer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+	"kubevirt.io/containerized-data-importer/pkg/common"
+	"kubevirt.io/containerized-data-importer/pkg/image"
+)
+
+var _ = Describe("OAuth2 token source", func() {
+	var (
+		tmpDir      string
+		tokenServer *httptest.Server
+		tokens      int32
+		scopes      atomic.Value
+		expiresIn   int
+		origDir     string
+	)
+
+	writeCredential := func(key, value string) {
+		Expect(os.WriteFile(filepath.Join(tmpDir, key), []byte(value), 0600)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		var err error
+		tmpDir, err = os.MkdirTemp("", "oauth2")
+		Expect(err).NotTo(HaveOccurred())
+		tokens = 0
+		scopes.Store("")
+		expiresIn = 3600
+		tokenServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			user, password, ok := r.BasicAuth()
+			if !ok || user != "importer" || password != "secret" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			Expect(r.ParseForm()).To(Succeed())
+			Expect(r.PostForm.Get("grant_type")).To(Equal("client_credentials"))
+			scopes.Store(r.PostForm.Get("scope"))
+			w.Header().Set("Content-Type", "application/json")
+			Expect(json.NewEncoder(w).Encode(map[string]interface{}{
+				"access_token": fmt.Sprintf("token-%d", atomic.AddInt32(&tokens, 1)),
+				"token_type":   "Bearer",
+				"expires_in":   expiresIn,
+			})).To(Succeed())
+		}))
+		writeCredential(common.KeyOAuth2TokenURL, tokenServer.URL+"\n")
+		writeCredential(common.KeyOAuth2ClientID, "importer")
+		writeCredential(common.KeyOAuth2ClientSecret, "secret")
+		origDir = oauth2CredentialDir
+		oauth2CredentialDir = tmpDir
+	})
+
+	AfterEach(func() {
+		oauth2CredentialDir = origDir
+		tokenServer.Close()
+		os.RemoveAll(tmpDir)
+	})
+
+	It("Should not be used without a secret", func() {
+		oauth2CredentialDir = filepath.Join(tmpDir, "missing")
+		tokenSource, err := getOAuth2TokenSource(context.Background(), "")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tokenSource).To(BeNil())
+	})
+
+	It("Should get a token with the client credentials and scopes", func() {
+		writeCredential(common.KeyOAuth2Scopes, "read:packages  read:registry")
+		tokenSource, err := getOAuth2TokenSource(context.Background(), "")
+		Expect(err).NotTo(HaveOccurred())
+		token, err := tokenSource.Token()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(token.AccessToken).To(Equal("token-1"))
+		Expect(scopes.Load()).To(Equal("read:packages read:registry"))
+	})
+
+	It("Should fail if the client is not granted a token", func() {
+		writeCredential(common.KeyOAuth2ClientSecret, "wrong")
+		_, err := getOAuth2TokenSource(context.Background(), "")
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("unable to get an OAuth2 token"))
+	})
+
+	It("Should fail without the client secret", func() {
+		Expect(os.Remove(filepath.Join(tmpDir, common.KeyOAuth2ClientSecret))).To(Succeed())
+		_, err := getOAuth2TokenSource(context.Background(), "")
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("unable to read OAuth2 credential " + common.KeyOAuth2ClientSecret))
+	})
+
+	It("Should keep the header file holding a current token", func() {
+		// Tokens expiring this soon are always replaced
+		expiresIn = 1
+		tokenSource, err := getOAuth2TokenSource(context.Background(), "")
+		Expect(err).NotTo(HaveOccurred())
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		headerFile := filepath.Join(tmpDir, "header")
+		Expect(startOAuth2HeaderRefresh(ctx, tokenSource, headerFile, 10*time.Millisecond)).To(Succeed())
+		header, err := os.ReadFile(headerFile)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(header)).To(Equal("Authorization: Bearer token-2\n"))
+		Eventually(func() (string, error) {
+			header, err := os.ReadFile(headerFile)
+			return string(header), err
+		}, 5*time.Second, 10*time.Millisecond).Should(SatisfyAll(HavePrefix("Authorization: Bearer token-"), Not(Equal(string(header)))))
+	})
+
+	It("Should send the token with the http requests and to nbdkit", func() {
+		var authorizations []string
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			authorizations = append(authorizations, r.Header.Get("Authorization"))
+			if r.Header.Get("Authorization") != "Bearer token-1" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			http.ServeContent(w, r, "disk.img", time.Time{}, strings.NewReader("data"))
+		}))
+		defer ts.Close()
+		var headerFile string
+		origFunc := createNbdkitCurlWithHeaderFile
+		defer func() { createNbdkitCurlWithHeaderFile = origFunc }()
+		createNbdkitCurlWithHeaderFile = func(pidFile, certDir, socket, file string, renewInterval time.Duration, extraHeaders, secretExtraHeaders []string) (image.NbdkitOperation, error) {
+			headerFile = file
+			return image.NewMockNbdkitCurlWithHeaderFile(pidFile, certDir, socket, file, renewInterval, extraHeaders, secretExtraHeaders)
+		}
+		defer os.Remove(oauth2HeaderFile)
+
+		dp, err := NewHTTPDataSource(ts.URL+"/disk.img", "", "", "", cdiv1.DataVolumeKubeVirt)
+		Expect(err).NotTo(HaveOccurred())
+		defer dp.Close()
+		data, err := io.ReadAll(dp.httpReader)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(Equal("data"))
+		Expect(authorizations).ToNot(BeEmpty())
+		Expect(authorizations).To(HaveEach("Bearer token-1"))
+		Expect(headerFile).To(Equal(oauth2HeaderFile))
+		header, err := os.ReadFile(headerFile)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(header)).To(Equal("Authorization: Bearer token-1\n"))
+	})
+
+	It("Should not send the token to the host of a redirect", func() {
+		var redirectAuthorizations []string
+		target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			redirectAuthorizations = append(redirectAuthorizations, r.Header.Get("Authorization"))
+			http.ServeContent(w, r, "disk.img", time.Time{}, strings.NewReader("data"))
+		}))
+		defer target.Close()
+		var authorizations []string
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			authorizations = append(authorizations, r.Header.Get("Authorization"))
+			http.Redirect(w, r, target.URL+"/disk.img", http.StatusFound)
+		}))
+		defer ts.Close()
+		tokenSource, err := getOAuth2TokenSource(context.Background(), "")
+		Expect(err).NotTo(HaveOccurred())
+		ep, err := url.Parse(ts.URL + "/disk.img")
+		Expect(err).NotTo(HaveOccurred())
+
+		reader, _, _, _, err := createHTTPReader(context.Background(), ep, "", "", "", nil, nil, tokenSource, cdiv1.DataVolumeKubeVirt)
+		Expect(err).NotTo(HaveOccurred())
+		defer reader.Close()
+		data, err := io.ReadAll(reader)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(Equal("data"))
+		Expect(authorizations).ToNot(BeEmpty())
+		Expect(authorizations).To(HaveEach(HavePrefix("Bearer token-")))
+		Expect(redirectAuthorizations).ToNot(BeEmpty())
+		Expect(redirectAuthorizations).To(HaveEach(BeEmpty()))
+	})
+})
@@ -0,0 +1,124 @@
+/*
+Copyright 2024 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package importer
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/containers/image/v5/manifest"
+	"github.com/containers/image/v5/types"
+	imgspecv1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+
+	"k8s.io/klog/v2"
+)
+
+const defaultArtifactDiskName = "disk.img"
+
+// diskImageExtensions are the extensions that name the disk image among the files of an artifact
+var diskImageExtensions = []string{".img", ".raw", ".qcow2", ".iso", ".vmdk", ".vhd", ".vhdx"}
+
+// isOCIArtifact returns true if img is an OCI artifact, like the ones pushed with ORAS, rather than a
+// container image. Artifacts are told apart by their config, which is not an image config.
+func isOCIArtifact(img types.Image) bool {
+	mediaType := img.ConfigInfo().MediaType
+	return mediaType != "" && mediaType != imgspecv1.MediaTypeImageConfig && mediaType != manifest.DockerV2Schema2ConfigMediaType
+}
+
+// getArtifactDiskLayer returns the layer of an artifact holding the disk image. The layers of an artifact
+// are the files it was pushed with, a single file is the disk image, otherwise it is the only one with
+// a disk image extension in its title.
+func getArtifactDiskLayer(layers []types.BlobInfo) (types.BlobInfo, error) {
+	if len(layers) == 1 {
+		return layers[0], nil
+	}
+	var disks []types.BlobInfo
+	for _, layer := range layers {
+		if isDiskImageName(layer.Annotations[imgspecv1.AnnotationTitle]) {
+			disks = append(disks, layer)
+		}
+	}
+	if len(disks) != 1 {
+		return types.BlobInfo{}, errors.Errorf("OCI artifact has %d files, %d of them named like a disk image, expected a single disk image", len(layers), len(disks))
+	}
+	return disks[0], nil
+}
+
+func isDiskImageName(name string) bool {
+	name = strings.ToLower(name)
+	for _, ext := range []string{".gz", ".xz", ".zst"} {
+		name = strings.TrimSuffix(name, ext)
+	}
+	for _, ext := range diskImageExtensions {
+		if strings.HasSuffix(name, ext) {
+			return true
+		}
+	}
+	return false
+}
+
+// copyArtifactDisk writes the disk image of an artifact to destDir, decompressing it if it was
+// compressed. The disk image is checked against the digest of its layer.
+func copyArtifactDisk(ctx context.Context, src types.ImageSource, img types.Image, destDir string, cache types.BlobInfoCache, preallocation bool) error {
+	layer, err := getArtifactDiskLayer(img.LayerInfos())
+	if err != nil {
+		return err
+	}
+	if err := layer.Digest.Validate(); err != nil {
+		return errors.Wrap(err, "OCI artifact layer has an invalid digest")
+	}
+	name := filepath.Base(layer.Annotations[imgspecv1.AnnotationTitle])
+	if name == "." || name == ".." || name == string(filepath.Separator) {
+		name = defaultArtifactDiskName
+	}
+	destFile, err := safeJoinPaths(destDir, name)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(destDir, os.ModePerm); err != nil {
+		return errors.Wrap(err, "Error creating output file's directory")
+	}
+	klog.Infof("Copying disk image %s of OCI artifact (%s) to %s", layer.Digest, layer.MediaType, destFile)
+
+	blob, _, err := src.GetBlob(ctx, layer, cache)
+	if err != nil {
+		return NewImagePullFailedError(err)
+	}
+	defer blob.Close()
+	verifier := layer.Digest.Verifier()
+	reader := io.TeeReader(blob, verifier)
+	fr, err := NewFormatReaders(io.NopCloser(reader), 0)
+	if err != nil {
+		return errors.Wrap(err, "Error reading OCI artifact")
+	}
+	defer fr.Close()
+	if _, _, err := StreamDataToFile(fr.TopReader(), destFile, preallocation); err != nil {
+		return errors.Wrap(err, "Error copying file")
+	}
+	// Decompressors may stop before the end of the blob, the digest covers all of it
+	if _, err := io.Copy(io.Discard, reader); err != nil {
+		return NewImagePullFailedError(err)
+	}
+	if !verifier.Verified() {
+		return errors.Errorf("disk image of OCI artifact does not match digest %s", layer.Digest)
+	}
+	return nil
+}
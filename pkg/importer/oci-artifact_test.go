@@ -0,0 +1,151 @@
+package importer
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/opencontainers/go-digest"
+	specs "github.com/opencontainers/image-spec/specs-go"
+	imgspecv1 "github.com/opencontainers/image-spec/specs-go/v1"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type artifactFile struct {
+	title     string
+	mediaType string
+	content   []byte
+	// stored is what the blob holds instead of content, to store a blob not matching its digest
+	stored []byte
+}
+
+// writeOCIArtifact writes an oci-archive holding an artifact made of files, laid out like ORAS pushes them
+func writeOCIArtifact(archive string, files ...artifactFile) {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	add := func(name string, content []byte) {
+		Expect(tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg})).To(Succeed())
+		_, err := tw.Write(content)
+		Expect(err).NotTo(HaveOccurred())
+	}
+	addBlob := func(mediaType string, content, stored []byte, annotations map[string]string) imgspecv1.Descriptor {
+		d := digest.FromBytes(content)
+		if stored == nil {
+			stored = content
+		}
+		add("blobs/sha256/"+d.Encoded(), stored)
+		return imgspecv1.Descriptor{MediaType: mediaType, Digest: d, Size: int64(len(content)), Annotations: annotations}
+	}
+	marshal := func(v interface{}) []byte {
+		content, err := json.Marshal(v)
+		Expect(err).NotTo(HaveOccurred())
+		return content
+	}
+
+	m := imgspecv1.Manifest{
+		Versioned:    specs.Versioned{SchemaVersion: 2},
+		MediaType:    imgspecv1.MediaTypeImageManifest,
+		ArtifactType: "application/vnd.example.disk",
+		Config:       addBlob(imgspecv1.MediaTypeEmptyJSON, []byte("{}"), nil, nil),
+	}
+	for _, f := range files {
+		m.Layers = append(m.Layers, addBlob(f.mediaType, f.content, f.stored, map[string]string{imgspecv1.AnnotationTitle: f.title}))
+	}
+	index := imgspecv1.Index{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		MediaType: imgspecv1.MediaTypeImageIndex,
+		Manifests: []imgspecv1.Descriptor{addBlob(imgspecv1.MediaTypeImageManifest, marshal(m), nil, nil)},
+	}
+	add(imgspecv1.ImageLayoutFile, marshal(imgspecv1.ImageLayout{Version: imgspecv1.ImageLayoutVersion}))
+	add(imgspecv1.ImageIndexFile, marshal(index))
+	Expect(tw.Close()).To(Succeed())
+	Expect(os.WriteFile(archive, buf.Bytes(), 0600)).To(Succeed())
+}
+
+var _ = Describe("OCI artifact", func() {
+	var (
+		tmpDir  string
+		archive string
+	)
+
+	disk := []byte("QFI\xfb" + string(bytes.Repeat([]byte{1}, 1024*1024)))
+
+	BeforeEach(func() {
+		var err error
+		tmpDir, err = os.MkdirTemp("", "artifact")
+		Expect(err).NotTo(HaveOccurred())
+		archive = filepath.Join(tmpDir, "artifact.tar")
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(tmpDir)
+	})
+
+	It("Should copy the disk image of a single file artifact", func() {
+		writeOCIArtifact(archive, artifactFile{title: "disk.qcow2", mediaType: "application/vnd.example.disk.qcow2", content: disk})
+		info, err := CopyRegistryImage("oci-archive:"+archive, tmpDir, containerDiskImageDir, "", "", "", "", false, false)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(info).NotTo(BeNil())
+		content, err := os.ReadFile(filepath.Join(tmpDir, containerDiskImageDir, "disk.qcow2"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(content).To(Equal(disk))
+	})
+
+	It("Should find and decompress the disk image among other files", func() {
+		var compressed bytes.Buffer
+		gz := gzip.NewWriter(&compressed)
+		_, err := gz.Write(disk)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(gz.Close()).To(Succeed())
+		writeOCIArtifact(archive,
+			artifactFile{title: "README.md", mediaType: "text/markdown", content: []byte("# disk")},
+			artifactFile{title: "fedora.qcow2.gz", mediaType: imgspecv1.MediaTypeImageLayerGzip, content: compressed.Bytes()},
+		)
+		_, err = CopyRegistryImage("oci-archive:"+archive, tmpDir, containerDiskImageDir, "", "", "", "", false, false)
+		Expect(err).NotTo(HaveOccurred())
+		content, err := os.ReadFile(filepath.Join(tmpDir, containerDiskImageDir, "fedora.qcow2.gz"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(content).To(Equal(disk))
+	})
+
+	It("Should fail if several files are named like a disk image", func() {
+		writeOCIArtifact(archive,
+			artifactFile{title: "a.img", mediaType: "application/octet-stream", content: disk},
+			artifactFile{title: "b.img", mediaType: "application/octet-stream", content: disk},
+		)
+		_, err := CopyRegistryImage("oci-archive:"+archive, tmpDir, containerDiskImageDir, "", "", "", "", false, false)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("expected a single disk image"))
+	})
+
+	It("Should fail if the disk image does not match its digest", func() {
+		corrupted := bytes.Clone(disk)
+		corrupted[len(corrupted)-1] = 0
+		writeOCIArtifact(archive, artifactFile{title: "disk.img", mediaType: "application/octet-stream", content: disk, stored: corrupted})
+		_, err := CopyRegistryImage("oci-archive:"+archive, tmpDir, containerDiskImageDir, "", "", "", "", false, false)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("does not match digest"))
+	})
+
+	It("Should not extract files from an artifact", func() {
+		writeOCIArtifact(archive, artifactFile{title: "disk.img", mediaType: "application/octet-stream", content: disk})
+		_, err := CopyRegistryImageAll("oci-archive:"+archive, tmpDir, "etc/", "", "", "", false, false)
+		Expect(err).To(HaveOccurred())
+	})
+
+	DescribeTable("Should tell disk image names", func(name string, isDisk bool) {
+		Expect(isDiskImageName(name)).To(Equal(isDisk))
+	},
+		Entry("qcow2", "fedora.qcow2", true),
+		Entry("compressed raw", "disk.RAW.xz", true),
+		Entry("iso", "install.iso", true),
+		Entry("text", "README.md", false),
+		Entry("signature", "disk.img.sig", false),
+		Entry("no title", "", false),
+	)
+})
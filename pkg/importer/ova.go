@@ -0,0 +1,235 @@
+/*
+Copyright 2024 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package importer
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"crypto/sha1" //nolint:gosec // OVA manifests of older tools only have SHA1 digests
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/xml"
+	"hash"
+	"io"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"k8s.io/klog/v2"
+)
+
+// ovfEnvelope is the part of an OVF descriptor listing the disks of an OVA and the files holding them
+type ovfEnvelope struct {
+	Files []ovfFile `xml:"References>File"`
+	Disks []ovfDisk `xml:"DiskSection>Disk"`
+}
+
+type ovfFile struct {
+	ID          string `xml:"id,attr"`
+	Href        string `xml:"href,attr"`
+	Compression string `xml:"compression,attr"`
+	ChunkSize   string `xml:"chunkSize,attr"`
+}
+
+type ovfDisk struct {
+	DiskID  string `xml:"diskId,attr"`
+	FileRef string `xml:"fileRef,attr"`
+}
+
+// ovaManifestLine matches the lines of an OVA manifest, like SHA256(disk1.vmdk)= <hex digest>
+var ovaManifestLine = regexp.MustCompile(`^(SHA1|SHA256|SHA512)\((.+)\)\s*=\s*([0-9a-fA-F]+)$`)
+
+type ovaDigest struct {
+	algorithm string
+	hex       string
+}
+
+func (d ovaDigest) newHash() hash.Hash {
+	switch d.algorithm {
+	case "SHA1":
+		return sha1.New() //nolint:gosec
+	case "SHA512":
+		return sha512.New()
+	}
+	return sha256.New()
+}
+
+// ovaDiskSelector selects a disk of an OVA by its index in the DiskSection of the OVF descriptor, or by name, its disk
+// id or the name of its file, when name is set
+type ovaDiskSelector struct {
+	index int
+	name  string
+}
+
+// parseOVADiskSelector returns the selector of the disk named by value, an index or a name
+func parseOVADiskSelector(value string) ovaDiskSelector {
+	if index, err := strconv.Atoi(value); err == nil && index >= 0 {
+		return ovaDiskSelector{index: index}
+	}
+	return ovaDiskSelector{name: value}
+}
+
+// find returns the disk of envelope selected by s
+func (s ovaDiskSelector) find(envelope *ovfEnvelope) (ovfDisk, error) {
+	if s.name == "" {
+		if s.index < 0 || s.index >= len(envelope.Disks) {
+			return ovfDisk{}, errors.Errorf("OVA has %d disks, there is no disk at index %d", len(envelope.Disks), s.index)
+		}
+		return envelope.Disks[s.index], nil
+	}
+	for _, disk := range envelope.Disks {
+		if disk.DiskID == s.name {
+			return disk, nil
+		}
+		for _, file := range envelope.Files {
+			if file.ID == disk.FileRef && path.Clean(file.Href) == path.Clean(s.name) {
+				return disk, nil
+			}
+		}
+	}
+	return ovfDisk{}, errors.Errorf("OVA has no disk %s", s.name)
+}
+
+// isOVA returns true if hdr is the header of a tar archive starting with an OVF descriptor, like OVAs do
+func isOVA(hdr []byte) bool {
+	header, err := tar.NewReader(bytes.NewReader(hdr)).Next()
+	return err == nil && header.Typeflag == tar.TypeReg && strings.EqualFold(path.Ext(header.Name), ".ovf")
+}
+
+// getOVADiskFile returns the file holding the disk selected by diskSelector in the DiskSection of an OVF descriptor
+func getOVADiskFile(descriptor io.Reader, diskSelector ovaDiskSelector) (ovfFile, error) {
+	envelope := ovfEnvelope{}
+	if err := xml.NewDecoder(descriptor).Decode(&envelope); err != nil {
+		return ovfFile{}, errors.Wrap(err, "unable to parse OVF descriptor")
+	}
+	disk, err := diskSelector.find(&envelope)
+	if err != nil {
+		return ovfFile{}, err
+	}
+	if disk.FileRef == "" {
+		return ovfFile{}, errors.Errorf("disk %s of the OVA has no file, it is created empty", disk.DiskID)
+	}
+	for _, file := range envelope.Files {
+		if file.ID != disk.FileRef {
+			continue
+		}
+		if file.ChunkSize != "" {
+			return ovfFile{}, errors.Errorf("file %s of the OVA is split in chunks, which is not supported", file.Href)
+		}
+		if file.Compression != "" && file.Compression != "gzip" {
+			return ovfFile{}, errors.Errorf("file %s of the OVA has unsupported compression %s", file.Href, file.Compression)
+		}
+		return file, nil
+	}
+	return ovfFile{}, errors.Errorf("disk %s of the OVA references unknown file %s", disk.DiskID, disk.FileRef)
+}
+
+// parseOVAManifest returns the digests of the files of an OVA listed in its manifest
+func parseOVAManifest(r io.Reader) (map[string]ovaDigest, error) {
+	digests := map[string]ovaDigest{}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		match := ovaManifestLine.FindStringSubmatch(line)
+		if match == nil {
+			klog.Warningf("Ignoring OVA manifest line %q", line)
+			continue
+		}
+		digests[path.Clean(match[2])] = ovaDigest{algorithm: match[1], hex: strings.ToLower(match[3])}
+	}
+	return digests, errors.Wrap(scanner.Err(), "unable to read OVA manifest")
+}
+
+// extractOVADisk reads the OVA tar stream r and writes the disk selected by diskSelector to fileName, decompressing it
+// if needed. An OVA starts with its OVF descriptor, optionally followed by its manifest, so the disk is
+// found in a single pass. The disk is checked against the manifest digest when there is one.
+func extractOVADisk(r io.Reader, diskSelector ovaDiskSelector, fileName string, preallocation bool) error {
+	tr := tar.NewReader(r)
+	var disk *ovfFile
+	digests := map[string]ovaDigest{}
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return errors.Wrap(err, "unable to read OVA")
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		name := path.Clean(hdr.Name)
+		switch {
+		case disk == nil:
+			if !strings.EqualFold(path.Ext(name), ".ovf") {
+				return errors.Errorf("OVA does not start with an OVF descriptor but with %s", hdr.Name)
+			}
+			file, err := getOVADiskFile(tr, diskSelector)
+			if err != nil {
+				return err
+			}
+			disk = &file
+		case strings.EqualFold(path.Ext(name), ".mf"):
+			if digests, err = parseOVAManifest(tr); err != nil {
+				return err
+			}
+		case name == path.Clean(disk.Href):
+			digest, ok := digests[name]
+			if !ok {
+				klog.Warningf("OVA manifest has no digest of %s, not checking it", name)
+			}
+			return writeOVADisk(tr, name, digest, ok, fileName, preallocation)
+		}
+	}
+	if disk == nil {
+		return errors.New("OVA has no OVF descriptor")
+	}
+	return errors.Errorf("OVA has no file %s", disk.Href)
+}
+
+func writeOVADisk(r io.Reader, name string, digest ovaDigest, verify bool, fileName string, preallocation bool) error {
+	klog.Infof("Extracting disk %s of the OVA to %s", name, fileName)
+	h := digest.newHash()
+	reader := io.TeeReader(r, h)
+	fr, err := NewFormatReaders(io.NopCloser(reader), 0)
+	if err != nil {
+		return errors.Wrapf(err, "unable to read disk %s of the OVA", name)
+	}
+	defer fr.Close()
+	if _, _, err := StreamDataToFile(fr.TopReader(), fileName, preallocation); err != nil {
+		return err
+	}
+	if !verify {
+		return nil
+	}
+	// Decompressors may stop before the end of the file, the digest covers all of it
+	if _, err := io.Copy(io.Discard, reader); err != nil {
+		return errors.Wrap(err, "unable to read OVA")
+	}
+	if actual := hex.EncodeToString(h.Sum(nil)); actual != digest.hex {
+		return errors.Errorf("disk %s of the OVA does not match its %s digest in the manifest", name, digest.algorithm)
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+package importer
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"kubevirt.io/containerized-data-importer/pkg/common"
+)
+
+const testOVFDescriptor = `<?xml version="1.0" encoding="UTF-8"?>
+<Envelope xmlns="http://schemas.dmtf.org/ovf/envelope/1" xmlns:ovf="http://schemas.dmtf.org/ovf/envelope/1">
+  <References>
+    <File ovf:id="file1" ovf:href="vm-disk1.vmdk"/>
+    <File ovf:id="file2" ovf:href="vm-disk2.vmdk" ovf:compression="gzip"/>
+  </References>
+  <DiskSection>
+    <Info>Virtual disk information</Info>
+    <Disk ovf:diskId="vmdisk1" ovf:fileRef="file1" ovf:capacity="1"/>
+    <Disk ovf:diskId="vmdisk2" ovf:fileRef="file2" ovf:capacity="1"/>
+    <Disk ovf:diskId="vmdisk3" ovf:capacity="1"/>
+  </DiskSection>
+</Envelope>
+`
+
+type ovaFile struct {
+	name    string
+	content []byte
+}
+
+// writeOVA returns an OVA made of files, in the order they are given
+func writeOVA(files ...ovaFile) []byte {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for _, f := range files {
+		Expect(tw.WriteHeader(&tar.Header{Name: f.name, Mode: 0644, Size: int64(len(f.content)), Typeflag: tar.TypeReg})).To(Succeed())
+		_, err := tw.Write(f.content)
+		Expect(err).NotTo(HaveOccurred())
+	}
+	Expect(tw.Close()).To(Succeed())
+	return buf.Bytes()
+}
+
+var _ = Describe("OVA", func() {
+	var (
+		tmpDir   string
+		fileName string
+	)
+
+	disk1 := []byte("QFI\xfb" + string(bytes.Repeat([]byte{1}, 1024*1024)))
+	disk2 := bytes.Repeat([]byte{2}, 1024*1024)
+	var compressedDisk2 bytes.Buffer
+	gz := gzip.NewWriter(&compressedDisk2)
+	_, _ = gz.Write(disk2)
+	_ = gz.Close()
+	manifest := fmt.Sprintf("SHA256(vm-disk1.vmdk)= %x\nSHA256(vm-disk2.vmdk)= %x\n", sha256.Sum256(disk1), sha256.Sum256(compressedDisk2.Bytes()))
+
+	BeforeEach(func() {
+		var err error
+		tmpDir, err = os.MkdirTemp("", "ova")
+		Expect(err).NotTo(HaveOccurred())
+		fileName = filepath.Join(tmpDir, tempFile)
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(tmpDir)
+	})
+
+	DescribeTable("Should extract the disk at the index", func(diskIndex int, expected []byte) {
+		ova := writeOVA(
+			ovaFile{"vm.ovf", []byte(testOVFDescriptor)},
+			ovaFile{"vm.mf", []byte(manifest)},
+			ovaFile{"vm-disk1.vmdk", disk1},
+			ovaFile{"vm-disk2.vmdk", compressedDisk2.Bytes()},
+		)
+		Expect(extractOVADisk(bytes.NewReader(ova), ovaDiskSelector{index: diskIndex}, fileName, false)).To(Succeed())
+		content, err := os.ReadFile(fileName)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(content).To(Equal(expected))
+	},
+		Entry("first disk", 0, disk1),
+		Entry("second, compressed, disk", 1, disk2),
+	)
+
+	DescribeTable("Should extract the disk selected by", func(disk string, expected []byte) {
+		ova := writeOVA(
+			ovaFile{"vm.ovf", []byte(testOVFDescriptor)},
+			ovaFile{"vm-disk1.vmdk", disk1},
+			ovaFile{"vm-disk2.vmdk", compressedDisk2.Bytes()},
+		)
+		Expect(extractOVADisk(bytes.NewReader(ova), parseOVADiskSelector(disk), fileName, false)).To(Succeed())
+		content, err := os.ReadFile(fileName)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(content).To(Equal(expected))
+	},
+		Entry("its index", "1", disk2),
+		Entry("its disk id", "vmdisk2", disk2),
+		Entry("the name of its file", "vm-disk1.vmdk", disk1),
+	)
+
+	It("Should fail to extract a disk of an unknown name", func() {
+		err := extractOVADisk(bytes.NewReader(writeOVA(ovaFile{"vm.ovf", []byte(testOVFDescriptor)})), parseOVADiskSelector("vmdisk4"), fileName, false)
+		Expect(err).To(MatchError(ContainSubstring("OVA has no disk vmdisk4")))
+	})
+
+	DescribeTable("Should detect an OVA", func(files []ovaFile, expected bool) {
+		Expect(isOVA(writeOVA(files...))).To(Equal(expected))
+	},
+		Entry("starting with its descriptor", []ovaFile{{"vm.ovf", []byte(testOVFDescriptor)}}, true),
+		Entry("not from a tar archive starting with another file", []ovaFile{{"README", []byte("readme")}}, false),
+	)
+
+	It("Should extract a disk without a manifest", func() {
+		ova := writeOVA(ovaFile{"vm.ovf", []byte(testOVFDescriptor)}, ovaFile{"vm-disk1.vmdk", disk1})
+		Expect(extractOVADisk(bytes.NewReader(ova), ovaDiskSelector{}, fileName, false)).To(Succeed())
+	})
+
+	DescribeTable("Should fail to extract", func(diskIndex int, files []ovaFile, errSubstring string) {
+		err := extractOVADisk(bytes.NewReader(writeOVA(files...)), ovaDiskSelector{index: diskIndex}, fileName, false)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring(errSubstring))
+	},
+		Entry("a disk index out of range", 3, []ovaFile{{"vm.ovf", []byte(testOVFDescriptor)}}, "no disk at index 3"),
+		Entry("a disk without a file", 2, []ovaFile{{"vm.ovf", []byte(testOVFDescriptor)}}, "has no file"),
+		Entry("a missing file", 1, []ovaFile{{"vm.ovf", []byte(testOVFDescriptor)}, {"vm-disk1.vmdk", disk1}}, "OVA has no file vm-disk2.vmdk"),
+		Entry("an OVA not starting with its descriptor", 0, []ovaFile{{"vm-disk1.vmdk", disk1}, {"vm.ovf", []byte(testOVFDescriptor)}}, "does not start with an OVF descriptor"),
+		Entry("a disk not matching the manifest", 0, []ovaFile{
+			{"vm.ovf", []byte(testOVFDescriptor)},
+			{"vm.mf", []byte(fmt.Sprintf("SHA256(vm-disk1.vmdk)= %x\n", sha256.Sum256(disk2)))},
+			{"vm-disk1.vmdk", disk1},
+		}, "does not match its SHA256 digest"),
+	)
+
+	It("Should read the disk index from the environment", func() {
+		disk, err := getOVADisk()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(disk).To(BeNil())
+		GinkgoT().Setenv(common.ImporterOVADisk, "1")
+		disk, err = getOVADisk()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(*disk).To(Equal(1))
+		GinkgoT().Setenv(common.ImporterOVADisk, "-1")
+		_, err = getOVADisk()
+		Expect(err).To(HaveOccurred())
+	})
+})
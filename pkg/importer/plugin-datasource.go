@@ -0,0 +1,203 @@
+/*
+Copyright 2024 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package importer
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/url"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"k8s.io/klog/v2"
+
+	"kubevirt.io/containerized-data-importer/pkg/common"
+	"kubevirt.io/containerized-data-importer/pkg/sourceplugin"
+)
+
+// pluginConnectTimeout is how long the importer waits for the source plugin to serve its socket
+const pluginConnectTimeout = 5 * time.Minute
+
+// Helper for unit-testing
+var pluginSocket = common.ImporterPluginSocket
+
+// PluginDataSource is the data provider for external source plugins. The plugin runs in a container
+// next to the importer and streams the image over gRPC, on a unix socket shared with the importer.
+// Sequence of phases:
+// 1. Info -> Transfer, or TransferDataFile if the image is raw
+// 2. Transfer -> Convert
+type PluginDataSource struct {
+	conn *grpc.ClientConn
+	// The stream of the image served by the plugin
+	stream *pluginReader
+	// The size of the image, 0 if the plugin does not know it
+	size uint64
+	// stack of readers
+	readers *FormatReaders
+	// The url qemu-img converts from
+	url *url.URL
+}
+
+// pluginReader reads the chunks of the image streamed by the plugin
+type pluginReader struct {
+	stream grpc.ServerStreamingClient[sourceplugin.ReadResponse]
+	cancel context.CancelFunc
+	chunk  []byte
+}
+
+// NewPluginDataSource creates a new instance of the PluginDataSource, reading the image named by the
+// JSON encoded parameters from the source plugin.
+func NewPluginDataSource(parameters string) (*PluginDataSource, error) {
+	params := map[string]string{}
+	if parameters != "" {
+		if err := json.Unmarshal([]byte(parameters), &params); err != nil {
+			return nil, errors.Wrap(err, "unable to decode the source plugin parameters")
+		}
+	}
+	conn, err := grpc.NewClient("unix://"+filepath.Clean(pluginSocket), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to create the source plugin client")
+	}
+	client := sourceplugin.NewSourcePluginClient(conn)
+
+	// The plugin container is started with the importer, wait for it to serve its socket
+	ctx, cancel := context.WithTimeout(context.Background(), pluginConnectTimeout)
+	defer cancel()
+	info, err := client.Info(ctx, &sourceplugin.InfoRequest{Parameters: params}, grpc.WaitForReady(true))
+	if err != nil {
+		conn.Close()
+		return nil, errors.Wrap(err, "source plugin info request failed")
+	}
+	klog.V(1).Infof("Source plugin serves an image of %d bytes", info.GetSize())
+
+	readCtx, readCancel := context.WithCancel(context.Background())
+	stream, err := client.Read(readCtx, &sourceplugin.ReadRequest{Parameters: params})
+	if err != nil {
+		readCancel()
+		conn.Close()
+		return nil, errors.Wrap(err, "source plugin read request failed")
+	}
+	return &PluginDataSource{
+		conn:   conn,
+		stream: &pluginReader{stream: stream, cancel: readCancel},
+		size:   info.GetSize(),
+	}, nil
+}
+
+// Info is called to get initial information about the data.
+func (pd *PluginDataSource) Info() (ProcessingPhase, error) {
+	var err error
+	pd.readers, err = NewFormatReaders(pd.stream, pd.size)
+	if err != nil {
+		klog.Errorf("Plugin Importer: Error creating readers: %v", err)
+		return ProcessingPhaseError, err
+	}
+	if !pd.readers.Convert {
+		// Reading a raw image, we can write that directly to the target.
+		return ProcessingPhaseTransferDataFile, nil
+	}
+	return ProcessingPhaseTransferScratch, nil
+}
+
+// Transfer is called to stream the image to scratch space.
+func (pd *PluginDataSource) Transfer(path string, preallocation bool) (ProcessingPhase, error) {
+	if pd.readers == nil {
+		return ProcessingPhaseError, errors.New("plugin readers are not initialized")
+	}
+	file := filepath.Join(path, tempFile)
+	if err := CleanAll(file); err != nil {
+		return ProcessingPhaseError, err
+	}
+	if size, _ := GetAvailableSpace(path); size <= int64(0) {
+		return ProcessingPhaseError, ErrInvalidPath
+	}
+	if _, _, err := StreamDataToFile(pd.readers.TopReader(), file, preallocation); err != nil {
+		return ProcessingPhaseError, err
+	}
+	// If streaming succeeded, then parsing the file into URL will also succeed, no need to check error status
+	pd.url, _ = url.Parse(file)
+	return ProcessingPhaseConvert, nil
+}
+
+// TransferFile is called to stream a raw image to the passed in file.
+func (pd *PluginDataSource) TransferFile(fileName string, preallocation bool) (ProcessingPhase, error) {
+	if pd.readers == nil {
+		return ProcessingPhaseError, errors.New("plugin readers are not initialized")
+	}
+	if err := CleanAll(fileName); err != nil {
+		return ProcessingPhaseError, err
+	}
+	if _, _, err := StreamDataToFile(pd.readers.TopReader(), fileName, preallocation); err != nil {
+		return ProcessingPhaseError, err
+	}
+	return ProcessingPhaseResize, nil
+}
+
+// GetURL returns the url that the data processor can use when converting the data.
+func (pd *PluginDataSource) GetURL() *url.URL {
+	return pd.url
+}
+
+// GetTerminationMessage returns data to be serialized and used as the termination message of the importer.
+func (pd *PluginDataSource) GetTerminationMessage() *common.TerminationMessage {
+	return nil
+}
+
+// Close cancels the stream and closes the connection to the plugin.
+func (pd *PluginDataSource) Close() error {
+	var err error
+	if pd.readers != nil {
+		err = pd.readers.Close()
+	} else if pd.stream != nil {
+		err = pd.stream.Close()
+	}
+	if pd.conn != nil {
+		if connErr := pd.conn.Close(); err == nil {
+			err = connErr
+		}
+	}
+	return err
+}
+
+// Read copies the next bytes of the image, receiving a chunk from the plugin when the last one is consumed
+func (pr *pluginReader) Read(p []byte) (int, error) {
+	for len(pr.chunk) == 0 {
+		resp, err := pr.stream.Recv()
+		if err == io.EOF {
+			return 0, io.EOF
+		} else if err != nil {
+			return 0, errors.Wrap(err, "source plugin read failed")
+		}
+		pr.chunk = resp.GetData()
+	}
+	n := copy(p, pr.chunk)
+	pr.chunk = pr.chunk[n:]
+	return n, nil
+}
+
+// Close cancels the stream
+func (pr *pluginReader) Close() error {
+	pr.cancel()
+	return nil
+}
+
+var _ DataSourceInterface = &PluginDataSource{}
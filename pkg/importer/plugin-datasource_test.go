@@ -0,0 +1,125 @@
+package importer
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"google.golang.org/grpc"
+
+	"kubevirt.io/containerized-data-importer/pkg/sourceplugin"
+)
+
+// fakeSourcePlugin serves a file in chunks, like a source plugin would
+type fakeSourcePlugin struct {
+	sourceplugin.UnimplementedSourcePluginServer
+	file       string
+	parameters map[string]string
+}
+
+func (p *fakeSourcePlugin) Info(_ context.Context, req *sourceplugin.InfoRequest) (*sourceplugin.InfoResponse, error) {
+	p.parameters = req.GetParameters()
+	fi, err := os.Stat(p.file)
+	if err != nil {
+		return nil, err
+	}
+	return &sourceplugin.InfoResponse{Size: uint64(fi.Size())}, nil
+}
+
+func (p *fakeSourcePlugin) Read(_ *sourceplugin.ReadRequest, stream grpc.ServerStreamingServer[sourceplugin.ReadResponse]) error {
+	data, err := os.ReadFile(p.file)
+	if err != nil {
+		return err
+	}
+	for len(data) > 0 {
+		n := min(len(data), 1024*1024)
+		if err := stream.Send(&sourceplugin.ReadResponse{Data: data[:n]}); err != nil {
+			return err
+		}
+		data = data[n:]
+	}
+	return nil
+}
+
+var _ = Describe("Plugin data source", func() {
+	var (
+		pd         *PluginDataSource
+		plugin     *fakeSourcePlugin
+		server     *grpc.Server
+		tmpDir     string
+		origSocket string
+	)
+
+	BeforeEach(func() {
+		var err error
+		tmpDir, err = os.MkdirTemp("", "plugin")
+		Expect(err).NotTo(HaveOccurred())
+		origSocket = pluginSocket
+		pluginSocket = filepath.Join(tmpDir, "plugin.sock")
+		listener, err := net.Listen("unix", pluginSocket)
+		Expect(err).NotTo(HaveOccurred())
+		plugin = &fakeSourcePlugin{}
+		server = grpc.NewServer()
+		sourceplugin.RegisterSourcePluginServer(server, plugin)
+		go func() {
+			_ = server.Serve(listener)
+		}()
+		pd = nil
+	})
+
+	AfterEach(func() {
+		if pd != nil {
+			pd.Close()
+		}
+		server.Stop()
+		pluginSocket = origSocket
+		os.RemoveAll(tmpDir)
+	})
+
+	It("Should pass the parameters and write a raw image to the target", func() {
+		var err error
+		plugin.file = tinyCoreFilePath
+		pd, err = NewPluginDataSource(`{"volume":"vm-backup-0042"}`)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(plugin.parameters).To(Equal(map[string]string{"volume": "vm-backup-0042"}))
+		phase, err := pd.Info()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(phase).To(Equal(ProcessingPhaseTransferDataFile))
+		fileName := filepath.Join(tmpDir, "disk.img")
+		phase, err = pd.TransferFile(fileName, false)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(phase).To(Equal(ProcessingPhaseResize))
+		expected, err := os.ReadFile(tinyCoreFilePath)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(os.ReadFile(fileName)).To(Equal(expected))
+	})
+
+	It("Should copy a qcow2 image to scratch space", func() {
+		var err error
+		plugin.file = cirrosFilePath
+		pd, err = NewPluginDataSource("")
+		Expect(err).NotTo(HaveOccurred())
+		phase, err := pd.Info()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(phase).To(Equal(ProcessingPhaseTransferScratch))
+		phase, err = pd.Transfer(tmpDir, false)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(phase).To(Equal(ProcessingPhaseConvert))
+		Expect(pd.GetURL().String()).To(Equal(filepath.Join(tmpDir, tempFile)))
+	})
+
+	It("Should fail when the plugin can not serve the image", func() {
+		plugin.file = filepath.Join(tmpDir, "missing")
+		_, err := NewPluginDataSource("")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("Should fail on invalid parameters", func() {
+		_, err := NewPluginDataSource("not json")
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,316 @@
+/*
+Copyright 2024 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"k8s.io/klog/v2"
+
+	"kubevirt.io/containerized-data-importer/pkg/common"
+	"kubevirt.io/containerized-data-importer/pkg/image"
+)
+
+const (
+	proxmoxVMStopped      = "stopped"
+	proxmoxDefaultSSHUser = "root"
+)
+
+// proxmoxDiskKey matches the keys of the disks in the configuration of a VM, cdrom drives use the same keys
+var proxmoxDiskKey = regexp.MustCompile(`^(ide|sata|scsi|virtio)\d+$`)
+
+// ProxmoxDataSource is the data provider for disks of Proxmox VE VMs. The Proxmox VE API has no call
+// downloading a disk, so the importer resolves the volume of the disk and its path on the node with
+// the API token, and reads it from the node over SFTP through nbdkit, so qemu-img converts it without
+// staging it in scratch space.
+// Sequence of phases:
+// 1. Info -> Convert
+type ProxmoxDataSource struct {
+	// Path of the volume on the node
+	path string
+	// nbdkit serving the volume
+	n image.NbdkitOperation
+	// The url qemu-img reads from
+	url *url.URL
+}
+
+// proxmoxAPI calls the Proxmox VE API with an API token
+type proxmoxAPI struct {
+	client *http.Client
+	base   string
+	token  string
+}
+
+type proxmoxVMStatus struct {
+	Status string `json:"status"`
+}
+
+type proxmoxVolume struct {
+	Path string `json:"path"`
+}
+
+type proxmoxClusterStatus []struct {
+	Type string `json:"type"`
+	Name string `json:"name"`
+	IP   string `json:"ip"`
+}
+
+// NewProxmoxDataSource creates a new instance of the ProxmoxDataSource. endpoint is the url of the Proxmox VE API,
+// disk the key of the disk in the configuration of the VM, the disk of the boot order if it is empty, and the
+// API token and the SSH credentials of the node are read from credentialDir.
+func NewProxmoxDataSource(endpoint, node string, vmid int, disk, credentialDir, certDir string) (*ProxmoxDataSource, error) {
+	ep, err := ParseEndpoint(endpoint)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to parse endpoint %q", endpoint)
+	}
+	if ep.Scheme != "https" {
+		return nil, errors.Errorf("Proxmox url %s must be https", ep.Redacted())
+	}
+	if node == "" || vmid <= 0 {
+		return nil, errors.New("Proxmox source needs the node and the id of the VM")
+	}
+	if credentialDir == "" {
+		return nil, errors.New("Proxmox sources need a secret holding the API token and the SSH credentials of the node")
+	}
+	token, err := readProxmoxToken(credentialDir)
+	if err != nil {
+		return nil, err
+	}
+	client, err := createHTTPClient(certDir)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error creating http client for proxmox")
+	}
+	api := &proxmoxAPI{
+		client: client,
+		base:   strings.TrimSuffix(ep.String(), "/") + "/api2/json",
+		token:  token,
+	}
+	vmPath := fmt.Sprintf("nodes/%s/qemu/%d", url.PathEscape(node), vmid)
+	status := &proxmoxVMStatus{}
+	if err := api.get(status, vmPath+"/status/current"); err != nil {
+		return nil, err
+	}
+	// The disk of a running VM changes while it is read
+	if status.Status != proxmoxVMStopped {
+		return nil, errors.Errorf("Proxmox VM %d is %s, it must be stopped", vmid, status.Status)
+	}
+	config := map[string]interface{}{}
+	if err := api.get(&config, vmPath+"/config"); err != nil {
+		return nil, err
+	}
+	volumeID, err := getProxmoxVolumeID(config, disk)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Proxmox VM %d", vmid)
+	}
+	path, err := api.getVolumePath(node, volumeID)
+	if err != nil {
+		return nil, err
+	}
+	klog.V(3).Infof("Proxmox Importer: importing volume %s of VM %d from %s", volumeID, vmid, path)
+
+	args := image.NbdkitSSHArgs{
+		Host: api.getNodeAddress(node, ep.Hostname()),
+		User: proxmoxDefaultSSHUser,
+		Path: path,
+	}
+	if user, err := os.ReadFile(filepath.Join(credentialDir, common.KeyProxmoxSSHUser)); err == nil && strings.TrimSpace(string(user)) != "" {
+		args.User = strings.TrimSpace(string(user))
+	}
+	if err := readSFTPCredentials(credentialDir, &args); err != nil {
+		return nil, errors.Wrapf(err, "unable to read the SSH credentials of node %s", node)
+	}
+	n, err := createNbdkitSSH(nbdkitPid, nbdkitSocket, args)
+	if err != nil {
+		return nil, err
+	}
+	return &ProxmoxDataSource{
+		path: path,
+		n:    n,
+	}, nil
+}
+
+// readProxmoxToken returns the value of the Authorization header of the API token in credentialDir
+func readProxmoxToken(credentialDir string) (string, error) {
+	values := map[string]string{}
+	for _, key := range []string{common.KeyProxmoxTokenID, common.KeyProxmoxTokenSecret} {
+		content, err := os.ReadFile(filepath.Join(credentialDir, key))
+		if err != nil {
+			return "", errors.Wrapf(err, "Proxmox secret has no %s", key)
+		}
+		values[key] = strings.TrimSpace(string(content))
+		if values[key] == "" {
+			return "", errors.Errorf("Proxmox secret %s is empty", key)
+		}
+	}
+	// API tokens are named USER@REALM!TOKENID
+	if !strings.Contains(values[common.KeyProxmoxTokenID], "!") {
+		return "", errors.Errorf("Proxmox %s must be like user@realm!tokenid", common.KeyProxmoxTokenID)
+	}
+	return fmt.Sprintf("PVEAPIToken=%s=%s", values[common.KeyProxmoxTokenID], values[common.KeyProxmoxTokenSecret]), nil
+}
+
+// get decodes the data of the API response of path into out, the elements of path are escaped by the caller
+func (api *proxmoxAPI) get(out interface{}, path string) error {
+	req, err := http.NewRequest(http.MethodGet, api.base+"/"+path, nil)
+	if err != nil {
+		return errors.Wrap(err, "could not create Proxmox request")
+	}
+	req.Header.Set("Authorization", api.token)
+	resp, err := api.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "Proxmox request errored")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return errors.Errorf("Proxmox request %s failed: %s", path, resp.Status)
+	}
+	body := struct {
+		Data interface{} `json:"data"`
+	}{Data: out}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return errors.Wrapf(err, "unable to decode Proxmox response of %s", path)
+	}
+	return nil
+}
+
+// getVolumePath returns the path on the node of the volume, which is read over SFTP
+func (api *proxmoxAPI) getVolumePath(node, volumeID string) (string, error) {
+	// Disks passed through from the node are configured with their path
+	if strings.HasPrefix(volumeID, "/") {
+		return "", errors.Errorf("Proxmox disk %s is a device of the node, not a volume", volumeID)
+	}
+	storage, _, found := strings.Cut(volumeID, ":")
+	if !found {
+		return "", errors.Errorf("Proxmox volume %s has no storage", volumeID)
+	}
+	volume := &proxmoxVolume{}
+	volumePath := fmt.Sprintf("nodes/%s/storage/%s/content/%s", url.PathEscape(node), url.PathEscape(storage), url.PathEscape(volumeID))
+	if err := api.get(volume, volumePath); err != nil {
+		return "", err
+	}
+	// Ceph RBD and iSCSI storages return urls, not paths
+	if !strings.HasPrefix(volume.Path, "/") {
+		return "", errors.Errorf("Proxmox volume %s is not a file of the node: %s", volumeID, volume.Path)
+	}
+	// SFTP reports a size of 0 for devices, like the volumes of LVM and ZFS storages
+	if strings.HasPrefix(volume.Path, "/dev/") {
+		return "", errors.Errorf("Proxmox volume %s is a block device, move the disk to a directory, NFS or CIFS storage to import it", volumeID)
+	}
+	return volume.Path, nil
+}
+
+// getNodeAddress returns the address of node in the cluster, or fallback if the token can not read the cluster status
+func (api *proxmoxAPI) getNodeAddress(node, fallback string) string {
+	status := proxmoxClusterStatus{}
+	if err := api.get(&status, "cluster/status"); err != nil {
+		klog.Warningf("Unable to read the address of Proxmox node %s, connecting to %s: %v", node, fallback, err)
+		return fallback
+	}
+	for _, entry := range status {
+		if entry.Type == "node" && entry.Name == node && entry.IP != "" {
+			return entry.IP
+		}
+	}
+	return fallback
+}
+
+// getProxmoxVolumeID returns the volume of disk in the configuration of a VM, or of the first disk of its boot order
+func getProxmoxVolumeID(config map[string]interface{}, disk string) (string, error) {
+	if disk == "" {
+		disk = getProxmoxBootDisk(config)
+		if disk == "" {
+			return "", errors.New("has no disk in its boot order, set the disk to import")
+		}
+	}
+	value, ok := config[disk].(string)
+	if !ok || !proxmoxDiskKey.MatchString(disk) {
+		return "", errors.Errorf("has no disk %s", disk)
+	}
+	// Disks are configured as VOLUME,option=value,...
+	options := strings.Split(value, ",")
+	for _, option := range options[1:] {
+		if option == "media=cdrom" {
+			return "", errors.Errorf("disk %s is a cdrom drive", disk)
+		}
+	}
+	return options[0], nil
+}
+
+// getProxmoxBootDisk returns the first disk of the boot order of a VM, skipping cdrom drives and network interfaces
+func getProxmoxBootDisk(config map[string]interface{}) string {
+	boot, _ := config["boot"].(string)
+	// Boot orders are configured as order=scsi0;ide2;net0, older VMs name the boot disk in bootdisk
+	if order, found := strings.CutPrefix(boot, "order="); found {
+		for _, device := range strings.Split(order, ";") {
+			value, _ := config[device].(string)
+			if proxmoxDiskKey.MatchString(device) && value != "" && !strings.Contains(value, "media=cdrom") {
+				return device
+			}
+		}
+		return ""
+	}
+	bootDisk, _ := config["bootdisk"].(string)
+	return bootDisk
+}
+
+// Info is called to get initial information about the data.
+func (pd *ProxmoxDataSource) Info() (ProcessingPhase, error) {
+	pd.url, _ = url.Parse(fmt.Sprintf("nbd+unix:///?socket=%s", nbdkitSocket))
+	if err := pd.n.StartNbdkit(pd.path); err != nil {
+		return ProcessingPhaseError, err
+	}
+	return ProcessingPhaseConvert, nil
+}
+
+// Transfer is not used, the volume is converted straight from nbdkit.
+func (pd *ProxmoxDataSource) Transfer(path string, preallocation bool) (ProcessingPhase, error) {
+	return ProcessingPhaseError, errors.New("transfer is not supported for Proxmox sources")
+}
+
+// TransferFile is not used, the volume is converted straight from nbdkit.
+func (pd *ProxmoxDataSource) TransferFile(fileName string, preallocation bool) (ProcessingPhase, error) {
+	return ProcessingPhaseError, errors.New("transfer is not supported for Proxmox sources")
+}
+
+// GetURL returns the url that the data processor can use when converting the data.
+func (pd *ProxmoxDataSource) GetURL() *url.URL {
+	return pd.url
+}
+
+// GetTerminationMessage returns data to be serialized and used as the termination message of the importer.
+func (pd *ProxmoxDataSource) GetTerminationMessage() *common.TerminationMessage {
+	return nil
+}
+
+// Close stops nbdkit.
+func (pd *ProxmoxDataSource) Close() error {
+	if pd.n != nil {
+		return pd.n.KillNbdkit()
+	}
+	return nil
+}
+
+var _ DataSourceInterface = &ProxmoxDataSource{}
@@ -0,0 +1,179 @@
+package importer
+
+import (
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"kubevirt.io/containerized-data-importer/pkg/common"
+	"kubevirt.io/containerized-data-importer/pkg/image"
+)
+
+const testProxmoxToken = "PVEAPIToken=root@pam!cdi=5f2d1c3e-8a7b-4c6d-9e0f-1a2b3c4d5e6f"
+
+var _ = Describe("Proxmox data source", func() {
+	var (
+		ts             *httptest.Server
+		credDir        string
+		certDir        string
+		vmStatus       string
+		vmConfig       map[string]interface{}
+		volumes        map[string]string
+		clusterStatus  int
+		nbdkitArgs     image.NbdkitSSHArgs
+		origNbdkitFunc func(string, string, image.NbdkitSSHArgs) (image.NbdkitOperation, error)
+	)
+
+	writeCredential := func(key, value string) {
+		Expect(os.WriteFile(filepath.Join(credDir, key), []byte(value), 0600)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		vmStatus = proxmoxVMStopped
+		vmConfig = map[string]interface{}{
+			"boot":   "order=ide2;scsi0;net0",
+			"ide2":   "local:iso/debian-12.iso,media=cdrom",
+			"scsi0":  "nfs-images:100/vm-100-disk-0.qcow2,iothread=1,size=32G",
+			"scsi1":  "local-lvm:vm-100-disk-1,size=100G",
+			"cores":  2,
+			"net0":   "virtio=BC:24:11:AA:BB:CC,bridge=vmbr0",
+			"digest": "0123456789abcdef",
+		}
+		volumes = map[string]string{
+			"/api2/json/nodes/pve2/storage/nfs-images/content/nfs-images:100%2Fvm-100-disk-0.qcow2": "/mnt/pve/nfs-images/images/100/vm-100-disk-0.qcow2",
+			"/api2/json/nodes/pve2/storage/local-lvm/content/local-lvm:vm-100-disk-1":               "/dev/pve/vm-100-disk-1",
+		}
+		clusterStatus = http.StatusOK
+		ts = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") != testProxmoxToken {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			var data interface{}
+			switch r.URL.EscapedPath() {
+			case "/api2/json/nodes/pve2/qemu/100/status/current":
+				data = map[string]interface{}{"status": vmStatus, "vmid": 100}
+			case "/api2/json/nodes/pve2/qemu/100/config":
+				data = vmConfig
+			case "/api2/json/cluster/status":
+				if clusterStatus != http.StatusOK {
+					w.WriteHeader(clusterStatus)
+					return
+				}
+				data = []map[string]interface{}{
+					{"type": "cluster", "name": "homelab"},
+					{"type": "node", "name": "pve1", "ip": "192.168.1.11"},
+					{"type": "node", "name": "pve2", "ip": "192.168.1.12"},
+				}
+			default:
+				path, ok := volumes[r.URL.EscapedPath()]
+				if !ok {
+					http.NotFound(w, r)
+					return
+				}
+				data = map[string]interface{}{"path": path, "format": "qcow2", "size": 34359738368}
+			}
+			Expect(json.NewEncoder(w).Encode(map[string]interface{}{"data": data})).To(Succeed())
+		}))
+
+		var err error
+		credDir, err = os.MkdirTemp("", "proxmox-creds")
+		Expect(err).NotTo(HaveOccurred())
+		certDir, err = os.MkdirTemp("", "proxmox-ca")
+		Expect(err).NotTo(HaveOccurred())
+		serverCert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ts.Certificate().Raw})
+		Expect(os.WriteFile(filepath.Join(certDir, "ca.pem"), serverCert, 0600)).To(Succeed())
+		writeCredential(common.KeyProxmoxTokenID, "root@pam!cdi\n")
+		writeCredential(common.KeyProxmoxTokenSecret, "5f2d1c3e-8a7b-4c6d-9e0f-1a2b3c4d5e6f")
+		writeCredential(common.KeySFTPKnownHosts, "192.168.1.12 ssh-ed25519 AAAA\n")
+		writeCredential(common.KeySFTPPrivateKey, "key")
+
+		nbdkitArgs = image.NbdkitSSHArgs{}
+		origNbdkitFunc = createNbdkitSSH
+		createNbdkitSSH = func(pidFile, socket string, args image.NbdkitSSHArgs) (image.NbdkitOperation, error) {
+			nbdkitArgs = args
+			return image.NewMockNbdkitSSH(pidFile, socket, args)
+		}
+	})
+
+	AfterEach(func() {
+		createNbdkitSSH = origNbdkitFunc
+		ts.Close()
+		os.RemoveAll(credDir)
+		os.RemoveAll(certDir)
+	})
+
+	It("Should read the boot disk from its node over SFTP", func() {
+		pd, err := NewProxmoxDataSource(ts.URL, "pve2", 100, "", credDir, certDir)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nbdkitArgs).To(Equal(image.NbdkitSSHArgs{
+			Host:           "192.168.1.12",
+			User:           proxmoxDefaultSSHUser,
+			Path:           "/mnt/pve/nfs-images/images/100/vm-100-disk-0.qcow2",
+			IdentityFile:   filepath.Join(credDir, common.KeySFTPPrivateKey),
+			KnownHostsFile: filepath.Join(credDir, common.KeySFTPKnownHosts),
+		}))
+		phase, err := pd.Info()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(phase).To(Equal(ProcessingPhaseConvert))
+		Expect(pd.GetURL().String()).To(Equal("nbd+unix:///?socket=" + nbdkitSocket))
+		Expect(pd.Close()).To(Succeed())
+	})
+
+	It("Should use the SSH user of the secret and fall back to the API host", func() {
+		clusterStatus = http.StatusForbidden
+		writeCredential(common.KeyProxmoxSSHUser, "cdi\n")
+		_, err := NewProxmoxDataSource(ts.URL, "pve2", 100, "scsi0", credDir, certDir)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nbdkitArgs.Host).To(Equal("127.0.0.1"))
+		Expect(nbdkitArgs.User).To(Equal("cdi"))
+	})
+
+	It("Should use the bootdisk of older VMs", func() {
+		vmConfig["boot"] = "cdn"
+		vmConfig["bootdisk"] = "scsi0"
+		_, err := NewProxmoxDataSource(ts.URL, "pve2", 100, "", credDir, certDir)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nbdkitArgs.Path).To(Equal("/mnt/pve/nfs-images/images/100/vm-100-disk-0.qcow2"))
+	})
+
+	DescribeTable("Should fail with", func(disk string, update func(), errSubstring string) {
+		if update != nil {
+			update()
+		}
+		_, err := NewProxmoxDataSource(ts.URL, "pve2", 100, disk, credDir, certDir)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring(errSubstring))
+	},
+		Entry("a running VM", "", func() { vmStatus = "running" }, "VM 100 is running, it must be stopped"),
+		Entry("a wrong token", "", func() { writeCredential(common.KeyProxmoxTokenSecret, "other") }, "401 Unauthorized"),
+		Entry("a token id without token name", "", func() { writeCredential(common.KeyProxmoxTokenID, "root@pam") }, "must be like user@realm!tokenid"),
+		Entry("a missing token secret", "", func() { Expect(os.Remove(filepath.Join(credDir, common.KeyProxmoxTokenSecret))).To(Succeed()) }, "Proxmox secret has no tokenSecret"),
+		Entry("an untrusted server", "", func() { Expect(os.Remove(filepath.Join(certDir, "ca.pem"))).To(Succeed()) }, "certificate"),
+		Entry("a missing disk", "virtio3", nil, "has no disk virtio3"),
+		Entry("a cdrom drive", "ide2", nil, "disk ide2 is a cdrom drive"),
+		Entry("a block device volume", "scsi1", nil, "is a block device"),
+		Entry("no disk in the boot order", "", func() { vmConfig["boot"] = "order=ide2;net0" }, "has no disk in its boot order"),
+		Entry("a disk passed through from the node", "scsi1", func() { vmConfig["scsi1"] = "/dev/disk/by-id/ata-WDC_WD40,size=4T" }, "is a device of the node"),
+		Entry("missing SSH credentials", "", func() { Expect(os.Remove(filepath.Join(credDir, common.KeySFTPKnownHosts))).To(Succeed()) }, "unable to read the SSH credentials of node pve2"),
+	)
+
+	It("Should refuse to send the token over plain http", func() {
+		_, err := NewProxmoxDataSource("http://127.0.0.1:8006", "pve2", 100, "", credDir, certDir)
+		Expect(err).To(MatchError(ContainSubstring("must be https")))
+	})
+
+	DescribeTable("Should find the boot disk", func(config map[string]interface{}, disk string) {
+		Expect(getProxmoxBootDisk(config)).To(Equal(disk))
+	},
+		Entry("skipping cdrom drives", map[string]interface{}{"boot": "order=ide2;virtio0", "ide2": "none,media=cdrom", "virtio0": "local:100/vm-100-disk-0.raw"}, "virtio0"),
+		Entry("skipping network interfaces", map[string]interface{}{"boot": "order=net0;sata1", "net0": "virtio=BC:24:11:AA:BB:CC", "sata1": "local:100/vm-100-disk-1.raw"}, "sata1"),
+		Entry("of VMs without boot order", map[string]interface{}{}, ""),
+	)
+})
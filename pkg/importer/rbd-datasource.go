@@ -0,0 +1,139 @@
+/*
+Copyright 2024 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package importer
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"k8s.io/klog/v2"
+
+	"kubevirt.io/containerized-data-importer/pkg/common"
+)
+
+const defaultRBDUser = "admin"
+
+// RBDDataSource is the data provider for RBD images of external Ceph clusters. qemu-img reads
+// the image with its rbd driver, so it is converted without staging it in scratch space.
+// Sequence of phases:
+// 1. Info -> Convert
+type RBDDataSource struct {
+	// Comma separated addresses of the Ceph monitors
+	monitors string
+	// pool/image[@snapshot] to import
+	image string
+	// Ceph user, without its client. prefix
+	user string
+	// Path of the keyring of the user
+	keyring string
+	// Directory holding the generated ceph.conf
+	confDir string
+	// The url qemu-img reads from
+	url *url.URL
+}
+
+// NewRBDDataSource creates a new instance of the RBDDataSource. monitors is the comma separated list of
+// the Ceph monitor addresses, and image is the pool/image[@snapshot] to import. The keyring and the
+// optional user are read from credentialDir.
+func NewRBDDataSource(monitors, image, credentialDir string) (*RBDDataSource, error) {
+	if monitors == "" {
+		return nil, errors.New("RBD source has no Ceph monitors")
+	}
+	if pool, name, _ := strings.Cut(image, "/"); pool == "" || name == "" || strings.HasPrefix(name, "@") {
+		return nil, errors.Errorf("RBD image %q is not pool/image[@snapshot]", image)
+	}
+	if strings.Contains(image, ":") {
+		return nil, errors.Errorf("RBD image %q must not contain ':'", image)
+	}
+	if credentialDir == "" {
+		return nil, errors.New("RBD sources need a secret holding the Ceph keyring")
+	}
+	keyring := filepath.Join(credentialDir, common.KeyRBDKeyring)
+	if _, err := os.Stat(keyring); err != nil {
+		return nil, errors.Wrapf(err, "RBD secret has no %s", common.KeyRBDKeyring)
+	}
+	user := defaultRBDUser
+	if content, err := os.ReadFile(filepath.Join(credentialDir, common.KeyRBDUserID)); err == nil {
+		user = strings.TrimPrefix(strings.TrimSpace(string(content)), "client.")
+	} else if !os.IsNotExist(err) {
+		return nil, errors.Wrapf(err, "unable to read RBD credential %s", common.KeyRBDUserID)
+	}
+	if user == "" || strings.Contains(user, ":") {
+		return nil, errors.Errorf("RBD user %q is not valid", user)
+	}
+	klog.V(3).Infof("RBD Importer: importing %s as client.%s", image, user)
+	return &RBDDataSource{
+		monitors: monitors,
+		image:    image,
+		user:     user,
+		keyring:  keyring,
+	}, nil
+}
+
+// Info is called to get initial information about the data. It writes the ceph.conf qemu's rbd
+// driver connects with, the monitor addresses would otherwise need escaping in the filename.
+func (rd *RBDDataSource) Info() (ProcessingPhase, error) {
+	if rd.confDir == "" {
+		dir, err := os.MkdirTemp("", "ceph")
+		if err != nil {
+			return ProcessingPhaseError, errors.Wrap(err, "unable to create the ceph.conf directory")
+		}
+		rd.confDir = dir
+	}
+	conf := filepath.Join(rd.confDir, "ceph.conf")
+	content := fmt.Sprintf("[global]\nmon_host = %s\nkeyring = %s\n", rd.monitors, rd.keyring)
+	if err := os.WriteFile(conf, []byte(content), 0600); err != nil {
+		return ProcessingPhaseError, errors.Wrap(err, "unable to write ceph.conf")
+	}
+	rd.url = &url.URL{Scheme: "rbd", Opaque: fmt.Sprintf("%s:conf=%s:id=%s", rd.image, conf, rd.user)}
+	return ProcessingPhaseConvert, nil
+}
+
+// Transfer is not used, the image is converted straight from the Ceph cluster.
+func (rd *RBDDataSource) Transfer(path string, preallocation bool) (ProcessingPhase, error) {
+	return ProcessingPhaseError, errors.New("transfer is not supported for RBD sources")
+}
+
+// TransferFile is not used, the image is converted straight from the Ceph cluster.
+func (rd *RBDDataSource) TransferFile(fileName string, preallocation bool) (ProcessingPhase, error) {
+	return ProcessingPhaseError, errors.New("transfer is not supported for RBD sources")
+}
+
+// GetURL returns the url that the data processor can use when converting the data.
+func (rd *RBDDataSource) GetURL() *url.URL {
+	return rd.url
+}
+
+// GetTerminationMessage returns data to be serialized and used as the termination message of the importer.
+func (rd *RBDDataSource) GetTerminationMessage() *common.TerminationMessage {
+	return nil
+}
+
+// Close removes the generated ceph.conf.
+func (rd *RBDDataSource) Close() error {
+	if rd.confDir != "" {
+		return os.RemoveAll(rd.confDir)
+	}
+	return nil
+}
+
+var _ DataSourceInterface = &RBDDataSource{}
@@ -0,0 +1,70 @@
+package importer
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"kubevirt.io/containerized-data-importer/pkg/common"
+)
+
+var _ = Describe("RBD data source", func() {
+	var tmpDir string
+
+	writeCredential := func(key, value string) {
+		Expect(os.WriteFile(filepath.Join(tmpDir, key), []byte(value), 0600)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		var err error
+		tmpDir, err = os.MkdirTemp("", "rbd")
+		Expect(err).NotTo(HaveOccurred())
+		writeCredential(common.KeyRBDKeyring, "[client.admin]\n\tkey = AQBzhQ==\n")
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(tmpDir)
+	})
+
+	It("Should have qemu-img read the image through a generated ceph.conf", func() {
+		writeCredential(common.KeyRBDUserID, "client.cdi\n")
+		rd, err := NewRBDDataSource("192.168.1.10:6789,[fd00::10]:3300", "vms/disk@base", tmpDir)
+		Expect(err).NotTo(HaveOccurred())
+		phase, err := rd.Info()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(phase).To(Equal(ProcessingPhaseConvert))
+		conf := filepath.Join(rd.confDir, "ceph.conf")
+		Expect(rd.GetURL().String()).To(Equal("rbd:vms/disk@base:conf=" + conf + ":id=cdi"))
+		Expect(os.ReadFile(conf)).To(BeEquivalentTo("[global]\nmon_host = 192.168.1.10:6789,[fd00::10]:3300\nkeyring = " +
+			filepath.Join(tmpDir, common.KeyRBDKeyring) + "\n"))
+		Expect(rd.Close()).To(Succeed())
+		Expect(rd.confDir).ToNot(BeADirectory())
+	})
+
+	It("Should connect as admin without a user", func() {
+		rd, err := NewRBDDataSource("192.168.1.10", "vms/disk", tmpDir)
+		Expect(err).NotTo(HaveOccurred())
+		defer rd.Close()
+		Expect(rd.user).To(Equal("admin"))
+	})
+
+	DescribeTable("Should fail with", func(monitors, image string, update func(), errSubstring string) {
+		if update != nil {
+			update()
+		}
+		_, err := NewRBDDataSource(monitors, image, tmpDir)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring(errSubstring))
+	},
+		Entry("no monitors", "", "vms/disk", nil, "has no Ceph monitors"),
+		Entry("no pool", "192.168.1.10", "disk", nil, "is not pool/image[@snapshot]"),
+		Entry("only a snapshot", "192.168.1.10", "vms/@base", nil, "is not pool/image[@snapshot]"),
+		Entry("options in the image", "192.168.1.10", "vms/disk:conf=/etc/ceph.conf", nil, "must not contain ':'"),
+		Entry("no keyring", "192.168.1.10", "vms/disk", func() {
+			Expect(os.Remove(filepath.Join(tmpDir, common.KeyRBDKeyring))).To(Succeed())
+		}, "RBD secret has no keyring"),
+		Entry("an invalid user", "192.168.1.10", "vms/disk", func() { writeCredential(common.KeyRBDUserID, "cdi:x") }, "is not valid"),
+	)
+})
@@ -23,9 +23,11 @@ import (
 	"strings"
 
 	"github.com/containers/image/v5/types"
+	"github.com/opencontainers/go-digest"
 	"github.com/pkg/errors"
 
 	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
 
 	"kubevirt.io/containerized-data-importer/pkg/common"
 )
@@ -52,6 +54,10 @@ type RegistryDataSource struct {
 	url *url.URL
 	//The discovered image info from the registry.
 	info *types.ImageInspectInfo
+	//The digest the image reference resolved to.
+	digest digest.Digest
+	//The digest of the image of the platform copied.
+	platformDigest digest.Digest
 }
 
 // NewRegistryDataSource creates a new instance of the Registry Data Source.
@@ -99,7 +105,7 @@ func (rd *RegistryDataSource) Transfer(path string, preallocation bool) (Process
 	}
 
 	klog.V(1).Infof("Copying registry image to scratch space.")
-	rd.info, err = CopyRegistryImage(rd.endpoint, path, containerDiskImageDir, rd.accessKey, rd.secKey, rd.imageArchitecture, rd.certDir, rd.insecureTLS, preallocation)
+	rd.info, rd.digest, rd.platformDigest, err = copyRegistryImage(rd.endpoint, path, containerDiskImageDir, rd.accessKey, rd.secKey, rd.imageArchitecture, rd.certDir, rd.insecureTLS, true, preallocation)
 	if err != nil {
 		return ProcessingPhaseError, errors.Wrapf(err, "Failed to read registry image")
 	}
@@ -125,14 +131,30 @@ func (rd *RegistryDataSource) GetURL() *url.URL {
 	return rd.url
 }
 
+// ObservedSourceDigest returns the digest the registry image resolved to
+func (rd *RegistryDataSource) ObservedSourceDigest() string {
+	return rd.digest.String()
+}
+
 // GetTerminationMessage returns data to be serialized and used as the termination message of the importer.
 func (rd *RegistryDataSource) GetTerminationMessage() *common.TerminationMessage {
 	if rd.info == nil {
 		return nil
 	}
-	return &common.TerminationMessage{
+	termMsg := &common.TerminationMessage{
 		Labels: envsToLabels(rd.info.Env),
 	}
+	if rd.digest != "" {
+		termMsg.SourceDigest = ptr.To(rd.digest.String())
+	}
+	// Artifacts have no image config and no architecture
+	if rd.info.Architecture != "" {
+		termMsg.ImportedPlatform = &common.ImportedPlatform{
+			Architecture: rd.info.Architecture,
+			Digest:       rd.platformDigest.String(),
+		}
+	}
+	return termMsg
 }
 
 // Close closes any readers or other open resources.
@@ -193,6 +215,10 @@ func CreateCertificateDir(registryCertDir string) (string, error) {
 		return allCerts, err
 	}
 
+	if err := linkClientCertificate(allCerts); err != nil {
+		return allCerts, err
+	}
+
 	if registryCertDir == "" {
 		klog.Info("Registry certs directory not configured")
 		return allCerts, nil
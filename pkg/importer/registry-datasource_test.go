@@ -9,6 +9,8 @@ import (
 	. "github.com/onsi/gomega"
 
 	"github.com/containers/image/v5/types"
+
+	"kubevirt.io/containerized-data-importer/pkg/common"
 )
 
 var (
@@ -88,6 +90,30 @@ var _ = Describe("Registry data source", func() {
 		Expect(termMesg.Labels).To(HaveKeyWithValue("instancetype.kubevirt.io/default-preference", "fedora"))
 	})
 
+	It("GetTerminationMessage should contain the digest the image resolved to", func() {
+		source := "oci-archive:" + imageFile
+		expected, err := GetImageDigest(source, "", "", "", true)
+		Expect(err).NotTo(HaveOccurred())
+		ds = NewRegistryDataSource(source, "", "", "", "", true)
+		_, err = ds.Transfer(tmpDir, false)
+		Expect(err).NotTo(HaveOccurred())
+
+		termMesg := ds.GetTerminationMessage()
+		Expect(termMesg).ToNot(BeNil())
+		Expect(termMesg.SourceDigest).To(HaveValue(Equal(expected)))
+		Expect(expected).To(HavePrefix("sha256:"))
+	})
+
+	It("GetTerminationMessage should contain the platform of the image", func() {
+		ds = NewRegistryDataSource("", "", "", "", "", true)
+		ds.info = &types.ImageInspectInfo{Architecture: "arm64"}
+		ds.platformDigest = "sha256:1234"
+
+		termMesg := ds.GetTerminationMessage()
+		Expect(termMesg).ToNot(BeNil())
+		Expect(termMesg.ImportedPlatform).To(HaveValue(Equal(common.ImportedPlatform{Architecture: "arm64", Digest: "sha256:1234"})))
+	})
+
 	It("getImageFileName should return an error with non-existing image directory", func() {
 		_, err := getImageFileName("/invalid")
 		Expect(err).To(HaveOccurred())
@@ -0,0 +1,155 @@
+/*
+Copyright 2024 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/pkg/errors"
+
+	"k8s.io/klog/v2"
+)
+
+const downloadStateSuffix = ".download"
+
+// downloadCheckpointSize is how much is downloaded between saves of the download state, a var for unit-testing
+var downloadCheckpointSize int64 = 64 * 1024 * 1024
+
+// downloadState is saved next to a partial download, so an importer pod that is restarted can continue
+// the download instead of starting over.
+type downloadState struct {
+	URL string `json:"url"`
+	// Validator is the ETag or Last-Modified the endpoint had when the download started
+	Validator string `json:"validator"`
+	Size      uint64 `json:"size"`
+	// Offset is how much of the download is synced to disk
+	Offset int64 `json:"offset"`
+}
+
+func downloadStateFile(fileName string) string {
+	return fileName + downloadStateSuffix
+}
+
+// getResumeOffset returns how much of the partial download in fileName can be kept, 0 if the download
+// has to start over. The file is truncated to the part that was synced to disk.
+func getResumeOffset(fileName string, want downloadState) int64 {
+	content, err := os.ReadFile(downloadStateFile(fileName))
+	if err != nil {
+		return 0
+	}
+	var state downloadState
+	if err := json.Unmarshal(content, &state); err != nil {
+		klog.Warningf("Ignoring invalid download state of %s: %v", fileName, err)
+		return 0
+	}
+	if state.URL != want.URL || state.Validator != want.Validator || state.Size != want.Size {
+		klog.V(1).Infof("The endpoint changed since %s was partially downloaded, starting over", fileName)
+		return 0
+	}
+	info, err := os.Stat(fileName)
+	if err != nil || state.Offset <= 0 || info.Size() < state.Offset || uint64(state.Offset) > state.Size {
+		return 0
+	}
+	if err := os.Truncate(fileName, state.Offset); err != nil {
+		klog.Warningf("Unable to truncate %s to the synced part of the download: %v", fileName, err)
+		return 0
+	}
+	return state.Offset
+}
+
+// writeResumableFile writes r to fileName at state.Offset. The file is synced and the state saved every
+// downloadCheckpointSize bytes, both are left behind if the download fails so it can be resumed.
+// Blocks of zeros are appended with truncate rather than written when sparse is set.
+func writeResumableFile(r io.Reader, fileName string, state downloadState, sparse bool) error {
+	flags := os.O_CREATE | os.O_WRONLY
+	if state.Offset == 0 {
+		flags |= os.O_TRUNC
+	}
+	outFile, err := os.OpenFile(fileName, flags, os.ModePerm)
+	if err != nil {
+		return errors.Wrapf(err, "could not open file %q", fileName)
+	}
+	defer outFile.Close()
+	if _, err := outFile.Seek(state.Offset, io.SeekStart); err != nil {
+		return errors.Wrapf(err, "could not seek to byte %d of %q", state.Offset, fileName)
+	}
+	klog.Infof("Writing the download to %s from byte %d", fileName, state.Offset)
+	for {
+		n, err := copyCheckpoint(outFile, r, state.Offset, sparse)
+		state.Offset += n
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			klog.Errorf("Unable to write file from dataReader: %v\n", err)
+			if IsNoCapacityError(err) {
+				return fmt.Errorf("unable to write to file: %w", err)
+			}
+			return NewImagePullFailedError(err)
+		}
+		if err := saveDownloadState(outFile, state); err != nil {
+			return err
+		}
+	}
+	if err := outFile.Sync(); err != nil {
+		return err
+	}
+	klog.Infof("Downloaded %d bytes to %s", state.Offset, fileName)
+	return removeDownloadState(fileName)
+}
+
+// copyCheckpoint copies up to downloadCheckpointSize bytes of r to outFile, whose content ends at offset.
+// It returns io.EOF once r is exhausted, like io.CopyN.
+func copyCheckpoint(outFile *os.File, r io.Reader, offset int64, sparse bool) (int64, error) {
+	if !sparse {
+		return io.CopyN(outFile, r, downloadCheckpointSize)
+	}
+	zeroWriter := func(dst *os.File, start, length int64) error {
+		return appendZeroWithTruncateFunc(dst, offset+start, length)
+	}
+	n, _, err := copyWithSparseCheck(outFile, io.LimitReader(r, downloadCheckpointSize), zeroWriter)
+	if err == nil && n < downloadCheckpointSize {
+		err = io.EOF
+	}
+	return n, err
+}
+
+// saveDownloadState syncs the file, so the state never claims more than what is on disk
+func saveDownloadState(outFile *os.File, state downloadState) error {
+	if err := outFile.Sync(); err != nil {
+		return errors.Wrapf(err, "could not sync %q", outFile.Name())
+	}
+	content, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	stateFile := downloadStateFile(outFile.Name())
+	if err := os.WriteFile(stateFile+".tmp", content, 0600); err != nil {
+		return errors.Wrap(err, "could not save the download state")
+	}
+	return errors.Wrap(os.Rename(stateFile+".tmp", stateFile), "could not save the download state")
+}
+
+func removeDownloadState(fileName string) error {
+	if err := os.Remove(downloadStateFile(fileName)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package importer
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"testing/iotest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/pkg/errors"
+)
+
+var _ = Describe("Resumable download", func() {
+	var (
+		tmpDir        string
+		fileName      string
+		origChunkSize int64
+	)
+
+	state := downloadState{URL: "http://example.com/disk.img", Validator: `"etag"`, Size: 4096}
+
+	writeState := func(s downloadState) {
+		content, err := json.Marshal(s)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(os.WriteFile(downloadStateFile(fileName), content, 0600)).To(Succeed())
+	}
+
+	readState := func() downloadState {
+		content, err := os.ReadFile(downloadStateFile(fileName))
+		Expect(err).NotTo(HaveOccurred())
+		var s downloadState
+		Expect(json.Unmarshal(content, &s)).To(Succeed())
+		return s
+	}
+
+	BeforeEach(func() {
+		var err error
+		tmpDir, err = os.MkdirTemp("", "resume")
+		Expect(err).NotTo(HaveOccurred())
+		fileName = filepath.Join(tmpDir, tempFile)
+		origChunkSize = downloadCheckpointSize
+		downloadCheckpointSize = 1024
+	})
+
+	AfterEach(func() {
+		downloadCheckpointSize = origChunkSize
+		os.RemoveAll(tmpDir)
+	})
+
+	It("Should resume from the synced offset and truncate what came after it", func() {
+		Expect(os.WriteFile(fileName, make([]byte, 3000), 0600)).To(Succeed())
+		saved := state
+		saved.Offset = 2048
+		writeState(saved)
+		Expect(getResumeOffset(fileName, state)).To(BeEquivalentTo(2048))
+		info, err := os.Stat(fileName)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(info.Size()).To(BeEquivalentTo(2048))
+	})
+
+	DescribeTable("Should start over", func(modify func(*downloadState), fileSize int) {
+		Expect(os.WriteFile(fileName, make([]byte, fileSize), 0600)).To(Succeed())
+		saved := state
+		saved.Offset = 2048
+		modify(&saved)
+		writeState(saved)
+		Expect(getResumeOffset(fileName, state)).To(BeZero())
+	},
+		Entry("if the url changed", func(s *downloadState) { s.URL = "http://example.com/other.img" }, 3000),
+		Entry("if the validator changed", func(s *downloadState) { s.Validator = `"other"` }, 3000),
+		Entry("if the size changed", func(s *downloadState) { s.Size = 8192 }, 3000),
+		Entry("if the file is shorter than the offset", func(s *downloadState) {}, 1000),
+		Entry("if nothing was synced", func(s *downloadState) { s.Offset = 0 }, 3000),
+	)
+
+	It("Should start over without a saved state", func() {
+		Expect(os.WriteFile(fileName, make([]byte, 3000), 0600)).To(Succeed())
+		Expect(getResumeOffset(fileName, state)).To(BeZero())
+	})
+
+	It("Should write at the offset and remove the state once done", func() {
+		data := bytes.Repeat([]byte("0123456789abcdef"), 256)
+		Expect(os.WriteFile(fileName, data[:2048], 0600)).To(Succeed())
+		resumed := state
+		resumed.Offset = 2048
+		Expect(writeResumableFile(bytes.NewReader(data[2048:]), fileName, resumed, false)).To(Succeed())
+		result, err := os.ReadFile(fileName)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(Equal(data))
+		_, err = os.Stat(downloadStateFile(fileName))
+		Expect(os.IsNotExist(err)).To(BeTrue())
+	})
+
+	It("Should leave the blocks of zeros as holes when resuming a sparse download", func() {
+		data := bytes.Repeat([]byte("0123456789abcdef"), 256)
+		copy(data[1024:2560], make([]byte, 1536))
+		copy(data[3584:], make([]byte, 512))
+		Expect(os.WriteFile(fileName, data[:1024], 0600)).To(Succeed())
+		resumed := state
+		resumed.Offset = 1024
+		Expect(writeResumableFile(bytes.NewReader(data[1024:]), fileName, resumed, true)).To(Succeed())
+		result, err := os.ReadFile(fileName)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(Equal(data))
+	})
+
+	It("Should keep the file and the last checkpoint if the download fails", func() {
+		data := bytes.Repeat([]byte("0123456789abcdef"), 256)
+		r := io.MultiReader(bytes.NewReader(data[:2500]), iotest.ErrReader(errors.New("connection reset")))
+		err := writeResumableFile(r, fileName, state, false)
+		Expect(err).To(HaveOccurred())
+		Expect(readState().Offset).To(BeEquivalentTo(2048))
+		Expect(getResumeOffset(fileName, state)).To(BeEquivalentTo(2048))
+		result, err := os.ReadFile(fileName)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(Equal(data[:2048]))
+	})
+})
@@ -1,14 +1,22 @@
 package importer
 
 import (
+	"context"
+	"fmt"
 	"io"
+	"net/http"
 	"net/url"
+	"os"
+	"path"
 	"path/filepath"
 	"regexp"
 	"strings"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/aws/aws-sdk-go/aws/endpoints"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/pkg/errors"
@@ -21,62 +29,107 @@ import (
 const (
 	s3FolderSep = "/"
 	httpScheme  = "http"
+
+	s3RoleSessionName = "cdi-importer"
+	defaultSTSRegion  = "us-east-1"
 )
 
 // S3Client is the interface to the used S3 client.
 type S3Client interface {
-	GetObject(input *s3.GetObjectInput) (*s3.GetObjectOutput, error)
+	GetObjectWithContext(ctx aws.Context, input *s3.GetObjectInput, opts ...request.Option) (*s3.GetObjectOutput, error)
 }
 
 // may be overridden in tests
-var newClientFunc = getS3Client
+var (
+	newClientFunc = getS3Client
+	stsEndpoint   = ""
+)
+
+// S3Credentials holds the values of the S3 secret. All of them are optional, without access keys the
+// default AWS credential chain is used, which includes the web identity token of IAM roles for service
+// accounts. If RoleARN is set the role is assumed with STS on top of those credentials.
+type S3Credentials struct {
+	AccessKey    string
+	SecretKey    string
+	SessionToken string
+	RoleARN      string
+	ExternalID   string
+}
 
 // S3DataSource is the struct containing the information needed to import from an S3 data source.
 // Sequence of phases:
-// 1. Info -> Transfer
-// 2. Transfer -> Convert
+// 1a. Info -> TransferDataFile if the object is a raw image.
+// 1b. Info -> Transfer in all other cases, VMDK descriptors included, their extents are fetched next to them.
+// 2.  Transfer -> Convert
 type S3DataSource struct {
 	// S3 end point
 	ep *url.URL
-	// User name
-	accessKey string
-	// Password
-	secKey string
+	// Credentials
+	creds S3Credentials
 	// Reader
 	s3Reader io.ReadCloser
 	// stack of readers
 	readers *FormatReaders
 	// The image file in scratch space.
 	url *url.URL
+	// set if the object is a VMDK descriptor, its extents are then fetched with fetchExtent
+	vmdkDescriptor bool
+	fetchExtent    vmdkExtentFetcher
+	// verifies the object if its checksum or its signature is set, nil otherwise
+	verifier *downloadVerifier
+	// throttles the download if the maximum bandwidth of the import is set, nil otherwise
+	bandwidth *bandwidthLimiter
 }
 
 // NewS3DataSource creates a new instance of the S3DataSource
-func NewS3DataSource(endpoint, accessKey, secKey string, certDir string) (*S3DataSource, error) {
+func NewS3DataSource(endpoint string, creds S3Credentials, certDir string) (*S3DataSource, error) {
 	ep, err := ParseEndpoint(endpoint)
 	if err != nil {
 		return nil, errors.Wrapf(err, "unable to parse endpoint %q", endpoint)
 	}
-	s3Reader, err := createS3Reader(ep, accessKey, secKey, certDir)
+	bandwidth, err := getBandwidthLimiter()
+	if err != nil {
+		return nil, err
+	}
+	verifier, err := getDownloadVerifier(ep, func(signatureURL *url.URL) (io.ReadCloser, error) {
+		return createS3Reader(signatureURL, creds, certDir)
+	})
+	if err != nil {
+		return nil, err
+	}
+	s3Reader, err := createS3Reader(ep, creds, certDir)
 	if err != nil {
 		return nil, err
 	}
 	return &S3DataSource{
 		ep:        ep,
-		accessKey: accessKey,
-		secKey:    secKey,
+		creds:     creds,
 		s3Reader:  s3Reader,
+		verifier:  verifier,
+		bandwidth: bandwidth,
+		fetchExtent: func(name string) (io.ReadCloser, error) {
+			// Extents are objects with the same prefix as the descriptor
+			extentEp := *ep
+			extentEp.Path = path.Join(path.Dir(ep.Path), name)
+			reader, err := createS3Reader(&extentEp, creds, certDir)
+			return bandwidth.wrap(reader), err
+		},
 	}, nil
 }
 
 // Info is called to get initial information about the data.
 func (sd *S3DataSource) Info() (ProcessingPhase, error) {
 	var err error
-	sd.readers, err = NewFormatReaders(sd.s3Reader, uint64(0))
+	sd.readers, err = NewFormatReaders(sd.bandwidth.wrap(sd.verifier.wrap(sd.s3Reader)), uint64(0))
 	if err != nil {
 		klog.Errorf("Error creating readers: %v", err)
 		return ProcessingPhaseError, err
 	}
-	if !sd.readers.Convert {
+	if isVMDKDescriptor(sd.readers.buf) {
+		sd.vmdkDescriptor = true
+		return ProcessingPhaseTransferScratch, nil
+	}
+	if !sd.readers.Convert && !isComparedWithSource() {
 		// Downloading a raw file, we can write that directly to the target.
 		return ProcessingPhaseTransferDataFile, nil
 	}
@@ -96,11 +149,25 @@ func (sd *S3DataSource) Transfer(path string, preallocation bool) (ProcessingPha
 		//Path provided is invalid.
 		return ProcessingPhaseError, ErrInvalidPath
 	}
+	if sd.vmdkDescriptor {
+		descriptor, err := transferVMDKDescriptor(sd.readers.TopReader(), path, sd.fetchExtent, preallocation)
+		if err != nil {
+			return ProcessingPhaseError, err
+		}
+		if err := sd.verifier.verify(); err != nil {
+			return ProcessingPhaseError, err
+		}
+		sd.url, _ = url.Parse(descriptor)
+		return ProcessingPhaseConvert, nil
+	}
 
 	_, _, err := StreamDataToFile(sd.readers.TopReader(), file, preallocation)
 	if err != nil {
 		return ProcessingPhaseError, err
 	}
+	if err := sd.verifier.verify(); err != nil {
+		return ProcessingPhaseError, err
+	}
 	// If streaming succeeded, then parsing the file into URL will also succeed, no need to check error status
 	sd.url, _ = url.Parse(file)
 	return ProcessingPhaseConvert, nil
@@ -116,6 +183,9 @@ func (sd *S3DataSource) TransferFile(fileName string, preallocation bool) (Proce
 	if err != nil {
 		return ProcessingPhaseError, err
 	}
+	if err := sd.verifier.verify(); err != nil {
+		return ProcessingPhaseError, err
+	}
 	return ProcessingPhaseResize, nil
 }
 
@@ -124,6 +194,11 @@ func (sd *S3DataSource) GetURL() *url.URL {
 	return sd.url
 }
 
+// ObservedSourceDigest returns the digest of the download found by the checksum of the source
+func (sd *S3DataSource) ObservedSourceDigest() string {
+	return sd.verifier.observedDigest()
+}
+
 // GetTerminationMessage returns data to be serialized and used as the termination message of the importer.
 func (sd *S3DataSource) GetTerminationMessage() *common.TerminationMessage {
 	return nil
@@ -138,7 +213,7 @@ func (sd *S3DataSource) Close() error {
 	return err
 }
 
-func createS3Reader(ep *url.URL, accessKey, secKey string, certDir string) (io.ReadCloser, error) {
+func createS3Reader(ep *url.URL, creds S3Credentials, certDir string) (io.ReadCloser, error) {
 	klog.V(3).Infoln("Using S3 client to get data")
 
 	endpoint := ep.Host
@@ -149,7 +224,7 @@ func createS3Reader(ep *url.URL, accessKey, secKey string, certDir string) (io.R
 
 	klog.V(1).Infof("bucket %s", bucket)
 	klog.V(1).Infof("object %s", object)
-	svc, err := newClientFunc(endpoint, accessKey, secKey, certDir, urlScheme)
+	svc, err := newClientFunc(endpoint, creds, certDir, urlScheme)
 	if err != nil {
 		return nil, errors.Wrapf(err, "could not build s3 client for %q", ep.Host)
 	}
@@ -158,15 +233,42 @@ func createS3Reader(ep *url.URL, accessKey, secKey string, certDir string) (io.R
 		Bucket: aws.String(bucket),
 		Key:    aws.String(object),
 	}
-	objOutput, err := svc.GetObject(objInput)
+	objOutput, err := svc.GetObjectWithContext(context.Background(), objInput)
 	if err != nil {
 		return nil, errors.Wrapf(err, "could not get s3 object: \"%s/%s\"", bucket, object)
 	}
 	objectReader := objOutput.Body
+	if sd := getSegmentedDownload(); objOutput.ContentLength != nil && sd.enabled(*objOutput.ContentLength) {
+		fetch := func(ctx context.Context, offset, length int64) (io.ReadCloser, error) {
+			return getS3Range(ctx, svc, bucket, object, offset, length)
+		}
+		if probeRanges(context.Background(), fetch) {
+			objectReader.Close()
+			objectReader = newSegmentedReader(context.Background(), *objOutput.ContentLength, sd, fetch)
+		}
+	}
 	return objectReader, nil
 }
 
-func getS3Client(endpoint, accessKey, secKey string, certDir string, urlScheme string) (S3Client, error) {
+// getS3Range returns a reader for length bytes of the object, starting at offset
+func getS3Range(ctx context.Context, svc S3Client, bucket, object string, offset, length int64) (io.ReadCloser, error) {
+	objOutput, err := svc.GetObjectWithContext(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(object),
+		Range:  aws.String(fmt.Sprintf("bytes=%d-%d", offset, offset+length-1)),
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not get s3 object: \"%s/%s\"", bucket, object)
+	}
+	if objOutput.ContentRange == nil {
+		// Stores ignoring the range return the whole object
+		objOutput.Body.Close()
+		return nil, errors.Errorf("s3 object \"%s/%s\" was returned without a content range", bucket, object)
+	}
+	return objOutput.Body, nil
+}
+
+func getS3Client(endpoint string, s3Creds S3Credentials, certDir string, urlScheme string) (S3Client, error) {
 	// Adding certs using CustomCABundle will overwrite the SystemCerts, so we opt by creating a custom HTTPClient
 	httpClient, err := createHTTPClient(certDir)
 
@@ -174,7 +276,10 @@ func getS3Client(endpoint, accessKey, secKey string, certDir string, urlScheme s
 		return nil, errors.Wrap(err, "Error creating http client for s3")
 	}
 
-	creds := credentials.NewStaticCredentials(accessKey, secKey, "")
+	creds, err := getS3Credentials(endpoint, s3Creds, httpClient)
+	if err != nil {
+		return nil, err
+	}
 	region := extractRegion(endpoint)
 	disableSSL := false
 	// Disable SSL for http endpoint. This should cause the s3 client to create http requests.
@@ -199,6 +304,54 @@ func getS3Client(endpoint, accessKey, secKey string, certDir string, urlScheme s
 	return svc, nil
 }
 
+// getS3Credentials returns the credentials the S3 requests are signed with, nil means the default chain
+func getS3Credentials(endpoint string, s3Creds S3Credentials, httpClient *http.Client) (*credentials.Credentials, error) {
+	var creds *credentials.Credentials
+	if s3Creds.AccessKey != "" || s3Creds.SecretKey != "" {
+		klog.V(3).Infoln("S3 Importer: Authentication: access keys")
+		creds = credentials.NewStaticCredentials(s3Creds.AccessKey, s3Creds.SecretKey, s3Creds.SessionToken)
+	} else {
+		klog.V(3).Infoln("S3 Importer: Authentication: default credential chain")
+	}
+	if s3Creds.RoleARN == "" {
+		return creds, nil
+	}
+
+	klog.V(3).Infof("S3 Importer: assuming role %s", s3Creds.RoleARN)
+	config := &aws.Config{
+		Region:              aws.String(getSTSRegion(endpoint)),
+		Credentials:         creds,
+		HTTPClient:          httpClient,
+		STSRegionalEndpoint: endpoints.RegionalSTSEndpoint,
+	}
+	if stsEndpoint != "" {
+		config.Endpoint = aws.String(stsEndpoint)
+	}
+	sess, err := session.NewSession(config)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create STS session")
+	}
+	return stscreds.NewCredentials(sess, s3Creds.RoleARN, func(p *stscreds.AssumeRoleProvider) {
+		p.RoleSessionName = s3RoleSessionName
+		if s3Creds.ExternalID != "" {
+			p.ExternalID = aws.String(s3Creds.ExternalID)
+		}
+	}), nil
+}
+
+// getSTSRegion returns the region of an AWS S3 endpoint, other endpoints, like S3 compatible
+// object stores, have no STS of their own so AWS_REGION or the global default is used.
+func getSTSRegion(endpoint string) string {
+	r, _ := regexp.Compile(`s3\.(.+)\.amazonaws\.com`)
+	if matches := r.FindStringSubmatch(endpoint); matches != nil {
+		return matches[1]
+	}
+	if region := os.Getenv("AWS_REGION"); region != "" {
+		return region
+	}
+	return defaultSTSRegion
+}
+
 func extractRegion(s string) string {
 	var region string
 	r, _ := regexp.Compile(`s3\.(.+)\.amazonaws\.com`)
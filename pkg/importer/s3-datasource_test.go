@@ -1,16 +1,28 @@
 package importer
 
 import (
+	"bytes"
+	"context"
+	"fmt"
 	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"os"
 	"path/filepath"
 	"reflect"
+	"strings"
+	"sync"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/pkg/errors"
+
+	"kubevirt.io/containerized-data-importer/pkg/common"
 )
 
 var _ = Describe("S3 data source", func() {
@@ -36,25 +48,25 @@ var _ = Describe("S3 data source", func() {
 	})
 
 	It("NewS3DataSource should Error, when passed in an invalid endpoint", func() {
-		sd, err = NewS3DataSource("thisisinvalid#$%#ep", "", "", "")
+		sd, err = NewS3DataSource("thisisinvalid#$%#ep", S3Credentials{}, "")
 		Expect(err).To(HaveOccurred())
 	})
 
 	It("NewS3DataSource should Error, when failing to create S3 client", func() {
 		newClientFunc = failMockS3Client
-		sd, err = NewS3DataSource("http://amazon.com", "", "", "")
+		sd, err = NewS3DataSource("http://amazon.com", S3Credentials{}, "")
 		Expect(err).To(HaveOccurred())
 	})
 
 	It("NewS3DataSource should Error, when failing to get object", func() {
 		newClientFunc = createErrMockS3Client
-		sd, err = NewS3DataSource("http://amazon.com", "", "", "")
+		sd, err = NewS3DataSource("http://amazon.com", S3Credentials{}, "")
 		Expect(err).To(HaveOccurred())
 	})
 
 	It("NewS3DataSource should fail when called with an invalid certdir", func() {
 		newClientFunc = getS3Client
-		sd, err = NewS3DataSource("http://amazon.com", "", "", "/invaliddir")
+		sd, err = NewS3DataSource("http://amazon.com", S3Credentials{}, "/invaliddir")
 		Expect(err).To(HaveOccurred())
 	})
 
@@ -64,7 +76,7 @@ var _ = Describe("S3 data source", func() {
 		Expect(err).NotTo(HaveOccurred())
 		err = file.Close()
 		Expect(err).NotTo(HaveOccurred())
-		sd, err = NewS3DataSource("http://region.amazon.com/bucket-1/object-1", "", "", "")
+		sd, err = NewS3DataSource("http://region.amazon.com/bucket-1/object-1", S3Credentials{}, "")
 		Expect(err).NotTo(HaveOccurred())
 		sd.s3Reader = file
 		result, err := sd.Info()
@@ -76,7 +88,7 @@ var _ = Describe("S3 data source", func() {
 		// Don't need to defer close, since ud.Close will close the reader
 		file, err := os.Open(cirrosFilePath)
 		Expect(err).NotTo(HaveOccurred())
-		sd, err = NewS3DataSource("http://region.amazon.com/bucket-1/object-1", "", "", "")
+		sd, err = NewS3DataSource("http://region.amazon.com/bucket-1/object-1", S3Credentials{}, "")
 		Expect(err).NotTo(HaveOccurred())
 		sd.s3Reader = file
 		result, err := sd.Info()
@@ -88,7 +100,7 @@ var _ = Describe("S3 data source", func() {
 		// Don't need to defer close, since ud.Close will close the reader
 		file, err := os.Open(tinyCoreFilePath)
 		Expect(err).NotTo(HaveOccurred())
-		sd, err = NewS3DataSource("http://region.amazon.com/bucket-1/object-1", "", "", "")
+		sd, err = NewS3DataSource("http://region.amazon.com/bucket-1/object-1", S3Credentials{}, "")
 		Expect(err).NotTo(HaveOccurred())
 		sd.s3Reader = file
 		result, err := sd.Info()
@@ -103,7 +115,7 @@ var _ = Describe("S3 data source", func() {
 		sourceFile, err := os.Open(fileName)
 		Expect(err).NotTo(HaveOccurred())
 
-		sd, err = NewS3DataSource("http://region.amazon.com/bucket-1/object-1", "", "", "")
+		sd, err = NewS3DataSource("http://region.amazon.com/bucket-1/object-1", S3Credentials{}, "")
 		Expect(err).NotTo(HaveOccurred())
 		// Replace minio.Object with a reader we can use.
 		sd.s3Reader = sourceFile
@@ -137,7 +149,7 @@ var _ = Describe("S3 data source", func() {
 		sourceFile, err := os.Open(cirrosFilePath)
 		Expect(err).NotTo(HaveOccurred())
 
-		sd, err = NewS3DataSource("http://region.amazon.com/bucket-1/object-1", "", "", "")
+		sd, err = NewS3DataSource("http://region.amazon.com/bucket-1/object-1", S3Credentials{}, "")
 		Expect(err).NotTo(HaveOccurred())
 		// Replace minio.Object with a reader we can use.
 		sd.s3Reader = sourceFile
@@ -155,7 +167,7 @@ var _ = Describe("S3 data source", func() {
 		// Don't need to defer close, since ud.Close will close the reader
 		file, err := os.Open(tinyCoreFilePath)
 		Expect(err).NotTo(HaveOccurred())
-		sd, err = NewS3DataSource("http://region.amazon.com/bucket-1/object-1", "", "", "")
+		sd, err = NewS3DataSource("http://region.amazon.com/bucket-1/object-1", S3Credentials{}, "")
 		Expect(err).NotTo(HaveOccurred())
 		// Replace minio.Object with a reader we can use.
 		sd.s3Reader = file
@@ -171,7 +183,7 @@ var _ = Describe("S3 data source", func() {
 		// Don't need to defer close, since ud.Close will close the reader
 		file, err := os.Open(tinyCoreFilePath)
 		Expect(err).NotTo(HaveOccurred())
-		sd, err = NewS3DataSource("http://region.amazon.com/bucket-1/object-1", "", "", "")
+		sd, err = NewS3DataSource("http://region.amazon.com/bucket-1/object-1", S3Credentials{}, "")
 		Expect(err).NotTo(HaveOccurred())
 		// Replace minio.Object with a reader we can use.
 		sd.s3Reader = file
@@ -183,8 +195,23 @@ var _ = Describe("S3 data source", func() {
 		Expect(ProcessingPhaseError).To(Equal(result))
 	})
 
+	It("TransferFile should fail if the object does not match its checksum", func() {
+		GinkgoT().Setenv(common.ImporterChecksum, "sha1:0123456789abcdef0123456789abcdef01234567")
+		file, err := os.Open(tinyCoreFilePath)
+		Expect(err).NotTo(HaveOccurred())
+		sd, err = NewS3DataSource("http://region.amazon.com/bucket-1/object-1", S3Credentials{}, "")
+		Expect(err).NotTo(HaveOccurred())
+		sd.s3Reader = file
+		result, err := sd.Info()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ProcessingPhaseTransferDataFile).To(Equal(result))
+		result, err = sd.TransferFile(filepath.Join(tmpDir, "file"), false)
+		Expect(err).To(MatchError(ContainSubstring(common.ChecksumMismatchText)))
+		Expect(ProcessingPhaseError).To(Equal(result))
+	})
+
 	It("GetS3Client should return a real client", func() {
-		_, err := getS3Client("", "", "", "", "")
+		_, err := getS3Client("", S3Credentials{}, "", "")
 		Expect(err).NotTo(HaveOccurred())
 	})
 
@@ -197,39 +224,210 @@ var _ = Describe("S3 data source", func() {
 		Expect(bucket).Should(Equal("Bucket1"))
 		Expect(object).Should(Equal("Folder1/Object.tmp"))
 	})
+
+	It("NewS3DataSource should pass all the secret values to the client", func() {
+		var clientCreds S3Credentials
+		newClientFunc = func(endpoint string, creds S3Credentials, certDir string, urlScheme string) (S3Client, error) {
+			clientCreds = creds
+			return createMockS3Client(endpoint, creds, certDir, urlScheme)
+		}
+		creds := S3Credentials{AccessKey: "key", SecretKey: "secret", SessionToken: "token", RoleARN: "role", ExternalID: "id"}
+		sd, err = NewS3DataSource("http://region.amazon.com/bucket-1/object-1", creds, "")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(clientCreds).To(Equal(creds))
+	})
+
+	DescribeTable("getSTSRegion should return", func(endpoint, envRegion, want string) {
+		if envRegion != "" {
+			os.Setenv("AWS_REGION", envRegion)
+			defer os.Unsetenv("AWS_REGION")
+		}
+		Expect(getSTSRegion(endpoint)).To(Equal(want))
+	},
+		Entry("the region of an AWS endpoint", "s3.eu-west-1.amazonaws.com", "us-west-2", "eu-west-1"),
+		Entry("AWS_REGION for other endpoints", "minio.example.com", "us-west-2", "us-west-2"),
+		Entry("the default without AWS_REGION", "minio.example.com", "", defaultSTSRegion),
+	)
+
+	It("NewS3DataSource should download the object in parts when parallelism is set", func() {
+		os.Setenv(common.ImporterDownloadParallelism, "4")
+		os.Setenv(common.ImporterDownloadPartSize, "1Mi")
+		defer os.Unsetenv(common.ImporterDownloadParallelism)
+		defer os.Unsetenv(common.ImporterDownloadPartSize)
+		content := []byte(strings.Repeat("s3 object data..", 5*1024*1024/16))
+		client := &rangeMockS3Client{content: content}
+		newClientFunc = func(endpoint string, creds S3Credentials, certDir string, urlScheme string) (S3Client, error) {
+			return client, nil
+		}
+		sd, err = NewS3DataSource("http://region.amazon.com/bucket-1/object-1", S3Credentials{}, "")
+		Expect(err).NotTo(HaveOccurred())
+		result, err := io.ReadAll(sd.s3Reader)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(Equal(content))
+		// The parts are downloaded once the range support is probed
+		Expect(client.ranges).To(HaveLen(6))
+		Expect(client.ranges).To(ContainElements("bytes=0-0", "bytes=1048576-2097151"))
+	})
+
+	It("NewS3DataSource should download the object with a single request if the store ignores ranges", func() {
+		os.Setenv(common.ImporterDownloadParallelism, "4")
+		os.Setenv(common.ImporterDownloadPartSize, "1Mi")
+		defer os.Unsetenv(common.ImporterDownloadParallelism)
+		defer os.Unsetenv(common.ImporterDownloadPartSize)
+		content := []byte(strings.Repeat("s3 object data..", 5*1024*1024/16))
+		client := &rangeMockS3Client{content: content, ignoreRanges: true}
+		newClientFunc = func(endpoint string, creds S3Credentials, certDir string, urlScheme string) (S3Client, error) {
+			return client, nil
+		}
+		sd, err = NewS3DataSource("http://region.amazon.com/bucket-1/object-1", S3Credentials{}, "")
+		Expect(err).NotTo(HaveOccurred())
+		result, err := io.ReadAll(sd.s3Reader)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(Equal(content))
+	})
+
+	It("getS3Range should stop when its context is canceled", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err := getS3Range(ctx, &rangeMockS3Client{content: []byte("data")}, "bucket-1", "object-1", 0, 2)
+		Expect(err).To(MatchError(ContainSubstring(context.Canceled.Error())))
+	})
+
+	Context("credentials", func() {
+		var (
+			ts      *httptest.Server
+			stsForm url.Values
+			origSTS string
+			denied  bool
+		)
+
+		BeforeEach(func() {
+			stsForm = nil
+			denied = false
+			ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.ParseForm()
+				stsForm = r.Form
+				if denied {
+					w.WriteHeader(http.StatusForbidden)
+					fmt.Fprint(w, `<ErrorResponse><Error><Code>AccessDenied</Code></Error></ErrorResponse>`)
+					return
+				}
+				fmt.Fprint(w, `<AssumeRoleResponse xmlns="https://sts.amazonaws.com/doc/2011-06-15/">
+<AssumeRoleResult><Credentials>
+<AccessKeyId>assumedKey</AccessKeyId><SecretAccessKey>assumedSecret</SecretAccessKey>
+<SessionToken>assumedToken</SessionToken><Expiration>2099-01-01T00:00:00Z</Expiration>
+</Credentials></AssumeRoleResult></AssumeRoleResponse>`)
+			}))
+			origSTS = stsEndpoint
+			stsEndpoint = ts.URL
+		})
+
+		AfterEach(func() {
+			stsEndpoint = origSTS
+			ts.Close()
+		})
+
+		It("Should use the access keys and session token", func() {
+			creds, err := getS3Credentials("s3.amazonaws.com", S3Credentials{AccessKey: "key", SecretKey: "secret", SessionToken: "token"}, http.DefaultClient)
+			Expect(err).NotTo(HaveOccurred())
+			value, err := creds.Get()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(value.AccessKeyID).To(Equal("key"))
+			Expect(value.SecretAccessKey).To(Equal("secret"))
+			Expect(value.SessionToken).To(Equal("token"))
+		})
+
+		It("Should use the default chain without access keys", func() {
+			creds, err := getS3Credentials("s3.amazonaws.com", S3Credentials{}, http.DefaultClient)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(creds).To(BeNil())
+		})
+
+		It("Should assume the role with the external ID", func() {
+			creds, err := getS3Credentials("s3.amazonaws.com", S3Credentials{AccessKey: "key", SecretKey: "secret", RoleARN: "arn:aws:iam::123456789012:role/importer", ExternalID: "external"}, http.DefaultClient)
+			Expect(err).NotTo(HaveOccurred())
+			value, err := creds.Get()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stsForm.Get("Action")).To(Equal("AssumeRole"))
+			Expect(stsForm.Get("RoleArn")).To(Equal("arn:aws:iam::123456789012:role/importer"))
+			Expect(stsForm.Get("ExternalId")).To(Equal("external"))
+			Expect(stsForm.Get("RoleSessionName")).To(Equal(s3RoleSessionName))
+			Expect(value.AccessKeyID).To(Equal("assumedKey"))
+			Expect(value.SessionToken).To(Equal("assumedToken"))
+		})
+
+		It("Should fail if the role can not be assumed", func() {
+			denied = true
+			creds, err := getS3Credentials("s3.amazonaws.com", S3Credentials{AccessKey: "key", SecretKey: "secret", RoleARN: "arn:aws:iam::123456789012:role/importer"}, http.DefaultClient)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = creds.Get()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("AccessDenied"))
+		})
+	})
 })
 
 // MockS3Client is a mock AWS S3 client
 type MockS3Client struct {
 	endpoint string //nolint:unused // TODO: check if need to remove this field
-	accKey   string
-	secKey   string
+	creds    S3Credentials
 	certDir  string
 	doErr    bool
 }
 
-func failMockS3Client(endpoint, accKey, secKey string, certDir string, urlScheme string) (S3Client, error) {
+func failMockS3Client(endpoint string, creds S3Credentials, certDir string, urlScheme string) (S3Client, error) {
 	return nil, errors.New("Failed to create client")
 }
 
-func createMockS3Client(endpoint, accKey, secKey string, certDir string, urlScheme string) (S3Client, error) {
+func createMockS3Client(endpoint string, creds S3Credentials, certDir string, urlScheme string) (S3Client, error) {
 	return &MockS3Client{
-		accKey:  accKey,
-		secKey:  secKey,
+		creds:   creds,
 		certDir: certDir,
 		doErr:   false,
 	}, nil
 }
 
-func createErrMockS3Client(endpoint, accKey, secKey string, certDir string, urlScheme string) (S3Client, error) {
+func createErrMockS3Client(endpoint string, creds S3Credentials, certDir string, urlScheme string) (S3Client, error) {
 	return &MockS3Client{
 		doErr: true,
 	}, nil
 }
 
-func (mc *MockS3Client) GetObject(input *s3.GetObjectInput) (*s3.GetObjectOutput, error) {
+func (mc *MockS3Client) GetObjectWithContext(_ aws.Context, input *s3.GetObjectInput, _ ...request.Option) (*s3.GetObjectOutput, error) {
 	if !mc.doErr {
 		return &s3.GetObjectOutput{}, nil
 	}
 	return nil, errors.New("Failed to get object")
 }
+
+// rangeMockS3Client serves an object from memory and records the ranges requested
+type rangeMockS3Client struct {
+	content []byte
+	lock    sync.Mutex
+	ranges  []string
+	// ignoreRanges returns the whole object, like stores without range support
+	ignoreRanges bool
+}
+
+func (mc *rangeMockS3Client) GetObjectWithContext(ctx aws.Context, input *s3.GetObjectInput, _ ...request.Option) (*s3.GetObjectOutput, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if input.Range == nil || mc.ignoreRanges {
+		return &s3.GetObjectOutput{
+			Body:          io.NopCloser(bytes.NewReader(mc.content)),
+			ContentLength: aws.Int64(int64(len(mc.content))),
+		}, nil
+	}
+	mc.lock.Lock()
+	mc.ranges = append(mc.ranges, *input.Range)
+	mc.lock.Unlock()
+	var start, end int
+	if _, err := fmt.Sscanf(*input.Range, "bytes=%d-%d", &start, &end); err != nil {
+		return nil, err
+	}
+	return &s3.GetObjectOutput{
+		Body:         io.NopCloser(bytes.NewReader(mc.content[start : end+1])),
+		ContentRange: aws.String(fmt.Sprintf("bytes %d-%d/%d", start, end, len(mc.content))),
+	}, nil
+}
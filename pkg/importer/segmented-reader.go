@@ -0,0 +1,207 @@
+/*
+Copyright 2024 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package importer
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/pkg/errors"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/klog/v2"
+
+	"kubevirt.io/containerized-data-importer/pkg/common"
+)
+
+const (
+	defaultDownloadPartSize = 64 * 1024 * 1024
+	minDownloadPartSize     = 1024 * 1024
+	// downloadPartRetries is how many times a part is fetched before the download fails
+	downloadPartRetries = 3
+	// downloadBufferShare is the share of the memory limit of the importer the parts waiting to be read can take
+	downloadBufferShare = 2
+)
+
+// rangeFetcher returns a reader for length bytes of the source, starting at offset
+type rangeFetcher func(ctx context.Context, offset, length int64) (io.ReadCloser, error)
+
+// segmentedDownload holds how a source is split into byte ranges that are downloaded concurrently
+type segmentedDownload struct {
+	parallelism int
+	partSize    int64
+}
+
+type downloadPart struct {
+	data []byte
+	err  error
+}
+
+// segmentedReader downloads parts of the source concurrently and returns them in order. At most
+// parallelism parts are downloaded, or waiting to be read, at a time.
+type segmentedReader struct {
+	ctx     context.Context
+	cancel  context.CancelFunc
+	parts   chan chan downloadPart
+	current *bytes.Reader
+	err     error
+}
+
+// getSegmentedDownload returns the parallel download settings of the importer pod, parallelism
+// is 1 if parts are not downloaded concurrently.
+func getSegmentedDownload() segmentedDownload {
+	sd := segmentedDownload{parallelism: 1, partSize: defaultDownloadPartSize}
+	if value := os.Getenv(common.ImporterDownloadParallelism); value != "" {
+		parallelism, err := strconv.Atoi(value)
+		if err != nil || parallelism < 1 {
+			klog.Warningf("Ignoring invalid download parallelism %q", value)
+		} else {
+			sd.parallelism = parallelism
+		}
+	}
+	if value := os.Getenv(common.ImporterDownloadPartSize); value != "" {
+		partSize, err := resource.ParseQuantity(value)
+		if err != nil || partSize.Value() < minDownloadPartSize {
+			klog.Warningf("Ignoring invalid download part size %q, it must be at least %d bytes", value, minDownloadPartSize)
+		} else {
+			sd.partSize = partSize.Value()
+		}
+	}
+	if value := os.Getenv(common.ImporterMemoryLimit); value != "" && sd.parallelism > 1 {
+		limit, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || limit <= 0 {
+			klog.Warningf("Ignoring invalid memory limit %q", value)
+		} else if maxParallelism := int(limit / downloadBufferShare / sd.partSize); maxParallelism < sd.parallelism {
+			// Up to parallelism parts are held in memory
+			klog.Warningf("Downloading %d parts of %d bytes at a time does not fit in the memory limit of %d bytes, downloading %d at a time",
+				sd.parallelism, sd.partSize, limit, max(maxParallelism, 1))
+			sd.parallelism = max(maxParallelism, 1)
+		}
+	}
+	return sd
+}
+
+// enabled returns true if a source of size bytes is worth downloading in parts
+func (sd segmentedDownload) enabled(size int64) bool {
+	return sd.parallelism > 1 && size > sd.partSize
+}
+
+// probeRanges checks the source answers range requests with the requested bytes, before it is downloaded in parts
+func probeRanges(ctx context.Context, fetch rangeFetcher) bool {
+	if _, err := readPart(ctx, fetch, 0, 1); err != nil {
+		klog.Warningf("The source does not support range requests, downloading it with a single request: %v", err)
+		return false
+	}
+	return true
+}
+
+// newSegmentedReader starts downloading the size bytes of the source with fetch
+func newSegmentedReader(ctx context.Context, size int64, sd segmentedDownload, fetch rangeFetcher) io.ReadCloser {
+	ctx, cancel := context.WithCancel(ctx)
+	sr := &segmentedReader{
+		ctx:    ctx,
+		cancel: cancel,
+		parts:  make(chan chan downloadPart, sd.parallelism-1),
+	}
+	klog.V(1).Infof("Downloading %d bytes in parts of %d bytes, %d at a time", size, sd.partSize, sd.parallelism)
+	go sr.download(size, sd.partSize, fetch)
+	return sr
+}
+
+// download queues the parts in order, the queue being full holds back the next part
+func (sr *segmentedReader) download(size, partSize int64, fetch rangeFetcher) {
+	defer close(sr.parts)
+	for offset := int64(0); offset < size; offset += partSize {
+		length := partSize
+		if offset+length > size {
+			length = size - offset
+		}
+		result := make(chan downloadPart, 1)
+		select {
+		case sr.parts <- result:
+		case <-sr.ctx.Done():
+			return
+		}
+		go func(offset, length int64) {
+			data, err := fetchPart(sr.ctx, fetch, offset, length)
+			result <- downloadPart{data: data, err: err}
+		}(offset, length)
+	}
+}
+
+func fetchPart(ctx context.Context, fetch rangeFetcher, offset, length int64) ([]byte, error) {
+	var err error
+	for attempt := 1; attempt <= downloadPartRetries; attempt++ {
+		var data []byte
+		if data, err = readPart(ctx, fetch, offset, length); err == nil {
+			return data, nil
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		klog.Warningf("Download of bytes %d-%d failed, attempt %d of %d: %v", offset, offset+length-1, attempt, downloadPartRetries, err)
+	}
+	return nil, errors.Wrapf(err, "unable to download bytes %d-%d", offset, offset+length-1)
+}
+
+func readPart(ctx context.Context, fetch rangeFetcher, offset, length int64) ([]byte, error) {
+	body, err := fetch(ctx, offset, length)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+	data := make([]byte, length)
+	if _, err := io.ReadFull(body, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// Read returns the parts in order, blocking until the next one is downloaded
+func (sr *segmentedReader) Read(p []byte) (int, error) {
+	for sr.current == nil || sr.current.Len() == 0 {
+		if sr.err != nil {
+			return 0, sr.err
+		}
+		result, ok := <-sr.parts
+		if !ok {
+			if sr.ctx.Err() != nil {
+				sr.err = sr.ctx.Err()
+			} else {
+				sr.err = io.EOF
+			}
+			continue
+		}
+		part := <-result
+		if part.err != nil {
+			sr.err = part.err
+			sr.cancel()
+			continue
+		}
+		sr.current = bytes.NewReader(part.data)
+	}
+	return sr.current.Read(p)
+}
+
+// Close stops the downloads in flight
+func (sr *segmentedReader) Close() error {
+	sr.cancel()
+	return nil
+}
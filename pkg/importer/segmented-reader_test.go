@@ -0,0 +1,153 @@
+package importer
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"math/rand"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/pkg/errors"
+
+	"kubevirt.io/containerized-data-importer/pkg/common"
+)
+
+var _ = Describe("Segmented reader", func() {
+	// Downloads left running by a failed read may still be reading it, so it is never rewritten
+	data := make([]byte, 10*1024+512)
+	_, _ = rand.Read(data)
+
+	bytesFetcher := func(ctx context.Context, offset, length int64) (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(data[offset : offset+length])), nil
+	}
+
+	It("Should return the parts in order", func() {
+		fetch := func(ctx context.Context, offset, length int64) (io.ReadCloser, error) {
+			// Later parts finish first
+			time.Sleep(time.Duration(len(data)-int(offset)) * time.Microsecond)
+			return bytesFetcher(ctx, offset, length)
+		}
+		r := newSegmentedReader(context.Background(), int64(len(data)), segmentedDownload{parallelism: 4, partSize: 1024}, fetch)
+		defer r.Close()
+		result, err := io.ReadAll(r)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(Equal(data))
+	})
+
+	It("Should download at most parallelism parts at a time", func() {
+		var inFlight, maxInFlight int32
+		fetch := func(ctx context.Context, offset, length int64) (io.ReadCloser, error) {
+			current := atomic.AddInt32(&inFlight, 1)
+			defer atomic.AddInt32(&inFlight, -1)
+			for {
+				old := atomic.LoadInt32(&maxInFlight)
+				if current <= old || atomic.CompareAndSwapInt32(&maxInFlight, old, current) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+			return bytesFetcher(ctx, offset, length)
+		}
+		r := newSegmentedReader(context.Background(), int64(len(data)), segmentedDownload{parallelism: 3, partSize: 512}, fetch)
+		defer r.Close()
+		result, err := io.ReadAll(r)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(Equal(data))
+		Expect(maxInFlight).To(BeNumerically("<=", 3))
+		Expect(maxInFlight).To(BeNumerically(">", 1))
+	})
+
+	It("Should retry a failed part", func() {
+		var lock sync.Mutex
+		failed := map[int64]bool{}
+		fetch := func(ctx context.Context, offset, length int64) (io.ReadCloser, error) {
+			lock.Lock()
+			defer lock.Unlock()
+			if !failed[offset] {
+				failed[offset] = true
+				return nil, errors.New("connection reset")
+			}
+			return bytesFetcher(ctx, offset, length)
+		}
+		r := newSegmentedReader(context.Background(), int64(len(data)), segmentedDownload{parallelism: 2, partSize: 4096}, fetch)
+		defer r.Close()
+		result, err := io.ReadAll(r)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(Equal(data))
+	})
+
+	It("Should fail once a part runs out of retries", func() {
+		var attempts int32
+		fetch := func(ctx context.Context, offset, length int64) (io.ReadCloser, error) {
+			if offset == 2048 {
+				atomic.AddInt32(&attempts, 1)
+				return nil, errors.New("connection reset")
+			}
+			return bytesFetcher(ctx, offset, length)
+		}
+		r := newSegmentedReader(context.Background(), int64(len(data)), segmentedDownload{parallelism: 2, partSize: 1024}, fetch)
+		defer r.Close()
+		result, err := io.ReadAll(r)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("unable to download bytes 2048-3071"))
+		Expect(result).To(Equal(data[:2048]))
+		Expect(atomic.LoadInt32(&attempts)).To(BeEquivalentTo(downloadPartRetries))
+	})
+
+	It("Should fail on a short part", func() {
+		fetch := func(ctx context.Context, offset, length int64) (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(data[offset : offset+length-1])), nil
+		}
+		r := newSegmentedReader(context.Background(), int64(len(data)), segmentedDownload{parallelism: 2, partSize: 1024}, fetch)
+		defer r.Close()
+		_, err := io.ReadAll(r)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("Should stop reading once closed", func() {
+		r := newSegmentedReader(context.Background(), int64(len(data)), segmentedDownload{parallelism: 2, partSize: 1024}, bytesFetcher)
+		buf := make([]byte, 10)
+		_, err := r.Read(buf)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(r.Close()).To(Succeed())
+		_, err = io.ReadAll(r)
+		Expect(err).To(MatchError(context.Canceled))
+	})
+
+	DescribeTable("getSegmentedDownload should", func(parallelism, partSize, memoryLimit string, expected segmentedDownload) {
+		if memoryLimit != "" {
+			GinkgoT().Setenv(common.ImporterMemoryLimit, memoryLimit)
+		}
+		if parallelism != "" {
+			os.Setenv(common.ImporterDownloadParallelism, parallelism)
+			defer os.Unsetenv(common.ImporterDownloadParallelism)
+		}
+		if partSize != "" {
+			os.Setenv(common.ImporterDownloadPartSize, partSize)
+			defer os.Unsetenv(common.ImporterDownloadPartSize)
+		}
+		Expect(getSegmentedDownload()).To(Equal(expected))
+	},
+		Entry("default to a single stream", "", "", "", segmentedDownload{parallelism: 1, partSize: defaultDownloadPartSize}),
+		Entry("read the parallelism and part size", "8", "16Mi", "", segmentedDownload{parallelism: 8, partSize: 16 * 1024 * 1024}),
+		Entry("ignore an invalid parallelism", "zero", "", "", segmentedDownload{parallelism: 1, partSize: defaultDownloadPartSize}),
+		Entry("ignore a negative parallelism", "-2", "", "", segmentedDownload{parallelism: 1, partSize: defaultDownloadPartSize}),
+		Entry("ignore a part size that is too small", "4", "1Ki", "", segmentedDownload{parallelism: 4, partSize: defaultDownloadPartSize}),
+		Entry("keep a parallelism fitting in the memory limit", "8", "16Mi", "1073741824", segmentedDownload{parallelism: 8, partSize: 16 * 1024 * 1024}),
+		Entry("lower the parallelism to fit in the memory limit", "8", "16Mi", "134217728", segmentedDownload{parallelism: 4, partSize: 16 * 1024 * 1024}),
+		Entry("download with a single stream if no two parts fit in the memory limit", "8", "64Mi", "134217728", segmentedDownload{parallelism: 1, partSize: 64 * 1024 * 1024}),
+		Entry("ignore an invalid memory limit", "8", "16Mi", "lots", segmentedDownload{parallelism: 8, partSize: 16 * 1024 * 1024}),
+	)
+
+	It("Should only be enabled with parallelism for sources larger than a part", func() {
+		Expect(segmentedDownload{parallelism: 1, partSize: 1024}.enabled(4096)).To(BeFalse())
+		Expect(segmentedDownload{parallelism: 4, partSize: 1024}.enabled(1024)).To(BeFalse())
+		Expect(segmentedDownload{parallelism: 4, partSize: 1024}.enabled(4096)).To(BeTrue())
+	})
+})
@@ -0,0 +1,163 @@
+/*
+Copyright 2024 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package importer
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"k8s.io/klog/v2"
+
+	"kubevirt.io/containerized-data-importer/pkg/common"
+	"kubevirt.io/containerized-data-importer/pkg/image"
+)
+
+// Helper for unit-testing
+var createNbdkitSSH = image.NewNbdkitSSH
+
+// SFTPDataSource is the data provider for SFTP servers. The file is read over SFTP through nbdkit,
+// so qemu-img converts it without staging it in scratch space.
+// Sequence of phases:
+// 1. Info -> Convert
+type SFTPDataSource struct {
+	// Path of the file on the server
+	path string
+	// nbdkit serving the file
+	n image.NbdkitOperation
+	// The url qemu-img reads from
+	url *url.URL
+}
+
+// NewSFTPDataSource creates a new instance of the SFTPDataSource. The known hosts file and either
+// the private key or the password are read from credentialDir.
+func NewSFTPDataSource(endpoint, credentialDir string) (*SFTPDataSource, error) {
+	ep, err := ParseEndpoint(endpoint)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to parse endpoint %q", endpoint)
+	}
+	if ep.Scheme != "sftp" {
+		return nil, errors.Errorf("invalid SFTP url scheme %q", ep.Scheme)
+	}
+	if ep.User == nil || ep.User.Username() == "" {
+		return nil, errors.New("SFTP url has no user")
+	}
+	if _, hasPassword := ep.User.Password(); hasPassword {
+		return nil, errors.New("SFTP url must not contain a password, put it in the secret")
+	}
+	if ep.Hostname() == "" {
+		return nil, errors.New("SFTP url has no host")
+	}
+	// sftp://user@host/~/disk.img names a file in the home directory of the user
+	path := strings.TrimPrefix(ep.Path, "/~/")
+	if path == "" || strings.HasSuffix(path, "/") {
+		return nil, errors.New("SFTP url does not name a file")
+	}
+
+	args := image.NbdkitSSHArgs{
+		Host: ep.Hostname(),
+		Port: ep.Port(),
+		User: ep.User.Username(),
+		Path: path,
+	}
+	if err := readSFTPCredentials(credentialDir, &args); err != nil {
+		return nil, err
+	}
+	n, err := createNbdkitSSH(nbdkitPid, nbdkitSocket, args)
+	if err != nil {
+		return nil, err
+	}
+	return &SFTPDataSource{
+		path: path,
+		n:    n,
+	}, nil
+}
+
+// Info is called to get initial information about the data.
+func (sd *SFTPDataSource) Info() (ProcessingPhase, error) {
+	sd.url, _ = url.Parse(fmt.Sprintf("nbd+unix:///?socket=%s", nbdkitSocket))
+	if err := sd.n.StartNbdkit(sd.path); err != nil {
+		return ProcessingPhaseError, err
+	}
+	return ProcessingPhaseConvert, nil
+}
+
+// Transfer is not used, the file is converted straight from nbdkit.
+func (sd *SFTPDataSource) Transfer(path string, preallocation bool) (ProcessingPhase, error) {
+	return ProcessingPhaseError, errors.New("transfer is not supported for SFTP sources")
+}
+
+// TransferFile is not used, the file is converted straight from nbdkit.
+func (sd *SFTPDataSource) TransferFile(fileName string, preallocation bool) (ProcessingPhase, error) {
+	return ProcessingPhaseError, errors.New("transfer is not supported for SFTP sources")
+}
+
+// GetURL returns the url that the data processor can use when converting the data.
+func (sd *SFTPDataSource) GetURL() *url.URL {
+	return sd.url
+}
+
+// GetTerminationMessage returns data to be serialized and used as the termination message of the importer.
+func (sd *SFTPDataSource) GetTerminationMessage() *common.TerminationMessage {
+	return nil
+}
+
+// Close stops nbdkit.
+func (sd *SFTPDataSource) Close() error {
+	if sd.n != nil {
+		return sd.n.KillNbdkit()
+	}
+	return nil
+}
+
+// readSFTPCredentials fills in the secret files of args. The known hosts file is required, so the
+// server key is always pinned, and the private key is preferred over the password.
+func readSFTPCredentials(credentialDir string, args *image.NbdkitSSHArgs) error {
+	if credentialDir == "" {
+		return errors.New("SFTP sources need a secret holding the known hosts of the server")
+	}
+	knownHosts := filepath.Join(credentialDir, common.KeySFTPKnownHosts)
+	if _, err := os.Stat(knownHosts); err != nil {
+		return errors.Wrapf(err, "SFTP secret has no %s", common.KeySFTPKnownHosts)
+	}
+	args.KnownHostsFile = knownHosts
+
+	identity := filepath.Join(credentialDir, common.KeySFTPPrivateKey)
+	if _, err := os.Stat(identity); err == nil {
+		klog.V(3).Infoln("SFTP Importer: Authentication: private key")
+		args.IdentityFile = identity
+		return nil
+	} else if !os.IsNotExist(err) {
+		return errors.Wrapf(err, "unable to read SFTP credential %s", common.KeySFTPPrivateKey)
+	}
+	password, err := os.ReadFile(filepath.Join(credentialDir, common.KeySFTPPassword))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return errors.Errorf("SFTP secret needs %s or %s", common.KeySFTPPrivateKey, common.KeySFTPPassword)
+		}
+		return errors.Wrapf(err, "unable to read SFTP credential %s", common.KeySFTPPassword)
+	}
+	klog.V(3).Infoln("SFTP Importer: Authentication: password")
+	args.Password = strings.TrimRight(string(password), "\r\n")
+	return nil
+}
+
+var _ DataSourceInterface = &SFTPDataSource{}
@@ -0,0 +1,189 @@
+/*
+Copyright 2024 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package importer
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"hash"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	// The detached signatures of images are still checked with the deprecated package, it verifies RSA, DSA and
+	// ECDSA signatures, which is what distributions sign their images with.
+	"golang.org/x/crypto/openpgp"        //nolint:staticcheck
+	"golang.org/x/crypto/openpgp/armor"  //nolint:staticcheck
+	"golang.org/x/crypto/openpgp/packet" //nolint:staticcheck
+
+	"k8s.io/klog/v2"
+
+	"kubevirt.io/containerized-data-importer/pkg/common"
+	"kubevirt.io/containerized-data-importer/pkg/util"
+)
+
+const (
+	// maxSignatureSize is the size signatures are read up to, a detached signature is well under a KiB per key
+	maxSignatureSize = 1024 * 1024
+	armorHeader      = "-----BEGIN "
+)
+
+// may be overridden in tests
+var signatureKeyringDir = common.ImporterSignatureKeyringDir
+
+// signatureCheck checks the data of a source against a detached GPG signature made by a key of its keyring
+type signatureCheck struct {
+	hash.Hash
+	url       string
+	signature *packet.Signature
+	key       openpgp.Key
+}
+
+// getSignature returns the check of the detached signature of the source at ep if it is set, nil otherwise. The
+// signature is fetched with fetch, and its keyring read from the secret mounted in signatureKeyringDir.
+func getSignature(ep *url.URL, fetch signatureFetcher) (*signatureCheck, error) {
+	value, _ := util.ParseEnvVar(common.ImporterSignatureURL, false)
+	if value == "" {
+		return nil, nil
+	}
+	if fetch == nil {
+		return nil, errors.New("the source does not support signatures")
+	}
+	ref, err := url.Parse(value)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid signature url %q", value)
+	}
+	signatureURL := ep.ResolveReference(ref)
+	keyring, err := readKeyring(signatureKeyringDir)
+	if err != nil {
+		return nil, err
+	}
+	reader, err := fetch(signatureURL)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to fetch the signature %s", signatureURL.Redacted())
+	}
+	defer reader.Close()
+	signature, key, err := readSignature(io.LimitReader(reader, maxSignatureSize), keyring)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to read the signature %s", signatureURL.Redacted())
+	}
+	klog.Infof("Checking the source against the signature %s of key %X", signatureURL.Redacted(), key.PublicKey.KeyId)
+	return &signatureCheck{
+		Hash:      signature.Hash.New(),
+		url:       signatureURL.Redacted(),
+		signature: signature,
+		key:       key,
+	}, nil
+}
+
+// readKeyring reads the public keys of the files of dir, each holding armored or binary keys
+func readKeyring(dir string) (openpgp.EntityList, error) {
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to read the keyring of the signature")
+	}
+	keyring := openpgp.EntityList{}
+	for _, file := range files {
+		// The keys of a secret volume are links to its hidden data directory
+		if file.IsDir() || strings.HasPrefix(file.Name(), ".") {
+			continue
+		}
+		f, err := os.Open(filepath.Join(dir, file.Name()))
+		if err != nil {
+			return nil, err
+		}
+		reader := bufio.NewReader(f)
+		var entities openpgp.EntityList
+		if armored(reader) {
+			entities, err = openpgp.ReadArmoredKeyRing(reader)
+		} else {
+			entities, err = openpgp.ReadKeyRing(reader)
+		}
+		f.Close()
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to read the keys of %s", file.Name())
+		}
+		keyring = append(keyring, entities...)
+	}
+	if len(keyring) == 0 {
+		return nil, errors.New("the keyring of the signature has no keys")
+	}
+	return keyring, nil
+}
+
+// readSignature returns the first signature made by a signing key of keyring, files can hold the signatures of
+// several keys
+func readSignature(r io.Reader, keyring openpgp.EntityList) (*packet.Signature, openpgp.Key, error) {
+	reader := bufio.NewReader(r)
+	var body io.Reader = reader
+	if armored(reader) {
+		block, err := armor.Decode(reader)
+		if err != nil {
+			return nil, openpgp.Key{}, err
+		}
+		if block.Type != openpgp.SignatureType {
+			return nil, openpgp.Key{}, errors.Errorf("the armored block is a %s, not a %s", block.Type, openpgp.SignatureType)
+		}
+		body = block.Body
+	}
+	packets := packet.NewReader(body)
+	issuers := []string{}
+	for {
+		p, err := packets.Next()
+		if err == io.EOF {
+			return nil, openpgp.Key{}, newSourceVerificationError(errors.Errorf("%s: no key of the keyring made the signature, it is signed by %s", common.SignatureVerificationFailureText, strings.Join(issuers, ", ")))
+		}
+		if err != nil {
+			return nil, openpgp.Key{}, err
+		}
+		signature, ok := p.(*packet.Signature)
+		if !ok {
+			return nil, openpgp.Key{}, errors.Errorf("the signature holds a %T packet, only version 4 signatures are supported", p)
+		}
+		if signature.SigType != packet.SigTypeBinary {
+			return nil, openpgp.Key{}, errors.Errorf("the signature is of type %d, only binary signatures are supported", signature.SigType)
+		}
+		if signature.IssuerKeyId == nil {
+			return nil, openpgp.Key{}, errors.New("the signature has no issuer")
+		}
+		if !signature.Hash.Available() {
+			return nil, openpgp.Key{}, errors.Errorf("the signature uses the unsupported hash %d", signature.Hash)
+		}
+		if keys := keyring.KeysByIdUsage(*signature.IssuerKeyId, packet.KeyFlagSign); len(keys) > 0 {
+			return signature, keys[0], nil
+		}
+		issuers = append(issuers, fmt.Sprintf("%016X", *signature.IssuerKeyId))
+	}
+}
+
+func (c *signatureCheck) check() error {
+	if err := c.key.PublicKey.VerifySignature(c.Hash, c.signature); err != nil {
+		return newSourceVerificationError(errors.Errorf("%s: the source does not match the signature %s of key %X: %v", common.SignatureVerificationFailureText, c.url, c.key.PublicKey.KeyId, err))
+	}
+	klog.Infof("The source matches the signature of key %X", c.key.PublicKey.KeyId)
+	return nil
+}
+
+// armored returns true if r starts with an armor header, without consuming it
+func armored(r *bufio.Reader) bool {
+	header, _ := r.Peek(len(armorHeader))
+	return bytes.Equal(header, []byte(armorHeader))
+}
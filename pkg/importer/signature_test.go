@@ -0,0 +1,152 @@
+package importer
+
+import (
+	"bytes"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"golang.org/x/crypto/openpgp"        //nolint:staticcheck
+	"golang.org/x/crypto/openpgp/armor"  //nolint:staticcheck
+	"golang.org/x/crypto/openpgp/packet" //nolint:staticcheck
+
+	"kubevirt.io/containerized-data-importer/pkg/common"
+)
+
+var _ = Describe("Signature", func() {
+	content := bytes.Repeat([]byte("disk image "), 10000)
+	ep, _ := url.Parse("https://images.example.com/images/disk.img")
+	var signer, other *openpgp.Entity
+	var signatures map[string][]byte
+
+	BeforeEach(func() {
+		var err error
+		signer, err = openpgp.NewEntity("signer", "", "signer@example.com", &packet.Config{RSABits: 1024})
+		Expect(err).NotTo(HaveOccurred())
+		other, err = openpgp.NewEntity("other", "", "other@example.com", &packet.Config{RSABits: 1024})
+		Expect(err).NotTo(HaveOccurred())
+		signatures = map[string][]byte{}
+
+		signatureKeyringDir = GinkgoT().TempDir()
+		DeferCleanup(func() {
+			signatureKeyringDir = common.ImporterSignatureKeyringDir
+		})
+		// The keys of a mounted secret are links next to its hidden data directory
+		Expect(os.Mkdir(filepath.Join(signatureKeyringDir, "..data"), 0700)).To(Succeed())
+	})
+
+	writeKey := func(name string, entity *openpgp.Entity, armored bool) {
+		buf := &bytes.Buffer{}
+		if armored {
+			w, err := armor.Encode(buf, openpgp.PublicKeyType, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(entity.Serialize(w)).To(Succeed())
+			Expect(w.Close()).To(Succeed())
+		} else {
+			Expect(entity.Serialize(buf)).To(Succeed())
+		}
+		Expect(os.WriteFile(filepath.Join(signatureKeyringDir, name), buf.Bytes(), 0600)).To(Succeed())
+	}
+
+	sign := func(entity *openpgp.Entity, armored bool, data []byte) []byte {
+		buf := &bytes.Buffer{}
+		if armored {
+			Expect(openpgp.ArmoredDetachSign(buf, entity, bytes.NewReader(data), nil)).To(Succeed())
+		} else {
+			Expect(openpgp.DetachSign(buf, entity, bytes.NewReader(data), nil)).To(Succeed())
+		}
+		return buf.Bytes()
+	}
+
+	fetch := func(signatureURL *url.URL) (io.ReadCloser, error) {
+		signature, ok := signatures[signatureURL.String()]
+		if !ok {
+			return nil, os.ErrNotExist
+		}
+		return io.NopCloser(bytes.NewReader(signature)), nil
+	}
+
+	verify := func(data []byte) error {
+		verifier, err := getDownloadVerifier(ep, fetch)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(verifier).NotTo(BeNil())
+		_, err = io.Copy(io.Discard, verifier.wrap(io.NopCloser(bytes.NewReader(data))))
+		Expect(err).NotTo(HaveOccurred())
+		return verifier.verify()
+	}
+
+	It("Should not check sources without a signature", func() {
+		verifier, err := getDownloadVerifier(ep, fetch)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(verifier).To(BeNil())
+	})
+
+	DescribeTable("Should verify", func(armoredKey, armoredSignature bool, signatureURL string) {
+		writeKey("key", signer, armoredKey)
+		signatures[ep.ResolveReference(&url.URL{Path: signatureURL}).String()] = sign(signer, armoredSignature, content)
+		GinkgoT().Setenv(common.ImporterSignatureURL, signatureURL)
+		Expect(verify(content)).To(Succeed())
+	},
+		Entry("a binary signature with a binary key", false, false, "disk.img.sig"),
+		Entry("an armored signature with an armored key", true, true, "disk.img.asc"),
+		Entry("a signature at an absolute url", true, false, "/signatures/disk.img.sig"),
+	)
+
+	It("Should verify the signature of any key of the keyring", func() {
+		writeKey("other", other, true)
+		writeKey("signer", signer, false)
+		signatures["https://images.example.com/images/disk.img.sig"] = sign(signer, false, content)
+		GinkgoT().Setenv(common.ImporterSignatureURL, "disk.img.sig")
+		Expect(verify(content)).To(Succeed())
+	})
+
+	It("Should check the checksum along with the signature", func() {
+		writeKey("key", signer, true)
+		signatures["https://images.example.com/images/disk.img.sig"] = sign(signer, false, content)
+		GinkgoT().Setenv(common.ImporterSignatureURL, "disk.img.sig")
+		GinkgoT().Setenv(common.ImporterChecksum, "md5:00000000000000000000000000000000")
+		Expect(verify(content)).To(MatchError(ContainSubstring(common.ChecksumMismatchText)))
+	})
+
+	It("Should fail if the source does not match its signature", func() {
+		writeKey("key", signer, true)
+		signatures["https://images.example.com/images/disk.img.sig"] = sign(signer, false, content)
+		GinkgoT().Setenv(common.ImporterSignatureURL, "disk.img.sig")
+		err := verify(content[1:])
+		Expect(err).To(MatchError(ContainSubstring(common.SignatureVerificationFailureText)))
+		Expect(err).To(MatchError(ContainSubstring("the source does not match the signature https://images.example.com/images/disk.img.sig")))
+	})
+
+	It("Should fail if no key of the keyring made the signature", func() {
+		writeKey("key", other, true)
+		signatures["https://images.example.com/images/disk.img.sig"] = sign(signer, false, content)
+		GinkgoT().Setenv(common.ImporterSignatureURL, "disk.img.sig")
+		_, err := getDownloadVerifier(ep, fetch)
+		Expect(err).To(MatchError(ContainSubstring(common.SignatureVerificationFailureText)))
+		Expect(err).To(MatchError(ContainSubstring("no key of the keyring made the signature")))
+	})
+
+	It("Should fail if the keyring has no keys", func() {
+		signatures["https://images.example.com/images/disk.img.sig"] = sign(signer, false, content)
+		GinkgoT().Setenv(common.ImporterSignatureURL, "disk.img.sig")
+		_, err := getDownloadVerifier(ep, fetch)
+		Expect(err).To(MatchError("the keyring of the signature has no keys"))
+	})
+
+	It("Should fail if the signature can not be fetched", func() {
+		writeKey("key", signer, true)
+		GinkgoT().Setenv(common.ImporterSignatureURL, "disk.img.sig")
+		_, err := getDownloadVerifier(ep, fetch)
+		Expect(err).To(MatchError(ContainSubstring("unable to fetch the signature https://images.example.com/images/disk.img.sig")))
+	})
+
+	It("Should fail if the source does not support signatures", func() {
+		GinkgoT().Setenv(common.ImporterSignatureURL, "disk.img.sig")
+		_, err := getDownloadVerifier(ep, nil)
+		Expect(err).To(MatchError("the source does not support signatures"))
+	})
+})
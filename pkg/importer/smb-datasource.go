@@ -0,0 +1,57 @@
+/*
+Copyright 2024 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package importer
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"k8s.io/klog/v2"
+
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+)
+
+// NewSMBDataSource creates the data source of an smb://server/share/path url. The SMB CSI driver mounts the share at
+// mountDir with the kernel SMB client, which speaks SMB 2 and 3 and follows DFS referrals, and the file is read from
+// the mount like the files of hostpath sources.
+func NewSMBDataSource(endpoint, mountDir string, contentType cdiv1.DataVolumeContentType) (*HostPathDataSource, error) {
+	ep, err := ParseEndpoint(endpoint)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to parse endpoint %q", endpoint)
+	}
+	if ep.Scheme != "smb" && ep.Scheme != "smbs" {
+		return nil, errors.Errorf("invalid SMB url scheme %q", ep.Scheme)
+	}
+	if ep.User != nil {
+		return nil, errors.New("SMB url must not contain credentials, put them in the secret")
+	}
+	if ep.Hostname() == "" {
+		return nil, errors.New("SMB url has no server")
+	}
+	parts := strings.SplitN(strings.Trim(ep.Path, "/"), "/", 2)
+	if len(parts) < 2 || strings.HasSuffix(ep.Path, "/") {
+		return nil, errors.New("SMB url does not name a share and a file")
+	}
+	filePath := filepath.Join(mountDir, parts[1])
+	if !strings.HasPrefix(filePath, filepath.Clean(mountDir)+string(filepath.Separator)) {
+		return nil, errors.New("SMB url names a file outside of the share")
+	}
+	klog.V(3).Infof("SMB Importer: reading %s of share //%s/%s", parts[1], ep.Hostname(), parts[0])
+	return NewHostPathDataSource(filePath, contentType)
+}
@@ -0,0 +1,384 @@
+/*
+Copyright 2024 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package importer
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"k8s.io/klog/v2"
+
+	"kubevirt.io/containerized-data-importer/pkg/common"
+	"kubevirt.io/containerized-data-importer/pkg/system"
+)
+
+const (
+	// torrentMetadataDir is the directory of scratch space holding the .torrent file
+	torrentMetadataDir = "torrent"
+	// torrentDownloadDir is the directory of scratch space the files of the torrent are downloaded to
+	torrentDownloadDir = "download"
+	// maxTorrentFileSize bounds the .torrent files read, which only hold the piece hashes of the image
+	maxTorrentFileSize = 64 * 1024 * 1024
+	// maxBencodeDepth bounds the nesting of the lists and dictionaries of .torrent files
+	maxBencodeDepth = 32
+)
+
+var torrentExecFunction = system.ExecWithLimits
+
+// TorrentDataSource is the data provider for images shared over BitTorrent. aria2c downloads the image into
+// scratch space, from the peers of the swarm and from the web seeds, checking every piece against the hashes
+// of the torrent, and the image is then converted to the target.
+// Sequence of phases:
+// 1. Info -> TransferScratch
+// 2. TransferScratch -> Convert
+type TorrentDataSource struct {
+	// magnet link or url of the .torrent file
+	endpoint *url.URL
+	// http(s) urls of the image, downloaded from along the peers
+	webSeeds []string
+	// path of the image in the torrent
+	file string
+	// Directory holding the CA of the .torrent url and the web seeds
+	certDir string
+	// The downloaded image in scratch space
+	url *url.URL
+}
+
+// torrentFile is a file of a torrent, with its path in the torrent
+type torrentFile struct {
+	path   string
+	length int64
+}
+
+// NewTorrentDataSource creates a new instance of the TorrentDataSource. endpoint is the magnet link or the http(s)
+// url of the .torrent file, and file the path of the image in the torrent, if it holds several files.
+func NewTorrentDataSource(endpoint string, webSeeds []string, file, certDir string) (*TorrentDataSource, error) {
+	ep, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to parse endpoint %q", endpoint)
+	}
+	if ep.Scheme != "magnet" && ep.Scheme != "http" && ep.Scheme != "https" {
+		return nil, errors.Errorf("invalid torrent url scheme %q, expected magnet, http or https", ep.Scheme)
+	}
+	klog.V(3).Infof("Torrent Importer: downloading %s with %d web seeds", ep.Redacted(), len(webSeeds))
+	return &TorrentDataSource{
+		endpoint: ep,
+		webSeeds: webSeeds,
+		file:     file,
+		certDir:  certDir,
+	}, nil
+}
+
+// Info is called to get initial information about the data. The image is downloaded to scratch space, pieces
+// arriving out of order from the peers.
+func (td *TorrentDataSource) Info() (ProcessingPhase, error) {
+	return ProcessingPhaseTransferScratch, nil
+}
+
+// Transfer downloads the image of the torrent into path.
+func (td *TorrentDataSource) Transfer(path string, preallocation bool) (ProcessingPhase, error) {
+	metadataDir := filepath.Join(path, torrentMetadataDir)
+	downloadDir := filepath.Join(path, torrentDownloadDir)
+	for _, dir := range []string{metadataDir, downloadDir} {
+		if err := os.MkdirAll(dir, 0750); err != nil {
+			return ProcessingPhaseError, errors.Wrap(err, "unable to create the torrent directories")
+		}
+	}
+	torrentPath, err := td.getTorrentFile(metadataDir)
+	if err != nil {
+		return ProcessingPhaseError, err
+	}
+	metadata, err := os.ReadFile(torrentPath)
+	if err != nil {
+		return ProcessingPhaseError, errors.Wrap(err, "unable to read the .torrent file")
+	}
+	files, err := parseTorrentFiles(metadata)
+	if err != nil {
+		return ProcessingPhaseError, err
+	}
+	index, err := selectTorrentFile(files, td.file)
+	if err != nil {
+		return ProcessingPhaseError, err
+	}
+	args := append(td.aria2Args(downloadDir), "--select-file="+strconv.Itoa(index+1), "-T", torrentPath)
+	// aria2c uses the urls given along the torrent as web seeds
+	args = append(args, td.webSeeds...)
+	if _, err := torrentExecFunction(nil, nil, "aria2c", args...); err != nil {
+		return ProcessingPhaseError, errors.Wrap(err, "aria2c could not download the torrent")
+	}
+	image := filepath.Join(downloadDir, filepath.FromSlash(files[index].path))
+	if _, err := os.Stat(image); err != nil {
+		return ProcessingPhaseError, errors.Wrapf(err, "torrent file %s was not downloaded", files[index].path)
+	}
+	td.url = &url.URL{Path: image}
+	return ProcessingPhaseConvert, nil
+}
+
+// aria2Args returns the aria2c arguments downloading into dir, leaving the swarm once the download is complete
+func (td *TorrentDataSource) aria2Args(dir string) []string {
+	args := []string{
+		"--dir=" + dir,
+		"--seed-time=0",
+		"--check-integrity=true",
+		"--file-allocation=none",
+		"--bt-remove-unselected-file=true",
+		"--allow-overwrite=true",
+		"--auto-file-renaming=false",
+		"--summary-interval=0",
+		"--console-log-level=warn",
+	}
+	if td.certDir != "" {
+		args = append(args, "--ca-certificate="+filepath.Join(td.certDir, "tls.crt"))
+	}
+	return args
+}
+
+// getTorrentFile writes the .torrent file of the image to dir, from its url or from the peers of a magnet link
+func (td *TorrentDataSource) getTorrentFile(dir string) (string, error) {
+	if td.endpoint.Scheme != "magnet" {
+		return td.downloadTorrentFile(dir)
+	}
+	args := append(td.aria2Args(dir), "--bt-metadata-only=true", "--bt-save-metadata=true", td.endpoint.String())
+	if _, err := torrentExecFunction(nil, nil, "aria2c", args...); err != nil {
+		return "", errors.Wrap(err, "aria2c could not get the torrent of the magnet link from the peers")
+	}
+	// aria2c names the .torrent file after the info hash
+	matches, err := filepath.Glob(filepath.Join(dir, "*.torrent"))
+	if err != nil || len(matches) != 1 {
+		return "", errors.New("aria2c did not save the torrent of the magnet link")
+	}
+	return matches[0], nil
+}
+
+func (td *TorrentDataSource) downloadTorrentFile(dir string) (string, error) {
+	client, err := createHTTPClient(td.certDir)
+	if err != nil {
+		return "", errors.Wrap(err, "Error creating http client")
+	}
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, td.endpoint.String(), nil)
+	if err != nil {
+		return "", errors.Wrap(err, "could not create the .torrent request")
+	}
+	req.Header.Add("User-Agent", defaultUserAgent)
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", errors.Wrap(err, "could not download the .torrent file")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", errors.Errorf("could not download the .torrent file: %s", resp.Status)
+	}
+	metadata, err := io.ReadAll(io.LimitReader(resp.Body, maxTorrentFileSize+1))
+	if err != nil {
+		return "", errors.Wrap(err, "could not download the .torrent file")
+	}
+	if len(metadata) > maxTorrentFileSize {
+		return "", errors.Errorf(".torrent file is larger than %d bytes", maxTorrentFileSize)
+	}
+	torrentPath := filepath.Join(dir, "image.torrent")
+	if err := os.WriteFile(torrentPath, metadata, 0600); err != nil {
+		return "", errors.Wrap(err, "unable to write the .torrent file")
+	}
+	return torrentPath, nil
+}
+
+// selectTorrentFile returns the index of the image in the files of the torrent
+func selectTorrentFile(files []torrentFile, file string) (int, error) {
+	if file == "" {
+		if len(files) != 1 {
+			return 0, errors.Errorf("torrent holds %d files, the file to import has to be set", len(files))
+		}
+		return 0, nil
+	}
+	for i, f := range files {
+		if f.path == file {
+			return i, nil
+		}
+	}
+	return 0, errors.Errorf("torrent has no file %s", file)
+}
+
+// parseTorrentFiles returns the files of the bencoded .torrent file metadata, as aria2c lays them out in its
+// download directory: the name of single file torrents, the name of the torrent joined with the path of the file
+// otherwise.
+func parseTorrentFiles(metadata []byte) ([]torrentFile, error) {
+	value, rest, err := decodeBencode(metadata, 0)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid .torrent file")
+	}
+	if len(rest) > 0 {
+		return nil, errors.New("invalid .torrent file: trailing data")
+	}
+	torrent, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, errors.New("invalid .torrent file: not a dictionary")
+	}
+	info, ok := torrent["info"].(map[string]interface{})
+	if !ok {
+		return nil, errors.New("invalid .torrent file: no info dictionary")
+	}
+	name, ok := info["name"].(string)
+	if !ok || !isTorrentPathElement(name) {
+		return nil, errors.New("invalid .torrent file: invalid name")
+	}
+	if length, ok := info["length"].(int64); ok {
+		return []torrentFile{{path: name, length: length}}, nil
+	}
+	list, ok := info["files"].([]interface{})
+	if !ok {
+		return nil, errors.New("invalid .torrent file: no file list, only version 1 and hybrid torrents are supported")
+	}
+	files := make([]torrentFile, 0, len(list))
+	for _, entry := range list {
+		f, ok := entry.(map[string]interface{})
+		if !ok {
+			return nil, errors.New("invalid .torrent file: invalid file")
+		}
+		length, ok := f["length"].(int64)
+		if !ok {
+			return nil, errors.New("invalid .torrent file: file has no length")
+		}
+		elements, ok := f["path"].([]interface{})
+		if !ok || len(elements) == 0 {
+			return nil, errors.New("invalid .torrent file: file has no path")
+		}
+		filePath := name
+		for _, element := range elements {
+			s, ok := element.(string)
+			if !ok || !isTorrentPathElement(s) {
+				return nil, errors.New("invalid .torrent file: invalid file path")
+			}
+			filePath = path.Join(filePath, s)
+		}
+		files = append(files, torrentFile{path: filePath, length: length})
+	}
+	return files, nil
+}
+
+// isTorrentPathElement checks an element of a path of the torrent stays in the download directory
+func isTorrentPathElement(element string) bool {
+	return element != "" && element != "." && element != ".." && !strings.ContainsAny(element, "/\\\x00")
+}
+
+// decodeBencode decodes the first bencoded value of data, nested depth times, returning the rest of data.
+// Dictionaries decode to map[string]interface{}, lists to []interface{}, integers to int64 and strings to string.
+func decodeBencode(data []byte, depth int) (interface{}, []byte, error) {
+	if len(data) == 0 {
+		return nil, nil, io.ErrUnexpectedEOF
+	}
+	if depth > maxBencodeDepth {
+		return nil, nil, errors.New("too deeply nested")
+	}
+	switch c := data[0]; {
+	case c == 'i':
+		end := bytes.IndexByte(data, 'e')
+		if end < 0 {
+			return nil, nil, io.ErrUnexpectedEOF
+		}
+		n, err := strconv.ParseInt(string(data[1:end]), 10, 64)
+		if err != nil {
+			return nil, nil, errors.Errorf("invalid integer %q", data[1:end])
+		}
+		return n, data[end+1:], nil
+	case c == 'l':
+		list := []interface{}{}
+		data = data[1:]
+		for len(data) > 0 && data[0] != 'e' {
+			value, rest, err := decodeBencode(data, depth+1)
+			if err != nil {
+				return nil, nil, err
+			}
+			list = append(list, value)
+			data = rest
+		}
+		if len(data) == 0 {
+			return nil, nil, io.ErrUnexpectedEOF
+		}
+		return list, data[1:], nil
+	case c == 'd':
+		dict := map[string]interface{}{}
+		data = data[1:]
+		for len(data) > 0 && data[0] != 'e' {
+			key, rest, err := decodeBencode(data, depth+1)
+			if err != nil {
+				return nil, nil, err
+			}
+			k, ok := key.(string)
+			if !ok {
+				return nil, nil, errors.New("dictionary key is not a string")
+			}
+			value, rest, err := decodeBencode(rest, depth+1)
+			if err != nil {
+				return nil, nil, err
+			}
+			dict[k] = value
+			data = rest
+		}
+		if len(data) == 0 {
+			return nil, nil, io.ErrUnexpectedEOF
+		}
+		return dict, data[1:], nil
+	case c >= '0' && c <= '9':
+		colon := bytes.IndexByte(data, ':')
+		if colon < 0 {
+			return nil, nil, io.ErrUnexpectedEOF
+		}
+		length, err := strconv.Atoi(string(data[:colon]))
+		if err != nil || length < 0 {
+			return nil, nil, errors.Errorf("invalid string length %q", data[:colon])
+		}
+		data = data[colon+1:]
+		if len(data) < length {
+			return nil, nil, io.ErrUnexpectedEOF
+		}
+		return string(data[:length]), data[length:], nil
+	default:
+		return nil, nil, errors.Errorf("unexpected bencode type %q", c)
+	}
+}
+
+// TransferFile is not used, the image is downloaded to scratch space.
+func (td *TorrentDataSource) TransferFile(fileName string, preallocation bool) (ProcessingPhase, error) {
+	return ProcessingPhaseError, errors.New("transfer file is not supported for torrent sources")
+}
+
+// GetURL returns the url that the data processor can use when converting the data.
+func (td *TorrentDataSource) GetURL() *url.URL {
+	return td.url
+}
+
+// GetTerminationMessage returns data to be serialized and used as the termination message of the importer.
+func (td *TorrentDataSource) GetTerminationMessage() *common.TerminationMessage {
+	return nil
+}
+
+// Close is a no-op, scratch space is removed with the scratch PVC.
+func (td *TorrentDataSource) Close() error {
+	return nil
+}
+
+var _ DataSourceInterface = &TorrentDataSource{}
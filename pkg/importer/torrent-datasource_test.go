@@ -0,0 +1,146 @@
+package importer
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"kubevirt.io/containerized-data-importer/pkg/system"
+)
+
+const (
+	singleFileTorrent = "d8:announce30:http://tracker.example.com/ann4:infod6:lengthi1024e4:name12:fedora.qcow212:piece lengthi262144e6:pieces0:ee"
+	multiFileTorrent  = "d4:infod5:filesld6:lengthi10e4:pathl6:READMEeed6:lengthi1024e4:pathl6:images12:fedora.qcow2eee4:name6:fedora12:piece lengthi262144e6:pieces0:ee"
+)
+
+var _ = Describe("Torrent data source", func() {
+	var (
+		tmpDir     string
+		aria2Calls [][]string
+		aria2Files []string
+		aria2Err   error
+	)
+
+	argValue := func(args []string, name string) string {
+		for _, arg := range args {
+			if value, ok := strings.CutPrefix(arg, name+"="); ok {
+				return value
+			}
+		}
+		return ""
+	}
+
+	BeforeEach(func() {
+		var err error
+		tmpDir, err = os.MkdirTemp("", "torrent")
+		Expect(err).NotTo(HaveOccurred())
+		aria2Calls = nil
+		aria2Files = nil
+		aria2Err = nil
+		torrentExecFunction = func(_ *system.ProcessLimitValues, _ func(string), command string, args ...string) ([]byte, error) {
+			defer GinkgoRecover()
+			Expect(command).To(Equal("aria2c"))
+			aria2Calls = append(aria2Calls, args)
+			dir := argValue(args, "--dir")
+			if argValue(args, "--bt-metadata-only") == "true" {
+				Expect(os.WriteFile(filepath.Join(dir, "c9e15763f722f23e98a29decdfae341b98d53056.torrent"), []byte(singleFileTorrent), 0600)).To(Succeed())
+			}
+			for _, file := range aria2Files {
+				Expect(os.MkdirAll(filepath.Dir(filepath.Join(dir, file)), 0750)).To(Succeed())
+				Expect(os.WriteFile(filepath.Join(dir, file), []byte("image"), 0600)).To(Succeed())
+			}
+			return nil, aria2Err
+		}
+	})
+
+	AfterEach(func() {
+		torrentExecFunction = system.ExecWithLimits
+		os.RemoveAll(tmpDir)
+	})
+
+	It("Should download the .torrent file and the image with the web seeds", func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(multiFileTorrent))
+		}))
+		defer server.Close()
+		aria2Files = []string{"fedora/images/fedora.qcow2"}
+		td, err := NewTorrentDataSource(server.URL+"/fedora.torrent", []string{"https://mirror-a.example.com/"}, "fedora/images/fedora.qcow2", "")
+		Expect(err).NotTo(HaveOccurred())
+		phase, err := td.Info()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(phase).To(Equal(ProcessingPhaseTransferScratch))
+		phase, err = td.Transfer(tmpDir, false)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(phase).To(Equal(ProcessingPhaseConvert))
+
+		torrentPath := filepath.Join(tmpDir, torrentMetadataDir, "image.torrent")
+		Expect(os.ReadFile(torrentPath)).To(BeEquivalentTo(multiFileTorrent))
+		Expect(aria2Calls).To(HaveLen(1))
+		Expect(aria2Calls[0]).To(ContainElement("--select-file=2"))
+		Expect(aria2Calls[0]).To(ContainElement("--seed-time=0"))
+		Expect(aria2Calls[0][len(aria2Calls[0])-3:]).To(Equal([]string{"-T", torrentPath, "https://mirror-a.example.com/"}))
+		Expect(td.GetURL().Path).To(Equal(filepath.Join(tmpDir, torrentDownloadDir, "fedora", "images", "fedora.qcow2")))
+		Expect(td.Close()).To(Succeed())
+	})
+
+	It("Should get the torrent of a magnet link from the peers", func() {
+		magnet := "magnet:?xt=urn:btih:c9e15763f722f23e98a29decdfae341b98d53056&dn=fedora.qcow2"
+		aria2Files = []string{"fedora.qcow2"}
+		td, err := NewTorrentDataSource(magnet, nil, "", "/certs")
+		Expect(err).NotTo(HaveOccurred())
+		phase, err := td.Transfer(tmpDir, false)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(phase).To(Equal(ProcessingPhaseConvert))
+		Expect(aria2Calls).To(HaveLen(2))
+		Expect(aria2Calls[0]).To(ContainElement("--bt-metadata-only=true"))
+		Expect(aria2Calls[0][len(aria2Calls[0])-1]).To(Equal(magnet))
+		Expect(aria2Calls[1]).To(ContainElements("--select-file=1", "--ca-certificate=/certs/tls.crt"))
+		Expect(td.GetURL().Path).To(Equal(filepath.Join(tmpDir, torrentDownloadDir, "fedora.qcow2")))
+	})
+
+	It("Should need the file to import of torrents holding several files", func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(multiFileTorrent))
+		}))
+		defer server.Close()
+		td, err := NewTorrentDataSource(server.URL+"/fedora.torrent", nil, "", "")
+		Expect(err).NotTo(HaveOccurred())
+		_, err = td.Transfer(tmpDir, false)
+		Expect(err).To(MatchError(ContainSubstring("torrent holds 2 files")))
+		Expect(aria2Calls).To(BeEmpty())
+	})
+
+	It("Should fail when aria2c fails", func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(singleFileTorrent))
+		}))
+		defer server.Close()
+		aria2Err = errors.New("no peers")
+		td, err := NewTorrentDataSource(server.URL+"/fedora.torrent", nil, "", "")
+		Expect(err).NotTo(HaveOccurred())
+		_, err = td.Transfer(tmpDir, false)
+		Expect(err).To(MatchError(ContainSubstring("aria2c could not download the torrent")))
+	})
+
+	It("Should refuse other url schemes", func() {
+		_, err := NewTorrentDataSource("ftp://images.example.com/fedora.torrent", nil, "", "")
+		Expect(err).To(HaveOccurred())
+	})
+
+	DescribeTable("Should refuse invalid .torrent files", func(metadata string) {
+		_, err := parseTorrentFiles([]byte(metadata))
+		Expect(err).To(HaveOccurred())
+	},
+		Entry("truncated", singleFileTorrent[:40]),
+		Entry("without info", "d8:announce30:http://tracker.example.com/anne"),
+		Entry("with a file out of the download directory", "d4:infod5:filesld6:lengthi10e4:pathl2:..6:passwdeee4:name6:fedoraee"),
+		Entry("with a name holding a path", "d4:infod6:lengthi10e4:name11:../evil.imgee"),
+		Entry("too deeply nested", strings.Repeat("l", 100)+strings.Repeat("e", 100)),
+	)
+})
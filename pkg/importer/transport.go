@@ -31,6 +31,7 @@ import (
 	"github.com/containers/image/v5/oci/archive"
 	"github.com/containers/image/v5/pkg/blobinfocache"
 	"github.com/containers/image/v5/types"
+	"github.com/opencontainers/go-digest"
 	"github.com/pkg/errors"
 
 	"k8s.io/klog/v2"
@@ -195,7 +196,10 @@ func safeJoinPaths(dir, path string) (v string, err error) {
 	return "", fmt.Errorf("%s: %s", "content filepath is tainted", path)
 }
 
-func copyRegistryImage(url, destDir, pathPrefix, accessKey, secKey, imageArchitecture, certDir string, insecureRegistry, stopAtFirst, preallocation bool) (*types.ImageInspectInfo, error) {
+// copyRegistryImage copies the files of the image at url, and returns the image info along with the digest its
+// reference resolved to, so imports of a floating tag record the exact image they copied, and the digest of the
+// image of the platform picked when the reference resolved to a multi-architecture image index
+func copyRegistryImage(url, destDir, pathPrefix, accessKey, secKey, imageArchitecture, certDir string, insecureRegistry, stopAtFirst, preallocation bool) (*types.ImageInspectInfo, digest.Digest, digest.Digest, error) {
 	klog.Infof("Downloading image from '%v', copying file from '%v' to '%v'", url, pathPrefix, destDir)
 
 	ctx, cancel := commandTimeoutContext()
@@ -204,22 +208,71 @@ func copyRegistryImage(url, destDir, pathPrefix, accessKey, secKey, imageArchite
 
 	src, err := readImageSource(ctx, srcCtx, url)
 	if err != nil {
-		return nil, err
+		return nil, "", "", err
 	}
 	defer closeImage(src)
 
+	// The source keeps the manifest the reference resolved to, the layers are read from the same image
+	imageManifest, _, err := src.GetManifest(ctx, nil)
+	if err != nil {
+		return nil, "", "", errors.Wrap(err, "Error retrieving image manifest")
+	}
+	imageDigest, err := manifest.Digest(imageManifest)
+	if err != nil {
+		return nil, "", "", err
+	}
+	klog.Infof("Image reference '%v' resolved to digest %s", url, imageDigest)
+
+	if err := verifyImage(ctx, srcCtx, src); err != nil {
+		return nil, "", "", err
+	}
+	bandwidth, err := getBandwidthLimiter()
+	if err != nil {
+		return nil, "", "", err
+	}
+	src = bandwidth.wrapImageSource(src)
+	// Layers read from the cache of the node are not throttled
+	layerCache, err := getLayerCache()
+	if err != nil {
+		return nil, "", "", err
+	}
+	src = layerCache.wrapImageSource(src)
+
 	imgCloser, err := image.FromSource(ctx, srcCtx, src)
 	if err != nil {
 		klog.Errorf("Error retrieving image: %v", err)
-		return nil, errors.Wrap(err, "Error retrieving image")
+		return nil, "", "", errors.Wrap(err, "Error retrieving image")
 	}
 	defer imgCloser.Close()
 
+	// Images picked from an image index have their own manifest, the other ones have the manifest resolved to
+	platformManifest, _, err := imgCloser.Manifest(ctx)
+	if err != nil {
+		return nil, "", "", errors.Wrap(err, "Error retrieving image manifest")
+	}
+	platformDigest, err := manifest.Digest(platformManifest)
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	if isOCIArtifact(imgCloser) {
+		if !stopAtFirst {
+			return nil, "", "", errors.New("Cannot extract files from an OCI artifact")
+		}
+		cache := blobinfocache.DefaultCache(srcCtx)
+		if err := copyArtifactDisk(ctx, src, imgCloser, filepath.Join(destDir, pathPrefix), cache, preallocation); err != nil {
+			klog.Errorf("Error copying the disk image of the OCI artifact: %v", err)
+			return nil, "", "", err
+		}
+		// Artifacts have no image config to inspect
+		return &types.ImageInspectInfo{}, imageDigest, platformDigest, nil
+	}
+
 	// in the event that target is not a manifest list / image index
 	if srcCtx.ArchitectureChoice != "" {
 		if err := validateImagePlatformMatch(srcCtx, imgCloser); err != nil {
 			klog.Errorf("Error validating architecture: %v", err)
-			return nil, fmt.Errorf("Error validating architecture: %w", err)
+			return nil, "", "", fmt.Errorf("Error validating architecture: %w", err)
 		}
 	}
 
@@ -236,7 +289,7 @@ func copyRegistryImage(url, destDir, pathPrefix, accessKey, secKey, imageArchite
 		}
 		if err != nil {
 			if !errors.Is(err, errReadingLayer) {
-				return nil, err
+				return nil, "", "", err
 			}
 			// Skipping layer and trying the next one.
 			// Error already logged in processLayer
@@ -246,15 +299,15 @@ func copyRegistryImage(url, destDir, pathPrefix, accessKey, secKey, imageArchite
 
 	if !found {
 		klog.Errorf("Failed to find VM disk image file in the container image")
-		return nil, errors.New("Failed to find VM disk image file in the container image")
+		return nil, "", "", errors.New("Failed to find VM disk image file in the container image")
 	}
 
 	info, err := imgCloser.Inspect(ctx)
 	if err != nil {
-		return nil, err
+		return nil, "", "", err
 	}
 
-	return info, nil
+	return info, imageDigest, platformDigest, nil
 }
 
 func validateImagePlatformMatch(sys *types.SystemContext, img types.Image) error {
@@ -310,7 +363,8 @@ func GetImageDigest(url, accessKey, secKey, certDir string, insecureRegistry boo
 // certDir: directory public CA keys are stored for registry identity verification
 // insecureRegistry: boolean if true will allow insecure registries.
 func CopyRegistryImage(url, destDir, pathPrefix, accessKey, secKey, imageArchitecture, certDir string, insecureRegistry, preallocation bool) (*types.ImageInspectInfo, error) {
-	return copyRegistryImage(url, destDir, pathPrefix, accessKey, secKey, imageArchitecture, certDir, insecureRegistry, true, preallocation)
+	info, _, _, err := copyRegistryImage(url, destDir, pathPrefix, accessKey, secKey, imageArchitecture, certDir, insecureRegistry, true, preallocation)
+	return info, err
 }
 
 // CopyRegistryImageAll download image from registry with docker image API. It will extract all files under the pathPrefix
@@ -322,5 +376,6 @@ func CopyRegistryImage(url, destDir, pathPrefix, accessKey, secKey, imageArchite
 // certDir: directory public CA keys are stored for registry identity verification
 // insecureRegistry: boolean if true will allow insecure registries.
 func CopyRegistryImageAll(url, destDir, pathPrefix, accessKey, secKey, certDir string, insecureRegistry, preallocation bool) (*types.ImageInspectInfo, error) {
-	return copyRegistryImage(url, destDir, pathPrefix, accessKey, secKey, "", certDir, insecureRegistry, false, preallocation)
+	info, _, _, err := copyRegistryImage(url, destDir, pathPrefix, accessKey, secKey, "", certDir, insecureRegistry, false, preallocation)
+	return info, err
 }
@@ -1,9 +1,12 @@
 package importer
 
 import (
+	"fmt"
 	"io"
 	"net/url"
+	"os"
 	"path/filepath"
+	"syscall"
 
 	"github.com/pkg/errors"
 
@@ -11,13 +14,20 @@ import (
 
 	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
 	"kubevirt.io/containerized-data-importer/pkg/common"
-	"kubevirt.io/containerized-data-importer/pkg/util"
+	"kubevirt.io/containerized-data-importer/pkg/image"
 )
 
+// uploadStreamPipe is the pipe the upload is written to for nbdkit to serve it
+const uploadStreamPipe = "upload.fifo"
+
+// Helpers for unit-testing
+var createNbdkitStreaming = image.NewNbdkitStreaming
+
 // UploadDataSource contains all the information need to upload data into a data volume.
 // Sequence of phases:
 // 1a. ProcessingPhaseInfo -> ProcessingPhaseTransferScratch (In Info phase the format readers are configured) In case the readers don't contain a raw file.
 // 1b. ProcessingPhaseInfo -> ProcessingPhaseTransferDataFile, in the case the readers contain a raw file.
+// 1c. ProcessingPhaseInfo -> ProcessingPhaseConvert, in the case of qcow2 uploads converted as they are streamed.
 // 2a. ProcessingPhaseTransferScratch -> ProcessingPhaseConvert
 // 2b. ProcessingPhaseTransferDataFile -> ProcessingPhaseResize
 type UploadDataSource struct {
@@ -29,6 +39,15 @@ type UploadDataSource struct {
 	url *url.URL
 	// contentType expected from the upload content
 	contentType cdiv1.DataVolumeContentType
+	// the disk to extract if the upload is an OVA, nil otherwise
+	ovaDisk *ovaDiskSelector
+	// streaming converts qcow2 uploads from nbdkit serving them as they arrive, instead of writing them to scratch space
+	streaming bool
+	// nbdkit serving the streamed upload
+	n image.NbdkitOperation
+	// The pipe the upload is streamed to, and the directory holding it
+	pipe    *os.File
+	pipeDir string
 }
 
 // NewUploadDataSource creates a new instance of an UploadDataSource
@@ -39,6 +58,27 @@ func NewUploadDataSource(stream io.ReadCloser, contentType cdiv1.DataVolumeConte
 	}
 }
 
+// NewStreamingUploadDataSource creates a new instance of an UploadDataSource converting qcow2 uploads as they are
+// streamed, so they don't need scratch space. Other formats qemu-img has to read still require it.
+func NewStreamingUploadDataSource(stream io.ReadCloser) *UploadDataSource {
+	return &UploadDataSource{
+		stream:      stream,
+		contentType: cdiv1.DataVolumeKubeVirt,
+		streaming:   true,
+	}
+}
+
+// NewOVAUploadDataSource creates a new instance of an UploadDataSource extracting the disk named by disk, an index or a
+// disk id or file name, from the uploaded OVA
+func NewOVAUploadDataSource(stream io.ReadCloser, disk string) *UploadDataSource {
+	diskSelector := parseOVADiskSelector(disk)
+	return &UploadDataSource{
+		stream:      stream,
+		contentType: cdiv1.DataVolumeKubeVirt,
+		ovaDisk:     &diskSelector,
+	}
+}
+
 // Info is called to get initial information about the data.
 func (ud *UploadDataSource) Info() (ProcessingPhase, error) {
 	var err error
@@ -51,6 +91,17 @@ func (ud *UploadDataSource) Info() (ProcessingPhase, error) {
 	if ud.contentType == cdiv1.DataVolumeArchive {
 		return ProcessingPhaseTransferDataDir, nil
 	}
+	if ud.ovaDisk == nil && ud.readers.DetectedType == detectedArchive && isOVA(ud.readers.buf) {
+		// OVAs are extracted without the client asking for it, their first disk is imported
+		ud.ovaDisk = &ovaDiskSelector{}
+	}
+	if ud.ovaDisk != nil {
+		// qemu-img cannot read the disk inside the OVA, it has to be extracted first
+		return ProcessingPhaseTransferScratch, nil
+	}
+	if ud.streaming && ud.readers.DetectedType == "qcow2" {
+		return ud.startStreaming()
+	}
 	if !ud.readers.Convert {
 		// Uploading a raw file, we can write that directly to the target.
 		return ProcessingPhaseTransferDataFile, nil
@@ -58,9 +109,54 @@ func (ud *UploadDataSource) Info() (ProcessingPhase, error) {
 	return ProcessingPhaseTransferScratch, nil
 }
 
+// startStreaming writes the upload to a pipe served by the nbdkit streaming plugin, qemu-img converts the image from
+// nbdkit while it is uploaded.
+func (ud *UploadDataSource) startStreaming() (ProcessingPhase, error) {
+	dir, err := os.MkdirTemp("", "upload-stream")
+	if err != nil {
+		return ProcessingPhaseError, errors.Wrap(err, "unable to create the directory of the upload pipe")
+	}
+	ud.pipeDir = dir
+	pipe := filepath.Join(dir, uploadStreamPipe)
+	if err := syscall.Mkfifo(pipe, 0600); err != nil {
+		return ProcessingPhaseError, errors.Wrap(err, "unable to create the upload pipe")
+	}
+	// Opened for reading too so the open doesn't wait for nbdkit, the pipe is only at its end once it is closed here
+	ud.pipe, err = os.OpenFile(pipe, os.O_RDWR, 0)
+	if err != nil {
+		return ProcessingPhaseError, errors.Wrap(err, "unable to open the upload pipe")
+	}
+	ud.n, err = createNbdkitStreaming(nbdkitPid, nbdkitSocket)
+	if err != nil {
+		return ProcessingPhaseError, err
+	}
+	go ud.writeToPipe()
+	ud.url, _ = url.Parse(fmt.Sprintf("nbd+unix:///?socket=%s", nbdkitSocket))
+	if err := ud.n.StartNbdkit(pipe); err != nil {
+		return ProcessingPhaseError, err
+	}
+	klog.V(1).Infof("Converting the qcow2 upload as it is streamed")
+	return ProcessingPhaseConvert, nil
+}
+
+// writeToPipe copies the upload to the pipe read by nbdkit, until the upload ends or the pipe is closed by Close
+func (ud *UploadDataSource) writeToPipe() {
+	if _, err := io.Copy(ud.pipe, ud.readers.TopReader()); err != nil && !errors.Is(err, os.ErrClosed) {
+		klog.Errorf("Error streaming the upload: %v", err)
+	}
+	ud.pipe.Close()
+}
+
 // Transfer is called to transfer the data from the source to the passed in path.
 func (ud *UploadDataSource) Transfer(path string, preallocation bool) (ProcessingPhase, error) {
-	if ud.contentType == cdiv1.DataVolumeKubeVirt {
+	if ud.contentType == cdiv1.DataVolumeKubeVirt && ud.ovaDisk != nil {
+		file, err := ud.extractOVADisk(path, preallocation)
+		if err != nil {
+			return ProcessingPhaseError, err
+		}
+		ud.url, _ = url.Parse(file)
+		return ProcessingPhaseConvert, nil
+	} else if ud.contentType == cdiv1.DataVolumeKubeVirt {
 		file := filepath.Join(path, tempFile)
 		if err := CleanAll(file); err != nil {
 			return ProcessingPhaseError, err
@@ -81,8 +177,8 @@ func (ud *UploadDataSource) Transfer(path string, preallocation bool) (Processin
 		ud.url, _ = url.Parse(file)
 		return ProcessingPhaseConvert, nil
 	} else if ud.contentType == cdiv1.DataVolumeArchive {
-		if err := util.UnArchiveTar(ud.readers.TopReader(), path); err != nil {
-			return ProcessingPhaseError, errors.Wrap(err, "unable to untar files from endpoint")
+		if err := ud.readers.UnArchive(path); err != nil {
+			return ProcessingPhaseError, errors.Wrap(err, "unable to extract files from endpoint")
 		}
 		ud.url = nil
 		return ProcessingPhaseComplete, nil
@@ -90,6 +186,18 @@ func (ud *UploadDataSource) Transfer(path string, preallocation bool) (Processin
 	return ProcessingPhaseError, errors.Errorf("Unknown content type: %s", ud.contentType)
 }
 
+// extractOVADisk writes the disk of the uploaded OVA to a file of path, returning its name
+func (ud *UploadDataSource) extractOVADisk(path string, preallocation bool) (string, error) {
+	file := filepath.Join(path, tempFile)
+	if err := CleanAll(file); err != nil {
+		return "", err
+	}
+	if err := extractOVADisk(ud.readers.TopReader(), *ud.ovaDisk, file, preallocation); err != nil {
+		return "", err
+	}
+	return file, nil
+}
+
 // TransferFile is called to transfer the data from the source to the passed in file.
 func (ud *UploadDataSource) TransferFile(fileName string, preallocation bool) (ProcessingPhase, error) {
 	if err := CleanAll(fileName); err != nil {
@@ -116,10 +224,22 @@ func (ud *UploadDataSource) GetTerminationMessage() *common.TerminationMessage {
 
 // Close closes any readers or other open resources.
 func (ud *UploadDataSource) Close() error {
+	var err error
+	if ud.n != nil {
+		err = ud.n.KillNbdkit()
+	}
+	if ud.pipe != nil {
+		ud.pipe.Close()
+	}
+	if ud.pipeDir != "" {
+		os.RemoveAll(ud.pipeDir)
+	}
 	if ud.stream != nil {
-		return ud.stream.Close()
+		if closeErr := ud.stream.Close(); err == nil {
+			err = closeErr
+		}
 	}
-	return nil
+	return err
 }
 
 // AsyncUploadDataSource is an asynchronouse version of an upload data source, that returns finished phase instead
@@ -140,6 +260,15 @@ func NewAsyncUploadDataSource(stream io.ReadCloser) *AsyncUploadDataSource {
 	}
 }
 
+// NewAsyncOVAUploadDataSource creates a new instance of an AsyncUploadDataSource extracting the disk named by disk, an
+// index or a disk id or file name, from the uploaded OVA
+func NewAsyncOVAUploadDataSource(stream io.ReadCloser, disk string) *AsyncUploadDataSource {
+	return &AsyncUploadDataSource{
+		uploadDataSource: *NewOVAUploadDataSource(stream, disk),
+		ResumePhase:      ProcessingPhaseInfo,
+	}
+}
+
 // Info is called to get initial information about the data.
 func (aud *AsyncUploadDataSource) Info() (ProcessingPhase, error) {
 	return aud.uploadDataSource.Info()
@@ -147,6 +276,15 @@ func (aud *AsyncUploadDataSource) Info() (ProcessingPhase, error) {
 
 // Transfer is called to transfer the data from the source to the passed in path.
 func (aud *AsyncUploadDataSource) Transfer(path string, preallocation bool) (ProcessingPhase, error) {
+	if aud.uploadDataSource.ovaDisk != nil {
+		file, err := aud.uploadDataSource.extractOVADisk(path, preallocation)
+		if err != nil {
+			return ProcessingPhaseError, err
+		}
+		aud.uploadDataSource.url, _ = url.Parse(file)
+		aud.ResumePhase = ProcessingPhaseConvert
+		return ProcessingPhaseValidatePause, nil
+	}
 	file := filepath.Join(path, tempFile)
 	if err := CleanAll(file); err != nil {
 		return ProcessingPhaseError, err
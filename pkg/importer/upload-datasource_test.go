@@ -1,15 +1,22 @@
 package importer
 
 import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/rand"
 	"io"
 	"os"
 	"path/filepath"
 	"reflect"
 
+	"github.com/klauspost/compress/zstd"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/ulikunitz/xz"
 
 	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+	"kubevirt.io/containerized-data-importer/pkg/image"
 )
 
 const (
@@ -81,6 +88,48 @@ var _ = Describe("Upload data source", func() {
 		Expect(ProcessingPhaseTransferDataFile).To(Equal(result))
 	})
 
+	Context("with streaming conversion", func() {
+		var origNbdkitFunc func(string, string) (image.NbdkitOperation, error)
+
+		BeforeEach(func() {
+			origNbdkitFunc = createNbdkitStreaming
+			createNbdkitStreaming = image.NewMockNbdkitStreaming
+		})
+
+		AfterEach(func() {
+			createNbdkitStreaming = origNbdkitFunc
+		})
+
+		It("Info should return Convert and stream the upload through nbdkit, when passed in a qcow2 image", func() {
+			file, err := os.Open(cirrosFilePath)
+			Expect(err).NotTo(HaveOccurred())
+			ud = NewStreamingUploadDataSource(file)
+			result, err := ud.Info()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(ProcessingPhaseConvert))
+			Expect(ud.GetURL().String()).To(Equal("nbd+unix:///?socket=" + nbdkitSocket))
+
+			By("Reading the upload from the pipe served by nbdkit")
+			pipe, err := os.Open(filepath.Join(ud.pipeDir, uploadStreamPipe))
+			Expect(err).NotTo(HaveOccurred())
+			defer pipe.Close()
+			magic := make([]byte, 4)
+			_, err = io.ReadFull(pipe, magic)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(magic).To(Equal([]byte{'Q', 'F', 'I', 0xfb}))
+		})
+
+		It("Info should return TransferData, when passed in a valid raw image", func() {
+			file, err := os.Open(tinyCoreFilePath)
+			Expect(err).NotTo(HaveOccurred())
+			ud = NewStreamingUploadDataSource(file)
+			result, err := ud.Info()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(ProcessingPhaseTransferDataFile))
+			Expect(ud.pipeDir).To(BeEmpty())
+		})
+	})
+
 	DescribeTable("calling transfer should", func(fileName string, dvContentType cdiv1.DataVolumeContentType, expectedPhase ProcessingPhase, scratchPath string, want []byte, wantErr bool) {
 		if scratchPath == "" {
 			scratchPath = tmpDir
@@ -114,6 +163,70 @@ var _ = Describe("Upload data source", func() {
 		Entry("return Complete with archive content type and archive file ", archiveFilePath, dvArchive, ProcessingPhaseComplete, "", []byte{}, false),
 	)
 
+	DescribeTable("Transfer should extract the files of", func(archive func() []byte) {
+		ud = NewUploadDataSource(io.NopCloser(bytes.NewReader(archive())), dvArchive)
+		result, err := ud.Info()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(Equal(ProcessingPhaseTransferDataDir))
+		result, err = ud.Transfer(tmpDir, false)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(Equal(ProcessingPhaseComplete))
+		Expect(os.ReadFile(filepath.Join(tmpDir, "data", "README"))).To(Equal([]byte("readme")))
+		Expect(os.ReadFile(filepath.Join(tmpDir, "data", "disk.iso"))).To(Equal(testArchiveISO))
+	},
+		Entry("a tar.xz archive", func() []byte {
+			return compressTestTar(func(w io.Writer) (io.WriteCloser, error) { return xz.NewWriter(w) })
+		}),
+		Entry("a tar.zst archive", func() []byte {
+			return compressTestTar(func(w io.Writer) (io.WriteCloser, error) { return zstd.NewWriter(w) })
+		}),
+		Entry("a zip archive", func() []byte {
+			return writeZip(zipFile{name: "data/", stored: true}, zipFile{name: "data/README", content: []byte("readme")}, zipFile{name: "data/disk.iso", content: testArchiveISO})
+		}),
+	)
+
+	It("Transfer should extract the ISO of a zip archive", func() {
+		ud = NewUploadDataSource(io.NopCloser(bytes.NewReader(writeZip(zipFile{name: "disk.iso", content: testArchiveISO}))), dvKubevirt)
+		result, err := ud.Info()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(Equal(ProcessingPhaseTransferScratch))
+		result, err = ud.Transfer(tmpDir, false)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(Equal(ProcessingPhaseConvert))
+		Expect(os.ReadFile(filepath.Join(tmpDir, tempFile))).To(Equal(testArchiveISO))
+	})
+
+	It("Transfer should extract the first disk of an OVA", func() {
+		disk1 := bytes.Repeat([]byte{1}, 1024*1024)
+		ova := writeOVA(ovaFile{"vm.ovf", []byte(testOVFDescriptor)}, ovaFile{"vm-disk1.vmdk", disk1})
+		ud = NewUploadDataSource(io.NopCloser(bytes.NewReader(ova)), dvKubevirt)
+		result, err := ud.Info()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(Equal(ProcessingPhaseTransferScratch))
+		result, err = ud.Transfer(tmpDir, false)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(Equal(ProcessingPhaseConvert))
+		Expect(os.ReadFile(filepath.Join(tmpDir, tempFile))).To(Equal(disk1))
+	})
+
+	It("Transfer should extract the named disk of an OVA", func() {
+		disk2 := bytes.Repeat([]byte{2}, 1024*1024)
+		var compressedDisk2 bytes.Buffer
+		gz := gzip.NewWriter(&compressedDisk2)
+		_, err := gz.Write(disk2)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(gz.Close()).To(Succeed())
+		ova := writeOVA(ovaFile{"vm.ovf", []byte(testOVFDescriptor)}, ovaFile{"vm-disk1.vmdk", []byte("disk1")}, ovaFile{"vm-disk2.vmdk", compressedDisk2.Bytes()})
+		ud = NewOVAUploadDataSource(io.NopCloser(bytes.NewReader(ova)), "vmdisk2")
+		result, err := ud.Info()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(Equal(ProcessingPhaseTransferScratch))
+		result, err = ud.Transfer(tmpDir, false)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(Equal(ProcessingPhaseConvert))
+		Expect(os.ReadFile(filepath.Join(tmpDir, tempFile))).To(Equal(disk2))
+	})
+
 	It("Transfer should fail on reader error", func() {
 		sourceFile, err := os.Open(cirrosFilePath)
 		Expect(err).NotTo(HaveOccurred())
@@ -286,3 +399,28 @@ var _ = Describe("Async Upload data source", func() {
 		Expect(err).NotTo(HaveOccurred())
 	})
 })
+
+// testArchiveISO is the ISO of the test archives, random data as the headers of archives compressed to less
+// than 512 bytes can not be read
+var testArchiveISO = func() []byte {
+	data := make([]byte, 256*1024)
+	_, _ = rand.Read(data)
+	return data
+}()
+
+// compressTestTar returns a tar archive of a data directory holding a README and an ISO, compressed with compress
+func compressTestTar(compress func(io.Writer) (io.WriteCloser, error)) []byte {
+	var buf bytes.Buffer
+	cw, err := compress(&buf)
+	Expect(err).NotTo(HaveOccurred())
+	tw := tar.NewWriter(cw)
+	Expect(tw.WriteHeader(&tar.Header{Name: "data/", Typeflag: tar.TypeDir, Mode: 0755})).To(Succeed())
+	for name, content := range map[string][]byte{"data/README": []byte("readme"), "data/disk.iso": testArchiveISO} {
+		Expect(tw.WriteHeader(&tar.Header{Name: name, Typeflag: tar.TypeReg, Mode: 0644, Size: int64(len(content))})).To(Succeed())
+		_, err := tw.Write(content)
+		Expect(err).NotTo(HaveOccurred())
+	}
+	Expect(tw.Close()).To(Succeed())
+	Expect(cw.Close()).To(Succeed())
+	return buf.Bytes()
+}
@@ -0,0 +1,207 @@
+/*
+Copyright 2024 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package importer
+
+import (
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+
+	"k8s.io/klog/v2"
+
+	"kubevirt.io/containerized-data-importer/pkg/common"
+	"kubevirt.io/containerized-data-importer/pkg/system"
+)
+
+const (
+	// v2vOutputName is the name virt-v2v gives the converted VM, its disks are written to NAME-sda, NAME-sdb...
+	v2vOutputName = "cdi-v2v"
+	// virt-v2v installs the virtio drivers of Windows guests from the ISO or directory in this variable
+	virtioWinEnv = "VIRTIO_WIN"
+	// libguestfs runs its appliance with libvirt by default, there is none in the importer pod
+	libguestfsBackendEnv = "LIBGUESTFS_BACKEND"
+)
+
+var v2vExecFunction = system.ExecWithLimits
+
+// V2VDataSource is the data provider for vSphere VMs converted by virt-v2v. virt-v2v copies the disks of the
+// VM into scratch space, installs the virtio drivers in the guest and fixes its boot configuration, so the
+// disk boots in KubeVirt, and one of the converted disks is then written to the target.
+// Sequence of phases:
+// 1. Info -> TransferScratch
+// 2. TransferScratch -> Convert
+type V2VDataSource struct {
+	// libvirt connection url of the vCenter or ESXi host, with the user
+	libvirtURL *url.URL
+	// password of the user
+	password string
+	// name of the VM to convert
+	vm string
+	// index of the disk of the VM to import
+	diskIndex int
+	// virt-v2v options configuring the guest
+	blockDriver string
+	macMappings []string
+	virtioWin   string
+	// Directory holding the password file
+	passwordDir string
+	// The converted disk in scratch space
+	url *url.URL
+}
+
+// NewV2VDataSource creates a new instance of the V2VDataSource. endpoint is the vpx:// or esx:// libvirt url of
+// the vSphere host of the VM, macMappings the virt-v2v --mac values and virtioWin the optional path of the virtio-win ISO.
+func NewV2VDataSource(endpoint, user, password, vm string, diskIndex int, blockDriver string, macMappings []string, virtioWin string) (*V2VDataSource, error) {
+	ep, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to parse endpoint %q", endpoint)
+	}
+	if ep.Scheme != "vpx" && ep.Scheme != "esx" {
+		return nil, errors.Errorf("invalid v2v url scheme %q, expected vpx or esx", ep.Scheme)
+	}
+	if user == "" || password == "" {
+		return nil, errors.New("v2v sources need the user and the password of the vSphere host")
+	}
+	if vm == "" {
+		return nil, errors.New("v2v source has no VM")
+	}
+	if diskIndex < 0 {
+		return nil, errors.Errorf("v2v disk index %d is not valid", diskIndex)
+	}
+	if virtioWin != "" {
+		if _, err := os.Stat(virtioWin); err != nil {
+			return nil, errors.Wrap(err, "unable to access the virtio-win ISO")
+		}
+	}
+	// libvirt takes the user from the url, vCenter users like administrator@vsphere.local are escaped
+	ep.User = url.User(user)
+	klog.V(3).Infof("V2V Importer: converting disk %d of VM %s from %s", diskIndex, vm, ep.Redacted())
+	return &V2VDataSource{
+		libvirtURL:  ep,
+		password:    password,
+		vm:          vm,
+		diskIndex:   diskIndex,
+		blockDriver: blockDriver,
+		macMappings: macMappings,
+		virtioWin:   virtioWin,
+	}, nil
+}
+
+// Info is called to get initial information about the data. virt-v2v writes the converted disks
+// to scratch space.
+func (vd *V2VDataSource) Info() (ProcessingPhase, error) {
+	return ProcessingPhaseTransferScratch, nil
+}
+
+// Transfer runs virt-v2v, converting the VM into path.
+func (vd *V2VDataSource) Transfer(path string, preallocation bool) (ProcessingPhase, error) {
+	outputDir := filepath.Join(path, v2vOutputName)
+	if err := os.RemoveAll(outputDir); err != nil {
+		return ProcessingPhaseError, errors.Wrap(err, "unable to clean the virt-v2v output directory")
+	}
+	if err := os.MkdirAll(outputDir, 0750); err != nil {
+		return ProcessingPhaseError, errors.Wrap(err, "unable to create the virt-v2v output directory")
+	}
+	passwordFile, err := vd.writePasswordFile()
+	if err != nil {
+		return ProcessingPhaseError, err
+	}
+	if err := os.Setenv(libguestfsBackendEnv, "direct"); err != nil {
+		return ProcessingPhaseError, errors.Wrap(err, "unable to set the libguestfs backend")
+	}
+	if vd.virtioWin != "" {
+		if err := os.Setenv(virtioWinEnv, vd.virtioWin); err != nil {
+			return ProcessingPhaseError, errors.Wrap(err, "unable to set the virtio-win ISO")
+		}
+	}
+	if _, err := v2vExecFunction(nil, nil, "virt-v2v", vd.v2vArgs(passwordFile, outputDir)...); err != nil {
+		return ProcessingPhaseError, errors.Wrapf(err, "virt-v2v could not convert VM %s", vd.vm)
+	}
+	disk := filepath.Join(outputDir, v2vOutputName+"-"+v2vDiskName(vd.diskIndex))
+	if _, err := os.Stat(disk); err != nil {
+		return ProcessingPhaseError, errors.Errorf("VM %s has no disk %d", vd.vm, vd.diskIndex)
+	}
+	vd.url = &url.URL{Path: disk}
+	return ProcessingPhaseConvert, nil
+}
+
+// v2vArgs returns the virt-v2v arguments converting the VM to raw disks in outputDir
+func (vd *V2VDataSource) v2vArgs(passwordFile, outputDir string) []string {
+	args := []string{
+		"-i", "libvirt", "-ic", vd.libvirtURL.String(), "-ip", passwordFile,
+		"-o", "local", "-os", outputDir, "-of", "raw", "-on", v2vOutputName,
+	}
+	if vd.blockDriver != "" {
+		args = append(args, "--block-driver", vd.blockDriver)
+	}
+	for _, mapping := range vd.macMappings {
+		args = append(args, "--mac", mapping)
+	}
+	return append(args, "--", vd.vm)
+}
+
+// writePasswordFile writes the password virt-v2v connects with, keeping it out of its command line
+func (vd *V2VDataSource) writePasswordFile() (string, error) {
+	if vd.passwordDir == "" {
+		dir, err := os.MkdirTemp("", "v2v")
+		if err != nil {
+			return "", errors.Wrap(err, "unable to create the virt-v2v password directory")
+		}
+		vd.passwordDir = dir
+	}
+	passwordFile := filepath.Join(vd.passwordDir, "password")
+	if err := os.WriteFile(passwordFile, []byte(vd.password), 0600); err != nil {
+		return "", errors.Wrap(err, "unable to write the virt-v2v password file")
+	}
+	return passwordFile, nil
+}
+
+// v2vDiskName returns the name virt-v2v gives disk index, sda, sdb, ..., sdz, sdaa...
+func v2vDiskName(index int) string {
+	name := ""
+	for index++; index > 0; index = (index - 1) / 26 {
+		name = string(rune('a'+(index-1)%26)) + name
+	}
+	return "sd" + name
+}
+
+// TransferFile is not used, the disk is converted in scratch space.
+func (vd *V2VDataSource) TransferFile(fileName string, preallocation bool) (ProcessingPhase, error) {
+	return ProcessingPhaseError, errors.New("transfer file is not supported for v2v sources")
+}
+
+// GetURL returns the url that the data processor can use when converting the data.
+func (vd *V2VDataSource) GetURL() *url.URL {
+	return vd.url
+}
+
+// GetTerminationMessage returns data to be serialized and used as the termination message of the importer.
+func (vd *V2VDataSource) GetTerminationMessage() *common.TerminationMessage {
+	return nil
+}
+
+// Close removes the password file.
+func (vd *V2VDataSource) Close() error {
+	if vd.passwordDir != "" {
+		return os.RemoveAll(vd.passwordDir)
+	}
+	return nil
+}
+
+var _ DataSourceInterface = &V2VDataSource{}
@@ -0,0 +1,121 @@
+package importer
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"kubevirt.io/containerized-data-importer/pkg/system"
+)
+
+var _ = Describe("V2V data source", func() {
+	const endpoint = "vpx://vcenter.example.com/Datacenter/Cluster/esxi1.example.com?no_verify=1"
+
+	var (
+		tmpDir      string
+		v2vArgs     []string
+		v2vPassword string
+		v2vDisks    []string
+		v2vErr      error
+	)
+
+	BeforeEach(func() {
+		var err error
+		tmpDir, err = os.MkdirTemp("", "v2v")
+		Expect(err).NotTo(HaveOccurred())
+		v2vArgs = nil
+		v2vDisks = []string{"sda", "sdb"}
+		v2vErr = nil
+		v2vExecFunction = func(_ *system.ProcessLimitValues, _ func(string), command string, args ...string) ([]byte, error) {
+			defer GinkgoRecover()
+			Expect(command).To(Equal("virt-v2v"))
+			v2vArgs = args
+			password, err := os.ReadFile(args[5])
+			Expect(err).NotTo(HaveOccurred())
+			v2vPassword = string(password)
+			for _, disk := range v2vDisks {
+				Expect(os.WriteFile(filepath.Join(args[9], v2vOutputName+"-"+disk), []byte("converted"), 0600)).To(Succeed())
+			}
+			return nil, v2vErr
+		}
+	})
+
+	AfterEach(func() {
+		v2vExecFunction = system.ExecWithLimits
+		os.RemoveAll(tmpDir)
+		os.Unsetenv(virtioWinEnv)
+		os.Unsetenv(libguestfsBackendEnv)
+	})
+
+	It("Should convert the VM and import the selected disk", func() {
+		virtioWin := filepath.Join(tmpDir, "virtio-win.iso")
+		Expect(os.WriteFile(virtioWin, nil, 0600)).To(Succeed())
+		vd, err := NewV2VDataSource(endpoint, "administrator@vsphere.local", "secret", "win2019", 1, "virtio-scsi",
+			[]string{"00:50:56:aa:bb:cc:network:pod", "00:50:56:aa:bb:cc:ip:192.168.1.50,192.168.1.1,24,192.168.1.2"}, virtioWin)
+		Expect(err).NotTo(HaveOccurred())
+		phase, err := vd.Info()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(phase).To(Equal(ProcessingPhaseTransferScratch))
+		phase, err = vd.Transfer(tmpDir, false)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(phase).To(Equal(ProcessingPhaseConvert))
+
+		outputDir := filepath.Join(tmpDir, v2vOutputName)
+		Expect(v2vArgs).To(Equal([]string{
+			"-i", "libvirt", "-ic", "vpx://administrator%40vsphere.local@vcenter.example.com/Datacenter/Cluster/esxi1.example.com?no_verify=1",
+			"-ip", filepath.Join(vd.passwordDir, "password"),
+			"-o", "local", "-os", outputDir, "-of", "raw", "-on", v2vOutputName,
+			"--block-driver", "virtio-scsi",
+			"--mac", "00:50:56:aa:bb:cc:network:pod",
+			"--mac", "00:50:56:aa:bb:cc:ip:192.168.1.50,192.168.1.1,24,192.168.1.2",
+			"--", "win2019",
+		}))
+		Expect(v2vPassword).To(Equal("secret"))
+		Expect(os.Getenv(virtioWinEnv)).To(Equal(virtioWin))
+		Expect(os.Getenv(libguestfsBackendEnv)).To(Equal("direct"))
+		Expect(vd.GetURL().String()).To(Equal(filepath.Join(outputDir, v2vOutputName+"-sdb")))
+		Expect(vd.Close()).To(Succeed())
+		Expect(vd.passwordDir).ToNot(BeADirectory())
+	})
+
+	It("Should fail if the VM has no such disk", func() {
+		vd, err := NewV2VDataSource("esx://esxi1.example.com", "root", "secret", "rhel9", 2, "", nil, "")
+		Expect(err).NotTo(HaveOccurred())
+		defer vd.Close()
+		_, err = vd.Transfer(tmpDir, false)
+		Expect(err).To(MatchError("VM rhel9 has no disk 2"))
+	})
+
+	It("Should fail if virt-v2v fails", func() {
+		v2vErr = errors.New("exit status 1")
+		vd, err := NewV2VDataSource(endpoint, "root", "secret", "rhel9", 0, "", nil, "")
+		Expect(err).NotTo(HaveOccurred())
+		defer vd.Close()
+		_, err = vd.Transfer(tmpDir, false)
+		Expect(err).To(MatchError(ContainSubstring("virt-v2v could not convert VM rhel9")))
+	})
+
+	DescribeTable("Should name disk", func(index int, name string) {
+		Expect(v2vDiskName(index)).To(Equal(name))
+	},
+		Entry("0 sda", 0, "sda"),
+		Entry("25 sdz", 25, "sdz"),
+		Entry("26 sdaa", 26, "sdaa"),
+		Entry("701 sdzz", 701, "sdzz"),
+	)
+
+	DescribeTable("Should fail with", func(endpoint, user, vm string, diskIndex int, virtioWin, errSubstring string) {
+		_, err := NewV2VDataSource(endpoint, user, "secret", vm, diskIndex, "", nil, virtioWin)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring(errSubstring))
+	},
+		Entry("another scheme", "https://vcenter.example.com/sdk", "root", "rhel9", 0, "", "invalid v2v url scheme"),
+		Entry("no user", endpoint, "", "rhel9", 0, "", "need the user and the password"),
+		Entry("no VM", endpoint, "root", "", 0, "", "has no VM"),
+		Entry("a negative disk index", endpoint, "root", "rhel9", -1, "", "disk index -1 is not valid"),
+		Entry("a missing virtio-win ISO", endpoint, "root", "rhel9", 0, "/virtio-win/disk.img", "unable to access the virtio-win ISO"),
+	)
+})
@@ -0,0 +1,78 @@
+/*
+Copyright 2026 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package importer
+
+import (
+	"github.com/pkg/errors"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+
+	"kubevirt.io/containerized-data-importer/pkg/common"
+)
+
+// ValidateSource checks the source of a validate only import without writing it anywhere. Reading the information of
+// the data source checks it is reachable with its credentials. The images the data source exposes as a URL are then
+// inspected with qemu-img, checking their format is supported and they fit in requestImageSize. Without a requested
+// size, like when the size of the target is detected from the source, only the format and the size are reported.
+func ValidateSource(ds DataSourceInterface, requestImageSize string, filesystemOverhead float64) *common.SourceValidation {
+	pp, err := ds.Info()
+	if err != nil {
+		return &common.SourceValidation{Message: errors.Wrap(err, "Unable to obtain information about data source").Error()}
+	}
+	switch {
+	case pp == ProcessingPhaseConvert, pp == ProcessingPhaseValidatePause, pp == ProcessingPhaseValidatePreScratch:
+	case pp == ProcessingPhaseTransferDataFile && isResumedToTarget(ds):
+		// Raw http images that are written as is to the target are still exposed by nbdkit
+	default:
+		klog.V(1).Infof("Source is read in phase %s, the image can only be inspected once transferred", pp)
+		return &common.SourceValidation{Valid: true}
+	}
+
+	url := ds.GetURL()
+	info, err := qemuOperations.Info(url)
+	if err != nil {
+		return &common.SourceValidation{Message: errors.Wrap(err, "Unable to inspect the image").Error()}
+	}
+	validation := &common.SourceValidation{
+		Format:      info.Format,
+		VirtualSize: ptr.To(info.VirtualSize),
+	}
+	if requestImageSize == "" {
+		validation.Valid = true
+		return validation
+	}
+	size, err := resource.ParseQuantity(requestImageSize)
+	if err != nil {
+		validation.Message = errors.Wrapf(err, "Invalid requested image size %q", requestImageSize).Error()
+		return validation
+	}
+	// Block volumes have no filesystem overhead
+	if err := qemuOperations.Validate(url, size.Value(), filesystemOverhead, v1.PersistentVolumeFilesystem); err != nil {
+		validation.Message = err.Error()
+		return validation
+	}
+	validation.Valid = true
+	return validation
+}
+
+func isResumedToTarget(ds DataSourceInterface) bool {
+	hs, ok := ds.(*HTTPDataSource)
+	return ok && hs.resumesToTarget()
+}
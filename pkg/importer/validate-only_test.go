@@ -0,0 +1,87 @@
+package importer
+
+import (
+	"errors"
+	"net/url"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/utils/ptr"
+
+	"kubevirt.io/containerized-data-importer/pkg/image"
+)
+
+var _ = Describe("Validate only imports", func() {
+	var sourceURL *url.URL
+
+	BeforeEach(func() {
+		var err error
+		sourceURL, err = url.Parse("http://example.com/disk.qcow2")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should find unreachable sources not valid", func() {
+		mdp := &MockDataProvider{infoResponse: ProcessingPhaseError}
+		validation := ValidateSource(mdp, "1G", 0.055)
+		Expect(validation.Valid).To(BeFalse())
+		Expect(validation.Message).To(ContainSubstring("Info errored"))
+		Expect(mdp.calledPhases).To(Equal([]ProcessingPhase{ProcessingPhaseInfo}))
+	})
+
+	It("should not inspect sources that are only readable once transferred", func() {
+		mdp := &MockDataProvider{infoResponse: ProcessingPhaseTransferScratch}
+		replaceQEMUOperations(NewQEMUAllErrors(), func() {
+			validation := ValidateSource(mdp, "1G", 0.055)
+			Expect(validation.Valid).To(BeTrue())
+			Expect(validation.Format).To(BeEmpty())
+			Expect(validation.VirtualSize).To(BeNil())
+		})
+		Expect(mdp.calledPhases).To(Equal([]ProcessingPhase{ProcessingPhaseInfo}))
+	})
+
+	It("should report the format and size of valid images", func() {
+		mdp := &MockDataProvider{infoResponse: ProcessingPhaseConvert, url: sourceURL}
+		info := fakeInfoOpRetVal{imgInfo: &image.ImgInfo{Format: "qcow2", VirtualSize: SmallVirtualSize}}
+		replaceQEMUOperations(NewFakeQEMUOperations(nil, nil, info, nil, nil, nil), func() {
+			validation := ValidateSource(mdp, "1G", 0.055)
+			Expect(validation.Valid).To(BeTrue())
+			Expect(validation.Format).To(Equal("qcow2"))
+			Expect(validation.VirtualSize).To(Equal(ptr.To[int64](SmallVirtualSize)))
+			Expect(validation.Message).To(BeEmpty())
+		})
+	})
+
+	It("should only report the format and size of images without a requested size", func() {
+		mdp := &MockDataProvider{infoResponse: ProcessingPhaseConvert, url: sourceURL}
+		info := fakeInfoOpRetVal{imgInfo: &image.ImgInfo{Format: "raw", VirtualSize: SmallVirtualSize}}
+		replaceQEMUOperations(NewFakeQEMUOperations(nil, nil, info, image.ErrLargerPVCRequired, nil, nil), func() {
+			validation := ValidateSource(mdp, "", 0)
+			Expect(validation.Valid).To(BeTrue())
+			Expect(validation.Format).To(Equal("raw"))
+			Expect(validation.VirtualSize).To(Equal(ptr.To[int64](SmallVirtualSize)))
+		})
+	})
+
+	It("should find images failing the validation not valid", func() {
+		mdp := &MockDataProvider{infoResponse: ProcessingPhaseValidatePreScratch, url: sourceURL}
+		info := fakeInfoOpRetVal{imgInfo: &image.ImgInfo{Format: "qcow2", VirtualSize: SmallVirtualSize}}
+		replaceQEMUOperations(NewFakeQEMUOperations(nil, nil, info, image.ErrLargerPVCRequired, nil, nil), func() {
+			validation := ValidateSource(mdp, "1Ki", 0.055)
+			Expect(validation.Valid).To(BeFalse())
+			Expect(validation.Format).To(Equal("qcow2"))
+			Expect(validation.Message).To(Equal(image.ErrLargerPVCRequired.Error()))
+		})
+	})
+
+	It("should find images qemu-img can not read not valid", func() {
+		mdp := &MockDataProvider{infoResponse: ProcessingPhaseConvert, url: sourceURL}
+		info := fakeInfoOpRetVal{e: errors.New("unknown format")}
+		replaceQEMUOperations(NewFakeQEMUOperations(nil, nil, info, nil, nil, nil), func() {
+			validation := ValidateSource(mdp, "1G", 0.055)
+			Expect(validation.Valid).To(BeFalse())
+			Expect(validation.Format).To(BeEmpty())
+			Expect(validation.Message).To(ContainSubstring("unknown format"))
+		})
+	})
+})
@@ -534,6 +534,20 @@ type NbdOperations interface {
 	BlockStatus(uint64, uint64, libnbd.ExtentCallback, *libnbd.BlockStatusOptargs) error
 }
 
+// throttledNbd throttles the data read from the source disk to the maximum bandwidth of the import
+type throttledNbd struct {
+	NbdOperations
+	bandwidth *bandwidthLimiter
+}
+
+func (t *throttledNbd) Pread(buf []byte, offset uint64, optargs *libnbd.PreadOptargs) error {
+	if err := t.NbdOperations.Pread(buf, offset, optargs); err != nil {
+		return err
+	}
+	t.bandwidth.wait(len(buf))
+	return nil
+}
+
 // BlockStatusData holds zero/hole status for one block of data
 type BlockStatusData struct {
 	Offset int64
@@ -842,6 +856,11 @@ func createVddkDataSource(endpoint string, accessKey string, secKey string, thum
 		return nil, errors.New("previous checkpoint set without current")
 	}
 
+	bandwidth, err := getBandwidthLimiter()
+	if err != nil {
+		return nil, err
+	}
+
 	// Log in to VMware to make sure disks and snapshots are present
 	vmware, err := newVMwareClient(endpoint, accessKey, secKey, thumbprint, uuid)
 	if err != nil {
@@ -882,6 +901,9 @@ func createVddkDataSource(endpoint string, accessKey string, secKey string, thum
 		klog.Errorf("Unable to start nbdkit: %v", err)
 		return nil, err
 	}
+	if bandwidth != nil {
+		nbdkit.Handle = &throttledNbd{NbdOperations: nbdkit.Handle, bandwidth: bandwidth}
+	}
 
 	// Get the total transfer size of either the disk or the delta
 	var size uint64
@@ -1098,7 +1120,7 @@ func (vs *VDDKDataSource) TransferFile(fileName string, preallocation bool) (Pro
 				changed.StartOffset = response.Returnval.StartOffset
 				changed.Length = response.Returnval.Length
 			} else { // Previous checkpoint is a snapshot
-				changedAreas, err := vs.VMware.vm.QueryChangedDiskAreas(vs.VMware.context, previousSnapshot, currentSnapshot, backingFileObject, changed.Length)
+				changedAreas, err := vs.VMware.vm.QueryChangedDiskAreas(vs.VMware.context, previousSnapshot, currentSnapshot, backingFileObject, offset)
 				if err != nil {
 					klog.Errorf("Unable to query changed areas: %s", err)
 					return ProcessingPhaseError, err
@@ -506,6 +506,59 @@ var _ = Describe("VDDK data source", func() {
 		Expect(*source.GetTerminationMessage()).To(Equal(common.TerminationMessage{VddkInfo: &common.VddkInfo{Version: testVersion, Host: testHost}}))
 	})
 
+	It("VDDK delta copy should query the changed areas between snapshots past the first 2000 blocks", func() {
+		diskName := "disk"
+		diskSize := int64(3 << 20)
+		newVddkDataSource = createVddkDataSource
+		snapshots := createSnapshots("checkpoint-1", "checkpoint-2")
+		currentVMwareFunctions.FindSnapshot = func(ctx context.Context, nameOrID string) (*types.ManagedObjectReference, error) {
+			return &snapshots.RootSnapshotList[0].Snapshot, nil
+		}
+		currentVMwareFunctions.Properties = func(ctx context.Context, ref types.ManagedObjectReference, property []string, result interface{}) error {
+			switch out := result.(type) {
+			case *mo.VirtualMachine:
+				if property[0] == "config.hardware.device" {
+					out.Config = createVirtualDiskConfigWithSize(diskName, 12345, diskSize)
+				} else if property[0] == "snapshot" {
+					out.Snapshot = snapshots
+				}
+			case *mo.VirtualMachineSnapshot:
+				out.Config = *createVirtualDiskConfigWithSize("snapshotdisk", 123456, diskSize)
+			}
+			return nil
+		}
+		// Each answer covers 1MiB of the disk, the next query has to start where it ended
+		var offsets []int64
+		currentVMwareFunctions.QueryChangedDiskAreas = func(ctx context.Context, base, changed *types.ManagedObjectReference, disk *types.VirtualDisk, offset int64) (types.DiskChangeInfo, error) {
+			offsets = append(offsets, offset)
+			if offset >= diskSize || len(offsets) > 3 {
+				return types.DiskChangeInfo{StartOffset: offset}, nil
+			}
+			return types.DiskChangeInfo{
+				StartOffset: offset,
+				Length:      1 << 20,
+				ChangedArea: []types.DiskChangeExtent{{Start: offset, Length: 512}},
+			}, nil
+		}
+		MockableStat = func(string) (fs.FileInfo, error) {
+			return nil, nil
+		}
+
+		ds, err := NewVDDKDataSource("http://vcenter.test", "user", "pass", "aa:bb:cc:dd", "1-2-3-4", diskName, "checkpoint-2", "checkpoint-1", "", v1.PersistentVolumeFilesystem)
+		Expect(err).ToNot(HaveOccurred())
+		ds.Size = uint64(diskSize)
+		sourceBytes := bytes.Repeat([]byte{0x55}, int(diskSize))
+		replaceExport := currentExport
+		replaceExport.Read = func(uint64) ([]byte, error) {
+			return sourceBytes, nil
+		}
+		currentExport = replaceExport
+		mockSinkBuffer = bytes.Repeat([]byte{0x00}, int(diskSize))
+		_, err = ds.TransferFile("", false)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(offsets).To(Equal([]int64{0, 1 << 20, 2 << 20}))
+	})
+
 	It("VDDK return more than 2000 changed block area", func() {
 		diskName := "disk"
 		snapshotName := "checkpoint-2"
@@ -0,0 +1,126 @@
+/*
+Copyright 2024 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package importer
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/pkg/errors"
+
+	"k8s.io/klog/v2"
+)
+
+const (
+	vmdkDescriptorSignature = "# Disk DescriptorFile"
+	// descriptors are a few lines of text, anything bigger is not one
+	vmdkMaxDescriptorSize = 1024 * 1024
+)
+
+// vmdkExtentLine matches the extent lines of a VMDK descriptor, like RW 4192256 SPARSE "disk-s001.vmdk"
+var vmdkExtentLine = regexp.MustCompile(`^(RW|RDONLY|NOACCESS)\s+\d+\s+(\w+)(?:\s+"([^"]*)")?`)
+
+// vmdkExtentFetcher returns a reader for an extent file of a VMDK, named relative to its descriptor
+type vmdkExtentFetcher func(name string) (io.ReadCloser, error)
+
+// isVMDKDescriptor returns true if hdr is the start of a VMDK descriptor file, the disk is then in the
+// separate extent files it references, like the -s001.vmdk parts of a split VMDK. Monolithic VMDKs
+// embed their descriptor and are not descriptor files.
+func isVMDKDescriptor(hdr []byte) bool {
+	return bytes.HasPrefix(hdr, []byte(vmdkDescriptorSignature))
+}
+
+// getVMDKExtents returns the files of the extents listed in a VMDK descriptor, in order
+func getVMDKExtents(descriptor []byte) ([]string, error) {
+	var extents []string
+	scanner := bufio.NewScanner(bytes.NewReader(descriptor))
+	for scanner.Scan() {
+		match := vmdkExtentLine.FindStringSubmatch(scanner.Text())
+		if match == nil {
+			continue
+		}
+		// ZERO extents read as zeroes and have no file
+		if match[2] == "ZERO" {
+			continue
+		}
+		name := match[3]
+		if name == "" {
+			return nil, errors.Errorf("VMDK %s extent has no file", match[2])
+		}
+		// Extents are fetched next to the descriptor, and written next to it in scratch space
+		if name != filepath.Base(name) || name == "." || name == ".." || name == tempFile {
+			return nil, errors.Errorf("VMDK extent file %q is not next to its descriptor", name)
+		}
+		extents = append(extents, name)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "unable to read VMDK descriptor")
+	}
+	if len(extents) == 0 {
+		return nil, errors.New("VMDK descriptor has no extent files")
+	}
+	return extents, nil
+}
+
+// transferVMDKDescriptor writes the VMDK descriptor read from r to dir, and the extents it references, fetched
+// with fetch, next to it where qemu-img looks for them. It returns the path of the descriptor in dir.
+func transferVMDKDescriptor(r io.Reader, dir string, fetch vmdkExtentFetcher, preallocation bool) (string, error) {
+	descriptor, err := io.ReadAll(io.LimitReader(r, vmdkMaxDescriptorSize+1))
+	if err != nil {
+		return "", errors.Wrap(err, "unable to read VMDK descriptor")
+	}
+	if len(descriptor) > vmdkMaxDescriptorSize {
+		return "", errors.Errorf("VMDK descriptor is larger than %d bytes", vmdkMaxDescriptorSize)
+	}
+	extents, err := getVMDKExtents(descriptor)
+	if err != nil {
+		return "", err
+	}
+	file := filepath.Join(dir, tempFile)
+	if err := CleanAll(file); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(file, descriptor, 0600); err != nil {
+		return "", errors.Wrap(err, "unable to write VMDK descriptor")
+	}
+	for _, name := range extents {
+		if err := transferVMDKExtent(name, filepath.Join(dir, name), fetch, preallocation); err != nil {
+			return "", err
+		}
+	}
+	return file, nil
+}
+
+func transferVMDKExtent(name, file string, fetch vmdkExtentFetcher, preallocation bool) error {
+	klog.Infof("Fetching VMDK extent %s", name)
+	if err := CleanAll(file); err != nil {
+		return err
+	}
+	reader, err := fetch(name)
+	if err != nil {
+		return errors.Wrapf(err, "unable to fetch VMDK extent %s", name)
+	}
+	defer reader.Close()
+	if _, _, err := StreamDataToFile(reader, file, preallocation); err != nil {
+		return errors.Wrapf(err, "unable to write VMDK extent %s", name)
+	}
+	return nil
+}
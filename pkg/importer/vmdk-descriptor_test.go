@@ -0,0 +1,165 @@
+package importer
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/pkg/errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+	"kubevirt.io/containerized-data-importer/pkg/image"
+)
+
+const testVMDKDescriptor = `# Disk DescriptorFile
+version=1
+encoding="UTF-8"
+CID=fffffffe
+parentCID=ffffffff
+createType="twoGbMaxExtentSparse"
+
+# Extent description
+RW 4192256 SPARSE "disk-s001.vmdk"
+RW 2048 ZERO
+RW 4192256 SPARSE "disk-s002.vmdk"
+
+# The Disk Data Base
+#DDB
+
+ddb.adapterType = "lsilogic"
+ddb.geometry.cylinders = "522"
+ddb.geometry.heads = "255"
+ddb.geometry.sectors = "63"
+ddb.longContentID = "2d6ae1a1e0b6c3e2b2a3c9a7fffffffe"
+ddb.uuid = "60 00 C2 9a 4f 3b 5d 1e-8b 2c 7f 4d 6e 9a 1b 3c"
+ddb.virtualHWVersion = "14"
+ddb.toolsInstallType = "4"
+ddb.toolsVersion = "11269"
+`
+
+// s3ObjectsMockClient returns the objects it holds, keyed by bucket/key
+type s3ObjectsMockClient struct {
+	objects map[string][]byte
+}
+
+func (mc *s3ObjectsMockClient) GetObjectWithContext(_ aws.Context, input *s3.GetObjectInput, _ ...request.Option) (*s3.GetObjectOutput, error) {
+	content, ok := mc.objects[aws.StringValue(input.Bucket)+"/"+aws.StringValue(input.Key)]
+	if !ok {
+		return nil, errors.New("NoSuchKey")
+	}
+	return &s3.GetObjectOutput{Body: io.NopCloser(bytes.NewReader(content))}, nil
+}
+
+var _ = Describe("VMDK descriptor", func() {
+	var tmpDir string
+
+	extent1 := []byte("KDMV" + strings.Repeat("1", 1024))
+	extent2 := []byte("KDMV" + strings.Repeat("2", 1024))
+
+	expectTransferred := func(descriptor string) {
+		Expect(descriptor).To(Equal(filepath.Join(tmpDir, tempFile)))
+		Expect(os.ReadFile(descriptor)).To(Equal([]byte(testVMDKDescriptor)))
+		Expect(os.ReadFile(filepath.Join(tmpDir, "disk-s001.vmdk"))).To(Equal(extent1))
+		Expect(os.ReadFile(filepath.Join(tmpDir, "disk-s002.vmdk"))).To(Equal(extent2))
+	}
+
+	BeforeEach(func() {
+		var err error
+		tmpDir, err = os.MkdirTemp("", "vmdk")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(tmpDir)
+	})
+
+	It("Should tell descriptor files apart", func() {
+		Expect(isVMDKDescriptor([]byte(testVMDKDescriptor))).To(BeTrue())
+		Expect(isVMDKDescriptor(extent1)).To(BeFalse())
+	})
+
+	It("Should list the extent files of a descriptor", func() {
+		extents, err := getVMDKExtents([]byte(testVMDKDescriptor))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(extents).To(Equal([]string{"disk-s001.vmdk", "disk-s002.vmdk"}))
+	})
+
+	DescribeTable("Should fail to list the extents of a descriptor with", func(extent, errSubstring string) {
+		_, err := getVMDKExtents([]byte(vmdkDescriptorSignature + "\n" + extent + "\n"))
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring(errSubstring))
+	},
+		Entry("an extent in another directory", `RW 2048 FLAT "../etc/passwd" 0`, "is not next to its descriptor"),
+		Entry("an extent named like the scratch image", `RW 2048 FLAT "`+tempFile+`" 0`, "is not next to its descriptor"),
+		Entry("an extent without a file", `RW 2048 SPARSE`, "extent has no file"),
+		Entry("only zero extents", `RW 2048 ZERO`, "has no extent files"),
+	)
+
+	It("Should fetch the extents next to the descriptor from an http endpoint", func() {
+		createNbdkitCurl = image.NewMockNbdkitCurl
+		files := map[string][]byte{"/images/disk.vmdk": []byte(testVMDKDescriptor), "/images/disk-s001.vmdk": extent1, "/images/disk-s002.vmdk": extent2}
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			content, ok := files[r.URL.Path]
+			if !ok {
+				http.NotFound(w, r)
+				return
+			}
+			_, _ = w.Write(content)
+		}))
+		defer ts.Close()
+		dp, err := NewHTTPDataSource(ts.URL+"/images/disk.vmdk", "", "", "", cdiv1.DataVolumeKubeVirt)
+		Expect(err).NotTo(HaveOccurred())
+		defer dp.Close()
+		phase, err := dp.Info()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(phase).To(Equal(ProcessingPhaseTransferScratch))
+		phase, err = dp.Transfer(tmpDir, false)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(phase).To(Equal(ProcessingPhaseConvert))
+		expectTransferred(dp.GetURL().String())
+	})
+
+	It("Should fetch the extents next to the descriptor from an s3 bucket", func() {
+		client := &s3ObjectsMockClient{objects: map[string][]byte{
+			"bucket/images/disk.vmdk":      []byte(testVMDKDescriptor),
+			"bucket/images/disk-s001.vmdk": extent1,
+			"bucket/images/disk-s002.vmdk": extent2,
+		}}
+		newClientFunc = func(endpoint string, creds S3Credentials, certDir string, urlScheme string) (S3Client, error) {
+			return client, nil
+		}
+		defer func() { newClientFunc = getS3Client }()
+		sd, err := NewS3DataSource("http://s3.example.com/bucket/images/disk.vmdk", S3Credentials{}, "")
+		Expect(err).NotTo(HaveOccurred())
+		defer sd.Close()
+		phase, err := sd.Info()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(phase).To(Equal(ProcessingPhaseTransferScratch))
+		phase, err = sd.Transfer(tmpDir, false)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(phase).To(Equal(ProcessingPhaseConvert))
+		expectTransferred(sd.GetURL().String())
+	})
+
+	It("Should fail if an extent is missing", func() {
+		fetch := func(name string) (io.ReadCloser, error) {
+			if name == "disk-s002.vmdk" {
+				return nil, errors.New("not found")
+			}
+			return io.NopCloser(bytes.NewReader(extent1)), nil
+		}
+		_, err := transferVMDKDescriptor(strings.NewReader(testVMDKDescriptor), tmpDir, fetch, false)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("unable to fetch VMDK extent disk-s002.vmdk"))
+	})
+})
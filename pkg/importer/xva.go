@@ -0,0 +1,271 @@
+/*
+Copyright 2024 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package importer
+
+import (
+	"archive/tar"
+	"bytes"
+	"crypto/sha1" //nolint:gosec // XVA chunks are checked against the SHA1 digests XAPI writes
+	"encoding/hex"
+	"encoding/xml"
+	"hash"
+	"io"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"k8s.io/klog/v2"
+)
+
+const (
+	// xvaDescriptor is the first file of an XVA, describing the exported VM and its disks
+	xvaDescriptor = "ova.xml"
+	// xvaChunkSize is the size of the chunk files the disks of an XVA are split in, chunks of zeros are left out
+	xvaChunkSize = 1024 * 1024
+	xvaChecksum  = ".checksum"
+)
+
+// xvaValue is an XML-RPC value of the XVA descriptor, the descriptor is the XAPI database of the exported objects
+type xvaValue struct {
+	Text   string      `xml:",chardata"`
+	String *string     `xml:"string"`
+	Struct []xvaMember `xml:"struct>member"`
+	Array  []xvaValue  `xml:"array>data>value"`
+}
+
+type xvaMember struct {
+	Name  string   `xml:"name"`
+	Value xvaValue `xml:"value"`
+}
+
+func (v xvaValue) str() string {
+	if v.String != nil {
+		return *v.String
+	}
+	return strings.TrimSpace(v.Text)
+}
+
+func (v xvaValue) member(name string) xvaValue {
+	for _, m := range v.Struct {
+		if m.Name == name {
+			return m.Value
+		}
+	}
+	return xvaValue{}
+}
+
+// xvaDisk is a disk of the VM of an XVA, its chunks are in the directory named after its ref
+type xvaDisk struct {
+	ref        string
+	userdevice int
+	size       int64
+}
+
+// parseXVADescriptor returns the disk at diskIndex of the VM of an XVA descriptor, in the order of the devices of the VM
+func parseXVADescriptor(descriptor io.Reader, diskIndex int) (xvaDisk, error) {
+	root := xvaValue{}
+	if err := xml.NewDecoder(descriptor).Decode(&root); err != nil {
+		return xvaDisk{}, errors.Wrap(err, "unable to parse XVA descriptor")
+	}
+	objects := map[string][]xvaValue{}
+	for _, object := range root.member("objects").Array {
+		class := object.member("class").str()
+		objects[class] = append(objects[class], object)
+	}
+	vmRef := ""
+	for _, vm := range objects["VM"] {
+		// Exports with snapshots also hold the snapshots of the VM
+		if vm.member("snapshot").member("is_a_snapshot").str() != "true" {
+			vmRef = vm.member("id").str()
+			break
+		}
+	}
+	if vmRef == "" {
+		return xvaDisk{}, errors.New("XVA has no VM")
+	}
+	sizes := map[string]int64{}
+	for _, vdi := range objects["VDI"] {
+		size, err := strconv.ParseInt(vdi.member("snapshot").member("virtual_size").str(), 10, 64)
+		if err != nil {
+			return xvaDisk{}, errors.Wrapf(err, "VDI %s of the XVA has no valid virtual size", vdi.member("id").str())
+		}
+		sizes[vdi.member("id").str()] = size
+	}
+	disks := []xvaDisk{}
+	for _, vbd := range objects["VBD"] {
+		snapshot := vbd.member("snapshot")
+		// cdrom drives have type CD, and empty drives no VDI
+		if snapshot.member("VM").str() != vmRef || !strings.EqualFold(snapshot.member("type").str(), "Disk") {
+			continue
+		}
+		size, ok := sizes[snapshot.member("VDI").str()]
+		if !ok {
+			continue
+		}
+		userdevice, _ := strconv.Atoi(snapshot.member("userdevice").str())
+		disks = append(disks, xvaDisk{ref: snapshot.member("VDI").str(), userdevice: userdevice, size: size})
+	}
+	sort.Slice(disks, func(i, j int) bool { return disks[i].userdevice < disks[j].userdevice })
+	if diskIndex < 0 || diskIndex >= len(disks) {
+		return xvaDisk{}, errors.Errorf("XVA has %d disks, there is no disk at index %d", len(disks), diskIndex)
+	}
+	return disks[diskIndex], nil
+}
+
+// xvaDiskReader reads the raw data of a disk of an XVA from its tar stream, filling the chunks of zeros
+// left out of the XVA, and checks the chunks against their digests.
+type xvaDiskReader struct {
+	tr   *tar.Reader
+	disk xvaDisk
+	// bytes of the disk already read
+	offset int64
+	// data of the current chunk
+	chunk io.Reader
+	// offset of the next chunk, set once its header is read
+	next int64
+	// name and digest of the last chunk read, checked against the checksum file following it
+	last     string
+	lastHash hash.Hash
+	hash     hash.Hash
+	// set once all the chunks of the disk are read
+	done bool
+}
+
+// newXVADiskReader reads the XVA tar stream r, which starts with its descriptor, and returns the reader of
+// the disk at diskIndex and its size. The chunks of the disk are read while downloading the XVA.
+func newXVADiskReader(r io.Reader, diskIndex int) (io.Reader, int64, error) {
+	tr := tar.NewReader(r)
+	hdr, err := tr.Next()
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "unable to read XVA")
+	}
+	if path.Clean(hdr.Name) != xvaDescriptor {
+		return nil, 0, errors.Errorf("XVA does not start with %s but with %s", xvaDescriptor, hdr.Name)
+	}
+	disk, err := parseXVADescriptor(tr, diskIndex)
+	if err != nil {
+		return nil, 0, err
+	}
+	klog.Infof("Reading disk %s of the XVA, %d bytes", disk.ref, disk.size)
+	return &xvaDiskReader{tr: tr, disk: disk, next: -1}, disk.size, nil
+}
+
+func (xr *xvaDiskReader) Read(p []byte) (int, error) {
+	for {
+		if xr.chunk != nil {
+			n, err := xr.chunk.Read(p)
+			xr.offset += int64(n)
+			if err == io.EOF {
+				xr.chunk = nil
+				xr.lastHash = xr.hash
+				if n == 0 {
+					continue
+				}
+				err = nil
+			}
+			return n, err
+		}
+		if xr.next >= 0 && xr.offset < xr.next {
+			return xr.zeros(p, xr.next), nil
+		}
+		if xr.next >= 0 {
+			xr.hash = sha1.New() //nolint:gosec
+			xr.chunk = io.TeeReader(xr.tr, xr.hash)
+			xr.next = -1
+			continue
+		}
+		if xr.done {
+			if xr.offset < xr.disk.size {
+				return xr.zeros(p, xr.disk.size), nil
+			}
+			return 0, io.EOF
+		}
+		if err := xr.nextChunk(); err != nil {
+			return 0, err
+		}
+	}
+}
+
+// zeros fills p with the zeros of the chunks left out of the XVA, up to end
+func (xr *xvaDiskReader) zeros(p []byte, end int64) int {
+	n := len(p)
+	if int64(n) > end-xr.offset {
+		n = int(end - xr.offset)
+	}
+	clear(p[:n])
+	xr.offset += int64(n)
+	return n
+}
+
+// nextChunk reads the tar stream up to the next chunk of the disk, checking the chunks read against their
+// checksum files, and sets done at the end of the disk
+func (xr *xvaDiskReader) nextChunk() error {
+	for {
+		hdr, err := xr.tr.Next()
+		if err == io.EOF {
+			xr.done = true
+			return nil
+		}
+		if err != nil {
+			return errors.Wrap(err, "unable to read XVA")
+		}
+		dir, name := path.Split(path.Clean(hdr.Name))
+		if path.Clean(dir) != xr.disk.ref {
+			if xr.last != "" {
+				// The chunks of a disk are stored together, the rest of the XVA holds other disks
+				xr.done = true
+				return nil
+			}
+			continue
+		}
+		if chunk, found := strings.CutSuffix(name, xvaChecksum); found {
+			if err := xr.checkChunk(chunk); err != nil {
+				return err
+			}
+			continue
+		}
+		index, err := strconv.ParseInt(name, 10, 64)
+		if err != nil {
+			// Newer XAPI versions write xxhash digests, which are not checked
+			continue
+		}
+		if index*xvaChunkSize < xr.offset {
+			return errors.Errorf("chunk %s of disk %s of the XVA is out of order", name, xr.disk.ref)
+		}
+		xr.next = index * xvaChunkSize
+		xr.last = name
+		xr.lastHash = nil
+		return nil
+	}
+}
+
+func (xr *xvaDiskReader) checkChunk(chunk string) error {
+	if chunk != xr.last || xr.lastHash == nil {
+		return errors.Errorf("XVA has a checksum of chunk %s of disk %s without the chunk", chunk, xr.disk.ref)
+	}
+	content, err := io.ReadAll(xr.tr)
+	if err != nil {
+		return errors.Wrap(err, "unable to read XVA")
+	}
+	if actual := hex.EncodeToString(xr.lastHash.Sum(nil)); !strings.EqualFold(actual, string(bytes.TrimSpace(content))) {
+		return errors.Errorf("chunk %s of disk %s of the XVA does not match its checksum", chunk, xr.disk.ref)
+	}
+	return nil
+}
@@ -0,0 +1,197 @@
+package importer
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha1" //nolint:gosec
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"kubevirt.io/containerized-data-importer/pkg/common"
+)
+
+// testXVADescriptor is the descriptor of a VM with a cdrom drive at device 3 and two disks, the chunks of device 0
+// are in Ref:9 and of device 1 in Ref:5
+const testXVADescriptor = `<value><struct>
+<member><name>version</name><value><struct><member><name>hostname</name><value>xcp-ng-1</value></member></struct></value></member>
+<member><name>objects</name><value><array><data>
+<value><struct>
+  <member><name>class</name><value>VM</value></member>
+  <member><name>id</name><value>Ref:0</value></member>
+  <member><name>snapshot</name><value><struct>
+    <member><name>name_label</name><value>debian</value></member>
+    <member><name>is_a_snapshot</name><value><boolean>0</boolean></value></member>
+  </struct></value></member>
+</struct></value>
+<value><struct>
+  <member><name>class</name><value>VBD</value></member>
+  <member><name>id</name><value>Ref:3</value></member>
+  <member><name>snapshot</name><value><struct>
+    <member><name>VM</name><value>Ref:0</value></member>
+    <member><name>VDI</name><value>Ref:5</value></member>
+    <member><name>userdevice</name><value>1</value></member>
+    <member><name>type</name><value>Disk</value></member>
+  </struct></value></member>
+</struct></value>
+<value><struct>
+  <member><name>class</name><value>VBD</value></member>
+  <member><name>id</name><value>Ref:4</value></member>
+  <member><name>snapshot</name><value><struct>
+    <member><name>VM</name><value>Ref:0</value></member>
+    <member><name>VDI</name><value>OpaqueRef:NULL</value></member>
+    <member><name>userdevice</name><value>3</value></member>
+    <member><name>type</name><value>CD</value></member>
+  </struct></value></member>
+</struct></value>
+<value><struct>
+  <member><name>class</name><value>VBD</value></member>
+  <member><name>id</name><value>Ref:8</value></member>
+  <member><name>snapshot</name><value><struct>
+    <member><name>VM</name><value>Ref:0</value></member>
+    <member><name>VDI</name><value>Ref:9</value></member>
+    <member><name>userdevice</name><value>0</value></member>
+    <member><name>type</name><value>Disk</value></member>
+  </struct></value></member>
+</struct></value>
+<value><struct>
+  <member><name>class</name><value>VDI</value></member>
+  <member><name>id</name><value>Ref:5</value></member>
+  <member><name>snapshot</name><value><struct>
+    <member><name>name_label</name><value>data</value></member>
+    <member><name>virtual_size</name><value><string>2097152</string></value></member>
+  </struct></value></member>
+</struct></value>
+<value><struct>
+  <member><name>class</name><value>VDI</value></member>
+  <member><name>id</name><value>Ref:9</value></member>
+  <member><name>snapshot</name><value><struct>
+    <member><name>name_label</name><value>root</value></member>
+    <member><name>virtual_size</name><value>4194304</value></member>
+  </struct></value></member>
+</struct></value>
+</data></array></value></member>
+</struct></value>
+`
+
+var _ = Describe("XVA", func() {
+	var (
+		tmpDir   string
+		fileName string
+	)
+
+	chunk0 := bytes.Repeat([]byte{9}, xvaChunkSize)
+	chunk2 := bytes.Repeat([]byte{7}, 1024)
+	data := bytes.Repeat([]byte{5}, xvaChunkSize)
+	checksum := func(content []byte) []byte {
+		return []byte(fmt.Sprintf("%x", sha1.Sum(content))) //nolint:gosec
+	}
+	// The root disk has a chunk of zeros left out, a partial chunk and a last chunk of zeros left out
+	rootDisk := append(append(append(bytes.Clone(chunk0), make([]byte, xvaChunkSize)...), chunk2...), make([]byte, 2*xvaChunkSize-len(chunk2))...)
+	testXVA := func() []ovaFile {
+		return []ovaFile{
+			{"ova.xml", []byte(testXVADescriptor)},
+			{"Ref:5/00000000", data},
+			{"Ref:5/00000000.checksum", checksum(data)},
+			{"Ref:9/00000000", chunk0},
+			{"Ref:9/00000000.checksum", checksum(chunk0)},
+			{"Ref:9/00000002", chunk2},
+			{"Ref:9/00000002.checksum", checksum(chunk2)},
+		}
+	}
+
+	readDisk := func(xva []byte, diskIndex int) ([]byte, error) {
+		reader, _, err := newXVADiskReader(bytes.NewReader(xva), diskIndex)
+		if err != nil {
+			return nil, err
+		}
+		return io.ReadAll(reader)
+	}
+
+	BeforeEach(func() {
+		var err error
+		tmpDir, err = os.MkdirTemp("", "xva")
+		Expect(err).NotTo(HaveOccurred())
+		fileName = filepath.Join(tmpDir, "disk.img")
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(tmpDir)
+	})
+
+	It("Should read the first disk, filling the chunks of zeros", func() {
+		reader, size, err := newXVADiskReader(bytes.NewReader(writeOVA(testXVA()...)), 0)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(size).To(Equal(int64(4 * xvaChunkSize)))
+		_, _, err = StreamDataToFile(reader, fileName, false)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(os.ReadFile(fileName)).To(Equal(rootDisk))
+	})
+
+	It("Should read another disk in the order of the devices", func() {
+		disk, err := readDisk(writeOVA(testXVA()...), 1)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(disk).To(Equal(append(bytes.Clone(data), make([]byte, xvaChunkSize)...)))
+	})
+
+	It("Should read a compressed XVA", func() {
+		var compressed bytes.Buffer
+		gz := gzip.NewWriter(&compressed)
+		_, err := gz.Write(writeOVA(testXVA()...))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(gz.Close()).To(Succeed())
+		fr, err := NewFormatReaders(io.NopCloser(&compressed), 0)
+		Expect(err).NotTo(HaveOccurred())
+		defer fr.Close()
+		reader, _, err := newXVADiskReader(fr.TopReader(), 0)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(io.ReadAll(reader)).To(Equal(rootDisk))
+	})
+
+	It("Should not check xxhash digests", func() {
+		files := testXVA()
+		files[4] = ovaFile{"Ref:9/00000000.xxhash", []byte("0123456789abcdef")}
+		disk, err := readDisk(writeOVA(files...), 0)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(disk).To(Equal(rootDisk))
+	})
+
+	DescribeTable("Should fail with", func(diskIndex int, update func([]ovaFile) []ovaFile, errSubstring string) {
+		_, err := readDisk(writeOVA(update(testXVA())...), diskIndex)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring(errSubstring))
+	},
+		Entry("a disk index out of range", 2, func(files []ovaFile) []ovaFile { return files }, "XVA has 2 disks, there is no disk at index 2"),
+		Entry("an XVA not starting with its descriptor", 0, func(files []ovaFile) []ovaFile { return files[1:] }, "does not start with ova.xml"),
+		Entry("a chunk not matching its checksum", 0, func(files []ovaFile) []ovaFile {
+			files[6].content = checksum(chunk0)
+			return files
+		}, "chunk 00000002 of disk Ref:9 of the XVA does not match its checksum"),
+		Entry("chunks out of order", 0, func(files []ovaFile) []ovaFile {
+			return append(files[:3], files[5], files[6], files[3], files[4])
+		}, "is out of order"),
+	)
+
+	It("Should fail with a truncated XVA", func() {
+		xva := writeOVA(testXVA()...)
+		_, err := readDisk(xva[:len(xva)-2*xvaChunkSize], 0)
+		Expect(err).To(MatchError(ContainSubstring("unexpected EOF")))
+	})
+
+	It("Should read the disk index from the environment", func() {
+		disk, err := getXVADisk()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(disk).To(BeNil())
+		GinkgoT().Setenv(common.ImporterXVADisk, "1")
+		disk, err = getXVADisk()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(*disk).To(Equal(1))
+		GinkgoT().Setenv(common.ImporterXVADisk, "-1")
+		_, err = getXVADisk()
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,360 @@
+/*
+Copyright 2024 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package importer
+
+import (
+	"bufio"
+	"compress/flate"
+	"encoding/binary"
+	"hash"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"k8s.io/klog/v2"
+)
+
+const (
+	zipLocalFileSignature        = 0x04034b50
+	zipCentralDirectorySignature = 0x02014b50
+	zipEndSignature              = 0x06054b50
+	zip64EndSignature            = 0x06064b50
+	zipDataDescriptorSignature   = 0x08074b50
+	zip64ExtraID                 = 0x0001
+	zipFlagEncrypted             = 0x1
+	zipFlagDataDescriptor        = 0x8
+	zipMethodStore               = 0
+	zipMethodDeflate             = 8
+	zipUint32Max                 = 0xffffffff
+	// zipMacOSMetadata is the directory macOS adds to the archives it makes, holding the metadata of the files
+	zipMacOSMetadata = "__MACOSX/"
+)
+
+// zipReader reads the entries of a zip archive from its local file headers, without the central directory at
+// the end of the archive, so the archive is extracted while it is downloaded.
+type zipReader struct {
+	r *zipCountingReader
+	// entry being read
+	entry *zipEntry
+	done  bool
+}
+
+// zipCountingReader counts the bytes of the archive read, flate reads it byte by byte without reading ahead
+type zipCountingReader struct {
+	*bufio.Reader
+	count uint64
+}
+
+// zipEntry is a file or a directory of a zip archive, its data is checked against its crc once read
+type zipEntry struct {
+	zr    *zipReader
+	name  string
+	flags uint16
+	crc   uint32
+	zip64 bool
+	// compressedSize and size are 0 in the header of entries followed by a data descriptor
+	compressedSize uint64
+	size           uint64
+	// offset of the data of the entry in the archive
+	start uint64
+	data  io.Reader
+	hash  hash.Hash32
+	read  uint64
+	eof   bool
+}
+
+func newZipReader(r io.Reader) *zipReader {
+	return &zipReader{r: &zipCountingReader{Reader: bufio.NewReader(r)}}
+}
+
+func (r *zipCountingReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	r.count += uint64(n)
+	return n, err
+}
+
+func (r *zipCountingReader) ReadByte() (byte, error) {
+	b, err := r.Reader.ReadByte()
+	if err == nil {
+		r.count++
+	}
+	return b, err
+}
+
+// next returns the next entry of the archive, and io.EOF once all the entries are read
+func (zr *zipReader) next() (*zipEntry, error) {
+	if zr.entry != nil && !zr.entry.eof {
+		if _, err := io.Copy(io.Discard, zr.entry); err != nil {
+			return nil, err
+		}
+	}
+	zr.entry = nil
+	if zr.done {
+		return nil, io.EOF
+	}
+	var signature uint32
+	if err := binary.Read(zr.r, binary.LittleEndian, &signature); err != nil {
+		return nil, errors.Wrap(err, "unable to read zip archive")
+	}
+	switch signature {
+	case zipLocalFileSignature:
+	case zipCentralDirectorySignature, zipEndSignature, zip64EndSignature:
+		// The central directory follows the entries
+		zr.done = true
+		return nil, io.EOF
+	default:
+		return nil, errors.Errorf("zip archive has an invalid header signature %#x", signature)
+	}
+	header := struct {
+		Version          uint16
+		Flags            uint16
+		Method           uint16
+		ModifiedTime     uint16
+		ModifiedDate     uint16
+		CRC32            uint32
+		CompressedSize   uint32
+		UncompressedSize uint32
+		NameLength       uint16
+		ExtraLength      uint16
+	}{}
+	if err := binary.Read(zr.r, binary.LittleEndian, &header); err != nil {
+		return nil, errors.Wrap(err, "unable to read zip archive")
+	}
+	name := make([]byte, header.NameLength)
+	extra := make([]byte, header.ExtraLength)
+	if _, err := io.ReadFull(zr.r, name); err != nil {
+		return nil, errors.Wrap(err, "unable to read zip archive")
+	}
+	if _, err := io.ReadFull(zr.r, extra); err != nil {
+		return nil, errors.Wrap(err, "unable to read zip archive")
+	}
+	entry := &zipEntry{
+		zr:             zr,
+		name:           string(name),
+		flags:          header.Flags,
+		crc:            header.CRC32,
+		compressedSize: uint64(header.CompressedSize),
+		size:           uint64(header.UncompressedSize),
+		hash:           crc32.NewIEEE(),
+	}
+	entry.readZip64Extra(extra)
+	if entry.flags&zipFlagEncrypted != 0 {
+		return nil, errors.Errorf("zip archive entry %s is encrypted", entry.name)
+	}
+	entry.start = zr.r.count
+	switch header.Method {
+	case zipMethodStore:
+		if entry.flags&zipFlagDataDescriptor != 0 && entry.compressedSize == 0 && !entry.isDir() {
+			// The size of the data is only written after it
+			return nil, errors.Errorf("zip archive entry %s is stored uncompressed without its size, it can not be streamed", entry.name)
+		}
+		entry.data = io.LimitReader(zr.r, int64(entry.compressedSize))
+	case zipMethodDeflate:
+		entry.data = flate.NewReader(zr.r)
+	default:
+		return nil, errors.Errorf("zip archive entry %s uses compression method %d, only deflate is supported", entry.name, header.Method)
+	}
+	zr.entry = entry
+	return entry, nil
+}
+
+// nextFile returns the next file of the archive, skipping directories and macOS metadata
+func (zr *zipReader) nextFile() (*zipEntry, error) {
+	for {
+		entry, err := zr.next()
+		if err != nil {
+			return nil, err
+		}
+		if !entry.isDir() && !strings.HasPrefix(entry.name, zipMacOSMetadata) {
+			return entry, nil
+		}
+	}
+}
+
+// extract writes the files of the archive to dir
+func (zr *zipReader) extract(dir string) error {
+	klog.V(1).Infof("begin unzip to %s...\n", dir)
+	for {
+		entry, err := zr.next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		name := filepath.FromSlash(strings.TrimSuffix(entry.name, "/"))
+		if !filepath.IsLocal(name) {
+			return errors.Errorf("zip archive entry %s is outside of the target directory", entry.name)
+		}
+		target := filepath.Join(dir, name)
+		klog.V(3).Infof("unzip %s", target)
+		if entry.isDir() {
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return err
+		}
+		if err := writeZipEntry(entry, target); err != nil {
+			return err
+		}
+	}
+}
+
+func writeZipEntry(entry *zipEntry, target string) error {
+	file, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	if _, err := io.Copy(file, entry); err != nil {
+		return err
+	}
+	return file.Close()
+}
+
+// readZip64Extra reads the sizes of the zip64 extra field, set for entries larger than 4GiB
+func (e *zipEntry) readZip64Extra(extra []byte) {
+	for len(extra) >= 4 {
+		id := binary.LittleEndian.Uint16(extra)
+		size := int(binary.LittleEndian.Uint16(extra[2:]))
+		extra = extra[4:]
+		if size > len(extra) {
+			return
+		}
+		if id == zip64ExtraID {
+			e.zip64 = true
+			field := extra[:size]
+			// Only the sizes that do not fit in the header are in the field, in this order
+			if e.size == zipUint32Max && len(field) >= 8 {
+				e.size = binary.LittleEndian.Uint64(field)
+				field = field[8:]
+			}
+			if e.compressedSize == zipUint32Max && len(field) >= 8 {
+				e.compressedSize = binary.LittleEndian.Uint64(field)
+			}
+		}
+		extra = extra[size:]
+	}
+}
+
+func (e *zipEntry) isDir() bool {
+	return strings.HasSuffix(e.name, "/")
+}
+
+// Read reads the data of the entry, and checks it once read
+func (e *zipEntry) Read(p []byte) (int, error) {
+	if e.eof {
+		return 0, io.EOF
+	}
+	n, err := e.data.Read(p)
+	e.hash.Write(p[:n])
+	e.read += uint64(n)
+	if err == io.EOF {
+		e.eof = true
+		if err := e.check(); err != nil {
+			return n, err
+		}
+	} else if err != nil {
+		err = errors.Wrapf(err, "unable to read zip archive entry %s", e.name)
+	}
+	return n, err
+}
+
+// check reads the data descriptor following the data of the entry, and checks the data against its crc and size
+func (e *zipEntry) check() error {
+	compressedSize := e.zr.r.count - e.start
+	if e.flags&zipFlagDataDescriptor != 0 {
+		descriptor := make([]byte, 4)
+		if _, err := io.ReadFull(e.zr.r, descriptor); err != nil {
+			return errors.Wrap(err, "unable to read zip archive")
+		}
+		// The signature of the descriptor is optional
+		offset := 0
+		if binary.LittleEndian.Uint32(descriptor) == zipDataDescriptorSignature {
+			offset = 4
+		}
+		// Writers that do not know the size of an entry in advance write 8 byte sizes for the entries over 4GiB
+		sizeLength := 4
+		if e.zip64 || compressedSize >= zipUint32Max || e.read >= zipUint32Max {
+			sizeLength = 8
+		}
+		rest := make([]byte, offset+2*sizeLength)
+		if _, err := io.ReadFull(e.zr.r, rest); err != nil {
+			return errors.Wrap(err, "unable to read zip archive")
+		}
+		descriptor = append(descriptor, rest...)[offset:]
+		e.crc = binary.LittleEndian.Uint32(descriptor)
+		if sizeLength == 8 {
+			e.compressedSize = binary.LittleEndian.Uint64(descriptor[4:])
+			e.size = binary.LittleEndian.Uint64(descriptor[12:])
+		} else {
+			e.compressedSize = uint64(binary.LittleEndian.Uint32(descriptor[4:]))
+			e.size = uint64(binary.LittleEndian.Uint32(descriptor[8:]))
+		}
+	}
+	if e.read != e.size || compressedSize != e.compressedSize {
+		return errors.Errorf("zip archive entry %s has %d bytes, %d compressed, its header has %d, %d compressed", e.name, e.read, compressedSize, e.size, e.compressedSize)
+	}
+	if e.hash.Sum32() != e.crc {
+		return errors.Errorf("zip archive entry %s does not match its crc", e.name)
+	}
+	return nil
+}
+
+// zipFileReader reads the single file of a zip archive, a disk image or an ISO
+type zipFileReader struct {
+	zr   *zipReader
+	file *zipEntry
+}
+
+func (r *zipFileReader) Read(p []byte) (int, error) {
+	if r.file == nil {
+		file, err := r.zr.nextFile()
+		if err == io.EOF {
+			return 0, errors.New("zip archive has no file")
+		}
+		if err != nil {
+			return 0, err
+		}
+		klog.V(2).Infof("zip: extracting %q\n", file.name)
+		r.file = file
+	}
+	n, err := r.file.Read(p)
+	if err == io.EOF {
+		// A disk image is imported from a zip archive that holds it alone
+		other, nextErr := r.zr.nextFile()
+		if nextErr == nil {
+			return n, errors.Errorf("zip archive holds more than one file, %s and %s, use the archive content type to extract them", r.file.name, other.name)
+		}
+		if nextErr != io.EOF {
+			return n, nextErr
+		}
+	}
+	return n, err
+}
+
+// Close does nothing, the stream of the archive is closed with the other readers
+func (r *zipFileReader) Close() error {
+	return nil
+}
@@ -0,0 +1,194 @@
+package importer
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/rand"
+	"encoding/binary"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/ulikunitz/xz"
+)
+
+type zipFile struct {
+	name    string
+	content []byte
+	stored  bool
+}
+
+// writeZip returns a zip archive of files, the deflated files are followed by data descriptors, as written by
+// tools streaming the archive, and the stored ones have their size in their header
+func writeZip(files ...zipFile) []byte {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	// The format readers read a header of 512 bytes, the comment pads the small archives
+	Expect(zw.SetComment(strings.Repeat(" ", 512))).To(Succeed())
+	for _, file := range files {
+		if file.stored {
+			w, err := zw.CreateRaw(&zip.FileHeader{
+				Name:               file.name,
+				Method:             zip.Store,
+				CRC32:              crc32.ChecksumIEEE(file.content),
+				CompressedSize64:   uint64(len(file.content)),
+				UncompressedSize64: uint64(len(file.content)),
+			})
+			Expect(err).NotTo(HaveOccurred())
+			_, err = w.Write(file.content)
+			Expect(err).NotTo(HaveOccurred())
+			continue
+		}
+		w, err := zw.Create(file.name)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = w.Write(file.content)
+		Expect(err).NotTo(HaveOccurred())
+	}
+	Expect(zw.Close()).To(Succeed())
+	return buf.Bytes()
+}
+
+var _ = Describe("Zip archives", func() {
+	var (
+		tmpDir string
+		fr     *FormatReaders
+	)
+
+	disk := bytes.Repeat([]byte("disk image "), 100000)
+
+	BeforeEach(func() {
+		var err error
+		tmpDir, err = os.MkdirTemp("", "zip")
+		Expect(err).NotTo(HaveOccurred())
+		fr = nil
+	})
+
+	AfterEach(func() {
+		if fr != nil {
+			fr.Close()
+		}
+		os.RemoveAll(tmpDir)
+	})
+
+	readFile := func(archive []byte) ([]byte, error) {
+		var err error
+		fr, err = NewFormatReaders(io.NopCloser(bytes.NewReader(archive)), 0)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fr.ArchiveZip).To(BeTrue())
+		Expect(fr.Archived).To(BeTrue())
+		Expect(fr.Convert).To(BeTrue())
+		return io.ReadAll(fr.TopReader())
+	}
+
+	DescribeTable("Should read the single file of an archive", func(files ...zipFile) {
+		Expect(readFile(writeZip(files...))).To(Equal(disk))
+	},
+		Entry("deflated", zipFile{name: "disk.img", content: disk}),
+		Entry("stored", zipFile{name: "disk.img", content: disk, stored: true}),
+		Entry("in a directory", zipFile{name: "images/", stored: true}, zipFile{name: "images/disk.iso", content: disk}),
+		Entry("with macOS metadata", zipFile{name: "disk.img", content: disk}, zipFile{name: "__MACOSX/._disk.img", content: []byte("metadata")}),
+	)
+
+	It("Should read a compressed archive", func() {
+		iso := make([]byte, 64*1024)
+		_, err := rand.Read(iso)
+		Expect(err).NotTo(HaveOccurred())
+		var compressed bytes.Buffer
+		writer, err := xz.NewWriter(&compressed)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = writer.Write(writeZip(zipFile{name: "disk.iso", content: iso}))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(writer.Close()).To(Succeed())
+		Expect(readFile(compressed.Bytes())).To(Equal(iso))
+		Expect(fr.ArchiveXz).To(BeTrue())
+	})
+
+	It("Should read the sizes of zip64 entries", func() {
+		var archive bytes.Buffer
+		content := []byte("hello")
+		extra := binary.LittleEndian.AppendUint16(nil, zip64ExtraID)
+		extra = binary.LittleEndian.AppendUint16(extra, 16)
+		extra = binary.LittleEndian.AppendUint64(extra, uint64(len(content)))
+		extra = binary.LittleEndian.AppendUint64(extra, uint64(len(content)))
+		header := struct {
+			Signature                          uint32
+			Version, Flags, Method, Time, Date uint16
+			CRC32, CompressedSize, Size        uint32
+			NameLength, ExtraLength            uint16
+		}{zipLocalFileSignature, 45, 0, zipMethodStore, 0, 0, crc32.ChecksumIEEE(content), zipUint32Max, zipUint32Max, uint16(len("disk.img")), uint16(len(extra))}
+		Expect(binary.Write(&archive, binary.LittleEndian, header)).To(Succeed())
+		archive.WriteString("disk.img")
+		archive.Write(extra)
+		archive.Write(content)
+		Expect(binary.Write(&archive, binary.LittleEndian, uint32(zipCentralDirectorySignature))).To(Succeed())
+		// The header of the format readers is a block of 512 bytes
+		archive.Write(make([]byte, 512))
+		Expect(readFile(archive.Bytes())).To(Equal(content))
+	})
+
+	DescribeTable("Should fail to read an archive with", func(errSubstring string, files ...zipFile) {
+		_, err := readFile(writeZip(files...))
+		Expect(err).To(MatchError(ContainSubstring(errSubstring)))
+	},
+		Entry("several files", "zip archive holds more than one file, disk.img and README",
+			zipFile{name: "disk.img", content: disk}, zipFile{name: "README", content: []byte("readme")}),
+		Entry("no file", "zip archive has no file", zipFile{name: "images/", stored: true}, zipFile{name: "other/", stored: true}),
+	)
+
+	It("Should fail to read an archive with another compression method", func() {
+		var buf bytes.Buffer
+		zw := zip.NewWriter(&buf)
+		w, err := zw.CreateRaw(&zip.FileHeader{Name: "disk.img", Method: 12, CompressedSize64: 4, UncompressedSize64: 4})
+		Expect(err).NotTo(HaveOccurred())
+		_, err = w.Write([]byte("BZh9"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(zw.SetComment(strings.Repeat(" ", 512))).To(Succeed())
+		Expect(zw.Close()).To(Succeed())
+		_, err = readFile(buf.Bytes())
+		Expect(err).To(MatchError("zip archive entry disk.img uses compression method 12, only deflate is supported"))
+	})
+
+	It("Should fail if a file does not match its crc", func() {
+		archive := writeZip(zipFile{name: "disk.img", content: disk, stored: true})
+		archive[100] ^= 0xff
+		_, err := readFile(archive)
+		Expect(err).To(MatchError("zip archive entry disk.img does not match its crc"))
+	})
+
+	It("Should fail with a truncated archive", func() {
+		archive := writeZip(zipFile{name: "disk.img", content: disk})
+		_, err := readFile(archive[:len(archive)/2])
+		Expect(err).To(MatchError(ContainSubstring("unable to read zip archive entry disk.img")))
+	})
+
+	It("Should extract the files of an archive", func() {
+		archive := writeZip(
+			zipFile{name: "README", content: []byte("readme")},
+			zipFile{name: "images/", stored: true},
+			zipFile{name: "images/disk.img", content: disk},
+			zipFile{name: "empty/", stored: true},
+			zipFile{name: "config/settings.json", content: []byte("{}"), stored: true},
+		)
+		var err error
+		fr, err = NewFormatReaders(io.NopCloser(bytes.NewReader(archive)), 0)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fr.UnArchive(tmpDir)).To(Succeed())
+		Expect(os.ReadFile(filepath.Join(tmpDir, "README"))).To(Equal([]byte("readme")))
+		Expect(os.ReadFile(filepath.Join(tmpDir, "images", "disk.img"))).To(Equal(disk))
+		Expect(os.ReadFile(filepath.Join(tmpDir, "config", "settings.json"))).To(Equal([]byte("{}")))
+		Expect(filepath.Join(tmpDir, "empty")).To(BeADirectory())
+	})
+
+	It("Should not extract files outside of the target directory", func() {
+		archive := writeZip(zipFile{name: "../evil", content: []byte("evil")})
+		var err error
+		fr, err = NewFormatReaders(io.NopCloser(bytes.NewReader(archive)), 0)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fr.UnArchive(tmpDir)).To(MatchError("zip archive entry ../evil is outside of the target directory"))
+		Expect(filepath.Join(filepath.Dir(tmpDir), "evil")).ToNot(BeAnExistingFile())
+	})
+})
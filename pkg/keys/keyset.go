@@ -0,0 +1,310 @@
+/*
+Copyright 2026 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keys
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+
+	"kubevirt.io/containerized-data-importer/pkg/util/cert"
+)
+
+const (
+	// KeyStorePreviousPrivateKeyFile is the key in a secret containing the RSA private key replaced by the last rotation
+	KeyStorePreviousPrivateKeyFile = "id_rsa.previous"
+
+	// KeyStorePreviousPublicKeyFile is the key in a secret containing the RSA public key replaced by the last rotation
+	KeyStorePreviousPublicKeyFile = "id_rsa.pub.previous"
+
+	// AnnKeyRotationTime is the time the key of a private key secret was last rotated
+	AnnKeyRotationTime = "cdi.kubevirt.io/keyRotationTime"
+
+	// keyPropagationDelay is how long the previous key keeps signing after a rotation, so the new public key reaches
+	// the secret volumes of the validating components before tokens are signed with the new key
+	keyPropagationDelay = 2 * time.Minute
+)
+
+// SecretKeySet is the token.KeySet of a private key secret, holding the keys of the secret it was last synced with
+type SecretKeySet struct {
+	client     kubernetes.Interface
+	namespace  string
+	secretName string
+
+	mutex   sync.RWMutex
+	signing *rsa.PrivateKey
+	public  []*rsa.PublicKey
+}
+
+// NewSecretKeySet returns the KeySet of a private key secret, creating the secret if missing
+func NewSecretKeySet(client kubernetes.Interface, namespace, secretName string, installerLabels map[string]string) (*SecretKeySet, error) {
+	secret, err := getOrCreatePrivateKeySecret(client, namespace, secretName, installerLabels)
+	if err != nil {
+		return nil, err
+	}
+	ks := &SecretKeySet{client: client, namespace: namespace, secretName: secretName}
+	if err := ks.load(secret, time.Now()); err != nil {
+		return nil, err
+	}
+	return ks, nil
+}
+
+// PrivateKey returns the key signing the new tokens
+func (ks *SecretKeySet) PrivateKey() (*rsa.PrivateKey, error) {
+	ks.mutex.RLock()
+	defer ks.mutex.RUnlock()
+	return ks.signing, nil
+}
+
+// PublicKeys returns the current and previous public keys of the secret
+func (ks *SecretKeySet) PublicKeys() ([]*rsa.PublicKey, error) {
+	ks.mutex.RLock()
+	defer ks.mutex.RUnlock()
+	return ks.public, nil
+}
+
+// Sync reloads the keys of the secret, rotating its private key first if it was last rotated more than
+// rotationInterval ago. The key is never rotated on an interval if rotationInterval is 0. The key is also rotated if it
+// was last rotated before revokedBefore, the previous key being dropped rather than kept, so that none of the tokens it
+// signed, forged ones included, are accepted anymore.
+func (ks *SecretKeySet) Sync(rotationInterval time.Duration, revokedBefore time.Time) error {
+	secrets := ks.client.CoreV1().Secrets(ks.namespace)
+	secret, err := secrets.Get(context.TODO(), ks.secretName, metav1.GetOptions{})
+	if err != nil {
+		return errors.Wrap(err, "Error getting secret")
+	}
+
+	now := time.Now()
+	revoked := !revokedBefore.IsZero() && !revokedBefore.After(now) && lastRotation(secret).Before(revokedBefore)
+	if revoked || (rotationInterval > 0 && now.Sub(lastRotation(secret)) >= rotationInterval) {
+		rotated, err := rotatePrivateKey(secret, now, !revoked)
+		if err != nil {
+			return err
+		}
+		updated, err := secrets.Update(context.TODO(), rotated, metav1.UpdateOptions{})
+		switch {
+		case err == nil:
+			klog.Infof("Rotated the private key of secret %s/%s", ks.namespace, ks.secretName)
+			secret = updated
+		case k8serrors.IsConflict(err):
+			// another replica rotated the key first, its keys are loaded on the next sync
+		default:
+			return errors.Wrap(err, "Error rotating private key")
+		}
+	}
+
+	return ks.load(secret, now)
+}
+
+// load holds the keys of secret. The previous private key signs while the new public key propagates.
+func (ks *SecretKeySet) load(secret *v1.Secret, now time.Time) error {
+	signing, public, err := parseKeySecret(secret.Data, now.Sub(lastRotation(secret)) < keyPropagationDelay)
+	if err != nil {
+		return err
+	}
+
+	ks.mutex.Lock()
+	defer ks.mutex.Unlock()
+	ks.signing, ks.public = signing, public
+	return nil
+}
+
+// lastRotation returns the time the key of secret was last rotated, its creation if it never was
+func lastRotation(secret *v1.Secret) time.Time {
+	if t, err := time.Parse(time.RFC3339, secret.Annotations[AnnKeyRotationTime]); err == nil {
+		return t
+	}
+	return secret.CreationTimestamp.Time
+}
+
+// rotatePrivateKey returns a copy of secret with a new private key, keeping the replaced one as the previous key if
+// keepPrevious
+func rotatePrivateKey(secret *v1.Secret, now time.Time, keepPrevious bool) (*v1.Secret, error) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error generating key")
+	}
+	publicKeyBytes, err := cert.EncodePublicKeyPEM(&privateKey.PublicKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error encoding public key")
+	}
+
+	rotated := secret.DeepCopy()
+	if rotated.Annotations == nil {
+		rotated.Annotations = map[string]string{}
+	}
+	rotated.Annotations[AnnKeyRotationTime] = now.UTC().Format(time.RFC3339)
+	rotated.Data = map[string][]byte{
+		KeyStorePrivateKeyFile: cert.EncodePrivateKeyPEM(privateKey),
+		KeyStorePublicKeyFile:  publicKeyBytes,
+	}
+	if keepPrevious {
+		rotated.Data[KeyStorePreviousPrivateKeyFile] = secret.Data[KeyStorePrivateKeyFile]
+		rotated.Data[KeyStorePreviousPublicKeyFile] = secret.Data[KeyStorePublicKeyFile]
+	}
+	return rotated, nil
+}
+
+// parseKeySecret returns the key signing tokens and the public keys validating them from the data of a private key
+// secret, the previous private key signing while the new public key is propagating
+func parseKeySecret(data map[string][]byte, propagating bool) (*rsa.PrivateKey, []*rsa.PublicKey, error) {
+	signing, err := parsePrivateKey(data[KeyStorePrivateKeyFile])
+	if err != nil {
+		return nil, nil, err
+	}
+	public, err := parsePublicKeys(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	if previous, ok := data[KeyStorePreviousPrivateKeyFile]; ok && propagating {
+		if signing, err = parsePrivateKey(previous); err != nil {
+			return nil, nil, err
+		}
+	}
+	return signing, public, nil
+}
+
+// parsePublicKeys returns the current public key of the data of a private key secret, followed by the previous one if
+// the key was rotated
+func parsePublicKeys(data map[string][]byte) ([]*rsa.PublicKey, error) {
+	var public []*rsa.PublicKey
+	for _, name := range []string{KeyStorePublicKeyFile, KeyStorePreviousPublicKeyFile} {
+		bytes, ok := data[name]
+		if !ok {
+			continue
+		}
+		key, err := parsePublicKey(bytes)
+		if err != nil {
+			return nil, err
+		}
+		public = append(public, key)
+	}
+	if len(public) == 0 {
+		return nil, errors.New("Secret missing public key")
+	}
+	return public, nil
+}
+
+func parsePublicKey(bytes []byte) (*rsa.PublicKey, error) {
+	keys, err := cert.ParsePublicKeysPEM(bytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error parsing public key")
+	}
+	if len(keys) != 1 {
+		return nil, errors.New("unexpected number of public keys")
+	}
+	key, ok := keys[0].(*rsa.PublicKey)
+	if !ok {
+		return nil, errors.New("PEM does not contain RSA key")
+	}
+	return key, nil
+}
+
+// FileKeySet is the token.KeySet of a private key secret mounted in a directory, reloaded when the kubelet updates the
+// secret volume after a rotation
+type FileKeySet struct {
+	dir string
+
+	mutex   sync.Mutex
+	modTime time.Time
+	private *rsa.PrivateKey
+	public  []*rsa.PublicKey
+}
+
+// NewFileKeySet returns the KeySet of the private key secret mounted in dir. The private key is optional for
+// components only validating tokens.
+func NewFileKeySet(dir string) (*FileKeySet, error) {
+	ks := &FileKeySet{dir: dir}
+	if err := ks.reload(); err != nil {
+		return nil, err
+	}
+	return ks, nil
+}
+
+// PrivateKey returns the private key of the secret
+func (ks *FileKeySet) PrivateKey() (*rsa.PrivateKey, error) {
+	if err := ks.reload(); err != nil {
+		return nil, err
+	}
+	ks.mutex.Lock()
+	defer ks.mutex.Unlock()
+	if ks.private == nil {
+		return nil, errors.Errorf("%s has no private key", ks.dir)
+	}
+	return ks.private, nil
+}
+
+// PublicKeys returns the current and previous public keys of the secret
+func (ks *FileKeySet) PublicKeys() ([]*rsa.PublicKey, error) {
+	if err := ks.reload(); err != nil {
+		return nil, err
+	}
+	ks.mutex.Lock()
+	defer ks.mutex.Unlock()
+	return ks.public, nil
+}
+
+// reload reads the keys again if the public key file changed since they were last read
+func (ks *FileKeySet) reload() error {
+	ks.mutex.Lock()
+	defer ks.mutex.Unlock()
+
+	info, err := os.Stat(filepath.Join(ks.dir, KeyStorePublicKeyFile))
+	if err != nil {
+		return errors.Wrap(err, "Error reading public key")
+	}
+	if ks.public != nil && info.ModTime().Equal(ks.modTime) {
+		return nil
+	}
+
+	data := map[string][]byte{}
+	for _, name := range []string{KeyStorePrivateKeyFile, KeyStorePublicKeyFile, KeyStorePreviousPublicKeyFile} {
+		bytes, err := os.ReadFile(filepath.Join(ks.dir, name))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return errors.Wrapf(err, "Error reading %s", name)
+		}
+		data[name] = bytes
+	}
+	public, err := parsePublicKeys(data)
+	if err != nil {
+		return err
+	}
+	var private *rsa.PrivateKey
+	if bytes, ok := data[KeyStorePrivateKeyFile]; ok {
+		if private, err = parsePrivateKey(bytes); err != nil {
+			return err
+		}
+	}
+
+	ks.modTime, ks.private, ks.public = info.ModTime(), private, public
+	return nil
+}
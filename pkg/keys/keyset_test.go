@@ -0,0 +1,198 @@
+/*
+Copyright 2026 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keys
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+
+	"kubevirt.io/containerized-data-importer/pkg/util/cert"
+)
+
+var _ = Describe("Secret key set", func() {
+	const (
+		namespace  = "cdi"
+		secretName = "cdi-api-signing-key"
+	)
+
+	var client *k8sfake.Clientset
+
+	BeforeEach(func() {
+		client = k8sfake.NewSimpleClientset()
+	})
+
+	rotatedAgo := func(d time.Duration) {
+		secret, err := client.CoreV1().Secrets(namespace).Get(context.TODO(), secretName, metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		if secret.Annotations == nil {
+			secret.Annotations = map[string]string{}
+		}
+		secret.Annotations[AnnKeyRotationTime] = time.Now().Add(-d).UTC().Format(time.RFC3339)
+		_, err = client.CoreV1().Secrets(namespace).Update(context.TODO(), secret, metav1.UpdateOptions{})
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	It("should create the secret and sign with its key", func() {
+		ks, err := NewSecretKeySet(client, namespace, secretName, nil)
+		Expect(err).ToNot(HaveOccurred())
+
+		privateKey, err := GetOrCreatePrivateKey(client, namespace, secretName, nil)
+		Expect(err).ToNot(HaveOccurred())
+		signing, err := ks.PrivateKey()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(signing).To(Equal(privateKey))
+		public, err := ks.PublicKeys()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(public).To(ConsistOf(&privateKey.PublicKey))
+	})
+
+	It("should not rotate the key before the interval", func() {
+		ks, err := NewSecretKeySet(client, namespace, secretName, nil)
+		Expect(err).ToNot(HaveOccurred())
+		original, _ := ks.PrivateKey()
+
+		rotatedAgo(time.Minute)
+		Expect(ks.Sync(time.Hour, time.Time{})).To(Succeed())
+		Expect(ks.Sync(0, time.Time{})).To(Succeed())
+		signing, _ := ks.PrivateKey()
+		Expect(signing).To(Equal(original))
+	})
+
+	It("should rotate the key and keep signing with the previous one while it propagates", func() {
+		ks, err := NewSecretKeySet(client, namespace, secretName, nil)
+		Expect(err).ToNot(HaveOccurred())
+		original, _ := ks.PrivateKey()
+
+		rotatedAgo(2 * time.Hour)
+		Expect(ks.Sync(time.Hour, time.Time{})).To(Succeed())
+		signing, _ := ks.PrivateKey()
+		Expect(signing).To(Equal(original))
+		public, _ := ks.PublicKeys()
+		Expect(public).To(HaveLen(2))
+		Expect(public[1]).To(Equal(&original.PublicKey))
+
+		rotatedAgo(keyPropagationDelay)
+		Expect(ks.Sync(time.Hour, time.Time{})).To(Succeed())
+		signing, _ = ks.PrivateKey()
+		Expect(signing).ToNot(Equal(original))
+		Expect(&signing.PublicKey).To(Equal(public[0]))
+	})
+
+	It("should rotate the key and drop the previous one when the tokens are revoked", func() {
+		ks, err := NewSecretKeySet(client, namespace, secretName, nil)
+		Expect(err).ToNot(HaveOccurred())
+		original, _ := ks.PrivateKey()
+
+		rotatedAgo(time.Hour)
+		Expect(ks.Sync(0, time.Now().Add(time.Minute))).To(Succeed())
+		signing, _ := ks.PrivateKey()
+		Expect(signing).To(Equal(original))
+
+		Expect(ks.Sync(0, time.Now().Add(-time.Minute))).To(Succeed())
+		signing, _ = ks.PrivateKey()
+		Expect(signing).ToNot(Equal(original))
+		public, _ := ks.PublicKeys()
+		Expect(public).To(ConsistOf(&signing.PublicKey))
+		secret, err := client.CoreV1().Secrets(namespace).Get(context.TODO(), secretName, metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(secret.Data).ToNot(HaveKey(KeyStorePreviousPrivateKeyFile))
+
+		// the key is rotated once per revocation
+		Expect(ks.Sync(0, time.Now().Add(-time.Minute))).To(Succeed())
+		rotated, _ := ks.PrivateKey()
+		Expect(rotated).To(Equal(signing))
+	})
+})
+
+var _ = Describe("File key set", func() {
+	var dir string
+
+	writeKey := func(name string, data []byte) {
+		Expect(os.WriteFile(filepath.Join(dir, name), data, 0600)).To(Succeed())
+	}
+
+	writeKeys := func(privateKey *rsa.PrivateKey, withPrivate bool) {
+		publicKeyBytes, err := cert.EncodePublicKeyPEM(&privateKey.PublicKey)
+		Expect(err).ToNot(HaveOccurred())
+		writeKey(KeyStorePublicKeyFile, publicKeyBytes)
+		if withPrivate {
+			writeKey(KeyStorePrivateKeyFile, cert.EncodePrivateKeyPEM(privateKey))
+		}
+	}
+
+	newKey := func() *rsa.PrivateKey {
+		privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		Expect(err).ToNot(HaveOccurred())
+		return privateKey
+	}
+
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+	})
+
+	It("should fail without public key", func() {
+		_, err := NewFileKeySet(dir)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should only validate without private key", func() {
+		privateKey := newKey()
+		writeKeys(privateKey, false)
+		ks, err := NewFileKeySet(dir)
+		Expect(err).ToNot(HaveOccurred())
+		public, err := ks.PublicKeys()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(public).To(ConsistOf(&privateKey.PublicKey))
+		_, err = ks.PrivateKey()
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should reload the keys when the secret volume is updated", func() {
+		original := newKey()
+		writeKeys(original, true)
+		ks, err := NewFileKeySet(dir)
+		Expect(err).ToNot(HaveOccurred())
+		signing, err := ks.PrivateKey()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(signing).To(Equal(original))
+
+		rotated := newKey()
+		writeKeys(rotated, true)
+		previousPublicKeyBytes, err := cert.EncodePublicKeyPEM(&original.PublicKey)
+		Expect(err).ToNot(HaveOccurred())
+		writeKey(KeyStorePreviousPublicKeyFile, previousPublicKeyBytes)
+		later := time.Now().Add(time.Minute)
+		Expect(os.Chtimes(filepath.Join(dir, KeyStorePublicKeyFile), later, later)).To(Succeed())
+
+		signing, err = ks.PrivateKey()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(signing).To(Equal(rotated))
+		public, err := ks.PublicKeys()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(public).To(Equal([]*rsa.PublicKey{&rotated.PublicKey, &original.PublicKey}))
+	})
+})
@@ -44,6 +44,20 @@ const (
 
 // GetOrCreatePrivateKey gets or creates a private key secret
 func GetOrCreatePrivateKey(client kubernetes.Interface, namespace, secretName string, installerLabels map[string]string) (*rsa.PrivateKey, error) {
+	secret, err := getOrCreatePrivateKeySecret(client, namespace, secretName, installerLabels)
+	if err != nil {
+		return nil, err
+	}
+
+	bytes, ok := secret.Data[KeyStorePrivateKeyFile]
+	if !ok {
+		return nil, errors.New("Secret missing private key")
+	}
+
+	return parsePrivateKey(bytes)
+}
+
+func getOrCreatePrivateKeySecret(client kubernetes.Interface, namespace, secretName string, installerLabels map[string]string) (*v1.Secret, error) {
 	secret, err := client.CoreV1().Secrets(namespace).Get(context.TODO(), secretName, metav1.GetOptions{})
 	if err != nil {
 		if !k8serrors.IsNotFound(err) {
@@ -75,12 +89,7 @@ func GetOrCreatePrivateKey(client kubernetes.Interface, namespace, secretName st
 		}
 	}
 
-	bytes, ok := secret.Data[KeyStorePrivateKeyFile]
-	if !ok {
-		return nil, errors.Wrap(err, "Secret missing private key")
-	}
-
-	return parsePrivateKey(bytes)
+	return secret, nil
 }
 
 // newPrivateKeySecret returns a new private key secret
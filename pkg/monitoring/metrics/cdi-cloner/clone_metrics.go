@@ -1,6 +1,9 @@
 package cdicloner
 
 import (
+	"sync"
+	"time"
+
 	ioprometheusclient "github.com/prometheus/client_model/go"
 	"github.com/rhobs/operator-observability-toolkit/pkg/operatormetrics"
 )
@@ -8,11 +11,20 @@ import (
 const (
 	// CloneProgressMetricName is the name of the clone progress metric
 	CloneProgressMetricName = "kubevirt_cdi_clone_progress_total"
+	// CloneTransferredBytesMetricName is the name of the transferred bytes metric
+	CloneTransferredBytesMetricName = "kubevirt_cdi_clone_transferred_bytes"
+	// CloneTotalBytesMetricName is the name of the total bytes metric
+	CloneTotalBytesMetricName = "kubevirt_cdi_clone_total_bytes"
+	// CloneThroughputMetricName is the name of the throughput metric
+	CloneThroughputMetricName = "kubevirt_cdi_clone_throughput_bytes_per_second"
 )
 
 var (
 	clonerMetrics = []operatormetrics.Metric{
 		cloneProgress,
+		cloneTransferredBytes,
+		cloneTotalBytes,
+		cloneThroughput,
 	}
 
 	cloneProgress = operatormetrics.NewCounterVec(
@@ -22,14 +34,42 @@ var (
 		},
 		[]string{"ownerUID"},
 	)
+
+	cloneTransferredBytes = operatormetrics.NewGaugeVec(
+		operatormetrics.MetricOpts{
+			Name: CloneTransferredBytesMetricName,
+			Help: "The bytes the clone source sent to the target",
+		},
+		[]string{"ownerUID"},
+	)
+
+	cloneTotalBytes = operatormetrics.NewGaugeVec(
+		operatormetrics.MetricOpts{
+			Name: CloneTotalBytesMetricName,
+			Help: "The size in bytes of the data the clone source sends to the target",
+		},
+		[]string{"ownerUID"},
+	)
+
+	cloneThroughput = operatormetrics.NewGaugeVec(
+		operatormetrics.MetricOpts{
+			Name: CloneThroughputMetricName,
+			Help: "The rate the clone source sends data at, measured every second",
+		},
+		[]string{"ownerUID"},
+	)
 )
 
 type CloneProgress struct {
 	ownerUID string
+
+	mutex       sync.Mutex
+	lastBytes   uint64
+	lastUpdated time.Time
 }
 
 func Progress(ownerUID string) *CloneProgress {
-	return &CloneProgress{ownerUID}
+	return &CloneProgress{ownerUID: ownerUID}
 }
 
 // Add adds value to the cloneProgress metric
@@ -46,6 +86,33 @@ func (cp *CloneProgress) Get() (float64, error) {
 	return dto.Counter.GetValue(), nil
 }
 
+// GetTransferredBytes returns the cloneTransferredBytes value
+func (cp *CloneProgress) GetTransferredBytes() (float64, error) {
+	dto := &ioprometheusclient.Metric{}
+	if err := cloneTransferredBytes.WithLabelValues(cp.ownerUID).Write(dto); err != nil {
+		return 0, err
+	}
+	return dto.Gauge.GetValue(), nil
+}
+
+// SetBytes records the bytes the clone source sent to the target, out of total, not recorded if 0. The throughput is
+// measured every second.
+func (cp *CloneProgress) SetBytes(transferred, total uint64) {
+	cp.mutex.Lock()
+	defer cp.mutex.Unlock()
+	now := time.Now()
+	if cp.lastUpdated.IsZero() || transferred < cp.lastBytes {
+		cp.lastBytes, cp.lastUpdated = transferred, now
+	} else if elapsed := now.Sub(cp.lastUpdated); elapsed >= time.Second {
+		cloneThroughput.WithLabelValues(cp.ownerUID).Set(float64(transferred-cp.lastBytes) / elapsed.Seconds())
+		cp.lastBytes, cp.lastUpdated = transferred, now
+	}
+	cloneTransferredBytes.WithLabelValues(cp.ownerUID).Set(float64(transferred))
+	if total > 0 {
+		cloneTotalBytes.WithLabelValues(cp.ownerUID).Set(float64(total))
+	}
+}
+
 // Delete removes the cloneProgress metric with the passed label
 func (cp *CloneProgress) Delete() {
 	cloneProgress.DeleteLabelValues(cp.ownerUID)
@@ -0,0 +1,100 @@
+package cdicontroller
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	ioprometheusclient "github.com/prometheus/client_model/go"
+	"github.com/rhobs/operator-observability-toolkit/pkg/operatormetrics"
+)
+
+const (
+	// PrometheusCloneSourceNsLabel labels the namespace of the source of the clone
+	PrometheusCloneSourceNsLabel = "src_ns"
+	// PrometheusCloneNsLabel labels the namespace of the cloned DataVolume
+	PrometheusCloneNsLabel = "ns"
+	// PrometheusCloneNameLabel labels the name of the cloned DataVolume
+	PrometheusCloneNameLabel = "dv_name"
+	// PrometheusClonePhaseLabel labels the phase of the clone
+	PrometheusClonePhaseLabel = "phase"
+)
+
+var (
+	cloneMetrics = []operatormetrics.Metric{
+		dataVolumeClonePhase,
+		dataVolumeCloneTransferredBytes,
+		dataVolumeCloneTotalBytes,
+		dataVolumeCloneThroughput,
+	}
+
+	cloneLabels = []string{PrometheusCloneSourceNsLabel, PrometheusCloneNsLabel, PrometheusCloneNameLabel}
+
+	dataVolumeClonePhase = operatormetrics.NewGaugeVec(
+		operatormetrics.MetricOpts{
+			Name: "kubevirt_cdi_datavolume_clone_phase",
+			Help: "The phase of the clone of a DataVolume in progress, 1 for its current phase",
+		},
+		[]string{PrometheusCloneSourceNsLabel, PrometheusCloneNsLabel, PrometheusCloneNameLabel, PrometheusClonePhaseLabel},
+	)
+
+	dataVolumeCloneTransferredBytes = operatormetrics.NewGaugeVec(
+		operatormetrics.MetricOpts{
+			Name: "kubevirt_cdi_datavolume_clone_transferred_bytes",
+			Help: "The bytes the host-assisted clone of a DataVolume in progress transferred",
+		},
+		cloneLabels,
+	)
+
+	dataVolumeCloneTotalBytes = operatormetrics.NewGaugeVec(
+		operatormetrics.MetricOpts{
+			Name: "kubevirt_cdi_datavolume_clone_total_bytes",
+			Help: "The bytes the host-assisted clone of a DataVolume in progress transfers",
+		},
+		cloneLabels,
+	)
+
+	dataVolumeCloneThroughput = operatormetrics.NewGaugeVec(
+		operatormetrics.MetricOpts{
+			Name: "kubevirt_cdi_datavolume_clone_throughput_bytes_per_second",
+			Help: "The rate the host-assisted clone of a DataVolume in progress transfers data at",
+		},
+		cloneLabels,
+	)
+)
+
+// SetDataVolumeCloneProgress sets the phase, bytes and throughput of the clone of a DataVolume, removing the values
+// which are not reported
+func SetDataVolumeCloneProgress(labels prometheus.Labels, phase string, transferred, total, throughput *int64) {
+	dataVolumeClonePhase.DeletePartialMatch(labels)
+	phaseLabels := prometheus.Labels{PrometheusClonePhaseLabel: phase}
+	for k, v := range labels {
+		phaseLabels[k] = v
+	}
+	dataVolumeClonePhase.With(phaseLabels).Set(1)
+
+	for gauge, value := range map[*operatormetrics.GaugeVec]*int64{
+		dataVolumeCloneTransferredBytes: transferred,
+		dataVolumeCloneTotalBytes:       total,
+		dataVolumeCloneThroughput:       throughput,
+	} {
+		if value != nil {
+			gauge.With(labels).Set(float64(*value))
+		} else {
+			gauge.Delete(labels)
+		}
+	}
+}
+
+// GetDataVolumeCloneTransferredBytes returns the dataVolumeCloneTransferredBytes value
+func GetDataVolumeCloneTransferredBytes(labels prometheus.Labels) float64 {
+	dto := &ioprometheusclient.Metric{}
+	_ = dataVolumeCloneTransferredBytes.With(labels).Write(dto)
+	return dto.Gauge.GetValue()
+}
+
+// DeleteDataVolumeCloneProgress deletes the clone metrics matching labels, and returns the number of deleted metrics
+func DeleteDataVolumeCloneProgress(labels prometheus.Labels) int {
+	deleted := 0
+	for _, gauge := range []*operatormetrics.GaugeVec{dataVolumeClonePhase, dataVolumeCloneTransferredBytes, dataVolumeCloneTotalBytes, dataVolumeCloneThroughput} {
+		deleted += gauge.DeletePartialMatch(labels)
+	}
+	return deleted
+}
@@ -8,11 +8,35 @@ import (
 const (
 	// ImportProgressMetricName is the name of the import progress metric
 	ImportProgressMetricName = "kubevirt_cdi_import_progress_total"
+	// ImportPreallocationMethodMetricName is the name of the detected preallocation method metric
+	ImportPreallocationMethodMetricName = "kubevirt_cdi_import_preallocation_method_info"
+	// ImportMaxBandwidthMetricName is the name of the maximum bandwidth metric
+	ImportMaxBandwidthMetricName = "kubevirt_cdi_import_max_bandwidth_bytes_per_second"
+	// ImportThroughputMetricName is the name of the throughput metric
+	ImportThroughputMetricName = "kubevirt_cdi_import_throughput_bytes_per_second"
+	// ImportSourceConnectionsMetricName is the name of the source connections metric
+	ImportSourceConnectionsMetricName = "kubevirt_cdi_import_source_connections_total"
+	// ImportSourceConnectionsReusedMetricName is the name of the reused source connections metric
+	ImportSourceConnectionsReusedMetricName = "kubevirt_cdi_import_source_connections_reused_total"
+	// ImportTransferredBytesMetricName is the name of the transferred bytes metric
+	ImportTransferredBytesMetricName = "kubevirt_cdi_import_transferred_bytes"
+	// ImportTotalBytesMetricName is the name of the total bytes metric
+	ImportTotalBytesMetricName = "kubevirt_cdi_import_total_bytes"
+	// ImportPhaseDurationMetricName is the name of the phase duration metric
+	ImportPhaseDurationMetricName = "kubevirt_cdi_import_phase_duration_seconds"
 )
 
 var (
 	importerMetrics = []operatormetrics.Metric{
 		importProgress,
+		importPreallocationMethod,
+		importMaxBandwidth,
+		importThroughput,
+		importSourceConnections,
+		importSourceConnectionsReused,
+		importTransferredBytes,
+		importTotalBytes,
+		importPhaseDuration,
 	}
 
 	importProgress = operatormetrics.NewCounterVec(
@@ -22,6 +46,70 @@ var (
 		},
 		[]string{"ownerUID"},
 	)
+
+	importPreallocationMethod = operatormetrics.NewGaugeVec(
+		operatormetrics.MetricOpts{
+			Name: ImportPreallocationMethodMetricName,
+			Help: "The preallocation method detected as supported by the import target",
+		},
+		[]string{"ownerUID", "method"},
+	)
+
+	importMaxBandwidth = operatormetrics.NewGaugeVec(
+		operatormetrics.MetricOpts{
+			Name: ImportMaxBandwidthMetricName,
+			Help: "The bandwidth the import is throttled to",
+		},
+		[]string{"ownerUID"},
+	)
+
+	importThroughput = operatormetrics.NewGaugeVec(
+		operatormetrics.MetricOpts{
+			Name: ImportThroughputMetricName,
+			Help: "The throughput of a throttled import, measured every second",
+		},
+		[]string{"ownerUID"},
+	)
+
+	importSourceConnections = operatormetrics.NewCounterVec(
+		operatormetrics.MetricOpts{
+			Name: ImportSourceConnectionsMetricName,
+			Help: "The connections nbdkit opened to the source of the import",
+		},
+		[]string{"ownerUID"},
+	)
+
+	importSourceConnectionsReused = operatormetrics.NewCounterVec(
+		operatormetrics.MetricOpts{
+			Name: ImportSourceConnectionsReusedMetricName,
+			Help: "The requests nbdkit sent to the source of the import over a connection it had already opened",
+		},
+		[]string{"ownerUID"},
+	)
+
+	importTransferredBytes = operatormetrics.NewGaugeVec(
+		operatormetrics.MetricOpts{
+			Name: ImportTransferredBytesMetricName,
+			Help: "The bytes the import read from its source",
+		},
+		[]string{"ownerUID"},
+	)
+
+	importTotalBytes = operatormetrics.NewGaugeVec(
+		operatormetrics.MetricOpts{
+			Name: ImportTotalBytesMetricName,
+			Help: "The size in bytes of the source of the import",
+		},
+		[]string{"ownerUID"},
+	)
+
+	importPhaseDuration = operatormetrics.NewGaugeVec(
+		operatormetrics.MetricOpts{
+			Name: ImportPhaseDurationMetricName,
+			Help: "The time the importer spent in each of its phases",
+		},
+		[]string{"ownerUID", "phase"},
+	)
 )
 
 type ImportProgress struct {
@@ -46,7 +134,43 @@ func (ip *ImportProgress) Get() (float64, error) {
 	return dto.Counter.GetValue(), nil
 }
 
+// SetBytes records the bytes the import read from its source, out of total
+func (ip *ImportProgress) SetBytes(transferred, total uint64) {
+	importTransferredBytes.WithLabelValues(ip.ownerUID).Set(float64(transferred))
+	importTotalBytes.WithLabelValues(ip.ownerUID).Set(float64(total))
+}
+
 // Delete removes the importProgress metric with the passed label
 func (ip *ImportProgress) Delete() {
 	importProgress.DeleteLabelValues(ip.ownerUID)
 }
+
+// SetPreallocationMethod records the preallocation method used for the import
+func SetPreallocationMethod(ownerUID, method string) {
+	importPreallocationMethod.WithLabelValues(ownerUID, method).Set(1)
+}
+
+// SetMaxBandwidth records the bandwidth the import is throttled to
+func SetMaxBandwidth(ownerUID string, bytesPerSecond float64) {
+	importMaxBandwidth.WithLabelValues(ownerUID).Set(bytesPerSecond)
+}
+
+// SetThroughput records the throughput of the import
+func SetThroughput(ownerUID string, bytesPerSecond float64) {
+	importThroughput.WithLabelValues(ownerUID).Set(bytesPerSecond)
+}
+
+// AddSourceConnection records a connection opened to the source of the import
+func AddSourceConnection(ownerUID string) {
+	importSourceConnections.WithLabelValues(ownerUID).Inc()
+}
+
+// AddSourceConnectionReused records a request sent over a connection to the source already opened
+func AddSourceConnectionReused(ownerUID string) {
+	importSourceConnectionsReused.WithLabelValues(ownerUID).Inc()
+}
+
+// AddPhaseDuration records the time the importer spent in phase
+func AddPhaseDuration(ownerUID, phase string, seconds float64) {
+	importPhaseDuration.WithLabelValues(ownerUID, phase).Add(seconds)
+}
@@ -73,6 +73,18 @@ func getAPIServerClusterPolicyRules() []rbacv1.PolicyRule {
 				"create",
 			},
 		},
+		{
+			APIGroups: []string{
+				"",
+			},
+			Resources: []string{
+				"events",
+			},
+			Verbs: []string{
+				"create",
+				"patch",
+			},
+		},
 		{
 			APIGroups: []string{
 				"",
@@ -139,12 +151,27 @@ func getAPIServerClusterPolicyRules() []rbacv1.PolicyRule {
 				"get",
 			},
 		},
+		{
+			APIGroups: []string{
+				"storage.k8s.io",
+			},
+			Resources: []string{
+				"csidrivers",
+			},
+			Verbs: []string{
+				"get",
+				"list",
+				"watch",
+			},
+		},
 		{
 			APIGroups: []string{
 				"snapshot.storage.k8s.io",
 			},
 			Resources: []string{
 				"volumesnapshots",
+				"volumesnapshotcontents",
+				"volumesnapshotclasses",
 			},
 			Verbs: []string{
 				"get",
@@ -173,6 +200,20 @@ func getAPIServerClusterPolicyRules() []rbacv1.PolicyRule {
 			},
 			Verbs: []string{
 				"get",
+				"list",
+				"watch",
+			},
+		},
+		{
+			APIGroups: []string{
+				"cdi.kubevirt.io",
+			},
+			Resources: []string{
+				"volumesnapshotgrants",
+				"clonegrants",
+			},
+			Verbs: []string{
+				"list",
 			},
 		},
 
@@ -228,6 +269,7 @@ func getAPIServerClusterPolicyRules() []rbacv1.PolicyRule {
 				"get",
 				"list",
 				"watch",
+				"patch",
 			},
 		},
 		{
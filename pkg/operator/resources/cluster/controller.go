@@ -117,6 +117,17 @@ func getControllerClusterPolicyRules() []rbacv1.PolicyRule {
 				"delete",
 			},
 		},
+		{
+			APIGroups: []string{
+				"",
+			},
+			Resources: []string{
+				"pods/log",
+			},
+			Verbs: []string{
+				"get",
+			},
+		},
 		{
 			APIGroups: []string{
 				"",
@@ -127,6 +138,22 @@ func getControllerClusterPolicyRules() []rbacv1.PolicyRule {
 			Verbs: []string{
 				"get",
 				"create",
+				"update",
+			},
+		},
+		{
+			APIGroups: []string{
+				"batch",
+			},
+			Resources: []string{
+				"jobs",
+			},
+			Verbs: []string{
+				"get",
+				"list",
+				"watch",
+				"create",
+				"delete",
 			},
 		},
 		{
@@ -258,6 +285,7 @@ func getControllerClusterPolicyRules() []rbacv1.PolicyRule {
 			},
 			Verbs: []string{
 				"create",
+				"patch",
 			},
 		},
 		{
@@ -271,6 +299,17 @@ func getControllerClusterPolicyRules() []rbacv1.PolicyRule {
 				"update",
 			},
 		},
+		{
+			APIGroups: []string{
+				"kubevirt.io",
+			},
+			Resources: []string{
+				"virtualmachineinstances",
+			},
+			Verbs: []string{
+				"get",
+			},
+		},
 	}
 }
 
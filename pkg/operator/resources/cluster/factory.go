@@ -64,6 +64,14 @@ func createCRDResources(args *FactoryArgs) []client.Object {
 		createVolumeImportSourceCRD(),
 		createVolumeUploadSourceCRD(),
 		createVolumeCloneSourceCRD(),
+		createImageVerificationPolicyCRD(),
+		createVolumeSnapshotGrantCRD(),
+		createCloneGrantCRD(),
+		createImportQuotaCRD(),
+		createMultiDataVolumeCRD(),
+		createDataExportCRD(),
+		createDataVolumeReplicationCRD(),
+		createDataVolumeMigrationCRD(),
 		createOvirtVolumePopulatorCRD(),
 		createOpenstackVolumePopulatorCRD(),
 	}
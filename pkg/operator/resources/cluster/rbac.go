@@ -45,9 +45,15 @@ func getAdminPolicyRules() []rbacv1.PolicyRule {
 				"datavolumes",
 				"dataimportcrons",
 				"datasources",
+				"dataexports",
+				"multidatavolumes",
+				"datavolumereplications",
+				"datavolumemigrations",
 				"volumeimportsources",
 				"volumeuploadsources",
 				"volumeclonesources",
+				"volumesnapshotgrants",
+				"clonegrants",
 			},
 			Verbs: []string{
 				"*",
@@ -70,6 +76,7 @@ func getAdminPolicyRules() []rbacv1.PolicyRule {
 			},
 			Resources: []string{
 				"uploadtokenrequests",
+				"datavolumerenderrequests",
 			},
 			Verbs: []string{
 				"*",
@@ -104,14 +111,22 @@ func getViewPolicyRules() []rbacv1.PolicyRule {
 			},
 			Resources: []string{
 				"cdiconfigs",
+				"dataexports",
 				"dataimportcrons",
 				"datasources",
+				"datavolumemigrations",
+				"datavolumereplications",
 				"datavolumes",
+				"imageverificationpolicies",
+				"importquotas",
+				"multidatavolumes",
 				"objecttransfers",
 				"storageprofiles",
 				"volumeimportsources",
 				"volumeuploadsources",
 				"volumeclonesources",
+				"volumesnapshotgrants",
+				"clonegrants",
 			},
 			Verbs: []string{
 				"get",
@@ -45,6 +45,18 @@ func getUploadProxyClusterPolicyRules() []rbacv1.PolicyRule {
 				"get",
 			},
 		},
+		{
+			APIGroups: []string{
+				"",
+			},
+			Resources: []string{
+				"events",
+			},
+			Verbs: []string{
+				"create",
+				"patch",
+			},
+		},
 	}
 }
 
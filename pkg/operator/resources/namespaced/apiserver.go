@@ -69,6 +69,21 @@ func getAPIServerNamespacedRules() []rbacv1.PolicyRule {
 				"create",
 			},
 		},
+		{
+			APIGroups: []string{
+				"",
+			},
+			Resources: []string{
+				"secrets",
+			},
+			ResourceNames: []string{
+				"cdi-api-signing-key",
+			},
+			Verbs: []string{
+				// rotates the key signing the tokens
+				"update",
+			},
+		},
 	}
 }
 
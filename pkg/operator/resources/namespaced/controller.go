@@ -290,17 +290,8 @@ func createControllerDeployment(controllerImage, importerImage, clonerImage, ovi
 			Name: "cdi-api-signing-key",
 			VolumeSource: corev1.VolumeSource{
 				Secret: &corev1.SecretVolumeSource{
-					SecretName: "cdi-api-signing-key",
-					Items: []corev1.KeyToPath{
-						{
-							Key:  "id_rsa.pub",
-							Path: "id_rsa.pub",
-						},
-						{
-							Key:  "id_rsa",
-							Path: "id_rsa",
-						},
-					},
+					// all the keys, the public key replaced by the last rotation validates the tokens it signed
+					SecretName:  "cdi-api-signing-key",
 					DefaultMode: &defaultMode,
 				},
 			},
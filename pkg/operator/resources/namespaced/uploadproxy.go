@@ -28,6 +28,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"kubevirt.io/containerized-data-importer/pkg/common"
+	"kubevirt.io/containerized-data-importer/pkg/controller"
 	utils "kubevirt.io/containerized-data-importer/pkg/operator/resources/utils"
 	sdkapi "kubevirt.io/controller-lifecycle-operator-sdk/api"
 )
@@ -96,19 +97,6 @@ func createUploadProxyDeployment(image, verbosity, pullPolicy string, imagePullS
 		deployment.Spec.Replicas = &replicas
 	}
 	container := utils.CreateContainer(common.CDIUploadProxyResourceName, image, verbosity, pullPolicy)
-	container.Env = []corev1.EnvVar{
-		{
-			Name: "APISERVER_PUBLIC_KEY",
-			ValueFrom: &corev1.EnvVarSource{
-				SecretKeyRef: &corev1.SecretKeySelector{
-					LocalObjectReference: corev1.LocalObjectReference{
-						Name: "cdi-api-signing-key",
-					},
-					Key: "id_rsa.pub",
-				},
-			},
-		},
-	}
 	container.ReadinessProbe = &corev1.Probe{
 		ProbeHandler: corev1.ProbeHandler{
 			HTTPGet: &corev1.HTTPGetAction{
@@ -137,6 +125,12 @@ func createUploadProxyDeployment(image, verbosity, pullPolicy string, imagePullS
 			MountPath: "/var/run/certs/cdi-uploadserver-client-cert",
 			ReadOnly:  true,
 		},
+		{
+			// validates the upload tokens and signs the renewed ones, reloaded when the key is rotated
+			Name:      "cdi-api-signing-key",
+			MountPath: controller.TokenKeyDir,
+			ReadOnly:  true,
+		},
 	}
 	container.Resources = corev1.ResourceRequirements{
 		Requests: corev1.ResourceList{
@@ -185,6 +179,15 @@ func createUploadProxyDeployment(image, verbosity, pullPolicy string, imagePullS
 				},
 			},
 		},
+		{
+			Name: "cdi-api-signing-key",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName:  "cdi-api-signing-key",
+					DefaultMode: &defaultMode,
+				},
+			},
+		},
 	}
 	if deployment.Spec.Template.Annotations == nil {
 		deployment.Spec.Template.Annotations = make(map[string]string)
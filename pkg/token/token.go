@@ -21,6 +21,8 @@ package token
 
 import (
 	"crypto/rsa"
+	"slices"
+	"sync"
 	"time"
 
 	jose "github.com/go-jose/go-jose/v3"
@@ -28,6 +30,8 @@ import (
 	"github.com/pkg/errors"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
 )
 
 const (
@@ -55,15 +59,112 @@ type Validator interface {
 	Validate(string) (*Payload, error)
 }
 
+// KeySet holds the private key signing tokens and the public keys validating them, which change when the key is rotated
+type KeySet interface {
+	// PrivateKey returns the key signing the new tokens
+	PrivateKey() (*rsa.PrivateKey, error)
+	// PublicKeys returns the keys the tokens accepted may be signed with, the current one first
+	PublicKeys() ([]*rsa.PublicKey, error)
+}
+
+// ConfigFunc returns the TokenConfig of the CDIConfig, nil if it doesn't configure tokens
+type ConfigFunc func() (*cdiv1.TokenConfig, error)
+
+// LifetimeFunc returns the lifetime of the tokens generated with config
+type LifetimeFunc func(config *cdiv1.TokenConfig) time.Duration
+
+type staticKeySet struct {
+	private *rsa.PrivateKey
+	public  []*rsa.PublicKey
+}
+
+// NewStaticKeySet returns a KeySet which is never rotated. private may be nil for validating tokens only, the public key
+// of private is used if public is empty.
+func NewStaticKeySet(private *rsa.PrivateKey, public ...*rsa.PublicKey) KeySet {
+	if len(public) == 0 && private != nil {
+		public = []*rsa.PublicKey{&private.PublicKey}
+	}
+	return &staticKeySet{private: private, public: public}
+}
+
+func (s *staticKeySet) PrivateKey() (*rsa.PrivateKey, error) {
+	if s.private == nil {
+		return nil, errors.New("no private key to sign tokens with")
+	}
+	return s.private, nil
+}
+
+func (s *staticKeySet) PublicKeys() ([]*rsa.PublicKey, error) {
+	return s.public, nil
+}
+
+// FixedLifetime returns the LifetimeFunc of tokens living for lifetime whatever the TokenConfig
+func FixedLifetime(lifetime time.Duration) LifetimeFunc {
+	return func(*cdiv1.TokenConfig) time.Duration {
+		return lifetime
+	}
+}
+
+// CloneTokenLifetime returns the LifetimeFunc of clone tokens, defaultLifetime if the TokenConfig doesn't set it
+func CloneTokenLifetime(defaultLifetime time.Duration) LifetimeFunc {
+	return func(config *cdiv1.TokenConfig) time.Duration {
+		if config == nil || config.CloneTokenLifetime == nil || config.CloneTokenLifetime.Duration <= 0 {
+			return defaultLifetime
+		}
+		return config.CloneTokenLifetime.Duration
+	}
+}
+
+// UploadTokenLifetime returns the LifetimeFunc of upload tokens, defaultLifetime if the TokenConfig doesn't set it
+func UploadTokenLifetime(defaultLifetime time.Duration) LifetimeFunc {
+	return func(config *cdiv1.TokenConfig) time.Duration {
+		if config == nil || config.UploadTokenLifetime == nil || config.UploadTokenLifetime.Duration <= 0 {
+			return defaultLifetime
+		}
+		return config.UploadTokenLifetime.Duration
+	}
+}
+
+func noConfig() (*cdiv1.TokenConfig, error) {
+	return nil, nil
+}
+
+// ConfigStore holds the TokenConfig of the CDIConfig watched by a component
+type ConfigStore struct {
+	mutex  sync.RWMutex
+	config *cdiv1.TokenConfig
+}
+
+// Update applies the TokenConfig of the CDIConfig, nil restoring the defaults
+func (s *ConfigStore) Update(config *cdiv1.TokenConfig) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.config = config.DeepCopy()
+}
+
+// Get returns the TokenConfig last applied, it is the ConfigFunc of the component
+func (s *ConfigStore) Get() (*cdiv1.TokenConfig, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.config, nil
+}
+
 type validator struct {
 	issuer string
-	key    *rsa.PublicKey
+	keys   KeySet
 	leeway time.Duration
+	config ConfigFunc
 }
 
 // NewValidator return a new Validator implementation
 func NewValidator(issuer string, key *rsa.PublicKey, leeway time.Duration) Validator {
-	return &validator{issuer: issuer, key: key, leeway: leeway}
+	return NewConfigurableValidator(issuer, NewStaticKeySet(nil, key), leeway, noConfig)
+}
+
+// NewConfigurableValidator returns a new Validator accepting the tokens signed with any of the public keys of keys,
+// issued for one of the audiences of the TokenConfig and not revoked by it
+func NewConfigurableValidator(issuer string, keys KeySet, leeway time.Duration, config ConfigFunc) Validator {
+	return &validator{issuer: issuer, keys: keys, leeway: leeway, config: config}
 }
 
 // Validate checks the token signature and returns the contents
@@ -73,10 +174,23 @@ func (v *validator) Validate(token string) (*Payload, error) {
 		return nil, err
 	}
 
+	keys, err := v.keys.PublicKeys()
+	if err != nil {
+		return nil, err
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("no public key to validate tokens with")
+	}
+
 	public := &jwt.Claims{}
 	private := &Payload{}
 
-	if err = tok.Claims(v.key, public, private); err != nil {
+	for _, key := range keys {
+		if err = tok.Claims(key, public, private); err == nil {
+			break
+		}
+	}
+	if err != nil {
 		return nil, err
 	}
 
@@ -89,9 +203,33 @@ func (v *validator) Validate(token string) (*Payload, error) {
 		return nil, err
 	}
 
+	config, err := v.config()
+	if err != nil {
+		return nil, errors.Wrap(err, "error getting the token config")
+	}
+	if err = validateConfig(public, config); err != nil {
+		return nil, err
+	}
+
 	return private, nil
 }
 
+// validateConfig checks the audiences of the token and whether it was revoked
+func validateConfig(claims *jwt.Claims, config *cdiv1.TokenConfig) error {
+	if config == nil {
+		return nil
+	}
+	if len(config.Audiences) > 0 && !slices.ContainsFunc(config.Audiences, claims.Audience.Contains) {
+		return errors.Errorf("token audiences %v not in %v", []string(claims.Audience), config.Audiences)
+	}
+	if revoked := config.RevokeTokensIssuedBefore; revoked != nil {
+		if claims.IssuedAt == nil || claims.IssuedAt.Time().Before(revoked.Time) {
+			return errors.Errorf("token issued before %s was revoked", revoked.UTC().Format(time.RFC3339))
+		}
+	}
+	return nil
+}
+
 // Generator generates tokens
 type Generator interface {
 	Generate(*Payload) (string, error)
@@ -99,31 +237,51 @@ type Generator interface {
 
 type generator struct {
 	issuer   string
-	key      *rsa.PrivateKey
-	lifetime time.Duration
+	keys     KeySet
+	lifetime LifetimeFunc
+	config   ConfigFunc
 }
 
 // NewGenerator returns a new Generator
 func NewGenerator(issuer string, key *rsa.PrivateKey, lifetime time.Duration) Generator {
-	return &generator{issuer: issuer, key: key, lifetime: lifetime}
+	return NewConfigurableGenerator(issuer, NewStaticKeySet(key), FixedLifetime(lifetime), noConfig)
+}
+
+// NewConfigurableGenerator returns a new Generator signing tokens with the private key of keys, for the audiences of the
+// TokenConfig and living for the lifetime it picks in the TokenConfig
+func NewConfigurableGenerator(issuer string, keys KeySet, lifetime LifetimeFunc, config ConfigFunc) Generator {
+	return &generator{issuer: issuer, keys: keys, lifetime: lifetime, config: config}
 }
 
 // Generate generates a token from the given parameters
 func (g *generator) Generate(payload *Payload) (string, error) {
-	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.PS256, Key: g.key}, nil)
+	key, err := g.keys.PrivateKey()
+	if err != nil {
+		return "", err
+	}
+	config, err := g.config()
+	if err != nil {
+		return "", errors.Wrap(err, "error getting the token config")
+	}
+
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.PS256, Key: key}, nil)
 	if err != nil {
 		return "", errors.Wrap(err, "error creating JWT signer")
 	}
 
 	t := time.Now()
+	claims := &jwt.Claims{
+		Issuer:    g.issuer,
+		IssuedAt:  jwt.NewNumericDate(t),
+		NotBefore: jwt.NewNumericDate(t),
+		Expiry:    jwt.NewNumericDate(t.Add(g.lifetime(config))),
+	}
+	if config != nil {
+		claims.Audience = jwt.Audience(config.Audiences)
+	}
 
 	return jwt.Signed(signer).
 		Claims(payload).
-		Claims(&jwt.Claims{
-			Issuer:    g.issuer,
-			IssuedAt:  jwt.NewNumericDate(t),
-			NotBefore: jwt.NewNumericDate(t),
-			Expiry:    jwt.NewNumericDate(t.Add(g.lifetime)),
-		}).
+		Claims(claims).
 		CompactSerialize()
 }
@@ -24,10 +24,13 @@ import (
 	"reflect"
 	"time"
 
+	"github.com/go-jose/go-jose/v3/jwt"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
 )
 
 func generateTestKey() (*rsa.PrivateKey, error) {
@@ -157,4 +160,101 @@ var _ = Describe("Token test", func() {
 		_, err = validator.Validate(signedToken)
 		Expect(err).To(HaveOccurred())
 	})
+	Context("with a TokenConfig", func() {
+		const issuer = "issuer"
+
+		var (
+			key       *rsa.PrivateKey
+			tokenData *Payload
+			config    ConfigStore
+		)
+
+		BeforeEach(func() {
+			var err error
+			key, err = generateTestKey()
+			Expect(err).ToNot(HaveOccurred())
+			tokenData = &Payload{Operation: OperationClone, Name: "source", Namespace: "sourcens"}
+			config = ConfigStore{}
+		})
+
+		generate := func(lifetime LifetimeFunc) string {
+			signedToken, err := NewConfigurableGenerator(issuer, NewStaticKeySet(key), lifetime, config.Get).Generate(tokenData)
+			Expect(err).ToNot(HaveOccurred())
+			return signedToken
+		}
+
+		validate := func(signedToken string) error {
+			_, err := NewConfigurableValidator(issuer, NewStaticKeySet(key), 0, config.Get).Validate(signedToken)
+			return err
+		}
+
+		expiry := func(signedToken string) time.Time {
+			tok, err := jwt.ParseSigned(signedToken)
+			Expect(err).ToNot(HaveOccurred())
+			claims := &jwt.Claims{}
+			Expect(tok.Claims(&key.PublicKey, claims)).To(Succeed())
+			return claims.Expiry.Time()
+		}
+
+		It("should generate tokens living for the lifetime of the config", func() {
+			config.Update(&cdiv1.TokenConfig{
+				CloneTokenLifetime:  &metav1.Duration{Duration: time.Minute},
+				UploadTokenLifetime: &metav1.Duration{Duration: time.Hour},
+			})
+			Expect(expiry(generate(CloneTokenLifetime(5 * time.Minute)))).To(BeTemporally("~", time.Now().Add(time.Minute), 5*time.Second))
+			Expect(expiry(generate(UploadTokenLifetime(5 * time.Minute)))).To(BeTemporally("~", time.Now().Add(time.Hour), 5*time.Second))
+			Expect(expiry(generate(FixedLifetime(2 * time.Hour)))).To(BeTemporally("~", time.Now().Add(2*time.Hour), 5*time.Second))
+		})
+
+		It("should generate tokens living for the default lifetime", func() {
+			Expect(expiry(generate(CloneTokenLifetime(5 * time.Minute)))).To(BeTemporally("~", time.Now().Add(5*time.Minute), 5*time.Second))
+		})
+
+		It("should only accept the tokens issued for the audiences of the config", func() {
+			config.Update(&cdiv1.TokenConfig{Audiences: []string{"cluster-a", "cluster-b"}})
+			signedToken := generate(FixedLifetime(time.Minute))
+			Expect(validate(signedToken)).To(Succeed())
+
+			config.Update(&cdiv1.TokenConfig{Audiences: []string{"cluster-b"}})
+			Expect(validate(signedToken)).To(Succeed())
+
+			config.Update(&cdiv1.TokenConfig{Audiences: []string{"cluster-c"}})
+			Expect(validate(signedToken)).ToNot(Succeed())
+		})
+
+		It("should refuse tokens without audience when the config has some", func() {
+			signedToken := generate(FixedLifetime(time.Minute))
+			config.Update(&cdiv1.TokenConfig{Audiences: []string{"cluster-a"}})
+			Expect(validate(signedToken)).ToNot(Succeed())
+		})
+
+		It("should refuse the revoked tokens", func() {
+			signedToken := generate(FixedLifetime(time.Minute))
+			config.Update(&cdiv1.TokenConfig{RevokeTokensIssuedBefore: &metav1.Time{Time: time.Now().Add(-time.Minute)}})
+			Expect(validate(signedToken)).To(Succeed())
+
+			config.Update(&cdiv1.TokenConfig{RevokeTokensIssuedBefore: &metav1.Time{Time: time.Now().Add(time.Minute)}})
+			Expect(validate(signedToken)).ToNot(Succeed())
+		})
+
+		It("should accept the tokens signed with any of the public keys", func() {
+			previousKey, err := generateTestKey()
+			Expect(err).ToNot(HaveOccurred())
+			signedToken, err := NewGenerator(issuer, previousKey, time.Minute).Generate(tokenData)
+			Expect(err).ToNot(HaveOccurred())
+
+			validator := NewConfigurableValidator(issuer, NewStaticKeySet(key, &key.PublicKey, &previousKey.PublicKey), 0, config.Get)
+			payload, err := validator.Validate(signedToken)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(payload).To(Equal(tokenData))
+
+			_, err = NewConfigurableValidator(issuer, NewStaticKeySet(key), 0, config.Get).Validate(signedToken)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should not generate tokens without private key", func() {
+			_, err := NewConfigurableGenerator(issuer, NewStaticKeySet(nil, &key.PublicKey), FixedLifetime(time.Minute), config.Get).Generate(tokenData)
+			Expect(err).To(HaveOccurred())
+		})
+	})
 })
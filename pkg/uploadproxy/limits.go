@@ -0,0 +1,161 @@
+package uploadproxy
+
+import (
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/klog/v2"
+
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+)
+
+// clientLimiterIdleTime is the time after which the rate limiters of clients without requests are dropped
+const clientLimiterIdleTime = time.Minute
+
+// uploadLimiter enforces the UploadProxyLimits of the CDIConfig, its zero value enforces no limit
+type uploadLimiter struct {
+	mutex  sync.Mutex
+	limits cdiv1.UploadProxyLimits
+	global *rate.Limiter
+	// the rate limiters of the client addresses
+	clients   map[string]*clientLimiter
+	lastPrune time.Time
+	inFlight  int32
+}
+
+type clientLimiter struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// update applies the limits of the CDIConfig, restarting the rate limiters if they changed
+func (l *uploadLimiter) update(limits *cdiv1.UploadProxyLimits) {
+	if limits == nil {
+		limits = &cdiv1.UploadProxyLimits{}
+	}
+	limits = &cdiv1.UploadProxyLimits{
+		RequestsPerSecond:       positiveLimit(limits.RequestsPerSecond),
+		ClientRequestsPerSecond: positiveLimit(limits.ClientRequestsPerSecond),
+		MaxConcurrentRequests:   positiveLimit(limits.MaxConcurrentRequests),
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if equality.Semantic.DeepEqual(&l.limits, limits) {
+		return
+	}
+	klog.Infof("Upload proxy limits: requests per second %s, per client %s, concurrent requests %s",
+		formatLimit(limits.RequestsPerSecond), formatLimit(limits.ClientRequestsPerSecond), formatLimit(limits.MaxConcurrentRequests))
+	l.limits = *limits
+	l.global = newRateLimiter(limits.RequestsPerSecond)
+	l.clients = nil
+}
+
+// acquire admits a request of client, returning the func releasing it once proxied, or false if a limit refuses it
+func (l *uploadLimiter) acquire(client string, now time.Time) (func(), bool) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if max := l.limits.MaxConcurrentRequests; max != nil && l.inFlight >= *max {
+		return nil, false
+	}
+	// the budget of a client is spent before the global one, so that refused clients don't spend it
+	if l.limits.ClientRequestsPerSecond != nil && !l.clientLimiter(client, now).AllowN(now, 1) {
+		return nil, false
+	}
+	if l.global != nil && !l.global.AllowN(now, 1) {
+		return nil, false
+	}
+
+	l.inFlight++
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			l.mutex.Lock()
+			defer l.mutex.Unlock()
+			l.inFlight--
+		})
+	}, true
+}
+
+func (l *uploadLimiter) clientLimiter(client string, now time.Time) *rate.Limiter {
+	if now.Sub(l.lastPrune) > clientLimiterIdleTime {
+		for address, c := range l.clients {
+			if now.Sub(c.lastSeen) > clientLimiterIdleTime {
+				delete(l.clients, address)
+			}
+		}
+		l.lastPrune = now
+	}
+
+	if l.clients == nil {
+		l.clients = map[string]*clientLimiter{}
+	}
+	c, ok := l.clients[client]
+	if !ok {
+		c = &clientLimiter{limiter: newRateLimiter(l.limits.ClientRequestsPerSecond)}
+		l.clients[client] = c
+	}
+	c.lastSeen = now
+	return c.limiter
+}
+
+// limitRequests refuses the requests beyond the limits of the upload proxy with 429 Too Many Requests
+func (app *uploadProxyApp) limitRequests(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == healthzPath {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		client := clientAddress(r)
+		release, ok := app.limiter.acquire(client, time.Now())
+		if !ok {
+			klog.V(3).Infof("Refusing request of %s to %s beyond the upload proxy limits", client, r.URL.Path)
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		defer release()
+		next.ServeHTTP(w, r)
+	})
+}
+
+func clientAddress(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// newRateLimiter returns a limiter of perSecond requests per second with bursts of one second of requests, nil
+// without limit
+func newRateLimiter(perSecond *int32) *rate.Limiter {
+	if perSecond == nil {
+		return nil
+	}
+	return rate.NewLimiter(rate.Limit(*perSecond), int(*perSecond))
+}
+
+// positiveLimit returns a copy of limit, nil if it is not positive
+func positiveLimit(limit *int32) *int32 {
+	if limit == nil || *limit <= 0 {
+		return nil
+	}
+	value := *limit
+	return &value
+}
+
+func formatLimit(limit *int32) string {
+	if limit == nil {
+		return "unlimited"
+	}
+	return strconv.Itoa(int(*limit))
+}
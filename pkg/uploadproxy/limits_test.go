@@ -0,0 +1,112 @@
+package uploadproxy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+	"kubevirt.io/containerized-data-importer/pkg/common"
+)
+
+var _ = Describe("Upload proxy limits", func() {
+	now := time.Now()
+
+	It("should not limit requests without limits", func() {
+		limiter := &uploadLimiter{}
+		limiter.update(nil)
+		for i := 0; i < 100; i++ {
+			_, ok := limiter.acquire("10.0.0.1", now)
+			Expect(ok).To(BeTrue())
+		}
+	})
+
+	It("should limit the rate of the requests of each client", func() {
+		limiter := &uploadLimiter{}
+		limiter.update(&cdiv1.UploadProxyLimits{ClientRequestsPerSecond: ptr.To[int32](2)})
+		for i := 0; i < 2; i++ {
+			_, ok := limiter.acquire("10.0.0.1", now)
+			Expect(ok).To(BeTrue())
+		}
+		_, ok := limiter.acquire("10.0.0.1", now)
+		Expect(ok).To(BeFalse())
+		_, ok = limiter.acquire("10.0.0.2", now)
+		Expect(ok).To(BeTrue())
+		_, ok = limiter.acquire("10.0.0.1", now.Add(time.Second))
+		Expect(ok).To(BeTrue())
+	})
+
+	It("should limit the rate of the requests of all clients", func() {
+		limiter := &uploadLimiter{}
+		limiter.update(&cdiv1.UploadProxyLimits{RequestsPerSecond: ptr.To[int32](2), ClientRequestsPerSecond: ptr.To[int32](1)})
+		_, ok := limiter.acquire("10.0.0.1", now)
+		Expect(ok).To(BeTrue())
+		// refused by the limit of the client, without spending the global budget
+		_, ok = limiter.acquire("10.0.0.1", now)
+		Expect(ok).To(BeFalse())
+		_, ok = limiter.acquire("10.0.0.2", now)
+		Expect(ok).To(BeTrue())
+		_, ok = limiter.acquire("10.0.0.3", now)
+		Expect(ok).To(BeFalse())
+	})
+
+	It("should limit the concurrent requests", func() {
+		limiter := &uploadLimiter{}
+		limiter.update(&cdiv1.UploadProxyLimits{MaxConcurrentRequests: ptr.To[int32](1)})
+		release, ok := limiter.acquire("10.0.0.1", now)
+		Expect(ok).To(BeTrue())
+		_, ok = limiter.acquire("10.0.0.2", now)
+		Expect(ok).To(BeFalse())
+		release()
+		release()
+		_, ok = limiter.acquire("10.0.0.2", now)
+		Expect(ok).To(BeTrue())
+		_, ok = limiter.acquire("10.0.0.2", now)
+		Expect(ok).To(BeFalse())
+	})
+
+	It("should ignore limits that are not positive", func() {
+		limiter := &uploadLimiter{}
+		limiter.update(&cdiv1.UploadProxyLimits{RequestsPerSecond: ptr.To[int32](0), MaxConcurrentRequests: ptr.To[int32](-1)})
+		_, ok := limiter.acquire("10.0.0.1", now)
+		Expect(ok).To(BeTrue())
+	})
+
+	It("should drop the limiters of idle clients", func() {
+		limiter := &uploadLimiter{}
+		limiter.update(&cdiv1.UploadProxyLimits{ClientRequestsPerSecond: ptr.To[int32](1)})
+		_, ok := limiter.acquire("10.0.0.1", now)
+		Expect(ok).To(BeTrue())
+		_, ok = limiter.acquire("10.0.0.2", now.Add(2*clientLimiterIdleTime))
+		Expect(ok).To(BeTrue())
+		Expect(limiter.clients).To(HaveLen(1))
+		Expect(limiter.clients).To(HaveKey("10.0.0.2"))
+	})
+
+	It("should refuse the requests beyond the limits with 429", func() {
+		app, server := setupProxyTests(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+		app.uploadPossible = func(*v1.PersistentVolumeClaim) error { return nil }
+		app.limiter.update(&cdiv1.UploadProxyLimits{ClientRequestsPerSecond: ptr.To[int32](1)})
+
+		submitRequestAndCheckStatus(newProxyRequest(common.UploadPathSync, "Bearer valid"), http.StatusOK, app)
+		rr := httptest.NewRecorder()
+		app.ServeHTTP(rr, newProxyRequest(common.UploadPathSync, "Bearer valid"))
+		Expect(rr.Code).To(Equal(http.StatusTooManyRequests))
+		Expect(rr.Header().Get("Retry-After")).To(Equal("1"))
+
+		req, err := http.NewRequest(http.MethodGet, healthzPath, nil)
+		Expect(err).ToNot(HaveOccurred())
+		rr = httptest.NewRecorder()
+		app.ServeHTTP(rr, req)
+		Expect(rr.Code).To(Equal(http.StatusOK))
+	})
+})
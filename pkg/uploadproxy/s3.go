@@ -0,0 +1,400 @@
+package uploadproxy
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/md5" //nolint:gosec // S3 ETags are MD5 digests, not used for security
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+
+	"kubevirt.io/containerized-data-importer/pkg/common"
+)
+
+const (
+	// s3SecurityTokenHeader is the header of the session token of the credentials of S3 clients, the upload token
+	s3SecurityTokenHeader = "X-Amz-Security-Token"
+	// s3SecurityTokenParam is the query parameter of the session token of presigned S3 URLs
+	s3SecurityTokenParam = "X-Amz-Security-Token"
+
+	s3Namespace = "http://s3.amazonaws.com/doc/2006-03-01/"
+
+	// maxS3ErrorSize is the largest error message of the upload server returned to S3 clients
+	maxS3ErrorSize = 4096
+	// maxS3CompletionSize is the largest body of the requests completing S3 multipart uploads
+	maxS3CompletionSize = 1 << 20
+)
+
+var s3UploadIDMatcher = regexp.MustCompile(`^[0-9a-f]+$`)
+
+type s3Error struct {
+	XMLName  xml.Name `xml:"Error"`
+	Code     string   `xml:"Code"`
+	Message  string   `xml:"Message"`
+	Resource string   `xml:"Resource,omitempty"`
+}
+
+type s3InitiateMultipartUploadResult struct {
+	XMLName  xml.Name `xml:"InitiateMultipartUploadResult"`
+	Xmlns    string   `xml:"xmlns,attr"`
+	Bucket   string   `xml:"Bucket"`
+	Key      string   `xml:"Key"`
+	UploadID string   `xml:"UploadId"`
+}
+
+type s3CompleteMultipartUpload struct {
+	XMLName xml.Name `xml:"CompleteMultipartUpload"`
+	Parts   []struct {
+		PartNumber int    `xml:"PartNumber"`
+		ETag       string `xml:"ETag"`
+	} `xml:"Part"`
+}
+
+type s3CompleteMultipartUploadResult struct {
+	XMLName  xml.Name `xml:"CompleteMultipartUploadResult"`
+	Xmlns    string   `xml:"xmlns,attr"`
+	Location string   `xml:"Location"`
+	Bucket   string   `xml:"Bucket"`
+	Key      string   `xml:"Key"`
+	ETag     string   `xml:"ETag"`
+}
+
+// handleS3Request serves the S3-compatible endpoint, translating the S3 object uploads of bucket/key to the uploads of
+// the PVC key in the namespace bucket. S3 clients send the upload token of the PVC as the session token of their
+// credentials, the signatures of their requests are not checked.
+func (app *uploadProxyApp) handleS3Request(w http.ResponseWriter, r *http.Request) {
+	bucket, key, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, common.UploadS3Path+"/"), "/")
+	if bucket == "" || key == "" || strings.Contains(key, "/") {
+		writeS3Error(w, http.StatusNotFound, "NoSuchKey", "Objects are the PVCs of the namespace of the bucket", r.URL.Path)
+		return
+	}
+
+	if tkn := s3SecurityToken(r); tkn != "" {
+		r.Header.Set("Authorization", "Bearer "+tkn)
+	}
+	tokenData, pvc, ok := app.authorizeUploadRequest(w, r)
+	if !ok {
+		return
+	}
+	if tokenData.Namespace != bucket || tokenData.Name != key {
+		writeS3Error(w, http.StatusForbidden, "AccessDenied", "The upload token is not the one of the PVC of the object", r.URL.Path)
+		return
+	}
+
+	query := r.URL.Query()
+	uploadID := query.Get("uploadId")
+	if uploadID != "" && !s3UploadIDMatcher.MatchString(uploadID) {
+		writeS3Error(w, http.StatusNotFound, "NoSuchUpload", "The upload does not exist", r.URL.Path)
+		return
+	}
+	switch {
+	case r.Method == http.MethodPut && uploadID == "":
+		app.s3PutObject(w, r, pvc, key)
+	case r.Method == http.MethodPost && query.Has("uploads"):
+		app.s3CreateMultipartUpload(w, r, pvc, bucket, key)
+	case r.Method == http.MethodPut:
+		app.s3UploadPart(w, r, pvc, key, uploadID, query.Get("partNumber"))
+	case r.Method == http.MethodPost && uploadID != "":
+		app.s3CompleteMultipartUpload(w, r, pvc, bucket, key, uploadID)
+	case r.Method == http.MethodDelete && uploadID != "":
+		app.proxyS3Request(pvc, key, common.UploadMultipartPath+"/"+uploadID, w, r, nil)
+	default:
+		writeS3Error(w, http.StatusNotImplemented, "NotImplemented", "Only object uploads are supported", r.URL.Path)
+	}
+}
+
+// s3PutObject uploads the object to the PVC with a synchronous upload
+func (app *uploadProxyApp) s3PutObject(w http.ResponseWriter, r *http.Request, pvc *v1.PersistentVolumeClaim, key string) {
+	if err := decodeAWSChunked(r); err != nil {
+		writeS3Error(w, http.StatusBadRequest, "InvalidRequest", err.Error(), r.URL.Path)
+		return
+	}
+	digest := md5.New() //nolint:gosec // S3 ETags are MD5 digests, not used for security
+	r.Body = &hashReadCloser{ReadCloser: r.Body, hash: digest}
+	r.Method = http.MethodPost
+	app.proxyS3Request(pvc, key, common.UploadPathSync, w, r, func(resp *http.Response) error {
+		resp.Header.Set("ETag", fmt.Sprintf(`"%x"`, digest.Sum(nil)))
+		return nil
+	})
+}
+
+func (app *uploadProxyApp) s3CreateMultipartUpload(w http.ResponseWriter, r *http.Request, pvc *v1.PersistentVolumeClaim, bucket, key string) {
+	app.proxyS3Request(pvc, key, common.UploadMultipartPath, w, r, func(resp *http.Response) error {
+		return setS3Response(resp, &s3InitiateMultipartUploadResult{
+			Xmlns:    s3Namespace,
+			Bucket:   bucket,
+			Key:      key,
+			UploadID: path.Base(resp.Header.Get("Location")),
+		})
+	})
+}
+
+func (app *uploadProxyApp) s3UploadPart(w http.ResponseWriter, r *http.Request, pvc *v1.PersistentVolumeClaim, key, uploadID, partNumber string) {
+	if _, err := strconv.Atoi(partNumber); err != nil {
+		writeS3Error(w, http.StatusBadRequest, "InvalidArgument", "Invalid part number", r.URL.Path)
+		return
+	}
+	if err := decodeAWSChunked(r); err != nil {
+		writeS3Error(w, http.StatusBadRequest, "InvalidRequest", err.Error(), r.URL.Path)
+		return
+	}
+	app.proxyS3Request(pvc, key, common.UploadMultipartPath+"/"+uploadID+"/"+partNumber, w, r, func(resp *http.Response) error {
+		resp.StatusCode = http.StatusOK
+		return nil
+	})
+}
+
+func (app *uploadProxyApp) s3CompleteMultipartUpload(w http.ResponseWriter, r *http.Request, pvc *v1.PersistentVolumeClaim, bucket, key, uploadID string) {
+	upload := &s3CompleteMultipartUpload{}
+	if err := xml.NewDecoder(io.LimitReader(r.Body, maxS3CompletionSize)).Decode(upload); err != nil {
+		writeS3Error(w, http.StatusBadRequest, "MalformedXML", err.Error(), r.URL.Path)
+		return
+	}
+	completion := &common.UploadMultipartCompletion{}
+	for _, part := range upload.Parts {
+		completion.Parts = append(completion.Parts, common.UploadMultipartPart{PartNumber: part.PartNumber, ETag: part.ETag})
+	}
+	body, err := json.Marshal(completion)
+	if err != nil {
+		writeS3Error(w, http.StatusInternalServerError, "InternalError", err.Error(), r.URL.Path)
+		return
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	r.ContentLength = int64(len(body))
+	r.Header.Set("Content-Type", "application/json")
+
+	app.proxyS3Request(pvc, key, common.UploadMultipartPath+"/"+uploadID, w, r, func(resp *http.Response) error {
+		return setS3Response(resp, &s3CompleteMultipartUploadResult{
+			Xmlns:    s3Namespace,
+			Location: r.URL.Path,
+			Bucket:   bucket,
+			Key:      key,
+			ETag:     resp.Header.Get("ETag"),
+		})
+	})
+}
+
+// proxyS3Request proxies the request to the upload server at uploadPath, calling modifyResponse with its successful
+// responses and translating its errors to S3 errors
+func (app *uploadProxyApp) proxyS3Request(pvc *v1.PersistentVolumeClaim, key, uploadPath string, w http.ResponseWriter, r *http.Request, modifyResponse func(*http.Response) error) {
+	url, err := app.resolveUploadPath(pvc, key, uploadPath)
+	if err != nil {
+		klog.Error(err)
+		writeS3Error(w, http.StatusServiceUnavailable, "ServiceUnavailable", err.Error(), r.URL.Path)
+		return
+	}
+	resource := r.URL.Path
+	app.proxyUploadRequestWithResponse(url, w, r, func(resp *http.Response) error {
+		if resp.StatusCode >= http.StatusBadRequest {
+			return setS3Error(resp, resource)
+		}
+		if modifyResponse == nil {
+			return nil
+		}
+		return modifyResponse(resp)
+	})
+}
+
+// s3SecurityToken returns the session token of the credentials of the S3 client, sent in a header or the query of
+// presigned URLs
+func s3SecurityToken(r *http.Request) string {
+	if tkn := r.Header.Get(s3SecurityTokenHeader); tkn != "" {
+		return tkn
+	}
+	return r.URL.Query().Get(s3SecurityTokenParam)
+}
+
+// s3ErrorCodes are the S3 error codes of the statuses of the upload server
+var s3ErrorCodes = map[int]string{
+	http.StatusBadRequest:            "InvalidRequest",
+	http.StatusNotFound:              "NoSuchUpload",
+	http.StatusMethodNotAllowed:      "MethodNotAllowed",
+	http.StatusConflict:              "OperationAborted",
+	http.StatusRequestEntityTooLarge: "EntityTooLarge",
+	http.StatusServiceUnavailable:    "ServiceUnavailable",
+}
+
+// setS3Error replaces the error response of the upload server with an S3 error
+func setS3Error(resp *http.Response, resource string) error {
+	message, err := io.ReadAll(io.LimitReader(resp.Body, maxS3ErrorSize))
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	code, ok := s3ErrorCodes[resp.StatusCode]
+	if !ok {
+		code = "InternalError"
+	}
+	if len(message) == 0 {
+		message = []byte(http.StatusText(resp.StatusCode))
+	}
+	return setS3Body(resp, &s3Error{Code: code, Message: string(message), Resource: resource})
+}
+
+// setS3Response replaces the successful response of the upload server with an S3 response
+func setS3Response(resp *http.Response, body any) error {
+	resp.Body.Close()
+	resp.StatusCode = http.StatusOK
+	return setS3Body(resp, body)
+}
+
+func setS3Body(resp *http.Response, body any) error {
+	data, err := xml.Marshal(body)
+	if err != nil {
+		return err
+	}
+	data = append([]byte(xml.Header), data...)
+	resp.Body = io.NopCloser(bytes.NewReader(data))
+	resp.ContentLength = int64(len(data))
+	resp.Header.Set("Content-Length", strconv.Itoa(len(data)))
+	resp.Header.Set("Content-Type", "application/xml")
+	resp.Header.Del("Location")
+	return nil
+}
+
+func writeS3Error(w http.ResponseWriter, status int, code, message, resource string) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	data, err := xml.Marshal(&s3Error{Code: code, Message: message, Resource: resource})
+	if err == nil {
+		_, err = w.Write(append([]byte(xml.Header), data...))
+	}
+	if err != nil {
+		klog.Errorf("writeS3Error: failed to send response: %v", err)
+	}
+}
+
+// hashReadCloser hashes the data read from the body of a request
+type hashReadCloser struct {
+	io.ReadCloser
+	hash hash.Hash
+}
+
+func (r *hashReadCloser) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	r.hash.Write(p[:n])
+	return n, err
+}
+
+// decodeAWSChunked replaces the body of r, sent with the aws-chunked content encoding of S3 streaming uploads, with
+// the data of its chunks. The signatures of the chunks and the trailers are ignored.
+func decodeAWSChunked(r *http.Request) error {
+	var chunked bool
+	var encodings []string
+	for _, encoding := range strings.Split(r.Header.Get("Content-Encoding"), ",") {
+		encoding = strings.TrimSpace(encoding)
+		if strings.EqualFold(encoding, "aws-chunked") {
+			chunked = true
+		} else if encoding != "" {
+			encodings = append(encodings, encoding)
+		}
+	}
+	if !chunked && !strings.HasPrefix(r.Header.Get("X-Amz-Content-Sha256"), "STREAMING-") {
+		return nil
+	}
+	if len(encodings) > 0 {
+		r.Header.Set("Content-Encoding", strings.Join(encodings, ","))
+	} else {
+		r.Header.Del("Content-Encoding")
+	}
+
+	r.ContentLength = -1
+	if decoded := r.Header.Get("X-Amz-Decoded-Content-Length"); decoded != "" {
+		length, err := strconv.ParseInt(decoded, 10, 64)
+		if err != nil || length < 0 {
+			return errors.Errorf("invalid decoded content length %q", decoded)
+		}
+		r.ContentLength = length
+	}
+	r.Header.Del("Content-Length")
+	r.Body = &awsChunkedReader{Closer: r.Body, r: bufio.NewReader(r.Body)}
+	return nil
+}
+
+// awsChunkedReader reads the data of the chunks of an aws-chunked body
+type awsChunkedReader struct {
+	io.Closer
+	r *bufio.Reader
+	// the bytes left to read in the current chunk
+	remaining int64
+	started   bool
+	done      bool
+}
+
+func (c *awsChunkedReader) Read(p []byte) (int, error) {
+	for c.remaining == 0 {
+		if c.done {
+			return 0, io.EOF
+		}
+		if err := c.nextChunk(); err != nil {
+			return 0, err
+		}
+	}
+	if int64(len(p)) > c.remaining {
+		p = p[:c.remaining]
+	}
+	n, err := c.r.Read(p)
+	c.remaining -= int64(n)
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	return n, err
+}
+
+// nextChunk reads the header of the next chunk, and the trailers after the last one
+func (c *awsChunkedReader) nextChunk() error {
+	if c.started {
+		if line, err := c.readLine(); err != nil || line != "" {
+			return errors.Errorf("invalid end of aws-chunked chunk %q", line)
+		}
+	}
+	c.started = true
+
+	header, err := c.readLine()
+	if err != nil {
+		return err
+	}
+	sizeHex, _, _ := strings.Cut(header, ";")
+	size, err := strconv.ParseInt(strings.TrimSpace(sizeHex), 16, 64)
+	if err != nil || size < 0 {
+		return errors.Errorf("invalid aws-chunked chunk header %q", header)
+	}
+	if size > 0 {
+		c.remaining = size
+		return nil
+	}
+
+	c.done = true
+	for {
+		trailer, err := c.readLine()
+		if err == io.EOF || (err == nil && trailer == "") {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (c *awsChunkedReader) readLine() (string, error) {
+	line, err := c.r.ReadSlice('\n')
+	if err == io.EOF && len(line) > 0 {
+		err = io.ErrUnexpectedEOF
+	}
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(strings.TrimSuffix(string(line), "\n"), "\r"), nil
+}
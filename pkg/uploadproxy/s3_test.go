@@ -0,0 +1,188 @@
+package uploadproxy
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	v1 "k8s.io/api/core/v1"
+
+	"kubevirt.io/containerized-data-importer/pkg/common"
+)
+
+var _ = Describe("S3-compatible uploads", func() {
+	type uploadServerRequest struct {
+		method string
+		path   string
+		header http.Header
+		body   string
+	}
+
+	var (
+		app      *uploadProxyApp
+		server   *httptest.Server
+		received chan uploadServerRequest
+		respond  func(w http.ResponseWriter)
+	)
+
+	BeforeEach(func() {
+		received = make(chan uploadServerRequest, 1)
+		respond = func(w http.ResponseWriter) { w.WriteHeader(http.StatusOK) }
+		app, server = setupProxyTests(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, err := io.ReadAll(r.Body)
+			Expect(err).ToNot(HaveOccurred())
+			received <- uploadServerRequest{method: r.Method, path: r.URL.Path, header: r.Header, body: string(body)}
+			respond(w)
+		}))
+		app.uploadPossible = func(*v1.PersistentVolumeClaim) error { return nil }
+		app.urlResolver = func(namespace, name, path string) string {
+			return server.URL + path
+		}
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	s3Request := func(method, target string, body io.Reader) *httptest.ResponseRecorder {
+		req, err := http.NewRequest(method, common.UploadS3Path+target, body)
+		Expect(err).ToNot(HaveOccurred())
+		req.Header.Set("Authorization", "AWS4-HMAC-SHA256 Credential=ignored")
+		req.Header.Set(s3SecurityTokenHeader, "valid")
+		rr := httptest.NewRecorder()
+		app.ServeHTTP(rr, req)
+		return rr
+	}
+
+	It("should upload objects synchronously", func() {
+		rr := s3Request(http.MethodPut, "/default/testpvc", strings.NewReader("data"))
+		Expect(rr.Code).To(Equal(http.StatusOK))
+		Expect(rr.Header().Get("ETag")).To(Equal(`"8d777f385d3dfec8815d20f7496026dc"`))
+		req := <-received
+		Expect(req.method).To(Equal(http.MethodPost))
+		Expect(req.path).To(Equal(common.UploadPathSync))
+		Expect(req.body).To(Equal("data"))
+	})
+
+	It("should decode aws-chunked uploads", func() {
+		chunked := "4;chunk-signature=abcd\r\ndata\r\n6;chunk-signature=ef01\r\n again\r\n0;chunk-signature=2345\r\nx-amz-checksum-crc32:AAAAAA==\r\n\r\n"
+		req, err := http.NewRequest(http.MethodPut, common.UploadS3Path+"/default/testpvc", strings.NewReader(chunked))
+		Expect(err).ToNot(HaveOccurred())
+		req.Header.Set(s3SecurityTokenHeader, "valid")
+		req.Header.Set("Content-Encoding", "aws-chunked,gzip")
+		req.Header.Set("X-Amz-Decoded-Content-Length", "10")
+		rr := httptest.NewRecorder()
+		app.ServeHTTP(rr, req)
+		Expect(rr.Code).To(Equal(http.StatusOK))
+		upload := <-received
+		Expect(upload.body).To(Equal("data again"))
+		Expect(upload.header.Get("Content-Encoding")).To(Equal("gzip"))
+	})
+
+	It("should accept the token of presigned URLs", func() {
+		req, err := http.NewRequest(http.MethodPut, common.UploadS3Path+"/default/testpvc?"+s3SecurityTokenParam+"=valid", strings.NewReader("data"))
+		Expect(err).ToNot(HaveOccurred())
+		rr := httptest.NewRecorder()
+		app.ServeHTTP(rr, req)
+		Expect(rr.Code).To(Equal(http.StatusOK))
+	})
+
+	It("should create multipart uploads", func() {
+		respond = func(w http.ResponseWriter) {
+			w.Header().Set("Location", common.UploadMultipartPath+"/0123abcd")
+			w.WriteHeader(http.StatusCreated)
+		}
+		rr := s3Request(http.MethodPost, "/default/testpvc?uploads", nil)
+		Expect(rr.Code).To(Equal(http.StatusOK))
+		result := &s3InitiateMultipartUploadResult{}
+		Expect(xml.Unmarshal(rr.Body.Bytes(), result)).To(Succeed())
+		Expect(result.Bucket).To(Equal("default"))
+		Expect(result.Key).To(Equal("testpvc"))
+		Expect(result.UploadID).To(Equal("0123abcd"))
+		Expect((<-received).path).To(Equal(common.UploadMultipartPath))
+	})
+
+	It("should upload parts", func() {
+		respond = func(w http.ResponseWriter) {
+			w.Header().Set("ETag", `"abcd"`)
+			w.WriteHeader(http.StatusNoContent)
+		}
+		rr := s3Request(http.MethodPut, "/default/testpvc?partNumber=2&uploadId=0123abcd", strings.NewReader("data"))
+		Expect(rr.Code).To(Equal(http.StatusOK))
+		Expect(rr.Header().Get("ETag")).To(Equal(`"abcd"`))
+		req := <-received
+		Expect(req.method).To(Equal(http.MethodPut))
+		Expect(req.path).To(Equal(common.UploadMultipartPath + "/0123abcd/2"))
+		Expect(req.body).To(Equal("data"))
+	})
+
+	It("should complete multipart uploads", func() {
+		respond = func(w http.ResponseWriter) {
+			w.Header().Set("ETag", `"abcd-2"`)
+			w.WriteHeader(http.StatusNoContent)
+		}
+		completion := `<CompleteMultipartUpload xmlns="http://s3.amazonaws.com/doc/2006-03-01/">` +
+			`<Part><PartNumber>1</PartNumber><ETag>"01"</ETag></Part>` +
+			`<Part><PartNumber>2</PartNumber><ETag>"02"</ETag></Part>` +
+			`</CompleteMultipartUpload>`
+		rr := s3Request(http.MethodPost, "/default/testpvc?uploadId=0123abcd", strings.NewReader(completion))
+		Expect(rr.Code).To(Equal(http.StatusOK))
+		result := &s3CompleteMultipartUploadResult{}
+		Expect(xml.Unmarshal(rr.Body.Bytes(), result)).To(Succeed())
+		Expect(result.ETag).To(Equal(`"abcd-2"`))
+
+		req := <-received
+		Expect(req.path).To(Equal(common.UploadMultipartPath + "/0123abcd"))
+		parts := &common.UploadMultipartCompletion{}
+		Expect(json.Unmarshal([]byte(req.body), parts)).To(Succeed())
+		Expect(parts.Parts).To(Equal([]common.UploadMultipartPart{{PartNumber: 1, ETag: `"01"`}, {PartNumber: 2, ETag: `"02"`}}))
+	})
+
+	It("should abort multipart uploads", func() {
+		respond = func(w http.ResponseWriter) { w.WriteHeader(http.StatusNoContent) }
+		Expect(s3Request(http.MethodDelete, "/default/testpvc?uploadId=0123abcd", nil).Code).To(Equal(http.StatusNoContent))
+		req := <-received
+		Expect(req.method).To(Equal(http.MethodDelete))
+		Expect(req.path).To(Equal(common.UploadMultipartPath + "/0123abcd"))
+	})
+
+	It("should return the errors of the upload server as S3 errors", func() {
+		respond = func(w http.ResponseWriter) {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("not enough space"))
+		}
+		rr := s3Request(http.MethodPut, "/default/testpvc", strings.NewReader("data"))
+		Expect(rr.Code).To(Equal(http.StatusBadRequest))
+		s3Err := &s3Error{}
+		Expect(xml.Unmarshal(rr.Body.Bytes(), s3Err)).To(Succeed())
+		Expect(s3Err.Code).To(Equal("InvalidRequest"))
+		Expect(s3Err.Message).To(Equal("not enough space"))
+	})
+
+	DescribeTable("should refuse", func(method, target string, statusCode int, code string) {
+		rr := s3Request(method, target, strings.NewReader("data"))
+		Expect(rr.Code).To(Equal(statusCode))
+		s3Err := &s3Error{}
+		Expect(xml.Unmarshal(rr.Body.Bytes(), s3Err)).To(Succeed())
+		Expect(s3Err.Code).To(Equal(code))
+		Expect(received).ToNot(Receive())
+	},
+		Entry("objects of other PVCs", http.MethodPut, "/default/otherpvc", http.StatusForbidden, "AccessDenied"),
+		Entry("objects of other namespaces", http.MethodPut, "/other/testpvc", http.StatusForbidden, "AccessDenied"),
+		Entry("objects without key", http.MethodPut, "/default", http.StatusNotFound, "NoSuchKey"),
+		Entry("invalid upload ids", http.MethodPut, "/default/testpvc?partNumber=1&uploadId=../x", http.StatusNotFound, "NoSuchUpload"),
+		Entry("invalid part numbers", http.MethodPut, "/default/testpvc?partNumber=x&uploadId=0123abcd", http.StatusBadRequest, "InvalidArgument"),
+		Entry("downloads", http.MethodGet, "/default/testpvc", http.StatusNotImplemented, "NotImplemented"),
+	)
+
+	It("should refuse invalid tokens", func() {
+		app.tokenValidator = &validateFailure{}
+		Expect(s3Request(http.MethodPut, "/default/testpvc", strings.NewReader("data")).Code).To(Equal(http.StatusUnauthorized))
+	})
+})
@@ -0,0 +1,119 @@
+package uploadproxy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+
+	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+
+	cdiuploadv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/upload/v1beta1"
+	"kubevirt.io/containerized-data-importer/pkg/common"
+	"kubevirt.io/containerized-data-importer/pkg/token"
+)
+
+var (
+	errUploadSessionEnded = errors.New("upload session ended")
+	errNoUploadSession    = errors.New("token without upload session")
+)
+
+// handleTokenRefreshRequest renews the upload token of an upload session, until the session ends or its PVC is deleted
+func (app *uploadProxyApp) handleTokenRefreshRequest(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	if _, err := app.tokenKeys.PrivateKey(); err != nil {
+		klog.Errorf("Unable to renew upload tokens without the apiserver private key: %v", err)
+		w.WriteHeader(http.StatusNotImplemented)
+		return
+	}
+
+	tokenData, ok := app.validateUploadToken(w, r)
+	if !ok {
+		return
+	}
+
+	lifetime, err := app.uploadSessionTokenLifetime(tokenData)
+	if err != nil {
+		klog.Error(err)
+		switch {
+		case errors.Is(err, errUploadSessionEnded):
+			w.WriteHeader(http.StatusUnauthorized)
+		case errors.Is(err, errNoUploadSession):
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		// Return the error to the caller in the body.
+		_, err = fmt.Fprint(w, html.EscapeString(err.Error()))
+		if err != nil {
+			klog.Errorf("handleTokenRefreshRequest: failed to send error response: %v", err)
+		}
+		return
+	}
+
+	tkn, err := token.NewConfigurableGenerator(common.UploadTokenIssuer, app.tokenKeys, token.FixedLifetime(lifetime), app.tokenConfig.Get).Generate(tokenData)
+	if err != nil {
+		klog.Errorf("Unable to renew upload token: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	if err := json.NewEncoder(w).Encode(&cdiuploadv1.UploadTokenRequestStatus{Token: tkn}); err != nil {
+		klog.Errorf("handleTokenRefreshRequest: failed to send response: %v", err)
+	}
+}
+
+// uploadSessionTokenLifetime returns the lifetime of the renewed token of the upload session of tokenData, the upload
+// token lifetime of the TokenConfig without outliving the session
+func (app *uploadProxyApp) uploadSessionTokenLifetime(tokenData *token.Payload) (time.Duration, error) {
+	value, ok := tokenData.Params[common.UploadTokenSessionExpiryParam]
+	if !ok {
+		return 0, errors.Wrapf(errNoUploadSession, "token of PVC %s can't be renewed", tokenData.Name)
+	}
+	expiry, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return 0, errors.Wrapf(errUploadSessionEnded, "invalid session expiry %q", value)
+	}
+	remaining := time.Until(expiry)
+	if remaining <= 0 {
+		return 0, errors.Wrapf(errUploadSessionEnded, "upload session to PVC %s expired at %s", tokenData.Name, value)
+	}
+
+	pvc, err := app.client.CoreV1().PersistentVolumeClaims(tokenData.Namespace).Get(context.TODO(), tokenData.Name, metav1.GetOptions{})
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return 0, errors.Wrapf(errUploadSessionEnded, "PVC %s of the upload session was deleted", tokenData.Name)
+		}
+		return 0, err
+	}
+	if err := checkUploadSessionPVC(pvc, tokenData); err != nil {
+		return 0, err
+	}
+
+	config, err := app.tokenConfig.Get()
+	if err != nil {
+		return 0, err
+	}
+	return min(token.UploadTokenLifetime(common.UploadTokenLifetime)(config), remaining), nil
+}
+
+// checkUploadSessionPVC returns errUploadSessionEnded if pvc isn't the PVC the upload session of tokenData was opened
+// for, which was deleted
+func checkUploadSessionPVC(pvc *v1.PersistentVolumeClaim, tokenData *token.Payload) error {
+	if uid, ok := tokenData.Params[common.UploadTokenUIDParam]; ok && string(pvc.UID) != uid {
+		return errors.Wrapf(errUploadSessionEnded, "PVC %s of the upload session was deleted", pvc.Name)
+	}
+	return nil
+}
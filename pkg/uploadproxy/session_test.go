@@ -0,0 +1,165 @@
+package uploadproxy
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/go-jose/go-jose/v3/jwt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+	cdiuploadv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/upload/v1beta1"
+	"kubevirt.io/containerized-data-importer/pkg/common"
+	"kubevirt.io/containerized-data-importer/pkg/token"
+)
+
+var _ = Describe("Upload sessions", func() {
+	const pvcUID = types.UID("1234")
+
+	var (
+		signingKey *rsa.PrivateKey
+		app        *uploadProxyApp
+		server     *httptest.Server
+	)
+
+	BeforeEach(func() {
+		var err error
+		signingKey, err = rsa.GenerateKey(rand.Reader, 2048)
+		Expect(err).ToNot(HaveOccurred())
+		app, server = setupProxyTests(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+		app.uploadPossible = func(*v1.PersistentVolumeClaim) error { return nil }
+		app.setTokenKeys(token.NewStaticKeySet(signingKey))
+		pvcs := app.client.CoreV1().PersistentVolumeClaims("default")
+		pvc, err := pvcs.Get(context.TODO(), "testpvc", metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		pvc.UID = pvcUID
+		_, err = pvcs.Update(context.TODO(), pvc, metav1.UpdateOptions{})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	newToken := func(params map[string]string) string {
+		generator := token.NewConfigurableGenerator(common.UploadTokenIssuer, token.NewStaticKeySet(signingKey),
+			token.FixedLifetime(common.UploadTokenLifetime), app.tokenConfig.Get)
+		tkn, err := generator.Generate(&token.Payload{
+			Operation: token.OperationUpload,
+			Name:      "testpvc",
+			Namespace: "default",
+			Resource: metav1.GroupVersionResource{
+				Version:  "v1",
+				Resource: "persistentvolumeclaims",
+			},
+			Params: params,
+		})
+		Expect(err).ToNot(HaveOccurred())
+		return tkn
+	}
+
+	sessionParams := func(ttl time.Duration, uid types.UID) map[string]string {
+		return map[string]string{
+			common.UploadTokenSessionExpiryParam: time.Now().Add(ttl).UTC().Format(time.RFC3339),
+			common.UploadTokenUIDParam:           string(uid),
+		}
+	}
+
+	refresh := func(method, tkn string) *httptest.ResponseRecorder {
+		req, err := http.NewRequest(method, common.UploadTokenRefreshPath, nil)
+		Expect(err).ToNot(HaveOccurred())
+		req.Header.Set("Authorization", "Bearer "+tkn)
+		rr := httptest.NewRecorder()
+		app.ServeHTTP(rr, req)
+		return rr
+	}
+
+	renewedToken := func(rr *httptest.ResponseRecorder) (*jwt.Claims, *token.Payload) {
+		Expect(rr.Code).To(Equal(http.StatusOK))
+		status := &cdiuploadv1.UploadTokenRequestStatus{}
+		Expect(json.Unmarshal(rr.Body.Bytes(), status)).To(Succeed())
+		tok, err := jwt.ParseSigned(status.Token)
+		Expect(err).ToNot(HaveOccurred())
+		claims := &jwt.Claims{}
+		payload := &token.Payload{}
+		Expect(tok.Claims(&signingKey.PublicKey, claims, payload)).To(Succeed())
+		return claims, payload
+	}
+
+	It("should renew the token of an upload session", func() {
+		params := sessionParams(time.Hour, pvcUID)
+		claims, payload := renewedToken(refresh(http.MethodPost, newToken(params)))
+		Expect(payload.Name).To(Equal("testpvc"))
+		Expect(payload.Params).To(Equal(params))
+		Expect(claims.Expiry.Time()).To(BeTemporally("~", time.Now().Add(common.UploadTokenLifetime), time.Minute))
+	})
+
+	It("should renew the token for the upload token lifetime and audiences of the token config", func() {
+		app.tokenConfig.Update(&cdiv1.TokenConfig{
+			UploadTokenLifetime: &metav1.Duration{Duration: time.Minute},
+			Audiences:           []string{"upload-proxy"},
+		})
+		claims, _ := renewedToken(refresh(http.MethodPost, newToken(sessionParams(time.Hour, pvcUID))))
+		Expect(claims.Audience).To(ConsistOf("upload-proxy"))
+		Expect(claims.Expiry.Time()).To(BeTemporally("~", time.Now().Add(time.Minute), 10*time.Second))
+	})
+
+	It("should refuse to renew revoked tokens", func() {
+		tkn := newToken(sessionParams(time.Hour, pvcUID))
+		app.tokenConfig.Update(&cdiv1.TokenConfig{RevokeTokensIssuedBefore: &metav1.Time{Time: time.Now().Add(time.Minute)}})
+		Expect(refresh(http.MethodPost, tkn).Code).To(Equal(http.StatusUnauthorized))
+	})
+
+	It("should refuse tokens issued for other audiences", func() {
+		tkn := newToken(sessionParams(time.Hour, pvcUID))
+		app.tokenConfig.Update(&cdiv1.TokenConfig{Audiences: []string{"upload-proxy"}})
+		Expect(refresh(http.MethodPost, tkn).Code).To(Equal(http.StatusUnauthorized))
+	})
+
+	It("should not renew the token beyond the end of the session", func() {
+		params := sessionParams(2*time.Minute, pvcUID)
+		claims, _ := renewedToken(refresh(http.MethodPost, newToken(params)))
+		expiry, err := time.Parse(time.RFC3339, params[common.UploadTokenSessionExpiryParam])
+		Expect(err).ToNot(HaveOccurred())
+		Expect(claims.Expiry.Time()).To(BeTemporally("<=", expiry))
+	})
+
+	DescribeTable("should refuse to renew", func(params func() map[string]string, statusCode int) {
+		Expect(refresh(http.MethodPost, newToken(params())).Code).To(Equal(statusCode))
+	},
+		Entry("tokens without session", func() map[string]string { return nil }, http.StatusForbidden),
+		Entry("tokens of ended sessions", func() map[string]string { return sessionParams(-time.Minute, pvcUID) }, http.StatusUnauthorized),
+		Entry("tokens of deleted PVCs", func() map[string]string { return sessionParams(time.Hour, "5678") }, http.StatusUnauthorized),
+	)
+
+	It("should refuse to renew tokens without the signing key", func() {
+		app.setTokenKeys(token.NewStaticKeySet(nil, &signingKey.PublicKey))
+		Expect(refresh(http.MethodPost, newToken(sessionParams(time.Hour, pvcUID))).Code).To(Equal(http.StatusNotImplemented))
+	})
+
+	It("should only accept POST requests", func() {
+		Expect(refresh(http.MethodGet, newToken(sessionParams(time.Hour, pvcUID))).Code).To(Equal(http.StatusMethodNotAllowed))
+	})
+
+	DescribeTable("should proxy uploads", func(params map[string]string, statusCode int) {
+		req := newProxyRequest(common.UploadPathSync, "Bearer "+newToken(params))
+		submitRequestAndCheckStatus(req, statusCode, app)
+	},
+		Entry("of the PVC of the session", sessionParams(time.Hour, pvcUID), http.StatusOK),
+		Entry("of tokens without session", nil, http.StatusOK),
+		Entry("but not of deleted PVCs", sessionParams(time.Hour, "5678"), http.StatusUnauthorized),
+	)
+})
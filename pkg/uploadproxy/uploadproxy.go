@@ -25,6 +25,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
 	"k8s.io/klog/v2"
 
 	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
@@ -33,6 +34,7 @@ import (
 	cc "kubevirt.io/containerized-data-importer/pkg/controller/common"
 	"kubevirt.io/containerized-data-importer/pkg/controller/populators"
 	"kubevirt.io/containerized-data-importer/pkg/token"
+	"kubevirt.io/containerized-data-importer/pkg/util/audit"
 	"kubevirt.io/containerized-data-importer/pkg/util/cert/fetcher"
 	cryptowatch "kubevirt.io/containerized-data-importer/pkg/util/tls-crypto-watch"
 )
@@ -80,6 +82,18 @@ type uploadProxyApp struct {
 
 	tokenValidator token.Validator
 
+	// validate the upload tokens and sign the renewed tokens of upload sessions, reloaded when rotated
+	tokenKeys token.KeySet
+
+	// the TokenConfig of the CDIConfig the upload tokens are validated and renewed with
+	tokenConfig token.ConfigStore
+
+	// enforces the upload proxy limits of the CDIConfig
+	limiter uploadLimiter
+
+	// emits the audit events of the upload tokens used
+	auditor *audit.Auditor
+
 	handler http.Handler
 
 	// test hooks
@@ -97,7 +111,7 @@ var authHeaderMatcher = regexp.MustCompile(`(?i)^Bearer\s+([A-Za-z0-9\-\._~\+\/]
 // NewUploadProxy returns an initialized uploadProxyApp
 func NewUploadProxy(bindAddress string,
 	bindPort uint,
-	apiServerPublicKey string,
+	tokenKeys token.KeySet,
 	cdiConfigTLSWatcher cryptowatch.CdiConfigTLSWatcher,
 	certWatcher CertWatcher,
 	clientCertFetcher fetcher.CertFetcher,
@@ -111,20 +125,36 @@ func NewUploadProxy(bindAddress string,
 		certWatcher:         certWatcher,
 		clientCreator:       &clientCreator{certFetcher: clientCertFetcher, bundleFetcher: serverCAFetcher},
 		client:              client,
+		auditor:             audit.NewAuditor(client, common.CDIUploadProxyResourceName),
 		urlResolver:         controller.GetUploadServerURL,
 		uploadPossible:      controller.UploadPossibleForPVC,
 	}
-	// retrieve RSA key used by apiserver to sign tokens
-	err = app.getSigningKey(apiServerPublicKey)
-	if err != nil {
-		return nil, errors.Errorf("unable to retrieve apiserver signing key: %v", errors.WithStack(err))
-	}
+	app.setTokenKeys(tokenKeys)
 
 	app.initHandler()
 
+	_, err = cdiConfigTLSWatcher.GetInformer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			app.updateConfig(obj.(*cdiv1.CDIConfig))
+		},
+		UpdateFunc: func(_, obj interface{}) {
+			app.updateConfig(obj.(*cdiv1.CDIConfig))
+		},
+	})
+	if err != nil {
+		return nil, errors.Errorf("unable to watch the upload proxy limits: %v", errors.WithStack(err))
+	}
+
 	return app, nil
 }
 
+// updateConfig applies the upload proxy limits, the token audit and the token config of the CDIConfig
+func (app *uploadProxyApp) updateConfig(config *cdiv1.CDIConfig) {
+	app.limiter.update(config.Spec.UploadProxyLimits)
+	app.auditor.Update(config.Spec.TokenAudit)
+	app.tokenConfig.Update(config.Spec.TokenConfig)
+}
+
 func (c *clientCreator) CreateClient() (*http.Client, error) {
 	clientCertBytes, err := c.certFetcher.CertBytes()
 	if err != nil {
@@ -164,10 +194,45 @@ func (c *clientCreator) CreateClient() (*http.Client, error) {
 func (app *uploadProxyApp) initHandler() {
 	mux := http.NewServeMux()
 	mux.HandleFunc(healthzPath, app.handleHealthzRequest)
+	mux.HandleFunc(common.UploadProgressPath, app.handleProgressRequest)
+	mux.HandleFunc(common.UploadTokenRefreshPath, app.handleTokenRefreshRequest)
+	mux.HandleFunc(common.UploadS3Path+"/", app.handleS3Request)
+	mux.HandleFunc(common.UploadWebsocketPath, app.handleWebsocketUploadRequest)
 	for _, path := range common.ProxyPaths {
 		mux.HandleFunc(path, app.handleUploadRequest)
 	}
-	app.handler = cors.AllowAll().Handler(mux)
+	for _, paths := range uploadIDPaths {
+		for _, path := range paths {
+			mux.HandleFunc(path, app.handleUploadRequest)
+			mux.HandleFunc(path+"/", app.handleUploadRequest)
+		}
+	}
+	app.handler = cors.New(cors.Options{
+		AllowedOrigins: []string{"*"},
+		AllowedMethods: []string{
+			http.MethodHead,
+			http.MethodGet,
+			http.MethodPost,
+			http.MethodPut,
+			http.MethodPatch,
+			http.MethodDelete,
+		},
+		AllowedHeaders: []string{"*"},
+		// upload clients running in browsers read the state of their tus, parallel, S3 and asynchronous uploads from
+		// these headers
+		ExposedHeaders: []string{
+			"ETag",
+			"Location",
+			common.UploadPreferenceAppliedHeader,
+			"Tus-Resumable",
+			"Tus-Version",
+			"Tus-Extension",
+			"Upload-Offset",
+			"Upload-Length",
+			"Upload-Part-Size",
+		},
+		AllowCredentials: false,
+	}).Handler(app.limitRequests(mux))
 }
 
 func (app *uploadProxyApp) ServeHTTP(w http.ResponseWriter, r *http.Request) {
@@ -182,60 +247,107 @@ func (app *uploadProxyApp) handleHealthzRequest(w http.ResponseWriter, r *http.R
 }
 
 func (app *uploadProxyApp) handleUploadRequest(w http.ResponseWriter, r *http.Request) {
-	tokenHeader := r.Header.Get("Authorization")
-	if tokenHeader == "" {
-		w.WriteHeader(http.StatusBadRequest)
+	tokenData, pvc, ok := app.authorizeUploadRequest(w, r)
+	if !ok {
 		return
 	}
 
-	match := authHeaderMatcher.FindStringSubmatch(tokenHeader)
-	if len(match) != 2 {
-		w.WriteHeader(http.StatusBadRequest)
+	uploadPath, err := app.resolveUploadPath(pvc, tokenData.Name, r.URL.Path)
+	if err != nil {
+		klog.Error(err)
+		w.WriteHeader(http.StatusServiceUnavailable)
+		// Return the error to the caller in the body.
+		_, err = fmt.Fprint(w, html.EscapeString(err.Error()))
+		if err != nil {
+			klog.Errorf("handleUploadRequest: failed to send error response: %v", err)
+		}
 		return
 	}
 
-	tokenData, err := app.tokenValidator.Validate(match[1])
-	if err != nil {
-		w.WriteHeader(http.StatusUnauthorized)
+	app.proxyUploadRequest(uploadPath, w, r)
+}
+
+// handleProgressRequest returns the progress of the upload in flight to the PVC of the token, from its upload server
+func (app *uploadProxyApp) handleProgressRequest(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
 		return
 	}
 
-	if tokenData.Operation != token.OperationUpload ||
-		tokenData.Name == "" ||
-		tokenData.Namespace == "" ||
-		tokenData.Resource.Resource != "persistentvolumeclaims" {
-		klog.Errorf("Bad token %+v", tokenData)
-		w.WriteHeader(http.StatusBadRequest)
+	_, pvc, ok := app.authorizeUploadRequest(w, r)
+	if !ok {
 		return
 	}
 
-	klog.V(1).Infof("Received valid token: pvc: %s, namespace: %s", tokenData.Name, tokenData.Namespace)
+	app.proxyUploadRequest(app.urlResolver(pvc.Namespace, pvc.Name, common.UploadProgressPath), w, r)
+}
+
+// authorizeUploadRequest validates the upload token of the request and waits for the upload server of its PVC to be
+// ready, writing the error response otherwise
+func (app *uploadProxyApp) authorizeUploadRequest(w http.ResponseWriter, r *http.Request) (*token.Payload, *v1.PersistentVolumeClaim, bool) {
+	tokenData, ok := app.validateUploadToken(w, r)
+	if !ok {
+		return nil, nil, false
+	}
 
-	pvc, err := app.uploadReady(tokenData.Name, tokenData.Namespace)
+	pvc, err := app.uploadReady(tokenData)
 	if err != nil {
 		klog.Error(err)
-		w.WriteHeader(http.StatusServiceUnavailable)
+		if errors.Is(err, errUploadSessionEnded) {
+			w.WriteHeader(http.StatusUnauthorized)
+		} else {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
 		// Return the error to the caller in the body.
 		_, err = fmt.Fprint(w, html.EscapeString(err.Error()))
 		if err != nil {
 			klog.Errorf("handleUploadRequest: failed to send error response: %v", err)
 		}
-		return
+		return nil, nil, false
 	}
 
-	uploadPath, err := app.resolveUploadPath(pvc, tokenData.Name, r.URL.Path)
+	return tokenData, pvc, true
+}
+
+// validateUploadToken validates the upload token of the request, writing the error response otherwise
+func (app *uploadProxyApp) validateUploadToken(w http.ResponseWriter, r *http.Request) (*token.Payload, bool) {
+	tokenHeader := r.Header.Get("Authorization")
+	if tokenHeader == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		return nil, false
+	}
+
+	match := authHeaderMatcher.FindStringSubmatch(tokenHeader)
+	if len(match) != 2 {
+		w.WriteHeader(http.StatusBadRequest)
+		return nil, false
+	}
+
+	auditEvent := audit.Event{Action: audit.ActionUseUploadToken, SourceIP: clientAddress(r)}
+	tokenData, err := app.tokenValidator.Validate(match[1])
 	if err != nil {
-		klog.Error(err)
-		w.WriteHeader(http.StatusServiceUnavailable)
-		// Return the error to the caller in the body.
-		_, err = fmt.Fprint(w, html.EscapeString(err.Error()))
-		if err != nil {
-			klog.Errorf("handleUploadRequest: failed to send error response: %v", err)
-		}
-		return
+		auditEvent.Outcome, auditEvent.Reason = audit.OutcomeDenied, err.Error()
+		app.auditor.Emit(auditEvent)
+		w.WriteHeader(http.StatusUnauthorized)
+		return nil, false
 	}
 
-	app.proxyUploadRequest(uploadPath, w, r)
+	if tokenData.Operation != token.OperationUpload ||
+		tokenData.Name == "" ||
+		tokenData.Namespace == "" ||
+		tokenData.Resource.Resource != "persistentvolumeclaims" {
+		klog.Errorf("Bad token %+v", tokenData)
+		auditEvent.Outcome, auditEvent.Reason = audit.OutcomeDenied, "not an upload token"
+		app.auditor.Emit(auditEvent)
+		w.WriteHeader(http.StatusBadRequest)
+		return nil, false
+	}
+
+	klog.V(1).Infof("Received valid token: pvc: %s, namespace: %s", tokenData.Name, tokenData.Namespace)
+	auditEvent.Outcome = audit.OutcomeSuccess
+	auditEvent.Kind, auditEvent.Namespace, auditEvent.Name = "PersistentVolumeClaim", tokenData.Namespace, tokenData.Name
+	app.auditor.Emit(auditEvent)
+	return tokenData, true
 }
 
 func (app *uploadProxyApp) resolveUploadPath(pvc *v1.PersistentVolumeClaim, pvcName, defaultPath string) (string, error) {
@@ -244,8 +356,13 @@ func (app *uploadProxyApp) resolveUploadPath(pvc *v1.PersistentVolumeClaim, pvcN
 	switch contentType {
 	case string(cdiv1.DataVolumeKubeVirt), "":
 		path = defaultPath
+		if paths, id, ok := uploadID(defaultPath); ok {
+			path = paths[0] + id
+		}
 	case string(cdiv1.DataVolumeArchive):
-		if strings.Contains(defaultPath, "alpha") {
+		if paths, id, ok := uploadID(defaultPath); ok {
+			path = paths[1] + id
+		} else if strings.Contains(defaultPath, "alpha") {
 			path = common.UploadArchiveAlphaPath
 		} else {
 			path = common.UploadArchivePath
@@ -258,8 +375,29 @@ func (app *uploadProxyApp) resolveUploadPath(pvc *v1.PersistentVolumeClaim, pvcN
 	return app.urlResolver(pvc.Namespace, pvc.Name, path), nil
 }
 
-func (app *uploadProxyApp) uploadReady(pvcName, pvcNamespace string) (*v1.PersistentVolumeClaim, error) {
+// uploadIDPaths are the paths of the uploads of disk images and archives followed by the id of the upload. Archives
+// have their own paths on the upload server, which the location of their uploads points to.
+var uploadIDPaths = [][2]string{
+	{common.UploadTusPath, common.UploadTusArchivePath},
+	{common.UploadParallelPath, common.UploadParallelArchivePath},
+	{common.UploadMultipartPath, common.UploadMultipartArchivePath},
+}
+
+// uploadID returns the upload paths the path starts with, along with the rest of the path, the id of the upload if any
+func uploadID(path string) ([2]string, string, bool) {
+	for _, paths := range uploadIDPaths {
+		for _, prefix := range paths {
+			if path == prefix || strings.HasPrefix(path, prefix+"/") {
+				return paths, strings.TrimPrefix(path, prefix), true
+			}
+		}
+	}
+	return [2]string{}, "", false
+}
+
+func (app *uploadProxyApp) uploadReady(tokenData *token.Payload) (*v1.PersistentVolumeClaim, error) {
 	var pvc *v1.PersistentVolumeClaim
+	pvcName, pvcNamespace := tokenData.Name, tokenData.Namespace
 	err := wait.PollUntilContextTimeout(context.TODO(), waitReadyImterval, waitReadyTime, true, func(ctx context.Context) (bool, error) {
 		var err error
 		pvc, err = app.client.CoreV1().PersistentVolumeClaims(pvcNamespace).Get(ctx, pvcName, metav1.GetOptions{})
@@ -270,6 +408,9 @@ func (app *uploadProxyApp) uploadReady(pvcName, pvcNamespace string) (*v1.Persis
 
 			return false, err
 		}
+		if err := checkUploadSessionPVC(pvc, tokenData); err != nil {
+			return false, err
+		}
 		// If using upload populator then need to check upload possibility to the PVC'
 		if populators.IsPVCDataSourceRefKind(pvc, cdiv1.VolumeUploadSourceRef) {
 			pvc, err = app.getPopulationPVC(ctx, pvc, pvcNamespace)
@@ -295,6 +436,12 @@ func (app *uploadProxyApp) uploadReady(pvcName, pvcNamespace string) (*v1.Persis
 }
 
 func (app *uploadProxyApp) proxyUploadRequest(uploadPath string, w http.ResponseWriter, r *http.Request) {
+	app.proxyUploadRequestWithResponse(uploadPath, w, r, nil)
+}
+
+// proxyUploadRequestWithResponse proxies the request to uploadPath, modifying the response of the upload server with
+// modifyResponse when not nil
+func (app *uploadProxyApp) proxyUploadRequestWithResponse(uploadPath string, w http.ResponseWriter, r *http.Request, modifyResponse func(*http.Response) error) {
 	client, err := app.clientCreator.CreateClient()
 	if err != nil {
 		klog.Error("Error creating http client")
@@ -311,8 +458,9 @@ func (app *uploadProxyApp) proxyUploadRequest(uploadPath string, w http.Response
 				req.Header.Set("User-Agent", "")
 			}
 		},
-		Transport: client.Transport,
-		ErrorLog:  log.New(&buff, "", 0),
+		Transport:      client.Transport,
+		ModifyResponse: modifyResponse,
+		ErrorLog:       log.New(&buff, "", 0),
 	}
 
 	p.ServeHTTP(w, r)
@@ -324,14 +472,10 @@ func (app *uploadProxyApp) proxyUploadRequest(uploadPath string, w http.Response
 	}
 }
 
-func (app *uploadProxyApp) getSigningKey(publicKeyPEM string) error {
-	publicKey, err := controller.DecodePublicKey([]byte(publicKeyPEM))
-	if err != nil {
-		return err
-	}
-
-	app.tokenValidator = token.NewValidator(common.UploadTokenIssuer, publicKey, uploadTokenLeeway)
-	return nil
+// setTokenKeys validates the upload tokens and signs the renewed ones with the keys used by apiserver to sign tokens
+func (app *uploadProxyApp) setTokenKeys(tokenKeys token.KeySet) {
+	app.tokenKeys = tokenKeys
+	app.tokenValidator = token.NewConfigurableValidator(common.UploadTokenIssuer, tokenKeys, uploadTokenLeeway, app.tokenConfig.Get)
 }
 
 func (app *uploadProxyApp) Start() error {
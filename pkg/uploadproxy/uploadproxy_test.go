@@ -3,11 +3,15 @@ package uploadproxy
 import (
 	"crypto/rand"
 	"crypto/rsa"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"regexp"
 	"strings"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -17,8 +21,11 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	k8sfake "k8s.io/client-go/kubernetes/fake"
 
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
 	"kubevirt.io/containerized-data-importer/pkg/common"
+	"kubevirt.io/containerized-data-importer/pkg/keys"
 	"kubevirt.io/containerized-data-importer/pkg/token"
+	"kubevirt.io/containerized-data-importer/pkg/util/audit"
 	"kubevirt.io/containerized-data-importer/pkg/util/cert"
 	"kubevirt.io/containerized-data-importer/pkg/util/cert/fetcher"
 	"kubevirt.io/containerized-data-importer/pkg/util/cert/triple"
@@ -134,12 +141,14 @@ func createApp() *uploadProxyApp {
 }
 
 var _ = Describe("Certificate functions", func() {
-	It("Get signing key", func() {
-		publicKeyPEM := getPublicKeyEncoded()
+	It("Get signing keys", func() {
+		dir := GinkgoT().TempDir()
+		Expect(os.WriteFile(filepath.Join(dir, keys.KeyStorePublicKeyFile), []byte(getPublicKeyEncoded()), 0600)).To(Succeed())
+		tokenKeys, err := keys.NewFileKeySet(dir)
+		Expect(err).ToNot(HaveOccurred())
 		app := createApp()
 
-		err := app.getSigningKey(publicKeyPEM)
-		Expect(err).ToNot(HaveOccurred())
+		app.setTokenKeys(tokenKeys)
 		Expect(app.tokenValidator).ToNot(BeNil())
 	})
 
@@ -209,6 +218,15 @@ var _ = Describe("submit request and check status", func() {
 		Entry("Test Form Sync error", common.UploadFormSync, http.StatusInternalServerError),
 		Entry("Test Form Async OK", common.UploadFormAsync, http.StatusOK),
 		Entry("Test Form Async error", common.UploadFormAsync, http.StatusInternalServerError),
+		Entry("Test tus creation OK", common.UploadTusPath, http.StatusCreated),
+		Entry("Test tus upload OK", common.UploadTusPath+"/abc", http.StatusNoContent),
+		Entry("Test tus archive upload OK", common.UploadTusArchivePath+"/abc", http.StatusNoContent),
+		Entry("Test parallel creation OK", common.UploadParallelPath, http.StatusCreated),
+		Entry("Test parallel upload OK", common.UploadParallelPath+"/abc", http.StatusNoContent),
+		Entry("Test parallel archive upload OK", common.UploadParallelArchivePath+"/abc", http.StatusNoContent),
+		Entry("Test multipart creation OK", common.UploadMultipartPath, http.StatusCreated),
+		Entry("Test multipart upload OK", common.UploadMultipartPath+"/abc/1", http.StatusNoContent),
+		Entry("Test multipart archive upload OK", common.UploadMultipartArchivePath+"/abc/1", http.StatusNoContent),
 	)
 	DescribeTable("Test proxy status code with CORS", func(path string, statusCode int) {
 		app, _ := setupProxyTests(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -229,6 +247,21 @@ var _ = Describe("submit request and check status", func() {
 		Entry("Test Form Async OK", common.UploadFormAsync, http.StatusOK),
 		Entry("Test Form Async error", common.UploadFormAsync, http.StatusInternalServerError),
 	)
+	It("Test tus headers exposed with CORS", func() {
+		app, _ := setupProxyTests(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Upload-Offset", "4")
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		app.uploadPossible = func(*v1.PersistentVolumeClaim) error { return nil }
+
+		req := newProxyRequest(common.UploadTusPath+"/abc", "Bearer valid")
+		req.Header.Set("Origin", "foo.bar.com")
+		rr := httptest.NewRecorder()
+		app.ServeHTTP(rr, req)
+		Expect(rr.Code).To(Equal(http.StatusNoContent))
+		Expect(rr.Header().Get("Upload-Offset")).To(Equal("4"))
+		Expect(rr.Header().Get("Access-Control-Expose-Headers")).To(ContainSubstring("Upload-Offset"))
+	})
 	DescribeTable("Test head proxy status code", func(statusCode int) {
 		app, _ := setupProxyTests(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			w.WriteHeader(statusCode)
@@ -249,6 +282,52 @@ var _ = Describe("submit request and check status", func() {
 
 		submitRequestAndCheckStatus(req, http.StatusUnauthorized, app)
 	})
+	DescribeTable("should audit the use of tokens", func(validator token.Validator, statusCode int, expected audit.Event) {
+		received := make(chan audit.Event, 1)
+		webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var event audit.Event
+			Expect(json.NewDecoder(r.Body).Decode(&event)).To(Succeed())
+			received <- event
+		}))
+		defer webhook.Close()
+
+		app, server := setupProxyTests(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+		app.uploadPossible = func(*v1.PersistentVolumeClaim) error { return nil }
+		app.tokenValidator = validator
+		app.auditor = audit.NewAuditor(app.client, common.CDIUploadProxyResourceName)
+		app.auditor.Update(&cdiv1.TokenAuditConfig{Sink: cdiv1.TokenAuditSinkWebhook, WebhookURL: webhook.URL})
+
+		req := newProxyRequest(common.UploadPathSync, "Bearer valid")
+		req.RemoteAddr = "10.0.0.1:1234"
+		submitRequestAndCheckStatus(req, statusCode, app)
+
+		var event audit.Event
+		Eventually(received).Should(Receive(&event))
+		Expect(event.Time).ToNot(BeZero())
+		event.Time = time.Time{}
+		Expect(event).To(Equal(expected))
+	},
+		Entry("valid", &validateSuccess{}, http.StatusOK, audit.Event{
+			Component: common.CDIUploadProxyResourceName,
+			Action:    audit.ActionUseUploadToken,
+			Outcome:   audit.OutcomeSuccess,
+			SourceIP:  "10.0.0.1",
+			Kind:      "PersistentVolumeClaim",
+			Namespace: "default",
+			Name:      "testpvc",
+		}),
+		Entry("invalid", &validateFailure{}, http.StatusUnauthorized, audit.Event{
+			Component: common.CDIUploadProxyResourceName,
+			Action:    audit.ActionUseUploadToken,
+			Outcome:   audit.OutcomeDenied,
+			Reason:    "Bad token",
+			SourceIP:  "10.0.0.1",
+		}),
+	)
+
 	DescribeTable("Test proxy auth header", func(headerValue string, statusCode int) {
 		req := newProxyRequest(common.UploadPathSync, headerValue)
 		submitRequestAndCheckStatus(req, statusCode, nil)
@@ -281,6 +360,74 @@ var _ = Describe("submit request and check status", func() {
 		submitRequestAndCheckStatus(req, http.StatusOK, nil)
 	})
 
+	DescribeTable("Test upload path with id", func(contentType cdiv1.DataVolumeContentType, path, expectedPath string) {
+		app := createApp()
+		app.urlResolver = func(namespace, name, path string) string {
+			return path
+		}
+		pvc := &v1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "testpvc",
+				Namespace:   "default",
+				Annotations: map[string]string{"cdi.kubevirt.io/storage.contentType": string(contentType)},
+			},
+		}
+		resolved, err := app.resolveUploadPath(pvc, pvc.Name, path)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(resolved).To(Equal(expectedPath))
+	},
+		Entry("of a disk image", cdiv1.DataVolumeKubeVirt, common.UploadTusPath, common.UploadTusPath),
+		Entry("of an upload of a disk image", cdiv1.DataVolumeKubeVirt, common.UploadTusPath+"/abc", common.UploadTusPath+"/abc"),
+		Entry("of an upload of a disk image at the archive path", cdiv1.DataVolumeKubeVirt, common.UploadTusArchivePath+"/abc", common.UploadTusPath+"/abc"),
+		Entry("of an archive", cdiv1.DataVolumeArchive, common.UploadTusPath, common.UploadTusArchivePath),
+		Entry("of an upload of an archive", cdiv1.DataVolumeArchive, common.UploadTusArchivePath+"/abc", common.UploadTusArchivePath+"/abc"),
+		Entry("of an upload of an archive at the disk image path", cdiv1.DataVolumeArchive, common.UploadTusPath+"/abc", common.UploadTusArchivePath+"/abc"),
+		Entry("of a parallel upload of a disk image", cdiv1.DataVolumeKubeVirt, common.UploadParallelPath+"/abc", common.UploadParallelPath+"/abc"),
+		Entry("of a parallel upload of a disk image at the archive path", cdiv1.DataVolumeKubeVirt, common.UploadParallelArchivePath+"/abc", common.UploadParallelPath+"/abc"),
+		Entry("of a parallel upload of an archive", cdiv1.DataVolumeArchive, common.UploadParallelPath, common.UploadParallelArchivePath),
+		Entry("of a parallel upload of an archive at the disk image path", cdiv1.DataVolumeArchive, common.UploadParallelPath+"/abc", common.UploadParallelArchivePath+"/abc"),
+	)
+
+	It("Test progress proxied to the upload server", func() {
+		var progressPath string
+		app, server := setupProxyTests(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			Expect(r.Method).To(Equal(http.MethodGet))
+			progressPath = r.URL.Path
+			_, err := w.Write([]byte(`{"phase":"Uploading","bytesReceived":4,"conversionProgress":0}`))
+			Expect(err).ToNot(HaveOccurred())
+		}))
+		app.urlResolver = func(namespace, name, path string) string {
+			return server.URL + path
+		}
+		app.uploadPossible = func(*v1.PersistentVolumeClaim) error { return nil }
+
+		req, err := http.NewRequest(http.MethodGet, common.UploadProgressPath, nil)
+		Expect(err).ToNot(HaveOccurred())
+		req.Header.Set("Authorization", "Bearer valid")
+		rr := httptest.NewRecorder()
+		app.ServeHTTP(rr, req)
+		Expect(rr.Code).To(Equal(http.StatusOK))
+		Expect(rr.Body.String()).To(ContainSubstring(`"bytesReceived":4`))
+		Expect(progressPath).To(Equal(common.UploadProgressPath))
+	})
+
+	DescribeTable("Test progress refused", func(method, authHeaderValue string, statusCode int) {
+		app, _ := setupProxyTests(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			panic("the upload server should not be called")
+		}))
+		app.uploadPossible = func(*v1.PersistentVolumeClaim) error { return nil }
+		app.tokenValidator = &validateFailure{}
+
+		req, err := http.NewRequest(method, common.UploadProgressPath, nil)
+		Expect(err).ToNot(HaveOccurred())
+		req.Header.Set("Authorization", authHeaderValue)
+		submitRequestAndCheckStatus(req, statusCode, app)
+	},
+		Entry("with an invalid token", http.MethodGet, "Bearer invalid", http.StatusUnauthorized),
+		Entry("without token", http.MethodGet, "", http.StatusBadRequest),
+		Entry("of another method", http.MethodPost, "Bearer valid", http.StatusMethodNotAllowed),
+	)
+
 	It("Upload server is unavailable", func() {
 		app, server := setupProxyTests(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			panic("Server is down, this should not be called")
@@ -0,0 +1,172 @@
+package uploadproxy
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/pkg/errors"
+
+	"k8s.io/klog/v2"
+
+	"kubevirt.io/containerized-data-importer/pkg/common"
+)
+
+const (
+	// websocketCloseStatusBase is added to the HTTP status of failed uploads to get the code of their close message,
+	// in the range of the codes of applications
+	websocketCloseStatusBase = 4000
+	// maxWebsocketCloseReason is the largest reason of websocket close messages
+	maxWebsocketCloseReason = 123
+	// websocketPingInterval is the interval of the pings keeping the websocket of an upload open while the upload
+	// server processes the data, for the load balancers closing idle connections
+	websocketPingInterval = 30 * time.Second
+	websocketWriteTimeout = 10 * time.Second
+)
+
+var errWebsocketUploadEnded = errors.New("websocket upload ended")
+
+var websocketUpgrader = websocket.Upgrader{
+	// uploads are authorized by their token rather than by cookies, so pages of any origin may send them, like the
+	// CORS policy of the other uploads allows
+	CheckOrigin: func(*http.Request) bool { return true },
+}
+
+// handleWebsocketUploadRequest receives a synchronous upload over a websocket, for browsers which can neither stream
+// the body of requests nor set the headers of websockets. The upload token is the token query parameter, and the data
+// the binary messages of the client, ended by a text message. The close message of the proxy is the result of the
+// upload.
+func (app *uploadProxyApp) handleWebsocketUploadRequest(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if tkn := query.Get(common.UploadTokenQueryParam); tkn != "" && r.Header.Get("Authorization") == "" {
+		r.Header.Set("Authorization", "Bearer "+tkn)
+	}
+	tokenData, pvc, ok := app.authorizeUploadRequest(w, r)
+	if !ok {
+		return
+	}
+
+	uploadPath, err := app.resolveUploadPath(pvc, tokenData.Name, common.UploadPathSync)
+	if err != nil {
+		klog.Error(err)
+		w.WriteHeader(http.StatusServiceUnavailable)
+		// Return the error to the caller in the body.
+		_, err = fmt.Fprint(w, html.EscapeString(err.Error()))
+		if err != nil {
+			klog.Errorf("handleWebsocketUploadRequest: failed to send error response: %v", err)
+		}
+		return
+	}
+	client, err := app.clientCreator.CreateClient()
+	if err != nil {
+		klog.Error("Error creating http client")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	// the upgrader writes the error response of failed handshakes
+	conn, err := websocketUpgrader.Upgrade(w, r, nil)
+	if err != nil {
+		klog.Errorf("Unable to open the websocket of the upload to PVC %s: %v", tokenData.Name, err)
+		return
+	}
+	defer conn.Close()
+
+	body, data := io.Pipe()
+	received := make(chan struct{})
+	go func() {
+		defer close(received)
+		receiveWebsocketUpload(conn, data)
+	}()
+	// waits for the close message of the client, so that closing the connection with unread data doesn't reset it
+	// before the client reads the result
+	defer func() {
+		select {
+		case <-received:
+		case <-time.After(websocketWriteTimeout):
+		}
+	}()
+	stopPings := make(chan struct{})
+	defer close(stopPings)
+	go pingWebsocket(conn, stopPings)
+
+	req, err := http.NewRequestWithContext(r.Context(), http.MethodPost, uploadPath, body)
+	if err != nil {
+		body.CloseWithError(errWebsocketUploadEnded)
+		closeWebsocketUpload(conn, nil, err)
+		return
+	}
+	if digest := query.Get(common.UploadSHA256QueryParam); digest != "" {
+		req.Header.Set(common.UploadSHA256Header, digest)
+	}
+	resp, err := client.Do(req)
+	// unblocks the receiver if the upload server answered before reading all the data
+	body.CloseWithError(errWebsocketUploadEnded)
+	closeWebsocketUpload(conn, resp, err)
+}
+
+// receiveWebsocketUpload writes the data of the binary messages of conn to data until a text message ends it, then
+// discards the messages until the client closes the websocket
+func receiveWebsocketUpload(conn *websocket.Conn, data *io.PipeWriter) {
+	for {
+		messageType, message, err := conn.NextReader()
+		if err != nil {
+			data.CloseWithError(errors.Wrap(err, "websocket upload interrupted"))
+			return
+		}
+		if messageType == websocket.TextMessage {
+			data.Close()
+			continue
+		}
+		if _, err := io.Copy(data, message); err != nil {
+			// the upload ended, the rest of the data is discarded
+			_, _ = io.Copy(io.Discard, message)
+		}
+	}
+}
+
+func pingWebsocket(conn *websocket.Conn, stop <-chan struct{}) {
+	ticker := time.NewTicker(websocketPingInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(websocketWriteTimeout)); err != nil {
+				klog.V(3).Infof("Unable to ping the websocket of an upload: %v", err)
+				return
+			}
+		}
+	}
+}
+
+// closeWebsocketUpload sends the result of the upload in the close message of conn: a normal closure once uploaded,
+// websocketCloseStatusBase plus the HTTP status of the upload server with its error otherwise
+func closeWebsocketUpload(conn *websocket.Conn, resp *http.Response, err error) {
+	code, reason := websocket.CloseNormalClosure, ""
+	switch {
+	case err != nil:
+		klog.Errorf("Error in websocket upload: %v", err)
+		code, reason = websocket.CloseInternalServerErr, err.Error()
+	case resp.StatusCode >= http.StatusMultipleChoices:
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, maxWebsocketCloseReason))
+		code, reason = websocketCloseStatusBase+resp.StatusCode, string(message)
+	}
+	if resp != nil {
+		resp.Body.Close()
+	}
+	if len(reason) > maxWebsocketCloseReason {
+		reason = reason[:maxWebsocketCloseReason]
+	}
+	reason = strings.ToValidUTF8(reason, "")
+
+	message := websocket.FormatCloseMessage(code, reason)
+	if err := conn.WriteControl(websocket.CloseMessage, message, time.Now().Add(websocketWriteTimeout)); err != nil {
+		klog.Errorf("Unable to send the result of the websocket upload: %v", err)
+	}
+}
@@ -0,0 +1,108 @@
+package uploadproxy
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/gorilla/websocket"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	v1 "k8s.io/api/core/v1"
+
+	"kubevirt.io/containerized-data-importer/pkg/common"
+)
+
+var _ = Describe("Websocket uploads", func() {
+	var (
+		app      *uploadProxyApp
+		server   *httptest.Server
+		proxy    *httptest.Server
+		received chan *http.Request
+		uploaded chan string
+		respond  func(w http.ResponseWriter)
+	)
+
+	BeforeEach(func() {
+		received = make(chan *http.Request, 1)
+		uploaded = make(chan string, 1)
+		respond = func(w http.ResponseWriter) { w.WriteHeader(http.StatusOK) }
+		app, server = setupProxyTests(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			received <- r
+			data, err := io.ReadAll(r.Body)
+			Expect(err).ToNot(HaveOccurred())
+			uploaded <- string(data)
+			respond(w)
+		}))
+		app.uploadPossible = func(*v1.PersistentVolumeClaim) error { return nil }
+		app.urlResolver = func(namespace, name, path string) string {
+			return server.URL + path
+		}
+		proxy = httptest.NewServer(app)
+	})
+
+	AfterEach(func() {
+		proxy.Close()
+		server.Close()
+	})
+
+	dial := func(query string) *websocket.Conn {
+		url := "ws" + strings.TrimPrefix(proxy.URL, "http") + common.UploadWebsocketPath + "?" + query
+		conn, resp, err := websocket.DefaultDialer.Dial(url, nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(resp.StatusCode).To(Equal(http.StatusSwitchingProtocols))
+		return conn
+	}
+
+	upload := func(conn *websocket.Conn, messages ...string) *websocket.CloseError {
+		for _, message := range messages {
+			Expect(conn.WriteMessage(websocket.BinaryMessage, []byte(message))).To(Succeed())
+		}
+		Expect(conn.WriteMessage(websocket.TextMessage, []byte("end"))).To(Succeed())
+		_, _, err := conn.ReadMessage()
+		var closeErr *websocket.CloseError
+		Expect(errors.As(err, &closeErr)).To(BeTrue())
+		return closeErr
+	}
+
+	It("should upload the data of the binary messages synchronously", func() {
+		conn := dial(common.UploadTokenQueryParam + "=valid&" + common.UploadSHA256QueryParam + "=abcd")
+		defer conn.Close()
+		closeErr := upload(conn, "first ", "second")
+		Expect(closeErr.Code).To(Equal(websocket.CloseNormalClosure))
+
+		req := <-received
+		Expect(req.Method).To(Equal(http.MethodPost))
+		Expect(req.URL.Path).To(Equal(common.UploadPathSync))
+		Expect(req.Header.Get(common.UploadSHA256Header)).To(Equal("abcd"))
+		Expect(<-uploaded).To(Equal("first second"))
+	})
+
+	It("should close the websocket with the error of the upload server", func() {
+		respond = func(w http.ResponseWriter) {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("checksum mismatch"))
+		}
+		conn := dial(common.UploadTokenQueryParam + "=valid")
+		defer conn.Close()
+		closeErr := upload(conn, "data")
+		Expect(closeErr.Code).To(Equal(websocketCloseStatusBase + http.StatusBadRequest))
+		Expect(closeErr.Text).To(Equal("checksum mismatch"))
+	})
+
+	DescribeTable("should refuse the handshake", func(query string, statusCode int) {
+		app.tokenValidator = &validateFailure{}
+		url := "ws" + strings.TrimPrefix(proxy.URL, "http") + common.UploadWebsocketPath + "?" + query
+		_, resp, err := websocket.DefaultDialer.Dial(url, nil)
+		Expect(err).To(MatchError(websocket.ErrBadHandshake))
+		Expect(resp.StatusCode).To(Equal(statusCode))
+		Expect(received).ToNot(Receive())
+	},
+		Entry("without token", "", http.StatusBadRequest),
+		Entry("with an invalid token", common.UploadTokenQueryParam+"=invalid", http.StatusUnauthorized),
+	)
+})
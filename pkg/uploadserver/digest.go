@@ -0,0 +1,128 @@
+/*
+Copyright 2024 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package uploadserver
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"hash"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"k8s.io/klog/v2"
+
+	"kubevirt.io/containerized-data-importer/pkg/common"
+)
+
+const sha256Prefix = "sha256:"
+
+var (
+	errUploadDigestMismatch = errors.New(common.ChecksumMismatchText)
+	errInvalidUploadDigest  = errors.New("invalid sha256 digest")
+)
+
+// uploadDigest computes the sha256 digest of the data of an upload, checked against the one the client expects
+type uploadDigest struct {
+	hash.Hash
+	expected []byte
+	// the request sending the expected digest in a trailer, received after its body
+	trailer *http.Request
+}
+
+// parseUploadDigest returns the digest of an upload expecting the digest in value, nil if value is empty. The digest is
+// hex encoded, optionally prefixed with "sha256:".
+func parseUploadDigest(value string) (*uploadDigest, error) {
+	if value == "" {
+		return nil, nil
+	}
+	expected, err := hex.DecodeString(strings.TrimPrefix(value, sha256Prefix))
+	if err != nil || len(expected) != sha256.Size {
+		return nil, errors.Wrapf(errInvalidUploadDigest, "%q", value)
+	}
+	return &uploadDigest{Hash: sha256.New(), expected: expected}, nil
+}
+
+// requestUploadDigest returns the digest of the upload sent by r if the client sends the digest it expects in a header
+// or a trailer, nil otherwise
+func requestUploadDigest(r *http.Request) (*uploadDigest, error) {
+	if _, found := r.Trailer[http.CanonicalHeaderKey(common.UploadSHA256Header)]; found && r.Header.Get(common.UploadSHA256Header) == "" {
+		return &uploadDigest{Hash: sha256.New(), trailer: r}, nil
+	}
+	return parseUploadDigest(r.Header.Get(common.UploadSHA256Header))
+}
+
+// reader returns stream, hashing the data read from it
+func (d *uploadDigest) reader(stream io.ReadCloser) io.ReadCloser {
+	return &closeWrapper{
+		Reader:  io.TeeReader(stream, d),
+		closers: []io.Closer{stream},
+	}
+}
+
+// verify reads the rest of stream returned by reader, which the processing of the upload may not read to its end, and
+// checks the digest of the upload
+func (d *uploadDigest) verify(stream io.Reader) error {
+	if _, err := io.Copy(io.Discard, stream); err != nil {
+		return errors.Wrap(err, "unable to read the end of the upload")
+	}
+	if d.trailer != nil {
+		// The trailers are only received at the end of the body
+		if _, err := io.Copy(io.Discard, d.trailer.Body); err != nil {
+			return errors.Wrap(err, "unable to read the end of the upload")
+		}
+		expected, err := parseUploadDigest(d.trailer.Trailer.Get(common.UploadSHA256Header))
+		if err != nil {
+			return err
+		}
+		if expected == nil {
+			return errors.Wrapf(errInvalidUploadDigest, "%s trailer missing", common.UploadSHA256Header)
+		}
+		d.expected = expected.expected
+	}
+	return d.check()
+}
+
+// verifyFile checks the digest of the upload written to file
+func (d *uploadDigest) verifyFile(file string) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if _, err := io.Copy(d, f); err != nil {
+		return errors.Wrap(err, "unable to read the upload")
+	}
+	return d.check()
+}
+
+func (d *uploadDigest) check() error {
+	if actual := d.Sum(nil); !bytes.Equal(actual, d.expected) {
+		return errors.Wrapf(errUploadDigestMismatch, "the sha256 digest of the upload is %x, expected %x", actual, d.expected)
+	}
+	klog.Infof("The sha256 digest of the upload matches the one expected by the client")
+	return nil
+}
+
+// String returns the digest of the upload as "sha256:<hex digest>"
+func (d *uploadDigest) String() string {
+	return sha256Prefix + hex.EncodeToString(d.Sum(nil))
+}
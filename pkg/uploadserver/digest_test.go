@@ -0,0 +1,108 @@
+package uploadserver
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+	"kubevirt.io/containerized-data-importer/pkg/common"
+)
+
+var _ = Describe("Upload digest", func() {
+	const data = "some data of the upload"
+
+	var destination string
+
+	sha256Of := func(data string) string {
+		sum := sha256.Sum256([]byte(data))
+		return hex.EncodeToString(sum[:])
+	}
+
+	// writes the first bytes of the stream, the end of the data is left to the digest
+	partialProcessor := func(stream io.ReadCloser, dest, imageSize string, filesystemOverhead float64, preallocation bool, contentType string, dvContentType cdiv1.DataVolumeContentType, ovaDisk string, streaming bool, storageFormat string) (bool, error) {
+		buf := make([]byte, 4)
+		if _, err := io.ReadFull(stream, buf); err != nil {
+			return false, err
+		}
+		return false, os.WriteFile(dest, buf, 0600)
+	}
+
+	upload := func(server *uploadServerApp, header http.Header, trailer http.Header) *httptest.ResponseRecorder {
+		req, err := http.NewRequest(http.MethodPost, common.UploadPathSync, strings.NewReader(data))
+		Expect(err).ToNot(HaveOccurred())
+		for k, v := range header {
+			req.Header[k] = v
+		}
+		req.Trailer = trailer
+		rr := httptest.NewRecorder()
+		server.ServeHTTP(rr, req)
+		return rr
+	}
+
+	newDigestServer := func() *uploadServerApp {
+		server := newServer()
+		server.config.Destination = destination
+		return server
+	}
+
+	BeforeEach(func() {
+		destination = filepath.Join(GinkgoT().TempDir(), "disk.img")
+	})
+
+	DescribeTable("should check the digest sent in a", func(digest func(string) (http.Header, http.Header)) {
+		replaceProcessorFunc(partialProcessor, func() {
+			server := newDigestServer()
+			header, trailer := digest(sha256Of(data))
+			rr := upload(server, header, trailer)
+			Expect(rr.Code).To(Equal(http.StatusOK))
+			Expect(server.done).To(BeTrue())
+			Expect(server.uploadDigest).To(Equal("sha256:" + sha256Of(data)))
+			Expect(destination).To(BeAnExistingFile())
+		})
+	},
+		Entry("header", func(digest string) (http.Header, http.Header) {
+			return http.Header{http.CanonicalHeaderKey(common.UploadSHA256Header): {digest}}, nil
+		}),
+		Entry("header with the algorithm", func(digest string) (http.Header, http.Header) {
+			return http.Header{http.CanonicalHeaderKey(common.UploadSHA256Header): {"sha256:" + digest}}, nil
+		}),
+		Entry("trailer", func(digest string) (http.Header, http.Header) {
+			return nil, http.Header{http.CanonicalHeaderKey(common.UploadSHA256Header): {digest}}
+		}),
+	)
+
+	It("should fail the upload and delete its data when the digest does not match", func() {
+		replaceProcessorFunc(partialProcessor, func() {
+			server := newDigestServer()
+			rr := upload(server, http.Header{http.CanonicalHeaderKey(common.UploadSHA256Header): {sha256Of("other data")}}, nil)
+			Expect(rr.Code).To(Equal(http.StatusBadRequest))
+			Expect(rr.Body.String()).To(ContainSubstring(common.ChecksumMismatchText))
+			Expect(server.done).To(BeFalse())
+			Expect(server.uploading).To(BeFalse())
+			Expect(destination).ToNot(BeAnExistingFile())
+		})
+	})
+
+	DescribeTable("should refuse uploads with", func(header, trailer http.Header) {
+		replaceProcessorFunc(partialProcessor, func() {
+			server := newDigestServer()
+			rr := upload(server, header, trailer)
+			Expect(rr.Code).To(Equal(http.StatusBadRequest))
+			Expect(server.done).To(BeFalse())
+			Expect(server.uploading).To(BeFalse())
+		})
+	},
+		Entry("an invalid digest", http.Header{http.CanonicalHeaderKey(common.UploadSHA256Header): {"abcd"}}, nil),
+		Entry("a digest of another algorithm", http.Header{http.CanonicalHeaderKey(common.UploadSHA256Header): {"md5:" + sha256Of(data)}}, nil),
+		Entry("a missing trailer", nil, http.Header{http.CanonicalHeaderKey(common.UploadSHA256Header): nil}),
+	)
+})
@@ -0,0 +1,127 @@
+/*
+Copyright 2024 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package uploadserver
+
+import (
+	"io"
+	"os"
+	"syscall"
+
+	"github.com/pkg/errors"
+
+	"k8s.io/klog/v2"
+
+	"kubevirt.io/containerized-data-importer/pkg/common"
+	"kubevirt.io/containerized-data-importer/pkg/importer"
+)
+
+const (
+	// directIOAlignment is the alignment of the buffers, offsets and lengths of O_DIRECT writes, the largest logical
+	// block size of block devices
+	directIOAlignment = 4096
+	// directUploadBufferSize is the size of the O_DIRECT writes of direct uploads
+	directUploadBufferSize = 8 << 20
+)
+
+var errDirectUploadUnsupported = errors.New("direct upload unsupported")
+
+// directUploadProcessor writes the raw data of a direct upload as it is to the block device at dest, without scratch
+// space nor conversion
+func directUploadProcessor(stream io.ReadCloser, dest string) (bool, error) {
+	defer stream.Close()
+	if dest != common.WriteBlockPath {
+		return false, errors.Wrap(errDirectUploadUnsupported, "the destination is not a block device")
+	}
+	size, err := importer.GetAvailableSpaceBlock(dest)
+	if err != nil {
+		return false, errors.Wrap(err, "unable to get the size of the block device")
+	}
+	return false, writeDirect(stream, dest, size)
+}
+
+// writeDirect writes the data read from stream to the start of dest, a device or file of size bytes, with O_DIRECT if
+// dest supports it
+func writeDirect(stream io.Reader, dest string, size int64) error {
+	directFile, err := os.OpenFile(dest, os.O_WRONLY|syscall.O_DIRECT, 0)
+	if perr := (&os.PathError{}); errors.As(err, &perr) && errors.Is(perr, syscall.EINVAL) {
+		klog.Warningf("%s does not support direct I/O, writing the upload through the page cache", dest)
+		directFile, err = os.OpenFile(dest, os.O_WRONLY, 0)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "unable to open %s", dest)
+	}
+	defer directFile.Close()
+
+	// O_DIRECT writes need buffers aligned in memory, which anonymous mappings are
+	buf, err := syscall.Mmap(-1, 0, directUploadBufferSize, syscall.PROT_READ|syscall.PROT_WRITE, syscall.MAP_ANON|syscall.MAP_PRIVATE)
+	if err != nil {
+		return errors.Wrap(err, "unable to allocate the write buffer")
+	}
+	defer func() {
+		if err := syscall.Munmap(buf); err != nil {
+			klog.Errorf("Unable to release the write buffer: %v", err)
+		}
+	}()
+
+	var offset int64
+	for {
+		n, err := io.ReadFull(stream, buf)
+		if offset+int64(n) > size {
+			return errors.Wrapf(syscall.ENOSPC, "the upload is larger than the %d bytes of %s", size, dest)
+		}
+		if n > 0 {
+			if werr := writeAlignedAt(directFile, dest, buf[:n], offset); werr != nil {
+				return werr
+			}
+			offset += int64(n)
+		}
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			break
+		}
+		if err != nil {
+			return errors.Wrap(err, "unable to read the upload")
+		}
+	}
+
+	if err := directFile.Sync(); err != nil {
+		return errors.Wrapf(err, "unable to sync %s", dest)
+	}
+	klog.Infof("Wrote %d bytes directly to %s", offset, dest)
+	return nil
+}
+
+// writeAlignedAt writes data at offset of f, the end of the data not aligned for O_DIRECT through another descriptor
+// of dest without it. Only the last write of the upload may not be aligned.
+func writeAlignedAt(f *os.File, dest string, data []byte, offset int64) error {
+	aligned := len(data) &^ (directIOAlignment - 1)
+	if _, err := f.WriteAt(data[:aligned], offset); err != nil {
+		return errors.Wrapf(err, "unable to write to %s", dest)
+	}
+	if aligned == len(data) {
+		return nil
+	}
+
+	tail, err := os.OpenFile(dest, os.O_WRONLY, 0)
+	if err != nil {
+		return errors.Wrapf(err, "unable to open %s", dest)
+	}
+	defer tail.Close()
+	if _, err := tail.WriteAt(data[aligned:], offset+int64(aligned)); err != nil {
+		return errors.Wrapf(err, "unable to write to %s", dest)
+	}
+	return errors.Wrapf(tail.Sync(), "unable to sync %s", dest)
+}
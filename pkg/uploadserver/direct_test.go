@@ -0,0 +1,74 @@
+package uploadserver
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"kubevirt.io/containerized-data-importer/pkg/common"
+	"kubevirt.io/containerized-data-importer/pkg/importer"
+)
+
+var _ = Describe("Direct upload", func() {
+	var dest string
+
+	newData := func(length int) []byte {
+		data := make([]byte, length)
+		for i := range data {
+			data[i] = byte(i%251 + 1)
+		}
+		return data
+	}
+
+	BeforeEach(func() {
+		dest = filepath.Join(GinkgoT().TempDir(), "disk.img")
+		Expect(os.WriteFile(dest, nil, 0600)).To(Succeed())
+	})
+
+	DescribeTable("should write the data to the destination", func(length int) {
+		data := newData(length)
+		Expect(writeDirect(bytes.NewReader(data), dest, int64(length))).To(Succeed())
+		written, err := os.ReadFile(dest)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(written).To(Equal(data))
+	},
+		Entry("aligned", 2*directUploadBufferSize),
+		Entry("not aligned", directUploadBufferSize+directIOAlignment+100),
+		Entry("smaller than a block", 100),
+		Entry("empty", 0),
+	)
+
+	It("should refuse data larger than the destination", func() {
+		err := writeDirect(bytes.NewReader(newData(directIOAlignment+1)), dest, directIOAlignment)
+		Expect(importer.IsNoCapacityError(err)).To(BeTrue())
+	})
+
+	It("should refuse destinations that are not block devices", func() {
+		_, err := directUploadProcessor(io.NopCloser(strings.NewReader("data")), dest)
+		Expect(err).To(MatchError(errDirectUploadUnsupported))
+	})
+
+	DescribeTable("should refuse direct uploads", func(path string) {
+		server := newServer()
+		server.config.Destination = dest
+		req, err := http.NewRequest(http.MethodPost, path, strings.NewReader("data"))
+		Expect(err).ToNot(HaveOccurred())
+		req.Header.Set(common.UploadContentTypeHeader, common.RawDirectContentType)
+		rr := httptest.NewRecorder()
+		server.ServeHTTP(rr, req)
+		Expect(rr.Code).To(Equal(http.StatusBadRequest))
+		Expect(server.done).To(BeFalse())
+		Expect(server.uploading).To(BeFalse())
+	},
+		Entry("to filesystem volumes", common.UploadPathSync),
+		Entry("of archives", common.UploadArchivePath),
+		Entry("processed asynchronously", common.UploadPathAsync),
+	)
+})
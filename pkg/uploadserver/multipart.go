@@ -0,0 +1,367 @@
+/*
+Copyright 2024 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package uploadserver
+
+import (
+	"crypto/md5" //nolint:gosec // S3 ETags are MD5 digests, not used for security
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"k8s.io/klog/v2"
+
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+	"kubevirt.io/containerized-data-importer/pkg/common"
+)
+
+const (
+	// maxMultipartUploadParts is the largest part number of multipart uploads, the one of S3
+	maxMultipartUploadParts = 10000
+	// maxMultipartCompletionSize is the largest body of the requests completing multipart uploads
+	maxMultipartCompletionSize = 1 << 20
+)
+
+// may be overridden in tests
+var multipartUploadDir = filepath.Join(common.ScratchDataDir, "multipart")
+
+// multipartUpload is an upload whose numbered parts, of any size, are sent by concurrent PUT requests. Each part is
+// written to its own file on scratch space. Completing the upload concatenates the parts in the order listed by the
+// client to the data file, which is imported.
+type multipartUpload struct {
+	id          string
+	contentType cdiv1.DataVolumeContentType
+	// the digest of the data expected by the client, if it sent one
+	sha256 string
+	parts  map[int]multipartPart
+	// the numbers of the parts being written
+	writing map[int]bool
+}
+
+type multipartPart struct {
+	etag string
+	size int64
+}
+
+// multipartUploadHandler serves the multipart uploads created at basePath
+func (app *uploadServerApp) multipartUploadHandler(basePath string, dvContentType cdiv1.DataVolumeContentType) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !app.authorizeRequest(w, r) {
+			return
+		}
+
+		path := strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, basePath), "/")
+		id, part, hasPart := strings.Cut(path, "/")
+		switch {
+		case path == "" && r.Method == http.MethodPost:
+			app.createMultipartUpload(w, r, basePath, dvContentType)
+		case id == "" || strings.Contains(part, "/"):
+			w.WriteHeader(http.StatusNotFound)
+		case hasPart && r.Method == http.MethodPut:
+			app.putMultipartPart(w, r, id, part)
+		case !hasPart && r.Method == http.MethodPost:
+			app.completeMultipartUpload(w, r, id)
+		case !hasPart && r.Method == http.MethodDelete:
+			app.abortMultipartUpload(w, id)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}
+}
+
+func (app *uploadServerApp) createMultipartUpload(w http.ResponseWriter, r *http.Request, basePath string, dvContentType cdiv1.DataVolumeContentType) {
+	if _, err := parseUploadDigest(r.Header.Get(common.UploadSHA256Header)); err != nil {
+		klog.Warningf("Got multipart upload with %v", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	app.mutex.Lock()
+	defer app.mutex.Unlock()
+
+	if app.uploading || app.processing {
+		klog.Warning("Got multipart upload during another upload")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	if app.done {
+		klog.Warning("Got multipart upload after the upload completed")
+		w.WriteHeader(http.StatusConflict)
+		return
+	}
+
+	id, err := newUploadID()
+	if err != nil {
+		handleStreamError(w, err)
+		return
+	}
+	if app.multipartUpload != nil {
+		klog.Infof("Replacing multipart upload %s", app.multipartUpload.id)
+		app.multipartUpload = nil
+	}
+	if err := os.RemoveAll(multipartUploadDir); err != nil {
+		handleStreamError(w, errors.Wrap(err, "unable to delete previous upload"))
+		return
+	}
+	if err := os.MkdirAll(multipartUploadDir, 0750); err != nil {
+		handleStreamError(w, errors.Wrap(err, "unable to create upload directory, scratch space is required"))
+		return
+	}
+	app.multipartUpload = &multipartUpload{
+		id:          id,
+		contentType: dvContentType,
+		sha256:      r.Header.Get(common.UploadSHA256Header),
+		parts:       map[int]multipartPart{},
+		writing:     map[int]bool{},
+	}
+	app.progress.reset(0, 0)
+	klog.Infof("Created multipart upload %s", id)
+
+	w.Header().Set("Location", basePath+"/"+id)
+	w.WriteHeader(http.StatusCreated)
+}
+
+func (app *uploadServerApp) putMultipartPart(w http.ResponseWriter, r *http.Request, id, part string) {
+	number, err := strconv.Atoi(part)
+	if err != nil || number < 1 || number > maxMultipartUploadParts {
+		klog.Warningf("Got multipart upload part with invalid number %q", part)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	app.mutex.Lock()
+	upload := app.multipartUpload
+	switch {
+	case upload == nil || upload.id != id:
+		app.mutex.Unlock()
+		w.WriteHeader(http.StatusNotFound)
+		return
+	case upload.writing[number]:
+		app.mutex.Unlock()
+		w.WriteHeader(http.StatusConflict)
+		return
+	case app.uploading && len(upload.writing) == 0, app.processing, app.done:
+		app.mutex.Unlock()
+		klog.Warning("Got part of multipart upload during another upload")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	upload.writing[number] = true
+	app.uploading = true
+	app.mutex.Unlock()
+
+	file := multipartPartFile(number)
+	written, err := writeMultipartPart(r.Body, file+".tmp")
+
+	app.mutex.Lock()
+	defer app.mutex.Unlock()
+	delete(upload.writing, number)
+	app.uploading = len(upload.writing) > 0
+	if err != nil {
+		klog.Errorf("Writing part %d of multipart upload failed: %v", number, err)
+		handleStreamError(w, err)
+		return
+	}
+	if app.multipartUpload != upload {
+		// The upload was aborted or replaced while the part was written
+		os.Remove(file + ".tmp")
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if err := os.Rename(file+".tmp", file); err != nil {
+		handleStreamError(w, err)
+		return
+	}
+	// A part sent again replaces the previous one
+	app.progress.received.Add(written.size - upload.parts[number].size)
+	upload.parts[number] = written
+
+	w.Header().Set("ETag", written.etag)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (app *uploadServerApp) completeMultipartUpload(w http.ResponseWriter, r *http.Request, id string) {
+	completion := &common.UploadMultipartCompletion{}
+	if err := json.NewDecoder(io.LimitReader(r.Body, maxMultipartCompletionSize)).Decode(completion); err != nil || len(completion.Parts) == 0 {
+		klog.Warningf("Got invalid completion of multipart upload: %v", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	app.mutex.Lock()
+	upload := app.multipartUpload
+	if upload == nil || upload.id != id {
+		app.mutex.Unlock()
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if len(upload.writing) > 0 || app.processing {
+		app.mutex.Unlock()
+		klog.Warning("Got completion of multipart upload while parts are written")
+		w.WriteHeader(http.StatusConflict)
+		return
+	}
+	etag, err := upload.checkCompletion(completion)
+	if err != nil {
+		app.mutex.Unlock()
+		klog.Warningf("Refusing completion of multipart upload: %v", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	// No more parts are accepted, the upload is complete once its parts are concatenated
+	app.multipartUpload = nil
+	app.uploading = true
+	app.mutex.Unlock()
+
+	err = concatenateMultipartParts(completion.Parts)
+
+	app.mutex.Lock()
+	defer app.mutex.Unlock()
+	app.uploading = false
+	if err != nil {
+		klog.Errorf("Concatenating parts of multipart upload %s failed: %v", id, err)
+		if err := os.RemoveAll(multipartUploadDir); err != nil {
+			klog.Errorf("Unable to delete upload: %v", err)
+		}
+		app.progress.reset(0, 0)
+		handleStreamError(w, err)
+		return
+	}
+	app.progress.receivedAll.Store(true)
+	klog.Infof("Completed multipart upload %s of %d parts", id, len(completion.Parts))
+	app.processUploadedFile(multipartUploadDir, upload.contentType, upload.sha256)
+
+	w.Header().Set("ETag", etag)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (app *uploadServerApp) abortMultipartUpload(w http.ResponseWriter, id string) {
+	app.mutex.Lock()
+	defer app.mutex.Unlock()
+	if app.multipartUpload == nil || app.multipartUpload.id != id {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	klog.Infof("Aborting multipart upload %s", id)
+	// The parts being written are refused once written
+	app.multipartUpload = nil
+	if err := os.RemoveAll(multipartUploadDir); err != nil {
+		klog.Errorf("Unable to delete upload: %v", err)
+	}
+	app.progress.reset(0, 0)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// checkCompletion checks the parts of completion were received, in ascending order and with their ETags, and returns
+// the ETag of the upload computed like S3 does. Called with the mutex held.
+func (u *multipartUpload) checkCompletion(completion *common.UploadMultipartCompletion) (string, error) {
+	digests := md5.New() //nolint:gosec // S3 ETags are MD5 digests, not used for security
+	previous := 0
+	for _, part := range completion.Parts {
+		if part.PartNumber <= previous {
+			return "", errors.Errorf("part %d listed after part %d", part.PartNumber, previous)
+		}
+		previous = part.PartNumber
+		received, ok := u.parts[part.PartNumber]
+		if !ok {
+			return "", errors.Errorf("part %d not received", part.PartNumber)
+		}
+		if part.ETag != "" && strings.Trim(part.ETag, `"`) != strings.Trim(received.etag, `"`) {
+			return "", errors.Errorf("part %d has ETag %s, not %s", part.PartNumber, received.etag, part.ETag)
+		}
+		digest, err := hex.DecodeString(strings.Trim(received.etag, `"`))
+		if err != nil {
+			return "", err
+		}
+		digests.Write(digest)
+	}
+	return fmt.Sprintf(`"%x-%d"`, digests.Sum(nil), len(completion.Parts)), nil
+}
+
+// multipartPartFile returns the file of a part of the multipart upload
+func multipartPartFile(number int) string {
+	return filepath.Join(multipartUploadDir, fmt.Sprintf("part-%05d", number))
+}
+
+// writeMultipartPart writes the part read from body to file, returning its size and ETag, the MD5 digest of its data
+func writeMultipartPart(body io.Reader, file string) (multipartPart, error) {
+	f, err := os.Create(file)
+	if err != nil {
+		return multipartPart{}, err
+	}
+	digest := md5.New() //nolint:gosec // S3 ETags are MD5 digests, not used for security
+	size, err := io.Copy(io.MultiWriter(f, digest), body)
+	if err == nil {
+		err = f.Sync()
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(file)
+		return multipartPart{}, err
+	}
+	return multipartPart{etag: fmt.Sprintf(`"%x"`, digest.Sum(nil)), size: size}, nil
+}
+
+// concatenateMultipartParts appends the parts in order to the data file of the upload, deleting each part once
+// appended so the upload does not take twice its size on scratch space. The parts not listed are deleted.
+func concatenateMultipartParts(parts []common.UploadMultipartPart) error {
+	data, err := os.Create(filepath.Join(multipartUploadDir, uploadDataFile))
+	if err != nil {
+		return err
+	}
+	defer data.Close()
+	for _, part := range parts {
+		if err := appendFile(data, multipartPartFile(part.PartNumber)); err != nil {
+			return errors.Wrapf(err, "unable to append part %d", part.PartNumber)
+		}
+	}
+	if err := data.Sync(); err != nil {
+		return err
+	}
+
+	unlisted, err := filepath.Glob(filepath.Join(multipartUploadDir, "part-*"))
+	if err != nil {
+		return err
+	}
+	for _, file := range unlisted {
+		if err := os.Remove(file); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func appendFile(dst *os.File, file string) error {
+	src, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	if _, err := io.Copy(dst, src); err != nil {
+		return err
+	}
+	return os.Remove(file)
+}
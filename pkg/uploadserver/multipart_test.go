@@ -0,0 +1,182 @@
+package uploadserver
+
+import (
+	"bytes"
+	"crypto/md5" //nolint:gosec // S3 ETags are MD5 digests
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+	"kubevirt.io/containerized-data-importer/pkg/common"
+)
+
+var _ = Describe("Multipart upload", func() {
+	var (
+		origMultipartUploadDir string
+		origFileProcessorFunc  func(string, string, string, float64, bool, cdiv1.DataVolumeContentType) (bool, error)
+		processed              chan []byte
+		processedContentType   cdiv1.DataVolumeContentType
+	)
+
+	BeforeEach(func() {
+		origMultipartUploadDir = multipartUploadDir
+		origFileProcessorFunc = uploadFileProcessorFunc
+		multipartUploadDir = filepath.Join(GinkgoT().TempDir(), "multipart")
+		processed = make(chan []byte, 1)
+		processedContentType = ""
+		uploadFileProcessorFunc = func(file, dest, imageSize string, filesystemOverhead float64, preallocation bool, dvContentType cdiv1.DataVolumeContentType) (bool, error) {
+			data, err := os.ReadFile(file)
+			Expect(err).ToNot(HaveOccurred())
+			processedContentType = dvContentType
+			processed <- data
+			return false, nil
+		}
+	})
+
+	AfterEach(func() {
+		multipartUploadDir = origMultipartUploadDir
+		uploadFileProcessorFunc = origFileProcessorFunc
+	})
+
+	createUpload := func(server *uploadServerApp, path string) string {
+		req, err := http.NewRequest(http.MethodPost, path, nil)
+		Expect(err).ToNot(HaveOccurred())
+		rr := httptest.NewRecorder()
+		server.ServeHTTP(rr, req)
+		Expect(rr.Code).To(Equal(http.StatusCreated))
+		location := rr.Header().Get("Location")
+		Expect(location).To(HavePrefix(path + "/"))
+		return location
+	}
+
+	putPart := func(server *uploadServerApp, location string, number int, data []byte) *httptest.ResponseRecorder {
+		req, err := http.NewRequest(http.MethodPut, location+"/"+strconv.Itoa(number), bytes.NewReader(data))
+		Expect(err).ToNot(HaveOccurred())
+		rr := httptest.NewRecorder()
+		server.ServeHTTP(rr, req)
+		return rr
+	}
+
+	complete := func(server *uploadServerApp, location string, parts ...common.UploadMultipartPart) *httptest.ResponseRecorder {
+		body, err := json.Marshal(&common.UploadMultipartCompletion{Parts: parts})
+		Expect(err).ToNot(HaveOccurred())
+		req, err := http.NewRequest(http.MethodPost, location, bytes.NewReader(body))
+		Expect(err).ToNot(HaveOccurred())
+		rr := httptest.NewRecorder()
+		server.ServeHTTP(rr, req)
+		return rr
+	}
+
+	etagOf := func(data []byte) string {
+		return fmt.Sprintf(`"%x"`, md5.Sum(data)) //nolint:gosec // S3 ETags are MD5 digests
+	}
+
+	It("should concatenate the parts sent concurrently in order and import the data", func() {
+		server := newServer()
+		location := createUpload(server, common.UploadMultipartPath)
+		parts := [][]byte{[]byte("first part "), []byte("second part "), []byte("last")}
+
+		var wg sync.WaitGroup
+		for i, part := range parts {
+			wg.Add(1)
+			go func() {
+				defer GinkgoRecover()
+				defer wg.Done()
+				rr := putPart(server, location, i+1, part)
+				Expect(rr.Code).To(Equal(http.StatusNoContent))
+				Expect(rr.Header().Get("ETag")).To(Equal(etagOf(part)))
+			}()
+		}
+		wg.Wait()
+		Expect(server.progress.received.Load()).To(BeEquivalentTo(len("first part second part last")))
+
+		digests := md5.New() //nolint:gosec // S3 ETags are MD5 digests
+		for _, part := range parts {
+			sum := md5.Sum(part) //nolint:gosec // S3 ETags are MD5 digests
+			digests.Write(sum[:])
+		}
+		rr := complete(server, location,
+			common.UploadMultipartPart{PartNumber: 1, ETag: etagOf(parts[0])},
+			common.UploadMultipartPart{PartNumber: 2},
+			common.UploadMultipartPart{PartNumber: 3, ETag: etagOf(parts[2])})
+		Expect(rr.Code).To(Equal(http.StatusNoContent))
+		Expect(rr.Header().Get("ETag")).To(Equal(fmt.Sprintf(`"%x-3"`, digests.Sum(nil))))
+
+		Eventually(processed).Should(Receive(Equal([]byte("first part second part last"))))
+		Eventually(server.doneChan).Should(BeClosed())
+		Expect(processedContentType).To(Equal(cdiv1.DataVolumeKubeVirt))
+	})
+
+	It("should import the parts listed, replacing the parts sent again", func() {
+		server := newServer()
+		location := createUpload(server, common.UploadMultipartArchivePath)
+		Expect(putPart(server, location, 1, []byte("data")).Code).To(Equal(http.StatusNoContent))
+		Expect(putPart(server, location, 2, []byte("unlisted")).Code).To(Equal(http.StatusNoContent))
+		Expect(putPart(server, location, 3, []byte("old")).Code).To(Equal(http.StatusNoContent))
+		Expect(putPart(server, location, 3, []byte(" again")).Code).To(Equal(http.StatusNoContent))
+		Expect(server.progress.received.Load()).To(BeEquivalentTo(len("dataunlisted again")))
+
+		Expect(complete(server, location, common.UploadMultipartPart{PartNumber: 1}, common.UploadMultipartPart{PartNumber: 3}).Code).To(Equal(http.StatusNoContent))
+		Eventually(processed).Should(Receive(Equal([]byte("data again"))))
+		Expect(processedContentType).To(Equal(cdiv1.DataVolumeArchive))
+		Expect(filepath.Glob(filepath.Join(multipartUploadDir, "part-*"))).To(BeEmpty())
+	})
+
+	DescribeTable("should refuse completions", func(parts ...common.UploadMultipartPart) {
+		server := newServer()
+		location := createUpload(server, common.UploadMultipartPath)
+		Expect(putPart(server, location, 1, []byte("data")).Code).To(Equal(http.StatusNoContent))
+		Expect(putPart(server, location, 2, []byte("data")).Code).To(Equal(http.StatusNoContent))
+		Expect(complete(server, location, parts...).Code).To(Equal(http.StatusBadRequest))
+		Expect(processed).ToNot(Receive())
+		Expect(server.multipartUpload).ToNot(BeNil())
+	},
+		Entry("without parts"),
+		Entry("of parts not received", common.UploadMultipartPart{PartNumber: 1}, common.UploadMultipartPart{PartNumber: 4}),
+		Entry("of parts out of order", common.UploadMultipartPart{PartNumber: 2}, common.UploadMultipartPart{PartNumber: 1}),
+		Entry("of parts with another ETag", common.UploadMultipartPart{PartNumber: 1, ETag: `"abcd"`}),
+	)
+
+	DescribeTable("should refuse parts numbered", func(number int) {
+		server := newServer()
+		location := createUpload(server, common.UploadMultipartPath)
+		Expect(putPart(server, location, number, []byte("data")).Code).To(Equal(http.StatusBadRequest))
+	},
+		Entry("0", 0),
+		Entry("beyond the last part", maxMultipartUploadParts+1),
+	)
+
+	It("should abort the upload", func() {
+		server := newServer()
+		location := createUpload(server, common.UploadMultipartPath)
+		Expect(putPart(server, location, 1, []byte("data")).Code).To(Equal(http.StatusNoContent))
+
+		req, err := http.NewRequest(http.MethodDelete, location, nil)
+		Expect(err).ToNot(HaveOccurred())
+		rr := httptest.NewRecorder()
+		server.ServeHTTP(rr, req)
+		Expect(rr.Code).To(Equal(http.StatusNoContent))
+		Expect(multipartUploadDir).ToNot(BeADirectory())
+		Expect(putPart(server, location, 2, []byte("data")).Code).To(Equal(http.StatusNotFound))
+		Expect(complete(server, location, common.UploadMultipartPart{PartNumber: 1}).Code).To(Equal(http.StatusNotFound))
+	})
+
+	It("should refuse uploads during another upload", func() {
+		server := newServer()
+		server.uploading = true
+		req, err := http.NewRequest(http.MethodPost, common.UploadMultipartPath, nil)
+		Expect(err).ToNot(HaveOccurred())
+		rr := httptest.NewRecorder()
+		server.ServeHTTP(rr, req)
+		Expect(rr.Code).To(Equal(http.StatusServiceUnavailable))
+	})
+})
@@ -0,0 +1,278 @@
+/*
+Copyright 2024 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package uploadserver
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"k8s.io/klog/v2"
+
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+	"kubevirt.io/containerized-data-importer/pkg/common"
+	"kubevirt.io/containerized-data-importer/pkg/importer"
+)
+
+const (
+	// uploadPartSizeHeader is the size of the parts of a parallel upload requested by the client, and the one
+	// negotiated by the server
+	uploadPartSizeHeader = "Upload-Part-Size"
+
+	defaultUploadPartSize = 64 << 20
+	minUploadPartSize     = 1 << 20
+	maxUploadPartSize     = 1 << 30
+)
+
+// may be overridden in tests
+var parallelUploadDir = filepath.Join(common.ScratchDataDir, "parallel")
+
+type partState int
+
+const (
+	partMissing partState = iota
+	partWriting
+	partReceived
+)
+
+// parallelUpload is an upload whose parts, the byte ranges of the data starting at multiples of the part size, are
+// sent by concurrent PUT requests. The parts are written at their offset in the data file on scratch space, which is
+// imported once all of them are received.
+type parallelUpload struct {
+	id          string
+	length      int64
+	partSize    int64
+	contentType cdiv1.DataVolumeContentType
+	// the digest of the data expected by the client, if it sent one
+	sha256   string
+	file     *os.File
+	parts    []partState
+	received int
+	// number of PUT requests writing parts
+	writing int
+}
+
+// parallelUploadHandler serves the parallel uploads created at basePath
+func (app *uploadServerApp) parallelUploadHandler(basePath string, dvContentType cdiv1.DataVolumeContentType) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !app.authorizeRequest(w, r) {
+			return
+		}
+
+		id := strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, basePath), "/")
+		switch {
+		case id == "" && r.Method == http.MethodPost:
+			app.createParallelUpload(w, r, basePath, dvContentType)
+		case id == "" || strings.Contains(id, "/"):
+			w.WriteHeader(http.StatusNotFound)
+		case r.Method == http.MethodPut:
+			app.putUploadPart(w, r, id)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}
+}
+
+func (app *uploadServerApp) createParallelUpload(w http.ResponseWriter, r *http.Request, basePath string, dvContentType cdiv1.DataVolumeContentType) {
+	length, err := strconv.ParseInt(r.Header.Get(uploadLengthHeader), 10, 64)
+	if err != nil || length <= 0 {
+		klog.Warningf("Got parallel upload with invalid length %q", r.Header.Get(uploadLengthHeader))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	partSize := negotiatePartSize(r.Header.Get(uploadPartSizeHeader))
+	if _, err := parseUploadDigest(r.Header.Get(common.UploadSHA256Header)); err != nil {
+		klog.Warningf("Got parallel upload with %v", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	app.mutex.Lock()
+	defer app.mutex.Unlock()
+
+	if app.uploading || app.processing {
+		klog.Warning("Got parallel upload during another upload")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	if app.done {
+		klog.Warning("Got parallel upload after the upload completed")
+		w.WriteHeader(http.StatusConflict)
+		return
+	}
+
+	id, err := newUploadID()
+	if err != nil {
+		handleStreamError(w, err)
+		return
+	}
+	if app.parallelUpload != nil {
+		klog.Infof("Replacing parallel upload %s", app.parallelUpload.id)
+		app.parallelUpload.file.Close()
+		app.parallelUpload = nil
+	}
+	if err := os.RemoveAll(parallelUploadDir); err != nil {
+		handleStreamError(w, errors.Wrap(err, "unable to delete previous upload"))
+		return
+	}
+	if err := os.MkdirAll(parallelUploadDir, 0750); err != nil {
+		handleStreamError(w, errors.Wrap(err, "unable to create upload directory, scratch space is required"))
+		return
+	}
+	available, err := importer.GetAvailableSpace(parallelUploadDir)
+	if err != nil {
+		handleStreamError(w, err)
+		return
+	}
+	if length > available {
+		klog.Warningf("Got parallel upload of %d bytes, larger than the %d bytes available on scratch space", length, available)
+		w.WriteHeader(http.StatusRequestEntityTooLarge)
+		return
+	}
+	file, err := os.Create(filepath.Join(parallelUploadDir, uploadDataFile))
+	if err != nil {
+		handleStreamError(w, err)
+		return
+	}
+	if err := file.Truncate(length); err != nil {
+		file.Close()
+		handleStreamError(w, err)
+		return
+	}
+	app.parallelUpload = &parallelUpload{
+		id:          id,
+		length:      length,
+		partSize:    partSize,
+		contentType: dvContentType,
+		sha256:      r.Header.Get(common.UploadSHA256Header),
+		file:        file,
+		parts:       make([]partState, (length+partSize-1)/partSize),
+	}
+	app.progress.reset(0, length)
+	klog.Infof("Created parallel upload %s of %d bytes in %d parts of %d bytes", app.parallelUpload.id, length, len(app.parallelUpload.parts), partSize)
+
+	w.Header().Set("Location", basePath+"/"+app.parallelUpload.id)
+	w.Header().Set(uploadPartSizeHeader, strconv.FormatInt(partSize, 10))
+	w.WriteHeader(http.StatusCreated)
+}
+
+func (app *uploadServerApp) putUploadPart(w http.ResponseWriter, r *http.Request, id string) {
+	app.mutex.Lock()
+	upload := app.parallelUpload
+	if upload == nil || upload.id != id {
+		app.mutex.Unlock()
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	start, end, err := parseContentRange(r.Header.Get("Content-Range"), upload.length)
+	if err != nil {
+		app.mutex.Unlock()
+		klog.Warningf("Got invalid part of parallel upload: %v", err)
+		w.WriteHeader(http.StatusRequestedRangeNotSatisfiable)
+		return
+	}
+	part := start / upload.partSize
+	if start%upload.partSize != 0 || end+1 != min(start+upload.partSize, upload.length) {
+		app.mutex.Unlock()
+		klog.Warningf("Got part of parallel upload at bytes %d-%d, not a part of %d bytes", start, end, upload.partSize)
+		w.WriteHeader(http.StatusRequestedRangeNotSatisfiable)
+		return
+	}
+	switch {
+	case upload.parts[part] == partReceived:
+		app.mutex.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+		return
+	case upload.parts[part] == partWriting:
+		app.mutex.Unlock()
+		w.WriteHeader(http.StatusConflict)
+		return
+	case app.uploading && upload.writing == 0, app.processing, app.done:
+		app.mutex.Unlock()
+		klog.Warning("Got part of parallel upload during another upload")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	upload.parts[part] = partWriting
+	upload.writing++
+	app.uploading = true
+	app.mutex.Unlock()
+
+	_, err = io.CopyN(io.NewOffsetWriter(upload.file, start), r.Body, end-start+1)
+
+	app.mutex.Lock()
+	defer app.mutex.Unlock()
+	upload.writing--
+	app.uploading = upload.writing > 0
+	if err != nil {
+		upload.parts[part] = partMissing
+		klog.Errorf("Writing part %d of parallel upload failed: %v", part, err)
+		if errors.Is(err, io.EOF) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		handleStreamError(w, err)
+		return
+	}
+	upload.parts[part] = partReceived
+	upload.received++
+	// Only the parts received are counted, parts failing to be written are sent again
+	app.progress.received.Add(end - start + 1)
+	if upload.received == len(upload.parts) {
+		if err := upload.file.Sync(); err != nil {
+			// The client sends the part again
+			upload.parts[part] = partMissing
+			upload.received--
+			app.progress.received.Add(-(end - start + 1))
+			handleStreamError(w, err)
+			return
+		}
+		upload.file.Close()
+		klog.Infof("Received all parts of parallel upload %s", upload.id)
+		app.parallelUpload = nil
+		app.processUploadedFile(parallelUploadDir, upload.contentType, upload.sha256)
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// negotiatePartSize returns the part size requested by the client within the supported limits, the default one if it
+// did not request any
+func negotiatePartSize(requested string) int64 {
+	size, err := strconv.ParseInt(requested, 10, 64)
+	if err != nil {
+		return defaultUploadPartSize
+	}
+	return min(max(size, minUploadPartSize), maxUploadPartSize)
+}
+
+// parseContentRange returns the first and last bytes of a "bytes first-last/length" content range
+func parseContentRange(contentRange string, length int64) (int64, int64, error) {
+	var start, end, total int64
+	if _, err := fmt.Sscanf(contentRange, "bytes %d-%d/%d", &start, &end, &total); err != nil {
+		return 0, 0, errors.Wrapf(err, "invalid content range %q", contentRange)
+	}
+	if total != length || start < 0 || end < start || end >= length {
+		return 0, 0, errors.Errorf("content range %q out of the %d bytes of the upload", contentRange, length)
+	}
+	return start, end, nil
+}
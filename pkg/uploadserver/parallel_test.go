@@ -0,0 +1,187 @@
+package uploadserver
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+	"kubevirt.io/containerized-data-importer/pkg/common"
+)
+
+var _ = Describe("Parallel upload", func() {
+	var (
+		origParallelUploadDir string
+		origFileProcessorFunc func(string, string, string, float64, bool, cdiv1.DataVolumeContentType) (bool, error)
+		processed             chan []byte
+		processedContentType  cdiv1.DataVolumeContentType
+	)
+
+	BeforeEach(func() {
+		origParallelUploadDir = parallelUploadDir
+		origFileProcessorFunc = uploadFileProcessorFunc
+		parallelUploadDir = filepath.Join(GinkgoT().TempDir(), "parallel")
+		processed = make(chan []byte, 1)
+		processedContentType = ""
+		uploadFileProcessorFunc = func(file, dest, imageSize string, filesystemOverhead float64, preallocation bool, dvContentType cdiv1.DataVolumeContentType) (bool, error) {
+			data, err := os.ReadFile(file)
+			Expect(err).ToNot(HaveOccurred())
+			processedContentType = dvContentType
+			processed <- data
+			return false, nil
+		}
+	})
+
+	AfterEach(func() {
+		parallelUploadDir = origParallelUploadDir
+		uploadFileProcessorFunc = origFileProcessorFunc
+	})
+
+	createUpload := func(server *uploadServerApp, path string, length int, partSize string) (string, int) {
+		req, err := http.NewRequest(http.MethodPost, path, nil)
+		Expect(err).ToNot(HaveOccurred())
+		req.Header.Set(uploadLengthHeader, strconv.Itoa(length))
+		req.Header.Set(uploadPartSizeHeader, partSize)
+		rr := httptest.NewRecorder()
+		server.ServeHTTP(rr, req)
+		Expect(rr.Code).To(Equal(http.StatusCreated))
+		location := rr.Header().Get("Location")
+		Expect(location).To(HavePrefix(path + "/"))
+		size, err := strconv.Atoi(rr.Header().Get(uploadPartSizeHeader))
+		Expect(err).ToNot(HaveOccurred())
+		return location, size
+	}
+
+	putPart := func(server *uploadServerApp, location, contentRange string, data []byte) int {
+		req, err := http.NewRequest(http.MethodPut, location, bytes.NewReader(data))
+		Expect(err).ToNot(HaveOccurred())
+		req.Header.Set("Content-Range", contentRange)
+		rr := httptest.NewRecorder()
+		server.ServeHTTP(rr, req)
+		return rr.Code
+	}
+
+	newData := func(length int) []byte {
+		data := make([]byte, length)
+		for i := range data {
+			data[i] = byte(i % 251)
+		}
+		return data
+	}
+
+	It("should reassemble the parts sent concurrently and import the data", func() {
+		server := newServer()
+		s := httptest.NewServer(server)
+		defer s.Close()
+		data := newData(5*minUploadPartSize + 1000)
+		location, partSize := createUpload(server, common.UploadParallelPath, len(data), "1")
+		Expect(partSize).To(Equal(minUploadPartSize))
+
+		var wg sync.WaitGroup
+		for start := 0; start < len(data); start += partSize {
+			end := min(start+partSize, len(data)) - 1
+			wg.Add(1)
+			go func() {
+				defer GinkgoRecover()
+				defer wg.Done()
+				req, err := http.NewRequest(http.MethodPut, s.URL+location, bytes.NewReader(data[start:end+1]))
+				Expect(err).ToNot(HaveOccurred())
+				req.Header.Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, end, len(data)))
+				resp, err := http.DefaultClient.Do(req)
+				Expect(err).ToNot(HaveOccurred())
+				resp.Body.Close()
+				Expect(resp.StatusCode).To(Equal(http.StatusNoContent))
+			}()
+		}
+		wg.Wait()
+
+		Eventually(processed).Should(Receive(Equal(data)))
+		Eventually(server.doneChan).Should(BeClosed())
+		Expect(processedContentType).To(Equal(cdiv1.DataVolumeKubeVirt))
+	})
+
+	It("should accept parts sent again", func() {
+		server := newServer()
+		data := newData(2 * minUploadPartSize)
+		location, partSize := createUpload(server, common.UploadParallelArchivePath, len(data), strconv.Itoa(minUploadPartSize))
+
+		contentRange := fmt.Sprintf("bytes %d-%d/%d", partSize, len(data)-1, len(data))
+		Expect(putPart(server, location, contentRange, data[partSize:len(data)-1])).To(Equal(http.StatusBadRequest))
+		Expect(putPart(server, location, contentRange, data[partSize:])).To(Equal(http.StatusNoContent))
+		Expect(putPart(server, location, contentRange, data[partSize:])).To(Equal(http.StatusNoContent))
+		Expect(processed).ToNot(Receive())
+		Expect(server.progress.received.Load()).To(BeEquivalentTo(partSize))
+
+		Expect(putPart(server, location, fmt.Sprintf("bytes 0-%d/%d", partSize-1, len(data)), data[:partSize])).To(Equal(http.StatusNoContent))
+		Eventually(processed).Should(Receive(Equal(data)))
+		Expect(processedContentType).To(Equal(cdiv1.DataVolumeArchive))
+	})
+
+	It("should record the digest of the upload", func() {
+		server := newServer()
+		data := newData(minUploadPartSize)
+		sum := sha256.Sum256(data)
+		req, err := http.NewRequest(http.MethodPost, common.UploadParallelPath, nil)
+		Expect(err).ToNot(HaveOccurred())
+		req.Header.Set(uploadLengthHeader, strconv.Itoa(len(data)))
+		req.Header.Set(common.UploadSHA256Header, hex.EncodeToString(sum[:]))
+		rr := httptest.NewRecorder()
+		server.ServeHTTP(rr, req)
+		Expect(rr.Code).To(Equal(http.StatusCreated))
+
+		location := rr.Header().Get("Location")
+		Expect(putPart(server, location, fmt.Sprintf("bytes 0-%d/%d", len(data)-1, len(data)), data)).To(Equal(http.StatusNoContent))
+		Eventually(processed).Should(Receive(Equal(data)))
+		Eventually(server.doneChan).Should(BeClosed())
+		Expect(server.uploadDigest).To(Equal("sha256:" + hex.EncodeToString(sum[:])))
+	})
+
+	DescribeTable("should refuse parts", func(contentRange string, expectedStatus int) {
+		server := newServer()
+		location, _ := createUpload(server, common.UploadParallelPath, 2*minUploadPartSize, strconv.Itoa(minUploadPartSize))
+		Expect(putPart(server, location, contentRange, []byte("data"))).To(Equal(expectedStatus))
+	},
+		Entry("without range", "", http.StatusRequestedRangeNotSatisfiable),
+		Entry("of another length", fmt.Sprintf("bytes 0-%d/%d", minUploadPartSize-1, minUploadPartSize), http.StatusRequestedRangeNotSatisfiable),
+		Entry("out of the data", fmt.Sprintf("bytes %d-%d/%d", 2*minUploadPartSize, 3*minUploadPartSize-1, 2*minUploadPartSize), http.StatusRequestedRangeNotSatisfiable),
+		Entry("not aligned on parts", fmt.Sprintf("bytes 1-%d/%d", minUploadPartSize, 2*minUploadPartSize), http.StatusRequestedRangeNotSatisfiable),
+		Entry("smaller than a part", fmt.Sprintf("bytes 0-3/%d", 2*minUploadPartSize), http.StatusRequestedRangeNotSatisfiable),
+	)
+
+	It("should refuse parts of unknown uploads", func() {
+		server := newServer()
+		createUpload(server, common.UploadParallelPath, 4, "")
+		Expect(putPart(server, common.UploadParallelPath+"/unknown", "bytes 0-3/4", []byte("data"))).To(Equal(http.StatusNotFound))
+	})
+
+	It("should refuse uploads during another upload", func() {
+		server := newServer()
+		server.uploading = true
+		req, err := http.NewRequest(http.MethodPost, common.UploadParallelPath, strings.NewReader(""))
+		Expect(err).ToNot(HaveOccurred())
+		req.Header.Set(uploadLengthHeader, "4")
+		rr := httptest.NewRecorder()
+		server.ServeHTTP(rr, req)
+		Expect(rr.Code).To(Equal(http.StatusServiceUnavailable))
+	})
+
+	DescribeTable("should negotiate the part size", func(requested string, expected int64) {
+		Expect(negotiatePartSize(requested)).To(Equal(expected))
+	},
+		Entry("defaulting it", "", int64(defaultUploadPartSize)),
+		Entry("accepting the requested one", "8388608", int64(8<<20)),
+		Entry("raising small ones", "1024", int64(minUploadPartSize)),
+		Entry("lowering large ones", "4294967296", int64(maxUploadPartSize)),
+	)
+})
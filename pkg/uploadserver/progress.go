@@ -0,0 +1,132 @@
+/*
+Copyright 2024 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package uploadserver
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"sync/atomic"
+
+	"k8s.io/klog/v2"
+
+	"kubevirt.io/containerized-data-importer/pkg/common"
+	metrics "kubevirt.io/containerized-data-importer/pkg/monitoring/metrics/cdi-importer"
+	"kubevirt.io/containerized-data-importer/pkg/util"
+)
+
+// may be overridden in tests
+var conversionProgressFunc = getConversionProgress
+
+// uploadProgress counts the bytes of the upload received by the server. The counters are updated by the requests
+// sending the data without holding the mutex.
+type uploadProgress struct {
+	received atomic.Int64
+	total    atomic.Int64
+	// set once the request sending the whole upload read it to its end
+	receivedAll atomic.Bool
+}
+
+// reset starts counting the bytes of an upload of total bytes, received bytes of which were already received
+func (p *uploadProgress) reset(received, total int64) {
+	p.received.Store(received)
+	p.total.Store(total)
+	p.receivedAll.Store(false)
+}
+
+// progressReader counts the bytes of the upload read from the body of a request
+type progressReader struct {
+	io.ReadCloser
+	progress *uploadProgress
+	// the body is the whole upload
+	whole bool
+}
+
+func (r *progressReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	r.progress.received.Add(int64(n))
+	if err == io.EOF && r.whole {
+		r.progress.receivedAll.Store(true)
+	}
+	return n, err
+}
+
+// countUploadRequest counts the bytes of the request sending the whole upload as they are read
+func (app *uploadServerApp) countUploadRequest(r *http.Request) {
+	app.progress.reset(0, max(r.ContentLength, 0))
+	r.Body = &progressReader{ReadCloser: r.Body, progress: &app.progress, whole: true}
+}
+
+// uploadProgressHandler returns the progress of the upload
+func (app *uploadServerApp) uploadProgressHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	if !app.authorizeRequest(w, r) {
+		return
+	}
+
+	app.mutex.Lock()
+	progress := &common.UploadProgress{
+		Phase:         app.uploadPhase(),
+		BytesReceived: app.progress.received.Load(),
+		BytesTotal:    app.progress.total.Load(),
+	}
+	app.mutex.Unlock()
+	if progress.Phase == common.UploadPhaseComplete {
+		progress.ConversionProgress = 100
+	} else {
+		progress.ConversionProgress = conversionProgressFunc()
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	if err := json.NewEncoder(w).Encode(progress); err != nil {
+		klog.Errorf("failed to send response; %v", err)
+	}
+}
+
+// uploadPhase returns the phase of the upload. Called with the mutex held.
+func (app *uploadServerApp) uploadPhase() common.UploadPhase {
+	switch {
+	case app.done:
+		return common.UploadPhaseComplete
+	case app.processing || app.progress.receivedAll.Load():
+		return common.UploadPhaseProcessing
+	case app.uploading || app.parallelUpload != nil || app.multipartUpload != nil || app.progress.received.Load() > 0:
+		// Resumable, parallel and multipart uploads are in progress between the requests sending their data
+		return common.UploadPhaseUploading
+	default:
+		return common.UploadPhaseWaiting
+	}
+}
+
+// getConversionProgress returns the progress of the conversion of the image, reported by qemu-img to the import
+// progress metric of the owner of the upload
+func getConversionProgress() float64 {
+	ownerUID, _ := util.ParseEnvVar(common.OwnerUID, false)
+	if ownerUID == "" {
+		return 0
+	}
+	progress, err := metrics.Progress(ownerUID).Get()
+	if err != nil {
+		klog.Errorf("Unable to read the conversion progress: %v", err)
+		return 0
+	}
+	return progress
+}
@@ -0,0 +1,117 @@
+package uploadserver
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+	"kubevirt.io/containerized-data-importer/pkg/common"
+)
+
+var _ = Describe("Upload progress", func() {
+	var origConversionProgressFunc func() float64
+
+	BeforeEach(func() {
+		origConversionProgressFunc = conversionProgressFunc
+		conversionProgressFunc = func() float64 {
+			return 42
+		}
+	})
+
+	AfterEach(func() {
+		conversionProgressFunc = origConversionProgressFunc
+	})
+
+	getProgress := func(server *uploadServerApp) *common.UploadProgress {
+		req, err := http.NewRequest(http.MethodGet, common.UploadProgressPath, nil)
+		Expect(err).ToNot(HaveOccurred())
+		rr := httptest.NewRecorder()
+		server.ServeHTTP(rr, req)
+		Expect(rr.Code).To(Equal(http.StatusOK))
+		Expect(rr.Header().Get("Content-Type")).To(Equal("application/json"))
+		progress := &common.UploadProgress{}
+		Expect(json.Unmarshal(rr.Body.Bytes(), progress)).To(Succeed())
+		return progress
+	}
+
+	It("should report the upload waiting for data", func() {
+		Expect(getProgress(newServer())).To(Equal(&common.UploadProgress{
+			Phase:              common.UploadPhaseWaiting,
+			ConversionProgress: 42,
+		}))
+	})
+
+	It("should report the bytes received and the conversion of the upload", func() {
+		read := make(chan struct{})
+		resume := make(chan struct{})
+		replaceProcessorFunc(func(stream io.ReadCloser, dest, imageSize string, filesystemOverhead float64, preallocation bool, contentType string, dvContentType cdiv1.DataVolumeContentType, ovaDisk string, streaming bool, storageFormat string) (bool, error) {
+			defer GinkgoRecover()
+			buf := make([]byte, 4)
+			_, err := io.ReadFull(stream, buf)
+			Expect(err).ToNot(HaveOccurred())
+			read <- struct{}{}
+			<-resume
+			_, err = io.ReadAll(stream)
+			Expect(err).ToNot(HaveOccurred())
+			read <- struct{}{}
+			<-resume
+			return false, nil
+		}, func() {
+			server := newServer()
+			req, err := http.NewRequest(http.MethodPost, common.UploadPathSync, strings.NewReader("some data"))
+			Expect(err).ToNot(HaveOccurred())
+			done := make(chan int)
+			go func() {
+				rr := httptest.NewRecorder()
+				server.ServeHTTP(rr, req)
+				done <- rr.Code
+			}()
+
+			Eventually(read).Should(Receive())
+			Expect(getProgress(server)).To(Equal(&common.UploadProgress{
+				Phase:              common.UploadPhaseUploading,
+				BytesReceived:      4,
+				BytesTotal:         9,
+				ConversionProgress: 42,
+			}))
+			resume <- struct{}{}
+
+			Eventually(read).Should(Receive())
+			progress := getProgress(server)
+			Expect(progress.Phase).To(Equal(common.UploadPhaseProcessing))
+			Expect(progress.BytesReceived).To(BeEquivalentTo(9))
+			resume <- struct{}{}
+
+			Eventually(done).Should(Receive(Equal(http.StatusOK)))
+			progress = getProgress(server)
+			Expect(progress.Phase).To(Equal(common.UploadPhaseComplete))
+			Expect(progress.ConversionProgress).To(BeEquivalentTo(100))
+		})
+	})
+
+	It("should forget the bytes of failed uploads", func() {
+		withProcessorFailure(func() {
+			server := newServer()
+			req, err := http.NewRequest(http.MethodPost, common.UploadPathSync, strings.NewReader("data"))
+			Expect(err).ToNot(HaveOccurred())
+			rr := httptest.NewRecorder()
+			server.ServeHTTP(rr, req)
+			Expect(rr.Code).To(Equal(http.StatusInternalServerError))
+			Expect(getProgress(server).Phase).To(Equal(common.UploadPhaseWaiting))
+		})
+	})
+
+	It("should only accept GET requests", func() {
+		req, err := http.NewRequest(http.MethodPost, common.UploadProgressPath, nil)
+		Expect(err).ToNot(HaveOccurred())
+		rr := httptest.NewRecorder()
+		newServer().ServeHTTP(rr, req)
+		Expect(rr.Code).To(Equal(http.StatusMethodNotAllowed))
+	})
+})
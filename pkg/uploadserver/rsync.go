@@ -0,0 +1,163 @@
+/*
+Copyright 2026 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package uploadserver
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os/exec"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"k8s.io/klog/v2"
+
+	"kubevirt.io/containerized-data-importer/pkg/common"
+)
+
+const rsyncPath = "/usr/bin/rsync"
+
+var (
+	errInvalidRsyncArgs = errors.New("invalid rsync arguments")
+
+	// rsyncServerOptions are the long options of the rsync receiver the clone source may pass, the others could make it
+	// read or write outside of the target volume
+	rsyncServerOptions = map[string]bool{
+		"--server":        true,
+		"--delete":        true,
+		"--delete-before": true,
+		"--delete-during": true,
+		"--delete-delay":  true,
+		"--delete-after":  true,
+		"--inplace":       true,
+		"--preallocate":   true,
+		"--sparse":        true,
+		"--numeric-ids":   true,
+	}
+
+	// rsyncShortOptions matches the short options rsync packs in a single argument, followed by the capabilities of its
+	// protocol after "e"
+	rsyncShortOptions = regexp.MustCompile(`^-[rlptgoDSvWucIxHAXz]*(e[.0-9a-zA-Z]*)?$`)
+)
+
+// rsyncServerArgs returns the arguments of the rsync receiver from the JSON encoded command of the remote rsync the clone
+// source sent. Its options are checked, and its paths replaced with the current directory.
+func rsyncServerArgs(command string) ([]string, error) {
+	var args []string
+	if err := json.Unmarshal([]byte(command), &args); err != nil {
+		return nil, errors.Wrap(errInvalidRsyncArgs, err.Error())
+	}
+	if len(args) < 2 || args[0] != "rsync" || args[1] != "--server" {
+		return nil, errors.Wrapf(errInvalidRsyncArgs, "expected an rsync server command, got %q", args)
+	}
+	var options []string
+	for _, arg := range args[1:] {
+		if !strings.HasPrefix(arg, "-") {
+			continue
+		}
+		if !rsyncServerOptions[arg] && !rsyncShortOptions.MatchString(arg) {
+			return nil, errors.Wrapf(errInvalidRsyncArgs, "option %q is not allowed", arg)
+		}
+		options = append(options, arg)
+	}
+	return append(options, ".", "."), nil
+}
+
+// processRsyncClone runs the rsync receiver of a filesystem clone in the target volume, on the connection of the
+// request upgraded to the rsync protocol
+func (app *uploadServerApp) processRsyncClone(w http.ResponseWriter, r *http.Request) {
+	if !app.validateShouldHandleRequest(w, r) {
+		return
+	}
+
+	err := app.rsyncClone(w, r)
+
+	app.mutex.Lock()
+	defer app.mutex.Unlock()
+	app.uploading = false
+
+	if err != nil {
+		klog.Errorf("Rsync clone failed: %v", err)
+		return
+	}
+
+	app.done = true
+	app.preallocationApplied = true
+	app.cloneTarget = true
+	close(app.doneChan)
+	klog.Infof("Synchronized data to %s", common.ImporterVolumePath)
+}
+
+func (app *uploadServerApp) rsyncClone(w http.ResponseWriter, r *http.Request) error {
+	if app.config.Destination == common.WriteBlockPath {
+		w.WriteHeader(http.StatusBadRequest)
+		return errors.New("rsync clones need a filesystem target")
+	}
+	if !strings.EqualFold(r.Header.Get("Upgrade"), common.RsyncUpgradeProtocol) {
+		w.WriteHeader(http.StatusBadRequest)
+		return errors.Errorf("expected an upgrade to %s", common.RsyncUpgradeProtocol)
+	}
+	args, err := rsyncServerArgs(r.Header.Get(common.RsyncArgsHeader))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return err
+	}
+
+	conn, rw, err := http.NewResponseController(w).Hijack()
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return err
+	}
+	defer conn.Close()
+	// the transfer of large volumes outlasts the timeouts of the server
+	if err := conn.SetDeadline(time.Time{}); err != nil {
+		return err
+	}
+	if _, err := rw.WriteString("HTTP/1.1 101 Switching Protocols\r\nConnection: Upgrade\r\nUpgrade: " + common.RsyncUpgradeProtocol + "\r\n\r\n"); err != nil {
+		return err
+	}
+	if err := rw.Flush(); err != nil {
+		return err
+	}
+
+	klog.Infof("Executing %s %+v", rsyncPath, args)
+	cmd := exec.Command(rsyncPath, args...)
+	cmd.Dir = common.ImporterVolumePath
+	cmd.Stdout = conn
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	// the client may have sent the start of the protocol with the request, buffered in rw
+	go func() {
+		_, _ = io.Copy(stdin, rw)
+		stdin.Close()
+	}()
+	if err := cmd.Wait(); err != nil {
+		return errors.Wrapf(err, "rsync failed: %s", stderr.String())
+	}
+	return nil
+}
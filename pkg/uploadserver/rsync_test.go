@@ -0,0 +1,55 @@
+package uploadserver
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"kubevirt.io/containerized-data-importer/pkg/common"
+)
+
+var _ = Describe("Rsync clone", func() {
+	It("should keep the options of the rsync receiver and replace its paths", func() {
+		args, err := rsyncServerArgs(`["rsync","--server","-rlptSe.iLsfxCIvu","--delete-during","--inplace",".","/etc"]`)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(args).To(Equal([]string{"--server", "-rlptSe.iLsfxCIvu", "--delete-during", "--inplace", ".", "."}))
+	})
+
+	DescribeTable("should refuse the commands", func(command string) {
+		_, err := rsyncServerArgs(command)
+		Expect(err).To(MatchError(errInvalidRsyncArgs))
+	},
+		Entry("not encoded in JSON", "rsync --server . ."),
+		Entry("of other binaries", `["sh","-c","id"]`),
+		Entry("of rsync senders", `["rsync","--server","--sender","-rlpte.iLsfxCIvu",".","."]`),
+		Entry("with options writing outside of the target", `["rsync","--server","-rlpte.iLsfxCIvu","--log-file=/tmp/log",".","."]`),
+		Entry("with short options not allowed", `["rsync","--server","-rlptTe.iLsfxCIvu",".","."]`),
+	)
+
+	DescribeTable("should refuse the requests", func(destination string, setHeaders func(*http.Request)) {
+		server := newServer()
+		server.config.Destination = destination
+		req, err := http.NewRequest(http.MethodPost, common.UploadPathSync, nil)
+		Expect(err).ToNot(HaveOccurred())
+		req.Header.Set(common.UploadContentTypeHeader, common.FilesystemRsyncCloneContentType)
+		req.Header.Set("Connection", "Upgrade")
+		req.Header.Set("Upgrade", common.RsyncUpgradeProtocol)
+		req.Header.Set(common.RsyncArgsHeader, `["rsync","--server","-rlpte.iLsfxCIvu",".","."]`)
+		setHeaders(req)
+		rr := httptest.NewRecorder()
+		server.ServeHTTP(rr, req)
+		Expect(rr.Code).To(Equal(http.StatusBadRequest))
+		Expect(server.done).To(BeFalse())
+		Expect(server.uploading).To(BeFalse())
+	},
+		Entry("to block volumes", common.WriteBlockPath, func(*http.Request) {}),
+		Entry("not upgraded to rsync", common.ImporterWritePath, func(req *http.Request) {
+			req.Header.Del("Upgrade")
+		}),
+		Entry("with invalid arguments", common.ImporterWritePath, func(req *http.Request) {
+			req.Header.Set(common.RsyncArgsHeader, `["rsync","--server","--sender",".","."]`)
+		}),
+	)
+})
@@ -0,0 +1,389 @@
+/*
+Copyright 2024 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package uploadserver
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"k8s.io/klog/v2"
+
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+	"kubevirt.io/containerized-data-importer/pkg/common"
+	"kubevirt.io/containerized-data-importer/pkg/importer"
+)
+
+const (
+	tusVersion           = "1.0.0"
+	tusExtensions        = "creation,termination"
+	tusOffsetContentType = "application/offset+octet-stream"
+
+	tusResumableHeader    = "Tus-Resumable"
+	tusVersionHeader      = "Tus-Version"
+	tusExtensionHeader    = "Tus-Extension"
+	tusUploadOffsetHeader = "Upload-Offset"
+	tusDeferLengthHeader  = "Upload-Defer-Length"
+
+	tusInfoFile = "info.json"
+)
+
+// may be overridden in tests
+var tusUploadDir = filepath.Join(common.ScratchDataDir, "tus")
+
+// tusUpload is a resumable upload of the tus protocol, persisted on scratch space with its data so that the upload
+// survives restarts of the upload server. The offset of the upload is the size of its data file, which is synced
+// before the offset is returned to the client.
+type tusUpload struct {
+	ID          string                      `json:"id"`
+	Length      int64                       `json:"length"`
+	ContentType cdiv1.DataVolumeContentType `json:"contentType"`
+	// SHA256 is the digest of the data expected by the client, if it sent one
+	SHA256 string `json:"sha256,omitempty"`
+}
+
+// tusPatch is a PATCH request writing the data of the tus upload
+type tusPatch struct {
+	controller *http.ResponseController
+	done       chan struct{}
+}
+
+// tusHandler serves the tus uploads created at basePath. The server holds a single upload at a time, the one of its
+// PVC, and imports it once all its data is written to scratch space.
+func (app *uploadServerApp) tusHandler(basePath string, dvContentType cdiv1.DataVolumeContentType) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(tusResumableHeader, tusVersion)
+		if r.Method == http.MethodOptions {
+			w.Header().Set(tusVersionHeader, tusVersion)
+			w.Header().Set(tusExtensionHeader, tusExtensions)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		if !app.authorizeRequest(w, r) {
+			return
+		}
+
+		if r.Header.Get(tusResumableHeader) != tusVersion {
+			w.Header().Set(tusVersionHeader, tusVersion)
+			w.WriteHeader(http.StatusPreconditionFailed)
+			return
+		}
+
+		id := strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, basePath), "/")
+		switch {
+		case id == "" && r.Method == http.MethodPost:
+			app.createTusUpload(w, r, basePath, dvContentType)
+		case id == "" || strings.Contains(id, "/"):
+			w.WriteHeader(http.StatusNotFound)
+		case r.Method == http.MethodHead:
+			app.headTusUpload(w, id)
+		case r.Method == http.MethodPatch:
+			app.patchTusUpload(w, r, id)
+		case r.Method == http.MethodDelete:
+			app.deleteTusUpload(w, id)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}
+}
+
+func (app *uploadServerApp) createTusUpload(w http.ResponseWriter, r *http.Request, basePath string, dvContentType cdiv1.DataVolumeContentType) {
+	if r.Header.Get(tusDeferLengthHeader) != "" {
+		klog.Warning("Got tus upload with deferred length")
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	length, err := strconv.ParseInt(r.Header.Get(uploadLengthHeader), 10, 64)
+	if err != nil || length <= 0 {
+		klog.Warningf("Got tus upload with invalid length %q", r.Header.Get(uploadLengthHeader))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	if _, err := parseUploadDigest(r.Header.Get(common.UploadSHA256Header)); err != nil {
+		klog.Warningf("Got tus upload with %v", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	app.mutex.Lock()
+	defer app.mutex.Unlock()
+
+	if !app.acquireTusUpload(w) {
+		return
+	}
+	if app.processing || app.done {
+		klog.Warning("Got tus upload after the upload completed")
+		w.WriteHeader(http.StatusConflict)
+		return
+	}
+
+	id, err := newUploadID()
+	if err != nil {
+		handleStreamError(w, err)
+		return
+	}
+	upload := &tusUpload{
+		ID:          id,
+		Length:      length,
+		ContentType: dvContentType,
+		SHA256:      r.Header.Get(common.UploadSHA256Header),
+	}
+	if err := os.RemoveAll(tusUploadDir); err != nil {
+		handleStreamError(w, errors.Wrap(err, "unable to delete previous upload"))
+		return
+	}
+	if err := os.MkdirAll(tusUploadDir, 0750); err != nil {
+		handleStreamError(w, errors.Wrap(err, "unable to create upload directory, scratch space is required"))
+		return
+	}
+	available, err := importer.GetAvailableSpace(tusUploadDir)
+	if err != nil {
+		handleStreamError(w, err)
+		return
+	}
+	if length > available {
+		klog.Warningf("Got tus upload of %d bytes, larger than the %d bytes available on scratch space", length, available)
+		w.WriteHeader(http.StatusRequestEntityTooLarge)
+		return
+	}
+	if err := createTusUploadFiles(upload); err != nil {
+		handleStreamError(w, err)
+		return
+	}
+	app.progress.reset(0, upload.Length)
+	klog.Infof("Created tus upload %s of %d bytes", upload.ID, upload.Length)
+
+	w.Header().Set("Location", basePath+"/"+upload.ID)
+	w.WriteHeader(http.StatusCreated)
+}
+
+func (app *uploadServerApp) headTusUpload(w http.ResponseWriter, id string) {
+	app.mutex.Lock()
+	defer app.mutex.Unlock()
+
+	if !app.acquireTusUpload(w) {
+		return
+	}
+	upload, offset, ok := getTusUpload(w, id)
+	if !ok {
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set(tusUploadOffsetHeader, strconv.FormatInt(offset, 10))
+	w.Header().Set(uploadLengthHeader, strconv.FormatInt(upload.Length, 10))
+	w.WriteHeader(http.StatusOK)
+}
+
+func (app *uploadServerApp) patchTusUpload(w http.ResponseWriter, r *http.Request, id string) {
+	if r.Header.Get("Content-Type") != tusOffsetContentType {
+		w.WriteHeader(http.StatusUnsupportedMediaType)
+		return
+	}
+	offset, err := strconv.ParseInt(r.Header.Get(tusUploadOffsetHeader), 10, 64)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	app.mutex.Lock()
+	if !app.acquireTusUpload(w) {
+		app.mutex.Unlock()
+		return
+	}
+	upload, size, ok := getTusUpload(w, id)
+	if !ok {
+		app.mutex.Unlock()
+		return
+	}
+	if offset != size {
+		app.mutex.Unlock()
+		klog.Warningf("Got tus upload data at offset %d, expected %d", offset, size)
+		w.WriteHeader(http.StatusConflict)
+		return
+	}
+	if size == upload.Length {
+		app.mutex.Unlock()
+		w.Header().Set(tusUploadOffsetHeader, strconv.FormatInt(size, 10))
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	patch := &tusPatch{
+		controller: http.NewResponseController(w),
+		done:       make(chan struct{}),
+	}
+	app.tusPatch = patch
+	app.uploading = true
+	app.mutex.Unlock()
+
+	written, err := writeTusUploadData(&progressReader{ReadCloser: r.Body, progress: &app.progress}, upload.Length-offset)
+	offset += written
+
+	app.mutex.Lock()
+	app.tusPatch = nil
+	app.uploading = false
+	close(patch.done)
+	if offset == upload.Length {
+		app.processUploadedFile(tusUploadDir, upload.ContentType, upload.SHA256)
+	}
+	app.mutex.Unlock()
+
+	w.Header().Set(tusUploadOffsetHeader, strconv.FormatInt(offset, 10))
+	if err != nil {
+		klog.Errorf("Writing the tus upload data stopped at offset %d: %v", offset, err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (app *uploadServerApp) deleteTusUpload(w http.ResponseWriter, id string) {
+	app.mutex.Lock()
+	defer app.mutex.Unlock()
+
+	if !app.acquireTusUpload(w) {
+		return
+	}
+	if app.processing || app.done {
+		w.WriteHeader(http.StatusConflict)
+		return
+	}
+	if _, _, ok := getTusUpload(w, id); !ok {
+		return
+	}
+	if err := os.RemoveAll(tusUploadDir); err != nil {
+		handleStreamError(w, err)
+		return
+	}
+	app.progress.reset(0, 0)
+	klog.Infof("Deleted tus upload %s", id)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// acquireTusUpload makes sure no other request writes data, stopping the tus PATCH request in flight if any. Clients
+// only send another request while their PATCH request is in flight when they lost its connection, which the server
+// would not notice before its TCP keepalives fail. Called with the mutex held.
+func (app *uploadServerApp) acquireTusUpload(w http.ResponseWriter) bool {
+	for app.tusPatch != nil {
+		patch := app.tusPatch
+		if err := patch.controller.SetReadDeadline(time.Now()); err != nil {
+			klog.Warningf("Unable to stop the tus upload request in flight: %v", err)
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return false
+		}
+		klog.Info("Stopping the tus upload request in flight")
+		app.mutex.Unlock()
+		<-patch.done
+		app.mutex.Lock()
+	}
+	if app.uploading {
+		klog.Warning("Got tus upload request during another upload")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return false
+	}
+	return true
+}
+
+// resumeTusUpload imports the tus upload completed before the server restarted, its client is done sending it
+func (app *uploadServerApp) resumeTusUpload() {
+	upload, size, err := readTusUpload()
+	if err != nil {
+		if !os.IsNotExist(err) {
+			klog.Errorf("Unable to read tus upload: %v", err)
+		}
+		return
+	}
+	klog.Infof("Found tus upload %s at offset %d of %d", upload.ID, size, upload.Length)
+	app.progress.reset(size, upload.Length)
+	if size == upload.Length {
+		app.mutex.Lock()
+		defer app.mutex.Unlock()
+		app.processUploadedFile(tusUploadDir, upload.ContentType, upload.SHA256)
+	}
+}
+
+// getTusUpload returns the tus upload with the given id along with its offset, writing the error response otherwise
+func getTusUpload(w http.ResponseWriter, id string) (*tusUpload, int64, bool) {
+	upload, size, err := readTusUpload()
+	if err != nil && !os.IsNotExist(err) {
+		handleStreamError(w, err)
+		return nil, 0, false
+	}
+	if err != nil || upload.ID != id {
+		w.WriteHeader(http.StatusNotFound)
+		return nil, 0, false
+	}
+	return upload, size, true
+}
+
+func readTusUpload() (*tusUpload, int64, error) {
+	bytes, err := os.ReadFile(filepath.Join(tusUploadDir, tusInfoFile))
+	if err != nil {
+		return nil, 0, err
+	}
+	upload := &tusUpload{}
+	if err := json.Unmarshal(bytes, upload); err != nil {
+		return nil, 0, errors.Wrap(err, "unable to parse tus upload")
+	}
+	info, err := os.Stat(filepath.Join(tusUploadDir, uploadDataFile))
+	if err != nil {
+		return nil, 0, err
+	}
+	return upload, info.Size(), nil
+}
+
+// createTusUploadFiles creates the files of the tus upload, the info file last so that it is never read partially written
+func createTusUploadFiles(upload *tusUpload) error {
+	file, err := os.Create(filepath.Join(tusUploadDir, uploadDataFile))
+	if err != nil {
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	bytes, err := json.Marshal(upload)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(tusUploadDir, tusInfoFile+".tmp"), bytes, 0600); err != nil {
+		return err
+	}
+	return os.Rename(filepath.Join(tusUploadDir, tusInfoFile+".tmp"), filepath.Join(tusUploadDir, tusInfoFile))
+}
+
+// writeTusUploadData appends up to length bytes of the stream to the data of the tus upload, returning how many
+// bytes were written and synced, even when the stream failed
+func writeTusUploadData(stream io.Reader, length int64) (int64, error) {
+	file, err := os.OpenFile(filepath.Join(tusUploadDir, uploadDataFile), os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+	written, err := io.Copy(file, io.LimitReader(stream, length))
+	if syncErr := file.Sync(); syncErr != nil {
+		return written, syncErr
+	}
+	return written, err
+}
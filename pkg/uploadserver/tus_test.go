@@ -0,0 +1,252 @@
+package uploadserver
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+	"kubevirt.io/containerized-data-importer/pkg/common"
+)
+
+var _ = Describe("tus upload", func() {
+	var (
+		origTusUploadDir      string
+		origFileProcessorFunc func(string, string, string, float64, bool, cdiv1.DataVolumeContentType) (bool, error)
+		processed             chan string
+		processedContentType  cdiv1.DataVolumeContentType
+		processorErr          error
+	)
+
+	BeforeEach(func() {
+		origTusUploadDir = tusUploadDir
+		origFileProcessorFunc = uploadFileProcessorFunc
+		tusUploadDir = filepath.Join(GinkgoT().TempDir(), "tus")
+		processed = make(chan string, 1)
+		processedContentType = ""
+		processorErr = nil
+		uploadFileProcessorFunc = func(file, dest, imageSize string, filesystemOverhead float64, preallocation bool, dvContentType cdiv1.DataVolumeContentType) (bool, error) {
+			data, err := os.ReadFile(file)
+			Expect(err).ToNot(HaveOccurred())
+			processedContentType = dvContentType
+			err = processorErr
+			processed <- string(data)
+			return false, err
+		}
+	})
+
+	AfterEach(func() {
+		tusUploadDir = origTusUploadDir
+		uploadFileProcessorFunc = origFileProcessorFunc
+	})
+
+	tusRequest := func(server *uploadServerApp, method, path string, headers map[string]string, body string) *httptest.ResponseRecorder {
+		req, err := http.NewRequest(method, path, strings.NewReader(body))
+		Expect(err).ToNot(HaveOccurred())
+		req.Header.Set(tusResumableHeader, tusVersion)
+		for k, v := range headers {
+			req.Header.Set(k, v)
+		}
+		rr := httptest.NewRecorder()
+		server.ServeHTTP(rr, req)
+		Expect(rr.Header().Get(tusResumableHeader)).To(Equal(tusVersion))
+		return rr
+	}
+
+	createUpload := func(server *uploadServerApp, path string, length int) string {
+		rr := tusRequest(server, http.MethodPost, path, map[string]string{uploadLengthHeader: fmt.Sprint(length)}, "")
+		Expect(rr.Code).To(Equal(http.StatusCreated))
+		location := rr.Header().Get("Location")
+		Expect(location).To(HavePrefix(path + "/"))
+		return location
+	}
+
+	patchUpload := func(server *uploadServerApp, location string, offset int, data string) *httptest.ResponseRecorder {
+		return tusRequest(server, http.MethodPatch, location, map[string]string{
+			"Content-Type":        tusOffsetContentType,
+			tusUploadOffsetHeader: fmt.Sprint(offset),
+		}, data)
+	}
+
+	It("should advertise the tus protocol", func() {
+		rr := tusRequest(newServer(), http.MethodOptions, common.UploadTusPath, nil, "")
+		Expect(rr.Code).To(Equal(http.StatusNoContent))
+		Expect(rr.Header().Get(tusVersionHeader)).To(Equal(tusVersion))
+		Expect(rr.Header().Get(tusExtensionHeader)).To(Equal("creation,termination"))
+	})
+
+	It("should refuse other versions of the tus protocol", func() {
+		req, err := http.NewRequest(http.MethodPost, common.UploadTusPath, nil)
+		Expect(err).ToNot(HaveOccurred())
+		req.Header.Set(tusResumableHeader, "0.2.2")
+		rr := httptest.NewRecorder()
+		newServer().ServeHTTP(rr, req)
+		Expect(rr.Code).To(Equal(http.StatusPreconditionFailed))
+		Expect(rr.Header().Get(tusVersionHeader)).To(Equal(tusVersion))
+	})
+
+	DescribeTable("should refuse to create uploads", func(headers map[string]string, expectedStatus int) {
+		rr := tusRequest(newServer(), http.MethodPost, common.UploadTusPath, headers, "")
+		Expect(rr.Code).To(Equal(expectedStatus))
+	},
+		Entry("without length", map[string]string{}, http.StatusBadRequest),
+		Entry("with deferred length", map[string]string{tusDeferLengthHeader: "1"}, http.StatusBadRequest),
+		Entry("larger than scratch space", map[string]string{uploadLengthHeader: fmt.Sprint(int64(1) << 62)}, http.StatusRequestEntityTooLarge),
+	)
+
+	It("should resume the upload at its offset and import it once complete", func() {
+		server := newServer()
+		location := createUpload(server, common.UploadTusPath, 8)
+
+		rr := tusRequest(server, http.MethodHead, location, nil, "")
+		Expect(rr.Code).To(Equal(http.StatusOK))
+		Expect(rr.Header().Get(tusUploadOffsetHeader)).To(Equal("0"))
+		Expect(rr.Header().Get(uploadLengthHeader)).To(Equal("8"))
+		Expect(rr.Header().Get("Cache-Control")).To(Equal("no-store"))
+
+		rr = patchUpload(server, location, 0, "abcd")
+		Expect(rr.Code).To(Equal(http.StatusNoContent))
+		Expect(rr.Header().Get(tusUploadOffsetHeader)).To(Equal("4"))
+
+		rr = patchUpload(server, location, 2, "cdef")
+		Expect(rr.Code).To(Equal(http.StatusConflict))
+
+		// The offset survives restarts of the server
+		server = newServer()
+		rr = tusRequest(server, http.MethodHead, location, nil, "")
+		Expect(rr.Header().Get(tusUploadOffsetHeader)).To(Equal("4"))
+		Expect(processed).ToNot(Receive())
+
+		rr = patchUpload(server, location, 4, "efgh")
+		Expect(rr.Code).To(Equal(http.StatusNoContent))
+		Expect(rr.Header().Get(tusUploadOffsetHeader)).To(Equal("8"))
+		Eventually(processed).Should(Receive(Equal("abcdefgh")))
+		Eventually(server.doneChan).Should(BeClosed())
+		Expect(processedContentType).To(Equal(cdiv1.DataVolumeKubeVirt))
+
+		rr = tusRequest(server, http.MethodHead, location, nil, "")
+		Expect(rr.Header().Get(tusUploadOffsetHeader)).To(Equal("8"))
+		rr = tusRequest(server, http.MethodPost, common.UploadTusPath, map[string]string{uploadLengthHeader: "8"}, "")
+		Expect(rr.Code).To(Equal(http.StatusConflict))
+	})
+
+	It("should import archives uploaded to the archive path", func() {
+		server := newServer()
+		location := createUpload(server, common.UploadTusArchivePath, 4)
+		Expect(patchUpload(server, location, 0, "data").Code).To(Equal(http.StatusNoContent))
+		Eventually(processed).Should(Receive(Equal("data")))
+		Expect(processedContentType).To(Equal(cdiv1.DataVolumeArchive))
+	})
+
+	It("should import the upload completed before the server restarted", func() {
+		server := newServer()
+		location := createUpload(server, common.UploadTusPath, 4)
+		Expect(os.WriteFile(filepath.Join(tusUploadDir, uploadDataFile), []byte("data"), 0600)).To(Succeed())
+		Expect(location).ToNot(BeEmpty())
+
+		server = newServer()
+		server.resumeTusUpload()
+		Eventually(processed).Should(Receive(Equal("data")))
+		Eventually(server.doneChan).Should(BeClosed())
+	})
+
+	It("should delete the upload failing to import", func() {
+		processorErr = fmt.Errorf("invalid image")
+		server := newServer()
+		location := createUpload(server, common.UploadTusPath, 4)
+		Expect(patchUpload(server, location, 0, "data").Code).To(Equal(http.StatusNoContent))
+		Eventually(server.errChan).Should(Receive(MatchError("invalid image")))
+		Expect(tusRequest(server, http.MethodHead, location, nil, "").Code).To(Equal(http.StatusNotFound))
+	})
+
+	It("should delete the upload not matching the digest expected by the client", func() {
+		server := newServer()
+		rr := tusRequest(server, http.MethodPost, common.UploadTusPath, map[string]string{
+			uploadLengthHeader:        "4",
+			common.UploadSHA256Header: strings.Repeat("00", sha256.Size),
+		}, "")
+		Expect(rr.Code).To(Equal(http.StatusCreated))
+		location := rr.Header().Get("Location")
+		Expect(patchUpload(server, location, 0, "data").Code).To(Equal(http.StatusNoContent))
+		Eventually(server.errChan).Should(Receive(MatchError(ContainSubstring(common.ChecksumMismatchText))))
+		Expect(processed).ToNot(Receive())
+		Expect(tusRequest(server, http.MethodHead, location, nil, "").Code).To(Equal(http.StatusNotFound))
+	})
+
+	It("should replace the previous upload", func() {
+		server := newServer()
+		previous := createUpload(server, common.UploadTusPath, 8)
+		Expect(patchUpload(server, previous, 0, "abcd").Code).To(Equal(http.StatusNoContent))
+		location := createUpload(server, common.UploadTusPath, 4)
+		Expect(location).ToNot(Equal(previous))
+		Expect(tusRequest(server, http.MethodHead, previous, nil, "").Code).To(Equal(http.StatusNotFound))
+		rr := tusRequest(server, http.MethodHead, location, nil, "")
+		Expect(rr.Header().Get(tusUploadOffsetHeader)).To(Equal("0"))
+	})
+
+	It("should delete the upload", func() {
+		server := newServer()
+		location := createUpload(server, common.UploadTusPath, 4)
+		Expect(tusRequest(server, http.MethodDelete, location, nil, "").Code).To(Equal(http.StatusNoContent))
+		Expect(tusRequest(server, http.MethodHead, location, nil, "").Code).To(Equal(http.StatusNotFound))
+		_, err := os.Stat(tusUploadDir)
+		Expect(os.IsNotExist(err)).To(BeTrue())
+	})
+
+	It("should refuse data of another content type", func() {
+		server := newServer()
+		location := createUpload(server, common.UploadTusPath, 4)
+		rr := tusRequest(server, http.MethodPatch, location, map[string]string{tusUploadOffsetHeader: "0"}, "data")
+		Expect(rr.Code).To(Equal(http.StatusUnsupportedMediaType))
+	})
+
+	It("should refuse requests during another upload", func() {
+		server := newServer()
+		location := createUpload(server, common.UploadTusPath, 4)
+		server.uploading = true
+		Expect(patchUpload(server, location, 0, "data").Code).To(Equal(http.StatusServiceUnavailable))
+	})
+
+	It("should stop the request in flight when the client resumes the upload", func() {
+		server := newServer()
+		s := httptest.NewServer(server)
+		defer s.Close()
+		location := createUpload(server, common.UploadTusPath, 8)
+
+		// The first request hangs after sending half of the data, like one whose connection was lost
+		reader, writer := io.Pipe()
+		defer writer.Close()
+		req, err := http.NewRequest(http.MethodPatch, s.URL+location, reader)
+		Expect(err).ToNot(HaveOccurred())
+		req.Header.Set(tusResumableHeader, tusVersion)
+		req.Header.Set("Content-Type", tusOffsetContentType)
+		req.Header.Set(tusUploadOffsetHeader, "0")
+		go func() {
+			defer GinkgoRecover()
+			resp, err := http.DefaultClient.Do(req)
+			if err == nil {
+				resp.Body.Close()
+			}
+		}()
+		_, err = writer.Write([]byte("abcd"))
+		Expect(err).ToNot(HaveOccurred())
+
+		Eventually(func() int64 {
+			info, err := os.Stat(filepath.Join(tusUploadDir, uploadDataFile))
+			Expect(err).ToNot(HaveOccurred())
+			return info.Size()
+		}).Should(BeEquivalentTo(4))
+		rr := tusRequest(server, http.MethodHead, location, nil, "")
+		Expect(rr.Header().Get(tusUploadOffsetHeader)).To(Equal("4"))
+		Expect(patchUpload(server, location, 4, "efgh").Code).To(Equal(http.StatusNoContent))
+		Eventually(processed).Should(Receive(Equal("abcdefgh")))
+	})
+})
@@ -21,21 +21,27 @@ package uploadserver
 
 import (
 	"archive/tar"
+	"compress/gzip"
 	"context"
+	"crypto/rand"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"mime/multipart"
 	"net"
 	"net/http"
 	"os"
+	"path/filepath"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/golang/snappy"
+	"github.com/klauspost/compress/zstd"
 	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 
 	"k8s.io/klog/v2"
 
@@ -48,6 +54,13 @@ import (
 
 const (
 	healthzPath = "/healthz"
+	metricsPath = "/metrics"
+
+	// uploadDataFile is the file holding the data of the uploads written to scratch space before they are imported
+	uploadDataFile = "data"
+	// uploadLengthHeader is the size of the uploads written to scratch space, sent by the client when creating them
+	uploadLengthHeader = "Upload-Length"
+	uploadIDLength     = 16
 )
 
 type Config struct {
@@ -63,6 +76,10 @@ type Config struct {
 	ImageSize          string
 	FilesystemOverhead float64
 	Preallocation      bool
+	// StreamingConversion converts qcow2 uploads as they are streamed, for upload pods without scratch space
+	StreamingConversion bool
+	// StorageFormat is the format clones converting the image store it in on a filesystem target, raw if empty
+	StorageFormat string
 
 	Deadline *time.Time
 
@@ -74,6 +91,8 @@ type RunResult struct {
 	CloneTarget          bool
 	PreallocationApplied bool
 	DeadlinePassed       bool
+	// UploadDigest is the sha256 digest of the upload checked by the server, if the client sent it
+	UploadDigest string
 }
 
 // UploadServer is the interface to uploadServerApp
@@ -89,16 +108,27 @@ type uploadServerApp struct {
 	done                 bool
 	preallocationApplied bool
 	cloneTarget          bool
+	uploadDigest         string
 	doneChan             chan struct{}
 	errChan              chan error
 	mutex                sync.Mutex
+	// the tus PATCH request writing data, if any
+	tusPatch *tusPatch
+	// the parallel upload receiving parts, if any
+	parallelUpload *parallelUpload
+	// the multipart upload receiving parts, if any
+	multipartUpload *multipartUpload
+	progress        uploadProgress
 }
 
 type imageReadCloser func(*http.Request) (io.ReadCloser, error)
 
+var errUnsupportedContentEncoding = errors.New("unsupported content encoding")
+
 // may be overridden in tests
 var uploadProcessorFunc = newUploadStreamProcessor
 var uploadProcessorFuncAsync = newAsyncUploadStreamProcessor
+var uploadFileProcessorFunc = newUploadFileProcessor
 
 func bodyReadCloser(r *http.Request) (io.ReadCloser, error) {
 	return r.Body, nil
@@ -129,7 +159,7 @@ func formReadCloser(r *http.Request) (io.ReadCloser, error) {
 }
 
 func isCloneTarget(contentType string) bool {
-	return contentType == common.BlockdeviceClone || contentType == common.FilesystemCloneContentType
+	return contentType == common.BlockdeviceClone || contentType == common.FilesystemCloneContentType || contentType == common.ImageCloneContentType
 }
 
 // NewUploadServer returns a new instance of uploadServerApp
@@ -159,6 +189,21 @@ func NewUploadServer(config *Config) UploadServer {
 	for _, path := range common.AsyncUploadFormPaths {
 		server.mux.HandleFunc(path, server.uploadHandlerAsync(formReadCloser))
 	}
+	server.mux.HandleFunc(common.UploadTusPath, server.tusHandler(common.UploadTusPath, cdiv1.DataVolumeKubeVirt))
+	server.mux.HandleFunc(common.UploadTusPath+"/", server.tusHandler(common.UploadTusPath, cdiv1.DataVolumeKubeVirt))
+	server.mux.HandleFunc(common.UploadTusArchivePath, server.tusHandler(common.UploadTusArchivePath, cdiv1.DataVolumeArchive))
+	server.mux.HandleFunc(common.UploadTusArchivePath+"/", server.tusHandler(common.UploadTusArchivePath, cdiv1.DataVolumeArchive))
+	server.mux.HandleFunc(common.UploadParallelPath, server.parallelUploadHandler(common.UploadParallelPath, cdiv1.DataVolumeKubeVirt))
+	server.mux.HandleFunc(common.UploadParallelPath+"/", server.parallelUploadHandler(common.UploadParallelPath, cdiv1.DataVolumeKubeVirt))
+	server.mux.HandleFunc(common.UploadParallelArchivePath, server.parallelUploadHandler(common.UploadParallelArchivePath, cdiv1.DataVolumeArchive))
+	server.mux.HandleFunc(common.UploadParallelArchivePath+"/", server.parallelUploadHandler(common.UploadParallelArchivePath, cdiv1.DataVolumeArchive))
+	server.mux.HandleFunc(common.UploadMultipartPath, server.multipartUploadHandler(common.UploadMultipartPath, cdiv1.DataVolumeKubeVirt))
+	server.mux.HandleFunc(common.UploadMultipartPath+"/", server.multipartUploadHandler(common.UploadMultipartPath, cdiv1.DataVolumeKubeVirt))
+	server.mux.HandleFunc(common.UploadMultipartArchivePath, server.multipartUploadHandler(common.UploadMultipartArchivePath, cdiv1.DataVolumeArchive))
+	server.mux.HandleFunc(common.UploadMultipartArchivePath+"/", server.multipartUploadHandler(common.UploadMultipartArchivePath, cdiv1.DataVolumeArchive))
+	server.mux.HandleFunc(common.UploadProgressPath, server.uploadProgressHandler)
+	// the conversion progress of the upload is scraped from the import progress metric, like the one of imports
+	server.mux.Handle(metricsPath, promhttp.Handler())
 
 	return server
 }
@@ -179,6 +224,18 @@ func (app *uploadServerApp) Run() (*RunResult, error) {
 		return nil, errors.Wrap(err, "Error getting TLS config")
 	}
 
+	if tlsConfig != nil {
+		// the certs are reloaded for every connection, so the ones rotated in the mounted secret are used without
+		// restarting the server
+		tlsConfig.GetConfigForClient = func(_ *tls.ClientHelloInfo) (*tls.Config, error) {
+			config, err := app.getTLSConfig()
+			if err != nil {
+				klog.Errorf("Error %+v getting TLS config", err)
+			}
+			return config, err
+		}
+	}
+
 	go func() {
 		defer uploadListener.Close()
 
@@ -195,6 +252,8 @@ func (app *uploadServerApp) Run() (*RunResult, error) {
 		app.errChan <- uploadServer.Serve(uploadListener)
 	}()
 
+	app.resumeTusUpload()
+
 	var timeChan <-chan time.Time
 
 	if app.config.Deadline != nil {
@@ -239,6 +298,7 @@ func (app *uploadServerApp) Run() (*RunResult, error) {
 	result := &RunResult{
 		CloneTarget:          app.cloneTarget,
 		PreallocationApplied: app.preallocationApplied,
+		UploadDigest:         app.uploadDigest,
 	}
 
 	return result, nil
@@ -299,6 +359,31 @@ func (app *uploadServerApp) validateShouldHandleRequest(w http.ResponseWriter, r
 		return false
 	}
 
+	if !app.authorizeRequest(w, r) {
+		return false
+	}
+
+	app.mutex.Lock()
+	defer app.mutex.Unlock()
+
+	if app.uploading || app.processing {
+		klog.Warning("Got concurrent upload request")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return false
+	}
+
+	if app.done {
+		klog.Warning("Got upload request after already done")
+		w.WriteHeader(http.StatusConflict)
+		return false
+	}
+
+	app.uploading = true
+
+	return true
+}
+
+func (app *uploadServerApp) authorizeRequest(w http.ResponseWriter, r *http.Request) bool {
 	if r.TLS != nil {
 		if len(r.TLS.VerifiedChains) == 0 {
 			w.WriteHeader(http.StatusUnauthorized)
@@ -325,79 +410,116 @@ func (app *uploadServerApp) validateShouldHandleRequest(w http.ResponseWriter, r
 		klog.V(3).Infof("Handling HTTP connection")
 	}
 
-	app.mutex.Lock()
-	defer app.mutex.Unlock()
-
-	if app.uploading || app.processing {
-		klog.Warning("Got concurrent upload request")
-		w.WriteHeader(http.StatusServiceUnavailable)
-		return false
-	}
-
-	if app.done {
-		klog.Warning("Got upload request after already done")
-		w.WriteHeader(http.StatusConflict)
-		return false
-	}
-
-	app.uploading = true
-
 	return true
 }
 
 func (app *uploadServerApp) uploadHandlerAsync(irc imageReadCloser) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		if r.Method == http.MethodHead {
+			w.Header().Set("Accept-Encoding", acceptedContentEncodings)
 			w.WriteHeader(http.StatusOK)
 			return
 		}
+		app.processUploadAsync(irc, w, r)
+	}
+}
 
-		if !app.validateShouldHandleRequest(w, r) {
-			return
-		}
+// processUploadAsync returns once the data of the upload is received, and converts it in the background. The clients
+// preferring to respond asynchronously get 202 Accepted, with the location of the progress of the conversion.
+func (app *uploadServerApp) processUploadAsync(irc imageReadCloser, w http.ResponseWriter, r *http.Request) {
+	if !app.validateShouldHandleRequest(w, r) {
+		return
+	}
 
-		cdiContentType := r.Header.Get(common.UploadContentTypeHeader)
+	cdiContentType := r.Header.Get(common.UploadContentTypeHeader)
 
-		klog.Infof("Content type header is %q\n", cdiContentType)
+	klog.Infof("Content type header is %q\n", cdiContentType)
 
-		readCloser, err := irc(r)
-		if err != nil {
-			w.WriteHeader(http.StatusBadRequest)
+	app.countUploadRequest(r)
+	if err := decodeRequestBody(r); err != nil {
+		app.refuseUpload(w, err)
+		return
+	}
+	readCloser, err := irc(r)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+	}
+
+	digest, err := requestUploadDigest(r)
+	if err != nil {
+		app.refuseUpload(w, err)
+		return
+	}
+	if digest != nil {
+		readCloser = digest.reader(readCloser)
+	}
+	ovaDisk, err := requestOVADisk(r, cdiContentType, cdiv1.DataVolumeKubeVirt)
+	if err != nil {
+		app.refuseUpload(w, err)
+		return
+	}
+
+	processor, err := uploadProcessorFuncAsync(readCloser, app.config.Destination, app.config.ImageSize, app.config.FilesystemOverhead, app.config.Preallocation, cdiContentType, ovaDisk)
+	if err == nil && digest != nil {
+		// The data was transferred, only its conversion is left
+		if err = digest.verify(readCloser); err != nil {
+			app.removeUploadedData(cdiv1.DataVolumeKubeVirt)
 		}
+	}
+
+	app.mutex.Lock()
+	defer app.mutex.Unlock()
+	app.uploading = false
+
+	if err != nil {
+		app.progress.reset(0, 0)
+		handleStreamError(w, err)
+		return
+	}
 
-		processor, err := uploadProcessorFuncAsync(readCloser, app.config.Destination, app.config.ImageSize, app.config.FilesystemOverhead, app.config.Preallocation, cdiContentType)
+	app.processing = true
 
+	// Start processing.
+	go func() {
+		err := processor.ProcessDataResume()
 		app.mutex.Lock()
 		defer app.mutex.Unlock()
-		app.uploading = false
-
+		app.processing = false
 		if err != nil {
-			handleStreamError(w, err)
+			klog.Errorf("Error during resumed processing: %v", err)
+			app.errChan <- err
 			return
 		}
+		defer close(app.doneChan)
+		app.done = true
+		app.preallocationApplied = processor.PreallocationApplied()
+		app.cloneTarget = isCloneTarget(cdiContentType)
+		if digest != nil {
+			app.uploadDigest = digest.String()
+		}
+		klog.Infof("Wrote data to %s", app.config.Destination)
+	}()
 
-		app.processing = true
+	if preferAsync(r) {
+		w.Header().Set(common.UploadPreferenceAppliedHeader, common.UploadPreferRespondAsync)
+		w.Header().Set("Location", common.UploadProgressPath)
+		w.WriteHeader(http.StatusAccepted)
+	}
+	klog.Info("Returning success to caller, continue processing in background")
+}
 
-		// Start processing.
-		go func() {
-			err := processor.ProcessDataResume()
-			app.mutex.Lock()
-			defer app.mutex.Unlock()
-			app.processing = false
-			if err != nil {
-				klog.Errorf("Error during resumed processing: %v", err)
-				app.errChan <- err
-				return
+// preferAsync returns true if the client of the upload prefers to respond asynchronously, so that it isn't cut off by
+// load balancers closing the connections idle during the conversion
+func preferAsync(r *http.Request) bool {
+	for _, header := range r.Header.Values(common.UploadPreferHeader) {
+		for _, preference := range strings.Split(header, ",") {
+			token, _, _ := strings.Cut(preference, ";")
+			if strings.EqualFold(strings.TrimSpace(token), common.UploadPreferRespondAsync) {
+				return true
 			}
-			defer close(app.doneChan)
-			app.done = true
-			app.preallocationApplied = processor.PreallocationApplied()
-			app.cloneTarget = isCloneTarget(cdiContentType)
-			klog.Infof("Wrote data to %s", app.config.Destination)
-		}()
-
-		klog.Info("Returning success to caller, continue processing in background")
+		}
 	}
+	return false
 }
 
 func (app *uploadServerApp) processUpload(irc imageReadCloser, w http.ResponseWriter, r *http.Request, dvContentType cdiv1.DataVolumeContentType) {
@@ -409,18 +531,44 @@ func (app *uploadServerApp) processUpload(irc imageReadCloser, w http.ResponseWr
 
 	klog.Infof("Content type header is %q\n", cdiContentType)
 
+	app.countUploadRequest(r)
+	if err := decodeRequestBody(r); err != nil {
+		app.refuseUpload(w, err)
+		return
+	}
 	readCloser, err := irc(r)
 	if err != nil {
 		w.WriteHeader(http.StatusBadRequest)
 	}
 
-	preallocationApplied, err := uploadProcessorFunc(readCloser, app.config.Destination, app.config.ImageSize, app.config.FilesystemOverhead, app.config.Preallocation, cdiContentType, dvContentType)
+	digest, err := requestUploadDigest(r)
+	if err != nil {
+		app.refuseUpload(w, err)
+		return
+	}
+	if digest != nil {
+		readCloser = digest.reader(readCloser)
+	}
+	readCloser = newContentReader(readCloser, cdiContentType, r.Header.Get("Content-Encoding"))
+	ovaDisk, err := requestOVADisk(r, cdiContentType, dvContentType)
+	if err != nil {
+		app.refuseUpload(w, err)
+		return
+	}
+
+	preallocationApplied, err := uploadProcessorFunc(readCloser, app.config.Destination, app.config.ImageSize, app.config.FilesystemOverhead, app.config.Preallocation, cdiContentType, dvContentType, ovaDisk, app.config.StreamingConversion, app.config.StorageFormat)
+	if err == nil && digest != nil {
+		if err = digest.verify(readCloser); err != nil {
+			app.removeUploadedData(dvContentType)
+		}
+	}
 
 	app.mutex.Lock()
 	defer app.mutex.Unlock()
 	app.uploading = false
 
 	if err != nil {
+		app.progress.reset(0, 0)
 		handleStreamError(w, err)
 		return
 	}
@@ -428,6 +576,9 @@ func (app *uploadServerApp) processUpload(irc imageReadCloser, w http.ResponseWr
 	app.done = true
 	app.preallocationApplied = preallocationApplied
 	app.cloneTarget = isCloneTarget(cdiContentType)
+	if digest != nil {
+		app.uploadDigest = digest.String()
+	}
 	close(app.doneChan)
 
 	if dvContentType == cdiv1.DataVolumeArchive {
@@ -439,6 +590,20 @@ func (app *uploadServerApp) processUpload(irc imageReadCloser, w http.ResponseWr
 
 func (app *uploadServerApp) uploadHandler(irc imageReadCloser) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		// clone sources check the content encodings accepted before compressing with them
+		if r.Method == http.MethodHead {
+			w.Header().Set("Accept-Encoding", acceptedContentEncodings)
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		if r.Header.Get(common.UploadContentTypeHeader) == common.FilesystemRsyncCloneContentType {
+			app.processRsyncClone(w, r)
+			return
+		}
+		if preferAsync(r) {
+			app.processUploadAsync(irc, w, r)
+			return
+		}
 		app.processUpload(irc, w, r, cdiv1.DataVolumeKubeVirt)
 	}
 }
@@ -449,29 +614,162 @@ func (app *uploadServerApp) uploadArchiveHandler(irc imageReadCloser) http.Handl
 	}
 }
 
-func newAsyncUploadStreamProcessor(stream io.ReadCloser, dest, imageSize string, filesystemOverhead float64, preallocation bool, sourceContentType string) (*importer.DataProcessor, error) {
+func newAsyncUploadStreamProcessor(stream io.ReadCloser, dest, imageSize string, filesystemOverhead float64, preallocation bool, sourceContentType, ovaDisk string) (*importer.DataProcessor, error) {
 	if isCloneTarget(sourceContentType) {
 		return nil, fmt.Errorf("async clone not supported")
 	}
+	if sourceContentType == common.RawDirectContentType {
+		return nil, errors.Wrap(errDirectUploadUnsupported, "async direct upload not supported")
+	}
 
-	uds := importer.NewAsyncUploadDataSource(newContentReader(stream, sourceContentType))
+	uds := importer.NewAsyncUploadDataSource(stream)
+	if ovaDisk != "" {
+		uds = importer.NewAsyncOVAUploadDataSource(stream, ovaDisk)
+	}
 	processor := importer.NewDataProcessor(uds, dest, common.ImporterVolumePath, common.ScratchDataDir, imageSize, filesystemOverhead, preallocation, "")
 	return processor, processor.ProcessDataWithPause()
 }
 
-func newUploadStreamProcessor(stream io.ReadCloser, dest, imageSize string, filesystemOverhead float64, preallocation bool, sourceContentType string, dvContentType cdiv1.DataVolumeContentType) (bool, error) {
-	stream = newContentReader(stream, sourceContentType)
+func newUploadStreamProcessor(stream io.ReadCloser, dest, imageSize string, filesystemOverhead float64, preallocation bool, sourceContentType string, dvContentType cdiv1.DataVolumeContentType, ovaDisk string, streaming bool, storageFormat string) (bool, error) {
+	if sourceContentType == common.ImageCloneContentType {
+		return imageCloneProcessor(stream, dest, imageSize, filesystemOverhead, preallocation, storageFormat)
+	}
 	if isCloneTarget(sourceContentType) {
 		return cloneProcessor(stream, sourceContentType, dest, preallocation)
 	}
+	if sourceContentType == common.RawDirectContentType {
+		if dvContentType == cdiv1.DataVolumeArchive {
+			stream.Close()
+			return false, errors.Wrap(errDirectUploadUnsupported, "archives can't be uploaded directly")
+		}
+		return directUploadProcessor(stream, dest)
+	}
 
 	// Clone block device to block device or file system
 	uds := importer.NewUploadDataSource(stream, dvContentType)
+	if ovaDisk != "" {
+		uds = importer.NewOVAUploadDataSource(stream, ovaDisk)
+	} else if streaming && dvContentType == cdiv1.DataVolumeKubeVirt {
+		uds = importer.NewStreamingUploadDataSource(stream)
+	}
 	processor := importer.NewDataProcessor(uds, dest, common.ImporterVolumePath, common.ScratchDataDir, imageSize, filesystemOverhead, preallocation, "")
 	err := processor.ProcessData()
 	return processor.PreallocationApplied(), err
 }
 
+// requestOVADisk returns the disk of the uploaded OVA named by the client, an index or a disk id or file name, empty
+// without one. The first disk of OVAs is imported when the client names none.
+func requestOVADisk(r *http.Request, sourceContentType string, dvContentType cdiv1.DataVolumeContentType) (string, error) {
+	disk := r.Header.Get(common.UploadOVADiskHeader)
+	if disk == "" {
+		return "", nil
+	}
+	if dvContentType != cdiv1.DataVolumeKubeVirt || isCloneTarget(sourceContentType) || sourceContentType == common.RawDirectContentType {
+		return "", errors.New("OVA disks can only be extracted from uploads of kubevirt content")
+	}
+	return disk, nil
+}
+
+// newUploadID returns a random id for the uploads written to scratch space
+func newUploadID() (string, error) {
+	id := make([]byte, uploadIDLength)
+	if _, err := rand.Read(id); err != nil {
+		return "", errors.Wrap(err, "unable to generate the id of the upload")
+	}
+	return hex.EncodeToString(id), nil
+}
+
+// processUploadedFile imports the data of the upload written to dir on scratch space in the background, once checked
+// against the sha256 digest expected by the client if any. The upload is deleted when its data cannot be imported.
+// Called with the mutex held.
+func (app *uploadServerApp) processUploadedFile(dir string, dvContentType cdiv1.DataVolumeContentType, expectedDigest string) {
+	if app.processing || app.done {
+		return
+	}
+	app.processing = true
+
+	go func() {
+		klog.Infof("Processing upload in %s", dir)
+		// The digest was checked when the upload was created
+		digest, _ := parseUploadDigest(expectedDigest)
+		var preallocationApplied bool
+		var err error
+		if digest != nil {
+			err = digest.verifyFile(filepath.Join(dir, uploadDataFile))
+		}
+		if err == nil {
+			preallocationApplied, err = uploadFileProcessorFunc(filepath.Join(dir, uploadDataFile), app.config.Destination, app.config.ImageSize, app.config.FilesystemOverhead, app.config.Preallocation, dvContentType)
+		}
+		app.mutex.Lock()
+		defer app.mutex.Unlock()
+		app.processing = false
+		if err != nil {
+			klog.Errorf("Error processing upload: %v", err)
+			// The data cannot be imported, it is not processed again when the server restarts
+			if err := os.RemoveAll(dir); err != nil {
+				klog.Errorf("Unable to delete upload: %v", err)
+			}
+			app.progress.reset(0, 0)
+			app.errChan <- err
+			return
+		}
+		defer close(app.doneChan)
+		app.done = true
+		app.preallocationApplied = preallocationApplied
+		if digest != nil {
+			app.uploadDigest = digest.String()
+		}
+		klog.Infof("Wrote data to %s", app.config.Destination)
+	}()
+}
+
+// refuseUpload responds to an upload started by validateShouldHandleRequest and refused for err
+func (app *uploadServerApp) refuseUpload(w http.ResponseWriter, err error) {
+	klog.Warningf("Refusing upload: %v", err)
+	app.progress.reset(0, 0)
+	app.mutex.Lock()
+	app.uploading = false
+	app.mutex.Unlock()
+	if errors.Is(err, errUnsupportedContentEncoding) {
+		w.WriteHeader(http.StatusUnsupportedMediaType)
+		return
+	}
+	w.WriteHeader(http.StatusBadRequest)
+}
+
+// removeUploadedData deletes the data of an upload not matching its digest, so the PVC does not keep data the client
+// did not send. Block devices are left as they are, the next upload overwrites them.
+func (app *uploadServerApp) removeUploadedData(dvContentType cdiv1.DataVolumeContentType) {
+	paths := []string{app.config.Destination}
+	if dvContentType == cdiv1.DataVolumeArchive {
+		// Archives are extracted to the volume
+		entries, err := os.ReadDir(common.ImporterVolumePath)
+		if err != nil {
+			klog.Errorf("Unable to list the extracted archive: %v", err)
+		}
+		for _, entry := range entries {
+			if entry.Name() != "lost+found" {
+				paths = append(paths, filepath.Join(common.ImporterVolumePath, entry.Name()))
+			}
+		}
+	}
+	if err := importer.CleanAll(paths...); err != nil {
+		klog.Errorf("Unable to delete the data of the upload: %v", err)
+	}
+}
+
+func newUploadFileProcessor(file, dest, imageSize string, filesystemOverhead float64, preallocation bool, dvContentType cdiv1.DataVolumeContentType) (bool, error) {
+	// The data is read where it was uploaded on scratch space, images qemu-img can read are converted in place
+	hds, err := importer.NewHostPathDataSource(file, dvContentType)
+	if err != nil {
+		return false, err
+	}
+	defer hds.Close()
+	processor := importer.NewDataProcessor(hds, dest, common.ImporterVolumePath, common.ScratchDataDir, imageSize, filesystemOverhead, preallocation, "")
+	err = processor.ProcessData()
+	return processor.PreallocationApplied(), err
+}
+
 func cloneProcessor(stream io.ReadCloser, contentType, dest string, preallocate bool) (bool, error) {
 	if contentType == common.FilesystemCloneContentType {
 		if dest != common.WriteBlockPath {
@@ -496,6 +794,19 @@ func cloneProcessor(stream io.ReadCloser, contentType, dest string, preallocate
 	return false, nil
 }
 
+// imageCloneProcessor converts the disk image of the source volume onto the target, resized to the target and stored in
+// the storage format. Clone targets have no scratch space, raw images are written directly and qcow2 images converted
+// by qemu-img as they are streamed.
+func imageCloneProcessor(stream io.ReadCloser, dest, imageSize string, filesystemOverhead float64, preallocation bool, storageFormat string) (bool, error) {
+	uds := importer.NewStreamingUploadDataSource(stream)
+	processor := importer.NewDataProcessor(uds, dest, common.ImporterVolumePath, common.ScratchDataDir, imageSize, filesystemOverhead, preallocation, "")
+	if storageFormat == common.StorageFormatQcow2 {
+		processor.SetStorageFormat(storageFormat)
+	}
+	err := processor.ProcessData()
+	return processor.PreallocationApplied(), err
+}
+
 func fileToFileCloneProcessor(stream io.ReadCloser) (bool, error) {
 	defer stream.Close()
 	if err := util.UnArchiveTar(stream, common.ImporterVolumePath); err != nil {
@@ -559,8 +870,43 @@ func newTarDiskImageReader(stream io.ReadCloser) (io.ReadCloser, error) {
 	return nil, fmt.Errorf("no disk image found in tar")
 }
 
-func newContentReader(stream io.ReadCloser, contentType string) io.ReadCloser {
-	if isCloneTarget(contentType) {
+// acceptedContentEncodings are the content encodings decodeRequestBody decompresses
+const acceptedContentEncodings = "gzip, zstd"
+
+// decodeRequestBody replaces the body of r, compressed by the client as its Content-Encoding header states, with the
+// decompressed data
+func decodeRequestBody(r *http.Request) error {
+	switch encoding := strings.ToLower(r.Header.Get("Content-Encoding")); encoding {
+	case "", "identity":
+		return nil
+	case "gzip", "x-gzip":
+		gz, err := gzip.NewReader(r.Body)
+		if err != nil {
+			return errors.Wrap(err, "could not create gzip reader")
+		}
+		r.Body = &closeWrapper{
+			Reader:  gz,
+			closers: []io.Closer{gz, r.Body},
+		}
+	case "zstd":
+		zst, err := zstd.NewReader(r.Body)
+		if err != nil {
+			return errors.Wrap(err, "could not create zstd reader")
+		}
+		r.Body = &closeWrapper{
+			Reader:  zst,
+			closers: []io.Closer{zst.IOReadCloser(), r.Body},
+		}
+	default:
+		return errors.Wrapf(errUnsupportedContentEncoding, "%q", encoding)
+	}
+	klog.Infof("Decompressing upload with content encoding %q", r.Header.Get("Content-Encoding"))
+	return nil
+}
+
+// newContentReader decompresses the snappy stream of clone sources, unless they compressed it with a content encoding
+func newContentReader(stream io.ReadCloser, contentType, contentEncoding string) io.ReadCloser {
+	if isCloneTarget(contentType) && (contentEncoding == "" || strings.EqualFold(contentEncoding, "identity")) {
 		return newSnappyReadCloser(stream)
 	}
 	return stream
@@ -577,6 +923,8 @@ func handleStreamError(w http.ResponseWriter, err error) {
 	if importer.IsNoCapacityError(err) {
 		w.WriteHeader(http.StatusBadRequest)
 		err = errors.New("effective image size is larger than the reported available storage. A larger PVC is required")
+	} else if errors.Is(err, errUploadDigestMismatch) || errors.Is(err, errInvalidUploadDigest) || errors.Is(err, errDirectUploadUnsupported) {
+		w.WriteHeader(http.StatusBadRequest)
 	} else {
 		w.WriteHeader(http.StatusInternalServerError)
 	}
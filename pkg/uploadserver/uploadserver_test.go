@@ -21,6 +21,7 @@ package uploadserver
 
 import (
 	"bytes"
+	"compress/gzip"
 	"crypto/tls"
 	"crypto/x509"
 	"fmt"
@@ -34,6 +35,8 @@ import (
 	"strings"
 	"time"
 
+	"github.com/golang/snappy"
+	"github.com/klauspost/compress/zstd"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
@@ -118,11 +121,11 @@ func newHTTPClient(clientKeyPair *triple.KeyPair, serverCACert *x509.Certificate
 	return client
 }
 
-func saveProcessorSuccess(stream io.ReadCloser, dest, imageSize string, filesystemOverhead float64, preallocation bool, contentType string, dvContentType cdiv1.DataVolumeContentType) (bool, error) {
+func saveProcessorSuccess(stream io.ReadCloser, dest, imageSize string, filesystemOverhead float64, preallocation bool, contentType string, dvContentType cdiv1.DataVolumeContentType, ovaDisk string, streaming bool, storageFormat string) (bool, error) {
 	return false, nil
 }
 
-func saveProcessorFailure(stream io.ReadCloser, dest, imageSize string, filesystemOverhead float64, preallocation bool, contentType string, dvContentType cdiv1.DataVolumeContentType) (bool, error) {
+func saveProcessorFailure(stream io.ReadCloser, dest, imageSize string, filesystemOverhead float64, preallocation bool, contentType string, dvContentType cdiv1.DataVolumeContentType, ovaDisk string, streaming bool, storageFormat string) (bool, error) {
 	return false, fmt.Errorf("Error using datastream")
 }
 
@@ -134,7 +137,7 @@ func withProcessorFailure(f func()) {
 	replaceProcessorFunc(saveProcessorFailure, f)
 }
 
-func replaceProcessorFunc(replacement func(io.ReadCloser, string, string, float64, bool, string, cdiv1.DataVolumeContentType) (bool, error), f func()) {
+func replaceProcessorFunc(replacement func(io.ReadCloser, string, string, float64, bool, string, cdiv1.DataVolumeContentType, string, bool, string) (bool, error), f func()) {
 	origProcessorFunc := uploadProcessorFunc
 	uploadProcessorFunc = replacement
 	defer func() {
@@ -186,11 +189,11 @@ func (amd *AsyncMockDataSource) GetResumePhase() importer.ProcessingPhase {
 	return importer.ProcessingPhaseComplete
 }
 
-func saveAsyncProcessorSuccess(stream io.ReadCloser, dest, imageSize string, filesystemOverhead float64, preallocation bool, contentType string) (*importer.DataProcessor, error) {
+func saveAsyncProcessorSuccess(stream io.ReadCloser, dest, imageSize string, filesystemOverhead float64, preallocation bool, contentType, ovaDisk string) (*importer.DataProcessor, error) {
 	return importer.NewDataProcessor(&AsyncMockDataSource{}, "", "", "", "", 0.06, false, ""), nil
 }
 
-func saveAsyncProcessorFailure(stream io.ReadCloser, dest, imageSize string, filesystemOverhead float64, preallocation bool, contentType string) (*importer.DataProcessor, error) {
+func saveAsyncProcessorFailure(stream io.ReadCloser, dest, imageSize string, filesystemOverhead float64, preallocation bool, contentType, ovaDisk string) (*importer.DataProcessor, error) {
 	return importer.NewDataProcessor(&AsyncMockDataSource{}, "", "", "", "", 0.06, false, ""), fmt.Errorf("Error using datastream")
 }
 
@@ -202,7 +205,7 @@ func withAsyncProcessorFailure(f func()) {
 	replaceAsyncProcessorFunc(saveAsyncProcessorFailure, f)
 }
 
-func replaceAsyncProcessorFunc(replacement func(io.ReadCloser, string, string, float64, bool, string) (*importer.DataProcessor, error), f func()) {
+func replaceAsyncProcessorFunc(replacement func(io.ReadCloser, string, string, float64, bool, string, string) (*importer.DataProcessor, error), f func()) {
 	origProcessorFuncAsync := uploadProcessorFuncAsync
 	uploadProcessorFuncAsync = replacement
 	defer func() {
@@ -318,6 +321,102 @@ var _ = Describe("Upload server tests", func() {
 		Entry("HEAD", "HEAD"),
 	)
 
+	DescribeTable("should accept uploads preferring to respond asynchronously", func(path, prefer string) {
+		withAsyncProcessorSuccess(func() {
+			req, err := http.NewRequest(http.MethodPost, path, strings.NewReader("data"))
+			Expect(err).ToNot(HaveOccurred())
+			req.Header.Set(common.UploadPreferHeader, prefer)
+
+			rr := httptest.NewRecorder()
+			server := newServer()
+			server.ServeHTTP(rr, req)
+
+			Expect(rr.Code).To(Equal(http.StatusAccepted))
+			Expect(rr.Header().Get(common.UploadPreferenceAppliedHeader)).To(Equal(common.UploadPreferRespondAsync))
+			Expect(rr.Header().Get("Location")).To(Equal(common.UploadProgressPath))
+		})
+	},
+		Entry("sync", common.UploadPathSync, "respond-async"),
+		Entry("async", common.UploadPathAsync, "respond-async"),
+		Entry("with other preferences", common.UploadPathSync, "return=minimal, Respond-Async; wait=10"),
+	)
+
+	It("should process archive uploads synchronously regardless of the preference of the client", func() {
+		withProcessorSuccess(func() {
+			req, err := http.NewRequest(http.MethodPost, common.UploadArchivePath, strings.NewReader("data"))
+			Expect(err).ToNot(HaveOccurred())
+			req.Header.Set(common.UploadPreferHeader, common.UploadPreferRespondAsync)
+
+			rr := httptest.NewRecorder()
+			server := newServer()
+			server.ServeHTTP(rr, req)
+
+			Expect(rr.Code).To(Equal(http.StatusOK))
+			Expect(rr.Header().Get(common.UploadPreferenceAppliedHeader)).To(BeEmpty())
+		})
+	})
+
+	It("should pass the OVA disk asked for by the client to the processor", func() {
+		var disk string
+		replaceProcessorFunc(func(stream io.ReadCloser, dest, imageSize string, filesystemOverhead float64, preallocation bool, contentType string, dvContentType cdiv1.DataVolumeContentType, ovaDisk string, streaming bool, storageFormat string) (bool, error) {
+			disk = ovaDisk
+			return false, nil
+		}, func() {
+			req, err := http.NewRequest(http.MethodPost, common.UploadPathSync, strings.NewReader("data"))
+			Expect(err).ToNot(HaveOccurred())
+			req.Header.Set(common.UploadOVADiskHeader, "vmdisk2")
+
+			rr := httptest.NewRecorder()
+			server := newServer()
+			server.ServeHTTP(rr, req)
+
+			Expect(rr.Code).To(Equal(http.StatusOK))
+			Expect(disk).To(Equal("vmdisk2"))
+		})
+	})
+
+	It("should convert uploads streaming when the server has no scratch space", func() {
+		var streamed bool
+		replaceProcessorFunc(func(stream io.ReadCloser, dest, imageSize string, filesystemOverhead float64, preallocation bool, contentType string, dvContentType cdiv1.DataVolumeContentType, ovaDisk string, streaming bool, storageFormat string) (bool, error) {
+			streamed = streaming
+			return false, nil
+		}, func() {
+			req, err := http.NewRequest(http.MethodPost, common.UploadPathSync, strings.NewReader("data"))
+			Expect(err).ToNot(HaveOccurred())
+
+			rr := httptest.NewRecorder()
+			server := newServer()
+			server.config.StreamingConversion = true
+			server.ServeHTTP(rr, req)
+
+			Expect(rr.Code).To(Equal(http.StatusOK))
+			Expect(streamed).To(BeTrue())
+		})
+	})
+
+	It("should refuse to extract the disk of an OVA from archive uploads", func() {
+		withProcessorSuccess(func() {
+			req, err := http.NewRequest(http.MethodPost, common.UploadArchivePath, strings.NewReader("data"))
+			Expect(err).ToNot(HaveOccurred())
+			req.Header.Set(common.UploadOVADiskHeader, "0")
+
+			rr := httptest.NewRecorder()
+			server := newServer()
+			server.ServeHTTP(rr, req)
+
+			Expect(rr.Code).To(Equal(http.StatusBadRequest))
+			Expect(server.uploading).To(BeFalse())
+		})
+	})
+
+	It("should serve the metrics of the conversion", func() {
+		req, err := http.NewRequest(http.MethodGet, metricsPath, nil)
+		Expect(err).ToNot(HaveOccurred())
+		rr := httptest.NewRecorder()
+		newServer().ServeHTTP(rr, req)
+		Expect(rr.Code).To(Equal(http.StatusOK))
+	})
+
 	DescribeTable("Success, form", func(processorFunc func(func()), path string) {
 		processorFunc(func() {
 			req := newFormRequest(path)
@@ -353,6 +452,79 @@ var _ = Describe("Upload server tests", func() {
 		Entry("archive", withProcessorFailure, common.UploadArchivePath),
 	)
 
+	DescribeTable("should decompress uploads with content encoding", func(encoding string, encode func([]byte) []byte, newRequest func(string, func([]byte) []byte) *http.Request, uploadPath string) {
+		var received []byte
+		replaceProcessorFunc(func(stream io.ReadCloser, dest, imageSize string, filesystemOverhead float64, preallocation bool, contentType string, dvContentType cdiv1.DataVolumeContentType, ovaDisk string, streaming bool, storageFormat string) (bool, error) {
+			var err error
+			received, err = io.ReadAll(stream)
+			return false, err
+		}, func() {
+			req := newRequest(uploadPath, encode)
+			req.Header.Set("Content-Encoding", encoding)
+			rr := httptest.NewRecorder()
+			server := newServer()
+			server.ServeHTTP(rr, req)
+			Expect(rr.Code).To(Equal(http.StatusOK))
+			Expect(received).To(Equal([]byte("data")))
+		})
+	},
+		Entry("identity", "identity", identityEncode, newBodyRequest, common.UploadPathSync),
+		Entry("gzip", "gzip", gzipEncode, newBodyRequest, common.UploadPathSync),
+		Entry("zstd", "zstd", zstdEncode, newBodyRequest, common.UploadPathSync),
+		Entry("gzip archive", "gzip", gzipEncode, newBodyRequest, common.UploadArchivePath),
+		Entry("zstd form", "zstd", zstdEncode, newEncodedFormRequest, common.UploadFormSync),
+	)
+
+	It("should list the content encodings accepted by clone sources", func() {
+		req, err := http.NewRequest(http.MethodHead, common.UploadPathSync, nil)
+		Expect(err).ToNot(HaveOccurred())
+		rr := httptest.NewRecorder()
+		server := newServer()
+		server.ServeHTTP(rr, req)
+		Expect(rr.Code).To(Equal(http.StatusOK))
+		Expect(rr.Header().Get("Accept-Encoding")).To(Equal("gzip, zstd"))
+		Expect(server.uploading).To(BeFalse())
+	})
+
+	DescribeTable("should decompress clones", func(contentType, encoding string, encode func([]byte) []byte) {
+		var received []byte
+		replaceProcessorFunc(func(stream io.ReadCloser, dest, imageSize string, filesystemOverhead float64, preallocation bool, contentType string, dvContentType cdiv1.DataVolumeContentType, ovaDisk string, streaming bool, storageFormat string) (bool, error) {
+			var err error
+			received, err = io.ReadAll(stream)
+			return false, err
+		}, func() {
+			req := newBodyRequest(common.UploadPathSync, encode)
+			req.Header.Set(common.UploadContentTypeHeader, contentType)
+			if encoding != "" {
+				req.Header.Set("Content-Encoding", encoding)
+			}
+			rr := httptest.NewRecorder()
+			server := newServer()
+			server.ServeHTTP(rr, req)
+			Expect(rr.Code).To(Equal(http.StatusOK))
+			Expect(received).To(Equal([]byte("data")))
+		})
+	},
+		Entry("compressed with snappy", common.BlockdeviceClone, "", snappyEncode),
+		Entry("compressed with zstd instead of snappy", common.BlockdeviceClone, "zstd", zstdEncode),
+		Entry("of disk images converted onto the target", common.ImageCloneContentType, "", snappyEncode),
+	)
+
+	DescribeTable("should refuse uploads with content encoding", func(encoding string, expectedStatus int) {
+		withProcessorSuccess(func() {
+			req := newBodyRequest(common.UploadPathSync, identityEncode)
+			req.Header.Set("Content-Encoding", encoding)
+			rr := httptest.NewRecorder()
+			server := newServer()
+			server.ServeHTTP(rr, req)
+			Expect(rr.Code).To(Equal(expectedStatus))
+			Expect(server.uploading).To(BeFalse())
+		})
+	},
+		Entry("not supported", "br", http.StatusUnsupportedMediaType),
+		Entry("not matching the data", "gzip", http.StatusBadRequest),
+	)
+
 	DescribeTable("Stream fail form", func(processorFunc func(func()), uploadPath string) {
 		processorFunc(func() {
 			req := newFormRequest(uploadPath)
@@ -434,6 +606,39 @@ var _ = Describe("Upload server tests", func() {
 			Fail("Timed out waiting for server to exit")
 		}
 	})
+
+	It("should serve the rotated server cert without restarting", func() {
+		server, _, _, cleanup := newTLSServer("client", "client")
+		defer cleanup()
+
+		ch := make(chan struct{})
+		go func() {
+			_, _ = server.Run()
+			close(ch)
+		}()
+		Eventually(func() int { return server.config.BindPort }, 5*time.Second, 100*time.Millisecond).ShouldNot(Equal(0))
+
+		servedCert := func() *x509.Certificate {
+			//nolint:gosec // only the served cert is checked
+			conn, err := tls.Dial("tcp", fmt.Sprintf("localhost:%d", server.config.BindPort), &tls.Config{InsecureSkipVerify: true})
+			Expect(err).ToNot(HaveOccurred())
+			defer conn.Close()
+			return conn.ConnectionState().PeerCertificates[0]
+		}
+		Expect(servedCert().Issuer.CommonName).To(Equal("server"))
+
+		rotatedCA, err := triple.NewCA("rotated")
+		Expect(err).ToNot(HaveOccurred())
+		rotatedKeyPair, err := triple.NewServerKeyPair(rotatedCA, "localhost", "localhost", "default", "local", []string{"127.0.0.1"}, []string{"localhost"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(os.WriteFile(server.config.ServerKeyFile, cert.EncodePrivateKeyPEM(rotatedKeyPair.Key), 0600)).To(Succeed())
+		Expect(os.WriteFile(server.config.ServerCertFile, cert.EncodeCertPEM(rotatedKeyPair.Cert), 0600)).To(Succeed())
+
+		Expect(servedCert().Issuer.CommonName).To(Equal("rotated"))
+
+		close(server.doneChan)
+		<-ch
+	})
 })
 
 func newFormRequest(path string) *http.Request {
@@ -456,3 +661,48 @@ func newFormRequest(path string) *http.Request {
 
 	return req
 }
+
+func newBodyRequest(path string, encode func([]byte) []byte) *http.Request {
+	req, err := http.NewRequest(http.MethodPost, path, bytes.NewReader(encode([]byte("data"))))
+	Expect(err).ToNot(HaveOccurred())
+	return req
+}
+
+// newEncodedFormRequest returns the request of newFormRequest with its whole body encoded
+func newEncodedFormRequest(path string, encode func([]byte) []byte) *http.Request {
+	req := newFormRequest(path)
+	data, err := io.ReadAll(req.Body)
+	Expect(err).ToNot(HaveOccurred())
+	req.Body = io.NopCloser(bytes.NewReader(encode(data)))
+	req.ContentLength = -1
+	return req
+}
+
+func identityEncode(data []byte) []byte {
+	return data
+}
+
+func gzipEncode(data []byte) []byte {
+	var b bytes.Buffer
+	w := gzip.NewWriter(&b)
+	_, err := w.Write(data)
+	Expect(err).ToNot(HaveOccurred())
+	Expect(w.Close()).To(Succeed())
+	return b.Bytes()
+}
+
+func snappyEncode(data []byte) []byte {
+	var b bytes.Buffer
+	w := snappy.NewBufferedWriter(&b)
+	_, err := w.Write(data)
+	Expect(err).ToNot(HaveOccurred())
+	Expect(w.Close()).To(Succeed())
+	return b.Bytes()
+}
+
+func zstdEncode(data []byte) []byte {
+	w, err := zstd.NewWriter(nil)
+	Expect(err).ToNot(HaveOccurred())
+	defer w.Close()
+	return w.EncodeAll(data, nil)
+}
@@ -0,0 +1,187 @@
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+)
+
+// webhookTimeout is the time the audit events have to be accepted by the webhook
+const webhookTimeout = 10 * time.Second
+
+// Action is the audited action on a token
+type Action string
+
+const (
+	// ActionIssueUploadToken is the issuance of an upload token by the upload token API
+	ActionIssueUploadToken Action = "IssueUploadToken"
+	// ActionIssueCloneToken is the issuance of a clone token to a DataVolume cloning a source
+	ActionIssueCloneToken Action = "IssueCloneToken"
+	// ActionUseUploadToken is the use of an upload token by a request to the upload proxy
+	ActionUseUploadToken Action = "UseUploadToken"
+)
+
+// Outcome is the outcome of an audited action
+type Outcome string
+
+const (
+	// OutcomeSuccess is the outcome of the actions that succeeded
+	OutcomeSuccess Outcome = "Success"
+	// OutcomeDenied is the outcome of the actions the requester is not allowed to do
+	OutcomeDenied Outcome = "Denied"
+	// OutcomeFailure is the outcome of the actions that failed
+	OutcomeFailure Outcome = "Failure"
+)
+
+// Event is the audit event of an action on a token
+type Event struct {
+	Time      time.Time `json:"time"`
+	Component string    `json:"component"`
+	Action    Action    `json:"action"`
+	Outcome   Outcome   `json:"outcome"`
+	// Reason tells why the action was denied or failed
+	Reason string `json:"reason,omitempty"`
+	// User is the user requesting the token, unknown for token uses
+	User     string `json:"user,omitempty"`
+	SourceIP string `json:"sourceIP,omitempty"`
+	// Kind, Namespace and Name are the object of the token, unknown for invalid tokens
+	Kind      string `json:"kind,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name,omitempty"`
+	// Grant is the namespace/name of the CloneGrant authorizing a clone token
+	Grant string `json:"grant,omitempty"`
+}
+
+// Auditor emits the audit events of tokens to the sink of the TokenAudit of the CDIConfig. A nil Auditor emits no
+// event.
+type Auditor struct {
+	component  string
+	recorder   record.EventRecorder
+	httpClient *http.Client
+
+	mutex  sync.Mutex
+	config *cdiv1.TokenAuditConfig
+}
+
+// NewAuditor returns the auditor of component, recording the events of the Event sink with client
+func NewAuditor(client kubernetes.Interface, component string) *Auditor {
+	broadcaster := record.NewBroadcaster()
+	broadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: client.CoreV1().Events("")})
+	return &Auditor{
+		component:  component,
+		recorder:   broadcaster.NewRecorder(scheme.Scheme, v1.EventSource{Component: component}),
+		httpClient: &http.Client{Timeout: webhookTimeout},
+	}
+}
+
+// Update applies the TokenAudit of the CDIConfig, nil disabling the audit
+func (a *Auditor) Update(config *cdiv1.TokenAuditConfig) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	if config != nil {
+		config = config.DeepCopy()
+	}
+	if config != nil && config.Sink == "" {
+		config.Sink = cdiv1.TokenAuditSinkLog
+	}
+	a.config = config
+}
+
+// Emit emits event to the sink if the audit is enabled. Events are posted to webhooks in the background, so emitting
+// never holds up the request audited.
+func (a *Auditor) Emit(event Event) {
+	if a == nil {
+		return
+	}
+	a.mutex.Lock()
+	config := a.config
+	a.mutex.Unlock()
+	if config == nil {
+		return
+	}
+
+	if event.Time.IsZero() {
+		event.Time = time.Now().UTC()
+	}
+	event.Component = a.component
+
+	switch config.Sink {
+	case cdiv1.TokenAuditSinkEvent:
+		a.record(event)
+	case cdiv1.TokenAuditSinkWebhook:
+		go a.post(config.WebhookURL, event)
+	default:
+		a.log(event)
+	}
+}
+
+func (a *Auditor) log(event Event) {
+	data, err := json.Marshal(event)
+	if err != nil {
+		klog.Errorf("Unable to marshal token audit event: %v", err)
+		return
+	}
+	klog.Infof("Token audit event: %s", data)
+}
+
+// record records event as a Kubernetes event of the object of the token, events without object are logged
+func (a *Auditor) record(event Event) {
+	if event.Kind == "" || event.Name == "" {
+		a.log(event)
+		return
+	}
+	eventType := v1.EventTypeNormal
+	if event.Outcome != OutcomeSuccess {
+		eventType = v1.EventTypeWarning
+	}
+	message := fmt.Sprintf("%s %s", event.Action, event.Outcome)
+	if event.User != "" {
+		message += " for user " + event.User
+	}
+	if event.SourceIP != "" {
+		message += " from " + event.SourceIP
+	}
+	if event.Grant != "" {
+		message += " granted by CloneGrant " + event.Grant
+	}
+	if event.Reason != "" {
+		message += ": " + event.Reason
+	}
+	ref := &v1.ObjectReference{Kind: event.Kind, Namespace: event.Namespace, Name: event.Name}
+	a.recorder.Event(ref, eventType, string(event.Action), message)
+}
+
+func (a *Auditor) post(url string, event Event) {
+	data, err := json.Marshal(event)
+	if err != nil {
+		klog.Errorf("Unable to marshal token audit event: %v", err)
+		return
+	}
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		klog.Errorf("Unable to post token audit event %s: %v", data, err)
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := a.httpClient.Do(req)
+	if err != nil {
+		klog.Errorf("Unable to post token audit event %s: %v", data, err)
+		return
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		klog.Errorf("Token audit webhook refused event %s with status %d", data, resp.StatusCode)
+	}
+}
@@ -0,0 +1,99 @@
+package audit
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
+
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+)
+
+var _ = Describe("Token audit", func() {
+	var (
+		auditor  *Auditor
+		recorder *record.FakeRecorder
+	)
+
+	event := Event{
+		Action:    ActionIssueUploadToken,
+		Outcome:   OutcomeDenied,
+		Reason:    "bad person",
+		User:      "alice",
+		SourceIP:  "10.0.0.1",
+		Kind:      "PersistentVolumeClaim",
+		Namespace: "default",
+		Name:      "test-pvc",
+	}
+
+	BeforeEach(func() {
+		auditor = NewAuditor(fake.NewSimpleClientset(), "cdi-apiserver")
+		recorder = record.NewFakeRecorder(10)
+		auditor.recorder = recorder
+	})
+
+	It("should emit no event until enabled", func() {
+		auditor.Emit(event)
+		Expect(recorder.Events).ToNot(Receive())
+		var nilAuditor *Auditor
+		nilAuditor.Emit(event)
+	})
+
+	DescribeTable("should record the events of the Event sink", func(event Event, expected string) {
+		auditor.Update(&cdiv1.TokenAuditConfig{Sink: cdiv1.TokenAuditSinkEvent})
+		auditor.Emit(event)
+		Expect(recorder.Events).To(Receive(Equal(expected)))
+	},
+		Entry("denied", event, "Warning IssueUploadToken IssueUploadToken Denied for user alice from 10.0.0.1: bad person"),
+		Entry("successful", Event{
+			Action:    ActionUseUploadToken,
+			Outcome:   OutcomeSuccess,
+			Kind:      "PersistentVolumeClaim",
+			Namespace: "default",
+			Name:      "test-pvc",
+		}, "Normal UseUploadToken UseUploadToken Success"),
+	)
+
+	It("should log the events without object of the Event sink", func() {
+		auditor.Update(&cdiv1.TokenAuditConfig{Sink: cdiv1.TokenAuditSinkEvent})
+		auditor.Emit(Event{Action: ActionUseUploadToken, Outcome: OutcomeDenied})
+		Expect(recorder.Events).ToNot(Receive())
+	})
+
+	It("should post the events of the Webhook sink", func() {
+		received := make(chan Event, 1)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer GinkgoRecover()
+			Expect(r.Method).To(Equal(http.MethodPost))
+			Expect(r.Header.Get("Content-Type")).To(Equal("application/json"))
+			var posted Event
+			Expect(json.NewDecoder(r.Body).Decode(&posted)).To(Succeed())
+			received <- posted
+		}))
+		defer server.Close()
+
+		auditor.Update(&cdiv1.TokenAuditConfig{Sink: cdiv1.TokenAuditSinkWebhook, WebhookURL: server.URL})
+		auditor.Emit(event)
+		var posted Event
+		Eventually(received).Should(Receive(&posted))
+		Expect(posted.Time).ToNot(BeZero())
+		Expect(posted.Component).To(Equal("cdi-apiserver"))
+		posted.Time, posted.Component = event.Time, event.Component
+		Expect(posted).To(Equal(event))
+		Expect(recorder.Events).ToNot(Receive())
+	})
+
+	It("should log the events by default", func() {
+		auditor.Update(&cdiv1.TokenAuditConfig{})
+		Expect(auditor.config.Sink).To(Equal(cdiv1.TokenAuditSinkLog))
+		auditor.Emit(event)
+		Expect(recorder.Events).ToNot(Receive())
+		auditor.Update(nil)
+		Expect(auditor.config).To(BeNil())
+	})
+})
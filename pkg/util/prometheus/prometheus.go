@@ -30,6 +30,11 @@ type ProgressMetric interface {
 	Delete()
 }
 
+// BytesMetric is implemented by the progress metrics also reporting the bytes read
+type BytesMetric interface {
+	SetBytes(transferred, total uint64)
+}
+
 // NewProgressReader creates a new instance of a prometheus updating progress reader.
 func NewProgressReader(r io.ReadCloser, metric ProgressMetric, total uint64) *ProgressReader {
 	promReader := &ProgressReader{
@@ -62,6 +67,9 @@ func (r *ProgressReader) timedUpdateProgress() {
 
 func (r *ProgressReader) updateProgress() bool {
 	if r.total > 0 {
+		if bm, ok := r.metric.(BytesMetric); ok {
+			bm.SetBytes(r.Current, r.total)
+		}
 		finished := r.final && r.Done
 		currentProgress := 100.0
 		if !finished && r.Current < r.total {
@@ -162,4 +162,30 @@ var _ = Describe("Update Progress", func() {
 		Expect(promReader.CountingReader.Current).To(Equal(uint64(16)))
 		Expect(false).To(Equal(result))
 	})
+
+	It("should report the bytes read to metrics supporting it", func() {
+		bytesMetric := &fakeBytesMetric{ProgressMetric: progressMetric}
+		promReader := &ProgressReader{
+			CountingReader: util.CountingReader{
+				Current: uint64(45),
+			},
+			metric: bytesMetric,
+			total:  uint64(100),
+			final:  true,
+		}
+		promReader.updateProgress()
+		Expect(bytesMetric.transferred).To(Equal(uint64(45)))
+		Expect(bytesMetric.total).To(Equal(uint64(100)))
+	})
 })
+
+type fakeBytesMetric struct {
+	ProgressMetric
+	transferred uint64
+	total       uint64
+}
+
+func (m *fakeBytesMetric) SetBytes(transferred, total uint64) {
+	m.transferred = transferred
+	m.total = total
+}
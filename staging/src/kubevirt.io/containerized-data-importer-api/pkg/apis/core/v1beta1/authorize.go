@@ -88,6 +88,28 @@ func (dv *DataVolume) AuthorizeUser(requestNamespace, requestName string, proxy
 		return CloneAuthResponse{Allowed: false, Reason: reason, Handler: cloneSourceHandler}, err
 	}
 
+	if !ok && cloneSourceHandler.CloneType == snapshotClone {
+		granted, err := isSnapshotGranted(proxy, sourceNamespace, sourceName, targetNamespace)
+		if err != nil {
+			return CloneAuthResponse{Allowed: false, Reason: reason, Handler: cloneSourceHandler}, err
+		}
+		if granted {
+			ok, reason = true, ""
+		}
+	}
+
+	var grant string
+	if !ok {
+		saNamespace, saName := serviceAccountOf(userInfo)
+		grant, err = isCloneGranted(proxy, cloneSourceHandler.CloneType, sourceNamespace, sourceName, targetNamespace, saNamespace, saName)
+		if err != nil {
+			return CloneAuthResponse{Allowed: false, Reason: reason, Handler: cloneSourceHandler}, err
+		}
+		if grant != "" {
+			ok, reason = true, ""
+		}
+	}
+
 	if !ok {
 		if noTokenOkay {
 			klog.V(3).Infof("DataVolume %s/%s is pre/static populated, not adding token, auth failed", targetNamespace, targetName)
@@ -95,7 +117,7 @@ func (dv *DataVolume) AuthorizeUser(requestNamespace, requestName string, proxy
 		}
 	}
 
-	return CloneAuthResponse{Allowed: ok, Reason: reason, Handler: cloneSourceHandler}, err
+	return CloneAuthResponse{Allowed: ok, Reason: reason, Handler: cloneSourceHandler, Grant: grant}, err
 }
 
 // AuthorizeSA indicates if the creating ServiceAccount is authorized to create the data volume
@@ -151,6 +173,27 @@ func (dv *DataVolume) AuthorizeSA(requestNamespace, requestName string, proxy Au
 		return CloneAuthResponse{Allowed: false, Reason: reason, Handler: cloneSourceHandler}, err
 	}
 
+	if !ok && cloneSourceHandler.CloneType == snapshotClone {
+		granted, err := isSnapshotGranted(proxy, sourceNamespace, sourceName, targetNamespace)
+		if err != nil {
+			return CloneAuthResponse{Allowed: false, Reason: reason, Handler: cloneSourceHandler}, err
+		}
+		if granted {
+			ok, reason = true, ""
+		}
+	}
+
+	var grant string
+	if !ok {
+		grant, err = isCloneGranted(proxy, cloneSourceHandler.CloneType, sourceNamespace, sourceName, targetNamespace, saNamespace, saName)
+		if err != nil {
+			return CloneAuthResponse{Allowed: false, Reason: reason, Handler: cloneSourceHandler}, err
+		}
+		if grant != "" {
+			ok, reason = true, ""
+		}
+	}
+
 	if !ok {
 		if noTokenOkay {
 			klog.V(3).Infof("DataVolume %s/%s is pre/static populated, not adding token, auth failed", targetNamespace, targetName)
@@ -158,5 +201,5 @@ func (dv *DataVolume) AuthorizeSA(requestNamespace, requestName string, proxy Au
 		}
 	}
 
-	return CloneAuthResponse{Allowed: ok, Reason: reason, Handler: cloneSourceHandler}, err
+	return CloneAuthResponse{Allowed: ok, Reason: reason, Handler: cloneSourceHandler, Grant: grant}, err
 }
@@ -18,6 +18,9 @@ package v1beta1
 
 import (
 	"fmt"
+	"slices"
+	"strings"
+	"time"
 
 	authentication "k8s.io/api/authentication/v1"
 	authorization "k8s.io/api/authorization/v1"
@@ -122,6 +125,8 @@ type CloneAuthResponse struct {
 	Handler CloneSourceHandler
 	Allowed bool
 	Reason  string
+	// Grant is the namespace/name of the CloneGrant authorizing the clone, if any
+	Grant string
 }
 
 type createSarFunc func(*authorization.SubjectAccessReview) (*authorization.SubjectAccessReview, error)
@@ -134,6 +139,18 @@ type AuthorizationHelperProxy interface {
 	GetDataSource(string, string) (*DataSource, error)
 }
 
+// VolumeSnapshotGrantLister is implemented by the AuthorizationHelperProxies able to list VolumeSnapshotGrants,
+// allowing DataVolumes to import the snapshots of other namespaces granted to them
+type VolumeSnapshotGrantLister interface {
+	ListVolumeSnapshotGrants(namespace string) ([]VolumeSnapshotGrant, error)
+}
+
+// CloneGrantLister is implemented by the AuthorizationHelperProxies able to list CloneGrants, allowing DataVolumes
+// to clone the PVCs and snapshots of other namespaces granted to them
+type CloneGrantLister interface {
+	ListCloneGrants(namespace string) ([]CloneGrant, error)
+}
+
 // UserCloneAuthFunc represents a user clone auth func
 type UserCloneAuthFunc func(createSar createSarFunc, sourceNamespace, pvcName, targetNamespace string, userInfo authentication.UserInfo) (bool, string, error)
 
@@ -228,6 +245,71 @@ func CanServiceAccountCloneSnapshot(createSar createSarFunc, pvcNamespace, pvcNa
 	return sendSubjectAccessReviewsSnapshot(createSar, pvcNamespace, pvcName, sarSpec)
 }
 
+// isSnapshotGranted checks if a VolumeSnapshotGrant of the namespace of the snapshot allows the DataVolumes of
+// targetNamespace to import it
+func isSnapshotGranted(proxy AuthorizationHelperProxy, snapshotNamespace, snapshotName, targetNamespace string) (bool, error) {
+	lister, ok := proxy.(VolumeSnapshotGrantLister)
+	if !ok {
+		return false, nil
+	}
+	grants, err := lister.ListVolumeSnapshotGrants(snapshotNamespace)
+	if err != nil {
+		return false, err
+	}
+	for _, grant := range grants {
+		if !slices.Contains(grant.Spec.Namespaces, targetNamespace) {
+			continue
+		}
+		if len(grant.Spec.Snapshots) == 0 || slices.Contains(grant.Spec.Snapshots, snapshotName) {
+			klog.V(3).Infof("VolumeSnapshotGrant %s/%s allows namespace %s to import snapshot %s", snapshotNamespace, grant.Name, targetNamespace, snapshotName)
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// isCloneGranted checks if a CloneGrant of the namespace of the source allows targetNamespace, or the ServiceAccount
+// saNamespace/saName, to clone it and returns the namespace/name of that grant
+func isCloneGranted(proxy AuthorizationHelperProxy, sourceType cloneType, sourceNamespace, sourceName, targetNamespace, saNamespace, saName string) (string, error) {
+	lister, ok := proxy.(CloneGrantLister)
+	if !ok {
+		return "", nil
+	}
+	kind := CloneGrantSourcePVC
+	if sourceType == snapshotClone {
+		kind = CloneGrantSourceSnapshot
+	}
+	grants, err := lister.ListCloneGrants(sourceNamespace)
+	if err != nil {
+		return "", err
+	}
+	now := time.Now()
+	for _, grant := range grants {
+		if grant.Spec.ExpirationTime != nil && !now.Before(grant.Spec.ExpirationTime.Time) {
+			continue
+		}
+		granted := slices.Contains(grant.Spec.Namespaces, targetNamespace) ||
+			slices.Contains(grant.Spec.ServiceAccounts, CloneGrantServiceAccount{Namespace: saNamespace, Name: saName})
+		if !granted {
+			continue
+		}
+		if len(grant.Spec.Sources) == 0 || slices.Contains(grant.Spec.Sources, CloneGrantSource{Kind: kind, Name: sourceName}) {
+			klog.V(3).Infof("CloneGrant %s/%s allows namespace %s to clone %s %s", sourceNamespace, grant.Name, targetNamespace, kind, sourceName)
+			return sourceNamespace + "/" + grant.Name, nil
+		}
+	}
+	return "", nil
+}
+
+// serviceAccountOf returns the namespace and name of the ServiceAccount a user authenticates as, if any
+func serviceAccountOf(userInfo authentication.UserInfo) (string, string) {
+	parts := strings.Split(userInfo.Username, ":")
+	if len(parts) != 4 || parts[0] != "system" || parts[1] != "serviceaccount" {
+		return "", ""
+	}
+	return parts[2], parts[3]
+}
+
 func sendSubjectAccessReviewsPvc(createSar createSarFunc, namespace, name string, sarSpec authorization.SubjectAccessReviewSpec) (bool, string, error) {
 	allowed := false
 
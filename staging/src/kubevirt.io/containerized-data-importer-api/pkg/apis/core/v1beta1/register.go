@@ -54,6 +54,22 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&VolumeUploadSourceList{},
 		&VolumeCloneSource{},
 		&VolumeCloneSourceList{},
+		&ImageVerificationPolicy{},
+		&ImageVerificationPolicyList{},
+		&VolumeSnapshotGrant{},
+		&VolumeSnapshotGrantList{},
+		&CloneGrant{},
+		&CloneGrantList{},
+		&ImportQuota{},
+		&ImportQuotaList{},
+		&MultiDataVolume{},
+		&MultiDataVolumeList{},
+		&DataVolumeReplication{},
+		&DataVolumeReplicationList{},
+		&DataVolumeMigration{},
+		&DataVolumeMigrationList{},
+		&DataExport{},
+		&DataExportList{},
 	)
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
 	return nil
@@ -18,6 +18,7 @@ package v1beta1
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	sdkapi "kubevirt.io/controller-lifecycle-operator-sdk/api"
 )
@@ -50,6 +51,10 @@ type DataVolumeSpec struct {
 	//SourceRef is an indirect reference to the source of data for the requested DataVolume
 	// +optional
 	SourceRef *DataVolumeSourceRef `json:"sourceRef,omitempty"`
+	// SourceFallbacks is an ordered list of alternative sources of the data, like mirrors of an image. Each time the
+	// importer fails to import from the source, the import is retried from the next fallback.
+	// +optional
+	SourceFallbacks []DataVolumeSourceFallback `json:"sourceFallbacks,omitempty"`
 	//PVC is the PVC specification
 	PVC *corev1.PersistentVolumeClaimSpec `json:"pvc,omitempty"`
 	// Storage is the requested storage specification
@@ -65,8 +70,237 @@ type DataVolumeSpec struct {
 	FinalCheckpoint bool `json:"finalCheckpoint,omitempty"`
 	// Preallocation controls whether storage for DataVolumes should be allocated in advance.
 	Preallocation *bool `json:"preallocation,omitempty"`
+	// RetryPolicy controls how failed imports are retried. Without one the importer pod is restarted until the import
+	// succeeds.
+	// +optional
+	RetryPolicy *DataVolumeRetryPolicy `json:"retryPolicy,omitempty"`
+	// Paused suspends the import until it is unset. Downloads to scratch space then continue where they stopped, other
+	// imports start over.
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+	// TTLAfterCompletion is how long the DataVolume is kept once it succeeded before it is deleted, along with its PVC.
+	// Kept until deleted if unset
+	// +optional
+	TTLAfterCompletion *metav1.Duration `json:"ttlAfterCompletion,omitempty"`
+	// FailureRetentionPolicy controls whether the DataVolume is kept once it failed, Retain if unset
+	// +optional
+	FailureRetentionPolicy *DataVolumeFailureRetentionPolicy `json:"failureRetentionPolicy,omitempty"`
+	// PodOverrides replaces the CDI-wide scheduling and resource settings of the importer pod of the DataVolume
+	// +optional
+	PodOverrides *DataVolumePodOverrides `json:"podOverrides,omitempty"`
+	// TransferPriority orders the DataVolumes waiting for a transfer slot once the transfer limits of the CDIConfig are
+	// reached, higher first, 0 if unset
+	// +optional
+	TransferPriority *int32 `json:"transferPriority,omitempty"`
+	// PostCompletionHooks are run in order once the DataVolume is populated, before it succeeds. The DataVolume fails
+	// if one of them fails
+	// +optional
+	PostCompletionHooks []DataVolumeHook `json:"postCompletionHooks,omitempty"`
+	// ValidateOnly makes the importer check the source is reachable with its credentials and its image fits the
+	// requested storage, in a pod without any PVC. No PVC is created. The results are reported in the validation of the status
+	// +optional
+	ValidateOnly bool `json:"validateOnly,omitempty"`
+	// ExpandFilesystem grows the last partition of the imported image and its ext2/3/4, xfs or btrfs filesystem to fill
+	// the PVC when it is larger than the image, so guests see the whole volume without resizing it on boot
+	// +optional
+	ExpandFilesystem bool `json:"expandFilesystem,omitempty"`
+	// Topology hints the topology domain the consumer of the DataVolume runs in. On WaitForFirstConsumer storage classes
+	// the import is then scheduled in that domain without waiting for the consumer
+	// +optional
+	Topology *DataVolumeTopology `json:"topology,omitempty"`
+	// ScratchSpace replaces the CDI-wide storage class and the size of the scratch space PVC of the import or upload of
+	// the DataVolume
+	// +optional
+	ScratchSpace *DataVolumeScratchSpace `json:"scratchSpace,omitempty"`
+	// Verify checks the disk image written to the PVC before the DataVolume succeeds, and optionally compares it with
+	// its source
+	// +optional
+	Verify *DataVolumeVerify `json:"verify,omitempty"`
+	// Deduplicate clones the PVC of a DataVolume of the namespace that imported the same source to the same storage
+	// class, instead of importing it again. The source must be pinned, by the digest of a registry image or the checksum
+	// of a http, s3 or gcs source. The PVCs of deduplicating DataVolumes are cloned as long as they exist, so they should
+	// be kept as imported, like golden images. Requires CDI populators
+	// +optional
+	Deduplicate bool `json:"deduplicate,omitempty"`
+	// Proxy replaces the importProxy of the CDIConfig for the import of the DataVolume
+	// +optional
+	Proxy *DataVolumeProxy `json:"proxy,omitempty"`
+	// CloneTransferMode is how the data of host-assisted clones of the DataVolume is transferred, tar if unset
+	// +optional
+	CloneTransferMode *CloneTransferMode `json:"cloneTransferMode,omitempty"`
+	// CloneCompression is how the data of host-assisted clones of the DataVolume is compressed on the network, the
+	// cloneCompression of the StorageProfile of the target if unset
+	// +optional
+	CloneCompression *CloneCompression `json:"cloneCompression,omitempty"`
+	// CloneStrategy is the strategy of the clone of the DataVolume: snapshot, csi-clone, reflink or copy. It takes
+	// precedence over the cloneStrategy of the StorageProfile of the target
+	// +optional
+	CloneStrategy *CDICloneStrategy `json:"cloneStrategy,omitempty"`
+	// CloneStrategyFallbacks is an ordered list of the strategies tried when the clone strategy can't be used. Once the
+	// clone strategy or its fallbacks are set, the clone only uses the listed strategies instead of falling back to copy
+	// +optional
+	CloneStrategyFallbacks []CDICloneStrategy `json:"cloneStrategyFallbacks,omitempty"`
+	// CloneFormat is the format the cloned image is stored in on a filesystem target, qcow2 compresses it. Once set, the
+	// clone is host-assisted and its target converts the image and resizes it to the requested storage as it receives it
+	// +kubebuilder:validation:Enum=raw;qcow2
+	// +optional
+	CloneFormat *DataVolumeTransformationFormat `json:"cloneFormat,omitempty"`
+	// CloneGroup fans out the source of the clone to the DataVolumes of the same group cloning it: their snapshot clones
+	// restore a single snapshot of the source, taken by the first of them
+	// +optional
+	CloneGroup string `json:"cloneGroup,omitempty"`
+}
+
+// DataVolumeProxy is the proxy configuration of the import of a DataVolume. The fields left unset are not used, so an
+// empty proxy has the import reach its source directly
+type DataVolumeProxy struct {
+	// HTTPProxy is the URL http://<username>:<pswd>@<ip>:<port> of the proxy for HTTP requests
+	// +optional
+	HTTPProxy string `json:"httpProxy,omitempty"`
+	// HTTPSProxy is the URL https://<username>:<pswd>@<ip>:<port> of the proxy for HTTPS requests
+	// +optional
+	HTTPSProxy string `json:"httpsProxy,omitempty"`
+	// NoProxy is a comma-separated list of hostnames and/or CIDRs for which the proxy is not used
+	// +optional
+	NoProxy string `json:"noProxy,omitempty"`
+	// TrustedCAProxy is the name of a ConfigMap in the namespace of the DataVolume holding the trusted certificate
+	// authority (CA) bundle of the proxy, like the trustedCAProxy of the CDIConfig
+	// +optional
+	TrustedCAProxy string `json:"trustedCAProxy,omitempty"`
 }
 
+// DataVolumeVerify defines the checks of an imported disk image, run once it is written to the PVC. qemu-img checks
+// the consistency of the image
+type DataVolumeVerify struct {
+	// Checksum compares the content of the disk image with the source it was converted from, which is downloaded to
+	// scratch space and checked against its checksum first. Requires a http, s3 or gcs source with a checksum
+	// +optional
+	Checksum bool `json:"checksum,omitempty"`
+}
+
+// DataVolumeScratchSpace holds the settings of the scratch space PVC of a DataVolume that replace the CDI-wide ones. At
+// most one of size and sizeMultiplier may be set
+type DataVolumeScratchSpace struct {
+	// StorageClassName replaces the scratchSpaceStorageClass of the CDIConfig for the scratch space PVC
+	// +optional
+	StorageClassName *string `json:"storageClassName,omitempty"`
+	// Size is the storage requested by the scratch space PVC, instead of the size of the target PVC
+	// +optional
+	Size *resource.Quantity `json:"size,omitempty"`
+	// SizeMultiplier scales the storage requested by the scratch space PVC, like "1.5" for images growing on
+	// conversion, or "0.5" for sources known to be compressed
+	// +optional
+	SizeMultiplier string `json:"sizeMultiplier,omitempty"`
+}
+
+// DataVolumeTopology is the topology domain the consumer of a DataVolume runs in, from a node selector, a
+// VirtualMachineInstance or both
+type DataVolumeTopology struct {
+	// NodeSelector selects the nodes of the topology domain, like with the topology.kubernetes.io/zone label
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	// VirtualMachineInstance is the name of the VirtualMachineInstance consuming the DataVolume, in its namespace.
+	// Its node, or else its node selector and required node affinity, place the import. The import waits for it to exist
+	// +optional
+	VirtualMachineInstance string `json:"virtualMachineInstance,omitempty"`
+}
+
+// DataVolumeHook is a step customizing the data of a populated DataVolume, like sysprep or license injection. Exactly
+// one of Job and Webhook is set
+type DataVolumeHook struct {
+	// Name identifies the hook in the status of the DataVolume, unique among the hooks of the DataVolume
+	Name string `json:"name"`
+	// Job runs the hook as a Job with the PVC of the DataVolume mounted
+	// +optional
+	Job *DataVolumeHookJob `json:"job,omitempty"`
+	// Webhook runs the hook by calling a URL
+	// +optional
+	Webhook *DataVolumeHookWebhook `json:"webhook,omitempty"`
+}
+
+// DataVolumeHookJob is a hook run as a Job. The PVC is mounted in /data for filesystem volumes and attached as
+// /dev/cdi-block-volume for block ones, the CDI_DATA_PATH, CDI_DATAVOLUME_NAME, CDI_DATAVOLUME_NAMESPACE and
+// CDI_HOOK_NAME environment variables describe the DataVolume
+type DataVolumeHookJob struct {
+	// Image is the container image running the hook
+	Image string `json:"image"`
+	// Command is the entrypoint of the container, the one of the image if unset
+	// +optional
+	Command []string `json:"command,omitempty"`
+	// Args are the arguments of the entrypoint
+	// +optional
+	Args []string `json:"args,omitempty"`
+	// BackoffLimit is the number of retries of the Job before the hook fails, the Job default if unset
+	// +optional
+	BackoffLimit *int32 `json:"backoffLimit,omitempty"`
+}
+
+// DataVolumeHookWebhook is a hook run by a POST request, whose JSON body holds the namespace, dataVolume, claimName
+// and hook names. The hook succeeds if the response status is 2xx
+type DataVolumeHookWebhook struct {
+	// URL is the http or https URL receiving the request, starting with one of the postCompletionHookURLs of the
+	// CDIConfig
+	URL string `json:"url"`
+}
+
+// DataVolumePodOverrides holds the settings of the importer pod of a DataVolume that replace the CDI-wide ones
+type DataVolumePodOverrides struct {
+	// Resources replaces the resource requirements of the importer pod containers set in the CDIConfig
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+	// NodeSelector replaces the node selector of the CDI workloads for the importer pod
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	// Tolerations replaces the tolerations of the CDI workloads for the importer pod
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+	// Affinity replaces the affinity of the CDI workloads for the importer pod
+	// +optional
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+}
+
+// DataVolumeFailureRetentionPolicy defines what happens to a DataVolume once it failed
+// +kubebuilder:validation:Enum=Retain;Delete
+type DataVolumeFailureRetentionPolicy string
+
+const (
+	// FailureRetentionRetain keeps the failed DataVolume until it is deleted, for debugging
+	FailureRetentionRetain DataVolumeFailureRetentionPolicy = "Retain"
+	// FailureRetentionDelete deletes the failed DataVolume once its ttlAfterCompletion passed, like a succeeded one
+	FailureRetentionDelete DataVolumeFailureRetentionPolicy = "Delete"
+)
+
+// DataVolumeRetryPolicy controls how the failed imports of a DataVolume are retried
+type DataVolumeRetryPolicy struct {
+	// MaxRetries is the number of times a failed import is retried before the DataVolume fails, unlimited if unset
+	// +optional
+	MaxRetries *int32 `json:"maxRetries,omitempty"`
+	// BackoffBase is the delay before the first retry, doubled for each retry after it, 10s if unset
+	// +optional
+	BackoffBase *metav1.Duration `json:"backoffBase,omitempty"`
+	// BackoffCap is the longest delay between two retries, 5m if unset
+	// +optional
+	BackoffCap *metav1.Duration `json:"backoffCap,omitempty"`
+	// RetryOn lists the classes of errors the import is retried on, all of them if empty
+	// +optional
+	RetryOn []DataVolumeRetryErrorClass `json:"retryOn,omitempty"`
+}
+
+// DataVolumeRetryErrorClass is a class of the errors failing imports
+// +kubebuilder:validation:Enum=Network;Source;Storage;Other
+type DataVolumeRetryErrorClass string
+
+const (
+	// RetryOnNetwork is the class of the errors reaching or reading the source, like timeouts or refused connections
+	RetryOnNetwork DataVolumeRetryErrorClass = "Network"
+	// RetryOnSource is the class of the errors of the source itself, like missing, forbidden or invalid images
+	RetryOnSource DataVolumeRetryErrorClass = "Source"
+	// RetryOnStorage is the class of the errors writing the target, like running out of space
+	RetryOnStorage DataVolumeRetryErrorClass = "Storage"
+	// RetryOnOther is the class of the errors of no other class
+	RetryOnOther DataVolumeRetryErrorClass = "Other"
+)
+
 // StorageSpec defines the Storage type specification
 type StorageSpec struct {
 	// AccessModes contains the desired access modes the volume should have.
@@ -126,18 +360,37 @@ const (
 	DataVolumeArchive DataVolumeContentType = "archive"
 )
 
-// DataVolumeSource represents the source for our Data Volume, this can be HTTP, Imageio, S3, GCS, Registry or an existing PVC
+// DataVolumeSource represents the source for our Data Volume, this can be HTTP, Imageio, S3, GCS, Azure, SFTP, SMB, Glance, RBD, iSCSI, a node hostPath, the export of another cluster, a VM converted by virt-v2v, a Proxmox VE VM disk, a source plugin, a BitTorrent swarm, Registry or an existing PVC
 type DataVolumeSource struct {
-	HTTP     *DataVolumeSourceHTTP     `json:"http,omitempty"`
-	S3       *DataVolumeSourceS3       `json:"s3,omitempty"`
-	GCS      *DataVolumeSourceGCS      `json:"gcs,omitempty"`
-	Registry *DataVolumeSourceRegistry `json:"registry,omitempty"`
-	PVC      *DataVolumeSourcePVC      `json:"pvc,omitempty"`
-	Upload   *DataVolumeSourceUpload   `json:"upload,omitempty"`
-	Blank    *DataVolumeBlankImage     `json:"blank,omitempty"`
-	Imageio  *DataVolumeSourceImageIO  `json:"imageio,omitempty"`
-	VDDK     *DataVolumeSourceVDDK     `json:"vddk,omitempty"`
-	Snapshot *DataVolumeSourceSnapshot `json:"snapshot,omitempty"`
+	HTTP       *DataVolumeSourceHTTP       `json:"http,omitempty"`
+	S3         *DataVolumeSourceS3         `json:"s3,omitempty"`
+	GCS        *DataVolumeSourceGCS        `json:"gcs,omitempty"`
+	Azure      *DataVolumeSourceAzure      `json:"azure,omitempty"`
+	SFTP       *DataVolumeSourceSFTP       `json:"sftp,omitempty"`
+	SMB        *DataVolumeSourceSMB        `json:"smb,omitempty"`
+	Glance     *DataVolumeSourceGlance     `json:"glance,omitempty"`
+	RBD        *DataVolumeSourceRBD        `json:"rbd,omitempty"`
+	ISCSI      *DataVolumeSourceISCSI      `json:"iscsi,omitempty"`
+	HostPath   *DataVolumeSourceHostPath   `json:"hostPath,omitempty"`
+	Export     *DataVolumeSourceExport     `json:"export,omitempty"`
+	V2V        *DataVolumeSourceV2V        `json:"v2v,omitempty"`
+	Proxmox    *DataVolumeSourceProxmox    `json:"proxmox,omitempty"`
+	Plugin     *DataVolumeSourcePlugin     `json:"plugin,omitempty"`
+	Torrent    *DataVolumeSourceTorrent    `json:"torrent,omitempty"`
+	Registry   *DataVolumeSourceRegistry   `json:"registry,omitempty"`
+	PVC        *DataVolumeSourcePVC        `json:"pvc,omitempty"`
+	Upload     *DataVolumeSourceUpload     `json:"upload,omitempty"`
+	Blank      *DataVolumeBlankImage       `json:"blank,omitempty"`
+	Imageio    *DataVolumeSourceImageIO    `json:"imageio,omitempty"`
+	VDDK       *DataVolumeSourceVDDK       `json:"vddk,omitempty"`
+	Snapshot   *DataVolumeSourceSnapshot   `json:"snapshot,omitempty"`
+	DataVolume *DataVolumeSourceDataVolume `json:"dataVolume,omitempty"`
+}
+
+// DataVolumeSourceFallback is an alternative HTTP or S3 source of the data of a DataVolume
+type DataVolumeSourceFallback struct {
+	HTTP *DataVolumeSourceHTTP `json:"http,omitempty"`
+	S3   *DataVolumeSourceS3   `json:"s3,omitempty"`
 }
 
 // DataVolumeSourcePVC provides the parameters to create a Data Volume from an existing PVC
@@ -156,6 +409,49 @@ type DataVolumeSourceSnapshot struct {
 	Name string `json:"name"`
 }
 
+// DataVolumeSourceDataVolume provides the parameters to create a Data Volume from the data of another Data Volume of
+// its namespace, transformed on the way, chaining Data Volumes into pipelines
+type DataVolumeSourceDataVolume struct {
+	// Name is the name of the source DataVolume, the import starts once it succeeded
+	Name string `json:"name"`
+	// Transformation is applied to the data of the source DataVolume. The image is resized by requesting a larger storage size
+	// +optional
+	Transformation *DataVolumeTransformation `json:"transformation,omitempty"`
+	// DeleteSource deletes the source DataVolume, an intermediate volume of the pipeline, once the import succeeded
+	// +optional
+	DeleteSource bool `json:"deleteSource,omitempty"`
+}
+
+// DataVolumeTransformation is the transformation of the data of a source DataVolume
+type DataVolumeTransformation struct {
+	// Format is the format the image is stored in, qcow2 compresses it on a filesystem volume. Raw if unset
+	// +kubebuilder:validation:Enum=raw;qcow2
+	// +optional
+	Format DataVolumeTransformationFormat `json:"format,omitempty"`
+	// Sparsify discards the blocks the filesystems of the image do not use
+	// +optional
+	Sparsify bool `json:"sparsify,omitempty"`
+	// Encryption stores the image as a LUKS encrypted qcow2 image on a filesystem volume
+	// +optional
+	Encryption *DataVolumeEncryption `json:"encryption,omitempty"`
+}
+
+// DataVolumeTransformationFormat is the format a transformed image is stored in
+type DataVolumeTransformationFormat string
+
+const (
+	// TransformationFormatRaw stores the image as raw
+	TransformationFormatRaw DataVolumeTransformationFormat = "raw"
+	// TransformationFormatQcow2 stores the image as compressed qcow2
+	TransformationFormatQcow2 DataVolumeTransformationFormat = "qcow2"
+)
+
+// DataVolumeEncryption provides the passphrase an image is encrypted with
+type DataVolumeEncryption struct {
+	// SecretRef is the name of the secret holding the passphrase in its passphrase key
+	SecretRef string `json:"secretRef"`
+}
+
 // DataSourceRefSourceDataSource serves as a reference to another DataSource
 // Can be resolved into a DataVolumeSourcePVC or a DataVolumeSourceSnapshot
 // The maximum depth of a reference chain may not exceed 1.
@@ -182,6 +478,22 @@ type DataVolumeSourceS3 struct {
 	// CertConfigMap is a configmap reference, containing a Certificate Authority(CA) public key, and a base64 encoded pem certificate
 	// +optional
 	CertConfigMap string `json:"certConfigMap,omitempty"`
+	// ClientCertSecretRef is a kubernetes.io/tls secret reference, containing the client certificate and key presented
+	// to the source when it requires mutual TLS
+	// +optional
+	ClientCertSecretRef string `json:"clientCertSecretRef,omitempty"`
+	// ServiceAccountName is the service account the importer pod runs as, so credentials bound to it,
+	// like IAM roles for service accounts, are used when the secret holds no access keys.
+	// The user creating the source must be allowed to impersonate the service account
+	// +optional
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+	// Checksum is the digest of the source, the import fails if the data downloaded does not match it
+	// +optional
+	Checksum *DataVolumeChecksum `json:"checksum,omitempty"`
+	// Signature is a detached GPG signature of the source, the import fails if the data downloaded is not signed
+	// by a key of its keyring
+	// +optional
+	Signature *DataVolumeSignature `json:"signature,omitempty"`
 }
 
 // DataVolumeSourceGCS provides the parameters to create a Data Volume from an GCS source
@@ -190,6 +502,125 @@ type DataVolumeSourceGCS struct {
 	URL string `json:"url"`
 	//SecretRef provides the secret reference needed to access the GCS source
 	SecretRef string `json:"secretRef,omitempty"`
+	// CertConfigMap is a configmap reference, containing a Certificate Authority(CA) public key of a private GCS endpoint
+	// +optional
+	CertConfigMap string `json:"certConfigMap,omitempty"`
+	// ClientCertSecretRef is a kubernetes.io/tls secret reference, containing the client certificate and key presented
+	// to the source when it requires mutual TLS
+	// +optional
+	ClientCertSecretRef string `json:"clientCertSecretRef,omitempty"`
+	// Checksum is the digest of the source, the import fails if the data downloaded does not match it
+	// +optional
+	Checksum *DataVolumeChecksum `json:"checksum,omitempty"`
+}
+
+// DataVolumeSourceAzure provides the parameters to create a Data Volume from an Azure Blob Storage source
+type DataVolumeSourceAzure struct {
+	//URL is the url of the blob, https://<account>.blob.core.windows.net/<container>/<blob>
+	URL string `json:"url"`
+	//SecretRef provides the secret reference needed to access the blob, containing either a sasToken
+	//or the tenantId, clientId and clientSecret of a service principal
+	// +optional
+	SecretRef string `json:"secretRef,omitempty"`
+	// CertConfigMap is a configmap reference, containing a Certificate Authority(CA) public key, and a base64 encoded pem certificate
+	// +optional
+	CertConfigMap string `json:"certConfigMap,omitempty"`
+	// ClientCertSecretRef is a kubernetes.io/tls secret reference, containing the client certificate and key presented
+	// to the source when it requires mutual TLS
+	// +optional
+	ClientCertSecretRef string `json:"clientCertSecretRef,omitempty"`
+}
+
+// DataVolumeSourceSFTP provides the parameters to create a Data Volume from an SFTP server
+type DataVolumeSourceSFTP struct {
+	//URL is the url of the image on the server, sftp://<user>@<host>[:<port>]/<path>
+	URL string `json:"url"`
+	//SecretRef provides the secret reference holding the known_hosts the server key is checked against,
+	//and the ssh-privatekey or password to log in with
+	SecretRef string `json:"secretRef"`
+}
+
+// DataVolumeSourceSMB provides the parameters to create a Data Volume from an SMB share
+type DataVolumeSourceSMB struct {
+	//URL is the url of the image on the share, smb://<server>/<share>/<path>, or smbs://<server>/<share>/<path> to require SMB 3 encryption
+	URL string `json:"url"`
+	//SecretRef provides the secret reference holding the username, password and optional domain the SMB CSI driver
+	//mounts the share with, the share is mounted as guest without it
+	// +optional
+	SecretRef string `json:"secretRef,omitempty"`
+}
+
+// DataVolumeSourceGlance provides the parameters to create a Data Volume from an image of the OpenStack Image service
+type DataVolumeSourceGlance struct {
+	//URL is the url of the Keystone identity service, like https://keystone.example.com:5000/v3, the Image service is found in its catalog
+	URL string `json:"url"`
+	//Image is the name or the UUID of the image
+	Image string `json:"image"`
+	//SecretRef provides the secret reference holding the Keystone credentials, either the applicationCredentialId and
+	//applicationCredentialSecret, or the username, password, userDomainName, projectName and projectDomainName.
+	//regionName optionally picks the region of the Image service
+	SecretRef string `json:"secretRef"`
+	// CertConfigMap is a configmap reference, containing a Certificate Authority(CA) public key, and a base64 encoded pem certificate
+	// +optional
+	CertConfigMap string `json:"certConfigMap,omitempty"`
+	// ClientCertSecretRef is a kubernetes.io/tls secret reference, containing the client certificate and key presented
+	// to the source when it requires mutual TLS
+	// +optional
+	ClientCertSecretRef string `json:"clientCertSecretRef,omitempty"`
+}
+
+// DataVolumeSourceRBD provides the parameters to create a Data Volume from an RBD image of an external Ceph cluster
+type DataVolumeSourceRBD struct {
+	//Monitors are the addresses of the Ceph monitors, like 192.168.1.10:6789
+	Monitors []string `json:"monitors"`
+	//Pool is the name of the pool holding the image
+	Pool string `json:"pool"`
+	//Image is the name of the RBD image
+	Image string `json:"image"`
+	//Snapshot is the name of a snapshot of the image to import instead of its current content
+	// +optional
+	Snapshot string `json:"snapshot,omitempty"`
+	//SecretRef provides the secret reference holding the Ceph keyring in its keyring key, and the Ceph user in its
+	//optional userID key, admin by default
+	SecretRef string `json:"secretRef"`
+}
+
+// DataVolumeSourceISCSI provides the parameters to create a Data Volume from an iSCSI LUN
+type DataVolumeSourceISCSI struct {
+	//Portal is the address of the iSCSI target portal, host or host:port, the port is 3260 by default
+	Portal string `json:"portal"`
+	//IQN is the iSCSI qualified name of the target
+	IQN string `json:"iqn"`
+	//LUN is the number of the logical unit to import
+	LUN int32 `json:"lun"`
+	//SecretRef provides the secret reference holding the CHAP user in its accessKeyId key and the CHAP password in its secretKey key
+	// +optional
+	SecretRef string `json:"secretRef,omitempty"`
+}
+
+// DataVolumeSourceHostPath provides the parameters to create a Data Volume from a file already present on a node
+type DataVolumeSourceHostPath struct {
+	//NodeName is the name of the node holding the file, the importer runs on it
+	NodeName string `json:"nodeName"`
+	//Path is the absolute path of the file on the node, under one of the hostPathImportDirectories of the CDIConfig
+	Path string `json:"path"`
+}
+
+// DataVolumeSourceExport provides the parameters to create a Data Volume from a volume exported by another cluster
+type DataVolumeSourceExport struct {
+	//URL is the https url of the exported volume, like the disk.img.gz url of a volume of a VirtualMachineExport,
+	//or its disk.tar.gz url for archive content
+	URL string `json:"url"`
+	//SecretRef provides the secret reference holding the export token in its token key, and optionally
+	//the client certificate and key presented to the export server in its tls.crt and tls.key keys
+	SecretRef string `json:"secretRef"`
+	// CertConfigMap is a configmap reference, containing a Certificate Authority(CA) public key of the export server
+	// +optional
+	CertConfigMap string `json:"certConfigMap,omitempty"`
+	// ClientCertSecretRef is a kubernetes.io/tls secret reference, containing the client certificate and key presented
+	// to the source when it requires mutual TLS
+	// +optional
+	ClientCertSecretRef string `json:"clientCertSecretRef,omitempty"`
 }
 
 // DataVolumeSourceRegistry provides the parameters to create a Data Volume from an registry source
@@ -209,6 +640,10 @@ type DataVolumeSourceRegistry struct {
 	//CertConfigMap provides a reference to the Registry certs
 	// +optional
 	CertConfigMap *string `json:"certConfigMap,omitempty"`
+	//ClientCertSecretRef provides a reference to the kubernetes.io/tls secret of the client certificate presented to
+	//registries requiring mutual TLS
+	// +optional
+	ClientCertSecretRef *string `json:"clientCertSecretRef,omitempty"`
 	//Platform describes the minimum runtime requirements of the image
 	// +optional
 	Platform *PlatformOptions `json:"platform,omitempty"`
@@ -218,6 +653,11 @@ type PlatformOptions struct {
 	//Architecture specifies the image target CPU architecture
 	// +optional
 	Architecture string `json:"architecture,omitempty"`
+	// SelectArchitecture picks the architecture of the image from a multi-architecture image index when Architecture
+	// is not set: the kubernetes.io/arch of the node selector of the topology of the DataVolume, else the default
+	// image architecture of the CDIConfig
+	// +optional
+	SelectArchitecture bool `json:"selectArchitecture,omitempty"`
 }
 
 const (
@@ -227,6 +667,38 @@ const (
 	RegistrySchemeOci = "oci-archive"
 )
 
+// DataVolumeChecksum is the digest of the data of a source, checked while it is imported
+type DataVolumeChecksum struct {
+	// Algorithm is the hash algorithm of the digest, one of md5, sha1, sha256 or sha512
+	Algorithm DataVolumeChecksumAlgorithm `json:"algorithm"`
+	// Value is the hex encoded digest
+	Value string `json:"value"`
+}
+
+// DataVolumeChecksumAlgorithm is the hash algorithm of a checksum
+type DataVolumeChecksumAlgorithm string
+
+const (
+	// ChecksumMD5 is the md5 hash algorithm
+	ChecksumMD5 DataVolumeChecksumAlgorithm = "md5"
+	// ChecksumSHA1 is the sha1 hash algorithm
+	ChecksumSHA1 DataVolumeChecksumAlgorithm = "sha1"
+	// ChecksumSHA256 is the sha256 hash algorithm
+	ChecksumSHA256 DataVolumeChecksumAlgorithm = "sha256"
+	// ChecksumSHA512 is the sha512 hash algorithm
+	ChecksumSHA512 DataVolumeChecksumAlgorithm = "sha512"
+)
+
+// DataVolumeSignature is a detached GPG signature of the data of a source, checked while it is imported
+type DataVolumeSignature struct {
+	// URL is the url of the signature, armored or binary, absolute or relative to the url of the source. It is
+	// fetched like the source, with its credentials.
+	URL string `json:"url"`
+	// KeyringSecretRef is a Secret reference, each key of the secret holds armored or binary public keys the source
+	// may be signed with
+	KeyringSecretRef string `json:"keyringSecretRef"`
+}
+
 // RegistryPullMethod represents the registry import pull method
 type RegistryPullMethod string
 
@@ -247,12 +719,60 @@ type DataVolumeSourceHTTP struct {
 	// CertConfigMap is a configmap reference, containing a Certificate Authority(CA) public key, and a base64 encoded pem certificate
 	// +optional
 	CertConfigMap string `json:"certConfigMap,omitempty"`
+	// ClientCertSecretRef is a kubernetes.io/tls secret reference, containing the client certificate and key presented
+	// to the source when it requires mutual TLS
+	// +optional
+	ClientCertSecretRef string `json:"clientCertSecretRef,omitempty"`
 	// ExtraHeaders is a list of strings containing extra headers to include with HTTP transfer requests
 	// +optional
 	ExtraHeaders []string `json:"extraHeaders,omitempty"`
 	// SecretExtraHeaders is a list of Secret references, each containing an extra HTTP header that may include sensitive information
 	// +optional
 	SecretExtraHeaders []string `json:"secretExtraHeaders,omitempty"`
+	// OAuth2SecretRef is a Secret reference, the secret should contain the tokenUrl, clientId and clientSecret, and optionally
+	// the space separated scopes, of an OAuth2 client. The importer sends a bearer token obtained with the client credentials
+	// grant, and refreshes it before it expires.
+	// +optional
+	OAuth2SecretRef string `json:"oauth2SecretRef,omitempty"`
+	// OVA imports a disk of the OVA at the url, instead of the url itself
+	// +optional
+	OVA *DataVolumeSourceOVA `json:"ova,omitempty"`
+	// XVA imports a disk of the XenServer or XCP-ng VM export at the url, instead of the url itself
+	// +optional
+	XVA *DataVolumeSourceXVA `json:"xva,omitempty"`
+	// Checksum is the digest of the source, the import fails if the data downloaded does not match it
+	// +optional
+	Checksum *DataVolumeChecksum `json:"checksum,omitempty"`
+	// Signature is a detached GPG signature of the source, the import fails if the data downloaded is not signed
+	// by a key of its keyring
+	// +optional
+	Signature *DataVolumeSignature `json:"signature,omitempty"`
+}
+
+// DataVolumeImportedPlatform is the platform of an imported registry image
+type DataVolumeImportedPlatform struct {
+	// Architecture is the CPU architecture of the image
+	// +optional
+	Architecture string `json:"architecture,omitempty"`
+	// Digest is the digest of the manifest of the image of the platform
+	// +optional
+	Digest string `json:"digest,omitempty"`
+}
+
+// DataVolumeSourceOVA selects the disk of an OVA to import
+type DataVolumeSourceOVA struct {
+	// DiskIndex is the position of the disk in the OVF descriptor of the OVA, the first disk if not set. The disks of a
+	// multi-disk OVA are imported with one DataVolume per disk.
+	// +optional
+	DiskIndex *int32 `json:"diskIndex,omitempty"`
+}
+
+// DataVolumeSourceXVA selects the disk of an XVA to import
+type DataVolumeSourceXVA struct {
+	// DiskIndex is the position of the disk in the devices of the exported VM, the first disk if not set. cdrom drives
+	// are skipped, and the disks of a multi-disk VM are imported with one DataVolume per disk.
+	// +optional
+	DiskIndex *int32 `json:"diskIndex,omitempty"`
 }
 
 // DataVolumeSourceImageIO provides the parameters to create a Data Volume from an imageio source
@@ -265,6 +785,10 @@ type DataVolumeSourceImageIO struct {
 	SecretRef string `json:"secretRef,omitempty"`
 	//CertConfigMap provides a reference to the CA cert
 	CertConfigMap string `json:"certConfigMap,omitempty"`
+	// ClientCertSecretRef is a kubernetes.io/tls secret reference, containing the client certificate and key presented
+	// to the source when it requires mutual TLS
+	// +optional
+	ClientCertSecretRef string `json:"clientCertSecretRef,omitempty"`
 }
 
 // DataVolumeSourceVDDK provides the parameters to create a Data Volume from a Vmware source
@@ -285,6 +809,106 @@ type DataVolumeSourceVDDK struct {
 	ExtraArgs string `json:"extraArgs,omitempty"`
 }
 
+// DataVolumeSourceV2V provides the parameters to create a Data Volume from a disk of a vSphere VM converted by virt-v2v,
+// which installs the virtio drivers in the guest and fixes its boot configuration so it boots in KubeVirt
+type DataVolumeSourceV2V struct {
+	// URL is the libvirt connection url of the vCenter, like vpx://vcenter.example.com/Datacenter/Cluster/esxi1.example.com?no_verify=1,
+	// or of the ESXi host, like esx://esxi1.example.com?no_verify=1, holding the VM
+	URL string `json:"url"`
+	// VMName is the name of the VM to convert, it must be powered off
+	VMName string `json:"vmName"`
+	// DiskIndex is the index of the disk of the VM imported into the Data Volume, 0 for its first disk
+	// +optional
+	DiskIndex int32 `json:"diskIndex,omitempty"`
+	// SecretRef provides a reference to a secret containing the username and password needed to access the vCenter or ESXi host
+	SecretRef string `json:"secretRef"`
+	// BlockDriver is the virtio driver the guest is configured to boot from, virtio-blk or virtio-scsi, defaults to virtio-blk
+	// +optional
+	BlockDriver string `json:"blockDriver,omitempty"`
+	// VirtioWinPVC is the name of a filesystem PVC holding the virtio-win ISO, imported by a DataVolume, to install the
+	// virtio drivers in Windows guests from
+	// +optional
+	VirtioWinPVC string `json:"virtioWinPVC,omitempty"`
+	// NetworkMappings configure the network interfaces of the guest, by their MAC address
+	// +optional
+	NetworkMappings []V2VNetworkMapping `json:"networkMappings,omitempty"`
+}
+
+// V2VNetworkMapping maps a network interface of a VM converted by virt-v2v
+type V2VNetworkMapping struct {
+	// MAC is the MAC address of the interface in the source VM
+	MAC string `json:"mac"`
+	// Network is the name of the network the interface is mapped to
+	// +optional
+	Network string `json:"network,omitempty"`
+	// IP is a static IP address configured on the interface of Windows guests, like the one it had in vSphere
+	// +optional
+	IP string `json:"ip,omitempty"`
+	// Gateway is the default gateway configured with the static IP address
+	// +optional
+	Gateway string `json:"gateway,omitempty"`
+	// PrefixLength is the length of the network prefix of the static IP address
+	// +optional
+	PrefixLength int32 `json:"prefixLength,omitempty"`
+	// Nameservers are the DNS servers configured with the static IP address
+	// +optional
+	Nameservers []string `json:"nameservers,omitempty"`
+}
+
+// DataVolumeSourceProxmox provides the parameters to create a Data Volume from a disk of a Proxmox VE VM
+type DataVolumeSourceProxmox struct {
+	// URL is the url of the Proxmox VE API, like https://pve.example.com:8006
+	URL string `json:"url"`
+	// Node is the name of the Proxmox VE node running the VM
+	Node string `json:"node"`
+	// VMID is the id of the VM, it must be stopped
+	VMID int32 `json:"vmid"`
+	// Disk is the key of the disk in the VM configuration, like scsi0 or virtio1, defaults to the first disk of the boot order
+	// +optional
+	Disk string `json:"disk,omitempty"`
+	// SecretRef provides the secret reference holding the API token in its tokenID and tokenSecret keys, and the
+	// known_hosts, ssh-privatekey or password keys the disk is read from the node over SFTP with
+	SecretRef string `json:"secretRef"`
+	// CertConfigMap is a configmap reference, containing a Certificate Authority(CA) public key of the Proxmox VE API
+	// +optional
+	CertConfigMap string `json:"certConfigMap,omitempty"`
+	// ClientCertSecretRef is a kubernetes.io/tls secret reference, containing the client certificate and key presented
+	// to the source when it requires mutual TLS
+	// +optional
+	ClientCertSecretRef string `json:"clientCertSecretRef,omitempty"`
+}
+
+// DataVolumeSourcePlugin provides the parameters to create a Data Volume from an external source plugin, a container
+// run next to the importer serving the image over gRPC
+type DataVolumeSourcePlugin struct {
+	// Name is the name of the plugin, registered by the cluster admin with its image in the cdi-source-plugins
+	// ConfigMap of the CDI namespace
+	Name string `json:"name"`
+	// Parameters are passed to the plugin, to name the image it serves
+	// +optional
+	Parameters map[string]string `json:"parameters,omitempty"`
+	// SecretRef provides the secret reference mounted in the plugin container, holding the credentials of the plugin
+	// +optional
+	SecretRef string `json:"secretRef,omitempty"`
+}
+
+// DataVolumeSourceTorrent provides the parameters to create a Data Volume from a BitTorrent swarm, with the pieces
+// of the image checked against the hashes of the torrent
+type DataVolumeSourceTorrent struct {
+	// URL is the magnet link of the image, or the http(s) url of its .torrent file
+	URL string `json:"url"`
+	// WebSeeds are http(s) urls of the image, pieces no peer serves are downloaded from
+	// +optional
+	WebSeeds []string `json:"webSeeds,omitempty"`
+	// File is the path of the image in the torrent, required when the torrent holds several files
+	// +optional
+	File string `json:"file,omitempty"`
+	// CertConfigMap is a configmap reference, containing a Certificate Authority(CA) public key of the .torrent url
+	// and the web seeds
+	// +optional
+	CertConfigMap string `json:"certConfigMap,omitempty"`
+}
+
 // DataVolumeSourceRef defines an indirect reference to the source of data for the DataVolume
 type DataVolumeSourceRef struct {
 	// The kind of the source reference, currently only "DataSource" is supported
@@ -311,6 +935,132 @@ type DataVolumeStatus struct {
 	// RestartCount is the number of times the pod populating the DataVolume has restarted
 	RestartCount int32                 `json:"restartCount,omitempty"`
 	Conditions   []DataVolumeCondition `json:"conditions,omitempty" optional:"true"`
+	// SourceDigest is the digest the registry image of the DataVolume resolved to at import time
+	// +optional
+	SourceDigest string `json:"sourceDigest,omitempty"`
+	// ObservedSourceDigest is the digest of the source read by the last import, as algorithm:hex. It is the digest of
+	// the registry image, or of the downloaded data when the DataVolume has a checksum, and is set on failed imports too
+	// +optional
+	ObservedSourceDigest string `json:"observedSourceDigest,omitempty"`
+	// DetectedContentType is the type of the data imported, found by its magic bytes: iso, qcow2, vmdk, vdi, vhd, vhdx, raw or archive
+	// +optional
+	DetectedContentType string `json:"detectedContentType,omitempty"`
+	// ImportedPlatform is the platform of the registry image copied by the last import, picked from the image index the
+	// reference resolved to for multi-architecture images
+	// +optional
+	ImportedPlatform *DataVolumeImportedPlatform `json:"importedPlatform,omitempty"`
+	// RetryCount is the number of times the failed import was retried following the retry policy of the DataVolume
+	// +optional
+	RetryCount int32 `json:"retryCount,omitempty"`
+	// NextRetryTime is when the failed import is retried next, unset unless a retry is pending
+	// +optional
+	NextRetryTime *metav1.Time `json:"nextRetryTime,omitempty"`
+	// TransferredBytes is the number of bytes the import read from its source, or the clone transferred, so far
+	// +optional
+	TransferredBytes *int64 `json:"transferredBytes,omitempty"`
+	// TotalBytes is the number of bytes the import reads from its source, or the clone transfers, unset if unknown
+	// +optional
+	TotalBytes *int64 `json:"totalBytes,omitempty"`
+	// Throughput is the number of bytes per second the clone transferred over the last second
+	// +optional
+	Throughput *int64 `json:"throughput,omitempty"`
+	// ClonePhase is the phase of the clone in progress: Snapshot, Transfer, Convert or Resize
+	// +optional
+	ClonePhase DataVolumeClonePhase `json:"clonePhase,omitempty"`
+	// CurrentPhaseStartTime is when the DataVolume entered its current phase
+	// +optional
+	CurrentPhaseStartTime *metav1.Time `json:"currentPhaseStartTime,omitempty"`
+	// PhaseDurations is the time the importer spent in each of its phases
+	// +optional
+	PhaseDurations *DataVolumePhaseDurations `json:"phaseDurations,omitempty"`
+	// PostCompletionHooks is the status of the post completion hooks run so far
+	// +optional
+	PostCompletionHooks []DataVolumeHookStatus `json:"postCompletionHooks,omitempty"`
+	// Validation is the result of the validation of the source of a validate only DataVolume
+	// +optional
+	Validation *DataVolumeValidation `json:"validation,omitempty"`
+	// FailureArtifacts is the name of the ConfigMap holding the termination message and the end of the logs of the last
+	// failed import, kept when the DataVolume has the cdi.kubevirt.io/storage.import.collectFailureArtifacts annotation
+	// +optional
+	FailureArtifacts string `json:"failureArtifacts,omitempty"`
+	// CloneStrategy is the strategy the clone of the DataVolume uses, and why the strategies before it were not used
+	// +optional
+	CloneStrategy *DataVolumeCloneStrategyStatus `json:"cloneStrategy,omitempty"`
+}
+
+// DataVolumeCloneStrategyStatus is the strategy a clone uses, and the strategies it fell back from
+type DataVolumeCloneStrategyStatus struct {
+	// Strategy is the strategy the clone uses
+	Strategy CDICloneStrategy `json:"strategy"`
+	// Fallbacks are the strategies tried before, in order, and why they could not be used
+	// +optional
+	Fallbacks []CloneStrategyFallback `json:"fallbacks,omitempty"`
+}
+
+// CloneStrategyFallback is a clone strategy that could not be used
+type CloneStrategyFallback struct {
+	// Strategy is the strategy that could not be used, unset if CDI could not try any
+	// +optional
+	Strategy CDICloneStrategy `json:"strategy,omitempty"`
+	// Reason is why the strategy could not be used
+	Reason string `json:"reason"`
+	// Message describes why the strategy could not be used
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// DataVolumeValidation is the result of the validation of the source of a validate only DataVolume
+type DataVolumeValidation struct {
+	// Valid is whether the source can be imported to the requested storage
+	Valid bool `json:"valid"`
+	// Format is the format of the image reported by qemu-img, unset if the image can only be inspected once downloaded
+	// +optional
+	Format string `json:"format,omitempty"`
+	// VirtualSize is the size of the disk of the image in bytes, unset if the image can only be inspected once downloaded
+	// +optional
+	VirtualSize *int64 `json:"virtualSize,omitempty"`
+	// Message describes why the source is not valid
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// DataVolumeHookStatus is the status of a post completion hook of a DataVolume
+type DataVolumeHookStatus struct {
+	// Name is the name of the hook
+	Name string `json:"name"`
+	// Phase is the phase of the hook: Running, Succeeded or Failed
+	Phase DataVolumeHookPhase `json:"phase"`
+	// Message describes why the hook failed
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// DataVolumeHookPhase is the phase of a post completion hook
+type DataVolumeHookPhase string
+
+const (
+	// HookRunning is the phase of a running hook
+	HookRunning DataVolumeHookPhase = "Running"
+	// HookSucceeded is the phase of a hook that succeeded
+	HookSucceeded DataVolumeHookPhase = "Succeeded"
+	// HookFailed is the phase of a hook that failed
+	HookFailed DataVolumeHookPhase = "Failed"
+)
+
+// DataVolumePhaseDurations is the time the importer of a DataVolume spent in each of its phases
+type DataVolumePhaseDurations struct {
+	// Download is the time spent reading the source of the import
+	// +optional
+	Download *metav1.Duration `json:"download,omitempty"`
+	// Convert is the time spent converting the image to the raw format
+	// +optional
+	Convert *metav1.Duration `json:"convert,omitempty"`
+	// Resize is the time spent resizing the image to the size of the PVC
+	// +optional
+	Resize *metav1.Duration `json:"resize,omitempty"`
+	// Verify is the time spent validating the image before it is written to the PVC
+	// +optional
+	Verify *metav1.Duration `json:"verify,omitempty"`
 }
 
 // DataVolumeList provides the needed parameters to do request a list of Data Volumes from the system
@@ -339,6 +1089,23 @@ type DataVolumePhase string
 // DataVolumeProgress is the current progress of the DataVolume transfer operation. Value between 0 and 100 inclusive, N/A if not available
 type DataVolumeProgress string
 
+// DataVolumeClonePhase is the phase of the clone of a DataVolume in progress
+type DataVolumeClonePhase string
+
+const (
+	// ClonePhaseSnapshot is the snapshot of the source taken for the clone
+	ClonePhaseSnapshot DataVolumeClonePhase = "Snapshot"
+
+	// ClonePhaseTransfer is the copy of the source to the target, by the clone pods or the storage
+	ClonePhaseTransfer DataVolumeClonePhase = "Transfer"
+
+	// ClonePhaseConvert is the copy of the source by the clone pods, converting the image to the clone format
+	ClonePhaseConvert DataVolumeClonePhase = "Convert"
+
+	// ClonePhaseResize is the expansion of the cloned volume to the requested size
+	ClonePhaseResize DataVolumeClonePhase = "Resize"
+)
+
 // DataVolumeConditionType is the string representation of known condition types
 type DataVolumeConditionType string
 
@@ -357,6 +1124,9 @@ const (
 	// ImportInProgress represents a data volume with a current phase of ImportInProgress
 	ImportInProgress DataVolumePhase = "ImportInProgress"
 
+	// ImportPaused represents a data volume whose import is paused by its spec
+	ImportPaused DataVolumePhase = "ImportPaused"
+
 	// CloneScheduled represents a data volume with a current phase of CloneScheduled
 	CloneScheduled DataVolumePhase = "CloneScheduled"
 
@@ -402,21 +1172,386 @@ const (
 	// Paused represents a DataVolumePhase of Paused
 	Paused DataVolumePhase = "Paused"
 
-	// PrepClaimInProgress represents a data volume with a current phase of PrepClaimInProgress
-	PrepClaimInProgress DataVolumePhase = "PrepClaimInProgress"
-	// RebindInProgress represents a data volume with a current phase of RebindInProgress
-	RebindInProgress DataVolumePhase = "RebindInProgress"
+	// PrepClaimInProgress represents a data volume with a current phase of PrepClaimInProgress
+	PrepClaimInProgress DataVolumePhase = "PrepClaimInProgress"
+	// RebindInProgress represents a data volume with a current phase of RebindInProgress
+	RebindInProgress DataVolumePhase = "RebindInProgress"
+	// PostCompletionHooksInProgress represents a populated data volume running its post completion hooks
+	PostCompletionHooksInProgress DataVolumePhase = "PostCompletionHooksInProgress"
+	// ValidationSucceeded represents a validate only data volume whose source can be imported
+	ValidationSucceeded DataVolumePhase = "ValidationSucceeded"
+	// ValidationFailed represents a validate only data volume whose source can not be imported
+	ValidationFailed DataVolumePhase = "ValidationFailed"
+
+	// DataVolumeReady is the condition that indicates if the data volume is ready to be consumed.
+	DataVolumeReady DataVolumeConditionType = "Ready"
+	// DataVolumeBound is the condition that indicates if the underlying PVC is bound or not.
+	DataVolumeBound DataVolumeConditionType = "Bound"
+	// DataVolumeRunning is the condition that indicates if the import/upload/clone container is running.
+	DataVolumeRunning DataVolumeConditionType = "Running"
+	// DataVolumePostCompletionHooks is the condition that indicates if the post completion hooks succeeded.
+	DataVolumePostCompletionHooks DataVolumeConditionType = "PostCompletionHooks"
+)
+
+// Reasons of the Running condition of a DataVolume whose import failed or is held, classified from the errors of the
+// importer so remediation can be automated without parsing the message of the condition
+const (
+	// DataVolumeReasonSourceUnreachable means the source could not be reached, or does not hold the requested image
+	DataVolumeReasonSourceUnreachable = "SourceUnreachable"
+	// DataVolumeReasonInsufficientSpace means the storage of the DataVolume is too small for the image
+	DataVolumeReasonInsufficientSpace = "InsufficientSpace"
+	// DataVolumeReasonAuthFailed means the source rejected the credentials of the DataVolume
+	DataVolumeReasonAuthFailed = "AuthFailed"
+	// DataVolumeReasonCorrupt means the image read from the source is not a valid image
+	DataVolumeReasonCorrupt = "Corrupt"
+	// DataVolumeReasonQuotaExceeded means a quota of the namespace holds the import, or the storage quota was exceeded
+	DataVolumeReasonQuotaExceeded = "QuotaExceeded"
+	// DataVolumeReasonThrottled means the source rate limited the import
+	DataVolumeReasonThrottled = "Throttled"
+	// DataVolumeReasonVerificationFailed means the image written to the storage of the DataVolume failed its verification
+	DataVolumeReasonVerificationFailed = "VerificationFailed"
+)
+
+// DataVolumeCloneSourceSubresource is the subresource checked for permission to clone
+const DataVolumeCloneSourceSubresource = "source"
+
+// MultiDataVolume imports the disks of a source holding several disks, like an OVA or a VM, into one DataVolume per disk
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// +kubebuilder:resource:shortName=mdv;mdvs,categories=all
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase",description="The phase of the import of the disks"
+// +kubebuilder:printcolumn:name="Progress",type="string",JSONPath=".status.progress",description="The progress of the import of the disks"
+type MultiDataVolume struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   MultiDataVolumeSpec   `json:"spec"`
+	Status MultiDataVolumeStatus `json:"status,omitempty"`
+}
+
+// MultiDataVolumeSpec defines specification for MultiDataVolume
+type MultiDataVolumeSpec struct {
+	// Template is the DataVolume the DataVolumes of the disks are created from. Its source is an OVA or XVA http source,
+	// or a v2v, imageio, vddk or proxmox source, whose disk is selected for each DataVolume.
+	Template DataVolume `json:"template"`
+	// Disks are the disks of the source imported, each into its own DataVolume
+	// +kubebuilder:validation:MinItems=1
+	Disks []MultiDataVolumeDisk `json:"disks"`
+}
+
+// MultiDataVolumeDisk selects a disk of the source of a MultiDataVolume
+type MultiDataVolumeDisk struct {
+	// Name is the name of the DataVolume of the disk, the name of the MultiDataVolume followed by disk and the position
+	// of the disk in the disks if not set
+	// +optional
+	Name string `json:"name,omitempty"`
+	// Index is the index of the disk in OVA, XVA and v2v sources
+	// +optional
+	Index *int32 `json:"index,omitempty"`
+	// ID is the id of the disk in imageio sources, its backing file in vddk sources and its key in proxmox sources
+	// +optional
+	ID string `json:"id,omitempty"`
+	// Storage is the storage of the DataVolume of the disk, the storage of the template if not set
+	// +optional
+	Storage *StorageSpec `json:"storage,omitempty"`
+}
+
+// MultiDataVolumeStatus provides the most recently observed status of the DataVolumes of the disks
+type MultiDataVolumeStatus struct {
+	// Phase is Failed once a DataVolume failed, Succeeded once all the DataVolumes succeeded, ImportInProgress while
+	// DataVolumes are imported and Pending otherwise
+	Phase DataVolumePhase `json:"phase,omitempty"`
+	// Progress is the average progress of the DataVolumes
+	Progress DataVolumeProgress `json:"progress,omitempty"`
+	// Disks are the status of the DataVolumes of the disks
+	Disks []MultiDataVolumeDiskStatus `json:"disks,omitempty"`
+}
+
+// MultiDataVolumeDiskStatus is the status of the DataVolume of a disk of a MultiDataVolume
+type MultiDataVolumeDiskStatus struct {
+	// DataVolumeName is the name of the DataVolume of the disk
+	DataVolumeName string `json:"dataVolumeName"`
+	// Phase is the phase of the DataVolume
+	Phase DataVolumePhase `json:"phase,omitempty"`
+	// Progress is the progress of the DataVolume
+	Progress DataVolumeProgress `json:"progress,omitempty"`
+}
+
+// MultiDataVolumeList provides the needed parameters to do request a list of MultiDataVolumes from the system
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type MultiDataVolumeList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	// Items provides a list of MultiDataVolumes
+	Items []MultiDataVolume `json:"items"`
+}
+
+// DataVolumeReplication keeps the PVC of a DataVolume synchronized with a remote disk while its VM still runs, copying
+// at each checkpoint the blocks changed since the previous one, until a final checkpoint cuts over to the PVC
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// +kubebuilder:resource:shortName=dvr;dvrs,categories=all
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase",description="The phase of the replication"
+// +kubebuilder:printcolumn:name="Checkpoint",type="string",JSONPath=".status.syncedCheckpoint",description="The last checkpoint synchronized"
+type DataVolumeReplication struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DataVolumeReplicationSpec   `json:"spec"`
+	Status DataVolumeReplicationStatus `json:"status,omitempty"`
+}
+
+// DataVolumeReplicationSpec defines specification for DataVolumeReplication
+type DataVolumeReplicationSpec struct {
+	// Template is the DataVolume replicated to, named after the DataVolumeReplication unless it has a name. Its source is
+	// a vddk or imageio source, whose changed blocks are copied at each checkpoint. Export sources, which have no changed
+	// block tracking, are refused. Its checkpoints are set by the DataVolumeReplication.
+	Template DataVolume `json:"template"`
+	// Checkpoints are the identifiers of the snapshots of the source, in the order they were taken. Snapshots are taken
+	// by the migration orchestrator, usually periodically, and appended here to be synchronized.
+	// +kubebuilder:validation:MinItems=1
+	Checkpoints []string `json:"checkpoints"`
+	// Finalize cuts over to the PVC once the last checkpoint is synchronized, which should be taken once the VM stopped
+	// +optional
+	Finalize bool `json:"finalize,omitempty"`
+}
+
+// DataVolumeReplicationStatus provides the most recently observed status of the DataVolumeReplication
+type DataVolumeReplicationStatus struct {
+	// Phase of the replication
+	Phase DataVolumeReplicationPhase `json:"phase,omitempty"`
+	// DataVolumeName is the name of the DataVolume replicated to
+	DataVolumeName string `json:"dataVolumeName,omitempty"`
+	// SyncedCheckpoint is the last checkpoint synchronized to the PVC
+	SyncedCheckpoint string `json:"syncedCheckpoint,omitempty"`
+	// LastSyncTime is when the last checkpoint was synchronized
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+	// Progress is the progress of the synchronization of the current checkpoint
+	Progress DataVolumeProgress `json:"progress,omitempty"`
+	// Message explains the phase, like why the replication failed
+	Message string `json:"message,omitempty"`
+}
+
+// DataVolumeReplicationPhase is the phase of the DataVolumeReplication
+type DataVolumeReplicationPhase string
+
+const (
+	// DataVolumeReplicationPending is the phase of the replications waiting for their first checkpoint to be copied
+	DataVolumeReplicationPending DataVolumeReplicationPhase = "Pending"
+	// DataVolumeReplicationSyncing is the phase of the replications copying a checkpoint
+	DataVolumeReplicationSyncing DataVolumeReplicationPhase = "Syncing"
+	// DataVolumeReplicationSynced is the phase of the replications waiting for the next checkpoint
+	DataVolumeReplicationSynced DataVolumeReplicationPhase = "Synced"
+	// DataVolumeReplicationFinalizing is the phase of the replications copying their final checkpoint
+	DataVolumeReplicationFinalizing DataVolumeReplicationPhase = "Finalizing"
+	// DataVolumeReplicationCompleted is the phase of the replications whose final checkpoint was copied, the PVC being
+	// ready for the VM
+	DataVolumeReplicationCompleted DataVolumeReplicationPhase = "Completed"
+	// DataVolumeReplicationFailed is the phase of the replications whose DataVolume failed or cannot be created
+	DataVolumeReplicationFailed DataVolumeReplicationPhase = "Failed"
+)
+
+// DataVolumeReplicationList provides the needed parameters to do request a list of DataVolumeReplications from the system
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type DataVolumeReplicationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	// Items provides a list of DataVolumeReplications
+	Items []DataVolumeReplication `json:"items"`
+}
+
+// DataVolumeMigration moves the PVC of a DataVolume to another storage class, cloning it to a PVC of the storage class,
+// host-assisted between storage classes of different provisioners, and rebinding the copy to the name of the PVC, which
+// keeps its DataVolume and consumers
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// +kubebuilder:resource:shortName=dvm;dvms,categories=all
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase",description="The phase of the migration"
+// +kubebuilder:printcolumn:name="DataVolume",type="string",JSONPath=".spec.dataVolumeName",description="The DataVolume migrated"
+// +kubebuilder:printcolumn:name="StorageClass",type="string",JSONPath=".spec.storageClassName",description="The storage class migrated to"
+type DataVolumeMigration struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DataVolumeMigrationSpec   `json:"spec"`
+	Status DataVolumeMigrationStatus `json:"status,omitempty"`
+}
+
+// DataVolumeMigrationSpec defines specification for DataVolumeMigration
+type DataVolumeMigrationSpec struct {
+	// DataVolumeName is the name of the DataVolume migrated, in the namespace of the DataVolumeMigration. The DataVolume
+	// must have succeeded and its PVC must not be used by pods.
+	DataVolumeName string `json:"dataVolumeName"`
+	// StorageClassName is the name of the storage class the PVC of the DataVolume is migrated to
+	StorageClassName string `json:"storageClassName"`
+}
+
+// DataVolumeMigrationStatus provides the most recently observed status of the DataVolumeMigration
+type DataVolumeMigrationStatus struct {
+	// Phase of the migration
+	Phase DataVolumeMigrationPhase `json:"phase,omitempty"`
+	// PersistentVolumeName is the name of the PV of the storage class the PVC of the DataVolume is rebound to
+	PersistentVolumeName string `json:"persistentVolumeName,omitempty"`
+	// Progress is the progress of the copy of the PVC
+	Progress DataVolumeProgress `json:"progress,omitempty"`
+	// Message explains the phase, like why the migration failed
+	Message string `json:"message,omitempty"`
+}
+
+// DataVolumeMigrationPhase is the phase of the DataVolumeMigration
+type DataVolumeMigrationPhase string
+
+const (
+	// DataVolumeMigrationPending is the phase of the migrations waiting for the DataVolume to succeed and its PVC to be unused
+	DataVolumeMigrationPending DataVolumeMigrationPhase = "Pending"
+	// DataVolumeMigrationCopying is the phase of the migrations copying the PVC to the storage class
+	DataVolumeMigrationCopying DataVolumeMigrationPhase = "Copying"
+	// DataVolumeMigrationRebinding is the phase of the migrations replacing the PVC with its copy
+	DataVolumeMigrationRebinding DataVolumeMigrationPhase = "Rebinding"
+	// DataVolumeMigrationCompleted is the phase of the migrations whose DataVolume has its PVC in the storage class
+	DataVolumeMigrationCompleted DataVolumeMigrationPhase = "Completed"
+	// DataVolumeMigrationFailed is the phase of the migrations which cannot be done
+	DataVolumeMigrationFailed DataVolumeMigrationPhase = "Failed"
+)
+
+// DataVolumeMigrationList provides the needed parameters to do request a list of DataVolumeMigrations from the system
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type DataVolumeMigrationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	// Items provides a list of DataVolumeMigrations
+	Items []DataVolumeMigration `json:"items"`
+}
+
+// DataExport exports the disk image of a PVC or a DataVolume, converted to qcow2, to an HTTP server, a S3 bucket or a
+// registry as a containerDisk
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// +kubebuilder:resource:shortName=dex;dexs,categories=all
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase",description="The phase of the export"
+type DataExport struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DataExportSpec   `json:"spec"`
+	Status DataExportStatus `json:"status,omitempty"`
+}
+
+// DataExportSpec defines specification for DataExport
+type DataExportSpec struct {
+	// Source is the PVC or the DataVolume whose disk image is exported
+	Source DataExportSource `json:"source"`
+	// Target is where the disk image is exported to
+	Target DataExportTarget `json:"target"`
+	// Compress compresses the clusters of the qcow2 image, which makes it smaller but slower to read
+	// +optional
+	Compress bool `json:"compress,omitempty"`
+}
+
+// DataExportSource is the PVC or the DataVolume exported, in the namespace of the DataExport
+type DataExportSource struct {
+	// Kind of the source, PersistentVolumeClaim or DataVolume. The export of a DataVolume waits for it to succeed.
+	// +kubebuilder:validation:Enum=PersistentVolumeClaim;DataVolume
+	Kind string `json:"kind"`
+	// Name of the source
+	Name string `json:"name"`
+}
+
+// DataExportTarget is where a disk image is exported to, exactly one of its fields is set
+type DataExportTarget struct {
+	// HTTP uploads the image with a PUT request
+	// +optional
+	HTTP *DataExportTargetHTTP `json:"http,omitempty"`
+	// S3 uploads the image as an object of a bucket
+	// +optional
+	S3 *DataExportTargetS3 `json:"s3,omitempty"`
+	// Registry pushes the image as a containerDisk
+	// +optional
+	Registry *DataExportTargetRegistry `json:"registry,omitempty"`
+}
+
+// DataExportTargetHTTP uploads the image with an HTTP PUT request
+type DataExportTargetHTTP struct {
+	// URL the image is uploaded to
+	URL string `json:"url"`
+	// SecretRef is the name of the secret with the accessKeyId and secretKey of the basic authentication
+	// +optional
+	SecretRef string `json:"secretRef,omitempty"`
+	// CertConfigMap is the name of the config map with the CA bundle of the server
+	// +optional
+	CertConfigMap string `json:"certConfigMap,omitempty"`
+}
+
+// DataExportTargetS3 uploads the image as an object of a S3 bucket
+type DataExportTargetS3 struct {
+	// URL of the object, like https://s3.us-east-1.amazonaws.com/bucket/fedora.qcow2
+	URL string `json:"url"`
+	// SecretRef is the name of the secret with the accessKeyId and secretKey of the bucket
+	// +optional
+	SecretRef string `json:"secretRef,omitempty"`
+	// CertConfigMap is the name of the config map with the CA bundle of the S3 endpoint
+	// +optional
+	CertConfigMap string `json:"certConfigMap,omitempty"`
+}
+
+// DataExportTargetRegistry pushes the image to a registry as a containerDisk, the image holding the disk as
+// /disk/disk.qcow2
+type DataExportTargetRegistry struct {
+	// URL of the image, like docker://registry.example.com/disks/fedora:40
+	URL string `json:"url"`
+	// SecretRef is the name of the secret with the accessKeyId and secretKey of the registry
+	// +optional
+	SecretRef string `json:"secretRef,omitempty"`
+	// CertConfigMap is the name of the config map with the CA bundle of the registry
+	// +optional
+	CertConfigMap string `json:"certConfigMap,omitempty"`
+}
+
+// DataExportStatus provides the most recently observed status of the DataExport
+type DataExportStatus struct {
+	// Phase of the export
+	Phase DataExportPhase `json:"phase,omitempty"`
+	// Digest of the exported image, the sha256 digest of the qcow2 image for HTTP and S3 targets and the digest of the
+	// manifest for registry targets
+	Digest string `json:"digest,omitempty"`
+	// Message explains the phase, like why the export failed
+	Message string `json:"message,omitempty"`
+}
 
-	// DataVolumeReady is the condition that indicates if the data volume is ready to be consumed.
-	DataVolumeReady DataVolumeConditionType = "Ready"
-	// DataVolumeBound is the condition that indicates if the underlying PVC is bound or not.
-	DataVolumeBound DataVolumeConditionType = "Bound"
-	// DataVolumeRunning is the condition that indicates if the import/upload/clone container is running.
-	DataVolumeRunning DataVolumeConditionType = "Running"
+// DataExportPhase is the phase of the DataExport
+type DataExportPhase string
+
+const (
+	// DataExportPending is the phase of the exports waiting for their source
+	DataExportPending DataExportPhase = "Pending"
+	// DataExportInProgress is the phase of the exports whose exporter pod runs
+	DataExportInProgress DataExportPhase = "InProgress"
+	// DataExportSucceeded is the phase of the exports whose image was exported
+	DataExportSucceeded DataExportPhase = "Succeeded"
+	// DataExportFailed is the (terminal) phase of the exports which could not be exported
+	DataExportFailed DataExportPhase = "Failed"
 )
 
-// DataVolumeCloneSourceSubresource is the subresource checked for permission to clone
-const DataVolumeCloneSourceSubresource = "source"
+// DataExportList provides the needed parameters to do request a list of DataExports from the system
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type DataExportList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	// Items provides a list of DataExports
+	Items []DataExport `json:"items"`
+}
 
 // this has to be here otherwise informer-gen doesn't recognize it
 // see https://github.com/kubernetes/code-generator/issues/59
@@ -447,6 +1582,12 @@ type StorageProfileSpec struct {
 	DataImportCronSourceFormat *DataImportCronSourceFormat `json:"dataImportCronSourceFormat,omitempty"`
 	// SnapshotClass is optional specific VolumeSnapshotClass for CloneStrategySnapshot. If not set, a VolumeSnapshotClass is chosen according to the provisioner.
 	SnapshotClass *string `json:"snapshotClass,omitempty"`
+	// ConversionDefaults tune the image conversions writing to the volumes of the storage class
+	// +optional
+	ConversionDefaults *ConversionDefaults `json:"conversionDefaults,omitempty"`
+	// CloneCompression is how the data of host-assisted clones to the storage class is compressed on the network, snappy if unset
+	// +optional
+	CloneCompression *CloneCompression `json:"cloneCompression,omitempty"`
 }
 
 // StorageProfileStatus provides the most recently observed status of the StorageProfile
@@ -464,8 +1605,41 @@ type StorageProfileStatus struct {
 	DataImportCronSourceFormat *DataImportCronSourceFormat `json:"dataImportCronSourceFormat,omitempty"`
 	// SnapshotClass is optional specific VolumeSnapshotClass for CloneStrategySnapshot. If not set, a VolumeSnapshotClass is chosen according to the provisioner.
 	SnapshotClass *string `json:"snapshotClass,omitempty"`
+	// ConversionDefaults tune the image conversions writing to the volumes of the storage class
+	ConversionDefaults *ConversionDefaults `json:"conversionDefaults,omitempty"`
+	// CloneCompression is how the data of host-assisted clones to the storage class is compressed on the network
+	CloneCompression *CloneCompression `json:"cloneCompression,omitempty"`
+}
+
+// ConversionDefaults are the defaults of the image conversions writing to the volumes of a storage class
+type ConversionDefaults struct {
+	// Preallocation preallocates the volumes of the storage class, unless the DataVolume sets it. It takes precedence over the preallocation of the CDIConfig
+	// +optional
+	Preallocation *bool `json:"preallocation,omitempty"`
+	// CacheMode is the cache mode of qemu-img writing to the volumes, none bypasses the page cache when the storage supports direct IO
+	// +kubebuilder:validation:Enum=writeback;none
+	// +optional
+	CacheMode *ConversionCacheMode `json:"cacheMode,omitempty"`
+	// SparseThreshold is the size of the consecutive zeroes qemu-img leaves unallocated, it is ignored when preallocating
+	// +optional
+	SparseThreshold *resource.Quantity `json:"sparseThreshold,omitempty"`
+	// Coroutines is the number of parallel coroutines of qemu-img
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=16
+	// +optional
+	Coroutines *int32 `json:"coroutines,omitempty"`
 }
 
+// ConversionCacheMode is the cache mode of qemu-img writing to a volume
+type ConversionCacheMode string
+
+const (
+	// ConversionCacheModeWriteback writes through the page cache
+	ConversionCacheModeWriteback ConversionCacheMode = "writeback"
+	// ConversionCacheModeNone bypasses the page cache when the storage supports direct IO
+	ConversionCacheModeNone ConversionCacheMode = "none"
+)
+
 // ClaimPropertySet is a set of properties applicable to PVC
 type ClaimPropertySet struct {
 	// AccessModes contains the desired access modes the volume should have.
@@ -587,12 +1761,20 @@ type DataImportCronSpec struct {
 	// Number of import PVCs to keep when garbage collecting. Default is 3.
 	// +optional
 	ImportsToKeep *int32 `json:"importsToKeep,omitempty"`
+	// MaxAge is how long an import is kept when garbage collecting, the older ones are deleted even within
+	// ImportsToKeep. The most recent import is always kept.
+	// +optional
+	MaxAge *metav1.Duration `json:"maxAge,omitempty"`
 	// ManagedDataSource specifies the name of the corresponding DataSource this cron will manage.
 	// DataSource has to be in the same namespace.
 	ManagedDataSource string `json:"managedDataSource"`
 	// RetentionPolicy specifies whether the created DataVolumes and DataSources are retained when their DataImportCron is deleted. Default is RatainAll.
 	// +optional
 	RetentionPolicy *DataImportCronRetentionPolicy `json:"retentionPolicy,omitempty"`
+	// SourceFormat is the format the imports are stored in, overriding the dataImportCronSourceFormat of the
+	// StorageProfile
+	// +optional
+	SourceFormat *DataImportCronSourceFormat `json:"sourceFormat,omitempty"`
 }
 
 // DataImportCronGarbageCollect represents the DataImportCron garbage collection mode
@@ -702,6 +1884,18 @@ type ImportSourceType struct {
 	S3       *DataVolumeSourceS3       `json:"s3,omitempty"`
 	Registry *DataVolumeSourceRegistry `json:"registry,omitempty"`
 	GCS      *DataVolumeSourceGCS      `json:"gcs,omitempty"`
+	Azure    *DataVolumeSourceAzure    `json:"azure,omitempty"`
+	SFTP     *DataVolumeSourceSFTP     `json:"sftp,omitempty"`
+	SMB      *DataVolumeSourceSMB      `json:"smb,omitempty"`
+	Glance   *DataVolumeSourceGlance   `json:"glance,omitempty"`
+	RBD      *DataVolumeSourceRBD      `json:"rbd,omitempty"`
+	ISCSI    *DataVolumeSourceISCSI    `json:"iscsi,omitempty"`
+	HostPath *DataVolumeSourceHostPath `json:"hostPath,omitempty"`
+	Export   *DataVolumeSourceExport   `json:"export,omitempty"`
+	V2V      *DataVolumeSourceV2V      `json:"v2v,omitempty"`
+	Proxmox  *DataVolumeSourceProxmox  `json:"proxmox,omitempty"`
+	Plugin   *DataVolumeSourcePlugin   `json:"plugin,omitempty"`
+	Torrent  *DataVolumeSourceTorrent  `json:"torrent,omitempty"`
 	Blank    *DataVolumeBlankImage     `json:"blank,omitempty"`
 	Imageio  *DataVolumeSourceImageIO  `json:"imageio,omitempty"`
 	VDDK     *DataVolumeSourceVDDK     `json:"vddk,omitempty"`
@@ -709,8 +1903,39 @@ type ImportSourceType struct {
 
 // VolumeImportSourceStatus provides the most recently observed status of the VolumeImportSource
 type VolumeImportSourceStatus struct {
+	// Claims is the population status of the PVCs referring to the VolumeImportSource in their dataSourceRef
+	// +optional
+	Claims []VolumePopulationStatus `json:"claims,omitempty"`
+}
+
+// VolumePopulationStatus is the population status of a PVC by a CDI volume populator
+type VolumePopulationStatus struct {
+	// Name is the name of the PVC
+	Name string `json:"name"`
+	// Phase is the phase of the population of the PVC
+	Phase VolumePopulationPhase `json:"phase"`
+	// Progress is the progress of the population of the PVC
+	// +optional
+	Progress DataVolumeProgress `json:"progress,omitempty"`
+	// Message is a human readable message of the population failure
+	// +optional
+	Message string `json:"message,omitempty"`
 }
 
+// VolumePopulationPhase is the phase of the population of a PVC by a CDI volume populator
+type VolumePopulationPhase string
+
+const (
+	// VolumePopulationPending is the phase of the PVCs waiting for their population to start
+	VolumePopulationPending VolumePopulationPhase = "Pending"
+	// VolumePopulationInProgress is the phase of the PVCs being populated
+	VolumePopulationInProgress VolumePopulationPhase = "InProgress"
+	// VolumePopulationSucceeded is the phase of the populated PVCs
+	VolumePopulationSucceeded VolumePopulationPhase = "Succeeded"
+	// VolumePopulationFailed is the phase of the PVCs whose last population attempt failed, it is retried
+	VolumePopulationFailed VolumePopulationPhase = "Failed"
+)
+
 // VolumeImportSourceList provides the needed parameters to do request a list of Import Sources from the system
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 type VolumeImportSourceList struct {
@@ -745,6 +1970,9 @@ type VolumeUploadSourceSpec struct {
 
 // VolumeUploadSourceStatus provides the most recently observed status of the VolumeUploadSource
 type VolumeUploadSourceStatus struct {
+	// Claims is the population status of the PVCs referring to the VolumeUploadSource in their dataSourceRef
+	// +optional
+	Claims []VolumePopulationStatus `json:"claims,omitempty"`
 }
 
 // VolumeUploadSourceList provides the needed parameters to do request a list of Upload Sources from the system
@@ -791,6 +2019,40 @@ type VolumeCloneSourceSpec struct {
 	// PriorityClassName is the priorityclass for the claim
 	// +optional
 	PriorityClassName *string `json:"priorityClassName,omitempty"`
+
+	// TransferMode is how the data of host-assisted clones is transferred, tar if unset
+	// +optional
+	TransferMode *CloneTransferMode `json:"transferMode,omitempty"`
+
+	// Compression is how the data of host-assisted clones is compressed on the network, the cloneCompression of the
+	// StorageProfile of the target if unset
+	// +optional
+	Compression *CloneCompression `json:"compression,omitempty"`
+
+	// Strategy is the strategy of the clone, it takes precedence over the cloneStrategy of the StorageProfile of the target
+	// +optional
+	Strategy *CDICloneStrategy `json:"strategy,omitempty"`
+
+	// StrategyFallbacks is an ordered list of the strategies tried when the strategy can't be used. Once the strategy or
+	// its fallbacks are set, the clone only uses the listed strategies instead of falling back to copy
+	// +optional
+	StrategyFallbacks []CDICloneStrategy `json:"strategyFallbacks,omitempty"`
+
+	// Format is the format the cloned image is stored in on a filesystem target. Once set, the clone is host-assisted and
+	// its target converts the image and resizes it to the requested storage as it receives it
+	// +kubebuilder:validation:Enum=raw;qcow2
+	// +optional
+	Format *DataVolumeTransformationFormat `json:"format,omitempty"`
+
+	// Group fans out the source to the clones of the same group: their snapshot clones restore a single snapshot of the
+	// source, taken by the first of them
+	// +optional
+	Group string `json:"group,omitempty"`
+
+	// Export clones the volume another cluster exports at its url instead of a local source, source names the exported
+	// claim. The clone is host-assisted, its source pod downloads the volume with the export token of the secret
+	// +optional
+	Export *DataVolumeSourceExport `json:"export,omitempty"`
 }
 
 // VolumeCloneSourceList provides the needed parameters to do request a list of VolumeCloneSources from the system
@@ -802,6 +2064,252 @@ type VolumeCloneSourceList struct {
 	Items []VolumeCloneSource `json:"items"`
 }
 
+// VolumeSnapshotGrant allows the DataVolumes of other namespaces to import the VolumeSnapshots of its namespace,
+// without their creators having permissions in the namespace of the snapshots
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// +kubebuilder:resource:shortName=vsgrant;vsgrants
+type VolumeSnapshotGrant struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec VolumeSnapshotGrantSpec `json:"spec"`
+}
+
+// VolumeSnapshotGrantSpec defines specification for VolumeSnapshotGrant
+type VolumeSnapshotGrantSpec struct {
+	// Namespaces are the namespaces whose DataVolumes may import the snapshots
+	// +kubebuilder:validation:MinItems=1
+	Namespaces []string `json:"namespaces"`
+	// Snapshots are the names of the snapshots granted, all the snapshots of the namespace when empty
+	// +optional
+	Snapshots []string `json:"snapshots,omitempty"`
+}
+
+// VolumeSnapshotGrantList provides the needed parameters to request a list of VolumeSnapshotGrants from the system
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type VolumeSnapshotGrantList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	// Items provides a list of VolumeSnapshotGrants
+	Items []VolumeSnapshotGrant `json:"items"`
+}
+
+// CloneGrant allows the DataVolumes of other namespaces, or ServiceAccounts, to clone the PVCs and VolumeSnapshots of
+// its namespace, without their creators having permissions in the namespace of the sources
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// +kubebuilder:resource:shortName=cgrant;cgrants
+type CloneGrant struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec CloneGrantSpec `json:"spec"`
+}
+
+// CloneGrantSpec defines specification for CloneGrant
+type CloneGrantSpec struct {
+	// Namespaces are the namespaces whose DataVolumes may clone the sources
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+	// ServiceAccounts are the ServiceAccounts that may clone the sources, into any namespace they create DataVolumes in
+	// +optional
+	ServiceAccounts []CloneGrantServiceAccount `json:"serviceAccounts,omitempty"`
+	// Sources are the PVCs and VolumeSnapshots granted, all the ones of the namespace when empty
+	// +optional
+	Sources []CloneGrantSource `json:"sources,omitempty"`
+	// ExpirationTime is when the grant stops authorizing new clones, it does not expire when unset
+	// +optional
+	ExpirationTime *metav1.Time `json:"expirationTime,omitempty"`
+}
+
+// CloneGrantServiceAccount is a ServiceAccount granted the sources of a CloneGrant
+type CloneGrantServiceAccount struct {
+	// Namespace of the ServiceAccount
+	Namespace string `json:"namespace"`
+	// Name of the ServiceAccount
+	Name string `json:"name"`
+}
+
+// CloneGrantSourceKind is the kind of a source granted by a CloneGrant
+type CloneGrantSourceKind string
+
+const (
+	// CloneGrantSourcePVC grants a PersistentVolumeClaim
+	CloneGrantSourcePVC CloneGrantSourceKind = "PersistentVolumeClaim"
+	// CloneGrantSourceSnapshot grants a VolumeSnapshot
+	CloneGrantSourceSnapshot CloneGrantSourceKind = "VolumeSnapshot"
+)
+
+// CloneGrantSource is a PVC or VolumeSnapshot granted by a CloneGrant
+type CloneGrantSource struct {
+	// Kind of the source
+	// +kubebuilder:validation:Enum=PersistentVolumeClaim;VolumeSnapshot
+	Kind CloneGrantSourceKind `json:"kind"`
+	// Name of the source
+	Name string `json:"name"`
+}
+
+// CloneGrantList provides the needed parameters to request a list of CloneGrants from the system
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type CloneGrantList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	// Items provides a list of CloneGrants
+	Items []CloneGrant `json:"items"`
+}
+
+// ImportQuota limits the imports of its namespace, so one tenant cannot take all the import bandwidth and scratch
+// storage of the cluster. Imports over the quota wait until other imports of the namespace complete or are deleted.
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// +kubebuilder:resource:shortName=iquota;iquotas
+type ImportQuota struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ImportQuotaSpec   `json:"spec"`
+	Status ImportQuotaStatus `json:"status,omitempty"`
+}
+
+// ImportQuotaSpec defines specification for ImportQuota, unset limits are not enforced
+type ImportQuotaSpec struct {
+	// MaxConcurrentImports is the number of importer pods running at the same time in the namespace
+	// +optional
+	MaxConcurrentImports *int32 `json:"maxConcurrentImports,omitempty"`
+	// MaxImportedBytes is the total size requested by the PVCs imported in the namespace, including the completed
+	// imports whose PVCs still exist
+	// +optional
+	MaxImportedBytes *resource.Quantity `json:"maxImportedBytes,omitempty"`
+	// MaxScratchSpace is the total size requested by the scratch space PVCs of the namespace, the ones of uploads included
+	// +optional
+	MaxScratchSpace *resource.Quantity `json:"maxScratchSpace,omitempty"`
+}
+
+// ImportQuotaStatus provides the usage of the quota, observed when an import of the namespace was last admitted or
+// refused
+type ImportQuotaStatus struct {
+	// ConcurrentImports is the number of importer pods running in the namespace
+	ConcurrentImports int32 `json:"concurrentImports,omitempty"`
+	// ImportedBytes is the total size requested by the PVCs imported in the namespace
+	ImportedBytes *resource.Quantity `json:"importedBytes,omitempty"`
+	// ScratchSpace is the total size requested by the scratch space PVCs of the namespace
+	ScratchSpace *resource.Quantity `json:"scratchSpace,omitempty"`
+}
+
+// ImportQuotaList provides the needed parameters to request a list of ImportQuotas from the system
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type ImportQuotaList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	// Items provides a list of ImportQuotas
+	Items []ImportQuota `json:"items"`
+}
+
+// this has to be here otherwise informer-gen doesn't recognize it
+// see https://github.com/kubernetes/code-generator/issues/59
+// +genclient:nonNamespaced
+
+// ImageVerificationPolicy requires the registry images it applies to be signed with cosign, and to carry signed
+// attestations, before they are imported
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// +kubebuilder:resource:shortName=ivp;ivps,scope=Cluster
+type ImageVerificationPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ImageVerificationPolicySpec `json:"spec"`
+}
+
+// ImageVerificationPolicySpec defines specification for ImageVerificationPolicy
+type ImageVerificationPolicySpec struct {
+	// Images are the glob patterns of the repositories the policy applies to, like registry.example.com/golden/*.
+	// A * matches any part of a single path segment of the repository, docker.io repositories are matched with their
+	// full name, like docker.io/library/fedora
+	// +kubebuilder:validation:MinItems=1
+	Images []string `json:"images"`
+	// Authorities are who may sign the images, an image must be signed by at least one of them
+	// +kubebuilder:validation:MinItems=1
+	Authorities []ImageVerificationAuthority `json:"authorities"`
+	// Attestations the images must carry, each signed by one of the authorities
+	// +optional
+	Attestations []ImageVerificationAttestation `json:"attestations,omitempty"`
+}
+
+// ImageVerificationAuthority is a key, or the Fulcio certificate authority of keyless signatures, images may be
+// signed with. Exactly one of key and keyless is set.
+type ImageVerificationAuthority struct {
+	// Name of the authority, used in the reason of failed verifications
+	Name string `json:"name"`
+	// Key the signatures are made with
+	// +optional
+	Key *ImageVerificationKey `json:"key,omitempty"`
+	// Keyless signatures are made with short lived Fulcio certificates and recorded in a Rekor transparency log
+	// +optional
+	Keyless *ImageVerificationKeyless `json:"keyless,omitempty"`
+}
+
+// ImageVerificationKey is the public key of signatures
+type ImageVerificationKey struct {
+	// Data is the PEM encoded ECDSA, RSA or Ed25519 public key, like the cosign.pub generated by cosign generate-key-pair
+	Data string `json:"data"`
+}
+
+// ImageVerificationKeyless verifies the signatures made with Fulcio certificates
+type ImageVerificationKeyless struct {
+	// Roots are the PEM encoded root and intermediate certificates of the Fulcio certificate authority
+	Roots string `json:"roots"`
+	// RekorPublicKey is the PEM encoded public key of the Rekor transparency log the signatures are recorded in.
+	// The time the log recorded a signature is when its certificate must have been valid
+	RekorPublicKey string `json:"rekorPublicKey"`
+	// Identities are who may sign, the certificate of a signature must be issued to one of them
+	// +kubebuilder:validation:MinItems=1
+	Identities []ImageVerificationIdentity `json:"identities"`
+}
+
+// ImageVerificationIdentity is the OIDC identity a Fulcio certificate is issued to. One of subject and subjectRegExp
+// is set
+type ImageVerificationIdentity struct {
+	// Issuer is the url of the OIDC issuer, like https://token.actions.githubusercontent.com
+	Issuer string `json:"issuer"`
+	// Subject is the email or the URI the certificate is issued to
+	// +optional
+	Subject string `json:"subject,omitempty"`
+	// SubjectRegExp is a regular expression the email or the URI the certificate is issued to must match
+	// +optional
+	SubjectRegExp string `json:"subjectRegExp,omitempty"`
+}
+
+// ImageVerificationAttestation is an in-toto attestation the images must carry
+type ImageVerificationAttestation struct {
+	// Name of the attestation, used in the reason of failed verifications
+	Name string `json:"name"`
+	// PredicateType is the in-toto predicate type of the attestation, like https://slsa.dev/provenance/v1
+	PredicateType string `json:"predicateType"`
+}
+
+// ImageVerificationPolicyList provides the needed parameters to request a list of ImageVerificationPolicies from the system
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type ImageVerificationPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	// Items provides a list of ImageVerificationPolicies
+	Items []ImageVerificationPolicy `json:"items"`
+}
+
 // this has to be here otherwise informer-gen doesn't recognize it
 // see https://github.com/kubernetes/code-generator/issues/59
 // +genclient:nonNamespaced
@@ -899,6 +2407,36 @@ const (
 
 	// CloneStrategyCsiClone specifies csi volume clone based cloning
 	CloneStrategyCsiClone CDICloneStrategy = "csi-clone"
+
+	// CloneStrategyReflink specifies cloning the files of the source with reflinks or server-side copies of the
+	// filesystem it shares with the target
+	CloneStrategyReflink CDICloneStrategy = "reflink"
+)
+
+// CloneTransferMode defines how the data of host-assisted clones is transferred
+// +kubebuilder:validation:Enum=tar;rsync
+type CloneTransferMode string
+
+const (
+	// CloneTransferModeTar streams the whole source to the target
+	CloneTransferModeTar CloneTransferMode = "tar"
+
+	// CloneTransferModeRsync transfers the source with rsync, only moving the files and blocks differing from the data
+	// already on the target. Block volumes are copied as with tar
+	CloneTransferModeRsync CloneTransferMode = "rsync"
+)
+
+// CloneCompression defines how the data of host-assisted clones is compressed between the source and target pods
+// +kubebuilder:validation:Enum=snappy;zstd
+type CloneCompression string
+
+const (
+	// CloneCompressionSnappy compresses the data with snappy, cheap on CPU but only shrinking sparse or mostly empty data
+	CloneCompressionSnappy CloneCompression = "snappy"
+
+	// CloneCompressionZstd compresses the data with zstd if the target supports it, shrinking compressible data more at
+	// the cost of CPU. It speeds up clones between nodes over slower networks
+	CloneCompressionZstd CloneCompression = "zstd"
 )
 
 // CustomizeComponents defines patches for components deployed by the CDI operator.
@@ -951,6 +2489,10 @@ const (
 
 	// DataImportCronSourceFormatPvc implies using a PVC as the resulting DataImportCron disk image source
 	DataImportCronSourceFormatPvc DataImportCronSourceFormat = "pvc"
+
+	// DataImportCronSourceFormatPvcQcow2 implies using a filesystem PVC holding the disk image as compressed qcow2 as the
+	// resulting DataImportCron disk image source
+	DataImportCronSourceFormatPvcQcow2 DataImportCronSourceFormat = "pvc-qcow2"
 )
 
 // CDIUninstallStrategy defines the state to leave CDI on uninstall
@@ -1017,6 +2559,24 @@ type FilesystemOverhead struct {
 type CDIConfigSpec struct {
 	// Override the URL used when uploading to a DataVolume
 	UploadProxyURLOverride *string `json:"uploadProxyURLOverride,omitempty"`
+	// UploadProxyLimits limits the rate and concurrency of the requests accepted by the upload proxy
+	// +optional
+	UploadProxyLimits *UploadProxyLimits `json:"uploadProxyLimits,omitempty"`
+	// TokenAudit emits audit events of the issuance and use of upload and clone tokens, none are emitted if unset
+	// +optional
+	TokenAudit *TokenAuditConfig `json:"tokenAudit,omitempty"`
+	// TokenConfig sets the lifetime and audiences of the upload and clone tokens, rotates the key signing them and
+	// revokes the outstanding ones. Tokens live 5 minutes, have no audience and the key is never rotated if unset
+	// +optional
+	TokenConfig *TokenConfig `json:"tokenConfig,omitempty"`
+	// UploadCertRotation rotates the certificates of the upload servers the upload proxy connects to, which are only
+	// renewed on upload server restarts if unset
+	// +optional
+	UploadCertRotation *UploadCertRotationConfig `json:"uploadCertRotation,omitempty"`
+	// EventAggregation suppresses the repeated events of the DataVolumes and PVCs being populated and reports their
+	// progress in periodic summary events, events are only aggregated by the Kubernetes event correlator if unset
+	// +optional
+	EventAggregation *EventAggregationConfig `json:"eventAggregation,omitempty"`
 	// ImportProxy contains importer pod proxy configuration.
 	// +optional
 	ImportProxy *ImportProxy `json:"importProxy,omitempty"`
@@ -1024,14 +2584,62 @@ type CDIConfigSpec struct {
 	ScratchSpaceStorageClass *string `json:"scratchSpaceStorageClass,omitempty"`
 	// ResourceRequirements describes the compute resource requirements.
 	PodResourceRequirements *corev1.ResourceRequirements `json:"podResourceRequirements,omitempty"`
+	// TransferLimits limits the importer and clone source pods running at the same time across the cluster, the
+	// DataVolumes over the limits wait for a free slot. Not enforced if unset
+	// +optional
+	TransferLimits *TransferLimits `json:"transferLimits,omitempty"`
+	// MetadataPropagation selects the labels and annotations of DataVolumes copied to their importer and upload pods,
+	// PVC primes and scratch space PVCs. None are copied if unset
+	// +optional
+	MetadataPropagation *MetadataPropagationPolicy `json:"metadataPropagation,omitempty"`
+	// TransferPodInjection adds approved init containers, sidecars and their volumes to the importer, upload and clone
+	// source pods, like egress proxies, SPIFFE agents or monitoring sidecars. Nothing is added if unset
+	// +optional
+	TransferPodInjection *TransferPodInjection `json:"transferPodInjection,omitempty"`
+	// TransferPodPriorityClasses are the priority classes of the importer, upload and clone pods of the DataVolumes and
+	// PVCs not selecting one, so critical imports are not evicted first and batch ones can be preempted
+	// +optional
+	TransferPodPriorityClasses *TransferPodPriorityClasses `json:"transferPodPriorityClasses,omitempty"`
+	// TransferPodImages overrides the images of the importer and upload server pods, for all of them or only for the
+	// imports of some sources, so an image shipping proprietary libraries is only used by the sources needing them
+	// +optional
+	TransferPodImages *TransferPodImages `json:"transferPodImages,omitempty"`
+	// DefaultImageArchitecture is the architecture picked from the multi-architecture registry images of the
+	// DataVolumes selecting their architecture without a node selector, like amd64 or arm64
+	// +optional
+	DefaultImageArchitecture string `json:"defaultImageArchitecture,omitempty"`
 	// FeatureGates are a list of specific enabled feature gates
 	FeatureGates []string `json:"featureGates,omitempty"`
 	// FilesystemOverhead describes the space reserved for overhead when using Filesystem volumes. A value is between 0 and 1, if not defined it is 0.06 (6% overhead)
 	FilesystemOverhead *FilesystemOverhead `json:"filesystemOverhead,omitempty"`
 	// Preallocation controls whether storage for DataVolumes should be allocated in advance.
 	Preallocation *bool `json:"preallocation,omitempty"`
+	// RegistryLayerCache caches the layers pulled by the importers of registry sources on their node,
+	// so repeated imports of the same image skip the registry pull
+	// +optional
+	RegistryLayerCache *RegistryLayerCache `json:"registryLayerCache,omitempty"`
 	// InsecureRegistries is a list of TLS disabled registries
 	InsecureRegistries []string `json:"insecureRegistries,omitempty"`
+	// HostPathImportDirectories are the node directories DataVolumes may import files from with a hostPath source.
+	// hostPath sources are refused when empty
+	// +optional
+	HostPathImportDirectories []string `json:"hostPathImportDirectories,omitempty"`
+	// PostCompletionHookURLs are the URL prefixes the webhooks of the post completion hooks of DataVolumes may call.
+	// Webhook hooks fail when empty
+	// +optional
+	PostCompletionHookURLs []string `json:"postCompletionHookURLs,omitempty"`
+	// PodOverrideTolerations are the tolerations the podOverrides of DataVolumes may set, each overridden toleration
+	// must equal one of them. Overridden tolerations are refused when empty
+	// +optional
+	PodOverrideTolerations []corev1.Toleration `json:"podOverrideTolerations,omitempty"`
+	// PodOverrideAffinityKeys are the node label keys the node affinity of the podOverrides of DataVolumes may select
+	// on, and the topology keys its pod affinity and anti-affinity may use. Overridden affinities are refused when empty
+	// +optional
+	PodOverrideAffinityKeys []string `json:"podOverrideAffinityKeys,omitempty"`
+	// ImporterPriorityClasses are the priority classes the priorityClassName of imports may name. Any priority class
+	// may be named when empty
+	// +optional
+	ImporterPriorityClasses []string `json:"importerPriorityClasses,omitempty"`
 	// DataVolumeTTLSeconds is the time in seconds after DataVolume completion it can be garbage collected. Disabled by default.
 	// Deprecated: Removed in v1.62.
 	// +optional
@@ -1076,6 +2684,167 @@ type CDIConfigList struct {
 	Items []CDIConfig `json:"items"`
 }
 
+// UploadProxyLimits are the limits of the requests accepted by the upload proxy, unset limits are not enforced
+type UploadProxyLimits struct {
+	// RequestsPerSecond is the rate of the requests accepted from all clients
+	// +optional
+	RequestsPerSecond *int32 `json:"requestsPerSecond,omitempty"`
+	// ClientRequestsPerSecond is the rate of the requests accepted from each client address
+	// +optional
+	ClientRequestsPerSecond *int32 `json:"clientRequestsPerSecond,omitempty"`
+	// MaxConcurrentRequests is the number of requests proxied to the upload servers at the same time
+	// +optional
+	MaxConcurrentRequests *int32 `json:"maxConcurrentRequests,omitempty"`
+}
+
+// TransferLimits are the limits of the importer and clone source pods running at the same time, unset limits are not
+// enforced
+type TransferLimits struct {
+	// MaxConcurrentTransfers is the number of transfers running at the same time across the cluster
+	// +optional
+	MaxConcurrentTransfers *int32 `json:"maxConcurrentTransfers,omitempty"`
+	// MaxConcurrentTransfersPerNamespace is the number of transfers running at the same time in each namespace
+	// +optional
+	MaxConcurrentTransfersPerNamespace *int32 `json:"maxConcurrentTransfersPerNamespace,omitempty"`
+}
+
+// MetadataPropagationPolicy is the allow-list of the label and annotation keys of DataVolumes copied to the objects
+// CDI creates to populate them. A key ending with * matches all the keys starting with the rest of it, like
+// cost.example.com/*. Labels and annotations set by CDI are never replaced
+type MetadataPropagationPolicy struct {
+	// Labels are the keys of the labels copied
+	// +optional
+	Labels []string `json:"labels,omitempty"`
+	// Annotations are the keys of the annotations copied
+	// +optional
+	Annotations []string `json:"annotations,omitempty"`
+}
+
+// TransferPodInjection holds the containers and volumes added to the importer, upload and clone source pods. They run
+// before the init containers of CDI, and their names must not clash with the ones of CDI
+type TransferPodInjection struct {
+	// InitContainers run in order before the sidecars and the containers of CDI
+	// +optional
+	InitContainers []corev1.Container `json:"initContainers,omitempty"`
+	// Sidecars are added as init containers always restarted, which keep running along the containers of CDI and are
+	// stopped once those completed. Requires Kubernetes native sidecar containers
+	// +optional
+	Sidecars []corev1.Container `json:"sidecars,omitempty"`
+	// Volumes are the volumes mounted by the injected containers
+	// +optional
+	Volumes []corev1.Volume `json:"volumes,omitempty"`
+}
+
+// TransferPodPriorityClasses holds the priority classes of the CDI worker pods. The preemption policy of the pods is the
+// one of their priority class
+type TransferPodPriorityClasses struct {
+	// Importer is the priority class of the importer pods
+	// +optional
+	Importer string `json:"importer,omitempty"`
+	// Upload is the priority class of the upload server pods of uploads
+	// +optional
+	Upload string `json:"upload,omitempty"`
+	// CloneSource is the priority class of the source pods of host-assisted clones
+	// +optional
+	CloneSource string `json:"cloneSource,omitempty"`
+	// CloneTarget is the priority class of the target pods of host-assisted clones
+	// +optional
+	CloneTarget string `json:"cloneTarget,omitempty"`
+}
+
+// TransferPodImages holds the images overriding the ones CDI runs its importer and upload server pods with
+type TransferPodImages struct {
+	// Importer is the image of the importer pods of the sources without an image in Sources
+	// +optional
+	Importer string `json:"importer,omitempty"`
+	// Uploader is the image of the upload server pods of uploads and host-assisted clones
+	// +optional
+	Uploader string `json:"uploader,omitempty"`
+	// Sources are the images of the importer pods of the sources of their keys, like vddk, http or registry
+	// +optional
+	Sources map[string]string `json:"sources,omitempty"`
+}
+
+// TokenAuditConfig defines the sink of the audit events of tokens
+type TokenAuditConfig struct {
+	// Sink is the Log, Event or Webhook sink the audit events are emitted to, Log by default
+	// +kubebuilder:validation:Enum=Log;Event;Webhook
+	// +optional
+	Sink TokenAuditSink `json:"sink,omitempty"`
+	// WebhookURL is the URL the audit events are posted to as JSON by the Webhook sink
+	// +optional
+	WebhookURL string `json:"webhookURL,omitempty"`
+}
+
+// TokenConfig defines the upload and clone tokens issued and accepted by CDI
+type TokenConfig struct {
+	// CloneTokenLifetime is the lifetime of the tokens authorizing clones across namespaces, 5m by default
+	// +optional
+	CloneTokenLifetime *metav1.Duration `json:"cloneTokenLifetime,omitempty"`
+	// UploadTokenLifetime is the lifetime of the upload tokens, 5m by default. The tokens renewed in upload sessions
+	// don't outlive it either
+	// +optional
+	UploadTokenLifetime *metav1.Duration `json:"uploadTokenLifetime,omitempty"`
+	// Audiences are the audiences of the tokens issued. When set, only the tokens issued for one of them are accepted
+	// +optional
+	Audiences []string `json:"audiences,omitempty"`
+	// SigningKeyRotationInterval is how often the key signing the tokens is rotated. The tokens signed by the previous
+	// key are accepted until the next rotation. Never rotated by default
+	// +optional
+	SigningKeyRotationInterval *metav1.Duration `json:"signingKeyRotationInterval,omitempty"`
+	// RevokeTokensIssuedBefore revokes the tokens issued before this time, the ones stored for the clones in progress
+	// included. Setting it to the current time revokes all the outstanding tokens. The signing key is rotated as well if it
+	// was last rotated before this time, and the previous key is not kept
+	// +optional
+	RevokeTokensIssuedBefore *metav1.Time `json:"revokeTokensIssuedBefore,omitempty"`
+}
+
+// TokenAuditSink is the sink of the audit events of tokens
+type TokenAuditSink string
+
+const (
+	// TokenAuditSinkLog writes the audit events to the log of the component
+	TokenAuditSinkLog TokenAuditSink = "Log"
+	// TokenAuditSinkEvent records the audit events as Kubernetes events of the objects of the tokens
+	TokenAuditSinkEvent TokenAuditSink = "Event"
+	// TokenAuditSinkWebhook posts the audit events to a webhook
+	TokenAuditSinkWebhook TokenAuditSink = "Webhook"
+)
+
+// UploadCertRotationConfig defines the rotation of the upload server certificates
+type UploadCertRotationConfig struct {
+	// RotationInterval is the lifetime of the upload server certificates, 24h by default
+	// +optional
+	RotationInterval *metav1.Duration `json:"rotationInterval,omitempty"`
+	// RenewalOverlap is how long before expiring the upload server certificates are renewed, half the RotationInterval
+	// by default
+	// +optional
+	RenewalOverlap *metav1.Duration `json:"renewalOverlap,omitempty"`
+}
+
+// EventAggregationConfig defines the thresholds of the aggregation of the events emitted while populating DataVolumes
+// and PVCs
+type EventAggregationConfig struct {
+	// DuplicateSuppressionWindow is how long an event is not emitted again for the same object with the same type,
+	// reason and message, 10m by default. The next event emitted reports how many were suppressed
+	// +optional
+	DuplicateSuppressionWindow *metav1.Duration `json:"duplicateSuppressionWindow,omitempty"`
+	// ProgressSummaryInterval is how often the progress of the DataVolumes being populated is reported in an event,
+	// 5m by default
+	// +optional
+	ProgressSummaryInterval *metav1.Duration `json:"progressSummaryInterval,omitempty"`
+}
+
+// RegistryLayerCache is a node directory holding the layers of registry images by digest
+type RegistryLayerCache struct {
+	// HostPath is the absolute path of the cache directory on the nodes, created if missing.
+	// The importer runs as uid 107 and needs to write to it
+	HostPath string `json:"hostPath"`
+	// MaxSize is the size of the cache above which the least recently used layers are evicted, 10Gi if unset
+	// +optional
+	MaxSize *resource.Quantity `json:"maxSize,omitempty"`
+}
+
 // ImportProxy provides the information on how to configure the importer pod proxy.
 type ImportProxy struct {
 	// HTTPProxy is the URL http://<username>:<pswd>@<ip>:<port> of the import proxy for HTTP requests.  Empty means unset and will not result in the import pod env var.
@@ -11,16 +11,117 @@ func (DataVolume) SwaggerDoc() map[string]string {
 
 func (DataVolumeSpec) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"":                  "DataVolumeSpec defines the DataVolume type specification",
-		"source":            "Source is the src of the data for the requested DataVolume\n+optional",
-		"sourceRef":         "SourceRef is an indirect reference to the source of data for the requested DataVolume\n+optional",
-		"pvc":               "PVC is the PVC specification",
-		"storage":           "Storage is the requested storage specification",
-		"priorityClassName": "PriorityClassName for Importer, Cloner and Uploader pod",
-		"contentType":       "DataVolumeContentType options: \"kubevirt\", \"archive\"\n+kubebuilder:validation:Enum=\"kubevirt\";\"archive\"",
-		"checkpoints":       "Checkpoints is a list of DataVolumeCheckpoints, representing stages in a multistage import.",
-		"finalCheckpoint":   "FinalCheckpoint indicates whether the current DataVolumeCheckpoint is the final checkpoint.",
-		"preallocation":     "Preallocation controls whether storage for DataVolumes should be allocated in advance.",
+		"":                       "DataVolumeSpec defines the DataVolume type specification",
+		"source":                 "Source is the src of the data for the requested DataVolume\n+optional",
+		"sourceRef":              "SourceRef is an indirect reference to the source of data for the requested DataVolume\n+optional",
+		"sourceFallbacks":        "SourceFallbacks is an ordered list of alternative sources of the data, like mirrors of an image. Each time the\nimporter fails to import from the source, the import is retried from the next fallback.\n+optional",
+		"pvc":                    "PVC is the PVC specification",
+		"storage":                "Storage is the requested storage specification",
+		"priorityClassName":      "PriorityClassName for Importer, Cloner and Uploader pod",
+		"contentType":            "DataVolumeContentType options: \"kubevirt\", \"archive\"\n+kubebuilder:validation:Enum=\"kubevirt\";\"archive\"",
+		"checkpoints":            "Checkpoints is a list of DataVolumeCheckpoints, representing stages in a multistage import.",
+		"finalCheckpoint":        "FinalCheckpoint indicates whether the current DataVolumeCheckpoint is the final checkpoint.",
+		"preallocation":          "Preallocation controls whether storage for DataVolumes should be allocated in advance.",
+		"retryPolicy":            "RetryPolicy controls how failed imports are retried. Without one the importer pod is restarted until the import\nsucceeds.\n+optional",
+		"paused":                 "Paused suspends the import until it is unset. Downloads to scratch space then continue where they stopped, other\nimports start over.\n+optional",
+		"ttlAfterCompletion":     "TTLAfterCompletion is how long the DataVolume is kept once it succeeded before it is deleted, along with its PVC.\nKept until deleted if unset\n+optional",
+		"failureRetentionPolicy": "FailureRetentionPolicy controls whether the DataVolume is kept once it failed, Retain if unset\n+optional",
+		"podOverrides":           "PodOverrides replaces the CDI-wide scheduling and resource settings of the importer pod of the DataVolume\n+optional",
+		"transferPriority":       "TransferPriority orders the DataVolumes waiting for a transfer slot once the transfer limits of the CDIConfig are\nreached, higher first, 0 if unset\n+optional",
+		"postCompletionHooks":    "PostCompletionHooks are run in order once the DataVolume is populated, before it succeeds. The DataVolume fails\nif one of them fails\n+optional",
+		"validateOnly":           "ValidateOnly makes the importer check the source is reachable with its credentials and its image fits the\nrequested storage, in a pod without any PVC. No PVC is created. The results are reported in the validation of the status\n+optional",
+		"expandFilesystem":       "ExpandFilesystem grows the last partition of the imported image and its ext2/3/4, xfs or btrfs filesystem to fill\nthe PVC when it is larger than the image, so guests see the whole volume without resizing it on boot\n+optional",
+		"topology":               "Topology hints the topology domain the consumer of the DataVolume runs in. On WaitForFirstConsumer storage classes\nthe import is then scheduled in that domain without waiting for the consumer\n+optional",
+		"scratchSpace":           "ScratchSpace replaces the CDI-wide storage class and the size of the scratch space PVC of the import or upload of\nthe DataVolume\n+optional",
+		"verify":                 "Verify checks the disk image written to the PVC before the DataVolume succeeds, and optionally compares it with\nits source\n+optional",
+		"deduplicate":            "Deduplicate clones the PVC of a DataVolume of the namespace that imported the same source to the same storage\nclass, instead of importing it again. The source must be pinned, by the digest of a registry image or the checksum\nof a http, s3 or gcs source. The PVCs of deduplicating DataVolumes are cloned as long as they exist, so they should\nbe kept as imported, like golden images. Requires CDI populators\n+optional",
+		"proxy":                  "Proxy replaces the importProxy of the CDIConfig for the import of the DataVolume\n+optional",
+		"cloneTransferMode":      "CloneTransferMode is how the data of host-assisted clones of the DataVolume is transferred, tar if unset\n+optional",
+		"cloneCompression":       "CloneCompression is how the data of host-assisted clones of the DataVolume is compressed on the network, the\ncloneCompression of the StorageProfile of the target if unset\n+optional",
+		"cloneStrategy":          "CloneStrategy is the strategy of the clone of the DataVolume: snapshot, csi-clone, reflink or copy. It takes\nprecedence over the cloneStrategy of the StorageProfile of the target\n+optional",
+		"cloneStrategyFallbacks": "CloneStrategyFallbacks is an ordered list of the strategies tried when the clone strategy can't be used. Once the\nclone strategy or its fallbacks are set, the clone only uses the listed strategies instead of falling back to copy\n+optional",
+		"cloneFormat":            "CloneFormat is the format the cloned image is stored in on a filesystem target, qcow2 compresses it. Once set, the\nclone is host-assisted and its target converts the image and resizes it to the requested storage as it receives it\n+kubebuilder:validation:Enum=raw;qcow2\n+optional",
+		"cloneGroup":             "CloneGroup fans out the source of the clone to the DataVolumes of the same group cloning it: their snapshot clones\nrestore a single snapshot of the source, taken by the first of them\n+optional",
+	}
+}
+
+func (DataVolumeProxy) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":               "DataVolumeProxy is the proxy configuration of the import of a DataVolume. The fields left unset are not used, so an\nempty proxy has the import reach its source directly",
+		"httpProxy":      "HTTPProxy is the URL http://<username>:<pswd>@<ip>:<port> of the proxy for HTTP requests\n+optional",
+		"httpsProxy":     "HTTPSProxy is the URL https://<username>:<pswd>@<ip>:<port> of the proxy for HTTPS requests\n+optional",
+		"noProxy":        "NoProxy is a comma-separated list of hostnames and/or CIDRs for which the proxy is not used\n+optional",
+		"trustedCAProxy": "TrustedCAProxy is the name of a ConfigMap in the namespace of the DataVolume holding the trusted certificate\nauthority (CA) bundle of the proxy, like the trustedCAProxy of the CDIConfig\n+optional",
+	}
+}
+
+func (DataVolumeVerify) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":         "DataVolumeVerify defines the checks of an imported disk image, run once it is written to the PVC. qemu-img checks\nthe consistency of the image",
+		"checksum": "Checksum compares the content of the disk image with the source it was converted from, which is downloaded to\nscratch space and checked against its checksum first. Requires a http, s3 or gcs source with a checksum\n+optional",
+	}
+}
+
+func (DataVolumeScratchSpace) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                 "DataVolumeScratchSpace holds the settings of the scratch space PVC of a DataVolume that replace the CDI-wide ones. At\nmost one of size and sizeMultiplier may be set",
+		"storageClassName": "StorageClassName replaces the scratchSpaceStorageClass of the CDIConfig for the scratch space PVC\n+optional",
+		"size":             "Size is the storage requested by the scratch space PVC, instead of the size of the target PVC\n+optional",
+		"sizeMultiplier":   "SizeMultiplier scales the storage requested by the scratch space PVC, like \"1.5\" for images growing on\nconversion, or \"0.5\" for sources known to be compressed\n+optional",
+	}
+}
+
+func (DataVolumeTopology) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                       "DataVolumeTopology is the topology domain the consumer of a DataVolume runs in, from a node selector, a\nVirtualMachineInstance or both",
+		"nodeSelector":           "NodeSelector selects the nodes of the topology domain, like with the topology.kubernetes.io/zone label\n+optional",
+		"virtualMachineInstance": "VirtualMachineInstance is the name of the VirtualMachineInstance consuming the DataVolume, in its namespace.\nIts node, or else its node selector and required node affinity, place the import. The import waits for it to exist\n+optional",
+	}
+}
+
+func (DataVolumeHook) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":        "DataVolumeHook is a step customizing the data of a populated DataVolume, like sysprep or license injection. Exactly\none of Job and Webhook is set",
+		"name":    "Name identifies the hook in the status of the DataVolume, unique among the hooks of the DataVolume",
+		"job":     "Job runs the hook as a Job with the PVC of the DataVolume mounted\n+optional",
+		"webhook": "Webhook runs the hook by calling a URL\n+optional",
+	}
+}
+
+func (DataVolumeHookJob) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":             "DataVolumeHookJob is a hook run as a Job. The PVC is mounted in /data for filesystem volumes and attached as\n/dev/cdi-block-volume for block ones, the CDI_DATA_PATH, CDI_DATAVOLUME_NAME, CDI_DATAVOLUME_NAMESPACE and\nCDI_HOOK_NAME environment variables describe the DataVolume",
+		"image":        "Image is the container image running the hook",
+		"command":      "Command is the entrypoint of the container, the one of the image if unset\n+optional",
+		"args":         "Args are the arguments of the entrypoint\n+optional",
+		"backoffLimit": "BackoffLimit is the number of retries of the Job before the hook fails, the Job default if unset\n+optional",
+	}
+}
+
+func (DataVolumeHookWebhook) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":    "DataVolumeHookWebhook is a hook run by a POST request, whose JSON body holds the namespace, dataVolume, claimName\nand hook names. The hook succeeds if the response status is 2xx",
+		"url": "URL is the http or https URL receiving the request, starting with one of the postCompletionHookURLs of the\nCDIConfig",
+	}
+}
+
+func (DataVolumePodOverrides) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":             "DataVolumePodOverrides holds the settings of the importer pod of a DataVolume that replace the CDI-wide ones",
+		"resources":    "Resources replaces the resource requirements of the importer pod containers set in the CDIConfig\n+optional",
+		"nodeSelector": "NodeSelector replaces the node selector of the CDI workloads for the importer pod\n+optional",
+		"tolerations":  "Tolerations replaces the tolerations of the CDI workloads for the importer pod\n+optional",
+		"affinity":     "Affinity replaces the affinity of the CDI workloads for the importer pod\n+optional",
+	}
+}
+
+func (DataVolumeRetryPolicy) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":            "DataVolumeRetryPolicy controls how the failed imports of a DataVolume are retried",
+		"maxRetries":  "MaxRetries is the number of times a failed import is retried before the DataVolume fails, unlimited if unset\n+optional",
+		"backoffBase": "BackoffBase is the delay before the first retry, doubled for each retry after it, 10s if unset\n+optional",
+		"backoffCap":  "BackoffCap is the longest delay between two retries, 5m if unset\n+optional",
+		"retryOn":     "RetryOn lists the classes of errors the import is retried on, all of them if empty\n+optional",
 	}
 }
 
@@ -48,7 +149,13 @@ func (DataVolumeCheckpoint) SwaggerDoc() map[string]string {
 
 func (DataVolumeSource) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"": "DataVolumeSource represents the source for our Data Volume, this can be HTTP, Imageio, S3, GCS, Registry or an existing PVC",
+		"": "DataVolumeSource represents the source for our Data Volume, this can be HTTP, Imageio, S3, GCS, Azure, SFTP, SMB, Glance, RBD, iSCSI, a node hostPath, the export of another cluster, a VM converted by virt-v2v, a Proxmox VE VM disk, a source plugin, a BitTorrent swarm, Registry or an existing PVC",
+	}
+}
+
+func (DataVolumeSourceFallback) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"": "DataVolumeSourceFallback is an alternative HTTP or S3 source of the data of a DataVolume",
 	}
 }
 
@@ -68,6 +175,31 @@ func (DataVolumeSourceSnapshot) SwaggerDoc() map[string]string {
 	}
 }
 
+func (DataVolumeSourceDataVolume) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":               "DataVolumeSourceDataVolume provides the parameters to create a Data Volume from the data of another Data Volume of\nits namespace, transformed on the way, chaining Data Volumes into pipelines",
+		"name":           "Name is the name of the source DataVolume, the import starts once it succeeded",
+		"transformation": "Transformation is applied to the data of the source DataVolume. The image is resized by requesting a larger storage size\n+optional",
+		"deleteSource":   "DeleteSource deletes the source DataVolume, an intermediate volume of the pipeline, once the import succeeded\n+optional",
+	}
+}
+
+func (DataVolumeTransformation) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":           "DataVolumeTransformation is the transformation of the data of a source DataVolume",
+		"format":     "Format is the format the image is stored in, qcow2 compresses it on a filesystem volume. Raw if unset\n+kubebuilder:validation:Enum=raw;qcow2\n+optional",
+		"sparsify":   "Sparsify discards the blocks the filesystems of the image do not use\n+optional",
+		"encryption": "Encryption stores the image as a LUKS encrypted qcow2 image on a filesystem volume\n+optional",
+	}
+}
+
+func (DataVolumeEncryption) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":          "DataVolumeEncryption provides the passphrase an image is encrypted with",
+		"secretRef": "SecretRef is the name of the secret holding the passphrase in its passphrase key",
+	}
+}
+
 func (DataSourceRefSourceDataSource) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"":          "DataSourceRefSourceDataSource serves as a reference to another DataSource\nCan be resolved into a DataVolumeSourcePVC or a DataVolumeSourceSnapshot\nThe maximum depth of a reference chain may not exceed 1.",
@@ -90,57 +222,187 @@ func (DataVolumeSourceUpload) SwaggerDoc() map[string]string {
 
 func (DataVolumeSourceS3) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"":              "DataVolumeSourceS3 provides the parameters to create a Data Volume from an S3 source",
-		"url":           "URL is the url of the S3 source",
-		"secretRef":     "SecretRef provides the secret reference needed to access the S3 source",
-		"certConfigMap": "CertConfigMap is a configmap reference, containing a Certificate Authority(CA) public key, and a base64 encoded pem certificate\n+optional",
+		"":                    "DataVolumeSourceS3 provides the parameters to create a Data Volume from an S3 source",
+		"url":                 "URL is the url of the S3 source",
+		"secretRef":           "SecretRef provides the secret reference needed to access the S3 source",
+		"certConfigMap":       "CertConfigMap is a configmap reference, containing a Certificate Authority(CA) public key, and a base64 encoded pem certificate\n+optional",
+		"clientCertSecretRef": "ClientCertSecretRef is a kubernetes.io/tls secret reference, containing the client certificate and key presented\nto the source when it requires mutual TLS\n+optional",
+		"serviceAccountName":  "ServiceAccountName is the service account the importer pod runs as, so credentials bound to it,\nlike IAM roles for service accounts, are used when the secret holds no access keys.\nThe user creating the source must be allowed to impersonate the service account\n+optional",
+		"checksum":            "Checksum is the digest of the source, the import fails if the data downloaded does not match it\n+optional",
+		"signature":           "Signature is a detached GPG signature of the source, the import fails if the data downloaded is not signed\nby a key of its keyring\n+optional",
 	}
 }
 
 func (DataVolumeSourceGCS) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"":          "DataVolumeSourceGCS provides the parameters to create a Data Volume from an GCS source",
-		"url":       "URL is the url of the GCS source",
-		"secretRef": "SecretRef provides the secret reference needed to access the GCS source",
+		"":                    "DataVolumeSourceGCS provides the parameters to create a Data Volume from an GCS source",
+		"url":                 "URL is the url of the GCS source",
+		"secretRef":           "SecretRef provides the secret reference needed to access the GCS source",
+		"certConfigMap":       "CertConfigMap is a configmap reference, containing a Certificate Authority(CA) public key of a private GCS endpoint\n+optional",
+		"clientCertSecretRef": "ClientCertSecretRef is a kubernetes.io/tls secret reference, containing the client certificate and key presented\nto the source when it requires mutual TLS\n+optional",
+		"checksum":            "Checksum is the digest of the source, the import fails if the data downloaded does not match it\n+optional",
+	}
+}
+
+func (DataVolumeSourceAzure) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                    "DataVolumeSourceAzure provides the parameters to create a Data Volume from an Azure Blob Storage source",
+		"url":                 "URL is the url of the blob, https://<account>.blob.core.windows.net/<container>/<blob>",
+		"secretRef":           "SecretRef provides the secret reference needed to access the blob, containing either a sasToken\nor the tenantId, clientId and clientSecret of a service principal\n+optional",
+		"certConfigMap":       "CertConfigMap is a configmap reference, containing a Certificate Authority(CA) public key, and a base64 encoded pem certificate\n+optional",
+		"clientCertSecretRef": "ClientCertSecretRef is a kubernetes.io/tls secret reference, containing the client certificate and key presented\nto the source when it requires mutual TLS\n+optional",
+	}
+}
+
+func (DataVolumeSourceSFTP) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":          "DataVolumeSourceSFTP provides the parameters to create a Data Volume from an SFTP server",
+		"url":       "URL is the url of the image on the server, sftp://<user>@<host>[:<port>]/<path>",
+		"secretRef": "SecretRef provides the secret reference holding the known_hosts the server key is checked against,\nand the ssh-privatekey or password to log in with",
+	}
+}
+
+func (DataVolumeSourceSMB) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":          "DataVolumeSourceSMB provides the parameters to create a Data Volume from an SMB share",
+		"url":       "URL is the url of the image on the share, smb://<server>/<share>/<path>, or smbs://<server>/<share>/<path> to require SMB 3 encryption",
+		"secretRef": "SecretRef provides the secret reference holding the username, password and optional domain the SMB CSI driver\nmounts the share with, the share is mounted as guest without it\n+optional",
+	}
+}
+
+func (DataVolumeSourceGlance) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                    "DataVolumeSourceGlance provides the parameters to create a Data Volume from an image of the OpenStack Image service",
+		"url":                 "URL is the url of the Keystone identity service, like https://keystone.example.com:5000/v3, the Image service is found in its catalog",
+		"image":               "Image is the name or the UUID of the image",
+		"secretRef":           "SecretRef provides the secret reference holding the Keystone credentials, either the applicationCredentialId and\napplicationCredentialSecret, or the username, password, userDomainName, projectName and projectDomainName.\nregionName optionally picks the region of the Image service",
+		"certConfigMap":       "CertConfigMap is a configmap reference, containing a Certificate Authority(CA) public key, and a base64 encoded pem certificate\n+optional",
+		"clientCertSecretRef": "ClientCertSecretRef is a kubernetes.io/tls secret reference, containing the client certificate and key presented\nto the source when it requires mutual TLS\n+optional",
+	}
+}
+
+func (DataVolumeSourceRBD) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":          "DataVolumeSourceRBD provides the parameters to create a Data Volume from an RBD image of an external Ceph cluster",
+		"monitors":  "Monitors are the addresses of the Ceph monitors, like 192.168.1.10:6789",
+		"pool":      "Pool is the name of the pool holding the image",
+		"image":     "Image is the name of the RBD image",
+		"snapshot":  "Snapshot is the name of a snapshot of the image to import instead of its current content\n+optional",
+		"secretRef": "SecretRef provides the secret reference holding the Ceph keyring in its keyring key, and the Ceph user in its\noptional userID key, admin by default",
+	}
+}
+
+func (DataVolumeSourceISCSI) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":          "DataVolumeSourceISCSI provides the parameters to create a Data Volume from an iSCSI LUN",
+		"portal":    "Portal is the address of the iSCSI target portal, host or host:port, the port is 3260 by default",
+		"iqn":       "IQN is the iSCSI qualified name of the target",
+		"lun":       "LUN is the number of the logical unit to import",
+		"secretRef": "SecretRef provides the secret reference holding the CHAP user in its accessKeyId key and the CHAP password in its secretKey key\n+optional",
+	}
+}
+
+func (DataVolumeSourceHostPath) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":         "DataVolumeSourceHostPath provides the parameters to create a Data Volume from a file already present on a node",
+		"nodeName": "NodeName is the name of the node holding the file, the importer runs on it",
+		"path":     "Path is the absolute path of the file on the node, under one of the hostPathImportDirectories of the CDIConfig",
+	}
+}
+
+func (DataVolumeSourceExport) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                    "DataVolumeSourceExport provides the parameters to create a Data Volume from a volume exported by another cluster",
+		"url":                 "URL is the https url of the exported volume, like the disk.img.gz url of a volume of a VirtualMachineExport,\nor its disk.tar.gz url for archive content",
+		"secretRef":           "SecretRef provides the secret reference holding the export token in its token key, and optionally\nthe client certificate and key presented to the export server in its tls.crt and tls.key keys",
+		"certConfigMap":       "CertConfigMap is a configmap reference, containing a Certificate Authority(CA) public key of the export server\n+optional",
+		"clientCertSecretRef": "ClientCertSecretRef is a kubernetes.io/tls secret reference, containing the client certificate and key presented\nto the source when it requires mutual TLS\n+optional",
 	}
 }
 
 func (DataVolumeSourceRegistry) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"":              "DataVolumeSourceRegistry provides the parameters to create a Data Volume from an registry source",
-		"url":           "URL is the url of the registry source (starting with the scheme: docker, oci-archive)\n+optional",
-		"imageStream":   "ImageStream is the name of image stream for import\n+optional",
-		"pullMethod":    "PullMethod can be either \"pod\" (default import), or \"node\" (node docker cache based import)\n+optional",
-		"secretRef":     "SecretRef provides the secret reference needed to access the Registry source\n+optional",
-		"certConfigMap": "CertConfigMap provides a reference to the Registry certs\n+optional",
-		"platform":      "Platform describes the minimum runtime requirements of the image\n+optional",
+		"":                    "DataVolumeSourceRegistry provides the parameters to create a Data Volume from an registry source",
+		"url":                 "URL is the url of the registry source (starting with the scheme: docker, oci-archive)\n+optional",
+		"imageStream":         "ImageStream is the name of image stream for import\n+optional",
+		"pullMethod":          "PullMethod can be either \"pod\" (default import), or \"node\" (node docker cache based import)\n+optional",
+		"secretRef":           "SecretRef provides the secret reference needed to access the Registry source\n+optional",
+		"certConfigMap":       "CertConfigMap provides a reference to the Registry certs\n+optional",
+		"clientCertSecretRef": "ClientCertSecretRef provides a reference to the kubernetes.io/tls secret of the client certificate presented to\nregistries requiring mutual TLS\n+optional",
+		"platform":            "Platform describes the minimum runtime requirements of the image\n+optional",
 	}
 }
 
 func (PlatformOptions) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"architecture": "Architecture specifies the image target CPU architecture\n+optional",
+		"architecture":       "Architecture specifies the image target CPU architecture\n+optional",
+		"selectArchitecture": "SelectArchitecture picks the architecture of the image from a multi-architecture image index when Architecture\nis not set: the kubernetes.io/arch of the node selector of the topology of the DataVolume, else the default\nimage architecture of the CDIConfig\n+optional",
+	}
+}
+
+func (DataVolumeImportedPlatform) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":             "DataVolumeImportedPlatform is the platform of an imported registry image",
+		"architecture": "Architecture is the CPU architecture of the image\n+optional",
+		"digest":       "Digest is the digest of the manifest of the image of the platform\n+optional",
+	}
+}
+
+func (DataVolumeChecksum) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":          "DataVolumeChecksum is the digest of the data of a source, checked while it is imported",
+		"algorithm": "Algorithm is the hash algorithm of the digest, one of md5, sha1, sha256 or sha512",
+		"value":     "Value is the hex encoded digest",
+	}
+}
+
+func (DataVolumeSignature) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                 "DataVolumeSignature is a detached GPG signature of the data of a source, checked while it is imported",
+		"url":              "URL is the url of the signature, armored or binary, absolute or relative to the url of the source. It is\nfetched like the source, with its credentials.",
+		"keyringSecretRef": "KeyringSecretRef is a Secret reference, each key of the secret holds armored or binary public keys the source\nmay be signed with",
 	}
 }
 
 func (DataVolumeSourceHTTP) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"":                   "DataVolumeSourceHTTP can be either an http or https endpoint, with an optional basic auth user name and password, and an optional configmap containing additional CAs",
-		"url":                "URL is the URL of the http(s) endpoint",
-		"secretRef":          "SecretRef A Secret reference, the secret should contain accessKeyId (user name) base64 encoded, and secretKey (password) also base64 encoded\n+optional",
-		"certConfigMap":      "CertConfigMap is a configmap reference, containing a Certificate Authority(CA) public key, and a base64 encoded pem certificate\n+optional",
-		"extraHeaders":       "ExtraHeaders is a list of strings containing extra headers to include with HTTP transfer requests\n+optional",
-		"secretExtraHeaders": "SecretExtraHeaders is a list of Secret references, each containing an extra HTTP header that may include sensitive information\n+optional",
+		"":                    "DataVolumeSourceHTTP can be either an http or https endpoint, with an optional basic auth user name and password, and an optional configmap containing additional CAs",
+		"url":                 "URL is the URL of the http(s) endpoint",
+		"secretRef":           "SecretRef A Secret reference, the secret should contain accessKeyId (user name) base64 encoded, and secretKey (password) also base64 encoded\n+optional",
+		"certConfigMap":       "CertConfigMap is a configmap reference, containing a Certificate Authority(CA) public key, and a base64 encoded pem certificate\n+optional",
+		"clientCertSecretRef": "ClientCertSecretRef is a kubernetes.io/tls secret reference, containing the client certificate and key presented\nto the source when it requires mutual TLS\n+optional",
+		"extraHeaders":        "ExtraHeaders is a list of strings containing extra headers to include with HTTP transfer requests\n+optional",
+		"secretExtraHeaders":  "SecretExtraHeaders is a list of Secret references, each containing an extra HTTP header that may include sensitive information\n+optional",
+		"oauth2SecretRef":     "OAuth2SecretRef is a Secret reference, the secret should contain the tokenUrl, clientId and clientSecret, and optionally\nthe space separated scopes, of an OAuth2 client. The importer sends a bearer token obtained with the client credentials\ngrant, and refreshes it before it expires.\n+optional",
+		"ova":                 "OVA imports a disk of the OVA at the url, instead of the url itself\n+optional",
+		"xva":                 "XVA imports a disk of the XenServer or XCP-ng VM export at the url, instead of the url itself\n+optional",
+		"checksum":            "Checksum is the digest of the source, the import fails if the data downloaded does not match it\n+optional",
+		"signature":           "Signature is a detached GPG signature of the source, the import fails if the data downloaded is not signed\nby a key of its keyring\n+optional",
+	}
+}
+
+func (DataVolumeSourceOVA) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":          "DataVolumeSourceOVA selects the disk of an OVA to import",
+		"diskIndex": "DiskIndex is the position of the disk in the OVF descriptor of the OVA, the first disk if not set. The disks of a\nmulti-disk OVA are imported with one DataVolume per disk.\n+optional",
+	}
+}
+
+func (DataVolumeSourceXVA) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":          "DataVolumeSourceXVA selects the disk of an XVA to import",
+		"diskIndex": "DiskIndex is the position of the disk in the devices of the exported VM, the first disk if not set. cdrom drives\nare skipped, and the disks of a multi-disk VM are imported with one DataVolume per disk.\n+optional",
 	}
 }
 
 func (DataVolumeSourceImageIO) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"":              "DataVolumeSourceImageIO provides the parameters to create a Data Volume from an imageio source",
-		"url":           "URL is the URL of the ovirt-engine",
-		"diskId":        "DiskID provides id of a disk to be imported",
-		"secretRef":     "SecretRef provides the secret reference needed to access the ovirt-engine",
-		"certConfigMap": "CertConfigMap provides a reference to the CA cert",
+		"":                    "DataVolumeSourceImageIO provides the parameters to create a Data Volume from an imageio source",
+		"url":                 "URL is the URL of the ovirt-engine",
+		"diskId":              "DiskID provides id of a disk to be imported",
+		"secretRef":           "SecretRef provides the secret reference needed to access the ovirt-engine",
+		"certConfigMap":       "CertConfigMap provides a reference to the CA cert",
+		"clientCertSecretRef": "ClientCertSecretRef is a kubernetes.io/tls secret reference, containing the client certificate and key presented\nto the source when it requires mutual TLS\n+optional",
 	}
 }
 
@@ -157,6 +419,63 @@ func (DataVolumeSourceVDDK) SwaggerDoc() map[string]string {
 	}
 }
 
+func (DataVolumeSourceV2V) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                "DataVolumeSourceV2V provides the parameters to create a Data Volume from a disk of a vSphere VM converted by virt-v2v,\nwhich installs the virtio drivers in the guest and fixes its boot configuration so it boots in KubeVirt",
+		"url":             "URL is the libvirt connection url of the vCenter, like vpx://vcenter.example.com/Datacenter/Cluster/esxi1.example.com?no_verify=1,\nor of the ESXi host, like esx://esxi1.example.com?no_verify=1, holding the VM",
+		"vmName":          "VMName is the name of the VM to convert, it must be powered off",
+		"diskIndex":       "DiskIndex is the index of the disk of the VM imported into the Data Volume, 0 for its first disk\n+optional",
+		"secretRef":       "SecretRef provides a reference to a secret containing the username and password needed to access the vCenter or ESXi host",
+		"blockDriver":     "BlockDriver is the virtio driver the guest is configured to boot from, virtio-blk or virtio-scsi, defaults to virtio-blk\n+optional",
+		"virtioWinPVC":    "VirtioWinPVC is the name of a filesystem PVC holding the virtio-win ISO, imported by a DataVolume, to install the\nvirtio drivers in Windows guests from\n+optional",
+		"networkMappings": "NetworkMappings configure the network interfaces of the guest, by their MAC address\n+optional",
+	}
+}
+
+func (V2VNetworkMapping) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":             "V2VNetworkMapping maps a network interface of a VM converted by virt-v2v",
+		"mac":          "MAC is the MAC address of the interface in the source VM",
+		"network":      "Network is the name of the network the interface is mapped to\n+optional",
+		"ip":           "IP is a static IP address configured on the interface of Windows guests, like the one it had in vSphere\n+optional",
+		"gateway":      "Gateway is the default gateway configured with the static IP address\n+optional",
+		"prefixLength": "PrefixLength is the length of the network prefix of the static IP address\n+optional",
+		"nameservers":  "Nameservers are the DNS servers configured with the static IP address\n+optional",
+	}
+}
+
+func (DataVolumeSourceProxmox) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                    "DataVolumeSourceProxmox provides the parameters to create a Data Volume from a disk of a Proxmox VE VM",
+		"url":                 "URL is the url of the Proxmox VE API, like https://pve.example.com:8006",
+		"node":                "Node is the name of the Proxmox VE node running the VM",
+		"vmid":                "VMID is the id of the VM, it must be stopped",
+		"disk":                "Disk is the key of the disk in the VM configuration, like scsi0 or virtio1, defaults to the first disk of the boot order\n+optional",
+		"secretRef":           "SecretRef provides the secret reference holding the API token in its tokenID and tokenSecret keys, and the\nknown_hosts, ssh-privatekey or password keys the disk is read from the node over SFTP with",
+		"certConfigMap":       "CertConfigMap is a configmap reference, containing a Certificate Authority(CA) public key of the Proxmox VE API\n+optional",
+		"clientCertSecretRef": "ClientCertSecretRef is a kubernetes.io/tls secret reference, containing the client certificate and key presented\nto the source when it requires mutual TLS\n+optional",
+	}
+}
+
+func (DataVolumeSourcePlugin) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":           "DataVolumeSourcePlugin provides the parameters to create a Data Volume from an external source plugin, a container\nrun next to the importer serving the image over gRPC",
+		"name":       "Name is the name of the plugin, registered by the cluster admin with its image in the cdi-source-plugins\nConfigMap of the CDI namespace",
+		"parameters": "Parameters are passed to the plugin, to name the image it serves\n+optional",
+		"secretRef":  "SecretRef provides the secret reference mounted in the plugin container, holding the credentials of the plugin\n+optional",
+	}
+}
+
+func (DataVolumeSourceTorrent) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":              "DataVolumeSourceTorrent provides the parameters to create a Data Volume from a BitTorrent swarm, with the pieces\nof the image checked against the hashes of the torrent",
+		"url":           "URL is the magnet link of the image, or the http(s) url of its .torrent file",
+		"webSeeds":      "WebSeeds are http(s) urls of the image, pieces no peer serves are downloaded from\n+optional",
+		"file":          "File is the path of the image in the torrent, required when the torrent holds several files\n+optional",
+		"certConfigMap": "CertConfigMap is a configmap reference, containing a Certificate Authority(CA) public key of the .torrent url\nand the web seeds\n+optional",
+	}
+}
+
 func (DataVolumeSourceRef) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"":          "DataVolumeSourceRef defines an indirect reference to the source of data for the DataVolume",
@@ -168,10 +487,72 @@ func (DataVolumeSourceRef) SwaggerDoc() map[string]string {
 
 func (DataVolumeStatus) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"":             "DataVolumeStatus contains the current status of the DataVolume",
-		"claimName":    "ClaimName is the name of the underlying PVC used by the DataVolume.",
-		"phase":        "Phase is the current phase of the data volume",
-		"restartCount": "RestartCount is the number of times the pod populating the DataVolume has restarted",
+		"":                      "DataVolumeStatus contains the current status of the DataVolume",
+		"claimName":             "ClaimName is the name of the underlying PVC used by the DataVolume.",
+		"phase":                 "Phase is the current phase of the data volume",
+		"restartCount":          "RestartCount is the number of times the pod populating the DataVolume has restarted",
+		"sourceDigest":          "SourceDigest is the digest the registry image of the DataVolume resolved to at import time\n+optional",
+		"observedSourceDigest":  "ObservedSourceDigest is the digest of the source read by the last import, as algorithm:hex. It is the digest of\nthe registry image, or of the downloaded data when the DataVolume has a checksum, and is set on failed imports too\n+optional",
+		"detectedContentType":   "DetectedContentType is the type of the data imported, found by its magic bytes: iso, qcow2, vmdk, vdi, vhd, vhdx, raw or archive\n+optional",
+		"importedPlatform":      "ImportedPlatform is the platform of the registry image copied by the last import, picked from the image index the\nreference resolved to for multi-architecture images\n+optional",
+		"retryCount":            "RetryCount is the number of times the failed import was retried following the retry policy of the DataVolume\n+optional",
+		"nextRetryTime":         "NextRetryTime is when the failed import is retried next, unset unless a retry is pending\n+optional",
+		"transferredBytes":      "TransferredBytes is the number of bytes the import read from its source, or the clone transferred, so far\n+optional",
+		"totalBytes":            "TotalBytes is the number of bytes the import reads from its source, or the clone transfers, unset if unknown\n+optional",
+		"throughput":            "Throughput is the number of bytes per second the clone transferred over the last second\n+optional",
+		"clonePhase":            "ClonePhase is the phase of the clone in progress: Snapshot, Transfer, Convert or Resize\n+optional",
+		"currentPhaseStartTime": "CurrentPhaseStartTime is when the DataVolume entered its current phase\n+optional",
+		"phaseDurations":        "PhaseDurations is the time the importer spent in each of its phases\n+optional",
+		"postCompletionHooks":   "PostCompletionHooks is the status of the post completion hooks run so far\n+optional",
+		"validation":            "Validation is the result of the validation of the source of a validate only DataVolume\n+optional",
+		"failureArtifacts":      "FailureArtifacts is the name of the ConfigMap holding the termination message and the end of the logs of the last\nfailed import, kept when the DataVolume has the cdi.kubevirt.io/storage.import.collectFailureArtifacts annotation\n+optional",
+		"cloneStrategy":         "CloneStrategy is the strategy the clone of the DataVolume uses, and why the strategies before it were not used\n+optional",
+	}
+}
+
+func (DataVolumeCloneStrategyStatus) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":          "DataVolumeCloneStrategyStatus is the strategy a clone uses, and the strategies it fell back from",
+		"strategy":  "Strategy is the strategy the clone uses",
+		"fallbacks": "Fallbacks are the strategies tried before, in order, and why they could not be used\n+optional",
+	}
+}
+
+func (CloneStrategyFallback) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":         "CloneStrategyFallback is a clone strategy that could not be used",
+		"strategy": "Strategy is the strategy that could not be used, unset if CDI could not try any\n+optional",
+		"reason":   "Reason is why the strategy could not be used",
+		"message":  "Message describes why the strategy could not be used\n+optional",
+	}
+}
+
+func (DataVolumeValidation) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":            "DataVolumeValidation is the result of the validation of the source of a validate only DataVolume",
+		"valid":       "Valid is whether the source can be imported to the requested storage",
+		"format":      "Format is the format of the image reported by qemu-img, unset if the image can only be inspected once downloaded\n+optional",
+		"virtualSize": "VirtualSize is the size of the disk of the image in bytes, unset if the image can only be inspected once downloaded\n+optional",
+		"message":     "Message describes why the source is not valid\n+optional",
+	}
+}
+
+func (DataVolumeHookStatus) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":        "DataVolumeHookStatus is the status of a post completion hook of a DataVolume",
+		"name":    "Name is the name of the hook",
+		"phase":   "Phase is the phase of the hook: Running, Succeeded or Failed",
+		"message": "Message describes why the hook failed\n+optional",
+	}
+}
+
+func (DataVolumePhaseDurations) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":         "DataVolumePhaseDurations is the time the importer of a DataVolume spent in each of its phases",
+		"download": "Download is the time spent reading the source of the import\n+optional",
+		"convert":  "Convert is the time spent converting the image to the raw format\n+optional",
+		"resize":   "Resize is the time spent resizing the image to the size of the PVC\n+optional",
+		"verify":   "Verify is the time spent validating the image before it is written to the PVC\n+optional",
 	}
 }
 
@@ -188,6 +569,195 @@ func (DataVolumeCondition) SwaggerDoc() map[string]string {
 	}
 }
 
+func (MultiDataVolume) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"": "MultiDataVolume imports the disks of a source holding several disks, like an OVA or a VM, into one DataVolume per disk\n+genclient\n+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object\n+kubebuilder:object:root=true\n+kubebuilder:storageversion\n+kubebuilder:resource:shortName=mdv;mdvs,categories=all\n+kubebuilder:printcolumn:name=\"Phase\",type=\"string\",JSONPath=\".status.phase\",description=\"The phase of the import of the disks\"\n+kubebuilder:printcolumn:name=\"Progress\",type=\"string\",JSONPath=\".status.progress\",description=\"The progress of the import of the disks\"",
+	}
+}
+
+func (MultiDataVolumeSpec) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":         "MultiDataVolumeSpec defines specification for MultiDataVolume",
+		"template": "Template is the DataVolume the DataVolumes of the disks are created from. Its source is an OVA or XVA http source,\nor a v2v, imageio, vddk or proxmox source, whose disk is selected for each DataVolume.",
+		"disks":    "Disks are the disks of the source imported, each into its own DataVolume\n+kubebuilder:validation:MinItems=1",
+	}
+}
+
+func (MultiDataVolumeDisk) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":        "MultiDataVolumeDisk selects a disk of the source of a MultiDataVolume",
+		"name":    "Name is the name of the DataVolume of the disk, the name of the MultiDataVolume followed by disk and the position\nof the disk in the disks if not set\n+optional",
+		"index":   "Index is the index of the disk in OVA, XVA and v2v sources\n+optional",
+		"id":      "ID is the id of the disk in imageio sources, its backing file in vddk sources and its key in proxmox sources\n+optional",
+		"storage": "Storage is the storage of the DataVolume of the disk, the storage of the template if not set\n+optional",
+	}
+}
+
+func (MultiDataVolumeStatus) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":         "MultiDataVolumeStatus provides the most recently observed status of the DataVolumes of the disks",
+		"phase":    "Phase is Failed once a DataVolume failed, Succeeded once all the DataVolumes succeeded, ImportInProgress while\nDataVolumes are imported and Pending otherwise",
+		"progress": "Progress is the average progress of the DataVolumes",
+		"disks":    "Disks are the status of the DataVolumes of the disks",
+	}
+}
+
+func (MultiDataVolumeDiskStatus) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":               "MultiDataVolumeDiskStatus is the status of the DataVolume of a disk of a MultiDataVolume",
+		"dataVolumeName": "DataVolumeName is the name of the DataVolume of the disk",
+		"phase":          "Phase is the phase of the DataVolume",
+		"progress":       "Progress is the progress of the DataVolume",
+	}
+}
+
+func (MultiDataVolumeList) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":      "MultiDataVolumeList provides the needed parameters to do request a list of MultiDataVolumes from the system\n+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object",
+		"items": "Items provides a list of MultiDataVolumes",
+	}
+}
+
+func (DataVolumeReplication) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"": "DataVolumeReplication keeps the PVC of a DataVolume synchronized with a remote disk while its VM still runs, copying\nat each checkpoint the blocks changed since the previous one, until a final checkpoint cuts over to the PVC\n+genclient\n+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object\n+kubebuilder:object:root=true\n+kubebuilder:storageversion\n+kubebuilder:resource:shortName=dvr;dvrs,categories=all\n+kubebuilder:printcolumn:name=\"Phase\",type=\"string\",JSONPath=\".status.phase\",description=\"The phase of the replication\"\n+kubebuilder:printcolumn:name=\"Checkpoint\",type=\"string\",JSONPath=\".status.syncedCheckpoint\",description=\"The last checkpoint synchronized\"",
+	}
+}
+
+func (DataVolumeReplicationSpec) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":            "DataVolumeReplicationSpec defines specification for DataVolumeReplication",
+		"template":    "Template is the DataVolume replicated to, named after the DataVolumeReplication unless it has a name. Its source is\na vddk or imageio source, whose changed blocks are copied at each checkpoint. Export sources, which have no changed\nblock tracking, are refused. Its checkpoints are set by the DataVolumeReplication.",
+		"checkpoints": "Checkpoints are the identifiers of the snapshots of the source, in the order they were taken. Snapshots are taken\nby the migration orchestrator, usually periodically, and appended here to be synchronized.\n+kubebuilder:validation:MinItems=1",
+		"finalize":    "Finalize cuts over to the PVC once the last checkpoint is synchronized, which should be taken once the VM stopped\n+optional",
+	}
+}
+
+func (DataVolumeReplicationStatus) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                 "DataVolumeReplicationStatus provides the most recently observed status of the DataVolumeReplication",
+		"phase":            "Phase of the replication",
+		"dataVolumeName":   "DataVolumeName is the name of the DataVolume replicated to",
+		"syncedCheckpoint": "SyncedCheckpoint is the last checkpoint synchronized to the PVC",
+		"lastSyncTime":     "LastSyncTime is when the last checkpoint was synchronized",
+		"progress":         "Progress is the progress of the synchronization of the current checkpoint",
+		"message":          "Message explains the phase, like why the replication failed",
+	}
+}
+
+func (DataVolumeReplicationList) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":      "DataVolumeReplicationList provides the needed parameters to do request a list of DataVolumeReplications from the system\n+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object",
+		"items": "Items provides a list of DataVolumeReplications",
+	}
+}
+
+func (DataVolumeMigration) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"": "DataVolumeMigration moves the PVC of a DataVolume to another storage class, cloning it to a PVC of the storage class,\nhost-assisted between storage classes of different provisioners, and rebinding the copy to the name of the PVC, which\nkeeps its DataVolume and consumers\n+genclient\n+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object\n+kubebuilder:object:root=true\n+kubebuilder:storageversion\n+kubebuilder:resource:shortName=dvm;dvms,categories=all\n+kubebuilder:printcolumn:name=\"Phase\",type=\"string\",JSONPath=\".status.phase\",description=\"The phase of the migration\"\n+kubebuilder:printcolumn:name=\"DataVolume\",type=\"string\",JSONPath=\".spec.dataVolumeName\",description=\"The DataVolume migrated\"\n+kubebuilder:printcolumn:name=\"StorageClass\",type=\"string\",JSONPath=\".spec.storageClassName\",description=\"The storage class migrated to\"",
+	}
+}
+
+func (DataVolumeMigrationSpec) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                 "DataVolumeMigrationSpec defines specification for DataVolumeMigration",
+		"dataVolumeName":   "DataVolumeName is the name of the DataVolume migrated, in the namespace of the DataVolumeMigration. The DataVolume\nmust have succeeded and its PVC must not be used by pods.",
+		"storageClassName": "StorageClassName is the name of the storage class the PVC of the DataVolume is migrated to",
+	}
+}
+
+func (DataVolumeMigrationStatus) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                     "DataVolumeMigrationStatus provides the most recently observed status of the DataVolumeMigration",
+		"phase":                "Phase of the migration",
+		"persistentVolumeName": "PersistentVolumeName is the name of the PV of the storage class the PVC of the DataVolume is rebound to",
+		"progress":             "Progress is the progress of the copy of the PVC",
+		"message":              "Message explains the phase, like why the migration failed",
+	}
+}
+
+func (DataVolumeMigrationList) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":      "DataVolumeMigrationList provides the needed parameters to do request a list of DataVolumeMigrations from the system\n+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object",
+		"items": "Items provides a list of DataVolumeMigrations",
+	}
+}
+
+func (DataExport) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"": "DataExport exports the disk image of a PVC or a DataVolume, converted to qcow2, to an HTTP server, a S3 bucket or a\nregistry as a containerDisk\n+genclient\n+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object\n+kubebuilder:object:root=true\n+kubebuilder:storageversion\n+kubebuilder:resource:shortName=dex;dexs,categories=all\n+kubebuilder:printcolumn:name=\"Phase\",type=\"string\",JSONPath=\".status.phase\",description=\"The phase of the export\"",
+	}
+}
+
+func (DataExportSpec) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":         "DataExportSpec defines specification for DataExport",
+		"source":   "Source is the PVC or the DataVolume whose disk image is exported",
+		"target":   "Target is where the disk image is exported to",
+		"compress": "Compress compresses the clusters of the qcow2 image, which makes it smaller but slower to read\n+optional",
+	}
+}
+
+func (DataExportSource) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":     "DataExportSource is the PVC or the DataVolume exported, in the namespace of the DataExport",
+		"kind": "Kind of the source, PersistentVolumeClaim or DataVolume. The export of a DataVolume waits for it to succeed.\n+kubebuilder:validation:Enum=PersistentVolumeClaim;DataVolume",
+		"name": "Name of the source",
+	}
+}
+
+func (DataExportTarget) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":         "DataExportTarget is where a disk image is exported to, exactly one of its fields is set",
+		"http":     "HTTP uploads the image with a PUT request\n+optional",
+		"s3":       "S3 uploads the image as an object of a bucket\n+optional",
+		"registry": "Registry pushes the image as a containerDisk\n+optional",
+	}
+}
+
+func (DataExportTargetHTTP) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":              "DataExportTargetHTTP uploads the image with an HTTP PUT request",
+		"url":           "URL the image is uploaded to",
+		"secretRef":     "SecretRef is the name of the secret with the accessKeyId and secretKey of the basic authentication\n+optional",
+		"certConfigMap": "CertConfigMap is the name of the config map with the CA bundle of the server\n+optional",
+	}
+}
+
+func (DataExportTargetS3) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":              "DataExportTargetS3 uploads the image as an object of a S3 bucket",
+		"url":           "URL of the object, like https://s3.us-east-1.amazonaws.com/bucket/fedora.qcow2",
+		"secretRef":     "SecretRef is the name of the secret with the accessKeyId and secretKey of the bucket\n+optional",
+		"certConfigMap": "CertConfigMap is the name of the config map with the CA bundle of the S3 endpoint\n+optional",
+	}
+}
+
+func (DataExportTargetRegistry) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":              "DataExportTargetRegistry pushes the image to a registry as a containerDisk, the image holding the disk as\n/disk/disk.qcow2",
+		"url":           "URL of the image, like docker://registry.example.com/disks/fedora:40",
+		"secretRef":     "SecretRef is the name of the secret with the accessKeyId and secretKey of the registry\n+optional",
+		"certConfigMap": "CertConfigMap is the name of the config map with the CA bundle of the registry\n+optional",
+	}
+}
+
+func (DataExportStatus) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":        "DataExportStatus provides the most recently observed status of the DataExport",
+		"phase":   "Phase of the export",
+		"digest":  "Digest of the exported image, the sha256 digest of the qcow2 image for HTTP and S3 targets and the digest of the\nmanifest for registry targets",
+		"message": "Message explains the phase, like why the export failed",
+	}
+}
+
+func (DataExportList) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":      "DataExportList provides the needed parameters to do request a list of DataExports from the system\n+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object",
+		"items": "Items provides a list of DataExports",
+	}
+}
+
 func (StorageProfile) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"": "StorageProfile provides a CDI specific recommendation for storage parameters\n+genclient\n+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object\n+kubebuilder:object:root=true\n+kubebuilder:storageversion\n+kubebuilder:resource:scope=Cluster",
@@ -201,6 +771,8 @@ func (StorageProfileSpec) SwaggerDoc() map[string]string {
 		"claimPropertySets":          "ClaimPropertySets is a provided set of properties applicable to PVC\n+kubebuilder:validation:MaxItems=8",
 		"dataImportCronSourceFormat": "DataImportCronSourceFormat defines the format of the DataImportCron-created disk image sources",
 		"snapshotClass":              "SnapshotClass is optional specific VolumeSnapshotClass for CloneStrategySnapshot. If not set, a VolumeSnapshotClass is chosen according to the provisioner.",
+		"conversionDefaults":         "ConversionDefaults tune the image conversions writing to the volumes of the storage class\n+optional",
+		"cloneCompression":           "CloneCompression is how the data of host-assisted clones to the storage class is compressed on the network, snappy if unset\n+optional",
 	}
 }
 
@@ -213,6 +785,18 @@ func (StorageProfileStatus) SwaggerDoc() map[string]string {
 		"claimPropertySets":          "ClaimPropertySets computed from the spec and detected in the system\n+kubebuilder:validation:MaxItems=8",
 		"dataImportCronSourceFormat": "DataImportCronSourceFormat defines the format of the DataImportCron-created disk image sources",
 		"snapshotClass":              "SnapshotClass is optional specific VolumeSnapshotClass for CloneStrategySnapshot. If not set, a VolumeSnapshotClass is chosen according to the provisioner.",
+		"conversionDefaults":         "ConversionDefaults tune the image conversions writing to the volumes of the storage class",
+		"cloneCompression":           "CloneCompression is how the data of host-assisted clones to the storage class is compressed on the network",
+	}
+}
+
+func (ConversionDefaults) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                "ConversionDefaults are the defaults of the image conversions writing to the volumes of a storage class",
+		"preallocation":   "Preallocation preallocates the volumes of the storage class, unless the DataVolume sets it. It takes precedence over the preallocation of the CDIConfig\n+optional",
+		"cacheMode":       "CacheMode is the cache mode of qemu-img writing to the volumes, none bypasses the page cache when the storage supports direct IO\n+kubebuilder:validation:Enum=writeback;none\n+optional",
+		"sparseThreshold": "SparseThreshold is the size of the consecutive zeroes qemu-img leaves unallocated, it is ignored when preallocating\n+optional",
+		"coroutines":      "Coroutines is the number of parallel coroutines of qemu-img\n+kubebuilder:validation:Minimum=1\n+kubebuilder:validation:Maximum=16\n+optional",
 	}
 }
 
@@ -292,8 +876,10 @@ func (DataImportCronSpec) SwaggerDoc() map[string]string {
 		"schedule":          "Schedule specifies in cron format when and how often to look for new imports",
 		"garbageCollect":    "GarbageCollect specifies whether old PVCs should be cleaned up after a new PVC is imported.\nOptions are currently \"Outdated\" and \"Never\", defaults to \"Outdated\".\n+optional",
 		"importsToKeep":     "Number of import PVCs to keep when garbage collecting. Default is 3.\n+optional",
+		"maxAge":            "MaxAge is how long an import is kept when garbage collecting, the older ones are deleted even within\nImportsToKeep. The most recent import is always kept.\n+optional",
 		"managedDataSource": "ManagedDataSource specifies the name of the corresponding DataSource this cron will manage.\nDataSource has to be in the same namespace.",
 		"retentionPolicy":   "RetentionPolicy specifies whether the created DataVolumes and DataSources are retained when their DataImportCron is deleted. Default is RatainAll.\n+optional",
+		"sourceFormat":      "SourceFormat is the format the imports are stored in, overriding the dataImportCronSourceFormat of the\nStorageProfile\n+optional",
 	}
 }
 
@@ -356,7 +942,18 @@ func (ImportSourceType) SwaggerDoc() map[string]string {
 
 func (VolumeImportSourceStatus) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"": "VolumeImportSourceStatus provides the most recently observed status of the VolumeImportSource",
+		"":       "VolumeImportSourceStatus provides the most recently observed status of the VolumeImportSource",
+		"claims": "Claims is the population status of the PVCs referring to the VolumeImportSource in their dataSourceRef\n+optional",
+	}
+}
+
+func (VolumePopulationStatus) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":         "VolumePopulationStatus is the population status of a PVC by a CDI volume populator",
+		"name":     "Name is the name of the PVC",
+		"phase":    "Phase is the phase of the population of the PVC",
+		"progress": "Progress is the progress of the population of the PVC\n+optional",
+		"message":  "Message is a human readable message of the population failure\n+optional",
 	}
 }
 
@@ -384,7 +981,8 @@ func (VolumeUploadSourceSpec) SwaggerDoc() map[string]string {
 
 func (VolumeUploadSourceStatus) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"": "VolumeUploadSourceStatus provides the most recently observed status of the VolumeUploadSource",
+		"":       "VolumeUploadSourceStatus provides the most recently observed status of the VolumeUploadSource",
+		"claims": "Claims is the population status of the PVCs referring to the VolumeUploadSource in their dataSourceRef\n+optional",
 	}
 }
 
@@ -407,6 +1005,13 @@ func (VolumeCloneSourceSpec) SwaggerDoc() map[string]string {
 		"source":            "Source is the src of the data to be cloned to the target PVC",
 		"preallocation":     "Preallocation controls whether storage for the target PVC should be allocated in advance.\n+optional",
 		"priorityClassName": "PriorityClassName is the priorityclass for the claim\n+optional",
+		"transferMode":      "TransferMode is how the data of host-assisted clones is transferred, tar if unset\n+optional",
+		"compression":       "Compression is how the data of host-assisted clones is compressed on the network, the cloneCompression of the\nStorageProfile of the target if unset\n+optional",
+		"strategy":          "Strategy is the strategy of the clone, it takes precedence over the cloneStrategy of the StorageProfile of the target\n+optional",
+		"strategyFallbacks": "StrategyFallbacks is an ordered list of the strategies tried when the strategy can't be used. Once the strategy or\nits fallbacks are set, the clone only uses the listed strategies instead of falling back to copy\n+optional",
+		"format":            "Format is the format the cloned image is stored in on a filesystem target. Once set, the clone is host-assisted and\nits target converts the image and resizes it to the requested storage as it receives it\n+kubebuilder:validation:Enum=raw;qcow2\n+optional",
+		"group":             "Group fans out the source to the clones of the same group: their snapshot clones restore a single snapshot of the\nsource, taken by the first of them\n+optional",
+		"export":            "Export clones the volume another cluster exports at its url instead of a local source, source names the exported\nclaim. The clone is host-assisted, its source pod downloads the volume with the export token of the secret\n+optional",
 	}
 }
 
@@ -417,6 +1022,161 @@ func (VolumeCloneSourceList) SwaggerDoc() map[string]string {
 	}
 }
 
+func (VolumeSnapshotGrant) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"": "VolumeSnapshotGrant allows the DataVolumes of other namespaces to import the VolumeSnapshots of its namespace,\nwithout their creators having permissions in the namespace of the snapshots\n+genclient\n+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object\n+kubebuilder:object:root=true\n+kubebuilder:storageversion\n+kubebuilder:resource:shortName=vsgrant;vsgrants",
+	}
+}
+
+func (VolumeSnapshotGrantSpec) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":           "VolumeSnapshotGrantSpec defines specification for VolumeSnapshotGrant",
+		"namespaces": "Namespaces are the namespaces whose DataVolumes may import the snapshots\n+kubebuilder:validation:MinItems=1",
+		"snapshots":  "Snapshots are the names of the snapshots granted, all the snapshots of the namespace when empty\n+optional",
+	}
+}
+
+func (VolumeSnapshotGrantList) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":      "VolumeSnapshotGrantList provides the needed parameters to request a list of VolumeSnapshotGrants from the system\n+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object",
+		"items": "Items provides a list of VolumeSnapshotGrants",
+	}
+}
+
+func (CloneGrant) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"": "CloneGrant allows the DataVolumes of other namespaces, or ServiceAccounts, to clone the PVCs and VolumeSnapshots of\nits namespace, without their creators having permissions in the namespace of the sources\n+genclient\n+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object\n+kubebuilder:object:root=true\n+kubebuilder:storageversion\n+kubebuilder:resource:shortName=cgrant;cgrants",
+	}
+}
+
+func (CloneGrantSpec) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                "CloneGrantSpec defines specification for CloneGrant",
+		"namespaces":      "Namespaces are the namespaces whose DataVolumes may clone the sources\n+optional",
+		"serviceAccounts": "ServiceAccounts are the ServiceAccounts that may clone the sources, into any namespace they create DataVolumes in\n+optional",
+		"sources":         "Sources are the PVCs and VolumeSnapshots granted, all the ones of the namespace when empty\n+optional",
+		"expirationTime":  "ExpirationTime is when the grant stops authorizing new clones, it does not expire when unset\n+optional",
+	}
+}
+
+func (CloneGrantServiceAccount) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":          "CloneGrantServiceAccount is a ServiceAccount granted the sources of a CloneGrant",
+		"namespace": "Namespace of the ServiceAccount",
+		"name":      "Name of the ServiceAccount",
+	}
+}
+
+func (CloneGrantSource) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":     "CloneGrantSource is a PVC or VolumeSnapshot granted by a CloneGrant",
+		"kind": "Kind of the source\n+kubebuilder:validation:Enum=PersistentVolumeClaim;VolumeSnapshot",
+		"name": "Name of the source",
+	}
+}
+
+func (CloneGrantList) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":      "CloneGrantList provides the needed parameters to request a list of CloneGrants from the system\n+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object",
+		"items": "Items provides a list of CloneGrants",
+	}
+}
+
+func (ImportQuota) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"": "ImportQuota limits the imports of its namespace, so one tenant cannot take all the import bandwidth and scratch\nstorage of the cluster. Imports over the quota wait until other imports of the namespace complete or are deleted.\n+genclient\n+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object\n+kubebuilder:object:root=true\n+kubebuilder:storageversion\n+kubebuilder:resource:shortName=iquota;iquotas",
+	}
+}
+
+func (ImportQuotaSpec) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                     "ImportQuotaSpec defines specification for ImportQuota, unset limits are not enforced",
+		"maxConcurrentImports": "MaxConcurrentImports is the number of importer pods running at the same time in the namespace\n+optional",
+		"maxImportedBytes":     "MaxImportedBytes is the total size requested by the PVCs imported in the namespace, including the completed\nimports whose PVCs still exist\n+optional",
+		"maxScratchSpace":      "MaxScratchSpace is the total size requested by the scratch space PVCs of the namespace, the ones of uploads included\n+optional",
+	}
+}
+
+func (ImportQuotaStatus) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                  "ImportQuotaStatus provides the usage of the quota, observed when an import of the namespace was last admitted or\nrefused",
+		"concurrentImports": "ConcurrentImports is the number of importer pods running in the namespace",
+		"importedBytes":     "ImportedBytes is the total size requested by the PVCs imported in the namespace",
+		"scratchSpace":      "ScratchSpace is the total size requested by the scratch space PVCs of the namespace",
+	}
+}
+
+func (ImportQuotaList) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":      "ImportQuotaList provides the needed parameters to request a list of ImportQuotas from the system\n+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object",
+		"items": "Items provides a list of ImportQuotas",
+	}
+}
+
+func (ImageVerificationPolicy) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"": "ImageVerificationPolicy requires the registry images it applies to be signed with cosign, and to carry signed\nattestations, before they are imported\n+genclient\n+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object\n+kubebuilder:object:root=true\n+kubebuilder:storageversion\n+kubebuilder:resource:shortName=ivp;ivps,scope=Cluster",
+	}
+}
+
+func (ImageVerificationPolicySpec) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":             "ImageVerificationPolicySpec defines specification for ImageVerificationPolicy",
+		"images":       "Images are the glob patterns of the repositories the policy applies to, like registry.example.com/golden/*.\nA * matches any part of a single path segment of the repository, docker.io repositories are matched with their\nfull name, like docker.io/library/fedora\n+kubebuilder:validation:MinItems=1",
+		"authorities":  "Authorities are who may sign the images, an image must be signed by at least one of them\n+kubebuilder:validation:MinItems=1",
+		"attestations": "Attestations the images must carry, each signed by one of the authorities\n+optional",
+	}
+}
+
+func (ImageVerificationAuthority) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":        "ImageVerificationAuthority is a key, or the Fulcio certificate authority of keyless signatures, images may be\nsigned with. Exactly one of key and keyless is set.",
+		"name":    "Name of the authority, used in the reason of failed verifications",
+		"key":     "Key the signatures are made with\n+optional",
+		"keyless": "Keyless signatures are made with short lived Fulcio certificates and recorded in a Rekor transparency log\n+optional",
+	}
+}
+
+func (ImageVerificationKey) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":     "ImageVerificationKey is the public key of signatures",
+		"data": "Data is the PEM encoded ECDSA, RSA or Ed25519 public key, like the cosign.pub generated by cosign generate-key-pair",
+	}
+}
+
+func (ImageVerificationKeyless) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":               "ImageVerificationKeyless verifies the signatures made with Fulcio certificates",
+		"roots":          "Roots are the PEM encoded root and intermediate certificates of the Fulcio certificate authority",
+		"rekorPublicKey": "RekorPublicKey is the PEM encoded public key of the Rekor transparency log the signatures are recorded in.\nThe time the log recorded a signature is when its certificate must have been valid",
+		"identities":     "Identities are who may sign, the certificate of a signature must be issued to one of them\n+kubebuilder:validation:MinItems=1",
+	}
+}
+
+func (ImageVerificationIdentity) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":              "ImageVerificationIdentity is the OIDC identity a Fulcio certificate is issued to. One of subject and subjectRegExp\nis set",
+		"issuer":        "Issuer is the url of the OIDC issuer, like https://token.actions.githubusercontent.com",
+		"subject":       "Subject is the email or the URI the certificate is issued to\n+optional",
+		"subjectRegExp": "SubjectRegExp is a regular expression the email or the URI the certificate is issued to must match\n+optional",
+	}
+}
+
+func (ImageVerificationAttestation) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":              "ImageVerificationAttestation is an in-toto attestation the images must carry",
+		"name":          "Name of the attestation, used in the reason of failed verifications",
+		"predicateType": "PredicateType is the in-toto predicate type of the attestation, like https://slsa.dev/provenance/v1",
+	}
+}
+
+func (ImageVerificationPolicyList) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":      "ImageVerificationPolicyList provides the needed parameters to request a list of ImageVerificationPolicies from the system\n+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object",
+		"items": "Items provides a list of ImageVerificationPolicies",
+	}
+}
+
 func (CDI) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"":       "CDI is the CDI Operator CRD\n+genclient\n+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object\n+kubebuilder:object:root=true\n+kubebuilder:storageversion\n+kubebuilder:resource:shortName=cdi;cdis,scope=Cluster\n+kubebuilder:printcolumn:name=\"Age\",type=\"date\",JSONPath=\".metadata.creationTimestamp\"\n+kubebuilder:printcolumn:name=\"Phase\",type=\"string\",JSONPath=\".status.phase\"",
@@ -515,19 +1275,56 @@ func (FilesystemOverhead) SwaggerDoc() map[string]string {
 
 func (CDIConfigSpec) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"":                         "CDIConfigSpec defines specification for user configuration",
-		"uploadProxyURLOverride":   "Override the URL used when uploading to a DataVolume",
-		"importProxy":              "ImportProxy contains importer pod proxy configuration.\n+optional",
-		"scratchSpaceStorageClass": "Override the storage class to used for scratch space during transfer operations. The scratch space storage class is determined in the following order: 1. value of scratchSpaceStorageClass, if that doesn't exist, use the default storage class, if there is no default storage class, use the storage class of the DataVolume, if no storage class specified, use no storage class for scratch space",
-		"podResourceRequirements":  "ResourceRequirements describes the compute resource requirements.",
-		"featureGates":             "FeatureGates are a list of specific enabled feature gates",
-		"filesystemOverhead":       "FilesystemOverhead describes the space reserved for overhead when using Filesystem volumes. A value is between 0 and 1, if not defined it is 0.06 (6% overhead)",
-		"preallocation":            "Preallocation controls whether storage for DataVolumes should be allocated in advance.",
-		"insecureRegistries":       "InsecureRegistries is a list of TLS disabled registries",
-		"dataVolumeTTLSeconds":     "DataVolumeTTLSeconds is the time in seconds after DataVolume completion it can be garbage collected. Disabled by default.\nDeprecated: Removed in v1.62.\n+optional",
-		"tlsSecurityProfile":       "TLSSecurityProfile is used by operators to apply cluster-wide TLS security settings to operands.",
-		"imagePullSecrets":         "The imagePullSecrets used to pull the container images",
-		"logVerbosity":             "LogVerbosity overrides the default verbosity level used to initialize loggers\n+optional",
+		"":                           "CDIConfigSpec defines specification for user configuration",
+		"uploadProxyURLOverride":     "Override the URL used when uploading to a DataVolume",
+		"uploadProxyLimits":          "UploadProxyLimits limits the rate and concurrency of the requests accepted by the upload proxy\n+optional",
+		"tokenAudit":                 "TokenAudit emits audit events of the issuance and use of upload and clone tokens, none are emitted if unset\n+optional",
+		"tokenConfig":                "TokenConfig sets the lifetime and audiences of the upload and clone tokens, rotates the key signing them and\nrevokes the outstanding ones. Tokens live 5 minutes, have no audience and the key is never rotated if unset\n+optional",
+		"uploadCertRotation":         "UploadCertRotation rotates the certificates of the upload servers the upload proxy connects to, which are only\nrenewed on upload server restarts if unset\n+optional",
+		"eventAggregation":           "EventAggregation suppresses the repeated events of the DataVolumes and PVCs being populated and reports their\nprogress in periodic summary events, events are only aggregated by the Kubernetes event correlator if unset\n+optional",
+		"importProxy":                "ImportProxy contains importer pod proxy configuration.\n+optional",
+		"scratchSpaceStorageClass":   "Override the storage class to used for scratch space during transfer operations. The scratch space storage class is determined in the following order: 1. value of scratchSpaceStorageClass, if that doesn't exist, use the default storage class, if there is no default storage class, use the storage class of the DataVolume, if no storage class specified, use no storage class for scratch space",
+		"podResourceRequirements":    "ResourceRequirements describes the compute resource requirements.",
+		"transferLimits":             "TransferLimits limits the importer and clone source pods running at the same time across the cluster, the\nDataVolumes over the limits wait for a free slot. Not enforced if unset\n+optional",
+		"metadataPropagation":        "MetadataPropagation selects the labels and annotations of DataVolumes copied to their importer and upload pods,\nPVC primes and scratch space PVCs. None are copied if unset\n+optional",
+		"transferPodInjection":       "TransferPodInjection adds approved init containers, sidecars and their volumes to the importer, upload and clone\nsource pods, like egress proxies, SPIFFE agents or monitoring sidecars. Nothing is added if unset\n+optional",
+		"transferPodPriorityClasses": "TransferPodPriorityClasses are the priority classes of the importer, upload and clone pods of the DataVolumes and\nPVCs not selecting one, so critical imports are not evicted first and batch ones can be preempted\n+optional",
+		"transferPodImages":          "TransferPodImages overrides the images of the importer and upload server pods, for all of them or only for the\nimports of some sources, so an image shipping proprietary libraries is only used by the sources needing them\n+optional",
+		"defaultImageArchitecture":   "DefaultImageArchitecture is the architecture picked from the multi-architecture registry images of the\nDataVolumes selecting their architecture without a node selector, like amd64 or arm64\n+optional",
+		"featureGates":               "FeatureGates are a list of specific enabled feature gates",
+		"filesystemOverhead":         "FilesystemOverhead describes the space reserved for overhead when using Filesystem volumes. A value is between 0 and 1, if not defined it is 0.06 (6% overhead)",
+		"preallocation":              "Preallocation controls whether storage for DataVolumes should be allocated in advance.",
+		"registryLayerCache":         "RegistryLayerCache caches the layers pulled by the importers of registry sources on their node,\nso repeated imports of the same image skip the registry pull\n+optional",
+		"insecureRegistries":         "InsecureRegistries is a list of TLS disabled registries",
+		"hostPathImportDirectories":  "HostPathImportDirectories are the node directories DataVolumes may import files from with a hostPath source.\nhostPath sources are refused when empty\n+optional",
+		"postCompletionHookURLs":     "PostCompletionHookURLs are the URL prefixes the webhooks of the post completion hooks of DataVolumes may call.\nWebhook hooks fail when empty\n+optional",
+		"podOverrideTolerations":     "PodOverrideTolerations are the tolerations the podOverrides of DataVolumes may set, each overridden toleration\nmust equal one of them. Overridden tolerations are refused when empty\n+optional",
+		"podOverrideAffinityKeys":    "PodOverrideAffinityKeys are the node label keys the node affinity of the podOverrides of DataVolumes may select\non, and the topology keys its pod affinity and anti-affinity may use. Overridden affinities are refused when empty\n+optional",
+		"importerPriorityClasses":    "ImporterPriorityClasses are the priority classes the priorityClassName of imports may name. Any priority class\nmay be named when empty\n+optional",
+		"dataVolumeTTLSeconds":       "DataVolumeTTLSeconds is the time in seconds after DataVolume completion it can be garbage collected. Disabled by default.\nDeprecated: Removed in v1.62.\n+optional",
+		"tlsSecurityProfile":         "TLSSecurityProfile is used by operators to apply cluster-wide TLS security settings to operands.",
+		"imagePullSecrets":           "The imagePullSecrets used to pull the container images",
+		"logVerbosity":               "LogVerbosity overrides the default verbosity level used to initialize loggers\n+optional",
+	}
+}
+
+func (TokenConfig) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                           "TokenConfig defines the upload and clone tokens issued and accepted by CDI",
+		"cloneTokenLifetime":         "CloneTokenLifetime is the lifetime of the tokens authorizing clones across namespaces, 5m by default\n+optional",
+		"uploadTokenLifetime":        "UploadTokenLifetime is the lifetime of the upload tokens, 5m by default. The tokens renewed in upload sessions\ndon't outlive it either\n+optional",
+		"audiences":                  "Audiences are the audiences of the tokens issued. When set, only the tokens issued for one of them are accepted\n+optional",
+		"signingKeyRotationInterval": "SigningKeyRotationInterval is how often the key signing the tokens is rotated. The tokens signed by the previous\nkey are accepted until the next rotation. Never rotated by default\n+optional",
+		"revokeTokensIssuedBefore":   "RevokeTokensIssuedBefore revokes the tokens issued before this time, the ones stored for the clones in progress\nincluded. Setting it to the current time revokes all the outstanding tokens. The signing key is rotated as well if it\nwas last rotated before this time, and the previous key is not kept\n+optional",
+	}
+}
+
+func (TransferPodImages) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":         "TransferPodImages holds the images overriding the ones CDI runs its importer and upload server pods with",
+		"importer": "Importer is the image of the importer pods of the sources without an image in Sources\n+optional",
+		"uploader": "Uploader is the image of the upload server pods of uploads and host-assisted clones\n+optional",
+		"sources":  "Sources are the images of the importer pods of the sources of their keys, like vddk, http or registry\n+optional",
 	}
 }
 
@@ -552,6 +1349,82 @@ func (CDIConfigList) SwaggerDoc() map[string]string {
 	}
 }
 
+func (UploadProxyLimits) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                        "UploadProxyLimits are the limits of the requests accepted by the upload proxy, unset limits are not enforced",
+		"requestsPerSecond":       "RequestsPerSecond is the rate of the requests accepted from all clients\n+optional",
+		"clientRequestsPerSecond": "ClientRequestsPerSecond is the rate of the requests accepted from each client address\n+optional",
+		"maxConcurrentRequests":   "MaxConcurrentRequests is the number of requests proxied to the upload servers at the same time\n+optional",
+	}
+}
+
+func (TransferLimits) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                                   "TransferLimits are the limits of the importer and clone source pods running at the same time, unset limits are not\nenforced",
+		"maxConcurrentTransfers":             "MaxConcurrentTransfers is the number of transfers running at the same time across the cluster\n+optional",
+		"maxConcurrentTransfersPerNamespace": "MaxConcurrentTransfersPerNamespace is the number of transfers running at the same time in each namespace\n+optional",
+	}
+}
+
+func (MetadataPropagationPolicy) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":            "MetadataPropagationPolicy is the allow-list of the label and annotation keys of DataVolumes copied to the objects\nCDI creates to populate them. A key ending with * matches all the keys starting with the rest of it, like\ncost.example.com/*. Labels and annotations set by CDI are never replaced",
+		"labels":      "Labels are the keys of the labels copied\n+optional",
+		"annotations": "Annotations are the keys of the annotations copied\n+optional",
+	}
+}
+
+func (TransferPodInjection) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":               "TransferPodInjection holds the containers and volumes added to the importer, upload and clone source pods. They run\nbefore the init containers of CDI, and their names must not clash with the ones of CDI",
+		"initContainers": "InitContainers run in order before the sidecars and the containers of CDI\n+optional",
+		"sidecars":       "Sidecars are added as init containers always restarted, which keep running along the containers of CDI and are\nstopped once those completed. Requires Kubernetes native sidecar containers\n+optional",
+		"volumes":        "Volumes are the volumes mounted by the injected containers\n+optional",
+	}
+}
+
+func (TransferPodPriorityClasses) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":            "TransferPodPriorityClasses holds the priority classes of the CDI worker pods. The preemption policy of the pods is the\none of their priority class",
+		"importer":    "Importer is the priority class of the importer pods\n+optional",
+		"upload":      "Upload is the priority class of the upload server pods of uploads\n+optional",
+		"cloneSource": "CloneSource is the priority class of the source pods of host-assisted clones\n+optional",
+		"cloneTarget": "CloneTarget is the priority class of the target pods of host-assisted clones\n+optional",
+	}
+}
+
+func (TokenAuditConfig) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":           "TokenAuditConfig defines the sink of the audit events of tokens",
+		"sink":       "Sink is the Log, Event or Webhook sink the audit events are emitted to, Log by default\n+kubebuilder:validation:Enum=Log;Event;Webhook\n+optional",
+		"webhookURL": "WebhookURL is the URL the audit events are posted to as JSON by the Webhook sink\n+optional",
+	}
+}
+
+func (UploadCertRotationConfig) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                 "UploadCertRotationConfig defines the rotation of the upload server certificates",
+		"rotationInterval": "RotationInterval is the lifetime of the upload server certificates, 24h by default\n+optional",
+		"renewalOverlap":   "RenewalOverlap is how long before expiring the upload server certificates are renewed, half the RotationInterval\nby default\n+optional",
+	}
+}
+
+func (EventAggregationConfig) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                           "EventAggregationConfig defines the thresholds of the aggregation of the events emitted while populating DataVolumes\nand PVCs",
+		"duplicateSuppressionWindow": "DuplicateSuppressionWindow is how long an event is not emitted again for the same object with the same type,\nreason and message, 10m by default. The next event emitted reports how many were suppressed\n+optional",
+		"progressSummaryInterval":    "ProgressSummaryInterval is how often the progress of the DataVolumes being populated is reported in an event,\n5m by default\n+optional",
+	}
+}
+
+func (RegistryLayerCache) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":         "RegistryLayerCache is a node directory holding the layers of registry images by digest",
+		"hostPath": "HostPath is the absolute path of the cache directory on the nodes, created if missing.\nThe importer runs as uid 107 and needs to write to it",
+		"maxSize":  "MaxSize is the size of the cache above which the least recently used layers are evicted, 10Gi if unset\n+optional",
+	}
+}
+
 func (ImportProxy) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"":               "ImportProxy provides the information on how to configure the importer pod proxy.",
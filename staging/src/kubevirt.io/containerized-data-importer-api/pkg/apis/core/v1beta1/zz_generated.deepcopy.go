@@ -155,6 +155,31 @@ func (in *CDIConfigSpec) DeepCopyInto(out *CDIConfigSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.UploadProxyLimits != nil {
+		in, out := &in.UploadProxyLimits, &out.UploadProxyLimits
+		*out = new(UploadProxyLimits)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TokenAudit != nil {
+		in, out := &in.TokenAudit, &out.TokenAudit
+		*out = new(TokenAuditConfig)
+		**out = **in
+	}
+	if in.TokenConfig != nil {
+		in, out := &in.TokenConfig, &out.TokenConfig
+		*out = new(TokenConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UploadCertRotation != nil {
+		in, out := &in.UploadCertRotation, &out.UploadCertRotation
+		*out = new(UploadCertRotationConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EventAggregation != nil {
+		in, out := &in.EventAggregation, &out.EventAggregation
+		*out = new(EventAggregationConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ImportProxy != nil {
 		in, out := &in.ImportProxy, &out.ImportProxy
 		*out = new(ImportProxy)
@@ -170,6 +195,31 @@ func (in *CDIConfigSpec) DeepCopyInto(out *CDIConfigSpec) {
 		*out = new(v1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.TransferLimits != nil {
+		in, out := &in.TransferLimits, &out.TransferLimits
+		*out = new(TransferLimits)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MetadataPropagation != nil {
+		in, out := &in.MetadataPropagation, &out.MetadataPropagation
+		*out = new(MetadataPropagationPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TransferPodInjection != nil {
+		in, out := &in.TransferPodInjection, &out.TransferPodInjection
+		*out = new(TransferPodInjection)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TransferPodPriorityClasses != nil {
+		in, out := &in.TransferPodPriorityClasses, &out.TransferPodPriorityClasses
+		*out = new(TransferPodPriorityClasses)
+		**out = **in
+	}
+	if in.TransferPodImages != nil {
+		in, out := &in.TransferPodImages, &out.TransferPodImages
+		*out = new(TransferPodImages)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.FeatureGates != nil {
 		in, out := &in.FeatureGates, &out.FeatureGates
 		*out = make([]string, len(*in))
@@ -185,11 +235,43 @@ func (in *CDIConfigSpec) DeepCopyInto(out *CDIConfigSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.RegistryLayerCache != nil {
+		in, out := &in.RegistryLayerCache, &out.RegistryLayerCache
+		*out = new(RegistryLayerCache)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.InsecureRegistries != nil {
 		in, out := &in.InsecureRegistries, &out.InsecureRegistries
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.HostPathImportDirectories != nil {
+		in, out := &in.HostPathImportDirectories, &out.HostPathImportDirectories
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PostCompletionHookURLs != nil {
+		in, out := &in.PostCompletionHookURLs, &out.PostCompletionHookURLs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PodOverrideTolerations != nil {
+		in, out := &in.PodOverrideTolerations, &out.PodOverrideTolerations
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PodOverrideAffinityKeys != nil {
+		in, out := &in.PodOverrideAffinityKeys, &out.PodOverrideAffinityKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ImporterPriorityClasses != nil {
+		in, out := &in.ImporterPriorityClasses, &out.ImporterPriorityClasses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.DataVolumeTTLSeconds != nil {
 		in, out := &in.DataVolumeTTLSeconds, &out.DataVolumeTTLSeconds
 		*out = new(int32)
@@ -415,6 +497,149 @@ func (in *ClaimPropertySet) DeepCopy() *ClaimPropertySet {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloneGrant) DeepCopyInto(out *CloneGrant) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloneGrant.
+func (in *CloneGrant) DeepCopy() *CloneGrant {
+	if in == nil {
+		return nil
+	}
+	out := new(CloneGrant)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CloneGrant) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloneGrantList) DeepCopyInto(out *CloneGrantList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CloneGrant, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloneGrantList.
+func (in *CloneGrantList) DeepCopy() *CloneGrantList {
+	if in == nil {
+		return nil
+	}
+	out := new(CloneGrantList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CloneGrantList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloneGrantServiceAccount) DeepCopyInto(out *CloneGrantServiceAccount) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloneGrantServiceAccount.
+func (in *CloneGrantServiceAccount) DeepCopy() *CloneGrantServiceAccount {
+	if in == nil {
+		return nil
+	}
+	out := new(CloneGrantServiceAccount)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloneGrantSource) DeepCopyInto(out *CloneGrantSource) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloneGrantSource.
+func (in *CloneGrantSource) DeepCopy() *CloneGrantSource {
+	if in == nil {
+		return nil
+	}
+	out := new(CloneGrantSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloneGrantSpec) DeepCopyInto(out *CloneGrantSpec) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ServiceAccounts != nil {
+		in, out := &in.ServiceAccounts, &out.ServiceAccounts
+		*out = make([]CloneGrantServiceAccount, len(*in))
+		copy(*out, *in)
+	}
+	if in.Sources != nil {
+		in, out := &in.Sources, &out.Sources
+		*out = make([]CloneGrantSource, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExpirationTime != nil {
+		in, out := &in.ExpirationTime, &out.ExpirationTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloneGrantSpec.
+func (in *CloneGrantSpec) DeepCopy() *CloneGrantSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CloneGrantSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloneStrategyFallback) DeepCopyInto(out *CloneStrategyFallback) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloneStrategyFallback.
+func (in *CloneStrategyFallback) DeepCopy() *CloneStrategyFallback {
+	if in == nil {
+		return nil
+	}
+	out := new(CloneStrategyFallback)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ComponentConfig) DeepCopyInto(out *ComponentConfig) {
 	*out = *in
@@ -482,6 +707,42 @@ func (in *CustomTLSProfile) DeepCopy() *CustomTLSProfile {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConversionDefaults) DeepCopyInto(out *ConversionDefaults) {
+	*out = *in
+	if in.Preallocation != nil {
+		in, out := &in.Preallocation, &out.Preallocation
+		*out = new(bool)
+		**out = **in
+	}
+	if in.CacheMode != nil {
+		in, out := &in.CacheMode, &out.CacheMode
+		*out = new(ConversionCacheMode)
+		**out = **in
+	}
+	if in.SparseThreshold != nil {
+		in, out := &in.SparseThreshold, &out.SparseThreshold
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Coroutines != nil {
+		in, out := &in.Coroutines, &out.Coroutines
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConversionDefaults.
+func (in *ConversionDefaults) DeepCopy() *ConversionDefaults {
+	if in == nil {
+		return nil
+	}
+	out := new(ConversionDefaults)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CustomizeComponents) DeepCopyInto(out *CustomizeComponents) {
 	*out = *in
@@ -525,27 +786,27 @@ func (in *CustomizeComponentsPatch) DeepCopy() *CustomizeComponentsPatch {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DataImportCron) DeepCopyInto(out *DataImportCron) {
+func (in *DataExport) DeepCopyInto(out *DataExport) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
+	out.Status = in.Status
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataImportCron.
-func (in *DataImportCron) DeepCopy() *DataImportCron {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataExport.
+func (in *DataExport) DeepCopy() *DataExport {
 	if in == nil {
 		return nil
 	}
-	out := new(DataImportCron)
+	out := new(DataExport)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *DataImportCron) DeepCopyObject() runtime.Object {
+func (in *DataExport) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -553,30 +814,13 @@ func (in *DataImportCron) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DataImportCronCondition) DeepCopyInto(out *DataImportCronCondition) {
-	*out = *in
-	in.ConditionState.DeepCopyInto(&out.ConditionState)
-	return
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataImportCronCondition.
-func (in *DataImportCronCondition) DeepCopy() *DataImportCronCondition {
-	if in == nil {
-		return nil
-	}
-	out := new(DataImportCronCondition)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DataImportCronList) DeepCopyInto(out *DataImportCronList) {
+func (in *DataExportList) DeepCopyInto(out *DataExportList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]DataImportCron, len(*in))
+		*out = make([]DataExport, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -584,18 +828,18 @@ func (in *DataImportCronList) DeepCopyInto(out *DataImportCronList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataImportCronList.
-func (in *DataImportCronList) DeepCopy() *DataImportCronList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataExportList.
+func (in *DataExportList) DeepCopy() *DataExportList {
 	if in == nil {
 		return nil
 	}
-	out := new(DataImportCronList)
+	out := new(DataExportList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *DataImportCronList) DeepCopyObject() runtime.Object {
+func (in *DataExportList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -603,89 +847,306 @@ func (in *DataImportCronList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DataImportCronSpec) DeepCopyInto(out *DataImportCronSpec) {
+func (in *DataExportSource) DeepCopyInto(out *DataExportSource) {
 	*out = *in
-	in.Template.DeepCopyInto(&out.Template)
-	if in.GarbageCollect != nil {
-		in, out := &in.GarbageCollect, &out.GarbageCollect
-		*out = new(DataImportCronGarbageCollect)
-		**out = **in
-	}
-	if in.ImportsToKeep != nil {
-		in, out := &in.ImportsToKeep, &out.ImportsToKeep
-		*out = new(int32)
-		**out = **in
-	}
-	if in.RetentionPolicy != nil {
-		in, out := &in.RetentionPolicy, &out.RetentionPolicy
-		*out = new(DataImportCronRetentionPolicy)
-		**out = **in
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataImportCronSpec.
-func (in *DataImportCronSpec) DeepCopy() *DataImportCronSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataExportSource.
+func (in *DataExportSource) DeepCopy() *DataExportSource {
 	if in == nil {
 		return nil
 	}
-	out := new(DataImportCronSpec)
+	out := new(DataExportSource)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DataImportCronStatus) DeepCopyInto(out *DataImportCronStatus) {
+func (in *DataExportSpec) DeepCopyInto(out *DataExportSpec) {
 	*out = *in
-	if in.CurrentImports != nil {
-		in, out := &in.CurrentImports, &out.CurrentImports
-		*out = make([]ImportStatus, len(*in))
-		copy(*out, *in)
-	}
-	if in.LastImportedPVC != nil {
-		in, out := &in.LastImportedPVC, &out.LastImportedPVC
-		*out = new(DataVolumeSourcePVC)
-		**out = **in
-	}
-	if in.LastExecutionTimestamp != nil {
-		in, out := &in.LastExecutionTimestamp, &out.LastExecutionTimestamp
-		*out = (*in).DeepCopy()
-	}
-	if in.LastImportTimestamp != nil {
-		in, out := &in.LastImportTimestamp, &out.LastImportTimestamp
-		*out = (*in).DeepCopy()
-	}
-	if in.SourceFormat != nil {
-		in, out := &in.SourceFormat, &out.SourceFormat
-		*out = new(DataImportCronSourceFormat)
-		**out = **in
-	}
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]DataImportCronCondition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
+	out.Source = in.Source
+	in.Target.DeepCopyInto(&out.Target)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataImportCronStatus.
-func (in *DataImportCronStatus) DeepCopy() *DataImportCronStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataExportSpec.
+func (in *DataExportSpec) DeepCopy() *DataExportSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(DataImportCronStatus)
+	out := new(DataExportSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DataSource) DeepCopyInto(out *DataSource) {
+func (in *DataExportStatus) DeepCopyInto(out *DataExportStatus) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataExportStatus.
+func (in *DataExportStatus) DeepCopy() *DataExportStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DataExportStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataExportTarget) DeepCopyInto(out *DataExportTarget) {
+	*out = *in
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(DataExportTargetHTTP)
+		**out = **in
+	}
+	if in.S3 != nil {
+		in, out := &in.S3, &out.S3
+		*out = new(DataExportTargetS3)
+		**out = **in
+	}
+	if in.Registry != nil {
+		in, out := &in.Registry, &out.Registry
+		*out = new(DataExportTargetRegistry)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataExportTarget.
+func (in *DataExportTarget) DeepCopy() *DataExportTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(DataExportTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataExportTargetHTTP) DeepCopyInto(out *DataExportTargetHTTP) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataExportTargetHTTP.
+func (in *DataExportTargetHTTP) DeepCopy() *DataExportTargetHTTP {
+	if in == nil {
+		return nil
+	}
+	out := new(DataExportTargetHTTP)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataExportTargetRegistry) DeepCopyInto(out *DataExportTargetRegistry) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataExportTargetRegistry.
+func (in *DataExportTargetRegistry) DeepCopy() *DataExportTargetRegistry {
+	if in == nil {
+		return nil
+	}
+	out := new(DataExportTargetRegistry)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataExportTargetS3) DeepCopyInto(out *DataExportTargetS3) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataExportTargetS3.
+func (in *DataExportTargetS3) DeepCopy() *DataExportTargetS3 {
+	if in == nil {
+		return nil
+	}
+	out := new(DataExportTargetS3)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataImportCron) DeepCopyInto(out *DataImportCron) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataImportCron.
+func (in *DataImportCron) DeepCopy() *DataImportCron {
+	if in == nil {
+		return nil
+	}
+	out := new(DataImportCron)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DataImportCron) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataImportCronCondition) DeepCopyInto(out *DataImportCronCondition) {
+	*out = *in
+	in.ConditionState.DeepCopyInto(&out.ConditionState)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataImportCronCondition.
+func (in *DataImportCronCondition) DeepCopy() *DataImportCronCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(DataImportCronCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataImportCronList) DeepCopyInto(out *DataImportCronList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DataImportCron, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataImportCronList.
+func (in *DataImportCronList) DeepCopy() *DataImportCronList {
+	if in == nil {
+		return nil
+	}
+	out := new(DataImportCronList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DataImportCronList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataImportCronSpec) DeepCopyInto(out *DataImportCronSpec) {
+	*out = *in
+	in.Template.DeepCopyInto(&out.Template)
+	if in.GarbageCollect != nil {
+		in, out := &in.GarbageCollect, &out.GarbageCollect
+		*out = new(DataImportCronGarbageCollect)
+		**out = **in
+	}
+	if in.ImportsToKeep != nil {
+		in, out := &in.ImportsToKeep, &out.ImportsToKeep
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxAge != nil {
+		in, out := &in.MaxAge, &out.MaxAge
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.RetentionPolicy != nil {
+		in, out := &in.RetentionPolicy, &out.RetentionPolicy
+		*out = new(DataImportCronRetentionPolicy)
+		**out = **in
+	}
+	if in.SourceFormat != nil {
+		in, out := &in.SourceFormat, &out.SourceFormat
+		*out = new(DataImportCronSourceFormat)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataImportCronSpec.
+func (in *DataImportCronSpec) DeepCopy() *DataImportCronSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DataImportCronSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataImportCronStatus) DeepCopyInto(out *DataImportCronStatus) {
+	*out = *in
+	if in.CurrentImports != nil {
+		in, out := &in.CurrentImports, &out.CurrentImports
+		*out = make([]ImportStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastImportedPVC != nil {
+		in, out := &in.LastImportedPVC, &out.LastImportedPVC
+		*out = new(DataVolumeSourcePVC)
+		**out = **in
+	}
+	if in.LastExecutionTimestamp != nil {
+		in, out := &in.LastExecutionTimestamp, &out.LastExecutionTimestamp
+		*out = (*in).DeepCopy()
+	}
+	if in.LastImportTimestamp != nil {
+		in, out := &in.LastImportTimestamp, &out.LastImportTimestamp
+		*out = (*in).DeepCopy()
+	}
+	if in.SourceFormat != nil {
+		in, out := &in.SourceFormat, &out.SourceFormat
+		*out = new(DataImportCronSourceFormat)
+		**out = **in
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]DataImportCronCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataImportCronStatus.
+func (in *DataImportCronStatus) DeepCopy() *DataImportCronStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DataImportCronStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataSource) DeepCopyInto(out *DataSource) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 	return
 }
@@ -907,587 +1368,2300 @@ func (in *DataVolumeCheckpoint) DeepCopy() *DataVolumeCheckpoint {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DataVolumeCondition) DeepCopyInto(out *DataVolumeCondition) {
+func (in *DataVolumeChecksum) DeepCopyInto(out *DataVolumeChecksum) {
 	*out = *in
-	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
-	in.LastHeartbeatTime.DeepCopyInto(&out.LastHeartbeatTime)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataVolumeCondition.
-func (in *DataVolumeCondition) DeepCopy() *DataVolumeCondition {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataVolumeChecksum.
+func (in *DataVolumeChecksum) DeepCopy() *DataVolumeChecksum {
 	if in == nil {
 		return nil
 	}
-	out := new(DataVolumeCondition)
+	out := new(DataVolumeChecksum)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DataVolumeList) DeepCopyInto(out *DataVolumeList) {
+func (in *DataVolumeCloneStrategyStatus) DeepCopyInto(out *DataVolumeCloneStrategyStatus) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]DataVolume, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.Fallbacks != nil {
+		in, out := &in.Fallbacks, &out.Fallbacks
+		*out = make([]CloneStrategyFallback, len(*in))
+		copy(*out, *in)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataVolumeList.
-func (in *DataVolumeList) DeepCopy() *DataVolumeList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataVolumeCloneStrategyStatus.
+func (in *DataVolumeCloneStrategyStatus) DeepCopy() *DataVolumeCloneStrategyStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(DataVolumeList)
+	out := new(DataVolumeCloneStrategyStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *DataVolumeList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataVolumeCondition) DeepCopyInto(out *DataVolumeCondition) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	in.LastHeartbeatTime.DeepCopyInto(&out.LastHeartbeatTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataVolumeCondition.
+func (in *DataVolumeCondition) DeepCopy() *DataVolumeCondition {
+	if in == nil {
+		return nil
 	}
-	return nil
+	out := new(DataVolumeCondition)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DataVolumeSource) DeepCopyInto(out *DataVolumeSource) {
+func (in *DataVolumeEncryption) DeepCopyInto(out *DataVolumeEncryption) {
 	*out = *in
-	if in.HTTP != nil {
-		in, out := &in.HTTP, &out.HTTP
-		*out = new(DataVolumeSourceHTTP)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.S3 != nil {
-		in, out := &in.S3, &out.S3
-		*out = new(DataVolumeSourceS3)
-		**out = **in
-	}
-	if in.GCS != nil {
-		in, out := &in.GCS, &out.GCS
-		*out = new(DataVolumeSourceGCS)
-		**out = **in
-	}
-	if in.Registry != nil {
-		in, out := &in.Registry, &out.Registry
-		*out = new(DataVolumeSourceRegistry)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.PVC != nil {
-		in, out := &in.PVC, &out.PVC
-		*out = new(DataVolumeSourcePVC)
-		**out = **in
-	}
-	if in.Upload != nil {
-		in, out := &in.Upload, &out.Upload
-		*out = new(DataVolumeSourceUpload)
-		**out = **in
-	}
-	if in.Blank != nil {
-		in, out := &in.Blank, &out.Blank
-		*out = new(DataVolumeBlankImage)
-		**out = **in
-	}
-	if in.Imageio != nil {
-		in, out := &in.Imageio, &out.Imageio
-		*out = new(DataVolumeSourceImageIO)
-		**out = **in
-	}
-	if in.VDDK != nil {
-		in, out := &in.VDDK, &out.VDDK
-		*out = new(DataVolumeSourceVDDK)
-		**out = **in
-	}
-	if in.Snapshot != nil {
-		in, out := &in.Snapshot, &out.Snapshot
-		*out = new(DataVolumeSourceSnapshot)
-		**out = **in
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataVolumeSource.
-func (in *DataVolumeSource) DeepCopy() *DataVolumeSource {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataVolumeEncryption.
+func (in *DataVolumeEncryption) DeepCopy() *DataVolumeEncryption {
 	if in == nil {
 		return nil
 	}
-	out := new(DataVolumeSource)
+	out := new(DataVolumeEncryption)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DataVolumeSourceGCS) DeepCopyInto(out *DataVolumeSourceGCS) {
+func (in *DataVolumeHook) DeepCopyInto(out *DataVolumeHook) {
 	*out = *in
+	if in.Job != nil {
+		in, out := &in.Job, &out.Job
+		*out = new(DataVolumeHookJob)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Webhook != nil {
+		in, out := &in.Webhook, &out.Webhook
+		*out = new(DataVolumeHookWebhook)
+		**out = **in
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataVolumeSourceGCS.
-func (in *DataVolumeSourceGCS) DeepCopy() *DataVolumeSourceGCS {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataVolumeHook.
+func (in *DataVolumeHook) DeepCopy() *DataVolumeHook {
 	if in == nil {
 		return nil
 	}
-	out := new(DataVolumeSourceGCS)
+	out := new(DataVolumeHook)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DataVolumeSourceHTTP) DeepCopyInto(out *DataVolumeSourceHTTP) {
+func (in *DataVolumeHookJob) DeepCopyInto(out *DataVolumeHookJob) {
 	*out = *in
-	if in.ExtraHeaders != nil {
-		in, out := &in.ExtraHeaders, &out.ExtraHeaders
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	if in.SecretExtraHeaders != nil {
-		in, out := &in.SecretExtraHeaders, &out.SecretExtraHeaders
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.BackoffLimit != nil {
+		in, out := &in.BackoffLimit, &out.BackoffLimit
+		*out = new(int32)
+		**out = **in
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataVolumeSourceHTTP.
-func (in *DataVolumeSourceHTTP) DeepCopy() *DataVolumeSourceHTTP {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataVolumeHookJob.
+func (in *DataVolumeHookJob) DeepCopy() *DataVolumeHookJob {
 	if in == nil {
 		return nil
 	}
-	out := new(DataVolumeSourceHTTP)
+	out := new(DataVolumeHookJob)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DataVolumeSourceImageIO) DeepCopyInto(out *DataVolumeSourceImageIO) {
+func (in *DataVolumeHookStatus) DeepCopyInto(out *DataVolumeHookStatus) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataVolumeSourceImageIO.
-func (in *DataVolumeSourceImageIO) DeepCopy() *DataVolumeSourceImageIO {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataVolumeHookStatus.
+func (in *DataVolumeHookStatus) DeepCopy() *DataVolumeHookStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(DataVolumeSourceImageIO)
+	out := new(DataVolumeHookStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DataVolumeSourcePVC) DeepCopyInto(out *DataVolumeSourcePVC) {
+func (in *DataVolumeHookWebhook) DeepCopyInto(out *DataVolumeHookWebhook) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataVolumeSourcePVC.
-func (in *DataVolumeSourcePVC) DeepCopy() *DataVolumeSourcePVC {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataVolumeHookWebhook.
+func (in *DataVolumeHookWebhook) DeepCopy() *DataVolumeHookWebhook {
 	if in == nil {
 		return nil
 	}
-	out := new(DataVolumeSourcePVC)
+	out := new(DataVolumeHookWebhook)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DataVolumeSourceRef) DeepCopyInto(out *DataVolumeSourceRef) {
+func (in *DataVolumeImportedPlatform) DeepCopyInto(out *DataVolumeImportedPlatform) {
 	*out = *in
-	if in.Namespace != nil {
-		in, out := &in.Namespace, &out.Namespace
-		*out = new(string)
-		**out = **in
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataVolumeSourceRef.
-func (in *DataVolumeSourceRef) DeepCopy() *DataVolumeSourceRef {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataVolumeImportedPlatform.
+func (in *DataVolumeImportedPlatform) DeepCopy() *DataVolumeImportedPlatform {
 	if in == nil {
 		return nil
 	}
-	out := new(DataVolumeSourceRef)
+	out := new(DataVolumeImportedPlatform)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DataVolumeSourceRegistry) DeepCopyInto(out *DataVolumeSourceRegistry) {
+func (in *DataVolumeList) DeepCopyInto(out *DataVolumeList) {
 	*out = *in
-	if in.URL != nil {
-		in, out := &in.URL, &out.URL
-		*out = new(string)
-		**out = **in
-	}
-	if in.ImageStream != nil {
-		in, out := &in.ImageStream, &out.ImageStream
-		*out = new(string)
-		**out = **in
-	}
-	if in.PullMethod != nil {
-		in, out := &in.PullMethod, &out.PullMethod
-		*out = new(RegistryPullMethod)
-		**out = **in
-	}
-	if in.SecretRef != nil {
-		in, out := &in.SecretRef, &out.SecretRef
-		*out = new(string)
-		**out = **in
-	}
-	if in.CertConfigMap != nil {
-		in, out := &in.CertConfigMap, &out.CertConfigMap
-		*out = new(string)
-		**out = **in
-	}
-	if in.Platform != nil {
-		in, out := &in.Platform, &out.Platform
-		*out = new(PlatformOptions)
-		**out = **in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DataVolume, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataVolumeSourceRegistry.
-func (in *DataVolumeSourceRegistry) DeepCopy() *DataVolumeSourceRegistry {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataVolumeList.
+func (in *DataVolumeList) DeepCopy() *DataVolumeList {
 	if in == nil {
 		return nil
 	}
-	out := new(DataVolumeSourceRegistry)
+	out := new(DataVolumeList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DataVolumeList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DataVolumeSourceS3) DeepCopyInto(out *DataVolumeSourceS3) {
+func (in *DataVolumeMigration) DeepCopyInto(out *DataVolumeMigration) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	out.Status = in.Status
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataVolumeSourceS3.
-func (in *DataVolumeSourceS3) DeepCopy() *DataVolumeSourceS3 {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataVolumeMigration.
+func (in *DataVolumeMigration) DeepCopy() *DataVolumeMigration {
 	if in == nil {
 		return nil
 	}
-	out := new(DataVolumeSourceS3)
+	out := new(DataVolumeMigration)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DataVolumeMigration) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DataVolumeSourceSnapshot) DeepCopyInto(out *DataVolumeSourceSnapshot) {
+func (in *DataVolumeMigrationList) DeepCopyInto(out *DataVolumeMigrationList) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DataVolumeMigration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataVolumeSourceSnapshot.
-func (in *DataVolumeSourceSnapshot) DeepCopy() *DataVolumeSourceSnapshot {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataVolumeMigrationList.
+func (in *DataVolumeMigrationList) DeepCopy() *DataVolumeMigrationList {
 	if in == nil {
 		return nil
 	}
-	out := new(DataVolumeSourceSnapshot)
+	out := new(DataVolumeMigrationList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DataVolumeMigrationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DataVolumeSourceUpload) DeepCopyInto(out *DataVolumeSourceUpload) {
+func (in *DataVolumeMigrationSpec) DeepCopyInto(out *DataVolumeMigrationSpec) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataVolumeSourceUpload.
-func (in *DataVolumeSourceUpload) DeepCopy() *DataVolumeSourceUpload {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataVolumeMigrationSpec.
+func (in *DataVolumeMigrationSpec) DeepCopy() *DataVolumeMigrationSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(DataVolumeSourceUpload)
+	out := new(DataVolumeMigrationSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DataVolumeSourceVDDK) DeepCopyInto(out *DataVolumeSourceVDDK) {
+func (in *DataVolumeMigrationStatus) DeepCopyInto(out *DataVolumeMigrationStatus) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataVolumeSourceVDDK.
-func (in *DataVolumeSourceVDDK) DeepCopy() *DataVolumeSourceVDDK {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataVolumeMigrationStatus.
+func (in *DataVolumeMigrationStatus) DeepCopy() *DataVolumeMigrationStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(DataVolumeSourceVDDK)
+	out := new(DataVolumeMigrationStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DataVolumeSpec) DeepCopyInto(out *DataVolumeSpec) {
+func (in *DataVolumePhaseDurations) DeepCopyInto(out *DataVolumePhaseDurations) {
 	*out = *in
-	if in.Source != nil {
-		in, out := &in.Source, &out.Source
-		*out = new(DataVolumeSource)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.SourceRef != nil {
-		in, out := &in.SourceRef, &out.SourceRef
-		*out = new(DataVolumeSourceRef)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.PVC != nil {
-		in, out := &in.PVC, &out.PVC
-		*out = new(v1.PersistentVolumeClaimSpec)
-		(*in).DeepCopyInto(*out)
+	if in.Download != nil {
+		in, out := &in.Download, &out.Download
+		*out = new(metav1.Duration)
+		**out = **in
 	}
-	if in.Storage != nil {
-		in, out := &in.Storage, &out.Storage
-		*out = new(StorageSpec)
-		(*in).DeepCopyInto(*out)
+	if in.Convert != nil {
+		in, out := &in.Convert, &out.Convert
+		*out = new(metav1.Duration)
+		**out = **in
 	}
-	if in.Checkpoints != nil {
-		in, out := &in.Checkpoints, &out.Checkpoints
-		*out = make([]DataVolumeCheckpoint, len(*in))
-		copy(*out, *in)
+	if in.Resize != nil {
+		in, out := &in.Resize, &out.Resize
+		*out = new(metav1.Duration)
+		**out = **in
 	}
-	if in.Preallocation != nil {
-		in, out := &in.Preallocation, &out.Preallocation
-		*out = new(bool)
+	if in.Verify != nil {
+		in, out := &in.Verify, &out.Verify
+		*out = new(metav1.Duration)
 		**out = **in
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataVolumeSpec.
-func (in *DataVolumeSpec) DeepCopy() *DataVolumeSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataVolumePhaseDurations.
+func (in *DataVolumePhaseDurations) DeepCopy() *DataVolumePhaseDurations {
 	if in == nil {
 		return nil
 	}
-	out := new(DataVolumeSpec)
+	out := new(DataVolumePhaseDurations)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DataVolumeStatus) DeepCopyInto(out *DataVolumeStatus) {
+func (in *DataVolumePodOverrides) DeepCopyInto(out *DataVolumePodOverrides) {
 	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]DataVolumeCondition, len(*in))
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]v1.Toleration, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	return
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(v1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataVolumeStatus.
-func (in *DataVolumeStatus) DeepCopy() *DataVolumeStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataVolumePodOverrides.
+func (in *DataVolumePodOverrides) DeepCopy() *DataVolumePodOverrides {
 	if in == nil {
 		return nil
 	}
-	out := new(DataVolumeStatus)
+	out := new(DataVolumePodOverrides)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FilesystemOverhead) DeepCopyInto(out *FilesystemOverhead) {
+func (in *DataVolumeProxy) DeepCopyInto(out *DataVolumeProxy) {
 	*out = *in
-	if in.StorageClass != nil {
-		in, out := &in.StorageClass, &out.StorageClass
-		*out = make(map[string]Percent, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataVolumeProxy.
+func (in *DataVolumeProxy) DeepCopy() *DataVolumeProxy {
+	if in == nil {
+		return nil
 	}
+	out := new(DataVolumeProxy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataVolumeReplication) DeepCopyInto(out *DataVolumeReplication) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FilesystemOverhead.
-func (in *FilesystemOverhead) DeepCopy() *FilesystemOverhead {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataVolumeReplication.
+func (in *DataVolumeReplication) DeepCopy() *DataVolumeReplication {
 	if in == nil {
 		return nil
 	}
-	out := new(FilesystemOverhead)
+	out := new(DataVolumeReplication)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DataVolumeReplication) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Flags) DeepCopyInto(out *Flags) {
+func (in *DataVolumeReplicationList) DeepCopyInto(out *DataVolumeReplicationList) {
 	*out = *in
-	if in.API != nil {
-		in, out := &in.API, &out.API
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DataVolumeReplication, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.Controller != nil {
-		in, out := &in.Controller, &out.Controller
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataVolumeReplicationList.
+func (in *DataVolumeReplicationList) DeepCopy() *DataVolumeReplicationList {
+	if in == nil {
+		return nil
 	}
-	if in.UploadProxy != nil {
-		in, out := &in.UploadProxy, &out.UploadProxy
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+	out := new(DataVolumeReplicationList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DataVolumeReplicationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataVolumeReplicationSpec) DeepCopyInto(out *DataVolumeReplicationSpec) {
+	*out = *in
+	in.Template.DeepCopyInto(&out.Template)
+	if in.Checkpoints != nil {
+		in, out := &in.Checkpoints, &out.Checkpoints
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Flags.
-func (in *Flags) DeepCopy() *Flags {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataVolumeReplicationSpec.
+func (in *DataVolumeReplicationSpec) DeepCopy() *DataVolumeReplicationSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(Flags)
+	out := new(DataVolumeReplicationSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ImportProxy) DeepCopyInto(out *ImportProxy) {
+func (in *DataVolumeReplicationStatus) DeepCopyInto(out *DataVolumeReplicationStatus) {
 	*out = *in
-	if in.HTTPProxy != nil {
-		in, out := &in.HTTPProxy, &out.HTTPProxy
-		*out = new(string)
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataVolumeReplicationStatus.
+func (in *DataVolumeReplicationStatus) DeepCopy() *DataVolumeReplicationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DataVolumeReplicationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataVolumeRetryPolicy) DeepCopyInto(out *DataVolumeRetryPolicy) {
+	*out = *in
+	if in.MaxRetries != nil {
+		in, out := &in.MaxRetries, &out.MaxRetries
+		*out = new(int32)
 		**out = **in
 	}
-	if in.HTTPSProxy != nil {
-		in, out := &in.HTTPSProxy, &out.HTTPSProxy
-		*out = new(string)
+	if in.BackoffBase != nil {
+		in, out := &in.BackoffBase, &out.BackoffBase
+		*out = new(metav1.Duration)
 		**out = **in
 	}
-	if in.NoProxy != nil {
-		in, out := &in.NoProxy, &out.NoProxy
+	if in.BackoffCap != nil {
+		in, out := &in.BackoffCap, &out.BackoffCap
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.RetryOn != nil {
+		in, out := &in.RetryOn, &out.RetryOn
+		*out = make([]DataVolumeRetryErrorClass, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataVolumeRetryPolicy.
+func (in *DataVolumeRetryPolicy) DeepCopy() *DataVolumeRetryPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(DataVolumeRetryPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataVolumeScratchSpace) DeepCopyInto(out *DataVolumeScratchSpace) {
+	*out = *in
+	if in.StorageClassName != nil {
+		in, out := &in.StorageClassName, &out.StorageClassName
 		*out = new(string)
 		**out = **in
 	}
-	if in.TrustedCAProxy != nil {
-		in, out := &in.TrustedCAProxy, &out.TrustedCAProxy
-		*out = new(string)
+	if in.Size != nil {
+		in, out := &in.Size, &out.Size
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataVolumeScratchSpace.
+func (in *DataVolumeScratchSpace) DeepCopy() *DataVolumeScratchSpace {
+	if in == nil {
+		return nil
+	}
+	out := new(DataVolumeScratchSpace)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataVolumeSignature) DeepCopyInto(out *DataVolumeSignature) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataVolumeSignature.
+func (in *DataVolumeSignature) DeepCopy() *DataVolumeSignature {
+	if in == nil {
+		return nil
+	}
+	out := new(DataVolumeSignature)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataVolumeSource) DeepCopyInto(out *DataVolumeSource) {
+	*out = *in
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(DataVolumeSourceHTTP)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.S3 != nil {
+		in, out := &in.S3, &out.S3
+		*out = new(DataVolumeSourceS3)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GCS != nil {
+		in, out := &in.GCS, &out.GCS
+		*out = new(DataVolumeSourceGCS)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Azure != nil {
+		in, out := &in.Azure, &out.Azure
+		*out = new(DataVolumeSourceAzure)
+		**out = **in
+	}
+	if in.SFTP != nil {
+		in, out := &in.SFTP, &out.SFTP
+		*out = new(DataVolumeSourceSFTP)
+		**out = **in
+	}
+	if in.SMB != nil {
+		in, out := &in.SMB, &out.SMB
+		*out = new(DataVolumeSourceSMB)
+		**out = **in
+	}
+	if in.Glance != nil {
+		in, out := &in.Glance, &out.Glance
+		*out = new(DataVolumeSourceGlance)
+		**out = **in
+	}
+	if in.RBD != nil {
+		in, out := &in.RBD, &out.RBD
+		*out = new(DataVolumeSourceRBD)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ISCSI != nil {
+		in, out := &in.ISCSI, &out.ISCSI
+		*out = new(DataVolumeSourceISCSI)
+		**out = **in
+	}
+	if in.HostPath != nil {
+		in, out := &in.HostPath, &out.HostPath
+		*out = new(DataVolumeSourceHostPath)
+		**out = **in
+	}
+	if in.Export != nil {
+		in, out := &in.Export, &out.Export
+		*out = new(DataVolumeSourceExport)
+		**out = **in
+	}
+	if in.V2V != nil {
+		in, out := &in.V2V, &out.V2V
+		*out = new(DataVolumeSourceV2V)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Proxmox != nil {
+		in, out := &in.Proxmox, &out.Proxmox
+		*out = new(DataVolumeSourceProxmox)
+		**out = **in
+	}
+	if in.Plugin != nil {
+		in, out := &in.Plugin, &out.Plugin
+		*out = new(DataVolumeSourcePlugin)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Torrent != nil {
+		in, out := &in.Torrent, &out.Torrent
+		*out = new(DataVolumeSourceTorrent)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Registry != nil {
+		in, out := &in.Registry, &out.Registry
+		*out = new(DataVolumeSourceRegistry)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PVC != nil {
+		in, out := &in.PVC, &out.PVC
+		*out = new(DataVolumeSourcePVC)
+		**out = **in
+	}
+	if in.Upload != nil {
+		in, out := &in.Upload, &out.Upload
+		*out = new(DataVolumeSourceUpload)
+		**out = **in
+	}
+	if in.Blank != nil {
+		in, out := &in.Blank, &out.Blank
+		*out = new(DataVolumeBlankImage)
+		**out = **in
+	}
+	if in.Imageio != nil {
+		in, out := &in.Imageio, &out.Imageio
+		*out = new(DataVolumeSourceImageIO)
+		**out = **in
+	}
+	if in.VDDK != nil {
+		in, out := &in.VDDK, &out.VDDK
+		*out = new(DataVolumeSourceVDDK)
+		**out = **in
+	}
+	if in.Snapshot != nil {
+		in, out := &in.Snapshot, &out.Snapshot
+		*out = new(DataVolumeSourceSnapshot)
+		**out = **in
+	}
+	if in.DataVolume != nil {
+		in, out := &in.DataVolume, &out.DataVolume
+		*out = new(DataVolumeSourceDataVolume)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataVolumeSource.
+func (in *DataVolumeSource) DeepCopy() *DataVolumeSource {
+	if in == nil {
+		return nil
+	}
+	out := new(DataVolumeSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataVolumeSourceAzure) DeepCopyInto(out *DataVolumeSourceAzure) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataVolumeSourceAzure.
+func (in *DataVolumeSourceAzure) DeepCopy() *DataVolumeSourceAzure {
+	if in == nil {
+		return nil
+	}
+	out := new(DataVolumeSourceAzure)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataVolumeSourceDataVolume) DeepCopyInto(out *DataVolumeSourceDataVolume) {
+	*out = *in
+	if in.Transformation != nil {
+		in, out := &in.Transformation, &out.Transformation
+		*out = new(DataVolumeTransformation)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataVolumeSourceDataVolume.
+func (in *DataVolumeSourceDataVolume) DeepCopy() *DataVolumeSourceDataVolume {
+	if in == nil {
+		return nil
+	}
+	out := new(DataVolumeSourceDataVolume)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataVolumeSourceExport) DeepCopyInto(out *DataVolumeSourceExport) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataVolumeSourceExport.
+func (in *DataVolumeSourceExport) DeepCopy() *DataVolumeSourceExport {
+	if in == nil {
+		return nil
+	}
+	out := new(DataVolumeSourceExport)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataVolumeSourceFallback) DeepCopyInto(out *DataVolumeSourceFallback) {
+	*out = *in
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(DataVolumeSourceHTTP)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.S3 != nil {
+		in, out := &in.S3, &out.S3
+		*out = new(DataVolumeSourceS3)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataVolumeSourceFallback.
+func (in *DataVolumeSourceFallback) DeepCopy() *DataVolumeSourceFallback {
+	if in == nil {
+		return nil
+	}
+	out := new(DataVolumeSourceFallback)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataVolumeSourceGCS) DeepCopyInto(out *DataVolumeSourceGCS) {
+	*out = *in
+	if in.Checksum != nil {
+		in, out := &in.Checksum, &out.Checksum
+		*out = new(DataVolumeChecksum)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataVolumeSourceGCS.
+func (in *DataVolumeSourceGCS) DeepCopy() *DataVolumeSourceGCS {
+	if in == nil {
+		return nil
+	}
+	out := new(DataVolumeSourceGCS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataVolumeSourceGlance) DeepCopyInto(out *DataVolumeSourceGlance) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataVolumeSourceGlance.
+func (in *DataVolumeSourceGlance) DeepCopy() *DataVolumeSourceGlance {
+	if in == nil {
+		return nil
+	}
+	out := new(DataVolumeSourceGlance)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataVolumeSourceHTTP) DeepCopyInto(out *DataVolumeSourceHTTP) {
+	*out = *in
+	if in.ExtraHeaders != nil {
+		in, out := &in.ExtraHeaders, &out.ExtraHeaders
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SecretExtraHeaders != nil {
+		in, out := &in.SecretExtraHeaders, &out.SecretExtraHeaders
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.OVA != nil {
+		in, out := &in.OVA, &out.OVA
+		*out = new(DataVolumeSourceOVA)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.XVA != nil {
+		in, out := &in.XVA, &out.XVA
+		*out = new(DataVolumeSourceXVA)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Checksum != nil {
+		in, out := &in.Checksum, &out.Checksum
+		*out = new(DataVolumeChecksum)
+		**out = **in
+	}
+	if in.Signature != nil {
+		in, out := &in.Signature, &out.Signature
+		*out = new(DataVolumeSignature)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataVolumeSourceHTTP.
+func (in *DataVolumeSourceHTTP) DeepCopy() *DataVolumeSourceHTTP {
+	if in == nil {
+		return nil
+	}
+	out := new(DataVolumeSourceHTTP)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataVolumeSourceHostPath) DeepCopyInto(out *DataVolumeSourceHostPath) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataVolumeSourceHostPath.
+func (in *DataVolumeSourceHostPath) DeepCopy() *DataVolumeSourceHostPath {
+	if in == nil {
+		return nil
+	}
+	out := new(DataVolumeSourceHostPath)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataVolumeSourceISCSI) DeepCopyInto(out *DataVolumeSourceISCSI) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataVolumeSourceISCSI.
+func (in *DataVolumeSourceISCSI) DeepCopy() *DataVolumeSourceISCSI {
+	if in == nil {
+		return nil
+	}
+	out := new(DataVolumeSourceISCSI)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataVolumeSourceImageIO) DeepCopyInto(out *DataVolumeSourceImageIO) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataVolumeSourceImageIO.
+func (in *DataVolumeSourceImageIO) DeepCopy() *DataVolumeSourceImageIO {
+	if in == nil {
+		return nil
+	}
+	out := new(DataVolumeSourceImageIO)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataVolumeSourceOVA) DeepCopyInto(out *DataVolumeSourceOVA) {
+	*out = *in
+	if in.DiskIndex != nil {
+		in, out := &in.DiskIndex, &out.DiskIndex
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataVolumeSourceOVA.
+func (in *DataVolumeSourceOVA) DeepCopy() *DataVolumeSourceOVA {
+	if in == nil {
+		return nil
+	}
+	out := new(DataVolumeSourceOVA)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataVolumeSourcePVC) DeepCopyInto(out *DataVolumeSourcePVC) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataVolumeSourcePVC.
+func (in *DataVolumeSourcePVC) DeepCopy() *DataVolumeSourcePVC {
+	if in == nil {
+		return nil
+	}
+	out := new(DataVolumeSourcePVC)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataVolumeSourcePlugin) DeepCopyInto(out *DataVolumeSourcePlugin) {
+	*out = *in
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataVolumeSourcePlugin.
+func (in *DataVolumeSourcePlugin) DeepCopy() *DataVolumeSourcePlugin {
+	if in == nil {
+		return nil
+	}
+	out := new(DataVolumeSourcePlugin)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataVolumeSourceProxmox) DeepCopyInto(out *DataVolumeSourceProxmox) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataVolumeSourceProxmox.
+func (in *DataVolumeSourceProxmox) DeepCopy() *DataVolumeSourceProxmox {
+	if in == nil {
+		return nil
+	}
+	out := new(DataVolumeSourceProxmox)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataVolumeSourceRBD) DeepCopyInto(out *DataVolumeSourceRBD) {
+	*out = *in
+	if in.Monitors != nil {
+		in, out := &in.Monitors, &out.Monitors
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataVolumeSourceRBD.
+func (in *DataVolumeSourceRBD) DeepCopy() *DataVolumeSourceRBD {
+	if in == nil {
+		return nil
+	}
+	out := new(DataVolumeSourceRBD)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataVolumeSourceRef) DeepCopyInto(out *DataVolumeSourceRef) {
+	*out = *in
+	if in.Namespace != nil {
+		in, out := &in.Namespace, &out.Namespace
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataVolumeSourceRef.
+func (in *DataVolumeSourceRef) DeepCopy() *DataVolumeSourceRef {
+	if in == nil {
+		return nil
+	}
+	out := new(DataVolumeSourceRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataVolumeSourceRegistry) DeepCopyInto(out *DataVolumeSourceRegistry) {
+	*out = *in
+	if in.URL != nil {
+		in, out := &in.URL, &out.URL
+		*out = new(string)
+		**out = **in
+	}
+	if in.ImageStream != nil {
+		in, out := &in.ImageStream, &out.ImageStream
+		*out = new(string)
+		**out = **in
+	}
+	if in.PullMethod != nil {
+		in, out := &in.PullMethod, &out.PullMethod
+		*out = new(RegistryPullMethod)
+		**out = **in
+	}
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(string)
+		**out = **in
+	}
+	if in.CertConfigMap != nil {
+		in, out := &in.CertConfigMap, &out.CertConfigMap
+		*out = new(string)
+		**out = **in
+	}
+	if in.ClientCertSecretRef != nil {
+		in, out := &in.ClientCertSecretRef, &out.ClientCertSecretRef
+		*out = new(string)
+		**out = **in
+	}
+	if in.Platform != nil {
+		in, out := &in.Platform, &out.Platform
+		*out = new(PlatformOptions)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataVolumeSourceRegistry.
+func (in *DataVolumeSourceRegistry) DeepCopy() *DataVolumeSourceRegistry {
+	if in == nil {
+		return nil
+	}
+	out := new(DataVolumeSourceRegistry)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataVolumeSourceS3) DeepCopyInto(out *DataVolumeSourceS3) {
+	*out = *in
+	if in.Checksum != nil {
+		in, out := &in.Checksum, &out.Checksum
+		*out = new(DataVolumeChecksum)
+		**out = **in
+	}
+	if in.Signature != nil {
+		in, out := &in.Signature, &out.Signature
+		*out = new(DataVolumeSignature)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataVolumeSourceS3.
+func (in *DataVolumeSourceS3) DeepCopy() *DataVolumeSourceS3 {
+	if in == nil {
+		return nil
+	}
+	out := new(DataVolumeSourceS3)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataVolumeSourceSFTP) DeepCopyInto(out *DataVolumeSourceSFTP) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataVolumeSourceSFTP.
+func (in *DataVolumeSourceSFTP) DeepCopy() *DataVolumeSourceSFTP {
+	if in == nil {
+		return nil
+	}
+	out := new(DataVolumeSourceSFTP)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataVolumeSourceSMB) DeepCopyInto(out *DataVolumeSourceSMB) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataVolumeSourceSMB.
+func (in *DataVolumeSourceSMB) DeepCopy() *DataVolumeSourceSMB {
+	if in == nil {
+		return nil
+	}
+	out := new(DataVolumeSourceSMB)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataVolumeSourceSnapshot) DeepCopyInto(out *DataVolumeSourceSnapshot) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataVolumeSourceSnapshot.
+func (in *DataVolumeSourceSnapshot) DeepCopy() *DataVolumeSourceSnapshot {
+	if in == nil {
+		return nil
+	}
+	out := new(DataVolumeSourceSnapshot)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataVolumeSourceTorrent) DeepCopyInto(out *DataVolumeSourceTorrent) {
+	*out = *in
+	if in.WebSeeds != nil {
+		in, out := &in.WebSeeds, &out.WebSeeds
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataVolumeSourceTorrent.
+func (in *DataVolumeSourceTorrent) DeepCopy() *DataVolumeSourceTorrent {
+	if in == nil {
+		return nil
+	}
+	out := new(DataVolumeSourceTorrent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataVolumeSourceUpload) DeepCopyInto(out *DataVolumeSourceUpload) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataVolumeSourceUpload.
+func (in *DataVolumeSourceUpload) DeepCopy() *DataVolumeSourceUpload {
+	if in == nil {
+		return nil
+	}
+	out := new(DataVolumeSourceUpload)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataVolumeSourceV2V) DeepCopyInto(out *DataVolumeSourceV2V) {
+	*out = *in
+	if in.NetworkMappings != nil {
+		in, out := &in.NetworkMappings, &out.NetworkMappings
+		*out = make([]V2VNetworkMapping, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataVolumeSourceV2V.
+func (in *DataVolumeSourceV2V) DeepCopy() *DataVolumeSourceV2V {
+	if in == nil {
+		return nil
+	}
+	out := new(DataVolumeSourceV2V)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataVolumeSourceVDDK) DeepCopyInto(out *DataVolumeSourceVDDK) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataVolumeSourceVDDK.
+func (in *DataVolumeSourceVDDK) DeepCopy() *DataVolumeSourceVDDK {
+	if in == nil {
+		return nil
+	}
+	out := new(DataVolumeSourceVDDK)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataVolumeSourceXVA) DeepCopyInto(out *DataVolumeSourceXVA) {
+	*out = *in
+	if in.DiskIndex != nil {
+		in, out := &in.DiskIndex, &out.DiskIndex
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataVolumeSourceXVA.
+func (in *DataVolumeSourceXVA) DeepCopy() *DataVolumeSourceXVA {
+	if in == nil {
+		return nil
+	}
+	out := new(DataVolumeSourceXVA)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataVolumeSpec) DeepCopyInto(out *DataVolumeSpec) {
+	*out = *in
+	if in.Source != nil {
+		in, out := &in.Source, &out.Source
+		*out = new(DataVolumeSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SourceRef != nil {
+		in, out := &in.SourceRef, &out.SourceRef
+		*out = new(DataVolumeSourceRef)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SourceFallbacks != nil {
+		in, out := &in.SourceFallbacks, &out.SourceFallbacks
+		*out = make([]DataVolumeSourceFallback, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PVC != nil {
+		in, out := &in.PVC, &out.PVC
+		*out = new(v1.PersistentVolumeClaimSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Storage != nil {
+		in, out := &in.Storage, &out.Storage
+		*out = new(StorageSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Checkpoints != nil {
+		in, out := &in.Checkpoints, &out.Checkpoints
+		*out = make([]DataVolumeCheckpoint, len(*in))
+		copy(*out, *in)
+	}
+	if in.Preallocation != nil {
+		in, out := &in.Preallocation, &out.Preallocation
+		*out = new(bool)
+		**out = **in
+	}
+	if in.RetryPolicy != nil {
+		in, out := &in.RetryPolicy, &out.RetryPolicy
+		*out = new(DataVolumeRetryPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TTLAfterCompletion != nil {
+		in, out := &in.TTLAfterCompletion, &out.TTLAfterCompletion
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.FailureRetentionPolicy != nil {
+		in, out := &in.FailureRetentionPolicy, &out.FailureRetentionPolicy
+		*out = new(DataVolumeFailureRetentionPolicy)
+		**out = **in
+	}
+	if in.PodOverrides != nil {
+		in, out := &in.PodOverrides, &out.PodOverrides
+		*out = new(DataVolumePodOverrides)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TransferPriority != nil {
+		in, out := &in.TransferPriority, &out.TransferPriority
+		*out = new(int32)
+		**out = **in
+	}
+	if in.PostCompletionHooks != nil {
+		in, out := &in.PostCompletionHooks, &out.PostCompletionHooks
+		*out = make([]DataVolumeHook, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Topology != nil {
+		in, out := &in.Topology, &out.Topology
+		*out = new(DataVolumeTopology)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ScratchSpace != nil {
+		in, out := &in.ScratchSpace, &out.ScratchSpace
+		*out = new(DataVolumeScratchSpace)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Verify != nil {
+		in, out := &in.Verify, &out.Verify
+		*out = new(DataVolumeVerify)
+		**out = **in
+	}
+	if in.Proxy != nil {
+		in, out := &in.Proxy, &out.Proxy
+		*out = new(DataVolumeProxy)
+		**out = **in
+	}
+	if in.CloneTransferMode != nil {
+		in, out := &in.CloneTransferMode, &out.CloneTransferMode
+		*out = new(CloneTransferMode)
+		**out = **in
+	}
+	if in.CloneCompression != nil {
+		in, out := &in.CloneCompression, &out.CloneCompression
+		*out = new(CloneCompression)
+		**out = **in
+	}
+	if in.CloneStrategy != nil {
+		in, out := &in.CloneStrategy, &out.CloneStrategy
+		*out = new(CDICloneStrategy)
+		**out = **in
+	}
+	if in.CloneStrategyFallbacks != nil {
+		in, out := &in.CloneStrategyFallbacks, &out.CloneStrategyFallbacks
+		*out = make([]CDICloneStrategy, len(*in))
+		copy(*out, *in)
+	}
+	if in.CloneFormat != nil {
+		in, out := &in.CloneFormat, &out.CloneFormat
+		*out = new(DataVolumeTransformationFormat)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataVolumeSpec.
+func (in *DataVolumeSpec) DeepCopy() *DataVolumeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DataVolumeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataVolumeStatus) DeepCopyInto(out *DataVolumeStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]DataVolumeCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ImportedPlatform != nil {
+		in, out := &in.ImportedPlatform, &out.ImportedPlatform
+		*out = new(DataVolumeImportedPlatform)
+		**out = **in
+	}
+	if in.NextRetryTime != nil {
+		in, out := &in.NextRetryTime, &out.NextRetryTime
+		*out = (*in).DeepCopy()
+	}
+	if in.TransferredBytes != nil {
+		in, out := &in.TransferredBytes, &out.TransferredBytes
+		*out = new(int64)
+		**out = **in
+	}
+	if in.TotalBytes != nil {
+		in, out := &in.TotalBytes, &out.TotalBytes
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Throughput != nil {
+		in, out := &in.Throughput, &out.Throughput
+		*out = new(int64)
+		**out = **in
+	}
+	if in.CurrentPhaseStartTime != nil {
+		in, out := &in.CurrentPhaseStartTime, &out.CurrentPhaseStartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.PhaseDurations != nil {
+		in, out := &in.PhaseDurations, &out.PhaseDurations
+		*out = new(DataVolumePhaseDurations)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PostCompletionHooks != nil {
+		in, out := &in.PostCompletionHooks, &out.PostCompletionHooks
+		*out = make([]DataVolumeHookStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.Validation != nil {
+		in, out := &in.Validation, &out.Validation
+		*out = new(DataVolumeValidation)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CloneStrategy != nil {
+		in, out := &in.CloneStrategy, &out.CloneStrategy
+		*out = new(DataVolumeCloneStrategyStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataVolumeStatus.
+func (in *DataVolumeStatus) DeepCopy() *DataVolumeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DataVolumeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataVolumeTopology) DeepCopyInto(out *DataVolumeTopology) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataVolumeTopology.
+func (in *DataVolumeTopology) DeepCopy() *DataVolumeTopology {
+	if in == nil {
+		return nil
+	}
+	out := new(DataVolumeTopology)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataVolumeTransformation) DeepCopyInto(out *DataVolumeTransformation) {
+	*out = *in
+	if in.Encryption != nil {
+		in, out := &in.Encryption, &out.Encryption
+		*out = new(DataVolumeEncryption)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataVolumeTransformation.
+func (in *DataVolumeTransformation) DeepCopy() *DataVolumeTransformation {
+	if in == nil {
+		return nil
+	}
+	out := new(DataVolumeTransformation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataVolumeValidation) DeepCopyInto(out *DataVolumeValidation) {
+	*out = *in
+	if in.VirtualSize != nil {
+		in, out := &in.VirtualSize, &out.VirtualSize
+		*out = new(int64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataVolumeValidation.
+func (in *DataVolumeValidation) DeepCopy() *DataVolumeValidation {
+	if in == nil {
+		return nil
+	}
+	out := new(DataVolumeValidation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataVolumeVerify) DeepCopyInto(out *DataVolumeVerify) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataVolumeVerify.
+func (in *DataVolumeVerify) DeepCopy() *DataVolumeVerify {
+	if in == nil {
+		return nil
+	}
+	out := new(DataVolumeVerify)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EventAggregationConfig) DeepCopyInto(out *EventAggregationConfig) {
+	*out = *in
+	if in.DuplicateSuppressionWindow != nil {
+		in, out := &in.DuplicateSuppressionWindow, &out.DuplicateSuppressionWindow
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.ProgressSummaryInterval != nil {
+		in, out := &in.ProgressSummaryInterval, &out.ProgressSummaryInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventAggregationConfig.
+func (in *EventAggregationConfig) DeepCopy() *EventAggregationConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(EventAggregationConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FilesystemOverhead) DeepCopyInto(out *FilesystemOverhead) {
+	*out = *in
+	if in.StorageClass != nil {
+		in, out := &in.StorageClass, &out.StorageClass
+		*out = make(map[string]Percent, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FilesystemOverhead.
+func (in *FilesystemOverhead) DeepCopy() *FilesystemOverhead {
+	if in == nil {
+		return nil
+	}
+	out := new(FilesystemOverhead)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Flags) DeepCopyInto(out *Flags) {
+	*out = *in
+	if in.API != nil {
+		in, out := &in.API, &out.API
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Controller != nil {
+		in, out := &in.Controller, &out.Controller
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.UploadProxy != nil {
+		in, out := &in.UploadProxy, &out.UploadProxy
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Flags.
+func (in *Flags) DeepCopy() *Flags {
+	if in == nil {
+		return nil
+	}
+	out := new(Flags)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageVerificationAttestation) DeepCopyInto(out *ImageVerificationAttestation) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageVerificationAttestation.
+func (in *ImageVerificationAttestation) DeepCopy() *ImageVerificationAttestation {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageVerificationAttestation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageVerificationAuthority) DeepCopyInto(out *ImageVerificationAuthority) {
+	*out = *in
+	if in.Key != nil {
+		in, out := &in.Key, &out.Key
+		*out = new(ImageVerificationKey)
+		**out = **in
+	}
+	if in.Keyless != nil {
+		in, out := &in.Keyless, &out.Keyless
+		*out = new(ImageVerificationKeyless)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageVerificationAuthority.
+func (in *ImageVerificationAuthority) DeepCopy() *ImageVerificationAuthority {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageVerificationAuthority)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageVerificationIdentity) DeepCopyInto(out *ImageVerificationIdentity) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageVerificationIdentity.
+func (in *ImageVerificationIdentity) DeepCopy() *ImageVerificationIdentity {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageVerificationIdentity)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageVerificationKey) DeepCopyInto(out *ImageVerificationKey) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageVerificationKey.
+func (in *ImageVerificationKey) DeepCopy() *ImageVerificationKey {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageVerificationKey)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageVerificationKeyless) DeepCopyInto(out *ImageVerificationKeyless) {
+	*out = *in
+	if in.Identities != nil {
+		in, out := &in.Identities, &out.Identities
+		*out = make([]ImageVerificationIdentity, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageVerificationKeyless.
+func (in *ImageVerificationKeyless) DeepCopy() *ImageVerificationKeyless {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageVerificationKeyless)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageVerificationPolicy) DeepCopyInto(out *ImageVerificationPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageVerificationPolicy.
+func (in *ImageVerificationPolicy) DeepCopy() *ImageVerificationPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageVerificationPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ImageVerificationPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageVerificationPolicyList) DeepCopyInto(out *ImageVerificationPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ImageVerificationPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageVerificationPolicyList.
+func (in *ImageVerificationPolicyList) DeepCopy() *ImageVerificationPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageVerificationPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ImageVerificationPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageVerificationPolicySpec) DeepCopyInto(out *ImageVerificationPolicySpec) {
+	*out = *in
+	if in.Images != nil {
+		in, out := &in.Images, &out.Images
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Authorities != nil {
+		in, out := &in.Authorities, &out.Authorities
+		*out = make([]ImageVerificationAuthority, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Attestations != nil {
+		in, out := &in.Attestations, &out.Attestations
+		*out = make([]ImageVerificationAttestation, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageVerificationPolicySpec.
+func (in *ImageVerificationPolicySpec) DeepCopy() *ImageVerificationPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageVerificationPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImportProxy) DeepCopyInto(out *ImportProxy) {
+	*out = *in
+	if in.HTTPProxy != nil {
+		in, out := &in.HTTPProxy, &out.HTTPProxy
+		*out = new(string)
+		**out = **in
+	}
+	if in.HTTPSProxy != nil {
+		in, out := &in.HTTPSProxy, &out.HTTPSProxy
+		*out = new(string)
+		**out = **in
+	}
+	if in.NoProxy != nil {
+		in, out := &in.NoProxy, &out.NoProxy
+		*out = new(string)
+		**out = **in
+	}
+	if in.TrustedCAProxy != nil {
+		in, out := &in.TrustedCAProxy, &out.TrustedCAProxy
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImportProxy.
+func (in *ImportProxy) DeepCopy() *ImportProxy {
+	if in == nil {
+		return nil
+	}
+	out := new(ImportProxy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImportQuota) DeepCopyInto(out *ImportQuota) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImportQuota.
+func (in *ImportQuota) DeepCopy() *ImportQuota {
+	if in == nil {
+		return nil
+	}
+	out := new(ImportQuota)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ImportQuota) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImportQuotaList) DeepCopyInto(out *ImportQuotaList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ImportQuota, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImportQuotaList.
+func (in *ImportQuotaList) DeepCopy() *ImportQuotaList {
+	if in == nil {
+		return nil
+	}
+	out := new(ImportQuotaList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ImportQuotaList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImportQuotaSpec) DeepCopyInto(out *ImportQuotaSpec) {
+	*out = *in
+	if in.MaxConcurrentImports != nil {
+		in, out := &in.MaxConcurrentImports, &out.MaxConcurrentImports
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxImportedBytes != nil {
+		in, out := &in.MaxImportedBytes, &out.MaxImportedBytes
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.MaxScratchSpace != nil {
+		in, out := &in.MaxScratchSpace, &out.MaxScratchSpace
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImportQuotaSpec.
+func (in *ImportQuotaSpec) DeepCopy() *ImportQuotaSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ImportQuotaSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImportQuotaStatus) DeepCopyInto(out *ImportQuotaStatus) {
+	*out = *in
+	if in.ImportedBytes != nil {
+		in, out := &in.ImportedBytes, &out.ImportedBytes
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.ScratchSpace != nil {
+		in, out := &in.ScratchSpace, &out.ScratchSpace
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImportQuotaStatus.
+func (in *ImportQuotaStatus) DeepCopy() *ImportQuotaStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ImportQuotaStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImportSourceType) DeepCopyInto(out *ImportSourceType) {
+	*out = *in
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(DataVolumeSourceHTTP)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.S3 != nil {
+		in, out := &in.S3, &out.S3
+		*out = new(DataVolumeSourceS3)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Registry != nil {
+		in, out := &in.Registry, &out.Registry
+		*out = new(DataVolumeSourceRegistry)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GCS != nil {
+		in, out := &in.GCS, &out.GCS
+		*out = new(DataVolumeSourceGCS)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Azure != nil {
+		in, out := &in.Azure, &out.Azure
+		*out = new(DataVolumeSourceAzure)
+		**out = **in
+	}
+	if in.SFTP != nil {
+		in, out := &in.SFTP, &out.SFTP
+		*out = new(DataVolumeSourceSFTP)
+		**out = **in
+	}
+	if in.SMB != nil {
+		in, out := &in.SMB, &out.SMB
+		*out = new(DataVolumeSourceSMB)
+		**out = **in
+	}
+	if in.Glance != nil {
+		in, out := &in.Glance, &out.Glance
+		*out = new(DataVolumeSourceGlance)
+		**out = **in
+	}
+	if in.RBD != nil {
+		in, out := &in.RBD, &out.RBD
+		*out = new(DataVolumeSourceRBD)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ISCSI != nil {
+		in, out := &in.ISCSI, &out.ISCSI
+		*out = new(DataVolumeSourceISCSI)
+		**out = **in
+	}
+	if in.HostPath != nil {
+		in, out := &in.HostPath, &out.HostPath
+		*out = new(DataVolumeSourceHostPath)
+		**out = **in
+	}
+	if in.Export != nil {
+		in, out := &in.Export, &out.Export
+		*out = new(DataVolumeSourceExport)
+		**out = **in
+	}
+	if in.V2V != nil {
+		in, out := &in.V2V, &out.V2V
+		*out = new(DataVolumeSourceV2V)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Proxmox != nil {
+		in, out := &in.Proxmox, &out.Proxmox
+		*out = new(DataVolumeSourceProxmox)
+		**out = **in
+	}
+	if in.Plugin != nil {
+		in, out := &in.Plugin, &out.Plugin
+		*out = new(DataVolumeSourcePlugin)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Torrent != nil {
+		in, out := &in.Torrent, &out.Torrent
+		*out = new(DataVolumeSourceTorrent)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Blank != nil {
+		in, out := &in.Blank, &out.Blank
+		*out = new(DataVolumeBlankImage)
+		**out = **in
+	}
+	if in.Imageio != nil {
+		in, out := &in.Imageio, &out.Imageio
+		*out = new(DataVolumeSourceImageIO)
+		**out = **in
+	}
+	if in.VDDK != nil {
+		in, out := &in.VDDK, &out.VDDK
+		*out = new(DataVolumeSourceVDDK)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImportSourceType.
+func (in *ImportSourceType) DeepCopy() *ImportSourceType {
+	if in == nil {
+		return nil
+	}
+	out := new(ImportSourceType)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImportStatus) DeepCopyInto(out *ImportStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImportStatus.
+func (in *ImportStatus) DeepCopy() *ImportStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ImportStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IntermediateTLSProfile) DeepCopyInto(out *IntermediateTLSProfile) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntermediateTLSProfile.
+func (in *IntermediateTLSProfile) DeepCopy() *IntermediateTLSProfile {
+	if in == nil {
+		return nil
+	}
+	out := new(IntermediateTLSProfile)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetadataPropagationPolicy) DeepCopyInto(out *MetadataPropagationPolicy) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetadataPropagationPolicy.
+func (in *MetadataPropagationPolicy) DeepCopy() *MetadataPropagationPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(MetadataPropagationPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModernTLSProfile) DeepCopyInto(out *ModernTLSProfile) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModernTLSProfile.
+func (in *ModernTLSProfile) DeepCopy() *ModernTLSProfile {
+	if in == nil {
+		return nil
+	}
+	out := new(ModernTLSProfile)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MultiDataVolume) DeepCopyInto(out *MultiDataVolume) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultiDataVolume.
+func (in *MultiDataVolume) DeepCopy() *MultiDataVolume {
+	if in == nil {
+		return nil
+	}
+	out := new(MultiDataVolume)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MultiDataVolume) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MultiDataVolumeDisk) DeepCopyInto(out *MultiDataVolumeDisk) {
+	*out = *in
+	if in.Index != nil {
+		in, out := &in.Index, &out.Index
+		*out = new(int32)
 		**out = **in
 	}
+	if in.Storage != nil {
+		in, out := &in.Storage, &out.Storage
+		*out = new(StorageSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImportProxy.
-func (in *ImportProxy) DeepCopy() *ImportProxy {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultiDataVolumeDisk.
+func (in *MultiDataVolumeDisk) DeepCopy() *MultiDataVolumeDisk {
 	if in == nil {
 		return nil
 	}
-	out := new(ImportProxy)
+	out := new(MultiDataVolumeDisk)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ImportSourceType) DeepCopyInto(out *ImportSourceType) {
+func (in *MultiDataVolumeDiskStatus) DeepCopyInto(out *MultiDataVolumeDiskStatus) {
 	*out = *in
-	if in.HTTP != nil {
-		in, out := &in.HTTP, &out.HTTP
-		*out = new(DataVolumeSourceHTTP)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.S3 != nil {
-		in, out := &in.S3, &out.S3
-		*out = new(DataVolumeSourceS3)
-		**out = **in
-	}
-	if in.Registry != nil {
-		in, out := &in.Registry, &out.Registry
-		*out = new(DataVolumeSourceRegistry)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.GCS != nil {
-		in, out := &in.GCS, &out.GCS
-		*out = new(DataVolumeSourceGCS)
-		**out = **in
-	}
-	if in.Blank != nil {
-		in, out := &in.Blank, &out.Blank
-		*out = new(DataVolumeBlankImage)
-		**out = **in
-	}
-	if in.Imageio != nil {
-		in, out := &in.Imageio, &out.Imageio
-		*out = new(DataVolumeSourceImageIO)
-		**out = **in
-	}
-	if in.VDDK != nil {
-		in, out := &in.VDDK, &out.VDDK
-		*out = new(DataVolumeSourceVDDK)
-		**out = **in
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImportSourceType.
-func (in *ImportSourceType) DeepCopy() *ImportSourceType {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultiDataVolumeDiskStatus.
+func (in *MultiDataVolumeDiskStatus) DeepCopy() *MultiDataVolumeDiskStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ImportSourceType)
+	out := new(MultiDataVolumeDiskStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ImportStatus) DeepCopyInto(out *ImportStatus) {
+func (in *MultiDataVolumeList) DeepCopyInto(out *MultiDataVolumeList) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]MultiDataVolume, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImportStatus.
-func (in *ImportStatus) DeepCopy() *ImportStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultiDataVolumeList.
+func (in *MultiDataVolumeList) DeepCopy() *MultiDataVolumeList {
 	if in == nil {
 		return nil
 	}
-	out := new(ImportStatus)
+	out := new(MultiDataVolumeList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MultiDataVolumeList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IntermediateTLSProfile) DeepCopyInto(out *IntermediateTLSProfile) {
+func (in *MultiDataVolumeSpec) DeepCopyInto(out *MultiDataVolumeSpec) {
 	*out = *in
+	in.Template.DeepCopyInto(&out.Template)
+	if in.Disks != nil {
+		in, out := &in.Disks, &out.Disks
+		*out = make([]MultiDataVolumeDisk, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntermediateTLSProfile.
-func (in *IntermediateTLSProfile) DeepCopy() *IntermediateTLSProfile {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultiDataVolumeSpec.
+func (in *MultiDataVolumeSpec) DeepCopy() *MultiDataVolumeSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(IntermediateTLSProfile)
+	out := new(MultiDataVolumeSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ModernTLSProfile) DeepCopyInto(out *ModernTLSProfile) {
+func (in *MultiDataVolumeStatus) DeepCopyInto(out *MultiDataVolumeStatus) {
 	*out = *in
+	if in.Disks != nil {
+		in, out := &in.Disks, &out.Disks
+		*out = make([]MultiDataVolumeDiskStatus, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModernTLSProfile.
-func (in *ModernTLSProfile) DeepCopy() *ModernTLSProfile {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultiDataVolumeStatus.
+func (in *MultiDataVolumeStatus) DeepCopy() *MultiDataVolumeStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ModernTLSProfile)
+	out := new(MultiDataVolumeStatus)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -1656,6 +3830,27 @@ func (in *PlatformOptions) DeepCopy() *PlatformOptions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RegistryLayerCache) DeepCopyInto(out *RegistryLayerCache) {
+	*out = *in
+	if in.MaxSize != nil {
+		in, out := &in.MaxSize, &out.MaxSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegistryLayerCache.
+func (in *RegistryLayerCache) DeepCopy() *RegistryLayerCache {
+	if in == nil {
+		return nil
+	}
+	out := new(RegistryLayerCache)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StorageProfile) DeepCopyInto(out *StorageProfile) {
 	*out = *in
@@ -1742,6 +3937,16 @@ func (in *StorageProfileSpec) DeepCopyInto(out *StorageProfileSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.ConversionDefaults != nil {
+		in, out := &in.ConversionDefaults, &out.ConversionDefaults
+		*out = new(ConversionDefaults)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CloneCompression != nil {
+		in, out := &in.CloneCompression, &out.CloneCompression
+		*out = new(CloneCompression)
+		**out = **in
+	}
 	return
 }
 
@@ -1780,129 +3985,297 @@ func (in *StorageProfileStatus) DeepCopyInto(out *StorageProfileStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.DataImportCronSourceFormat != nil {
-		in, out := &in.DataImportCronSourceFormat, &out.DataImportCronSourceFormat
-		*out = new(DataImportCronSourceFormat)
+	if in.DataImportCronSourceFormat != nil {
+		in, out := &in.DataImportCronSourceFormat, &out.DataImportCronSourceFormat
+		*out = new(DataImportCronSourceFormat)
+		**out = **in
+	}
+	if in.SnapshotClass != nil {
+		in, out := &in.SnapshotClass, &out.SnapshotClass
+		*out = new(string)
+		**out = **in
+	}
+	if in.ConversionDefaults != nil {
+		in, out := &in.ConversionDefaults, &out.ConversionDefaults
+		*out = new(ConversionDefaults)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CloneCompression != nil {
+		in, out := &in.CloneCompression, &out.CloneCompression
+		*out = new(CloneCompression)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageProfileStatus.
+func (in *StorageProfileStatus) DeepCopy() *StorageProfileStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(StorageProfileStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StorageSpec) DeepCopyInto(out *StorageSpec) {
+	*out = *in
+	if in.AccessModes != nil {
+		in, out := &in.AccessModes, &out.AccessModes
+		*out = make([]v1.PersistentVolumeAccessMode, len(*in))
+		copy(*out, *in)
+	}
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Resources.DeepCopyInto(&out.Resources)
+	if in.StorageClassName != nil {
+		in, out := &in.StorageClassName, &out.StorageClassName
+		*out = new(string)
+		**out = **in
+	}
+	if in.VolumeMode != nil {
+		in, out := &in.VolumeMode, &out.VolumeMode
+		*out = new(v1.PersistentVolumeMode)
+		**out = **in
+	}
+	if in.DataSource != nil {
+		in, out := &in.DataSource, &out.DataSource
+		*out = new(v1.TypedLocalObjectReference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DataSourceRef != nil {
+		in, out := &in.DataSourceRef, &out.DataSourceRef
+		*out = new(v1.TypedObjectReference)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageSpec.
+func (in *StorageSpec) DeepCopy() *StorageSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(StorageSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TLSProfileSpec) DeepCopyInto(out *TLSProfileSpec) {
+	*out = *in
+	if in.Ciphers != nil {
+		in, out := &in.Ciphers, &out.Ciphers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TLSProfileSpec.
+func (in *TLSProfileSpec) DeepCopy() *TLSProfileSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TLSProfileSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TLSSecurityProfile) DeepCopyInto(out *TLSSecurityProfile) {
+	*out = *in
+	if in.Old != nil {
+		in, out := &in.Old, &out.Old
+		*out = new(OldTLSProfile)
+		**out = **in
+	}
+	if in.Intermediate != nil {
+		in, out := &in.Intermediate, &out.Intermediate
+		*out = new(IntermediateTLSProfile)
+		**out = **in
+	}
+	if in.Modern != nil {
+		in, out := &in.Modern, &out.Modern
+		*out = new(ModernTLSProfile)
+		**out = **in
+	}
+	if in.Custom != nil {
+		in, out := &in.Custom, &out.Custom
+		*out = new(CustomTLSProfile)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TLSSecurityProfile.
+func (in *TLSSecurityProfile) DeepCopy() *TLSSecurityProfile {
+	if in == nil {
+		return nil
+	}
+	out := new(TLSSecurityProfile)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TokenAuditConfig) DeepCopyInto(out *TokenAuditConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TokenAuditConfig.
+func (in *TokenAuditConfig) DeepCopy() *TokenAuditConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(TokenAuditConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TokenConfig) DeepCopyInto(out *TokenConfig) {
+	*out = *in
+	if in.CloneTokenLifetime != nil {
+		in, out := &in.CloneTokenLifetime, &out.CloneTokenLifetime
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.UploadTokenLifetime != nil {
+		in, out := &in.UploadTokenLifetime, &out.UploadTokenLifetime
+		*out = new(metav1.Duration)
 		**out = **in
 	}
-	if in.SnapshotClass != nil {
-		in, out := &in.SnapshotClass, &out.SnapshotClass
-		*out = new(string)
+	if in.Audiences != nil {
+		in, out := &in.Audiences, &out.Audiences
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SigningKeyRotationInterval != nil {
+		in, out := &in.SigningKeyRotationInterval, &out.SigningKeyRotationInterval
+		*out = new(metav1.Duration)
 		**out = **in
 	}
+	if in.RevokeTokensIssuedBefore != nil {
+		in, out := &in.RevokeTokensIssuedBefore, &out.RevokeTokensIssuedBefore
+		*out = (*in).DeepCopy()
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageProfileStatus.
-func (in *StorageProfileStatus) DeepCopy() *StorageProfileStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TokenConfig.
+func (in *TokenConfig) DeepCopy() *TokenConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(StorageProfileStatus)
+	out := new(TokenConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *StorageSpec) DeepCopyInto(out *StorageSpec) {
+func (in *TransferLimits) DeepCopyInto(out *TransferLimits) {
 	*out = *in
-	if in.AccessModes != nil {
-		in, out := &in.AccessModes, &out.AccessModes
-		*out = make([]v1.PersistentVolumeAccessMode, len(*in))
-		copy(*out, *in)
-	}
-	if in.Selector != nil {
-		in, out := &in.Selector, &out.Selector
-		*out = new(metav1.LabelSelector)
-		(*in).DeepCopyInto(*out)
-	}
-	in.Resources.DeepCopyInto(&out.Resources)
-	if in.StorageClassName != nil {
-		in, out := &in.StorageClassName, &out.StorageClassName
-		*out = new(string)
+	if in.MaxConcurrentTransfers != nil {
+		in, out := &in.MaxConcurrentTransfers, &out.MaxConcurrentTransfers
+		*out = new(int32)
 		**out = **in
 	}
-	if in.VolumeMode != nil {
-		in, out := &in.VolumeMode, &out.VolumeMode
-		*out = new(v1.PersistentVolumeMode)
+	if in.MaxConcurrentTransfersPerNamespace != nil {
+		in, out := &in.MaxConcurrentTransfersPerNamespace, &out.MaxConcurrentTransfersPerNamespace
+		*out = new(int32)
 		**out = **in
 	}
-	if in.DataSource != nil {
-		in, out := &in.DataSource, &out.DataSource
-		*out = new(v1.TypedLocalObjectReference)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.DataSourceRef != nil {
-		in, out := &in.DataSourceRef, &out.DataSourceRef
-		*out = new(v1.TypedObjectReference)
-		(*in).DeepCopyInto(*out)
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageSpec.
-func (in *StorageSpec) DeepCopy() *StorageSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TransferLimits.
+func (in *TransferLimits) DeepCopy() *TransferLimits {
 	if in == nil {
 		return nil
 	}
-	out := new(StorageSpec)
+	out := new(TransferLimits)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TLSProfileSpec) DeepCopyInto(out *TLSProfileSpec) {
+func (in *TransferPodImages) DeepCopyInto(out *TransferPodImages) {
 	*out = *in
-	if in.Ciphers != nil {
-		in, out := &in.Ciphers, &out.Ciphers
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.Sources != nil {
+		in, out := &in.Sources, &out.Sources
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TLSProfileSpec.
-func (in *TLSProfileSpec) DeepCopy() *TLSProfileSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TransferPodImages.
+func (in *TransferPodImages) DeepCopy() *TransferPodImages {
 	if in == nil {
 		return nil
 	}
-	out := new(TLSProfileSpec)
+	out := new(TransferPodImages)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TLSSecurityProfile) DeepCopyInto(out *TLSSecurityProfile) {
+func (in *TransferPodInjection) DeepCopyInto(out *TransferPodInjection) {
 	*out = *in
-	if in.Old != nil {
-		in, out := &in.Old, &out.Old
-		*out = new(OldTLSProfile)
-		**out = **in
+	if in.InitContainers != nil {
+		in, out := &in.InitContainers, &out.InitContainers
+		*out = make([]v1.Container, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
-	if in.Intermediate != nil {
-		in, out := &in.Intermediate, &out.Intermediate
-		*out = new(IntermediateTLSProfile)
-		**out = **in
+	if in.Sidecars != nil {
+		in, out := &in.Sidecars, &out.Sidecars
+		*out = make([]v1.Container, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
-	if in.Modern != nil {
-		in, out := &in.Modern, &out.Modern
-		*out = new(ModernTLSProfile)
-		**out = **in
+	if in.Volumes != nil {
+		in, out := &in.Volumes, &out.Volumes
+		*out = make([]v1.Volume, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
-	if in.Custom != nil {
-		in, out := &in.Custom, &out.Custom
-		*out = new(CustomTLSProfile)
-		(*in).DeepCopyInto(*out)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TransferPodInjection.
+func (in *TransferPodInjection) DeepCopy() *TransferPodInjection {
+	if in == nil {
+		return nil
 	}
+	out := new(TransferPodInjection)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TransferPodPriorityClasses) DeepCopyInto(out *TransferPodPriorityClasses) {
+	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TLSSecurityProfile.
-func (in *TLSSecurityProfile) DeepCopy() *TLSSecurityProfile {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TransferPodPriorityClasses.
+func (in *TransferPodPriorityClasses) DeepCopy() *TransferPodPriorityClasses {
 	if in == nil {
 		return nil
 	}
-	out := new(TLSSecurityProfile)
+	out := new(TransferPodPriorityClasses)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -1956,6 +4329,84 @@ func (in *TransferTarget) DeepCopy() *TransferTarget {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UploadCertRotationConfig) DeepCopyInto(out *UploadCertRotationConfig) {
+	*out = *in
+	if in.RotationInterval != nil {
+		in, out := &in.RotationInterval, &out.RotationInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.RenewalOverlap != nil {
+		in, out := &in.RenewalOverlap, &out.RenewalOverlap
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UploadCertRotationConfig.
+func (in *UploadCertRotationConfig) DeepCopy() *UploadCertRotationConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(UploadCertRotationConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UploadProxyLimits) DeepCopyInto(out *UploadProxyLimits) {
+	*out = *in
+	if in.RequestsPerSecond != nil {
+		in, out := &in.RequestsPerSecond, &out.RequestsPerSecond
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ClientRequestsPerSecond != nil {
+		in, out := &in.ClientRequestsPerSecond, &out.ClientRequestsPerSecond
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxConcurrentRequests != nil {
+		in, out := &in.MaxConcurrentRequests, &out.MaxConcurrentRequests
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UploadProxyLimits.
+func (in *UploadProxyLimits) DeepCopy() *UploadProxyLimits {
+	if in == nil {
+		return nil
+	}
+	out := new(UploadProxyLimits)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *V2VNetworkMapping) DeepCopyInto(out *V2VNetworkMapping) {
+	*out = *in
+	if in.Nameservers != nil {
+		in, out := &in.Nameservers, &out.Nameservers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new V2VNetworkMapping.
+func (in *V2VNetworkMapping) DeepCopy() *V2VNetworkMapping {
+	if in == nil {
+		return nil
+	}
+	out := new(V2VNetworkMapping)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VolumeCloneSource) DeepCopyInto(out *VolumeCloneSource) {
 	*out = *in
@@ -2030,6 +4481,36 @@ func (in *VolumeCloneSourceSpec) DeepCopyInto(out *VolumeCloneSourceSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.TransferMode != nil {
+		in, out := &in.TransferMode, &out.TransferMode
+		*out = new(CloneTransferMode)
+		**out = **in
+	}
+	if in.Compression != nil {
+		in, out := &in.Compression, &out.Compression
+		*out = new(CloneCompression)
+		**out = **in
+	}
+	if in.Strategy != nil {
+		in, out := &in.Strategy, &out.Strategy
+		*out = new(CDICloneStrategy)
+		**out = **in
+	}
+	if in.StrategyFallbacks != nil {
+		in, out := &in.StrategyFallbacks, &out.StrategyFallbacks
+		*out = make([]CDICloneStrategy, len(*in))
+		copy(*out, *in)
+	}
+	if in.Format != nil {
+		in, out := &in.Format, &out.Format
+		*out = new(DataVolumeTransformationFormat)
+		**out = **in
+	}
+	if in.Export != nil {
+		in, out := &in.Export, &out.Export
+		*out = new(DataVolumeSourceExport)
+		**out = **in
+	}
 	return
 }
 
@@ -2049,7 +4530,7 @@ func (in *VolumeImportSource) DeepCopyInto(out *VolumeImportSource) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
@@ -2148,6 +4629,11 @@ func (in *VolumeImportSourceSpec) DeepCopy() *VolumeImportSourceSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VolumeImportSourceStatus) DeepCopyInto(out *VolumeImportSourceStatus) {
 	*out = *in
+	if in.Claims != nil {
+		in, out := &in.Claims, &out.Claims
+		*out = make([]VolumePopulationStatus, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -2161,13 +4647,115 @@ func (in *VolumeImportSourceStatus) DeepCopy() *VolumeImportSourceStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumePopulationStatus) DeepCopyInto(out *VolumePopulationStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumePopulationStatus.
+func (in *VolumePopulationStatus) DeepCopy() *VolumePopulationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumePopulationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeSnapshotGrant) DeepCopyInto(out *VolumeSnapshotGrant) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeSnapshotGrant.
+func (in *VolumeSnapshotGrant) DeepCopy() *VolumeSnapshotGrant {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeSnapshotGrant)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VolumeSnapshotGrant) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeSnapshotGrantList) DeepCopyInto(out *VolumeSnapshotGrantList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VolumeSnapshotGrant, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeSnapshotGrantList.
+func (in *VolumeSnapshotGrantList) DeepCopy() *VolumeSnapshotGrantList {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeSnapshotGrantList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VolumeSnapshotGrantList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeSnapshotGrantSpec) DeepCopyInto(out *VolumeSnapshotGrantSpec) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Snapshots != nil {
+		in, out := &in.Snapshots, &out.Snapshots
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeSnapshotGrantSpec.
+func (in *VolumeSnapshotGrantSpec) DeepCopy() *VolumeSnapshotGrantSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeSnapshotGrantSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VolumeUploadSource) DeepCopyInto(out *VolumeUploadSource) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
@@ -2246,6 +4834,11 @@ func (in *VolumeUploadSourceSpec) DeepCopy() *VolumeUploadSourceSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VolumeUploadSourceStatus) DeepCopyInto(out *VolumeUploadSourceStatus) {
 	*out = *in
+	if in.Claims != nil {
+		in, out := &in.Claims, &out.Claims
+		*out = make([]VolumePopulationStatus, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -17,7 +17,10 @@ limitations under the License.
 package v1beta1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
 )
 
 // UploadTokenRequest is the CR used to initiate a CDI upload
@@ -38,6 +41,11 @@ type UploadTokenRequest struct {
 type UploadTokenRequestSpec struct {
 	// PvcName is the name of the PVC to upload to
 	PvcName string `json:"pvcName"`
+
+	// SessionTTL is how long the upload session of the token lasts. The token expires after a few minutes, but it may be
+	// renewed at the upload proxy until the session ends or the PVC is deleted. Tokens can't be renewed when omitted.
+	// +optional
+	SessionTTL *metav1.Duration `json:"sessionTTL,omitempty"`
 }
 
 // UploadTokenRequestStatus stores the status of a token request
@@ -55,3 +63,55 @@ type UploadTokenRequestList struct {
 	// Items contains a list of UploadTokenRequests
 	Items []UploadTokenRequest `json:"items"`
 }
+
+// DataVolumeRenderRequest is the CR used to render what CDI creates for a DataVolume, without creating anything
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type DataVolumeRenderRequest struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+
+	// Spec contains the parameters of the request
+	Spec DataVolumeRenderRequestSpec `json:"spec"`
+
+	// Status contains the rendering of the DataVolume
+	Status DataVolumeRenderRequestStatus `json:"status"`
+}
+
+// DataVolumeRenderRequestSpec defines the parameters of the render request
+type DataVolumeRenderRequestSpec struct {
+	// DataVolume is the DataVolume to render, in the namespace of the request
+	DataVolume cdiv1.DataVolume `json:"dataVolume"`
+}
+
+// DataVolumePopulationStrategy is how the PVC of a DataVolume is populated
+type DataVolumePopulationStrategy string
+
+const (
+	// PopulationStrategyCsiClone clones the source PVC with the CSI driver
+	PopulationStrategyCsiClone DataVolumePopulationStrategy = "csi-clone"
+	// PopulationStrategySnapshot restores the PVC from a snapshot of the source
+	PopulationStrategySnapshot DataVolumePopulationStrategy = "snapshot"
+	// PopulationStrategyReflink clones the files of the source PVC with reflinks of the filesystem both PVCs share
+	PopulationStrategyReflink DataVolumePopulationStrategy = "reflink"
+	// PopulationStrategyHostAssisted copies the source into the PVC with CDI pods
+	PopulationStrategyHostAssisted DataVolumePopulationStrategy = "host-assisted"
+	// PopulationStrategyPopulator populates a PVC prime with the CDI volume populators, and then rebinds its PV to the PVC
+	PopulationStrategyPopulator DataVolumePopulationStrategy = "populator"
+	// PopulationStrategyPod populates the PVC in place with a CDI pod, as storage without CSI drivers requires
+	PopulationStrategyPod DataVolumePopulationStrategy = "pod"
+)
+
+// DataVolumeRenderRequestStatus stores the rendering of the DataVolume
+type DataVolumeRenderRequestStatus struct {
+	// PVCSpec is the spec of the PVC created for the DataVolume
+	PVCSpec *corev1.PersistentVolumeClaimSpec `json:"pvcSpec,omitempty"`
+
+	// PopulationStrategy is how the PVC is populated. It is omitted when it depends on a clone source that does not exist yet.
+	PopulationStrategy DataVolumePopulationStrategy `json:"populationStrategy,omitempty"`
+
+	// FallbackReason is why a clone falls back to the host-assisted strategy
+	FallbackReason string `json:"fallbackReason,omitempty"`
+
+	// StorageProfile holds the values of the StorageProfile of the storage class of the PVC
+	StorageProfile *cdiv1.StorageProfileStatus `json:"storageProfile,omitempty"`
+}
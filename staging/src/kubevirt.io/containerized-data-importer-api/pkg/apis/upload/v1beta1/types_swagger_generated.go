@@ -12,8 +12,9 @@ func (UploadTokenRequest) SwaggerDoc() map[string]string {
 
 func (UploadTokenRequestSpec) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"":        "UploadTokenRequestSpec defines the parameters of the token request",
-		"pvcName": "PvcName is the name of the PVC to upload to",
+		"":           "UploadTokenRequestSpec defines the parameters of the token request",
+		"pvcName":    "PvcName is the name of the PVC to upload to",
+		"sessionTTL": "SessionTTL is how long the upload session of the token lasts. The token expires after a few minutes, but it may be\nrenewed at the upload proxy until the session ends or the PVC is deleted. Tokens can't be renewed when omitted.\n+optional",
 	}
 }
 
@@ -30,3 +31,28 @@ func (UploadTokenRequestList) SwaggerDoc() map[string]string {
 		"items": "Items contains a list of UploadTokenRequests",
 	}
 }
+
+func (DataVolumeRenderRequest) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":       "DataVolumeRenderRequest is the CR used to render what CDI creates for a DataVolume, without creating anything\n+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object",
+		"spec":   "Spec contains the parameters of the request",
+		"status": "Status contains the rendering of the DataVolume",
+	}
+}
+
+func (DataVolumeRenderRequestSpec) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":           "DataVolumeRenderRequestSpec defines the parameters of the render request",
+		"dataVolume": "DataVolume is the DataVolume to render, in the namespace of the request",
+	}
+}
+
+func (DataVolumeRenderRequestStatus) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                   "DataVolumeRenderRequestStatus stores the rendering of the DataVolume",
+		"pvcSpec":            "PVCSpec is the spec of the PVC created for the DataVolume",
+		"populationStrategy": "PopulationStrategy is how the PVC is populated. It is omitted when it depends on a clone source that does not exist yet.",
+		"fallbackReason":     "FallbackReason is why a clone falls back to the host-assisted strategy",
+		"storageProfile":     "StorageProfile holds the values of the StorageProfile of the storage class of the PVC",
+	}
+}
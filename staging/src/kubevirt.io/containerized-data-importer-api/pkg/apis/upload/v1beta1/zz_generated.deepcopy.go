@@ -22,15 +22,89 @@ limitations under the License.
 package v1beta1
 
 import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
+	corev1beta1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataVolumeRenderRequest) DeepCopyInto(out *DataVolumeRenderRequest) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataVolumeRenderRequest.
+func (in *DataVolumeRenderRequest) DeepCopy() *DataVolumeRenderRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(DataVolumeRenderRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DataVolumeRenderRequest) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataVolumeRenderRequestSpec) DeepCopyInto(out *DataVolumeRenderRequestSpec) {
+	*out = *in
+	in.DataVolume.DeepCopyInto(&out.DataVolume)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataVolumeRenderRequestSpec.
+func (in *DataVolumeRenderRequestSpec) DeepCopy() *DataVolumeRenderRequestSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DataVolumeRenderRequestSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataVolumeRenderRequestStatus) DeepCopyInto(out *DataVolumeRenderRequestStatus) {
+	*out = *in
+	if in.PVCSpec != nil {
+		in, out := &in.PVCSpec, &out.PVCSpec
+		*out = new(corev1.PersistentVolumeClaimSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StorageProfile != nil {
+		in, out := &in.StorageProfile, &out.StorageProfile
+		*out = new(corev1beta1.StorageProfileStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataVolumeRenderRequestStatus.
+func (in *DataVolumeRenderRequestStatus) DeepCopy() *DataVolumeRenderRequestStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DataVolumeRenderRequestStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UploadTokenRequest) DeepCopyInto(out *UploadTokenRequest) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	out.Status = in.Status
 	return
 }
@@ -89,6 +163,11 @@ func (in *UploadTokenRequestList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UploadTokenRequestSpec) DeepCopyInto(out *UploadTokenRequestSpec) {
 	*out = *in
+	if in.SessionTTL != nil {
+		in, out := &in.SessionTTL, &out.SessionTTL
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 	return
 }
 
@@ -243,6 +243,7 @@ var _ = Describe("Aggregated role definition tests", Serial, func() {
 			},
 			Resources: []string{
 				"uploadtokenrequests",
+				"datavolumerenderrequests",
 			},
 			Verbs: []string{
 				"*",